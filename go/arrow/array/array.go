@@ -139,7 +139,7 @@ func (a *array) Offset() int {
 type arrayConstructorFn func(*Data) Interface
 
 var (
-	makeArrayFn [32]arrayConstructorFn
+	makeArrayFn [64]arrayConstructorFn
 )
 
 func unsupportedArrayType(data *Data) Interface {
@@ -150,9 +150,18 @@ func invalidDataType(data *Data) Interface {
 	panic("invalid data type: " + data.dtype.ID().String())
 }
 
+// newUnionData returns a SparseUnion or DenseUnion array, depending on the
+// mode of the union type of data.
+func newUnionData(data *Data) Interface {
+	if data.dtype.(*arrow.UnionType).Mode() == arrow.DenseMode {
+		return NewDenseUnionData(data)
+	}
+	return NewSparseUnionData(data)
+}
+
 // MakeFromData constructs a strongly-typed array instance from generic Data.
 func MakeFromData(data *Data) Interface {
-	return makeArrayFn[byte(data.dtype.ID()&0x1f)](data)
+	return makeArrayFn[byte(data.dtype.ID()&0x3f)](data)
 }
 
 // NewSlice constructs a zero-copy slice of the array with the indicated
@@ -195,14 +204,23 @@ func init() {
 		arrow.DECIMAL:           func(data *Data) Interface { return NewDecimal128Data(data) },
 		arrow.LIST:              func(data *Data) Interface { return NewListData(data) },
 		arrow.STRUCT:            func(data *Data) Interface { return NewStructData(data) },
-		arrow.UNION:             unsupportedArrayType,
-		arrow.DICTIONARY:        unsupportedArrayType,
+		arrow.UNION:             func(data *Data) Interface { return newUnionData(data) },
+		arrow.DICTIONARY:        func(data *Data) Interface { return NewDictionaryData(data) },
 		arrow.MAP:               unsupportedArrayType,
-		arrow.EXTENSION:         unsupportedArrayType,
+		arrow.EXTENSION:         func(data *Data) Interface { return NewExtensionData(data) },
 		arrow.FIXED_SIZE_LIST:   func(data *Data) Interface { return NewFixedSizeListData(data) },
 		arrow.DURATION:          func(data *Data) Interface { return NewDurationData(data) },
+		arrow.RUN_END_ENCODED:   func(data *Data) Interface { return NewRunEndEncodedData(data) },
+		arrow.BINARY_VIEW:       func(data *Data) Interface { return NewBinaryViewData(data) },
+		arrow.STRING_VIEW:       func(data *Data) Interface { return NewStringViewData(data) },
+		arrow.DECIMAL256:        func(data *Data) Interface { return NewDecimal256Data(data) },
 
-		// invalid data types to fill out array size 2⁵-1
-		31: invalidDataType,
+		// invalid data types to fill out array size 2⁶-1
+		63: invalidDataType,
+	}
+	for i, fn := range makeArrayFn {
+		if fn == nil {
+			makeArrayFn[i] = invalidDataType
+		}
 	}
 }
@@ -40,6 +40,7 @@ func TestMakeFromData(t *testing.T) {
 		d        arrow.DataType
 		size     int
 		child    []*array.Data
+		dict     *array.Data
 		expPanic bool
 		expError string
 	}{
@@ -84,16 +85,33 @@ func TestMakeFromData(t *testing.T) {
 			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
 		}},
 		{name: "duration", d: &testDataType{arrow.DURATION}},
+		{name: "binary_view", d: &testDataType{arrow.BINARY_VIEW}},
+		{name: "string_view", d: &testDataType{arrow.STRING_VIEW}},
+
+		{name: "run_end_encoded", d: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64), child: []*array.Data{
+			array.NewData(&testDataType{arrow.INT32}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+		}},
+
+		{name: "dictionary", d: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int64},
+			dict: array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0)},
+
+		{name: "sparse_union", d: arrow.SparseUnionOf([]arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int64}}, []arrow.UnionTypeCode{2}), child: []*array.Data{
+			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+		}},
+		{name: "dense_union", d: arrow.DenseUnionOf([]arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int64}}, []arrow.UnionTypeCode{2}), child: []*array.Data{
+			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+		}},
+		{name: "extension", d: newLengthType("m")},
 
 		// unsupported types
-		{name: "union", d: &testDataType{arrow.UNION}, expPanic: true, expError: "unsupported data type: UNION"},
-		{name: "dictionary", d: &testDataType{arrow.DICTIONARY}, expPanic: true, expError: "unsupported data type: DICTIONARY"},
 		{name: "map", d: &testDataType{arrow.Type(27)}, expPanic: true, expError: "unsupported data type: MAP"},
-		{name: "extension", d: &testDataType{arrow.Type(28)}, expPanic: true, expError: "unsupported data type: EXTENSION"},
 
 		// invalid types
+		{name: "extension(not extension type)", d: &testDataType{arrow.Type(28)}, expPanic: true, expError: "arrow/array: data type *array_test.testDataType is not an extension type"},
 		{name: "invalid(-1)", d: &testDataType{arrow.Type(-1)}, expPanic: true, expError: "invalid data type: Type(-1)"},
-		{name: "invalid(31)", d: &testDataType{arrow.Type(31)}, expPanic: true, expError: "invalid data type: Type(31)"},
+		{name: "invalid(35)", d: &testDataType{arrow.Type(35)}, expPanic: true, expError: "invalid data type: Type(35)"},
+		{name: "invalid(63)", d: &testDataType{arrow.Type(63)}, expPanic: true, expError: "invalid data type: Type(63)"},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -103,6 +121,9 @@ func TestMakeFromData(t *testing.T) {
 				n = test.size
 			}
 			data := array.NewData(test.d, 0, b[:n], test.child, 0, 0)
+			if test.dict != nil {
+				data = array.NewDataWithDictionary(test.d, 0, b[:n], 0, 0, test.dict)
+			}
 
 			if test.expPanic {
 				assert.PanicsWithValue(t, test.expError, func() {
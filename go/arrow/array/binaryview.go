@@ -0,0 +1,194 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// viewArray holds the views and data buffers of the BinaryView and
+// StringView arrays.
+type viewArray struct {
+	array
+	values  []arrow.ViewHeader
+	buffers [][]byte
+}
+
+func (a *viewArray) setData(data *Data) {
+	if len(data.buffers) < 2 {
+		panic("len(data.buffers) < 2")
+	}
+
+	a.array.setData(data)
+
+	a.values = nil
+	if views := data.buffers[1]; views != nil {
+		a.values = arrow.ViewHeaderTraits.CastFromBytes(views.Bytes())
+	}
+
+	a.buffers = make([][]byte, len(data.buffers)-2)
+	for i, buf := range data.buffers[2:] {
+		if buf != nil {
+			a.buffers[i] = buf.Bytes()
+		}
+	}
+}
+
+// view returns the bytes of the value at index i.
+func (a *viewArray) view(i int) []byte {
+	if i < 0 || i >= a.array.data.length {
+		panic("arrow/array: index out of range")
+	}
+	h := &a.values[a.array.data.offset+i]
+	if h.IsInline() {
+		return h.InlineBytes()
+	}
+	off := int(h.BufferOffset())
+	return a.buffers[h.BufferIndex()][off : off+h.Len()]
+}
+
+// ValueHeader returns the view of the value at index i.
+func (a *viewArray) ValueHeader(i int) *arrow.ViewHeader {
+	if i < 0 || i >= a.array.data.length {
+		panic("arrow/array: index out of range")
+	}
+	return &a.values[a.array.data.offset+i]
+}
+
+// ValueLen returns the length of the value at index i.
+func (a *viewArray) ValueLen(i int) int { return a.ValueHeader(i).Len() }
+
+// DataBuffers returns the buffers holding the values which are not
+// stored inline in their view.
+func (a *viewArray) DataBuffers() []*memory.Buffer { return a.array.data.buffers[2:] }
+
+// BinaryView represents an immutable sequence of variable-length binary
+// values stored as views: a value of at most arrow.ViewInlineSize bytes
+// is stored in its view, the others in one of the data buffers.
+type BinaryView struct {
+	viewArray
+}
+
+// NewBinaryViewData constructs a new BinaryView array from data.
+func NewBinaryViewData(data *Data) *BinaryView {
+	a := &BinaryView{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Value returns the slice at index i. This value should not be mutated.
+func (a *BinaryView) Value(i int) []byte { return a.view(i) }
+
+// ValueString returns the string at index i without performing additional allocations.
+// The string is only valid for the lifetime of the BinaryView array.
+func (a *BinaryView) ValueString(i int) string {
+	b := a.Value(i)
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+func (a *BinaryView) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			fmt.Fprintf(o, "%q", a.ValueString(i))
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+func arrayEqualBinaryView(left, right *BinaryView) bool {
+	for i := 0; i < left.Len(); i++ {
+		if left.IsNull(i) {
+			continue
+		}
+		if !bytes.Equal(left.Value(i), right.Value(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// StringView represents an immutable sequence of variable-length UTF-8
+// strings stored as views, see BinaryView.
+type StringView struct {
+	viewArray
+}
+
+// NewStringViewData constructs a new StringView array from data.
+func NewStringViewData(data *Data) *StringView {
+	a := &StringView{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Value returns the string at index i without performing additional allocations.
+// The string is only valid for the lifetime of the StringView array.
+func (a *StringView) Value(i int) string {
+	b := a.view(i)
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+func (a *StringView) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			fmt.Fprintf(o, "%q", a.Value(i))
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+func arrayEqualStringView(left, right *StringView) bool {
+	for i := 0; i < left.Len(); i++ {
+		if left.IsNull(i) {
+			continue
+		}
+		if left.Value(i) != right.Value(i) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	_ Interface = (*BinaryView)(nil)
+	_ Interface = (*StringView)(nil)
+)
@@ -0,0 +1,241 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestStringViewArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var (
+		want   = []string{"hello", "", "a string longer than inline", "exactly12byt", "世界"}
+		valids = []bool{true, false, true, true, true}
+		inline = []bool{true, true, false, true, true}
+	)
+
+	b := array.NewStringViewBuilder(mem)
+	defer b.Release()
+
+	b.AppendValues(want[:2], valids[:2])
+	for _, v := range want[2:] {
+		b.Append(v)
+	}
+	if got, want := b.Len(), len(want); got != want {
+		t.Fatalf("invalid len: got=%d, want=%d", got, want)
+	}
+	if got, want := b.NullN(), 1; got != want {
+		t.Fatalf("invalid nulls: got=%d, want=%d", got, want)
+	}
+
+	arr := b.NewStringViewArray()
+	defer arr.Release()
+
+	if got, want := arr.DataType(), arrow.BinaryTypes.StringView; !arrow.TypeEqual(got, want) {
+		t.Fatalf("invalid type: got=%v, want=%v", got, want)
+	}
+	if got, want := arr.NullN(), 1; got != want {
+		t.Fatalf("invalid nulls: got=%d, want=%d", got, want)
+	}
+	if got, want := len(arr.DataBuffers()), 1; got != want {
+		t.Fatalf("invalid number of data buffers: got=%d, want=%d", got, want)
+	}
+
+	for i := range want {
+		if got, want := arr.IsValid(i), valids[i]; got != want {
+			t.Fatalf("invalid validity %d: got=%v, want=%v", i, got, want)
+		}
+		if !valids[i] {
+			continue
+		}
+		if got, want := arr.Value(i), want[i]; got != want {
+			t.Fatalf("invalid value %d: got=%q, want=%q", i, got, want)
+		}
+		if got, want := arr.ValueLen(i), len(want[i]); got != want {
+			t.Fatalf("invalid value length %d: got=%d, want=%d", i, got, want)
+		}
+		if got, want := arr.ValueHeader(i).IsInline(), inline[i]; got != want {
+			t.Fatalf("invalid inline %d: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	h := arr.ValueHeader(2)
+	if got, want := h.Prefix(), [4]byte{'a', ' ', 's', 't'}; got != want {
+		t.Fatalf("invalid prefix: got=%q, want=%q", got[:], want[:])
+	}
+	if got, want := h.BufferIndex(), int32(0); got != want {
+		t.Fatalf("invalid buffer index: got=%d, want=%d", got, want)
+	}
+
+	if got, want := arr.String(), `["hello" (null) "a string longer than inline" "exactly12byt" "世界"]`; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+
+	slice := array.NewSlice(arr, 1, 4).(*array.StringView)
+	defer slice.Release()
+
+	if got, want := slice.Len(), 3; got != want {
+		t.Fatalf("invalid slice len: got=%d, want=%d", got, want)
+	}
+	if got, want := slice.NullN(), 1; got != want {
+		t.Fatalf("invalid slice nulls: got=%d, want=%d", got, want)
+	}
+	if !slice.IsNull(0) {
+		t.Fatalf("slice value 0 should be null")
+	}
+	for i, want := range want[2:4] {
+		if got := slice.Value(i + 1); got != want {
+			t.Fatalf("invalid slice value %d: got=%q, want=%q", i+1, got, want)
+		}
+	}
+
+	func() {
+		defer func() {
+			e := recover()
+			if got, want := e, "arrow/array: index out of range"; got != want {
+				t.Fatalf("invalid panic: got=%v, want=%q", got, want)
+			}
+		}()
+		slice.Value(3)
+	}()
+}
+
+func TestBinaryViewBuilderBlocks(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
+	defer b.Release()
+
+	// enough values to spread over several data buffers.
+	const n = 100
+	want := make([][]byte, n)
+	for i := range want {
+		want[i] = bytes.Repeat([]byte{byte(i)}, 1000+i)
+		switch {
+		case i%10 == 3:
+			b.AppendNull()
+			want[i] = nil
+		default:
+			b.Append(want[i])
+		}
+	}
+
+	arr := b.NewBinaryViewArray()
+	defer arr.Release()
+
+	if got := len(arr.DataBuffers()); got < 2 {
+		t.Fatalf("invalid number of data buffers: got=%d, want>1", got)
+	}
+	if got, want := arr.NullN(), n/10; got != want {
+		t.Fatalf("invalid nulls: got=%d, want=%d", got, want)
+	}
+	for i := range want {
+		if want[i] == nil {
+			if !arr.IsNull(i) {
+				t.Fatalf("value %d should be null", i)
+			}
+			continue
+		}
+		if got := arr.Value(i); !bytes.Equal(got, want[i]) {
+			t.Fatalf("invalid value %d: got=%d bytes, want=%d bytes", i, len(got), len(want[i]))
+		}
+	}
+
+	// the builder can be reused after NewArray.
+	b.AppendValues([][]byte{[]byte("abc"), nil, []byte(strings.Repeat("x", 20))}, []bool{true, false, true})
+	other := b.NewBinaryViewArray()
+	defer other.Release()
+
+	if got, want := other.String(), `["abc" (null) "xxxxxxxxxxxxxxxxxxxx"]`; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+}
+
+func TestBinaryViewArrayEqual(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	long := strings.Repeat("long value ", 3)
+	build := func(vs []string, valids []bool) *array.StringView {
+		b := array.NewStringViewBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vs, valids)
+		return b.NewStringViewArray()
+	}
+
+	a1 := build([]string{"a", long, "", "c"}, []bool{true, true, false, true})
+	defer a1.Release()
+	a2 := build([]string{"z", "a", long, "x", "c"}, []bool{true, true, true, false, true})
+	defer a2.Release()
+	a3 := build([]string{"a", long + "!", "", "c"}, []bool{true, true, false, true})
+	defer a3.Release()
+
+	s2 := array.NewSlice(a2, 1, 5)
+	defer s2.Release()
+
+	if !array.ArrayEqual(a1, s2) {
+		t.Fatalf("arrays should be equal: %v, %v", a1, s2)
+	}
+	if !array.ArrayApproxEqual(a1, s2) {
+		t.Fatalf("arrays should be approximately equal: %v, %v", a1, s2)
+	}
+	if array.ArrayEqual(a1, a3) {
+		t.Fatalf("arrays should differ: %v, %v", a1, a3)
+	}
+}
+
+func TestStringViewMaps(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringViewBuilder(mem)
+	defer b.Release()
+	b.AppendValues([]string{"a", "", "a value out of line"}, []bool{true, false, true})
+
+	arr := b.NewArray()
+	defer arr.Release()
+
+	rec := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "s", Type: arr.DataType(), Nullable: true}}, nil), []array.Interface{arr}, -1)
+	defer rec.Release()
+
+	maps := array.RecordToMaps(rec)
+	if got, want := maps[0]["s"], "a"; got != want {
+		t.Fatalf("invalid value 0: got=%v, want=%v", got, want)
+	}
+	if got := maps[1]["s"]; got != nil {
+		t.Fatalf("invalid value 1: got=%v, want=nil", got)
+	}
+
+	back, err := array.RecordFromMaps(mem, rec.Schema(), maps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer back.Release()
+
+	if !array.RecordEqual(rec, back) {
+		t.Fatalf("records differ:\ngot= %v\nwant=%v", back, rec)
+	}
+}
@@ -0,0 +1,334 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+const (
+	// viewBlockSize is the size of the data buffers of the view builders,
+	// which only hold larger values in a buffer of their own.
+	viewBlockSize = 32 << 10
+)
+
+// A BinaryViewBuilder is used to build a BinaryView array using the Append methods.
+type BinaryViewBuilder struct {
+	builder
+
+	dtype   arrow.BinaryViewDataType
+	data    *memory.Buffer
+	rawData []arrow.ViewHeader
+	blocks  []*memory.Buffer   // data buffers already filled.
+	block   *byteBufferBuilder // data buffer being filled.
+}
+
+func NewBinaryViewBuilder(mem memory.Allocator, dtype arrow.BinaryViewDataType) *BinaryViewBuilder {
+	return &BinaryViewBuilder{
+		builder: builder{refCount: 1, mem: mem},
+		dtype:   dtype,
+		block:   newByteBufferBuilder(mem),
+	}
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (b *BinaryViewBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		if b.data != nil {
+			b.data.Release()
+			b.data = nil
+			b.rawData = nil
+		}
+		for _, blk := range b.blocks {
+			blk.Release()
+		}
+		b.blocks = nil
+		if b.block != nil {
+			b.block.Release()
+			b.block = nil
+		}
+	}
+}
+
+func (b *BinaryViewBuilder) Append(v []byte) {
+	b.Reserve(1)
+	b.UnsafeAppend(v)
+}
+
+func (b *BinaryViewBuilder) AppendString(v string) {
+	b.Append([]byte(v))
+}
+
+func (b *BinaryViewBuilder) AppendNull() {
+	b.Reserve(1)
+	b.rawData[b.length] = arrow.ViewHeader{}
+	b.UnsafeAppendBoolToBitmap(false)
+}
+
+// UnsafeAppend appends v, which is stored inline in its view when short
+// enough, or else in the data buffers.
+func (b *BinaryViewBuilder) UnsafeAppend(v []byte) {
+	b.setView(b.length, v)
+	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
+	b.length++
+}
+
+// AppendValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *BinaryViewBuilder) AppendValues(v [][]byte, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	if len(v) == 0 {
+		return
+	}
+
+	b.Reserve(len(v))
+	for i, vv := range v {
+		if len(valid) != 0 && !valid[i] {
+			b.rawData[b.length+i] = arrow.ViewHeader{}
+			continue
+		}
+		b.setView(b.length+i, vv)
+	}
+
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+// AppendStringValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *BinaryViewBuilder) AppendStringValues(v []string, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	if len(v) == 0 {
+		return
+	}
+
+	b.Reserve(len(v))
+	for i, vv := range v {
+		if len(valid) != 0 && !valid[i] {
+			b.rawData[b.length+i] = arrow.ViewHeader{}
+			continue
+		}
+		b.setView(b.length+i, []byte(vv))
+	}
+
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+// setView sets the i-th view to the one of v, appending v to the data
+// buffers when it is not inline.
+func (b *BinaryViewBuilder) setView(i int, v []byte) {
+	if len(v) > binaryArrayMaximumCapacity {
+		panic("arrow/array: value too large")
+	}
+
+	h := &b.rawData[i]
+	h.SetBytes(v)
+	if h.IsInline() {
+		return
+	}
+
+	if n := b.block.Len(); n > 0 && n+len(v) > viewBlockSize {
+		b.blocks = append(b.blocks, b.block.Finish())
+	}
+	h.SetIndexOffset(int32(len(b.blocks)), int32(b.block.Len()))
+	b.block.Append(v)
+}
+
+func (b *BinaryViewBuilder) init(capacity int) {
+	b.builder.init(capacity)
+
+	b.data = memory.NewResizableBuffer(b.mem)
+	bytesN := arrow.ViewHeaderTraits.BytesRequired(capacity)
+	b.data.Resize(bytesN)
+	b.rawData = arrow.ViewHeaderTraits.CastFromBytes(b.data.Bytes())
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *BinaryViewBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *BinaryViewBuilder) Resize(n int) {
+	nBuilder := n
+	if n < minBuilderCapacity {
+		n = minBuilderCapacity
+	}
+
+	if b.capacity == 0 {
+		b.init(n)
+	} else {
+		b.builder.resize(nBuilder, b.init)
+		b.data.Resize(arrow.ViewHeaderTraits.BytesRequired(n))
+		b.rawData = arrow.ViewHeaderTraits.CastFromBytes(b.data.Bytes())
+	}
+}
+
+// NewArray creates a BinaryView array from the memory buffers used by the builder and resets the BinaryViewBuilder
+// so it can be used to build a new array.
+func (b *BinaryViewBuilder) NewArray() Interface {
+	return b.NewBinaryViewArray()
+}
+
+// NewBinaryViewArray creates a BinaryView array from the memory buffers used by the builder and resets the BinaryViewBuilder
+// so it can be used to build a new array.
+func (b *BinaryViewBuilder) NewBinaryViewArray() (a *BinaryView) {
+	data := b.newData()
+	a = NewBinaryViewData(data)
+	data.Release()
+	return
+}
+
+func (b *BinaryViewBuilder) newData() (data *Data) {
+	bytesRequired := arrow.ViewHeaderTraits.BytesRequired(b.length)
+	if bytesRequired > 0 && bytesRequired < b.data.Len() {
+		// trim buffers
+		b.data.Resize(bytesRequired)
+	}
+	if b.block.Len() > 0 {
+		b.blocks = append(b.blocks, b.block.Finish())
+	}
+
+	buffers := append([]*memory.Buffer{b.nullBitmap, b.data}, b.blocks...)
+	data = NewData(b.dtype, b.length, buffers, nil, b.nulls, 0)
+	b.reset()
+
+	if b.data != nil {
+		b.data.Release()
+		b.data = nil
+		b.rawData = nil
+	}
+	for _, blk := range b.blocks {
+		blk.Release()
+	}
+	b.blocks = nil
+
+	return
+}
+
+// A StringViewBuilder is used to build a StringView array using the Append methods.
+type StringViewBuilder struct {
+	builder *BinaryViewBuilder
+}
+
+// NewStringViewBuilder creates a new StringViewBuilder.
+func NewStringViewBuilder(mem memory.Allocator) *StringViewBuilder {
+	return &StringViewBuilder{
+		builder: NewBinaryViewBuilder(mem, arrow.BinaryTypes.StringView),
+	}
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (b *StringViewBuilder) Release() {
+	b.builder.Release()
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (b *StringViewBuilder) Retain() {
+	b.builder.Retain()
+}
+
+// Len returns the number of elements in the array builder.
+func (b *StringViewBuilder) Len() int { return b.builder.Len() }
+
+// Cap returns the total number of elements that can be stored without allocating additional memory.
+func (b *StringViewBuilder) Cap() int { return b.builder.Cap() }
+
+// NullN returns the number of null values in the array builder.
+func (b *StringViewBuilder) NullN() int { return b.builder.NullN() }
+
+// Append appends a string to the builder.
+func (b *StringViewBuilder) Append(v string) {
+	b.builder.AppendString(v)
+}
+
+// AppendNull appends a null to the builder.
+func (b *StringViewBuilder) AppendNull() {
+	b.builder.AppendNull()
+}
+
+// AppendValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *StringViewBuilder) AppendValues(v []string, valid []bool) {
+	b.builder.AppendStringValues(v, valid)
+}
+
+func (b *StringViewBuilder) init(capacity int) {
+	b.builder.init(capacity)
+}
+
+func (b *StringViewBuilder) resize(newBits int, init func(int)) {
+	b.builder.resize(newBits, init)
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *StringViewBuilder) Reserve(n int) {
+	b.builder.Reserve(n)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *StringViewBuilder) Resize(n int) {
+	b.builder.Resize(n)
+}
+
+// NewArray creates a StringView array from the memory buffers used by the builder and resets the StringViewBuilder
+// so it can be used to build a new array.
+func (b *StringViewBuilder) NewArray() Interface {
+	return b.NewStringViewArray()
+}
+
+// NewStringViewArray creates a StringView array from the memory buffers used by the builder and resets the StringViewBuilder
+// so it can be used to build a new array.
+func (b *StringViewBuilder) NewStringViewArray() (a *StringView) {
+	data := b.builder.newData()
+	a = NewStringViewData(data)
+	data.Release()
+	return
+}
+
+var (
+	_ Builder = (*BinaryViewBuilder)(nil)
+	_ Builder = (*StringViewBuilder)(nil)
+)
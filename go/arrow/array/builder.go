@@ -275,15 +275,35 @@ func NewBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		typ := dtype.(*arrow.StructType)
 		return NewStructBuilder(mem, typ)
 	case arrow.UNION:
+		typ := dtype.(*arrow.UnionType)
+		if typ.Mode() == arrow.DenseMode {
+			return NewDenseUnionBuilder(mem, typ)
+		}
+		return NewSparseUnionBuilder(mem, typ)
 	case arrow.DICTIONARY:
 	case arrow.MAP:
 	case arrow.EXTENSION:
+		typ := dtype.(arrow.ExtensionType)
+		if custom, ok := typ.(CustomExtensionBuilder); ok {
+			return custom.NewBuilder(mem)
+		}
+		return NewExtensionBuilder(mem, typ)
 	case arrow.FIXED_SIZE_LIST:
 		typ := dtype.(*arrow.FixedSizeListType)
 		return NewFixedSizeListBuilder(mem, typ.Len(), typ.Elem())
 	case arrow.DURATION:
 		typ := dtype.(*arrow.DurationType)
 		return NewDurationBuilder(mem, typ)
+	case arrow.RUN_END_ENCODED:
+		typ := dtype.(*arrow.RunEndEncodedType)
+		return NewRunEndEncodedBuilder(mem, typ.RunEnds(), typ.Encoded())
+	case arrow.BINARY_VIEW:
+		return NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
+	case arrow.STRING_VIEW:
+		return NewStringViewBuilder(mem)
+	case arrow.DECIMAL256:
+		typ := dtype.(*arrow.Decimal256Type)
+		return NewDecimal256Builder(mem, typ)
 	}
 	panic(fmt.Errorf("arrow/array: unsupported builder for %T", dtype))
 }
@@ -95,6 +95,12 @@ func ArrayEqual(left, right Interface) bool {
 	case *String:
 		r := right.(*String)
 		return arrayEqualString(l, r)
+	case *BinaryView:
+		r := right.(*BinaryView)
+		return arrayEqualBinaryView(l, r)
+	case *StringView:
+		r := right.(*StringView)
+		return arrayEqualStringView(l, r)
 	case *Int8:
 		r := right.(*Int8)
 		return arrayEqualInt8(l, r)
@@ -131,6 +137,9 @@ func ArrayEqual(left, right Interface) bool {
 	case *Decimal128:
 		r := right.(*Decimal128)
 		return arrayEqualDecimal128(l, r)
+	case *Decimal256:
+		r := right.(*Decimal256)
+		return arrayEqualDecimal256(l, r)
 	case *Date32:
 		r := right.(*Date32)
 		return arrayEqualDate32(l, r)
@@ -155,6 +164,9 @@ func ArrayEqual(left, right Interface) bool {
 	case *Struct:
 		r := right.(*Struct)
 		return arrayEqualStruct(l, r)
+	case *Dictionary:
+		r := right.(*Dictionary)
+		return arrayEqualDictionary(l, r)
 	case *MonthInterval:
 		r := right.(*MonthInterval)
 		return arrayEqualMonthInterval(l, r)
@@ -164,6 +176,18 @@ func ArrayEqual(left, right Interface) bool {
 	case *Duration:
 		r := right.(*Duration)
 		return arrayEqualDuration(l, r)
+	case *RunEndEncoded:
+		r := right.(*RunEndEncoded)
+		return arrayEqualRunEndEncoded(l, r)
+	case *SparseUnion:
+		r := right.(*SparseUnion)
+		return arrayEqualUnion(l, r)
+	case *DenseUnion:
+		r := right.(*DenseUnion)
+		return arrayEqualUnion(l, r)
+	case ExtensionArray:
+		r := right.(ExtensionArray)
+		return ArrayEqual(l.Storage(), r.Storage())
 
 	default:
 		panic(xerrors.Errorf("arrow/array: unknown array type %T", l))
@@ -284,6 +308,12 @@ func arrayApproxEqual(left, right Interface, opt equalOption) bool {
 	case *String:
 		r := right.(*String)
 		return arrayEqualString(l, r)
+	case *BinaryView:
+		r := right.(*BinaryView)
+		return arrayEqualBinaryView(l, r)
+	case *StringView:
+		r := right.(*StringView)
+		return arrayEqualStringView(l, r)
 	case *Int8:
 		r := right.(*Int8)
 		return arrayEqualInt8(l, r)
@@ -320,6 +350,9 @@ func arrayApproxEqual(left, right Interface, opt equalOption) bool {
 	case *Decimal128:
 		r := right.(*Decimal128)
 		return arrayEqualDecimal128(l, r)
+	case *Decimal256:
+		r := right.(*Decimal256)
+		return arrayEqualDecimal256(l, r)
 	case *Date32:
 		r := right.(*Date32)
 		return arrayEqualDate32(l, r)
@@ -344,6 +377,9 @@ func arrayApproxEqual(left, right Interface, opt equalOption) bool {
 	case *Struct:
 		r := right.(*Struct)
 		return arrayApproxEqualStruct(l, r, opt)
+	case *Dictionary:
+		r := right.(*Dictionary)
+		return arrayApproxEqualDictionary(l, r, opt)
 	case *MonthInterval:
 		r := right.(*MonthInterval)
 		return arrayEqualMonthInterval(l, r)
@@ -353,6 +389,18 @@ func arrayApproxEqual(left, right Interface, opt equalOption) bool {
 	case *Duration:
 		r := right.(*Duration)
 		return arrayEqualDuration(l, r)
+	case *RunEndEncoded:
+		r := right.(*RunEndEncoded)
+		return arrayApproxEqualRunEndEncoded(l, r, opt)
+	case *SparseUnion:
+		r := right.(*SparseUnion)
+		return arrayApproxEqualUnion(l, r, opt)
+	case *DenseUnion:
+		r := right.(*DenseUnion)
+		return arrayApproxEqualUnion(l, r, opt)
+	case ExtensionArray:
+		r := right.(ExtensionArray)
+		return arrayApproxEqual(l.Storage(), r.Storage(), opt)
 
 	default:
 		panic(xerrors.Errorf("arrow/array: unknown array type %T", l))
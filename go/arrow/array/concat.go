@@ -0,0 +1,813 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// MetadataMergePolicy controls how ConcatenateTablesWithPromotion combines
+// the metadata of the fields of the same name of the tables.
+type MetadataMergePolicy int8
+
+const (
+	// MetadataKeepFirst keeps the metadata of the field of the first table
+	// holding the field.
+	MetadataKeepFirst MetadataMergePolicy = iota
+	// MetadataMerge merges the metadata of the fields of all the tables.
+	// Values of later tables replace the values of earlier tables for the
+	// same key.
+	MetadataMerge
+	// MetadataDrop drops the metadata of the fields.
+	MetadataDrop
+)
+
+// ConcatenateOptions configures ConcatenateTablesWithPromotion.
+type ConcatenateOptions struct {
+	// Mem allocates the arrays of promoted and missing columns.
+	// memory.DefaultAllocator is used if Mem is nil.
+	Mem memory.Allocator
+	// FieldMetadata is the policy used to combine the metadata of fields.
+	FieldMetadata MetadataMergePolicy
+}
+
+// ConcatenateTablesWithPromotion returns a table made of the rows of tables,
+// in order, whose schemas may differ. If opts is nil, the default options
+// are used.
+//
+// The schemas of the tables are unified by field name, in the order the
+// fields first appear, with the schema metadata of the first table:
+//   - a column missing from a table is made of nulls for its rows;
+//   - columns of the null type take the type of the other tables;
+//   - integer columns promote to the widest of their types, and to a
+//     signed type wide enough for unsigned values when signed and unsigned
+//     columns are mixed;
+//   - floating point columns promote to the widest of their types, and to a
+//     type wide enough to represent 16-bit integers exactly, or to float64
+//     for wider integers, when mixed with integer columns.
+//
+// Columns of other types must have equal types in all the tables. Unified
+// fields are nullable if they are nullable, null or missing in any of the
+// tables.
+//
+// Columns with unchanged types share the memory of the input tables.
+// The returned table must be Release()'d after use.
+func ConcatenateTablesWithPromotion(tables []Table, opts *ConcatenateOptions) (Table, error) {
+	if opts == nil {
+		opts = &ConcatenateOptions{}
+	}
+	mem := opts.Mem
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	if len(tables) == 0 {
+		return nil, xerrors.Errorf("arrow/array: no tables to concatenate")
+	}
+
+	schema, err := unifySchemas(tables, opts.FieldMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows int64
+	for _, tbl := range tables {
+		rows += tbl.NumRows()
+	}
+
+	cols := make([]Column, 0, len(schema.Fields()))
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	for _, field := range schema.Fields() {
+		chunks := make([]Interface, 0, len(tables))
+		for _, tbl := range tables {
+			chunks = appendTableChunks(mem, chunks, tbl, field)
+		}
+		data := NewChunked(field.Type, chunks)
+		for _, c := range chunks {
+			c.Release()
+		}
+		cols = append(cols, *NewColumn(field, data))
+		data.Release()
+	}
+	return NewTable(schema, cols, rows), nil
+}
+
+// appendTableChunks appends to chunks the values of the column field of tbl,
+// converted to the type of field.
+func appendTableChunks(mem memory.Allocator, chunks []Interface, tbl Table, field arrow.Field) []Interface {
+	rows := int(tbl.NumRows())
+	indices := tbl.Schema().FieldIndices(field.Name)
+	if len(indices) == 0 {
+		if rows > 0 {
+			chunks = append(chunks, makeNulls(mem, field.Type, rows))
+		}
+		return chunks
+	}
+
+	// columns may be longer than their table.
+	for _, c := range tbl.Column(indices[0]).Data().Chunks() {
+		if rows == 0 {
+			break
+		}
+		if c.Len() > rows {
+			c = NewSlice(c, 0, int64(rows))
+		} else {
+			c.Retain()
+		}
+		rows -= c.Len()
+
+		if !arrow.TypeEqual(c.DataType(), field.Type) {
+			promoted := promoteArray(mem, c, field.Type)
+			c.Release()
+			c = promoted
+		}
+		chunks = append(chunks, c)
+	}
+	return chunks
+}
+
+func unifySchemas(tables []Table, policy MetadataMergePolicy) (*arrow.Schema, error) {
+	var (
+		fields []arrow.Field
+		counts []int
+		index  = make(map[string]int)
+	)
+	for i, tbl := range tables {
+		schema := tbl.Schema()
+		for _, f := range schema.Fields() {
+			if len(schema.FieldIndices(f.Name)) > 1 {
+				return nil, xerrors.Errorf("arrow/array: duplicate field %q in the schema of table %d", f.Name, i)
+			}
+			if policy == MetadataDrop {
+				f.Metadata = arrow.Metadata{}
+			}
+			if f.Type.ID() == arrow.NULL {
+				f.Nullable = true
+			}
+
+			j, ok := index[f.Name]
+			if !ok {
+				index[f.Name] = len(fields)
+				fields = append(fields, f)
+				counts = append(counts, 1)
+				continue
+			}
+
+			dt := promoteTypes(fields[j].Type, f.Type)
+			if dt == nil {
+				return nil, xerrors.Errorf("arrow/array: cannot unify the types %v and %v of field %q", fields[j].Type, f.Type, f.Name)
+			}
+			fields[j].Type = dt
+			fields[j].Nullable = fields[j].Nullable || f.Nullable
+			if policy == MetadataMerge {
+				fields[j].Metadata = mergeMetadata(fields[j].Metadata, f.Metadata)
+			}
+			counts[j]++
+		}
+	}
+	for j := range fields {
+		if counts[j] < len(tables) {
+			fields[j].Nullable = true
+		}
+	}
+	md := tables[0].Schema().Metadata()
+	return arrow.NewSchema(fields, &md), nil
+}
+
+func mergeMetadata(a, b arrow.Metadata) arrow.Metadata {
+	if b.Len() == 0 {
+		return a
+	}
+	keys := append([]string(nil), a.Keys()...)
+	values := append([]string(nil), a.Values()...)
+	for i, k := range b.Keys() {
+		if j := a.FindKey(k); j >= 0 {
+			values[j] = b.Values()[i]
+			continue
+		}
+		keys = append(keys, k)
+		values = append(values, b.Values()[i])
+	}
+	return arrow.NewMetadata(keys, values)
+}
+
+type numericKind int8
+
+const (
+	notNumeric numericKind = iota
+	signedKind
+	unsignedKind
+	floatKind
+)
+
+func numericKindOf(dt arrow.DataType) (numericKind, int) {
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return signedKind, dt.(arrow.FixedWidthDataType).BitWidth()
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return unsignedKind, dt.(arrow.FixedWidthDataType).BitWidth()
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return floatKind, dt.(arrow.FixedWidthDataType).BitWidth()
+	}
+	return notNumeric, 0
+}
+
+var numericTypes = map[numericKind]map[int]arrow.DataType{
+	signedKind: {
+		8:  arrow.PrimitiveTypes.Int8,
+		16: arrow.PrimitiveTypes.Int16,
+		32: arrow.PrimitiveTypes.Int32,
+		64: arrow.PrimitiveTypes.Int64,
+	},
+	unsignedKind: {
+		8:  arrow.PrimitiveTypes.Uint8,
+		16: arrow.PrimitiveTypes.Uint16,
+		32: arrow.PrimitiveTypes.Uint32,
+		64: arrow.PrimitiveTypes.Uint64,
+	},
+	floatKind: {
+		16: arrow.FixedWidthTypes.Float16,
+		32: arrow.PrimitiveTypes.Float32,
+		64: arrow.PrimitiveTypes.Float64,
+	},
+}
+
+// promoteTypes returns the type the values of types a and b promote to, or
+// nil if they are incompatible.
+func promoteTypes(a, b arrow.DataType) arrow.DataType {
+	switch {
+	case arrow.TypeEqual(a, b):
+		return a
+	case a.ID() == arrow.NULL:
+		return b
+	case b.ID() == arrow.NULL:
+		return a
+	}
+
+	ka, wa := numericKindOf(a)
+	kb, wb := numericKindOf(b)
+	if ka == notNumeric || kb == notNumeric {
+		return nil
+	}
+	if ka > kb {
+		ka, wa, kb, wb = kb, wb, ka, wa
+	}
+
+	kind, width := ka, max(wa, wb)
+	switch {
+	case ka == signedKind && kb == unsignedKind:
+		width = max(wa, 2*wb)
+	case kb == floatKind && ka != floatKind:
+		width = max(wb, 32)
+		if wa > 16 {
+			width = 64
+		}
+		kind = floatKind
+	}
+	return numericTypes[kind][width]
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// promoteArray converts the values of the numeric or null array arr to the
+// type to.
+func promoteArray(mem memory.Allocator, arr Interface, to arrow.DataType) Interface {
+	if arr.DataType().ID() == arrow.NULL {
+		return makeNulls(mem, to, arr.Len())
+	}
+
+	bldr := NewBuilder(mem, to)
+	defer bldr.Release()
+	bldr.Reserve(arr.Len())
+
+	value := numericValue(arr)
+	add := numericAppender(bldr)
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			bldr.AppendNull()
+			continue
+		}
+		add(value(i))
+	}
+	return bldr.NewArray()
+}
+
+// numericValue returns a function giving the i-th value of the numeric
+// array arr as an int64, a uint64 and a float64.
+func numericValue(arr Interface) func(i int) (int64, uint64, float64) {
+	switch arr := arr.(type) {
+	case *Int8:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Int16:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Int32:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Int64:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return v, uint64(v), float64(v)
+		}
+	case *Uint8:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Uint16:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Uint32:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Uint64:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), v, float64(v)
+		}
+	case *Float16:
+		return func(i int) (int64, uint64, float64) {
+			v := float64(arr.Value(i).Float32())
+			return int64(v), uint64(v), v
+		}
+	case *Float32:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), float64(v)
+		}
+	case *Float64:
+		return func(i int) (int64, uint64, float64) {
+			v := arr.Value(i)
+			return int64(v), uint64(v), v
+		}
+	}
+	panic(xerrors.Errorf("arrow/array: invalid numeric array %T", arr))
+}
+
+// numericAppender returns a function appending the value it is given, as an
+// int64, a uint64 or a float64, to the numeric builder bldr.
+func numericAppender(bldr Builder) func(i int64, u uint64, f float64) {
+	switch b := bldr.(type) {
+	case *Int8Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(int8(i)) }
+	case *Int16Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(int16(i)) }
+	case *Int32Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(int32(i)) }
+	case *Int64Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(i) }
+	case *Uint8Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(uint8(u)) }
+	case *Uint16Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(uint16(u)) }
+	case *Uint32Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(uint32(u)) }
+	case *Uint64Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(u) }
+	case *Float16Builder:
+		return func(i int64, u uint64, f float64) { b.Append(float16.FromFloat64(f)) }
+	case *Float32Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(float32(f)) }
+	case *Float64Builder:
+		return func(i int64, u uint64, f float64) { b.UnsafeAppend(f) }
+	}
+	panic(xerrors.Errorf("arrow/array: invalid numeric builder %T", bldr))
+}
+
+// makeNulls returns an array of n nulls of type dt.
+func makeNulls(mem memory.Allocator, dt arrow.DataType, n int) Interface {
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return NewNull(n)
+	case *arrow.DictionaryType:
+		indices := makeNulls(mem, dt.IndexType, n)
+		defer indices.Release()
+		dict := makeNulls(mem, dt.ValueType, 0)
+		defer dict.Release()
+		return NewDictionaryArray(dt, indices, dict)
+	}
+
+	bldr := NewBuilder(mem, dt)
+	defer bldr.Release()
+	bldr.Reserve(n)
+	for i := 0; i < n; i++ {
+		bldr.AppendNull()
+	}
+	return bldr.NewArray()
+}
+
+// Concatenate returns a new array made of the values of arrs, in order.
+// The arrays must all be of the same type. The dictionaries of dictionary
+// arrays with different dictionary values are unified, the values of list,
+// binary and string arrays must fit 32-bit offsets.
+//
+// The returned array must be Release()'d after use.
+func Concatenate(arrs []Interface, mem memory.Allocator) (Interface, error) {
+	if len(arrs) == 0 {
+		return nil, xerrors.New("arrow/array: no arrays to concatenate")
+	}
+	dt := arrs[0].DataType()
+	for _, arr := range arrs[1:] {
+		if !arrow.TypeEqual(arr.DataType(), dt) {
+			return nil, xerrors.Errorf("arrow/array: cannot concatenate arrays of types %v and %v", dt, arr.DataType())
+		}
+	}
+
+	data, err := concatData(mem, dt, arrs)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return MakeFromData(data), nil
+}
+
+// ConcatenateRecords returns a new record made of the rows of recs, in
+// order, concatenating their columns with Concatenate. The records must all
+// have the same schema.
+//
+// The returned record must be Release()'d after use.
+func ConcatenateRecords(recs []Record, mem memory.Allocator) (Record, error) {
+	if len(recs) == 0 {
+		return nil, xerrors.New("arrow/array: no records to concatenate")
+	}
+	schema := recs[0].Schema()
+	var rows int64
+	for i, rec := range recs {
+		if !rec.Schema().Equal(schema) {
+			return nil, xerrors.Errorf("arrow/array: schema of record %d differs from the schema of record 0:\n%v", i, arrow.SchemaDiff(schema, rec.Schema()))
+		}
+		rows += rec.NumRows()
+	}
+
+	cols := make([]Interface, 0, len(schema.Fields()))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	arrs := make([]Interface, len(recs))
+	for i := range schema.Fields() {
+		for j, rec := range recs {
+			arrs[j] = rec.Column(i)
+		}
+		col, err := Concatenate(arrs, mem)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/array: could not concatenate column %d: %w", i, err)
+		}
+		cols = append(cols, col)
+	}
+	return NewRecord(schema, cols, rows), nil
+}
+
+// CombineChunks returns a table of the rows of tbl whose columns are made
+// of a single chunk, the concatenation of the chunks of the columns of tbl
+// with Concatenate. Columns of a single chunk share the memory of tbl.
+//
+// The returned table must be Release()'d after use.
+func CombineChunks(tbl Table, mem memory.Allocator) (Table, error) {
+	cols := make([]Column, 0, tbl.NumCols())
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	for i := 0; i < int(tbl.NumCols()); i++ {
+		col := tbl.Column(i)
+		chunks := col.Data().Chunks()
+		if len(chunks) > 1 {
+			arr, err := Concatenate(chunks, mem)
+			if err != nil {
+				return nil, xerrors.Errorf("arrow/array: could not combine the chunks of column %q: %w", col.Name(), err)
+			}
+			defer arr.Release()
+			chunks = []Interface{arr}
+		}
+		data := NewChunked(col.DataType(), chunks)
+		cols = append(cols, *NewColumn(col.Field(), data))
+		data.Release()
+	}
+	return NewTable(tbl.Schema(), cols, tbl.NumRows()), nil
+}
+
+// concatData returns the data of the concatenation of arrs, of type dt.
+func concatData(mem memory.Allocator, dt arrow.DataType, arrs []Interface) (*Data, error) {
+	var n, nulls int
+	for _, arr := range arrs {
+		n += arr.Len()
+		nulls += arr.NullN()
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return NewData(dt, n, []*memory.Buffer{nil}, nil, n, 0), nil
+	case *arrow.DictionaryType:
+		return concatDictionaries(mem, dt, arrs)
+	}
+
+	buffers := []*memory.Buffer{concatValidity(mem, arrs, n, nulls)}
+	var children []*Data
+	release := func() {
+		for _, b := range buffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+		for _, c := range children {
+			c.Release()
+		}
+	}
+	defer release()
+
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		buf := memory.NewResizableBuffer(mem)
+		buf.Resize(int(bitutil.BytesForBits(int64(n))))
+		pos := 0
+		for _, arr := range arrs {
+			if arr.Len() == 0 {
+				continue
+			}
+			copyBits(arr.Data().Buffers()[1].Bytes(), arr.Data().Offset(), buf.Bytes(), pos, arr.Len())
+			pos += arr.Len()
+		}
+		buffers = append(buffers, buf)
+
+	case arrow.FixedWidthDataType:
+		width := dt.BitWidth() / 8
+		if dt.ID() == arrow.DECIMAL {
+			width = arrow.Decimal128SizeBytes
+		}
+		buf := memory.NewResizableBuffer(mem)
+		buf.Resize(n * width)
+		pos := 0
+		for _, arr := range arrs {
+			if arr.Len() == 0 {
+				continue
+			}
+			beg := arr.Data().Offset() * width
+			pos += copy(buf.Bytes()[pos:], arr.Data().Buffers()[1].Bytes()[beg:beg+arr.Len()*width])
+		}
+		buffers = append(buffers, buf)
+
+	case *arrow.BinaryType, *arrow.StringType:
+		offsets, ranges, err := concatOffsets(mem, arrs, n)
+		if err != nil {
+			return nil, err
+		}
+		buffers = append(buffers, offsets)
+		size := 0
+		for _, rng := range ranges {
+			size += rng[1] - rng[0]
+		}
+		values := memory.NewResizableBuffer(mem)
+		values.Resize(size)
+		pos := 0
+		for i, arr := range arrs {
+			if rng := ranges[i]; rng[1] > rng[0] {
+				pos += copy(values.Bytes()[pos:], arr.Data().Buffers()[2].Bytes()[rng[0]:rng[1]])
+			}
+		}
+		buffers = append(buffers, values)
+
+	case *arrow.ListType:
+		offsets, ranges, err := concatOffsets(mem, arrs, n)
+		if err != nil {
+			return nil, err
+		}
+		buffers = append(buffers, offsets)
+		slices := make([]Interface, len(arrs))
+		for i, arr := range arrs {
+			slices[i] = NewSlice(arr.(*List).ListValues(), int64(ranges[i][0]), int64(ranges[i][1]))
+		}
+		child, err := concatSlices(mem, dt.Elem(), slices)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.FixedSizeListType:
+		size := int64(dt.Len())
+		slices := make([]Interface, len(arrs))
+		for i, arr := range arrs {
+			off := int64(arr.Data().Offset())
+			slices[i] = NewSlice(arr.(*FixedSizeList).ListValues(), off*size, (off+int64(arr.Len()))*size)
+		}
+		child, err := concatSlices(mem, dt.Elem(), slices)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.StructType:
+		for i, field := range dt.Fields() {
+			// the fields of a struct are sliced as the struct is.
+			fields := make([]Interface, len(arrs))
+			for j, arr := range arrs {
+				fields[j] = arr.(*Struct).Field(i)
+			}
+			child, err := concatData(mem, field.Type, fields)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+
+	default:
+		return nil, xerrors.Errorf("arrow/array: concatenation of %v arrays not implemented", dt)
+	}
+
+	return NewData(dt, n, buffers, children, nulls, 0), nil
+}
+
+// concatSlices returns the data of the concatenation of slices, which are
+// released.
+func concatSlices(mem memory.Allocator, dt arrow.DataType, slices []Interface) (*Data, error) {
+	defer func() {
+		for _, slice := range slices {
+			slice.Release()
+		}
+	}()
+	return concatData(mem, dt, slices)
+}
+
+// concatDictionaries returns the data of the concatenation of the
+// dictionary arrays arrs. Arrays sharing the same dictionary values have
+// their indices concatenated, the dictionaries of the others are unified
+// and their indices transposed to the unified dictionary.
+func concatDictionaries(mem memory.Allocator, dt *arrow.DictionaryType, arrs []Interface) (*Data, error) {
+	dict := arrs[0].(*Dictionary).Dictionary()
+	indices := make([]Interface, len(arrs))
+	for i, arr := range arrs {
+		arr := arr.(*Dictionary)
+		if d := arr.Dictionary(); d.Data() != dict.Data() && !ArrayEqual(d, dict) {
+			return unifyDictionaries(mem, dt, arrs)
+		}
+		indices[i] = arr.Indices()
+	}
+	data, err := concatData(mem, dt.IndexType, indices)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return NewDataWithDictionary(dt, data.length, data.buffers, data.nulls, 0, dict.Data()), nil
+}
+
+// unifyDictionaries returns the data of the concatenation of the
+// dictionary arrays arrs, indexing the distinct values of their
+// dictionaries.
+func unifyDictionaries(mem memory.Allocator, dt *arrow.DictionaryType, arrs []Interface) (*Data, error) {
+	u, err := NewDictionaryUnifier(mem, dt.ValueType)
+	if err != nil {
+		return nil, err
+	}
+	defer u.Release()
+
+	transposes := make([][]int32, len(arrs))
+	for i, arr := range arrs {
+		transposes[i], err = u.Unify(arr.(*Dictionary).Dictionary())
+		if err != nil {
+			return nil, err
+		}
+	}
+	dict := u.NewDictionary()
+	defer dict.Release()
+
+	transposed := make([]Interface, len(arrs))
+	defer func() {
+		for _, arr := range transposed {
+			if arr != nil {
+				arr.Release()
+			}
+		}
+	}()
+	for i, arr := range arrs {
+		arr, err := TransposeDictionary(mem, arr.(*Dictionary), dt, dict, transposes[i])
+		if err != nil {
+			return nil, err
+		}
+		transposed[i] = arr
+	}
+	return concatDictionaries(mem, dt, transposed)
+}
+
+// maxIndices returns the number of values integers of the given kind and
+// width can index, up to 64 bits.
+func maxIndices(kind numericKind, width int) int {
+	if kind == signedKind {
+		width--
+	}
+	return 1 << uint(width)
+}
+
+// concatValidity returns the validity bitmap of the n values of arrs, or
+// nil when none of them is null.
+func concatValidity(mem memory.Allocator, arrs []Interface, n, nulls int) *memory.Buffer {
+	if nulls == 0 {
+		return nil
+	}
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(int(bitutil.BytesForBits(int64(n))))
+	out := buf.Bytes()
+	pos := 0
+	for _, arr := range arrs {
+		switch arr.NullN() {
+		case 0:
+			for i := 0; i < arr.Len(); i++ {
+				bitutil.SetBit(out, pos+i)
+			}
+		case arr.Len():
+			// the bits are already cleared.
+		default:
+			copyBits(arr.NullBitmapBytes(), arr.Data().Offset(), out, pos, arr.Len())
+		}
+		pos += arr.Len()
+	}
+	return buf
+}
+
+// concatOffsets returns the offsets of the n values of the offset-based
+// arrs, rebased to follow one another, along with the range of the child
+// values of each array. It fails when the offsets overflow 32 bits.
+func concatOffsets(mem memory.Allocator, arrs []Interface, n int) (*memory.Buffer, [][2]int, error) {
+	var total int64
+	for _, arr := range arrs {
+		if arr.Len() == 0 {
+			continue
+		}
+		data := arr.Data()
+		offsets := arrow.Int32Traits.CastFromBytes(data.Buffers()[1].Bytes())
+		total += int64(offsets[data.Offset()+data.Len()]) - int64(offsets[data.Offset()])
+	}
+	if total > math.MaxInt32 {
+		return nil, nil, xerrors.Errorf("arrow/array: concatenation of %d values overflows the 32-bit offsets of %v arrays", total, arrs[0].DataType())
+	}
+
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(arrow.Int32Traits.BytesRequired(n + 1))
+	out := arrow.Int32Traits.CastFromBytes(buf.Bytes())
+
+	ranges := make([][2]int, len(arrs))
+	pos, next := 0, int32(0)
+	for i, arr := range arrs {
+		if arr.Len() == 0 {
+			continue
+		}
+		data := arr.Data()
+		offsets := arrow.Int32Traits.CastFromBytes(data.Buffers()[1].Bytes())[data.Offset() : data.Offset()+data.Len()+1]
+		for j, o := range offsets[:len(offsets)-1] {
+			out[pos+j] = next + o - offsets[0]
+		}
+		ranges[i] = [2]int{int(offsets[0]), int(offsets[len(offsets)-1])}
+		next += offsets[len(offsets)-1] - offsets[0]
+		pos += arr.Len()
+	}
+	out[n] = next
+	return buf, ranges, nil
+}
+
+// copyBits copies the n bits of src starting at bit srcOffset to dst
+// starting at bit dstOffset.
+func copyBits(src []byte, srcOffset int, dst []byte, dstOffset, n int) {
+	for i := 0; i < n; i++ {
+		bitutil.SetBitTo(dst, dstOffset+i, bitutil.BitIsSet(src, srcOffset+i))
+	}
+}
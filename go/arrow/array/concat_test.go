@@ -0,0 +1,596 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func concatTable(mem memory.Allocator, fields []arrow.Field, values ...interface{}) array.Table {
+	cols := make([]array.Column, len(fields))
+	for i, f := range fields {
+		bldr := array.NewBuilder(mem, f.Type)
+		switch b := bldr.(type) {
+		case *array.Int8Builder:
+			b.AppendValues(values[i].([]int8), nil)
+		case *array.Int32Builder:
+			b.AppendValues(values[i].([]int32), nil)
+		case *array.Int64Builder:
+			b.AppendValues(values[i].([]int64), nil)
+		case *array.Uint8Builder:
+			b.AppendValues(values[i].([]uint8), nil)
+		case *array.Uint64Builder:
+			b.AppendValues(values[i].([]uint64), nil)
+		case *array.Float32Builder:
+			b.AppendValues(values[i].([]float32), nil)
+		case *array.StringBuilder:
+			b.AppendValues(values[i].([]string), nil)
+		case *array.NullBuilder:
+			for j := 0; j < values[i].(int); j++ {
+				b.AppendNull()
+			}
+		}
+		arr := bldr.NewArray()
+		chunked := array.NewChunked(f.Type, []array.Interface{arr})
+		cols[i] = *array.NewColumn(f, chunked)
+		chunked.Release()
+		arr.Release()
+		bldr.Release()
+	}
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	return array.NewTable(arrow.NewSchema(fields, nil), cols, -1)
+}
+
+func columnString(col *array.Column) string {
+	chunks := make([]string, len(col.Data().Chunks()))
+	for i, c := range col.Data().Chunks() {
+		chunks[i] = fmt.Sprint(c)
+	}
+	return strings.Join(chunks, " ")
+}
+
+func TestConcatenateTablesWithPromotion(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name   string
+		fields [2][]arrow.Field
+		values [2][]interface{}
+		want   []arrow.Field
+		cols   []string
+	}{
+		{
+			name: "missing column",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int32}},
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int32}, {Name: "b", Type: arrow.BinaryTypes.String}},
+			},
+			values: [2][]interface{}{{[]int32{1, 2}}, {[]int32{3}, []string{"x"}}},
+			want:   []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int32}, {Name: "b", Type: arrow.BinaryTypes.String, Nullable: true}},
+			cols:   []string{"[1 2] [3]", `[(null) (null)] ["x"]`},
+		},
+		{
+			name: "widen integers",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int32}},
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true}},
+			},
+			values: [2][]interface{}{{[]int32{-1, 2}}, {[]int64{1 << 40}}},
+			want:   []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true}},
+			cols:   []string{"[-1 2] [1099511627776]"},
+		},
+		{
+			name: "signed and unsigned",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.PrimitiveTypes.Uint8}},
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int8}},
+			},
+			values: [2][]interface{}{{[]uint8{255}}, {[]int8{-128}}},
+			want:   []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int16}},
+			cols:   []string{"[255] [-128]"},
+		},
+		{
+			name: "integers and floats",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.PrimitiveTypes.Float32}, {Name: "b", Type: arrow.PrimitiveTypes.Float32}},
+				{{Name: "b", Type: arrow.PrimitiveTypes.Int8}, {Name: "a", Type: arrow.PrimitiveTypes.Int32}},
+			},
+			values: [2][]interface{}{{[]float32{0.5}, []float32{1.5}}, {[]int8{3}, []int32{1 << 30}}},
+			want:   []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Float64}, {Name: "b", Type: arrow.PrimitiveTypes.Float32}},
+			cols:   []string{"[0.5] [1.073741824e+09]", "[1.5] [3]"},
+		},
+		{
+			name: "null type",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.Null}},
+				{{Name: "a", Type: arrow.BinaryTypes.String}},
+			},
+			values: [2][]interface{}{{2}, {[]string{"y"}}},
+			want:   []arrow.Field{{Name: "a", Type: arrow.BinaryTypes.String, Nullable: true}},
+			cols:   []string{`[(null) (null)] ["y"]`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t1 := concatTable(mem, tc.fields[0], tc.values[0]...)
+			defer t1.Release()
+			t2 := concatTable(mem, tc.fields[1], tc.values[1]...)
+			defer t2.Release()
+
+			tbl, err := array.ConcatenateTablesWithPromotion([]array.Table{t1, t2}, &array.ConcatenateOptions{Mem: mem})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tbl.Release()
+
+			if got, want := tbl.Schema(), arrow.NewSchema(tc.want, nil); !got.Equal(want) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, want)
+			}
+			if got, want := tbl.NumRows(), t1.NumRows()+t2.NumRows(); got != want {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+			}
+			for i, want := range tc.cols {
+				if got := columnString(tbl.Column(i)); got != want {
+					t.Errorf("invalid column %q: got=%s, want=%s", tbl.Column(i).Name(), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConcatenateTablesWithPromotionSlices(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fields := []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int32}}
+	tables := make([]array.Table, 3)
+	for i := range tables {
+		tables[i] = concatTable(mem, fields, []int32{int32(3 * i), int32(3*i + 1), int32(3*i + 2)})
+		defer tables[i].Release()
+	}
+
+	// the columns of a table may be longer than the table.
+	short := array.NewTable(tables[1].Schema(), []array.Column{*tables[1].Column(0)}, 2)
+	defer short.Release()
+
+	tbl, err := array.ConcatenateTablesWithPromotion([]array.Table{tables[2], short, tables[0]}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tbl.Release()
+	if got, want := columnString(tbl.Column(0)), "[6 7 8] [3 4] [0 1 2]"; got != want {
+		t.Fatalf("invalid column: got=%s, want=%s", got, want)
+	}
+	if got, want := tbl.NumRows(), int64(8); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+}
+
+func TestConcatenateTablesWithPromotionMetadata(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	t1 := concatTable(mem, []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int32, Metadata: arrow.NewMetadata([]string{"k1", "k2"}, []string{"a", "b"})}}, []int32{1})
+	defer t1.Release()
+	t2 := concatTable(mem, []arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int32, Metadata: arrow.NewMetadata([]string{"k2", "k3"}, []string{"c", "d"})}}, []int32{2})
+	defer t2.Release()
+
+	for _, tc := range []struct {
+		policy array.MetadataMergePolicy
+		want   arrow.Metadata
+	}{
+		{array.MetadataKeepFirst, arrow.NewMetadata([]string{"k1", "k2"}, []string{"a", "b"})},
+		{array.MetadataMerge, arrow.NewMetadata([]string{"k1", "k2", "k3"}, []string{"a", "c", "d"})},
+		{array.MetadataDrop, arrow.Metadata{}},
+	} {
+		tbl, err := array.ConcatenateTablesWithPromotion([]array.Table{t1, t2}, &array.ConcatenateOptions{FieldMetadata: tc.policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tbl.Schema().Field(0).Metadata; got.String() != tc.want.String() {
+			t.Errorf("policy %d: invalid metadata: got=%v, want=%v", tc.policy, got, tc.want)
+		}
+		tbl.Release()
+	}
+}
+
+func TestConcatenateTablesWithPromotionErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name   string
+		fields [2][]arrow.Field
+		values [2][]interface{}
+		err    string
+	}{
+		{
+			name: "string and integer",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int32}, {Name: "b", Type: arrow.BinaryTypes.String}},
+				{{Name: "b", Type: arrow.PrimitiveTypes.Int64}},
+			},
+			values: [2][]interface{}{{[]int32{1}, []string{"x"}}, {[]int64{2}}},
+			err:    `field "b"`,
+		},
+		{
+			name: "uint64 and signed",
+			fields: [2][]arrow.Field{
+				{{Name: "u", Type: arrow.PrimitiveTypes.Uint64}},
+				{{Name: "u", Type: arrow.PrimitiveTypes.Int8}},
+			},
+			values: [2][]interface{}{{[]uint64{1}}, {[]int8{2}}},
+			err:    `field "u"`,
+		},
+		{
+			name: "duplicate field",
+			fields: [2][]arrow.Field{
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int32}, {Name: "a", Type: arrow.PrimitiveTypes.Int32}},
+				{{Name: "a", Type: arrow.PrimitiveTypes.Int32}},
+			},
+			values: [2][]interface{}{{[]int32{1}, []int32{1}}, {[]int32{2}}},
+			err:    `duplicate field "a"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t1 := concatTable(mem, tc.fields[0], tc.values[0]...)
+			defer t1.Release()
+			t2 := concatTable(mem, tc.fields[1], tc.values[1]...)
+			defer t2.Release()
+
+			_, err := array.ConcatenateTablesWithPromotion([]array.Table{t1, t2}, &array.ConcatenateOptions{Mem: mem})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%s", err, tc.err)
+			}
+		})
+	}
+
+	if _, err := array.ConcatenateTablesWithPromotion(nil, nil); err == nil {
+		t.Fatalf("concatenating no tables should fail")
+	}
+}
+
+func TestConcatenate(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	valid := []bool{true, false, true, true, false, true, true}
+	build := func(dt arrow.DataType) array.Interface {
+		switch dt.ID() {
+		case arrow.NULL:
+			return array.NewNull(len(valid))
+		case arrow.BOOL:
+			b := array.NewBooleanBuilder(mem)
+			defer b.Release()
+			b.AppendValues([]bool{true, false, false, true, true, false, true}, valid)
+			return b.NewArray()
+		case arrow.INT32:
+			b := array.NewInt32Builder(mem)
+			defer b.Release()
+			b.AppendValues([]int32{1, 2, 3, 4, 5, 6, 7}, valid)
+			return b.NewArray()
+		case arrow.STRING:
+			b := array.NewStringBuilder(mem)
+			defer b.Release()
+			b.AppendValues([]string{"a", "", "bc", "def", "", "g", "hi"}, valid)
+			return b.NewArray()
+		case arrow.LIST:
+			b := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int32)
+			defer b.Release()
+			vb := b.ValueBuilder().(*array.Int32Builder)
+			for i, ok := range valid {
+				b.Append(ok)
+				for j := 0; ok && j < i%3; j++ {
+					vb.Append(int32(10*i + j))
+				}
+			}
+			return b.NewArray()
+		case arrow.FIXED_SIZE_LIST:
+			b := array.NewFixedSizeListBuilder(mem, 2, arrow.PrimitiveTypes.Int32)
+			defer b.Release()
+			vb := b.ValueBuilder().(*array.Int32Builder)
+			for i, ok := range valid {
+				b.Append(ok)
+				vb.AppendValues([]int32{int32(i), int32(-i)}, nil)
+			}
+			return b.NewArray()
+		case arrow.STRUCT:
+			b := array.NewStructBuilder(mem, dt.(*arrow.StructType))
+			defer b.Release()
+			for i, ok := range valid {
+				b.Append(ok)
+				b.FieldBuilder(0).(*array.Int32Builder).Append(int32(i))
+				b.FieldBuilder(1).(*array.StringBuilder).Append(fmt.Sprint(i))
+			}
+			return b.NewArray()
+		case arrow.DICTIONARY:
+			b := array.NewInt8Builder(mem)
+			defer b.Release()
+			b.AppendValues([]int8{0, 1, 2, 0, 1, 2, 0}, valid)
+			indices := b.NewArray()
+			defer indices.Release()
+			sb := array.NewStringBuilder(mem)
+			defer sb.Release()
+			sb.AppendValues([]string{"x", "y", "z"}, nil)
+			dict := sb.NewArray()
+			defer dict.Release()
+			return array.NewDictionaryArray(dt.(*arrow.DictionaryType), indices, dict)
+		}
+		panic("unexpected type")
+	}
+
+	for _, dt := range []arrow.DataType{
+		arrow.Null,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.PrimitiveTypes.Int32,
+		arrow.BinaryTypes.String,
+		arrow.ListOf(arrow.PrimitiveTypes.Int32),
+		arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int32),
+		arrow.StructOf(
+			arrow.Field{Name: "i", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "s", Type: arrow.BinaryTypes.String},
+		),
+		&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String},
+	} {
+		t.Run(dt.Name(), func(t *testing.T) {
+			arr := build(dt)
+			defer arr.Release()
+
+			// slices starting off byte boundaries, along with an empty one.
+			var slices []array.Interface
+			for _, rng := range [][2]int64{{0, 3}, {3, 3}, {3, 5}, {5, 7}} {
+				slices = append(slices, array.NewSlice(arr, rng[0], rng[1]))
+			}
+			defer func() {
+				for _, s := range slices {
+					s.Release()
+				}
+			}()
+
+			got, err := array.Concatenate(slices, mem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if !array.ArrayEqual(got, arr) {
+				t.Fatalf("invalid concatenation:\ngot= %v\nwant=%v", got, arr)
+			}
+		})
+	}
+
+	t.Run("unified dictionaries", func(t *testing.T) {
+		dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+		dict := func(values []string, valid []bool, indices []int8, validIndices []bool) array.Interface {
+			ib := array.NewInt8Builder(mem)
+			defer ib.Release()
+			ib.AppendValues(indices, validIndices)
+			idx := ib.NewArray()
+			defer idx.Release()
+			sb := array.NewStringBuilder(mem)
+			defer sb.Release()
+			sb.AppendValues(values, valid)
+			d := sb.NewArray()
+			defer d.Release()
+			return array.NewDictionaryArray(dt, idx, d)
+		}
+		arrs := []array.Interface{
+			dict([]string{"x", "y", "z"}, nil, []int8{0, 1, 2, 0, 1, 2, 0}, valid),
+			dict([]string{"z", "", "w", "x"}, []bool{true, false, true, true}, []int8{3, 1, 0, 2, 2}, []bool{true, true, false, true, true}),
+			dict(nil, nil, nil, nil),
+			dict([]string{"y"}, nil, []int8{0, 0, 0}, nil),
+		}
+		defer func() {
+			for _, arr := range arrs {
+				arr.Release()
+			}
+		}()
+		// slices starting off byte boundaries.
+		slices := []array.Interface{
+			array.NewSlice(arrs[0], 3, 7),
+			array.NewSlice(arrs[1], 1, 5),
+			arrs[2],
+			array.NewSlice(arrs[3], 1, 3),
+		}
+		defer slices[0].Release()
+		defer slices[1].Release()
+		defer slices[3].Release()
+
+		got, err := array.Concatenate(slices, mem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+
+		var values []string
+		d := got.(*array.Dictionary)
+		for i := 0; i < d.Len(); i++ {
+			switch {
+			case d.IsNull(i):
+				values = append(values, "(null)")
+			case d.Dictionary().IsNull(d.GetValueIndex(i)):
+				values = append(values, "(null value)")
+			default:
+				values = append(values, d.Dictionary().(*array.String).Value(d.GetValueIndex(i)))
+			}
+		}
+		want := "x (null) z x (null value) (null) w w y y"
+		if got := strings.Join(values, " "); got != want {
+			t.Fatalf("invalid values: got=%q, want=%q", got, want)
+		}
+		if got, want := d.Dictionary().Len(), 5; got != want {
+			t.Fatalf("invalid unified dictionary length: got=%d, want=%d: %v", got, want, d.Dictionary())
+		}
+	})
+
+	t.Run("dictionary indices overflow", func(t *testing.T) {
+		dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int32}
+		var arrs []array.Interface
+		for i := 0; i < 2; i++ {
+			ib := array.NewInt8Builder(mem)
+			ib.Append(0)
+			idx := ib.NewArray()
+			ib.Release()
+			vb := array.NewInt32Builder(mem)
+			for j := 0; j < 100; j++ {
+				vb.Append(int32(100*i + j))
+			}
+			d := vb.NewArray()
+			vb.Release()
+			arrs = append(arrs, array.NewDictionaryArray(dt, idx, d))
+			idx.Release()
+			d.Release()
+		}
+		defer arrs[0].Release()
+		defer arrs[1].Release()
+
+		if _, err := array.Concatenate(arrs, mem); err == nil || !strings.Contains(err.Error(), "overflow") {
+			t.Fatalf("expected an overflow error, got %v", err)
+		}
+	})
+
+	t.Run("offsets overflow", func(t *testing.T) {
+		// offsets spanning 1GiB of values, which are not read.
+		offsets := memory.NewBufferBytes(arrow.Int32Traits.CastToBytes([]int32{0, 1 << 30}))
+		values := memory.NewBufferBytes([]byte("x"))
+		data := array.NewData(arrow.BinaryTypes.Binary, 1, []*memory.Buffer{nil, offsets, values}, nil, 0, 0)
+		defer data.Release()
+		arr := array.NewBinaryData(data)
+		defer arr.Release()
+
+		if _, err := array.Concatenate([]array.Interface{arr, arr}, mem); err == nil || !strings.Contains(err.Error(), "overflow") {
+			t.Fatalf("expected an overflow error, got %v", err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := array.Concatenate(nil, mem); err == nil {
+			t.Fatal("expected an error without arrays")
+		}
+
+		ints := build(arrow.PrimitiveTypes.Int32)
+		defer ints.Release()
+		strs := build(arrow.BinaryTypes.String)
+		defer strs.Release()
+		if _, err := array.Concatenate([]array.Interface{ints, strs}, mem); err == nil {
+			t.Fatal("expected an error for arrays of different types")
+		}
+	})
+}
+
+func TestConcatenateRecords(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			got, err := array.ConcatenateRecords(recs, mem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			var beg int64
+			for i, rec := range recs {
+				slice := got.NewSlice(beg, beg+rec.NumRows())
+				if !array.RecordEqual(slice, rec) {
+					t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", i, slice, rec)
+				}
+				slice.Release()
+				beg += rec.NumRows()
+			}
+			if beg != got.NumRows() {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got.NumRows(), beg)
+			}
+
+			// concatenating slices at odd offsets gives the record back.
+			var slices []array.Record
+			for beg := int64(0); beg < got.NumRows(); beg += 3 {
+				end := beg + 3
+				if end > got.NumRows() {
+					end = got.NumRows()
+				}
+				slices = append(slices, got.NewSlice(beg, end))
+			}
+			again, err := array.ConcatenateRecords(slices, mem)
+			for _, slice := range slices {
+				slice.Release()
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer again.Release()
+			if !array.RecordEqual(again, got) {
+				t.Fatalf("invalid concatenation of slices:\ngot= %v\nwant=%v", again, got)
+			}
+		})
+	}
+
+	t.Run("schema mismatch", func(t *testing.T) {
+		recs := []array.Record{arrdata.Records["primitives"][0], arrdata.Records["strings"][0]}
+		if _, err := array.ConcatenateRecords(recs, memory.NewGoAllocator()); err == nil {
+			t.Fatal("expected an error for records of different schemas")
+		}
+	})
+}
+
+func TestCombineChunks(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			tbl := array.NewTableFromRecords(recs[0].Schema(), recs)
+			defer tbl.Release()
+
+			got, err := array.CombineChunks(tbl, mem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got.NumRows() != tbl.NumRows() || !got.Schema().Equal(tbl.Schema()) {
+				t.Fatalf("invalid table: got %d rows of %v", got.NumRows(), got.Schema())
+			}
+			for i := 0; i < int(got.NumCols()); i++ {
+				chunks := got.Column(i).Data().Chunks()
+				if len(chunks) != 1 {
+					t.Fatalf("column %d has %d chunks", i, len(chunks))
+				}
+				var beg int64
+				for j, rec := range recs {
+					end := beg + rec.NumRows()
+					if !array.ArraySliceEqual(chunks[0], beg, end, rec.Column(i), 0, rec.NumRows()) {
+						t.Fatalf("column %d of records[%d] differs", i, j)
+					}
+					beg = end
+				}
+			}
+		})
+	}
+}
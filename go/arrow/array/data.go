@@ -26,13 +26,14 @@ import (
 
 // Data represents the memory and metadata of an Arrow array.
 type Data struct {
-	refCount  int64
-	dtype     arrow.DataType
-	nulls     int
-	offset    int
-	length    int
-	buffers   []*memory.Buffer // TODO(sgc): should this be an interface?
-	childData []*Data          // TODO(sgc): managed by ListArray, StructArray and UnionArray types
+	refCount   int64
+	dtype      arrow.DataType
+	nulls      int
+	offset     int
+	length     int
+	buffers    []*memory.Buffer // TODO(sgc): should this be an interface?
+	childData  []*Data          // TODO(sgc): managed by ListArray, StructArray and UnionArray types
+	dictionary *Data            // values of a dictionary-encoded array
 }
 
 // NewData creates a new Data.
@@ -60,6 +61,17 @@ func NewData(dtype arrow.DataType, length int, buffers []*memory.Buffer, childDa
 	}
 }
 
+// NewDataWithDictionary creates a new Data for a dictionary-encoded array,
+// where buffers hold the indices into the values of dict.
+func NewDataWithDictionary(dtype arrow.DataType, length int, buffers []*memory.Buffer, nulls, offset int, dict *Data) *Data {
+	data := NewData(dtype, length, buffers, nil, nulls, offset)
+	if dict != nil {
+		dict.Retain()
+	}
+	data.dictionary = dict
+	return data
+}
+
 // Reset sets the Data for re-use.
 func (d *Data) Reset(dtype arrow.DataType, length int, buffers []*memory.Buffer, childData []*Data, nulls, offset int) {
 	// Retain new buffers before releasing existing buffers in-case they're the same ones to prevent accidental premature
@@ -90,6 +102,11 @@ func (d *Data) Reset(dtype arrow.DataType, length int, buffers []*memory.Buffer,
 	}
 	d.childData = childData
 
+	if d.dictionary != nil {
+		d.dictionary.Release()
+		d.dictionary = nil
+	}
+
 	d.dtype = dtype
 	d.length = length
 	d.nulls = nulls
@@ -118,7 +135,10 @@ func (d *Data) Release() {
 		for _, b := range d.childData {
 			b.Release()
 		}
-		d.buffers, d.childData = nil, nil
+		if d.dictionary != nil {
+			d.dictionary.Release()
+		}
+		d.buffers, d.childData, d.dictionary = nil, nil, nil
 	}
 }
 
@@ -137,15 +157,25 @@ func (d *Data) Offset() int { return d.offset }
 // Buffers returns the buffers.
 func (d *Data) Buffers() []*memory.Buffer { return d.buffers }
 
+// Dictionary returns the dictionary values of a dictionary-encoded array,
+// or nil.
+func (d *Data) Dictionary() *Data { return d.dictionary }
+
+// Children returns the data of the children of a nested array. The
+// offset and length of the parent apply to the children of structs.
+func (d *Data) Children() []*Data { return d.childData }
+
 // NewSliceData returns a new slice that shares backing data with the input.
 // The returned Data slice starts at i and extends j-i elements, such as:
-//    slice := data[i:j]
+//
+//	slice := data[i:j]
+//
 // The returned value must be Release'd after use.
 //
 // NewSliceData panics if the slice is outside the valid range of the input Data.
 // NewSliceData panics if j < i.
 func NewSliceData(data *Data, i, j int64) *Data {
-	if j > int64(data.length) || i > j || data.offset+int(i) > data.offset+data.length {
+	if i < 0 || j > int64(data.length) || i > j {
 		panic("arrow/array: index out of range")
 	}
 
@@ -161,14 +191,19 @@ func NewSliceData(data *Data, i, j int64) *Data {
 		}
 	}
 
+	if data.dictionary != nil {
+		data.dictionary.Retain()
+	}
+
 	o := &Data{
-		refCount:  1,
-		dtype:     data.dtype,
-		nulls:     UnknownNullCount,
-		length:    int(j - i),
-		offset:    data.offset + int(i),
-		buffers:   data.buffers,
-		childData: data.childData,
+		refCount:   1,
+		dtype:      data.dtype,
+		nulls:      UnknownNullCount,
+		length:     int(j - i),
+		offset:     data.offset + int(i),
+		buffers:    data.buffers,
+		childData:  data.childData,
+		dictionary: data.dictionary,
 	}
 
 	if data.nulls == 0 {
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"golang.org/x/xerrors"
+)
+
+// Dictionary represents an immutable sequence of dictionary-encoded values:
+// each slot holds an integer index into an array of dictionary values.
+type Dictionary struct {
+	array
+	indices Interface
+	dict    Interface
+}
+
+// NewDictionaryArray returns a new dictionary array of type typ, from the
+// indices and the dictionary values.
+//
+// NewDictionaryArray panics if the types of indices or dict do not match typ.
+func NewDictionaryArray(typ *arrow.DictionaryType, indices, dict Interface) *Dictionary {
+	if !arrow.TypeEqual(indices.DataType(), typ.IndexType) {
+		panic(xerrors.Errorf("arrow/array: invalid dictionary indices type %v (want=%v)", indices.DataType(), typ.IndexType))
+	}
+	if !arrow.TypeEqual(dict.DataType(), typ.ValueType) {
+		panic(xerrors.Errorf("arrow/array: invalid dictionary values type %v (want=%v)", dict.DataType(), typ.ValueType))
+	}
+
+	idx := indices.Data()
+	data := NewDataWithDictionary(typ, idx.length, idx.buffers, idx.nulls, idx.offset, dict.Data())
+	defer data.Release()
+	return NewDictionaryData(data)
+}
+
+// NewDictionaryData returns a new Dictionary array value, from data.
+func NewDictionaryData(data *Data) *Dictionary {
+	a := &Dictionary{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Indices returns the indices of the values in the dictionary.
+func (a *Dictionary) Indices() Interface { return a.indices }
+
+// Dictionary returns the dictionary values.
+func (a *Dictionary) Dictionary() Interface { return a.dict }
+
+// GetValueIndex returns the index in the dictionary of the i-th value.
+func (a *Dictionary) GetValueIndex(i int) int {
+	switch idx := a.indices.(type) {
+	case *Int8:
+		return int(idx.Value(i))
+	case *Uint8:
+		return int(idx.Value(i))
+	case *Int16:
+		return int(idx.Value(i))
+	case *Uint16:
+		return int(idx.Value(i))
+	case *Int32:
+		return int(idx.Value(i))
+	case *Uint32:
+		return int(idx.Value(i))
+	case *Int64:
+		return int(idx.Value(i))
+	case *Uint64:
+		return int(idx.Value(i))
+	}
+	panic(xerrors.Errorf("arrow/array: invalid dictionary indices type %v", a.indices.DataType()))
+}
+
+func (a *Dictionary) String() string {
+	return fmt.Sprintf("{ dictionary: %v\n  indices: %v }", a.dict, a.indices)
+}
+
+func (a *Dictionary) setData(data *Data) {
+	a.array.setData(data)
+	typ := data.dtype.(*arrow.DictionaryType)
+	idx := NewData(typ.IndexType, data.length, data.buffers, nil, data.nulls, data.offset)
+	defer idx.Release()
+	a.indices = MakeFromData(idx)
+	a.dict = MakeFromData(data.dictionary)
+}
+
+func arrayEqualDictionary(left, right *Dictionary) bool {
+	return ArrayEqual(left.indices, right.indices) && ArrayEqual(left.dict, right.dict)
+}
+
+func arrayApproxEqualDictionary(left, right *Dictionary, opt equalOption) bool {
+	return ArrayEqual(left.indices, right.indices) && arrayApproxEqual(left.dict, right.dict, opt)
+}
+
+func (a *Dictionary) Retain() {
+	a.array.Retain()
+	a.indices.Retain()
+	a.dict.Retain()
+}
+
+func (a *Dictionary) Release() {
+	a.array.Release()
+	a.indices.Release()
+	a.dict.Release()
+}
+
+var (
+	_ Interface = (*Dictionary)(nil)
+)
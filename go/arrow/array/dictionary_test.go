@@ -0,0 +1,98 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestDictionaryArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	sb := array.NewStringBuilder(pool)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "b", "c"}, nil)
+	dict := sb.NewArray()
+	defer dict.Release()
+
+	ib := array.NewInt8Builder(pool)
+	defer ib.Release()
+	ib.AppendValues([]int8{2, 0, 0, 1, 2}, []bool{true, true, false, true, true})
+	indices := ib.NewArray()
+	defer indices.Release()
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	arr := array.NewDictionaryArray(dt, indices, dict)
+	defer arr.Release()
+
+	arr.Retain()
+	arr.Release()
+
+	if got, want := arr.DataType().ID(), arrow.DICTIONARY; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	if got, want := arr.Len(), 5; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := arr.NullN(), 1; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if !array.ArrayEqual(arr.Indices(), indices) {
+		t.Fatalf("invalid indices: got=%v, want=%v", arr.Indices(), indices)
+	}
+	if !array.ArrayEqual(arr.Dictionary(), dict) {
+		t.Fatalf("invalid dictionary: got=%v, want=%v", arr.Dictionary(), dict)
+	}
+	for i, want := range []int{2, 0, 0, 1, 2} {
+		if i == 2 {
+			continue
+		}
+		if got := arr.GetValueIndex(i); got != want {
+			t.Fatalf("got[%d]=%d, want[%d]=%d", i, got, i, want)
+		}
+	}
+
+	sub := array.NewSlice(arr, 1, 4).(*array.Dictionary)
+	defer sub.Release()
+
+	if got, want := sub.Len(), 3; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := sub.GetValueIndex(2), 1; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if !sub.IsNull(1) {
+		t.Fatalf("slot 1 of the slice should be null")
+	}
+	if !array.ArrayEqual(sub.Dictionary(), dict) {
+		t.Fatalf("invalid dictionary: got=%v, want=%v", sub.Dictionary(), dict)
+	}
+
+	other := array.NewDictionaryArray(dt, indices, dict)
+	defer other.Release()
+	if !array.ArrayEqual(arr, other) {
+		t.Fatalf("dictionary arrays should be equal")
+	}
+	if array.ArraySliceEqual(arr, 0, 2, other, 1, 3) {
+		t.Fatalf("dictionary slices should differ")
+	}
+}
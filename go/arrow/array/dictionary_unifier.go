@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// DictionaryUnifier unifies the dictionaries of dictionary arrays of the
+// same value type, such as the dictionaries of a column read from several
+// streams, into a dictionary of their distinct values.
+//
+// The values of a dictionary missing from the unified dictionary are
+// appended to it, in order, and the null values of all the dictionaries
+// are unified into a single null value.
+type DictionaryUnifier struct {
+	dt    arrow.DataType
+	bldr  Builder
+	index map[interface{}]int32
+}
+
+// NewDictionaryUnifier returns a unifier of dictionaries of values of type
+// dt, which may not be a nested or dictionary type.
+func NewDictionaryUnifier(mem memory.Allocator, dt arrow.DataType) (*DictionaryUnifier, error) {
+	switch dt.ID() {
+	case arrow.LIST, arrow.FIXED_SIZE_LIST, arrow.STRUCT, arrow.DICTIONARY:
+		return nil, xerrors.Errorf("arrow/array: unification of dictionaries of %v values not implemented", dt)
+	}
+	return &DictionaryUnifier{dt: dt, bldr: NewBuilder(mem, dt), index: make(map[interface{}]int32)}, nil
+}
+
+// Unify adds the values of dict missing from the unified dictionary, and
+// returns the transpose map of dict: the index in the unified dictionary of
+// each value of dict.
+func (u *DictionaryUnifier) Unify(dict Interface) ([]int32, error) {
+	if !arrow.TypeEqual(dict.DataType(), u.dt) {
+		return nil, xerrors.Errorf("arrow/array: cannot unify a dictionary of %v values with %v values", dict.DataType(), u.dt)
+	}
+	transpose := make([]int32, dict.Len())
+	for i := range transpose {
+		v := goValue(dict, i)
+		key := v
+		if b, ok := v.([]byte); ok {
+			key = string(b)
+		}
+		j, ok := u.index[key]
+		if !ok {
+			j = int32(len(u.index))
+			u.index[key] = j
+			if err := appendGoValue(u.bldr, u.dt, v); err != nil {
+				return nil, err
+			}
+		}
+		transpose[i] = j
+	}
+	return transpose, nil
+}
+
+// Len returns the number of values of the unified dictionary.
+func (u *DictionaryUnifier) Len() int { return len(u.index) }
+
+// IndexType returns the integer type of the indices of the unified
+// dictionary: dt, or the narrowest wider integer type of the same
+// signedness when dt cannot index all of its values.
+func (u *DictionaryUnifier) IndexType(dt arrow.DataType) (arrow.DataType, error) {
+	kind, width := numericKindOf(dt)
+	if kind != signedKind && kind != unsignedKind {
+		return nil, xerrors.Errorf("arrow/array: invalid dictionary index type %v", dt)
+	}
+	for width < 64 && u.Len() > maxIndices(kind, width) {
+		width *= 2
+	}
+	return numericTypes[kind][width], nil
+}
+
+// NewDictionary returns the unified dictionary. The unifier must not be
+// used afterwards, but released.
+//
+// The returned array must be Release()'d after use.
+func (u *DictionaryUnifier) NewDictionary() Interface { return u.bldr.NewArray() }
+
+// Release releases the memory of the unifier.
+func (u *DictionaryUnifier) Release() { u.bldr.Release() }
+
+// TransposeDictionary returns a dictionary array of the values of arr, of
+// type dt, indexing dict whose indices are those of arr mapped with
+// transpose, as returned by DictionaryUnifier.Unify for the dictionary of
+// arr. The values are not decoded: the indices are rewritten to the index
+// type of dt, which must be able to hold them.
+//
+// The returned array must be Release()'d after use.
+func TransposeDictionary(mem memory.Allocator, arr *Dictionary, dt *arrow.DictionaryType, dict Interface, transpose []int32) (*Dictionary, error) {
+	if !arrow.TypeEqual(dict.DataType(), dt.ValueType) {
+		return nil, xerrors.Errorf("arrow/array: invalid dictionary values type %v (want=%v)", dict.DataType(), dt.ValueType)
+	}
+	if len(transpose) != arr.Dictionary().Len() {
+		return nil, xerrors.Errorf("arrow/array: transpose map of %d values for a dictionary of %d values", len(transpose), arr.Dictionary().Len())
+	}
+	if kind, width := numericKindOf(dt.IndexType); kind != signedKind && kind != unsignedKind {
+		return nil, xerrors.Errorf("arrow/array: invalid dictionary index type %v", dt.IndexType)
+	} else if width < 64 && dict.Len() > maxIndices(kind, width) {
+		return nil, xerrors.Errorf("arrow/array: %d dictionary values overflow the %v indices of %v", dict.Len(), dt.IndexType, dt)
+	}
+
+	bldr := NewBuilder(mem, dt.IndexType)
+	defer bldr.Release()
+	bldr.Reserve(arr.Len())
+	add := numericAppender(bldr)
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			bldr.AppendNull()
+			continue
+		}
+		idx := transpose[arr.GetValueIndex(i)]
+		add(int64(idx), uint64(idx), float64(idx))
+	}
+	indices := bldr.NewArray()
+	defer indices.Release()
+	return NewDictionaryArray(dt, indices, dict), nil
+}
@@ -0,0 +1,241 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestDictionaryUnifier(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	strs := func(vs []string, valid []bool) array.Interface {
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vs, valid)
+		return b.NewArray()
+	}
+	ints := func(vs []int64, valid []bool) array.Interface {
+		b := array.NewInt64Builder(mem)
+		defer b.Release()
+		b.AppendValues(vs, valid)
+		return b.NewArray()
+	}
+	bins := func(vs [][]byte) array.Interface {
+		b := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+		defer b.Release()
+		b.AppendValues(vs, nil)
+		return b.NewArray()
+	}
+
+	for _, tc := range []struct {
+		name       string
+		dicts      []array.Interface
+		transposes [][]int32
+		want       string
+	}{
+		{
+			name: "duplicates",
+			dicts: []array.Interface{
+				strs([]string{"a", "b", "c"}, nil),
+				strs([]string{"c", "d", "a"}, nil),
+				strs([]string{"d"}, nil),
+			},
+			transposes: [][]int32{{0, 1, 2}, {2, 3, 0}, {3}},
+			want:       `["a" "b" "c" "d"]`,
+		},
+		{
+			name: "nulls",
+			dicts: []array.Interface{
+				strs([]string{"a", ""}, []bool{true, false}),
+				strs([]string{"", "b", ""}, []bool{false, true, false}),
+			},
+			transposes: [][]int32{{0, 1}, {1, 2, 1}},
+			want:       `["a" (null) "b"]`,
+		},
+		{
+			name: "empty",
+			dicts: []array.Interface{
+				strs(nil, nil),
+				strs([]string{"a", ""}, nil),
+				strs(nil, nil),
+			},
+			transposes: [][]int32{{}, {0, 1}, {}},
+			want:       `["a" ""]`,
+		},
+		{
+			name: "numeric",
+			dicts: []array.Interface{
+				ints([]int64{3, 1, 0}, []bool{true, true, false}),
+				ints([]int64{0, 1, 2}, nil),
+			},
+			transposes: [][]int32{{0, 1, 2}, {3, 1, 4}},
+			want:       `[3 1 (null) 0 2]`,
+		},
+		{
+			name: "binary",
+			dicts: []array.Interface{
+				bins([][]byte{[]byte("x"), []byte("y")}),
+				bins([][]byte{[]byte("y"), []byte("z")}),
+			},
+			transposes: [][]int32{{0, 1}, {1, 2}},
+			want:       `["x" "y" "z"]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				for _, d := range tc.dicts {
+					d.Release()
+				}
+			}()
+
+			u, err := array.NewDictionaryUnifier(mem, tc.dicts[0].DataType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer u.Release()
+
+			for i, d := range tc.dicts {
+				transpose, err := u.Unify(d)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(transpose, tc.transposes[i]) {
+					t.Fatalf("invalid transpose map of dictionary %d: got=%v, want=%v", i, transpose, tc.transposes[i])
+				}
+			}
+			dict := u.NewDictionary()
+			defer dict.Release()
+			if got := fmt.Sprint(dict); got != tc.want {
+				t.Fatalf("invalid unified dictionary: got=%s, want=%s", got, tc.want)
+			}
+			if u.Len() != dict.Len() {
+				t.Fatalf("invalid length: got=%d, want=%d", u.Len(), dict.Len())
+			}
+		})
+	}
+
+	t.Run("invalid types", func(t *testing.T) {
+		if _, err := array.NewDictionaryUnifier(mem, arrow.ListOf(arrow.PrimitiveTypes.Int8)); err == nil {
+			t.Fatal("expected an error for list values")
+		}
+
+		u, err := array.NewDictionaryUnifier(mem, arrow.BinaryTypes.String)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer u.Release()
+		d := ints([]int64{1}, nil)
+		defer d.Release()
+		if _, err := u.Unify(d); err == nil {
+			t.Fatal("expected an error for a dictionary of another type")
+		}
+	})
+}
+
+func TestTransposeDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// two dictionaries of 100 values, 50 of them shared, indexed by int8.
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Int32}
+	var arrs []*array.Dictionary
+	for i := 0; i < 2; i++ {
+		ib := array.NewInt8Builder(mem)
+		ib.AppendValues([]int8{0, 99, 0, 50}, []bool{true, true, false, true})
+		indices := ib.NewArray()
+		ib.Release()
+		vb := array.NewInt32Builder(mem)
+		for j := 0; j < 100; j++ {
+			vb.Append(int32(50*i + j))
+		}
+		values := vb.NewArray()
+		vb.Release()
+		arrs = append(arrs, array.NewDictionaryArray(dt, indices, values))
+		indices.Release()
+		values.Release()
+	}
+	defer arrs[0].Release()
+	defer arrs[1].Release()
+
+	u, err := array.NewDictionaryUnifier(mem, dt.ValueType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.Release()
+	var transposes [][]int32
+	for _, arr := range arrs {
+		transpose, err := u.Unify(arr.Dictionary())
+		if err != nil {
+			t.Fatal(err)
+		}
+		transposes = append(transposes, transpose)
+	}
+
+	if u.Len() != 150 {
+		t.Fatalf("invalid number of unified values: got=%d, want=150", u.Len())
+	}
+	it, err := u.IndexType(dt.IndexType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !arrow.TypeEqual(it, arrow.PrimitiveTypes.Int16) {
+		t.Fatalf("invalid index type: got=%v, want=int16", it)
+	}
+	if it, _ := u.IndexType(arrow.PrimitiveTypes.Uint8); !arrow.TypeEqual(it, arrow.PrimitiveTypes.Uint8) {
+		t.Fatalf("invalid index type: got=%v, want=uint8", it)
+	}
+
+	dict := u.NewDictionary()
+	defer dict.Release()
+
+	if _, err := array.TransposeDictionary(mem, arrs[0], dt, dict, transposes[0]); err == nil {
+		t.Fatal("expected an error for overflowing int8 indices")
+	}
+
+	unified := &arrow.DictionaryType{IndexType: it, ValueType: dt.ValueType}
+	for i, arr := range arrs {
+		got, err := array.TransposeDictionary(mem, arr, unified, dict, transposes[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+
+		if !arrow.TypeEqual(got.DataType(), unified) || got.Len() != arr.Len() {
+			t.Fatalf("invalid transposed array %d: %v", i, got)
+		}
+		for j := 0; j < arr.Len(); j++ {
+			if got.IsNull(j) != arr.IsNull(j) {
+				t.Fatalf("invalid validity of array %d at %d", i, j)
+			}
+			if arr.IsNull(j) {
+				continue
+			}
+			want := arr.Dictionary().(*array.Int32).Value(arr.GetValueIndex(j))
+			if v := got.Dictionary().(*array.Int32).Value(got.GetValueIndex(j)); v != want {
+				t.Fatalf("invalid value of array %d at %d: got=%d, want=%d", i, j, v, want)
+			}
+		}
+	}
+}
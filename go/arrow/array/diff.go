@@ -0,0 +1,173 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// RecordDifference is the difference between two records, as reported by
+// RecordDiff.
+type RecordDifference struct {
+	// Schema are the differences between the schemas of the records.
+	Schema arrow.Diffs
+	// LeftRows and RightRows are the number of rows of the records.
+	LeftRows, RightRows int64
+	// Row and Column locate the first value differing, in the order of
+	// the rows then of the columns, or are -1 when the rows the records
+	// have in common are equal. The values are not compared when their
+	// schemas differ in more than the metadata or nullability of fields.
+	Row    int64
+	Column int
+	// Left and Right are the values differing.
+	Left, Right string
+}
+
+// Equal returns whether the records are equal.
+func (d RecordDifference) Equal() bool {
+	return d.Schema.Equal() && d.LeftRows == d.RightRows && d.Row < 0
+}
+
+func (d RecordDifference) String() string {
+	if d.Equal() {
+		return "records are equal"
+	}
+	o := new(strings.Builder)
+	if !d.Schema.Equal() {
+		fmt.Fprintf(o, "schemas differ:\n%v\n", d.Schema)
+	}
+	if d.LeftRows != d.RightRows {
+		fmt.Fprintf(o, "number of rows differ: %d != %d\n", d.LeftRows, d.RightRows)
+	}
+	if d.Row >= 0 {
+		fmt.Fprintf(o, "row %d, column %d differ: %s != %s\n", d.Row, d.Column, d.Left, d.Right)
+	}
+	return strings.TrimSuffix(o.String(), "\n")
+}
+
+type diffOption struct {
+	schema []arrow.DiffOption
+	approx bool
+	eq     equalOption
+}
+
+// DiffOption is a functional option type used to configure RecordDiff.
+type DiffOption func(*diffOption)
+
+// WithSchemaOptions configures the comparison of the schemas of the records
+// with opts, see arrow.SchemaDiff.
+func WithSchemaOptions(opts ...arrow.DiffOption) DiffOption {
+	return func(o *diffOption) {
+		o.schema = append(o.schema, opts...)
+	}
+}
+
+// WithApproxEqual compares the floating point values approximately, as
+// RecordApproxEqual configured with opts.
+func WithApproxEqual(opts ...EqualOption) DiffOption {
+	return func(o *diffOption) {
+		o.approx = true
+		o.eq = newEqualOption(opts...)
+	}
+}
+
+// RecordDiff returns the difference between the left and right records:
+// the differences of their schemas, of their number of rows, and the first
+// value differing.
+func RecordDiff(left, right Record, opts ...DiffOption) RecordDifference {
+	var cfg diffOption
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	d := RecordDifference{
+		Schema:    arrow.SchemaDiff(left.Schema(), right.Schema(), cfg.schema...),
+		LeftRows:  left.NumRows(),
+		RightRows: right.NumRows(),
+		Row:       -1,
+		Column:    -1,
+	}
+	for _, sd := range d.Schema {
+		if sd.Kind != arrow.DiffMetadata && sd.Kind != arrow.DiffNullable {
+			return d
+		}
+	}
+
+	rows := d.LeftRows
+	if d.RightRows < rows {
+		rows = d.RightRows
+	}
+	for i := range left.Columns() {
+		row := cfg.firstDiff(left.Column(i), right.Column(i), rows)
+		if row < 0 || (d.Row >= 0 && row >= d.Row) {
+			continue
+		}
+		d.Row, d.Column = row, i
+	}
+	if d.Row >= 0 {
+		d.Left = valueString(left.Column(d.Column), d.Row)
+		d.Right = valueString(right.Column(d.Column), d.Row)
+	}
+	return d
+}
+
+// firstDiff returns the first of the n first rows of left and right
+// differing, or -1 when they are equal.
+func (cfg diffOption) firstDiff(left, right Interface, n int64) int64 {
+	if cfg.equal(left, right, n) {
+		return -1
+	}
+	// the first n rows differ: bisect the length of the longest prefix
+	// equal, lo rows being equal and hi rows not.
+	lo, hi := int64(0), n
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if cfg.equal(left, right, mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// equal returns whether the n first rows of left and right are equal.
+func (cfg diffOption) equal(left, right Interface, n int64) bool {
+	l := NewSlice(left, 0, n)
+	defer l.Release()
+	r := NewSlice(right, 0, n)
+	defer r.Release()
+
+	if cfg.approx {
+		return arrayApproxEqual(l, r, cfg.eq)
+	}
+	return ArrayEqual(l, r)
+}
+
+// valueString returns the value of arr at i, the value in the dictionary
+// for dictionary arrays.
+func valueString(arr Interface, i int64) string {
+	if dict, ok := arr.(*Dictionary); ok && dict.IsValid(int(i)) {
+		return valueString(dict.Dictionary(), int64(dict.GetValueIndex(int(i))))
+	}
+	v := NewSlice(arr, i, i+1)
+	defer v.Release()
+	return strings.TrimSuffix(strings.TrimPrefix(fmt.Sprint(v), "["), "]")
+}
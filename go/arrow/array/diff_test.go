@@ -0,0 +1,212 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestRecordDiffRecords(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			if d := array.RecordDiff(recs[0], recs[0]); !d.Equal() {
+				t.Fatalf("identical records differ:\n%v", d)
+			}
+
+			left, right := recs[0], recs[1]
+			d := array.RecordDiff(left, right)
+			if d.Equal() != array.RecordEqual(left, right) {
+				t.Fatalf("diff is inconsistent with RecordEqual:\n%v", d)
+			}
+			if d.Row < 0 {
+				return
+			}
+			// the rows before d.Row are equal, as are the columns before
+			// d.Column at d.Row.
+			for i := range left.Columns() {
+				end := d.Row
+				if i < d.Column {
+					end++
+				}
+				if !array.ArraySliceEqual(left.Column(i), 0, end, right.Column(i), 0, end) {
+					t.Fatalf("column %d differs before row %d", i, d.Row)
+				}
+			}
+			if array.ArraySliceEqual(left.Column(d.Column), d.Row, d.Row+1, right.Column(d.Column), d.Row, d.Row+1) {
+				t.Fatalf("row %d, column %d do not differ", d.Row, d.Column)
+			}
+		})
+	}
+}
+
+func TestRecordDiff(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var (
+		f64s = func(vs ...float64) array.Interface {
+			bldr := array.NewFloat64Builder(mem)
+			defer bldr.Release()
+			bldr.AppendValues(vs, nil)
+			return bldr.NewArray()
+		}
+		lists = func(vs ...[]int64) array.Interface {
+			bldr := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+			defer bldr.Release()
+			vb := bldr.ValueBuilder().(*array.Int64Builder)
+			for _, v := range vs {
+				bldr.Append(true)
+				vb.AppendValues(v, nil)
+			}
+			return bldr.NewArray()
+		}
+		structs = func(vs ...int64) array.Interface {
+			bldr := array.NewStructBuilder(mem, arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int64}))
+			defer bldr.Release()
+			for _, v := range vs {
+				bldr.Append(true)
+				bldr.FieldBuilder(0).(*array.Int64Builder).Append(v)
+			}
+			return bldr.NewArray()
+		}
+		dicts = func(indices ...int8) array.Interface {
+			ib := array.NewInt8Builder(mem)
+			defer ib.Release()
+			ib.AppendValues(indices, nil)
+			idx := ib.NewArray()
+			defer idx.Release()
+			vb := array.NewStringBuilder(mem)
+			defer vb.Release()
+			vb.AppendValues([]string{"a", "b"}, nil)
+			values := vb.NewArray()
+			defer values.Release()
+			return array.NewDictionaryArray(&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}, idx, values)
+		}
+		record = func(nullable bool, cols ...array.Interface) array.Record {
+			fields := make([]arrow.Field, len(cols))
+			for i, col := range cols {
+				fields[i] = arrow.Field{Name: fmt.Sprintf("c%d", i), Type: col.DataType(), Nullable: nullable}
+				defer col.Release()
+			}
+			return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(cols[0].Len()))
+		}
+	)
+
+	for _, tc := range []struct {
+		name        string
+		left, right array.Record
+		opts        []array.DiffOption
+		want        string
+	}{
+		{
+			name:  "equal",
+			left:  record(true, f64s(1, 2), lists([]int64{1}, nil)),
+			right: record(true, f64s(1, 2), lists([]int64{1}, nil)),
+			want:  "records are equal",
+		},
+		{
+			name:  "floats",
+			left:  record(true, f64s(1, 2, 3), f64s(1, 2, 3)),
+			right: record(true, f64s(1, 2, 3.001), f64s(1, 2.001, 3)),
+			want:  "row 1, column 1 differ: 2 != 2.001",
+		},
+		{
+			name:  "approx floats",
+			left:  record(true, f64s(1, 2, 3)),
+			right: record(true, f64s(1, 2, 3+1e-9)),
+			opts:  []array.DiffOption{array.WithApproxEqual()},
+			want:  "records are equal",
+		},
+		{
+			name:  "approx floats beyond tolerance",
+			left:  record(true, f64s(1, 2, 3)),
+			right: record(true, f64s(1, 2, 3.5)),
+			opts:  []array.DiffOption{array.WithApproxEqual(array.WithAbsTolerance(0.1))},
+			want:  "row 2, column 0 differ: 3 != 3.5",
+		},
+		{
+			name:  "approx NaNs",
+			left:  record(true, f64s(1, math.NaN())),
+			right: record(true, f64s(1, math.NaN())),
+			opts:  []array.DiffOption{array.WithApproxEqual(array.WithNaNsEqual(true))},
+			want:  "records are equal",
+		},
+		{
+			name:  "lists",
+			left:  record(true, lists([]int64{1, 2}, []int64{3}, nil)),
+			right: record(true, lists([]int64{1, 2}, []int64{3, 4}, nil)),
+			want:  "row 1, column 0 differ: [3] != [3 4]",
+		},
+		{
+			name:  "structs",
+			left:  record(true, structs(1, 2, 3)),
+			right: record(true, structs(1, 2, 4)),
+			want:  "row 2, column 0 differ: {[3]} != {[4]}",
+		},
+		{
+			name:  "dictionaries",
+			left:  record(true, dicts(0, 1, 1)),
+			right: record(true, dicts(0, 1, 0)),
+			want:  `row 2, column 0 differ: "b" != "a"`,
+		},
+		{
+			name:  "rows",
+			left:  record(true, f64s(1, 2, 3)),
+			right: record(true, f64s(1, 2)),
+			want:  "number of rows differ: 3 != 2",
+		},
+		{
+			name:  "schemas",
+			left:  record(true, f64s(1, 2)),
+			right: record(true, lists([]int64{1}, nil)),
+			want:  "schemas differ:\nc0: type: float64 != list<item: int64>",
+		},
+		{
+			name:  "nullability",
+			left:  record(true, f64s(1, 2)),
+			right: record(false, f64s(1, 3)),
+			want:  "schemas differ:\nc0: nullable: true != false\nrow 1, column 0 differ: 2 != 3",
+		},
+		{
+			name:  "ignored nullability",
+			left:  record(true, f64s(1, 2)),
+			right: record(false, f64s(1, 2)),
+			opts:  []array.DiffOption{array.WithSchemaOptions(arrow.IgnoreNullability())},
+			want:  "records are equal",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.left.Release()
+			defer tc.right.Release()
+
+			d := array.RecordDiff(tc.left, tc.right, tc.opts...)
+			if got := d.String(); got != tc.want {
+				t.Fatalf("invalid diff:\ngot:\n%s\nwant:\n%s", got, tc.want)
+			}
+			if d.Equal() != (tc.want == "records are equal") {
+				t.Fatalf("invalid equality: %v", d.Equal())
+			}
+		})
+	}
+}
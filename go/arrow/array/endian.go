@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/endian"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// SwapEndianArrayData returns a copy of data with the byte order of its
+// values reversed, as needed to read data written on a host of the other
+// endianness. Fixed width values, offsets and dictionary indices are
+// swapped, recursively through the children and the dictionary of data.
+// Validity bitmaps and byte-sized values are shared with data.
+//
+// The returned Data must be Release()'d after use.
+func SwapEndianArrayData(data *Data) (*Data, error) {
+	buffers := make([]*memory.Buffer, len(data.buffers))
+	copy(buffers, data.buffers)
+
+	swapped := make([]*memory.Buffer, 0, 1)
+	defer func() {
+		for _, buf := range swapped {
+			buf.Release()
+		}
+	}()
+	swap := func(i, byteWidth int) {
+		if i >= len(buffers) || buffers[i] == nil {
+			return
+		}
+		b := make([]byte, buffers[i].Len())
+		copy(b, buffers[i].Bytes())
+		endian.SwapBuffer(b, byteWidth)
+		buffers[i] = memory.NewBufferBytes(b)
+		swapped = append(swapped, buffers[i])
+	}
+
+	switch dt := data.dtype.(type) {
+	case *arrow.NullType, *arrow.BooleanType, *arrow.FixedSizeBinaryType,
+		*arrow.StructType, *arrow.FixedSizeListType:
+		// only the children, if any, hold multi-byte values.
+	case *arrow.BinaryType, *arrow.StringType, *arrow.ListType:
+		swap(1, arrow.Int32SizeBytes)
+	case *arrow.DayTimeIntervalType:
+		swap(1, arrow.Int32SizeBytes)
+	case *arrow.Decimal128Type:
+		swap(1, arrow.Decimal128SizeBytes)
+	case *arrow.Decimal256Type:
+		swap(1, arrow.Decimal256SizeBytes)
+	case arrow.BinaryViewDataType:
+		// only the length and, for the values out of line, the buffer
+		// index and offset of the views are integers.
+		if len(buffers) > 1 && buffers[1] != nil {
+			b := make([]byte, buffers[1].Len())
+			copy(b, buffers[1].Bytes())
+			for i := 0; i+arrow.ViewHeaderSizeBytes <= len(b); i += arrow.ViewHeaderSizeBytes {
+				v := b[i : i+arrow.ViewHeaderSizeBytes]
+				endian.SwapBuffer(v[:4], 4)
+				if int32(endian.Native.Uint32(v[:4])) > arrow.ViewInlineSize {
+					endian.SwapBuffer(v[8:], 4)
+				}
+			}
+			buffers[1] = memory.NewBufferBytes(b)
+			swapped = append(swapped, buffers[1])
+		}
+	case *arrow.DictionaryType:
+		swap(1, dt.IndexType.(arrow.FixedWidthDataType).BitWidth()/8)
+		if data.dictionary == nil {
+			return NewDataWithDictionary(dt, data.length, buffers, data.nulls, data.offset, nil), nil
+		}
+		dict, err := SwapEndianArrayData(data.dictionary)
+		if err != nil {
+			return nil, err
+		}
+		defer dict.Release()
+		return NewDataWithDictionary(dt, data.length, buffers, data.nulls, data.offset, dict), nil
+	case arrow.FixedWidthDataType:
+		swap(1, dt.BitWidth()/8)
+	default:
+		return nil, xerrors.Errorf("arrow/array: cannot swap the endianness of arrays of type %v", dt)
+	}
+
+	children := make([]*Data, 0, len(data.childData))
+	defer func() {
+		for _, child := range children {
+			child.Release()
+		}
+	}()
+	for _, child := range data.childData {
+		c, err := SwapEndianArrayData(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, c)
+	}
+
+	return NewData(data.dtype, data.length, buffers, children, data.nulls, data.offset), nil
+}
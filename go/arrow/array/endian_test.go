@@ -0,0 +1,217 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/endian"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestSwapEndianArrayData(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	makeInt32 := func() array.Interface {
+		bld := array.NewInt32Builder(mem)
+		defer bld.Release()
+		bld.AppendValues([]int32{0x01020304, -2, 0, 42}, []bool{true, true, false, true})
+		return bld.NewArray()
+	}
+
+	for _, tc := range []struct {
+		name string
+		arr  func() array.Interface
+	}{
+		{"int32", makeInt32},
+		{"sliced", func() array.Interface {
+			arr := makeInt32()
+			defer arr.Release()
+			return array.NewSlice(arr, 1, 4)
+		}},
+		{"float64", func() array.Interface {
+			bld := array.NewFloat64Builder(mem)
+			defer bld.Release()
+			bld.AppendValues([]float64{1.5, -2.25, 1e300}, nil)
+			return bld.NewArray()
+		}},
+		{"string", func() array.Interface {
+			bld := array.NewStringBuilder(mem)
+			defer bld.Release()
+			bld.AppendValues([]string{"a", "", "bcd"}, []bool{true, false, true})
+			return bld.NewArray()
+		}},
+		{"string-view", func() array.Interface {
+			bld := array.NewStringViewBuilder(mem)
+			defer bld.Release()
+			bld.AppendValues([]string{"a", "", "a value not inline"}, []bool{true, false, true})
+			return bld.NewArray()
+		}},
+		{"list", func() array.Interface {
+			bld := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int16)
+			defer bld.Release()
+			vb := bld.ValueBuilder().(*array.Int16Builder)
+			bld.Append(true)
+			vb.AppendValues([]int16{1, 2, 3}, nil)
+			bld.AppendNull()
+			bld.Append(true)
+			vb.AppendValues([]int16{0x0102}, nil)
+			return bld.NewArray()
+		}},
+		{"struct", func() array.Interface {
+			dtype := arrow.StructOf(
+				arrow.Field{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+				arrow.Field{Name: "dec", Type: &arrow.Decimal128Type{Precision: 20, Scale: 2}},
+			)
+			bld := array.NewStructBuilder(mem, dtype)
+			defer bld.Release()
+			bld.AppendValues([]bool{true, true})
+			bld.FieldBuilder(0).(*array.Int64Builder).AppendValues([]int64{1, -1}, nil)
+			bld.FieldBuilder(1).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.New(2, 1), decimal128.FromI64(-3)}, nil)
+			return bld.NewArray()
+		}},
+		{"day-time-interval", func() array.Interface {
+			bld := array.NewDayTimeIntervalBuilder(mem)
+			defer bld.Release()
+			bld.AppendValues([]arrow.DayTimeInterval{{Days: 1, Milliseconds: 2}, {Days: -3, Milliseconds: 4}}, nil)
+			return bld.NewArray()
+		}},
+		{"dictionary", func() array.Interface {
+			ib := array.NewInt16Builder(mem)
+			defer ib.Release()
+			ib.AppendValues([]int16{1, 0, 1, 1}, nil)
+			indices := ib.NewArray()
+			defer indices.Release()
+
+			sb := array.NewStringBuilder(mem)
+			defer sb.Release()
+			sb.AppendValues([]string{"foo", "bar"}, nil)
+			dict := sb.NewArray()
+			defer dict.Release()
+
+			dtype := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}
+			return array.NewDictionaryArray(dtype, indices, dict)
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.arr()
+			defer arr.Release()
+
+			once, err := array.SwapEndianArrayData(arr.Data())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer once.Release()
+
+			twice, err := array.SwapEndianArrayData(once)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer twice.Release()
+
+			got := array.MakeFromData(twice)
+			defer got.Release()
+
+			if !array.ArrayEqual(got, arr) {
+				t.Fatalf("double swap should be the identity:\ngot= %v\nwant=%v", got, arr)
+			}
+		})
+	}
+}
+
+func TestSwapEndianArrayDataValues(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	t.Run("int32", func(t *testing.T) {
+		bld := array.NewInt32Builder(mem)
+		defer bld.Release()
+		bld.AppendValues([]int32{0x01020304, -2}, nil)
+
+		arr := bld.NewInt32Array()
+		defer arr.Release()
+
+		data, err := array.SwapEndianArrayData(arr.Data())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer data.Release()
+
+		b := data.Buffers()[1].Bytes()
+		for i, want := range arr.Int32Values() {
+			if got := int32(endian.NonNative.Uint32(b[4*i:])); got != want {
+				t.Fatalf("value %d: got=%#x, want=%#x", i, got, want)
+			}
+		}
+		if got, want := arr.Value(0), int32(0x01020304); got != want {
+			t.Fatalf("input array should be left untouched: got=%#x, want=%#x", got, want)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		bld := array.NewStringBuilder(mem)
+		defer bld.Release()
+		bld.AppendValues([]string{"a", "bcd"}, nil)
+
+		arr := bld.NewStringArray()
+		defer arr.Release()
+
+		data, err := array.SwapEndianArrayData(arr.Data())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer data.Release()
+
+		b := data.Buffers()[1].Bytes()
+		for i, want := range []uint32{0, 1, 4} {
+			if got := endian.NonNative.Uint32(b[4*i:]); got != want {
+				t.Fatalf("offset %d: got=%d, want=%d", i, got, want)
+			}
+		}
+		if got, want := string(data.Buffers()[2].Bytes()[:4]), "abcd"; got != want {
+			t.Fatalf("invalid data: got=%q, want=%q", got, want)
+		}
+	})
+
+	t.Run("decimal128", func(t *testing.T) {
+		bld := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 38, Scale: 0})
+		defer bld.Release()
+		bld.Append(decimal128.New(0x0102030405060708, 0x090a0b0c0d0e0f10))
+
+		arr := bld.NewDecimal128Array()
+		defer arr.Release()
+
+		data, err := array.SwapEndianArrayData(arr.Data())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer data.Release()
+
+		// the 128-bit value is stored with its high word first.
+		b := data.Buffers()[1].Bytes()
+		if got, want := endian.NonNative.Uint64(b[0:]), uint64(0x0102030405060708); got != want {
+			t.Fatalf("invalid high word: got=%#x, want=%#x", got, want)
+		}
+		if got, want := endian.NonNative.Uint64(b[8:]), uint64(0x090a0b0c0d0e0f10); got != want {
+			t.Fatalf("invalid low word: got=%#x, want=%#x", got, want)
+		}
+	})
+}
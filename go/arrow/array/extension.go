@@ -0,0 +1,159 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// ExtensionArray is implemented by the arrays of extension types, which
+// embed ExtensionArrayBase.
+type ExtensionArray interface {
+	Interface
+	// ExtensionType returns the extension type of the array.
+	ExtensionType() arrow.ExtensionType
+	// Storage returns the array storing the values, of the storage type.
+	Storage() Interface
+
+	setData(data *Data)
+	extensionBase() *ExtensionArrayBase
+}
+
+// ExtensionArrayBase holds the storage of the arrays of extension types,
+// which embed it in the struct type returned by the ArrayType method of
+// their type.
+type ExtensionArrayBase struct {
+	array
+	storage Interface
+}
+
+// ExtensionType returns the extension type of the array.
+func (a *ExtensionArrayBase) ExtensionType() arrow.ExtensionType {
+	return a.array.data.dtype.(arrow.ExtensionType)
+}
+
+// Storage returns the array storing the values, of the storage type.
+func (a *ExtensionArrayBase) Storage() Interface { return a.storage }
+
+func (a *ExtensionArrayBase) String() string { return fmt.Sprint(a.storage) }
+
+func (a *ExtensionArrayBase) Retain() {
+	a.array.Retain()
+	a.storage.Retain()
+}
+
+func (a *ExtensionArrayBase) Release() {
+	a.array.Release()
+	a.storage.Release()
+}
+
+func (a *ExtensionArrayBase) extensionBase() *ExtensionArrayBase { return a }
+
+func (a *ExtensionArrayBase) setData(data *Data) {
+	dt, ok := data.dtype.(arrow.ExtensionType)
+	if !ok {
+		panic(fmt.Sprintf("arrow/array: data type %T is not an extension type", data.dtype))
+	}
+	a.array.setData(data)
+
+	storage := NewDataWithDictionary(dt.StorageType(), data.length, data.buffers, data.nulls, data.offset, data.dictionary)
+	storage.childData = data.childData
+	for _, child := range storage.childData {
+		child.Retain()
+	}
+	if a.storage != nil {
+		a.storage.Release()
+	}
+	a.storage = MakeFromData(storage)
+	storage.Release()
+}
+
+// NewExtensionData returns a new array of the extension type of data, as
+// a pointer to the array type of the extension type.
+func NewExtensionData(data *Data) ExtensionArray {
+	dt, ok := data.dtype.(arrow.ExtensionType)
+	if !ok {
+		panic(fmt.Sprintf("arrow/array: data type %T is not an extension type", data.dtype))
+	}
+	a, ok := reflect.New(dt.ArrayType()).Interface().(ExtensionArray)
+	if !ok {
+		panic(fmt.Errorf("arrow/array: array type %v of %s does not embed ExtensionArrayBase", dt.ArrayType(), dt.ExtensionName()))
+	}
+	a.extensionBase().refCount = 1
+	a.setData(data)
+	return a
+}
+
+// NewExtensionArrayWithStorage returns a new array of the extension type
+// dt, sharing the memory of storage, whose type must be the storage type
+// of dt.
+func NewExtensionArrayWithStorage(dt arrow.ExtensionType, storage Interface) ExtensionArray {
+	if !arrow.TypeEqual(dt.StorageType(), storage.DataType()) {
+		panic(fmt.Errorf("arrow/array: invalid storage type %v for extension type %s (want %v)", storage.DataType(), dt.ExtensionName(), dt.StorageType()))
+	}
+	sd := storage.Data()
+	data := NewDataWithDictionary(dt, sd.length, sd.buffers, sd.nulls, sd.offset, sd.dictionary)
+	data.childData = sd.childData
+	for _, child := range data.childData {
+		child.Retain()
+	}
+	defer data.Release()
+	return NewExtensionData(data)
+}
+
+// CustomExtensionBuilder is implemented by the extension types which
+// provide their own builder, returned by NewBuilder.
+type CustomExtensionBuilder interface {
+	NewBuilder(mem memory.Allocator) Builder
+}
+
+// ExtensionBuilder builds the arrays of an extension type by appending the
+// values to a builder of its storage type.
+type ExtensionBuilder struct {
+	Builder
+	dt arrow.ExtensionType
+}
+
+// NewExtensionBuilder returns a builder of arrays of the extension type dt.
+func NewExtensionBuilder(mem memory.Allocator, dt arrow.ExtensionType) *ExtensionBuilder {
+	return &ExtensionBuilder{Builder: NewBuilder(mem, dt.StorageType()), dt: dt}
+}
+
+// StorageBuilder returns the builder the values are appended to.
+func (b *ExtensionBuilder) StorageBuilder() Builder { return b.Builder }
+
+// NewArray creates a new array from the memory buffers used by the builder
+// and resets the builder so it can be used to build a new array.
+func (b *ExtensionBuilder) NewArray() Interface {
+	return b.NewExtensionArray()
+}
+
+// NewExtensionArray creates a new array from the memory buffers used by the
+// builder and resets the builder so it can be used to build a new array.
+func (b *ExtensionBuilder) NewExtensionArray() ExtensionArray {
+	storage := b.Builder.NewArray()
+	defer storage.Release()
+	return NewExtensionArrayWithStorage(b.dt, storage)
+}
+
+var (
+	_ Builder = (*ExtensionBuilder)(nil)
+)
@@ -0,0 +1,133 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// lengthType is an extension type of lengths in a unit, stored as float64.
+type lengthType struct {
+	arrow.ExtensionBase
+	unit string
+}
+
+func newLengthType(unit string) *lengthType {
+	return &lengthType{ExtensionBase: arrow.ExtensionBase{Storage: arrow.PrimitiveTypes.Float64}, unit: unit}
+}
+
+func (*lengthType) Name() string            { return "extension" }
+func (t *lengthType) String() string        { return "extension<test.length[" + t.unit + "]>" }
+func (*lengthType) ExtensionName() string   { return "test.length" }
+func (t *lengthType) Serialize() string     { return t.unit }
+func (*lengthType) ArrayType() reflect.Type { return reflect.TypeOf(lengthArray{}) }
+func (t *lengthType) ExtensionEquals(o arrow.ExtensionType) bool {
+	return t.unit == o.(*lengthType).unit
+}
+func (*lengthType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	if storage.ID() != arrow.FLOAT64 {
+		return nil, fmt.Errorf("invalid storage type %v", storage)
+	}
+	return newLengthType(data), nil
+}
+
+type lengthArray struct {
+	array.ExtensionArrayBase
+}
+
+func (a *lengthArray) Value(i int) float64 { return a.Storage().(*array.Float64).Value(i) }
+
+func TestExtensionArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dt := newLengthType("m")
+	b := array.NewBuilder(pool, dt).(*array.ExtensionBuilder)
+	defer b.Release()
+
+	b.StorageBuilder().(*array.Float64Builder).AppendValues([]float64{1, 2, 0, 4}, []bool{true, true, false, true})
+	arr := b.NewArray()
+	defer arr.Release()
+
+	got, ok := arr.(*lengthArray)
+	if !ok {
+		t.Fatalf("invalid array type %T", arr)
+	}
+	if !arrow.TypeEqual(got.DataType(), dt) || got.ExtensionType() != dt {
+		t.Fatalf("invalid data type %v", got.DataType())
+	}
+	if got, want := got.Len(), 4; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	if got, want := got.NullN(), 1; got != want {
+		t.Fatalf("invalid nulls: got=%d, want=%d", got, want)
+	}
+	if !got.IsNull(2) || got.Value(3) != 4 {
+		t.Fatalf("invalid values: %v", got)
+	}
+	if got, want := fmt.Sprint(got), "[1 2 (null) 4]"; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+
+	slice := array.NewSlice(arr, 1, 4).(*lengthArray)
+	defer slice.Release()
+	if got, want := fmt.Sprint(slice), "[2 (null) 4]"; got != want {
+		t.Fatalf("invalid slice: got=%q, want=%q", got, want)
+	}
+	if slice.Value(2) != 4 {
+		t.Fatalf("invalid slice value: got=%v", slice.Value(2))
+	}
+
+	same := array.NewExtensionArrayWithStorage(dt, got.Storage())
+	defer same.Release()
+	if !array.ArrayEqual(arr, same) {
+		t.Fatalf("arrays should be equal")
+	}
+
+	other := array.NewExtensionArrayWithStorage(newLengthType("cm"), got.Storage())
+	defer other.Release()
+	if array.ArrayEqual(arr, other) {
+		t.Fatalf("arrays of different extension types should differ")
+	}
+	if array.ArrayEqual(arr, got.Storage()) {
+		t.Fatalf("extension array should differ from its storage")
+	}
+}
+
+func TestExtensionArrayInvalidStorage(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	b := array.NewInt32Builder(pool)
+	defer b.Release()
+	b.Append(1)
+	storage := b.NewArray()
+	defer storage.Release()
+
+	defer func() {
+		if e := recover(); e == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	array.NewExtensionArrayWithStorage(newLengthType("m"), storage)
+}
@@ -18,6 +18,7 @@ package array
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"sync/atomic"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/apache/arrow/go/arrow/bitutil"
 	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
 )
 
 // FixedSizeList represents an immutable sequence of N array values.
@@ -161,6 +163,39 @@ func (b *FixedSizeListBuilder) AppendValues(valid []bool) {
 	b.builder.unsafeAppendBoolsToBitmap(valid, len(valid))
 }
 
+// AppendFlatValues appends len(valid) lists, or all the lists of values if
+// valid is empty, whose elements are the consecutive elements of the Go
+// slice values. values must hold the elements of every list, null ones
+// included, and be of the type taken by the AppendValues method of the
+// value builder, e.g. []int32 for int32 elements. The elements are
+// appended with a single AppendValues call.
+//
+// Nothing is appended if values does not hold exactly n elements per list.
+func (b *FixedSizeListBuilder) AppendFlatValues(values interface{}, valid []bool) error {
+	rv := reflect.ValueOf(values)
+	if rv.Kind() != reflect.Slice {
+		return xerrors.Errorf("arrow/array: invalid fixed size list values %T", values)
+	}
+
+	n := len(valid)
+	if n == 0 && b.n > 0 {
+		n = rv.Len() / int(b.n)
+	}
+	if rv.Len() != n*int(b.n) {
+		return xerrors.Errorf("arrow/array: %d values for %d lists of %d elements", rv.Len(), n, b.n)
+	}
+
+	fn := reflect.ValueOf(b.values).MethodByName("AppendValues")
+	if !fn.IsValid() || fn.Type().NumIn() != 2 || fn.Type().In(0) != rv.Type() || fn.Type().In(1) != reflect.TypeOf(valid) {
+		return xerrors.Errorf("arrow/array: cannot append %T to a list of %v", values, b.etype)
+	}
+	fn.Call([]reflect.Value{rv, reflect.Zero(fn.Type().In(1))})
+
+	b.Reserve(n)
+	b.builder.unsafeAppendBoolsToBitmap(valid, n)
+	return nil
+}
+
 func (b *FixedSizeListBuilder) unsafeAppend(v bool) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.length++
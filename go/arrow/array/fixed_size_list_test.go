@@ -213,3 +213,89 @@ func TestFixedSizeListArraySlice(t *testing.T) {
 		t.Fatalf("got=%q, want=%q", got, want)
 	}
 }
+
+func TestFixedSizeListBuilderAppendFlatValues(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	lb := array.NewFixedSizeListBuilder(pool, 2, arrow.PrimitiveTypes.Int32)
+	defer lb.Release()
+
+	if err := lb.AppendFlatValues([]int32{0, 1, 2, 3, 4, 5}, []bool{true, false, true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lb.AppendFlatValues([]int32{6, 7}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		values interface{}
+		valid  []bool
+	}{
+		{"too-few", []int32{0, 1, 2}, []bool{true, true}},
+		{"too-many", []int32{0, 1, 2}, []bool{true}},
+		{"partial-list", []int32{0, 1, 2}, nil},
+		{"invalid-type", []int64{0, 1}, nil},
+		{"not-a-slice", int32(1), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := lb.AppendFlatValues(tc.values, tc.valid); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	arr := lb.NewArray().(*array.FixedSizeList)
+	defer arr.Release()
+
+	if got, want := arr.String(), "[[0 1] (null) [4 5] [6 7]]"; got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
+	if got, want := arr.NullN(), 1; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := arr.ListValues().Len(), 8; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+}
+
+func BenchmarkFixedSizeListBuilder(b *testing.B) {
+	const (
+		n     = 8
+		lists = 1 << 12
+	)
+	values := make([]float64, n*lists)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	mem := memory.NewGoAllocator()
+
+	b.Run("flat", func(b *testing.B) {
+		lb := array.NewFixedSizeListBuilder(mem, n, arrow.PrimitiveTypes.Float64)
+		defer lb.Release()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := lb.AppendFlatValues(values, nil); err != nil {
+				b.Fatal(err)
+			}
+			lb.NewArray().Release()
+		}
+	})
+
+	b.Run("elements", func(b *testing.B) {
+		lb := array.NewFixedSizeListBuilder(mem, n, arrow.PrimitiveTypes.Float64)
+		defer lb.Release()
+		vb := lb.ValueBuilder().(*array.Float64Builder)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < lists; j++ {
+				lb.Append(true)
+				for _, v := range values[j*n : (j+1)*n] {
+					vb.Append(v)
+				}
+			}
+			lb.NewArray().Release()
+		}
+	})
+}
@@ -41,6 +41,14 @@ func (a *Float16) Value(i int) float16.Num { return a.values[i] }
 
 func (a *Float16) Values() []float16.Num { return a.values }
 
+// Float32Values returns the values of the array converted to float32s.
+// The values of null slots are unspecified.
+func (a *Float16) Float32Values() []float32 {
+	o := make([]float32, len(a.values))
+	float16.ToFloat32s(o, a.values)
+	return o
+}
+
 func (a *Float16) String() string {
 	o := new(strings.Builder)
 	o.WriteString("[")
@@ -75,7 +83,9 @@ func arrayEqualFloat16(left, right *Float16) bool {
 		if left.IsNull(i) {
 			continue
 		}
-		if left.Value(i) != right.Value(i) {
+		// compare by value, as for float32 arrays: NaNs are not equal to
+		// anything and both zeros are equal.
+		if left.Value(i).Float32() != right.Value(i).Float32() {
 			return false
 		}
 	}
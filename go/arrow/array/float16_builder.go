@@ -99,6 +99,24 @@ func (b *Float16Builder) AppendValues(v []float16.Num, valid []bool) {
 	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
 }
 
+// AppendFloat32Values converts the float32 values in the v slice to
+// half-precision values, rounded to the nearest value, and appends them.
+// The valid slice determines which values in v are valid (not null), as for
+// AppendValues.
+func (b *Float16Builder) AppendFloat32Values(v []float32, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	if len(v) == 0 {
+		return
+	}
+
+	b.Reserve(len(v))
+	float16.FromFloat32s(b.rawData[b.length:], v)
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
 func (b *Float16Builder) init(capacity int) {
 	b.builder.init(capacity)
 
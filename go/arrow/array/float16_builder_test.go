@@ -17,6 +17,7 @@
 package array_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/apache/arrow/go/arrow/array"
@@ -117,3 +118,46 @@ func TestFloat16Builder_Empty(t *testing.T) {
 	assert.Equal(t, want, a.Values())
 	a.Release()
 }
+
+func TestFloat16Builder_AppendFloat32Values(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ab := array.NewFloat16Builder(mem)
+	defer ab.Release()
+
+	ab.Append(float16.New(1))
+	ab.AppendFloat32Values([]float32{0.5, 65520, float32(math.Ldexp(1, -24)), 4}, []bool{true, true, true, false})
+	a := ab.NewFloat16Array()
+	defer a.Release()
+
+	assert.Equal(t, 5, a.Len())
+	assert.Equal(t, 1, a.NullN())
+	assert.Equal(t, []float32{1, 0.5, float32(math.Inf(1)), float32(math.Ldexp(1, -24))}, a.Float32Values()[:4])
+	assert.Equal(t, float32Values(a), a.Float32Values())
+	assert.True(t, a.IsNull(4))
+}
+
+func TestFloat16Equal(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	newArray := func(vs ...float64) *array.Float16 {
+		ab := array.NewFloat16Builder(mem)
+		defer ab.Release()
+		for _, v := range vs {
+			ab.Append(float16.FromFloat64(v))
+		}
+		return ab.NewFloat16Array()
+	}
+
+	zeros, negZeros := newArray(0, 1), newArray(math.Copysign(0, -1), 1)
+	defer zeros.Release()
+	defer negZeros.Release()
+	assert.True(t, array.ArrayEqual(zeros, negZeros), "zeros should be equal")
+
+	nans := newArray(math.NaN(), 1)
+	defer nans.Release()
+	assert.False(t, array.ArrayEqual(nans, nans), "NaNs should not be equal")
+	assert.True(t, array.ArrayApproxEqual(nans, nans, array.WithNaNsEqual(true)), "NaNs should be approximately equal")
+}
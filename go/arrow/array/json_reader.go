@@ -0,0 +1,511 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// JSONOption configures how JSON rows are decoded, see NewJSONReader, or
+// encoded, see NewJSONWriter.
+type JSONOption func(*jsonConfig)
+
+type jsonConfig struct {
+	mem             memory.Allocator
+	chunk           int
+	timestampLayout string
+	strict          bool
+
+	// options of the writer.
+	epoch          bool
+	binaryHex      bool
+	decimalStrings bool
+	nulls          bool
+}
+
+// WithJSONAllocator sets the allocator of the records.
+func WithJSONAllocator(mem memory.Allocator) JSONOption {
+	return func(cfg *jsonConfig) { cfg.mem = mem }
+}
+
+// WithJSONChunk sets the number of rows of the records. If n is zero or 1,
+// each row is a record. If n is negative, all the rows are read into one
+// record.
+func WithJSONChunk(n int) JSONOption {
+	return func(cfg *jsonConfig) { cfg.chunk = n }
+}
+
+// WithJSONTimestampLayout sets the layout of the strings decoded or encoded
+// as timestamps and defaults to time.RFC3339Nano. Timestamps without a time
+// zone are in the time zone of their type.
+func WithJSONTimestampLayout(layout string) JSONOption {
+	return func(cfg *jsonConfig) { cfg.timestampLayout = layout }
+}
+
+// WithJSONUnknownFields sets whether the keys of the JSON objects which are
+// not fields of the schema are an error, instead of being ignored.
+func WithJSONUnknownFields(reject bool) JSONOption {
+	return func(cfg *jsonConfig) { cfg.strict = reject }
+}
+
+func newJSONConfig(opts ...JSONOption) jsonConfig {
+	cfg := jsonConfig{
+		mem:             memory.DefaultAllocator,
+		chunk:           1,
+		timestampLayout: time.RFC3339Nano,
+		nulls:           true,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// JSONReader reads records from a stream of JSON objects, usually one per
+// line, each object being a row keyed by field names.
+//
+// The JSON values are converted to the types of the fields:
+//
+//	bool                        true or false
+//	integers, floats            numbers, which must fit in the type
+//	decimal, decimal256         numbers or strings
+//	string                      strings
+//	binary, fixed size binary   base64 encoded strings
+//	date32, date64              "2006-01-02" strings
+//	timestamp                   strings in the layout of the reader, or numbers in the unit of the type
+//	time32, time64              "15:04:05.999999999" strings, or numbers in the unit of the type
+//	duration                    strings parsed by time.ParseDuration, or numbers in the unit of the type
+//	month interval              numbers
+//	day-time interval           {"days": d, "milliseconds": ms} objects
+//	list, fixed size list       arrays
+//	struct                      objects
+//
+// Missing fields and null values are null.
+type JSONReader struct {
+	dec    *json.Decoder
+	schema *arrow.Schema
+	cfg    jsonConfig
+
+	refs int64
+	bld  *RecordBuilder
+	cur  Record
+	err  error
+
+	row  int // index of the next row in the stream.
+	done bool
+}
+
+// NewJSONReader returns a reader of the JSON rows of r, with the given
+// schema.
+//
+// NewJSONReader panics if a field of the schema has a type which cannot
+// be built, such as a dictionary.
+func NewJSONReader(r io.Reader, schema *arrow.Schema, opts ...JSONOption) *JSONReader {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	cfg := newJSONConfig(opts...)
+	return &JSONReader{
+		dec:    dec,
+		schema: schema,
+		cfg:    cfg,
+		refs:   1,
+		bld:    NewRecordBuilder(cfg.mem, schema),
+	}
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *JSONReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *JSONReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		r.bld.Release()
+	}
+}
+
+func (r *JSONReader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record. It is valid until the next call to
+// Next.
+func (r *JSONReader) Record() Record { return r.cur }
+
+// Err returns the error which stopped the reader, if any. Its message
+// holds the index of the invalid row, counted from zero, and the path of
+// the invalid value, e.g. "row 1234, field 'user.tags[2]': cannot convert
+// ...".
+func (r *JSONReader) Err() error { return r.err }
+
+// Next returns whether a record could be read. The rows read before an
+// invalid row make up the last record.
+func (r *JSONReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+	if r.err != nil || r.done {
+		return false
+	}
+
+	n := 0
+	for ; r.cfg.chunk < 0 || n < max(r.cfg.chunk, 1); n++ {
+		var v interface{}
+		if err := r.dec.Decode(&v); err != nil {
+			r.done = true
+			if err != io.EOF {
+				r.err = xerrors.Errorf("arrow/array: row %d: %w", r.row, err)
+			}
+			break
+		}
+		if err := appendJSONRow(r.bld, v, r.row, &r.cfg); err != nil {
+			r.err = err
+			break
+		}
+		r.row++
+	}
+
+	if n == 0 {
+		return false
+	}
+	r.cur = r.bld.NewRecord()
+	return true
+}
+
+// UnmarshalJSON appends the rows of the JSON objects of data, usually one
+// per line, or of JSON arrays of objects, as written by JSONWriter, as a
+// JSONReader with default options would. The rows before an invalid row are
+// appended.
+func (b *RecordBuilder) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	cfg := newJSONConfig()
+	for row := 0; ; {
+		var v interface{}
+		switch err := dec.Decode(&v); err {
+		case nil:
+		case io.EOF:
+			return nil
+		default:
+			return xerrors.Errorf("arrow/array: row %d: %w", row, err)
+		}
+		rows, ok := v.([]interface{})
+		if !ok {
+			rows = []interface{}{v}
+		}
+		for _, v := range rows {
+			if err := appendJSONRow(b, v, row, &cfg); err != nil {
+				return err
+			}
+			row++
+		}
+	}
+}
+
+// appendJSONRow appends the JSON object v to b, or nothing if any of its
+// values cannot be converted.
+func appendJSONRow(b *RecordBuilder, v interface{}, row int, cfg *jsonConfig) error {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return xerrors.Errorf("arrow/array: row %d: cannot convert %s to a row", row, jsonString(v))
+	}
+	if cfg.strict {
+		for name := range obj {
+			if !b.schema.HasField(name) {
+				return xerrors.Errorf("arrow/array: row %d, field '%s': unknown field", row, name)
+			}
+		}
+	}
+
+	fields := b.schema.Fields()
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		x, err := jsonGoValue(obj[f.Name], f.Type, f.Name, cfg)
+		if err != nil {
+			return xerrors.Errorf("arrow/array: row %d, %v", row, err)
+		}
+		values[i] = x
+	}
+	for i, f := range fields {
+		if err := appendGoValue(b.Field(i), f.Type, values[i]); err != nil {
+			// the values are checked by jsonGoValue.
+			panic(xerrors.Errorf("arrow/array: row %d, field '%s': %w", row, f.Name, err))
+		}
+	}
+	return nil
+}
+
+// jsonGoValue converts the decoded JSON value v of the field at path to the
+// Go value appendGoValue takes for type dt.
+func jsonGoValue(v interface{}, dt arrow.DataType, path string, cfg *jsonConfig) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	invalid := func() error {
+		return xerrors.Errorf("field '%s': cannot convert %s to %v", path, jsonString(v), dt)
+	}
+	num, isNum := v.(json.Number)
+	str, isStr := v.(string)
+
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return nil, invalid()
+	case *arrow.BooleanType:
+		if _, ok := v.(bool); !ok {
+			return nil, invalid()
+		}
+		return v, nil
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
+		if !isNum {
+			return nil, invalid()
+		}
+		x, err := strconv.ParseInt(string(num), 10, dt.(arrow.FixedWidthDataType).BitWidth())
+		if err != nil {
+			return nil, invalid()
+		}
+		return x, nil
+	case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		if !isNum {
+			return nil, invalid()
+		}
+		x, err := strconv.ParseUint(string(num), 10, dt.(arrow.FixedWidthDataType).BitWidth())
+		if err != nil {
+			return nil, invalid()
+		}
+		return x, nil
+	case *arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type:
+		if !isNum {
+			return nil, invalid()
+		}
+		x, err := num.Float64()
+		if err != nil {
+			return nil, invalid()
+		}
+		return x, nil
+	case *arrow.Decimal128Type:
+		if !isNum && !isStr {
+			return nil, invalid()
+		}
+		if isNum {
+			str = string(num)
+		}
+		x, err := decimal128.FromString(str, dt.Precision, dt.Scale)
+		if err != nil {
+			return nil, xerrors.Errorf("field '%s': cannot convert %s to %v: %w", path, jsonString(v), dt, err)
+		}
+		return x, nil
+	case *arrow.Decimal256Type:
+		if !isNum && !isStr {
+			return nil, invalid()
+		}
+		if isNum {
+			str = string(num)
+		}
+		x, err := decimal256.FromString(str, dt.Precision, dt.Scale)
+		if err != nil {
+			return nil, xerrors.Errorf("field '%s': cannot convert %s to %v: %w", path, jsonString(v), dt, err)
+		}
+		return x, nil
+	case *arrow.StringType, *arrow.StringViewType:
+		if !isStr {
+			return nil, invalid()
+		}
+		return str, nil
+	case *arrow.BinaryType, *arrow.BinaryViewType, *arrow.FixedSizeBinaryType:
+		if !isStr {
+			return nil, invalid()
+		}
+		x, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return nil, invalid()
+		}
+		if fsb, ok := dt.(*arrow.FixedSizeBinaryType); ok && len(x) != fsb.ByteWidth {
+			return nil, invalid()
+		}
+		return x, nil
+	case *arrow.Date32Type, *arrow.Date64Type:
+		if !isStr {
+			return nil, invalid()
+		}
+		x, err := time.Parse("2006-01-02", str)
+		if err != nil {
+			return nil, invalid()
+		}
+		return x, nil
+	case *arrow.TimestampType:
+		switch {
+		case isStr:
+			x, err := time.ParseInLocation(cfg.timestampLayout, str, location(dt.TimeZone))
+			if err != nil {
+				return nil, invalid()
+			}
+			return x, nil
+		case isNum:
+			x, err := num.Int64()
+			if err != nil {
+				return nil, invalid()
+			}
+			return unitToTime(x, dt.Unit), nil
+		}
+		return nil, invalid()
+	case *arrow.Time32Type, *arrow.Time64Type, *arrow.DurationType:
+		var unit arrow.TimeUnit
+		switch dt := dt.(type) {
+		case *arrow.Time32Type:
+			unit = dt.Unit
+		case *arrow.Time64Type:
+			unit = dt.Unit
+		case *arrow.DurationType:
+			unit = dt.Unit
+		}
+		switch {
+		case isStr && dt.ID() == arrow.DURATION:
+			x, err := time.ParseDuration(str)
+			if err != nil {
+				return nil, invalid()
+			}
+			return x, nil
+		case isStr:
+			t, err := time.Parse("15:04:05.999999999", str)
+			if err != nil {
+				return nil, invalid()
+			}
+			return t.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)), nil
+		case isNum:
+			x, err := num.Int64()
+			if err != nil {
+				return nil, invalid()
+			}
+			return time.Duration(x) * unitDuration(unit), nil
+		}
+		return nil, invalid()
+	case *arrow.MonthIntervalType:
+		if !isNum {
+			return nil, invalid()
+		}
+		x, err := strconv.ParseInt(string(num), 10, 32)
+		if err != nil {
+			return nil, invalid()
+		}
+		return arrow.MonthInterval(x), nil
+	case *arrow.DayTimeIntervalType:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, invalid()
+		}
+		var x [2]int64
+		for i, name := range []string{"days", "milliseconds"} {
+			n, ok := obj[name].(json.Number)
+			if !ok && obj[name] != nil {
+				return nil, invalid()
+			}
+			if ok {
+				var err error
+				if x[i], err = strconv.ParseInt(string(n), 10, 32); err != nil {
+					return nil, invalid()
+				}
+			}
+		}
+		return arrow.DayTimeInterval{Days: int32(x[0]), Milliseconds: int32(x[1])}, nil
+	case *arrow.ListType, *arrow.FixedSizeListType:
+		vs, ok := v.([]interface{})
+		if !ok {
+			return nil, invalid()
+		}
+		var elem arrow.DataType
+		switch dt := dt.(type) {
+		case *arrow.ListType:
+			elem = dt.Elem()
+		case *arrow.FixedSizeListType:
+			if len(vs) != int(dt.Len()) {
+				return nil, xerrors.Errorf("field '%s': %d elements for %v", path, len(vs), dt)
+			}
+			elem = dt.Elem()
+		}
+		o := make([]interface{}, len(vs))
+		for i, e := range vs {
+			x, err := jsonGoValue(e, elem, fmt.Sprintf("%s[%d]", path, i), cfg)
+			if err != nil {
+				return nil, err
+			}
+			o[i] = x
+		}
+		return o, nil
+	case *arrow.StructType:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, invalid()
+		}
+		if cfg.strict {
+			for name := range obj {
+				if _, ok := dt.FieldByName(name); !ok {
+					return nil, xerrors.Errorf("field '%s.%s': unknown field", path, name)
+				}
+			}
+		}
+		o := make(map[string]interface{}, len(dt.Fields()))
+		for _, f := range dt.Fields() {
+			x, err := jsonGoValue(obj[f.Name], f.Type, path+"."+f.Name, cfg)
+			if err != nil {
+				return nil, err
+			}
+			o[f.Name] = x
+		}
+		return o, nil
+	case *arrow.RunEndEncodedType:
+		return jsonGoValue(v, dt.Encoded(), path, cfg)
+	}
+	return nil, invalid()
+}
+
+// jsonString returns the JSON encoding of the decoded JSON value v, for
+// error messages.
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+var (
+	_ RecordReader = (*JSONReader)(nil)
+)
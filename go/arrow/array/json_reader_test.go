@@ -0,0 +1,198 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestJSONReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}, Nullable: true},
+		{Name: "price", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}, Nullable: true},
+		{Name: "user", Type: arrow.StructOf(
+			arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "tags", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64), Nullable: true},
+		), Nullable: true},
+	}, nil)
+
+	const input = `{"id": 1, "ts": "2021-01-02 03:04:05.5", "price": 1.25, "user": {"name": "a", "tags": [1, 2]}}
+{"id": 2, "ts": 1000, "price": "-3"}
+{"id": 3, "ts": null, "user": {"name": "c", "tags": null}, "extra": true}
+{"id": 4}
+{"id": 5, "user": {"name": "e", "tags": [1, 2, "x"]}}
+{"id": 6}
+`
+
+	r := array.NewJSONReader(strings.NewReader(input), schema,
+		array.WithJSONAllocator(mem),
+		array.WithJSONChunk(3),
+		array.WithJSONTimestampLayout("2006-01-02 15:04:05.999"),
+	)
+	defer r.Release()
+
+	var got []string
+	for r.Next() {
+		rec := r.Record()
+		for i, col := range rec.Columns() {
+			got = append(got, fmt.Sprintf("%s: %v", rec.ColumnName(i), col))
+		}
+	}
+	want := []string{
+		`id: [1 2 3]`,
+		`ts: [1609556645500 1000 (null)]`,
+		`price: [{125 0} {18446744073709551316 -1} (null)]`,
+		`user: {["a" (null) "c"] [[1 2] (null) (null)]}`,
+		`id: [4]`,
+		`ts: [(null)]`,
+		`price: [(null)]`,
+		`user: {[(null)] [(null)]}`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("invalid records:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got, want := fmt.Sprint(r.Err()), `arrow/array: row 4, field 'user.tags[2]': cannot convert "x" to int64`; got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+}
+
+func TestJSONReaderErrors(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+		{Name: "s", Type: arrow.StructOf(arrow.Field{Name: "b", Type: arrow.FixedWidthTypes.Boolean}), Nullable: true},
+	}, nil)
+
+	for _, tc := range []struct {
+		name  string
+		input string
+		opts  []array.JSONOption
+		rows  int
+		err   string
+	}{
+		{"overflow", `{"i8": 1}` + "\n" + `{"i8": 128}`, nil, 1, `arrow/array: row 1, field 'i8': cannot convert 128 to int8`},
+		{"malformed", `{"i8": 1}` + "\n" + `{"i8": 2}` + "\n" + `{"i8": `, nil, 2, `arrow/array: row 2: unexpected EOF`},
+		{"not-an-object", `{"i8": 1}` + "\n" + `[1]`, nil, 1, `arrow/array: row 1: cannot convert [1] to a row`},
+		{"unknown-field", `{"i8": 1, "x": 2}`, []array.JSONOption{array.WithJSONUnknownFields(true)}, 0, `arrow/array: row 0, field 'x': unknown field`},
+		{"unknown-nested-field", `{"s": {"b": true, "c": 1}}`, []array.JSONOption{array.WithJSONUnknownFields(true)}, 0, `arrow/array: row 0, field 's.c': unknown field`},
+		{"ignored-unknown-fields", `{"i8": 1, "x": 2, "s": {"c": 1}}`, nil, 1, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			r := array.NewJSONReader(strings.NewReader(tc.input), schema, append(tc.opts, array.WithJSONAllocator(mem), array.WithJSONChunk(-1))...)
+			defer r.Release()
+
+			rows := 0
+			for r.Next() {
+				rows += int(r.Record().NumRows())
+			}
+			if rows != tc.rows {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", rows, tc.rows)
+			}
+			switch {
+			case tc.err == "" && r.Err() != nil:
+				t.Fatalf("unexpected error: %v", r.Err())
+			case tc.err != "" && fmt.Sprint(r.Err()) != tc.err:
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", r.Err(), tc.err)
+			}
+		})
+	}
+}
+
+func TestJSONReaderDecimal256(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "amount", Type: &arrow.Decimal256Type{Precision: 50, Scale: 2}, Nullable: true},
+	}, nil)
+
+	const input = `{"amount": "1234567890123456789012345678901234567890.25"}
+{"amount": -3.5}
+{"amount": null}
+{"amount": "1e48"}
+`
+	r := array.NewJSONReader(strings.NewReader(input), schema, array.WithJSONAllocator(mem), array.WithJSONChunk(3))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatal(r.Err())
+	}
+	if got, want := fmt.Sprint(r.Record().Column(0)), `[1234567890123456789012345678901234567890.25 -3.50 (null)]`; got != want {
+		t.Fatalf("invalid values:\ngot= %s\nwant=%s", got, want)
+	}
+
+	if r.Next() {
+		t.Fatalf("expected an overflow error, got=%v", r.Record().Column(0))
+	}
+	if got, want := fmt.Sprint(r.Err()), `arrow/array: row 3, field 'amount': cannot convert "1e48" to decimal256(50, 2): `; !strings.HasPrefix(got, want) {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s...", got, want)
+	}
+}
+
+func TestRecordBuilderUnmarshalJSON(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "b", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "d", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+		{Name: "t", Type: arrow.FixedWidthTypes.Time32ms, Nullable: true},
+		{Name: "dur", Type: arrow.FixedWidthTypes.Duration_s, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	err := b.UnmarshalJSON([]byte(`{"b": "AQI=", "d": "1970-01-03", "t": "01:00:00.5", "dur": "1m", "f": 1.5}
+{"t": 42, "dur": 3}
+{"d": "yesterday"}`))
+	if got, want := fmt.Sprint(err), `arrow/array: row 2, field 'd': cannot convert "yesterday" to date32`; got != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var got []string
+	for i, col := range rec.Columns() {
+		got = append(got, fmt.Sprintf("%s: %v", rec.ColumnName(i), col))
+	}
+	want := []string{
+		`b: ["\x01\x02" (null)]`,
+		`d: [2 (null)]`,
+		`t: [3600500 42]`,
+		`dur: [60 3]`,
+		`f: [1.5 (null)]`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("invalid record:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,390 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"golang.org/x/xerrors"
+)
+
+// WithJSONTimestampEpoch sets whether timestamps are written as numbers in
+// the unit of their type, instead of strings in the timestamp layout.
+func WithJSONTimestampEpoch(v bool) JSONOption {
+	return func(cfg *jsonConfig) { cfg.epoch = v }
+}
+
+// WithJSONBinaryHex sets whether binary values are written as hexadecimal
+// strings, instead of base64 encoded strings.
+func WithJSONBinaryHex(v bool) JSONOption {
+	return func(cfg *jsonConfig) { cfg.binaryHex = v }
+}
+
+// WithJSONDecimalStrings sets whether decimals are written as strings,
+// which JSON decoders parsing numbers as floats do not round, instead of
+// numbers.
+func WithJSONDecimalStrings(v bool) JSONOption {
+	return func(cfg *jsonConfig) { cfg.decimalStrings = v }
+}
+
+// WithJSONNulls sets whether null values are written as JSON nulls, the
+// default, or omitted from the objects of rows and structs.
+func WithJSONNulls(v bool) JSONOption {
+	return func(cfg *jsonConfig) { cfg.nulls = v }
+}
+
+// JSONWriter writes records as a JSON array of row objects, keyed by field
+// names, one row per line, as the JSONReader reads them:
+//
+//	bool                        true or false
+//	integers, floats            numbers, NaN and infinities being null
+//	decimal, decimal256         numbers, or strings, see WithJSONDecimalStrings
+//	string                      strings
+//	binary, fixed size binary   base64 encoded strings, see WithJSONBinaryHex
+//	date32, date64              "2006-01-02" strings
+//	timestamp                   strings in the layout of the writer, see WithJSONTimestampEpoch
+//	time32, time64              "15:04:05.999999999" strings
+//	duration                    strings, as time.Duration formats them
+//	month interval              numbers
+//	day-time interval           {"days": d, "milliseconds": ms} objects
+//	list, fixed size list       arrays
+//	struct                      objects
+//
+// Dictionary-encoded, run-end encoded, union and extension values are
+// written as their logical values.
+//
+// The rows are written to a buffered writer, flushed after each record, so
+// that the records need not be held in memory.
+type JSONWriter struct {
+	w   *bufio.Writer
+	enc jsonEncoder
+	n   int64 // number of rows written
+}
+
+// NewJSONWriter returns a writer of records to w as a JSON array of rows.
+// The array is terminated by Close.
+func NewJSONWriter(w io.Writer, opts ...JSONOption) *JSONWriter {
+	cfg := newJSONConfig(opts...)
+	return &JSONWriter{w: bufio.NewWriter(w), enc: jsonEncoder{cfg: &cfg}}
+}
+
+// Write writes the rows of rec.
+func (w *JSONWriter) Write(rec Record) error {
+	cols := rec.Columns()
+	names := make([]string, len(cols))
+	for i := range names {
+		names[i] = rec.Schema().Field(i).Name
+	}
+	for i := 0; i < int(rec.NumRows()); i++ {
+		w.enc.buf = w.enc.buf[:0]
+		if w.n == 0 {
+			w.enc.buf = append(w.enc.buf, "[\n"...)
+		} else {
+			w.enc.buf = append(w.enc.buf, ",\n"...)
+		}
+		if err := w.enc.object(names, cols, i); err != nil {
+			return xerrors.Errorf("arrow/array: row %d: %w", w.n, err)
+		}
+		if _, err := w.w.Write(w.enc.buf); err != nil {
+			return err
+		}
+		w.n++
+	}
+	return w.w.Flush()
+}
+
+// WriteTable writes the rows of tbl, one chunk at a time.
+func (w *JSONWriter) WriteTable(tbl Table) error {
+	tr := NewTableReader(tbl, 0)
+	defer tr.Release()
+	for tr.Next() {
+		if err := w.Write(tr.Record()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close terminates the JSON array of rows. It does not close the
+// underlying writer.
+func (w *JSONWriter) Close() error {
+	end := "\n]\n"
+	if w.n == 0 {
+		end = "[]\n"
+	}
+	if _, err := w.w.WriteString(end); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// MarshalJSON returns the rows of the record as a JSON array of objects,
+// as a JSONWriter with default options writes them.
+func (rec *simpleRecord) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	w := NewJSONWriter(&buf)
+	if err := w.Write(rec); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON returns the rows of the table as a JSON array of objects,
+// as a JSONWriter with default options writes them.
+func (tbl *simpleTable) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	w := NewJSONWriter(&buf)
+	if err := w.WriteTable(tbl); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonEncoder appends the JSON encoding of values to buf.
+type jsonEncoder struct {
+	cfg *jsonConfig
+	buf []byte
+}
+
+// object appends the values at i of cols as an object keyed by names.
+func (e *jsonEncoder) object(names []string, cols []Interface, i int) error {
+	e.buf = append(e.buf, '{')
+	first := true
+	for j, col := range cols {
+		if !e.cfg.nulls && col.IsNull(i) {
+			continue
+		}
+		if !first {
+			e.buf = append(e.buf, ',')
+		}
+		first = false
+		e.buf = appendJSONString(e.buf, names[j])
+		e.buf = append(e.buf, ':')
+		if err := e.value(col, i); err != nil {
+			return xerrors.Errorf("field '%s': %w", names[j], err)
+		}
+	}
+	e.buf = append(e.buf, '}')
+	return nil
+}
+
+// value appends the value of arr at i.
+func (e *jsonEncoder) value(arr Interface, i int) error {
+	if arr.IsNull(i) {
+		e.buf = append(e.buf, "null"...)
+		return nil
+	}
+
+	switch a := arr.(type) {
+	case *Null:
+		e.buf = append(e.buf, "null"...)
+	case *Boolean:
+		e.buf = strconv.AppendBool(e.buf, a.Value(i))
+	case *Int8:
+		e.buf = strconv.AppendInt(e.buf, int64(a.Value(i)), 10)
+	case *Int16:
+		e.buf = strconv.AppendInt(e.buf, int64(a.Value(i)), 10)
+	case *Int32:
+		e.buf = strconv.AppendInt(e.buf, int64(a.Value(i)), 10)
+	case *Int64:
+		e.buf = strconv.AppendInt(e.buf, a.Value(i), 10)
+	case *Uint8:
+		e.buf = strconv.AppendUint(e.buf, uint64(a.Value(i)), 10)
+	case *Uint16:
+		e.buf = strconv.AppendUint(e.buf, uint64(a.Value(i)), 10)
+	case *Uint32:
+		e.buf = strconv.AppendUint(e.buf, uint64(a.Value(i)), 10)
+	case *Uint64:
+		e.buf = strconv.AppendUint(e.buf, a.Value(i), 10)
+	case *Float16:
+		e.float(float64(a.Value(i).Float32()), 32)
+	case *Float32:
+		e.float(float64(a.Value(i)), 32)
+	case *Float64:
+		e.float(a.Value(i), 64)
+	case *Decimal128:
+		e.decimal(a.Value(i).ToString(a.DataType().(*arrow.Decimal128Type).Scale))
+	case *Decimal256:
+		e.decimal(a.Value(i).ToString(a.DataType().(*arrow.Decimal256Type).Scale))
+	case *String:
+		e.buf = appendJSONString(e.buf, a.Value(i))
+	case *StringView:
+		e.buf = appendJSONString(e.buf, a.Value(i))
+	case *Binary:
+		e.binary(a.Value(i))
+	case *BinaryView:
+		e.binary(a.Value(i))
+	case *FixedSizeBinary:
+		e.binary(a.Value(i))
+	case *Date32:
+		e.time(time.Unix(int64(a.Value(i))*secondsPerDay, 0).UTC(), "2006-01-02")
+	case *Date64:
+		e.time(unitToTime(int64(a.Value(i)), arrow.Millisecond).UTC(), "2006-01-02")
+	case *Timestamp:
+		dt := a.DataType().(*arrow.TimestampType)
+		if e.cfg.epoch {
+			e.buf = strconv.AppendInt(e.buf, int64(a.Value(i)), 10)
+			break
+		}
+		e.time(unitToTime(int64(a.Value(i)), dt.Unit).In(location(dt.TimeZone)), e.cfg.timestampLayout)
+	case *Time32:
+		d := time.Duration(a.Value(i)) * unitDuration(a.DataType().(*arrow.Time32Type).Unit)
+		e.time(time.Time{}.Add(d), "15:04:05.999999999")
+	case *Time64:
+		d := time.Duration(a.Value(i)) * unitDuration(a.DataType().(*arrow.Time64Type).Unit)
+		e.time(time.Time{}.Add(d), "15:04:05.999999999")
+	case *Duration:
+		d := time.Duration(a.Value(i)) * unitDuration(a.DataType().(*arrow.DurationType).Unit)
+		e.buf = appendJSONString(e.buf, d.String())
+	case *MonthInterval:
+		e.buf = strconv.AppendInt(e.buf, int64(a.Value(i)), 10)
+	case *DayTimeInterval:
+		v := a.Value(i)
+		e.buf = append(e.buf, `{"days":`...)
+		e.buf = strconv.AppendInt(e.buf, int64(v.Days), 10)
+		e.buf = append(e.buf, `,"milliseconds":`...)
+		e.buf = strconv.AppendInt(e.buf, int64(v.Milliseconds), 10)
+		e.buf = append(e.buf, '}')
+	case *List:
+		j := i + a.Data().Offset()
+		return e.list(a.ListValues(), int(a.Offsets()[j]), int(a.Offsets()[j+1]))
+	case *FixedSizeList:
+		n := int(a.DataType().(*arrow.FixedSizeListType).Len())
+		j := i + a.Data().Offset()
+		return e.list(a.ListValues(), j*n, (j+1)*n)
+	case *Struct:
+		dt := a.DataType().(*arrow.StructType)
+		names := make([]string, a.NumField())
+		cols := make([]Interface, a.NumField())
+		for k := range cols {
+			names[k], cols[k] = dt.Field(k).Name, a.Field(k)
+		}
+		return e.object(names, cols, i)
+	case *Dictionary:
+		return e.value(a.Dictionary(), a.GetValueIndex(i))
+	case *RunEndEncoded:
+		return e.value(a.Values(), a.GetPhysicalIndex(i))
+	case unionArray:
+		return e.value(a.Field(a.ChildID(i)), a.valueIndex(i))
+	case ExtensionArray:
+		return e.value(a.Storage(), i)
+	default:
+		return xerrors.Errorf("unsupported array type %T", arr)
+	}
+	return nil
+}
+
+func (e *jsonEncoder) float(v float64, bits int) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		e.buf = append(e.buf, "null"...)
+		return
+	}
+	e.buf = strconv.AppendFloat(e.buf, v, 'g', -1, bits)
+}
+
+func (e *jsonEncoder) decimal(v string) {
+	if e.cfg.decimalStrings {
+		e.buf = appendJSONString(e.buf, v)
+		return
+	}
+	e.buf = append(e.buf, v...)
+}
+
+func (e *jsonEncoder) binary(v []byte) {
+	e.buf = append(e.buf, '"')
+	if e.cfg.binaryHex {
+		n := len(e.buf)
+		e.buf = append(e.buf, make([]byte, hex.EncodedLen(len(v)))...)
+		hex.Encode(e.buf[n:], v)
+	} else {
+		n := len(e.buf)
+		e.buf = append(e.buf, make([]byte, base64.StdEncoding.EncodedLen(len(v)))...)
+		base64.StdEncoding.Encode(e.buf[n:], v)
+	}
+	e.buf = append(e.buf, '"')
+}
+
+func (e *jsonEncoder) time(t time.Time, layout string) {
+	e.buf = append(e.buf, '"')
+	e.buf = t.AppendFormat(e.buf, layout)
+	e.buf = append(e.buf, '"')
+}
+
+func (e *jsonEncoder) list(values Interface, beg, end int) error {
+	e.buf = append(e.buf, '[')
+	for k := beg; k < end; k++ {
+		if k > beg {
+			e.buf = append(e.buf, ',')
+		}
+		if err := e.value(values, k); err != nil {
+			return err
+		}
+	}
+	e.buf = append(e.buf, ']')
+	return nil
+}
+
+// appendJSONString appends s as a JSON string, its invalid UTF-8 bytes
+// being replaced by U+FFFD.
+func appendJSONString(buf []byte, s string) []byte {
+	const hexDigits = "0123456789abcdef"
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				buf = append(buf, '\\', c)
+			case c == '\n':
+				buf = append(buf, '\\', 'n')
+			case c == '\r':
+				buf = append(buf, '\\', 'r')
+			case c == '\t':
+				buf = append(buf, '\\', 't')
+			case c < 0x20:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			default:
+				buf = append(buf, c)
+			}
+			i++
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && n == 1 {
+			buf = append(buf, `�`...)
+		} else {
+			buf = append(buf, s[i:i+n]...)
+		}
+		i += n
+	}
+	return append(buf, '"')
+}
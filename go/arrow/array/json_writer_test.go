@@ -0,0 +1,276 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// jsonSchema has a field of each type the JSONReader and JSONWriter
+// support.
+var jsonSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "bool", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	{Name: "i8", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+	{Name: "i16", Type: arrow.PrimitiveTypes.Int16, Nullable: true},
+	{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "u8", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
+	{Name: "u16", Type: arrow.PrimitiveTypes.Uint16, Nullable: true},
+	{Name: "u32", Type: arrow.PrimitiveTypes.Uint32, Nullable: true},
+	{Name: "u64", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	{Name: "f16", Type: arrow.FixedWidthTypes.Float16, Nullable: true},
+	{Name: "f32", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	{Name: "f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "dec128", Type: &arrow.Decimal128Type{Precision: 38, Scale: 2}, Nullable: true},
+	{Name: "dec256", Type: &arrow.Decimal256Type{Precision: 76, Scale: 3}, Nullable: true},
+	{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "strview", Type: arrow.BinaryTypes.StringView, Nullable: true},
+	{Name: "bin", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "binview", Type: arrow.BinaryTypes.BinaryView, Nullable: true},
+	{Name: "fsb", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}, Nullable: true},
+	{Name: "date32", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+	{Name: "date64", Type: arrow.FixedWidthTypes.Date64, Nullable: true},
+	{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "Europe/Paris"}, Nullable: true},
+	{Name: "time32", Type: arrow.FixedWidthTypes.Time32ms, Nullable: true},
+	{Name: "time64", Type: arrow.FixedWidthTypes.Time64ns, Nullable: true},
+	{Name: "dur", Type: arrow.FixedWidthTypes.Duration_ms, Nullable: true},
+	{Name: "months", Type: arrow.FixedWidthTypes.MonthInterval, Nullable: true},
+	{Name: "daytime", Type: arrow.FixedWidthTypes.DayTimeInterval, Nullable: true},
+	{Name: "list", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32), Nullable: true},
+	{Name: "fsl", Type: arrow.FixedSizeListOf(2, arrow.BinaryTypes.String), Nullable: true},
+	{Name: "struct", Type: arrow.StructOf(
+		arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		arrow.Field{Name: "b", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	), Nullable: true},
+	{Name: "ree", Type: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String), Nullable: true},
+}, nil)
+
+const jsonRows = `{"bool": true, "i8": -8, "i16": -16, "i32": -32, "i64": -64, "u8": 8, "u16": 16, "u32": 32, "u64": 18446744073709551615, "f16": 1.5, "f32": 0.25, "f64": 1e100, "dec128": "12345678901234567890.25", "dec256": -1.5, "str": "a \"quoted\"\né\u0001", "strview": "a string longer than twelve bytes", "bin": "AQID", "binview": "", "fsb": "AAE=", "date32": "2021-03-04", "date64": "1969-12-31", "ts": "2021-03-04T05:06:07.000008+01:00", "time32": "01:02:03.5", "time64": "23:59:59.999999999", "dur": "-1h2m3.004s", "months": 14, "daytime": {"days": 1, "milliseconds": -2}, "list": [1, null, 3], "fsl": ["x", null], "struct": {"a": 1, "b": ["y"]}, "ree": "r"}
+{}
+{"list": [], "struct": {"a": null, "b": null}, "ree": "r"}
+`
+
+func TestJSONWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewRecordBuilder(mem, jsonSchema)
+	defer b.Release()
+	if err := b.UnmarshalJSON([]byte(jsonRows)); err != nil {
+		t.Fatal(err)
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	got, err := json.Marshal(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"bool":true,"i8":-8,"i16":-16,"i32":-32,"i64":-64,"u8":8,"u16":16,"u32":32,"u64":18446744073709551615,` +
+		`"f16":1.5,"f32":0.25,"f64":1e+100,"dec128":12345678901234567890.25,"dec256":-1.500,` +
+		`"str":"a \"quoted\"\né\u0001","strview":"a string longer than twelve bytes","bin":"AQID","binview":"","fsb":"AAE=",` +
+		`"date32":"2021-03-04","date64":"1969-12-31","ts":"2021-03-04T05:06:07.000008+01:00",` +
+		`"time32":"01:02:03.5","time64":"23:59:59.999999999","dur":"-1h2m3.004s","months":14,"daytime":{"days":1,"milliseconds":-2},` +
+		`"list":[1,null,3],"fsl":["x",null],"struct":{"a":1,"b":["y"]},"ree":"r"},`
+	if !strings.HasPrefix(string(got), want) {
+		t.Fatalf("invalid JSON:\ngot= %s\nwant=%s...", got, want)
+	}
+
+	if err := b.UnmarshalJSON(got); err != nil {
+		t.Fatal(err)
+	}
+	back := b.NewRecord()
+	defer back.Release()
+	if !array.RecordEqual(back, rec) {
+		t.Fatalf("records differ:\ngot= %v\nwant=%v", back, rec)
+	}
+}
+
+func TestJSONWriterOptions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}, Nullable: true},
+		{Name: "bin", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "dec", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "s", Type: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8, Nullable: true}), Nullable: true},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	if err := b.UnmarshalJSON([]byte(`[{"ts": 1500, "bin": "AQI=", "dec": 1.5, "f": 1, "s": {"a": null}}, {"f": 2}]`)); err != nil {
+		t.Fatal(err)
+	}
+	b.Field(3).(*array.Float64Builder).Append(math.NaN())
+	for i := 0; i < 4; i++ {
+		if i != 3 {
+			b.Field(i).AppendNull()
+		}
+	}
+	b.Field(4).AppendNull()
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		opts []array.JSONOption
+		want string
+	}{
+		{
+			name: "default",
+			want: `[
+{"ts":"1970-01-01T00:00:01.5Z","bin":"AQI=","dec":1.50,"f":1,"s":{"a":null}},
+{"ts":null,"bin":null,"dec":null,"f":2,"s":null},
+{"ts":null,"bin":null,"dec":null,"f":null,"s":null}
+]
+`,
+		},
+		{
+			name: "options",
+			opts: []array.JSONOption{
+				array.WithJSONTimestampEpoch(true),
+				array.WithJSONBinaryHex(true),
+				array.WithJSONDecimalStrings(true),
+				array.WithJSONNulls(false),
+			},
+			want: `[
+{"ts":1500,"bin":"0102","dec":"1.50","f":1,"s":{}},
+{"f":2},
+{"f":null}
+]
+`,
+		},
+		{
+			name: "layout",
+			opts: []array.JSONOption{array.WithJSONTimestampLayout("2006-01-02 15:04:05.000"), array.WithJSONNulls(false)},
+			want: `[
+{"ts":"1970-01-01 00:00:01.500","bin":"AQI=","dec":1.50,"f":1,"s":{}},
+{"f":2},
+{"f":null}
+]
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := array.NewJSONWriter(&buf, tc.opts...)
+			if err := w.Write(rec); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("invalid JSON:\ngot:\n%s\nwant:\n%s", got, tc.want)
+			}
+			if !json.Valid(buf.Bytes()) {
+				t.Fatalf("invalid JSON:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestJSONWriterStream(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var buf bytes.Buffer
+	w := array.NewJSONWriter(&buf)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "[]\n"; got != want {
+		t.Fatalf("invalid empty JSON: got=%q, want=%q", got, want)
+	}
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "dict", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}, Nullable: true},
+		{Name: "union", Type: arrow.DenseUnionOf([]arrow.Field{
+			{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+			{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+		}, nil)},
+	}, nil)
+	ib := array.NewInt8Builder(mem)
+	defer ib.Release()
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	ub := array.NewDenseUnionBuilder(mem, schema.Field(1).Type.(*arrow.UnionType))
+	defer ub.Release()
+
+	var recs []array.Record
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+	for i := 0; i < 2; i++ {
+		ib.AppendValues([]int8{0, 0}, []bool{true, false})
+		sb.Append("a")
+		indices, dict := ib.NewArray(), sb.NewArray()
+		da := array.NewDictionaryArray(schema.Field(0).Type.(*arrow.DictionaryType), indices, dict)
+		indices.Release()
+		dict.Release()
+
+		ub.Append(0).(*array.Int32Builder).Append(int32(i))
+		ub.Append(1).(*array.StringBuilder).Append("b")
+		ua := ub.NewArray()
+
+		recs = append(recs, array.NewRecord(schema, []array.Interface{da, ua}, 2))
+		da.Release()
+		ua.Release()
+	}
+
+	buf.Reset()
+	w = array.NewJSONWriter(&buf)
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+{"dict":"a","union":0},
+{"dict":null,"union":"b"},
+{"dict":"a","union":1},
+{"dict":null,"union":"b"}
+]
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("invalid JSON:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+	got, err := json.Marshal(tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Replace(want, "\n", "", -1); string(got) != want {
+		t.Fatalf("invalid table JSON:\ngot= %s\nwant=%s", got, want)
+	}
+}
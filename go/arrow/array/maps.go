@@ -0,0 +1,716 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// RecordToMaps converts every row of rec with RowToMap.
+func RecordToMaps(rec Record) []map[string]interface{} {
+	rows := make([]map[string]interface{}, rec.NumRows())
+	for i := range rows {
+		rows[i] = RowToMap(rec, i)
+	}
+	return rows
+}
+
+// RowToMap converts the i-th row of rec to a map from column names to
+// plain Go values that can be logged or encoded to JSON:
+//
+//	null                        nil
+//	bool, integers, floats      bool, the Go integer type, float32/float64
+//	float16                     float32
+//	string, binary              string, []byte
+//	fixed size binary           []byte
+//	date32, date64, timestamp   time.Time, in the time zone of the type
+//	time32, time64, duration    time.Duration
+//	intervals                   arrow.MonthInterval, arrow.DayTimeInterval
+//	decimal, decimal256         string, as formatted by decimal128.Num.ToString
+//	                            or decimal256.Num.ToString
+//	list, fixed size list       []interface{}
+//	struct                      map[string]interface{}
+//	dictionary                  the decoded value
+//
+// Timestamps whose time zone cannot be loaded are returned in UTC.
+func RowToMap(rec Record, i int) map[string]interface{} {
+	row := make(map[string]interface{}, rec.NumCols())
+	for j, col := range rec.Columns() {
+		row[rec.ColumnName(j)] = goValue(col, i)
+	}
+	return row
+}
+
+func goValue(arr Interface, i int) interface{} {
+	if arr.IsNull(i) {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *Null:
+		return nil
+	case *Boolean:
+		return a.Value(i)
+	case *Int8:
+		return a.Value(i)
+	case *Int16:
+		return a.Value(i)
+	case *Int32:
+		return a.Value(i)
+	case *Int64:
+		return a.Value(i)
+	case *Uint8:
+		return a.Value(i)
+	case *Uint16:
+		return a.Value(i)
+	case *Uint32:
+		return a.Value(i)
+	case *Uint64:
+		return a.Value(i)
+	case *Float16:
+		return a.Value(i).Float32()
+	case *Float32:
+		return a.Value(i)
+	case *Float64:
+		return a.Value(i)
+	case *String:
+		return a.Value(i)
+	case *Binary:
+		return cloneBytes(a.Value(i))
+	case *StringView:
+		return a.Value(i)
+	case *BinaryView:
+		return cloneBytes(a.Value(i))
+	case *FixedSizeBinary:
+		return cloneBytes(a.Value(i))
+	case *Date32:
+		return time.Unix(int64(a.Value(i))*secondsPerDay, 0).UTC()
+	case *Date64:
+		return unitToTime(int64(a.Value(i)), arrow.Millisecond).UTC()
+	case *Timestamp:
+		dt := a.DataType().(*arrow.TimestampType)
+		return unitToTime(int64(a.Value(i)), dt.Unit).In(location(dt.TimeZone))
+	case *Time32:
+		return time.Duration(a.Value(i)) * unitDuration(a.DataType().(*arrow.Time32Type).Unit)
+	case *Time64:
+		return time.Duration(a.Value(i)) * unitDuration(a.DataType().(*arrow.Time64Type).Unit)
+	case *Duration:
+		return time.Duration(a.Value(i)) * unitDuration(a.DataType().(*arrow.DurationType).Unit)
+	case *MonthInterval:
+		return a.Value(i)
+	case *DayTimeInterval:
+		return a.Value(i)
+	case *Decimal128:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal128Type).Scale)
+	case *Decimal256:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal256Type).Scale)
+	case *List:
+		j := i + a.Data().Offset()
+		return goValues(a.ListValues(), int(a.Offsets()[j]), int(a.Offsets()[j+1]))
+	case *FixedSizeList:
+		n := int(a.DataType().(*arrow.FixedSizeListType).Len())
+		j := i + a.Data().Offset()
+		return goValues(a.ListValues(), j*n, (j+1)*n)
+	case *Struct:
+		dt := a.DataType().(*arrow.StructType)
+		m := make(map[string]interface{}, a.NumField())
+		for k := 0; k < a.NumField(); k++ {
+			m[dt.Field(k).Name] = goValue(a.Field(k), i)
+		}
+		return m
+	case *Dictionary:
+		return goValue(a.Dictionary(), a.GetValueIndex(i))
+	case *RunEndEncoded:
+		return goValue(a.Values(), a.GetPhysicalIndex(i))
+	case unionArray:
+		return goValue(a.Field(a.ChildID(i)), a.valueIndex(i))
+	case ExtensionArray:
+		return goValue(a.Storage(), i)
+	}
+	panic(xerrors.Errorf("arrow/array: unsupported array type %T", arr))
+}
+
+func goValues(arr Interface, beg, end int) []interface{} {
+	vs := make([]interface{}, end-beg)
+	for i := range vs {
+		vs[i] = goValue(arr, beg+i)
+	}
+	return vs
+}
+
+func cloneBytes(b []byte) []byte {
+	o := make([]byte, len(b))
+	copy(o, b)
+	return o
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+func unitDuration(u arrow.TimeUnit) time.Duration {
+	return [...]time.Duration{time.Nanosecond, time.Microsecond, time.Millisecond, time.Second}[u]
+}
+
+func unitToTime(v int64, u arrow.TimeUnit) time.Time {
+	per := int64(time.Second / unitDuration(u))
+	return time.Unix(v/per, v%per*int64(unitDuration(u)))
+}
+
+func timeToUnit(t time.Time, u arrow.TimeUnit) int64 {
+	per := int64(time.Second / unitDuration(u))
+	return t.Unix()*per + int64(t.Nanosecond())/int64(unitDuration(u))
+}
+
+// location returns the location of an Arrow time zone, or UTC if tz is
+// not a valid time zone.
+func location(tz string) *time.Location {
+	if loc, err := arrow.LoadLocation(tz); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// RecordFromMaps builds a record with the given schema from rows of plain
+// Go values, as returned by RecordToMaps. Missing columns are null.
+// Numeric columns accept any Go integer or floating point value that fits,
+// decimal columns accept strings or decimal128.Num and decimal256.Num
+// respectively, and binary columns accept strings.
+//
+// Dictionary columns are encoded from their logical values, and are only
+// supported at the top level of the schema. RecordFromMaps is meant for
+// tests and small fixtures, not for bulk conversions.
+func RecordFromMaps(mem memory.Allocator, schema *arrow.Schema, rows []map[string]interface{}) (Record, error) {
+	cols := make([]Interface, 0, len(schema.Fields()))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for i, row := range rows {
+		for name := range row {
+			if !schema.HasField(name) {
+				return nil, xerrors.Errorf("arrow/array: unknown column %q in row %d", name, i)
+			}
+		}
+	}
+
+	for _, f := range schema.Fields() {
+		col, err := columnFromMaps(mem, f, rows)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	return NewRecord(schema, cols, int64(len(rows))), nil
+}
+
+func columnFromMaps(mem memory.Allocator, f arrow.Field, rows []map[string]interface{}) (Interface, error) {
+	dt := f.Type
+	dict, isDict := dt.(*arrow.DictionaryType)
+	if isDict {
+		dt = dict.ValueType
+	}
+
+	bldr := NewBuilder(mem, dt)
+	defer bldr.Release()
+
+	var (
+		indices = make([]interface{}, len(rows))
+		memo    = make(map[interface{}]int)
+	)
+	for i, row := range rows {
+		v := row[f.Name]
+		switch {
+		case isDict && v == nil:
+			continue
+		case isDict:
+			// values that cannot be map keys each get their own entry.
+			key := v
+			if b, ok := v.([]byte); ok {
+				key = string(b)
+			}
+			if reflect.TypeOf(key).Comparable() {
+				if idx, ok := memo[key]; ok {
+					indices[i] = idx
+					continue
+				}
+				memo[key] = bldr.Len()
+			}
+			indices[i] = bldr.Len()
+		}
+		if err := appendGoValue(bldr, dt, v); err != nil {
+			return nil, xerrors.Errorf("arrow/array: column %q, row %d: %w", f.Name, i, err)
+		}
+	}
+
+	values := bldr.NewArray()
+	if !isDict {
+		return values, nil
+	}
+	defer values.Release()
+
+	ib := NewBuilder(mem, dict.IndexType)
+	defer ib.Release()
+	for i, idx := range indices {
+		if err := appendGoValue(ib, dict.IndexType, idx); err != nil {
+			return nil, xerrors.Errorf("arrow/array: column %q, row %d: %w", f.Name, i, err)
+		}
+	}
+	idx := ib.NewArray()
+	defer idx.Release()
+
+	data := NewDataWithDictionary(dict, idx.Len(), idx.Data().Buffers(), idx.NullN(), 0, values.Data())
+	defer data.Release()
+	return MakeFromData(data), nil
+}
+
+func appendGoValue(b Builder, dt arrow.DataType, v interface{}) error {
+	if v == nil {
+		b.AppendNull()
+		if b, ok := b.(*FixedSizeListBuilder); ok {
+			for i := int32(0); i < dt.(*arrow.FixedSizeListType).Len(); i++ {
+				b.ValueBuilder().AppendNull()
+			}
+		}
+		return nil
+	}
+
+	invalid := func() error {
+		return xerrors.Errorf("cannot convert %T to %v", v, dt)
+	}
+
+	switch b := b.(type) {
+	case *BooleanBuilder:
+		x, ok := v.(bool)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *Int8Builder:
+		x, ok := toInt(v, 8)
+		if !ok {
+			return invalid()
+		}
+		b.Append(int8(x))
+	case *Int16Builder:
+		x, ok := toInt(v, 16)
+		if !ok {
+			return invalid()
+		}
+		b.Append(int16(x))
+	case *Int32Builder:
+		x, ok := toInt(v, 32)
+		if !ok {
+			return invalid()
+		}
+		b.Append(int32(x))
+	case *Int64Builder:
+		x, ok := toInt(v, 64)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *Uint8Builder:
+		x, ok := toUint(v, 8)
+		if !ok {
+			return invalid()
+		}
+		b.Append(uint8(x))
+	case *Uint16Builder:
+		x, ok := toUint(v, 16)
+		if !ok {
+			return invalid()
+		}
+		b.Append(uint16(x))
+	case *Uint32Builder:
+		x, ok := toUint(v, 32)
+		if !ok {
+			return invalid()
+		}
+		b.Append(uint32(x))
+	case *Uint64Builder:
+		x, ok := toUint(v, 64)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *Float16Builder:
+		x, ok := toFloat(v)
+		if !ok {
+			return invalid()
+		}
+		b.Append(float16.FromFloat64(x))
+	case *Float32Builder:
+		x, ok := toFloat(v)
+		if !ok {
+			return invalid()
+		}
+		b.Append(float32(x))
+	case *Float64Builder:
+		x, ok := toFloat(v)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *StringBuilder:
+		x, ok := v.(string)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *BinaryBuilder:
+		switch x := v.(type) {
+		case []byte:
+			b.Append(x)
+		case string:
+			b.AppendString(x)
+		default:
+			return invalid()
+		}
+	case *StringViewBuilder:
+		x, ok := v.(string)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *BinaryViewBuilder:
+		switch x := v.(type) {
+		case []byte:
+			b.Append(x)
+		case string:
+			b.AppendString(x)
+		default:
+			return invalid()
+		}
+	case *FixedSizeBinaryBuilder:
+		x, ok := v.([]byte)
+		if !ok || len(x) != dt.(*arrow.FixedSizeBinaryType).ByteWidth {
+			return invalid()
+		}
+		b.Append(x)
+	case *Date32Builder:
+		x, ok := v.(time.Time)
+		if !ok {
+			return invalid()
+		}
+		days := x.Unix() / secondsPerDay
+		if x.Unix()%secondsPerDay < 0 {
+			days--
+		}
+		b.Append(arrow.Date32(days))
+	case *Date64Builder:
+		x, ok := v.(time.Time)
+		if !ok {
+			return invalid()
+		}
+		b.Append(arrow.Date64(timeToUnit(x, arrow.Millisecond)))
+	case *TimestampBuilder:
+		x, ok := v.(time.Time)
+		if !ok {
+			return invalid()
+		}
+		b.Append(arrow.Timestamp(timeToUnit(x, dt.(*arrow.TimestampType).Unit)))
+	case *Time32Builder:
+		x, ok := v.(time.Duration)
+		if !ok {
+			return invalid()
+		}
+		b.Append(arrow.Time32(x / unitDuration(dt.(*arrow.Time32Type).Unit)))
+	case *Time64Builder:
+		x, ok := v.(time.Duration)
+		if !ok {
+			return invalid()
+		}
+		b.Append(arrow.Time64(x / unitDuration(dt.(*arrow.Time64Type).Unit)))
+	case *DurationBuilder:
+		x, ok := v.(time.Duration)
+		if !ok {
+			return invalid()
+		}
+		b.Append(arrow.Duration(x / unitDuration(dt.(*arrow.DurationType).Unit)))
+	case *MonthIntervalBuilder:
+		x, ok := v.(arrow.MonthInterval)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *DayTimeIntervalBuilder:
+		x, ok := v.(arrow.DayTimeInterval)
+		if !ok {
+			return invalid()
+		}
+		b.Append(x)
+	case *Decimal128Builder:
+		dec := dt.(*arrow.Decimal128Type)
+		switch x := v.(type) {
+		case decimal128.Num:
+			b.Append(x)
+		case string:
+			n, err := decimal128.FromString(x, dec.Precision, dec.Scale)
+			if err != nil {
+				return err
+			}
+			b.Append(n)
+		default:
+			return invalid()
+		}
+	case *Decimal256Builder:
+		dec := dt.(*arrow.Decimal256Type)
+		switch x := v.(type) {
+		case decimal256.Num:
+			b.Append(x)
+		case string:
+			n, err := decimal256.FromString(x, dec.Precision, dec.Scale)
+			if err != nil {
+				return err
+			}
+			b.Append(n)
+		default:
+			return invalid()
+		}
+	case *ListBuilder:
+		x, ok := v.([]interface{})
+		if !ok {
+			return invalid()
+		}
+		b.Append(true)
+		for _, e := range x {
+			if err := appendGoValue(b.ValueBuilder(), dt.(*arrow.ListType).Elem(), e); err != nil {
+				return err
+			}
+		}
+	case *FixedSizeListBuilder:
+		typ := dt.(*arrow.FixedSizeListType)
+		x, ok := v.([]interface{})
+		if !ok || len(x) != int(typ.Len()) {
+			return invalid()
+		}
+		b.Append(true)
+		for _, e := range x {
+			if err := appendGoValue(b.ValueBuilder(), typ.Elem(), e); err != nil {
+				return err
+			}
+		}
+	case *StructBuilder:
+		typ := dt.(*arrow.StructType)
+		x, ok := v.(map[string]interface{})
+		if !ok {
+			return invalid()
+		}
+		for name := range x {
+			if _, ok := typ.FieldByName(name); !ok {
+				return xerrors.Errorf("unknown field %q in %v", name, dt)
+			}
+		}
+		b.Append(true)
+		for i, f := range typ.Fields() {
+			if err := appendGoValue(b.FieldBuilder(i), f.Type, x[f.Name]); err != nil {
+				return err
+			}
+		}
+	case *RunEndEncodedBuilder:
+		b.Append(1)
+		return appendGoValue(b.ValueBuilder(), dt.(*arrow.RunEndEncodedType).Encoded(), v)
+	default:
+		return invalid()
+	}
+	return nil
+}
+
+// goValueOf converts v, which may hold structs, pointers and typed slices,
+// to the plain Go values appendGoValue takes for type dt, checking that the
+// non-nullable fields of the structs are set.
+func goValueOf(v reflect.Value, dt arrow.DataType) (interface{}, error) {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.StructType:
+		var field func(name string) reflect.Value
+		switch v.Kind() {
+		case reflect.Map:
+			if v.IsNil() {
+				return nil, nil
+			}
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, xerrors.Errorf("cannot convert %v to %v", v.Type(), dt)
+			}
+			for _, k := range v.MapKeys() {
+				if _, ok := dt.FieldByName(k.String()); !ok {
+					return nil, xerrors.Errorf("unknown field %q in %v", k.String(), dt)
+				}
+			}
+			field = func(name string) reflect.Value {
+				return v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			}
+		case reflect.Struct:
+			index := make(map[string]int, v.NumField())
+			for i := 0; i < v.NumField(); i++ {
+				sf := v.Type().Field(i)
+				name := sf.Name
+				if tag, ok := sf.Tag.Lookup("arrow"); ok {
+					name = tag
+				}
+				if name == "-" || sf.PkgPath != "" {
+					continue
+				}
+				index[name] = i
+			}
+			field = func(name string) reflect.Value {
+				i, ok := index[name]
+				if !ok {
+					return reflect.Value{}
+				}
+				return v.Field(i)
+			}
+		default:
+			return nil, xerrors.Errorf("cannot convert %v to %v", v.Type(), dt)
+		}
+
+		o := make(map[string]interface{}, len(dt.Fields()))
+		for _, f := range dt.Fields() {
+			x, err := goValueOf(field(f.Name), f.Type)
+			if err != nil {
+				return nil, xerrors.Errorf("field %q: %w", f.Name, err)
+			}
+			if x == nil && !f.Nullable {
+				return nil, xerrors.Errorf("field %q: null value for a non-nullable field", f.Name)
+			}
+			o[f.Name] = x
+		}
+		return o, nil
+
+	case *arrow.ListType, *arrow.FixedSizeListType:
+		var elem arrow.DataType
+		switch dt := dt.(type) {
+		case *arrow.ListType:
+			elem = dt.Elem()
+		case *arrow.FixedSizeListType:
+			elem = dt.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Slice:
+			if v.IsNil() {
+				return nil, nil
+			}
+		case reflect.Array:
+		default:
+			return nil, xerrors.Errorf("cannot convert %v to %v", v.Type(), dt)
+		}
+		o := make([]interface{}, v.Len())
+		for i := range o {
+			x, err := goValueOf(v.Index(i), elem)
+			if err != nil {
+				return nil, xerrors.Errorf("element %d: %w", i, err)
+			}
+			o[i] = x
+		}
+		return o, nil
+
+	case *arrow.RunEndEncodedType:
+		return goValueOf(v, dt.Encoded())
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 && v.IsNil() {
+		return nil, nil
+	}
+	return v.Interface(), nil
+}
+
+// toInt converts v to an integer that fits in a signed integer of the
+// given bit size.
+func toInt(v interface{}, bits uint) (int64, bool) {
+	var x int64
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		x = int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		x = int64(f)
+	default:
+		return 0, false
+	}
+	if s := x >> (bits - 1); s != 0 && s != -1 {
+		return 0, false
+	}
+	return x, true
+}
+
+// toUint converts v to an integer that fits in an unsigned integer of the
+// given bit size.
+func toUint(v interface{}, bits uint) (uint64, bool) {
+	var x uint64
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, false
+		}
+		x = uint64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x = rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, false
+		}
+		x = uint64(f)
+	default:
+		return 0, false
+	}
+	if bits < 64 && x>>bits != 0 {
+		return 0, false
+	}
+	return x, true
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,246 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestRecordMapsRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	point := arrow.StructOf(
+		arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "tag", Type: arrow.BinaryTypes.String, Nullable: true},
+	)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "null", Type: arrow.Null, Nullable: true},
+		{Name: "bool", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+		{Name: "i16", Type: arrow.PrimitiveTypes.Int16, Nullable: true},
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "u8", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
+		{Name: "u16", Type: arrow.PrimitiveTypes.Uint16, Nullable: true},
+		{Name: "u32", Type: arrow.PrimitiveTypes.Uint32, Nullable: true},
+		{Name: "u64", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: "f16", Type: arrow.FixedWidthTypes.Float16, Nullable: true},
+		{Name: "f32", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "bin", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "fsb", Type: &arrow.FixedSizeBinaryType{ByteWidth: 3}, Nullable: true},
+		{Name: "date32", Type: arrow.PrimitiveTypes.Date32, Nullable: true},
+		{Name: "date64", Type: arrow.PrimitiveTypes.Date64, Nullable: true},
+		{Name: "ts", Type: arrow.FixedWidthTypes.Timestamp_us, Nullable: true},
+		{Name: "time32", Type: arrow.FixedWidthTypes.Time32ms, Nullable: true},
+		{Name: "time64", Type: arrow.FixedWidthTypes.Time64ns, Nullable: true},
+		{Name: "dur", Type: arrow.FixedWidthTypes.Duration_s, Nullable: true},
+		{Name: "months", Type: arrow.FixedWidthTypes.MonthInterval, Nullable: true},
+		{Name: "daytime", Type: arrow.FixedWidthTypes.DayTimeInterval, Nullable: true},
+		{Name: "dec", Type: &arrow.Decimal128Type{Precision: 10, Scale: 3}, Nullable: true},
+		{Name: "list", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64), Nullable: true},
+		{Name: "fsl", Type: arrow.FixedSizeListOf(2, arrow.BinaryTypes.String), Nullable: true},
+		{Name: "struct", Type: point, Nullable: true},
+		{Name: "list_struct", Type: arrow.ListOf(point), Nullable: true},
+		{Name: "dict", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}, Nullable: true},
+	}, nil)
+
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 8000, time.UTC)
+	rows := []map[string]interface{}{
+		{
+			"null": nil, "bool": true,
+			"i8": int8(-8), "i16": int16(-16), "i32": int32(-32), "i64": int64(-64),
+			"u8": uint8(8), "u16": uint16(16), "u32": uint32(32), "u64": uint64(1 << 63),
+			"f16": float32(1.5), "f32": float32(-2.25), "f64": 3.125,
+			"str": "hello", "bin": []byte{0, 1, 2}, "fsb": []byte("abc"),
+			"date32": time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+			"date64": time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+			"ts":     ts,
+			"time32": 13*time.Hour + 500*time.Millisecond, "time64": time.Nanosecond,
+			"dur":     -90 * time.Second,
+			"months":  arrow.MonthInterval(14),
+			"daytime": arrow.DayTimeInterval{Days: 2, Milliseconds: 3},
+			"dec":     "-1234.567",
+			"list":    []interface{}{int64(1), nil, int64(3)},
+			"fsl":     []interface{}{"a", nil},
+			"struct":  map[string]interface{}{"x": int32(1), "tag": "p"},
+			"list_struct": []interface{}{
+				map[string]interface{}{"x": int32(2), "tag": nil},
+				nil,
+			},
+			"dict": "b",
+		},
+		{
+			"null": nil, "bool": nil, "i8": nil, "i16": nil, "i32": nil, "i64": nil,
+			"u8": nil, "u16": nil, "u32": nil, "u64": nil, "f16": nil, "f32": nil, "f64": nil,
+			"str": nil, "bin": nil, "fsb": nil, "date32": nil, "date64": nil, "ts": nil,
+			"time32": nil, "time64": nil, "dur": nil, "months": nil, "daytime": nil, "dec": nil,
+			"list": nil, "fsl": nil, "struct": nil, "list_struct": nil, "dict": nil,
+		},
+		{
+			"null": nil, "bool": false,
+			"i8": int8(127), "i16": int16(0), "i32": int32(1), "i64": int64(1 << 62),
+			"u8": uint8(255), "u16": uint16(0), "u32": uint32(0), "u64": uint64(0),
+			"f16": float32(0), "f32": float32(0), "f64": -0.5,
+			"str": "", "bin": []byte{}, "fsb": []byte("xyz"),
+			"date32": time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+			"date64": time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+			"ts":     time.Date(1950, 1, 1, 0, 0, 0, 1000, time.UTC),
+			"time32": time.Duration(0), "time64": 23 * time.Hour,
+			"dur":         time.Duration(0),
+			"months":      arrow.MonthInterval(-1),
+			"daytime":     arrow.DayTimeInterval{},
+			"dec":         "0.001",
+			"list":        []interface{}{},
+			"fsl":         []interface{}{"c", "d"},
+			"struct":      map[string]interface{}{"x": nil, "tag": nil},
+			"list_struct": []interface{}{},
+			"dict":        "a",
+		},
+		{
+			"dict": "b",
+		},
+	}
+
+	rec, err := array.RecordFromMaps(mem, schema, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	if got, want := rec.NumRows(), int64(len(rows)); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	dict := rec.Column(rec.Schema().FieldIndices("dict")[0]).(*array.Dictionary)
+	if got, want := dict.Dictionary().Len(), 2; got != want {
+		t.Fatalf("invalid dictionary size: got=%d, want=%d", got, want)
+	}
+
+	// the last row only sets the dictionary column: all others are null.
+	last := map[string]interface{}{}
+	for _, f := range schema.Fields() {
+		last[f.Name] = nil
+	}
+	last["dict"] = "b"
+	rows[3] = last
+
+	got := array.RecordToMaps(rec)
+	if !reflect.DeepEqual(got, rows) {
+		for i := range rows {
+			for k, want := range rows[i] {
+				if !reflect.DeepEqual(got[i][k], want) {
+					t.Errorf("row %d, column %q: got=%#v, want=%#v", i, k, got[i][k], want)
+				}
+			}
+		}
+		t.FailNow()
+	}
+
+	// rows of a sliced record convert alike.
+	sli := rec.NewSlice(2, 4)
+	defer sli.Release()
+	if got := array.RecordToMaps(sli); !reflect.DeepEqual(got, rows[2:4]) {
+		t.Fatalf("invalid rows for the sliced record:\ngot= %v\nwant=%v", got, rows[2:4])
+	}
+
+	if _, err := json.Marshal(got); err != nil {
+		t.Fatalf("rows cannot be encoded to JSON: %+v", err)
+	}
+}
+
+func TestRowToMapTimeZone(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "+05:30"})
+	defer bldr.Release()
+	bldr.Append(0)
+	arr := bldr.NewArray()
+	defer arr.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "ts", Type: arr.DataType()}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, 1)
+	defer rec.Release()
+
+	ts := array.RowToMap(rec, 0)["ts"].(time.Time)
+	if !ts.Equal(time.Unix(0, 0)) {
+		t.Fatalf("invalid instant: %v", ts)
+	}
+	if got, want := ts.Format(time.RFC3339), "1970-01-01T05:30:00+05:30"; got != want {
+		t.Fatalf("invalid time: got=%s, want=%s", got, want)
+	}
+}
+
+func TestRecordFromMapsErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+		{Name: "u32", Type: arrow.PrimitiveTypes.Uint32, Nullable: true},
+		{Name: "s", Type: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64}), Nullable: true},
+		{Name: "fsl", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int64), Nullable: true},
+		{Name: "dec", Type: &arrow.Decimal128Type{Precision: 4, Scale: 2}, Nullable: true},
+	}, nil)
+
+	for _, tc := range []struct {
+		name string
+		row  map[string]interface{}
+		err  string
+	}{
+		{"unknown column", map[string]interface{}{"x": 1}, `unknown column "x"`},
+		{"overflow", map[string]interface{}{"i8": 128}, `column "i8", row 0: cannot convert int to int8`},
+		{"fraction", map[string]interface{}{"i8": 1.5}, `cannot convert float64 to int8`},
+		{"negative", map[string]interface{}{"u32": -1}, `cannot convert int to uint32`},
+		{"type", map[string]interface{}{"u32": "1"}, `cannot convert string to uint32`},
+		{"struct field", map[string]interface{}{"s": map[string]interface{}{"b": 1}}, `unknown field "b"`},
+		{"list length", map[string]interface{}{"fsl": []interface{}{1}}, `cannot convert []interface {} to fixed_size_list`},
+		{"decimal", map[string]interface{}{"dec": "123.4"}, `column "dec"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, err := array.RecordFromMaps(mem, schema, []map[string]interface{}{tc.row})
+			if err == nil {
+				rec.Release()
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%q, want=%q", err, tc.err)
+			}
+		})
+	}
+
+	rec, err := array.RecordFromMaps(mem, schema, []map[string]interface{}{
+		{"i8": -128, "u32": 4294967295.0, "fsl": nil},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+	if got, want := rec.Column(3).Data().Children()[0].Len(), 2; got != want {
+		t.Fatalf("null fixed size list must hold %d child slots, got %d", want, got)
+	}
+}
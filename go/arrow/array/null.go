@@ -67,6 +67,12 @@ func (a *Null) String() string {
 	return o.String()
 }
 
+// IsNull returns true, all the values of a Null array being null.
+func (a *Null) IsNull(i int) bool { return true }
+
+// IsValid returns false, all the values of a Null array being null.
+func (a *Null) IsValid(i int) bool { return false }
+
 func (a *Null) setData(data *Data) {
 	a.array.setData(data)
 	a.array.nullBitmapBytes = nil
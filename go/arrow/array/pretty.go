@@ -0,0 +1,323 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/apache/arrow/go/arrow"
+	"golang.org/x/text/width"
+	"golang.org/x/xerrors"
+)
+
+// PrettyOption configures how records and tables are rendered by
+// RecordToString and TableString.
+type PrettyOption func(*prettyConfig)
+
+type prettyConfig struct {
+	rows  int
+	width int
+	null  string
+	depth int
+	meta  bool
+}
+
+// WithPrettyMaxRows sets the maximum number of rows rendered. The rows past
+// the limit are elided in the middle, keeping the first and last rows, and
+// replaced with a "... N more rows" line. If n is zero or negative, all the
+// rows are rendered, which is the default.
+func WithPrettyMaxRows(n int) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.rows = n }
+}
+
+// WithPrettyMaxWidth sets the maximum width of the columns, in terminal
+// cells. Longer values are truncated and end with "…". If n is zero or
+// negative, which is the default, the columns are as wide as their values.
+func WithPrettyMaxWidth(n int) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.width = n }
+}
+
+// WithPrettyNull sets the string rendered for null values, "(null)" by
+// default.
+func WithPrettyNull(null string) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.null = null }
+}
+
+// WithPrettyMaxDepth sets the number of levels of nested values rendered.
+// The lists and structs nested deeper are rendered as "[...]" and "{...}".
+// If n is zero or negative, which is the default, all the levels are
+// rendered.
+func WithPrettyMaxDepth(n int) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.depth = n }
+}
+
+// WithPrettyMetadata sets whether the metadata of the schema and of its
+// fields are rendered before the rows.
+func WithPrettyMetadata(v bool) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.meta = v }
+}
+
+// RecordToString renders rec as a table with one line per row, preceded by
+// the names and types of its columns, such as:
+//
+//	id    | name
+//	int64 | utf8
+//	------+-------
+//	1     | alice
+//	2     | (null)
+//
+// The output does not depend on anything but rec and opts, so that it can
+// be compared in tests.
+func RecordToString(rec Record, opts ...PrettyOption) string {
+	cols := make([][]Interface, rec.NumCols())
+	for i, col := range rec.Columns() {
+		cols[i] = []Interface{col}
+	}
+	return prettyString(rec.Schema(), cols, rec.NumRows(), opts)
+}
+
+// TableString renders tbl as RecordToString renders records, reading the
+// rows from the chunks of its columns.
+func TableString(tbl Table, opts ...PrettyOption) string {
+	cols := make([][]Interface, tbl.NumCols())
+	for i := range cols {
+		cols[i] = tbl.Column(i).Data().Chunks()
+	}
+	return prettyString(tbl.Schema(), cols, tbl.NumRows(), opts)
+}
+
+func prettyString(schema *arrow.Schema, cols [][]Interface, nrows int64, opts []PrettyOption) string {
+	cfg := prettyConfig{null: "(null)"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// rows to render, and where to elide the others.
+	var (
+		rows  []int64
+		elide = -1
+	)
+	switch {
+	case cfg.rows <= 0 || nrows <= int64(cfg.rows):
+		for i := int64(0); i < nrows; i++ {
+			rows = append(rows, i)
+		}
+	default:
+		head := int64(cfg.rows+1) / 2
+		tail := int64(cfg.rows) / 2
+		for i := int64(0); i < head; i++ {
+			rows = append(rows, i)
+		}
+		elide = len(rows)
+		for i := nrows - tail; i < nrows; i++ {
+			rows = append(rows, i)
+		}
+	}
+
+	// cells[0] and cells[1] are the names and types of the columns.
+	cells := make([][]string, 2+len(rows))
+	for i := range cells {
+		cells[i] = make([]string, len(cols))
+	}
+	widths := make([]int, len(cols))
+	for j, field := range schema.Fields() {
+		cells[0][j] = cfg.truncate(escapeControl(field.Name))
+		cells[1][j] = cfg.truncate(fmt.Sprint(field.Type))
+		for i, row := range rows {
+			arr, k := chunkAt(cols[j], row)
+			cells[2+i][j] = cfg.truncate(cfg.value(arr, k, 0, false))
+		}
+		for i := range cells {
+			if w := displayWidth(cells[i][j]); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	o := new(strings.Builder)
+	if cfg.meta {
+		if schema.HasMetadata() {
+			fmt.Fprintf(o, "metadata: %v\n", schema.Metadata())
+		}
+		for _, field := range schema.Fields() {
+			if field.HasMetadata() {
+				fmt.Fprintf(o, "%s metadata: %v\n", escapeControl(field.Name), field.Metadata)
+			}
+		}
+	}
+	writeLine := func(line []string) {
+		s := new(strings.Builder)
+		for j, cell := range line {
+			if j > 0 {
+				s.WriteString(" | ")
+			}
+			s.WriteString(cell)
+			s.WriteString(strings.Repeat(" ", widths[j]-displayWidth(cell)))
+		}
+		o.WriteString(strings.TrimRight(s.String(), " "))
+		o.WriteString("\n")
+	}
+	writeLine(cells[0])
+	writeLine(cells[1])
+	for j, w := range widths {
+		if j > 0 {
+			o.WriteString("-+-")
+		}
+		o.WriteString(strings.Repeat("-", w))
+	}
+	o.WriteString("\n")
+	for i := range rows {
+		if i == elide {
+			fmt.Fprintf(o, "... %d more rows\n", nrows-int64(len(rows)))
+		}
+		writeLine(cells[2+i])
+	}
+	if elide == len(rows) {
+		fmt.Fprintf(o, "... %d more rows\n", nrows-int64(len(rows)))
+	}
+	return o.String()
+}
+
+// chunkAt returns the chunk of chunks holding the row i, and the index of
+// the row in the chunk.
+func chunkAt(chunks []Interface, i int64) (Interface, int) {
+	for _, chunk := range chunks {
+		if n := int64(chunk.Len()); i >= n {
+			i -= n
+			continue
+		}
+		return chunk, int(i)
+	}
+	panic(xerrors.Errorf("arrow/array: row %d out of bounds", i))
+}
+
+// value renders the value of arr at i, nested in depth lists or structs.
+// The strings of nested values are quoted.
+func (cfg *prettyConfig) value(arr Interface, i, depth int, nested bool) string {
+	if arr.IsNull(i) {
+		return cfg.null
+	}
+	switch a := arr.(type) {
+	case *List, *FixedSizeList:
+		if cfg.depth > 0 && depth >= cfg.depth {
+			return "[...]"
+		}
+		var (
+			values   Interface
+			beg, end int
+		)
+		switch a := a.(type) {
+		case *List:
+			j := i + a.Data().Offset()
+			values, beg, end = a.ListValues(), int(a.Offsets()[j]), int(a.Offsets()[j+1])
+		case *FixedSizeList:
+			n := int(a.DataType().(*arrow.FixedSizeListType).Len())
+			j := i + a.Data().Offset()
+			values, beg, end = a.ListValues(), j*n, (j+1)*n
+		}
+		vs := make([]string, 0, end-beg)
+		for k := beg; k < end; k++ {
+			vs = append(vs, cfg.value(values, k, depth+1, true))
+		}
+		return "[" + strings.Join(vs, " ") + "]"
+	case *Struct:
+		if cfg.depth > 0 && depth >= cfg.depth {
+			return "{...}"
+		}
+		dt := a.DataType().(*arrow.StructType)
+		vs := make([]string, a.NumField())
+		for k := range vs {
+			vs[k] = dt.Field(k).Name + ": " + cfg.value(a.Field(k), i, depth+1, true)
+		}
+		return "{" + strings.Join(vs, ", ") + "}"
+	case *Dictionary:
+		return cfg.value(a.Dictionary(), a.GetValueIndex(i), depth, nested)
+	case *RunEndEncoded:
+		return cfg.value(a.Values(), a.GetPhysicalIndex(i), depth, nested)
+	case unionArray:
+		return cfg.value(a.Field(a.ChildID(i)), a.valueIndex(i), depth, nested)
+	case ExtensionArray:
+		return cfg.value(a.Storage(), i, depth, nested)
+	}
+
+	switch v := goValue(arr, i).(type) {
+	case string:
+		if nested {
+			return strconv.Quote(v)
+		}
+		return escapeControl(v)
+	case []byte:
+		return fmt.Sprintf("%q", v)
+	case time.Time:
+		switch arr.(type) {
+		case *Date32, *Date64:
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// truncate truncates s to the maximum width of the columns.
+func (cfg *prettyConfig) truncate(s string) string {
+	if cfg.width <= 0 || displayWidth(s) <= cfg.width {
+		return s
+	}
+	n := 0
+	for i, r := range s {
+		if n+runeWidth(r) > cfg.width-1 {
+			return s[:i] + "…"
+		}
+		n += runeWidth(r)
+	}
+	return s
+}
+
+// escapeControl escapes the control characters of s, such as new lines,
+// which would break the alignment of the columns.
+func escapeControl(s string) string {
+	if strings.IndexFunc(s, unicode.IsControl) < 0 {
+		return s
+	}
+	q := strconv.Quote(s)
+	return q[1 : len(q)-1]
+}
+
+// displayWidth returns the number of terminal cells taken by s.
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+func runeWidth(r rune) int {
+	if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
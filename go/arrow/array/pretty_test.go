@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestRecordToString(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	inner := arrow.StructOf(
+		arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+	)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true, Metadata: arrow.NewMetadata([]string{"k"}, []string{"v"})},
+		{Name: "nested", Type: arrow.StructOf(arrow.Field{Name: "inner", Type: inner}), Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"alice", "", "日本語のテキスト", "a\nb", "bob"}, []bool{true, false, true, true, true})
+	sb := b.Field(2).(*array.StructBuilder)
+	ib := sb.FieldBuilder(0).(*array.StructBuilder)
+	xb := ib.FieldBuilder(0).(*array.Int32Builder)
+	lb := ib.FieldBuilder(1).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*array.StringBuilder)
+	for i := 0; i < 5; i++ {
+		if i == 1 {
+			sb.AppendNull()
+			continue
+		}
+		sb.Append(true)
+		ib.Append(true)
+		xb.Append(int32(i))
+		lb.Append(true)
+		for j := 0; j < i; j++ {
+			vb.Append(string(rune('a' + j)))
+		}
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		opts []array.PrettyOption
+		want string
+	}{
+		{
+			name: "default",
+			want: `id    | name             | nested
+int64 | utf8             | struct<inner: struct<x: int32, tags: list<item: utf8>>>
+------+------------------+--------------------------------------------------------
+1     | alice            | {inner: {x: 0, tags: []}}
+2     | (null)           | (null)
+3     | 日本語のテキスト | {inner: {x: 2, tags: ["a" "b"]}}
+4     | a\nb             | {inner: {x: 3, tags: ["a" "b" "c"]}}
+5     | bob              | {inner: {x: 4, tags: ["a" "b" "c" "d"]}}
+`,
+		},
+		{
+			name: "rows-width-null",
+			opts: []array.PrettyOption{
+				array.WithPrettyMaxRows(2),
+				array.WithPrettyMaxWidth(10),
+				array.WithPrettyNull("NA"),
+			},
+			want: `id    | name  | nested
+int64 | utf8  | struct<in…
+------+-------+-----------
+1     | alice | {inner: {…
+... 3 more rows
+5     | bob   | {inner: {…
+`,
+		},
+		{
+			name: "wide-truncated",
+			opts: []array.PrettyOption{
+				array.WithPrettyMaxWidth(8),
+			},
+			want: `id    | name    | nested
+int64 | utf8    | struct<…
+------+---------+---------
+1     | alice   | {inner:…
+2     | (null)  | (null)
+3     | 日本語… | {inner:…
+4     | a\nb    | {inner:…
+5     | bob     | {inner:…
+`,
+		},
+		{
+			name: "depth-metadata",
+			opts: []array.PrettyOption{
+				array.WithPrettyMaxDepth(2),
+				array.WithPrettyMetadata(true),
+			},
+			want: `name metadata: ["k": "v"]
+id    | name             | nested
+int64 | utf8             | struct<inner: struct<x: int32, tags: list<item: utf8>>>
+------+------------------+--------------------------------------------------------
+1     | alice            | {inner: {x: 0, tags: [...]}}
+2     | (null)           | (null)
+3     | 日本語のテキスト | {inner: {x: 2, tags: [...]}}
+4     | a\nb             | {inner: {x: 3, tags: [...]}}
+5     | bob              | {inner: {x: 4, tags: [...]}}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := array.RecordToString(rec, tc.opts...); got != tc.want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTableString(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	meta := arrow.MetadataFrom(map[string]string{"origin": "test"})
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+	}, &meta)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i := 0; i < 3; i++ {
+		b.Field(0).(*array.Float64Builder).AppendValues([]float64{float64(i), 0.5}, []bool{true, i != 1})
+		b.Field(1).(*array.Date32Builder).AppendValues([]arrow.Date32{arrow.Date32(18629 + i), 0}, nil)
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	const want = `metadata: ["origin": "test"]
+f64     | date
+float64 | date32
+--------+-----------
+0       | 2021-01-02
+0.5     | 1970-01-01
+1       | 2021-01-03
+... 1 more rows
+2       | 2021-01-04
+0.5     | 1970-01-01
+`
+	got := array.TableString(tbl, array.WithPrettyMaxRows(5), array.WithPrettyMetadata(true))
+	if got != want {
+		t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
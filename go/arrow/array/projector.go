@@ -0,0 +1,224 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// ProjectOption configures a SchemaProjector.
+type ProjectOption func(*projectConfig)
+
+type projectConfig struct {
+	mem         memory.Allocator
+	fillNulls   bool
+	dropUnknown bool
+	foldCase    bool
+}
+
+// WithProjectAllocator sets the allocator of the null arrays filling the
+// missing columns. The default is memory.DefaultAllocator.
+func WithProjectAllocator(mem memory.Allocator) ProjectOption {
+	return func(cfg *projectConfig) { cfg.mem = mem }
+}
+
+// WithProjectFillNulls sets whether the nullable fields of the target
+// schema missing from a record are filled with nulls. Otherwise, and for
+// non-nullable fields, missing fields are an error.
+func WithProjectFillNulls(v bool) ProjectOption {
+	return func(cfg *projectConfig) { cfg.fillNulls = v }
+}
+
+// WithProjectDropUnknown sets whether the fields of a record which are not
+// in the target schema are dropped. Otherwise, they are an error.
+func WithProjectDropUnknown(v bool) ProjectOption {
+	return func(cfg *projectConfig) { cfg.dropUnknown = v }
+}
+
+// WithProjectFoldCase sets whether the names of the fields are matched
+// case-insensitively, exact matches being preferred.
+func WithProjectFoldCase(v bool) ProjectOption {
+	return func(cfg *projectConfig) { cfg.foldCase = v }
+}
+
+// SchemaProjector projects records onto a target schema, matching their
+// columns by name: columns are reordered, missing ones filled and unknown
+// ones dropped, depending on its options. The fields of struct columns are
+// projected likewise.
+//
+// Columns whose type already is the target one are reused as is, without
+// copies; no type is ever cast.
+type SchemaProjector struct {
+	schema *arrow.Schema
+	cfg    projectConfig
+}
+
+// NewSchemaProjector returns a projector of records onto schema.
+func NewSchemaProjector(schema *arrow.Schema, opts ...ProjectOption) *SchemaProjector {
+	p := &SchemaProjector{
+		schema: schema,
+		cfg:    projectConfig{mem: memory.DefaultAllocator},
+	}
+	for _, opt := range opts {
+		opt(&p.cfg)
+	}
+	return p
+}
+
+// Schema returns the target schema.
+func (p *SchemaProjector) Schema() *arrow.Schema { return p.schema }
+
+// Project returns a record of the target schema made of the columns of rec.
+// The returned record must be released by the caller.
+func (p *SchemaProjector) Project(rec Record) (Record, error) {
+	idx, err := p.match(p.schema.Fields(), rec.Schema().Fields(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Interface, len(idx))
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+	for i, field := range p.schema.Fields() {
+		if idx[i] < 0 {
+			cols[i] = makeNulls(p.cfg.mem, field.Type, int(rec.NumRows()))
+			continue
+		}
+		col := rec.Column(idx[i])
+		if !field.Nullable && col.NullN() > 0 {
+			return nil, xerrors.Errorf("arrow/array: required field %q has %d nulls", field.Name, col.NullN())
+		}
+		cols[i], err = p.project(field, col, field.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewRecord(p.schema, cols, rec.NumRows()), nil
+}
+
+// match returns the indices of the src fields matching the dst ones, -1 for
+// the missing ones which are filled with nulls.
+func (p *SchemaProjector) match(dst, src []arrow.Field, path string) ([]int, error) {
+	var (
+		idx  = make([]int, len(dst))
+		used = make([]bool, len(src))
+	)
+	for i, field := range dst {
+		idx[i] = -1
+		for j := range src {
+			if !used[j] && src[j].Name == field.Name {
+				idx[i] = j
+				break
+			}
+		}
+		if idx[i] < 0 && p.cfg.foldCase {
+			for j := range src {
+				if !used[j] && strings.EqualFold(src[j].Name, field.Name) {
+					idx[i] = j
+					break
+				}
+			}
+		}
+		switch {
+		case idx[i] >= 0:
+			used[idx[i]] = true
+		case !field.Nullable:
+			return nil, xerrors.Errorf("arrow/array: missing required field %q", path+field.Name)
+		case !p.cfg.fillNulls:
+			return nil, xerrors.Errorf("arrow/array: missing field %q", path+field.Name)
+		}
+	}
+
+	if !p.cfg.dropUnknown {
+		for j, ok := range used {
+			if !ok {
+				return nil, xerrors.Errorf("arrow/array: unknown field %q", path+src[j].Name)
+			}
+		}
+	}
+	return idx, nil
+}
+
+// project returns arr, of the field at path, projected onto field.
+func (p *SchemaProjector) project(field arrow.Field, arr Interface, path string) (Interface, error) {
+	if arrow.TypeEqual(field.Type, arr.DataType()) {
+		arr.Retain()
+		return arr, nil
+	}
+
+	dst, ok := field.Type.(*arrow.StructType)
+	if !ok {
+		return nil, xerrors.Errorf("arrow/array: field %q type mismatch: got=%v, want=%v", path, arr.DataType(), field.Type)
+	}
+	src, ok := arr.DataType().(*arrow.StructType)
+	if !ok {
+		return nil, xerrors.Errorf("arrow/array: field %q type mismatch: got=%v, want=%v", path, arr.DataType(), field.Type)
+	}
+
+	idx, err := p.match(dst.Fields(), src.Fields(), path+".")
+	if err != nil {
+		return nil, err
+	}
+
+	// the children of a struct are not sliced by its offset: the projected
+	// ones are taken from its data, with the same offset, and the filled
+	// ones cover it.
+	var (
+		data     = arr.Data()
+		children = make([]*Data, len(idx))
+	)
+	defer func() {
+		for _, child := range children {
+			if child != nil {
+				child.Release()
+			}
+		}
+	}()
+	for i, f := range dst.Fields() {
+		if idx[i] < 0 {
+			nulls := makeNulls(p.cfg.mem, f.Type, data.offset+data.length)
+			children[i] = nulls.Data()
+			children[i].Retain()
+			nulls.Release()
+			continue
+		}
+
+		child := MakeFromData(data.childData[idx[i]])
+		col, err := p.project(f, child, path+"."+f.Name)
+		child.Release()
+		if err != nil {
+			return nil, err
+		}
+		children[i] = col.Data()
+		children[i].Retain()
+		col.Release()
+	}
+
+	out := NewData(field.Type, data.length, data.buffers[:1], children, data.nulls, data.offset)
+	defer out.Release()
+	return MakeFromData(out), nil
+}
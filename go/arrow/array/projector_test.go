@@ -0,0 +1,240 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestSchemaProjector(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	srcStruct := arrow.StructOf(
+		arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "extra", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "x", Type: arrow.BinaryTypes.String},
+	)
+	src := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "B", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "extra", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "s", Type: srcStruct, Nullable: true},
+			{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+		},
+		nil,
+	)
+
+	rb := array.NewRecordBuilder(mem, src)
+	defer rb.Release()
+
+	rb.Field(0).(*array.StringBuilder).AppendValues([]string{"b0", "b1", "", "b3", "b4"}, []bool{true, true, false, true, true})
+	rb.Field(1).(*array.Float64Builder).AppendValues([]float64{0, 1, 2, 3, 4}, nil)
+	sb := rb.Field(2).(*array.StructBuilder)
+	for i := 0; i < 5; i++ {
+		sb.Append(i != 3)
+		sb.FieldBuilder(0).(*array.Int32Builder).Append(int32(i))
+		sb.FieldBuilder(1).(*array.Float64Builder).Append(float64(i))
+		sb.FieldBuilder(2).(*array.StringBuilder).Append(string(rune('a' + i)))
+	}
+	rb.Field(3).(*array.Int64Builder).AppendValues([]int64{0, 10, 20, 30, 40}, nil)
+
+	full := rb.NewRecord()
+	defer full.Release()
+	rec := full.NewSlice(1, 5)
+	defer rec.Release()
+
+	dstStruct := arrow.StructOf(
+		arrow.Field{Name: "x", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "w", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Int32},
+	)
+	dst := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "c", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			{Name: "s", Type: dstStruct, Nullable: true},
+		},
+		nil,
+	)
+
+	t.Run("all-options", func(t *testing.T) {
+		p := array.NewSchemaProjector(dst,
+			array.WithProjectAllocator(mem),
+			array.WithProjectFillNulls(true),
+			array.WithProjectDropUnknown(true),
+			array.WithProjectFoldCase(true),
+		)
+		if !p.Schema().Equal(dst) {
+			t.Fatalf("invalid schema: got=%v, want=%v", p.Schema(), dst)
+		}
+
+		out, err := p.Project(rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+
+		if !out.Schema().Equal(dst) {
+			t.Fatalf("invalid schema: got=%v, want=%v", out.Schema(), dst)
+		}
+		if got, want := out.NumRows(), int64(4); got != want {
+			t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+		}
+
+		// the columns of the right type are not copied.
+		if out.Column(0) != rec.Column(3) {
+			t.Fatalf("column a was copied")
+		}
+		if out.Column(1) != rec.Column(0) {
+			t.Fatalf("column b was copied")
+		}
+
+		if got, want := out.Column(2).NullN(), 4; got != want {
+			t.Fatalf("column c: invalid number of nulls: got=%d, want=%d", got, want)
+		}
+
+		got := out.Column(3).(*array.Struct)
+		want := rec.Column(2).(*array.Struct)
+		if got.Data().Buffers()[0] != want.Data().Buffers()[0] {
+			t.Fatalf("struct validity was copied")
+		}
+		for _, tc := range []struct{ dst, src int }{{0, 2}, {2, 0}} {
+			g, w := got.Field(tc.dst), want.Field(tc.src)
+			if g.Data().Buffers()[1] != w.Data().Buffers()[1] {
+				t.Fatalf("struct field %d was copied", tc.dst)
+			}
+			if !array.ArrayEqual(g, w) {
+				t.Fatalf("struct field %d: got=%v, want=%v", tc.dst, g, w)
+			}
+		}
+		if got, want := got.Field(1).Len(), 4; got != want {
+			t.Fatalf("struct field w: invalid length: got=%d, want=%d", got, want)
+		}
+		if got, want := got.Field(1).NullN(), 4; got != want {
+			t.Fatalf("struct field w: invalid number of nulls: got=%d, want=%d", got, want)
+		}
+		for i := 0; i < got.Len(); i++ {
+			if got.IsValid(i) != want.IsValid(i) {
+				t.Fatalf("struct validity differ at row %d", i)
+			}
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		p := array.NewSchemaProjector(src, array.WithProjectAllocator(mem))
+		out, err := p.Project(rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+
+		for i, col := range out.Columns() {
+			if col != rec.Column(i) {
+				t.Fatalf("column %d was copied", i)
+			}
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		schema *arrow.Schema
+		opts   []array.ProjectOption
+		err    string
+	}{
+		{
+			name:   "missing",
+			schema: dst,
+			opts:   []array.ProjectOption{array.WithProjectDropUnknown(true), array.WithProjectFoldCase(true)},
+			err:    `missing field "c"`,
+		},
+		{
+			name: "missing-required",
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+				{Name: "d", Type: arrow.PrimitiveTypes.Int64},
+			}, nil),
+			opts: []array.ProjectOption{array.WithProjectFillNulls(true), array.WithProjectDropUnknown(true)},
+			err:  `missing required field "d"`,
+		},
+		{
+			name: "missing-required-nested",
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "s", Type: arrow.StructOf(arrow.Field{Name: "v", Type: arrow.PrimitiveTypes.Int32}), Nullable: true},
+			}, nil),
+			opts: []array.ProjectOption{array.WithProjectFillNulls(true), array.WithProjectDropUnknown(true)},
+			err:  `missing required field "s.v"`,
+		},
+		{
+			name:   "unknown",
+			schema: dst,
+			opts:   []array.ProjectOption{array.WithProjectFillNulls(true), array.WithProjectFoldCase(true)},
+			err:    `unknown field "extra"`,
+		},
+		{
+			name: "unknown-nested",
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+				{Name: "B", Type: arrow.BinaryTypes.String, Nullable: true},
+				{Name: "extra", Type: arrow.PrimitiveTypes.Float64},
+				{Name: "s", Type: dstStruct, Nullable: true},
+			}, nil),
+			opts: []array.ProjectOption{array.WithProjectFillNulls(true)},
+			err:  `unknown field "s.extra"`,
+		},
+		{
+			name:   "case-sensitive",
+			schema: dst,
+			opts:   []array.ProjectOption{array.WithProjectDropUnknown(true)},
+			err:    `missing field "b"`,
+		},
+		{
+			name: "type-mismatch",
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+			}, nil),
+			opts: []array.ProjectOption{array.WithProjectDropUnknown(true)},
+			err:  `field "a" type mismatch`,
+		},
+		{
+			name: "required-nulls",
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "B", Type: arrow.BinaryTypes.String},
+			}, nil),
+			opts: []array.ProjectOption{array.WithProjectDropUnknown(true)},
+			err:  `required field "B" has 1 nulls`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := array.NewSchemaProjector(tc.schema, append(tc.opts, array.WithProjectAllocator(mem))...)
+			out, err := p.Project(rec)
+			if err == nil {
+				out.Release()
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%q, want=%q", err, tc.err)
+			}
+		})
+	}
+}
@@ -231,6 +231,11 @@ func (rec *simpleRecord) ColumnName(i int) string { return rec.schema.Field(i).N
 // NewSlice panics if the slice is outside the valid range of the record array.
 // NewSlice panics if j < i.
 func (rec *simpleRecord) NewSlice(i, j int64) Record {
+	// the bounds are checked against the rows of the record, rather than
+	// the lengths of its columns.
+	if i < 0 || j > rec.rows || i > j {
+		panic("arrow/array: index out of range")
+	}
 	arrs := make([]Interface, len(rec.arrs))
 	for ii, arr := range rec.arrs {
 		arrs[ii] = NewSlice(arr, i, j)
@@ -243,6 +248,52 @@ func (rec *simpleRecord) NewSlice(i, j int64) Record {
 	return NewRecord(rec.schema, arrs, j-i)
 }
 
+type sliceConfig struct {
+	clamp bool
+}
+
+// SliceOption is a functional option type used to configure SliceRecord.
+type SliceOption func(*sliceConfig)
+
+// WithClampedBounds configures SliceRecord to clamp the bounds of the slice
+// to the rows of the record, instead of failing.
+func WithClampedBounds() SliceOption {
+	return func(cfg *sliceConfig) {
+		cfg.clamp = true
+	}
+}
+
+// SliceRecord returns a zero-copy slice of rec, as rec.NewSlice(i, j), or an
+// error when i and j are not such that 0 <= i <= j <= rec.NumRows().
+//
+// The returned record must be Release()'d after use.
+func SliceRecord(rec Record, i, j int64, opts ...SliceOption) (Record, error) {
+	var cfg sliceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	rows := rec.NumRows()
+	if cfg.clamp {
+		switch {
+		case i < 0:
+			i = 0
+		case i > rows:
+			i = rows
+		}
+		switch {
+		case j < i:
+			j = i
+		case j > rows:
+			j = rows
+		}
+	}
+	if i < 0 || j > rows || i > j {
+		return nil, fmt.Errorf("arrow/array: slice [%d:%d] out of range of a record of %d rows", i, j, rows)
+	}
+	return rec.NewSlice(i, j), nil
+}
+
 func (rec *simpleRecord) String() string {
 	o := new(strings.Builder)
 	fmt.Fprintf(o, "record:\n  %v\n", rec.schema)
@@ -0,0 +1,406 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// RunEndEncoded represents an immutable sequence of runs of values: the
+// j-th value of Values is repeated up to the logical index, exclusive,
+// given by the j-th value of RunEndsArr.
+//
+// The offset and length of a RunEndEncoded array are logical: slicing it
+// does not slice its run ends and values, see GetPhysicalIndex.
+type RunEndEncoded struct {
+	array
+	ends   Interface
+	values Interface
+}
+
+// NewRunEndEncodedData returns a new RunEndEncoded array value, from data.
+func NewRunEndEncodedData(data *Data) *RunEndEncoded {
+	a := &RunEndEncoded{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// NewRunEndEncoded returns a RunEndEncoded array of the given logical
+// length and offset, from the run ends and values of its runs.
+//
+// NewRunEndEncoded panics if runEnds has nulls, if runEnds and values do
+// not have the same length or if the runs end before offset+length.
+func NewRunEndEncoded(runEnds, values Interface, length, offset int) *RunEndEncoded {
+	switch {
+	case runEnds.NullN() != 0:
+		panic("arrow/array: run ends with nulls")
+	case runEnds.Len() != values.Len():
+		panic("arrow/array: run ends and values length mismatch")
+	}
+
+	dt := arrow.RunEndEncodedOf(runEnds.DataType(), values.DataType())
+	data := NewData(dt, length, []*memory.Buffer{nil}, []*Data{runEnds.Data(), values.Data()}, 0, offset)
+	defer data.Release()
+
+	a := NewRunEndEncodedData(data)
+	if n := a.ends.Len(); length > 0 && (n == 0 || a.runEnd(n-1) < offset+length) {
+		a.Release()
+		panic("arrow/array: index out of range")
+	}
+	return a
+}
+
+// RunEndsArr returns the logical end of each run.
+func (a *RunEndEncoded) RunEndsArr() Interface { return a.ends }
+
+// Values returns the value of each run.
+func (a *RunEndEncoded) Values() Interface { return a.values }
+
+// GetPhysicalIndex returns the index in RunEndsArr and Values of the run
+// holding the logical value i.
+func (a *RunEndEncoded) GetPhysicalIndex(i int) int {
+	i += a.array.data.offset
+	return sort.Search(a.ends.Len(), func(j int) bool { return a.runEnd(j) > i })
+}
+
+// GetPhysicalOffset returns the index of the first run of the array.
+func (a *RunEndEncoded) GetPhysicalOffset() int { return a.GetPhysicalIndex(0) }
+
+// GetPhysicalLength returns the number of runs of the array.
+func (a *RunEndEncoded) GetPhysicalLength() int {
+	if a.Len() == 0 {
+		return 0
+	}
+	return a.GetPhysicalIndex(a.Len()-1) - a.GetPhysicalOffset() + 1
+}
+
+// LogicalRunEndsArr returns the run ends of the runs of the array, relative
+// to its offset and bounded by its length. It is the run ends array itself
+// when the array is not sliced.
+// The returned array must be Release()'d after use.
+func (a *RunEndEncoded) LogicalRunEndsArr(mem memory.Allocator) Interface {
+	beg, n := a.GetPhysicalOffset(), a.GetPhysicalLength()
+	if a.array.data.offset == 0 && (n == 0 || a.runEnd(beg+n-1) == a.Len()) {
+		return NewSlice(a.ends, int64(beg), int64(beg+n))
+	}
+
+	bldr := NewBuilder(mem, a.ends.DataType())
+	defer bldr.Release()
+	bldr.Reserve(n)
+	for j := beg; j < beg+n; j++ {
+		end := a.runEnd(j) - a.array.data.offset
+		if end > a.Len() {
+			end = a.Len()
+		}
+		switch bldr := bldr.(type) {
+		case *Int16Builder:
+			bldr.UnsafeAppend(int16(end))
+		case *Int32Builder:
+			bldr.UnsafeAppend(int32(end))
+		case *Int64Builder:
+			bldr.UnsafeAppend(int64(end))
+		}
+	}
+	return bldr.NewArray()
+}
+
+// LogicalValuesArr returns the values of the runs of the array.
+// The returned array must be Release()'d after use.
+func (a *RunEndEncoded) LogicalValuesArr() Interface {
+	beg := a.GetPhysicalOffset()
+	return NewSlice(a.values, int64(beg), int64(beg+a.GetPhysicalLength()))
+}
+
+// IsNull returns true if the logical value at index is null.
+func (a *RunEndEncoded) IsNull(i int) bool { return a.values.IsNull(a.GetPhysicalIndex(i)) }
+
+// IsValid returns true if the logical value at index is not null.
+func (a *RunEndEncoded) IsValid(i int) bool { return a.values.IsValid(a.GetPhysicalIndex(i)) }
+
+// runEnd returns the j-th run end, regardless of the run ends type.
+func (a *RunEndEncoded) runEnd(j int) int {
+	switch ends := a.ends.(type) {
+	case *Int16:
+		return int(ends.Value(j))
+	case *Int32:
+		return int(ends.Value(j))
+	default:
+		return int(ends.(*Int64).Value(j))
+	}
+}
+
+func (a *RunEndEncoded) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		if a.IsNull(i) {
+			o.WriteString("(null)")
+			continue
+		}
+		o.WriteString(valueString(a.values, int64(a.GetPhysicalIndex(i))))
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+func (a *RunEndEncoded) setData(data *Data) {
+	a.array.setData(data)
+	a.ends = MakeFromData(data.childData[0])
+	a.values = MakeFromData(data.childData[1])
+}
+
+func (a *RunEndEncoded) Retain() {
+	a.array.Retain()
+	a.ends.Retain()
+	a.values.Retain()
+}
+
+func (a *RunEndEncoded) Release() {
+	a.array.Release()
+	a.ends.Release()
+	a.values.Release()
+}
+
+// runsEqual reports whether the logical values of left and right are
+// equal, comparing the values of the runs overlapping with eq.
+func runsEqual(left, right *RunEndEncoded, eq func(l, r Interface) bool) bool {
+	li, ri := left.GetPhysicalOffset(), right.GetPhysicalOffset()
+	for pos := 0; pos < left.Len(); {
+		lend := left.runEnd(li) - left.array.data.offset
+		rend := right.runEnd(ri) - right.array.data.offset
+		o := func() bool {
+			l := NewSlice(left.values, int64(li), int64(li+1))
+			defer l.Release()
+			r := NewSlice(right.values, int64(ri), int64(ri+1))
+			defer r.Release()
+			return eq(l, r)
+		}()
+		if !o {
+			return false
+		}
+
+		pos = lend
+		if rend < pos {
+			pos = rend
+		}
+		if lend == pos {
+			li++
+		}
+		if rend == pos {
+			ri++
+		}
+	}
+	return true
+}
+
+func arrayEqualRunEndEncoded(left, right *RunEndEncoded) bool {
+	return runsEqual(left, right, ArrayEqual)
+}
+
+func arrayApproxEqualRunEndEncoded(left, right *RunEndEncoded, opt equalOption) bool {
+	return runsEqual(left, right, func(l, r Interface) bool { return arrayApproxEqual(l, r, opt) })
+}
+
+// RunEndEncodedBuilder builds RunEndEncoded arrays: each run is started
+// with Append, its value being the next one appended to ValueBuilder.
+type RunEndEncodedBuilder struct {
+	builder
+
+	dt     *arrow.RunEndEncodedType
+	ends   []int64 // logical end of each run.
+	maxEnd int64   // maximum run end representable by the run ends type.
+	values Builder // value builder for the values of the runs.
+}
+
+// NewRunEndEncodedBuilder returns a builder, using the provided memory allocator.
+// The created builder will create run-end encoded arrays with run ends of
+// type runEnds and values of type encoded.
+func NewRunEndEncodedBuilder(mem memory.Allocator, runEnds, encoded arrow.DataType) *RunEndEncodedBuilder {
+	dt := arrow.RunEndEncodedOf(runEnds, encoded)
+	maxEnd := int64(math.MaxInt64)
+	switch runEnds.ID() {
+	case arrow.INT16:
+		maxEnd = math.MaxInt16
+	case arrow.INT32:
+		maxEnd = math.MaxInt32
+	}
+	return &RunEndEncodedBuilder{
+		builder: builder{refCount: 1, mem: mem},
+		dt:      dt,
+		maxEnd:  maxEnd,
+		values:  NewBuilder(mem, encoded),
+	}
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *RunEndEncodedBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.values != nil {
+			b.values.Release()
+			b.values = nil
+		}
+		b.ends = nil
+	}
+}
+
+// Append starts a run of n logical values, whose value is the next one
+// appended to ValueBuilder.
+//
+// Append panics if n is zero or if the run ends overflow their type.
+func (b *RunEndEncodedBuilder) Append(n uint64) {
+	if n == 0 {
+		panic("arrow/array: empty run")
+	}
+	b.ends = append(b.ends, b.grow(n))
+}
+
+// AppendRuns starts runs of the given lengths, whose values are the next
+// ones appended to ValueBuilder.
+func (b *RunEndEncodedBuilder) AppendRuns(runs []uint64) {
+	for _, n := range runs {
+		b.Append(n)
+	}
+}
+
+// ContinueRun extends the last run by n logical values, starting a run of
+// nulls when there is none.
+func (b *RunEndEncodedBuilder) ContinueRun(n uint64) {
+	if len(b.ends) == 0 {
+		b.Append(n)
+		b.values.AppendNull()
+		return
+	}
+	b.ends[len(b.ends)-1] = b.grow(n)
+}
+
+// AppendNull appends a run of a single null value.
+func (b *RunEndEncodedBuilder) AppendNull() {
+	b.Append(1)
+	b.values.AppendNull()
+}
+
+// grow adds n logical values to the builder and returns the new length.
+func (b *RunEndEncodedBuilder) grow(n uint64) int64 {
+	if n > uint64(b.maxEnd-int64(b.length)) {
+		panic("arrow/array: run end overflow")
+	}
+	b.length += int(n)
+	return int64(b.length)
+}
+
+// Cap returns the number of runs that can be appended without allocating
+// additional memory.
+func (b *RunEndEncodedBuilder) Cap() int { return b.values.Cap() }
+
+// Reserve ensures there is enough space for appending n runs
+// by checking the capacity and calling Resize if necessary.
+func (b *RunEndEncodedBuilder) Reserve(n int) { b.values.Reserve(n) }
+
+// Resize adjusts the space allocated by b to n runs. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *RunEndEncodedBuilder) Resize(n int) { b.values.Resize(n) }
+
+func (b *RunEndEncodedBuilder) ValueBuilder() Builder {
+	return b.values
+}
+
+// NewArray creates a RunEndEncoded array from the memory buffers used by the builder and resets the RunEndEncodedBuilder
+// so it can be used to build a new array.
+func (b *RunEndEncodedBuilder) NewArray() Interface {
+	return b.NewRunEndEncodedArray()
+}
+
+// NewRunEndEncodedArray creates a RunEndEncoded array from the memory buffers used by the builder and resets the RunEndEncodedBuilder
+// so it can be used to build a new array.
+//
+// NewRunEndEncodedArray panics if the number of runs and of values appended differ.
+func (b *RunEndEncodedBuilder) NewRunEndEncodedArray() (a *RunEndEncoded) {
+	data := b.newData()
+	a = NewRunEndEncodedData(data)
+	data.Release()
+	return
+}
+
+func (b *RunEndEncodedBuilder) newData() (data *Data) {
+	if len(b.ends) != b.values.Len() {
+		panic("arrow/array: run ends and values length mismatch")
+	}
+
+	ends := b.newRunEnds()
+	defer ends.Release()
+	values := b.values.NewArray()
+	defer values.Release()
+
+	data = NewData(
+		b.dt, b.length,
+		[]*memory.Buffer{nil},
+		[]*Data{ends.Data(), values.Data()},
+		0,
+		0,
+	)
+	b.ends = b.ends[:0]
+	b.reset()
+
+	return
+}
+
+// newRunEnds returns the run ends appended to the builder, as an array of
+// the run ends type.
+func (b *RunEndEncodedBuilder) newRunEnds() Interface {
+	switch b.dt.RunEnds().ID() {
+	case arrow.INT16:
+		bldr := NewInt16Builder(b.mem)
+		defer bldr.Release()
+		bldr.Reserve(len(b.ends))
+		for _, v := range b.ends {
+			bldr.UnsafeAppend(int16(v))
+		}
+		return bldr.NewArray()
+	case arrow.INT32:
+		bldr := NewInt32Builder(b.mem)
+		defer bldr.Release()
+		bldr.Reserve(len(b.ends))
+		for _, v := range b.ends {
+			bldr.UnsafeAppend(int32(v))
+		}
+		return bldr.NewArray()
+	default:
+		bldr := NewInt64Builder(b.mem)
+		defer bldr.Release()
+		bldr.AppendValues(b.ends, nil)
+		return bldr.NewArray()
+	}
+}
+
+var (
+	_ Interface = (*RunEndEncoded)(nil)
+	_ Builder   = (*RunEndEncodedBuilder)(nil)
+)
@@ -0,0 +1,251 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// newRunEndEncodedStrings returns the run-end encoded array of the runs of
+// strings vs, of the given lengths, a nil value being a null.
+func newRunEndEncodedStrings(mem memory.Allocator, ends arrow.DataType, runs []uint64, vs []interface{}) *array.RunEndEncoded {
+	b := array.NewRunEndEncodedBuilder(mem, ends, arrow.BinaryTypes.String)
+	defer b.Release()
+
+	vb := b.ValueBuilder().(*array.StringBuilder)
+	for i, n := range runs {
+		b.Append(n)
+		switch v := vs[i].(type) {
+		case nil:
+			vb.AppendNull()
+		default:
+			vb.Append(v.(string))
+		}
+	}
+	return b.NewRunEndEncodedArray()
+}
+
+func TestRunEndEncodedArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	for _, ends := range []arrow.DataType{arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64} {
+		t.Run(ends.Name(), func(t *testing.T) {
+			arr := newRunEndEncodedStrings(pool, ends, []uint64{2, 1, 3}, []interface{}{"a", nil, "b"})
+			defer arr.Release()
+
+			if got, want := arr.DataType(), arrow.RunEndEncodedOf(ends, arrow.BinaryTypes.String); !arrow.TypeEqual(got, want) {
+				t.Fatalf("invalid type: got=%v, want=%v", got, want)
+			}
+			if got, want := arr.Len(), 6; got != want {
+				t.Fatalf("invalid length: got=%d, want=%d", got, want)
+			}
+			if got, want := arr.NullN(), 0; got != want {
+				t.Fatalf("invalid number of nulls: got=%d, want=%d", got, want)
+			}
+			if got, want := arr.String(), "[\"a\" \"a\" (null) \"b\" \"b\" \"b\"]"; got != want {
+				t.Fatalf("invalid string: got=%q, want=%q", got, want)
+			}
+			if got, want := arr.RunEndsArr().Len(), 3; got != want {
+				t.Fatalf("invalid number of runs: got=%d, want=%d", got, want)
+			}
+
+			for i, want := range []int{0, 0, 1, 2, 2, 2} {
+				if got := arr.GetPhysicalIndex(i); got != want {
+					t.Fatalf("invalid physical index of %d: got=%d, want=%d", i, got, want)
+				}
+				if got, want := arr.IsNull(i), i == 2; got != want {
+					t.Fatalf("invalid null %d: got=%v, want=%v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunEndEncodedBuilder(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	b := array.NewRunEndEncodedBuilder(pool, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64)
+	defer b.Release()
+
+	vb := b.ValueBuilder().(*array.Int64Builder)
+	b.AppendRuns([]uint64{1, 2})
+	vb.AppendValues([]int64{1, 2}, nil)
+	b.ContinueRun(2)
+	b.AppendNull()
+	b.ContinueRun(1)
+
+	if got, want := b.Len(), 7; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+
+	arr := b.NewRunEndEncodedArray()
+	defer arr.Release()
+
+	if got, want := arr.RunEndsArr().(*array.Int32).Int32Values(), []int32{1, 5, 7}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid run ends: got=%v, want=%v", got, want)
+	}
+	if got, want := arr.String(), "[1 2 2 2 2 (null) (null)]"; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+	if got, want := b.Len(), 0; got != want {
+		t.Fatalf("builder was not reset: got=%d, want=%d", got, want)
+	}
+
+	// ContinueRun without runs starts a run of nulls.
+	b.ContinueRun(3)
+	empty := b.NewArray()
+	defer empty.Release()
+	if got, want := fmt.Sprint(empty), "[(null) (null) (null)]"; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+
+	small := array.NewRunEndEncodedBuilder(pool, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int64)
+	defer small.Release()
+	small.Append(1 << 14)
+	small.ValueBuilder().AppendNull()
+	func() {
+		defer func() {
+			if e := recover(); e != "arrow/array: run end overflow" {
+				t.Fatalf("invalid panic: %v", e)
+			}
+		}()
+		small.ContinueRun(1 << 14)
+	}()
+}
+
+func TestRunEndEncodedSlice(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	arr := newRunEndEncodedStrings(pool, arrow.PrimitiveTypes.Int32, []uint64{2, 1, 3, 2}, []interface{}{"a", nil, "b", "c"})
+	defer arr.Release()
+
+	for _, tc := range []struct {
+		i, j   int64
+		want   string
+		ends   []int32
+		values string
+	}{
+		{0, 8, "[\"a\" \"a\" (null) \"b\" \"b\" \"b\" \"c\" \"c\"]", []int32{2, 3, 6, 8}, `["a" (null) "b" "c"]`},
+		{1, 4, "[\"a\" (null) \"b\"]", []int32{1, 2, 3}, `["a" (null) "b"]`},
+		{3, 5, "[\"b\" \"b\"]", []int32{2}, `["b"]`},
+		{4, 7, "[\"b\" \"b\" \"c\"]", []int32{2, 3}, `["b" "c"]`},
+		{0, 2, "[\"a\" \"a\"]", []int32{2}, `["a"]`},
+		{5, 5, "[]", nil, "[]"},
+	} {
+		slice := array.NewSlice(arr, tc.i, tc.j).(*array.RunEndEncoded)
+		defer slice.Release()
+
+		if got := slice.String(); got != tc.want {
+			t.Fatalf("invalid slice [%d:%d]: got=%q, want=%q", tc.i, tc.j, got, tc.want)
+		}
+		if got, want := slice.GetPhysicalLength(), len(tc.ends); got != want {
+			t.Fatalf("invalid physical length of [%d:%d]: got=%d, want=%d", tc.i, tc.j, got, want)
+		}
+
+		ends := slice.LogicalRunEndsArr(pool)
+		defer ends.Release()
+		if got := ends.(*array.Int32).Int32Values(); !reflect.DeepEqual(got, tc.ends) {
+			t.Fatalf("invalid logical run ends of [%d:%d]: got=%v, want=%v", tc.i, tc.j, got, tc.ends)
+		}
+		values := slice.LogicalValuesArr()
+		defer values.Release()
+		if got := values.(*array.String).String(); got != tc.values {
+			t.Fatalf("invalid logical values of [%d:%d]: got=%s, want=%s", tc.i, tc.j, got, tc.values)
+		}
+
+		// the logical run ends and values encode the same logical values.
+		rebased := array.NewRunEndEncoded(ends, values, slice.Len(), 0)
+		defer rebased.Release()
+		if !array.ArrayEqual(slice, rebased) {
+			t.Fatalf("rebased slice [%d:%d] differs: got=%v, want=%v", tc.i, tc.j, rebased, slice)
+		}
+	}
+}
+
+func TestRunEndEncodedEqual(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	arr := newRunEndEncodedStrings(pool, arrow.PrimitiveTypes.Int32, []uint64{2, 1, 3}, []interface{}{"a", nil, "b"})
+	defer arr.Release()
+
+	// the same logical values, with runs split differently.
+	split := newRunEndEncodedStrings(pool, arrow.PrimitiveTypes.Int32, []uint64{1, 1, 1, 1, 2}, []interface{}{"a", "a", nil, "b", "b"})
+	defer split.Release()
+	if !array.ArrayEqual(arr, split) {
+		t.Fatalf("arrays differ: %v, %v", arr, split)
+	}
+	if !array.ArrayApproxEqual(arr, split) {
+		t.Fatalf("arrays differ approximately: %v, %v", arr, split)
+	}
+
+	other := newRunEndEncodedStrings(pool, arrow.PrimitiveTypes.Int32, []uint64{2, 1, 3}, []interface{}{"a", nil, "c"})
+	defer other.Release()
+	if array.ArrayEqual(arr, other) {
+		t.Fatalf("arrays are equal: %v, %v", arr, other)
+	}
+
+	nulls := newRunEndEncodedStrings(pool, arrow.PrimitiveTypes.Int32, []uint64{2, 4}, []interface{}{"a", nil})
+	defer nulls.Release()
+	if array.ArrayEqual(arr, nulls) {
+		t.Fatalf("arrays are equal: %v, %v", arr, nulls)
+	}
+
+	// slices are compared by their logical values.
+	l := array.NewSlice(arr, 3, 6)
+	defer l.Release()
+	r := array.NewSlice(split, 3, 6)
+	defer r.Release()
+	if !array.ArrayEqual(l, r) {
+		t.Fatalf("slices differ: %v, %v", l, r)
+	}
+}
+
+func TestRunEndEncodedMaps(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ree", Type: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String), Nullable: true},
+	}, nil)
+	rows := []map[string]interface{}{{"ree": "a"}, {"ree": "a"}, {"ree": nil}, {"ree": "b"}}
+
+	rec, err := array.RecordFromMaps(pool, schema, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	if got := array.RecordToMaps(rec); !reflect.DeepEqual(got, rows) {
+		t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, rows)
+	}
+
+	slice := rec.NewSlice(1, 3)
+	defer slice.Release()
+	if got, want := array.RecordToMaps(slice), rows[1:3]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid sliced rows:\ngot= %v\nwant=%v", got, want)
+	}
+}
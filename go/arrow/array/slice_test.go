@@ -0,0 +1,175 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// dictionaryRecords returns records of a dictionary column, with nulls in
+// the indices and in the dictionary.
+func dictionaryRecords(mem memory.Allocator) []array.Record {
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}
+	schema := arrow.NewSchema([]arrow.Field{{Name: "dict", Type: dt, Nullable: true}}, nil)
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "", "bc", "d"}, []bool{true, false, true, true})
+	dict := sb.NewArray()
+	defer dict.Release()
+
+	ib := array.NewInt16Builder(mem)
+	defer ib.Release()
+	var recs []array.Record
+	for r := 0; r < 2; r++ {
+		for i := 0; i < 37; i++ {
+			if (i+r)%5 == 0 {
+				ib.AppendNull()
+				continue
+			}
+			ib.Append(int16((i * 7) % 4))
+		}
+		indices := ib.NewArray()
+		arr := array.NewDictionaryArray(dt, indices, dict)
+		recs = append(recs, array.NewRecord(schema, []array.Interface{arr}, int64(arr.Len())))
+		indices.Release()
+		arr.Release()
+	}
+	return recs
+}
+
+func TestNewSliceRandom(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	all := map[string][]array.Record{"dictionaries": dictionaryRecords(mem)}
+	defer func() {
+		for _, rec := range all["dictionaries"] {
+			rec.Release()
+		}
+	}()
+	for name, recs := range arrdata.Records {
+		all[name] = recs
+	}
+
+	rng := rand.New(rand.NewSource(0))
+	for name, recs := range all {
+		t.Run(name, func(t *testing.T) {
+			for r, rec := range recs {
+				rows := array.RecordToMaps(rec)
+				for k := 0; k < 20; k++ {
+					i := rng.Int63n(rec.NumRows() + 1)
+					j := i + rng.Int63n(rec.NumRows()-i+1)
+					slice := rec.NewSlice(i, j)
+					checkSlice(t, fmt.Sprintf("records[%d][%d:%d]", r, i, j), rec, slice, i, rows[i:j])
+
+					// slices of slices.
+					ii := i + rng.Int63n(j-i+1)
+					jj := ii + rng.Int63n(j-ii+1)
+					sub := slice.NewSlice(ii-i, jj-i)
+					checkSlice(t, fmt.Sprintf("records[%d][%d:%d][%d:%d]", r, i, j, ii-i, jj-i), rec, sub, ii, rows[ii:jj])
+					sub.Release()
+					slice.Release()
+				}
+			}
+		})
+	}
+}
+
+// checkSlice compares slice element-wise to the rows of rec from beg it
+// holds.
+func checkSlice(t *testing.T, name string, rec, slice array.Record, beg int64, rows []map[string]interface{}) {
+	t.Helper()
+	if got, want := slice.NumRows(), int64(len(rows)); got != want {
+		t.Fatalf("%s: invalid number of rows: got=%d, want=%d", name, got, want)
+	}
+	if got := array.RecordToMaps(slice); len(rows) > 0 && !reflect.DeepEqual(got, rows) {
+		t.Fatalf("%s: invalid rows:\ngot= %v\nwant=%v", name, got, rows)
+	}
+	for c, col := range slice.Columns() {
+		if col.Len() != len(rows) {
+			t.Fatalf("%s: invalid length of column %d: got=%d, want=%d", name, c, col.Len(), len(rows))
+		}
+		nulls := 0
+		for k := 0; k < col.Len(); k++ {
+			if col.IsNull(k) != rec.Column(c).IsNull(int(beg)+k) {
+				t.Fatalf("%s: invalid validity of column %d at row %d", name, c, k)
+			}
+			if col.IsNull(k) {
+				nulls++
+			}
+		}
+		if col.NullN() != nulls {
+			t.Fatalf("%s: invalid number of nulls in column %d: got=%d, want=%d", name, c, col.NullN(), nulls)
+		}
+	}
+}
+
+func TestSliceRecord(t *testing.T) {
+	rec := arrdata.Records["primitives"][0]
+	rows := rec.NumRows()
+	for _, tc := range []struct {
+		i, j     int64
+		clamp    bool
+		beg, end int64
+		err      bool
+	}{
+		{i: 0, j: rows, beg: 0, end: rows},
+		{i: 1, j: 2, beg: 1, end: 2},
+		{i: rows, j: rows, beg: rows, end: rows},
+		{i: -1, j: 2, err: true},
+		{i: 2, j: 1, err: true},
+		{i: 0, j: rows + 1, err: true},
+		{i: -1, j: 2, clamp: true, beg: 0, end: 2},
+		{i: 2, j: 1, clamp: true, beg: 2, end: 2},
+		{i: 1, j: rows + 1, clamp: true, beg: 1, end: rows},
+		{i: rows + 1, j: rows + 2, clamp: true, beg: rows, end: rows},
+	} {
+		t.Run(fmt.Sprintf("%d:%d/clamp=%v", tc.i, tc.j, tc.clamp), func(t *testing.T) {
+			var opts []array.SliceOption
+			if tc.clamp {
+				opts = append(opts, array.WithClampedBounds())
+			}
+			slice, err := array.SliceRecord(rec, tc.i, tc.j, opts...)
+			if tc.err {
+				if err == nil {
+					slice.Release()
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer slice.Release()
+
+			want := rec.NewSlice(tc.beg, tc.end)
+			defer want.Release()
+			if !array.RecordEqual(slice, want) {
+				t.Fatalf("invalid slice:\ngot= %v\nwant=%v", slice, want)
+			}
+		})
+	}
+}
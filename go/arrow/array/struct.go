@@ -19,6 +19,7 @@ package array
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync/atomic"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/apache/arrow/go/arrow/bitutil"
 	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
 )
 
 // Struct represents an ordered sequence of relative types.
@@ -72,21 +74,36 @@ func (a *Struct) String() string {
 //   "When reading the struct array the parent validity bitmap takes priority."
 func (a *Struct) newStructFieldWithParentValidityMask(fieldIndex int) Interface {
 	field := a.Field(fieldIndex)
-	nullBitmapBytes := field.NullBitmapBytes()
-	maskedNullBitmapBytes := make([]byte, len(nullBitmapBytes))
-	copy(maskedNullBitmapBytes, nullBitmapBytes)
-	for i := 0; i < field.Len(); i++ {
-		if !a.IsValid(i) {
-			bitutil.ClearBit(maskedNullBitmapBytes, i)
-		}
+	if a.NullN() == 0 {
+		field.Retain()
+		return field
+	}
+
+	var (
+		parent = a.NullBitmapBytes()
+		offset = field.Data().Offset()
+		n      = field.Len()
+	)
+	maskedNullBitmapBytes := make([]byte, bitutil.BytesForBits(int64(offset+n)))
+	switch child := field.NullBitmapBytes(); {
+	case len(parent) == 0:
+		// all the parent values are null.
+	case len(child) == 0:
+		bitutil.BitmapAnd(parent, parent, a.data.offset, a.data.offset, maskedNullBitmapBytes, offset, n)
+	default:
+		bitutil.BitmapAnd(child, parent, offset, a.data.offset, maskedNullBitmapBytes, offset, n)
 	}
-	data := NewSliceData(field.Data(), 0, int64(field.Len()))
+
+	data := NewSliceData(field.Data(), 0, int64(n))
 	defer data.Release()
 	bufs := make([]*memory.Buffer, len(data.buffers))
 	copy(bufs, data.buffers)
-	bufs[0].Release()
+	if bufs[0] != nil {
+		bufs[0].Release()
+	}
 	bufs[0] = memory.NewBufferBytes(maskedNullBitmapBytes)
 	data.buffers = bufs
+	data.nulls = UnknownNullCount
 	maskedField := MakeFromData(data)
 	return maskedField
 }
@@ -183,6 +200,42 @@ func (b *StructBuilder) AppendValues(valids []bool) {
 
 func (b *StructBuilder) AppendNull() { b.Append(false) }
 
+// AppendGoValue appends the Go value v, which is either nil for a null, a
+// map[string]interface{} keyed by field names, or a struct or a pointer to
+// a struct. The fields of a Go struct are matched by their arrow tag, if
+// any, or else by their name; fields tagged "-" are skipped. Nested structs
+// and lists are converted recursively, and their elements are converted as
+// in RecordFromMaps.
+//
+// Missing, nil and unexported values are null. AppendGoValue returns an
+// error without appending anything if a non-nullable field is null, but the
+// builder should be discarded if it fails to convert a value.
+func (b *StructBuilder) AppendGoValue(v interface{}) error {
+	x, err := goValueOf(reflect.ValueOf(v), b.dtype)
+	if err != nil {
+		return xerrors.Errorf("arrow/array: %w", err)
+	}
+	if err := appendGoValue(b, b.dtype, x); err != nil {
+		return xerrors.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+// AppendGoValues appends the elements of the Go slice vs with AppendGoValue.
+func (b *StructBuilder) AppendGoValues(vs interface{}) error {
+	rv := reflect.ValueOf(vs)
+	if rv.Kind() != reflect.Slice {
+		return xerrors.Errorf("arrow/array: invalid struct values %T", vs)
+	}
+	b.Reserve(rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if err := b.AppendGoValue(rv.Index(i).Interface()); err != nil {
+			return xerrors.Errorf("row %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func (b *StructBuilder) unsafeAppend(v bool) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.length++
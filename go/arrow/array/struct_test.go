@@ -17,6 +17,7 @@
 package array_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -406,4 +407,83 @@ func TestStructArrayNullBitmap(t *testing.T) {
 	if got != want {
 		t.Fatalf("invalid string representation:\ngot = %q\nwant= %q", got, want)
 	}
+
+	slice := array.NewSlice(arr, 1, 4).(*array.Struct)
+	defer slice.Release()
+
+	want = "{[1.2 1.3 (null)] [(null) 3 (null)]}"
+	got = slice.String()
+	if got != want {
+		t.Fatalf("invalid string representation of slice:\ngot = %q\nwant= %q", got, want)
+	}
+}
+
+func TestStructBuilderAppendGoValue(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	type point struct {
+		X int32 `arrow:"x"`
+		Y *int32
+	}
+	type shape struct {
+		Name   string  `arrow:"name"`
+		Points []point `arrow:"points"`
+		Skip   string  `arrow:"-"`
+		hidden string
+	}
+
+	dtype := arrow.StructOf(
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "points", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "Y", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		)), Nullable: true},
+	)
+
+	sb := array.NewStructBuilder(pool, dtype)
+	defer sb.Release()
+
+	y := int32(2)
+	err := sb.AppendGoValues([]interface{}{
+		shape{Name: "a", Points: []point{{X: 1, Y: &y}, {X: 3}}, Skip: "skipped", hidden: "hidden"},
+		nil,
+		&shape{Name: "b"},
+		map[string]interface{}{"name": "c", "points": []interface{}{map[string]interface{}{"x": 4}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		v    interface{}
+	}{
+		{"non-nullable", map[string]interface{}{"points": nil}},
+		{"nested-non-nullable", map[string]interface{}{"name": "d", "points": []interface{}{map[string]interface{}{"Y": 1}}}},
+		{"unknown-field", map[string]interface{}{"name": "d", "size": 1}},
+		{"invalid-type", 42},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := sb.AppendGoValue(tc.v); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	arr := sb.NewArray().(*array.Struct)
+	defer arr.Release()
+
+	if got, want := arr.Len(), 4; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := arr.NullN(), 1; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := fmt.Sprint(arr.Field(0)), `["a" (null) "b" "c"]`; got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
+	if got, want := fmt.Sprint(arr.Field(1)), "[{[1 3] [2 (null)]} (null) (null) {[4] [(null)]}]"; got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
 }
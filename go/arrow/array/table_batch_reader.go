@@ -0,0 +1,259 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// TableBatchOption configures a TableBatchReader.
+type TableBatchOption func(*tableBatchConfig)
+
+type tableBatchConfig struct {
+	rows  int64
+	bytes int64
+	mem   memory.Allocator // allocates concatenated batches, if not nil
+}
+
+// WithBatchRows sets the number of rows of the records read, or fewer when
+// a chunk of the table ends first, see WithBatchConcatenate.
+func WithBatchRows(n int64) TableBatchOption {
+	return func(cfg *tableBatchConfig) { cfg.rows = n }
+}
+
+// WithBatchBytes sets the size, in bytes, of the records read, their
+// number of rows being estimated from the size of the buffers of the
+// current chunks of the table, and their length. The records have at least
+// one row.
+func WithBatchBytes(n int64) TableBatchOption {
+	return func(cfg *tableBatchConfig) { cfg.bytes = n }
+}
+
+// WithBatchConcatenate sets whether the records read span the boundaries
+// of the chunks of the table, their columns made of several chunks being
+// concatenated with memory from mem, so that all the records but the last
+// have the target size. Otherwise, the records end at the first chunk
+// boundary of the columns, and are zero-copy slices of the table.
+func WithBatchConcatenate(mem memory.Allocator) TableBatchOption {
+	return func(cfg *tableBatchConfig) { cfg.mem = mem }
+}
+
+// TableBatchReader is a Record iterator over a Table, reading records of a
+// target number of rows or size, regardless of the chunks of the table.
+// Only the current record is held in memory, slicing the chunks of the
+// table without copying them unless WithBatchConcatenate is set.
+type TableBatchReader struct {
+	refCount int64
+
+	tbl  Table
+	cfg  tableBatchConfig
+	rows int64 // number of rows read
+	rec  Record
+	err  error
+
+	chunks  []*Chunked
+	slots   []int   // chunk indices
+	offsets []int64 // chunk offsets
+}
+
+// NewTableBatchReader returns a reader of the records of tbl. Without
+// options, the records are the largest slices of the table which do not
+// span the boundaries of its chunks.
+func NewTableBatchReader(tbl Table, opts ...TableBatchOption) *TableBatchReader {
+	r := &TableBatchReader{
+		refCount: 1,
+		tbl:      tbl,
+		chunks:   make([]*Chunked, tbl.NumCols()),
+		slots:    make([]int, tbl.NumCols()),
+		offsets:  make([]int64, tbl.NumCols()),
+	}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+	tbl.Retain()
+	for i := range r.chunks {
+		r.chunks[i] = tbl.Column(i).Data()
+		r.chunks[i].Retain()
+	}
+	return r
+}
+
+func (r *TableBatchReader) Schema() *arrow.Schema { return r.tbl.Schema() }
+
+// Record returns the current record. It is valid until the next call to
+// Next.
+func (r *TableBatchReader) Record() Record { return r.rec }
+
+// Err returns the error which stopped the reader, if any.
+func (r *TableBatchReader) Err() error { return r.err }
+
+// Progress returns the number of rows read, those of the current record
+// included, and the number of rows of the table.
+func (r *TableBatchReader) Progress() (rows, total int64) {
+	return r.rows, r.tbl.NumRows()
+}
+
+// Next returns whether a record could be read.
+func (r *TableBatchReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+	if r.err != nil || r.rows >= r.tbl.NumRows() {
+		return false
+	}
+
+	for i := range r.chunks {
+		r.skipEmpty(i)
+	}
+
+	n := r.tbl.NumRows() - r.rows
+	if r.cfg.rows > 0 {
+		n = imin64(n, r.cfg.rows)
+	}
+	if r.cfg.bytes > 0 {
+		var size float64
+		for i, chunks := range r.chunks {
+			chunk := chunks.Chunk(r.slots[i])
+			size += float64(dataSize(chunk.Data())) / float64(chunk.Len())
+		}
+		if size > 0 {
+			n = imin64(n, imax64(int64(float64(r.cfg.bytes)/size), 1))
+		}
+	}
+	if r.cfg.mem == nil {
+		for i, chunks := range r.chunks {
+			n = imin64(n, int64(chunks.Chunk(r.slots[i]).Len())-r.offsets[i])
+		}
+	}
+
+	cols := make([]Interface, len(r.chunks))
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+	for i := range cols {
+		cols[i], r.err = r.column(i, n)
+		if r.err != nil {
+			return false
+		}
+	}
+
+	r.rows += n
+	r.rec = NewRecord(r.tbl.Schema(), cols, n)
+	return true
+}
+
+// skipEmpty advances the chunk slot of the column i past the chunks which
+// were read or are empty.
+func (r *TableBatchReader) skipEmpty(i int) {
+	for r.slots[i] < len(r.chunks[i].Chunks()) && r.offsets[i] == int64(r.chunks[i].Chunk(r.slots[i]).Len()) {
+		r.slots[i]++
+		r.offsets[i] = 0
+	}
+}
+
+// column returns the next n values of the column i, concatenating them if
+// they span several chunks.
+func (r *TableBatchReader) column(i int, n int64) (Interface, error) {
+	var pieces []Interface
+	defer func() {
+		for _, piece := range pieces {
+			piece.Release()
+		}
+	}()
+	for n > 0 {
+		r.skipEmpty(i)
+		chunk := r.chunks[i].Chunk(r.slots[i])
+		beg := r.offsets[i]
+		end := imin64(int64(chunk.Len()), beg+n)
+		if beg == 0 && end == int64(chunk.Len()) {
+			chunk.Retain()
+			pieces = append(pieces, chunk)
+		} else {
+			pieces = append(pieces, NewSlice(chunk, beg, end))
+		}
+		r.offsets[i] = end
+		n -= end - beg
+	}
+
+	if len(pieces) == 1 {
+		pieces[0].Retain()
+		return pieces[0], nil
+	}
+	return Concatenate(pieces, r.cfg.mem)
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *TableBatchReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *TableBatchReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		r.tbl.Release()
+		for _, chunks := range r.chunks {
+			chunks.Release()
+		}
+		if r.rec != nil {
+			r.rec.Release()
+		}
+		r.tbl, r.chunks, r.rec = nil, nil, nil
+	}
+}
+
+// dataSize returns the size of the buffers of data, its children and its
+// dictionary. The buffers of a slice are counted whole.
+func dataSize(data *Data) int64 {
+	var n int64
+	for _, buf := range data.buffers {
+		if buf != nil {
+			n += int64(buf.Len())
+		}
+	}
+	for _, child := range data.childData {
+		n += dataSize(child)
+	}
+	if data.dictionary != nil {
+		n += dataSize(data.dictionary)
+	}
+	return n
+}
+
+func imax64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+var (
+	_ RecordReader = (*TableBatchReader)(nil)
+)
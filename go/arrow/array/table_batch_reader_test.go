@@ -0,0 +1,247 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestTableBatchReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	const nrows = 100
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	for i := 0; i < nrows; i++ {
+		if i%7 == 3 {
+			ib.AppendNull()
+			sb.AppendNull()
+			continue
+		}
+		ib.Append(int64(i))
+		sb.Append(fmt.Sprintf("str-%d", i))
+	}
+	want := []array.Interface{ib.NewArray(), sb.NewArray()}
+	defer want[0].Release()
+	defer want[1].Release()
+
+	// chunk slices the arrays of want in chunks of the given sizes, cycling
+	// through them.
+	chunk := func(arr array.Interface, sizes ...int64) *array.Chunked {
+		var chunks []array.Interface
+		for beg, i := int64(0), 0; beg < nrows; i++ {
+			end := beg + sizes[i%len(sizes)]
+			if end > nrows {
+				end = nrows
+			}
+			chunks = append(chunks, array.NewSlice(arr, beg, end))
+			beg = end
+		}
+		defer func() {
+			for _, c := range chunks {
+				c.Release()
+			}
+		}()
+		return array.NewChunked(arr.DataType(), chunks)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		chunks [2][]int64
+	}{
+		{"single-chunk", [2][]int64{{nrows}, {nrows}}},
+		{"1-row-chunks", [2][]int64{{1}, {1}}},
+		{"misaligned", [2][]int64{{1}, {nrows}}},
+		{"uneven", [2][]int64{{7, 0, 3}, {13, 1}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cols := make([]array.Column, 2)
+			for i := range cols {
+				chunks := chunk(want[i], tc.chunks[i]...)
+				cols[i] = *array.NewColumn(schema.Field(i), chunks)
+				chunks.Release()
+			}
+			tbl := array.NewTable(schema, cols, nrows)
+			for i := range cols {
+				cols[i].Release()
+			}
+			defer tbl.Release()
+
+			for _, opts := range []struct {
+				name   string
+				opts   []array.TableBatchOption
+				rows   int64 // exact number of rows of the records but the last
+				maxrow int64 // maximum number of rows of the records
+			}{
+				{name: "default", maxrow: nrows},
+				{name: "rows=10", opts: []array.TableBatchOption{array.WithBatchRows(10)}, maxrow: 10},
+				{name: "bytes=64", opts: []array.TableBatchOption{array.WithBatchBytes(64)}, maxrow: nrows},
+				{
+					name:   "rows=10-concat",
+					opts:   []array.TableBatchOption{array.WithBatchRows(10), array.WithBatchConcatenate(mem)},
+					rows:   10,
+					maxrow: 10,
+				},
+				{
+					name:   "rows=1-concat",
+					opts:   []array.TableBatchOption{array.WithBatchRows(1), array.WithBatchConcatenate(mem)},
+					rows:   1,
+					maxrow: 1,
+				},
+				{
+					name:   "concat",
+					opts:   []array.TableBatchOption{array.WithBatchConcatenate(mem)},
+					rows:   nrows,
+					maxrow: nrows,
+				},
+			} {
+				t.Run(opts.name, func(t *testing.T) {
+					r := array.NewTableBatchReader(tbl, opts.opts...)
+					defer r.Release()
+
+					r.Retain()
+					r.Release()
+
+					if !r.Schema().Equal(schema) {
+						t.Fatalf("invalid schema: got=%v, want=%v", r.Schema(), schema)
+					}
+
+					var (
+						got  = make([][]array.Interface, 2)
+						recs []int64
+						n    int64
+					)
+					defer func() {
+						for _, col := range got {
+							for _, arr := range col {
+								arr.Release()
+							}
+						}
+					}()
+					for r.Next() {
+						rec := r.Record()
+						if rec.NumRows() == 0 || rec.NumRows() > opts.maxrow {
+							t.Fatalf("invalid number of rows: got=%d, max=%d", rec.NumRows(), opts.maxrow)
+						}
+						n += rec.NumRows()
+						recs = append(recs, rec.NumRows())
+						if rows, total := r.Progress(); rows != n || total != nrows {
+							t.Fatalf("invalid progress: got=(%d, %d), want=(%d, %d)", rows, total, n, nrows)
+						}
+						for i, col := range rec.Columns() {
+							col.Retain()
+							got[i] = append(got[i], col)
+						}
+					}
+					if err := r.Err(); err != nil {
+						t.Fatal(err)
+					}
+					if r.Next() {
+						t.Fatalf("reader should be exhausted")
+					}
+
+					if n != nrows {
+						t.Fatalf("invalid number of rows: got=%d, want=%d", n, nrows)
+					}
+					if opts.rows > 0 {
+						for i, rows := range recs[:len(recs)-1] {
+							if rows != opts.rows {
+								t.Fatalf("record %d: invalid number of rows: got=%d, want=%d", i, rows, opts.rows)
+							}
+						}
+					}
+
+					for i := range got {
+						arr, err := array.Concatenate(got[i], mem)
+						if err != nil {
+							t.Fatal(err)
+						}
+						defer arr.Release()
+						if !array.ArrayEqual(arr, want[i]) {
+							t.Fatalf("column %d: got=%v, want=%v", i, arr, want[i])
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestTableBatchReaderBytes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	b := array.NewInt64Builder(mem)
+	defer b.Release()
+	for i := 0; i < 64; i++ {
+		b.Append(int64(i))
+	}
+	arr := b.NewArray()
+	defer arr.Release()
+
+	chunks := array.NewChunked(arr.DataType(), []array.Interface{arr})
+	defer chunks.Release()
+	col := array.NewColumn(schema.Field(0), chunks)
+	defer col.Release()
+	tbl := array.NewTable(schema, []array.Column{*col}, 64)
+	defer tbl.Release()
+
+	// the int64 values and their validity bitmap, if any, make for 8 to 9
+	// bytes per row: 7 or 8 rows per record, but the last one.
+	r := array.NewTableBatchReader(tbl, array.WithBatchBytes(64))
+	defer r.Release()
+
+	for r.Next() {
+		rows, _ := r.Progress()
+		if n := r.Record().NumRows(); n != 8 && n != 7 && rows != 64 {
+			t.Fatalf("invalid number of rows: got=%d, want=7 or 8", n)
+		}
+	}
+
+	r = array.NewTableBatchReader(tbl, array.WithBatchBytes(1))
+	defer r.Release()
+
+	n := 0
+	for r.Next() {
+		if got := r.Record().NumRows(); got != 1 {
+			t.Fatalf("invalid number of rows: got=%d, want=1", got)
+		}
+		n++
+	}
+	if n != 64 {
+		t.Fatalf("invalid number of records: got=%d, want=64", n)
+	}
+}
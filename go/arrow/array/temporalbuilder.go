@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// AppendTime appends the instant t in the unit of the builder's type.
+// The precision of t finer than the unit is truncated towards the past.
+//
+// AppendTime panics if t is out of the range of timestamps in the unit of
+// the builder's type, see arrow.TimestampFromTime.
+func (b *TimestampBuilder) AppendTime(t time.Time) {
+	v, err := arrow.TimestampFromTime(t, b.dtype.Unit)
+	if err != nil {
+		panic(err)
+	}
+	b.Append(v)
+}
+
+// AppendTime appends the calendar date of t in its location.
+func (b *Date32Builder) AppendTime(t time.Time) {
+	b.Append(arrow.Date32FromTime(t))
+}
+
+// AppendTime appends the calendar date of t in its location.
+func (b *Date64Builder) AppendTime(t time.Time) {
+	b.Append(arrow.Date64FromTime(t))
+}
+
+// AppendTime appends the time of day of t in its location, in the unit of
+// the builder's type.
+func (b *Time32Builder) AppendTime(t time.Time) {
+	b.Append(arrow.Time32FromTime(t, b.dtype.Unit))
+}
+
+// AppendTime appends the time of day of t in its location, in the unit of
+// the builder's type.
+func (b *Time64Builder) AppendTime(t time.Time) {
+	b.Append(arrow.Time64FromTime(t, b.dtype.Unit))
+}
+
+// AppendDuration appends d in the unit of the builder's type, truncated
+// towards zero.
+func (b *DurationBuilder) AppendDuration(d time.Duration) {
+	b.Append(arrow.DurationFromTime(d, b.dtype.Unit))
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestTemporalBuilderAppendTime(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	loc, err := arrow.LoadLocation("+05:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := time.Date(2021, time.March, 14, 1, 9, 26, 535897932, loc)
+
+	for _, unit := range []arrow.TimeUnit{arrow.Second, arrow.Millisecond, arrow.Microsecond, arrow.Nanosecond} {
+		dt := &arrow.TimestampType{Unit: unit, TimeZone: "+05:30"}
+		b := array.NewTimestampBuilder(mem, dt)
+		b.AppendTime(tm)
+		arr := b.NewTimestampArray()
+		b.Release()
+
+		got, err := dt.ToTime(arr.Value(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := tm.Truncate(unit.Multiplier()); !got.Equal(want) || got.Location() != loc {
+			t.Errorf("invalid timestamp[%v]: got=%v, want=%v", unit, got, want)
+		}
+		arr.Release()
+	}
+
+	// the date and time of day are the ones of tm in its location.
+	db := array.NewDate32Builder(mem)
+	db.AppendTime(tm)
+	dates := db.NewDate32Array()
+	db.Release()
+	defer dates.Release()
+	if got, want := dates.Value(0).ToTime(), time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("invalid date32: got=%v, want=%v", got, want)
+	}
+
+	d64 := array.NewDate64Builder(mem)
+	d64.AppendTime(tm)
+	dates64 := d64.NewDate64Array()
+	d64.Release()
+	defer dates64.Release()
+	if got, want := dates64.Value(0), arrow.Date64FromTime(tm); got != want {
+		t.Errorf("invalid date64: got=%v, want=%v", got, want)
+	}
+
+	tb := array.NewTime32Builder(mem, arrow.FixedWidthTypes.Time32ms.(*arrow.Time32Type))
+	tb.AppendTime(tm)
+	times := tb.NewTime32Array()
+	tb.Release()
+	defer times.Release()
+	if got, want := times.Value(0), arrow.Time32(4166535); got != want {
+		t.Errorf("invalid time32: got=%v, want=%v", got, want)
+	}
+
+	t64 := array.NewTime64Builder(mem, arrow.FixedWidthTypes.Time64ns.(*arrow.Time64Type))
+	t64.AppendTime(tm)
+	times64 := t64.NewTime64Array()
+	t64.Release()
+	defer times64.Release()
+	if got, want := times64.Value(0), arrow.Time64(4166535897932); got != want {
+		t.Errorf("invalid time64: got=%v, want=%v", got, want)
+	}
+
+	ub := array.NewDurationBuilder(mem, arrow.FixedWidthTypes.Duration_us.(*arrow.DurationType))
+	ub.AppendDuration(1500 * time.Nanosecond)
+	durations := ub.NewDurationArray()
+	ub.Release()
+	defer durations.Release()
+	if got, want := durations.Value(0), arrow.Duration(1); got != want {
+		t.Errorf("invalid duration: got=%v, want=%v", got, want)
+	}
+}
+
+func TestTimestampBuilderAppendTimeOutOfRange(t *testing.T) {
+	b := array.NewTimestampBuilder(memory.NewGoAllocator(), &arrow.TimestampType{Unit: arrow.Nanosecond})
+	defer b.Release()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	b.AppendTime(time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+}
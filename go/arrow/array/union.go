@@ -0,0 +1,527 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"math"
+	"strings"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// unionArray is implemented by the sparse and dense union arrays.
+type unionArray interface {
+	Interface
+	TypeCode(i int) arrow.UnionTypeCode
+	ChildID(i int) int
+	Field(pos int) Interface
+
+	// valueIndex returns the index in its field of the value at i.
+	valueIndex(i int) int
+}
+
+// union holds the type codes and children common to the sparse and dense
+// union arrays.
+//
+// Union arrays have no validity bitmap: the value at i is null when the
+// value of its child is null, and NullN is always zero.
+type union struct {
+	array
+	dt       *arrow.UnionType
+	codes    []arrow.UnionTypeCode
+	children []Interface
+}
+
+// NumFields returns the number of children of the union.
+func (a *union) NumFields() int { return len(a.children) }
+
+// TypeCodes returns the type code of each value of the union.
+func (a *union) TypeCodes() []arrow.UnionTypeCode {
+	beg := a.array.data.offset
+	return a.codes[beg : beg+a.array.data.length]
+}
+
+// TypeCode returns the type code of the value at i.
+func (a *union) TypeCode(i int) arrow.UnionTypeCode { return a.codes[a.array.data.offset+i] }
+
+// ChildID returns the index of the child holding the value at i.
+func (a *union) ChildID(i int) int { return a.dt.ChildID(a.TypeCode(i)) }
+
+func (a *union) setData(data *Data) {
+	a.array.setData(data)
+	a.dt = data.dtype.(*arrow.UnionType)
+	if len(a.dt.Fields()) != len(data.childData) {
+		panic("arrow/array: union children and fields mismatch")
+	}
+	if vals := data.buffers[1]; vals != nil {
+		a.codes = arrow.Int8Traits.CastFromBytes(vals.Bytes())
+	}
+}
+
+func (a *union) Retain() {
+	a.array.Retain()
+	for _, c := range a.children {
+		c.Retain()
+	}
+}
+
+func (a *union) Release() {
+	a.array.Release()
+	for _, c := range a.children {
+		c.Release()
+	}
+}
+
+// SparseUnion represents an immutable sequence of values, each of the type
+// of one of its children. The children of a sparse union have its length:
+// the value at i is the i-th value of the child identified by its type code.
+type SparseUnion struct {
+	union
+}
+
+// NewSparseUnionData returns a new SparseUnion array value, from data.
+func NewSparseUnionData(data *Data) *SparseUnion {
+	a := &SparseUnion{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Field returns the child at pos, sliced as the union is.
+func (a *SparseUnion) Field(pos int) Interface { return a.children[pos] }
+
+func (a *SparseUnion) valueIndex(i int) int { return i }
+
+// IsNull returns true if the value at i is null in its child.
+func (a *SparseUnion) IsNull(i int) bool { return unionIsNull(a, i) }
+
+// IsValid returns true if the value at i is not null in its child.
+func (a *SparseUnion) IsValid(i int) bool { return !unionIsNull(a, i) }
+
+func (a *SparseUnion) String() string { return unionString(a) }
+
+func (a *SparseUnion) setData(data *Data) {
+	a.union.setData(data)
+	a.children = make([]Interface, len(data.childData))
+	for i, child := range data.childData {
+		if data.offset != 0 || child.length != data.length {
+			sub := NewSliceData(child, int64(data.offset), int64(data.offset+data.length))
+			a.children[i] = MakeFromData(sub)
+			sub.Release()
+		} else {
+			a.children[i] = MakeFromData(child)
+		}
+	}
+}
+
+// DenseUnion represents an immutable sequence of values, each of the type
+// of one of its children. The value at i is the value of the child
+// identified by its type code at its value offset. The children of a dense
+// union are not sliced with it.
+type DenseUnion struct {
+	union
+	offsets []int32
+}
+
+// NewDenseUnionData returns a new DenseUnion array value, from data.
+func NewDenseUnionData(data *Data) *DenseUnion {
+	a := &DenseUnion{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Field returns the child at pos.
+func (a *DenseUnion) Field(pos int) Interface { return a.children[pos] }
+
+// ValueOffsets returns the offset of each value of the union in its child.
+func (a *DenseUnion) ValueOffsets() []int32 {
+	beg := a.array.data.offset
+	return a.offsets[beg : beg+a.array.data.length]
+}
+
+// ValueOffset returns the offset of the value at i in its child.
+func (a *DenseUnion) ValueOffset(i int) int32 { return a.offsets[a.array.data.offset+i] }
+
+func (a *DenseUnion) valueIndex(i int) int { return int(a.ValueOffset(i)) }
+
+// IsNull returns true if the value at i is null in its child.
+func (a *DenseUnion) IsNull(i int) bool { return unionIsNull(a, i) }
+
+// IsValid returns true if the value at i is not null in its child.
+func (a *DenseUnion) IsValid(i int) bool { return !unionIsNull(a, i) }
+
+func (a *DenseUnion) String() string { return unionString(a) }
+
+func (a *DenseUnion) setData(data *Data) {
+	a.union.setData(data)
+	if vals := data.buffers[2]; vals != nil {
+		a.offsets = arrow.Int32Traits.CastFromBytes(vals.Bytes())
+	}
+	a.children = make([]Interface, len(data.childData))
+	for i, child := range data.childData {
+		a.children[i] = MakeFromData(child)
+	}
+}
+
+func unionIsNull(a unionArray, i int) bool {
+	return a.Field(a.ChildID(i)).IsNull(a.valueIndex(i))
+}
+
+func unionString(a unionArray) string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		if a.IsNull(i) {
+			o.WriteString("(null)")
+			continue
+		}
+		o.WriteString(valueString(a.Field(a.ChildID(i)), int64(a.valueIndex(i))))
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+// unionsEqual reports whether left and right have the same type codes and
+// their values are equal with eq.
+func unionsEqual(left, right unionArray, eq func(l, r Interface) bool) bool {
+	for i := 0; i < left.Len(); i++ {
+		if left.TypeCode(i) != right.TypeCode(i) {
+			return false
+		}
+		if left.IsNull(i) {
+			continue
+		}
+		id := left.ChildID(i)
+		li, ri := int64(left.valueIndex(i)), int64(right.valueIndex(i))
+		o := func() bool {
+			l := NewSlice(left.Field(id), li, li+1)
+			defer l.Release()
+			r := NewSlice(right.Field(id), ri, ri+1)
+			defer r.Release()
+			return eq(l, r)
+		}()
+		if !o {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayEqualUnion(left, right unionArray) bool {
+	return unionsEqual(left, right, ArrayEqual)
+}
+
+func arrayApproxEqualUnion(left, right unionArray, opt equalOption) bool {
+	return unionsEqual(left, right, func(l, r Interface) bool { return arrayApproxEqual(l, r, opt) })
+}
+
+// unionBuilder holds the type codes and child builders common to the
+// sparse and dense union builders.
+type unionBuilder struct {
+	builder
+
+	dt       *arrow.UnionType
+	codes    []arrow.UnionTypeCode
+	children []Builder
+}
+
+func newUnionBuilder(mem memory.Allocator, dt *arrow.UnionType) unionBuilder {
+	b := unionBuilder{
+		builder:  builder{refCount: 1, mem: mem},
+		dt:       dt,
+		children: make([]Builder, len(dt.Fields())),
+	}
+	for i, f := range dt.Fields() {
+		b.children[i] = NewBuilder(mem, f.Type)
+	}
+	return b
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *unionBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		for _, c := range b.children {
+			c.Release()
+		}
+		b.children = nil
+		b.codes = nil
+	}
+}
+
+// NumChildren returns the number of child builders.
+func (b *unionBuilder) NumChildren() int { return len(b.children) }
+
+// Child returns the builder of the child at pos.
+func (b *unionBuilder) Child(pos int) Builder { return b.children[pos] }
+
+// appendCode appends code and returns the index of the child it identifies.
+func (b *unionBuilder) appendCode(code arrow.UnionTypeCode) int {
+	id := b.dt.ChildID(code)
+	if id == arrow.InvalidUnionChildID {
+		panic("arrow/array: invalid union type code")
+	}
+	b.codes = append(b.codes, code)
+	b.length++
+	return id
+}
+
+// Cap returns the number of values that can be appended without
+// allocating additional memory.
+func (b *unionBuilder) Cap() int { return cap(b.codes) }
+
+func (b *unionBuilder) reserveCodes(n int) {
+	if len(b.codes)+n > cap(b.codes) {
+		codes := make([]arrow.UnionTypeCode, len(b.codes), len(b.codes)+n)
+		copy(codes, b.codes)
+		b.codes = codes
+	}
+}
+
+// newCodes returns a buffer with the type codes appended to the builder.
+func (b *unionBuilder) newCodes() *memory.Buffer {
+	buf := memory.NewResizableBuffer(b.mem)
+	buf.Resize(len(b.codes))
+	copy(buf.Bytes(), arrow.Int8Traits.CastToBytes(b.codes))
+	b.codes = b.codes[:0]
+	return buf
+}
+
+// newChildren returns the arrays built by the child builders. They must be
+// released after use.
+func (b *unionBuilder) newChildren() []Interface {
+	children := make([]Interface, len(b.children))
+	for i, c := range b.children {
+		children[i] = c.NewArray()
+	}
+	return children
+}
+
+// SparseUnionBuilder builds SparseUnion arrays: each value is started with
+// Append, which returns the builder to append it into.
+type SparseUnionBuilder struct {
+	unionBuilder
+}
+
+// NewSparseUnionBuilder returns a builder, using the provided memory
+// allocator.
+//
+// NewSparseUnionBuilder panics if dt is not a sparse union type.
+func NewSparseUnionBuilder(mem memory.Allocator, dt *arrow.UnionType) *SparseUnionBuilder {
+	if dt.Mode() != arrow.SparseMode {
+		panic("arrow/array: sparse union builder with a dense union type")
+	}
+	return &SparseUnionBuilder{unionBuilder: newUnionBuilder(mem, dt)}
+}
+
+// Append starts a value of the child identified by code and returns its
+// builder, into which exactly one value must be appended. A null is
+// appended to the other children.
+//
+// Append panics if code does not identify a child of the union.
+func (b *SparseUnionBuilder) Append(code arrow.UnionTypeCode) Builder {
+	id := b.appendCode(code)
+	for i, c := range b.children {
+		if i != id {
+			c.AppendNull()
+		}
+	}
+	return b.children[id]
+}
+
+// AppendNull appends a null value of the first child.
+//
+// AppendNull panics if the union has no children.
+func (b *SparseUnionBuilder) AppendNull() {
+	if len(b.children) == 0 {
+		panic("arrow/array: null appended to a union without children")
+	}
+	b.Append(b.dt.TypeCodes()[0]).AppendNull()
+}
+
+// Reserve ensures there is enough space for appending n values.
+func (b *SparseUnionBuilder) Reserve(n int) {
+	b.reserveCodes(n)
+	for _, c := range b.children {
+		c.Reserve(n)
+	}
+}
+
+// Resize adjusts the space allocated by b to n values.
+func (b *SparseUnionBuilder) Resize(n int) {
+	if n < len(b.codes) {
+		b.codes = b.codes[:n]
+		b.length = n
+	}
+	b.reserveCodes(n - len(b.codes))
+	for _, c := range b.children {
+		c.Resize(n)
+	}
+}
+
+// NewArray creates a SparseUnion array from the memory buffers used by the builder and resets the SparseUnionBuilder
+// so it can be used to build a new array.
+func (b *SparseUnionBuilder) NewArray() Interface {
+	return b.NewSparseUnionArray()
+}
+
+// NewSparseUnionArray creates a SparseUnion array from the memory buffers used by the builder and resets the
+// SparseUnionBuilder so it can be used to build a new array.
+//
+// NewSparseUnionArray panics if a value was not appended to a child after Append.
+func (b *SparseUnionBuilder) NewSparseUnionArray() (a *SparseUnion) {
+	for _, c := range b.children {
+		if c.Len() != b.length {
+			panic("arrow/array: union children length mismatch")
+		}
+	}
+
+	codes := b.newCodes()
+	defer codes.Release()
+	children := b.newChildren()
+	childData := make([]*Data, len(children))
+	for i, c := range children {
+		defer c.Release()
+		childData[i] = c.Data()
+	}
+
+	data := NewData(b.dt, b.length, []*memory.Buffer{nil, codes}, childData, 0, 0)
+	b.reset()
+	a = NewSparseUnionData(data)
+	data.Release()
+	return
+}
+
+// DenseUnionBuilder builds DenseUnion arrays: each value is started with
+// Append, which returns the builder to append it into.
+type DenseUnionBuilder struct {
+	unionBuilder
+
+	offsets []int32
+}
+
+// NewDenseUnionBuilder returns a builder, using the provided memory
+// allocator.
+//
+// NewDenseUnionBuilder panics if dt is not a dense union type.
+func NewDenseUnionBuilder(mem memory.Allocator, dt *arrow.UnionType) *DenseUnionBuilder {
+	if dt.Mode() != arrow.DenseMode {
+		panic("arrow/array: dense union builder with a sparse union type")
+	}
+	return &DenseUnionBuilder{unionBuilder: newUnionBuilder(mem, dt)}
+}
+
+// Append starts a value of the child identified by code and returns its
+// builder, into which exactly one value must be appended. The value offset
+// is the current length of the child.
+//
+// Append panics if code does not identify a child of the union, or if the
+// child is too long to be indexed by an int32 offset.
+func (b *DenseUnionBuilder) Append(code arrow.UnionTypeCode) Builder {
+	id := b.dt.ChildID(code)
+	if id != arrow.InvalidUnionChildID && b.children[id].Len() > math.MaxInt32 {
+		panic("arrow/array: union offset overflow")
+	}
+	b.appendCode(code)
+	b.offsets = append(b.offsets, int32(b.children[id].Len()))
+	return b.children[id]
+}
+
+// AppendNull appends a null value of the first child.
+//
+// AppendNull panics if the union has no children.
+func (b *DenseUnionBuilder) AppendNull() {
+	if len(b.children) == 0 {
+		panic("arrow/array: null appended to a union without children")
+	}
+	b.Append(b.dt.TypeCodes()[0]).AppendNull()
+}
+
+// Reserve ensures there is enough space for appending n values.
+func (b *DenseUnionBuilder) Reserve(n int) {
+	b.reserveCodes(n)
+	if len(b.offsets)+n > cap(b.offsets) {
+		offsets := make([]int32, len(b.offsets), len(b.offsets)+n)
+		copy(offsets, b.offsets)
+		b.offsets = offsets
+	}
+}
+
+// Resize adjusts the space allocated by b to n values.
+func (b *DenseUnionBuilder) Resize(n int) {
+	if n < len(b.codes) {
+		b.codes = b.codes[:n]
+		b.offsets = b.offsets[:n]
+		b.length = n
+	}
+	b.Reserve(n - len(b.codes))
+}
+
+// NewArray creates a DenseUnion array from the memory buffers used by the builder and resets the DenseUnionBuilder
+// so it can be used to build a new array.
+func (b *DenseUnionBuilder) NewArray() Interface {
+	return b.NewDenseUnionArray()
+}
+
+// NewDenseUnionArray creates a DenseUnion array from the memory buffers used by the builder and resets the
+// DenseUnionBuilder so it can be used to build a new array.
+//
+// NewDenseUnionArray panics if a value was not appended to a child after Append.
+func (b *DenseUnionBuilder) NewDenseUnionArray() (a *DenseUnion) {
+	for i, code := range b.codes {
+		if int(b.offsets[i]) >= b.children[b.dt.ChildID(code)].Len() {
+			panic("arrow/array: union children length mismatch")
+		}
+	}
+
+	codes := b.newCodes()
+	defer codes.Release()
+	offsets := memory.NewResizableBuffer(b.mem)
+	defer offsets.Release()
+	offsets.Resize(arrow.Int32Traits.BytesRequired(len(b.offsets)))
+	copy(offsets.Bytes(), arrow.Int32Traits.CastToBytes(b.offsets))
+	b.offsets = b.offsets[:0]
+
+	children := b.newChildren()
+	childData := make([]*Data, len(children))
+	for i, c := range children {
+		defer c.Release()
+		childData[i] = c.Data()
+	}
+
+	data := NewData(b.dt, b.length, []*memory.Buffer{nil, codes, offsets}, childData, 0, 0)
+	b.reset()
+	a = NewDenseUnionData(data)
+	data.Release()
+	return
+}
+
+var (
+	_ Interface = (*SparseUnion)(nil)
+	_ Interface = (*DenseUnion)(nil)
+	_ Builder   = (*SparseUnionBuilder)(nil)
+	_ Builder   = (*DenseUnionBuilder)(nil)
+)
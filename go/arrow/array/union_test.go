@@ -0,0 +1,273 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// unionFields are the fields of the union types of the tests: their type
+// codes are not contiguous and the float64 field is never used.
+var (
+	unionFields = []arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}
+	unionCodes = []arrow.UnionTypeCode{2, 7, 5}
+)
+
+// appendUnionValue appends v, an int32, a string or nil for a null, to the
+// union builder b.
+func appendUnionValue(b interface {
+	Append(arrow.UnionTypeCode) array.Builder
+	AppendNull()
+}, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		b.AppendNull()
+	case int32:
+		b.Append(2).(*array.Int32Builder).Append(v)
+	case string:
+		b.Append(7).(*array.StringBuilder).Append(v)
+	}
+}
+
+// newUnion returns the union array of the given mode of the values vs.
+func newUnion(mem memory.Allocator, mode arrow.UnionMode, vs []interface{}) array.Interface {
+	dt := arrow.UnionOf(mode, unionFields, unionCodes)
+	switch mode {
+	case arrow.SparseMode:
+		b := array.NewSparseUnionBuilder(mem, dt)
+		defer b.Release()
+		for _, v := range vs {
+			appendUnionValue(b, v)
+		}
+		return b.NewArray()
+	default:
+		b := array.NewDenseUnionBuilder(mem, dt)
+		defer b.Release()
+		for _, v := range vs {
+			appendUnionValue(b, v)
+		}
+		return b.NewArray()
+	}
+}
+
+func TestUnionArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	vs := []interface{}{int32(1), "a", nil, "b", int32(2)}
+	for _, tc := range []struct {
+		mode    arrow.UnionMode
+		lens    []int
+		offsets []int32
+	}{
+		{mode: arrow.SparseMode, lens: []int{5, 5, 5}},
+		{mode: arrow.DenseMode, lens: []int{3, 2, 0}, offsets: []int32{0, 0, 1, 1, 2}},
+	} {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			arr := newUnion(pool, tc.mode, vs)
+			defer arr.Release()
+
+			if got, want := arr.DataType(), arrow.UnionOf(tc.mode, unionFields, unionCodes); !arrow.TypeEqual(got, want) {
+				t.Fatalf("invalid type: got=%v, want=%v", got, want)
+			}
+			if got, want := arr.Len(), len(vs); got != want {
+				t.Fatalf("invalid length: got=%d, want=%d", got, want)
+			}
+			if got, want := arr.NullN(), 0; got != want {
+				t.Fatalf("invalid number of nulls: got=%d, want=%d", got, want)
+			}
+			if got, want := fmt.Sprint(arr), `[1 "a" (null) "b" 2]`; got != want {
+				t.Fatalf("invalid string: got=%q, want=%q", got, want)
+			}
+
+			var (
+				codes []arrow.UnionTypeCode
+				field func(int) array.Interface
+			)
+			switch arr := arr.(type) {
+			case *array.SparseUnion:
+				codes, field = arr.TypeCodes(), arr.Field
+			case *array.DenseUnion:
+				codes, field = arr.TypeCodes(), arr.Field
+				if got, want := arr.ValueOffsets(), tc.offsets; !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid offsets: got=%v, want=%v", got, want)
+				}
+			}
+			if got, want := codes, []arrow.UnionTypeCode{2, 7, 2, 7, 2}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid type codes: got=%v, want=%v", got, want)
+			}
+			for i, want := range tc.lens {
+				if got := field(i).Len(); got != want {
+					t.Fatalf("invalid length of child %d: got=%d, want=%d", i, got, want)
+				}
+			}
+			for i, v := range vs {
+				if got, want := arr.IsNull(i), v == nil; got != want {
+					t.Fatalf("invalid null %d: got=%v, want=%v", i, got, want)
+				}
+			}
+
+			other := newUnion(pool, tc.mode, []interface{}{int32(1), "a", nil, "c", int32(2)})
+			defer other.Release()
+			if !array.ArrayEqual(arr, arr) {
+				t.Fatalf("array should be equal to itself")
+			}
+			if array.ArrayEqual(arr, other) {
+				t.Fatalf("arrays should differ")
+			}
+			if !array.ArraySliceEqual(arr, 0, 3, other, 0, 3) {
+				t.Fatalf("slices should be equal")
+			}
+		})
+	}
+}
+
+func TestUnionArraySlice(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	vs := []interface{}{int32(1), "a", nil, "b", int32(2), "c"}
+	for _, mode := range []arrow.UnionMode{arrow.SparseMode, arrow.DenseMode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			arr := newUnion(pool, mode, vs)
+			defer arr.Release()
+
+			slice := array.NewSlice(arr, 2, 5)
+			defer slice.Release()
+
+			want := newUnion(pool, mode, vs[2:5])
+			defer want.Release()
+
+			if got, want := fmt.Sprint(slice), `[(null) "b" 2]`; got != want {
+				t.Fatalf("invalid string: got=%q, want=%q", got, want)
+			}
+			if !array.ArrayEqual(slice, want) {
+				t.Fatalf("invalid slice:\ngot= %v\nwant=%v", slice, want)
+			}
+
+			var codes []arrow.UnionTypeCode
+			switch slice := slice.(type) {
+			case *array.SparseUnion:
+				codes = slice.TypeCodes()
+				if got, want := slice.Field(1).Len(), 3; got != want {
+					t.Fatalf("invalid length of child: got=%d, want=%d", got, want)
+				}
+			case *array.DenseUnion:
+				codes = slice.TypeCodes()
+				if got, want := slice.ValueOffsets(), []int32{1, 1, 2}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid offsets: got=%v, want=%v", got, want)
+				}
+			}
+			if got, want := codes, []arrow.UnionTypeCode{2, 7, 2}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid type codes: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestUnionNestedInList(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dt := arrow.DenseUnionOf(unionFields, unionCodes)
+	lb := array.NewListBuilder(pool, dt)
+	defer lb.Release()
+
+	ub := lb.ValueBuilder().(*array.DenseUnionBuilder)
+	for _, row := range [][]interface{}{{int32(1), "a"}, nil, {}, {nil, "b"}} {
+		if row == nil {
+			lb.AppendNull()
+			continue
+		}
+		lb.Append(true)
+		for _, v := range row {
+			appendUnionValue(ub, v)
+		}
+	}
+	arr := lb.NewListArray()
+	defer arr.Release()
+
+	if got, want := arr.String(), `[[1 "a"] (null) [] [(null) "b"]]`; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "l", Type: arr.DataType(), Nullable: true}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	got := array.RecordToMaps(rec)
+	want := []map[string]interface{}{
+		{"l": []interface{}{int32(1), "a"}},
+		{"l": nil},
+		{"l": []interface{}{}},
+		{"l": []interface{}{nil, "b"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid maps:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestUnionBuilderPanics(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name string
+		f    func(b array.Builder)
+	}{
+		{"invalid code", func(b array.Builder) {
+			switch b := b.(type) {
+			case *array.SparseUnionBuilder:
+				b.Append(3)
+			case *array.DenseUnionBuilder:
+				b.Append(3)
+			}
+		}},
+		{"missing value", func(b array.Builder) {
+			switch b := b.(type) {
+			case *array.SparseUnionBuilder:
+				b.Append(7)
+			case *array.DenseUnionBuilder:
+				b.Append(7)
+			}
+			b.NewArray().Release()
+		}},
+	} {
+		for _, mode := range []arrow.UnionMode{arrow.SparseMode, arrow.DenseMode} {
+			t.Run(tc.name+"/"+mode.String(), func(t *testing.T) {
+				b := array.NewBuilder(pool, arrow.UnionOf(mode, unionFields, unionCodes))
+				defer b.Release()
+				defer func() {
+					if e := recover(); e == nil {
+						t.Fatalf("test should have panicked but did not")
+					}
+				}()
+				tc.f(b)
+			})
+		}
+	}
+}
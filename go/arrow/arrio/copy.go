@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrio
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// Progress reports the records copied by CopyContext.
+type Progress struct {
+	Records int64         // number of records written.
+	Rows    int64         // number of rows written.
+	Bytes   int64         // size of the buffers of the records written.
+	Elapsed time.Duration // time since the start of the copy.
+}
+
+// CopyOption configures CopyContext.
+type CopyOption func(*copyConfig)
+
+type copyConfig struct {
+	maxRows  int64
+	progress func(Progress)
+}
+
+// WithMaxRows stops the copy once n rows were written, the last record
+// being sliced to the rows left.
+func WithMaxRows(n int64) CopyOption {
+	return func(cfg *copyConfig) {
+		cfg.maxRows = n
+	}
+}
+
+// WithProgress calls f after each record written, from the goroutine
+// copying the records.
+func WithProgress(f func(Progress)) CopyOption {
+	return func(cfg *copyConfig) {
+		cfg.progress = f
+	}
+}
+
+// CopyContext copies the records of src to dst until src returns io.EOF,
+// the limit set with WithMaxRows is reached, or ctx is done. It returns the
+// progress of the copy and the first error encountered, ctx.Err() when ctx
+// is done: ctx is checked before reading each record, a record read being
+// always written.
+//
+// The last record is sliced to the rows left when WithMaxRows is reached
+// within it. That slice shares the memory of the record read from src, and
+// is released once written: a dst keeping the records it is handed must
+// retain them, as usual, and may then release them independently of src.
+func CopyContext(ctx context.Context, dst Writer, src Reader, opts ...CopyOption) (Progress, error) {
+	cfg := copyConfig{maxRows: -1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		p     Progress
+		start = time.Now()
+	)
+	for cfg.maxRows < 0 || p.Rows < cfg.maxRows {
+		if err := ctx.Err(); err != nil {
+			return p, err
+		}
+		rec, err := src.Read()
+		if err != nil {
+			if err == io.EOF {
+				return p, nil
+			}
+			return p, err
+		}
+
+		sliced := cfg.maxRows >= 0 && rec.NumRows() > cfg.maxRows-p.Rows
+		if sliced {
+			rec = rec.NewSlice(0, cfg.maxRows-p.Rows)
+		}
+		err = dst.Write(rec)
+		if err == nil {
+			p.Records++
+			p.Rows += rec.NumRows()
+			for _, col := range rec.Columns() {
+				p.Bytes += dataSize(col.Data())
+			}
+		}
+		if sliced {
+			rec.Release()
+		}
+		if err != nil {
+			return p, err
+		}
+		p.Elapsed = time.Since(start)
+		if cfg.progress != nil {
+			cfg.progress(p)
+		}
+	}
+	return p, nil
+}
+
+// CopyRows copies at most n rows from src to dst, slicing the last record
+// to the rows left. It returns the number of rows copied and the first
+// error encountered while copying, if any. Unlike CopyN, reaching the end
+// of src before n rows is not an error.
+func CopyRows(dst Writer, src Reader, n int64) (rows int64, err error) {
+	p, err := CopyContext(context.Background(), dst, src, WithMaxRows(n))
+	return p.Rows, err
+}
+
+// dataSize returns the size of the buffers of data, its children and its
+// dictionary. The buffers of a slice are counted whole.
+func dataSize(data *array.Data) int64 {
+	var n int64
+	for _, buf := range data.Buffers() {
+		if buf != nil {
+			n += int64(buf.Len())
+		}
+	}
+	for _, child := range data.Children() {
+		n += dataSize(child)
+	}
+	if dict := data.Dictionary(); dict != nil {
+		n += dataSize(dict)
+	}
+	return n
+}
@@ -0,0 +1,189 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrio_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/arrio"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// recordsWriter keeps the records written to it.
+type recordsWriter struct {
+	recs []array.Record
+}
+
+func (w *recordsWriter) Write(rec array.Record) error {
+	rec.Retain()
+	w.recs = append(w.recs, rec)
+	return nil
+}
+
+func (w *recordsWriter) release() {
+	for _, rec := range w.recs {
+		rec.Release()
+	}
+	w.recs = nil
+}
+
+// streamReader returns a reader of recs written to an IPC stream, read
+// with mem.
+func streamReader(t *testing.T, mem memory.Allocator, recs []array.Record) *ipc.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(recs[0].Schema()))
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestCopyRows(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		var total int64
+		for _, rec := range recs {
+			total += rec.NumRows()
+		}
+		for _, n := range []int64{0, 1, recs[0].NumRows(), recs[0].NumRows() + 1, total - 1, total, total + 1} {
+			t.Run(fmt.Sprintf("%s/n=%d", name, n), func(t *testing.T) {
+				mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+				defer mem.AssertSize(t, 0)
+
+				r := streamReader(t, mem, recs)
+				var w recordsWriter
+				rows, err := arrio.CopyRows(&w, r, n)
+				// the records copied outlive the reader.
+				r.Release()
+				defer w.release()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := n
+				if want > total {
+					want = total
+				}
+				if rows != want {
+					t.Fatalf("invalid number of rows: got=%d, want=%d", rows, want)
+				}
+
+				left := want
+				for i, rec := range w.recs {
+					exp := recs[i]
+					if exp.NumRows() > left {
+						exp = exp.NewSlice(0, left)
+						defer exp.Release()
+					}
+					if !array.RecordEqual(rec, exp) {
+						t.Fatalf("records[%d] differ:\ngot=%v\nwant=%v", i, rec, exp)
+					}
+					left -= rec.NumRows()
+				}
+				if left != 0 {
+					t.Fatalf("invalid number of rows written: %d missing", left)
+				}
+
+				// the sliced records are written as such to IPC streams.
+				if len(w.recs) > 0 {
+					rr := streamReader(t, mem, w.recs)
+					defer rr.Release()
+					for i := 0; rr.Next(); i++ {
+						if !array.RecordEqual(rr.Record(), w.recs[i]) {
+							t.Fatalf("records[%d] differ once written", i)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCopyContext(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	recs := arrdata.Records["primitives"]
+	r := streamReader(t, mem, recs)
+	defer r.Release()
+
+	var (
+		w        recordsWriter
+		reports  []arrio.Progress
+		ctx, cnl = context.WithCancel(context.Background())
+	)
+	defer w.release()
+
+	p, err := arrio.CopyContext(ctx, &w, r, arrio.WithProgress(func(p arrio.Progress) {
+		reports = append(reports, p)
+		if p.Records == 2 {
+			cnl()
+		}
+	}))
+	if err != context.Canceled {
+		t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+	}
+	if p.Records != 2 || len(w.recs) != 2 || len(reports) != 2 {
+		t.Fatalf("invalid number of records: got=%d, written=%d, reported=%d, want=2", p.Records, len(w.recs), len(reports))
+	}
+	if p != reports[1] {
+		t.Fatalf("invalid progress: got=%+v, reported=%+v", p, reports[1])
+	}
+	for i, rep := range reports {
+		var rows int64
+		for _, rec := range recs[:i+1] {
+			rows += rec.NumRows()
+		}
+		if rep.Rows != rows {
+			t.Fatalf("invalid rows reported[%d]: got=%d, want=%d", i, rep.Rows, rows)
+		}
+		if i > 0 && (rep.Bytes <= reports[i-1].Bytes || rep.Elapsed < reports[i-1].Elapsed) {
+			t.Fatalf("progress[%d] does not increase: %+v, then %+v", i, reports[i-1], rep)
+		}
+	}
+
+	// the copy resumes where it stopped.
+	p, err = arrio.CopyContext(context.Background(), &w, r, arrio.WithMaxRows(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Records != 1 || p.Rows != 1 {
+		t.Fatalf("invalid progress: got=%+v, want 1 row", p)
+	}
+	exp := recs[2].NewSlice(0, 1)
+	defer exp.Release()
+	if !array.RecordEqual(w.recs[2], exp) {
+		t.Fatalf("invalid record: got=%v, want=%v", w.recs[2], exp)
+	}
+}
@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrio
+
+import (
+	"sync"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"golang.org/x/xerrors"
+)
+
+type multiWriter struct {
+	ws []Writer
+}
+
+func (mw *multiWriter) Write(rec array.Record) error {
+	for i, w := range mw.ws {
+		if err := w.Write(rec); err != nil {
+			return xerrors.Errorf("arrow/arrio: could not write record to writer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// MultiWriter creates a writer that duplicates its writes to all the
+// provided writers, similar to the Unix tee(1) command.
+//
+// Each write is written to each listed writer, one at a time.
+// If a listed writer returns an error, that overall write operation
+// stops and returns the error, wrapped with the index of the writer; it
+// does not continue down the list.
+func MultiWriter(ws ...Writer) Writer {
+	all := make([]Writer, len(ws))
+	copy(all, ws)
+	return &multiWriter{ws: all}
+}
+
+// fanOutBuffer is the maximum number of records read from the source of a
+// fan-out and not yet read by all of its readers.
+const fanOutBuffer = 16
+
+// fanOut is the state shared by the readers of a fan-out.
+type fanOut struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	src     Reader
+	reading bool
+	err     error // error of src, io.EOF at its end.
+
+	// recs are the records read from src which have not been read by all
+	// readers yet, recs[0] being the record of index base.
+	recs    []array.Record
+	base    int64
+	readers []*FanOutReader
+}
+
+// FanOutReader is one of the readers of a fan-out, created by NewFanOut.
+type FanOutReader struct {
+	f      *fanOut
+	pos    int64 // index of the next record.
+	cur    array.Record
+	closed bool
+}
+
+// NewFanOut returns n readers each reading all the records of r, which is
+// read once. The records read from r are buffered until all the readers
+// have read them, and a reader more than 16 records ahead of the slowest
+// one blocks, so that the readers should be read concurrently. A reader
+// which is not read to the end must be closed for the others to proceed.
+//
+// The records are retained until all the readers have read them. A record
+// returned by a reader is valid until its next call to Read or Close.
+// Users need to call Retain on that record to keep it valid for longer.
+func NewFanOut(r Reader, n int) []*FanOutReader {
+	f := &fanOut{src: r, readers: make([]*FanOutReader, n)}
+	f.cond = sync.NewCond(&f.mu)
+	for i := range f.readers {
+		f.readers[i] = &FanOutReader{f: f}
+	}
+	readers := make([]*FanOutReader, n)
+	copy(readers, f.readers)
+	return readers
+}
+
+// Read reads the next record of the fan-out source, and returns (nil,
+// io.EOF) at its end. The error of the source, if any, is returned once all
+// the records read before it were returned.
+func (r *FanOutReader) Read() (array.Record, error) {
+	f := r.f
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r.release()
+	if r.closed {
+		return nil, xerrors.New("arrow/arrio: read of closed fan-out reader")
+	}
+	for {
+		if i := r.pos - f.base; i < int64(len(f.recs)) {
+			r.cur = f.recs[i]
+			r.cur.Retain()
+			r.pos++
+			f.trim()
+			return r.cur, nil
+		}
+		if f.err != nil {
+			return nil, f.err
+		}
+		if f.reading || len(f.recs) >= fanOutBuffer {
+			f.cond.Wait()
+			continue
+		}
+
+		f.reading = true
+		f.mu.Unlock()
+		rec, err := f.src.Read()
+		if err == nil {
+			rec.Retain()
+		}
+		f.mu.Lock()
+		f.reading = false
+		if err != nil {
+			f.err = err
+		} else {
+			f.recs = append(f.recs, rec)
+		}
+		f.cond.Broadcast()
+	}
+}
+
+// Close releases the current record of r, and stops buffering records for
+// it. Read must not be called after Close.
+func (r *FanOutReader) Close() error {
+	f := r.f
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r.release()
+	r.closed = true
+	f.trim()
+	return nil
+}
+
+func (r *FanOutReader) release() {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+}
+
+// trim releases the records read by all the open readers.
+func (f *fanOut) trim() {
+	n := int64(len(f.recs))
+	for _, r := range f.readers {
+		if !r.closed && r.pos-f.base < n {
+			n = r.pos - f.base
+		}
+	}
+	if n == 0 {
+		return
+	}
+	for _, rec := range f.recs[:n] {
+		rec.Release()
+	}
+	f.recs = append(f.recs[:0], f.recs[n:]...)
+	f.base += n
+	f.cond.Broadcast()
+}
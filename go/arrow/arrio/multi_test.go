@@ -0,0 +1,245 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrio_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/arrio"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// sliceReader reads a slice of records, followed by err or io.EOF.
+type sliceReader struct {
+	recs []array.Record
+	err  error
+}
+
+func (r *sliceReader) Read() (array.Record, error) {
+	if len(r.recs) == 0 {
+		if r.err != nil {
+			return nil, r.err
+		}
+		return nil, io.EOF
+	}
+	rec := r.recs[0]
+	r.recs = r.recs[1:]
+	return rec, nil
+}
+
+// failingWriter fails to write after n records.
+type failingWriter struct {
+	n    int
+	recs []array.Record
+}
+
+func (w *failingWriter) Write(rec array.Record) error {
+	if len(w.recs) == w.n {
+		return xerrors.New("disk full")
+	}
+	w.recs = append(w.recs, rec)
+	return nil
+}
+
+func makeRecords(mem memory.Allocator, n int) (*arrow.Schema, []array.Record) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	recs := make([]array.Record, n)
+	for i := range recs {
+		b.Field(0).(*array.Int64Builder).AppendValues([]int64{int64(i), int64(2 * i)}, nil)
+		recs[i] = b.NewRecord()
+	}
+	return schema, recs
+}
+
+func releaseRecords(recs []array.Record) {
+	for _, rec := range recs {
+		rec.Release()
+	}
+}
+
+func TestMultiWriter(t *testing.T) {
+	for _, name := range arrdata.RecordNames {
+		t.Run(name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			recs := arrdata.Records[name]
+			schema := recs[0].Schema()
+
+			var bufs [2]bytes.Buffer
+			w0 := ipc.NewWriter(&bufs[0], ipc.WithSchema(schema), ipc.WithAllocator(mem))
+			w1 := ipc.NewWriter(&bufs[1], ipc.WithSchema(schema), ipc.WithAllocator(mem))
+			n, err := arrio.Copy(arrio.MultiWriter(w0, w1), &sliceReader{recs: recs})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(len(recs)) {
+				t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+			}
+			for _, w := range []*ipc.Writer{w0, w1} {
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for i := range bufs {
+				r, err := ipc.NewReader(&bufs[i], ipc.WithSchema(schema), ipc.WithAllocator(mem))
+				if err != nil {
+					t.Fatal(err)
+				}
+				for j := 0; r.Next(); j++ {
+					if !array.RecordEqual(r.Record(), recs[j]) {
+						t.Fatalf("writer %d: invalid record %d:\ngot= %v\nwant=%v", i, j, r.Record(), recs[j])
+					}
+				}
+				r.Release()
+			}
+		})
+	}
+}
+
+func TestMultiWriterError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	_, recs := makeRecords(mem, 5)
+	defer releaseRecords(recs)
+
+	ws := []*failingWriter{{n: 5}, {n: 2}, {n: 5}}
+	n, err := arrio.Copy(arrio.MultiWriter(ws[0], ws[1], ws[2]), &sliceReader{recs: recs})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if n != 2 {
+		t.Fatalf("invalid number of records: got=%d, want=2", n)
+	}
+	if !strings.Contains(err.Error(), "writer 1") || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("invalid error: %v", err)
+	}
+	// the writers after the failing one do not get the record.
+	if got := []int{len(ws[0].recs), len(ws[1].recs), len(ws[2].recs)}; fmt.Sprint(got) != "[3 2 2]" {
+		t.Fatalf("invalid records written: got=%v", got)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		recs, n   int
+		err       error
+		closeLast bool
+	}{
+		{name: "one", recs: 3, n: 1},
+		{name: "many", recs: 50, n: 4},
+		{name: "empty", recs: 0, n: 2},
+		{name: "error", recs: 20, n: 3, err: xerrors.New("broken pipe")},
+		{name: "close", recs: 50, n: 3, closeLast: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			_, recs := makeRecords(mem, tc.recs)
+			src := &sliceReader{recs: recs, err: tc.err}
+			readers := arrio.NewFanOut(src, tc.n)
+			// the source only lends its records to the fan-out.
+			defer releaseRecords(recs)
+
+			if tc.closeLast {
+				readers[tc.n-1].Close()
+				readers = readers[:tc.n-1]
+			}
+
+			var wg sync.WaitGroup
+			got := make([][]array.Record, len(readers))
+			errs := make([]error, len(readers))
+			for i, r := range readers {
+				wg.Add(1)
+				go func(i int, r *arrio.FanOutReader) {
+					defer wg.Done()
+					for {
+						rec, err := r.Read()
+						if err != nil {
+							errs[i] = err
+							return
+						}
+						rec.Retain()
+						got[i] = append(got[i], rec)
+					}
+				}(i, r)
+			}
+			wg.Wait()
+
+			want := tc.err
+			if want == nil {
+				want = io.EOF
+			}
+			for i := range readers {
+				if errs[i] != want {
+					t.Errorf("reader %d: invalid error: got=%v, want=%v", i, errs[i], want)
+				}
+				if len(got[i]) != len(recs) {
+					t.Errorf("reader %d: invalid number of records: got=%d, want=%d", i, len(got[i]), len(recs))
+				}
+				for j, rec := range got[i] {
+					if rec != recs[j] {
+						t.Errorf("reader %d: invalid record %d", i, j)
+					}
+				}
+				releaseRecords(got[i])
+				readers[i].Close()
+			}
+		})
+	}
+}
+
+func TestFanOutSequential(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	_, recs := makeRecords(mem, 10)
+	defer releaseRecords(recs)
+
+	// readers less records apart than the buffer size can be read one after the
+	// other.
+	readers := arrio.NewFanOut(&sliceReader{recs: recs}, 2)
+	for i, r := range readers {
+		n, err := arrio.Copy(&failingWriter{n: len(recs)}, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(recs)) {
+			t.Fatalf("reader %d: invalid number of records: got=%d, want=%d", i, n, len(recs))
+		}
+		r.Close()
+	}
+	if _, err := readers[0].Read(); err == nil {
+		t.Fatalf("read a closed reader")
+	}
+}
@@ -15,17 +15,23 @@
 // limitations under the License.
 
 // Package arrjson provides types and functions to encode and decode ARROW types and data
-// to and from JSON files.
-package arrjson // import "github.com/apache/arrow/go/arrow/internal/arrjson"
+// to and from JSON files, in the JSON format of the Arrow integration tests.
+//
+// ReadJSON and WriteJSON read and write all the records of such a file, which
+// makes it possible to compare records with human-readable golden files, or
+// with the files of the other Arrow implementations.
+package arrjson // import "github.com/apache/arrow/go/arrow/arrjson"
 
 import (
 	"encoding/hex"
 	"encoding/json"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
 	"github.com/apache/arrow/go/arrow/float16"
 	"github.com/apache/arrow/go/arrow/memory"
 	"golang.org/x/xerrors"
@@ -45,10 +51,19 @@ type Schema struct {
 }
 
 type Field struct {
-	Name     string   `json:"name"`
-	Type     dataType `json:"type"`
-	Nullable bool     `json:"nullable"`
-	Children []Field  `json:"children"`
+	Name       string          `json:"name"`
+	Type       dataType        `json:"type"`
+	Nullable   bool            `json:"nullable"`
+	Children   []Field         `json:"children"`
+	Dictionary *dictionaryType `json:"dictionary,omitempty"`
+}
+
+// dictionaryType describes the encoding of a dictionary-encoded field, whose
+// type and children are the ones of its dictionary values.
+type dictionaryType struct {
+	ID        int64    `json:"id"`
+	IndexType dataType `json:"indexType"`
+	Ordered   bool     `json:"isOrdered"`
 }
 
 type dataType struct {
@@ -61,6 +76,8 @@ type dataType struct {
 	Unit      string `json:"unit,omitempty"`
 	TimeZone  string `json:"timezone,omitempty"`
 	Scale     int    `json:"scale,omitempty"` // for Decimal128
+	Mode      string `json:"mode,omitempty"`  // for Union
+	TypeIDs   []int  `json:"typeIds,omitempty"`
 }
 
 func dtypeToJSON(dt arrow.DataType) dataType {
@@ -95,6 +112,10 @@ func dtypeToJSON(dt arrow.DataType) dataType {
 		return dataType{Name: "binary"}
 	case *arrow.StringType:
 		return dataType{Name: "utf8"}
+	case *arrow.BinaryViewType:
+		return dataType{Name: "binaryview"}
+	case *arrow.StringViewType:
+		return dataType{Name: "utf8view"}
 	case *arrow.Date32Type:
 		return dataType{Name: "date", Unit: "DAY"}
 	case *arrow.Date64Type:
@@ -146,6 +167,17 @@ func dtypeToJSON(dt arrow.DataType) dataType {
 		return dataType{Name: "struct"}
 	case *arrow.FixedSizeListType:
 		return dataType{Name: "fixedsizelist", ListSize: dt.Len()}
+	case *arrow.RunEndEncodedType:
+		return dataType{Name: "runendencoded"}
+	case *arrow.UnionType:
+		o := dataType{Name: "union", Mode: "SPARSE", TypeIDs: make([]int, len(dt.TypeCodes()))}
+		if dt.Mode() == arrow.DenseMode {
+			o.Mode = "DENSE"
+		}
+		for i, code := range dt.TypeCodes() {
+			o.TypeIDs[i] = int(code)
+		}
+		return o
 	case *arrow.FixedSizeBinaryType:
 		return dataType{
 			Name:      "fixedsizebinary",
@@ -199,6 +231,10 @@ func dtypeFromJSON(dt dataType, children []Field) arrow.DataType {
 		return arrow.BinaryTypes.Binary
 	case "utf8":
 		return arrow.BinaryTypes.String
+	case "binaryview":
+		return arrow.BinaryTypes.BinaryView
+	case "utf8view":
+		return arrow.BinaryTypes.StringView
 	case "date":
 		switch dt.Unit {
 		case "DAY":
@@ -235,13 +271,32 @@ func dtypeFromJSON(dt dataType, children []Field) arrow.DataType {
 			return &arrow.TimestampType{TimeZone: dt.TimeZone, Unit: arrow.Nanosecond}
 		}
 	case "list":
-		return arrow.ListOf(dtypeFromJSON(children[0].Type, nil))
+		return arrow.ListOf(fieldFromJSON(children[0]).Type)
 	case "struct":
 		return arrow.StructOf(fieldsFromJSON(children)...)
 	case "fixedsizebinary":
 		return &arrow.FixedSizeBinaryType{ByteWidth: dt.ByteWidth}
 	case "fixedsizelist":
-		return arrow.FixedSizeListOf(dt.ListSize, dtypeFromJSON(children[0].Type, nil))
+		return arrow.FixedSizeListOf(dt.ListSize, fieldFromJSON(children[0]).Type)
+	case "runendencoded":
+		return arrow.RunEndEncodedOf(
+			fieldFromJSON(children[0]).Type,
+			fieldFromJSON(children[1]).Type,
+		)
+	case "union":
+		var codes []arrow.UnionTypeCode
+		if dt.TypeIDs != nil {
+			codes = make([]arrow.UnionTypeCode, len(dt.TypeIDs))
+			for i, id := range dt.TypeIDs {
+				codes[i] = arrow.UnionTypeCode(id)
+			}
+		}
+		switch dt.Mode {
+		case "SPARSE":
+			return arrow.SparseUnionOf(fieldsFromJSON(children), codes)
+		case "DENSE":
+			return arrow.DenseUnionOf(fieldsFromJSON(children), codes)
+		}
 	case "interval":
 		switch dt.Unit {
 		case "YEAR_MONTH":
@@ -265,8 +320,9 @@ func dtypeFromJSON(dt dataType, children []Field) arrow.DataType {
 }
 
 func schemaToJSON(schema *arrow.Schema) Schema {
+	var id int64
 	return Schema{
-		Fields: fieldsToJSON(schema.Fields()),
+		Fields: fieldsToJSON(schema.Fields(), &id),
 	}
 }
 
@@ -274,22 +330,39 @@ func schemaFromJSON(schema Schema) *arrow.Schema {
 	return arrow.NewSchema(fieldsFromJSON(schema.Fields), nil)
 }
 
-func fieldsToJSON(fields []arrow.Field) []Field {
+// fieldsToJSON returns the JSON form of fields. The dictionary-encoded fields
+// are given IDs from id, in depth-first order, see dictionariesOf.
+func fieldsToJSON(fields []arrow.Field, id *int64) []Field {
 	o := make([]Field, len(fields))
 	for i, f := range fields {
-		o[i] = Field{
-			Name:     f.Name,
-			Type:     dtypeToJSON(f.Type),
-			Nullable: f.Nullable,
-			Children: []Field{},
-		}
-		switch dt := f.Type.(type) {
+		typ := f.Type
+		if dt, ok := typ.(*arrow.DictionaryType); ok {
+			o[i].Dictionary = &dictionaryType{
+				ID:        *id,
+				IndexType: dtypeToJSON(dt.IndexType),
+				Ordered:   dt.Ordered,
+			}
+			*id++
+			typ = dt.ValueType
+		}
+		o[i].Name = f.Name
+		o[i].Type = dtypeToJSON(typ)
+		o[i].Nullable = f.Nullable
+		o[i].Children = []Field{}
+		switch dt := typ.(type) {
 		case *arrow.ListType:
-			o[i].Children = fieldsToJSON([]arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: f.Nullable}})
+			o[i].Children = fieldsToJSON([]arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: f.Nullable}}, id)
 		case *arrow.FixedSizeListType:
-			o[i].Children = fieldsToJSON([]arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: f.Nullable}})
+			o[i].Children = fieldsToJSON([]arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: f.Nullable}}, id)
 		case *arrow.StructType:
-			o[i].Children = fieldsToJSON(dt.Fields())
+			o[i].Children = fieldsToJSON(dt.Fields(), id)
+		case *arrow.UnionType:
+			o[i].Children = fieldsToJSON(dt.Fields(), id)
+		case *arrow.RunEndEncodedType:
+			o[i].Children = fieldsToJSON([]arrow.Field{
+				{Name: "run_ends", Type: dt.RunEnds()},
+				{Name: "values", Type: dt.Encoded(), Nullable: true},
+			}, id)
 		}
 	}
 	return o
@@ -304,28 +377,160 @@ func fieldsFromJSON(fields []Field) []arrow.Field {
 }
 
 func fieldFromJSON(f Field) arrow.Field {
+	typ := dtypeFromJSON(f.Type, f.Children)
+	if f.Dictionary != nil {
+		typ = &arrow.DictionaryType{
+			IndexType: dtypeFromJSON(f.Dictionary.IndexType, nil),
+			ValueType: typ,
+			Ordered:   f.Dictionary.Ordered,
+		}
+	}
 	return arrow.Field{
 		Name:     f.Name,
-		Type:     dtypeFromJSON(f.Type, f.Children),
+		Type:     typ,
 		Nullable: f.Nullable,
 	}
 }
 
+// dictTypesFromJSON adds to types the dictionary types of the fields read
+// from the JSON fields, by dictionary ID.
+func dictTypesFromJSON(fields []Field, afields []arrow.Field, types map[int64]*arrow.DictionaryType) {
+	for i, f := range fields {
+		dictTypeFromJSON(f, afields[i].Type, types)
+	}
+}
+
+func dictTypeFromJSON(f Field, typ arrow.DataType, types map[int64]*arrow.DictionaryType) {
+	if f.Dictionary != nil {
+		dt := typ.(*arrow.DictionaryType)
+		types[f.Dictionary.ID] = dt
+		typ = dt.ValueType
+	}
+	switch dt := typ.(type) {
+	case *arrow.ListType:
+		dictTypeFromJSON(f.Children[0], dt.Elem(), types)
+	case *arrow.FixedSizeListType:
+		dictTypeFromJSON(f.Children[0], dt.Elem(), types)
+	case *arrow.StructType:
+		dictTypesFromJSON(f.Children, dt.Fields(), types)
+	case *arrow.UnionType:
+		dictTypesFromJSON(f.Children, dt.Fields(), types)
+	case *arrow.RunEndEncodedType:
+		dictTypeFromJSON(f.Children[0], dt.RunEnds(), types)
+		dictTypeFromJSON(f.Children[1], dt.Encoded(), types)
+	}
+}
+
+// Dictionary is the JSON form of the values of a dictionary-encoded field.
+type Dictionary struct {
+	ID   int64  `json:"id"`
+	Data Record `json:"data"`
+}
+
+// dictionaries holds the values of the dictionaries read, by type.
+type dictionaries map[*arrow.DictionaryType]array.Interface
+
+func (d dictionaries) release() {
+	for _, dict := range d {
+		dict.Release()
+	}
+}
+
+// dictionariesFromJSON reads the dictionaries dicts of the fields of schema.
+func dictionariesFromJSON(mem memory.Allocator, schema *arrow.Schema, fields []Field, dicts []Dictionary) dictionaries {
+	types := make(map[int64]*arrow.DictionaryType)
+	dictTypesFromJSON(fields, schema.Fields(), types)
+
+	// the values of a dictionary come before the dictionaries they use,
+	// which have greater IDs.
+	dicts = append([]Dictionary(nil), dicts...)
+	sort.Slice(dicts, func(i, j int) bool { return dicts[i].ID > dicts[j].ID })
+
+	o := make(dictionaries, len(dicts))
+	for _, dict := range dicts {
+		dt, ok := types[dict.ID]
+		if !ok {
+			o.release()
+			panic(xerrors.Errorf("arrjson: no field with dictionary ID=%d", dict.ID))
+		}
+		if len(dict.Data.Columns) != 1 {
+			o.release()
+			panic(xerrors.Errorf("arrjson: invalid number of columns for dictionary ID=%d (got=%d, want=1)", dict.ID, len(dict.Data.Columns)))
+		}
+		o[dt] = arrayFromJSON(mem, dt.ValueType, dict.Data.Columns[0], o)
+	}
+	return o
+}
+
+// dictionariesOf returns the dictionaries of the dictionary-encoded columns
+// of rec, in the depth-first order of their IDs, see fieldsToJSON.
+func dictionariesOf(rec array.Record) []array.Interface {
+	var o []array.Interface
+	for _, col := range rec.Columns() {
+		o = appendDictionaries(o, col)
+	}
+	return o
+}
+
+func appendDictionaries(o []array.Interface, arr array.Interface) []array.Interface {
+	switch arr := arr.(type) {
+	case *array.Dictionary:
+		o = append(o, arr.Dictionary())
+		o = appendDictionaries(o, arr.Dictionary())
+	case *array.List:
+		o = appendDictionaries(o, arr.ListValues())
+	case *array.FixedSizeList:
+		o = appendDictionaries(o, arr.ListValues())
+	case *array.Struct:
+		for i := 0; i < arr.NumField(); i++ {
+			o = appendDictionaries(o, arr.Field(i))
+		}
+	case *array.RunEndEncoded:
+		o = appendDictionaries(o, arr.RunEndsArr())
+		o = appendDictionaries(o, arr.Values())
+	case *array.SparseUnion:
+		for i := 0; i < arr.NumFields(); i++ {
+			o = appendDictionaries(o, arr.Field(i))
+		}
+	case *array.DenseUnion:
+		for i := 0; i < arr.NumFields(); i++ {
+			o = appendDictionaries(o, arr.Field(i))
+		}
+	}
+	return o
+}
+
+func dictionariesToJSON(dicts []array.Interface) []Dictionary {
+	o := make([]Dictionary, len(dicts))
+	for i, dict := range dicts {
+		o[i] = Dictionary{
+			ID: int64(i),
+			Data: Record{
+				Count: int64(dict.Len()),
+				Columns: []Array{
+					arrayToJSON(arrow.Field{Name: "DICT" + strconv.Itoa(i), Type: dict.DataType(), Nullable: true}, dict),
+				},
+			},
+		}
+	}
+	return o
+}
+
 type Record struct {
 	Count   int64   `json:"count"`
 	Columns []Array `json:"columns"`
 }
 
-func recordsFromJSON(mem memory.Allocator, schema *arrow.Schema, recs []Record) []array.Record {
+func recordsFromJSON(mem memory.Allocator, schema *arrow.Schema, recs []Record, dicts dictionaries) []array.Record {
 	vs := make([]array.Record, len(recs))
 	for i, rec := range recs {
-		vs[i] = recordFromJSON(mem, schema, rec)
+		vs[i] = recordFromJSON(mem, schema, rec, dicts)
 	}
 	return vs
 }
 
-func recordFromJSON(mem memory.Allocator, schema *arrow.Schema, rec Record) array.Record {
-	arrs := arraysFromJSON(mem, schema, rec.Columns)
+func recordFromJSON(mem memory.Allocator, schema *arrow.Schema, rec Record, dicts dictionaries) array.Record {
+	arrs := arraysFromJSON(mem, schema, rec.Columns, dicts)
 	defer func() {
 		for _, arr := range arrs {
 			arr.Release()
@@ -347,13 +552,27 @@ type Array struct {
 	Valids   []int         `json:"VALIDITY,omitempty"`
 	Data     []interface{} `json:"DATA,omitempty"`
 	Offset   []int32       `json:"OFFSET,omitempty"`
+	TypeID   []int         `json:"TYPE_ID,omitempty"`
+	Views    []View        `json:"VIEWS,omitempty"`
+	Variadic []string      `json:"VARIADIC_DATA_BUFFERS,omitempty"`
 	Children []Array       `json:"children,omitempty"`
 }
 
-func arraysFromJSON(mem memory.Allocator, schema *arrow.Schema, arrs []Array) []array.Interface {
+// View is the JSON form of an arrow.ViewHeader: its value when it is
+// inline, or else the prefix and location of its value in the variadic
+// data buffers.
+type View struct {
+	Size    int32   `json:"SIZE"`
+	Inlined *string `json:"INLINED,omitempty"`
+	Prefix  string  `json:"PREFIX_HEX,omitempty"`
+	Index   *int32  `json:"BUFFER_INDEX,omitempty"`
+	Offset  *int32  `json:"OFFSET,omitempty"`
+}
+
+func arraysFromJSON(mem memory.Allocator, schema *arrow.Schema, arrs []Array, dicts dictionaries) []array.Interface {
 	o := make([]array.Interface, len(arrs))
 	for i, v := range arrs {
-		o[i] = arrayFromJSON(mem, schema.Field(i).Type, v)
+		o[i] = arrayFromJSON(mem, schema.Field(i).Type, v, dicts)
 	}
 	return o
 }
@@ -366,7 +585,7 @@ func arraysToJSON(schema *arrow.Schema, arrs []array.Interface) []Array {
 	return o
 }
 
-func arrayFromJSON(mem memory.Allocator, dt arrow.DataType, arr Array) array.Interface {
+func arrayFromJSON(mem memory.Allocator, dt arrow.DataType, arr Array, dicts dictionaries) array.Interface {
 	switch dt := dt.(type) {
 	case *arrow.NullType:
 		return array.NewNull(arr.Count)
@@ -483,56 +702,89 @@ func arrayFromJSON(mem memory.Allocator, dt arrow.DataType, arr Array) array.Int
 		bldr.AppendValues(data, valids)
 		return bldr.NewArray()
 
-	case *arrow.ListType:
-		bldr := array.NewListBuilder(mem, dt.Elem())
+	case *arrow.BinaryViewType:
+		bldr := array.NewBinaryViewBuilder(mem, dt)
 		defer bldr.Release()
+		data := viewsFromJSON(arr, false)
 		valids := validsFromJSON(arr.Valids)
-		elems := arrayFromJSON(mem, dt.Elem(), arr.Children[0])
-		defer elems.Release()
-		for i, v := range valids {
-			bldr.Append(v)
-			beg := int64(arr.Offset[i])
-			end := int64(arr.Offset[i+1])
-			slice := array.NewSlice(elems, beg, end)
-			buildArray(bldr.ValueBuilder(), slice)
-			slice.Release()
-		}
+		bldr.AppendValues(data, valids)
 		return bldr.NewArray()
 
-	case *arrow.FixedSizeListType:
-		bldr := array.NewFixedSizeListBuilder(mem, dt.Len(), dt.Elem())
+	case *arrow.StringViewType:
+		bldr := array.NewStringViewBuilder(mem)
 		defer bldr.Release()
-		valids := validsFromJSON(arr.Valids)
-		elems := arrayFromJSON(mem, dt.Elem(), arr.Children[0])
-		defer elems.Release()
-		size := int64(dt.Len())
-		for i, v := range valids {
-			bldr.Append(v)
-			beg := int64(i) * size
-			end := int64(i+1) * size
-			slice := array.NewSlice(elems, beg, end)
-			buildArray(bldr.ValueBuilder(), slice)
-			slice.Release()
+		views := viewsFromJSON(arr, true)
+		data := make([]string, len(views))
+		for i, v := range views {
+			data[i] = string(v)
 		}
+		valids := validsFromJSON(arr.Valids)
+		bldr.AppendValues(data, valids)
 		return bldr.NewArray()
 
-	case *arrow.StructType:
-		bldr := array.NewStructBuilder(mem, dt)
-		defer bldr.Release()
-		valids := validsFromJSON(arr.Valids)
-		fields := make([]array.Interface, len(dt.Fields()))
-		for i := range fields {
-			fields[i] = arrayFromJSON(mem, dt.Field(i).Type, arr.Children[i])
-		}
+	case *arrow.ListType:
+		elems := arrayFromJSON(mem, dt.Elem(), arr.Children[0], dicts)
+		defer elems.Release()
+		bitmap, nulls := validityFromJSON(arr.Valids)
+		offsets := memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(arr.Offset))
+		data := array.NewData(dt, arr.Count, []*memory.Buffer{bitmap, offsets}, []*array.Data{elems.Data()}, nulls, 0)
+		defer data.Release()
+		return array.NewListData(data)
 
-		bldr.AppendValues(valids)
-		for i := range dt.Fields() {
-			fbldr := bldr.FieldBuilder(i)
-			buildArray(fbldr, fields[i])
-			fields[i].Release()
-		}
+	case *arrow.FixedSizeListType:
+		elems := arrayFromJSON(mem, dt.Elem(), arr.Children[0], dicts)
+		defer elems.Release()
+		bitmap, nulls := validityFromJSON(arr.Valids)
+		data := array.NewData(dt, arr.Count, []*memory.Buffer{bitmap}, []*array.Data{elems.Data()}, nulls, 0)
+		defer data.Release()
+		return array.NewFixedSizeListData(data)
+
+	case *arrow.RunEndEncodedType:
+		ends := arrayFromJSON(mem, dt.RunEnds(), arr.Children[0], dicts)
+		defer ends.Release()
+		values := arrayFromJSON(mem, dt.Encoded(), arr.Children[1], dicts)
+		defer values.Release()
+		return array.NewRunEndEncoded(ends, values, arr.Count, 0)
+
+	case *arrow.DictionaryType:
+		dict, ok := dicts[dt]
+		if !ok {
+			panic(xerrors.Errorf("arrjson: missing dictionary for type %v", dt))
+		}
+		indices := arrayFromJSON(mem, dt.IndexType, arr, dicts)
+		defer indices.Release()
+		return array.NewDictionaryArray(dt, indices, dict)
 
-		return bldr.NewArray()
+	case *arrow.StructType:
+		fields := make([]*array.Data, len(dt.Fields()))
+		for i := range fields {
+			field := arrayFromJSON(mem, dt.Field(i).Type, arr.Children[i], dicts)
+			defer field.Release()
+			fields[i] = field.Data()
+		}
+		bitmap, nulls := validityFromJSON(arr.Valids)
+		data := array.NewData(dt, arr.Count, []*memory.Buffer{bitmap}, fields, nulls, 0)
+		defer data.Release()
+		return array.NewStructData(data)
+
+	case *arrow.UnionType:
+		children := make([]*array.Data, len(dt.Fields()))
+		for i := range children {
+			child := arrayFromJSON(mem, dt.Field(i).Type, arr.Children[i], dicts)
+			defer child.Release()
+			children[i] = child.Data()
+		}
+		codes := make([]arrow.UnionTypeCode, len(arr.TypeID))
+		for i, id := range arr.TypeID {
+			codes[i] = arrow.UnionTypeCode(id)
+		}
+		buffers := []*memory.Buffer{nil, memory.NewBufferBytes(arrow.Int8Traits.CastToBytes(codes))}
+		if dt.Mode() == arrow.DenseMode {
+			buffers = append(buffers, memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(arr.Offset)))
+		}
+		data := array.NewData(dt, arr.Count, buffers, children, 0, 0)
+		defer data.Release()
+		return array.MakeFromData(data)
 
 	case *arrow.FixedSizeBinaryType:
 		bldr := array.NewFixedSizeBinaryBuilder(mem, dt)
@@ -744,6 +996,24 @@ func arrayToJSON(field arrow.Field, arr array.Interface) Array {
 			Offset: arr.ValueOffsets(),
 		}
 
+	case *array.BinaryView:
+		return Array{
+			Name:     field.Name,
+			Count:    arr.Len(),
+			Valids:   validsToJSON(arr),
+			Views:    viewsToJSON(arr.Len(), arr.ValueHeader, false),
+			Variadic: variadicToJSON(arr.DataBuffers()),
+		}
+
+	case *array.StringView:
+		return Array{
+			Name:     field.Name,
+			Count:    arr.Len(),
+			Valids:   validsToJSON(arr),
+			Views:    viewsToJSON(arr.Len(), arr.ValueHeader, true),
+			Variadic: variadicToJSON(arr.DataBuffers()),
+		}
+
 	case *array.List:
 		o := Array{
 			Name:   field.Name,
@@ -767,6 +1037,25 @@ func arrayToJSON(field arrow.Field, arr array.Interface) Array {
 		}
 		return o
 
+	case *array.RunEndEncoded:
+		dt := arr.DataType().(*arrow.RunEndEncodedType)
+		ends := arr.LogicalRunEndsArr(memory.DefaultAllocator)
+		defer ends.Release()
+		values := arr.LogicalValuesArr()
+		defer values.Release()
+		return Array{
+			Name:  field.Name,
+			Count: arr.Len(),
+			Children: []Array{
+				arrayToJSON(arrow.Field{Name: "run_ends", Type: dt.RunEnds()}, ends),
+				arrayToJSON(arrow.Field{Name: "values", Type: dt.Encoded(), Nullable: true}, values),
+			},
+		}
+
+	case *array.Dictionary:
+		dt := arr.DataType().(*arrow.DictionaryType)
+		return arrayToJSON(arrow.Field{Name: field.Name, Type: dt.IndexType}, arr.Indices())
+
 	case *array.Struct:
 		dt := arr.DataType().(*arrow.StructType)
 		o := Array{
@@ -780,6 +1069,33 @@ func arrayToJSON(field arrow.Field, arr array.Interface) Array {
 		}
 		return o
 
+	case *array.SparseUnion:
+		dt := arr.DataType().(*arrow.UnionType)
+		o := Array{
+			Name:     field.Name,
+			Count:    arr.Len(),
+			TypeID:   typeCodesToJSON(arr.TypeCodes()),
+			Children: make([]Array, len(dt.Fields())),
+		}
+		for i := range o.Children {
+			o.Children[i] = arrayToJSON(dt.Field(i), arr.Field(i))
+		}
+		return o
+
+	case *array.DenseUnion:
+		dt := arr.DataType().(*arrow.UnionType)
+		o := Array{
+			Name:     field.Name,
+			Count:    arr.Len(),
+			TypeID:   typeCodesToJSON(arr.TypeCodes()),
+			Offset:   arr.ValueOffsets(),
+			Children: make([]Array, len(dt.Fields())),
+		}
+		for i := range o.Children {
+			o.Children[i] = arrayToJSON(dt.Field(i), arr.Field(i))
+		}
+		return o
+
 	case *array.FixedSizeBinary:
 		dt := arr.DataType().(*arrow.FixedSizeBinaryType)
 		o := Array{
@@ -864,6 +1180,24 @@ func arrayToJSON(field arrow.Field, arr array.Interface) Array {
 	panic("impossible")
 }
 
+// validityFromJSON returns the validity bitmap of the JSON validity vs, or
+// nil if all the values are valid, and the number of null values.
+func validityFromJSON(vs []int) (*memory.Buffer, int) {
+	nulls := 0
+	bitmap := make([]byte, bitutil.CeilByte(len(vs))/8)
+	for i, v := range vs {
+		if v != 0 {
+			bitutil.SetBit(bitmap, i)
+		} else {
+			nulls++
+		}
+	}
+	if nulls == 0 {
+		return nil, 0
+	}
+	return memory.NewBufferBytes(bitmap), nulls
+}
+
 func validsFromJSON(vs []int) []bool {
 	o := make([]bool, len(vs))
 	for i, v := range vs {
@@ -874,6 +1208,14 @@ func validsFromJSON(vs []int) []bool {
 	return o
 }
 
+func typeCodesToJSON(codes []arrow.UnionTypeCode) []int {
+	o := make([]int, len(codes))
+	for i, v := range codes {
+		o[i] = int(v)
+	}
+	return o
+}
+
 func validsToJSON(arr array.Interface) []int {
 	o := make([]int, arr.Len())
 	for i := range o {
@@ -1075,7 +1417,7 @@ func f16FromJSON(vs []interface{}) []float16.Num {
 		if err != nil {
 			panic(err)
 		}
-		o[i] = float16.New(float32(vv))
+		o[i] = float16.FromFloat64(vv)
 	}
 	return o
 }
@@ -1178,6 +1520,69 @@ func bytesToJSON(arr *array.Binary) []interface{} {
 	return o
 }
 
+// viewsFromJSON returns the values of the views of arr, whose inlined
+// values are hex encoded unless utf8 is set.
+func viewsFromJSON(arr Array, utf8 bool) [][]byte {
+	buffers := make([][]byte, len(arr.Variadic))
+	for i, v := range arr.Variadic {
+		b, err := hex.DecodeString(v)
+		if err != nil {
+			panic(xerrors.Errorf("could not decode %v: %v", v, err))
+		}
+		buffers[i] = b
+	}
+
+	o := make([][]byte, len(arr.Views))
+	for i, v := range arr.Views {
+		switch {
+		case v.Inlined != nil && utf8:
+			o[i] = []byte(*v.Inlined)
+		case v.Inlined != nil:
+			b, err := hex.DecodeString(*v.Inlined)
+			if err != nil {
+				panic(xerrors.Errorf("could not decode %v: %v", *v.Inlined, err))
+			}
+			o[i] = b
+		case v.Index != nil && v.Offset != nil:
+			o[i] = buffers[*v.Index][*v.Offset : *v.Offset+v.Size]
+		default:
+			panic(xerrors.Errorf("invalid view %d: neither inlined nor in a buffer", i))
+		}
+	}
+	return o
+}
+
+func viewsToJSON(n int, header func(int) *arrow.ViewHeader, utf8 bool) []View {
+	o := make([]View, n)
+	for i := range o {
+		h := header(i)
+		o[i].Size = int32(h.Len())
+		if h.IsInline() {
+			v := string(h.InlineBytes())
+			if !utf8 {
+				v = strings.ToUpper(hex.EncodeToString(h.InlineBytes()))
+			}
+			o[i].Inlined = &v
+			continue
+		}
+		prefix := h.Prefix()
+		index, offset := h.BufferIndex(), h.BufferOffset()
+		o[i].Prefix = strings.ToUpper(hex.EncodeToString(prefix[:]))
+		o[i].Index, o[i].Offset = &index, &offset
+	}
+	return o
+}
+
+func variadicToJSON(buffers []*memory.Buffer) []string {
+	o := make([]string, len(buffers))
+	for i, buf := range buffers {
+		if buf != nil {
+			o[i] = strings.ToUpper(hex.EncodeToString(buf.Bytes()))
+		}
+	}
+	return o
+}
+
 func date32FromJSON(vs []interface{}) []arrow.Date32 {
 	o := make([]arrow.Date32, len(vs))
 	for i, v := range vs {
@@ -1358,144 +1763,3 @@ func durationToJSON(arr *array.Duration) []interface{} {
 	}
 	return o
 }
-
-func buildArray(bldr array.Builder, data array.Interface) {
-	defer data.Release()
-
-	switch bldr := bldr.(type) {
-	default:
-		panic(xerrors.Errorf("unknown builder %T", bldr))
-
-	case *array.BooleanBuilder:
-		data := data.(*array.Boolean)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Int8Builder:
-		data := data.(*array.Int8)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Int16Builder:
-		data := data.(*array.Int16)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Int32Builder:
-		data := data.(*array.Int32)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Int64Builder:
-		data := data.(*array.Int64)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Uint8Builder:
-		data := data.(*array.Uint8)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Uint16Builder:
-		data := data.(*array.Uint16)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Uint32Builder:
-		data := data.(*array.Uint32)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Uint64Builder:
-		data := data.(*array.Uint64)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Float32Builder:
-		data := data.(*array.Float32)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.Float64Builder:
-		data := data.(*array.Float64)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-
-	case *array.StringBuilder:
-		data := data.(*array.String)
-		for i := 0; i < data.Len(); i++ {
-			switch {
-			case data.IsValid(i):
-				bldr.Append(data.Value(i))
-			default:
-				bldr.AppendNull()
-			}
-		}
-	}
-}
@@ -14,14 +14,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package arrjson // import "github.com/apache/arrow/go/arrow/internal/arrjson"
+package arrjson // import "github.com/apache/arrow/go/arrow/arrjson"
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/internal/arrdata"
 	"github.com/apache/arrow/go/arrow/memory"
@@ -49,7 +53,8 @@ func TestReadWrite(t *testing.T) {
 
 	for name, recs := range arrdata.Records {
 		t.Run(name, func(t *testing.T) {
-			if name == "decimal128" {
+			switch name {
+			case "decimal128", "decimal256":
 				t.Skip() // FIXME(sbinet): implement full decimal128 support
 			}
 			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
@@ -135,6 +140,338 @@ func TestReadWrite(t *testing.T) {
 	}
 }
 
+func TestReadWriteViews(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// views in the layout of the integration files.
+	const input = `{
+  "schema": {
+    "fields": [
+      {"name": "s", "type": {"name": "utf8view"}, "nullable": true, "children": []},
+      {"name": "b", "type": {"name": "binaryview"}, "nullable": true, "children": []}
+    ]
+  },
+  "batches": [
+    {
+      "count": 3,
+      "columns": [
+        {
+          "name": "s",
+          "count": 3,
+          "VALIDITY": [1, 0, 1],
+          "VIEWS": [
+            {"SIZE": 5, "INLINED": "hello"},
+            {"SIZE": 0, "INLINED": ""},
+            {"SIZE": 14, "PREFIX_HEX": "61206C6F", "BUFFER_INDEX": 1, "OFFSET": 2}
+          ],
+          "VARIADIC_DATA_BUFFERS": ["", "787861206C6F6E6765722076616C7565"]
+        },
+        {
+          "name": "b",
+          "count": 3,
+          "VALIDITY": [1, 1, 1],
+          "VIEWS": [
+            {"SIZE": 2, "INLINED": "BEEF"},
+            {"SIZE": 13, "PREFIX_HEX": "30313233", "BUFFER_INDEX": 0, "OFFSET": 0},
+            {"SIZE": 0, "INLINED": ""}
+          ],
+          "VARIADIC_DATA_BUFFERS": ["30313233343536373839616263"]
+        }
+      ]
+    }
+  ]
+}`
+
+	r, err := NewReader(strings.NewReader(input), WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	rec, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := rec.Column(0).(*array.StringView)
+	if got, want := s.String(), `["hello" (null) "a longer value"]`; got != want {
+		t.Fatalf("invalid strings: got=%s, want=%s", got, want)
+	}
+	b := rec.Column(1).(*array.BinaryView)
+	for i, want := range [][]byte{{0xbe, 0xef}, []byte("0123456789abc"), {}} {
+		if got := b.Value(i); !bytes.Equal(got, want) {
+			t.Fatalf("invalid binary %d: got=%x, want=%x", i, got, want)
+		}
+	}
+
+	if got, want := rec.Schema().Field(0).Type, arrow.BinaryTypes.StringView; !arrow.TypeEqual(got, want) {
+		t.Fatalf("invalid type: got=%v, want=%v", got, want)
+	}
+
+	o := new(bytes.Buffer)
+	w, err := NewWriter(o, rec.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rr, err := NewReader(o, WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	got, err := rr.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !array.RecordEqual(got, rec) {
+		t.Fatalf("records differ:\ngot= %v\nwant=%v", got, rec)
+	}
+}
+
+func TestReadWriteUnions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// unions in the layout of the integration files, with non-contiguous
+	// type codes and an empty child.
+	const input = `{
+  "schema": {
+    "fields": [
+      {"name": "sparse", "type": {"name": "union", "mode": "SPARSE", "typeIds": [2, 7]}, "nullable": true, "children": [
+        {"name": "i", "type": {"name": "int", "isSigned": true, "bitWidth": 32}, "nullable": true, "children": []},
+        {"name": "s", "type": {"name": "utf8"}, "nullable": true, "children": []}
+      ]},
+      {"name": "dense", "type": {"name": "union", "mode": "DENSE", "typeIds": [2, 7]}, "nullable": true, "children": [
+        {"name": "i", "type": {"name": "int", "isSigned": true, "bitWidth": 32}, "nullable": true, "children": []},
+        {"name": "s", "type": {"name": "utf8"}, "nullable": true, "children": []}
+      ]}
+    ]
+  },
+  "batches": [
+    {
+      "count": 3,
+      "columns": [
+        {
+          "name": "sparse",
+          "count": 3,
+          "TYPE_ID": [2, 7, 2],
+          "children": [
+            {"name": "i", "count": 3, "VALIDITY": [1, 0, 0], "DATA": [1, 0, 0]},
+            {"name": "s", "count": 3, "VALIDITY": [0, 1, 0], "OFFSET": [0, 0, 1, 1], "DATA": ["", "a", ""]}
+          ]
+        },
+        {
+          "name": "dense",
+          "count": 3,
+          "TYPE_ID": [2, 2, 2],
+          "OFFSET": [0, 1, 2],
+          "children": [
+            {"name": "i", "count": 3, "VALIDITY": [1, 0, 1], "DATA": [1, 0, 3]},
+            {"name": "s", "count": 0, "OFFSET": [0], "DATA": []}
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+	r, err := NewReader(strings.NewReader(input), WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	rec, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := []arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}
+	codes := []arrow.UnionTypeCode{2, 7}
+	for i, want := range []struct {
+		dt  arrow.DataType
+		str string
+	}{
+		{arrow.SparseUnionOf(fields, codes), `[1 "a" (null)]`},
+		{arrow.DenseUnionOf(fields, codes), `[1 (null) 3]`},
+	} {
+		if got := rec.Schema().Field(i).Type; !arrow.TypeEqual(got, want.dt) {
+			t.Fatalf("invalid type %d: got=%v, want=%v", i, got, want.dt)
+		}
+		if got := fmt.Sprint(rec.Column(i)); got != want.str {
+			t.Fatalf("invalid union %d: got=%s, want=%s", i, got, want.str)
+		}
+	}
+
+	for _, slice := range []array.Record{rec, rec.NewSlice(1, 3)} {
+		o := new(bytes.Buffer)
+		w, err := NewWriter(o, slice.Schema())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write(slice); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		rr, err := NewReader(o, WithAllocator(mem))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := rr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !array.RecordEqual(got, slice) {
+			t.Fatalf("records differ:\ngot= %v\nwant=%v", got, slice)
+		}
+		rr.Release()
+		if slice != rec {
+			slice.Release()
+		}
+	}
+}
+
+func TestReadWriteJSONFiles(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want []string // string form of the columns of the first record
+	}{
+		{
+			name: "simple",
+			want: []string{
+				"[1 (null) 3 4 5]",
+				"[1 (null) (null) 4 5]",
+				`["aa" (null) (null) "bbb" "cccc"]`,
+			},
+		},
+		{
+			name: "struct",
+			want: []string{
+				`{[(null) (null) 137773603 410361374 (null) (null) (null)] [(null) "MhRNxD4" "3F9HBxK" "aVd88fp" (null) "3loZrRf" (null)]}`,
+			},
+		},
+		{
+			name: "dictionary",
+			want: []string{
+				`{ dictionary: ["foo" "bar" "baz"]
+  indices: [2 (null) 0 1] }`,
+				`[{ dictionary: [-10 20]
+  indices: [1 (null)] } { dictionary: [-10 20]
+  indices: [] } (null) { dictionary: [-10 20]
+  indices: [0] }]`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			f, err := os.Open("testdata/" + tc.name + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			recs, err := ReadJSON(f, mem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				for _, rec := range recs {
+					rec.Release()
+				}
+			}()
+
+			for i, col := range recs[0].Columns() {
+				if got, want := fmt.Sprint(col), tc.want[i]; got != want {
+					t.Fatalf("invalid column %d:\ngot= %s\nwant=%s", i, got, want)
+				}
+			}
+
+			buf := new(bytes.Buffer)
+			if err := WriteJSON(buf, recs); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := ReadJSON(buf, mem)
+			if err != nil {
+				t.Fatalf("could not read written JSON: %v\n%s", err, buf)
+			}
+			defer func() {
+				for _, rec := range out {
+					rec.Release()
+				}
+			}()
+
+			if got, want := len(out), len(recs); got != want {
+				t.Fatalf("invalid number of records: got=%d, want=%d", got, want)
+			}
+			for i := range out {
+				if !out[i].Schema().Equal(recs[i].Schema()) {
+					t.Fatalf("invalid schema:\ngot= %v\nwant=%v", out[i].Schema(), recs[i].Schema())
+				}
+				if !array.RecordEqual(out[i], recs[i]) {
+					t.Fatalf("records[%d] differ", i)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteJSONDictionaryReplacement(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	schema := arrow.NewSchema([]arrow.Field{{Name: "dict", Type: dt}}, nil)
+
+	indices := array.NewInt8Builder(mem)
+	defer indices.Release()
+	values := array.NewStringBuilder(mem)
+	defer values.Release()
+
+	var recs []array.Record
+	for _, v := range []string{"a", "b"} {
+		indices.Append(0)
+		idx := indices.NewArray()
+		defer idx.Release()
+		values.Append(v)
+		dict := values.NewArray()
+		defer dict.Release()
+
+		arr := array.NewDictionaryArray(dt, idx, dict)
+		defer arr.Release()
+		rec := array.NewRecord(schema, []array.Interface{arr}, 1)
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	if err := WriteJSON(ioutil.Discard, recs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJSON(ioutil.Discard, recs); err == nil {
+		t.Fatalf("expected an error writing records with different dictionaries")
+	}
+	if err := WriteJSON(ioutil.Discard, nil); err == nil {
+		t.Fatalf("expected an error writing no record")
+	}
+}
+
 func makeNullWantJSONs() string {
 	return `{
   "schema": {
@@ -3101,4 +3438,4 @@ func makeDurationsWantJSONs() string {
 
 func makeDecimal128sWantJSONs() string {
 	return `` // FIXME(fredgan): implement full decimal128 JSON support
-}
\ No newline at end of file
+}
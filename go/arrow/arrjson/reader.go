@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package arrjson // import "github.com/apache/arrow/go/arrow/internal/arrjson"
+package arrjson // import "github.com/apache/arrow/go/arrow/arrjson"
 
 import (
 	"encoding/json"
@@ -25,6 +25,8 @@ import (
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/arrio"
 	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
 )
 
 type Reader struct {
@@ -36,14 +38,15 @@ type Reader struct {
 	irec int // current record index. used for the arrio.Reader interface.
 }
 
-func NewReader(r io.Reader, opts ...Option) (*Reader, error) {
+func NewReader(r io.Reader, opts ...Option) (rr *Reader, err error) {
 	dec := json.NewDecoder(r)
 	dec.UseNumber()
 	var raw struct {
-		Schema  Schema   `json:"schema"`
-		Records []Record `json:"batches"`
+		Schema       Schema       `json:"schema"`
+		Dictionaries []Dictionary `json:"dictionaries"`
+		Records      []Record     `json:"batches"`
 	}
-	err := dec.Decode(&raw)
+	err = dec.Decode(&raw)
 	if err != nil {
 		return nil, err
 	}
@@ -53,11 +56,26 @@ func NewReader(r io.Reader, opts ...Option) (*Reader, error) {
 		opt(cfg)
 	}
 
+	defer func() {
+		if e := recover(); e != nil {
+			rr = nil
+			switch e := e.(type) {
+			case error:
+				err = xerrors.Errorf("arrjson: could not read JSON data: %w", e)
+			default:
+				err = xerrors.Errorf("arrjson: could not read JSON data: %v", e)
+			}
+		}
+	}()
+
 	schema := schemaFromJSON(raw.Schema)
-	rr := &Reader{
+	dicts := dictionariesFromJSON(cfg.alloc, schema, raw.Schema.Fields, raw.Dictionaries)
+	defer dicts.release()
+
+	rr = &Reader{
 		refs:   1,
 		schema: schema,
-		recs:   recordsFromJSON(cfg.alloc, schema, raw.Records),
+		recs:   recordsFromJSON(cfg.alloc, schema, raw.Records, dicts),
 	}
 	return rr, nil
 }
@@ -95,6 +113,24 @@ func (r *Reader) Read() (array.Record, error) {
 	return rec, nil
 }
 
+// ReadJSON reads the records of the Arrow JSON integration file r, such as
+// the ones of the arrow-testing repository, with the memory allocator mem.
+// The records must be released by the caller.
+func ReadJSON(r io.Reader, mem memory.Allocator) ([]array.Record, error) {
+	rr, err := NewReader(r, WithAllocator(mem))
+	if err != nil {
+		return nil, err
+	}
+	defer rr.Release()
+
+	recs := make([]array.Record, len(rr.recs))
+	for i, rec := range rr.recs {
+		rec.Retain()
+		recs[i] = rec
+	}
+	return recs, nil
+}
+
 var (
 	_ arrio.Reader = (*Reader)(nil)
 )
@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package arrjson // import "github.com/apache/arrow/go/arrow/internal/arrjson"
+package arrjson // import "github.com/apache/arrow/go/arrow/arrjson"
 
 import (
 	"encoding/json"
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/arrio"
+	"golang.org/x/xerrors"
 )
 
 const (
@@ -36,6 +37,7 @@ type Writer struct {
 
 	schema *arrow.Schema
 	nrecs  int64
+	dicts  []array.Interface // dictionaries of the first record
 }
 
 func NewWriter(w io.Writer, schema *arrow.Schema) (*Writer, error) {
@@ -55,14 +57,26 @@ func NewWriter(w io.Writer, schema *arrow.Schema) (*Writer, error) {
 	return ww, nil
 }
 
+// Write writes the record rec. The JSON format has one set of dictionaries
+// for all the records, so the dictionary-encoded columns of the records must
+// have the dictionaries of the first record.
 func (w *Writer) Write(rec array.Record) error {
 	switch {
 	case w.nrecs == 0:
+		if err := w.writeDictionaries(rec); err != nil {
+			return err
+		}
 		_, err := w.w.Write([]byte(",\n" + jsonPrefix + `"batches": [` + "\n" + jsonRecPrefix))
 		if err != nil {
 			return err
 		}
 	case w.nrecs > 0:
+		dicts := dictionariesOf(rec)
+		for i, dict := range dicts {
+			if !array.ArrayEqual(dict, w.dicts[i]) {
+				return xerrors.Errorf("arrjson: dictionary ID=%d of record %d differs from the one of the first record", i, w.nrecs)
+			}
+		}
 		_, err := w.w.Write([]byte(",\n" + jsonRecPrefix))
 		if err != nil {
 			return err
@@ -83,6 +97,27 @@ func (w *Writer) Write(rec array.Record) error {
 	return nil
 }
 
+func (w *Writer) writeDictionaries(rec array.Record) error {
+	w.dicts = dictionariesOf(rec)
+	if len(w.dicts) == 0 {
+		return nil
+	}
+	for _, dict := range w.dicts {
+		dict.Retain()
+	}
+
+	_, err := w.w.Write([]byte(",\n" + jsonPrefix + `"dictionaries": `))
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(dictionariesToJSON(w.dicts), jsonPrefix, jsonIndent)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(raw)
+	return err
+}
+
 func (w *Writer) writeSchema() error {
 	_, err := w.w.Write([]byte(`  "schema": `))
 	if err != nil {
@@ -100,17 +135,54 @@ func (w *Writer) writeSchema() error {
 	return nil
 }
 
+func (w *Writer) release() {
+	for _, dict := range w.dicts {
+		dict.Release()
+	}
+	w.dicts = nil
+}
+
 func (w *Writer) Close() error {
 	if w.w == nil {
 		return nil
 	}
-	_, err := w.w.Write([]byte("\n  ]\n}"))
+	w.release()
+
+	end := "\n  ]\n}"
+	if w.nrecs == 0 {
+		end = ",\n" + jsonPrefix + `"batches": []` + "\n}"
+	}
+	_, err := w.w.Write([]byte(end))
 	if err == nil {
 		w.w = nil
 	}
 	return err
 }
 
+// WriteJSON writes records to w in the Arrow JSON integration format.
+// The records must all have the schema of the first one.
+func WriteJSON(w io.Writer, records []array.Record) error {
+	if len(records) == 0 {
+		return xerrors.Errorf("arrjson: no record to write")
+	}
+
+	ww, err := NewWriter(w, records[0].Schema())
+	if err != nil {
+		return err
+	}
+	for i, rec := range records {
+		if !rec.Schema().Equal(ww.schema) {
+			ww.release()
+			return xerrors.Errorf("arrjson: invalid schema for record %d (got=%v, want=%v)", i, rec.Schema(), ww.schema)
+		}
+		if err := ww.Write(rec); err != nil {
+			ww.release()
+			return err
+		}
+	}
+	return ww.Close()
+}
+
 var (
 	_ arrio.Writer = (*Writer)(nil)
 )
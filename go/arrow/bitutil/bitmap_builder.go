@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitutil
+
+import (
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// BitmapBuilder builds a bitmap by appending bits, single ones or whole
+// ranges of other bitmaps.
+type BitmapBuilder struct {
+	mem    memory.Allocator
+	buf    *memory.Buffer
+	length int
+}
+
+// NewBitmapBuilder returns a builder of bitmaps allocated from mem.
+func NewBitmapBuilder(mem memory.Allocator) *BitmapBuilder {
+	return &BitmapBuilder{mem: mem}
+}
+
+// Len returns the number of bits appended to the builder.
+func (b *BitmapBuilder) Len() int { return b.length }
+
+// Bytes returns the bytes of the bitmap appended to the builder. They are
+// only valid until the next append.
+func (b *BitmapBuilder) Bytes() []byte {
+	if b.buf == nil {
+		return nil
+	}
+	return b.buf.Bytes()[:BytesForBits(int64(b.length))]
+}
+
+// Reserve ensures there is enough space for appending n bits.
+func (b *BitmapBuilder) Reserve(n int) {
+	size := int(BytesForBits(int64(b.length + n)))
+	if b.buf == nil {
+		b.buf = memory.NewResizableBuffer(b.mem)
+	}
+	if size <= b.buf.Len() {
+		return
+	}
+	old := b.buf.Len()
+	if grown := 2 * old; size < grown {
+		size = grown
+	}
+	b.buf.Resize(size)
+	memory.Set(b.buf.Bytes()[old:], 0)
+}
+
+// Append appends the bit v.
+func (b *BitmapBuilder) Append(v bool) {
+	b.Reserve(1)
+	SetBitTo(b.buf.Bytes(), b.length, v)
+	b.length++
+}
+
+// AppendN appends n bits equal to v.
+func (b *BitmapBuilder) AppendN(v bool, n int) {
+	b.Reserve(n)
+	var word uint64
+	if v {
+		word = ^uint64(0)
+	}
+	out := b.buf.Bytes()
+	if head := (8 - b.length%8) % 8; head > 0 {
+		k := min(head, n)
+		setBits(out, b.length, k, word)
+		b.length += k
+		n -= k
+	}
+	if nbytes := n / 8; nbytes > 0 {
+		beg := b.length / 8
+		memory.Set(out[beg:beg+nbytes], byte(word))
+		b.length += 8 * nbytes
+		n -= 8 * nbytes
+	}
+	if n > 0 {
+		setBits(out, b.length, n, word)
+		b.length += n
+	}
+}
+
+// AppendBitmap appends the length bits of bitmap starting at bit offset.
+func (b *BitmapBuilder) AppendBitmap(bitmap []byte, offset, length int) {
+	b.Reserve(length)
+	CopyBitmap(bitmap, offset, b.buf.Bytes(), b.length, length)
+	b.length += length
+}
+
+// Finish returns the bitmap appended to the builder and resets it. The
+// bits past its length in the last byte are zero.
+// The returned buffer must be Release()'d after use.
+func (b *BitmapBuilder) Finish() *memory.Buffer {
+	if b.buf == nil {
+		b.buf = memory.NewResizableBuffer(b.mem)
+	}
+	buf := b.buf
+	buf.Resize(int(BytesForBits(int64(b.length))))
+	b.buf, b.length = nil, 0
+	return buf
+}
+
+// Release releases the memory of the bitmap being built.
+func (b *BitmapBuilder) Release() {
+	if b.buf != nil {
+		b.buf.Release()
+		b.buf, b.length = nil, 0
+	}
+}
@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitutil_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestBitmapBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	r := rand.New(rand.NewSource(0))
+	src := randomBitmap(r, 300)
+
+	for start := 0; start < 16; start++ {
+		var (
+			b    = bitutil.NewBitmapBuilder(mem)
+			want []bool
+		)
+		for i := 0; i < start; i++ {
+			b.Append(i%3 == 0)
+			want = append(want, i%3 == 0)
+		}
+		for _, n := range []int{0, 1, 7, 9, 70} {
+			b.AppendN(true, n)
+			for i := 0; i < n; i++ {
+				want = append(want, true)
+			}
+			b.AppendN(false, n)
+			for i := 0; i < n; i++ {
+				want = append(want, false)
+			}
+		}
+		for _, rng := range [][2]int{{0, 0}, {3, 5}, {8, 64}, {13, 130}} {
+			b.AppendBitmap(src, rng[0], rng[1])
+			for i := 0; i < rng[1]; i++ {
+				want = append(want, bitutil.BitIsSet(src, rng[0]+i))
+			}
+		}
+
+		if got := b.Len(); got != len(want) {
+			t.Fatalf("start=%d: invalid length: got=%d, want=%d", start, got, len(want))
+		}
+		expected := make([]byte, bitutil.BytesForBits(int64(len(want))))
+		for i, v := range want {
+			bitutil.SetBitTo(expected, i, v)
+		}
+		if got := b.Bytes(); !bytes.Equal(got, expected) {
+			t.Fatalf("start=%d: invalid bytes:\ngot= %08b\nwant=%08b", start, got, expected)
+		}
+
+		buf := b.Finish()
+		if got := buf.Bytes(); !bytes.Equal(got, expected) {
+			t.Fatalf("start=%d: invalid bitmap:\ngot= %08b\nwant=%08b", start, got, expected)
+		}
+		buf.Release()
+
+		if got := b.Len(); got != 0 {
+			t.Fatalf("start=%d: builder should be reset, got length=%d", start, got)
+		}
+		b.Append(true)
+		b.Release()
+	}
+}
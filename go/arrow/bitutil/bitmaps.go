@@ -0,0 +1,190 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitutil
+
+import (
+	"encoding/binary"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// BitmapAnd writes the bitwise AND of the length bits of left starting at
+// bit lOffset and of right starting at bit rOffset into out, starting at
+// bit outOffset. The bitmaps may have different offsets; the bits of out
+// outside of the written range are left untouched.
+//
+// out may be one of the inputs, in place, if it has the same offset.
+func BitmapAnd(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, right, lOffset, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a & b })
+}
+
+// BitmapOr writes the bitwise OR of two bitmaps into out, like BitmapAnd.
+func BitmapOr(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, right, lOffset, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a | b })
+}
+
+// BitmapXor writes the bitwise XOR of two bitmaps into out, like BitmapAnd.
+func BitmapXor(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, right, lOffset, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// BitmapAndNot writes the bitwise AND of left and of the negation of right
+// into out, like BitmapAnd.
+func BitmapAndNot(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, right, lOffset, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// BitmapAndAlloc returns a new buffer allocated from mem holding the
+// bitwise AND of two bitmaps, starting at bit outOffset. The bits before
+// outOffset are zero.
+func BitmapAndAlloc(mem memory.Allocator, left, right []byte, lOffset, rOffset, length, outOffset int) *memory.Buffer {
+	return bitmapOpAlloc(mem, left, right, lOffset, rOffset, length, outOffset, BitmapAnd)
+}
+
+// BitmapOrAlloc returns a new buffer holding the bitwise OR of two bitmaps,
+// like BitmapAndAlloc.
+func BitmapOrAlloc(mem memory.Allocator, left, right []byte, lOffset, rOffset, length, outOffset int) *memory.Buffer {
+	return bitmapOpAlloc(mem, left, right, lOffset, rOffset, length, outOffset, BitmapOr)
+}
+
+// BitmapXorAlloc returns a new buffer holding the bitwise XOR of two
+// bitmaps, like BitmapAndAlloc.
+func BitmapXorAlloc(mem memory.Allocator, left, right []byte, lOffset, rOffset, length, outOffset int) *memory.Buffer {
+	return bitmapOpAlloc(mem, left, right, lOffset, rOffset, length, outOffset, BitmapXor)
+}
+
+// BitmapAndNotAlloc returns a new buffer holding the bitwise AND of left
+// and of the negation of right, like BitmapAndAlloc.
+func BitmapAndNotAlloc(mem memory.Allocator, left, right []byte, lOffset, rOffset, length, outOffset int) *memory.Buffer {
+	return bitmapOpAlloc(mem, left, right, lOffset, rOffset, length, outOffset, BitmapAndNot)
+}
+
+// BitmapNot writes the negation of the length bits of in starting at bit
+// inOffset into out, starting at bit outOffset, like BitmapAnd.
+func BitmapNot(in []byte, inOffset int, out []byte, outOffset, length int) {
+	bitmapOp(in, nil, inOffset, 0, out, outOffset, length, func(a, _ uint64) uint64 { return ^a })
+}
+
+// BitmapNotAlloc returns a new buffer holding the negation of a bitmap,
+// like BitmapAndAlloc.
+func BitmapNotAlloc(mem memory.Allocator, in []byte, inOffset, length, outOffset int) *memory.Buffer {
+	return bitmapOpAlloc(mem, in, nil, inOffset, 0, length, outOffset, func(in, _ []byte, inOffset, _ int, out []byte, outOffset, length int) {
+		BitmapNot(in, inOffset, out, outOffset, length)
+	})
+}
+
+// CopyBitmap copies the length bits of src starting at bit srcOffset into
+// dst, starting at bit dstOffset, like BitmapAnd. The offsets need not be
+// aligned on a byte boundary.
+func CopyBitmap(src []byte, srcOffset int, dst []byte, dstOffset, length int) {
+	bitmapOp(src, nil, srcOffset, 0, dst, dstOffset, length, func(a, _ uint64) uint64 { return a })
+}
+
+func bitmapOpAlloc(mem memory.Allocator, left, right []byte, lOffset, rOffset, length, outOffset int, op func(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int)) *memory.Buffer {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(int(BytesForBits(int64(outOffset + length))))
+	memory.Set(buf.Bytes(), 0)
+	op(left, right, lOffset, rOffset, buf.Bytes(), outOffset, length)
+	return buf
+}
+
+// bitmapOp writes op(left, right) into out, 64 bits at a time. The output
+// is first aligned on a byte boundary, then the inputs are read as
+// unaligned words, shifted to the position of the output.
+func bitmapOp(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int, op func(a, b uint64) uint64) {
+	if length == 0 {
+		return
+	}
+
+	i := 0
+	if head := (8 - outOffset%8) % 8; head > 0 {
+		i = min(head, length)
+		setBits(out, outOffset, i, op(loadWord(left, lOffset), loadWord(right, rOffset)))
+	}
+
+	for ; i+64 <= length; i += 64 {
+		v := op(loadWord(left, lOffset+i), loadWord(right, rOffset+i))
+		binary.LittleEndian.PutUint64(out[(outOffset+i)/8:], v)
+	}
+
+	for ; i+8 <= length; i += 8 {
+		out[(outOffset+i)/8] = byte(op(loadWord(left, lOffset+i), loadWord(right, rOffset+i)))
+	}
+
+	if i < length {
+		setBits(out, outOffset+i, length-i, op(loadWord(left, lOffset+i), loadWord(right, rOffset+i)))
+	}
+}
+
+// loadWord returns the 64 bits of buf starting at bit offset. The bits past
+// the end of buf are zero.
+func loadWord(buf []byte, offset int) uint64 {
+	var (
+		i     = offset / 8
+		shift = uint(offset % 8)
+		lo    uint64
+	)
+	if i+8 <= len(buf) {
+		lo = binary.LittleEndian.Uint64(buf[i:])
+	} else {
+		for j := 0; i+j < len(buf); j++ {
+			lo |= uint64(buf[i+j]) << uint(8*j)
+		}
+	}
+
+	v := lo >> shift
+	if shift > 0 && i+8 < len(buf) {
+		v |= uint64(buf[i+8]) << (64 - shift)
+	}
+	return v
+}
+
+// setBits sets the n bits of buf starting at bit offset to the low n bits
+// of v, a byte at a time, leaving the other bits of the bytes untouched.
+func setBits(buf []byte, offset, n int, v uint64) {
+	for n > 0 {
+		var (
+			i     = offset / 8
+			shift = uint(offset % 8)
+			k     = min(8-int(shift), n)
+			mask  = byte((1<<uint(k) - 1) << shift)
+		)
+		buf[i] = buf[i]&^mask | byte(v<<shift)&mask
+		v >>= uint(k)
+		offset += k
+		n -= k
+	}
+}
+
+// OptionalBitIndexer reads the bits of a bitmap that may be missing, as
+// the validity bitmap of an array without nulls. All the bits of a missing
+// bitmap are set.
+type OptionalBitIndexer struct {
+	Bitmap []byte
+	Offset int
+}
+
+// NewOptionalBitIndexer returns an indexer reading the bits of bitmap
+// starting at bit offset. bitmap may be nil.
+func NewOptionalBitIndexer(bitmap []byte, offset int) OptionalBitIndexer {
+	return OptionalBitIndexer{Bitmap: bitmap, Offset: offset}
+}
+
+// GetBit returns whether the i-th bit of the bitmap is set.
+func (b OptionalBitIndexer) GetBit(i int) bool {
+	return len(b.Bitmap) == 0 || BitIsSet(b.Bitmap, b.Offset+i)
+}
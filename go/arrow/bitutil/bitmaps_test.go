@@ -0,0 +1,325 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitutil_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+type bitmapOp struct {
+	name  string
+	op    func(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int)
+	alloc func(mem memory.Allocator, left, right []byte, lOffset, rOffset, length, outOffset int) *memory.Buffer
+	bit   func(a, b bool) bool
+}
+
+var bitmapOps = []bitmapOp{
+	{"and", bitutil.BitmapAnd, bitutil.BitmapAndAlloc, func(a, b bool) bool { return a && b }},
+	{"or", bitutil.BitmapOr, bitutil.BitmapOrAlloc, func(a, b bool) bool { return a || b }},
+	{"xor", bitutil.BitmapXor, bitutil.BitmapXorAlloc, func(a, b bool) bool { return a != b }},
+	{"and-not", bitutil.BitmapAndNot, bitutil.BitmapAndNotAlloc, func(a, b bool) bool { return a && !b }},
+}
+
+func randomBitmap(r *rand.Rand, nbits int) []byte {
+	buf := make([]byte, bitutil.BytesForBits(int64(nbits)))
+	r.Read(buf)
+	return buf
+}
+
+func TestBitmapOps(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, op := range bitmapOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, length := range bitmapLengths {
+				for lOffset := 0; lOffset < 16; lOffset++ {
+					for rOffset := 0; rOffset < 16; rOffset++ {
+						for outOffset := 0; outOffset < 16; outOffset++ {
+							var (
+								left  = randomBitmap(r, lOffset+length)
+								right = randomBitmap(r, rOffset+length)
+								out   = randomBitmap(r, outOffset+length+8)
+								want  = append([]byte(nil), out...)
+							)
+							for i := 0; i < length; i++ {
+								v := op.bit(bitutil.BitIsSet(left, lOffset+i), bitutil.BitIsSet(right, rOffset+i))
+								bitutil.SetBitTo(want, outOffset+i, v)
+							}
+
+							op.op(left, right, lOffset, rOffset, out, outOffset, length)
+							if !bytes.Equal(out, want) {
+								t.Fatalf("length=%d offsets=(%d, %d, %d): got=%08b, want=%08b",
+									length, lOffset, rOffset, outOffset, out, want)
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// bitmapLengths are the lengths of the bitmaps of the tests, around the
+// byte and word boundaries.
+var bitmapLengths = []int{0, 1, 7, 8, 9, 63, 64, 65, 127, 128, 200}
+
+type unaryBitmapOp struct {
+	name string
+	op   func(in []byte, inOffset int, out []byte, outOffset, length int)
+	bit  func(a bool) bool
+}
+
+var unaryBitmapOps = []unaryBitmapOp{
+	{"not", bitutil.BitmapNot, func(a bool) bool { return !a }},
+	{"copy", bitutil.CopyBitmap, func(a bool) bool { return a }},
+}
+
+func TestUnaryBitmapOps(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, op := range unaryBitmapOps {
+		t.Run(op.name, func(t *testing.T) {
+			for _, length := range bitmapLengths {
+				for inOffset := 0; inOffset < 16; inOffset++ {
+					for outOffset := 0; outOffset < 16; outOffset++ {
+						var (
+							in   = randomBitmap(r, inOffset+length)
+							out  = randomBitmap(r, outOffset+length+8)
+							want = append([]byte(nil), out...)
+						)
+						for i := 0; i < length; i++ {
+							bitutil.SetBitTo(want, outOffset+i, op.bit(bitutil.BitIsSet(in, inOffset+i)))
+						}
+
+						op.op(in, inOffset, out, outOffset, length)
+						if !bytes.Equal(out, want) {
+							t.Fatalf("length=%d offsets=(%d, %d): got=%08b, want=%08b",
+								length, inOffset, outOffset, out, want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBitmapOpsInPlace(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, length := range bitmapLengths {
+		for offset := 0; offset < 16; offset++ {
+			var (
+				left  = randomBitmap(r, offset+length+8)
+				right = randomBitmap(r, offset+length)
+				want  = append([]byte(nil), left...)
+			)
+			bitutil.BitmapAnd(left, right, offset, offset, want, offset, length)
+
+			bitutil.BitmapAnd(left, right, offset, offset, left, offset, length)
+			if !bytes.Equal(left, want) {
+				t.Fatalf("length=%d offset=%d: got=%08b, want=%08b", length, offset, left, want)
+			}
+
+			want = append([]byte(nil), left...)
+			for i := 0; i < length; i++ {
+				bitutil.SetBitTo(want, offset+i, bitutil.BitIsNotSet(left, offset+i))
+			}
+			bitutil.BitmapNot(left, offset, left, offset, length)
+			if !bytes.Equal(left, want) {
+				t.Fatalf("not: length=%d offset=%d: got=%08b, want=%08b", length, offset, left, want)
+			}
+		}
+	}
+}
+
+func TestCountSetBitsRanges(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	buf := randomBitmap(r, 16+200)
+	for _, length := range bitmapLengths {
+		for offset := 0; offset < 16; offset++ {
+			want := 0
+			for i := 0; i < length; i++ {
+				if bitutil.BitIsSet(buf, offset+i) {
+					want++
+				}
+			}
+			// the bitmap ends with the range.
+			in := buf[:bitutil.BytesForBits(int64(offset+length))]
+			if got := bitutil.CountSetBits(in, offset, length); got != want {
+				t.Fatalf("length=%d offset=%d: got=%d, want=%d", length, offset, got, want)
+			}
+		}
+	}
+}
+
+func TestBitmapNotAlloc(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	in := []byte{0xa5, 0x0f}
+	buf := bitutil.BitmapNotAlloc(mem, in, 3, 10, 5)
+	defer buf.Release()
+
+	if got, want := buf.Len(), 2; got != want {
+		t.Fatalf("invalid buffer length: got=%d, want=%d", got, want)
+	}
+	for i := 0; i < 15; i++ {
+		want := i >= 5 && bitutil.BitIsNotSet(in, 3+i-5)
+		if got := bitutil.BitIsSet(buf.Bytes(), i); got != want {
+			t.Fatalf("bit %d: got=%v, want=%v", i, got, want)
+		}
+	}
+}
+
+func TestBitmapOpsAlloc(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	r := rand.New(rand.NewSource(0))
+	for _, op := range bitmapOps {
+		if op.alloc == nil {
+			continue
+		}
+		t.Run(op.name, func(t *testing.T) {
+			const length = 100
+			for _, outOffset := range []int{0, 3, 8, 13} {
+				var (
+					left  = randomBitmap(r, length+5)
+					right = randomBitmap(r, length+2)
+				)
+				buf := op.alloc(mem, left, right, 5, 2, length, outOffset)
+				defer buf.Release()
+
+				if got, want := buf.Len(), int(bitutil.BytesForBits(int64(outOffset+length))); got != want {
+					t.Fatalf("invalid buffer length: got=%d, want=%d", got, want)
+				}
+				out := buf.Bytes()
+				for i := 0; i < outOffset; i++ {
+					if bitutil.BitIsSet(out, i) {
+						t.Fatalf("bit %d before the offset should be zero", i)
+					}
+				}
+				for i := 0; i < length; i++ {
+					want := op.bit(bitutil.BitIsSet(left, 5+i), bitutil.BitIsSet(right, 2+i))
+					if got := bitutil.BitIsSet(out, outOffset+i); got != want {
+						t.Fatalf("bit %d: got=%v, want=%v", i, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOptionalBitIndexer(t *testing.T) {
+	var missing bitutil.OptionalBitIndexer
+	for i := 0; i < 20; i++ {
+		if !missing.GetBit(i) {
+			t.Fatalf("bit %d of a missing bitmap should be set", i)
+		}
+	}
+
+	bitmap := []byte{0xa5, 0x0f}
+	idx := bitutil.NewOptionalBitIndexer(bitmap, 3)
+	for i := 0; i < 13; i++ {
+		if got, want := idx.GetBit(i), bitutil.BitIsSet(bitmap, i+3); got != want {
+			t.Fatalf("bit %d: got=%v, want=%v", i, got, want)
+		}
+	}
+}
+
+func BenchmarkBitmapAnd(b *testing.B) {
+	const length = 1 << 16
+	r := rand.New(rand.NewSource(0))
+	left := randomBitmap(r, length+8)
+	right := randomBitmap(r, length+8)
+	out := make([]byte, bitutil.BytesForBits(length+8))
+
+	for _, offsets := range [][3]int{{0, 0, 0}, {3, 5, 0}, {3, 5, 1}} {
+		b.Run(fmt.Sprintf("offsets=%v", offsets), func(b *testing.B) {
+			b.Run("words", func(b *testing.B) {
+				b.SetBytes(length / 8)
+				for i := 0; i < b.N; i++ {
+					bitutil.BitmapAnd(left, right, offsets[0], offsets[1], out, offsets[2], length)
+				}
+			})
+			b.Run("bits", func(b *testing.B) {
+				b.SetBytes(length / 8)
+				for i := 0; i < b.N; i++ {
+					for j := 0; j < length; j++ {
+						v := bitutil.BitIsSet(left, offsets[0]+j) && bitutil.BitIsSet(right, offsets[1]+j)
+						bitutil.SetBitTo(out, offsets[2]+j, v)
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkBitmapNot(b *testing.B) {
+	const length = 1 << 16
+	r := rand.New(rand.NewSource(0))
+	in := randomBitmap(r, length+8)
+	out := make([]byte, bitutil.BytesForBits(length+8))
+
+	for _, offsets := range [][2]int{{0, 0}, {3, 0}, {3, 1}} {
+		b.Run(fmt.Sprintf("offsets=%v", offsets), func(b *testing.B) {
+			b.Run("words", func(b *testing.B) {
+				b.SetBytes(length / 8)
+				for i := 0; i < b.N; i++ {
+					bitutil.BitmapNot(in, offsets[0], out, offsets[1], length)
+				}
+			})
+			b.Run("bits", func(b *testing.B) {
+				b.SetBytes(length / 8)
+				for i := 0; i < b.N; i++ {
+					for j := 0; j < length; j++ {
+						bitutil.SetBitTo(out, offsets[1]+j, bitutil.BitIsNotSet(in, offsets[0]+j))
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkCopyBitmap(b *testing.B) {
+	const length = 1 << 16
+	r := rand.New(rand.NewSource(0))
+	in := randomBitmap(r, length+8)
+	out := make([]byte, bitutil.BytesForBits(length+8))
+
+	for _, offsets := range [][2]int{{0, 0}, {3, 0}, {3, 1}} {
+		b.Run(fmt.Sprintf("offsets=%v", offsets), func(b *testing.B) {
+			b.Run("words", func(b *testing.B) {
+				b.SetBytes(length / 8)
+				for i := 0; i < b.N; i++ {
+					bitutil.CopyBitmap(in, offsets[0], out, offsets[1], length)
+				}
+			})
+			b.Run("bits", func(b *testing.B) {
+				b.SetBytes(length / 8)
+				for i := 0; i < b.N; i++ {
+					for j := 0; j < length; j++ {
+						bitutil.SetBitTo(out, offsets[1]+j, bitutil.BitIsSet(in, offsets[0]+j))
+					}
+				}
+			})
+		})
+	}
+}
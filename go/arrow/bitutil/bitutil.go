@@ -80,10 +80,8 @@ func CountSetBits(buf []byte, offset, n int) int {
 	}
 
 	// tail bits
-	for i := n &^ 0x7; i < n; i++ {
-		if BitIsSet(buf, i) {
-			count++
-		}
+	if tail := n & 0x7; tail > 0 {
+		count += bits.OnesCount8(buf[n/8] & (1<<uint(tail) - 1))
 	}
 
 	return count
@@ -98,11 +96,7 @@ func countSetBitsWithOffset(buf []byte, offset, n int) int {
 	begU8 := roundUp(beg, uint64SizeBits)
 
 	init := min(n, begU8-beg)
-	for i := offset; i < beg+init; i++ {
-		if BitIsSet(buf, i) {
-			count++
-		}
-	}
+	count += countLowBits(loadWord(buf, beg), init)
 
 	nU64 := (n - init) / uint64SizeBits
 	begU64 := begU8 / uint64SizeBits
@@ -114,19 +108,17 @@ func countSetBitsWithOffset(buf []byte, offset, n int) int {
 		}
 	}
 
-	// FIXME: use a fallback to bits.OnesCount8
-	// before counting the tail bits.
-
 	tail := beg + init + nU64*uint64SizeBits
-	for i := tail; i < end; i++ {
-		if BitIsSet(buf, i) {
-			count++
-		}
-	}
+	count += countLowBits(loadWord(buf, tail), end-tail)
 
 	return count
 }
 
+// countLowBits returns the number of 1's in the low n bits of v, n < 64.
+func countLowBits(v uint64, n int) int {
+	return bits.OnesCount64(v & (1<<uint(n) - 1))
+}
+
 func roundUp(v, f int) int {
 	return (v + (f - 1)) / f * f
 }
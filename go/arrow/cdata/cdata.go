@@ -0,0 +1,738 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cdata implements the Arrow C data interface, which shares
+// arrays, records, streams of records and schemas with other libraries of
+// the same process, such as DuckDB, or the Python and R bindings of Arrow,
+// without copying the exported values.
+//
+// See https://arrow.apache.org/docs/format/CDataInterface.html and
+// https://arrow.apache.org/docs/format/CStreamInterface.html for the
+// specification of the interfaces.
+package cdata // import "github.com/apache/arrow/go/arrow/cdata"
+
+// #include <stdlib.h>
+// #include "arrow/c/abi.h"
+//
+// static void releaseSchema(struct ArrowSchema* s) { s->release(s); }
+// static void releaseArray(struct ArrowArray* a) { a->release(a); }
+//
+// static struct ArrowSchema* schemaChild(struct ArrowSchema* s, int64_t i) { return s->children[i]; }
+// static struct ArrowArray* arrayChild(struct ArrowArray* a, int64_t i) { return a->children[i]; }
+// static const void* arrayBuffer(struct ArrowArray* a, int64_t i) { return a->buffers[i]; }
+import "C"
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/endian"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// CArrowSchema is the C struct ArrowSchema, describing a data type.
+type CArrowSchema = C.struct_ArrowSchema
+
+// CArrowArray is the C struct ArrowArray, holding the values of an array.
+type CArrowArray = C.struct_ArrowArray
+
+// CArrowArrayStream is the C struct ArrowArrayStream, producing a sequence
+// of arrays of the same type.
+type CArrowArrayStream = C.struct_ArrowArrayStream
+
+const (
+	flagDictionaryOrdered = C.ARROW_FLAG_DICTIONARY_ORDERED
+	flagNullable          = C.ARROW_FLAG_NULLABLE
+)
+
+// ReleaseCArrowSchema releases schema, unless it was already released.
+func ReleaseCArrowSchema(schema *CArrowSchema) {
+	if schema.release != nil {
+		C.releaseSchema(schema)
+	}
+}
+
+// ReleaseCArrowArray releases arr, unless it was already released.
+func ReleaseCArrowArray(arr *CArrowArray) {
+	if arr.release != nil {
+		C.releaseArray(arr)
+	}
+}
+
+var formats = map[arrow.Type]string{
+	arrow.NULL:    "n",
+	arrow.BOOL:    "b",
+	arrow.INT8:    "c",
+	arrow.UINT8:   "C",
+	arrow.INT16:   "s",
+	arrow.UINT16:  "S",
+	arrow.INT32:   "i",
+	arrow.UINT32:  "I",
+	arrow.INT64:   "l",
+	arrow.UINT64:  "L",
+	arrow.FLOAT16: "e",
+	arrow.FLOAT32: "f",
+	arrow.FLOAT64: "g",
+	arrow.BINARY:  "z",
+	arrow.STRING:  "u",
+	arrow.DATE32:  "tdD",
+	arrow.DATE64:  "tdm",
+	arrow.STRUCT:  "+s",
+	arrow.LIST:    "+l",
+
+	arrow.BINARY_VIEW:     "vz",
+	arrow.STRING_VIEW:     "vu",
+	arrow.RUN_END_ENCODED: "+r",
+}
+
+var unitFormats = map[arrow.TimeUnit]string{
+	arrow.Second:      "s",
+	arrow.Millisecond: "m",
+	arrow.Microsecond: "u",
+	arrow.Nanosecond:  "n",
+}
+
+// formatOf returns the format string of dt. Dictionaries have the format
+// of their indices.
+func formatOf(dt arrow.DataType) (string, error) {
+	switch dt := dt.(type) {
+	case *arrow.FixedSizeBinaryType:
+		return "w:" + strconv.Itoa(dt.ByteWidth), nil
+	case *arrow.Decimal128Type:
+		return "d:" + strconv.Itoa(int(dt.Precision)) + "," + strconv.Itoa(int(dt.Scale)), nil
+	case *arrow.Decimal256Type:
+		return "d:" + strconv.Itoa(int(dt.Precision)) + "," + strconv.Itoa(int(dt.Scale)) + ",256", nil
+	case *arrow.Time32Type:
+		return "tt" + unitFormats[dt.Unit], nil
+	case *arrow.Time64Type:
+		return "tt" + unitFormats[dt.Unit], nil
+	case *arrow.TimestampType:
+		return "ts" + unitFormats[dt.Unit] + ":" + dt.TimeZone, nil
+	case *arrow.DurationType:
+		return "tD" + unitFormats[dt.Unit], nil
+	case *arrow.MonthIntervalType:
+		return "tiM", nil
+	case *arrow.DayTimeIntervalType:
+		return "tiD", nil
+	case *arrow.FixedSizeListType:
+		return "+w:" + strconv.Itoa(int(dt.Len())), nil
+	case *arrow.DictionaryType:
+		return formatOf(dt.IndexType)
+	}
+	if f, ok := formats[dt.ID()]; ok {
+		return f, nil
+	}
+	return "", xerrors.Errorf("arrow/cdata: unsupported data type %v", dt)
+}
+
+// typeOf returns the data type of the format string f, whose children
+// have the given fields.
+func typeOf(f string, children []arrow.Field) (arrow.DataType, error) {
+	for id, format := range formats {
+		if f != format {
+			continue
+		}
+		switch id {
+		case arrow.STRUCT:
+			return arrow.StructOf(children...), nil
+		case arrow.LIST:
+			if len(children) != 1 {
+				return nil, xerrors.Errorf("arrow/cdata: list with %d children", len(children))
+			}
+			return arrow.ListOf(children[0].Type), nil
+		case arrow.RUN_END_ENCODED:
+			if len(children) != 2 {
+				return nil, xerrors.Errorf("arrow/cdata: run-end encoded with %d children", len(children))
+			}
+			switch children[0].Type.ID() {
+			case arrow.INT16, arrow.INT32, arrow.INT64:
+				return arrow.RunEndEncodedOf(children[0].Type, children[1].Type), nil
+			}
+			return nil, xerrors.Errorf("arrow/cdata: run-end encoded with run ends of type %v", children[0].Type)
+		}
+		dt, ok := primitiveTypes[id]
+		if !ok {
+			break
+		}
+		return dt, nil
+	}
+
+	switch {
+	case strings.HasPrefix(f, "w:"):
+		n, err := strconv.Atoi(f[2:])
+		if err != nil || n <= 0 {
+			break
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: n}, nil
+	case strings.HasPrefix(f, "d:"):
+		parts := strings.Split(f[2:], ",")
+		bitWidth := "128"
+		if len(parts) == 3 {
+			parts, bitWidth = parts[:2], parts[2]
+		}
+		if len(parts) != 2 {
+			break
+		}
+		p, err1 := strconv.Atoi(parts[0])
+		s, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil || p <= 0 {
+			break
+		}
+		switch {
+		case bitWidth == "128" && p <= decimal128.MaxPrecision:
+			return &arrow.Decimal128Type{Precision: int32(p), Scale: int32(s)}, nil
+		case bitWidth == "256" && p <= decimal256.MaxPrecision:
+			return &arrow.Decimal256Type{Precision: int32(p), Scale: int32(s)}, nil
+		}
+		// decimals of other bit widths are not supported.
+	case strings.HasPrefix(f, "+w:"):
+		n, err := strconv.Atoi(f[3:])
+		if err != nil || n <= 0 || len(children) != 1 {
+			break
+		}
+		return arrow.FixedSizeListOf(int32(n), children[0].Type), nil
+	case strings.HasPrefix(f, "ts") && len(f) >= 4 && f[3] == ':':
+		if unit, ok := unitOf(f[2]); ok {
+			dt, err := arrow.NewTimestampType(unit, f[4:])
+			if err != nil {
+				return nil, xerrors.Errorf("arrow/cdata: invalid format %q: %w", f, err)
+			}
+			return dt, nil
+		}
+	case len(f) == 3 && strings.HasPrefix(f, "tt"):
+		switch unit, _ := unitOf(f[2]); f[2] {
+		case 's', 'm':
+			return &arrow.Time32Type{Unit: unit}, nil
+		case 'u', 'n':
+			return &arrow.Time64Type{Unit: unit}, nil
+		}
+	case len(f) == 3 && strings.HasPrefix(f, "tD"):
+		if unit, ok := unitOf(f[2]); ok {
+			return &arrow.DurationType{Unit: unit}, nil
+		}
+	case f == "tiM":
+		return arrow.FixedWidthTypes.MonthInterval, nil
+	case f == "tiD":
+		return arrow.FixedWidthTypes.DayTimeInterval, nil
+	}
+	return nil, xerrors.Errorf("arrow/cdata: unsupported format %q", f)
+}
+
+func unitOf(c byte) (arrow.TimeUnit, bool) {
+	for unit, f := range unitFormats {
+		if f[0] == c {
+			return unit, true
+		}
+	}
+	return 0, false
+}
+
+var primitiveTypes = map[arrow.Type]arrow.DataType{
+	arrow.NULL:    arrow.Null,
+	arrow.BOOL:    arrow.FixedWidthTypes.Boolean,
+	arrow.INT8:    arrow.PrimitiveTypes.Int8,
+	arrow.UINT8:   arrow.PrimitiveTypes.Uint8,
+	arrow.INT16:   arrow.PrimitiveTypes.Int16,
+	arrow.UINT16:  arrow.PrimitiveTypes.Uint16,
+	arrow.INT32:   arrow.PrimitiveTypes.Int32,
+	arrow.UINT32:  arrow.PrimitiveTypes.Uint32,
+	arrow.INT64:   arrow.PrimitiveTypes.Int64,
+	arrow.UINT64:  arrow.PrimitiveTypes.Uint64,
+	arrow.FLOAT16: arrow.FixedWidthTypes.Float16,
+	arrow.FLOAT32: arrow.PrimitiveTypes.Float32,
+	arrow.FLOAT64: arrow.PrimitiveTypes.Float64,
+	arrow.BINARY:  arrow.BinaryTypes.Binary,
+	arrow.STRING:  arrow.BinaryTypes.String,
+	arrow.DATE32:  arrow.PrimitiveTypes.Date32,
+	arrow.DATE64:  arrow.PrimitiveTypes.Date64,
+
+	arrow.BINARY_VIEW: arrow.BinaryTypes.BinaryView,
+	arrow.STRING_VIEW: arrow.BinaryTypes.StringView,
+}
+
+// encodeMetadata encodes md in the binary format of the C data interface:
+// the number of pairs, followed by the length and bytes of each key and
+// value, as native int32s.
+func encodeMetadata(md arrow.Metadata) []byte {
+	if md.Len() == 0 {
+		return nil
+	}
+	var buf []byte
+	appendInt32 := func(v int) {
+		var b [4]byte
+		endian.Native.PutUint32(b[:], uint32(v))
+		buf = append(buf, b[:]...)
+	}
+	appendInt32(md.Len())
+	for i, k := range md.Keys() {
+		v := md.Values()[i]
+		appendInt32(len(k))
+		buf = append(buf, k...)
+		appendInt32(len(v))
+		buf = append(buf, v...)
+	}
+	return buf
+}
+
+// decodeMetadata decodes the metadata encoded at p.
+func decodeMetadata(p *C.char) arrow.Metadata {
+	if p == nil {
+		return arrow.Metadata{}
+	}
+	readInt32 := func() int {
+		v := int(int32(endian.Native.Uint32(C.GoBytes(unsafe.Pointer(p), 4))))
+		p = (*C.char)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + 4))
+		return v
+	}
+	readString := func() string {
+		n := readInt32()
+		s := C.GoStringN(p, C.int(n))
+		p = (*C.char)(unsafe.Pointer(uintptr(unsafe.Pointer(p)) + uintptr(n)))
+		return s
+	}
+	n := readInt32()
+	keys, values := make([]string, n), make([]string, n)
+	for i := range keys {
+		keys[i] = readString()
+		values[i] = readString()
+	}
+	return arrow.NewMetadata(keys, values)
+}
+
+// ImportCArrowField returns the field described by schema. schema is not
+// released.
+func ImportCArrowField(schema *CArrowSchema) (arrow.Field, error) {
+	if schema.release == nil {
+		return arrow.Field{}, xerrors.New("arrow/cdata: cannot import released schema")
+	}
+	children := make([]arrow.Field, schema.n_children)
+	for i := range children {
+		child, err := ImportCArrowField(C.schemaChild(schema, C.int64_t(i)))
+		if err != nil {
+			return arrow.Field{}, err
+		}
+		children[i] = child
+	}
+
+	dt, err := typeOf(C.GoString(schema.format), children)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	if schema.dictionary != nil {
+		values, err := ImportCArrowField(schema.dictionary)
+		if err != nil {
+			return arrow.Field{}, err
+		}
+		dt = &arrow.DictionaryType{
+			IndexType: dt,
+			ValueType: values.Type,
+			Ordered:   schema.flags&flagDictionaryOrdered != 0,
+		}
+	}
+
+	var name string
+	if schema.name != nil {
+		name = C.GoString(schema.name)
+	}
+	return arrow.Field{
+		Name:     name,
+		Type:     dt,
+		Nullable: schema.flags&flagNullable != 0,
+		Metadata: decodeMetadata(schema.metadata),
+	}, nil
+}
+
+// ImportCArrowSchema returns the schema described by schema, which must
+// describe a struct whose fields are the fields of the schema. schema is
+// not released.
+func ImportCArrowSchema(schema *CArrowSchema) (*arrow.Schema, error) {
+	field, err := ImportCArrowField(schema)
+	if err != nil {
+		return nil, err
+	}
+	st, ok := field.Type.(*arrow.StructType)
+	if !ok {
+		return nil, xerrors.Errorf("arrow/cdata: cannot import schema of type %v", field.Type)
+	}
+	md := field.Metadata
+	return arrow.NewSchema(st.Fields(), &md), nil
+}
+
+// ImportCArrayWithType returns an array of type dt, wrapping the buffers
+// of arr without copying them. arr is moved: it is marked as released, and
+// its release callback is called once the returned array, and any array
+// sharing its buffers, are released.
+//
+// The returned array must be Release()'d after use.
+func ImportCArrayWithType(arr *CArrowArray, dt arrow.DataType) (array.Interface, error) {
+	imp, err := importArray(arr)
+	if err != nil {
+		return nil, err
+	}
+	defer imp.Free(nil)
+
+	data, err := importData(imp, imp.arr, dt)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+// ImportCArray returns the field described by schema, and an array
+// wrapping the buffers of arr, like ImportCArrayWithType. arr is moved,
+// schema is not released.
+//
+// The returned array must be Release()'d after use.
+func ImportCArray(arr *CArrowArray, schema *CArrowSchema) (arrow.Field, array.Interface, error) {
+	field, err := ImportCArrowField(schema)
+	if err != nil {
+		return arrow.Field{}, nil, err
+	}
+	out, err := ImportCArrayWithType(arr, field.Type)
+	return field, out, err
+}
+
+// ImportCRecordBatchWithSchema returns a record wrapping the buffers of
+// arr, which must be a struct array with a child per field of schema. arr
+// is moved, like with ImportCArrayWithType.
+//
+// The returned record must be Release()'d after use.
+func ImportCRecordBatchWithSchema(arr *CArrowArray, schema *arrow.Schema) (array.Record, error) {
+	st, err := ImportCArrayWithType(arr, arrow.StructOf(schema.Fields()...))
+	if err != nil {
+		return nil, err
+	}
+	defer st.Release()
+
+	// the columns are the children of the struct, which hold its offset.
+	cols := make([]array.Interface, len(schema.Fields()))
+	for i := range cols {
+		cols[i] = st.(*array.Struct).Field(i)
+	}
+	return array.NewRecord(schema, cols, int64(st.Len())), nil
+}
+
+// ImportCRecordBatch returns a record of the schema described by schema,
+// wrapping the buffers of arr. arr is moved, schema is not released.
+//
+// The returned record must be Release()'d after use.
+func ImportCRecordBatch(arr *CArrowArray, schema *CArrowSchema) (array.Record, error) {
+	sc, err := ImportCArrowSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return ImportCRecordBatchWithSchema(arr, sc)
+}
+
+// importedArray owns an array moved from its producer. It is the allocator
+// of the buffers wrapping the memory of the array, and calls its release
+// callback once all of them, and the importer itself, are freed.
+type importedArray struct {
+	arr  *CArrowArray
+	refs int64
+}
+
+// importArray moves arr to a new importedArray.
+func importArray(arr *CArrowArray) (*importedArray, error) {
+	if arr.release == nil {
+		return nil, xerrors.New("arrow/cdata: cannot import released array")
+	}
+	imp := &importedArray{
+		arr:  (*CArrowArray)(C.malloc(C.sizeof_struct_ArrowArray)),
+		refs: 1,
+	}
+	*imp.arr = *arr
+	arr.release = nil
+	return imp, nil
+}
+
+func (imp *importedArray) Allocate(int) []byte {
+	panic("arrow/cdata: cannot allocate imported memory")
+}
+
+func (imp *importedArray) Reallocate(int, []byte) []byte {
+	panic("arrow/cdata: cannot reallocate imported memory")
+}
+
+func (imp *importedArray) Free([]byte) {
+	if atomic.AddInt64(&imp.refs, -1) == 0 {
+		C.releaseArray(imp.arr)
+		C.free(unsafe.Pointer(imp.arr))
+	}
+}
+
+// buffer returns a buffer wrapping the size bytes at p.
+func (imp *importedArray) buffer(p unsafe.Pointer, size int) *memory.Buffer {
+	atomic.AddInt64(&imp.refs, 1)
+	var b []byte
+	h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+	h.Data, h.Len, h.Cap = uintptr(p), size, size
+	return memory.NewBufferWithAllocator(b, imp)
+}
+
+// importData wraps the buffers of arr, of type dt, after checking that its
+// layout matches dt.
+func importData(imp *importedArray, arr *CArrowArray, dt arrow.DataType) (*array.Data, error) {
+	if arr.length < 0 || arr.offset < 0 || arr.null_count < -1 || arr.null_count > arr.length {
+		return nil, xerrors.Errorf("arrow/cdata: array of type %v has invalid length %d, offset %d or null count %d", dt, arr.length, arr.offset, arr.null_count)
+	}
+	if (arr.n_buffers != 0 && arr.buffers == nil) || (arr.n_children != 0 && arr.children == nil) {
+		return nil, xerrors.Errorf("arrow/cdata: array of type %v has no buffers or children", dt)
+	}
+	if _, ok := dt.(*arrow.DictionaryType); ok != (arr.dictionary != nil) {
+		return nil, xerrors.Errorf("arrow/cdata: array of type %v has an unexpected dictionary", dt)
+	}
+
+	var (
+		length = int(arr.length)
+		offset = int(arr.offset)
+		end    = offset + length
+
+		buffers  []*memory.Buffer
+		children []*array.Data
+		dict     *array.Data
+		err      error
+	)
+	defer func() {
+		for _, b := range buffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+		for _, c := range children {
+			c.Release()
+		}
+		if dict != nil {
+			dict.Release()
+		}
+	}()
+
+	checkBuffers := func(n int) bool {
+		if err == nil && int(arr.n_buffers) != n {
+			err = xerrors.Errorf("arrow/cdata: array of type %v has %d buffers, want %d", dt, arr.n_buffers, n)
+		}
+		return err == nil
+	}
+	// buffer wraps the i-th buffer of size bytes, which may only be
+	// missing if it is the validity bitmap or empty.
+	buffer := func(i, size int) *memory.Buffer {
+		if err != nil {
+			return nil
+		}
+		p := C.arrayBuffer(arr, C.int64_t(i))
+		switch {
+		case p != nil:
+			return imp.buffer(unsafe.Pointer(p), size)
+		case i > 0 && size > 0:
+			err = xerrors.Errorf("arrow/cdata: array of type %v has no buffer %d", dt, i)
+		}
+		return nil
+	}
+	// lastOffset checks the offsets of the values of arr, returning the
+	// end of the last value.
+	lastOffset := func(offsets *memory.Buffer) int {
+		if offsets == nil || err != nil {
+			return 0
+		}
+		offs := arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+		if offs[offset] < 0 || offs[offset] > offs[end] {
+			err = xerrors.Errorf("arrow/cdata: array of type %v has invalid offsets", dt)
+			return 0
+		}
+		return int(offs[end])
+	}
+	importChildren := func(types ...arrow.DataType) {
+		if err != nil {
+			return
+		}
+		if int(arr.n_children) != len(types) {
+			err = xerrors.Errorf("arrow/cdata: array of type %v has %d children, want %d", dt, arr.n_children, len(types))
+			return
+		}
+		for i, t := range types {
+			var child *array.Data
+			child, err = importData(imp, C.arrayChild(arr, C.int64_t(i)), t)
+			if err != nil {
+				return
+			}
+			children = append(children, child)
+		}
+	}
+	// checkViews checks that the views of arr which are not inline lie
+	// within the data buffers.
+	checkViews := func(buffers []*memory.Buffer) {
+		if err != nil || buffers[1] == nil {
+			return
+		}
+		views := arrow.ViewHeaderTraits.CastFromBytes(buffers[1].Bytes())
+		for i := offset; i < end; i++ {
+			h := &views[i]
+			if h.Len() < 0 {
+				err = xerrors.Errorf("arrow/cdata: array of type %v has an invalid view at index %d", dt, i-offset)
+				return
+			}
+			if h.IsInline() || (buffers[0] != nil && !bitutil.BitIsSet(buffers[0].Bytes(), i)) {
+				continue
+			}
+			j, off := int(h.BufferIndex()), int(h.BufferOffset())
+			if j < 0 || j+2 >= len(buffers) || off < 0 || off+h.Len() > buffers[j+2].Len() {
+				err = xerrors.Errorf("arrow/cdata: array of type %v has an invalid view at index %d", dt, i-offset)
+				return
+			}
+		}
+	}
+	checkChildren := func(n int) {
+		for _, c := range children {
+			if err == nil && c.Len() < n {
+				err = xerrors.Errorf("arrow/cdata: array of type %v has a child of length %d, want at least %d", dt, c.Len(), n)
+			}
+		}
+	}
+	bitmapSize := int(bitutil.BytesForBits(int64(end)))
+
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		if checkBuffers(0) {
+			buffers = []*memory.Buffer{nil}
+		}
+	case *arrow.BooleanType:
+		if checkBuffers(2) {
+			buffers = []*memory.Buffer{buffer(0, bitmapSize), buffer(1, bitmapSize)}
+		}
+	case *arrow.BinaryType, *arrow.StringType:
+		if checkBuffers(3) {
+			offsets := buffer(1, (end+1)*arrow.Int32SizeBytes)
+			buffers = []*memory.Buffer{buffer(0, bitmapSize), offsets, nil}
+			buffers[2] = buffer(2, lastOffset(offsets))
+		}
+	case *arrow.BinaryViewType, *arrow.StringViewType:
+		// the views and data buffers are followed by a buffer holding the
+		// size of each data buffer.
+		if arr.n_buffers < 3 {
+			checkBuffers(3)
+			break
+		}
+		n := int(arr.n_buffers) - 3
+		sizes := buffer(n+2, n*arrow.Int64SizeBytes)
+		buffers = []*memory.Buffer{buffer(0, bitmapSize), buffer(1, end*arrow.ViewHeaderSizeBytes)}
+		if err != nil {
+			break
+		}
+		var sz []int64
+		if sizes != nil {
+			sz = arrow.Int64Traits.CastFromBytes(sizes.Bytes())
+			defer sizes.Release()
+		}
+		for i := 0; i < n && err == nil; i++ {
+			if sz[i] < 0 {
+				err = xerrors.Errorf("arrow/cdata: array of type %v has a data buffer of size %d", dt, sz[i])
+				break
+			}
+			buffers = append(buffers, buffer(i+2, int(sz[i])))
+		}
+		checkViews(buffers)
+	case *arrow.ListType:
+		if checkBuffers(2) {
+			offsets := buffer(1, (end+1)*arrow.Int32SizeBytes)
+			buffers = []*memory.Buffer{buffer(0, bitmapSize), offsets}
+			n := lastOffset(offsets)
+			importChildren(dt.Elem())
+			checkChildren(n)
+		}
+	case *arrow.FixedSizeListType:
+		if checkBuffers(1) {
+			buffers = []*memory.Buffer{buffer(0, bitmapSize)}
+			importChildren(dt.Elem())
+			checkChildren(end * int(dt.Len()))
+		}
+	case *arrow.StructType:
+		if checkBuffers(1) {
+			buffers = []*memory.Buffer{buffer(0, bitmapSize)}
+			types := make([]arrow.DataType, len(dt.Fields()))
+			for i, f := range dt.Fields() {
+				types[i] = f.Type
+			}
+			importChildren(types...)
+			checkChildren(end)
+		}
+	case *arrow.RunEndEncodedType:
+		if checkBuffers(0) {
+			buffers = []*memory.Buffer{nil}
+			importChildren(dt.RunEnds(), dt.Encoded())
+			if err == nil && children[0].Len() != children[1].Len() {
+				err = xerrors.Errorf("arrow/cdata: array of type %v has %d run ends for %d values", dt, children[0].Len(), children[1].Len())
+			}
+		}
+	default:
+		valueType := dt
+		if dt, ok := dt.(*arrow.DictionaryType); ok {
+			valueType = dt.IndexType
+			dict, err = importData(imp, arr.dictionary, dt.ValueType)
+		}
+		width, ok := byteWidth(valueType)
+		if !ok {
+			return nil, xerrors.Errorf("arrow/cdata: unsupported data type %v", dt)
+		}
+		if checkBuffers(2) {
+			buffers = []*memory.Buffer{buffer(0, bitmapSize), buffer(1, end*width)}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nulls := int(arr.null_count)
+	switch {
+	case dt.ID() == arrow.NULL:
+		nulls = length
+	case dt.ID() == arrow.RUN_END_ENCODED:
+		// the nulls of run-end encoded arrays are the ones of their values.
+		nulls = 0
+	case buffers[0] == nil && nulls > 0:
+		return nil, xerrors.Errorf("arrow/cdata: array of type %v has %d nulls but no validity bitmap", dt, nulls)
+	case buffers[0] == nil:
+		nulls = 0
+	case nulls < 0:
+		nulls = length - bitutil.CountSetBits(buffers[0].Bytes(), offset, length)
+	}
+	if dict != nil {
+		return array.NewDataWithDictionary(dt, length, buffers, nulls, offset, dict), nil
+	}
+	return array.NewData(dt, length, buffers, children, nulls, offset), nil
+}
+
+// byteWidth returns the width of the values of the fixed width type dt.
+func byteWidth(dt arrow.DataType) (int, bool) {
+	switch dt := dt.(type) {
+	case *arrow.Decimal128Type:
+		return arrow.Decimal128SizeBytes, true
+	case *arrow.FixedSizeBinaryType:
+		return dt.ByteWidth, true
+	case arrow.FixedWidthDataType:
+		return dt.BitWidth() / 8, dt.BitWidth()%8 == 0
+	}
+	return 0, false
+}
@@ -0,0 +1,362 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata_test
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/cdata"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestExportImportRecords(t *testing.T) {
+	for _, name := range arrdata.RecordNames {
+		t.Run(name, func(t *testing.T) {
+			for i, rec := range arrdata.Records[name] {
+				t.Run(fmt.Sprint(i), func(t *testing.T) {
+					arr, sc := new(cdata.CArrowArray), new(cdata.CArrowSchema)
+					cdata.ExportArrowRecordBatch(rec, arr, sc)
+					defer cdata.ReleaseCArrowSchema(sc)
+
+					got, err := cdata.ImportCRecordBatch(arr, sc)
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer got.Release()
+
+					if !got.Schema().Equal(rec.Schema()) {
+						t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got.Schema(), rec.Schema())
+					}
+					if !array.RecordEqual(got, rec) {
+						t.Fatalf("invalid record:\ngot= %v\nwant=%v", got, rec)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestExportImportArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ints := func() array.Interface {
+		b := array.NewInt32Builder(mem)
+		defer b.Release()
+		b.AppendValues([]int32{1, 2, 3, 4, 5, 6}, []bool{true, false, true, true, true, false})
+		return b.NewArray()
+	}
+	strs := func() array.Interface {
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues([]string{"a", "", "bc", "def", "", "g"}, []bool{true, true, false, true, true, true})
+		return b.NewArray()
+	}
+
+	for _, tc := range []struct {
+		name string
+		arr  func() array.Interface
+	}{
+		{"ints", ints},
+		{"strings", strs},
+		{
+			"sliced-ints", func() array.Interface {
+				arr := ints()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 5)
+			},
+		},
+		{
+			"sliced-strings", func() array.Interface {
+				arr := strs()
+				defer arr.Release()
+				return array.NewSlice(arr, 2, 6)
+			},
+		},
+		{
+			"empty-strings", func() array.Interface {
+				b := array.NewStringBuilder(mem)
+				defer b.Release()
+				return b.NewArray()
+			},
+		},
+		{
+			"nulls", func() array.Interface {
+				return array.NewNull(4)
+			},
+		},
+		{
+			"dictionary", func() array.Interface {
+				dict := strs()
+				defer dict.Release()
+				b := array.NewInt8Builder(mem)
+				defer b.Release()
+				b.AppendValues([]int8{0, 3, 3, 1, 5, 0, 2}, []bool{true, true, false, true, true, true, true})
+				indices := b.NewArray()
+				defer indices.Release()
+				dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String, Ordered: true}
+				return array.NewDictionaryArray(dt, indices, dict)
+			},
+		},
+		{
+			"sliced-dictionary", func() array.Interface {
+				dict := strs()
+				defer dict.Release()
+				b := array.NewInt16Builder(mem)
+				defer b.Release()
+				b.AppendValues([]int16{0, 3, 3, 1, 5, 0, 2}, nil)
+				indices := b.NewArray()
+				defer indices.Release()
+				dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}
+				arr := array.NewDictionaryArray(dt, indices, dict)
+				defer arr.Release()
+				return array.NewSlice(arr, 2, 6)
+			},
+		},
+		{
+			"list", func() array.Interface {
+				b := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.Int64Builder)
+				b.Append(true)
+				vb.AppendValues([]int64{1, 2}, nil)
+				b.AppendNull()
+				b.Append(true)
+				b.Append(true)
+				vb.AppendValues([]int64{3, 4, 5}, []bool{true, false, true})
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 4)
+			},
+		},
+		{
+			"struct", func() array.Interface {
+				dt := arrow.StructOf(
+					arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+					arrow.Field{Name: "b", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+				)
+				b := array.NewStructBuilder(mem, dt)
+				defer b.Release()
+				fb := b.FieldBuilder(0).(*array.Float64Builder)
+				lb := b.FieldBuilder(1).(*array.ListBuilder)
+				vb := lb.ValueBuilder().(*array.StringBuilder)
+				for i := 0; i < 5; i++ {
+					b.Append(i != 2)
+					fb.Append(float64(i))
+					lb.Append(true)
+					vb.AppendValues([]string{"x", "yz"}[:i%3], nil)
+				}
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 5)
+			},
+		},
+		{
+			"string-views", func() array.Interface {
+				b := array.NewStringViewBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]string{"a", "a value which is not inlined", "", "another value which is not inlined", "bc"}, []bool{true, true, false, true, true})
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 5)
+			},
+		},
+		{
+			"empty-binary-views", func() array.Interface {
+				b := array.NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
+				defer b.Release()
+				return b.NewArray()
+			},
+		},
+		{
+			"run-end-encoded", func() array.Interface {
+				b := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.StringBuilder)
+				b.AppendRuns([]uint64{2, 1, 3})
+				vb.AppendValues([]string{"a", "", "bc"}, []bool{true, false, true})
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 5)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.arr()
+			defer want.Release()
+
+			arr, sc := new(cdata.CArrowArray), new(cdata.CArrowSchema)
+			cdata.ExportArrowArray(want, arr, sc)
+			defer cdata.ReleaseCArrowSchema(sc)
+
+			field, got, err := cdata.ImportCArray(arr, sc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if !arrow.TypeEqual(field.Type, want.DataType()) {
+				t.Fatalf("invalid type: got=%v, want=%v", field.Type, want.DataType())
+			}
+			if !field.Nullable {
+				t.Fatalf("exported field is not nullable")
+			}
+			if !array.ArrayEqual(got, want) {
+				t.Fatalf("invalid array:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestExportRelease(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringBuilder(mem)
+	defer b.Release()
+	b.AppendValues([]string{"a", "bc", "def"}, nil)
+	str := b.NewArray()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "s", Type: arrow.BinaryTypes.String}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{str}, 3)
+	str.Release()
+
+	arr := new(cdata.CArrowArray)
+	cdata.ExportArrowRecordBatch(rec, arr, nil)
+
+	// the exported array keeps the values alive after the record is
+	// released, until the array itself is released.
+	rec.Release()
+	runtime.GC()
+
+	got, err := cdata.ImportCRecordBatchWithSchema(arr, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	if v := got.Column(0).(*array.String).Value(2); v != "def" {
+		t.Fatalf("invalid value: got=%q, want=%q", v, "def")
+	}
+
+	// releasing a released array is a no-op.
+	cdata.ReleaseCArrowArray(arr)
+}
+
+func TestExportImportSchema(t *testing.T) {
+	md := arrow.NewMetadata([]string{"k"}, []string{"v"})
+	dictType := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Uint32, ValueType: arrow.BinaryTypes.Binary}
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int16, Nullable: true},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "Europe/Paris"}},
+		{Name: "d", Type: dictType, Nullable: true},
+		{Name: "l", Type: arrow.FixedSizeListOf(3, arrow.ListOf(&arrow.Decimal128Type{Precision: 10, Scale: 2}))},
+		{
+			Name: "uuid", Type: &arrow.FixedSizeBinaryType{ByteWidth: 16},
+			Metadata: arrow.NewMetadata([]string{"ARROW:extension:name", "ARROW:extension:metadata"}, []string{"uuid", ""}),
+		},
+		{Name: "s", Type: arrow.StructOf(
+			arrow.Field{Name: "dur", Type: arrow.FixedWidthTypes.Duration_ns},
+			arrow.Field{Name: "day", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+		)},
+	}, &md)
+
+	sc := new(cdata.CArrowSchema)
+	cdata.ExportArrowSchema(want, sc)
+	defer cdata.ReleaseCArrowSchema(sc)
+
+	got, err := cdata.ImportCArrowSchema(sc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, want)
+	}
+	if !reflect.DeepEqual(got.Metadata(), want.Metadata()) {
+		t.Fatalf("invalid metadata: got=%v, want=%v", got.Metadata(), want.Metadata())
+	}
+	for i, f := range got.Fields() {
+		if !reflect.DeepEqual(f.Metadata, want.Field(i).Metadata) {
+			t.Fatalf("invalid metadata of field %q: got=%v, want=%v", f.Name, f.Metadata, want.Field(i).Metadata)
+		}
+	}
+}
+
+// sizeRecorder records whether a memory size assertion failed.
+type sizeRecorder struct{ failed bool }
+
+func (r *sizeRecorder) Errorf(string, ...interface{}) { r.failed = true }
+func (r *sizeRecorder) Helper()                       {}
+
+func TestImportZeroCopy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStructBuilder(mem, arrow.StructOf(
+		arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+		arrow.Field{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+	))
+	defer b.Release()
+	ab := b.FieldBuilder(0).(*array.Int64Builder)
+	sb := b.FieldBuilder(1).(*array.StringBuilder)
+	for i, s := range []string{"a", "bc", "", "def", "gh"} {
+		b.Append(true)
+		ab.Append(int64(i))
+		sb.AppendValues([]string{s}, []bool{s != ""})
+	}
+	want := b.NewArray()
+	wantValues := want.(*array.Struct).Field(0).Data().Buffers()[1].Bytes()
+
+	arr := new(cdata.CArrowArray)
+	cdata.ExportArrowArray(want, arr, nil)
+	dt := want.DataType()
+	want.Release()
+
+	got, err := cdata.ImportCArrayWithType(arr, dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cdata.ImportCArrayWithType(arr, dt); err == nil {
+		t.Fatalf("imported a moved array")
+	}
+
+	gotValues := got.(*array.Struct).Field(0).Data().Buffers()[1].Bytes()
+	if &gotValues[0] != &wantValues[0] {
+		t.Fatalf("imported values were copied")
+	}
+
+	// the sliced child outlives its parent, and the exported array is
+	// released once, with the child.
+	child := array.NewSlice(got.(*array.Struct).Field(1), 1, 4)
+	got.Release()
+	runtime.GC()
+
+	var rec sizeRecorder
+	mem.AssertSize(&rec, 0)
+	if !rec.failed {
+		t.Fatalf("exported array released before the imported child")
+	}
+	if v := child.(*array.String).Value(2); v != "def" {
+		t.Fatalf("invalid value: got=%q, want=%q", v, "def")
+	}
+	child.Release()
+}
@@ -0,0 +1,378 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+// #include <errno.h>
+// #include <stdlib.h>
+// #include "arrow/c/abi.h"
+//
+// void releaseExportedSchema(struct ArrowSchema*);
+// void releaseExportedArray(struct ArrowArray*);
+//
+// int exportedStreamGetSchema(struct ArrowArrayStream*, struct ArrowSchema*);
+// int exportedStreamGetNext(struct ArrowArrayStream*, struct ArrowArray*);
+// char* exportedStreamGetLastError(struct ArrowArrayStream*);
+// void releaseExportedStream(struct ArrowArrayStream*);
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/cgo"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// emptyBuffer is exported in place of empty buffers, so that consumers
+// never get a NULL buffer other than a validity bitmap. It holds zeros,
+// such as the single offset of an empty binary array.
+var emptyBuffer = C.calloc(1, 64)
+
+// ExportArrowSchema exports schema to out, as a struct whose fields are
+// the fields of the schema. The release callback of out frees the memory
+// allocated by the export.
+//
+// ExportArrowSchema panics if a field has a type which cannot be
+// exported.
+func ExportArrowSchema(schema *arrow.Schema, out *CArrowSchema) {
+	exportField(arrow.Field{Type: arrow.StructOf(schema.Fields()...), Metadata: schema.Metadata()}, out)
+}
+
+// ExportArrowField exports field to out. Its metadata, such as the name
+// of an extension type, is exported along with it.
+//
+// ExportArrowField panics if the field has a type which cannot be
+// exported.
+func ExportArrowField(field arrow.Field, out *CArrowSchema) {
+	exportField(field, out)
+}
+
+// ExportArrowArray exports arr to out without copying its values, which
+// are retained and pinned in memory until the release callback of out is
+// called. If outSchema is not nil, the type of arr is exported to it.
+//
+// ExportArrowArray panics if arr has a type which cannot be exported.
+func ExportArrowArray(arr array.Interface, out *CArrowArray, outSchema *CArrowSchema) {
+	exportData(arr.Data(), out)
+	if outSchema != nil {
+		exportField(arrow.Field{Type: arr.DataType(), Nullable: true}, outSchema)
+	}
+}
+
+// ExportArrowRecordBatch exports rec to out as a struct array with a child
+// per column, like ExportArrowArray. If outSchema is not nil, the schema
+// of rec is exported to it.
+func ExportArrowRecordBatch(rec array.Record, out *CArrowArray, outSchema *CArrowSchema) {
+	children := make([]*array.Data, rec.NumCols())
+	for i, col := range rec.Columns() {
+		children[i] = col.Data()
+	}
+	data := array.NewData(arrow.StructOf(rec.Schema().Fields()...), int(rec.NumRows()), []*memory.Buffer{nil}, children, 0, 0)
+	defer data.Release()
+	exportData(data, out)
+	if outSchema != nil {
+		ExportArrowSchema(rec.Schema(), outSchema)
+	}
+}
+
+func exportField(field arrow.Field, out *CArrowSchema) {
+	format, err := formatOf(field.Type)
+	if err != nil {
+		panic(err)
+	}
+
+	var children []arrow.Field
+	switch dt := field.Type.(type) {
+	case *arrow.StructType:
+		children = dt.Fields()
+	case *arrow.ListType:
+		children = []arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: true}}
+	case *arrow.FixedSizeListType:
+		children = []arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: true}}
+	case *arrow.RunEndEncodedType:
+		children = []arrow.Field{
+			{Name: "run_ends", Type: dt.RunEnds()},
+			{Name: "values", Type: dt.Encoded(), Nullable: true},
+		}
+	}
+
+	*out = CArrowSchema{}
+	out.format = C.CString(format)
+	out.name = C.CString(field.Name)
+	if md := encodeMetadata(field.Metadata); md != nil {
+		out.metadata = (*C.char)(C.CBytes(md))
+	}
+	if field.Nullable {
+		out.flags |= flagNullable
+	}
+	if dt, ok := field.Type.(*arrow.DictionaryType); ok {
+		if dt.Ordered {
+			out.flags |= flagDictionaryOrdered
+		}
+		out.dictionary = (*CArrowSchema)(C.calloc(1, C.sizeof_struct_ArrowSchema))
+		exportField(arrow.Field{Type: dt.ValueType, Nullable: true}, out.dictionary)
+	}
+	if len(children) > 0 {
+		out.n_children = C.int64_t(len(children))
+		out.children = (**CArrowSchema)(C.calloc(C.size_t(len(children)), C.size_t(unsafe.Sizeof((*CArrowSchema)(nil)))))
+		for i, f := range children {
+			child := (*CArrowSchema)(C.calloc(1, C.sizeof_struct_ArrowSchema))
+			exportField(f, child)
+			schemaChildren(out)[i] = child
+		}
+	}
+	out.release = (*[0]byte)(C.releaseExportedSchema)
+}
+
+//export releaseExportedSchema
+func releaseExportedSchema(s *CArrowSchema) {
+	C.free(unsafe.Pointer(s.format))
+	C.free(unsafe.Pointer(s.name))
+	C.free(unsafe.Pointer(s.metadata))
+	for _, child := range schemaChildren(s) {
+		ReleaseCArrowSchema(child)
+		C.free(unsafe.Pointer(child))
+	}
+	C.free(unsafe.Pointer(s.children))
+	if s.dictionary != nil {
+		ReleaseCArrowSchema(s.dictionary)
+		C.free(unsafe.Pointer(s.dictionary))
+	}
+	*s = CArrowSchema{}
+}
+
+// exportedArray holds the Go values referenced by an exported array until
+// it is released.
+type exportedArray struct {
+	data   *array.Data
+	sizes  []int64 // sizes of the data buffers of a view array
+	pinner runtime.Pinner
+}
+
+func exportData(data *array.Data, out *CArrowArray) {
+	if _, err := formatOf(data.DataType()); err != nil {
+		panic(err)
+	}
+	data.Retain()
+	exp := &exportedArray{data: data}
+
+	*out = CArrowArray{
+		length:     C.int64_t(data.Len()),
+		null_count: C.int64_t(data.NullN()),
+		offset:     C.int64_t(data.Offset()),
+	}
+
+	// the C data interface has no buffer for null and run-end encoded
+	// arrays, and only the validity bitmap for structs, while Go arrays may
+	// hold more. View arrays have an extra buffer with the size of each of
+	// their data buffers.
+	buffers := data.Buffers()
+	switch data.DataType().ID() {
+	case arrow.NULL, arrow.RUN_END_ENCODED:
+		buffers = nil
+	case arrow.STRUCT, arrow.FIXED_SIZE_LIST:
+		buffers = buffers[:1]
+	case arrow.BINARY_VIEW, arrow.STRING_VIEW:
+		exp.sizes = make([]int64, len(buffers)-2)
+		for i, b := range buffers[2:] {
+			if b != nil {
+				exp.sizes[i] = int64(b.Len())
+			}
+		}
+		sizes := memory.NewBufferBytes(arrow.Int64Traits.CastToBytes(exp.sizes))
+		buffers = append(buffers[:len(buffers):len(buffers)], sizes)
+	}
+	if len(buffers) > 0 {
+		out.n_buffers = C.int64_t(len(buffers))
+		out.buffers = (*unsafe.Pointer)(C.calloc(C.size_t(len(buffers)), C.size_t(unsafe.Sizeof(unsafe.Pointer(nil)))))
+		ptrs := (*[1 << 30]unsafe.Pointer)(unsafe.Pointer(out.buffers))[:len(buffers):len(buffers)]
+		for i, b := range buffers {
+			switch {
+			case b != nil && b.Len() > 0:
+				p := &b.Bytes()[0]
+				exp.pinner.Pin(p)
+				ptrs[i] = unsafe.Pointer(p)
+			case i > 0:
+				ptrs[i] = emptyBuffer
+			}
+		}
+	}
+
+	if children := data.Children(); len(children) > 0 {
+		out.n_children = C.int64_t(len(children))
+		out.children = (**CArrowArray)(C.calloc(C.size_t(len(children)), C.size_t(unsafe.Sizeof((*CArrowArray)(nil)))))
+		for i, child := range children {
+			c := (*CArrowArray)(C.calloc(1, C.sizeof_struct_ArrowArray))
+			exportData(child, c)
+			arrayChildren(out)[i] = c
+		}
+	}
+	if dict := data.Dictionary(); dict != nil {
+		out.dictionary = (*CArrowArray)(C.calloc(1, C.sizeof_struct_ArrowArray))
+		exportData(dict, out.dictionary)
+	}
+
+	h := (*cgo.Handle)(C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0)))))
+	*h = cgo.NewHandle(exp)
+	out.private_data = unsafe.Pointer(h)
+	out.release = (*[0]byte)(C.releaseExportedArray)
+}
+
+//export releaseExportedArray
+func releaseExportedArray(a *CArrowArray) {
+	for _, child := range arrayChildren(a) {
+		ReleaseCArrowArray(child)
+		C.free(unsafe.Pointer(child))
+	}
+	C.free(unsafe.Pointer(a.children))
+	if a.dictionary != nil {
+		ReleaseCArrowArray(a.dictionary)
+		C.free(unsafe.Pointer(a.dictionary))
+	}
+	C.free(unsafe.Pointer(a.buffers))
+
+	h := (*cgo.Handle)(a.private_data)
+	exp := h.Value().(*exportedArray)
+	exp.pinner.Unpin()
+	exp.data.Release()
+	h.Delete()
+	C.free(a.private_data)
+	*a = CArrowArray{}
+}
+
+func schemaChildren(s *CArrowSchema) []*CArrowSchema {
+	if s.n_children == 0 {
+		return nil
+	}
+	n := int(s.n_children)
+	return (*[1 << 30]*CArrowSchema)(unsafe.Pointer(s.children))[:n:n]
+}
+
+func arrayChildren(a *CArrowArray) []*CArrowArray {
+	if a.n_children == 0 {
+		return nil
+	}
+	n := int(a.n_children)
+	return (*[1 << 30]*CArrowArray)(unsafe.Pointer(a.children))[:n:n]
+}
+
+// ExportRecordReader exports rr to out as a stream of struct arrays, like
+// ExportArrowRecordBatch. rr is retained until the release callback of out
+// is called.
+//
+// The get_next callback reports the error of rr, if it has an Err() error
+// method, with the EIO error code. Records or schemas which cannot be
+// exported are reported with the EINVAL code.
+func ExportRecordReader(rr array.RecordReader, out *CArrowArrayStream) {
+	rr.Retain()
+	h := (*cgo.Handle)(C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0)))))
+	*h = cgo.NewHandle(&exportedStream{rr: rr})
+	*out = CArrowArrayStream{
+		get_schema:     (*[0]byte)(C.exportedStreamGetSchema),
+		get_next:       (*[0]byte)(C.exportedStreamGetNext),
+		get_last_error: (*[0]byte)(C.exportedStreamGetLastError),
+		release:        (*[0]byte)(C.releaseExportedStream),
+		private_data:   unsafe.Pointer(h),
+	}
+}
+
+// exportedStream is the state of an exported record reader.
+type exportedStream struct {
+	rr      array.RecordReader
+	lastErr *C.char
+}
+
+// exportedStreamOf returns the state of s, or nil if s was released.
+func exportedStreamOf(s *CArrowArrayStream) *exportedStream {
+	if s.private_data == nil {
+		return nil
+	}
+	return (*cgo.Handle)(s.private_data).Value().(*exportedStream)
+}
+
+// call calls fn, keeping its error, or panic, as the last error of the
+// stream.
+func (st *exportedStream) call(fn func() error) (code C.int) {
+	C.free(unsafe.Pointer(st.lastErr))
+	st.lastErr = nil
+	defer func() {
+		if e := recover(); e != nil {
+			st.lastErr = C.CString(fmt.Sprint(e))
+			code = C.EINVAL
+		}
+	}()
+	if err := fn(); err != nil {
+		st.lastErr = C.CString(err.Error())
+		return C.EIO
+	}
+	return 0
+}
+
+//export exportedStreamGetSchema
+func exportedStreamGetSchema(s *CArrowArrayStream, out *CArrowSchema) C.int {
+	st := exportedStreamOf(s)
+	if st == nil {
+		return C.EINVAL
+	}
+	return st.call(func() error {
+		ExportArrowSchema(st.rr.Schema(), out)
+		return nil
+	})
+}
+
+//export exportedStreamGetNext
+func exportedStreamGetNext(s *CArrowArrayStream, out *CArrowArray) C.int {
+	st := exportedStreamOf(s)
+	if st == nil {
+		return C.EINVAL
+	}
+	return st.call(func() error {
+		if st.rr.Next() {
+			ExportArrowRecordBatch(st.rr.Record(), out, nil)
+			return nil
+		}
+		if r, ok := st.rr.(interface{ Err() error }); ok && r.Err() != nil {
+			return r.Err()
+		}
+		// a released array marks the end of the stream.
+		*out = CArrowArray{}
+		return nil
+	})
+}
+
+//export exportedStreamGetLastError
+func exportedStreamGetLastError(s *CArrowArrayStream) *C.char {
+	st := exportedStreamOf(s)
+	if st == nil {
+		return nil
+	}
+	return st.lastErr
+}
+
+//export releaseExportedStream
+func releaseExportedStream(s *CArrowArrayStream) {
+	h := (*cgo.Handle)(s.private_data)
+	st := h.Value().(*exportedStream)
+	C.free(unsafe.Pointer(st.lastErr))
+	st.rr.Release()
+	h.Delete()
+	C.free(s.private_data)
+	*s = CArrowArrayStream{}
+}
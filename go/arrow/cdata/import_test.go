@@ -0,0 +1,252 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestTypeOf(t *testing.T) {
+	item := []arrow.Field{{Name: "item", Type: arrow.PrimitiveTypes.Int8, Nullable: true}}
+	runs := []arrow.Field{
+		{Name: "run_ends", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "values", Type: arrow.BinaryTypes.String, Nullable: true},
+	}
+	for _, tc := range []struct {
+		format   string
+		children []arrow.Field
+		want     arrow.DataType
+	}{
+		{format: "n", want: arrow.Null},
+		{format: "b", want: arrow.FixedWidthTypes.Boolean},
+		{format: "c", want: arrow.PrimitiveTypes.Int8},
+		{format: "C", want: arrow.PrimitiveTypes.Uint8},
+		{format: "s", want: arrow.PrimitiveTypes.Int16},
+		{format: "S", want: arrow.PrimitiveTypes.Uint16},
+		{format: "i", want: arrow.PrimitiveTypes.Int32},
+		{format: "I", want: arrow.PrimitiveTypes.Uint32},
+		{format: "l", want: arrow.PrimitiveTypes.Int64},
+		{format: "L", want: arrow.PrimitiveTypes.Uint64},
+		{format: "e", want: arrow.FixedWidthTypes.Float16},
+		{format: "f", want: arrow.PrimitiveTypes.Float32},
+		{format: "g", want: arrow.PrimitiveTypes.Float64},
+		{format: "z", want: arrow.BinaryTypes.Binary},
+		{format: "u", want: arrow.BinaryTypes.String},
+		{format: "tdD", want: arrow.PrimitiveTypes.Date32},
+		{format: "tdm", want: arrow.PrimitiveTypes.Date64},
+		{format: "tts", want: arrow.FixedWidthTypes.Time32s},
+		{format: "ttm", want: arrow.FixedWidthTypes.Time32ms},
+		{format: "ttu", want: arrow.FixedWidthTypes.Time64us},
+		{format: "ttn", want: arrow.FixedWidthTypes.Time64ns},
+		{format: "tss:", want: &arrow.TimestampType{Unit: arrow.Second}},
+		{format: "tsu:Europe/Paris", want: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "Europe/Paris"}},
+		{format: "tsn:+01:00", want: &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "+01:00"}},
+		{format: "tDm", want: arrow.FixedWidthTypes.Duration_ms},
+		{format: "tiM", want: arrow.FixedWidthTypes.MonthInterval},
+		{format: "tiD", want: arrow.FixedWidthTypes.DayTimeInterval},
+		{format: "w:16", want: &arrow.FixedSizeBinaryType{ByteWidth: 16}},
+		{format: "d:10,2", want: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{format: "d:38,-2,128", want: &arrow.Decimal128Type{Precision: 38, Scale: -2}},
+		{format: "d:76,10,256", want: &arrow.Decimal256Type{Precision: 76, Scale: 10}},
+		{format: "+l", children: item, want: arrow.ListOf(arrow.PrimitiveTypes.Int8)},
+		{format: "+w:3", children: item, want: arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Int8)},
+		{format: "+s", children: item, want: arrow.StructOf(item...)},
+		{format: "+s", want: arrow.StructOf()},
+		{format: "vz", want: arrow.BinaryTypes.BinaryView},
+		{format: "vu", want: arrow.BinaryTypes.StringView},
+		{format: "+r", children: runs, want: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String)},
+
+		// invalid or unsupported formats.
+		{format: ""},
+		{format: "x"},
+		{format: "ii"},
+		{format: "w:"},
+		{format: "w:0"},
+		{format: "w:abc"},
+		{format: "d:10"},
+		{format: "d:39,2"},
+		{format: "d:77,2,256"},
+		{format: "d:10,2,64"},
+		{format: "ts"},
+		{format: "tss:Not/AZone"},
+		{format: "tss:+25:00"},
+		{format: "tsx:"},
+		{format: "ttx"},
+		{format: "tDx"},
+		{format: "+l"},
+		{format: "+w:"},
+		{format: "+w:-1", children: item},
+		{format: "+L", children: item},
+		{format: "+m", children: item},
+		{format: "+r", children: item},
+		{format: "+r", children: append(item[:1:1], item...)},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			got, err := typeOf(tc.format, tc.children)
+			switch {
+			case tc.want == nil && err == nil:
+				t.Fatalf("expected an error, got %v", got)
+			case tc.want == nil:
+				return
+			case err != nil:
+				t.Fatal(err)
+			case !arrow.TypeEqual(got, tc.want):
+				t.Fatalf("invalid type: got=%v, want=%v", got, tc.want)
+			}
+
+			format, err := formatOf(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back, err := typeOf(format, tc.children); err != nil || !arrow.TypeEqual(back, got) {
+				t.Fatalf("format %q does not round trip: got=%v, err=%v", format, back, err)
+			}
+		})
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ints := func(valid []bool) array.Interface {
+		b := array.NewInt32Builder(mem)
+		defer b.Release()
+		b.AppendValues([]int32{1, 2, 3}, valid)
+		return b.NewArray()
+	}
+	st := func() array.Interface {
+		b := array.NewStructBuilder(mem, arrow.StructOf(
+			arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8},
+			arrow.Field{Name: "b", Type: arrow.PrimitiveTypes.Int8},
+		))
+		defer b.Release()
+		b.AppendNull()
+		b.FieldBuilder(0).(*array.Int8Builder).Append(1)
+		b.FieldBuilder(1).(*array.Int8Builder).Append(2)
+		return b.NewArray()
+	}
+
+	views := func() array.Interface {
+		b := array.NewStringViewBuilder(mem)
+		defer b.Release()
+		b.AppendValues([]string{"a", "a value which is not inlined"}, nil)
+		return b.NewArray()
+	}
+	runs := func() array.Interface {
+		b := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int8)
+		defer b.Release()
+		vb := b.ValueBuilder().(*array.Int8Builder)
+		b.Append(2)
+		vb.Append(1)
+		b.Append(3)
+		vb.Append(2)
+		return b.NewArray()
+	}
+
+	for _, tc := range []struct {
+		name   string
+		arr    func() array.Interface
+		dt     arrow.DataType
+		modify func(*CArrowArray)
+	}{
+		{
+			name: "negative-length", arr: func() array.Interface { return ints(nil) },
+			modify: func(a *CArrowArray) { a.length = -1 },
+		},
+		{
+			name: "negative-offset", arr: func() array.Interface { return ints(nil) },
+			modify: func(a *CArrowArray) { a.offset = -1 },
+		},
+		{
+			name: "null-count", arr: func() array.Interface { return ints(nil) },
+			modify: func(a *CArrowArray) { a.null_count = 4 },
+		},
+		{
+			name: "missing-bitmap", arr: func() array.Interface { return ints(nil) },
+			modify: func(a *CArrowArray) {
+				a.null_count = 1
+				(*[2]unsafe.Pointer)(unsafe.Pointer(a.buffers))[0] = nil
+			},
+		},
+		{
+			name: "buffers", arr: func() array.Interface { return ints([]bool{true, false, true}) },
+			modify: func(a *CArrowArray) { a.n_buffers = 1 },
+		},
+		{
+			name: "type", arr: func() array.Interface { return ints(nil) },
+			dt: arrow.BinaryTypes.String,
+		},
+		{
+			name: "no-dictionary", arr: func() array.Interface { return ints(nil) },
+			dt: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String},
+		},
+		{
+			name: "children", arr: st,
+			dt: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8}),
+		},
+		{
+			name: "child-length", arr: st,
+			modify: func(a *CArrowArray) { a.length = 3 },
+		},
+		{
+			name: "view-buffer-size", arr: views,
+			modify: func(a *CArrowArray) {
+				sizes := (*[4]*int64)(unsafe.Pointer(a.buffers))[3]
+				*sizes = 10
+			},
+		},
+		{
+			name: "view-buffers", arr: views,
+			modify: func(a *CArrowArray) { a.n_buffers = 2 },
+		},
+		{
+			name: "run-ends", arr: runs,
+			modify: func(a *CArrowArray) { arrayChildren(a)[0].length = 1 },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.arr()
+			arr := new(CArrowArray)
+			ExportArrowArray(want, arr, nil)
+			dt := tc.dt
+			if dt == nil {
+				dt = want.DataType()
+			}
+			want.Release()
+			if tc.modify != nil {
+				tc.modify(arr)
+			}
+
+			// the array is released by the failed import.
+			got, err := ImportCArrayWithType(arr, dt)
+			if err == nil {
+				got.Release()
+				t.Fatalf("expected an error")
+			}
+			if arr.release != nil {
+				t.Fatalf("array was not moved")
+			}
+			mem.AssertSize(t, 0)
+		})
+	}
+}
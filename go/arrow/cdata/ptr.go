@@ -0,0 +1,131 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+import (
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// The functions of this file take the addresses of C structs as uintptrs,
+// for callers which get them from a layer other than cgo, such as purego
+// or syscall. They behave like the functions they are named after, and
+// the memory at the addresses must remain valid for the duration of the
+// calls, and of the release callbacks of exported structs.
+
+func arrayFromPtr(ptr uintptr) *CArrowArray {
+	return *(**CArrowArray)(unsafe.Pointer(&ptr))
+}
+
+func schemaFromPtr(ptr uintptr) *CArrowSchema {
+	return *(**CArrowSchema)(unsafe.Pointer(&ptr))
+}
+
+func streamFromPtr(ptr uintptr) *CArrowArrayStream {
+	return *(**CArrowArrayStream)(unsafe.Pointer(&ptr))
+}
+
+// ExportArrowSchemaToPtr is like ExportArrowSchema, with the address of
+// the exported C struct.
+func ExportArrowSchemaToPtr(schema *arrow.Schema, schemaPtr uintptr) {
+	ExportArrowSchema(schema, schemaFromPtr(schemaPtr))
+}
+
+// ExportArrowFieldToPtr is like ExportArrowField, with the address of the
+// exported C struct.
+func ExportArrowFieldToPtr(field arrow.Field, schemaPtr uintptr) {
+	ExportArrowField(field, schemaFromPtr(schemaPtr))
+}
+
+// ExportArrowArrayToPtr is like ExportArrowArray, with the addresses of
+// the exported C structs. The type of arr is exported if schemaPtr is not
+// zero.
+func ExportArrowArrayToPtr(arr array.Interface, arrayPtr, schemaPtr uintptr) {
+	var schema *CArrowSchema
+	if schemaPtr != 0 {
+		schema = schemaFromPtr(schemaPtr)
+	}
+	ExportArrowArray(arr, arrayFromPtr(arrayPtr), schema)
+}
+
+// ExportArrowRecordBatchToPtr is like ExportArrowRecordBatch, with the
+// addresses of the exported C structs. The schema of rec is exported if
+// schemaPtr is not zero.
+func ExportArrowRecordBatchToPtr(rec array.Record, arrayPtr, schemaPtr uintptr) {
+	var schema *CArrowSchema
+	if schemaPtr != 0 {
+		schema = schemaFromPtr(schemaPtr)
+	}
+	ExportArrowRecordBatch(rec, arrayFromPtr(arrayPtr), schema)
+}
+
+// ExportRecordReaderToPtr is like ExportRecordReader, with the address of
+// the exported C struct.
+func ExportRecordReaderToPtr(rr array.RecordReader, streamPtr uintptr) {
+	ExportRecordReader(rr, streamFromPtr(streamPtr))
+}
+
+// ImportCArrowSchemaFromPtr is like ImportCArrowSchema, with the address
+// of the C struct.
+func ImportCArrowSchemaFromPtr(schemaPtr uintptr) (*arrow.Schema, error) {
+	return ImportCArrowSchema(schemaFromPtr(schemaPtr))
+}
+
+// ImportCArrowFieldFromPtr is like ImportCArrowField, with the address of
+// the C struct.
+func ImportCArrowFieldFromPtr(schemaPtr uintptr) (arrow.Field, error) {
+	return ImportCArrowField(schemaFromPtr(schemaPtr))
+}
+
+// ImportCArrayFromPtr is like ImportCArray, with the addresses of the C
+// structs. The C struct of the array only needs to remain valid for the
+// duration of the call, since the array is moved.
+func ImportCArrayFromPtr(arrayPtr, schemaPtr uintptr) (arrow.Field, array.Interface, error) {
+	return ImportCArray(arrayFromPtr(arrayPtr), schemaFromPtr(schemaPtr))
+}
+
+// ImportCRecordBatchFromPtr is like ImportCRecordBatch, with the addresses
+// of the C structs.
+func ImportCRecordBatchFromPtr(arrayPtr, schemaPtr uintptr) (array.Record, error) {
+	return ImportCRecordBatch(arrayFromPtr(arrayPtr), schemaFromPtr(schemaPtr))
+}
+
+// ImportCRecordReaderFromPtr is like ImportCRecordReader, with the address
+// of the C struct.
+func ImportCRecordReaderFromPtr(streamPtr uintptr) (array.RecordReader, error) {
+	return ImportCRecordReader(streamFromPtr(streamPtr))
+}
+
+// ReleaseCArrowSchemaPtr is like ReleaseCArrowSchema, with the address of
+// the C struct.
+func ReleaseCArrowSchemaPtr(schemaPtr uintptr) {
+	ReleaseCArrowSchema(schemaFromPtr(schemaPtr))
+}
+
+// ReleaseCArrowArrayPtr is like ReleaseCArrowArray, with the address of
+// the C struct.
+func ReleaseCArrowArrayPtr(arrayPtr uintptr) {
+	ReleaseCArrowArray(arrayFromPtr(arrayPtr))
+}
+
+// ReleaseCArrowArrayStreamPtr is like ReleaseCArrowArrayStream, with the
+// address of the C struct.
+func ReleaseCArrowArrayStreamPtr(streamPtr uintptr) {
+	ReleaseCArrowArrayStream(streamFromPtr(streamPtr))
+}
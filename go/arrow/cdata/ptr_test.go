@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/cdata"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestPtrArrayRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringBuilder(mem)
+	defer b.Release()
+	b.AppendValues([]string{"a", "", "bc"}, []bool{true, false, true})
+	want := b.NewArray()
+	defer want.Release()
+
+	arr, sc := new(cdata.CArrowArray), new(cdata.CArrowSchema)
+	arrPtr, scPtr := uintptr(unsafe.Pointer(arr)), uintptr(unsafe.Pointer(sc))
+	cdata.ExportArrowArrayToPtr(want, arrPtr, scPtr)
+	defer cdata.ReleaseCArrowSchemaPtr(scPtr)
+
+	field, err := cdata.ImportCArrowFieldFromPtr(scPtr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !arrow.TypeEqual(field.Type, arrow.BinaryTypes.String) {
+		t.Fatalf("invalid type: %v", field.Type)
+	}
+
+	_, got, err := cdata.ImportCArrayFromPtr(arrPtr, scPtr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	if !array.ArrayEqual(got, want) {
+		t.Fatalf("invalid array:\ngot= %v\nwant=%v", got, want)
+	}
+	if _, _, err := cdata.ImportCArrayFromPtr(arrPtr, scPtr); err == nil {
+		t.Fatalf("imported a moved array")
+	}
+}
+
+func TestPtrRecordBatchRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := streamRecords(mem)
+	defer releaseRecords(recs)
+
+	sc := new(cdata.CArrowSchema)
+	scPtr := uintptr(unsafe.Pointer(sc))
+	cdata.ExportArrowSchemaToPtr(schema, scPtr)
+	got, err := cdata.ImportCArrowSchemaFromPtr(scPtr)
+	cdata.ReleaseCArrowSchemaPtr(scPtr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, schema)
+	}
+
+	arr := new(cdata.CArrowArray)
+	arrPtr := uintptr(unsafe.Pointer(arr))
+	cdata.ExportArrowRecordBatchToPtr(recs[1], arrPtr, scPtr)
+	defer cdata.ReleaseCArrowSchemaPtr(scPtr)
+	rec, err := cdata.ImportCRecordBatchFromPtr(arrPtr, scPtr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+	if !array.RecordEqual(rec, recs[1]) {
+		t.Fatalf("invalid record:\ngot= %v\nwant=%v", rec, recs[1])
+	}
+
+	// an exported array is released without being imported.
+	cdata.ExportArrowRecordBatchToPtr(recs[2], arrPtr, 0)
+	cdata.ReleaseCArrowArrayPtr(arrPtr)
+}
+
+func TestPtrRecordReaderRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := streamRecords(mem)
+	defer releaseRecords(recs)
+	r, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	stream := new(cdata.CArrowArrayStream)
+	streamPtr := uintptr(unsafe.Pointer(stream))
+	cdata.ExportRecordReaderToPtr(r, streamPtr)
+	rr, err := cdata.ImportCRecordReaderFromPtr(streamPtr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	n := 0
+	for ; rr.Next(); n++ {
+		if !array.RecordEqual(rr.Record(), recs[n]) {
+			t.Fatalf("invalid record %d:\ngot= %v\nwant=%v", n, rr.Record(), recs[n])
+		}
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+	}
+
+	// releasing a moved stream is a no-op.
+	cdata.ReleaseCArrowArrayStreamPtr(streamPtr)
+}
@@ -0,0 +1,152 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+// #include <stdlib.h>
+// #include "arrow/c/abi.h"
+//
+// static int streamGetSchema(struct ArrowArrayStream* s, struct ArrowSchema* out) { return s->get_schema(s, out); }
+// static int streamGetNext(struct ArrowArrayStream* s, struct ArrowArray* out) { return s->get_next(s, out); }
+// static const char* streamGetLastError(struct ArrowArrayStream* s) { return s->get_last_error(s); }
+// static void releaseStream(struct ArrowArrayStream* s) { s->release(s); }
+import "C"
+
+import (
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"golang.org/x/xerrors"
+)
+
+// ReleaseCArrowArrayStream releases stream, unless it was already
+// released.
+func ReleaseCArrowArrayStream(stream *CArrowArrayStream) {
+	if stream.release != nil {
+		C.releaseStream(stream)
+	}
+}
+
+// ImportCRecordReader returns a reader of the records of stream, which are
+// pulled from it one at a time, like with ImportCRecordBatchWithSchema.
+// stream is moved: it is marked as released, and its release callback is
+// called once the reader is released.
+//
+// Errors of the stream are reported by the Err method of the reader, and
+// wrap the error code of the stream, as a syscall.Errno.
+//
+// The returned reader must be Release()'d after use.
+func ImportCRecordReader(stream *CArrowArrayStream) (array.RecordReader, error) {
+	if stream.release == nil {
+		return nil, xerrors.New("arrow/cdata: cannot import released stream")
+	}
+	rr := &importedRecordReader{
+		refCount: 1,
+		stream:   (*CArrowArrayStream)(C.malloc(C.sizeof_struct_ArrowArrayStream)),
+	}
+	*rr.stream = *stream
+	stream.release = nil
+
+	var schema CArrowSchema
+	if err := rr.check(C.streamGetSchema(rr.stream, &schema)); err != nil {
+		rr.Release()
+		return nil, err
+	}
+	defer ReleaseCArrowSchema(&schema)
+
+	sc, err := ImportCArrowSchema(&schema)
+	if err != nil {
+		rr.Release()
+		return nil, err
+	}
+	rr.schema = sc
+	return rr, nil
+}
+
+// importedRecordReader reads the records of an imported stream.
+type importedRecordReader struct {
+	refCount int64
+
+	stream *CArrowArrayStream
+	schema *arrow.Schema
+	cur    array.Record
+	err    error
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (rr *importedRecordReader) Retain() {
+	atomic.AddInt64(&rr.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the stream is released.
+// Release may be called simultaneously from multiple goroutines.
+func (rr *importedRecordReader) Release() {
+	debug.Assert(atomic.LoadInt64(&rr.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&rr.refCount, -1) == 0 {
+		if rr.cur != nil {
+			rr.cur.Release()
+			rr.cur = nil
+		}
+		ReleaseCArrowArrayStream(rr.stream)
+		C.free(unsafe.Pointer(rr.stream))
+		rr.stream = nil
+	}
+}
+
+func (rr *importedRecordReader) Schema() *arrow.Schema { return rr.schema }
+func (rr *importedRecordReader) Record() array.Record  { return rr.cur }
+
+// Err returns the error of the stream, if any.
+func (rr *importedRecordReader) Err() error { return rr.err }
+
+func (rr *importedRecordReader) Next() bool {
+	if rr.cur != nil {
+		rr.cur.Release()
+		rr.cur = nil
+	}
+	if rr.stream == nil || rr.err != nil {
+		return false
+	}
+
+	var arr CArrowArray
+	if rr.err = rr.check(C.streamGetNext(rr.stream, &arr)); rr.err != nil {
+		return false
+	}
+	if arr.release == nil {
+		// the stream has ended.
+		return false
+	}
+	rr.cur, rr.err = ImportCRecordBatchWithSchema(&arr, rr.schema)
+	return rr.err == nil
+}
+
+// check returns the error reported by the stream with code, if any.
+func (rr *importedRecordReader) check(code C.int) error {
+	if code == 0 {
+		return nil
+	}
+	if msg := C.streamGetLastError(rr.stream); msg != nil {
+		return xerrors.Errorf("arrow/cdata: stream error: %s: %w", C.GoString(msg), syscall.Errno(code))
+	}
+	return xerrors.Errorf("arrow/cdata: stream error: %w", syscall.Errno(code))
+}
@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata_test
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/cdata"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func streamRecords(mem memory.Allocator) (*arrow.Schema, []array.Record) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i := 0; i < 3; i++ {
+		for j := 0; j <= i; j++ {
+			b.Field(0).(*array.Int32Builder).Append(int32(10*i + j))
+			b.Field(1).(*array.StringBuilder).AppendValues([]string{strings.Repeat("x", j)}, []bool{j != 1})
+		}
+		recs = append(recs, b.NewRecord())
+	}
+	return schema, recs
+}
+
+// errReader is a record reader failing after its records.
+type errReader struct {
+	array.RecordReader
+	err error
+}
+
+func (r *errReader) Err() error { return r.err }
+
+func TestRecordReaderRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := streamRecords(mem)
+	defer releaseRecords(recs)
+	r, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := new(cdata.CArrowArrayStream)
+	cdata.ExportRecordReader(r, stream)
+	// the exported stream holds the reader.
+	r.Release()
+
+	rr, err := cdata.ImportCRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cdata.ImportCRecordReader(stream); err == nil {
+		t.Fatalf("imported a moved stream")
+	}
+	if !rr.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", rr.Schema(), schema)
+	}
+
+	var first array.Record
+	n := 0
+	for rr.Next() {
+		if n >= len(recs) {
+			t.Fatalf("too many records")
+		}
+		if !array.RecordEqual(rr.Record(), recs[n]) {
+			t.Fatalf("invalid record %d:\ngot= %v\nwant=%v", n, rr.Record(), recs[n])
+		}
+		if n == 0 {
+			first = rr.Record()
+			first.Retain()
+		}
+		n++
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+	}
+	if err := rr.(interface{ Err() error }).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rr.Next() {
+		t.Fatalf("read past the end of the stream")
+	}
+
+	// records outlive the reader, and the stream.
+	rr.Release()
+	if !array.RecordEqual(first, recs[0]) {
+		t.Fatalf("invalid record after release:\ngot= %v\nwant=%v", first, recs[0])
+	}
+	first.Release()
+}
+
+func TestRecordReaderError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := streamRecords(mem)
+	defer releaseRecords(recs)
+	r, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	stream := new(cdata.CArrowArrayStream)
+	cdata.ExportRecordReader(&errReader{RecordReader: r, err: xerrors.New("broken pipe")}, stream)
+	rr, err := cdata.ImportCRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	n := 0
+	for rr.Next() {
+		n++
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+	}
+	err = rr.(interface{ Err() error }).Err()
+	if !xerrors.Is(err, syscall.EIO) || !strings.Contains(err.Error(), "broken pipe") {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if rr.Next() {
+		t.Fatalf("read past an error of the stream")
+	}
+}
+
+func TestRecordReaderRelease(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := streamRecords(mem)
+	r, err := array.NewRecordReader(schema, recs)
+	releaseRecords(recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := new(cdata.CArrowArrayStream)
+	cdata.ExportRecordReader(r, stream)
+	r.Release()
+
+	rr, err := cdata.ImportCRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rr.Next() {
+		t.Fatalf("no record")
+	}
+	// releasing the reader releases the current record, the stream and
+	// the exported reader with its remaining records.
+	rr.Release()
+	if rr.Next() {
+		t.Fatalf("read a released stream")
+	}
+}
+
+func releaseRecords(recs []array.Record) {
+	for _, rec := range recs {
+		rec.Release()
+	}
+}
@@ -51,6 +51,11 @@ func TypeEqual(left, right DataType, opts ...TypeEqualOption) bool {
 		return false
 	}
 
+	if l, ok := left.(ExtensionType); ok {
+		r, ok := right.(ExtensionType)
+		return ok && l.ExtensionName() == r.ExtensionName() && l.ExtensionEquals(r)
+	}
+
 	// StructType is the only type that has metadata.
 	l, ok := left.(*StructType)
 	if !ok || cfg.metadata {
@@ -0,0 +1,572 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// ScalarAggregateOptions controls the behavior of the functions
+// aggregating a datum into a single scalar.
+type ScalarAggregateOptions struct {
+	// SkipNulls makes the aggregations ignore null values. Otherwise, the
+	// result is null if the input holds any null.
+	SkipNulls bool
+	// MinCount is the minimum number of valid values below which the
+	// result is null.
+	MinCount int
+	// CheckOverflow makes Sum fail with ErrInvalid when the sum of
+	// integers or decimals overflows, instead of wrapping around. Mean
+	// does not overflow.
+	CheckOverflow bool
+}
+
+// DefaultScalarAggregateOptions returns the options used when nil options
+// are passed to an aggregation: nulls are skipped, and at least one valid
+// value is required.
+func DefaultScalarAggregateOptions() *ScalarAggregateOptions {
+	return &ScalarAggregateOptions{SkipNulls: true, MinCount: 1}
+}
+
+// CountMode selects the values counted by Count.
+type CountMode int8
+
+const (
+	// CountValid counts the non-null values.
+	CountValid CountMode = iota
+	// CountNull counts the null values.
+	CountNull
+	// CountAll counts all values.
+	CountAll
+)
+
+// CountOptions controls the behavior of Count.
+type CountOptions struct {
+	Mode CountMode
+}
+
+// Sum returns the sum of the values of a numeric or decimal datum.
+//
+// Signed integers are summed as an int64, unsigned integers as an uint64
+// and floating point numbers as a float64, using a compensated summation.
+// Decimals are summed as a decimal of precision 38 with the scale of the
+// input.
+func Sum(ctx context.Context, input Datum, opts *ScalarAggregateOptions) (scalar.Scalar, error) {
+	opts = aggregateOptions(opts)
+	dt, err := sumType("sum", input.DataType())
+	if err != nil {
+		return nil, err
+	}
+	st, nulls, err := sum(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if (!opts.SkipNulls && nulls > 0) || st.count < int64(opts.MinCount) {
+		return scalar.MakeNullScalar(dt), nil
+	}
+	if st.overflow && opts.CheckOverflow {
+		return nil, xerrors.Errorf("arrow/compute: overflow in sum: %w", ErrInvalid)
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.Int64Type:
+		return scalar.NewInt64Scalar(st.i), nil
+	case *arrow.Uint64Type:
+		return scalar.NewUint64Scalar(st.u), nil
+	case *arrow.Float64Type:
+		return scalar.NewFloat64Scalar(st.f + st.c), nil
+	default:
+		return scalar.NewDecimal128Scalar(bigToDecimal(st.dec), dt), nil
+	}
+}
+
+// Mean returns the arithmetic mean of the values of a numeric or decimal
+// datum. The mean of numbers is a float64, and the mean of decimals a
+// decimal of the input type, rounded half to even. The mean is null if
+// there are no values to average.
+func Mean(ctx context.Context, input Datum, opts *ScalarAggregateOptions) (scalar.Scalar, error) {
+	opts = aggregateOptions(opts)
+	if _, err := sumType("mean", input.DataType()); err != nil {
+		return nil, err
+	}
+	dt := input.DataType()
+	if dt.ID() != arrow.DECIMAL {
+		dt = arrow.PrimitiveTypes.Float64
+	}
+	st, nulls, err := sum(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if (!opts.SkipNulls && nulls > 0) || st.count < int64(opts.MinCount) || st.count == 0 {
+		return scalar.MakeNullScalar(dt), nil
+	}
+	if dt.ID() == arrow.DECIMAL {
+		return scalar.NewDecimal128Scalar(st.meanDecimal(), dt), nil
+	}
+	return scalar.NewFloat64Scalar(st.mean(input.DataType().ID())), nil
+}
+
+// MinMax returns the minimum and maximum values of a numeric, decimal,
+// temporal, boolean, string or binary datum. NaNs are ignored, unless all
+// the values are NaNs. Strings and binary values are compared byte-wise.
+func MinMax(ctx context.Context, input Datum, opts *ScalarAggregateOptions) (min, max scalar.Scalar, err error) {
+	opts = aggregateOptions(opts)
+	dt := input.DataType()
+	minMax, err := minMaxKernel(dt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mem := GetAllocator(ctx)
+	chunks, err := datumChunks(mem, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer releaseArrays(chunks)
+
+	// the extrema of each chunk are gathered into an array, whose extrema
+	// are the result.
+	var (
+		cands selection
+		nulls int
+		valid int
+	)
+	extrema := make([][2]int, len(chunks))
+	forEachChunk(ctx, chunks, func(i int) {
+		extrema[i][0], extrema[i][1] = minMax(chunks[i])
+	})
+	for i, c := range chunks {
+		nulls += c.NullN()
+		valid += c.Len() - c.NullN()
+		if imin, imax := extrema[i][0], extrema[i][1]; imin >= 0 {
+			cands.add(i, imin, 1, false)
+			cands.add(i, imax, 1, false)
+		}
+	}
+	if (!opts.SkipNulls && nulls > 0) || valid < opts.MinCount || cands.n == 0 {
+		return scalar.MakeNullScalar(dt), scalar.MakeNullScalar(dt), nil
+	}
+
+	arr, err := gather(mem, chunks, &cands)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer arr.Release()
+	imin, imax := minMax(arr)
+	if min, err = scalar.GetScalar(arr, imin); err != nil {
+		return nil, nil, err
+	}
+	if max, err = scalar.GetScalar(arr, imax); err != nil {
+		return nil, nil, err
+	}
+	return min, max, nil
+}
+
+// Count returns the number of values of a datum, as an Int64 scalar.
+// By default, only non-null values are counted.
+func Count(ctx context.Context, input Datum, opts *CountOptions) (scalar.Scalar, error) {
+	if opts == nil {
+		opts = &CountOptions{}
+	}
+	var nulls int64
+	switch d := input.(type) {
+	case *ScalarDatum:
+		if !d.Value.IsValid() {
+			nulls = 1
+		}
+	case *ArrayDatum:
+		nulls = int64(d.Value.NullN())
+	case *ChunkedDatum:
+		nulls = int64(d.Value.NullN())
+	default:
+		return nil, xerrors.Errorf("arrow/compute: invalid datum %T: %w", input, ErrInvalid)
+	}
+
+	switch opts.Mode {
+	case CountValid:
+		return scalar.NewInt64Scalar(input.Len() - nulls), nil
+	case CountNull:
+		return scalar.NewInt64Scalar(nulls), nil
+	case CountAll:
+		return scalar.NewInt64Scalar(input.Len()), nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: invalid count mode %d: %w", opts.Mode, ErrInvalid)
+}
+
+// Any returns whether any of the values of a boolean datum is true. If
+// nulls are not skipped, the result follows the three-valued logic of SQL:
+// it is null if no value is true and some values are null.
+func Any(ctx context.Context, input Datum, opts *ScalarAggregateOptions) (scalar.Scalar, error) {
+	return anyAll(ctx, "any", input, opts, true)
+}
+
+// All returns whether all the values of a boolean datum are true. If
+// nulls are not skipped, the result follows the three-valued logic of SQL:
+// it is null if no value is false and some values are null.
+func All(ctx context.Context, input Datum, opts *ScalarAggregateOptions) (scalar.Scalar, error) {
+	return anyAll(ctx, "all", input, opts, false)
+}
+
+// anyAll looks for a valid value equal to target. It returns !target if
+// there is none.
+func anyAll(ctx context.Context, name string, input Datum, opts *ScalarAggregateOptions, target bool) (scalar.Scalar, error) {
+	opts = aggregateOptions(opts)
+	if err := checkBoolean(name, input.DataType()); err != nil {
+		return nil, err
+	}
+	mem := GetAllocator(ctx)
+	chunks, err := datumChunks(mem, input)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(chunks)
+
+	var (
+		found        bool
+		nulls, valid int
+	)
+	for _, c := range chunks {
+		n := c.Len()
+		nulls += c.NullN()
+		valid += n - c.NullN()
+		if found || n == 0 {
+			continue
+		}
+
+		vals, ok := boolValues(operand{c, false}, n), boolValidity(operand{c, false}, n)
+		matches := make([]byte, len(vals))
+		bitmapWords(matches, vals, ok, n, func(v, ok uint64) uint64 {
+			if !target {
+				v = ^v
+			}
+			return v & ok
+		})
+		found = bitutil.CountSetBits(matches, 0, n) > 0
+	}
+
+	switch {
+	case valid < opts.MinCount:
+		return scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean), nil
+	case found:
+		return scalar.NewBooleanScalar(target), nil
+	case nulls > 0 && !opts.SkipNulls:
+		return scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean), nil
+	}
+	return scalar.NewBooleanScalar(!target), nil
+}
+
+func aggregateOptions(opts *ScalarAggregateOptions) *ScalarAggregateOptions {
+	if opts == nil {
+		return DefaultScalarAggregateOptions()
+	}
+	return opts
+}
+
+// sumType returns the type of the sum of values of type dt.
+func sumType(name string, dt arrow.DataType) (arrow.DataType, error) {
+	switch id := dt.ID(); {
+	case isSigned(id):
+		return arrow.PrimitiveTypes.Int64, nil
+	case isInteger(id):
+		return arrow.PrimitiveTypes.Uint64, nil
+	case isFloating(id):
+		return arrow.PrimitiveTypes.Float64, nil
+	case id == arrow.DECIMAL:
+		return &arrow.Decimal128Type{Precision: 38, Scale: dt.(*arrow.Decimal128Type).Scale}, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+}
+
+// sumBlockSize is the number of floating point values summed without
+// compensation.
+const sumBlockSize = 128
+
+// sumState accumulates the valid values of numeric or decimal arrays.
+type sumState struct {
+	i        int64
+	u        uint64
+	f, c     float64 // sum of floats, and its compensation
+	dec      *big.Int
+	count    int64 // number of values
+	overflow bool
+}
+
+// addFloat adds v to the sum of floats, using Neumaier's compensated
+// summation.
+func (st *sumState) addFloat(v float64) {
+	t := st.f + v
+	if math.Abs(st.f) >= math.Abs(v) {
+		st.c += (st.f - t) + v
+	} else {
+		st.c += (v - t) + st.f
+	}
+	st.f = t
+}
+
+// mean returns the mean of the numbers of type id summed by st. The mean
+// of integers whose sum overflows is that of their sum as floats.
+func (st *sumState) mean(id arrow.Type) float64 {
+	n := float64(st.count)
+	switch {
+	case isFloating(id), st.overflow:
+		return (st.f + st.c) / n
+	case isSigned(id):
+		return float64(st.i) / n
+	default:
+		return float64(st.u) / n
+	}
+}
+
+// meanDecimal returns the mean of the decimals summed by st, rounded half
+// to even.
+func (st *sumState) meanDecimal() decimal128.Num {
+	return bigToDecimal(decimal128.RoundHalfToEven.Round(new(big.Rat).SetFrac(st.dec, big.NewInt(st.count))))
+}
+
+func (st *sumState) addDecimal(arr *array.Decimal128) {
+	vals := arr.Values()
+	visitValid(arr, func(pos, n int) {
+		for _, v := range vals[pos : pos+n] {
+			st.dec.Add(st.dec, v.ToBigInt())
+		}
+		st.count += int64(n)
+	})
+	if !fitsPrecision(st.dec, 38) {
+		st.overflow = true
+	}
+}
+
+// sum accumulates the values of input, and returns the number of nulls.
+func sum(ctx context.Context, input Datum) (*sumState, int, error) {
+	chunks, err := datumChunks(GetAllocator(ctx), input)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer releaseArrays(chunks)
+
+	// the chunks are summed separately, and their sums added in order.
+	states := make([]*sumState, len(chunks))
+	forEachChunk(ctx, chunks, func(i int) {
+		st := &sumState{dec: new(big.Int)}
+		if c, ok := chunks[i].(*array.Decimal128); ok {
+			st.addDecimal(c)
+		} else {
+			sumNumeric(st, chunks[i])
+		}
+		states[i] = st
+	})
+
+	st := &sumState{dec: new(big.Int)}
+	nulls := 0
+	for i, c := range chunks {
+		nulls += c.NullN()
+		st.merge(states[i])
+	}
+	return st, nulls, nil
+}
+
+// merge adds the values accumulated by o to st.
+func (st *sumState) merge(o *sumState) {
+	i := st.i + o.i
+	if (st.i^i)&(o.i^i) < 0 {
+		st.overflow = true
+	}
+	u := st.u + o.u
+	if u < st.u {
+		st.overflow = true
+	}
+	st.i, st.u = i, u
+	st.addFloat(o.f)
+	st.c += o.c
+	if o.dec.Sign() != 0 {
+		st.dec.Add(st.dec, o.dec)
+		if !fitsPrecision(st.dec, 38) {
+			st.overflow = true
+		}
+	}
+	st.count += o.count
+	st.overflow = st.overflow || o.overflow
+}
+
+// parallelAggregateThreshold is the number of values below which chunks
+// are aggregated serially.
+const parallelAggregateThreshold = 1 << 16
+
+// forEachChunk calls fn with the index of each chunk, on as many as
+// GetAggregateConcurrency(ctx) goroutines if the chunks hold at least
+// parallelAggregateThreshold values. fn must only write the state of the
+// chunk it is called for.
+func forEachChunk(ctx context.Context, chunks []array.Interface, fn func(i int)) {
+	n := 0
+	for _, c := range chunks {
+		n += c.Len()
+	}
+	workers := min(GetAggregateConcurrency(ctx), len(chunks))
+	if workers <= 1 || n < parallelAggregateThreshold {
+		for i := range chunks {
+			fn(i)
+		}
+		return
+	}
+
+	var (
+		next = int64(-1)
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(chunks) {
+					return
+				}
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// visitValid calls fn for each run of valid values of arr.
+func visitValid(arr array.Interface, fn func(pos, n int)) {
+	switch {
+	case arr.Len() == 0:
+	case arr.NullN() == 0:
+		fn(0, arr.Len())
+	case arr.NullN() < arr.Len():
+		visitSetBitRuns(arr.NullBitmapBytes(), arr.Data().Offset(), arr.Len(), fn)
+	}
+}
+
+// minMaxKernel returns a function returning the positions of the minimum
+// and maximum valid values of an array of type dt, or -1 if there are
+// none.
+func minMaxKernel(dt arrow.DataType) (func(arr array.Interface) (imin, imax int), error) {
+	switch id := dt.ID(); {
+	case isInteger(id) || isFloating(id):
+		return minMaxNumeric, nil
+	case id == arrow.FLOAT16:
+		return minMaxFloat16, nil
+	case isTemporal(id):
+		storage := storageType(dt)
+		return func(arr array.Interface) (int, int) {
+			values := reinterpret(arr, storage)
+			defer values.Release()
+			return minMaxNumeric(values)
+		}, nil
+	case id == arrow.DECIMAL:
+		return minMaxDecimal, nil
+	case isBinaryLike(id):
+		return minMaxBinary, nil
+	case id == arrow.BOOL:
+		return minMaxBoolean, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: min_max is not implemented for %v: %w", dt, ErrNotImplemented)
+}
+
+// minMaxFloat16 is minMaxNumeric for half-precision floating point arrays,
+// whose values are compared as float32s.
+func minMaxFloat16(arr array.Interface) (imin, imax int) {
+	var (
+		vals     = arr.(*array.Float16).Values()
+		min, max float32
+	)
+	imin, imax = -1, -1
+	visitValid(arr, func(pos, n int) {
+		for i, x := range vals[pos : pos+n] {
+			v := x.Float32()
+			if v != v {
+				if imin < 0 {
+					imin, imax, min, max = pos+i, pos+i, v, v
+				}
+				continue
+			}
+			if imin < 0 || min != min || v < min {
+				imin, min = pos+i, v
+			}
+			if imax < 0 || max != max || v > max {
+				imax, max = pos+i, v
+			}
+		}
+	})
+	return imin, imax
+}
+
+func minMaxDecimal(arr array.Interface) (imin, imax int) {
+	vals := arr.(*array.Decimal128).Values()
+	imin, imax = -1, -1
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			if imin < 0 || decimalLess(vals[i], vals[imin]) {
+				imin = i
+			}
+			if imax < 0 || decimalLess(vals[imax], vals[i]) {
+				imax = i
+			}
+		}
+	})
+	return imin, imax
+}
+
+func minMaxBinary(arr array.Interface) (imin, imax int) {
+	offsets, data := binaryValues(arr)
+	value := func(i int) []byte { return data[offsets[i]:offsets[i+1]] }
+	imin, imax = -1, -1
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			v := value(i)
+			if imin < 0 || bytes.Compare(v, value(imin)) < 0 {
+				imin = i
+			}
+			if imax < 0 || bytes.Compare(v, value(imax)) > 0 {
+				imax = i
+			}
+		}
+	})
+	return imin, imax
+}
+
+func minMaxBoolean(arr array.Interface) (imin, imax int) {
+	a := arr.(*array.Boolean)
+	imin, imax = -1, -1
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			v := a.Value(i)
+			if imin < 0 || (!v && a.Value(imin)) {
+				imin = i
+			}
+			if imax < 0 || (v && !a.Value(imax)) {
+				imax = i
+			}
+		}
+	})
+	return imin, imax
+}
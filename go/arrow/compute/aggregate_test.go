@@ -0,0 +1,706 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+type aggregateFunc func(context.Context, compute.Datum, *compute.ScalarAggregateOptions) (scalar.Scalar, error)
+
+func TestScalarAggregate(t *testing.T) {
+	var (
+		i8   = arrow.PrimitiveTypes.Int8
+		i32  = arrow.PrimitiveTypes.Int32
+		i64  = arrow.PrimitiveTypes.Int64
+		u16  = arrow.PrimitiveTypes.Uint16
+		u64  = arrow.PrimitiveTypes.Uint64
+		f32  = arrow.PrimitiveTypes.Float32
+		f64  = arrow.PrimitiveTypes.Float64
+		bl   = arrow.FixedWidthTypes.Boolean
+		dec2 = &arrow.Decimal128Type{Precision: 10, Scale: 2}
+
+		keepNulls = &compute.ScalarAggregateOptions{MinCount: 1}
+		minCount3 = &compute.ScalarAggregateOptions{SkipNulls: true, MinCount: 3}
+		minCount0 = &compute.ScalarAggregateOptions{SkipNulls: true}
+		checked   = &compute.ScalarAggregateOptions{SkipNulls: true, MinCount: 1, CheckOverflow: true}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		fn     aggregateFunc
+		opts   *compute.ScalarAggregateOptions
+		dt     arrow.DataType
+		values interface{}
+		valid  []bool
+		want   scalar.Scalar
+		err    error
+	}{
+		{
+			name: "sum-int8", fn: compute.Sum,
+			dt: i8, values: []int8{100, 100, 100, -1}, valid: []bool{true, true, true, false},
+			want: scalar.NewInt64Scalar(300),
+		},
+		{
+			name: "sum-uint16", fn: compute.Sum,
+			dt: u16, values: []uint16{65535, 65535},
+			want: scalar.NewUint64Scalar(131070),
+		},
+		{
+			name: "sum-float32", fn: compute.Sum,
+			dt: f32, values: []float32{0.5, 0.25},
+			want: scalar.NewFloat64Scalar(0.75),
+		},
+		{
+			name: "sum-keep-nulls", fn: compute.Sum, opts: keepNulls,
+			dt: i32, values: []int32{1, 2}, valid: []bool{true, false},
+			want: scalar.MakeNullScalar(i64),
+		},
+		{
+			name: "sum-empty", fn: compute.Sum,
+			dt: i32, values: []int32{},
+			want: scalar.MakeNullScalar(i64),
+		},
+		{
+			name: "sum-empty-min-count-0", fn: compute.Sum, opts: minCount0,
+			dt: i32, values: []int32{},
+			want: scalar.NewInt64Scalar(0),
+		},
+		{
+			name: "sum-all-null", fn: compute.Sum,
+			dt: f64, values: []float64{1, 2}, valid: []bool{false, false},
+			want: scalar.MakeNullScalar(f64),
+		},
+		{
+			name: "sum-min-count", fn: compute.Sum, opts: minCount3,
+			dt: i32, values: []int32{1, 2, 3}, valid: []bool{true, false, true},
+			want: scalar.MakeNullScalar(i64),
+		},
+		{
+			name: "sum-wraps", fn: compute.Sum,
+			dt: i64, values: []int64{math.MaxInt64, 1},
+			want: scalar.NewInt64Scalar(math.MinInt64),
+		},
+		{
+			name: "sum-overflow", fn: compute.Sum, opts: checked,
+			dt: i64, values: []int64{math.MaxInt64, 1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "sum-decimal", fn: compute.Sum,
+			dt: dec2, values: []decimal128.Num{dec(150), dec(-25), dec(1)},
+			want: scalar.NewDecimal128Scalar(dec(126), &arrow.Decimal128Type{Precision: 38, Scale: 2}),
+		},
+		{
+			name: "mean-int", fn: compute.Mean,
+			dt: i32, values: []int32{1, 2, 4, 100}, valid: []bool{true, true, true, false},
+			want: scalar.NewFloat64Scalar(7.0 / 3),
+		},
+		{
+			name: "mean-int64-overflow", fn: compute.Mean, opts: checked,
+			dt: i64, values: []int64{math.MaxInt64, math.MaxInt64},
+			want: scalar.NewFloat64Scalar(math.MaxInt64),
+		},
+		{
+			name: "mean-int64-underflow", fn: compute.Mean,
+			dt: i64, values: []int64{math.MinInt64, math.MinInt64, 3},
+			want: scalar.NewFloat64Scalar((2*math.MinInt64 + 3) / 3.0),
+		},
+		{
+			name: "mean-uint64-overflow", fn: compute.Mean,
+			dt: u64, values: []uint64{math.MaxUint64, math.MaxUint64},
+			want: scalar.NewFloat64Scalar(math.MaxUint64),
+		},
+		{
+			name: "mean-empty", fn: compute.Mean, opts: minCount0,
+			dt: f64, values: []float64{},
+			want: scalar.MakeNullScalar(f64),
+		},
+		{
+			// (1.00 + 0.25 + 0.00) / 3, rounded to 0.42.
+			name: "mean-decimal", fn: compute.Mean,
+			dt: dec2, values: []decimal128.Num{dec(100), dec(25), dec(0)},
+			want: scalar.NewDecimal128Scalar(dec(42), dec2),
+		},
+		{
+			name: "any", fn: compute.Any,
+			dt: bl, values: []bool{false, true, false}, valid: []bool{true, false, true},
+			want: scalar.NewBooleanScalar(false),
+		},
+		{
+			name: "any-kleene-null", fn: compute.Any, opts: keepNulls,
+			dt: bl, values: []bool{false, true, false}, valid: []bool{true, false, true},
+			want: scalar.MakeNullScalar(bl),
+		},
+		{
+			name: "any-kleene-true", fn: compute.Any, opts: keepNulls,
+			dt: bl, values: []bool{false, true, true}, valid: []bool{true, false, true},
+			want: scalar.NewBooleanScalar(true),
+		},
+		{
+			name: "all", fn: compute.All,
+			dt: bl, values: []bool{true, false, true}, valid: []bool{true, false, true},
+			want: scalar.NewBooleanScalar(true),
+		},
+		{
+			name: "all-kleene-false", fn: compute.All, opts: keepNulls,
+			dt: bl, values: []bool{true, false, false}, valid: []bool{true, false, true},
+			want: scalar.NewBooleanScalar(false),
+		},
+		{
+			name: "all-empty", fn: compute.All, opts: minCount0,
+			dt: bl, values: []bool{},
+			want: scalar.NewBooleanScalar(true),
+		},
+		{
+			name: "all-all-null", fn: compute.All,
+			dt: bl, values: []bool{true}, valid: []bool{false},
+			want: scalar.MakeNullScalar(bl),
+		},
+		{
+			name: "sum-string", fn: compute.Sum,
+			dt: arrow.BinaryTypes.String, values: []string{"a"},
+			err: compute.ErrNotImplemented,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			input := datumOf(mem, tc.dt, tc.values, tc.valid, nil)
+			defer input.Release()
+
+			got, err := tc.fn(ctx, input, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertScalarEqual(t, tc.want, got)
+		})
+	}
+}
+
+func assertScalarEqual(t *testing.T, want, got scalar.Scalar) {
+	t.Helper()
+	if !arrow.TypeEqual(want.DataType(), got.DataType()) || want.String() != got.String() {
+		t.Fatalf("scalars differ:\ngot= %v (%v)\nwant=%v (%v)", got, got.DataType(), want, want.DataType())
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	var (
+		nan = math.NaN()
+		ts  = &arrow.TimestampType{Unit: arrow.Millisecond}
+	)
+
+	for _, tc := range []struct {
+		name     string
+		dt       arrow.DataType
+		chunks   []interface{}
+		opts     *compute.ScalarAggregateOptions
+		min, max scalar.Scalar
+	}{
+		{
+			name:   "int32",
+			dt:     arrow.PrimitiveTypes.Int32,
+			chunks: []interface{}{[]int32{3, -7, 5}, []int32{}, []int32{9, 0}},
+			min:    scalar.NewInt32Scalar(-7), max: scalar.NewInt32Scalar(9),
+		},
+		{
+			name:   "uint64",
+			dt:     arrow.PrimitiveTypes.Uint64,
+			chunks: []interface{}{[]uint64{math.MaxUint64, 1}},
+			min:    scalar.NewUint64Scalar(1), max: scalar.NewUint64Scalar(math.MaxUint64),
+		},
+		{
+			name:   "float-nan",
+			dt:     arrow.PrimitiveTypes.Float64,
+			chunks: []interface{}{[]float64{nan, 2}, []float64{nan, -1, nan}},
+			min:    scalar.NewFloat64Scalar(-1), max: scalar.NewFloat64Scalar(2),
+		},
+		{
+			name:   "float-all-nan",
+			dt:     arrow.PrimitiveTypes.Float64,
+			chunks: []interface{}{[]float64{nan}, []float64{nan}},
+			min:    scalar.NewFloat64Scalar(nan), max: scalar.NewFloat64Scalar(nan),
+		},
+		{
+			name:   "float16-nan",
+			dt:     arrow.FixedWidthTypes.Float16,
+			chunks: []interface{}{[]float16.Num{half(nan), half(math.Ldexp(1, -24))}, []float16.Num{half(nan), half(-0.5), half(nan)}},
+			min:    scalar.NewFloat16Scalar(half(-0.5)), max: scalar.NewFloat16Scalar(float16.FromBits(0x0001)),
+		},
+		{
+			name:   "float16-inf",
+			dt:     arrow.FixedWidthTypes.Float16,
+			chunks: []interface{}{[]float16.Num{half(math.Inf(-1)), half(65504)}, []float16.Num{half(math.Inf(1))}},
+			min:    scalar.NewFloat16Scalar(half(math.Inf(-1))), max: scalar.NewFloat16Scalar(half(math.Inf(1))),
+		},
+		{
+			name:   "timestamp",
+			dt:     ts,
+			chunks: []interface{}{[]arrow.Timestamp{10, 5}, []arrow.Timestamp{20}},
+			min:    scalar.NewTimestampScalar(5, ts), max: scalar.NewTimestampScalar(20, ts),
+		},
+		{
+			name:   "decimal",
+			dt:     &arrow.Decimal128Type{Precision: 5, Scale: 1},
+			chunks: []interface{}{[]decimal128.Num{dec(-5), dec(3)}, []decimal128.Num{dec(-10)}},
+			min:    scalar.NewDecimal128Scalar(dec(-10), &arrow.Decimal128Type{Precision: 5, Scale: 1}),
+			max:    scalar.NewDecimal128Scalar(dec(3), &arrow.Decimal128Type{Precision: 5, Scale: 1}),
+		},
+		{
+			name:   "string",
+			dt:     arrow.BinaryTypes.String,
+			chunks: []interface{}{[]string{"b", "ab"}, []string{"ba", ""}},
+			min:    scalar.NewStringScalar(""), max: scalar.NewStringScalar("ba"),
+		},
+		{
+			name:   "boolean",
+			dt:     arrow.FixedWidthTypes.Boolean,
+			chunks: []interface{}{[]bool{true}, []bool{true}},
+			min:    scalar.NewBooleanScalar(true), max: scalar.NewBooleanScalar(true),
+		},
+		{
+			name:   "empty",
+			dt:     arrow.PrimitiveTypes.Int8,
+			chunks: []interface{}{[]int8{}},
+			min:    scalar.MakeNullScalar(arrow.PrimitiveTypes.Int8), max: scalar.MakeNullScalar(arrow.PrimitiveTypes.Int8),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			chunks := make([]array.Interface, len(tc.chunks))
+			for i, c := range tc.chunks {
+				chunks[i] = arrayOf(mem, tc.dt, c, nil)
+				defer chunks[i].Release()
+			}
+			chunked := array.NewChunked(tc.dt, chunks)
+			defer chunked.Release()
+			input := compute.NewDatum(chunked)
+			defer input.Release()
+
+			min, max, err := compute.MinMax(ctx, input, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertScalarEqual(t, tc.min, min)
+			assertScalarEqual(t, tc.max, max)
+		})
+	}
+}
+
+// aggregateOptionSets are the combinations of null handling options
+// exercised by the aggregation tests.
+var aggregateOptionSets = []struct {
+	name string
+	opts *compute.ScalarAggregateOptions
+}{
+	{"skip-min1", &compute.ScalarAggregateOptions{SkipNulls: true, MinCount: 1}},
+	{"skip-min0", &compute.ScalarAggregateOptions{SkipNulls: true}},
+	{"keep-min0", &compute.ScalarAggregateOptions{}},
+	{"skip-min3", &compute.ScalarAggregateOptions{SkipNulls: true, MinCount: 3}},
+	{"keep-min1", &compute.ScalarAggregateOptions{MinCount: 1}},
+}
+
+// aggregateOptionInputs are the inputs of the aggregation tests, as
+// integers and booleans.
+var aggregateOptionInputs = []struct {
+	name  string
+	ints  []int32
+	bools []bool
+	valid []bool
+}{
+	{"empty", []int32{}, []bool{}, nil},
+	{"all-null", []int32{0, 0}, []bool{false, false}, []bool{false, false}},
+	{"mixed", []int32{1, 0, 4}, []bool{true, false, true}, []bool{true, false, true}},
+	{"full", []int32{1, 2}, []bool{true, false}, nil},
+}
+
+func TestAggregateOptions(t *testing.T) {
+	// want holds the results of sum, mean, min, max, any and all for each
+	// input, and each set of options.
+	want := map[string]map[string]string{
+		"empty": {
+			"skip-min1": "null null null null null null",
+			"skip-min0": "0 null null null false true",
+			"keep-min0": "0 null null null false true",
+			"skip-min3": "null null null null null null",
+			"keep-min1": "null null null null null null",
+		},
+		"all-null": {
+			"skip-min1": "null null null null null null",
+			"skip-min0": "0 null null null false true",
+			"keep-min0": "null null null null null null",
+			"skip-min3": "null null null null null null",
+			"keep-min1": "null null null null null null",
+		},
+		"mixed": {
+			"skip-min1": "5 2.5 1 4 true true",
+			"skip-min0": "5 2.5 1 4 true true",
+			"keep-min0": "null null null null true null",
+			"skip-min3": "null null null null null null",
+			"keep-min1": "null null null null true null",
+		},
+		"full": {
+			"skip-min1": "3 1.5 1 2 true false",
+			"skip-min0": "3 1.5 1 2 true false",
+			"keep-min0": "3 1.5 1 2 true false",
+			"skip-min3": "null null null null null null",
+			"keep-min1": "3 1.5 1 2 true false",
+		},
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	for _, in := range aggregateOptionInputs {
+		ints := datumOf(mem, arrow.PrimitiveTypes.Int32, in.ints, in.valid, nil)
+		bools := datumOf(mem, arrow.FixedWidthTypes.Boolean, in.bools, in.valid, nil)
+		for _, set := range aggregateOptionSets {
+			t.Run(in.name+"-"+set.name, func(t *testing.T) {
+				got, err := aggregateResults(ctx, ints, bools, set.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if w := want[in.name][set.name]; got != w {
+					t.Fatalf("got %q, want %q", got, w)
+				}
+			})
+		}
+		ints.Release()
+		bools.Release()
+	}
+}
+
+// aggregateResults returns the results of sum, mean, min and max over ints,
+// and any and all over bools, separated by spaces.
+func aggregateResults(ctx context.Context, ints, bools compute.Datum, opts *compute.ScalarAggregateOptions) (string, error) {
+	sum, err := compute.Sum(ctx, ints, opts)
+	if err != nil {
+		return "", err
+	}
+	mean, err := compute.Mean(ctx, ints, opts)
+	if err != nil {
+		return "", err
+	}
+	min, max, err := compute.MinMax(ctx, ints, opts)
+	if err != nil {
+		return "", err
+	}
+	any, err := compute.Any(ctx, bools, opts)
+	if err != nil {
+		return "", err
+	}
+	all, err := compute.All(ctx, bools, opts)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(sum, mean, min, max, any, all), nil
+}
+
+func TestMinMaxNulls(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{-100, 2, 100, 1}, []bool{false, true, false, true})
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	min, max, err := compute.MinMax(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertScalarEqual(t, scalar.NewInt16Scalar(1), min)
+	assertScalarEqual(t, scalar.NewInt16Scalar(2), max)
+
+	min, max, err = compute.MinMax(ctx, input, &compute.ScalarAggregateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min.IsValid() || max.IsValid() {
+		t.Fatalf("invalid result: got=(%v, %v), want=(null, null)", min, max)
+	}
+}
+
+func TestCount(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	a := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 3}, []bool{true, false, true})
+	defer a.Release()
+	b := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{4, 5}, []bool{false, false})
+	defer b.Release()
+	chunked := array.NewChunked(arrow.PrimitiveTypes.Int32, []array.Interface{a, b})
+	defer chunked.Release()
+	input := compute.NewDatum(chunked)
+	defer input.Release()
+
+	for _, tc := range []struct {
+		opts *compute.CountOptions
+		want int64
+	}{
+		{nil, 2},
+		{&compute.CountOptions{Mode: compute.CountValid}, 2},
+		{&compute.CountOptions{Mode: compute.CountNull}, 3},
+		{&compute.CountOptions{Mode: compute.CountAll}, 5},
+	} {
+		got, err := compute.Count(ctx, input, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertScalarEqual(t, scalar.NewInt64Scalar(tc.want), got)
+	}
+}
+
+func TestSumChunked(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var chunks []array.Interface
+	for _, vals := range [][]uint8{{1, 2, 3}, {}, {250, 250}} {
+		arr := arrayOf(mem, arrow.PrimitiveTypes.Uint8, vals, nil)
+		defer arr.Release()
+		chunks = append(chunks, arr)
+	}
+	chunked := array.NewChunked(arrow.PrimitiveTypes.Uint8, chunks)
+	defer chunked.Release()
+	input := compute.NewDatum(chunked)
+	defer input.Release()
+
+	got, err := compute.Sum(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertScalarEqual(t, scalar.NewUint64Scalar(506), got)
+
+	mean, err := compute.Mean(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertScalarEqual(t, scalar.NewFloat64Scalar(506.0/5), mean)
+}
+
+func TestSumFloatAccuracy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	// values of very different magnitudes, which lose precision when
+	// summed naively.
+	var (
+		rng    = rand.New(rand.NewSource(0))
+		values = make([]float64, 100000)
+		ref    = new(big.Float).SetPrec(2048)
+		naive  float64
+	)
+	for i := range values {
+		values[i] = math.Ldexp(rng.Float64()-0.5, rng.Intn(80)-40)
+		if i%1000 == 0 {
+			values[i] = 1e20
+		}
+		ref.Add(ref, big.NewFloat(values[i]))
+		naive += values[i]
+	}
+	want, _ := ref.Float64()
+
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Float64, values, nil)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	got, err := compute.Sum(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := got.(*scalar.Float64).Value
+	if diff, naiveDiff := math.Abs(sum-want), math.Abs(naive-want); diff > naiveDiff || diff > math.Abs(want)*1e-15 {
+		t.Fatalf("inaccurate sum: got=%v, want=%v (naive=%v)", sum, want, naive)
+	}
+}
+
+// chunkedFloats returns a chunked float64 array of nchunks chunks of n
+// random values of very different magnitudes, with some nulls.
+func chunkedFloats(mem memory.Allocator, nchunks, n int) *array.Chunked {
+	rng := rand.New(rand.NewSource(0))
+	chunks := make([]array.Interface, nchunks)
+	for i := range chunks {
+		values := make([]float64, n)
+		valid := make([]bool, n)
+		for j := range values {
+			values[j] = math.Ldexp(rng.Float64()-0.5, rng.Intn(80)-40)
+			valid[j] = rng.Intn(10) != 0
+		}
+		chunks[i] = arrayOf(mem, arrow.PrimitiveTypes.Float64, values, valid)
+	}
+	defer releaseAll(chunks)
+	return array.NewChunked(arrow.PrimitiveTypes.Float64, chunks)
+}
+
+func TestAggregateConcurrency(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	floats := chunkedFloats(mem, 32, 1<<12)
+	defer floats.Release()
+
+	var ints []array.Interface
+	for i := 0; i < 32; i++ {
+		vals := make([]int64, 1<<12)
+		for j := range vals {
+			vals[j] = int64(i*len(vals)+j) - 1<<15
+		}
+		ints = append(ints, arrayOf(mem, arrow.PrimitiveTypes.Int64, vals, nil))
+	}
+	intsChunked := array.NewChunked(arrow.PrimitiveTypes.Int64, ints)
+	releaseAll(ints)
+	defer intsChunked.Release()
+
+	for _, chunked := range []*array.Chunked{floats, intsChunked} {
+		input := compute.NewDatum(chunked)
+		defer input.Release()
+
+		// the serial results are the references.
+		serial := compute.WithAggregateConcurrency(compute.WithAllocator(context.Background(), mem), 1)
+		sum, err := compute.Sum(serial, input, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mean, err := compute.Mean(serial, input, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		min, max, err := compute.MinMax(serial, input, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, n := range []int{0, 2, 4, 8, 64} {
+			ctx := compute.WithAggregateConcurrency(compute.WithAllocator(context.Background(), mem), n)
+			got, err := compute.Sum(ctx, input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertScalarEqual(t, sum, got)
+			got, err = compute.Mean(ctx, input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertScalarEqual(t, mean, got)
+			gotMin, gotMax, err := compute.MinMax(ctx, input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertScalarEqual(t, min, gotMin)
+			assertScalarEqual(t, max, gotMax)
+		}
+	}
+}
+
+func BenchmarkAggregateConcurrency(b *testing.B) {
+	mem := memory.NewGoAllocator()
+	chunked := chunkedFloats(mem, 32, 1<<16)
+	defer chunked.Release()
+	input := compute.NewDatum(chunked)
+	defer input.Release()
+
+	for _, n := range []int{1, 4, 8} {
+		ctx := compute.WithAggregateConcurrency(compute.WithAllocator(context.Background(), mem), n)
+		b.Run(fmt.Sprintf("sum-%d", n), func(b *testing.B) {
+			b.SetBytes(int64(chunked.Len()) * 8)
+			for i := 0; i < b.N; i++ {
+				if _, err := compute.Sum(ctx, input, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("minmax-%d", n), func(b *testing.B) {
+			b.SetBytes(int64(chunked.Len()) * 8)
+			for i := 0; i < b.N; i++ {
+				if _, _, err := compute.MinMax(ctx, input, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	const n = 1 << 20
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var (
+		ints   = make([]int64, n)
+		floats = make([]float64, n)
+		valid  = make([]bool, n)
+	)
+	for i := range ints {
+		ints[i], floats[i], valid[i] = int64(i), float64(i), i%10 != 0
+	}
+
+	for _, bc := range []struct {
+		name   string
+		dt     arrow.DataType
+		values interface{}
+		valid  []bool
+	}{
+		{"int64", arrow.PrimitiveTypes.Int64, ints, nil},
+		{"int64-nulls", arrow.PrimitiveTypes.Int64, ints, valid},
+		{"float64", arrow.PrimitiveTypes.Float64, floats, nil},
+		{"float64-nulls", arrow.PrimitiveTypes.Float64, floats, valid},
+	} {
+		input := datumOf(mem, bc.dt, bc.values, bc.valid, nil)
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(n * 8)
+			for i := 0; i < b.N; i++ {
+				if _, err := compute.Sum(ctx, input, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		input.Release()
+	}
+}
@@ -0,0 +1,498 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// ArithmeticOptions controls the behavior of the arithmetic functions.
+type ArithmeticOptions struct {
+	// CheckOverflow makes integer, decimal and temporal operations fail
+	// with ErrInvalid when the result overflows, instead of wrapping
+	// around. Floating point division by zero fails as well.
+	CheckOverflow bool
+}
+
+// Add returns the element-wise sum of left and right.
+//
+// The arithmetic functions accept arrays, chunked arrays and scalars of
+// numeric, decimal, timestamp and duration types. Scalars are broadcast
+// against arrays, and a null on either side produces a null. The inputs are
+// promoted to a common type as follows:
+//
+//   - integers of the same signedness promote to the widest of the two,
+//     e.g. int32+int64 gives int64;
+//   - a signed and an unsigned integer promote to a signed integer wide
+//     enough to hold both, up to int64, e.g. int8+uint8 gives int16;
+//   - an integer and a floating point number promote to the floating point
+//     type, and float32 with float64 gives float64;
+//   - integers combined with decimals are treated as decimals of scale 0,
+//     and decimals combined with floating point numbers as float64;
+//   - decimals follow the SQL rules: for addition and subtraction, the
+//     scale is max(s1, s2) and the precision max(p1-s1, p2-s2) + scale + 1;
+//     for multiplication the scale is s1+s2 and the precision p1+p2+1; for
+//     division the scale is max(4, s1+p2-s2+1) and the precision
+//     p1-s1+s2+scale. Precisions are capped to 38 digits;
+//   - temporal values of different units are converted to the finest unit.
+//     timestamp±duration gives a timestamp, timestamp-timestamp a duration,
+//     duration±duration and duration×integer a duration.
+//
+// Integer division truncates towards zero, and fails on division by zero.
+func Add(ctx context.Context, left, right Datum, opts *ArithmeticOptions) (Datum, error) {
+	return arithmetic(ctx, opAdd, left, right, opts)
+}
+
+// Subtract returns the element-wise difference of left and right.
+// See Add for the type promotion rules.
+func Subtract(ctx context.Context, left, right Datum, opts *ArithmeticOptions) (Datum, error) {
+	return arithmetic(ctx, opSub, left, right, opts)
+}
+
+// Multiply returns the element-wise product of left and right.
+// See Add for the type promotion rules.
+func Multiply(ctx context.Context, left, right Datum, opts *ArithmeticOptions) (Datum, error) {
+	return arithmetic(ctx, opMul, left, right, opts)
+}
+
+// Divide returns the element-wise quotient of left and right.
+// See Add for the type promotion rules.
+func Divide(ctx context.Context, left, right Datum, opts *ArithmeticOptions) (Datum, error) {
+	return arithmetic(ctx, opDiv, left, right, opts)
+}
+
+type arithOp int8
+
+const (
+	opAdd arithOp = iota
+	opSub
+	opMul
+	opDiv
+)
+
+func (op arithOp) String() string {
+	return [...]string{"add", "subtract", "multiply", "divide"}[op]
+}
+
+func arithmetic(ctx context.Context, op arithOp, left, right Datum, opts *ArithmeticOptions) (Datum, error) {
+	if opts == nil {
+		opts = &ArithmeticOptions{}
+	}
+	kernel, err := arithmeticKernel(op, left.DataType(), right.DataType(), opts.CheckOverflow)
+	if err != nil {
+		return nil, err
+	}
+	return execBinary(GetAllocator(ctx), left, right, kernel)
+}
+
+func isFloating(id arrow.Type) bool {
+	return id == arrow.FLOAT32 || id == arrow.FLOAT64
+}
+
+func isSigned(id arrow.Type) bool {
+	switch id {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return true
+	}
+	return false
+}
+
+func arithmeticKernel(op arithOp, lt, rt arrow.DataType, checked bool) (binaryKernel, error) {
+	var (
+		lid, rid = lt.ID(), rt.ID()
+		lnum     = isInteger(lid) || isFloating(lid)
+		rnum     = isInteger(rid) || isFloating(rid)
+	)
+	switch {
+	case lnum && rnum:
+		return numericKernel(op, promoteNumeric(lt, rt), checked), nil
+	case isDecimal(lid) && isFloating(rid), isFloating(lid) && isDecimal(rid):
+		return numericKernel(op, arrow.PrimitiveTypes.Float64, checked), nil
+	case isDecimal(lid) || isDecimal(rid):
+		ld, lok := asDecimal(lt)
+		rd, rok := asDecimal(rt)
+		if lok && rok {
+			return decimalKernel(op, ld, rd, checked)
+		}
+	case isTemporal(lid) || isTemporal(rid):
+		return temporalKernel(op, lt, rt, checked)
+	}
+	return nil, errArithNotImplemented(op, lt, rt)
+}
+
+// promoteNumeric returns the common type of two numeric types.
+func promoteNumeric(a, b arrow.DataType) arrow.DataType {
+	var (
+		aw = a.(arrow.FixedWidthDataType).BitWidth()
+		bw = b.(arrow.FixedWidthDataType).BitWidth()
+	)
+	switch {
+	case isFloating(a.ID()) || isFloating(b.ID()):
+		if a.ID() == arrow.FLOAT64 || b.ID() == arrow.FLOAT64 {
+			return arrow.PrimitiveTypes.Float64
+		}
+		return arrow.PrimitiveTypes.Float32
+	case isSigned(a.ID()) == isSigned(b.ID()):
+		if aw >= bw {
+			return a
+		}
+		return b
+	}
+
+	// mixed signedness: the signed result must hold all values of the
+	// unsigned operand.
+	if !isSigned(a.ID()) {
+		aw *= 2
+	} else {
+		bw *= 2
+	}
+	switch w := max(aw, bw); {
+	case w <= 8:
+		return arrow.PrimitiveTypes.Int8
+	case w <= 16:
+		return arrow.PrimitiveTypes.Int16
+	case w <= 32:
+		return arrow.PrimitiveTypes.Int32
+	}
+	return arrow.PrimitiveTypes.Int64
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// asDecimal returns the decimal type holding all values of dt, which must
+// be a decimal or an integer type.
+func asDecimal(dt arrow.DataType) (arrow.DataType, bool) {
+	var prec int32
+	switch dt.ID() {
+	case arrow.DECIMAL, arrow.DECIMAL256:
+		return dt, true
+	case arrow.INT8, arrow.UINT8:
+		prec = 3
+	case arrow.INT16, arrow.UINT16:
+		prec = 5
+	case arrow.INT32, arrow.UINT32:
+		prec = 10
+	case arrow.INT64:
+		prec = 19
+	case arrow.UINT64:
+		prec = 20
+	default:
+		return nil, false
+	}
+	return &arrow.Decimal128Type{Precision: prec, Scale: 0}, true
+}
+
+// binaryValidity returns the validity bitmap of the result of a binary
+// kernel over l and r, and its number of nulls.
+func binaryValidity(mem memory.Allocator, l, r operand, n int) (*memory.Buffer, int) {
+	switch {
+	case l.NullN() == 0 && r.NullN() == 0:
+		return nil, 0
+	case l.scalar && l.NullN() > 0, r.scalar && r.NullN() > 0:
+		return newBuffer(mem, int(bitutil.BytesForBits(int64(n)))), n
+	case l.scalar || l.NullN() == 0:
+		return copyValidity(mem, r), r.NullN()
+	case r.scalar || r.NullN() == 0:
+		return copyValidity(mem, l), l.NullN()
+	}
+
+	buf := bitutil.BitmapAndAlloc(mem, l.NullBitmapBytes(), r.NullBitmapBytes(), l.Data().Offset(), r.Data().Offset(), n, 0)
+	return buf, n - bitutil.CountSetBits(buf.Bytes(), 0, n)
+}
+
+// validFunc returns a function reporting whether the i-th value of a
+// result with the given validity bitmap is valid.
+func validFunc(validity *memory.Buffer) func(int) bool {
+	var bitmap []byte
+	if validity != nil {
+		bitmap = validity.Bytes()
+	}
+	return bitutil.NewOptionalBitIndexer(bitmap, 0).GetBit
+}
+
+func stride(n, out int) int {
+	if n == out {
+		return 1
+	}
+	return 0
+}
+
+func outLen(l, r operand) int {
+	if l.scalar {
+		return r.Len()
+	}
+	return l.Len()
+}
+
+// castOperand casts the operand to dt, keeping it flagged as a scalar.
+func castOperand(mem memory.Allocator, o operand, dt arrow.DataType, opts *CastOptions) (operand, error) {
+	arr, err := castArray(mem, o.Interface, dt, opts)
+	if err != nil {
+		return operand{}, err
+	}
+	return operand{arr, o.scalar}, nil
+}
+
+// reinterpret returns an array of type dt sharing the buffers of arr, which
+// must be a fixed width array with the same physical layout.
+func reinterpret(arr array.Interface, dt arrow.DataType) array.Interface {
+	d := arr.Data()
+	data := array.NewData(dt, d.Len(), d.Buffers(), nil, d.NullN(), d.Offset())
+	defer data.Release()
+	return array.MakeFromData(data)
+}
+
+func numericKernel(op arithOp, dt arrow.DataType, checked bool) binaryKernel {
+	return func(mem memory.Allocator, left, right operand) (array.Interface, error) {
+		l, err := castOperand(mem, left, dt, UnsafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer l.Release()
+		r, err := castOperand(mem, right, dt, UnsafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer r.Release()
+
+		n := outLen(l, r)
+		validity, nulls := binaryValidity(mem, l, r, n)
+		values := newBuffer(mem, n*byteWidth(dt.(arrow.FixedWidthDataType)))
+		if nulls < n {
+			err = arithNumeric(op, checked, dt, l.Interface, r.Interface, values.Bytes(), validFunc(validity))
+		}
+		if err != nil {
+			values.Release()
+			if validity != nil {
+				validity.Release()
+			}
+			return nil, err
+		}
+		return makeArray(dt, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+	}
+}
+
+// decimalType returns the type of the result of op over decimals of types
+// l and r. The result is a decimal256 if any of them is.
+func decimalType(op arithOp, l, r arrow.DataType) (arrow.DataType, error) {
+	id := arrow.DECIMAL
+	if l.ID() == arrow.DECIMAL256 || r.ID() == arrow.DECIMAL256 {
+		id = arrow.DECIMAL256
+	}
+	var (
+		lp, ls      = decimalParams(l)
+		rp, rs      = decimalParams(r)
+		prec, scale int32
+		maxPrec     = maxDecimalPrecision(id)
+	)
+	switch op {
+	case opAdd, opSub:
+		scale = maxInt32(ls, rs)
+		prec = maxInt32(lp-ls, rp-rs) + scale + 1
+	case opMul:
+		scale = ls + rs
+		prec = lp + rp + 1
+	case opDiv:
+		scale = maxInt32(4, ls+rp-rs+1)
+		prec = lp - ls + rs + scale
+	}
+	if prec > maxPrec {
+		prec = maxPrec
+	}
+	if scale > prec {
+		return nil, xerrors.Errorf("arrow/compute: scale of the result of %s(%v, %v) exceeds %d: %w", op, l, r, maxPrec, ErrInvalid)
+	}
+	return newDecimalType(id, prec, scale), nil
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func decimalKernel(op arithOp, lt, rt arrow.DataType, checked bool) (binaryKernel, error) {
+	dt, err := decimalType(op, lt, rt)
+	if err != nil {
+		return nil, err
+	}
+
+	// factors applied to the unscaled operands.
+	var (
+		lf, rf      *big.Int
+		_, lscale   = decimalParams(lt)
+		_, rscale   = decimalParams(rt)
+		prec, scale = decimalParams(dt)
+	)
+	switch op {
+	case opAdd, opSub:
+		lf, rf = pow10(scale-lscale), pow10(scale-rscale)
+	case opMul:
+		lf, rf = pow10(0), pow10(0)
+	case opDiv:
+		lf, rf = pow10(scale-lscale+rscale), pow10(0)
+	}
+
+	return func(mem memory.Allocator, left, right operand) (array.Interface, error) {
+		l, err := castOperand(mem, left, lt, SafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer l.Release()
+		r, err := castOperand(mem, right, rt, SafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer r.Release()
+
+		var (
+			n               = outLen(l, r)
+			validity, nulls = binaryValidity(mem, l, r, n)
+			valid           = validFunc(validity)
+			values, set     = newDecimalValues(mem, dt, n)
+			ls, rs          = stride(l.Len(), n), stride(r.Len(), n)
+			v               = new(big.Int)
+		)
+		for i := 0; i < n && nulls < n; i++ {
+			if !valid(i) {
+				continue
+			}
+			a := decimalValue(l.Interface, i*ls)
+			b := decimalValue(r.Interface, i*rs)
+			a.Mul(a, lf)
+			b.Mul(b, rf)
+			switch op {
+			case opAdd:
+				v.Add(a, b)
+			case opSub:
+				v.Sub(a, b)
+			case opMul:
+				v.Mul(a, b)
+			case opDiv:
+				if b.Sign() == 0 {
+					err = errDivideByZero(i)
+					break
+				}
+				v.Quo(a, b)
+			}
+			if err == nil && checked && !fitsPrecision(v, prec) {
+				err = errArithOverflow(op, i)
+			}
+			if err != nil {
+				values.Release()
+				if validity != nil {
+					validity.Release()
+				}
+				return nil, err
+			}
+			set(i, v)
+		}
+		return makeArray(dt, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+	}, nil
+}
+
+// finerUnit returns the finest of two time units.
+func finerUnit(a, b arrow.TimeUnit) arrow.TimeUnit {
+	if unitsPerSecond(a) > unitsPerSecond(b) {
+		return a
+	}
+	return b
+}
+
+func temporalKernel(op arithOp, lt, rt arrow.DataType, checked bool) (binaryKernel, error) {
+	var (
+		lid, rid = lt.ID(), rt.ID()
+		unit     = finerUnit(timeUnitOf(lt), timeUnitOf(rt))
+		lin, rin arrow.DataType
+		out      arrow.DataType
+		dur      = &arrow.DurationType{Unit: unit}
+	)
+
+	switch {
+	case lid == arrow.TIMESTAMP && rid == arrow.DURATION && (op == opAdd || op == opSub):
+		lin = &arrow.TimestampType{Unit: unit, TimeZone: timeZoneOf(lt)}
+		rin, out = dur, lin
+	case lid == arrow.DURATION && rid == arrow.TIMESTAMP && op == opAdd:
+		rin = &arrow.TimestampType{Unit: unit, TimeZone: timeZoneOf(rt)}
+		lin, out = dur, rin
+	case lid == arrow.TIMESTAMP && rid == arrow.TIMESTAMP && op == opSub:
+		if timeZoneOf(lt) != timeZoneOf(rt) {
+			return nil, xerrors.Errorf("arrow/compute: cannot subtract timestamps with different time zones (%v and %v): %w", lt, rt, ErrInvalid)
+		}
+		lin = &arrow.TimestampType{Unit: unit, TimeZone: timeZoneOf(lt)}
+		rin, out = lin, dur
+	case lid == arrow.DURATION && rid == arrow.DURATION && (op == opAdd || op == opSub):
+		lin, rin, out = dur, dur, dur
+	case lid == arrow.DURATION && isInteger(rid) && (op == opMul || op == opDiv):
+		lin, rin, out = lt, arrow.PrimitiveTypes.Int64, lt
+	case isInteger(lid) && rid == arrow.DURATION && op == opMul:
+		lin, rin, out = arrow.PrimitiveTypes.Int64, rt, rt
+	default:
+		return nil, errArithNotImplemented(op, lt, rt)
+	}
+
+	int64Kernel := numericKernel(op, arrow.PrimitiveTypes.Int64, checked)
+	return func(mem memory.Allocator, left, right operand) (array.Interface, error) {
+		l, err := castOperand(mem, left, lin, SafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer l.Release()
+		r, err := castOperand(mem, right, rin, SafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer r.Release()
+
+		li := operand{reinterpret(l, arrow.PrimitiveTypes.Int64), l.scalar}
+		defer li.Release()
+		ri := operand{reinterpret(r, arrow.PrimitiveTypes.Int64), r.scalar}
+		defer ri.Release()
+
+		res, err := int64Kernel(mem, li, ri)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Release()
+		return reinterpret(res, out), nil
+	}, nil
+}
+
+func errArithNotImplemented(op arithOp, lt, rt arrow.DataType) error {
+	return xerrors.Errorf("arrow/compute: %s is not implemented for %v and %v: %w", op, lt, rt, ErrNotImplemented)
+}
+
+func errArithOverflow(op arithOp, i int) error {
+	return xerrors.Errorf("arrow/compute: overflow in %s at row %d: %w", op, i, ErrInvalid)
+}
+
+func errDivideByZero(i int) error {
+	return xerrors.Errorf("arrow/compute: divide by zero at row %d: %w", i, ErrInvalid)
+}
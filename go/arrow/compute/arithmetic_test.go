@@ -0,0 +1,573 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+type arithFunc func(context.Context, compute.Datum, compute.Datum, *compute.ArithmeticOptions) (compute.Datum, error)
+
+func TestArithmetic(t *testing.T) {
+	var (
+		i8  = arrow.PrimitiveTypes.Int8
+		i16 = arrow.PrimitiveTypes.Int16
+		i32 = arrow.PrimitiveTypes.Int32
+		i64 = arrow.PrimitiveTypes.Int64
+		u8  = arrow.PrimitiveTypes.Uint8
+		u32 = arrow.PrimitiveTypes.Uint32
+		u64 = arrow.PrimitiveTypes.Uint64
+		f32 = arrow.PrimitiveTypes.Float32
+		f64 = arrow.PrimitiveTypes.Float64
+
+		checked = &compute.ArithmeticOptions{CheckOverflow: true}
+	)
+
+	for _, tc := range []struct {
+		name    string
+		fn      arithFunc
+		opts    *compute.ArithmeticOptions
+		lt      arrow.DataType
+		lhs     interface{}
+		lvalid  []bool
+		lscalar scalar.Scalar
+		rt      arrow.DataType
+		rhs     interface{}
+		rvalid  []bool
+		rscalar scalar.Scalar
+		wt      arrow.DataType
+		want    interface{}
+		wvalid  []bool
+		err     error
+	}{
+		{
+			name: "add-int32", fn: compute.Add,
+			lt: i32, lhs: []int32{1, 2, 3, 4},
+			rt: i32, rhs: []int32{10, 20, 30, 40},
+			wt: i32, want: []int32{11, 22, 33, 44},
+		},
+		{
+			name: "sub-nulls", fn: compute.Subtract,
+			lt: i64, lhs: []int64{1, 2, 3, 4}, lvalid: []bool{true, false, true, true},
+			rt: i64, rhs: []int64{10, 20, 30, 40}, rvalid: []bool{true, true, true, false},
+			wt: i64, want: []int64{-9, 0, -27, 0}, wvalid: []bool{true, false, true, false},
+		},
+		{
+			name: "mul-scalar-right", fn: compute.Multiply,
+			lt: u32, lhs: []uint32{1, 2, 3}, lvalid: []bool{true, false, true},
+			rscalar: scalar.NewUint32Scalar(3),
+			wt:      u32, want: []uint32{3, 0, 9}, wvalid: []bool{true, false, true},
+		},
+		{
+			name: "sub-scalar-left", fn: compute.Subtract,
+			lscalar: scalar.NewFloat64Scalar(1),
+			rt:      f64, rhs: []float64{0.5, 1, 2},
+			wt: f64, want: []float64{0.5, 0, -1},
+		},
+		{
+			name: "null-scalar", fn: compute.Add,
+			lt: i32, lhs: []int32{1, 2},
+			rscalar: scalar.MakeNullScalar(i32),
+			wt:      i32, want: []int32{0, 0}, wvalid: []bool{false, false},
+		},
+		{
+			name: "promote-signed", fn: compute.Add,
+			lt: i8, lhs: []int8{100, -100},
+			rt: i32, rhs: []int32{100, -100},
+			wt: i32, want: []int32{200, -200},
+		},
+		{
+			name: "promote-mixed", fn: compute.Add,
+			lt: i8, lhs: []int8{-1, 127},
+			rt: u8, rhs: []uint8{255, 255},
+			wt: i16, want: []int16{254, 382},
+		},
+		{
+			name: "promote-uint64", fn: compute.Add,
+			lt: i32, lhs: []int32{-1},
+			rt: u64, rhs: []uint64{2},
+			wt: i64, want: []int64{1},
+		},
+		{
+			name: "promote-float", fn: compute.Multiply,
+			lt: i64, lhs: []int64{3, 4},
+			rt: f32, rhs: []float32{0.5, 0.25},
+			wt: f32, want: []float32{1.5, 1},
+		},
+		{
+			name: "promote-float64", fn: compute.Add,
+			lt: f32, lhs: []float32{1.5},
+			rt: f64, rhs: []float64{2},
+			wt: f64, want: []float64{3.5},
+		},
+		{
+			name: "add-wraps", fn: compute.Add,
+			lt: i8, lhs: []int8{127, -128},
+			rt: i8, rhs: []int8{1, -1},
+			wt: i8, want: []int8{-128, 127},
+		},
+		{
+			name: "add-overflow", fn: compute.Add, opts: checked,
+			lt: i8, lhs: []int8{1, 127},
+			rt: i8, rhs: []int8{1, 1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "add-overflow-null", fn: compute.Add, opts: checked,
+			lt: i8, lhs: []int8{1, 127}, lvalid: []bool{true, false},
+			rt: i8, rhs: []int8{1, 1},
+			wt: i8, want: []int8{2, 0}, wvalid: []bool{true, false},
+		},
+		{
+			name: "sub-overflow-unsigned", fn: compute.Subtract, opts: checked,
+			lt: u32, lhs: []uint32{1},
+			rt: u32, rhs: []uint32{2},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "mul-overflow-int64", fn: compute.Multiply, opts: checked,
+			lt: i64, lhs: []int64{math.MaxInt64 / 2},
+			rt: i64, rhs: []int64{3},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "mul-overflow-uint64", fn: compute.Multiply, opts: checked,
+			lt: u64, lhs: []uint64{1 << 32},
+			rt: u64, rhs: []uint64{1 << 32},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "mul-checked", fn: compute.Multiply, opts: checked,
+			lt: i16, lhs: []int16{-181, 100},
+			rt: i16, rhs: []int16{181, -100},
+			wt: i16, want: []int16{-32761, -10000},
+		},
+		{
+			name: "div-int", fn: compute.Divide,
+			lt: i32, lhs: []int32{7, -7, 0, 5}, lvalid: []bool{true, true, true, false},
+			rt: i32, rhs: []int32{2, 2, 3, 0},
+			wt: i32, want: []int32{3, -3, 0, 0}, wvalid: []bool{true, true, true, false},
+		},
+		{
+			name: "div-by-zero", fn: compute.Divide,
+			lt: i32, lhs: []int32{1, 2},
+			rt: i32, rhs: []int32{1, 0},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "div-min", fn: compute.Divide, opts: checked,
+			lt: i64, lhs: []int64{math.MinInt64},
+			rscalar: scalar.NewInt64Scalar(-1),
+			err:     compute.ErrInvalid,
+		},
+		{
+			name: "div-float", fn: compute.Divide,
+			lt: f64, lhs: []float64{1, -1},
+			rt: f64, rhs: []float64{4, 0},
+			wt: f64, want: []float64{0.25, math.Inf(-1)},
+		},
+		{
+			name: "div-float-checked", fn: compute.Divide, opts: checked,
+			lt: f64, lhs: []float64{1, -1},
+			rt: f64, rhs: []float64{4, 0},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "length-mismatch", fn: compute.Add,
+			lt: i32, lhs: []int32{1, 2},
+			rt: i32, rhs: []int32{1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "not-implemented", fn: compute.Add,
+			lt: arrow.BinaryTypes.String, lhs: []string{"a"},
+			rt: i32, rhs: []int32{1},
+			err: compute.ErrNotImplemented,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			lhs := datumOf(mem, tc.lt, tc.lhs, tc.lvalid, tc.lscalar)
+			defer lhs.Release()
+			rhs := datumOf(mem, tc.rt, tc.rhs, tc.rvalid, tc.rscalar)
+			defer rhs.Release()
+
+			got, err := tc.fn(ctx, lhs, rhs, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got.Kind() != compute.KindArray {
+				t.Fatalf("invalid datum kind: got=%v, want=%v", got.Kind(), compute.KindArray)
+			}
+			want := arrayOf(mem, tc.wt, tc.want, tc.wvalid)
+			defer want.Release()
+			assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+// datumOf returns a scalar datum holding s if it is not nil, and an array
+// datum built from values otherwise.
+func datumOf(mem memory.Allocator, dt arrow.DataType, values interface{}, valid []bool, s scalar.Scalar) compute.Datum {
+	if s != nil {
+		return compute.NewDatum(s)
+	}
+	arr := arrayOf(mem, dt, values, valid)
+	defer arr.Release()
+	return compute.NewDatum(arr)
+}
+
+func TestArithmeticScalars(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := compute.Add(ctx,
+		compute.NewDatum(scalar.NewInt8Scalar(1)),
+		compute.NewDatum(scalar.NewInt16Scalar(2)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	s, ok := got.(*compute.ScalarDatum)
+	if !ok {
+		t.Fatalf("invalid datum kind: got=%v, want=%v", got.Kind(), compute.KindScalar)
+	}
+	if v, ok := s.Value.(*scalar.Int16); !ok || !v.Valid || v.Value != 3 {
+		t.Fatalf("invalid result: got=%v (%v), want=3 (int16)", s.Value, s.Value.DataType())
+	}
+}
+
+func TestArithmeticDecimal(t *testing.T) {
+	decimal := func(prec, scale int32) arrow.DataType {
+		return &arrow.Decimal128Type{Precision: prec, Scale: scale}
+	}
+	decimal256Type := func(prec, scale int32) arrow.DataType {
+		return &arrow.Decimal256Type{Precision: prec, Scale: scale}
+	}
+
+	for _, tc := range []struct {
+		name string
+		fn   arithFunc
+		opts *compute.ArithmeticOptions
+		lt   arrow.DataType
+		lhs  interface{}
+		rt   arrow.DataType
+		rhs  interface{}
+		wt   arrow.DataType
+		want interface{}
+		err  error
+	}{
+		{
+			// 1.23 + 4.5
+			name: "add", fn: compute.Add,
+			lt: decimal(5, 2), lhs: []decimal128.Num{dec(123)},
+			rt: decimal(4, 1), rhs: []decimal128.Num{dec(45)},
+			wt: decimal(6, 2), want: []decimal128.Num{dec(573)},
+		},
+		{
+			// 1.23 - 4
+			name: "sub-int", fn: compute.Subtract,
+			lt: decimal(5, 2), lhs: []decimal128.Num{dec(123)},
+			rt: arrow.PrimitiveTypes.Int8, rhs: []int8{4},
+			wt: decimal(6, 2), want: []decimal128.Num{dec(-277)},
+		},
+		{
+			// 1.5 * 2.25
+			name: "mul", fn: compute.Multiply,
+			lt: decimal(3, 1), lhs: []decimal128.Num{dec(15)},
+			rt: decimal(3, 2), rhs: []decimal128.Num{dec(225)},
+			wt: decimal(7, 3), want: []decimal128.Num{dec(3375)},
+		},
+		{
+			// 1 / 3
+			name: "div", fn: compute.Divide,
+			lt: decimal(5, 0), lhs: []decimal128.Num{dec(1)},
+			rt: decimal(5, 0), rhs: []decimal128.Num{dec(3)},
+			wt: decimal(11, 6), want: []decimal128.Num{dec(333333)},
+		},
+		{
+			name: "div-by-zero", fn: compute.Divide,
+			lt: decimal(5, 0), lhs: []decimal128.Num{dec(1)},
+			rt: decimal(5, 0), rhs: []decimal128.Num{dec(0)},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "overflow", fn: compute.Multiply, opts: &compute.ArithmeticOptions{CheckOverflow: true},
+			lt: decimal(38, 0), lhs: []decimal128.Num{decimal128.New(1<<62, 0)},
+			rt: decimal(38, 0), rhs: []decimal128.Num{decimal128.New(1<<62, 0)},
+			err: compute.ErrInvalid,
+		},
+		{
+			// 1.23 + 4.5, as a decimal256
+			name: "add-decimal256", fn: compute.Add,
+			lt: decimal(5, 2), lhs: []decimal128.Num{dec(123)},
+			rt: decimal256Type(4, 1), rhs: []decimal256.Num{dec256("45")},
+			wt: decimal256Type(6, 2), want: []decimal256.Num{dec256("573")},
+		},
+		{
+			// 10^37 * 10^37, past 128 bits
+			name: "mul-decimal256", fn: compute.Multiply, opts: &compute.ArithmeticOptions{CheckOverflow: true},
+			lt: decimal256Type(38, 0), lhs: []decimal256.Num{dec256("1" + strings.Repeat("0", 37))},
+			rt: decimal256Type(38, 0), rhs: []decimal256.Num{dec256("1" + strings.Repeat("0", 37))},
+			wt: decimal256Type(76, 0), want: []decimal256.Num{dec256("1" + strings.Repeat("0", 74))},
+		},
+		{
+			// 1 / 3 and -7 / 2
+			name: "div-decimal256-int", fn: compute.Divide,
+			lt: decimal256Type(5, 0), lhs: []decimal256.Num{dec256("1"), dec256("-7")},
+			rt: arrow.PrimitiveTypes.Int32, rhs: []int32{3, 2},
+			wt: decimal256Type(16, 11), want: []decimal256.Num{dec256("33333333333"), dec256("-350000000000")},
+		},
+		{
+			name: "overflow-decimal256", fn: compute.Multiply, opts: &compute.ArithmeticOptions{CheckOverflow: true},
+			lt: decimal256Type(76, 0), lhs: []decimal256.Num{dec256("1" + strings.Repeat("0", 40))},
+			rt: decimal256Type(76, 0), rhs: []decimal256.Num{dec256("1" + strings.Repeat("0", 40))},
+			err: compute.ErrInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			lhs := datumOf(mem, tc.lt, tc.lhs, nil, nil)
+			defer lhs.Release()
+			rhs := datumOf(mem, tc.rt, tc.rhs, nil, nil)
+			defer rhs.Release()
+
+			got, err := tc.fn(ctx, lhs, rhs, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, tc.wt, tc.want, nil)
+			defer want.Release()
+			assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestArithmeticTemporal(t *testing.T) {
+	var (
+		tsS  = &arrow.TimestampType{Unit: arrow.Second}
+		tsMs = &arrow.TimestampType{Unit: arrow.Millisecond}
+		durS = &arrow.DurationType{Unit: arrow.Second}
+		durM = &arrow.DurationType{Unit: arrow.Millisecond}
+	)
+
+	for _, tc := range []struct {
+		name string
+		fn   arithFunc
+		lt   arrow.DataType
+		lhs  interface{}
+		rt   arrow.DataType
+		rhs  interface{}
+		wt   arrow.DataType
+		want interface{}
+		err  error
+	}{
+		{
+			name: "ts+dur", fn: compute.Add,
+			lt: tsS, lhs: []arrow.Timestamp{10, 20},
+			rt: durM, rhs: []arrow.Duration{1500, -500},
+			wt: tsMs, want: []arrow.Timestamp{11500, 19500},
+		},
+		{
+			name: "dur+ts", fn: compute.Add,
+			lt: durS, lhs: []arrow.Duration{1},
+			rt: tsS, rhs: []arrow.Timestamp{10},
+			wt: tsS, want: []arrow.Timestamp{11},
+		},
+		{
+			name: "ts-ts", fn: compute.Subtract,
+			lt: tsMs, lhs: []arrow.Timestamp{10000},
+			rt: tsS, rhs: []arrow.Timestamp{4},
+			wt: durM, want: []arrow.Duration{6000},
+		},
+		{
+			name: "dur*int", fn: compute.Multiply,
+			lt: durS, lhs: []arrow.Duration{3},
+			rt: arrow.PrimitiveTypes.Int8, rhs: []int8{-2},
+			wt: durS, want: []arrow.Duration{-6},
+		},
+		{
+			name: "dur/int", fn: compute.Divide,
+			lt: durS, lhs: []arrow.Duration{7},
+			rt: arrow.PrimitiveTypes.Int32, rhs: []int32{2},
+			wt: durS, want: []arrow.Duration{3},
+		},
+		{
+			name: "ts-ts-zones", fn: compute.Subtract,
+			lt: tsS, lhs: []arrow.Timestamp{1},
+			rt: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}, rhs: []arrow.Timestamp{1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "ts+ts", fn: compute.Add,
+			lt: tsS, lhs: []arrow.Timestamp{1},
+			rt: tsS, rhs: []arrow.Timestamp{1},
+			err: compute.ErrNotImplemented,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			lhs := datumOf(mem, tc.lt, tc.lhs, nil, nil)
+			defer lhs.Release()
+			rhs := datumOf(mem, tc.rt, tc.rhs, nil, nil)
+			defer rhs.Release()
+
+			got, err := tc.fn(ctx, lhs, rhs, nil)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, tc.wt, tc.want, nil)
+			defer want.Release()
+			assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestArithmeticChunked(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	i32 := arrow.PrimitiveTypes.Int32
+	chunked := func(chunks ...[]int32) *array.Chunked {
+		arrs := make([]array.Interface, len(chunks))
+		for i, c := range chunks {
+			arrs[i] = arrayOf(mem, i32, c, nil)
+			defer arrs[i].Release()
+		}
+		return array.NewChunked(i32, arrs)
+	}
+
+	left := chunked([]int32{1, 2, 3}, []int32{4, 5})
+	defer left.Release()
+	right := chunked([]int32{10}, []int32{20, 30, 40, 50})
+	defer right.Release()
+	flat := arrayOf(mem, i32, []int32{100, 200, 300, 400, 500}, nil)
+	defer flat.Release()
+
+	for _, tc := range []struct {
+		name        string
+		left, right compute.Datum
+		want        []int32
+	}{
+		{"chunked-chunked", compute.NewDatum(left), compute.NewDatum(right), []int32{11, 22, 33, 44, 55}},
+		{"chunked-array", compute.NewDatum(left), compute.NewDatum(flat), []int32{101, 202, 303, 404, 505}},
+		{"scalar-chunked", compute.NewDatum(scalar.NewInt32Scalar(1)), compute.NewDatum(right), []int32{11, 21, 31, 41, 51}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.left.Release()
+			defer tc.right.Release()
+
+			got, err := compute.Add(ctx, tc.left, tc.right, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got.Kind() != compute.KindChunked {
+				t.Fatalf("invalid datum kind: got=%v, want=%v", got.Kind(), compute.KindChunked)
+			}
+			var values []int32
+			for _, c := range got.(*compute.ChunkedDatum).Value.Chunks() {
+				values = append(values, c.(*array.Int32).Int32Values()...)
+			}
+			if !reflect.DeepEqual(values, tc.want) {
+				t.Fatalf("invalid values: got=%v, want=%v", values, tc.want)
+			}
+		})
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	const n = 1 << 16
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values := make([]int64, n)
+	for i := range values {
+		values[i] = int64(i)
+	}
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int64, values, nil)
+	defer arr.Release()
+	datum := compute.NewDatum(arr)
+	defer datum.Release()
+
+	for _, bc := range []struct {
+		name string
+		opts *compute.ArithmeticOptions
+	}{
+		{"wrapping", nil},
+		{"checked", &compute.ArithmeticOptions{CheckOverflow: true}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(n * 8)
+			for i := 0; i < b.N; i++ {
+				res, err := compute.Add(ctx, datum, datum, bc.opts)
+				if err != nil {
+					b.Fatal(err)
+				}
+				res.Release()
+			}
+		})
+	}
+}
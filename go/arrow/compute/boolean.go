@@ -0,0 +1,233 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// And returns the element-wise logical AND of two boolean datums. A null
+// on either side produces a null; see KleeneAnd for the SQL semantics.
+func And(ctx context.Context, left, right Datum) (Datum, error) {
+	return logical(ctx, "and", left, right, bitutil.BitmapAnd)
+}
+
+// Or returns the element-wise logical OR of two boolean datums. A null
+// on either side produces a null; see KleeneOr for the SQL semantics.
+func Or(ctx context.Context, left, right Datum) (Datum, error) {
+	return logical(ctx, "or", left, right, bitutil.BitmapOr)
+}
+
+// Xor returns the element-wise logical XOR of two boolean datums. A null
+// on either side produces a null.
+func Xor(ctx context.Context, left, right Datum) (Datum, error) {
+	return logical(ctx, "xor", left, right, bitutil.BitmapXor)
+}
+
+// AndNot returns the element-wise logical AND of left and of the negation
+// of right. A null on either side produces a null.
+func AndNot(ctx context.Context, left, right Datum) (Datum, error) {
+	return logical(ctx, "and_not", left, right, bitutil.BitmapAndNot)
+}
+
+// KleeneAnd returns the element-wise logical AND of two boolean datums,
+// following the three-valued logic of SQL: false AND null is false, and
+// true AND null is null.
+func KleeneAnd(ctx context.Context, left, right Datum) (Datum, error) {
+	return kleene(ctx, "and_kleene", left, right, func(lv, lok, rv, rok uint64) (uint64, uint64) {
+		// known if both sides are, or if either side is a known false.
+		return lv & rv, lok&rok | lok&^lv | rok&^rv
+	})
+}
+
+// KleeneOr returns the element-wise logical OR of two boolean datums,
+// following the three-valued logic of SQL: true OR null is true, and
+// false OR null is null.
+func KleeneOr(ctx context.Context, left, right Datum) (Datum, error) {
+	return kleene(ctx, "or_kleene", left, right, func(lv, lok, rv, rok uint64) (uint64, uint64) {
+		// known if both sides are, or if either side is a known true.
+		return lv | rv, lok&rok | lok&lv | rok&rv
+	})
+}
+
+// Invert returns the element-wise logical NOT of a boolean datum. Nulls
+// are kept.
+func Invert(ctx context.Context, input Datum) (Datum, error) {
+	if err := checkBoolean("invert", input.DataType()); err != nil {
+		return nil, err
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		n := arr.Len()
+		values := newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		in := operand{arr, false}
+		bitmapWords(values.Bytes(), boolValues(in, n), nil, n, func(a, _ uint64) uint64 { return ^a })
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{copyValidity(mem, arr), values}, nil, arr.NullN()), nil
+	})
+}
+
+func checkBoolean(name string, dts ...arrow.DataType) error {
+	for _, dt := range dts {
+		if dt.ID() != arrow.BOOL {
+			return xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+		}
+	}
+	return nil
+}
+
+func logical(ctx context.Context, name string, left, right Datum, op func(left, right []byte, lOffset, rOffset int, out []byte, outOffset, length int)) (Datum, error) {
+	if err := checkBoolean(name, left.DataType(), right.DataType()); err != nil {
+		return nil, err
+	}
+	return execBinary(GetAllocator(ctx), left, right, func(mem memory.Allocator, l, r operand) (array.Interface, error) {
+		n := outLen(l, r)
+		validity, nulls := binaryValidity(mem, l, r, n)
+		values := newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		lv, loff := boolBits(l, n)
+		rv, roff := boolBits(r, n)
+		op(lv, rv, loff, roff, values.Bytes(), 0, n)
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+	})
+}
+
+func kleene(ctx context.Context, name string, left, right Datum, fn func(lv, lok, rv, rok uint64) (uint64, uint64)) (Datum, error) {
+	if err := checkBoolean(name, left.DataType(), right.DataType()); err != nil {
+		return nil, err
+	}
+	return execBinary(GetAllocator(ctx), left, right, func(mem memory.Allocator, l, r operand) (array.Interface, error) {
+		var (
+			n      = outLen(l, r)
+			nbytes = int(bitutil.BytesForBits(int64(n)))
+			values = newBuffer(mem, nbytes)
+			lv, rv = boolValues(l, n), boolValues(r, n)
+		)
+		if l.NullN() == 0 && r.NullN() == 0 {
+			bitmapWords(values.Bytes(), lv, rv, n, func(a, b uint64) uint64 {
+				v, _ := fn(a, ^uint64(0), b, ^uint64(0))
+				return v
+			})
+			return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{nil, values}, nil, 0), nil
+		}
+
+		var (
+			validity = newBuffer(mem, nbytes)
+			lok      = boolValidity(l, n)
+			rok      = boolValidity(r, n)
+			out      = values.Bytes()
+			outOk    = validity.Bytes()
+		)
+		i := 0
+		for ; i+8 <= nbytes; i += 8 {
+			v, ok := fn(word(lv[i:]), word(lok[i:]), word(rv[i:]), word(rok[i:]))
+			binary.LittleEndian.PutUint64(out[i:], v)
+			binary.LittleEndian.PutUint64(outOk[i:], ok)
+		}
+		for ; i < nbytes; i++ {
+			v, ok := fn(uint64(lv[i]), uint64(lok[i]), uint64(rv[i]), uint64(rok[i]))
+			out[i], outOk[i] = byte(v), byte(ok)
+		}
+		nulls := n - bitutil.CountSetBits(outOk, 0, n)
+		if nulls == 0 {
+			validity.Release()
+			validity = nil
+		}
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+	})
+}
+
+func word(b []byte) uint64 { return binary.LittleEndian.Uint64(b) }
+
+// bitmapWords writes fn(a, b) into the n bits of out, a word at a time. b
+// may be nil for unary functions.
+func bitmapWords(out, a, b []byte, n int, fn func(a, b uint64) uint64) {
+	var (
+		nbytes = int(bitutil.BytesForBits(int64(n)))
+		i      = 0
+	)
+	for ; i+8 <= nbytes; i += 8 {
+		var bw uint64
+		if b != nil {
+			bw = word(b[i:])
+		}
+		binary.LittleEndian.PutUint64(out[i:], fn(word(a[i:]), bw))
+	}
+	for ; i < nbytes; i++ {
+		var bw uint64
+		if b != nil {
+			bw = uint64(b[i])
+		}
+		out[i] = byte(fn(uint64(a[i]), bw))
+	}
+}
+
+// boolBits returns the bitmap holding the n values of the boolean operand
+// o, and the offset of its first value. Scalars are broadcast.
+func boolBits(o operand, n int) ([]byte, int) {
+	if o.scalar || n == 0 {
+		return boolValues(o, n), 0
+	}
+	return o.Data().Buffers()[1].Bytes(), o.Data().Offset()
+}
+
+// boolValues returns the n bits of the values of the boolean operand o,
+// starting at bit zero. Scalars are broadcast.
+func boolValues(o operand, n int) []byte {
+	switch {
+	case o.scalar:
+		return broadcastBit(o.IsValid(0) && o.Interface.(*array.Boolean).Value(0), n)
+	case n == 0:
+		return nil
+	}
+	return bitmapAt(o.Data().Buffers()[1].Bytes(), o.Data().Offset(), n)
+}
+
+// boolValidity returns the n bits of the validity bitmap of o, starting at
+// bit zero. Scalars are broadcast.
+func boolValidity(o operand, n int) []byte {
+	switch {
+	case o.scalar:
+		return broadcastBit(o.IsValid(0), n)
+	case o.NullN() == 0:
+		return broadcastBit(true, n)
+	case len(o.NullBitmapBytes()) == 0:
+		return broadcastBit(false, n)
+	}
+	return bitmapAt(o.NullBitmapBytes(), o.Data().Offset(), n)
+}
+
+// bitmapAt returns n bits of bitmap starting at offset, copying them only
+// if offset is not byte-aligned.
+func bitmapAt(bitmap []byte, offset, n int) []byte {
+	if offset%8 == 0 {
+		return bitmap[offset/8 : offset/8+int(bitutil.BytesForBits(int64(n)))]
+	}
+	return alignedBitmap(bitmap, offset, n)
+}
+
+func broadcastBit(set bool, n int) []byte {
+	out := make([]byte, bitutil.BytesForBits(int64(n)))
+	if set {
+		memory.Set(out, 0xff)
+	}
+	return out
+}
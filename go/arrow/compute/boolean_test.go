@@ -0,0 +1,317 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// tri is a three-valued boolean used as the reference for the kernels.
+type tri int8
+
+const (
+	triFalse tri = iota
+	triTrue
+	triNull
+)
+
+var tris = [...]tri{triFalse, triTrue, triNull}
+
+func triOf(b bool) tri {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+var logicalRefs = []struct {
+	name string
+	fn   func(context.Context, compute.Datum, compute.Datum) (compute.Datum, error)
+	ref  func(a, b tri) tri
+}{
+	{"and", compute.And, nullPropagating(func(a, b bool) bool { return a && b })},
+	{"or", compute.Or, nullPropagating(func(a, b bool) bool { return a || b })},
+	{"xor", compute.Xor, nullPropagating(func(a, b bool) bool { return a != b })},
+	{"and-not", compute.AndNot, nullPropagating(func(a, b bool) bool { return a && !b })},
+	{"kleene-and", compute.KleeneAnd, func(a, b tri) tri {
+		switch {
+		case a == triFalse || b == triFalse:
+			return triFalse
+		case a == triNull || b == triNull:
+			return triNull
+		}
+		return triTrue
+	}},
+	{"kleene-or", compute.KleeneOr, func(a, b tri) tri {
+		switch {
+		case a == triTrue || b == triTrue:
+			return triTrue
+		case a == triNull || b == triNull:
+			return triNull
+		}
+		return triFalse
+	}},
+}
+
+func nullPropagating(fn func(a, b bool) bool) func(a, b tri) tri {
+	return func(a, b tri) tri {
+		if a == triNull || b == triNull {
+			return triNull
+		}
+		return triOf(fn(a == triTrue, b == triTrue))
+	}
+}
+
+// triArray returns a boolean array holding values, preceded by offset
+// values which are sliced away.
+func triArray(mem memory.Allocator, values []tri, offset int) array.Interface {
+	var (
+		vals  = make([]bool, offset+len(values))
+		valid = make([]bool, offset+len(values))
+	)
+	for i := 0; i < offset; i++ {
+		vals[i], valid[i] = i%2 == 0, i%3 != 0
+	}
+	for i, v := range values {
+		vals[offset+i], valid[offset+i] = v == triTrue, v != triNull
+	}
+	arr := arrayOf(mem, arrow.FixedWidthTypes.Boolean, vals, valid)
+	defer arr.Release()
+	return array.NewSlice(arr, int64(offset), int64(len(vals)))
+}
+
+func triScalar(v tri) scalar.Scalar {
+	if v == triNull {
+		return scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean)
+	}
+	return scalar.NewBooleanScalar(v == triTrue)
+}
+
+func assertTris(t *testing.T, want []tri, got compute.Datum) {
+	t.Helper()
+	arr := got.(*compute.ArrayDatum).Value.(*array.Boolean)
+	if arr.Len() != len(want) {
+		t.Fatalf("invalid length: got=%d, want=%d", arr.Len(), len(want))
+	}
+	nulls := 0
+	for i, w := range want {
+		g := triNull
+		if arr.IsValid(i) {
+			g = triOf(arr.Value(i))
+		}
+		if g != w {
+			t.Fatalf("invalid value at %d: got=%d, want=%d", i, g, w)
+		}
+		if w == triNull {
+			nulls++
+		}
+	}
+	if arr.NullN() != nulls {
+		t.Fatalf("invalid null count: got=%d, want=%d", arr.NullN(), nulls)
+	}
+}
+
+func TestLogicalTruthTables(t *testing.T) {
+	// the 3x3 truth table is repeated to span several words.
+	const reps = 10
+	var left, right []tri
+	for i := 0; i < reps; i++ {
+		for _, a := range tris {
+			for _, b := range tris {
+				left = append(left, a)
+				right = append(right, b)
+			}
+		}
+	}
+
+	for _, tc := range logicalRefs {
+		for _, offsets := range [][2]int{{0, 0}, {1, 0}, {0, 7}, {8, 8}, {9, 3}, {63, 64}, {65, 1}} {
+			t.Run(fmt.Sprintf("%s/offsets=%d,%d", tc.name, offsets[0], offsets[1]), func(t *testing.T) {
+				mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+				defer mem.AssertSize(t, 0)
+				ctx := compute.WithAllocator(context.Background(), mem)
+
+				l := triArray(mem, left, offsets[0])
+				defer l.Release()
+				r := triArray(mem, right, offsets[1])
+				defer r.Release()
+				ld, rd := compute.NewDatum(l), compute.NewDatum(r)
+				defer ld.Release()
+				defer rd.Release()
+
+				got, err := tc.fn(ctx, ld, rd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+
+				want := make([]tri, len(left))
+				for i := range want {
+					want[i] = tc.ref(left[i], right[i])
+				}
+				assertTris(t, want, got)
+			})
+		}
+	}
+}
+
+func TestLogicalScalars(t *testing.T) {
+	values := []tri{triFalse, triTrue, triNull, triTrue, triFalse}
+
+	for _, tc := range logicalRefs {
+		for _, s := range tris {
+			t.Run(fmt.Sprintf("%s/scalar=%d", tc.name, s), func(t *testing.T) {
+				mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+				defer mem.AssertSize(t, 0)
+				ctx := compute.WithAllocator(context.Background(), mem)
+
+				arr := triArray(mem, values, 3)
+				defer arr.Release()
+				ad := compute.NewDatum(arr)
+				defer ad.Release()
+
+				got, err := tc.fn(ctx, ad, compute.NewDatum(triScalar(s)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+				want := make([]tri, len(values))
+				for i, v := range values {
+					want[i] = tc.ref(v, s)
+				}
+				assertTris(t, want, got)
+
+				rev, err := tc.fn(ctx, compute.NewDatum(triScalar(s)), ad)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer rev.Release()
+				for i, v := range values {
+					want[i] = tc.ref(s, v)
+				}
+				assertTris(t, want, rev)
+			})
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values := []tri{triFalse, triTrue, triNull, triTrue, triFalse, triNull, triTrue, triTrue, triFalse, triFalse}
+	arr := triArray(mem, values, 5)
+	defer arr.Release()
+	ad := compute.NewDatum(arr)
+	defer ad.Release()
+
+	got, err := compute.Invert(ctx, ad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	want := make([]tri, len(values))
+	for i, v := range values {
+		want[i] = v
+		if v != triNull {
+			want[i] = triOf(v == triFalse)
+		}
+	}
+	assertTris(t, want, got)
+
+	s, err := compute.Invert(ctx, compute.NewDatum(scalar.NewBooleanScalar(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := s.(*compute.ScalarDatum).Value.(*scalar.Boolean); !v.Valid || v.Value {
+		t.Fatalf("invalid result: got=%v, want=false", v)
+	}
+}
+
+func TestLogicalInvalidType(t *testing.T) {
+	ctx := context.Background()
+	_, err := compute.And(ctx, compute.NewDatum(scalar.NewBooleanScalar(true)), compute.NewDatum(scalar.NewInt8Scalar(1)))
+	if !xerrors.Is(err, compute.ErrNotImplemented) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, compute.ErrNotImplemented)
+	}
+}
+
+func BenchmarkKleeneAnd(b *testing.B) {
+	const n = 1 << 20
+	var (
+		mem   = memory.NewGoAllocator()
+		ctx   = compute.WithAllocator(context.Background(), mem)
+		rng   = rand.New(rand.NewSource(0))
+		vals  = make([][]bool, 2)
+		valid = make([][]bool, 2)
+		arrs  = make([]*array.Boolean, 2)
+	)
+	for i := range arrs {
+		vals[i], valid[i] = make([]bool, n), make([]bool, n)
+		for j := 0; j < n; j++ {
+			vals[i][j], valid[i][j] = rng.Intn(2) == 0, rng.Intn(10) != 0
+		}
+		arrs[i] = arrayOf(mem, arrow.FixedWidthTypes.Boolean, vals[i], valid[i]).(*array.Boolean)
+		defer arrs[i].Release()
+	}
+	l, r := compute.NewDatum(arrs[0]), compute.NewDatum(arrs[1])
+	defer l.Release()
+	defer r.Release()
+
+	b.Run("kernel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			out, err := compute.KleeneAnd(ctx, l, r)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+		}
+	})
+
+	b.Run("per-bit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bldr := array.NewBooleanBuilder(mem)
+			bldr.Reserve(n)
+			for j := 0; j < n; j++ {
+				lv, lok := arrs[0].Value(j), arrs[0].IsValid(j)
+				rv, rok := arrs[1].Value(j), arrs[1].IsValid(j)
+				switch {
+				case (lok && !lv) || (rok && !rv):
+					bldr.UnsafeAppend(false)
+				case lok && rok:
+					bldr.UnsafeAppend(true)
+				default:
+					bldr.UnsafeAppendBoolToBitmap(false)
+				}
+			}
+			bldr.NewArray().Release()
+			bldr.Release()
+		}
+	})
+}
@@ -0,0 +1,561 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// CastOptions controls the behavior of CastArray and CastRecord.
+//
+// The zero value performs a safe cast: any value which cannot be represented
+// exactly in the target type produces an error naming the offending row.
+type CastOptions struct {
+	// AllowIntOverflow allows integer values to wrap around when they do not
+	// fit in the target type.
+	AllowIntOverflow bool
+	// SaturateIntOverflow clamps integer values which do not fit in the
+	// target type to its range, rather than failing or wrapping around.
+	// Floating point values are clamped as well, but NaNs still overflow.
+	SaturateIntOverflow bool
+	// AllowTimeTruncate allows temporal values to lose precision when cast to
+	// a coarser unit, e.g. from milliseconds to seconds.
+	AllowTimeTruncate bool
+	// AllowTimeOverflow allows temporal values to overflow when cast to a
+	// finer unit.
+	AllowTimeOverflow bool
+	// AllowDecimalTruncate allows decimal values to lose digits when cast to
+	// a smaller scale or precision, or to an integer.
+	AllowDecimalTruncate bool
+	// AllowFloatTruncate allows floating point values to lose their
+	// fractional part when cast to an integer, and integers to lose precision
+	// when cast to a floating point type.
+	AllowFloatTruncate bool
+	// AllowInvalidUtf8 skips the UTF-8 validation of binary values cast to
+	// strings.
+	AllowInvalidUtf8 bool
+
+	// TimestampLayout is the time.Parse layout used to convert between
+	// strings and timestamps. If empty, ISO-8601 formatted values are
+	// accepted, with or without a zone offset, and timestamps are formatted
+	// as "2006-01-02 15:04:05" followed by as many fractional digits as the
+	// unit requires and the zone offset for zoned timestamps.
+	TimestampLayout string
+	// Strptime, if set, parses strings cast to timestamps with its format
+	// instead of TimestampLayout, and makes the strings which cannot be
+	// parsed null if its ErrorIsNull is set.
+	Strptime *StrptimeOptions
+	// DateLayout is the layout used to convert between strings and dates.
+	// It defaults to "2006-01-02".
+	DateLayout string
+	// TimeLayout is the layout used to convert between strings and times of
+	// day. It defaults to "15:04:05" followed by as many fractional digits
+	// as the unit requires.
+	TimeLayout string
+}
+
+// SafeCastOptions returns options which make a cast fail rather than lose
+// or corrupt any value.
+func SafeCastOptions() *CastOptions { return &CastOptions{} }
+
+// UnsafeCastOptions returns options allowing all lossy conversions.
+func UnsafeCastOptions() *CastOptions {
+	return &CastOptions{
+		AllowIntOverflow:     true,
+		AllowTimeTruncate:    true,
+		AllowTimeOverflow:    true,
+		AllowDecimalTruncate: true,
+		AllowFloatTruncate:   true,
+		AllowInvalidUtf8:     true,
+	}
+}
+
+// CastArray returns a new array holding the values of arr converted to the
+// data type toType. Null values stay null. If opts is nil, SafeCastOptions
+// are used.
+//
+// Numeric, boolean, string, binary, decimal and temporal types can be cast
+// to each other where the conversion is meaningful. String and binary views
+// convert to and from the other types as strings and binaries. Dictionary-encoded
+// arrays are decoded and their values cast to toType, and casting to a
+// dictionary type casts the values to its value type and dictionary-encodes
+// them with its index type. ErrNotImplemented is
+// returned for unsupported conversions, and ErrInvalid for values that
+// cannot be converted under the given options.
+//
+// The returned array must be Release()'d after use.
+func CastArray(ctx context.Context, arr array.Interface, toType arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	if opts == nil {
+		opts = SafeCastOptions()
+	}
+	return castArray(GetAllocator(ctx), arr, toType, opts)
+}
+
+// CastRecord casts each column of rec to the type of the corresponding field
+// of schema, which must have as many fields as rec has columns.
+//
+// The returned record must be Release()'d after use.
+func CastRecord(ctx context.Context, rec array.Record, schema *arrow.Schema, opts *CastOptions) (array.Record, error) {
+	if int64(len(schema.Fields())) != rec.NumCols() {
+		return nil, xerrors.Errorf("arrow/compute: cannot cast record with %d columns to schema with %d fields: %w",
+			rec.NumCols(), len(schema.Fields()), ErrInvalid)
+	}
+	if opts == nil {
+		opts = SafeCastOptions()
+	}
+
+	mem := GetAllocator(ctx)
+	cols := make([]array.Interface, rec.NumCols())
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+
+	for i, col := range rec.Columns() {
+		out, err := castArray(mem, col, schema.Field(i).Type, opts)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/compute: could not cast column %q: %w", rec.ColumnName(i), err)
+		}
+		cols[i] = out
+	}
+	return array.NewRecord(schema, cols, rec.NumRows()), nil
+}
+
+// CastTable casts the columns of tbl to the types of the corresponding
+// fields of schema, which must have as many fields as tbl has columns.
+// Columns already of the right type are shared with tbl.
+//
+// The returned table must be Release()'d after use.
+func CastTable(ctx context.Context, tbl array.Table, schema *arrow.Schema, opts *CastOptions) (array.Table, error) {
+	if int64(len(schema.Fields())) != tbl.NumCols() {
+		return nil, xerrors.Errorf("arrow/compute: cannot cast table with %d columns to schema with %d fields: %w",
+			tbl.NumCols(), len(schema.Fields()), ErrInvalid)
+	}
+	if opts == nil {
+		opts = SafeCastOptions()
+	}
+
+	mem := GetAllocator(ctx)
+	cols := make([]array.Column, 0, tbl.NumCols())
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	for i := 0; i < int(tbl.NumCols()); i++ {
+		col := tbl.Column(i)
+		to := schema.Field(i).Type
+		chunks := make([]array.Interface, 0, len(col.Data().Chunks()))
+		for _, chunk := range col.Data().Chunks() {
+			out, err := castArray(mem, chunk, to, opts)
+			if err != nil {
+				releaseArrays(chunks)
+				return nil, xerrors.Errorf("arrow/compute: could not cast column %q: %w", col.Name(), err)
+			}
+			chunks = append(chunks, out)
+		}
+		chunked := array.NewChunked(to, chunks)
+		releaseArrays(chunks)
+		cols = append(cols, *array.NewColumn(schema.Field(i), chunked))
+		chunked.Release()
+	}
+	return array.NewTable(schema, cols, tbl.NumRows()), nil
+}
+
+func castArray(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	from := arr.DataType()
+	switch {
+	case arrow.TypeEqual(from, to):
+		arr.Retain()
+		return arr, nil
+	case from.ID() == arrow.NULL:
+		return makeNullArray(mem, to, arr.Len()), nil
+	case from.ID() == arrow.DICTIONARY:
+		values, err := decodeDictionary(mem, arr.(*array.Dictionary))
+		if err != nil {
+			return nil, err
+		}
+		defer values.Release()
+		return castArray(mem, values, to, opts)
+	case isView(from.ID()) && !isView(to.ID()) && to.ID() != arrow.STRING && to.ID() != arrow.BINARY:
+		// the other types only convert from regular strings and binaries.
+		flat := arrow.DataType(arrow.BinaryTypes.Binary)
+		if from.ID() == arrow.STRING_VIEW {
+			flat = arrow.BinaryTypes.String
+		}
+		values, err := castToString(mem, arr, flat, opts)
+		if err != nil {
+			return nil, err
+		}
+		defer values.Release()
+		return castArray(mem, values, to, opts)
+	}
+
+	switch to.ID() {
+	case arrow.DICTIONARY:
+		return castToDictionary(mem, arr, to.(*arrow.DictionaryType), opts)
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64,
+		arrow.FLOAT32, arrow.FLOAT64:
+		return castToNumeric(mem, arr, to, opts)
+	case arrow.FLOAT16:
+		return castToFloat16(mem, arr, to, opts)
+	case arrow.BOOL:
+		return castToBoolean(mem, arr, opts)
+	case arrow.STRING, arrow.BINARY, arrow.STRING_VIEW, arrow.BINARY_VIEW:
+		return castToString(mem, arr, to, opts)
+	case arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64, arrow.TIMESTAMP, arrow.DURATION:
+		return castToTemporal(mem, arr, to, opts)
+	case arrow.DECIMAL, arrow.DECIMAL256:
+		return castToDecimal(mem, arr, to, opts)
+	}
+	return nil, errCastNotImplemented(from, to)
+}
+
+// castToDictionary casts the values of arr to the value type of dt and
+// dictionary-encodes them.
+func castToDictionary(mem memory.Allocator, arr array.Interface, dt *arrow.DictionaryType, opts *CastOptions) (array.Interface, error) {
+	values, err := castArray(mem, arr, dt.ValueType, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+
+	ctx := WithAllocator(context.Background(), mem)
+	encoded, err := DictionaryEncode(ctx, &ArrayDatum{Value: values}, &DictionaryEncodeOptions{IndexType: dt.IndexType})
+	if err != nil {
+		return nil, err
+	}
+	defer encoded.Release()
+	dict := encoded.(*ArrayDatum).Value.(*array.Dictionary)
+	return array.NewDictionaryArray(dt, dict.Indices(), dict.Dictionary()), nil
+}
+
+type numKind int8
+
+const (
+	kindInt numKind = iota
+	kindUint
+	kindFloat
+)
+
+// numericValues holds the values of an array widened to int64, uint64 or
+// float64 depending on its kind.
+type numericValues struct {
+	kind   numKind
+	ints   []int64
+	uints  []uint64
+	floats []float64
+}
+
+func isInteger(id arrow.Type) bool {
+	switch id {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return true
+	}
+	return false
+}
+
+func isView(id arrow.Type) bool {
+	return id == arrow.STRING_VIEW || id == arrow.BINARY_VIEW
+}
+
+func isTemporal(id arrow.Type) bool {
+	switch id {
+	case arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64, arrow.TIMESTAMP, arrow.DURATION:
+		return true
+	}
+	return false
+}
+
+func castToNumeric(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	var (
+		src numericValues
+		err error
+	)
+
+	from := arr.DataType()
+	switch from.ID() {
+	case arrow.BOOL:
+		a := arr.(*array.Boolean)
+		src = numericValues{kind: kindInt, ints: make([]int64, a.Len())}
+		for i := range src.ints {
+			if a.Value(i) {
+				src.ints[i] = 1
+			}
+		}
+	case arrow.FLOAT16:
+		vs := arr.(*array.Float16).Values()
+		src = numericValues{kind: kindFloat, floats: make([]float64, len(vs))}
+		for i, v := range vs {
+			src.floats[i] = float64(v.Float32())
+		}
+	case arrow.STRING, arrow.BINARY:
+		src, err = parseNumeric(arr, to)
+	case arrow.DECIMAL, arrow.DECIMAL256:
+		src, err = decimalToNumeric(arr, to, opts)
+	default:
+		switch {
+		case isTemporal(from.ID()):
+			src = numericValues{kind: kindInt, ints: temporalValues(arr)}
+		default:
+			var ok bool
+			if src, ok = widenNumeric(arr); !ok {
+				return nil, errCastNotImplemented(from, to)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return castNumericTo(mem, arr, src, to, opts)
+}
+
+func castToFloat16(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	f64, err := castToNumeric(mem, arr, arrow.PrimitiveTypes.Float64, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer f64.Release()
+
+	vs := f64.(*array.Float64).Float64Values()
+	values := newBuffer(mem, arrow.Float16Traits.BytesRequired(len(vs)))
+	out := arrow.Float16Traits.CastFromBytes(values.Bytes())
+	for i, v := range vs {
+		out[i] = float16.FromFloat64(v)
+	}
+	return makeArray(to, len(vs), []*memory.Buffer{copyValidity(mem, arr), values}, nil, arr.NullN()), nil
+}
+
+func castToBoolean(mem memory.Allocator, arr array.Interface, opts *CastOptions) (array.Interface, error) {
+	from := arr.DataType()
+	bldr := array.NewBooleanBuilder(mem)
+	defer bldr.Release()
+	bldr.Reserve(arr.Len())
+
+	switch from.ID() {
+	case arrow.STRING, arrow.BINARY:
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsNull(i) {
+				bldr.AppendNull()
+				continue
+			}
+			s := stringValue(arr, i)
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, errCastParse(arr, i, s, arrow.FixedWidthTypes.Boolean)
+			}
+			bldr.Append(v)
+		}
+	case arrow.DECIMAL, arrow.DECIMAL256:
+		for i := 0; i < arr.Len(); i++ {
+			if arr.IsNull(i) {
+				bldr.AppendNull()
+				continue
+			}
+			bldr.Append(decimalValue(arr, i).Sign() != 0)
+		}
+	default:
+		if !isInteger(from.ID()) && from.ID() != arrow.FLOAT16 &&
+			from.ID() != arrow.FLOAT32 && from.ID() != arrow.FLOAT64 {
+			return nil, errCastNotImplemented(from, arrow.FixedWidthTypes.Boolean)
+		}
+		f64, err := castToNumeric(mem, arr, arrow.PrimitiveTypes.Float64, UnsafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer f64.Release()
+		for i, v := range f64.(*array.Float64).Float64Values() {
+			if arr.IsNull(i) {
+				bldr.AppendNull()
+				continue
+			}
+			bldr.Append(v != 0)
+		}
+	}
+	return bldr.NewArray(), nil
+}
+
+// stringValue returns the i-th value of a String or Binary array.
+func stringValue(arr array.Interface, i int) string {
+	switch a := arr.(type) {
+	case *array.String:
+		return a.Value(i)
+	case *array.Binary:
+		return a.ValueString(i)
+	}
+	panic("arrow/compute: invalid string array type " + arr.DataType().Name())
+}
+
+func parseNumeric(arr array.Interface, to arrow.DataType) (numericValues, error) {
+	var (
+		n   = arr.Len()
+		out numericValues
+	)
+
+	bits := 64
+	if fw, ok := to.(arrow.FixedWidthDataType); ok {
+		bits = fw.BitWidth()
+	}
+
+	switch to.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		out = numericValues{kind: kindInt, ints: make([]int64, n)}
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		out = numericValues{kind: kindUint, uints: make([]uint64, n)}
+	default:
+		out = numericValues{kind: kindFloat, floats: make([]float64, n)}
+	}
+
+	for i := 0; i < n; i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		var (
+			s   = stringValue(arr, i)
+			err error
+		)
+		switch out.kind {
+		case kindInt:
+			out.ints[i], err = strconv.ParseInt(s, 10, bits)
+		case kindUint:
+			out.uints[i], err = strconv.ParseUint(s, 10, bits)
+		case kindFloat:
+			out.floats[i], err = strconv.ParseFloat(s, bits)
+		}
+		if err != nil {
+			return out, errCastParse(arr, i, s, to)
+		}
+	}
+	return out, nil
+}
+
+func castToString(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	from := arr.DataType()
+	bldr := array.NewBuilder(mem, to)
+	defer bldr.Release()
+	bldr.Reserve(arr.Len())
+
+	var appendString func(string)
+	switch b := bldr.(type) {
+	case *array.StringBuilder:
+		appendString = b.Append
+	case *array.BinaryBuilder:
+		appendString = b.AppendString
+	case *array.StringViewBuilder:
+		appendString = b.Append
+	case *array.BinaryViewBuilder:
+		appendString = b.AppendString
+	}
+
+	toUtf8 := to.ID() == arrow.STRING || to.ID() == arrow.STRING_VIEW
+	var format func(i int) string
+	switch a := arr.(type) {
+	case *array.String:
+		format = a.Value
+	case *array.StringView:
+		format = a.Value
+	case *array.Binary:
+		format = a.ValueString
+		if !opts.AllowInvalidUtf8 && toUtf8 {
+			for i := 0; i < a.Len(); i++ {
+				if a.IsValid(i) && !utf8.Valid(a.Value(i)) {
+					return nil, xerrors.Errorf("arrow/compute: invalid UTF-8 sequence at row %d: %w", i, ErrInvalid)
+				}
+			}
+		}
+	case *array.BinaryView:
+		format = a.ValueString
+		if !opts.AllowInvalidUtf8 && toUtf8 {
+			for i := 0; i < a.Len(); i++ {
+				if a.IsValid(i) && !utf8.Valid(a.Value(i)) {
+					return nil, xerrors.Errorf("arrow/compute: invalid UTF-8 sequence at row %d: %w", i, ErrInvalid)
+				}
+			}
+		}
+	case *array.Boolean:
+		format = func(i int) string { return strconv.FormatBool(a.Value(i)) }
+	case *array.Float16:
+		format = func(i int) string { return a.Value(i).String() }
+	case *array.Float32:
+		format = func(i int) string { return strconv.FormatFloat(float64(a.Value(i)), 'g', -1, 32) }
+	case *array.Float64:
+		format = func(i int) string { return strconv.FormatFloat(a.Value(i), 'g', -1, 64) }
+	case *array.Decimal128, *array.Decimal256:
+		_, scale := decimalParams(from)
+		format = func(i int) string { return formatDecimal(decimalValue(a, i), scale) }
+	default:
+		switch {
+		case isInteger(from.ID()):
+			src, _ := widenNumeric(arr)
+			switch src.kind {
+			case kindInt:
+				format = func(i int) string { return strconv.FormatInt(src.ints[i], 10) }
+			default:
+				format = func(i int) string { return strconv.FormatUint(src.uints[i], 10) }
+			}
+		case isTemporal(from.ID()):
+			var err error
+			if format, err = temporalFormatter(arr, opts); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errCastNotImplemented(from, to)
+		}
+	}
+
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			bldr.AppendNull()
+			continue
+		}
+		appendString(format(i))
+	}
+	return bldr.NewArray(), nil
+}
+
+func errCastNotImplemented(from, to arrow.DataType) error {
+	return xerrors.Errorf("arrow/compute: unsupported cast from %v to %v: %w", from, to, ErrNotImplemented)
+}
+
+func errCastOverflow(arr array.Interface, i int, v interface{}, to arrow.DataType) error {
+	return xerrors.Errorf("arrow/compute: value %v at row %d overflows %v when casting from %v: %w",
+		v, i, to, arr.DataType(), ErrInvalid)
+}
+
+func errCastTruncated(arr array.Interface, i int, v interface{}, to arrow.DataType) error {
+	return xerrors.Errorf("arrow/compute: value %v at row %d would be truncated when casting from %v to %v: %w",
+		v, i, arr.DataType(), to, ErrInvalid)
+}
+
+func errCastParse(arr array.Interface, i int, s string, to arrow.DataType) error {
+	return xerrors.Errorf("arrow/compute: cannot parse %q at row %d as %v: %w", s, i, to, ErrInvalid)
+}
@@ -0,0 +1,342 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+var (
+	mask64  = new(big.Int).SetUint64(math.MaxUint64)
+	pow10mu sync.Mutex
+	pow10s  = map[int32]*big.Int{}
+)
+
+// pow10 returns 10^n. The result must not be modified.
+func pow10(n int32) *big.Int {
+	pow10mu.Lock()
+	defer pow10mu.Unlock()
+	p, ok := pow10s[n]
+	if !ok {
+		p = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+		pow10s[n] = p
+	}
+	return p
+}
+
+// bigToDecimal returns the low 128 bits of v as a decimal128.Num, wrapping
+// around like the unchecked arithmetic kernels.
+func bigToDecimal(v *big.Int) decimal128.Num {
+	lo := new(big.Int).And(v, mask64).Uint64()
+	hi := new(big.Int).Rsh(v, 64)
+	return decimal128.New(int64(hi.And(hi, mask64).Uint64()), lo)
+}
+
+// bigToDecimal256 returns the low 256 bits of v as a decimal256.Num.
+func bigToDecimal256(v *big.Int) decimal256.Num {
+	var (
+		w [4]uint64
+		x = new(big.Int).Set(v)
+	)
+	for i := range w {
+		w[i] = new(big.Int).And(x, mask64).Uint64()
+		x.Rsh(x, 64)
+	}
+	return decimal256.New(w[3], w[2], w[1], w[0])
+}
+
+func isDecimal(id arrow.Type) bool {
+	return id == arrow.DECIMAL || id == arrow.DECIMAL256
+}
+
+// decimalParams returns the precision and scale of the decimal type dt.
+func decimalParams(dt arrow.DataType) (prec, scale int32) {
+	if dt, ok := dt.(*arrow.Decimal256Type); ok {
+		return dt.Precision, dt.Scale
+	}
+	d := dt.(*arrow.Decimal128Type)
+	return d.Precision, d.Scale
+}
+
+// maxDecimalPrecision returns the maximum precision of the decimal type id.
+func maxDecimalPrecision(id arrow.Type) int32 {
+	if id == arrow.DECIMAL256 {
+		return decimal256.MaxPrecision
+	}
+	return decimal128.MaxPrecision
+}
+
+// newDecimalType returns the decimal type of type id with the given
+// precision and scale.
+func newDecimalType(id arrow.Type, prec, scale int32) arrow.DataType {
+	if id == arrow.DECIMAL256 {
+		return &arrow.Decimal256Type{Precision: prec, Scale: scale}
+	}
+	return &arrow.Decimal128Type{Precision: prec, Scale: scale}
+}
+
+// decimalValue returns the unscaled i-th value of the decimal array arr.
+func decimalValue(arr array.Interface, i int) *big.Int {
+	if a, ok := arr.(*array.Decimal256); ok {
+		return a.Value(i).ToBigInt()
+	}
+	return arr.(*array.Decimal128).Value(i).ToBigInt()
+}
+
+// newDecimalValues returns a buffer of n values of the decimal type dt, and
+// a function setting its i-th value to v. The function reports whether v
+// fits the width of dt, and stores its low bits otherwise.
+func newDecimalValues(mem memory.Allocator, dt arrow.DataType, n int) (*memory.Buffer, func(i int, v *big.Int) bool) {
+	if dt.ID() == arrow.DECIMAL256 {
+		values := newBuffer(mem, arrow.Decimal256Traits.BytesRequired(n))
+		out := arrow.Decimal256Traits.CastFromBytes(values.Bytes())
+		return values, func(i int, v *big.Int) bool {
+			d, err := decimal256.FromBigInt(v)
+			if err != nil {
+				out[i] = bigToDecimal256(v)
+				return false
+			}
+			out[i] = d
+			return true
+		}
+	}
+	values := newBuffer(mem, arrow.Decimal128Traits.BytesRequired(n))
+	out := arrow.Decimal128Traits.CastFromBytes(values.Bytes())
+	return values, func(i int, v *big.Int) bool {
+		d, err := decimal128.FromBigInt(v)
+		if err != nil {
+			out[i] = bigToDecimal(v)
+			return false
+		}
+		out[i] = d
+		return true
+	}
+}
+
+// decimalLess reports whether a < b.
+func decimalLess(a, b decimal128.Num) bool {
+	return a.HighBits() < b.HighBits() || (a.HighBits() == b.HighBits() && a.LowBits() < b.LowBits())
+}
+
+// fitsPrecision reports whether v has at most prec digits.
+func fitsPrecision(v *big.Int, prec int32) bool {
+	return new(big.Int).Abs(v).Cmp(pow10(prec)) < 0
+}
+
+// formatDecimal formats the unscaled value v with the given scale.
+func formatDecimal(v *big.Int, scale int32) string {
+	digits := new(big.Int).Abs(v).String()
+	if scale <= 0 {
+		if v.Sign() != 0 {
+			digits += strings.Repeat("0", int(-scale))
+		}
+	} else {
+		if pad := int(scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+func castToDecimal(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	var (
+		n               = arr.Len()
+		from            = arr.DataType()
+		toPrec, toScale = decimalParams(to)
+		scale           = pow10(toScale)
+		get             func(i int) (*big.Int, error)
+	)
+
+	// toUnscaled converts the rational value r to an unscaled decimal.
+	toUnscaled := func(i int, r *big.Rat, v interface{}) (*big.Int, error) {
+		r.Mul(r, new(big.Rat).SetInt(scale))
+		if !r.IsInt() && !opts.AllowDecimalTruncate {
+			return nil, errCastTruncated(arr, i, v, to)
+		}
+		return decimal128.RoundHalfToEven.Round(r), nil
+	}
+
+	switch a := arr.(type) {
+	case *array.Decimal128, *array.Decimal256:
+		_, fromScale := decimalParams(from)
+		get = func(i int) (*big.Int, error) {
+			v := decimalValue(a, i)
+			switch {
+			case toScale > fromScale:
+				return v.Mul(v, pow10(toScale-fromScale)), nil
+			case toScale < fromScale:
+				q, r := new(big.Int).QuoRem(v, pow10(fromScale-toScale), new(big.Int))
+				if r.Sign() != 0 && !opts.AllowDecimalTruncate {
+					return nil, errCastTruncated(arr, i, formatDecimal(v, fromScale), to)
+				}
+				return q, nil
+			}
+			return v, nil
+		}
+	case *array.String, *array.Binary:
+		get = func(i int) (*big.Int, error) {
+			s := strings.TrimSpace(stringValue(arr, i))
+			r, ok := new(big.Rat).SetString(s)
+			if !ok || strings.ContainsRune(s, '/') {
+				return nil, errCastParse(arr, i, s, to)
+			}
+			return toUnscaled(i, r, s)
+		}
+	case *array.Boolean:
+		get = func(i int) (*big.Int, error) {
+			if a.Value(i) {
+				return new(big.Int).Set(scale), nil
+			}
+			return new(big.Int), nil
+		}
+	default:
+		if !isInteger(from.ID()) && from.ID() != arrow.FLOAT16 &&
+			from.ID() != arrow.FLOAT32 && from.ID() != arrow.FLOAT64 {
+			return nil, errCastNotImplemented(from, to)
+		}
+		var src numericValues
+		if from.ID() == arrow.FLOAT16 {
+			src = numericValues{kind: kindFloat, floats: make([]float64, n)}
+			for i, v := range arr.(*array.Float16).Values() {
+				src.floats[i] = float64(v.Float32())
+			}
+		} else {
+			src, _ = widenNumeric(arr)
+		}
+		// floating point values are converted from their shortest decimal
+		// representation, so that e.g. 0.1 is not seen as
+		// 0.1000000000000000055511151231257827.
+		fbits := 64
+		if from.ID() != arrow.FLOAT64 {
+			fbits = 32
+		}
+		get = func(i int) (*big.Int, error) {
+			switch src.kind {
+			case kindInt:
+				v := big.NewInt(src.ints[i])
+				return v.Mul(v, scale), nil
+			case kindUint:
+				v := new(big.Int).SetUint64(src.uints[i])
+				return v.Mul(v, scale), nil
+			}
+			f := src.floats[i]
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, errCastOverflow(arr, i, f, to)
+			}
+			r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, fbits))
+			return toUnscaled(i, r, f)
+		}
+	}
+
+	values, set := newDecimalValues(mem, to, n)
+	for i := 0; i < n; i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		v, err := get(i)
+		if err != nil {
+			values.Release()
+			return nil, err
+		}
+		if !set(i, v) || (!opts.AllowDecimalTruncate && !fitsPrecision(v, toPrec)) {
+			values.Release()
+			return nil, errCastOverflow(arr, i, formatDecimal(v, toScale), to)
+		}
+	}
+
+	return makeArray(to, n, []*memory.Buffer{copyValidity(mem, arr), values}, nil, arr.NullN()), nil
+}
+
+// decimalToNumeric converts the values of arr to the widened representation
+// matching the numeric type to.
+func decimalToNumeric(arr array.Interface, to arrow.DataType, opts *CastOptions) (numericValues, error) {
+	var (
+		n         = arr.Len()
+		_, dscale = decimalParams(arr.DataType())
+		scale     = pow10(dscale)
+		out       numericValues
+	)
+
+	switch to.ID() {
+	case arrow.FLOAT32, arrow.FLOAT64:
+		out = numericValues{kind: kindFloat, floats: make([]float64, n)}
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		out = numericValues{kind: kindUint, uints: make([]uint64, n)}
+	default:
+		out = numericValues{kind: kindInt, ints: make([]int64, n)}
+	}
+
+	for i := 0; i < n; i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		v := decimalValue(arr, i)
+		if out.kind == kindFloat {
+			out.floats[i], _ = new(big.Rat).SetFrac(v, scale).Float64()
+			continue
+		}
+
+		q, r := new(big.Int).QuoRem(v, scale, new(big.Int))
+		if r.Sign() != 0 && !opts.AllowDecimalTruncate {
+			return out, errCastTruncated(arr, i, formatDecimal(v, dscale), to)
+		}
+		switch out.kind {
+		case kindInt:
+			switch {
+			case q.IsInt64():
+			case opts.SaturateIntOverflow:
+				// clamped again to the range of the target type.
+				out.ints[i] = math.MaxInt64
+				if q.Sign() < 0 {
+					out.ints[i] = math.MinInt64
+				}
+				continue
+			case !opts.AllowIntOverflow:
+				return out, errCastOverflow(arr, i, formatDecimal(v, dscale), to)
+			}
+			out.ints[i] = int64(new(big.Int).And(q, mask64).Uint64())
+		case kindUint:
+			switch {
+			case q.IsUint64():
+			case opts.SaturateIntOverflow:
+				out.uints[i] = math.MaxUint64
+				if q.Sign() < 0 {
+					out.uints[i] = 0
+				}
+				continue
+			case !opts.AllowIntOverflow:
+				return out, errCastOverflow(arr, i, formatDecimal(v, dscale), to)
+			}
+			out.uints[i] = new(big.Int).And(q, mask64).Uint64()
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,519 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+const (
+	secondsPerDay = 86400
+	msPerDay      = secondsPerDay * 1000
+)
+
+var defaultTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// unitsPerSecond returns the number of ticks of unit u in one second.
+func unitsPerSecond(u arrow.TimeUnit) int64 {
+	switch u {
+	case arrow.Millisecond:
+		return 1e3
+	case arrow.Microsecond:
+		return 1e6
+	case arrow.Nanosecond:
+		return 1e9
+	}
+	return 1
+}
+
+// fractionLayout returns the layout of the fractional seconds of unit u.
+func fractionLayout(u arrow.TimeUnit) string {
+	switch u {
+	case arrow.Millisecond:
+		return ".000"
+	case arrow.Microsecond:
+		return ".000000"
+	case arrow.Nanosecond:
+		return ".000000000"
+	}
+	return ""
+}
+
+// timeUnitOf returns the unit of a temporal type. Dates are reported in
+// milliseconds, which is the unit of date64.
+func timeUnitOf(dt arrow.DataType) arrow.TimeUnit {
+	switch dt := dt.(type) {
+	case *arrow.TimestampType:
+		return dt.Unit
+	case *arrow.Time32Type:
+		return dt.Unit
+	case *arrow.Time64Type:
+		return dt.Unit
+	case *arrow.DurationType:
+		return dt.Unit
+	}
+	return arrow.Millisecond
+}
+
+func timeZoneOf(dt arrow.DataType) string {
+	if ts, ok := dt.(*arrow.TimestampType); ok {
+		return ts.TimeZone
+	}
+	return ""
+}
+
+// loadLocation returns the location named by tz, which is either empty or
+// "UTC", a fixed offset such as "+05:30", or a name from the IANA time zone
+// database.
+func loadLocation(tz string) (*time.Location, error) {
+	loc, err := arrow.LoadLocation(tz)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/compute: %v: %w", err, ErrInvalid)
+	}
+	return loc, nil
+}
+
+// temporalValues returns the values of a temporal array widened to int64.
+func temporalValues(arr array.Interface) []int64 {
+	var out []int64
+	switch a := arr.(type) {
+	case *array.Date32:
+		vs := a.Date32Values()
+		out = make([]int64, len(vs))
+		for i, v := range vs {
+			out[i] = int64(v)
+		}
+	case *array.Time32:
+		vs := a.Time32Values()
+		out = make([]int64, len(vs))
+		for i, v := range vs {
+			out[i] = int64(v)
+		}
+	case *array.Date64:
+		vs := a.Date64Values()
+		out = make([]int64, len(vs))
+		for i, v := range vs {
+			out[i] = int64(v)
+		}
+	case *array.Time64:
+		vs := a.Time64Values()
+		out = make([]int64, len(vs))
+		for i, v := range vs {
+			out[i] = int64(v)
+		}
+	case *array.Timestamp:
+		vs := a.TimestampValues()
+		out = make([]int64, len(vs))
+		for i, v := range vs {
+			out[i] = int64(v)
+		}
+	case *array.Duration:
+		vs := a.DurationValues()
+		out = make([]int64, len(vs))
+		for i, v := range vs {
+			out[i] = int64(v)
+		}
+	}
+	return out
+}
+
+// storageType returns the integer type used to store values of the
+// temporal type dt.
+func storageType(dt arrow.DataType) arrow.DataType {
+	switch dt.ID() {
+	case arrow.DATE32, arrow.TIME32:
+		return arrow.PrimitiveTypes.Int32
+	}
+	return arrow.PrimitiveTypes.Int64
+}
+
+func castToTemporal(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts *CastOptions) (array.Interface, error) {
+	from := arr.DataType()
+	if err := checkTimeZone(to); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case isInteger(from.ID()):
+		// integers are reinterpreted as the raw temporal values.
+		storage, err := castToNumeric(mem, arr, storageType(to), opts)
+		if err != nil {
+			return nil, err
+		}
+		defer storage.Release()
+		sd := storage.Data()
+		data := array.NewData(to, sd.Len(), sd.Buffers(), nil, sd.NullN(), sd.Offset())
+		defer data.Release()
+		return array.MakeFromData(data), nil
+
+	case (from.ID() == arrow.STRING || from.ID() == arrow.BINARY) && to.ID() == arrow.TIMESTAMP && opts.Strptime != nil:
+		return castStrptime(mem, arr, to.(*arrow.TimestampType), opts)
+
+	case from.ID() == arrow.STRING || from.ID() == arrow.BINARY:
+		vals, err := parseTemporal(arr, to, opts)
+		if err != nil {
+			return nil, err
+		}
+		return makeTemporal(mem, arr, to, vals, opts)
+
+	case isTemporal(from.ID()):
+		vals, err := convertTemporal(arr, to, opts)
+		if err != nil {
+			return nil, err
+		}
+		return makeTemporal(mem, arr, to, vals, opts)
+	}
+
+	return nil, errCastNotImplemented(from, to)
+}
+
+func checkTimeZone(dt arrow.DataType) error {
+	_, err := loadLocation(timeZoneOf(dt))
+	return err
+}
+
+// makeTemporal stores vals in a new array of the temporal type to.
+func makeTemporal(mem memory.Allocator, arr array.Interface, to arrow.DataType, vals []int64, opts *CastOptions) (array.Interface, error) {
+	var (
+		n      = len(vals)
+		values *memory.Buffer
+	)
+
+	switch storageType(to).ID() {
+	case arrow.INT32:
+		values = newBuffer(mem, arrow.Int32Traits.BytesRequired(n))
+		out := arrow.Int32Traits.CastFromBytes(values.Bytes())
+		for i, v := range vals {
+			if v < math.MinInt32 || v > math.MaxInt32 {
+				if !opts.AllowTimeOverflow && arr.IsValid(i) {
+					values.Release()
+					return nil, errCastOverflow(arr, i, v, to)
+				}
+			}
+			out[i] = int32(v)
+		}
+	default:
+		values = newBuffer(mem, arrow.Int64Traits.BytesRequired(n))
+		copy(arrow.Int64Traits.CastFromBytes(values.Bytes()), vals)
+	}
+
+	return makeArray(to, n, []*memory.Buffer{copyValidity(mem, arr), values}, nil, arr.NullN()), nil
+}
+
+// rescale converts v from a unit with fromPerSec ticks per second to a unit
+// with toPerSec ticks per second.
+func rescale(arr array.Interface, i int, v, fromPerSec, toPerSec int64, to arrow.DataType, opts *CastOptions) (int64, error) {
+	switch {
+	case toPerSec > fromPerSec:
+		out, ok := mulInt64(v, toPerSec/fromPerSec)
+		if !ok && !opts.AllowTimeOverflow && arr.IsValid(i) {
+			return 0, errCastOverflow(arr, i, v, to)
+		}
+		return out, nil
+	case toPerSec < fromPerSec:
+		factor := fromPerSec / toPerSec
+		if v%factor != 0 && !opts.AllowTimeTruncate && arr.IsValid(i) {
+			return 0, errCastTruncated(arr, i, v, to)
+		}
+		return v / factor, nil
+	}
+	return v, nil
+}
+
+// mulInt64 returns a*b and whether the multiplication did not overflow.
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (c < 0) != ((a < 0) != (b < 0)) || c/b != a {
+		return c, false
+	}
+	return c, true
+}
+
+// floorDiv returns the quotient of a and b rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func convertTemporal(arr array.Interface, to arrow.DataType, opts *CastOptions) ([]int64, error) {
+	var (
+		from   = arr.DataType()
+		vals   = temporalValues(arr)
+		fromPS = unitsPerSecond(timeUnitOf(from))
+		toPS   = unitsPerSecond(timeUnitOf(to))
+		err    error
+	)
+
+	switch from.ID() {
+	case arrow.DATE32:
+		// express as milliseconds, like date64.
+		for i, v := range vals {
+			vals[i] = v * msPerDay
+		}
+		fallthrough
+	case arrow.DATE64:
+		switch to.ID() {
+		case arrow.DATE32:
+			for i, v := range vals {
+				if v%msPerDay != 0 && !opts.AllowTimeTruncate && arr.IsValid(i) {
+					return nil, errCastTruncated(arr, i, v, to)
+				}
+				vals[i] = floorDiv(v, msPerDay)
+			}
+			return vals, nil
+		case arrow.DATE64:
+			return vals, nil
+		case arrow.TIMESTAMP:
+			for i, v := range vals {
+				if vals[i], err = rescale(arr, i, v, fromPS, toPS, to, opts); err != nil {
+					return nil, err
+				}
+			}
+			return vals, nil
+		}
+
+	case arrow.TIMESTAMP:
+		loc, err := loadLocation(timeZoneOf(from))
+		if err != nil {
+			return nil, err
+		}
+		switch to.ID() {
+		case arrow.TIMESTAMP:
+			// changing the time zone only changes how the instants are
+			// displayed, the stored UTC values are kept as is.
+			for i, v := range vals {
+				if vals[i], err = rescale(arr, i, v, fromPS, toPS, to, opts); err != nil {
+					return nil, err
+				}
+			}
+			return vals, nil
+		case arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64:
+			for i, v := range vals {
+				v = localTicks(v, fromPS, loc)
+				day := floorDiv(v, secondsPerDay*fromPS)
+				switch to.ID() {
+				case arrow.DATE32:
+					vals[i] = day
+				case arrow.DATE64:
+					vals[i] = day * msPerDay
+				default:
+					tod := v - day*secondsPerDay*fromPS
+					if vals[i], err = rescale(arr, i, tod, fromPS, toPS, to, opts); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return vals, nil
+		}
+
+	case arrow.TIME32, arrow.TIME64:
+		if to.ID() == arrow.TIME32 || to.ID() == arrow.TIME64 {
+			for i, v := range vals {
+				if vals[i], err = rescale(arr, i, v, fromPS, toPS, to, opts); err != nil {
+					return nil, err
+				}
+			}
+			return vals, nil
+		}
+
+	case arrow.DURATION:
+		if to.ID() == arrow.DURATION {
+			for i, v := range vals {
+				if vals[i], err = rescale(arr, i, v, fromPS, toPS, to, opts); err != nil {
+					return nil, err
+				}
+			}
+			return vals, nil
+		}
+	}
+
+	return nil, errCastNotImplemented(from, to)
+}
+
+// toTime returns the instant v, expressed in ticks of perSec per second
+// since the UNIX epoch, in the location loc.
+func toTime(v, perSec int64, loc *time.Location) time.Time {
+	sec := floorDiv(v, perSec)
+	nsec := (v - sec*perSec) * (1e9 / perSec)
+	return time.Unix(sec, nsec).In(loc)
+}
+
+// localTicks shifts the UTC instant v by the offset of loc at that instant.
+func localTicks(v, perSec int64, loc *time.Location) int64 {
+	if loc == time.UTC {
+		return v
+	}
+	_, offset := toTime(v, perSec, loc).Zone()
+	return v + int64(offset)*perSec
+}
+
+// fromTime returns t as the number of ticks of perSec per second since the
+// UNIX epoch.
+func fromTime(arr array.Interface, i int, t time.Time, perSec int64, to arrow.DataType, opts *CastOptions) (int64, error) {
+	nsecPerTick := 1e9 / perSec
+	nsec := int64(t.Nanosecond())
+	if nsec%nsecPerTick != 0 && !opts.AllowTimeTruncate {
+		return 0, errCastTruncated(arr, i, t, to)
+	}
+	v, ok := mulInt64(t.Unix(), perSec)
+	ticks := nsec / nsecPerTick
+	if (!ok || v > math.MaxInt64-ticks) && !opts.AllowTimeOverflow {
+		return 0, errCastOverflow(arr, i, t, to)
+	}
+	return v + ticks, nil
+}
+
+func parseTemporal(arr array.Interface, to arrow.DataType, opts *CastOptions) ([]int64, error) {
+	var (
+		n      = arr.Len()
+		vals   = make([]int64, n)
+		perSec = unitsPerSecond(timeUnitOf(to))
+	)
+
+	loc, err := loadLocation(timeZoneOf(to))
+	if err != nil {
+		return nil, err
+	}
+
+	var layouts []string
+	switch to.ID() {
+	case arrow.TIMESTAMP:
+		layouts = defaultTimestampLayouts
+		if opts.TimestampLayout != "" {
+			layouts = []string{opts.TimestampLayout}
+		}
+	case arrow.DATE32, arrow.DATE64:
+		layouts = []string{"2006-01-02"}
+		if opts.DateLayout != "" {
+			layouts = []string{opts.DateLayout}
+		}
+	case arrow.TIME32, arrow.TIME64:
+		layouts = []string{"15:04:05.999999999"}
+		if opts.TimeLayout != "" {
+			layouts = []string{opts.TimeLayout}
+		}
+	default:
+		return nil, errCastNotImplemented(arr.DataType(), to)
+	}
+
+	for i := range vals {
+		if arr.IsNull(i) {
+			continue
+		}
+		s := strings.TrimSpace(stringValue(arr, i))
+		t, ok := parseTime(s, layouts, loc)
+		if !ok {
+			return nil, errCastParse(arr, i, s, to)
+		}
+
+		switch to.ID() {
+		case arrow.TIMESTAMP:
+			vals[i], err = fromTime(arr, i, t, perSec, to, opts)
+		case arrow.DATE32:
+			vals[i] = floorDiv(t.Unix(), secondsPerDay)
+		case arrow.DATE64:
+			vals[i] = floorDiv(t.Unix(), secondsPerDay) * msPerDay
+		default:
+			midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+			vals[i], err = fromTime(arr, i, time.Unix(0, int64(t.Sub(midnight))), perSec, to, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+func parseTime(s string, layouts []string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// temporalFormatter returns a function formatting the i-th value of the
+// temporal array arr as a string.
+func temporalFormatter(arr array.Interface, opts *CastOptions) (func(i int) string, error) {
+	var (
+		dt     = arr.DataType()
+		unit   = timeUnitOf(dt)
+		perSec = unitsPerSecond(unit)
+		vals   = temporalValues(arr)
+	)
+
+	switch dt.ID() {
+	case arrow.TIMESTAMP:
+		tz := timeZoneOf(dt)
+		loc, err := loadLocation(tz)
+		if err != nil {
+			return nil, err
+		}
+		layout := opts.TimestampLayout
+		if layout == "" {
+			layout = "2006-01-02 15:04:05" + fractionLayout(unit)
+			if tz != "" {
+				layout += "Z07:00"
+			}
+		}
+		return func(i int) string { return toTime(vals[i], perSec, loc).Format(layout) }, nil
+
+	case arrow.DATE32, arrow.DATE64:
+		layout := opts.DateLayout
+		if layout == "" {
+			layout = "2006-01-02"
+		}
+		if dt.ID() == arrow.DATE32 {
+			return func(i int) string { return toTime(vals[i]*secondsPerDay, 1, time.UTC).Format(layout) }, nil
+		}
+		return func(i int) string { return toTime(vals[i], 1e3, time.UTC).Format(layout) }, nil
+
+	case arrow.TIME32, arrow.TIME64:
+		layout := opts.TimeLayout
+		if layout == "" {
+			layout = "15:04:05" + fractionLayout(unit)
+		}
+		return func(i int) string { return toTime(vals[i], perSec, time.UTC).Format(layout) }, nil
+
+	case arrow.DURATION:
+		return func(i int) string { return strconv.FormatInt(vals[i], 10) }, nil
+	}
+
+	return nil, errCastNotImplemented(dt, arrow.BinaryTypes.String)
+}
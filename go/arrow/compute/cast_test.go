@@ -0,0 +1,489 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func dec(v int64) decimal128.Num { return decimal128.FromI64(v) }
+
+// dec256 returns the decimal256 with the unscaled value s.
+func dec256(s string) decimal256.Num {
+	n, err := decimal256.FromString(s, decimal256.MaxPrecision, 0)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func half(v float64) float16.Num { return float16.FromFloat64(v) }
+
+func TestCastArray(t *testing.T) {
+	var (
+		i8    = arrow.PrimitiveTypes.Int8
+		i16   = arrow.PrimitiveTypes.Int16
+		i32   = arrow.PrimitiveTypes.Int32
+		i64   = arrow.PrimitiveTypes.Int64
+		u8    = arrow.PrimitiveTypes.Uint8
+		u32   = arrow.PrimitiveTypes.Uint32
+		u64   = arrow.PrimitiveTypes.Uint64
+		f16   = arrow.FixedWidthTypes.Float16
+		f32   = arrow.PrimitiveTypes.Float32
+		f64   = arrow.PrimitiveTypes.Float64
+		str   = arrow.BinaryTypes.String
+		bin   = arrow.BinaryTypes.Binary
+		strv  = arrow.BinaryTypes.StringView
+		binv  = arrow.BinaryTypes.BinaryView
+		boo   = arrow.FixedWidthTypes.Boolean
+		d32   = arrow.FixedWidthTypes.Date32
+		d64   = arrow.FixedWidthTypes.Date64
+		tss   = &arrow.TimestampType{Unit: arrow.Second}
+		tsms  = &arrow.TimestampType{Unit: arrow.Millisecond}
+		tsus  = &arrow.TimestampType{Unit: arrow.Microsecond}
+		tsns  = &arrow.TimestampType{Unit: arrow.Nanosecond}
+		tsNY  = &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"}
+		tsOff = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "+05:30"}
+		t32s  = &arrow.Time32Type{Unit: arrow.Second}
+		t32ms = &arrow.Time32Type{Unit: arrow.Millisecond}
+		t64us = &arrow.Time64Type{Unit: arrow.Microsecond}
+		dus   = &arrow.DurationType{Unit: arrow.Microsecond}
+		dms   = &arrow.DurationType{Unit: arrow.Millisecond}
+		dec52 = &arrow.Decimal128Type{Precision: 5, Scale: 2}
+		dec51 = &arrow.Decimal128Type{Precision: 5, Scale: 1}
+		dec30 = &arrow.Decimal128Type{Precision: 3, Scale: 0}
+		dec38 = &arrow.Decimal128Type{Precision: 38, Scale: 10}
+		d256  = &arrow.Decimal256Type{Precision: 60, Scale: 2}
+		d2561 = &arrow.Decimal256Type{Precision: 60, Scale: 1}
+
+		unsafe   = compute.UnsafeCastOptions()
+		saturate = &compute.CastOptions{SaturateIntOverflow: true}
+	)
+
+	valid := []bool{true, false, true, true}
+
+	for _, tc := range []struct {
+		name  string
+		from  arrow.DataType
+		in    interface{}
+		to    arrow.DataType
+		want  interface{}
+		valid []bool
+		opts  *compute.CastOptions
+		err   error
+		row   int
+	}{
+		// numeric widening and narrowing
+		{name: "int8-int64", from: i8, in: []int8{-128, 0, 1, 127}, to: i64, want: []int64{-128, 0, 1, 127}},
+		{name: "int64-int8", from: i64, in: []int64{-128, 0, 1, 127}, to: i8, want: []int8{-128, 0, 1, 127}},
+		{name: "int64-int8-overflow", from: i64, in: []int64{1, 2, 128, 3}, to: i8, err: compute.ErrInvalid, row: 2},
+		{name: "int64-int8-wrap", from: i64, in: []int64{1, 2, 128, 3}, to: i8, want: []int8{1, 2, -128, 3}, opts: unsafe},
+		{name: "int32-uint8-negative", from: i32, in: []int32{1, -1, 2, 3}, to: u8, err: compute.ErrInvalid, row: 1},
+		{name: "int32-uint8-null-overflow", from: i32, in: []int32{1, -1, 2, 3}, to: u8, valid: valid, want: []uint8{1, 0, 2, 3}},
+		{name: "uint64-int64-overflow", from: u64, in: []uint64{0, math.MaxUint64, 1, 2}, to: i64, err: compute.ErrInvalid, row: 1},
+		{name: "uint32-int16", from: u32, in: []uint32{0, 42, 32767, 1}, to: i16, want: []int16{0, 42, 32767, 1}},
+		{name: "int32-float64", from: i32, in: []int32{-1, 0, 1, 1 << 30}, to: f64, want: []float64{-1, 0, 1, 1 << 30}},
+		{name: "int64-float32-truncate", from: i64, in: []int64{1, 1<<24 + 1, 2, 3}, to: f32, err: compute.ErrInvalid, row: 1},
+		{name: "float64-int32", from: f64, in: []float64{-2, 0, 1, 3}, to: i32, want: []int32{-2, 0, 1, 3}},
+		{name: "float64-int32-truncate", from: f64, in: []float64{1, 1.5, 2, 3}, to: i32, err: compute.ErrInvalid, row: 1},
+		{name: "float64-int32-unsafe", from: f64, in: []float64{1, 1.5, -2.5, 3}, to: i32, want: []int32{1, 1, -2, 3}, opts: unsafe},
+		{name: "float64-int8-overflow", from: f64, in: []float64{1, 2, 3, 128}, to: i8, err: compute.ErrInvalid, row: 3},
+		{name: "int64-int8-saturate", from: i64, in: []int64{-1000, 2, 128, 3}, to: i8, want: []int8{-128, 2, 127, 3}, opts: saturate},
+		{name: "int32-uint8-saturate", from: i32, in: []int32{-1, 256, 2, 3}, to: u8, want: []uint8{0, 255, 2, 3}, opts: saturate},
+		{name: "uint64-int64-saturate", from: u64, in: []uint64{0, math.MaxUint64, 1, 2}, to: i64, want: []int64{0, math.MaxInt64, 1, 2}, opts: saturate},
+		{name: "float64-int8-saturate", from: f64, in: []float64{-1e10, 2, 3, 128}, to: i8, want: []int8{-128, 2, 3, 127}, opts: saturate},
+		{name: "float64-int8-saturate-nan", from: f64, in: []float64{1, math.NaN(), 3, 4}, to: i8, err: compute.ErrInvalid, row: 1, opts: saturate},
+		{name: "decimal-int8-saturate", from: dec52, in: []decimal128.Num{dec(0), dec(12800), dec(-12900), dec(0)}, to: i8, want: []int8{0, 127, -128, 0}, opts: saturate},
+		{name: "float64-int64-nan", from: f64, in: []float64{1, math.NaN(), 2, 3}, to: i64, err: compute.ErrInvalid, row: 1},
+		{name: "float32-float64", from: f32, in: []float32{1.5, 0, -2.25, 3}, to: f64, want: []float64{1.5, 0, -2.25, 3}},
+		{name: "float64-float16", from: f64, in: []float64{1.5, 0, -2.25, 3}, to: f16,
+			want: []float16.Num{float16.New(1.5), float16.New(0), float16.New(-2.25), float16.New(3)}},
+		{name: "float64-float16-round", from: f64, in: []float64{1 + math.Ldexp(1, -11) + math.Ldexp(1, -40), math.Ldexp(1, -25), math.Ldexp(1, -24), 65520}, to: f16,
+			want: []float16.Num{float16.FromBits(0x3c01), float16.FromBits(0x0000), float16.FromBits(0x0001), float16.FromBits(0x7c00)}},
+		{name: "float16-float32", from: f16, in: []float16.Num{float16.FromBits(0x0001), float16.FromBits(0x03ff), float16.FromBits(0x7c00), float16.FromBits(0xfc00)}, to: f32,
+			want: []float32{float32(math.Ldexp(1, -24)), float32(math.Ldexp(0x3ff, -24)), float32(math.Inf(1)), float32(math.Inf(-1))}},
+		{name: "float16-float64", from: f16, in: []float16.Num{float16.MaxNum, float16.MinNum, float16.FromBits(0x8000), float16.FromBits(0x3555)}, to: f64,
+			want: []float64{65504, -65504, math.Copysign(0, -1), math.Ldexp(0x555, -12)}},
+		{name: "float16-int32", from: f16, in: []float16.Num{float16.New(1), float16.New(0), float16.New(-2), float16.New(3)}, to: i32,
+			want: []int32{1, 0, -2, 3}},
+		{name: "bool-int32", from: boo, in: []bool{true, false, true, false}, to: i32, want: []int32{1, 0, 1, 0}},
+		{name: "int32-bool", from: i32, in: []int32{1, 0, -5, 0}, to: boo, want: []bool{true, false, true, false}},
+		{name: "float64-bool", from: f64, in: []float64{0.5, 0, 0, 1}, to: boo, want: []bool{true, false, false, true}, valid: valid},
+
+		// strings
+		{name: "string-int32", from: str, in: []string{"1", "-2", " ", "42"}, to: i32, valid: []bool{true, true, false, true}, want: []int32{1, -2, 0, 42}},
+		{name: "string-int8-overflow", from: str, in: []string{"1", "-2", "300", "42"}, to: i8, err: compute.ErrInvalid, row: 2},
+		{name: "string-int32-invalid", from: str, in: []string{"1", "x", "3", "42"}, to: i32, err: compute.ErrInvalid, row: 1},
+		{name: "string-uint64", from: str, in: []string{"18446744073709551615", "0", "1", "2"}, to: u64, want: []uint64{math.MaxUint64, 0, 1, 2}},
+		{name: "string-float64", from: str, in: []string{"1.5", "-2e3", "inf", "0"}, to: f64, want: []float64{1.5, -2000, math.Inf(1), 0}},
+		{name: "string-bool", from: str, in: []string{"true", "false", "1", "0"}, to: boo, want: []bool{true, false, true, false}},
+		{name: "int64-string", from: i64, in: []int64{-1, 0, 1, math.MaxInt64}, to: str, valid: valid, want: []string{"-1", "", "1", "9223372036854775807"}},
+		{name: "uint64-string", from: u64, in: []uint64{math.MaxUint64, 0, 1, 2}, to: str, want: []string{"18446744073709551615", "0", "1", "2"}},
+		{name: "float64-string", from: f64, in: []float64{1.5, 0, -0.25, 1e21}, to: str, want: []string{"1.5", "0", "-0.25", "1e+21"}},
+		{name: "bool-string", from: boo, in: []bool{true, false, true, false}, to: str, want: []string{"true", "false", "true", "false"}},
+		{name: "binary-string", from: bin, in: [][]byte{[]byte("a"), []byte("bc"), nil, []byte("d")}, to: str, want: []string{"a", "bc", "", "d"}},
+		{name: "binary-string-invalid-utf8", from: bin, in: [][]byte{[]byte("a"), []byte("\xff"), nil, []byte("d")}, to: str, err: compute.ErrInvalid, row: 1},
+		{name: "string-binary", from: str, in: []string{"a", "bc", "", "d"}, to: bin, want: [][]byte{[]byte("a"), []byte("bc"), []byte(""), []byte("d")}},
+
+		// string and binary views
+		{name: "string-stringview", from: str, in: []string{"a", "a value not inline", "", "d"}, to: strv, valid: valid, want: []string{"a", "", "", "d"}},
+		{name: "stringview-string", from: strv, in: []string{"a", "a value not inline", "", "d"}, to: str, want: []string{"a", "a value not inline", "", "d"}},
+		{name: "binary-binaryview", from: bin, in: [][]byte{[]byte("a"), []byte("a value not inline"), nil, []byte("d")}, to: binv,
+			want: [][]byte{[]byte("a"), []byte("a value not inline"), nil, []byte("d")}},
+		{name: "binaryview-stringview", from: binv, in: [][]byte{[]byte("a"), []byte("a value not inline"), nil, []byte("d")}, to: strv,
+			want: []string{"a", "a value not inline", "", "d"}},
+		{name: "binaryview-string-invalid-utf8", from: binv, in: [][]byte{[]byte("a"), []byte("\xff"), nil, []byte("d")}, to: str, err: compute.ErrInvalid, row: 1},
+		{name: "stringview-int32", from: strv, in: []string{"1", "-2", " ", "42"}, to: i32, valid: []bool{true, true, false, true}, want: []int32{1, -2, 0, 42}},
+		{name: "int64-stringview", from: i64, in: []int64{-1, 0, 1, math.MaxInt64}, to: strv, valid: valid, want: []string{"-1", "", "1", "9223372036854775807"}},
+
+		// temporal
+		{name: "string-timestamp-s", from: str, in: []string{"1970-01-01T00:00:00Z", "2000-01-01 00:00:00", "1970-01-02", "1969-12-31T23:59:59-00:00"}, to: tss,
+			want: []int64{0, 946684800, 86400, -1}},
+		{name: "string-timestamp-ms", from: str, in: []string{"1970-01-01T00:00:00.123Z", "x", "1970-01-01 00:00:01.5", "1970-01-01T01:00:00+01:00"}, to: tsms, valid: valid,
+			want: []int64{123, 0, 1500, 0}},
+		{name: "string-timestamp-truncate", from: str, in: []string{"1970-01-01 00:00:00", "1970-01-01 00:00:00.5", "", ""}, to: tss, valid: []bool{true, true, false, false},
+			err: compute.ErrInvalid, row: 1},
+		{name: "string-timestamp-tz", from: str, in: []string{"2020-07-01 12:00:00", "2020-01-01 12:00:00", "2020-07-01T12:00:00Z", "1970-01-01"}, to: tsNY,
+			want: []int64{1593619200, 1577898000, 1593604800, 18000}},
+		{name: "string-timestamp-invalid", from: str, in: []string{"1970-01-01", "yesterday", "", ""}, to: tss, err: compute.ErrInvalid, row: 1},
+		{name: "string-date32", from: str, in: []string{"1970-01-01", "2000-03-01", "1969-12-31", "2020-02-29"}, to: d32,
+			want: []int32{0, 11017, -1, 18321}},
+		{name: "string-date64", from: str, in: []string{"1970-01-01", "1970-01-02", "1969-12-31", "2020-02-29"}, to: d64,
+			want: []int64{0, 86400000, -86400000, 1582934400000}},
+		{name: "string-time32", from: str, in: []string{"00:00:00", "12:34:56", "23:59:59", "00:00:01"}, to: t32s,
+			want: []int32{0, 45296, 86399, 1}},
+		{name: "string-time64", from: str, in: []string{"00:00:00.000001", "12:34:56.5", "23:59:59", "00:00:01"}, to: t64us,
+			want: []int64{1, 45296500000, 86399000000, 1000000}},
+		{name: "timestamp-string", from: tsms, in: []int64{0, 1500, -1, 86400000}, to: str,
+			want: []string{"1970-01-01 00:00:00.000", "1970-01-01 00:00:01.500", "1969-12-31 23:59:59.999", "1970-01-02 00:00:00.000"}},
+		{name: "timestamp-tz-string", from: tsNY, in: []int64{0, 1593604800, 0, 0}, to: str, valid: valid,
+			want: []string{"1969-12-31 19:00:00-05:00", "", "1969-12-31 19:00:00-05:00", "1969-12-31 19:00:00-05:00"}},
+		{name: "date32-string", from: d32, in: []int32{0, 11017, -1, 18321}, to: str, want: []string{"1970-01-01", "2000-03-01", "1969-12-31", "2020-02-29"}},
+		{name: "time32-string", from: t32ms, in: []int32{0, 45296789, 86399999, 1}, to: str, want: []string{"00:00:00.000", "12:34:56.789", "23:59:59.999", "00:00:00.001"}},
+		{name: "duration-string", from: dms, in: []int64{0, -5, 10, 1}, to: str, want: []string{"0", "-5", "10", "1"}},
+		{name: "timestamp-s-ns", from: tss, in: []int64{0, 1, -1, 1e9}, to: tsns, want: []int64{0, 1e9, -1e9, 1e18}},
+		{name: "timestamp-s-ns-overflow", from: tss, in: []int64{0, 1, 1e10, 1}, to: tsns, err: compute.ErrInvalid, row: 2},
+		{name: "timestamp-us-ms", from: tsus, in: []int64{0, 1000, -2000, 5000}, to: tsms, want: []int64{0, 1, -2, 5}},
+		{name: "timestamp-us-ms-truncate", from: tsus, in: []int64{0, 1000, 1, 5000}, to: tsms, err: compute.ErrInvalid, row: 2},
+		{name: "timestamp-us-ms-unsafe", from: tsus, in: []int64{0, 1000, 1999, 5000}, to: tsms, opts: unsafe, want: []int64{0, 1, 1, 5}},
+		{name: "timestamp-tz-relabel", from: tss, in: []int64{0, 1, 2, 3}, to: tsOff, want: []int64{0, 1000, 2000, 3000}},
+		{name: "timestamp-tz-unknown", from: tss, in: []int64{0, 1, 2, 3}, to: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Mars/Olympus"}, err: compute.ErrInvalid, row: -1},
+		{name: "timestamp-date32", from: tss, in: []int64{0, 86399, 86400, -1}, to: d32, want: []int32{0, 0, 1, -1}},
+		{name: "timestamp-tz-date32", from: tsNY, in: []int64{0, 18000, 86400, 104400}, to: d32, want: []int32{-1, 0, 0, 1}},
+		{name: "timestamp-date64", from: tsms, in: []int64{0, 86399999, 86400000, -1}, to: d64, want: []int64{0, 0, 86400000, -86400000}},
+		{name: "timestamp-time64", from: tss, in: []int64{0, 86399, 86401, -1}, to: t64us, want: []int64{0, 86399e6, 1e6, 86399e6}},
+		{name: "date32-date64", from: d32, in: []int32{0, 1, -1, 18321}, to: d64, want: []int64{0, 86400000, -86400000, 1582934400000}},
+		{name: "date64-date32", from: d64, in: []int64{0, 86400000, -86400000, 1582934400000}, to: d32, want: []int32{0, 1, -1, 18321}},
+		{name: "date64-date32-truncate", from: d64, in: []int64{0, 86400000, 1, 0}, to: d32, err: compute.ErrInvalid, row: 2},
+		{name: "date32-timestamp", from: d32, in: []int32{0, 1, -1, 2}, to: tss, want: []int64{0, 86400, -86400, 172800}},
+		{name: "time32-time64", from: t32s, in: []int32{0, 1, 86399, 2}, to: t64us, want: []int64{0, 1e6, 86399e6, 2e6}},
+		{name: "time64-time32-truncate", from: t64us, in: []int64{0, 1e6, 1, 2e6}, to: t32ms, err: compute.ErrInvalid, row: 2},
+		{name: "duration-us-ms", from: dus, in: []int64{0, 1000, -3000, 2000}, to: dms, want: []int64{0, 1, -3, 2}},
+		{name: "int64-timestamp", from: i64, in: []int64{0, 1, -1, 2}, to: tss, want: []int64{0, 1, -1, 2}, valid: valid},
+		{name: "int64-date32-overflow", from: i64, in: []int64{0, 1, math.MaxInt64, 2}, to: d32, err: compute.ErrInvalid, row: 2},
+		{name: "timestamp-int64", from: tss, in: []int64{0, 1, -1, 2}, to: i64, want: []int64{0, 1, -1, 2}},
+		{name: "date64-int32-overflow", from: d64, in: []int64{0, 86400000 * 30000, 0, 0}, to: i32, err: compute.ErrInvalid, row: 1},
+		{name: "timestamp-float64", from: tss, in: []int64{0, 1, -1, 2}, to: f64, want: []float64{0, 1, -1, 2}},
+		{name: "time32-timestamp", from: t32s, in: []int32{0, 1, 2, 3}, to: tss, err: compute.ErrNotImplemented, row: -1},
+
+		// decimals
+		{name: "int32-decimal", from: i32, in: []int32{0, 1, -123, 999}, to: dec52, want: []decimal128.Num{dec(0), dec(100), dec(-12300), dec(99900)}},
+		{name: "int32-decimal-precision", from: i32, in: []int32{0, 1, 1000, 999}, to: dec52, err: compute.ErrInvalid, row: 2},
+		{name: "float64-decimal", from: f64, in: []float64{0, 1.25, -3.5, 999.99}, to: dec52, want: []decimal128.Num{dec(0), dec(125), dec(-350), dec(99999)}},
+		{name: "float64-decimal-truncate", from: f64, in: []float64{0, 1.255, -3.5, 999.99}, to: dec52, err: compute.ErrInvalid, row: 1},
+		{name: "float64-decimal-round", from: f64, in: []float64{0.125, 0.135, -0.125, 1.5}, to: dec52, opts: unsafe, want: []decimal128.Num{dec(12), dec(14), dec(-12), dec(150)}},
+		{name: "float64-decimal-nan", from: f64, in: []float64{0, math.NaN(), 1, 2}, to: dec52, opts: unsafe, err: compute.ErrInvalid, row: 1},
+		{name: "decimal-float64", from: dec52, in: []decimal128.Num{dec(0), dec(125), dec(-350), dec(99999)}, to: f64, want: []float64{0, 1.25, -3.5, 999.99}},
+		{name: "decimal-int64", from: dec52, in: []decimal128.Num{dec(0), dec(100), dec(-12300), dec(99900)}, to: i64, want: []int64{0, 1, -123, 999}},
+		{name: "decimal-int64-truncate", from: dec52, in: []decimal128.Num{dec(0), dec(150), dec(-12300), dec(99900)}, to: i64, err: compute.ErrInvalid, row: 1},
+		{name: "decimal-int64-unsafe", from: dec52, in: []decimal128.Num{dec(0), dec(150), dec(-12350), dec(99900)}, to: i64, opts: unsafe, want: []int64{0, 1, -123, 999}},
+		{name: "decimal-int8-overflow", from: dec52, in: []decimal128.Num{dec(0), dec(12800), dec(0), dec(0)}, to: i8, err: compute.ErrInvalid, row: 1},
+		{name: "decimal-uint8-negative", from: dec52, in: []decimal128.Num{dec(0), dec(-100), dec(0), dec(0)}, to: u8, err: compute.ErrInvalid, row: 1},
+		{name: "decimal-upscale", from: dec51, in: []decimal128.Num{dec(0), dec(15), dec(-9999), dec(1)}, to: dec38, want: []decimal128.Num{dec(0), dec(15e9), dec(-9999e9), dec(1e9)}},
+		{name: "decimal-downscale", from: dec52, in: []decimal128.Num{dec(0), dec(150), dec(-99990), dec(10)}, to: dec51, want: []decimal128.Num{dec(0), dec(15), dec(-9999), dec(1)}},
+		{name: "decimal-downscale-truncate", from: dec52, in: []decimal128.Num{dec(0), dec(155), dec(0), dec(0)}, to: dec51, err: compute.ErrInvalid, row: 1},
+		{name: "decimal-precision", from: dec52, in: []decimal128.Num{dec(0), dec(99900), dec(100000), dec(0)}, to: dec30, valid: valid, err: compute.ErrInvalid, row: 2},
+		{name: "string-decimal", from: str, in: []string{"0", "1.5", "-999.99", "1e2"}, to: dec52, want: []decimal128.Num{dec(0), dec(150), dec(-99999), dec(10000)}},
+		{name: "string-decimal-invalid", from: str, in: []string{"0", "1/2", "", ""}, to: dec52, err: compute.ErrInvalid, row: 1},
+		{name: "decimal-string", from: dec52, in: []decimal128.Num{dec(0), dec(5), dec(-99999), dec(-50)}, to: str, want: []string{"0.00", "0.05", "-999.99", "-0.50"}},
+		{name: "decimal-bool", from: dec52, in: []decimal128.Num{dec(0), dec(5), dec(-99999), dec(0)}, to: boo, want: []bool{false, true, true, false}},
+		{name: "int64-decimal256", from: i64, in: []int64{0, 1, math.MinInt64, 999}, to: d256,
+			want: []decimal256.Num{dec256("0"), dec256("100"), dec256("-922337203685477580800"), dec256("99900")}},
+		{name: "decimal-decimal256", from: dec52, in: []decimal128.Num{dec(0), dec(5), dec(-99999), dec(0)}, to: d2561, opts: unsafe,
+			want: []decimal256.Num{dec256("0"), dec256("0"), dec256("-9999"), dec256("0")}},
+		{name: "decimal256-decimal", from: d256, in: []decimal256.Num{dec256("0"), dec256("150"), dec256("-99990"), dec256("10")}, to: dec51,
+			want: []decimal128.Num{dec(0), dec(15), dec(-9999), dec(1)}},
+		{name: "decimal256-decimal-overflow", from: d256, in: []decimal256.Num{dec256("0"), dec256("1" + strings.Repeat("0", 45)), dec256("0"), dec256("0")}, to: dec38,
+			opts: unsafe, err: compute.ErrInvalid, row: 1},
+		{name: "decimal256-decimal256-downscale-truncate", from: d256, in: []decimal256.Num{dec256("0"), dec256("155"), dec256("0"), dec256("0")}, to: d2561, err: compute.ErrInvalid, row: 1},
+		{name: "decimal256-float64", from: d256, in: []decimal256.Num{dec256("0"), dec256("125"), dec256("-350"), dec256("1" + strings.Repeat("0", 52))}, to: f64,
+			want: []float64{0, 1.25, -3.5, 1e50}},
+		{name: "decimal256-int64-truncate", from: d256, in: []decimal256.Num{dec256("0"), dec256("150"), dec256("0"), dec256("0")}, to: i64, err: compute.ErrInvalid, row: 1},
+		{name: "decimal256-int64-overflow", from: d256, in: []decimal256.Num{dec256("0"), dec256("1" + strings.Repeat("0", 22)), dec256("0"), dec256("0")}, to: i64, err: compute.ErrInvalid, row: 1},
+		{name: "string-decimal256", from: str, in: []string{"0", "1.5", "-999.99", "1e50"}, to: d256,
+			want: []decimal256.Num{dec256("0"), dec256("150"), dec256("-99999"), dec256("1" + strings.Repeat("0", 52))}},
+		{name: "decimal256-string", from: d256, in: []decimal256.Num{dec256("0"), dec256("5"), dec256("-" + strings.Repeat("9", 50)), dec256("-50")}, to: str,
+			want: []string{"0.00", "0.05", "-" + strings.Repeat("9", 48) + ".99", "-0.50"}},
+		{name: "decimal256-bool", from: d256, in: []decimal256.Num{dec256("0"), dec256("5"), dec256("-99999"), dec256("0")}, to: boo, want: []bool{false, true, true, false}},
+
+		// nulls and identity
+		{name: "identity", from: i32, in: []int32{1, 2, 3, 4}, to: i32, valid: valid, want: []int32{1, 0, 3, 4}},
+		{name: "null-int32", from: arrow.Null, in: nil, to: i32, want: []int32{0, 0, 0, 0}, valid: []bool{false, false, false, false}},
+		{name: "null-string", from: arrow.Null, in: nil, to: str, want: []string{"", "", "", ""}, valid: []bool{false, false, false, false}},
+		{name: "string-list", from: str, in: []string{"a", "b", "c", "d"}, to: arrow.ListOf(str), err: compute.ErrNotImplemented, row: -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			var in array.Interface
+			if tc.from.ID() == arrow.NULL {
+				in = array.NewNull(4)
+			} else {
+				in = arrayOf(mem, tc.from, tc.in, tc.valid)
+			}
+			defer in.Release()
+
+			ctx := compute.WithAllocator(context.Background(), mem)
+			got, err := compute.CastArray(ctx, in, tc.to, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				if tc.row >= 0 && !strings.Contains(err.Error(), fmt.Sprintf("row %d", tc.row)) {
+					t.Fatalf("error does not name row %d: %v", tc.row, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, tc.to, tc.want, tc.valid)
+			defer want.Release()
+			assertArrayEqual(t, want, got)
+		})
+	}
+}
+
+func TestCastArraySliced(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int64,
+		[]int64{1000, 1, 2, 3, 4, 5, 6, 7, 8, 9, 1000},
+		[]bool{true, true, false, true, true, false, true, true, true, false, true})
+	defer arr.Release()
+
+	slice := array.NewSlice(arr, 1, 10)
+	defer slice.Release()
+
+	got, err := compute.CastArray(ctx, slice, arrow.PrimitiveTypes.Int8, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	want := arrayOf(mem, arrow.PrimitiveTypes.Int8,
+		[]int8{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		[]bool{true, false, true, true, false, true, true, true, false})
+	defer want.Release()
+	assertArrayEqual(t, want, got)
+
+	strs, err := compute.CastArray(ctx, slice, arrow.BinaryTypes.String, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer strs.Release()
+
+	wantStrs := arrayOf(mem, arrow.BinaryTypes.String,
+		[]string{"1", "", "3", "4", "", "6", "7", "8", ""},
+		[]bool{true, false, true, true, false, true, true, true, false})
+	defer wantStrs.Release()
+	assertArrayEqual(t, wantStrs, strs)
+}
+
+func TestCastRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "b", Type: arrow.BinaryTypes.String},
+	}, nil)
+	cols := []array.Interface{
+		arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 3}, []bool{true, false, true}),
+		arrayOf(mem, arrow.BinaryTypes.String, []string{"1.5", "2", "x"}, nil),
+	}
+	defer cols[0].Release()
+	defer cols[1].Release()
+
+	rec := array.NewRecord(schema, cols, 3)
+	defer rec.Release()
+
+	toSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "b", Type: arrow.PrimitiveTypes.Float32},
+	}, nil)
+
+	_, err := compute.CastRecord(ctx, rec, toSchema, nil)
+	if !xerrors.Is(err, compute.ErrInvalid) || !strings.Contains(err.Error(), `column "b"`) {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	slice := rec.NewSlice(0, 2)
+	defer slice.Release()
+
+	got, err := compute.CastRecord(ctx, slice, toSchema, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(toSchema) {
+		t.Fatalf("invalid schema: got=%v, want=%v", got.Schema(), toSchema)
+	}
+
+	wantA := arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{1, 0}, []bool{true, false})
+	defer wantA.Release()
+	wantB := arrayOf(mem, arrow.PrimitiveTypes.Float32, []float32{1.5, 2}, nil)
+	defer wantB.Release()
+	assertArrayEqual(t, wantA, got.Column(0))
+	assertArrayEqual(t, wantB, got.Column(1))
+
+	_, err = compute.CastRecord(ctx, rec, arrow.NewSchema(toSchema.Fields()[:1], nil), nil)
+	if !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func TestCastDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	in := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{3, 1, 3, 0, 1}, []bool{true, true, true, false, true})
+	defer in.Release()
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	got, err := compute.CastArray(ctx, in, dt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !arrow.TypeEqual(got.DataType(), dt) {
+		t.Fatalf("invalid type: got=%v, want=%v", got.DataType(), dt)
+	}
+	dict := got.(*array.Dictionary)
+	wantDict := arrayOf(mem, arrow.BinaryTypes.String, []string{"3", "1"}, nil)
+	defer wantDict.Release()
+	assertArrayEqual(t, wantDict, dict.Dictionary())
+	wantIdx := arrayOf(mem, arrow.PrimitiveTypes.Int8, []int8{0, 1, 0, 0, 1}, []bool{true, true, true, false, true})
+	defer wantIdx.Release()
+	assertArrayEqual(t, wantIdx, dict.Indices())
+
+	// dictionary to dictionary with another index type.
+	dt16 := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}
+	got16, err := compute.CastArray(ctx, got, dt16, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got16.Release()
+	assertArrayEqual(t, wantDict, got16.(*array.Dictionary).Dictionary())
+
+	// and back to the values.
+	back, err := compute.CastArray(ctx, got16, arrow.PrimitiveTypes.Int64, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer back.Release()
+	assertArrayEqual(t, in, back)
+
+	_, err = compute.CastArray(ctx, in, arrow.ListOf(arrow.PrimitiveTypes.Int64), nil)
+	if !xerrors.Is(err, compute.ErrNotImplemented) || !strings.Contains(err.Error(), "from int64 to list<item: int64>") {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func TestCastTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.BinaryTypes.String},
+	}, nil)
+	a := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2}, nil)
+	defer a.Release()
+	b := arrayOf(mem, arrow.BinaryTypes.String, []string{"x", "y"}, nil)
+	defer b.Release()
+	rec := array.NewRecord(schema, []array.Interface{a, b}, 2)
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(schema, []array.Record{rec, rec})
+	defer tbl.Release()
+
+	toSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "b", Type: arrow.BinaryTypes.String},
+	}, nil)
+	got, err := compute.CastTable(ctx, tbl, toSchema, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(toSchema) || got.NumRows() != 4 {
+		t.Fatalf("invalid table: schema=%v, rows=%d", got.Schema(), got.NumRows())
+	}
+	wantA := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{1, 2}, nil)
+	defer wantA.Release()
+	for i, chunk := range got.Column(0).Data().Chunks() {
+		assertArrayEqual(t, wantA, chunk)
+		if got.Column(1).Data().Chunk(i) != b {
+			t.Fatalf("column b was not shared")
+		}
+	}
+
+	_, err = compute.CastTable(ctx, tbl, arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.PrimitiveTypes.Int32},
+	}, nil), nil)
+	if !xerrors.Is(err, compute.ErrInvalid) || !strings.Contains(err.Error(), `column "b"`) {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := compute.CastTable(ctx, tbl, arrow.NewSchema(toSchema.Fields()[:1], nil), nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
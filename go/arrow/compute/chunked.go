@@ -0,0 +1,224 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// ExecuteExpressions evaluates the bound expressions exprs over the rows of
+// rec for which the bound boolean expression filter is true, or over all
+// the rows of rec if filter is nil. It returns one array per expression,
+// holding as many values as there are selected rows.
+//
+// The rows of rec are processed in chunks of the size configured with
+// WithExecChunkSize. The filter of each chunk is turned into a selection of
+// its rows, which is only applied to the results of exprs: the columns of
+// rec are never copied, and intermediate results are released as soon as
+// their chunk is done.
+//
+// The returned arrays must be Release()'d after use.
+func ExecuteExpressions(ctx context.Context, exprs []Expression, filter Expression, rec array.Record) ([]array.Interface, error) {
+	if filter != nil {
+		switch dt := filter.DataType(); {
+		case dt == nil:
+			return nil, xerrors.Errorf("arrow/compute: expression %v is not bound: %w", filter, ErrInvalid)
+		case dt.ID() != arrow.BOOL:
+			return nil, xerrors.Errorf("arrow/compute: filter %v must be a boolean expression, got %v: %w", filter, dt, ErrInvalid)
+		}
+	}
+	for _, expr := range exprs {
+		if expr.DataType() == nil {
+			return nil, xerrors.Errorf("arrow/compute: expression %v is not bound: %w", expr, ErrInvalid)
+		}
+	}
+	return execChunked(ctx, rec, filter, exprs, GetExecChunkSize(ctx))
+}
+
+// execChunked evaluates exprs over the rows of rec selected by filter, in
+// chunks of size rows evaluated concurrently by a bounded pool of workers,
+// and concatenates the results of the chunks.
+func execChunked(ctx context.Context, rec array.Record, filter Expression, exprs []Expression, size int) ([]array.Interface, error) {
+	nrows := int(rec.NumRows())
+	if size <= 0 || size > nrows {
+		size = nrows
+	}
+	nchunks := 1
+	if size > 0 {
+		nchunks = (nrows + size - 1) / size
+	}
+
+	var (
+		results = make([][]array.Interface, nchunks)
+		errs    = make([]error, nchunks)
+		next    = int64(-1)
+		failed  int32
+		wg      sync.WaitGroup
+	)
+	defer func() {
+		for _, res := range results {
+			releaseArrays(res)
+		}
+	}()
+
+	workers := min(runtime.GOMAXPROCS(0), nchunks)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= nchunks || atomic.LoadInt32(&failed) != 0 {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					atomic.StoreInt32(&failed, 1)
+					return
+				}
+				chunk := rec.NewSlice(int64(i*size), int64(min(nrows, (i+1)*size)))
+				results[i], errs[i] = execChunk(ctx, chunk, filter, exprs)
+				chunk.Release()
+				if errs[i] != nil {
+					atomic.StoreInt32(&failed, 1)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if nchunks == 1 {
+		out := results[0]
+		results[0] = nil
+		return out, nil
+	}
+
+	mem := GetAllocator(ctx)
+	out := make([]array.Interface, 0, len(exprs))
+	chunks := make([]array.Interface, nchunks)
+	for j, expr := range exprs {
+		for i, res := range results {
+			chunks[i] = res[j]
+		}
+		arr, err := concatenate(mem, expr.DataType(), chunks)
+		if err != nil {
+			releaseArrays(out)
+			return nil, err
+		}
+		out = append(out, arr)
+	}
+	return out, nil
+}
+
+// execChunk evaluates exprs over the rows of rec selected by filter.
+func execChunk(ctx context.Context, rec array.Record, filter Expression, exprs []Expression) ([]array.Interface, error) {
+	mem := GetAllocator(ctx)
+	n := int(rec.NumRows())
+
+	// a nil selection selects all the rows.
+	var sel *selection
+	if filter != nil {
+		mask, err := filter.eval(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		sel = &selection{}
+		switch mask := mask.(type) {
+		case *ScalarDatum:
+			if v, ok := mask.Value.(*scalar.Boolean); ok && v.Valid && v.Value {
+				sel = nil
+			}
+		case *ArrayDatum:
+			filterSelection(sel, mask.Value.(*array.Boolean), 0, nil)
+			if sel.n == n {
+				sel = nil
+			}
+		}
+		mask.Release()
+	}
+
+	out := make([]array.Interface, 0, len(exprs))
+	for _, expr := range exprs {
+		if sel != nil && sel.n == 0 {
+			out = append(out, makeNullArray(mem, expr.DataType(), 0))
+			continue
+		}
+		arr, err := evalArray(ctx, expr, rec)
+		if err != nil {
+			releaseArrays(out)
+			return nil, err
+		}
+		if sel != nil {
+			selected, err := gather(mem, []array.Interface{arr}, sel)
+			arr.Release()
+			if err != nil {
+				releaseArrays(out)
+				return nil, err
+			}
+			arr = selected
+		}
+		out = append(out, arr)
+	}
+	return out, nil
+}
+
+// evalArray evaluates expr over rec, broadcasting scalar results to the
+// length of rec.
+func evalArray(ctx context.Context, expr Expression, rec array.Record) (array.Interface, error) {
+	d, err := expr.eval(ctx, rec)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Release()
+	switch d := d.(type) {
+	case *ArrayDatum:
+		d.Value.Retain()
+		return d.Value, nil
+	case *ScalarDatum:
+		return scalar.MakeArrayFromScalar(d.Value, int(rec.NumRows()), GetAllocator(ctx))
+	}
+	return nil, xerrors.Errorf("arrow/compute: expression %v evaluated to a %v datum: %w", expr, d.Kind(), ErrInvalid)
+}
+
+// referencesFields reports whether expr references any column.
+func referencesFields(expr Expression) bool {
+	switch expr := expr.(type) {
+	case *FieldRef:
+		return true
+	case *Call:
+		for _, arg := range expr.args {
+			if referencesFields(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
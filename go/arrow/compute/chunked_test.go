@@ -0,0 +1,351 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// syncAllocator is a checked allocator safe for concurrent use, which
+// records the peak of the allocated memory.
+type syncAllocator struct {
+	mu   sync.Mutex
+	mem  *memory.CheckedAllocator
+	cur  int
+	peak int
+}
+
+func newSyncAllocator() *syncAllocator {
+	return &syncAllocator{mem: memory.NewCheckedAllocator(memory.NewGoAllocator())}
+}
+
+func (a *syncAllocator) Allocate(size int) []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.grow(size)
+	return a.mem.Allocate(size)
+}
+
+func (a *syncAllocator) Reallocate(size int, b []byte) []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.grow(size - len(b))
+	return a.mem.Reallocate(size, b)
+}
+
+func (a *syncAllocator) Free(b []byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.grow(-len(b))
+	a.mem.Free(b)
+}
+
+func (a *syncAllocator) grow(n int) {
+	a.cur += n
+	if a.cur > a.peak {
+		a.peak = a.cur
+	}
+}
+
+func (a *syncAllocator) AssertSize(t *testing.T, sz int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mem.AssertSize(t, sz)
+}
+
+func pipelineRecord(mem memory.Allocator, n int) array.Record {
+	var (
+		rng   = rand.New(rand.NewSource(1))
+		a     = make([]int64, n)
+		b     = make([]float64, n)
+		s     = make([]string, n)
+		valid = make([]bool, n)
+	)
+	for i := range a {
+		a[i] = rng.Int63n(100)
+		b[i] = rng.Float64()
+		s[i] = string(rune('a' + rng.Intn(26)))
+		valid[i] = rng.Intn(10) != 0
+	}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "b", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	cols := []array.Interface{
+		arrayOf(mem, arrow.PrimitiveTypes.Int64, a, valid),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, b, nil),
+		arrayOf(mem, arrow.BinaryTypes.String, s, valid),
+	}
+	defer releaseAll(cols)
+	return array.NewRecord(schema, cols, int64(n))
+}
+
+func bindAll(t testing.TB, schema *arrow.Schema, exprs ...compute.Expression) []compute.Expression {
+	t.Helper()
+	out := make([]compute.Expression, len(exprs))
+	for i, expr := range exprs {
+		bound, err := expr.Bind(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[i] = bound
+	}
+	return out
+}
+
+func TestExecuteExpressions(t *testing.T) {
+	mem := newSyncAllocator()
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	const n = 1000
+	rec := pipelineRecord(mem, n)
+	defer rec.Release()
+
+	exprs := bindAll(t, rec.Schema(),
+		compute.NewCall("multiply", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt64Scalar(3))),
+		compute.NewCall("add", compute.NewFieldRef("b"), compute.NewFieldRef("b")),
+		compute.NewCall("utf8_upper", compute.NewFieldRef("s")),
+		compute.NewLiteral(scalar.NewInt32Scalar(7)),
+	)
+	filters := bindAll(t, rec.Schema(),
+		compute.NewCall("greater", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt64Scalar(30))),
+		compute.NewCall("less", compute.NewFieldRef("b"), compute.NewLiteral(scalar.NewFloat64Scalar(-1))),
+		compute.NewLiteral(scalar.NewBooleanScalar(true)),
+		compute.NewLiteral(scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean)),
+	)
+
+	for _, filter := range append(filters, nil) {
+		// the reference results are computed by filtering the record, and
+		// evaluating the expressions whole over the filtered rows.
+		filtered := rec
+		filtered.Retain()
+		if filter != nil {
+			mask, err := compute.ExecuteScalarExpression(ctx, filter, rec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			maskArr, err := scalar.MakeArrayFromScalar(scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean), n, mem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch mask := mask.(type) {
+			case *compute.ArrayDatum:
+				maskArr.Release()
+				maskArr = mask.Value
+				maskArr.Retain()
+			case *compute.ScalarDatum:
+				maskArr.Release()
+				if maskArr, err = scalar.MakeArrayFromScalar(mask.Value, n, mem); err != nil {
+					t.Fatal(err)
+				}
+			}
+			mask.Release()
+			filtered.Release()
+			if filtered, err = compute.FilterRecord(ctx, rec, maskArr, nil); err != nil {
+				t.Fatal(err)
+			}
+			maskArr.Release()
+		}
+
+		want := make([]array.Interface, len(exprs))
+		for i, expr := range exprs {
+			d, err := compute.ExecuteScalarExpression(ctx, expr, filtered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch d := d.(type) {
+			case *compute.ArrayDatum:
+				want[i] = d.Value
+				want[i].Retain()
+			case *compute.ScalarDatum:
+				if want[i], err = scalar.MakeArrayFromScalar(d.Value, int(filtered.NumRows()), mem); err != nil {
+					t.Fatal(err)
+				}
+			}
+			d.Release()
+		}
+		filtered.Release()
+
+		for _, size := range []int{0, 1, 7, 64, n - 1, n, 2 * n} {
+			got, err := compute.ExecuteExpressions(compute.WithExecChunkSize(ctx, size), exprs, filter, rec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range exprs {
+				if !array.ArrayEqual(got[i], want[i]) {
+					t.Errorf("filter %v, chunk size %d: invalid result for %v:\ngot= %v\nwant=%v", filter, size, exprs[i], got[i], want[i])
+				}
+			}
+			releaseAll(got)
+		}
+		releaseAll(want)
+	}
+}
+
+func TestExecuteScalarExpressionChunked(t *testing.T) {
+	mem := newSyncAllocator()
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rec := pipelineRecord(mem, 500)
+	defer rec.Release()
+
+	exprs := bindAll(t, rec.Schema(),
+		compute.NewCall("coalesce", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt64Scalar(-1))),
+		compute.NewCall("and_kleene",
+			compute.NewCall("is_valid", compute.NewFieldRef("s")),
+			compute.NewCall("greater", compute.NewFieldRef("b"), compute.NewLiteral(scalar.NewFloat64Scalar(0.5)))),
+		compute.NewCall("add", compute.NewLiteral(scalar.NewInt64Scalar(1)), compute.NewLiteral(scalar.NewInt64Scalar(2))),
+	)
+	for _, expr := range exprs {
+		want, err := compute.ExecuteScalarExpression(ctx, expr, rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := compute.ExecuteScalarExpression(compute.WithExecChunkSize(ctx, 33), expr, rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Kind() != want.Kind() {
+			t.Fatalf("%v: invalid datum kind: got=%v, want=%v", expr, got.Kind(), want.Kind())
+		}
+		switch want := want.(type) {
+		case *compute.ArrayDatum:
+			if !array.ArrayEqual(got.(*compute.ArrayDatum).Value, want.Value) {
+				t.Errorf("%v: invalid result:\ngot= %v\nwant=%v", expr, got, want)
+			}
+		case *compute.ScalarDatum:
+			if !got.(*compute.ScalarDatum).Value.Equals(want.Value) {
+				t.Errorf("%v: invalid result: got=%v, want=%v", expr, got, want)
+			}
+		}
+		got.Release()
+		want.Release()
+	}
+}
+
+func TestExecuteExpressionsErrors(t *testing.T) {
+	mem := newSyncAllocator()
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithExecChunkSize(compute.WithAllocator(context.Background(), mem), 10)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	values := make([]int64, 100)
+	values[95] = math.MaxInt64
+	col := arrayOf(mem, arrow.PrimitiveTypes.Int64, values, nil)
+	rec := array.NewRecord(schema, []array.Interface{col}, 100)
+	col.Release()
+	defer rec.Release()
+
+	overflow := bindAll(t, schema, compute.NewCall("add_checked", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt64Scalar(1))))
+	for _, tc := range []struct {
+		name   string
+		exprs  []compute.Expression
+		filter compute.Expression
+	}{
+		{"unbound", []compute.Expression{compute.NewFieldRef("a")}, nil},
+		{"unbound filter", nil, compute.NewFieldRef("a")},
+		{"non-boolean filter", nil, overflow[0]},
+		{"overflow", overflow, nil},
+	} {
+		_, err := compute.ExecuteExpressions(ctx, tc.exprs, tc.filter, rec)
+		if !xerrors.Is(err, compute.ErrInvalid) {
+			t.Errorf("%s: invalid error: got=%v, want=%v", tc.name, err, compute.ErrInvalid)
+		}
+	}
+
+	// the overflowing row is not selected by the filter.
+	filter := bindAll(t, schema, compute.NewCall("less", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt64Scalar(1))))[0]
+	out, err := compute.ExecuteExpressions(ctx, overflow, filter, rec)
+	if !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, compute.ErrInvalid)
+	}
+	releaseAll(out)
+}
+
+// BenchmarkPipeline evaluates a filter, an arithmetic expression over the
+// selected rows and the sum of its results, and reports the peak memory
+// allocated to do so.
+func BenchmarkPipeline(b *testing.B) {
+	mem := newSyncAllocator()
+	rec := pipelineRecord(mem, 1<<20)
+	defer rec.Release()
+
+	exprs := bindAll(b, rec.Schema(),
+		compute.NewCall("greater", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt64Scalar(50))),
+		compute.NewCall("multiply", compute.NewFieldRef("a"), compute.NewFieldRef("b")),
+	)
+	filter, product := exprs[0], exprs[1]
+
+	run := func(b *testing.B, ctx context.Context, pipeline func(ctx context.Context) array.Interface) {
+		base := mem.cur
+		mem.peak = base
+		for i := 0; i < b.N; i++ {
+			arr := pipeline(ctx)
+			out := compute.NewDatum(arr)
+			arr.Release()
+			if _, err := compute.Sum(ctx, out, nil); err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+		}
+		b.ReportMetric(float64(mem.peak-base), "peak-B")
+	}
+
+	b.Run("whole", func(b *testing.B) {
+		run(b, compute.WithAllocator(context.Background(), mem), func(ctx context.Context) array.Interface {
+			mask, err := compute.ExecuteScalarExpression(ctx, filter, rec)
+			if err != nil {
+				b.Fatal(err)
+			}
+			filtered, err := compute.FilterRecord(ctx, rec, mask.(*compute.ArrayDatum).Value, nil)
+			mask.Release()
+			if err != nil {
+				b.Fatal(err)
+			}
+			res, err := compute.ExecuteScalarExpression(ctx, product, filtered)
+			filtered.Release()
+			if err != nil {
+				b.Fatal(err)
+			}
+			return res.(*compute.ArrayDatum).Value
+		})
+	})
+	b.Run("chunked", func(b *testing.B) {
+		ctx := compute.WithExecChunkSize(compute.WithAllocator(context.Background(), mem), 1<<14)
+		run(b, ctx, func(ctx context.Context) array.Interface {
+			out, err := compute.ExecuteExpressions(ctx, []compute.Expression{product}, filter, rec)
+			if err != nil {
+				b.Fatal(err)
+			}
+			return out[0]
+		})
+	})
+}
@@ -0,0 +1,246 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// Equal returns a boolean datum holding whether the values of left and
+// right are equal.
+//
+// The comparison functions accept arrays, chunked arrays and scalars of
+// numeric, decimal, temporal, string and binary types. Scalars are
+// broadcast against arrays, and a null on either side produces a null.
+//
+// Numeric and decimal inputs are promoted to a common type, as for Add.
+// Floating point values follow IEEE-754: NaN is not equal to, less or
+// greater than any value, including itself, so that NotEqual is the only
+// function returning true for NaNs. String and binary values are compared
+// byte-wise, without collation. Temporal inputs must have the same type,
+// and timestamps the same unit and time zone.
+func Equal(ctx context.Context, left, right Datum) (Datum, error) {
+	return compare(ctx, cmpEq, left, right)
+}
+
+// NotEqual returns a boolean datum holding whether the values of left and
+// right differ. See Equal for the supported types.
+func NotEqual(ctx context.Context, left, right Datum) (Datum, error) {
+	return compare(ctx, cmpNe, left, right)
+}
+
+// Less returns a boolean datum holding whether the values of left are
+// less than those of right. See Equal for the supported types.
+func Less(ctx context.Context, left, right Datum) (Datum, error) {
+	return compare(ctx, cmpLt, left, right)
+}
+
+// LessEqual returns a boolean datum holding whether the values of left are
+// less than or equal to those of right. See Equal for the supported types.
+func LessEqual(ctx context.Context, left, right Datum) (Datum, error) {
+	return compare(ctx, cmpLe, left, right)
+}
+
+// Greater returns a boolean datum holding whether the values of left are
+// greater than those of right. See Equal for the supported types.
+func Greater(ctx context.Context, left, right Datum) (Datum, error) {
+	return compare(ctx, cmpLt, right, left)
+}
+
+// GreaterEqual returns a boolean datum holding whether the values of left
+// are greater than or equal to those of right. See Equal for the supported
+// types.
+func GreaterEqual(ctx context.Context, left, right Datum) (Datum, error) {
+	return compare(ctx, cmpLe, right, left)
+}
+
+// cmpOp is a comparison operator. Greater and GreaterEqual are computed
+// as Less and LessEqual with swapped operands.
+type cmpOp int8
+
+const (
+	cmpEq cmpOp = iota
+	cmpNe
+	cmpLt
+	cmpLe
+)
+
+// eval returns the result of the comparison from the result of a
+// three-way comparison.
+func (op cmpOp) eval(c int) bool {
+	switch op {
+	case cmpEq:
+		return c == 0
+	case cmpNe:
+		return c != 0
+	case cmpLt:
+		return c < 0
+	}
+	return c <= 0
+}
+
+func compare(ctx context.Context, op cmpOp, left, right Datum) (Datum, error) {
+	kernel, err := comparisonKernel(op, left.DataType(), right.DataType())
+	if err != nil {
+		return nil, err
+	}
+	return execBinary(GetAllocator(ctx), left, right, kernel)
+}
+
+func isBinaryLike(id arrow.Type) bool {
+	return id == arrow.STRING || id == arrow.BINARY
+}
+
+func comparisonKernel(op cmpOp, lt, rt arrow.DataType) (binaryKernel, error) {
+	var (
+		lid, rid = lt.ID(), rt.ID()
+		lnum     = isInteger(lid) || isFloating(lid)
+		rnum     = isInteger(rid) || isFloating(rid)
+	)
+	switch {
+	case lnum && rnum:
+		return numericComparison(op, promoteNumeric(lt, rt)), nil
+	case isDecimal(lid) && isFloating(rid), isFloating(lid) && isDecimal(rid):
+		return numericComparison(op, arrow.PrimitiveTypes.Float64), nil
+	case isDecimal(lid) || isDecimal(rid):
+		ld, lok := asDecimal(lt)
+		rd, rok := asDecimal(rt)
+		if lok && rok {
+			return decimalComparison(op, ld, rd), nil
+		}
+	case isBinaryLike(lid) && isBinaryLike(rid):
+		return binaryComparison(op), nil
+	case isTemporal(lid) && isTemporal(rid):
+		if !arrow.TypeEqual(lt, rt) {
+			return nil, xerrors.Errorf("arrow/compute: cannot compare %v and %v: %w", lt, rt, ErrInvalid)
+		}
+		return temporalComparison(op, lt), nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: comparison is not implemented for %v and %v: %w", lt, rt, ErrNotImplemented)
+}
+
+// comparisonKernelFunc returns a kernel writing the result of cmp over l and r,
+// of length n, into a bitmap.
+func comparisonKernelFunc(cmp func(l, r operand, out []byte, n int)) binaryKernel {
+	return func(mem memory.Allocator, l, r operand) (array.Interface, error) {
+		n := outLen(l, r)
+		validity, nulls := binaryValidity(mem, l, r, n)
+		values := newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		if nulls < n {
+			cmp(l, r, values.Bytes(), n)
+		}
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+	}
+}
+
+func numericComparison(op cmpOp, dt arrow.DataType) binaryKernel {
+	return func(mem memory.Allocator, left, right operand) (array.Interface, error) {
+		l, err := castOperand(mem, left, dt, UnsafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer l.Release()
+		r, err := castOperand(mem, right, dt, UnsafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer r.Release()
+
+		return comparisonKernelFunc(func(l, r operand, out []byte, n int) {
+			compareNumeric(op, dt, l.Interface, r.Interface, out, n)
+		})(mem, l, r)
+	}
+}
+
+func temporalComparison(op cmpOp, dt arrow.DataType) binaryKernel {
+	storage := storageType(dt)
+	return comparisonKernelFunc(func(l, r operand, out []byte, n int) {
+		li := reinterpret(l, storage)
+		defer li.Release()
+		ri := reinterpret(r, storage)
+		defer ri.Release()
+		compareNumeric(op, storage, li, ri, out, n)
+	})
+}
+
+func decimalComparison(op cmpOp, lt, rt arrow.DataType) binaryKernel {
+	// values are compared at the largest of the two scales.
+	var (
+		_, lscale = decimalParams(lt)
+		_, rscale = decimalParams(rt)
+		scale     = maxInt32(lscale, rscale)
+		lf, rf    = pow10(scale - lscale), pow10(scale - rscale)
+	)
+
+	return func(mem memory.Allocator, left, right operand) (array.Interface, error) {
+		l, err := castOperand(mem, left, lt, SafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer l.Release()
+		r, err := castOperand(mem, right, rt, SafeCastOptions())
+		if err != nil {
+			return nil, err
+		}
+		defer r.Release()
+
+		return comparisonKernelFunc(func(l, r operand, out []byte, n int) {
+			ls, rs := stride(l.Len(), n), stride(r.Len(), n)
+			for i := 0; i < n; i++ {
+				a := decimalValue(l.Interface, i*ls)
+				b := decimalValue(r.Interface, i*rs)
+				if op.eval(a.Mul(a, lf).Cmp(b.Mul(b, rf))) {
+					bitutil.SetBit(out, i)
+				}
+			}
+		})(mem, l, r)
+	}
+}
+
+func binaryComparison(op cmpOp) binaryKernel {
+	return comparisonKernelFunc(func(l, r operand, out []byte, n int) {
+		var (
+			lo, lb = binaryValues(l)
+			ro, rb = binaryValues(r)
+			ls, rs = stride(l.Len(), n), stride(r.Len(), n)
+		)
+		for i := 0; i < n; i++ {
+			a := lb[lo[i*ls]:lo[i*ls+1]]
+			b := rb[ro[i*rs]:ro[i*rs+1]]
+			if op.eval(bytes.Compare(a, b)) {
+				bitutil.SetBit(out, i)
+			}
+		}
+	})
+}
+
+// binaryValues returns the offsets and the data buffer of a String or
+// Binary array.
+func binaryValues(arr array.Interface) ([]int32, []byte) {
+	var data []byte
+	if buf := arr.Data().Buffers()[2]; buf != nil {
+		data = buf.Bytes()
+	}
+	return valueOffsets(arr), data
+}
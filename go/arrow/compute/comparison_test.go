@@ -0,0 +1,305 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+type compareFunc func(context.Context, compute.Datum, compute.Datum) (compute.Datum, error)
+
+func TestCompare(t *testing.T) {
+	var (
+		i32  = arrow.PrimitiveTypes.Int32
+		u8   = arrow.PrimitiveTypes.Uint8
+		f64  = arrow.PrimitiveTypes.Float64
+		str  = arrow.BinaryTypes.String
+		bin  = arrow.BinaryTypes.Binary
+		tsS  = &arrow.TimestampType{Unit: arrow.Second}
+		dec1 = &arrow.Decimal128Type{Precision: 5, Scale: 1}
+		dec2 = &arrow.Decimal128Type{Precision: 5, Scale: 2}
+		d256 = &arrow.Decimal256Type{Precision: 60, Scale: 2}
+		nan  = math.NaN()
+	)
+
+	for _, tc := range []struct {
+		name    string
+		fn      compareFunc
+		lt      arrow.DataType
+		lhs     interface{}
+		lvalid  []bool
+		lscalar scalar.Scalar
+		rt      arrow.DataType
+		rhs     interface{}
+		rvalid  []bool
+		rscalar scalar.Scalar
+		want    []bool
+		wvalid  []bool
+		err     error
+	}{
+		{
+			name: "equal", fn: compute.Equal,
+			lt: i32, lhs: []int32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			rt: i32, rhs: []int32{1, 0, 3, 0, 5, 0, 7, 0, 9},
+			want: []bool{true, false, true, false, true, false, true, false, true},
+		},
+		{
+			name: "not-equal-nulls", fn: compute.NotEqual,
+			lt: i32, lhs: []int32{1, 2, 3}, lvalid: []bool{true, false, true},
+			rt: i32, rhs: []int32{1, 2, 4},
+			want: []bool{false, false, true}, wvalid: []bool{true, false, true},
+		},
+		{
+			name: "less-scalar", fn: compute.Less,
+			lt: i32, lhs: []int32{1, 2, 3},
+			rscalar: scalar.NewInt32Scalar(2),
+			want:    []bool{true, false, false},
+		},
+		{
+			name: "less-equal-scalar-left", fn: compute.LessEqual,
+			lscalar: scalar.NewInt32Scalar(2),
+			rt:      i32, rhs: []int32{1, 2, 3},
+			want: []bool{false, true, true},
+		},
+		{
+			name: "greater-mixed", fn: compute.Greater,
+			lt: u8, lhs: []uint8{255, 0, 10},
+			rt: i32, rhs: []int32{-1, 0, 10},
+			want: []bool{true, false, false},
+		},
+		{
+			name: "greater-equal-float", fn: compute.GreaterEqual,
+			lt: i32, lhs: []int32{1, 2, 3},
+			rt: f64, rhs: []float64{1.5, 2, 2.5},
+			want: []bool{false, true, true},
+		},
+		{
+			name: "null-scalar", fn: compute.Equal,
+			lt: i32, lhs: []int32{1, 2},
+			rscalar: scalar.MakeNullScalar(i32),
+			want:    []bool{false, false}, wvalid: []bool{false, false},
+		},
+		{
+			name: "nan-equal", fn: compute.Equal,
+			lt: f64, lhs: []float64{nan, nan, 1},
+			rt: f64, rhs: []float64{nan, 1, 1},
+			want: []bool{false, false, true},
+		},
+		{
+			name: "nan-not-equal", fn: compute.NotEqual,
+			lt: f64, lhs: []float64{nan, nan, 1},
+			rt: f64, rhs: []float64{nan, 1, 1},
+			want: []bool{true, true, false},
+		},
+		{
+			name: "nan-less", fn: compute.Less,
+			lt: f64, lhs: []float64{nan, 0, math.Inf(-1)},
+			rt: f64, rhs: []float64{0, nan, nan},
+			want: []bool{false, false, false},
+		},
+		{
+			name: "nan-greater-equal", fn: compute.GreaterEqual,
+			lt: f64, lhs: []float64{nan, 0, math.Inf(1)},
+			rt: f64, rhs: []float64{0, nan, nan},
+			want: []bool{false, false, false},
+		},
+		{
+			name: "string", fn: compute.Less,
+			lt: str, lhs: []string{"a", "b", "", "ab", "é"},
+			rt: str, rhs: []string{"b", "a", "a", "a", "z"},
+			want: []bool{true, false, true, false, false},
+		},
+		{
+			name: "string-binary", fn: compute.Equal,
+			lt: str, lhs: []string{"a", "b", ""}, lvalid: []bool{true, true, false},
+			rt: bin, rhs: [][]byte{[]byte("a"), []byte("c"), nil},
+			want: []bool{true, false, false}, wvalid: []bool{true, true, false},
+		},
+		{
+			name: "string-scalar", fn: compute.GreaterEqual,
+			lt: str, lhs: []string{"abc", "abd", "ab"},
+			rscalar: scalar.NewStringScalar("abd"),
+			want:    []bool{false, true, false},
+		},
+		{
+			name: "timestamp", fn: compute.Less,
+			lt: tsS, lhs: []arrow.Timestamp{1, 2, 3},
+			rt: tsS, rhs: []arrow.Timestamp{2, 2, 2},
+			want: []bool{true, false, false},
+		},
+		{
+			name: "timestamp-units", fn: compute.Less,
+			lt: tsS, lhs: []arrow.Timestamp{1},
+			rt: &arrow.TimestampType{Unit: arrow.Millisecond}, rhs: []arrow.Timestamp{1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "timestamp-zones", fn: compute.Equal,
+			lt: tsS, lhs: []arrow.Timestamp{1},
+			rt: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}, rhs: []arrow.Timestamp{1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "date32", fn: compute.NotEqual,
+			lt: arrow.FixedWidthTypes.Date32, lhs: []arrow.Date32{1, 2},
+			rt: arrow.FixedWidthTypes.Date32, rhs: []arrow.Date32{1, 3},
+			want: []bool{false, true},
+		},
+		{
+			// 1.5, 2.0, -0.1 vs 1.50, 1.99, -0.11
+			name: "decimal-scales", fn: compute.LessEqual,
+			lt: dec1, lhs: []decimal128.Num{dec(15), dec(20), dec(-1)},
+			rt: dec2, rhs: []decimal128.Num{dec(150), dec(199), dec(-11)},
+			want: []bool{true, false, false},
+		},
+		{
+			name: "decimal-int", fn: compute.Equal,
+			lt: dec1, lhs: []decimal128.Num{dec(20), dec(25)},
+			rt: i32, rhs: []int32{2, 2},
+			want: []bool{true, false},
+		},
+		{
+			// 1.5, 2.0, -0.1, 10^55 vs 1.50, 1.99, -0.11, 10^54
+			name: "decimal256-scales", fn: compute.Greater,
+			lt: dec1, lhs: []decimal128.Num{dec(15), dec(20), dec(-1), dec(0)},
+			rt: d256, rhs: []decimal256.Num{dec256("150"), dec256("199"), dec256("-11"), dec256("-1" + strings.Repeat("0", 56))},
+			want: []bool{false, true, true, true},
+		},
+		{
+			name: "decimal256-float", fn: compute.Less,
+			lt: d256, lhs: []decimal256.Num{dec256("150"), dec256("1" + strings.Repeat("0", 54))},
+			rt: f64, rhs: []float64{1.6, 1e51},
+			want: []bool{true, false},
+		},
+		{
+			name: "not-implemented", fn: compute.Equal,
+			lt: str, lhs: []string{"1"},
+			rt: i32, rhs: []int32{1},
+			err: compute.ErrNotImplemented,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			lhs := datumOf(mem, tc.lt, tc.lhs, tc.lvalid, tc.lscalar)
+			defer lhs.Release()
+			rhs := datumOf(mem, tc.rt, tc.rhs, tc.rvalid, tc.rscalar)
+			defer rhs.Release()
+
+			got, err := tc.fn(ctx, lhs, rhs)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, arrow.FixedWidthTypes.Boolean, tc.want, tc.wvalid)
+			defer want.Release()
+			assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestCompareSliced(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	arr := arrayOf(mem, arrow.BinaryTypes.String, []string{"x", "a", "b", "c", "d"}, []bool{true, true, false, true, true})
+	defer arr.Release()
+	slice := array.NewSlice(arr, 1, 5)
+	defer slice.Release()
+	lhs := compute.NewDatum(slice)
+	defer lhs.Release()
+
+	got, err := compute.Equal(ctx, lhs, compute.NewDatum(scalar.NewStringScalar("c")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	want := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{false, false, true, false}, []bool{true, false, true, true})
+	defer want.Release()
+	assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+}
+
+func BenchmarkCompare(b *testing.B) {
+	const n = 10000000
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var (
+		ints = make([]int64, n)
+		strs = make([]string, n)
+	)
+	for i := range ints {
+		ints[i] = int64(i % 1000)
+		strs[i] = string(rune('a' + i%26))
+	}
+
+	for _, bc := range []struct {
+		name   string
+		dt     arrow.DataType
+		values interface{}
+		scalar scalar.Scalar
+	}{
+		{"int64", arrow.PrimitiveTypes.Int64, ints, scalar.NewInt64Scalar(500)},
+		{"utf8", arrow.BinaryTypes.String, strs, scalar.NewStringScalar("m")},
+	} {
+		arr := arrayOf(mem, bc.dt, bc.values, nil)
+		datum := compute.NewDatum(arr)
+		arr.Release()
+
+		for _, rc := range []struct {
+			name  string
+			right compute.Datum
+		}{
+			{"array", datum},
+			{"scalar", compute.NewDatum(bc.scalar)},
+		} {
+			b.Run(bc.name+"/"+rc.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					res, err := compute.Less(ctx, datum, rc.right)
+					if err != nil {
+						b.Fatal(err)
+					}
+					res.Release()
+				}
+			})
+		}
+		datum.Release()
+	}
+}
@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// arrayOf builds an array of type dt from the Go slice values. Values are
+// converted to the argument type of the builder's Append method. If valid
+// is not nil, the elements where it is false are null.
+func arrayOf(mem memory.Allocator, dt arrow.DataType, values interface{}, valid []bool) array.Interface {
+	bldr := array.NewBuilder(mem, dt)
+	defer bldr.Release()
+
+	var (
+		rv       = reflect.ValueOf(values)
+		appendFn = reflect.ValueOf(bldr).MethodByName("Append")
+		argT     = appendFn.Type().In(0)
+	)
+	for i := 0; i < rv.Len(); i++ {
+		if valid != nil && !valid[i] {
+			bldr.AppendNull()
+			continue
+		}
+		appendFn.Call([]reflect.Value{rv.Index(i).Convert(argT)})
+	}
+	return bldr.NewArray()
+}
+
+func assertArrayEqual(t *testing.T, want, got array.Interface) {
+	t.Helper()
+	if !array.ArrayEqual(want, got) {
+		t.Fatalf("arrays differ:\ngot= %v (%v)\nwant=%v (%v)", got, got.DataType(), want, want.DataType())
+	}
+}
+
+// randomArray returns an array of n random values of type dt, where values
+// are null with probability nulls.
+func randomArray(mem memory.Allocator, rng *rand.Rand, dt arrow.DataType, n int, nulls float64) array.Interface {
+	valid := make([]bool, n)
+	for i := range valid {
+		valid[i] = rng.Float64() >= nulls
+	}
+	randomString := func() string { return "abcdefgh"[:rng.Intn(9)] }
+
+	switch dt := dt.(type) {
+	case *arrow.Int32Type:
+		vs := make([]int32, n)
+		for i := range vs {
+			vs[i] = rng.Int31()
+		}
+		return arrayOf(mem, dt, vs, valid)
+	case *arrow.Float64Type:
+		vs := make([]float64, n)
+		for i := range vs {
+			vs[i] = rng.NormFloat64()
+		}
+		return arrayOf(mem, dt, vs, valid)
+	case *arrow.BooleanType:
+		vs := make([]bool, n)
+		for i := range vs {
+			vs[i] = rng.Intn(2) == 0
+		}
+		return arrayOf(mem, dt, vs, valid)
+	case *arrow.StringType:
+		vs := make([]string, n)
+		for i := range vs {
+			vs[i] = randomString()
+		}
+		return arrayOf(mem, dt, vs, valid)
+	case *arrow.DictionaryType:
+		dict := randomArray(mem, rng, dt.ValueType, 5, 0)
+		defer dict.Release()
+		vs := make([]int8, n)
+		for i := range vs {
+			vs[i] = int8(rng.Intn(dict.Len()))
+		}
+		indices := arrayOf(mem, dt.IndexType, vs, valid)
+		defer indices.Release()
+		return array.NewDictionaryArray(dt, indices, dict)
+	case *arrow.ListType:
+		bldr := array.NewListBuilder(mem, dt.Elem())
+		defer bldr.Release()
+		vb := bldr.ValueBuilder().(*array.Int32Builder)
+		for _, ok := range valid {
+			if !ok {
+				bldr.AppendNull()
+				continue
+			}
+			bldr.Append(true)
+			for j := rng.Intn(4); j > 0; j-- {
+				vb.Append(rng.Int31())
+			}
+		}
+		return bldr.NewArray()
+	case *arrow.StructType:
+		bldr := array.NewStructBuilder(mem, dt)
+		defer bldr.Release()
+		for _, ok := range valid {
+			if !ok {
+				bldr.AppendNull()
+				continue
+			}
+			bldr.Append(true)
+			bldr.FieldBuilder(0).(*array.Int64Builder).Append(rng.Int63())
+			bldr.FieldBuilder(1).(*array.StringBuilder).Append(randomString())
+		}
+		return bldr.NewArray()
+	}
+	panic("unsupported type " + dt.Name())
+}
+
+// randomTypes are the types of the randomized cross-checks.
+var randomTypes = []arrow.DataType{
+	arrow.PrimitiveTypes.Int32,
+	arrow.PrimitiveTypes.Float64,
+	arrow.FixedWidthTypes.Boolean,
+	arrow.BinaryTypes.String,
+	&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String},
+	arrow.ListOf(arrow.PrimitiveTypes.Int32),
+	arrow.StructOf(
+		arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		arrow.Field{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+	),
+}
+
+// randomChunks splits arr into a chunked array of chunks of random lengths,
+// some of them empty.
+func randomChunks(rng *rand.Rand, arr array.Interface) *array.Chunked {
+	var chunks []array.Interface
+	for i := 0; i < arr.Len(); {
+		j := i + rng.Intn(arr.Len()-i+1)
+		chunks = append(chunks, array.NewSlice(arr, int64(i), int64(j)))
+		i = j
+	}
+	defer func() {
+		for _, c := range chunks {
+			c.Release()
+		}
+	}()
+	return array.NewChunked(arr.DataType(), chunks)
+}
@@ -0,0 +1,393 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// DatumKind identifies the kind of value held by a Datum.
+type DatumKind int8
+
+const (
+	KindScalar DatumKind = iota
+	KindArray
+	KindChunked
+	KindRecord
+	KindTable
+)
+
+func (k DatumKind) String() string {
+	switch k {
+	case KindScalar:
+		return "scalar"
+	case KindArray:
+		return "array"
+	case KindChunked:
+		return "chunked"
+	case KindRecord:
+		return "record"
+	case KindTable:
+		return "table"
+	}
+	return fmt.Sprintf("DatumKind(%d)", int8(k))
+}
+
+// Datum is an input or output value of a compute function: a scalar, an
+// array, a chunked array, a record or a table.
+//
+// Datums returned by compute functions must be Release()'d after use.
+type Datum interface {
+	fmt.Stringer
+	Kind() DatumKind
+	// DataType returns the type of the values held by the datum. Records
+	// and tables are seen as arrays of structs.
+	DataType() arrow.DataType
+	// Len returns the number of values held by the datum, 1 for scalars.
+	Len() int64
+	Release()
+}
+
+// ScalarDatum is a Datum holding a single value.
+type ScalarDatum struct {
+	Value scalar.Scalar
+}
+
+func (*ScalarDatum) Kind() DatumKind            { return KindScalar }
+func (d *ScalarDatum) DataType() arrow.DataType { return d.Value.DataType() }
+func (*ScalarDatum) Len() int64                 { return 1 }
+func (d *ScalarDatum) String() string           { return d.Value.String() }
+
+// Release releases the scalar value if it holds reference counted memory,
+// as List and Dictionary scalars do.
+func (d *ScalarDatum) Release() {
+	if r, ok := d.Value.(interface{ Release() }); ok {
+		r.Release()
+	}
+}
+
+// ArrayDatum is a Datum holding an array.
+type ArrayDatum struct {
+	Value array.Interface
+}
+
+func (*ArrayDatum) Kind() DatumKind            { return KindArray }
+func (d *ArrayDatum) DataType() arrow.DataType { return d.Value.DataType() }
+func (d *ArrayDatum) Len() int64               { return int64(d.Value.Len()) }
+func (d *ArrayDatum) Release()                 { d.Value.Release() }
+func (d *ArrayDatum) String() string           { return fmt.Sprintf("%v", d.Value) }
+
+// ChunkedDatum is a Datum holding a chunked array.
+type ChunkedDatum struct {
+	Value *array.Chunked
+}
+
+func (*ChunkedDatum) Kind() DatumKind            { return KindChunked }
+func (d *ChunkedDatum) DataType() arrow.DataType { return d.Value.DataType() }
+func (d *ChunkedDatum) Len() int64               { return int64(d.Value.Len()) }
+func (d *ChunkedDatum) Release()                 { d.Value.Release() }
+func (d *ChunkedDatum) String() string           { return fmt.Sprintf("%v", d.Value.Chunks()) }
+
+// RecordDatum is a Datum holding a record.
+type RecordDatum struct {
+	Value array.Record
+}
+
+func (*RecordDatum) Kind() DatumKind { return KindRecord }
+func (d *RecordDatum) DataType() arrow.DataType {
+	return arrow.StructOf(d.Value.Schema().Fields()...)
+}
+func (d *RecordDatum) Len() int64     { return d.Value.NumRows() }
+func (d *RecordDatum) Release()       { d.Value.Release() }
+func (d *RecordDatum) String() string { return fmt.Sprintf("record(%v)", d.Value.Schema()) }
+
+// TableDatum is a Datum holding a table.
+type TableDatum struct {
+	Value array.Table
+}
+
+func (*TableDatum) Kind() DatumKind { return KindTable }
+func (d *TableDatum) DataType() arrow.DataType {
+	return arrow.StructOf(d.Value.Schema().Fields()...)
+}
+func (d *TableDatum) Len() int64     { return d.Value.NumRows() }
+func (d *TableDatum) Release()       { d.Value.Release() }
+func (d *TableDatum) String() string { return fmt.Sprintf("table(%v)", d.Value.Schema()) }
+
+// NewDatum wraps v, which must be a scalar.Scalar, an array.Interface, an
+// *array.Chunked, an array.Record or an array.Table, into a Datum. Values
+// are retained, and released by the Release method of the datum.
+func NewDatum(v interface{}) Datum {
+	switch v := v.(type) {
+	case scalar.Scalar:
+		if r, ok := v.(interface{ Retain() }); ok {
+			r.Retain()
+		}
+		return &ScalarDatum{Value: v}
+	case array.Interface:
+		v.Retain()
+		return &ArrayDatum{Value: v}
+	case *array.Chunked:
+		v.Retain()
+		return &ChunkedDatum{Value: v}
+	case array.Record:
+		v.Retain()
+		return &RecordDatum{Value: v}
+	case array.Table:
+		v.Retain()
+		return &TableDatum{Value: v}
+	}
+	panic(xerrors.Errorf("arrow/compute: invalid datum value %T", v))
+}
+
+// binaryKernel computes the result of a binary function over two arrays of
+// the same length, or an array and a scalar broadcast as an array of length
+// one. Scalars are flagged so that the kernel can broadcast them.
+type binaryKernel func(mem memory.Allocator, left, right operand) (array.Interface, error)
+
+// operand is an input of a kernel: an array, or a scalar held as an array
+// of length one.
+type operand struct {
+	array.Interface
+	scalar bool
+}
+
+// execBinary runs kernel over left and right. The chunks of chunked inputs
+// are aligned with each other, and scalars are broadcast. The result is a
+// scalar if both inputs are scalars, a chunked array if any of them is
+// chunked and an array otherwise.
+func execBinary(mem memory.Allocator, left, right Datum, kernel binaryKernel) (Datum, error) {
+	if left.Kind() != KindScalar && right.Kind() != KindScalar && left.Len() != right.Len() {
+		return nil, xerrors.Errorf("arrow/compute: inputs have different lengths (%d and %d): %w",
+			left.Len(), right.Len(), ErrInvalid)
+	}
+
+	lchunks, err := datumChunks(mem, left)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(lchunks)
+	rchunks, err := datumChunks(mem, right)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(rchunks)
+
+	var (
+		lscalar = left.Kind() == KindScalar
+		rscalar = right.Kind() == KindScalar
+		out     []array.Interface
+	)
+	defer func() { releaseArrays(out) }()
+
+	switch {
+	case lscalar && rscalar:
+		res, err := kernel(mem, operand{lchunks[0], true}, operand{rchunks[0], true})
+		if err != nil {
+			return nil, err
+		}
+		defer res.Release()
+		s, err := scalar.GetScalar(res, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &ScalarDatum{Value: s}, nil
+	case lscalar:
+		for _, r := range rchunks {
+			res, err := kernel(mem, operand{lchunks[0], true}, operand{r, false})
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, res)
+		}
+	case rscalar:
+		for _, l := range lchunks {
+			res, err := kernel(mem, operand{l, false}, operand{rchunks[0], true})
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, res)
+		}
+	case left.Kind() == KindArray && right.Kind() == KindArray:
+		res, err := kernel(mem, operand{lchunks[0], false}, operand{rchunks[0], false})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, res)
+	default:
+		err := alignChunks(lchunks, rchunks, func(l, r array.Interface) error {
+			res, err := kernel(mem, operand{l, false}, operand{r, false})
+			if err != nil {
+				return err
+			}
+			out = append(out, res)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if left.Kind() != KindChunked && right.Kind() != KindChunked {
+		res := out[0]
+		out = nil
+		return &ArrayDatum{Value: res}, nil
+	}
+
+	dt := left.DataType()
+	if len(out) > 0 {
+		dt = out[0].DataType()
+	} else if dt, err = kernelType(mem, kernel, left, right); err != nil {
+		return nil, err
+	}
+	return &ChunkedDatum{Value: array.NewChunked(dt, out)}, nil
+}
+
+// unaryKernel computes the result of a unary function over an array.
+type unaryKernel func(mem memory.Allocator, arr array.Interface) (array.Interface, error)
+
+// execUnary runs kernel over each chunk of d. Scalars are passed to the
+// kernel as arrays of length one. The result has the same kind as d.
+func execUnary(mem memory.Allocator, d Datum, kernel unaryKernel) (Datum, error) {
+	chunks, err := datumChunks(mem, d)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(chunks)
+
+	out := make([]array.Interface, 0, len(chunks))
+	defer func() { releaseArrays(out) }()
+	for _, c := range chunks {
+		res, err := kernel(mem, c)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, res)
+	}
+
+	switch d.Kind() {
+	case KindScalar:
+		s, err := scalar.GetScalar(out[0], 0)
+		if err != nil {
+			return nil, err
+		}
+		return &ScalarDatum{Value: s}, nil
+	case KindArray:
+		res := out[0]
+		out = nil
+		return &ArrayDatum{Value: res}, nil
+	}
+
+	dt := d.DataType()
+	if len(out) > 0 {
+		dt = out[0].DataType()
+	} else {
+		empty := makeNullArray(mem, d.DataType(), 0)
+		defer empty.Release()
+		res, err := kernel(mem, empty)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Release()
+		dt = res.DataType()
+	}
+	return &ChunkedDatum{Value: array.NewChunked(dt, out)}, nil
+}
+
+// kernelType returns the output type of kernel, found by running it over
+// empty inputs.
+func kernelType(mem memory.Allocator, kernel binaryKernel, left, right Datum) (arrow.DataType, error) {
+	l := makeNullArray(mem, left.DataType(), 1)
+	defer l.Release()
+	r := makeNullArray(mem, right.DataType(), 1)
+	defer r.Release()
+	res, err := kernel(mem, operand{l, true}, operand{r, true})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Release()
+	return res.DataType(), nil
+}
+
+// datumChunks returns the arrays held by d. Scalars are returned as arrays
+// of length one. The returned arrays must be released.
+func datumChunks(mem memory.Allocator, d Datum) ([]array.Interface, error) {
+	switch d := d.(type) {
+	case *ScalarDatum:
+		arr, err := scalar.MakeArrayFromScalar(d.Value, 1, mem)
+		if err != nil {
+			return nil, err
+		}
+		return []array.Interface{arr}, nil
+	case *ArrayDatum:
+		d.Value.Retain()
+		return []array.Interface{d.Value}, nil
+	case *ChunkedDatum:
+		chunks := d.Value.Chunks()
+		for _, c := range chunks {
+			c.Retain()
+		}
+		return append([]array.Interface(nil), chunks...), nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: invalid datum %T: %w", d, ErrInvalid)
+}
+
+// alignChunks calls fn with slices of left and right covering the same
+// rows, until both sequences of chunks are exhausted.
+func alignChunks(left, right []array.Interface, fn func(l, r array.Interface) error) error {
+	var (
+		li, ri     int // current chunks
+		loff, roff int // offsets in the current chunks
+	)
+	for {
+		for li < len(left) && loff == left[li].Len() {
+			li, loff = li+1, 0
+		}
+		for ri < len(right) && roff == right[ri].Len() {
+			ri, roff = ri+1, 0
+		}
+		if li == len(left) || ri == len(right) {
+			return nil
+		}
+
+		n := min(left[li].Len()-loff, right[ri].Len()-roff)
+		l := array.NewSlice(left[li], int64(loff), int64(loff+n))
+		r := array.NewSlice(right[ri], int64(roff), int64(roff+n))
+		err := fn(l, r)
+		l.Release()
+		r.Release()
+		if err != nil {
+			return err
+		}
+		loff += n
+		roff += n
+	}
+}
+
+func releaseArrays(arrs []array.Interface) {
+	for _, a := range arrs {
+		if a != nil {
+			a.Release()
+		}
+	}
+}
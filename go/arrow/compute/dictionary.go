@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// DictionaryEncodeOptions controls the behavior of DictionaryEncode.
+type DictionaryEncodeOptions struct {
+	// NullEncoding selects whether nulls are kept as null indices, or
+	// encoded as a null entry of the dictionary.
+	NullEncoding NullEncodingBehavior
+	// IndexType is the integer type of the indices. It defaults to Int32.
+	IndexType arrow.DataType
+	// AllowWiden allows the indices to use the smallest wider signed
+	// integer type able to index the dictionary when it holds too many
+	// values for IndexType. Otherwise, ErrInvalid is returned.
+	AllowWiden bool
+}
+
+// DictionaryEncode returns the dictionary-encoded values of an array or a
+// chunked array. The dictionary holds the distinct values of the input, in
+// the order of their first appearance, and is shared by all the chunks of
+// a chunked input.
+//
+// The returned datum must be Release()'d after use.
+func DictionaryEncode(ctx context.Context, input Datum, opts *DictionaryEncodeOptions) (Datum, error) {
+	if opts == nil {
+		opts = &DictionaryEncodeOptions{}
+	}
+	idxType := opts.IndexType
+	if idxType == nil {
+		idxType = arrow.PrimitiveTypes.Int32
+	}
+	if !isInteger(idxType.ID()) {
+		return nil, xerrors.Errorf("arrow/compute: dictionary indices must be integers, got %v: %w", idxType, ErrInvalid)
+	}
+	if input.DataType().ID() == arrow.DICTIONARY {
+		return nil, xerrors.Errorf("arrow/compute: %v values are already dictionary-encoded: %w", input.DataType(), ErrInvalid)
+	}
+
+	mem := GetAllocator(ctx)
+	var chunks [][]int32
+	memo, err := hashDatum(mem, input, &HashOptions{NullEncoding: opts.NullEncoding}, func(indices []int32) {
+		chunks = append(chunks, append([]int32(nil), indices...))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if n := memo.len(); n > 0 && !fitsIndex(idxType, n-1) {
+		if !opts.AllowWiden {
+			return nil, xerrors.Errorf("arrow/compute: dictionary of %d values overflows %v indices: %w", n, idxType, ErrInvalid)
+		}
+		idxType = widenIndex(idxType, n-1)
+	}
+
+	dict := memo.values(mem)
+	defer dict.Release()
+	dt := &arrow.DictionaryType{IndexType: idxType, ValueType: dict.DataType()}
+
+	out := make([]array.Interface, 0, len(chunks))
+	defer func() { releaseArrays(out) }()
+	for _, idx := range chunks {
+		indices, err := makeIndices(mem, idxType, idx)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, array.NewDictionaryArray(dt, indices, dict))
+		indices.Release()
+	}
+
+	if input.Kind() == KindArray {
+		res := out[0]
+		out = nil
+		return &ArrayDatum{Value: res}, nil
+	}
+	return &ChunkedDatum{Value: array.NewChunked(dt, out)}, nil
+}
+
+// DictionaryDecode returns the values of a dictionary-encoded array or
+// chunked array, i.e. the values of the dictionary at each index. It is
+// equivalent to a cast to the value type of the dictionary.
+//
+// The returned datum must be Release()'d after use.
+func DictionaryDecode(ctx context.Context, input Datum) (Datum, error) {
+	if input.DataType().ID() != arrow.DICTIONARY {
+		return nil, xerrors.Errorf("arrow/compute: cannot decode %v values: %w", input.DataType(), ErrInvalid)
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		return decodeDictionary(mem, arr.(*array.Dictionary))
+	})
+}
+
+// decodeDictionary returns the values of the dictionary of arr at each of
+// its indices.
+func decodeDictionary(mem memory.Allocator, arr *array.Dictionary) (array.Interface, error) {
+	sel, err := takeSelection(arr.Indices(), arr.Dictionary().Len(), DefaultTakeOptions())
+	if err != nil {
+		return nil, err
+	}
+	return gather(mem, []array.Interface{arr.Dictionary()}, sel)
+}
+
+// makeIndices returns an array of type dt holding the given indices, where
+// negative indices are null.
+func makeIndices(mem memory.Allocator, dt arrow.DataType, indices []int32) (array.Interface, error) {
+	var (
+		n        = len(indices)
+		validity = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		values   = newBuffer(mem, arrow.Int32Traits.BytesRequired(n))
+		nulls    int
+	)
+	copy(arrow.Int32Traits.CastFromBytes(values.Bytes()), indices)
+	for i, idx := range indices {
+		if idx < 0 {
+			nulls++
+			continue
+		}
+		bitutil.SetBit(validity.Bytes(), i)
+	}
+	idx := makeArray(arrow.PrimitiveTypes.Int32, n, []*memory.Buffer{validity, values}, nil, nulls)
+	defer idx.Release()
+	return castArray(mem, idx, dt, SafeCastOptions())
+}
+
+// fitsIndex reports whether the integer type dt can represent v.
+func fitsIndex(dt arrow.DataType, v int) bool {
+	switch dt.ID() {
+	case arrow.INT8:
+		return v <= math.MaxInt8
+	case arrow.UINT8:
+		return v <= math.MaxUint8
+	case arrow.INT16:
+		return v <= math.MaxInt16
+	case arrow.UINT16:
+		return v <= math.MaxUint16
+	}
+	return true
+}
+
+// widenIndex returns the smallest signed integer type, wider than dt, able
+// to represent v.
+func widenIndex(dt arrow.DataType, v int) arrow.DataType {
+	width := dt.(arrow.FixedWidthDataType).BitWidth()
+	for _, t := range []arrow.DataType{arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int32} {
+		if t.(arrow.FixedWidthDataType).BitWidth() > width && fitsIndex(t, v) {
+			return t
+		}
+	}
+	return arrow.PrimitiveTypes.Int32
+}
@@ -0,0 +1,346 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestDictionaryEncode(t *testing.T) {
+	var (
+		str   = arrow.BinaryTypes.String
+		i32   = arrow.PrimitiveTypes.Int32
+		valid = []bool{true, true, false, true, true, false}
+	)
+
+	for _, tc := range []struct {
+		name    string
+		dt      arrow.DataType
+		values  interface{}
+		valid   []bool
+		opts    *compute.DictionaryEncodeOptions
+		idxType arrow.DataType
+		dict    interface{}
+		dvalid  []bool
+		indices []int64
+		ivalid  []bool
+	}{
+		{
+			name:    "string",
+			dt:      str,
+			values:  []string{"b", "a", "b", "c", "a", "b"},
+			idxType: i32,
+			dict:    []string{"b", "a", "c"},
+			indices: []int64{0, 1, 0, 2, 1, 0},
+		},
+		{
+			name:    "nulls-masked",
+			dt:      str,
+			values:  []string{"b", "a", "", "c", "a", ""},
+			valid:   valid,
+			idxType: i32,
+			dict:    []string{"b", "a", "c"},
+			indices: []int64{0, 1, 0, 2, 1, 0},
+			ivalid:  valid,
+		},
+		{
+			name:    "nulls-encoded",
+			dt:      str,
+			values:  []string{"b", "a", "", "c", "a", ""},
+			valid:   valid,
+			opts:    &compute.DictionaryEncodeOptions{NullEncoding: compute.NullEncodingEncode},
+			idxType: i32,
+			dict:    []string{"b", "a", "", "c"},
+			dvalid:  []bool{true, true, false, true},
+			indices: []int64{0, 1, 2, 3, 1, 2},
+		},
+		{
+			name:    "int64-uint8-indices",
+			dt:      arrow.PrimitiveTypes.Int64,
+			values:  []int64{7, 7, -1, 42},
+			opts:    &compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Uint8},
+			idxType: arrow.PrimitiveTypes.Uint8,
+			dict:    []int64{7, -1, 42},
+			indices: []int64{0, 0, 1, 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			arr := arrayOf(mem, tc.dt, tc.values, tc.valid)
+			defer arr.Release()
+			input := compute.NewDatum(arr)
+			defer input.Release()
+
+			out, err := compute.DictionaryEncode(ctx, input, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+
+			got := out.(*compute.ArrayDatum).Value.(*array.Dictionary)
+			want := &arrow.DictionaryType{IndexType: tc.idxType, ValueType: tc.dt}
+			if !arrow.TypeEqual(got.DataType(), want) {
+				t.Fatalf("invalid type: got=%v, want=%v", got.DataType(), want)
+			}
+
+			dict := arrayOf(mem, tc.dt, tc.dict, tc.dvalid)
+			defer dict.Release()
+			assertArrayEqual(t, dict, got.Dictionary())
+			indices := arrayOf(mem, tc.idxType, tc.indices, tc.ivalid)
+			defer indices.Release()
+			assertArrayEqual(t, indices, got.Indices())
+
+			decoded, err := compute.DictionaryDecode(ctx, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer decoded.Release()
+			assertArrayEqual(t, arr, decoded.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestDictionaryEncodeIndexOverflow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values := make([]int32, 300)
+	for i := range values {
+		values[i] = int32(i % 200)
+	}
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int32, values, nil)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	for _, tc := range []struct {
+		opts *compute.DictionaryEncodeOptions
+		want arrow.DataType
+	}{
+		{&compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Int8}, nil},
+		{&compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Int8, AllowWiden: true}, arrow.PrimitiveTypes.Int16},
+		{&compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Uint8}, arrow.PrimitiveTypes.Uint8},
+		{&compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Float32}, nil},
+	} {
+		t.Run(tc.opts.IndexType.Name()+"-"+strconv.FormatBool(tc.opts.AllowWiden), func(t *testing.T) {
+			out, err := compute.DictionaryEncode(ctx, input, tc.opts)
+			if tc.want == nil {
+				if !xerrors.Is(err, compute.ErrInvalid) {
+					t.Fatalf("got err=%v, want ErrInvalid", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+
+			got := out.(*compute.ArrayDatum).Value.(*array.Dictionary)
+			if idx := got.DataType().(*arrow.DictionaryType).IndexType; !arrow.TypeEqual(idx, tc.want) {
+				t.Fatalf("invalid index type: got=%v, want=%v", idx, tc.want)
+			}
+			if got, want := got.Dictionary().Len(), 200; got != want {
+				t.Fatalf("invalid dictionary length: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestDictionaryEncodeChunked(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var chunks []array.Interface
+	for _, vs := range [][]string{{"x", "y"}, {}, {"z", "x", "y"}, {"w"}} {
+		chunks = append(chunks, arrayOf(mem, arrow.BinaryTypes.String, vs, nil))
+	}
+	chunked := array.NewChunked(arrow.BinaryTypes.String, chunks)
+	defer chunked.Release()
+	for _, c := range chunks {
+		c.Release()
+	}
+	input := compute.NewDatum(chunked)
+	defer input.Release()
+
+	out, err := compute.DictionaryEncode(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Release()
+
+	got := out.(*compute.ChunkedDatum).Value
+	if got, want := len(got.Chunks()), 4; got != want {
+		t.Fatalf("invalid number of chunks: got=%d, want=%d", got, want)
+	}
+	dict := got.Chunks()[0].(*array.Dictionary).Dictionary()
+	want := arrayOf(mem, arrow.BinaryTypes.String, []string{"x", "y", "z", "w"}, nil)
+	defer want.Release()
+	assertArrayEqual(t, want, dict)
+	for i, c := range got.Chunks() {
+		if c.(*array.Dictionary).Dictionary().Data() != dict.Data() {
+			t.Fatalf("chunk %d does not share the dictionary of the first chunk", i)
+		}
+	}
+
+	decoded, err := compute.DictionaryDecode(ctx, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Release()
+	for i, c := range decoded.(*compute.ChunkedDatum).Value.Chunks() {
+		assertArrayEqual(t, chunked.Chunk(i), c)
+	}
+
+	// chunks sharing a dictionary can be concatenated, e.g. when sorting.
+	indices, err := compute.SortIndices(ctx, out, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer indices.Release()
+	assertIndices(t, []uint64{5, 0, 3, 1, 4, 2}, indices)
+}
+
+func TestDictionaryKernels(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{5, 3, 0, 5, 9}, []bool{true, true, false, true, true})
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+	enc, err := compute.DictionaryEncode(ctx, input, &compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Int8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer enc.Release()
+	dict := enc.(*compute.ArrayDatum).Value
+
+	t.Run("cast", func(t *testing.T) {
+		got, err := compute.CastArray(ctx, dict, arrow.PrimitiveTypes.Float64, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+		want := arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{5, 3, 0, 5, 9}, []bool{true, true, false, true, true})
+		defer want.Release()
+		assertArrayEqual(t, want, got)
+	})
+
+	t.Run("take", func(t *testing.T) {
+		indices := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{4, 2, 0}, nil)
+		defer indices.Release()
+		got, err := compute.TakeArray(ctx, dict, indices, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+		if got.(*array.Dictionary).Dictionary().Data() != dict.(*array.Dictionary).Dictionary().Data() {
+			t.Fatalf("take should keep the dictionary")
+		}
+		values, err := compute.CastArray(ctx, got, arrow.PrimitiveTypes.Int16, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer values.Release()
+		want := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{9, 0, 5}, []bool{true, false, true})
+		defer want.Release()
+		assertArrayEqual(t, want, values)
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		got, err := compute.Unique(ctx, enc, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+		want := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{5, 3, 9}, nil)
+		defer want.Release()
+		assertArrayEqual(t, want, got)
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		got, err := compute.SortIndices(ctx, enc, &compute.SortOptions{
+			Keys: []compute.SortKey{{Order: compute.Descending}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+		assertIndices(t, []uint64{4, 0, 3, 1, 2}, got)
+	})
+}
+
+func TestDictionaryEncodeMemory(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	const n = 1 << 16
+	categories := []string{"electronics", "groceries", "clothing", "furniture"}
+	values := make([]string, n)
+	for i := range values {
+		values[i] = categories[(i*7)%len(categories)]
+	}
+	arr := arrayOf(mem, arrow.BinaryTypes.String, values, nil)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	out, err := compute.DictionaryEncode(ctx, input, &compute.DictionaryEncodeOptions{IndexType: arrow.PrimitiveTypes.Int8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Release()
+
+	plain := arraySize(arr.Data())
+	encoded := arraySize(out.(*compute.ArrayDatum).Value.Data())
+	if encoded*8 > plain {
+		t.Fatalf("dictionary encoding uses %d bytes, want at most 1/8 of the %d bytes of the plain array", encoded, plain)
+	}
+}
+
+// arraySize returns the number of bytes of the buffers of data, its
+// children and its dictionary.
+func arraySize(data *array.Data) int {
+	n := 0
+	for _, b := range data.Buffers() {
+		if b != nil {
+			n += b.Len()
+		}
+	}
+	for _, c := range data.Children() {
+		n += arraySize(c)
+	}
+	if dict := data.Dictionary(); dict != nil {
+		n += arraySize(dict)
+	}
+	return n
+}
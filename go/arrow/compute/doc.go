@@ -0,0 +1,31 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compute provides kernels operating on Arrow arrays and records,
+// such as casting values from one data type to another.
+//
+// Functions take a context.Context as their first argument. The memory
+// allocator used for the results can be configured on that context with
+// WithAllocator; memory.DefaultAllocator is used otherwise.
+//
+// Casts, arithmetic, comparisons, rounding, sorting, the hash kernels
+// (Unique, ValueCounts, DictionaryEncode), the set lookups (IsIn, IndexIn)
+// and GroupBy keys accept both decimal128 and decimal256 values. The
+// aggregations, including those of GroupBy, only support decimal128 and
+// return ErrNotImplemented for decimal256.
+package compute // import "github.com/apache/arrow/go/arrow/compute"
+
+//go:generate go run ../_tools/tmpl/main.go -i -data=numeric.tmpldata cast_numeric.gen.go.tmpl arithmetic.gen.go.tmpl comparison.gen.go.tmpl aggregate.gen.go.tmpl sort.gen.go.tmpl hash.gen.go.tmpl groupby.gen.go.tmpl
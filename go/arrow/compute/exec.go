@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+var (
+	// ErrInvalid is returned when the input values cannot be processed by a
+	// function, such as a value overflowing the target type of a safe cast.
+	ErrInvalid = errors.New("invalid value")
+
+	// ErrNotImplemented is returned when a function has no kernel for the
+	// provided input types.
+	ErrNotImplemented = errors.New("not implemented")
+)
+
+type allocatorCtxKey struct{}
+
+// WithAllocator returns a new context carrying mem, which compute functions
+// will use to allocate the memory of the values they return.
+func WithAllocator(ctx context.Context, mem memory.Allocator) context.Context {
+	return context.WithValue(ctx, allocatorCtxKey{}, mem)
+}
+
+// GetAllocator returns the allocator stored in ctx by WithAllocator, or
+// memory.DefaultAllocator if there is none.
+func GetAllocator(ctx context.Context) memory.Allocator {
+	mem, ok := ctx.Value(allocatorCtxKey{}).(memory.Allocator)
+	if !ok || mem == nil {
+		return memory.DefaultAllocator
+	}
+	return mem
+}
+
+type chunkSizeCtxKey struct{}
+
+// WithExecChunkSize returns a new context carrying n, the number of rows of
+// the chunks expressions are evaluated over. Records with more than n rows
+// are split in chunks evaluated concurrently, which bounds the memory used
+// by intermediate results to a few chunks. The allocator used with chunked
+// execution must be safe for concurrent use.
+//
+// A chunk size of 0, the default, evaluates records whole.
+func WithExecChunkSize(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, chunkSizeCtxKey{}, n)
+}
+
+// GetExecChunkSize returns the chunk size stored in ctx by WithExecChunkSize,
+// or 0 if there is none.
+func GetExecChunkSize(ctx context.Context) int {
+	n, _ := ctx.Value(chunkSizeCtxKey{}).(int)
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+type aggregateConcurrencyCtxKey struct{}
+
+// WithAggregateConcurrency returns a new context carrying n, the maximum
+// number of goroutines aggregating the chunks of chunked values. Chunks
+// are aggregated concurrently only if they hold enough values in total.
+//
+// The partial results of the chunks are always combined in the order of
+// the chunks, so that the results, including the rounding of floating
+// point sums, do not depend on n.
+//
+// A concurrency of 0, the default, uses runtime.GOMAXPROCS goroutines.
+func WithAggregateConcurrency(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, aggregateConcurrencyCtxKey{}, n)
+}
+
+// GetAggregateConcurrency returns the concurrency stored in ctx by
+// WithAggregateConcurrency, or runtime.GOMAXPROCS if there is none.
+func GetAggregateConcurrency(ctx context.Context) int {
+	n, _ := ctx.Value(aggregateConcurrencyCtxKey{}).(int)
+	if n <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return n
+}
@@ -0,0 +1,366 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// Expression is a tree of field references, literals and function calls,
+// evaluated over the columns of a record with ExecuteScalarExpression.
+//
+// Expressions are built unbound, and must be bound to a schema with Bind
+// before being executed.
+type Expression interface {
+	fmt.Stringer
+
+	// DataType returns the type of the values of the expression, or nil
+	// if the expression is not bound.
+	DataType() arrow.DataType
+	// Bind resolves the field references of the expression against schema,
+	// and the functions called by the expression in the default registry.
+	// It returns a bound copy of the expression.
+	Bind(schema *arrow.Schema) (Expression, error)
+
+	bind(schema *arrow.Schema, reg *FunctionRegistry) (Expression, error)
+	eval(ctx context.Context, rec array.Record) (Datum, error)
+}
+
+// FieldRef is an expression referencing a column of a record by name.
+type FieldRef struct {
+	name  string
+	index int
+	dt    arrow.DataType
+}
+
+// NewFieldRef returns an expression referencing the column with the given
+// name.
+func NewFieldRef(name string) *FieldRef {
+	return &FieldRef{name: name, index: -1}
+}
+
+// Name returns the name of the referenced column.
+func (f *FieldRef) Name() string { return f.name }
+
+func (f *FieldRef) DataType() arrow.DataType { return f.dt }
+func (f *FieldRef) String() string           { return f.name }
+
+func (f *FieldRef) Bind(schema *arrow.Schema) (Expression, error) {
+	return f.bind(schema, defaultRegistry)
+}
+
+func (f *FieldRef) bind(schema *arrow.Schema, reg *FunctionRegistry) (Expression, error) {
+	indices := schema.FieldIndices(f.name)
+	switch len(indices) {
+	case 0:
+		return nil, xerrors.Errorf("arrow/compute: unknown field %q in schema %v: %w", f.name, schema, ErrInvalid)
+	case 1:
+		return &FieldRef{name: f.name, index: indices[0], dt: schema.Field(indices[0]).Type}, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: ambiguous field %q in schema %v: %w", f.name, schema, ErrInvalid)
+}
+
+func (f *FieldRef) eval(ctx context.Context, rec array.Record) (Datum, error) {
+	if f.index < 0 {
+		return nil, xerrors.Errorf("arrow/compute: field %q is not bound: %w", f.name, ErrInvalid)
+	}
+	if f.index >= int(rec.NumCols()) || rec.ColumnName(f.index) != f.name || !arrow.TypeEqual(rec.Column(f.index).DataType(), f.dt) {
+		return nil, xerrors.Errorf("arrow/compute: field %q (%v) does not match the schema of the record %v: %w", f.name, f.dt, rec.Schema(), ErrInvalid)
+	}
+	return NewDatum(rec.Column(f.index)), nil
+}
+
+// Literal is an expression holding a constant value.
+type Literal struct {
+	value scalar.Scalar
+}
+
+// NewLiteral returns an expression holding v.
+func NewLiteral(v scalar.Scalar) *Literal {
+	return &Literal{value: v}
+}
+
+// Value returns the value of the literal.
+func (l *Literal) Value() scalar.Scalar { return l.value }
+
+func (l *Literal) DataType() arrow.DataType                      { return l.value.DataType() }
+func (l *Literal) String() string                                { return l.value.String() }
+func (l *Literal) Bind(schema *arrow.Schema) (Expression, error) { return l, nil }
+
+func (l *Literal) bind(schema *arrow.Schema, reg *FunctionRegistry) (Expression, error) {
+	return l, nil
+}
+
+func (l *Literal) eval(ctx context.Context, rec array.Record) (Datum, error) {
+	return &ScalarDatum{Value: l.value}, nil
+}
+
+// Call is an expression calling a function of a FunctionRegistry, by name,
+// on the results of other expressions. The built-in functions of the
+// default registry are:
+//
+//   - add, subtract, multiply and divide, and their add_checked,
+//     subtract_checked, multiply_checked and divide_checked variants
+//     which check for overflows;
+//   - equal, not_equal, less, less_equal, greater and greater_equal;
+//   - and, or, xor, and_not, and_kleene, or_kleene and invert;
+//   - is_null, is_valid and coalesce, which takes any number of arguments;
+//   - ascii_upper, ascii_lower, utf8_upper, utf8_lower, utf8_length,
+//     binary_length and utf8_trim_whitespace;
+//   - year, month, day, day_of_week, iso_week, hour, minute and second;
+//   - round, floor, ceil and trunc;
+//   - dictionary_decode.
+//
+// Functions taking options are called with their default options.
+//
+// Calls are bound to the functions of the registry used by Bind, and
+// executed with the function of the same name of the registry set on the
+// context of the execution with WithRegistry, if any.
+type Call struct {
+	name string
+	args []Expression
+	fn   *function
+	dt   arrow.DataType
+}
+
+// NewCall returns an expression calling the function name with the
+// results of args. Unknown functions are reported by Bind.
+func NewCall(name string, args ...Expression) *Call {
+	return &Call{name: name, args: args}
+}
+
+// Function returns the name of the called function.
+func (c *Call) Function() string { return c.name }
+
+// Args returns the arguments of the call.
+func (c *Call) Args() []Expression { return c.args }
+
+func (c *Call) DataType() arrow.DataType { return c.dt }
+
+func (c *Call) String() string {
+	args := make([]string, len(c.args))
+	for i, arg := range c.args {
+		args[i] = arg.String()
+	}
+	return c.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (c *Call) Bind(schema *arrow.Schema) (Expression, error) {
+	return c.bind(schema, defaultRegistry)
+}
+
+func (c *Call) bind(schema *arrow.Schema, reg *FunctionRegistry) (Expression, error) {
+	fn := reg.lookup(c.name)
+	if fn == nil {
+		return nil, xerrors.Errorf("arrow/compute: unknown function %q: %w", c.name, ErrInvalid)
+	}
+	if err := fn.checkArity(len(c.args)); err != nil {
+		return nil, err
+	}
+
+	bound := &Call{name: c.name, args: make([]Expression, len(c.args)), fn: fn}
+	types := make([]arrow.DataType, len(c.args))
+	for i, arg := range c.args {
+		arg, err := arg.bind(schema, reg)
+		if err != nil {
+			return nil, err
+		}
+		bound.args[i] = arg
+		types[i] = arg.DataType()
+	}
+
+	if fn.outputType != nil {
+		if _, err := fn.kernel(types); err != nil {
+			return nil, xerrors.Errorf("arrow/compute: invalid call %v: %w", bound, err)
+		}
+		dt, err := fn.outputType(types)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/compute: invalid call %v: %w", bound, err)
+		}
+		bound.dt = dt
+		return bound, nil
+	}
+
+	// the type of the result is found by calling the function over empty
+	// arrays, or the literals.
+	mem := memory.DefaultAllocator
+	args := make([]Datum, len(bound.args))
+	for i, arg := range bound.args {
+		if lit, ok := arg.(*Literal); ok {
+			args[i] = &ScalarDatum{Value: lit.value}
+			continue
+		}
+		args[i] = &ArrayDatum{Value: makeNullArray(mem, arg.DataType(), 0)}
+	}
+	defer releaseDatums(args)
+	res, err := fn.exec(WithAllocator(context.Background(), mem), args)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/compute: invalid call %v: %w", bound, err)
+	}
+	defer res.Release()
+	bound.dt = res.DataType()
+	return bound, nil
+}
+
+func (c *Call) eval(ctx context.Context, rec array.Record) (Datum, error) {
+	fn := c.fn
+	if fn == nil || c.dt == nil {
+		return nil, xerrors.Errorf("arrow/compute: call %v is not bound: %w", c, ErrInvalid)
+	}
+	if reg, ok := ctx.Value(registryCtxKey{}).(*FunctionRegistry); ok && reg != nil {
+		if override := reg.lookup(c.name); override != nil {
+			fn = override
+		}
+	}
+	args := make([]Datum, 0, len(c.args))
+	defer func() { releaseDatums(args) }()
+	for _, arg := range c.args {
+		d, err := arg.eval(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, d)
+	}
+	return fn.exec(ctx, args)
+}
+
+// ExecuteScalarExpression evaluates the bound expression expr over the
+// columns of rec, whose schema must match the schema expr was bound to.
+// The result is an array of the length of rec, or a scalar if expr does
+// not reference any field.
+//
+// Records larger than the chunk size configured with WithExecChunkSize
+// are evaluated in chunks, as with ExecuteExpressions.
+//
+// The returned datum must be Release()'d after use.
+func ExecuteScalarExpression(ctx context.Context, expr Expression, rec array.Record) (Datum, error) {
+	if expr.DataType() == nil {
+		return nil, xerrors.Errorf("arrow/compute: expression %v is not bound: %w", expr, ErrInvalid)
+	}
+	if size := GetExecChunkSize(ctx); size > 0 && rec.NumRows() > int64(size) && referencesFields(expr) {
+		out, err := execChunked(ctx, rec, nil, []Expression{expr}, size)
+		if err != nil {
+			return nil, err
+		}
+		return &ArrayDatum{Value: out[0]}, nil
+	}
+	return expr.eval(ctx, rec)
+}
+
+func releaseDatums(ds []Datum) {
+	for _, d := range ds {
+		d.Release()
+	}
+}
+
+// exprFunction is a built-in function of the default registry.
+type exprFunction struct {
+	arity int // number of arguments, or -1 if variadic
+	exec  func(ctx context.Context, args []Datum) (Datum, error)
+}
+
+func unaryFunction(fn func(ctx context.Context, input Datum) (Datum, error)) exprFunction {
+	return exprFunction{1, func(ctx context.Context, args []Datum) (Datum, error) {
+		return fn(ctx, args[0])
+	}}
+}
+
+func binaryFunction(fn func(ctx context.Context, left, right Datum) (Datum, error)) exprFunction {
+	return exprFunction{2, func(ctx context.Context, args []Datum) (Datum, error) {
+		return fn(ctx, args[0], args[1])
+	}}
+}
+
+func arithmeticFunction(fn func(ctx context.Context, left, right Datum, opts *ArithmeticOptions) (Datum, error), checked bool) exprFunction {
+	opts := &ArithmeticOptions{CheckOverflow: checked}
+	return binaryFunction(func(ctx context.Context, left, right Datum) (Datum, error) {
+		return fn(ctx, left, right, opts)
+	})
+}
+
+func temporalFunction(fn func(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error)) exprFunction {
+	return unaryFunction(func(ctx context.Context, input Datum) (Datum, error) {
+		return fn(ctx, input, nil)
+	})
+}
+
+var builtinFunctions = map[string]exprFunction{
+	"add":              arithmeticFunction(Add, false),
+	"subtract":         arithmeticFunction(Subtract, false),
+	"multiply":         arithmeticFunction(Multiply, false),
+	"divide":           arithmeticFunction(Divide, false),
+	"add_checked":      arithmeticFunction(Add, true),
+	"subtract_checked": arithmeticFunction(Subtract, true),
+	"multiply_checked": arithmeticFunction(Multiply, true),
+	"divide_checked":   arithmeticFunction(Divide, true),
+
+	"equal":         binaryFunction(Equal),
+	"not_equal":     binaryFunction(NotEqual),
+	"less":          binaryFunction(Less),
+	"less_equal":    binaryFunction(LessEqual),
+	"greater":       binaryFunction(Greater),
+	"greater_equal": binaryFunction(GreaterEqual),
+
+	"and":        binaryFunction(And),
+	"or":         binaryFunction(Or),
+	"xor":        binaryFunction(Xor),
+	"and_not":    binaryFunction(AndNot),
+	"and_kleene": binaryFunction(KleeneAnd),
+	"or_kleene":  binaryFunction(KleeneOr),
+	"invert":     unaryFunction(Invert),
+
+	"is_null":  unaryFunction(IsNull),
+	"is_valid": unaryFunction(IsValid),
+	"coalesce": {-1, func(ctx context.Context, args []Datum) (Datum, error) {
+		return Coalesce(ctx, args...)
+	}},
+
+	"ascii_upper":          unaryFunction(UpperASCII),
+	"ascii_lower":          unaryFunction(LowerASCII),
+	"utf8_upper":           unaryFunction(Upper),
+	"utf8_lower":           unaryFunction(Lower),
+	"utf8_length":          unaryFunction(Utf8Length),
+	"binary_length":        unaryFunction(BinaryLength),
+	"utf8_trim_whitespace": unaryFunction(TrimWhitespace),
+
+	"year":        temporalFunction(Year),
+	"month":       temporalFunction(Month),
+	"day":         temporalFunction(Day),
+	"day_of_week": temporalFunction(DayOfWeek),
+	"iso_week":    temporalFunction(ISOWeek),
+	"hour":        temporalFunction(Hour),
+	"minute":      temporalFunction(Minute),
+	"second":      temporalFunction(Second),
+
+	"round": unaryFunction(func(ctx context.Context, input Datum) (Datum, error) {
+		return Round(ctx, input, nil)
+	}),
+	"floor": unaryFunction(Floor),
+	"ceil":  unaryFunction(Ceil),
+	"trunc": unaryFunction(Trunc),
+
+	"dictionary_decode": unaryFunction(DictionaryDecode),
+	"run_end_decode":    unaryFunction(RunEndDecode),
+}
@@ -0,0 +1,270 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+func exprRecord(mem memory.Allocator) array.Record {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "c", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	cols := []array.Interface{
+		arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 3, 4, 5, 6}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{1, 10, 0, 2, -1, 7}, []bool{true, true, false, true, true, true}),
+		arrayOf(mem, arrow.BinaryTypes.String, []string{"x", "", "y", "x", "z", "X"}, []bool{true, false, true, true, true, true}),
+	}
+	defer releaseAll(cols)
+	return array.NewRecord(schema, cols, 6)
+}
+
+func releaseAll(arrs []array.Interface) {
+	for _, arr := range arrs {
+		arr.Release()
+	}
+}
+
+func TestExpression(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rec := exprRecord(mem)
+	defer rec.Release()
+
+	// a + b > 5 AND c IS NOT NULL
+	expr := compute.NewCall("and_kleene",
+		compute.NewCall("greater",
+			compute.NewCall("add", compute.NewFieldRef("a"), compute.NewFieldRef("b")),
+			compute.NewLiteral(scalar.NewInt64Scalar(5)),
+		),
+		compute.NewCall("is_valid", compute.NewFieldRef("c")),
+	)
+	if got, want := expr.String(), "and_kleene(greater(add(a, b), 5), is_valid(c))"; got != want {
+		t.Fatalf("invalid string: got=%q, want=%q", got, want)
+	}
+	if expr.DataType() != nil {
+		t.Fatalf("unbound expression has type %v", expr.DataType())
+	}
+
+	bound, err := expr.Bind(rec.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bound.DataType(), arrow.FixedWidthTypes.Boolean; !arrow.TypeEqual(got, want) {
+		t.Fatalf("invalid type: got=%v, want=%v", got, want)
+	}
+
+	got, err := compute.ExecuteScalarExpression(ctx, bound, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	// the same, chaining the kernels by hand.
+	a := compute.NewDatum(rec.Column(0))
+	defer a.Release()
+	b := compute.NewDatum(rec.Column(1))
+	defer b.Release()
+	c := compute.NewDatum(rec.Column(2))
+	defer c.Release()
+	sum, err := compute.Add(ctx, a, b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sum.Release()
+	greater, err := compute.Greater(ctx, sum, compute.NewDatum(scalar.NewInt64Scalar(5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer greater.Release()
+	valid, err := compute.IsValid(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer valid.Release()
+	want, err := compute.KleeneAnd(ctx, greater, valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer want.Release()
+
+	assertArrayEqual(t, want.(*compute.ArrayDatum).Value, got.(*compute.ArrayDatum).Value)
+
+	// a + b is null on row 2, and c on row 1.
+	wantValues := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{false, false, false, true, false, true}, []bool{true, true, false, true, true, true})
+	defer wantValues.Release()
+	assertArrayEqual(t, wantValues, got.(*compute.ArrayDatum).Value)
+}
+
+func TestExpressionFunctions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rec := exprRecord(mem)
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name  string
+		expr  compute.Expression
+		dt    arrow.DataType
+		want  interface{}
+		valid []bool
+	}{
+		{
+			name: "string",
+			expr: compute.NewCall("equal",
+				compute.NewCall("utf8_upper", compute.NewFieldRef("c")),
+				compute.NewLiteral(scalar.NewStringScalar("X"))),
+			dt:    arrow.FixedWidthTypes.Boolean,
+			want:  []bool{true, false, false, true, false, true},
+			valid: []bool{true, false, true, true, true, true},
+		},
+		{
+			name: "coalesce",
+			expr: compute.NewCall("coalesce",
+				compute.NewFieldRef("b"),
+				compute.NewLiteral(scalar.NewInt64Scalar(-9))),
+			dt:   arrow.PrimitiveTypes.Int64,
+			want: []int64{1, 10, -9, 2, -1, 7},
+		},
+		{
+			name: "literals",
+			expr: compute.NewCall("multiply",
+				compute.NewLiteral(scalar.NewInt8Scalar(2)),
+				compute.NewCall("add", compute.NewFieldRef("a"), compute.NewLiteral(scalar.NewInt8Scalar(1)))),
+			dt:   arrow.PrimitiveTypes.Int32,
+			want: []int32{4, 6, 8, 10, 12, 14},
+		},
+		{
+			name: "field",
+			expr: compute.NewFieldRef("a"),
+			dt:   arrow.PrimitiveTypes.Int32,
+			want: []int32{1, 2, 3, 4, 5, 6},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bound, err := tc.expr.Bind(rec.Schema())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !arrow.TypeEqual(bound.DataType(), tc.dt) {
+				t.Fatalf("invalid type: got=%v, want=%v", bound.DataType(), tc.dt)
+			}
+			got, err := compute.ExecuteScalarExpression(ctx, bound, rec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			want := arrayOf(mem, tc.dt, tc.want, tc.valid)
+			defer want.Release()
+			assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestExpressionScalar(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rec := exprRecord(mem)
+	defer rec.Release()
+
+	expr, err := compute.NewCall("add",
+		compute.NewLiteral(scalar.NewInt32Scalar(1)),
+		compute.NewLiteral(scalar.NewInt32Scalar(2)),
+	).Bind(rec.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := compute.ExecuteScalarExpression(ctx, expr, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	if v := got.(*compute.ScalarDatum).Value.(*scalar.Int32); v.Value != 3 {
+		t.Fatalf("got=%v, want=3", v)
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rec := exprRecord(mem)
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		expr compute.Expression
+		err  error
+	}{
+		{"unknown-field", compute.NewCall("is_null", compute.NewFieldRef("d")), compute.ErrInvalid},
+		{"unknown-function", compute.NewCall("frobnicate", compute.NewFieldRef("a")), compute.ErrInvalid},
+		{"arity", compute.NewCall("add", compute.NewFieldRef("a")), compute.ErrInvalid},
+		{"no-args", compute.NewCall("coalesce"), compute.ErrInvalid},
+		{"types", compute.NewCall("add", compute.NewFieldRef("a"), compute.NewFieldRef("c")), compute.ErrNotImplemented},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.expr.Bind(rec.Schema()); !xerrors.Is(err, tc.err) {
+				t.Fatalf("got err=%v, want %v", err, tc.err)
+			}
+		})
+	}
+
+	t.Run("ambiguous-field", func(t *testing.T) {
+		schema := arrow.NewSchema([]arrow.Field{
+			{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+		}, nil)
+		if _, err := compute.NewFieldRef("a").Bind(schema); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+	})
+
+	t.Run("unbound", func(t *testing.T) {
+		expr := compute.NewCall("is_null", compute.NewFieldRef("a"))
+		if _, err := compute.ExecuteScalarExpression(ctx, expr, rec); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+	})
+
+	t.Run("schema-mismatch", func(t *testing.T) {
+		schema := arrow.NewSchema([]arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int64}}, nil)
+		expr, err := compute.NewCall("is_null", compute.NewFieldRef("a")).Bind(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compute.ExecuteScalarExpression(ctx, expr, rec); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"golang.org/x/xerrors"
+)
+
+// NullSelectionBehavior controls how null values of a filter mask are
+// handled.
+type NullSelectionBehavior int8
+
+const (
+	// DropNulls drops the rows where the mask is null.
+	DropNulls NullSelectionBehavior = iota
+	// EmitNulls emits a null value for the rows where the mask is null.
+	EmitNulls
+)
+
+// FilterOptions controls the behavior of the Filter functions.
+type FilterOptions struct {
+	NullSelection NullSelectionBehavior
+}
+
+// FilterArray returns a new array made of the values of arr for which the
+// corresponding value of mask is true. mask must be a boolean array of the
+// same length as arr. If opts is nil, nulls in the mask are dropped.
+//
+// The returned array must be Release()'d after use.
+func FilterArray(ctx context.Context, arr, mask array.Interface, opts *FilterOptions) (array.Interface, error) {
+	if err := checkMask(mask, arr.Len()); err != nil {
+		return nil, err
+	}
+	sel := &selection{}
+	filterSelection(sel, mask.(*array.Boolean), 0, opts)
+	return gather(GetAllocator(ctx), []array.Interface{arr}, sel)
+}
+
+// FilterChunked returns a new chunked array made of the values of arr for
+// which the corresponding value of the chunked boolean mask is true. The
+// chunks of mask need not line up with the chunks of arr.
+//
+// The returned array is made of a single chunk and must be Release()'d
+// after use.
+func FilterChunked(ctx context.Context, arr *array.Chunked, mask *array.Chunked, opts *FilterOptions) (*array.Chunked, error) {
+	sel, err := chunkedFilterSelection(mask.Chunks(), mask.DataType(), arr.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectChunked(GetAllocator(ctx), arr, sel)
+}
+
+// FilterRecord returns a new record made of the rows of rec for which the
+// corresponding value of mask is true.
+//
+// The returned record must be Release()'d after use.
+func FilterRecord(ctx context.Context, rec array.Record, mask array.Interface, opts *FilterOptions) (array.Record, error) {
+	if err := checkMask(mask, int(rec.NumRows())); err != nil {
+		return nil, err
+	}
+	sel := &selection{}
+	filterSelection(sel, mask.(*array.Boolean), 0, opts)
+	return selectRecord(GetAllocator(ctx), rec, sel)
+}
+
+// FilterTable returns a new table made of the rows of tbl for which the
+// corresponding value of the chunked boolean mask is true. The chunks of
+// mask need not line up with the chunks of the columns of tbl.
+//
+// The columns of the returned table are made of a single chunk.
+// The returned table must be Release()'d after use.
+func FilterTable(ctx context.Context, tbl array.Table, mask *array.Chunked, opts *FilterOptions) (array.Table, error) {
+	sel, err := chunkedFilterSelection(mask.Chunks(), mask.DataType(), int(tbl.NumRows()), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectTable(GetAllocator(ctx), tbl, sel)
+}
+
+// Filter returns the values of the array, chunked array, record or table
+// values for which the corresponding value of mask, a boolean array or
+// chunked array, is true. The returned datum is of the same kind as values.
+//
+// The returned datum must be Release()'d after use.
+func Filter(ctx context.Context, values, mask Datum, opts *FilterOptions) (Datum, error) {
+	if k := mask.Kind(); k != KindArray && k != KindChunked {
+		return nil, xerrors.Errorf("arrow/compute: filter mask must be an array or a chunked array, got %v: %w", k, ErrInvalid)
+	}
+	mem := GetAllocator(ctx)
+	chunks, err := datumChunks(mem, mask)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(chunks)
+
+	sel, err := chunkedFilterSelection(chunks, mask.DataType(), int(values.Len()), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectDatum(mem, values, sel)
+}
+
+func checkMask(mask array.Interface, n int) error {
+	if mask.DataType().ID() != arrow.BOOL || mask.Len() != n {
+		return xerrors.Errorf("arrow/compute: filter mask must be a boolean array of length %d, got %v of length %d: %w",
+			n, mask.DataType(), mask.Len(), ErrInvalid)
+	}
+	return nil
+}
+
+// chunkedFilterSelection returns the selection of the rows of the chunks of
+// a mask of type dt over n values.
+func chunkedFilterSelection(chunks []array.Interface, dt arrow.DataType, n int, opts *FilterOptions) (*selection, error) {
+	length := 0
+	for _, chunk := range chunks {
+		length += chunk.Len()
+	}
+	if dt.ID() != arrow.BOOL || length != n {
+		return nil, xerrors.Errorf("arrow/compute: filter mask must be a boolean array of length %d, got %v of length %d: %w",
+			n, dt, length, ErrInvalid)
+	}
+	sel := &selection{}
+	offset := 0
+	for _, chunk := range chunks {
+		filterSelection(sel, chunk.(*array.Boolean), offset, opts)
+		offset += chunk.Len()
+	}
+	return sel, nil
+}
+
+// filterSelection appends the rows selected by mask to sel, with positions
+// shifted by offset.
+func filterSelection(sel *selection, mask *array.Boolean, offset int, opts *FilterOptions) {
+	var (
+		n      = mask.Len()
+		data   = mask.Data()
+		values = data.Buffers()[1]
+	)
+	if n == 0 {
+		return
+	}
+	if sel.spans == nil {
+		sel.spans = make([]span, 0, n/4)
+	}
+	if mask.NullN() == n && (opts == nil || opts.NullSelection == DropNulls) {
+		return
+	}
+
+	switch {
+	case mask.NullN() == 0:
+		visitSetBitRuns(values.Bytes(), data.Offset(), n, func(pos, n int) {
+			sel.add(0, offset+pos, n, false)
+		})
+
+	case opts == nil || opts.NullSelection == DropNulls:
+		selected := alignedBitmap(values.Bytes(), data.Offset(), n)
+		valid := alignedBitmap(mask.NullBitmapBytes(), data.Offset(), n)
+		for i := range selected {
+			selected[i] &= valid[i]
+		}
+		visitSetBitRuns(selected, 0, n, func(pos, n int) {
+			sel.add(0, offset+pos, n, false)
+		})
+
+	default:
+		// a row is emitted if it is selected or null. Within runs of emitted
+		// rows, the null ones produce nulls.
+		valid := make([]byte, bitutil.BytesForBits(int64(n)))
+		if mask.NullN() < n {
+			valid = alignedBitmap(mask.NullBitmapBytes(), data.Offset(), n)
+		}
+		emitted := make([]byte, len(valid))
+		if mask.NullN() < n {
+			emitted = alignedBitmap(values.Bytes(), data.Offset(), n)
+		}
+		for i := range emitted {
+			emitted[i] |= ^valid[i]
+		}
+		visitSetBitRuns(emitted, 0, n, func(pos, n int) {
+			for end := pos + n; pos < end; {
+				next := nextBit(valid, pos, end, false)
+				sel.add(0, offset+pos, next-pos, false)
+				pos = nextBit(valid, next, end, true)
+				sel.add(0, 0, pos-next, true)
+			}
+		})
+	}
+}
+
+// alignedBitmap returns a copy of n bits of bitmap starting at offset.
+func alignedBitmap(bitmap []byte, offset, n int) []byte {
+	out := make([]byte, bitutil.BytesForBits(int64(n)))
+	copyBitmap(bitmap, offset, out, 0, n)
+	return out
+}
@@ -0,0 +1,633 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestFilterArray(t *testing.T) {
+	var (
+		mask    = []bool{true, false, true, true, false, true, true, true, false, true}
+		valid   = []bool{true, true, false, true, true, true, true, false, true, true}
+		mvalid  = []bool{true, true, true, false, true, true, true, true, true, false}
+		nomask  = []bool{false, false, false, false, false, false, false, false, false, false}
+		emitOpt = &compute.FilterOptions{NullSelection: compute.EmitNulls}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		dt     arrow.DataType
+		in     interface{}
+		valid  []bool
+		mask   []bool
+		mvalid []bool
+		opts   *compute.FilterOptions
+		want   interface{}
+		wvalid []bool
+	}{
+		{
+			name: "int32", dt: arrow.PrimitiveTypes.Int32,
+			in:   []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			mask: mask,
+			want: []int32{0, 2, 3, 5, 6, 7, 9},
+		},
+		{
+			name: "int32-nulls", dt: arrow.PrimitiveTypes.Int32,
+			in: []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, valid: valid,
+			mask: mask,
+			want: []int32{0, 2, 3, 5, 6, 7, 9}, wvalid: []bool{true, false, true, true, true, false, true},
+		},
+		{
+			name: "mask-nulls-drop", dt: arrow.PrimitiveTypes.Int32,
+			in:   []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			mask: mask, mvalid: mvalid,
+			want: []int32{0, 2, 5, 6, 7},
+		},
+		{
+			name: "mask-nulls-emit", dt: arrow.PrimitiveTypes.Int32,
+			in: []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, valid: valid,
+			mask: mask, mvalid: mvalid, opts: emitOpt,
+			want: []int32{0, 2, 0, 5, 6, 7, 0}, wvalid: []bool{true, false, false, true, true, false, false},
+		},
+		{
+			name: "all-null-mask-drop", dt: arrow.PrimitiveTypes.Int64,
+			in:   []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			mask: mask, mvalid: nomask,
+			want: []int64{},
+		},
+		{
+			name: "all-null-mask-emit", dt: arrow.PrimitiveTypes.Int64,
+			in:   []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			mask: mask, mvalid: nomask, opts: emitOpt,
+			want: make([]int64, 10), wvalid: nomask,
+		},
+		{
+			name: "empty", dt: arrow.PrimitiveTypes.Int64,
+			in: []int64{}, mask: []bool{}, want: []int64{},
+		},
+		{
+			name: "none-selected", dt: arrow.BinaryTypes.String,
+			in:   []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"},
+			mask: nomask,
+			want: []string{},
+		},
+		{
+			name: "bool", dt: arrow.FixedWidthTypes.Boolean,
+			in: []bool{true, true, false, false, true, true, false, false, true, true}, valid: valid,
+			mask: mask,
+			want: []bool{true, false, false, true, false, false, true}, wvalid: []bool{true, false, true, true, true, false, true},
+		},
+		{
+			name: "string", dt: arrow.BinaryTypes.String,
+			in: []string{"a", "bb", "", "ddd", "e", "ff", "g", "", "i", "jjjj"}, valid: valid,
+			mask: mask, mvalid: mvalid, opts: emitOpt,
+			want: []string{"a", "", "", "ff", "g", "", ""}, wvalid: []bool{true, false, false, true, true, false, false},
+		},
+		{
+			name: "binary", dt: arrow.BinaryTypes.Binary,
+			in:   [][]byte{[]byte("a"), []byte("bb"), nil, []byte("ddd"), []byte("e"), []byte("ff"), []byte("g"), nil, []byte("i"), []byte("jjjj")},
+			mask: mask,
+			want: [][]byte{[]byte("a"), nil, []byte("ddd"), []byte("ff"), []byte("g"), nil, []byte("jjjj")},
+		},
+		{
+			name: "decimal", dt: &arrow.Decimal128Type{Precision: 10, Scale: 2},
+			in:   []decimal128.Num{dec(0), dec(1), dec(2), dec(3), dec(4), dec(5), dec(6), dec(7), dec(8), dec(9)},
+			mask: mask,
+			want: []decimal128.Num{dec(0), dec(2), dec(3), dec(5), dec(6), dec(7), dec(9)},
+		},
+		{
+			name: "timestamp", dt: &arrow.TimestampType{Unit: arrow.Second},
+			in:   []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			mask: mask, mvalid: mvalid,
+			want: []int64{0, 2, 5, 6, 7},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			arr := arrayOf(mem, tc.dt, tc.in, tc.valid)
+			defer arr.Release()
+			mask := arrayOf(mem, arrow.FixedWidthTypes.Boolean, tc.mask, tc.mvalid)
+			defer mask.Release()
+
+			got, err := compute.FilterArray(ctx, arr, mask, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, tc.dt, tc.want, tc.wvalid)
+			defer want.Release()
+			assertArrayEqual(t, want, got)
+		})
+	}
+}
+
+func TestFilterArraySliced(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	const n = 300
+	var (
+		vals  = make([]string, n)
+		valid = make([]bool, n)
+		mask  = make([]bool, n)
+		mval  = make([]bool, n)
+		rng   = rand.New(rand.NewSource(0))
+	)
+	for i := range vals {
+		vals[i] = string(rune('a' + i%26))
+		valid[i] = rng.Intn(5) != 0
+		mask[i] = rng.Intn(3) != 0 || (i > 100 && i < 250)
+		mval[i] = rng.Intn(7) != 0
+	}
+
+	arr := arrayOf(mem, arrow.BinaryTypes.String, vals, valid)
+	defer arr.Release()
+	m := arrayOf(mem, arrow.FixedWidthTypes.Boolean, mask, mval)
+	defer m.Release()
+
+	for _, tc := range []struct {
+		vbeg, mbeg, n int
+	}{
+		{0, 0, n},
+		{3, 3, 200},
+		{5, 11, 250},
+		{64, 1, 230},
+		{13, 0, 0},
+	} {
+		for _, emit := range []bool{false, true} {
+			opts := &compute.FilterOptions{}
+			if emit {
+				opts.NullSelection = compute.EmitNulls
+			}
+
+			vs := array.NewSlice(arr, int64(tc.vbeg), int64(tc.vbeg+tc.n))
+			ms := array.NewSlice(m, int64(tc.mbeg), int64(tc.mbeg+tc.n))
+
+			var (
+				want  []string
+				wval  []bool
+				anyNa bool
+			)
+			for i := 0; i < tc.n; i++ {
+				switch {
+				case !mval[tc.mbeg+i]:
+					if emit {
+						want = append(want, "")
+						wval = append(wval, false)
+						anyNa = true
+					}
+				case mask[tc.mbeg+i]:
+					want = append(want, vals[tc.vbeg+i])
+					wval = append(wval, valid[tc.vbeg+i])
+					anyNa = anyNa || !valid[tc.vbeg+i]
+				}
+			}
+			if want == nil {
+				want = []string{}
+			}
+			if !anyNa {
+				wval = nil
+			}
+
+			got, err := compute.FilterArray(ctx, vs, ms, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp := arrayOf(mem, arrow.BinaryTypes.String, want, wval)
+			assertArrayEqual(t, exp, got)
+
+			exp.Release()
+			got.Release()
+			vs.Release()
+			ms.Release()
+		}
+	}
+}
+
+func TestFilterNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	mask := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{false, true, true, false, true}, nil)
+	defer mask.Release()
+
+	t.Run("list", func(t *testing.T) {
+		lb := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int32)
+		defer lb.Release()
+		vb := lb.ValueBuilder().(*array.Int32Builder)
+		lb.Append(true)
+		vb.AppendValues([]int32{0, 1}, nil)
+		lb.Append(true)
+		vb.AppendValues([]int32{2, 3, 4}, nil)
+		lb.AppendNull()
+		lb.Append(true)
+		lb.Append(true)
+		vb.AppendValues([]int32{5, 6}, []bool{false, true})
+		arr := lb.NewArray()
+		defer arr.Release()
+
+		lb.AppendNull()
+		lb.Append(true)
+		vb.AppendValues([]int32{2, 3, 4}, nil)
+		lb.AppendNull()
+		lb.Append(true)
+		vb.AppendValues([]int32{5, 6}, []bool{false, true})
+		want := lb.NewArray()
+		defer want.Release()
+
+		slice := array.NewSlice(arr, 1, 5)
+		defer slice.Release()
+		smask := array.NewSlice(mask, 1, 5)
+		defer smask.Release()
+
+		got, err := compute.FilterArray(ctx, slice, smask, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+
+		wslice := array.NewSlice(want, 1, 4)
+		defer wslice.Release()
+		assertArrayEqual(t, wslice, got)
+	})
+
+	t.Run("fixed-size-list", func(t *testing.T) {
+		lb := array.NewFixedSizeListBuilder(mem, 2, arrow.PrimitiveTypes.Int8)
+		defer lb.Release()
+		vb := lb.ValueBuilder().(*array.Int8Builder)
+		for i := 0; i < 5; i++ {
+			lb.Append(i != 2)
+			vb.AppendValues([]int8{int8(2 * i), int8(2*i + 1)}, nil)
+		}
+		arr := lb.NewArray()
+		defer arr.Release()
+
+		for _, i := range []int{1, 2, 4} {
+			lb.Append(i != 2)
+			vb.AppendValues([]int8{int8(2 * i), int8(2*i + 1)}, nil)
+		}
+		want := lb.NewArray()
+		defer want.Release()
+
+		got, err := compute.FilterArray(ctx, arr, mask, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+		assertArrayEqual(t, want, got)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		dt := arrow.StructOf(
+			arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+			arrow.Field{Name: "b", Type: arrow.BinaryTypes.String},
+		)
+		sb := array.NewStructBuilder(mem, dt)
+		defer sb.Release()
+		ab := sb.FieldBuilder(0).(*array.Int64Builder)
+		bb := sb.FieldBuilder(1).(*array.StringBuilder)
+		appendRows := func(rows []int) {
+			for _, i := range rows {
+				sb.Append(i != 4)
+				ab.Append(int64(i))
+				bb.Append(string(rune('a' + i)))
+			}
+		}
+		appendRows([]int{0, 1, 2, 3, 4})
+		arr := sb.NewArray()
+		defer arr.Release()
+
+		appendRows([]int{1, 2, 4})
+		want := sb.NewArray()
+		defer want.Release()
+
+		got, err := compute.FilterArray(ctx, arr, mask, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Release()
+		assertArrayEqual(t, want, got)
+	})
+}
+
+func TestFilterRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	a := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 3, 4}, nil)
+	defer a.Release()
+	b := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "b", "c", "d"}, []bool{true, true, false, true})
+	defer b.Release()
+	rec := array.NewRecord(schema, []array.Interface{a, b}, 4)
+	defer rec.Release()
+
+	mask := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{false, true, true, false}, nil)
+	defer mask.Release()
+
+	got, err := compute.FilterRecord(ctx, rec, mask, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got.NumRows() != 2 || !got.Schema().Equal(schema) {
+		t.Fatalf("invalid record: rows=%d, schema=%v", got.NumRows(), got.Schema())
+	}
+	wantA := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{2, 3}, nil)
+	defer wantA.Release()
+	wantB := arrayOf(mem, arrow.BinaryTypes.String, []string{"b", ""}, []bool{true, false})
+	defer wantB.Release()
+	assertArrayEqual(t, wantA, got.Column(0))
+	assertArrayEqual(t, wantB, got.Column(1))
+
+	short := array.NewSlice(mask, 0, 3)
+	defer short.Release()
+	if _, err := compute.FilterRecord(ctx, rec, short, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := compute.FilterRecord(ctx, rec, a, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func TestFilterTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+
+	c1 := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{0, 1, 2}, nil)
+	defer c1.Release()
+	c2 := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{3, 4, 5, 6}, nil)
+	defer c2.Release()
+	chunks := array.NewChunked(arrow.PrimitiveTypes.Int64, []array.Interface{c1, c2})
+	defer chunks.Release()
+	col := array.NewColumn(schema.Field(0), chunks)
+	defer col.Release()
+	tbl := array.NewTable(schema, []array.Column{*col}, -1)
+	defer tbl.Release()
+
+	m1 := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{true, false, true, true, true}, nil)
+	defer m1.Release()
+	m2 := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{false, true}, nil)
+	defer m2.Release()
+	mask := array.NewChunked(arrow.FixedWidthTypes.Boolean, []array.Interface{m1, m2})
+	defer mask.Release()
+
+	got, err := compute.FilterTable(ctx, tbl, mask, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got.NumRows() != 5 {
+		t.Fatalf("invalid number of rows: got=%d, want=5", got.NumRows())
+	}
+	gotChunks := got.Column(0).Data().Chunks()
+	if len(gotChunks) != 1 {
+		t.Fatalf("invalid number of chunks: %d", len(gotChunks))
+	}
+	want := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{0, 2, 3, 4, 6}, nil)
+	defer want.Release()
+	assertArrayEqual(t, want, gotChunks[0])
+}
+
+func TestFilterRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, dt := range randomTypes {
+		t.Run(dt.Name(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			for iter := 0; iter < 20; iter++ {
+				n := rng.Intn(150)
+				values := randomArray(mem, rng, dt, n, 0.2)
+				defer values.Release()
+				mask := randomArray(mem, rng, arrow.FixedWidthTypes.Boolean, n, 0.2).(*array.Boolean)
+				defer mask.Release()
+
+				for _, opts := range []*compute.FilterOptions{
+					{NullSelection: compute.DropNulls},
+					{NullSelection: compute.EmitNulls},
+				} {
+					var refs []int
+					for i := 0; i < n; i++ {
+						switch {
+						case mask.IsNull(i):
+							if opts.NullSelection == compute.EmitNulls {
+								refs = append(refs, -1)
+							}
+						case mask.Value(i):
+							refs = append(refs, i)
+						}
+					}
+
+					got, err := compute.FilterArray(ctx, values, mask, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					assertSelected(t, values, got, refs)
+					got.Release()
+
+					chunked := randomChunks(rng, values)
+					chunkedMask := randomChunks(rng, mask)
+					res, err := compute.FilterChunked(ctx, chunked, chunkedMask, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					assertSelected(t, values, res.Chunk(0), refs)
+					res.Release()
+					chunked.Release()
+					chunkedMask.Release()
+				}
+			}
+		})
+	}
+}
+
+func TestFilterDatum(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.BinaryTypes.String},
+	}, nil)
+	a := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "b", "c", "d"}, nil)
+	defer a.Release()
+	rec := array.NewRecord(schema, []array.Interface{a}, 4)
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(schema, []array.Record{rec})
+	defer tbl.Release()
+	chunked := array.NewChunked(a.DataType(), []array.Interface{a, a})
+	defer chunked.Release()
+
+	m := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{true, false, false, true}, []bool{true, true, false, true})
+	defer m.Release()
+	mask := compute.NewDatum(m)
+	defer mask.Release()
+	want := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "", "d"}, []bool{true, false, true})
+	defer want.Release()
+
+	opts := &compute.FilterOptions{NullSelection: compute.EmitNulls}
+	for _, tc := range []struct {
+		values interface{}
+		col    func(compute.Datum) array.Interface
+	}{
+		{a, func(d compute.Datum) array.Interface { return d.(*compute.ArrayDatum).Value }},
+		{rec, func(d compute.Datum) array.Interface { return d.(*compute.RecordDatum).Value.Column(0) }},
+		{tbl, func(d compute.Datum) array.Interface { return d.(*compute.TableDatum).Value.Column(0).Data().Chunk(0) }},
+	} {
+		values := compute.NewDatum(tc.values)
+		got, err := compute.Filter(ctx, values, mask, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Kind() != values.Kind() {
+			t.Fatalf("invalid datum kind: got=%v, want=%v", got.Kind(), values.Kind())
+		}
+		assertArrayEqual(t, want, tc.col(got))
+		got.Release()
+		values.Release()
+	}
+
+	values := compute.NewDatum(chunked)
+	defer values.Release()
+	if _, err := compute.Filter(ctx, values, mask, opts); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func benchmarkFilterData(n int, selectivity float64) ([]int64, []bool) {
+	rng := rand.New(rand.NewSource(0))
+	vals := make([]int64, n)
+	mask := make([]bool, n)
+	for i := range vals {
+		vals[i] = rng.Int63()
+		mask[i] = rng.Float64() < selectivity
+	}
+	return vals, mask
+}
+
+func BenchmarkFilterArray(b *testing.B) {
+	for _, bm := range []struct {
+		name        string
+		selectivity float64
+	}{
+		{"sel=0.05", 0.05},
+		{"sel=0.50", 0.50},
+		{"sel=0.95", 0.95},
+	} {
+		vals, mask := benchmarkFilterData(1<<20, bm.selectivity)
+		mem := memory.NewGoAllocator()
+		arr := arrayOf(mem, arrow.PrimitiveTypes.Int64, vals, nil)
+		m := arrayOf(mem, arrow.FixedWidthTypes.Boolean, mask, nil).(*array.Boolean)
+		ctx := compute.WithAllocator(context.Background(), mem)
+
+		b.Run(bm.name+"/kernel", func(b *testing.B) {
+			b.SetBytes(int64(len(vals) * 8))
+			for i := 0; i < b.N; i++ {
+				out, err := compute.FilterArray(ctx, arr, m, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				out.Release()
+			}
+		})
+
+		b.Run(bm.name+"/builder", func(b *testing.B) {
+			b.SetBytes(int64(len(vals) * 8))
+			src := arr.(*array.Int64)
+			for i := 0; i < b.N; i++ {
+				bldr := array.NewInt64Builder(mem)
+				for j := 0; j < src.Len(); j++ {
+					if m.Value(j) {
+						bldr.Append(src.Value(j))
+					}
+				}
+				bldr.NewArray().Release()
+				bldr.Release()
+			}
+		})
+
+		arr.Release()
+		m.Release()
+	}
+}
+
+func BenchmarkFilterChunked(b *testing.B) {
+	const chunkSize = 1 << 14
+
+	vals, mask := benchmarkFilterData(1<<20, 0.5)
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int64, vals, nil)
+	defer arr.Release()
+	m := arrayOf(mem, arrow.FixedWidthTypes.Boolean, mask, nil)
+	defer m.Release()
+
+	var chunks, masks []array.Interface
+	for i := 0; i < len(vals); i += chunkSize {
+		chunks = append(chunks, array.NewSlice(arr, int64(i), int64(i+chunkSize)))
+		masks = append(masks, array.NewSlice(m, int64(i), int64(i+chunkSize)))
+	}
+	chunked := array.NewChunked(arr.DataType(), chunks)
+	defer chunked.Release()
+	chunkedMask := array.NewChunked(m.DataType(), masks)
+	defer chunkedMask.Release()
+	for i := range chunks {
+		chunks[i].Release()
+		masks[i].Release()
+	}
+
+	b.SetBytes(int64(len(vals) * 8))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out, err := compute.FilterChunked(ctx, chunked, chunkedMask, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		out.Release()
+	}
+}
@@ -0,0 +1,870 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"math/big"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/hashing"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// Aggregation is an aggregate function computed by GroupBy over the
+// values of a column, for each group.
+type Aggregation struct {
+	// Function is one of "count", "sum", "mean", "min", "max", "any" or
+	// "all". They behave like Count, with the default options, Sum, Mean,
+	// MinMax, Any and All.
+	Function string
+	// Column is the name of the aggregated column.
+	Column string
+	// Name is the name of the result column, Column_Function by default.
+	Name string
+	// Options controls the handling of nulls by all functions but count.
+	// DefaultScalarAggregateOptions are used if nil.
+	Options *ScalarAggregateOptions
+}
+
+func (agg *Aggregation) name() string {
+	if agg.Name != "" {
+		return agg.Name
+	}
+	return agg.Column + "_" + agg.Function
+}
+
+// GroupByOptions controls the behavior of GroupBy.
+type GroupByOptions struct {
+	// NullEncoding controls whether the rows whose keys hold a null form
+	// their own groups, with NullEncodingEncode, or are left out, with
+	// NullEncodingMask.
+	NullEncoding NullEncodingBehavior
+}
+
+// DefaultGroupByOptions returns the options used when nil options are
+// passed to GroupBy: nulls form their own groups.
+func DefaultGroupByOptions() *GroupByOptions {
+	return &GroupByOptions{NullEncoding: NullEncodingEncode}
+}
+
+// GroupBy groups the rows of the records of reader by the values of the
+// key columns, and computes the aggregations over the rows of each group.
+// Records are consumed one at a time, and only the keys and the states of
+// the aggregations of the groups are kept in memory. Nothing is spilled to
+// disk: the memory used grows with the number of groups, not of rows.
+//
+// The result has a row per group, in the order of their first appearance,
+// with a column per key followed by a column per aggregation. Keys can be
+// numeric, decimal, temporal, boolean, string or binary columns, or
+// dictionaries of those, which are decoded.
+//
+// The returned record must be Release()'d after use.
+func GroupBy(ctx context.Context, reader array.RecordReader, keys []string, aggs []Aggregation, opts *GroupByOptions) (array.Record, error) {
+	if opts == nil {
+		opts = DefaultGroupByOptions()
+	}
+	mem := GetAllocator(ctx)
+	schema := reader.Schema()
+
+	keyCols := make([]int, len(keys))
+	for i, key := range keys {
+		idx, err := columnIndex(schema, key)
+		if err != nil {
+			return nil, err
+		}
+		keyCols[i] = idx
+	}
+	aggCols := make([]int, len(aggs))
+	aggregators := make([]groupAggregator, len(aggs))
+	for i := range aggs {
+		idx, err := columnIndex(schema, aggs[i].Column)
+		if err != nil {
+			return nil, err
+		}
+		aggCols[i] = idx
+		if aggregators[i], err = newGroupAggregator(&aggs[i], decodedType(schema.Field(idx).Type)); err != nil {
+			return nil, err
+		}
+	}
+
+	g, err := newGrouper(mem, schema, keyCols, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer g.release()
+	for reader.Next() {
+		if err := g.consume(mem, reader.Record(), keyCols, aggCols, aggregators); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]arrow.Field, 0, len(keys)+len(aggs))
+	cols := make([]array.Interface, 0, len(keys)+len(aggs))
+	defer func() { releaseArrays(cols) }()
+	for i, key := range keys {
+		dt := decodedType(schema.Field(keyCols[i]).Type)
+		arr, err := g.values(mem, i, dt)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, arr)
+		fields = append(fields, arrow.Field{Name: key, Type: dt, Nullable: true})
+	}
+	for i, agg := range aggregators {
+		agg.resize(g.n)
+		arr, err := agg.finish(mem)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, arr)
+		fields = append(fields, arrow.Field{Name: aggs[i].name(), Type: arr.DataType(), Nullable: true})
+	}
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(g.n)), nil
+}
+
+// GroupByTable is like GroupBy, over the rows of tbl.
+//
+// The returned record must be Release()'d after use.
+func GroupByTable(ctx context.Context, tbl array.Table, keys []string, aggs []Aggregation, opts *GroupByOptions) (array.Record, error) {
+	reader := array.NewTableReader(tbl, -1)
+	defer reader.Release()
+	return GroupBy(ctx, reader, keys, aggs, opts)
+}
+
+// columnIndex returns the index of the column of schema with the given
+// name, which must be unique.
+func columnIndex(schema *arrow.Schema, name string) (int, error) {
+	indices := schema.FieldIndices(name)
+	switch len(indices) {
+	case 0:
+		return 0, xerrors.Errorf("arrow/compute: unknown column %q in schema %v: %w", name, schema, ErrInvalid)
+	case 1:
+		return indices[0], nil
+	}
+	return 0, xerrors.Errorf("arrow/compute: ambiguous column %q in schema %v: %w", name, schema, ErrInvalid)
+}
+
+// decodedType returns the value type of dictionaries, and dt otherwise.
+func decodedType(dt arrow.DataType) arrow.DataType {
+	if dict, ok := dt.(*arrow.DictionaryType); ok {
+		return dict.ValueType
+	}
+	return dt
+}
+
+// decoded returns the values of arr, decoded if it is a dictionary. The
+// returned array must be released.
+func decoded(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+	if dict, ok := arr.(*array.Dictionary); ok {
+		return decodeDictionary(mem, dict)
+	}
+	arr.Retain()
+	return arr, nil
+}
+
+// grouper assigns consecutive group ids to the distinct keys of the rows
+// of records, in the order of their first appearance. Single keys are
+// indexed by a memo table. Multiple keys are encoded as byte strings, made
+// of the encoding of the value of each key column, and indexed by a binary
+// memo table.
+type grouper struct {
+	memo        memoTable
+	index       *hashing.BinaryMemoTable
+	encodeNulls bool
+	n           int // number of groups
+
+	// keys holds, for each key column, the key values of the new groups
+	// of each record, when there are multiple keys.
+	keys [][]array.Interface
+
+	key    []byte
+	groups []int32
+}
+
+func newGrouper(mem memory.Allocator, schema *arrow.Schema, keyCols []int, opts *GroupByOptions) (*grouper, error) {
+	g := &grouper{
+		encodeNulls: opts.NullEncoding == NullEncodingEncode,
+		keys:        make([][]array.Interface, len(keyCols)),
+	}
+	if len(keyCols) == 1 {
+		memo, err := newMemoTable(decodedType(schema.Field(keyCols[0]).Type), g.encodeNulls)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/compute: grouping by %v keys is not implemented: %w", schema.Field(keyCols[0]).Type, ErrNotImplemented)
+		}
+		g.memo = memo
+		return g, nil
+	}
+	for _, idx := range keyCols {
+		empty := makeNullArray(mem, decodedType(schema.Field(idx).Type), 0)
+		_, err := newKeyEncoder(empty)
+		empty.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	g.index = hashing.NewBinaryMemoTable(0, 0)
+	return g, nil
+}
+
+func (g *grouper) release() {
+	for _, chunks := range g.keys {
+		releaseArrays(chunks)
+	}
+}
+
+// consume assigns the rows of rec to groups, and updates the aggregators
+// with the values of their columns.
+func (g *grouper) consume(mem memory.Allocator, rec array.Record, keyCols, aggCols []int, aggregators []groupAggregator) error {
+	var (
+		n    = int(rec.NumRows())
+		cols = rec.Columns()
+	)
+	if cap(g.groups) < n {
+		g.groups = make([]int32, n)
+	}
+	groups := g.groups[:n]
+
+	keys := make([]array.Interface, len(keyCols))
+	defer func() { releaseArrays(keys) }()
+	for i, idx := range keyCols {
+		arr, err := decoded(mem, cols[idx])
+		if err != nil {
+			return err
+		}
+		keys[i] = arr
+	}
+	if g.memo != nil {
+		g.memo.insert(keys[0], groups)
+		g.n = g.memo.len()
+	} else if err := g.encode(mem, keys, groups); err != nil {
+		return err
+	}
+
+	for i, agg := range aggregators {
+		arr, err := decoded(mem, cols[aggCols[i]])
+		if err != nil {
+			return err
+		}
+		agg.resize(g.n)
+		agg.consume(arr, groups)
+		arr.Release()
+	}
+	return nil
+}
+
+// encode assigns the rows of the key columns to groups, by the encoding of
+// their values.
+func (g *grouper) encode(mem memory.Allocator, keys []array.Interface, groups []int32) error {
+	encoders := make([]keyEncoder, len(keys))
+	for i, arr := range keys {
+		enc, err := newKeyEncoder(arr)
+		if err != nil {
+			return err
+		}
+		encoders[i] = enc
+	}
+
+	var sel selection
+	for i := range groups {
+		var (
+			key  = g.key[:0]
+			null bool
+		)
+		for _, enc := range encoders {
+			var isNull bool
+			key, isNull = enc(key, i)
+			null = null || isNull
+		}
+		g.key = key
+		if null && !g.encodeNulls {
+			groups[i] = -1
+			continue
+		}
+		id, found := g.index.GetOrInsert(key)
+		if !found {
+			g.n++
+			sel.add(0, i, 1, false)
+		}
+		groups[i] = int32(id)
+	}
+
+	if sel.n == 0 {
+		return nil
+	}
+	for i, arr := range keys {
+		values, err := gather(mem, []array.Interface{arr}, &sel)
+		if err != nil {
+			return err
+		}
+		g.keys[i] = append(g.keys[i], values)
+	}
+	return nil
+}
+
+// values returns the values of the i-th key of the groups.
+func (g *grouper) values(mem memory.Allocator, i int, dt arrow.DataType) (array.Interface, error) {
+	if g.memo != nil {
+		return g.memo.values(mem), nil
+	}
+	return concatenate(mem, dt, g.keys[i])
+}
+
+// keyEncoder appends the encoding of the i-th value of an array to dst,
+// and reports whether the value is null.
+type keyEncoder func(dst []byte, i int) ([]byte, bool)
+
+// newKeyEncoder returns the key encoder of arr. Valid values are encoded
+// as a 1 followed by their little-endian bytes, or by their length and
+// bytes for variable-length values, and nulls as a 0.
+func newKeyEncoder(arr array.Interface) (keyEncoder, error) {
+	var enc keyEncoder
+	switch a := arr.(type) {
+	case *array.Boolean:
+		enc = func(dst []byte, i int) ([]byte, bool) {
+			if a.Value(i) {
+				return append(dst, 1, 1), false
+			}
+			return append(dst, 1, 0), false
+		}
+	case *array.Float32:
+		vals := a.Float32Values()
+		enc = func(dst []byte, i int) ([]byte, bool) {
+			return appendUint64(append(dst, 1), uint64(floatKey(float64(vals[i])))), false
+		}
+	case *array.Float64:
+		vals := a.Float64Values()
+		enc = func(dst []byte, i int) ([]byte, bool) {
+			return appendUint64(append(dst, 1), floatKey(vals[i])), false
+		}
+	default:
+		switch dt := arr.DataType().(type) {
+		case arrow.FixedWidthDataType:
+			var (
+				width = byteWidth(dt)
+				data  = arr.Data()
+				vals  []byte
+			)
+			if data.Len() > 0 {
+				vals = data.Buffers()[1].Bytes()[data.Offset()*width:]
+			}
+			enc = func(dst []byte, i int) ([]byte, bool) {
+				return append(append(dst, 1), vals[i*width:(i+1)*width]...), false
+			}
+		case arrow.BinaryDataType:
+			offsets, data := binaryValues(arr)
+			enc = func(dst []byte, i int) ([]byte, bool) {
+				v := data[offsets[i]:offsets[i+1]]
+				dst = append(dst, 1)
+				dst = appendUint64(dst, uint64(len(v)))
+				return append(dst, v...), false
+			}
+		default:
+			return nil, xerrors.Errorf("arrow/compute: grouping by %v keys is not implemented: %w", arr.DataType(), ErrNotImplemented)
+		}
+	}
+	if arr.NullN() == 0 {
+		return enc, nil
+	}
+	return func(dst []byte, i int) ([]byte, bool) {
+		if arr.IsNull(i) {
+			return append(dst, 0), true
+		}
+		return enc(dst, i)
+	}, nil
+}
+
+// floatKey returns the bits of v, with all zeros and all NaNs encoded
+// alike.
+func floatKey(v float64) uint64 {
+	switch {
+	case v == 0:
+		return 0
+	case v != v:
+		return math.Float64bits(math.NaN())
+	}
+	return math.Float64bits(v)
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+// groupAggregator computes an aggregation over the values of each group.
+type groupAggregator interface {
+	// resize grows the states of the aggregation to n groups.
+	resize(n int)
+	// consume adds the values of arr to the states of their groups. The
+	// values whose group is -1 are skipped.
+	consume(arr array.Interface, groups []int32)
+	// finish returns the results of the aggregation of each group.
+	finish(mem memory.Allocator) (array.Interface, error)
+}
+
+func newGroupAggregator(agg *Aggregation, dt arrow.DataType) (groupAggregator, error) {
+	opts := aggregateOptions(agg.Options)
+	switch agg.Function {
+	case "count":
+		return &groupCount{}, nil
+	case "sum", "mean":
+		out, err := sumType(agg.Function, dt)
+		if err != nil {
+			return nil, err
+		}
+		if agg.Function == "mean" && dt.ID() != arrow.DECIMAL {
+			out = arrow.PrimitiveTypes.Float64
+		} else if agg.Function == "mean" {
+			out = dt
+		}
+		return &groupSum{groupNulls: groupNulls{opts: opts}, in: dt, out: out, mean: agg.Function == "mean"}, nil
+	case "min", "max":
+		ext, err := newGroupExtrema(dt, agg.Function == "max")
+		if err != nil {
+			return nil, err
+		}
+		return &groupMinMax{groupNulls: groupNulls{opts: opts}, ext: ext}, nil
+	case "any", "all":
+		if err := checkBoolean(agg.Function, dt); err != nil {
+			return nil, err
+		}
+		return &groupAnyAll{groupNulls: groupNulls{opts: opts}, target: agg.Function == "any"}, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: unknown aggregate function %q: %w", agg.Function, ErrInvalid)
+}
+
+// groupNulls counts the valid and null values of each group.
+type groupNulls struct {
+	opts         *ScalarAggregateOptions
+	valid, nulls []int64
+}
+
+func (c *groupNulls) resize(n int) {
+	if n > len(c.valid) {
+		c.valid = append(c.valid, make([]int64, n-len(c.valid))...)
+		c.nulls = append(c.nulls, make([]int64, n-len(c.nulls))...)
+	}
+}
+
+func (c *groupNulls) count(arr array.Interface, groups []int32) {
+	nulls := arr.NullN() > 0
+	for i, g := range groups {
+		switch {
+		case g < 0:
+		case nulls && arr.IsNull(i):
+			c.nulls[g]++
+		default:
+			c.valid[g]++
+		}
+	}
+}
+
+// validity returns the validity bitmap of the results, and their number
+// of nulls. A result is null if the group has a null and nulls are not
+// skipped, or it has fewer valid values than required, or none if
+// nonEmpty is set.
+func (c *groupNulls) validity(mem memory.Allocator, nonEmpty bool) (*memory.Buffer, int) {
+	var (
+		buf   = newBuffer(mem, int(bitutil.BytesForBits(int64(len(c.valid)))))
+		nulls = 0
+	)
+	for g, valid := range c.valid {
+		if (!c.opts.SkipNulls && c.nulls[g] > 0) || valid < int64(c.opts.MinCount) || (nonEmpty && valid == 0) {
+			nulls++
+			continue
+		}
+		bitutil.SetBit(buf.Bytes(), g)
+	}
+	if nulls == 0 {
+		buf.Release()
+		return nil, 0
+	}
+	return buf, nulls
+}
+
+type groupCount struct {
+	counts []int64
+}
+
+func (c *groupCount) resize(n int) {
+	if n > len(c.counts) {
+		c.counts = append(c.counts, make([]int64, n-len(c.counts))...)
+	}
+}
+
+func (c *groupCount) consume(arr array.Interface, groups []int32) {
+	nulls := arr.NullN() > 0
+	for i, g := range groups {
+		if g >= 0 && !(nulls && arr.IsNull(i)) {
+			c.counts[g]++
+		}
+	}
+}
+
+func (c *groupCount) finish(mem memory.Allocator) (array.Interface, error) {
+	buf := newBuffer(mem, arrow.Int64Traits.BytesRequired(len(c.counts)))
+	copy(arrow.Int64Traits.CastFromBytes(buf.Bytes()), c.counts)
+	return makeArray(arrow.PrimitiveTypes.Int64, len(c.counts), []*memory.Buffer{nil, buf}, nil, 0), nil
+}
+
+// groupSum computes the sum, or the mean, of each group.
+type groupSum struct {
+	groupNulls
+	in, out arrow.DataType
+	mean    bool
+	sums    []sumState
+}
+
+func (s *groupSum) resize(n int) {
+	s.groupNulls.resize(n)
+	if n > len(s.sums) {
+		s.sums = append(s.sums, make([]sumState, n-len(s.sums))...)
+	}
+}
+
+func (s *groupSum) consume(arr array.Interface, groups []int32) {
+	s.count(arr, groups)
+	if groupSumNumeric(s.sums, arr, groups) {
+		return
+	}
+	vals := arr.(*array.Decimal128).Values()
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			g := groups[i]
+			if g < 0 {
+				continue
+			}
+			st := &s.sums[g]
+			if st.dec == nil {
+				st.dec = new(big.Int)
+			}
+			st.dec.Add(st.dec, vals[i].ToBigInt())
+			st.count++
+		}
+	})
+}
+
+func (s *groupSum) finish(mem memory.Allocator) (array.Interface, error) {
+	var (
+		n        = len(s.sums)
+		values   = newBuffer(mem, int(bitutil.BytesForBits(int64(n*s.out.(arrow.FixedWidthDataType).BitWidth()))))
+		validity *memory.Buffer
+		nulls    int
+	)
+	for g := range s.sums {
+		st := &s.sums[g]
+		if st.dec != nil && !fitsPrecision(st.dec, 38) {
+			st.overflow = true
+		}
+		if st.overflow && s.opts.CheckOverflow {
+			values.Release()
+			return nil, xerrors.Errorf("arrow/compute: overflow in %s: %w", s.name(), ErrInvalid)
+		}
+	}
+
+	switch id := s.in.ID(); {
+	case id == arrow.DECIMAL:
+		out := arrow.Decimal128Traits.CastFromBytes(values.Bytes())
+		for g := range s.sums {
+			st := &s.sums[g]
+			switch {
+			case st.count == 0:
+			case s.mean:
+				out[g] = st.meanDecimal()
+			default:
+				out[g] = bigToDecimal(st.dec)
+			}
+		}
+	case s.mean:
+		out := arrow.Float64Traits.CastFromBytes(values.Bytes())
+		for g := range s.sums {
+			if s.sums[g].count > 0 {
+				out[g] = s.sums[g].mean(id)
+			}
+		}
+	case isFloating(id):
+		out := arrow.Float64Traits.CastFromBytes(values.Bytes())
+		for g, st := range s.sums {
+			out[g] = st.f + st.c
+		}
+	case isSigned(id):
+		out := arrow.Int64Traits.CastFromBytes(values.Bytes())
+		for g, st := range s.sums {
+			out[g] = st.i
+		}
+	default:
+		out := arrow.Uint64Traits.CastFromBytes(values.Bytes())
+		for g, st := range s.sums {
+			out[g] = st.u
+		}
+	}
+
+	validity, nulls = s.validity(mem, s.mean)
+	return makeArray(s.out, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+}
+
+func (s *groupSum) name() string {
+	if s.mean {
+		return "mean"
+	}
+	return "sum"
+}
+
+// groupExtrema tracks the minimum, or maximum, valid value of each group.
+type groupExtrema interface {
+	resize(n int)
+	consume(arr array.Interface, groups []int32)
+	// values returns the extrema as an array with the given validity.
+	values(mem memory.Allocator, validity *memory.Buffer, nulls int) array.Interface
+}
+
+func newGroupExtrema(dt arrow.DataType, max bool) (groupExtrema, error) {
+	if ext := newGroupExtremaNumeric(dt, max); ext != nil {
+		return ext, nil
+	}
+	switch id := dt.ID(); {
+	case isTemporal(id):
+		return &groupExtremaTemporal{newGroupExtremaNumeric(storageType(dt), max), dt}, nil
+	case id == arrow.DECIMAL:
+		return &groupExtremaDecimal{max: max, dt: dt}, nil
+	case isBinaryLike(id):
+		return &groupExtremaBinary{max: max, dt: dt}, nil
+	case id == arrow.BOOL:
+		return &groupExtremaBoolean{max: max}, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: min_max is not implemented for %v: %w", dt, ErrNotImplemented)
+}
+
+// groupMinMax computes the minimum, or maximum, of each group.
+type groupMinMax struct {
+	groupNulls
+	ext groupExtrema
+}
+
+func (m *groupMinMax) resize(n int) {
+	m.groupNulls.resize(n)
+	m.ext.resize(n)
+}
+
+func (m *groupMinMax) consume(arr array.Interface, groups []int32) {
+	m.count(arr, groups)
+	m.ext.consume(arr, groups)
+}
+
+func (m *groupMinMax) finish(mem memory.Allocator) (array.Interface, error) {
+	validity, nulls := m.validity(mem, true)
+	return m.ext.values(mem, validity, nulls), nil
+}
+
+// groupExtremaTemporal tracks the extrema of temporal values through
+// their storage type.
+type groupExtremaTemporal struct {
+	groupExtrema
+	dt arrow.DataType
+}
+
+func (e *groupExtremaTemporal) consume(arr array.Interface, groups []int32) {
+	storage := reinterpret(arr, storageType(e.dt))
+	defer storage.Release()
+	e.groupExtrema.consume(storage, groups)
+}
+
+func (e *groupExtremaTemporal) values(mem memory.Allocator, validity *memory.Buffer, nulls int) array.Interface {
+	storage := e.groupExtrema.values(mem, validity, nulls)
+	defer storage.Release()
+	return reinterpret(storage, e.dt)
+}
+
+type groupExtremaDecimal struct {
+	max  bool
+	dt   arrow.DataType
+	vals []decimal128.Num
+	seen []bool
+}
+
+func (e *groupExtremaDecimal) resize(n int) {
+	if n > len(e.vals) {
+		e.vals = append(e.vals, make([]decimal128.Num, n-len(e.vals))...)
+		e.seen = append(e.seen, make([]bool, n-len(e.seen))...)
+	}
+}
+
+func (e *groupExtremaDecimal) consume(arr array.Interface, groups []int32) {
+	vals := arr.(*array.Decimal128).Values()
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			g, v := groups[i], vals[i]
+			switch {
+			case g < 0:
+			case !e.seen[g]:
+				e.vals[g], e.seen[g] = v, true
+			case e.max && decimalLess(e.vals[g], v), !e.max && decimalLess(v, e.vals[g]):
+				e.vals[g] = v
+			}
+		}
+	})
+}
+
+func (e *groupExtremaDecimal) values(mem memory.Allocator, validity *memory.Buffer, nulls int) array.Interface {
+	buf := newBuffer(mem, arrow.Decimal128Traits.BytesRequired(len(e.vals)))
+	copy(arrow.Decimal128Traits.CastFromBytes(buf.Bytes()), e.vals)
+	return makeArray(e.dt, len(e.vals), []*memory.Buffer{validity, buf}, nil, nulls)
+}
+
+type groupExtremaBinary struct {
+	max  bool
+	dt   arrow.DataType
+	vals [][]byte
+	seen []bool
+}
+
+func (e *groupExtremaBinary) resize(n int) {
+	if n > len(e.vals) {
+		e.vals = append(e.vals, make([][]byte, n-len(e.vals))...)
+		e.seen = append(e.seen, make([]bool, n-len(e.seen))...)
+	}
+}
+
+func (e *groupExtremaBinary) consume(arr array.Interface, groups []int32) {
+	offsets, data := binaryValues(arr)
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			g, v := groups[i], data[offsets[i]:offsets[i+1]]
+			if g < 0 {
+				continue
+			}
+			if cmp := bytes.Compare(v, e.vals[g]); !e.seen[g] || (e.max && cmp > 0) || (!e.max && cmp < 0) {
+				// the values are copied, as arr is released.
+				e.vals[g], e.seen[g] = append(e.vals[g][:0], v...), true
+			}
+		}
+	})
+}
+
+func (e *groupExtremaBinary) values(mem memory.Allocator, validity *memory.Buffer, nulls int) array.Interface {
+	size := 0
+	for _, v := range e.vals {
+		size += len(v)
+	}
+	var (
+		offsets = newBuffer(mem, arrow.Int32Traits.BytesRequired(len(e.vals)+1))
+		data    = newBuffer(mem, size)
+		offs    = arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+		pos     = 0
+	)
+	for i, v := range e.vals {
+		pos += copy(data.Bytes()[pos:], v)
+		offs[i+1] = int32(pos)
+	}
+	return makeArray(e.dt, len(e.vals), []*memory.Buffer{validity, offsets, data}, nil, nulls)
+}
+
+type groupExtremaBoolean struct {
+	max  bool
+	vals []bool
+	seen []bool
+}
+
+func (e *groupExtremaBoolean) resize(n int) {
+	if n > len(e.vals) {
+		e.vals = append(e.vals, make([]bool, n-len(e.vals))...)
+		e.seen = append(e.seen, make([]bool, n-len(e.seen))...)
+	}
+}
+
+func (e *groupExtremaBoolean) consume(arr array.Interface, groups []int32) {
+	a := arr.(*array.Boolean)
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			g, v := groups[i], a.Value(i)
+			switch {
+			case g < 0:
+			case !e.seen[g]:
+				e.vals[g], e.seen[g] = v, true
+			case e.max:
+				e.vals[g] = e.vals[g] || v
+			default:
+				e.vals[g] = e.vals[g] && v
+			}
+		}
+	})
+}
+
+func (e *groupExtremaBoolean) values(mem memory.Allocator, validity *memory.Buffer, nulls int) array.Interface {
+	buf := newBuffer(mem, int(bitutil.BytesForBits(int64(len(e.vals)))))
+	for i, v := range e.vals {
+		if v {
+			bitutil.SetBit(buf.Bytes(), i)
+		}
+	}
+	return makeArray(arrow.FixedWidthTypes.Boolean, len(e.vals), []*memory.Buffer{validity, buf}, nil, nulls)
+}
+
+// groupAnyAll computes whether any, or all, of the values of each group
+// are true, following the three-valued logic of Any and All.
+type groupAnyAll struct {
+	groupNulls
+	target bool
+	found  []bool // whether the group has a valid value equal to target
+}
+
+func (a *groupAnyAll) resize(n int) {
+	a.groupNulls.resize(n)
+	if n > len(a.found) {
+		a.found = append(a.found, make([]bool, n-len(a.found))...)
+	}
+}
+
+func (a *groupAnyAll) consume(arr array.Interface, groups []int32) {
+	a.count(arr, groups)
+	b := arr.(*array.Boolean)
+	visitValid(arr, func(pos, n int) {
+		for i := pos; i < pos+n; i++ {
+			if g := groups[i]; g >= 0 && b.Value(i) == a.target {
+				a.found[g] = true
+			}
+		}
+	})
+}
+
+func (a *groupAnyAll) finish(mem memory.Allocator) (array.Interface, error) {
+	var (
+		n        = len(a.found)
+		values   = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		validity = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		nulls    = 0
+	)
+	for g, found := range a.found {
+		if a.valid[g] < int64(a.opts.MinCount) || (!found && a.nulls[g] > 0 && !a.opts.SkipNulls) {
+			nulls++
+			continue
+		}
+		bitutil.SetBit(validity.Bytes(), g)
+		if found == a.target {
+			bitutil.SetBit(values.Bytes(), g)
+		}
+	}
+	return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{validity, values}, nil, nulls), nil
+}
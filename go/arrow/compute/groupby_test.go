@@ -0,0 +1,690 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// recordReaderOf returns a reader over records of the given schema, built
+// from the columns of each batch.
+func recordReaderOf(t testing.TB, schema *arrow.Schema, batches ...[]array.Interface) array.RecordReader {
+	recs := make([]array.Record, len(batches))
+	for i, cols := range batches {
+		n := 0
+		if len(cols) > 0 {
+			n = cols[0].Len()
+		}
+		recs[i] = array.NewRecord(schema, cols, int64(n))
+		defer recs[i].Release()
+		releaseAll(cols)
+	}
+	reader, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
+func TestGroupBy(t *testing.T) {
+	var (
+		str    = arrow.BinaryTypes.String
+		schema = arrow.NewSchema([]arrow.Field{
+			{Name: "k1", Type: str, Nullable: true},
+			{Name: "k2", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "v", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			{Name: "f", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "s", Type: str},
+		}, nil)
+		aggs = []compute.Aggregation{
+			{Function: "count", Column: "v"},
+			{Function: "sum", Column: "v"},
+			{Function: "sum", Column: "v", Name: "strict", Options: &compute.ScalarAggregateOptions{}},
+			{Function: "mean", Column: "v"},
+			{Function: "max", Column: "f"},
+			{Function: "min", Column: "s"},
+		}
+	)
+
+	for _, tc := range []struct {
+		name  string
+		opts  *compute.GroupByOptions
+		k1    []string
+		k1Ok  []bool
+		k2    []int32
+		count []int64
+		sum   []int64
+		strOk []bool
+		mean  []float64
+		max   []float64
+		min   []string
+	}{
+		{
+			name:  "nulls-encoded",
+			k1:    []string{"a", "b", "", "a"},
+			k1Ok:  []bool{true, true, false, true},
+			k2:    []int32{1, 1, 2, 2},
+			count: []int64{1, 2, 2, 1},
+			sum:   []int64{1, 12, 24, 30},
+			strOk: []bool{false, true, true, true},
+			mean:  []float64{1, 6, 12, 30},
+			max:   []float64{2.5, 1.5, 9, 0},
+			min:   []string{"x", "a", "b", "c"},
+		},
+		{
+			name:  "nulls-masked",
+			opts:  &compute.GroupByOptions{NullEncoding: compute.NullEncodingMask},
+			k1:    []string{"a", "b", "a"},
+			k2:    []int32{1, 1, 2},
+			count: []int64{1, 2, 1},
+			sum:   []int64{1, 12, 30},
+			strOk: []bool{false, true, true},
+			mean:  []float64{1, 6, 30},
+			max:   []float64{2.5, 1.5, 0},
+			min:   []string{"x", "a", "c"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			reader := recordReaderOf(t, schema,
+				[]array.Interface{
+					arrayOf(mem, str, []string{"a", "b", "a", ""}, []bool{true, true, true, false}),
+					arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 1, 1, 2}, nil),
+					arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{1, 2, 0, 4}, []bool{true, true, false, true}),
+					arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{0.5, 1.5, 2.5, 3.5}, nil),
+					arrayOf(mem, str, []string{"x", "y", "z", "w"}, nil),
+				},
+				[]array.Interface{
+					arrayOf(mem, str, []string{"b", "", "a"}, []bool{true, false, true}),
+					arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 2}, nil),
+					arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{10, 20, 30}, nil),
+					arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{-1, 9, 0}, nil),
+					arrayOf(mem, str, []string{"a", "b", "c"}, nil),
+				},
+			)
+			defer reader.Release()
+
+			got, err := compute.GroupBy(ctx, reader, []string{"k1", "k2"}, aggs, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			wantNames := []string{"k1", "k2", "v_count", "v_sum", "strict", "v_mean", "f_max", "s_min"}
+			for i, f := range got.Schema().Fields() {
+				if f.Name != wantNames[i] {
+					t.Fatalf("invalid field %d: got=%q, want=%q", i, f.Name, wantNames[i])
+				}
+			}
+
+			want := []array.Interface{
+				arrayOf(mem, str, tc.k1, tc.k1Ok),
+				arrayOf(mem, arrow.PrimitiveTypes.Int32, tc.k2, nil),
+				arrayOf(mem, arrow.PrimitiveTypes.Int64, tc.count, nil),
+				arrayOf(mem, arrow.PrimitiveTypes.Int64, tc.sum, nil),
+				arrayOf(mem, arrow.PrimitiveTypes.Int64, tc.sum, tc.strOk),
+				arrayOf(mem, arrow.PrimitiveTypes.Float64, tc.mean, nil),
+				arrayOf(mem, arrow.PrimitiveTypes.Float64, tc.max, nil),
+				arrayOf(mem, str, tc.min, nil),
+			}
+			defer releaseAll(want)
+			for i, w := range want {
+				assertArrayEqual(t, w, got.Column(i))
+			}
+		})
+	}
+}
+
+func TestGroupByRandom(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	type state struct {
+		count, sum int64
+		min        int32
+		max        string
+	}
+	var (
+		rng    = rand.New(rand.NewSource(0))
+		str    = arrow.BinaryTypes.String
+		schema = arrow.NewSchema([]arrow.Field{
+			{Name: "k1", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "k2", Type: str},
+			{Name: "v", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "s", Type: str},
+		}, nil)
+		naive   = make(map[[2]string]*state)
+		batches [][]array.Interface
+	)
+	for b := 0; b < 3; b++ {
+		const n = 10000
+		var (
+			k1 = make([]int64, n)
+			k2 = make([]string, n)
+			v  = make([]int32, n)
+			vs = make([]string, n)
+		)
+		for i := range v {
+			k1[i], k2[i], v[i] = rng.Int63n(100), strconv.Itoa(rng.Intn(7)), rng.Int31n(1000)-500
+			vs[i] = strconv.Itoa(int(v[i]))
+			key := [2]string{strconv.FormatInt(k1[i], 10), k2[i]}
+			st, ok := naive[key]
+			if !ok {
+				st = &state{min: v[i], max: vs[i]}
+				naive[key] = st
+			}
+			st.count++
+			st.sum += int64(v[i])
+			if v[i] < st.min {
+				st.min = v[i]
+			}
+			if vs[i] > st.max {
+				st.max = vs[i]
+			}
+		}
+		batches = append(batches, []array.Interface{
+			arrayOf(mem, arrow.PrimitiveTypes.Int64, k1, nil),
+			arrayOf(mem, str, k2, nil),
+			arrayOf(mem, arrow.PrimitiveTypes.Int32, v, nil),
+			arrayOf(mem, str, vs, nil),
+		})
+	}
+	reader := recordReaderOf(t, schema, batches...)
+	defer reader.Release()
+
+	got, err := compute.GroupBy(ctx, reader, []string{"k1", "k2"}, []compute.Aggregation{
+		{Function: "count", Column: "v"},
+		{Function: "sum", Column: "v"},
+		{Function: "min", Column: "v"},
+		{Function: "max", Column: "s"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got, want := int(got.NumRows()), len(naive); got != want {
+		t.Fatalf("invalid number of groups: got=%d, want=%d", got, want)
+	}
+	var (
+		k1    = got.Column(0).(*array.Int64)
+		k2    = got.Column(1).(*array.String)
+		count = got.Column(2).(*array.Int64)
+		sum   = got.Column(3).(*array.Int64)
+		min   = got.Column(4).(*array.Int32)
+		max   = got.Column(5).(*array.String)
+	)
+	for i := 0; i < int(got.NumRows()); i++ {
+		st := naive[[2]string{strconv.FormatInt(k1.Value(i), 10), k2.Value(i)}]
+		if st == nil {
+			t.Fatalf("row %d: unknown group (%d, %q)", i, k1.Value(i), k2.Value(i))
+		}
+		if count.Value(i) != st.count || sum.Value(i) != st.sum || min.Value(i) != st.min || max.Value(i) != st.max {
+			t.Fatalf("row %d: got=(%d, %d, %d, %q), want=%+v", i, count.Value(i), sum.Value(i), min.Value(i), max.Value(i), *st)
+		}
+	}
+}
+
+// TestGroupByOptions checks that the aggregations of each group are the
+// scalar aggregations of their values, for each set of options.
+func TestGroupByOptions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "v", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "b", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	}, nil)
+
+	// the rows of the non-empty inputs are interleaved, each input forming
+	// a group.
+	inputs := aggregateOptionInputs[1:]
+	var (
+		keys  []int32
+		ints  []int32
+		bools []bool
+		valid []bool
+	)
+	for i := 0; i < 3; i++ {
+		for k, in := range inputs {
+			if i >= len(in.ints) {
+				continue
+			}
+			keys = append(keys, int32(k))
+			ints = append(ints, in.ints[i])
+			bools = append(bools, in.bools[i])
+			valid = append(valid, in.valid == nil || in.valid[i])
+		}
+	}
+
+	for _, set := range aggregateOptionSets {
+		t.Run(set.name, func(t *testing.T) {
+			reader := recordReaderOf(t, schema, []array.Interface{
+				arrayOf(mem, arrow.PrimitiveTypes.Int32, keys, nil),
+				arrayOf(mem, arrow.PrimitiveTypes.Int32, ints, valid),
+				arrayOf(mem, arrow.FixedWidthTypes.Boolean, bools, valid),
+			})
+			defer reader.Release()
+
+			var aggs []compute.Aggregation
+			for _, agg := range []struct{ fn, col string }{
+				{"sum", "v"}, {"mean", "v"}, {"min", "v"}, {"max", "v"}, {"any", "b"}, {"all", "b"},
+			} {
+				aggs = append(aggs, compute.Aggregation{Function: agg.fn, Column: agg.col, Options: set.opts})
+			}
+			got, err := compute.GroupBy(ctx, reader, []string{"k"}, aggs, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			for g, in := range inputs {
+				results := make([]interface{}, len(aggs))
+				for i := range aggs {
+					s, err := scalar.GetScalar(got.Column(i+1), g)
+					if err != nil {
+						t.Fatal(err)
+					}
+					results[i] = s
+				}
+
+				ints := datumOf(mem, arrow.PrimitiveTypes.Int32, in.ints, in.valid, nil)
+				defer ints.Release()
+				bools := datumOf(mem, arrow.FixedWidthTypes.Boolean, in.bools, in.valid, nil)
+				defer bools.Release()
+				want, err := aggregateResults(ctx, ints, bools, set.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := fmt.Sprint(results...); got != want {
+					t.Fatalf("group %s: got %q, want %q", in.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupByEmpty(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: arrow.BinaryTypes.String},
+		{Name: "v", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+	}, nil)
+	reader := recordReaderOf(t, schema)
+	defer reader.Release()
+
+	got, err := compute.GroupBy(ctx, reader, []string{"k"}, []compute.Aggregation{
+		{Function: "sum", Column: "v"},
+		{Function: "mean", Column: "v"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "v_sum", Type: &arrow.Decimal128Type{Precision: 38, Scale: 2}, Nullable: true},
+		{Name: "v_mean", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}, Nullable: true},
+	}, nil)
+	if got.NumRows() != 0 || !got.Schema().Equal(want) {
+		t.Fatalf("invalid result: got=%v (%d rows), want=%v", got.Schema(), got.NumRows(), want)
+	}
+}
+
+func TestGroupByDecimalKeys(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	dt := &arrow.Decimal128Type{Precision: 10, Scale: 2}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k1", Type: dt},
+		{Name: "k2", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "v", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	// 1 and 65537 share their two low bytes.
+	reader := recordReaderOf(t, schema, []array.Interface{
+		arrayOf(mem, dt, []decimal128.Num{dec(1), dec(65537), dec(1), dec(-1)}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 1, 1, 1}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{1, 2, 3, 4}, nil),
+	})
+	defer reader.Release()
+
+	got, err := compute.GroupBy(ctx, reader, []string{"k1", "k2"}, []compute.Aggregation{
+		{Function: "sum", Column: "v"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	for i, want := range []array.Interface{
+		arrayOf(mem, dt, []decimal128.Num{dec(1), dec(65537), dec(-1)}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 1, 1}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{4, 2, 4}, nil),
+	} {
+		assertArrayEqual(t, want, got.Column(i))
+		want.Release()
+	}
+}
+
+func TestGroupByTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "region", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "year", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "v", Type: arrow.PrimitiveTypes.Float64},
+	}, nil)
+	var recs []array.Record
+	for _, batch := range [][]array.Interface{
+		{
+			arrayOf(mem, arrow.BinaryTypes.String, []string{"eu", "us", "eu", ""}, []bool{true, true, true, false}),
+			arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{2020, 2020, 2021, 2020}, nil),
+			arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{1, 2, 3, 4}, nil),
+		},
+		{
+			arrayOf(mem, arrow.BinaryTypes.String, []string{"eu", "", "us"}, []bool{true, false, true}),
+			arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{2020, 2020, 2021}, nil),
+			arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{5, 6, 7}, nil),
+		},
+	} {
+		recs = append(recs, array.NewRecord(schema, batch, -1))
+		defer recs[len(recs)-1].Release()
+		releaseAll(batch)
+	}
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	got, err := compute.GroupByTable(ctx, tbl, []string{"region", "year"}, []compute.Aggregation{
+		{Function: "sum", Column: "v"},
+		{Function: "min", Column: "v"},
+		{Function: "max", Column: "v"},
+		{Function: "count", Column: "v"},
+		{Function: "mean", Column: "v"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	for i, want := range []array.Interface{
+		arrayOf(mem, arrow.BinaryTypes.String, []string{"eu", "us", "eu", "", "us"}, []bool{true, true, true, false, true}),
+		arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{2020, 2020, 2021, 2020, 2021}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{6, 2, 3, 10, 7}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{1, 2, 3, 4, 7}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{5, 2, 3, 6, 7}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{2, 1, 1, 2, 1}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{3, 2, 3, 5, 7}, nil),
+	} {
+		assertArrayEqual(t, want, got.Column(i))
+		want.Release()
+	}
+}
+
+func TestGroupByDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	keys := datumOf(mem, arrow.BinaryTypes.String, []string{"x", "y", "x"}, nil, nil)
+	defer keys.Release()
+	encoded, err := compute.DictionaryEncode(ctx, keys, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoded.Release()
+	dict := encoded.(*compute.ArrayDatum).Value
+	dict.Retain()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: dict.DataType()},
+		{Name: "v", Type: arrow.PrimitiveTypes.Uint8},
+	}, nil)
+	reader := recordReaderOf(t, schema, []array.Interface{
+		dict,
+		arrayOf(mem, arrow.PrimitiveTypes.Uint8, []uint8{1, 2, 3}, nil),
+	})
+	defer reader.Release()
+
+	got, err := compute.GroupBy(ctx, reader, []string{"k"}, []compute.Aggregation{{Function: "sum", Column: "v"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	want := []array.Interface{
+		arrayOf(mem, arrow.BinaryTypes.String, []string{"x", "y"}, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Uint64, []uint64{4, 2}, nil),
+	}
+	defer releaseAll(want)
+	for i, w := range want {
+		assertArrayEqual(t, w, got.Column(i))
+	}
+}
+
+func TestGroupByErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "s", Type: arrow.BinaryTypes.String},
+		{Name: "l", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+	}, nil)
+	for _, tc := range []struct {
+		name string
+		keys []string
+		aggs []compute.Aggregation
+		err  error
+	}{
+		{"unknown-key", []string{"x"}, nil, compute.ErrInvalid},
+		{"unknown-column", []string{"k"}, []compute.Aggregation{{Function: "sum", Column: "x"}}, compute.ErrInvalid},
+		{"unknown-function", []string{"k"}, []compute.Aggregation{{Function: "median", Column: "k"}}, compute.ErrInvalid},
+		{"sum-strings", []string{"k"}, []compute.Aggregation{{Function: "sum", Column: "s"}}, compute.ErrNotImplemented},
+		{"min-lists", []string{"k"}, []compute.Aggregation{{Function: "min", Column: "l"}}, compute.ErrNotImplemented},
+		{"any-ints", []string{"k"}, []compute.Aggregation{{Function: "any", Column: "k"}}, compute.ErrNotImplemented},
+		{"list-keys", []string{"l"}, nil, compute.ErrNotImplemented},
+		{"list-multi-keys", []string{"k", "l"}, nil, compute.ErrNotImplemented},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := recordReaderOf(t, schema)
+			defer reader.Release()
+			if _, err := compute.GroupBy(ctx, reader, tc.keys, tc.aggs, nil); !xerrors.Is(err, tc.err) {
+				t.Fatalf("got err=%v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func BenchmarkGroupBy(b *testing.B) {
+	const (
+		batches   = 10
+		batchSize = 1000000
+		groups    = 100000
+	)
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var (
+		rng    = rand.New(rand.NewSource(0))
+		schema = arrow.NewSchema([]arrow.Field{
+			{Name: "k", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "v", Type: arrow.PrimitiveTypes.Int64},
+		}, nil)
+		recs = make([]array.Record, batches)
+	)
+	for i := range recs {
+		k, v := make([]int64, batchSize), make([]int64, batchSize)
+		for j := range k {
+			k[j], v[j] = rng.Int63n(groups), rng.Int63n(1000)
+		}
+		cols := []array.Interface{
+			arrayOf(mem, arrow.PrimitiveTypes.Int64, k, nil),
+			arrayOf(mem, arrow.PrimitiveTypes.Int64, v, nil),
+		}
+		recs[i] = array.NewRecord(schema, cols, batchSize)
+		defer recs[i].Release()
+		releaseAll(cols)
+	}
+	aggs := []compute.Aggregation{
+		{Function: "sum", Column: "v"},
+		{Function: "count", Column: "v"},
+	}
+
+	b.Run("kernel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reader, err := array.NewRecordReader(schema, recs)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out, err := compute.GroupBy(ctx, reader, []string{"k"}, aggs, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+			reader.Release()
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		type state struct{ sum, count int64 }
+		for i := 0; i < b.N; i++ {
+			var (
+				index  = make(map[int64]int)
+				keys   []int64
+				states []state
+			)
+			for _, rec := range recs {
+				k := rec.Column(0).(*array.Int64).Int64Values()
+				v := rec.Column(1).(*array.Int64).Int64Values()
+				for j, key := range k {
+					idx, ok := index[key]
+					if !ok {
+						idx = len(states)
+						index[key] = idx
+						keys = append(keys, key)
+						states = append(states, state{})
+					}
+					states[idx].sum += v[j]
+					states[idx].count++
+				}
+			}
+			bldr := array.NewRecordBuilder(mem, arrow.NewSchema([]arrow.Field{
+				{Name: "k", Type: arrow.PrimitiveTypes.Int64},
+				{Name: "v_sum", Type: arrow.PrimitiveTypes.Int64},
+				{Name: "v_count", Type: arrow.PrimitiveTypes.Int64},
+			}, nil))
+			for j, st := range states {
+				bldr.Field(0).(*array.Int64Builder).Append(keys[j])
+				bldr.Field(1).(*array.Int64Builder).Append(st.sum)
+				bldr.Field(2).(*array.Int64Builder).Append(st.count)
+			}
+			bldr.NewRecord().Release()
+			bldr.Release()
+		}
+	})
+}
+
+func BenchmarkGroupByString(b *testing.B) {
+	const (
+		n      = 1000000
+		groups = 10000
+	)
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var (
+		rng    = rand.New(rand.NewSource(0))
+		schema = arrow.NewSchema([]arrow.Field{
+			{Name: "k", Type: arrow.BinaryTypes.String},
+			{Name: "v", Type: arrow.PrimitiveTypes.Float64},
+		}, nil)
+		k = make([]string, n)
+		v = make([]float64, n)
+	)
+	for i := range k {
+		k[i], v[i] = fmt.Sprintf("key-%d", rng.Intn(groups)), rng.Float64()
+	}
+	cols := []array.Interface{
+		arrayOf(mem, arrow.BinaryTypes.String, k, nil),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, v, nil),
+	}
+	rec := array.NewRecord(schema, cols, n)
+	defer rec.Release()
+	releaseAll(cols)
+	aggs := []compute.Aggregation{{Function: "sum", Column: "v"}}
+
+	b.Run("kernel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reader, err := array.NewRecordReader(schema, []array.Record{rec})
+			if err != nil {
+				b.Fatal(err)
+			}
+			out, err := compute.GroupBy(ctx, reader, []string{"k"}, aggs, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+			reader.Release()
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sums := make(map[string]float64)
+			keys := rec.Column(0).(*array.String)
+			vals := rec.Column(1).(*array.Float64).Float64Values()
+			for j, v := range vals {
+				sums[keys.Value(j)] += v
+			}
+			bldr := array.NewRecordBuilder(mem, arrow.NewSchema([]arrow.Field{
+				{Name: "k", Type: arrow.BinaryTypes.String},
+				{Name: "v_sum", Type: arrow.PrimitiveTypes.Float64},
+			}, nil))
+			for k, sum := range sums {
+				bldr.Field(0).(*array.StringBuilder).Append(k)
+				bldr.Field(1).(*array.Float64Builder).Append(sum)
+			}
+			bldr.NewRecord().Release()
+			bldr.Release()
+		}
+	})
+}
@@ -0,0 +1,484 @@
+// Code generated by hash.gen.go.tmpl. DO NOT EDIT.
+
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/hashing"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// newNumericMemo returns a memo table for the numeric type dt, or nil if
+// dt is not numeric.
+func newNumericMemo(dt arrow.DataType, encodeNulls bool) memoTable {
+	switch dt.ID() {
+	case arrow.INT8:
+		return &memoInt8{hashing.NewInt8MemoTable(0), encodeNulls}
+	case arrow.INT16:
+		return &memoInt16{hashing.NewInt16MemoTable(0), encodeNulls}
+	case arrow.INT32:
+		return &memoInt32{hashing.NewInt32MemoTable(0), encodeNulls}
+	case arrow.INT64:
+		return &memoInt64{hashing.NewInt64MemoTable(0), encodeNulls}
+	case arrow.UINT8:
+		return &memoUint8{hashing.NewUint8MemoTable(0), encodeNulls}
+	case arrow.UINT16:
+		return &memoUint16{hashing.NewUint16MemoTable(0), encodeNulls}
+	case arrow.UINT32:
+		return &memoUint32{hashing.NewUint32MemoTable(0), encodeNulls}
+	case arrow.UINT64:
+		return &memoUint64{hashing.NewUint64MemoTable(0), encodeNulls}
+	case arrow.FLOAT32:
+		return &memoFloat32{hashing.NewFloat32MemoTable(0), encodeNulls}
+	case arrow.FLOAT64:
+		return &memoFloat64{hashing.NewFloat64MemoTable(0), encodeNulls}
+	}
+	return nil
+}
+
+type memoInt8 struct {
+	*hashing.Int8MemoTable
+	encodeNulls bool
+}
+
+func (m *memoInt8) len() int { return m.Size() }
+
+func (m *memoInt8) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int8).Int8Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt8) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int8).Int8Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt8) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Int8Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Int8Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Int8, m.Size(), nullIndex(m), buf)
+}
+
+type memoInt16 struct {
+	*hashing.Int16MemoTable
+	encodeNulls bool
+}
+
+func (m *memoInt16) len() int { return m.Size() }
+
+func (m *memoInt16) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int16).Int16Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt16) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int16).Int16Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt16) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Int16Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Int16Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Int16, m.Size(), nullIndex(m), buf)
+}
+
+type memoInt32 struct {
+	*hashing.Int32MemoTable
+	encodeNulls bool
+}
+
+func (m *memoInt32) len() int { return m.Size() }
+
+func (m *memoInt32) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int32).Int32Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt32) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int32).Int32Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt32) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Int32Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Int32Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Int32, m.Size(), nullIndex(m), buf)
+}
+
+type memoInt64 struct {
+	*hashing.Int64MemoTable
+	encodeNulls bool
+}
+
+func (m *memoInt64) len() int { return m.Size() }
+
+func (m *memoInt64) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int64).Int64Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt64) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Int64).Int64Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoInt64) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Int64Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Int64Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Int64, m.Size(), nullIndex(m), buf)
+}
+
+type memoUint8 struct {
+	*hashing.Uint8MemoTable
+	encodeNulls bool
+}
+
+func (m *memoUint8) len() int { return m.Size() }
+
+func (m *memoUint8) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint8).Uint8Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint8) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint8).Uint8Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint8) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Uint8Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Uint8Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Uint8, m.Size(), nullIndex(m), buf)
+}
+
+type memoUint16 struct {
+	*hashing.Uint16MemoTable
+	encodeNulls bool
+}
+
+func (m *memoUint16) len() int { return m.Size() }
+
+func (m *memoUint16) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint16).Uint16Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint16) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint16).Uint16Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint16) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Uint16Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Uint16Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Uint16, m.Size(), nullIndex(m), buf)
+}
+
+type memoUint32 struct {
+	*hashing.Uint32MemoTable
+	encodeNulls bool
+}
+
+func (m *memoUint32) len() int { return m.Size() }
+
+func (m *memoUint32) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint32).Uint32Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint32) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint32).Uint32Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint32) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Uint32Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Uint32Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Uint32, m.Size(), nullIndex(m), buf)
+}
+
+type memoUint64 struct {
+	*hashing.Uint64MemoTable
+	encodeNulls bool
+}
+
+func (m *memoUint64) len() int { return m.Size() }
+
+func (m *memoUint64) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint64).Uint64Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint64) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Uint64).Uint64Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoUint64) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Uint64Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Uint64Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Uint64, m.Size(), nullIndex(m), buf)
+}
+
+type memoFloat32 struct {
+	*hashing.Float32MemoTable
+	encodeNulls bool
+}
+
+func (m *memoFloat32) len() int { return m.Size() }
+
+func (m *memoFloat32) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Float32).Float32Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoFloat32) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Float32).Float32Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoFloat32) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Float32Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Float32Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Float32, m.Size(), nullIndex(m), buf)
+}
+
+type memoFloat64 struct {
+	*hashing.Float64MemoTable
+	encodeNulls bool
+}
+
+func (m *memoFloat64) len() int { return m.Size() }
+
+func (m *memoFloat64) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Float64).Float64Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoFloat64) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = arr.(*array.Float64).Float64Values()
+		nulls = arr.NullN() > 0
+	)
+	for i, v := range vals {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(v)
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoFloat64) values(mem memory.Allocator) array.Interface {
+	buf := newBuffer(mem, arrow.Float64Traits.BytesRequired(m.Size()))
+	m.CopyValues(arrow.Float64Traits.CastFromBytes(buf.Bytes()))
+	return memoArray(mem, arrow.PrimitiveTypes.Float64, m.Size(), nullIndex(m), buf)
+}
@@ -0,0 +1,394 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/hashing"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// NullEncodingBehavior controls how the hashing functions handle nulls.
+type NullEncodingBehavior int8
+
+const (
+	// NullEncodingMask leaves nulls out of the distinct values.
+	NullEncodingMask NullEncodingBehavior = iota
+	// NullEncodingEncode handles null as a distinct value.
+	NullEncodingEncode
+)
+
+// HashOptions controls the behavior of the hashing functions, such as
+// Unique and ValueCounts.
+type HashOptions struct {
+	NullEncoding NullEncodingBehavior
+}
+
+// Unique returns the distinct values of an array or a chunked array, in
+// the order of their first appearance. Floating point NaNs are all
+// considered equal.
+//
+// The returned array must be Release()'d after use.
+func Unique(ctx context.Context, input Datum, opts *HashOptions) (array.Interface, error) {
+	mem := GetAllocator(ctx)
+	memo, err := hashDatum(mem, input, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return memo.values(mem), nil
+}
+
+// ValueCounts returns the distinct values of an array or a chunked array,
+// in the order of their first appearance, and their number of occurrences.
+// The result is a struct array with a "values" field, of the input type,
+// and a "counts" field of type Int64.
+//
+// The returned array must be Release()'d after use.
+func ValueCounts(ctx context.Context, input Datum, opts *HashOptions) (array.Interface, error) {
+	mem := GetAllocator(ctx)
+	var counts []int64
+	memo, err := hashDatum(mem, input, opts, func(indices []int32) {
+		for _, idx := range indices {
+			if idx < 0 {
+				continue
+			}
+			for int(idx) >= len(counts) {
+				counts = append(counts, 0)
+			}
+			counts[idx]++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := memo.values(mem)
+	defer values.Release()
+	buf := newBuffer(mem, arrow.Int64Traits.BytesRequired(len(counts)))
+	copy(arrow.Int64Traits.CastFromBytes(buf.Bytes()), counts)
+	countsArr := makeArray(arrow.PrimitiveTypes.Int64, len(counts), []*memory.Buffer{nil, buf}, nil, 0)
+	defer countsArr.Release()
+
+	dt := arrow.StructOf(
+		arrow.Field{Name: "values", Type: values.DataType(), Nullable: true},
+		arrow.Field{Name: "counts", Type: arrow.PrimitiveTypes.Int64},
+	)
+	return makeArray(dt, len(counts), []*memory.Buffer{nil}, []*array.Data{values.Data(), countsArr.Data()}, 0), nil
+}
+
+// hashDatum inserts the values of input into a new memo table. If fn is
+// not nil, it is called with the indices of the values of each chunk.
+func hashDatum(mem memory.Allocator, input Datum, opts *HashOptions, fn func(indices []int32)) (memoTable, error) {
+	if opts == nil {
+		opts = &HashOptions{}
+	}
+	if input.Kind() != KindArray && input.Kind() != KindChunked {
+		return nil, xerrors.Errorf("arrow/compute: cannot hash a %v: %w", input.Kind(), ErrNotImplemented)
+	}
+	dt := input.DataType()
+	if dict, ok := dt.(*arrow.DictionaryType); ok {
+		// dictionary-encoded values are hashed once decoded.
+		dt = dict.ValueType
+	}
+	memo, err := newMemoTable(dt, opts.NullEncoding == NullEncodingEncode)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := datumChunks(mem, input)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(chunks)
+
+	var indices []int32
+	for _, c := range chunks {
+		if cap(indices) < c.Len() {
+			indices = make([]int32, c.Len())
+		}
+		indices = indices[:c.Len()]
+		if dict, ok := c.(*array.Dictionary); ok {
+			values, err := decodeDictionary(mem, dict)
+			if err != nil {
+				return nil, err
+			}
+			memo.insert(values, indices)
+			values.Release()
+		} else {
+			memo.insert(c, indices)
+		}
+		if fn != nil {
+			fn(indices)
+		}
+	}
+	return memo, nil
+}
+
+// memoTable assigns consecutive indices to the distinct values of one or
+// more arrays, in the order of their first appearance.
+type memoTable interface {
+	// insert writes the index of each value of arr into out, adding the
+	// values which are not in the table yet. The index of null values is
+	// -1, unless nulls are encoded as a distinct value.
+	insert(arr array.Interface, out []int32)
+	// lookup writes the index of each value of arr into out, or -1 for the
+	// values which are not in the table.
+	lookup(arr array.Interface, out []int32)
+	// len returns the number of distinct values.
+	len() int
+	// values returns the distinct values as an array, ordered by index.
+	values(mem memory.Allocator) array.Interface
+}
+
+// newMemoTable returns a memo table for values of type dt.
+func newMemoTable(dt arrow.DataType, encodeNulls bool) (memoTable, error) {
+	if memo := newNumericMemo(dt, encodeNulls); memo != nil {
+		return memo, nil
+	}
+	switch id := dt.ID(); {
+	case isTemporal(id):
+		return &memoTemporal{newNumericMemo(storageType(dt), encodeNulls), dt}, nil
+	case isBinaryLike(id):
+		return &memoBinary{hashing.NewBinaryMemoTable(0, 0), dt, encodeNulls}, nil
+	case isDecimal(id):
+		width := byteWidth(dt.(arrow.FixedWidthDataType))
+		return &memoFixedWidth{hashing.NewBinaryMemoTable(0, 0), dt, width, encodeNulls}, nil
+	case id == arrow.BOOL:
+		return &memoBoolean{hashing.NewUint8MemoTable(2), encodeNulls}, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: hashing is not implemented for %v: %w", dt, ErrNotImplemented)
+}
+
+// insertNull returns the index of null values in memo: the index of its
+// null slot, inserted if needed, when nulls are encoded, and -1 otherwise.
+func insertNull(memo hashing.MemoTable, encodeNulls bool) int32 {
+	if !encodeNulls {
+		return -1
+	}
+	idx, _ := memo.GetOrInsertNull()
+	return int32(idx)
+}
+
+// nullIndex returns the index of the null slot of memo, or -1.
+func nullIndex(memo hashing.MemoTable) int32 {
+	if idx, ok := memo.GetNull(); ok {
+		return int32(idx)
+	}
+	return -1
+}
+
+// memoArray returns an array of type dt and length n, with the given
+// values buffer, where the value at nullIdx, if positive, is null.
+func memoArray(mem memory.Allocator, dt arrow.DataType, n int, nullIdx int32, buffers ...*memory.Buffer) array.Interface {
+	var (
+		validity *memory.Buffer
+		nulls    int
+	)
+	if nullIdx >= 0 {
+		validity = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		setBits(validity.Bytes(), 0, n)
+		bitutil.ClearBit(validity.Bytes(), int(nullIdx))
+		nulls = 1
+	}
+	return makeArray(dt, n, append([]*memory.Buffer{validity}, buffers...), nil, nulls)
+}
+
+// memoTemporal is the memo table of a temporal type, indexing the values
+// of its storage type.
+type memoTemporal struct {
+	memoTable
+	dt arrow.DataType
+}
+
+func (m *memoTemporal) insert(arr array.Interface, out []int32) {
+	storage := reinterpret(arr, storageType(m.dt))
+	defer storage.Release()
+	m.memoTable.insert(storage, out)
+}
+
+func (m *memoTemporal) lookup(arr array.Interface, out []int32) {
+	storage := reinterpret(arr, storageType(m.dt))
+	defer storage.Release()
+	m.memoTable.lookup(storage, out)
+}
+
+func (m *memoTemporal) values(mem memory.Allocator) array.Interface {
+	storage := m.memoTable.values(mem)
+	defer storage.Release()
+	return reinterpret(storage, m.dt)
+}
+
+type memoBinary struct {
+	*hashing.BinaryMemoTable
+	dt          arrow.DataType
+	encodeNulls bool
+}
+
+func (m *memoBinary) len() int { return m.Size() }
+
+func (m *memoBinary) insert(arr array.Interface, out []int32) {
+	var (
+		offsets, data = binaryValues(arr)
+		nulls         = arr.NullN() > 0
+	)
+	for i := range out {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(data[offsets[i]:offsets[i+1]])
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoBinary) lookup(arr array.Interface, out []int32) {
+	var (
+		offsets, data = binaryValues(arr)
+		nulls         = arr.NullN() > 0
+	)
+	for i := range out {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(data[offsets[i]:offsets[i+1]])
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoBinary) values(mem memory.Allocator) array.Interface {
+	offsets := newBuffer(mem, arrow.Int32Traits.BytesRequired(m.Size()+1))
+	m.CopyOffsets(arrow.Int32Traits.CastFromBytes(offsets.Bytes()))
+	data := newBuffer(mem, m.ValuesSize())
+	m.CopyValues(data.Bytes())
+	return memoArray(mem, m.dt, m.Size(), nullIndex(m), offsets, data)
+}
+
+// memoFixedWidth is the memo table of a fixed-width type without a
+// numeric memo table, such as decimals, indexing the bytes of each value.
+type memoFixedWidth struct {
+	*hashing.BinaryMemoTable
+	dt          arrow.DataType
+	width       int
+	encodeNulls bool
+}
+
+func (m *memoFixedWidth) len() int { return m.Size() }
+
+// bytes returns the bytes of the values of arr.
+func (m *memoFixedWidth) bytes(arr array.Interface) []byte {
+	data := arr.Data()
+	if data.Len() == 0 {
+		return nil
+	}
+	return data.Buffers()[1].Bytes()[data.Offset()*m.width:]
+}
+
+func (m *memoFixedWidth) insert(arr array.Interface, out []int32) {
+	var (
+		vals  = m.bytes(arr)
+		nulls = arr.NullN() > 0
+	)
+	for i := range out {
+		if nulls && arr.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(vals[i*m.width : (i+1)*m.width])
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoFixedWidth) lookup(arr array.Interface, out []int32) {
+	var (
+		vals  = m.bytes(arr)
+		nulls = arr.NullN() > 0
+	)
+	for i := range out {
+		if nulls && arr.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(vals[i*m.width : (i+1)*m.width])
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoFixedWidth) values(mem memory.Allocator) array.Interface {
+	// the null slot is stored as an empty value, and left zeroed.
+	buf := newBuffer(mem, m.Size()*m.width)
+	for i := 0; i < m.Size(); i++ {
+		copy(buf.Bytes()[i*m.width:], m.Value(i))
+	}
+	return memoArray(mem, m.dt, m.Size(), nullIndex(m), buf)
+}
+
+// memoBoolean indexes booleans as the uint8 values 0 and 1.
+type memoBoolean struct {
+	*hashing.Uint8MemoTable
+	encodeNulls bool
+}
+
+func (m *memoBoolean) len() int { return m.Size() }
+
+func (m *memoBoolean) insert(arr array.Interface, out []int32) {
+	a := arr.(*array.Boolean)
+	for i := range out {
+		if a.IsNull(i) {
+			out[i] = insertNull(m, m.encodeNulls)
+			continue
+		}
+		idx, _ := m.GetOrInsert(boolToUint8(a.Value(i)))
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoBoolean) lookup(arr array.Interface, out []int32) {
+	a := arr.(*array.Boolean)
+	for i := range out {
+		if a.IsNull(i) {
+			out[i] = nullIndex(m)
+			continue
+		}
+		idx, _ := m.Get(boolToUint8(a.Value(i)))
+		out[i] = int32(idx)
+	}
+}
+
+func (m *memoBoolean) values(mem memory.Allocator) array.Interface {
+	vals := make([]uint8, m.Size())
+	m.CopyValues(vals)
+	buf := newBuffer(mem, int(bitutil.BytesForBits(int64(len(vals)))))
+	for i, v := range vals {
+		if v == 1 {
+			bitutil.SetBit(buf.Bytes(), i)
+		}
+	}
+	return memoArray(mem, arrow.FixedWidthTypes.Boolean, m.Size(), nullIndex(m), buf)
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,276 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestUniqueAndValueCounts(t *testing.T) {
+	var (
+		nan    = math.NaN()
+		encode = &compute.HashOptions{NullEncoding: compute.NullEncodingEncode}
+		ts     = &arrow.TimestampType{Unit: arrow.Microsecond}
+		dec2   = &arrow.Decimal128Type{Precision: 10, Scale: 2}
+		d256   = &arrow.Decimal256Type{Precision: 70, Scale: 2}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		dt     arrow.DataType
+		chunks []interface{}
+		valids [][]bool
+		opts   *compute.HashOptions
+		want   interface{}
+		wvalid []bool
+		counts []int64
+	}{
+		{
+			name:   "int32",
+			dt:     arrow.PrimitiveTypes.Int32,
+			chunks: []interface{}{[]int32{3, 1, 3, 2, 1, 3}},
+			want:   []int32{3, 1, 2}, counts: []int64{3, 2, 1},
+		},
+		{
+			name:   "nulls-masked",
+			dt:     arrow.PrimitiveTypes.Uint8,
+			chunks: []interface{}{[]uint8{1, 0, 1, 0}},
+			valids: [][]bool{{true, false, true, false}},
+			want:   []uint8{1}, counts: []int64{2},
+		},
+		{
+			name:   "nulls-encoded",
+			dt:     arrow.PrimitiveTypes.Uint8,
+			chunks: []interface{}{[]uint8{1, 0, 1, 0}},
+			valids: [][]bool{{true, false, true, false}},
+			opts:   encode,
+			want:   []uint8{1, 0}, wvalid: []bool{true, false}, counts: []int64{2, 2},
+		},
+		{
+			name:   "float-nan",
+			dt:     arrow.PrimitiveTypes.Float64,
+			chunks: []interface{}{[]float64{nan, 1, nan}, []float64{1, nan, 0}},
+			want:   []float64{nan, 1, 0}, counts: []int64{3, 2, 1},
+		},
+		{
+			name:   "string-chunked",
+			dt:     arrow.BinaryTypes.String,
+			chunks: []interface{}{[]string{"a", "", "b"}, []string{}, []string{"b", "a", "c", "x"}},
+			valids: [][]bool{nil, nil, {true, true, true, false}},
+			opts:   encode,
+			want:   []string{"a", "", "b", "c", ""}, wvalid: []bool{true, true, true, true, false},
+			counts: []int64{2, 1, 2, 1, 1},
+		},
+		{
+			name:   "binary",
+			dt:     arrow.BinaryTypes.Binary,
+			chunks: []interface{}{[][]byte{[]byte("x"), []byte("y"), []byte("x")}},
+			want:   [][]byte{[]byte("x"), []byte("y")}, counts: []int64{2, 1},
+		},
+		{
+			name:   "timestamp",
+			dt:     ts,
+			chunks: []interface{}{[]arrow.Timestamp{5, 5, 6}},
+			want:   []arrow.Timestamp{5, 6}, counts: []int64{2, 1},
+		},
+		{
+			name:   "decimal",
+			dt:     dec2,
+			chunks: []interface{}{[]decimal128.Num{dec(1), dec(-1), dec(1), dec(65537)}},
+			want:   []decimal128.Num{dec(1), dec(-1), dec(65537)}, counts: []int64{2, 1, 1},
+		},
+		{
+			name:   "decimal256-nulls-encoded",
+			dt:     d256,
+			chunks: []interface{}{[]decimal256.Num{dec256("1"), dec256("0"), dec256("-1" + strings.Repeat("0", 60))}, []decimal256.Num{dec256("1")}},
+			valids: [][]bool{{true, false, true}, nil},
+			opts:   encode,
+			want:   []decimal256.Num{dec256("1"), {}, dec256("-1" + strings.Repeat("0", 60))}, wvalid: []bool{true, false, true},
+			counts: []int64{2, 1, 1},
+		},
+		{
+			name:   "bool",
+			dt:     arrow.FixedWidthTypes.Boolean,
+			chunks: []interface{}{[]bool{false, false, true}, []bool{true}},
+			want:   []bool{false, true}, counts: []int64{2, 2},
+		},
+		{
+			name:   "empty",
+			dt:     arrow.PrimitiveTypes.Int64,
+			chunks: []interface{}{},
+			want:   []int64{}, counts: []int64{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			chunks := make([]array.Interface, len(tc.chunks))
+			for i, c := range tc.chunks {
+				var valid []bool
+				if tc.valids != nil {
+					valid = tc.valids[i]
+				}
+				chunks[i] = arrayOf(mem, tc.dt, c, valid)
+				defer chunks[i].Release()
+			}
+			chunked := array.NewChunked(tc.dt, chunks)
+			defer chunked.Release()
+			input := compute.NewDatum(chunked)
+			defer input.Release()
+
+			want := arrayOf(mem, tc.dt, tc.want, tc.wvalid)
+			defer want.Release()
+
+			got, err := compute.Unique(ctx, input, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			assertValuesEqual(t, want, got)
+
+			vc, err := compute.ValueCounts(ctx, input, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer vc.Release()
+			wantCounts := arrayOf(mem, arrow.PrimitiveTypes.Int64, tc.counts, nil)
+			defer wantCounts.Release()
+			assertValuesEqual(t, want, vc.(*array.Struct).Field(0))
+			assertArrayEqual(t, wantCounts, vc.(*array.Struct).Field(1))
+		})
+	}
+}
+
+// assertValuesEqual is like assertArrayEqual, but considers NaNs equal.
+func assertValuesEqual(t *testing.T, want, got array.Interface) {
+	t.Helper()
+	w, ok := want.(*array.Float64)
+	if !ok {
+		assertArrayEqual(t, want, got)
+		return
+	}
+	g := got.(*array.Float64)
+	if g.Len() != w.Len() {
+		t.Fatalf("invalid length: got=%d, want=%d", g.Len(), w.Len())
+	}
+	for i := 0; i < w.Len(); i++ {
+		if math.Float64bits(g.Value(i)) != math.Float64bits(w.Value(i)) {
+			t.Fatalf("arrays differ:\ngot= %v\nwant=%v", g, w)
+		}
+	}
+}
+
+func TestUniqueHighCardinality(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	const (
+		n        = 200000
+		distinct = 150000
+	)
+	values := make([]string, n)
+	for i := range values {
+		values[i] = strconv.Itoa((i * 7919) % distinct)
+	}
+	input := datumOf(mem, arrow.BinaryTypes.String, values, nil, nil)
+	defer input.Release()
+
+	vc, err := compute.ValueCounts(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer vc.Release()
+
+	var (
+		uniq   = vc.(*array.Struct).Field(0).(*array.String)
+		counts = vc.(*array.Struct).Field(1).(*array.Int64)
+		total  int64
+	)
+	if uniq.Len() != distinct {
+		t.Fatalf("invalid number of distinct values: got=%d, want=%d", uniq.Len(), distinct)
+	}
+	for i := 0; i < uniq.Len(); i++ {
+		if uniq.Value(i) != values[i] {
+			t.Fatalf("invalid value at %d: got=%q, want=%q", i, uniq.Value(i), values[i])
+		}
+		total += counts.Value(i)
+	}
+	if total != n {
+		t.Fatalf("invalid total count: got=%d, want=%d", total, n)
+	}
+}
+
+func TestUniqueInvalidType(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	input := datumOf(mem, arrow.FixedWidthTypes.Float16, []float32{}, nil, nil)
+	defer input.Release()
+	if _, err := compute.Unique(ctx, input, nil); !xerrors.Is(err, compute.ErrNotImplemented) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, compute.ErrNotImplemented)
+	}
+}
+
+func BenchmarkValueCounts(b *testing.B) {
+	const n = 1 << 20
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values := make([]string, n)
+	for i := range values {
+		values[i] = strconv.Itoa(i % 1000)
+	}
+	arr := arrayOf(mem, arrow.BinaryTypes.String, values, nil).(*array.String)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	b.Run("kernel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			vc, err := compute.ValueCounts(ctx, input, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			vc.Release()
+		}
+	})
+
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			counts := make(map[string]int)
+			for j := 0; j < arr.Len(); j++ {
+				counts[arr.Value(j)]++
+			}
+		}
+	})
+}
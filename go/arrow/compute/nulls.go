@@ -0,0 +1,171 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// IsNull returns a boolean datum which is true where input is null. The
+// result has no nulls.
+func IsNull(ctx context.Context, input Datum) (Datum, error) {
+	return execUnary(GetAllocator(ctx), input, validityKernel(true))
+}
+
+// IsValid returns a boolean datum which is true where input is not null.
+// The result has no nulls.
+func IsValid(ctx context.Context, input Datum) (Datum, error) {
+	return execUnary(GetAllocator(ctx), input, validityKernel(false))
+}
+
+// validityKernel returns a kernel copying the validity bitmap of its input
+// as the values of a boolean array, inverted if invert is true.
+func validityKernel(invert bool) unaryKernel {
+	return func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		n := arr.Len()
+		values := newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		out := values.Bytes()
+		switch src := arr.NullBitmapBytes(); {
+		case arr.NullN() == 0:
+			setBits(out, 0, n)
+		case len(src) > 0:
+			copyBitmap(src, arr.Data().Offset(), out, 0, n)
+		}
+		// otherwise all values are null, e.g. an array of the NULL type.
+
+		if invert {
+			for i := range out {
+				out[i] = ^out[i]
+			}
+			if n%8 != 0 {
+				// keep the padding bits cleared.
+				out[n/8] &= byte(1)<<uint(n%8) - 1
+			}
+		}
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{nil, values}, nil, 0), nil
+	}
+}
+
+// FillNull returns input where null values are replaced by fill, which is
+// cast to the type of input if needed. A null fill leaves input unchanged.
+// Values of any type, including nested types, can be filled.
+func FillNull(ctx context.Context, input Datum, fill scalar.Scalar) (Datum, error) {
+	dt := input.DataType()
+	if !arrow.TypeEqual(fill.DataType(), dt) {
+		mem := GetAllocator(ctx)
+		arr, err := scalar.MakeArrayFromScalar(fill, 1, mem)
+		if err != nil {
+			return nil, err
+		}
+		defer arr.Release()
+		casted, err := castArray(mem, arr, dt, SafeCastOptions())
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/compute: invalid fill value %v for %v values: %w", fill, dt, err)
+		}
+		defer casted.Release()
+		if fill, err = scalar.GetScalar(casted, 0); err != nil {
+			return nil, err
+		}
+	}
+	return Coalesce(ctx, input, &ScalarDatum{Value: fill})
+}
+
+// Coalesce returns, for each row, the first non-null value of args, which
+// are scalars, arrays or chunked arrays of the same type and length. The
+// value is null where all the arguments are null.
+func Coalesce(ctx context.Context, args ...Datum) (Datum, error) {
+	if len(args) == 0 {
+		return nil, xerrors.Errorf("arrow/compute: coalesce needs at least one argument: %w", ErrInvalid)
+	}
+	dt := args[0].DataType()
+	for _, arg := range args[1:] {
+		if !arrow.TypeEqual(arg.DataType(), dt) {
+			return nil, xerrors.Errorf("arrow/compute: cannot coalesce %v and %v values: %w", dt, arg.DataType(), ErrInvalid)
+		}
+	}
+
+	mem := GetAllocator(ctx)
+	out, err := execUnary(mem, args[0], func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		arr.Retain()
+		return arr, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args[1:] {
+		res, err := execBinary(mem, out, arg, coalesceKernel)
+		out.Release()
+		if err != nil {
+			return nil, err
+		}
+		out = res
+	}
+	return out, nil
+}
+
+// coalesceKernel selects the values of left where they are valid, and
+// the values of right otherwise.
+func coalesceKernel(mem memory.Allocator, left, right operand) (array.Interface, error) {
+	var (
+		n    = outLen(left, right)
+		sel  = &selection{spans: make([]span, 0, 1)}
+		fill = func(pos, n int) {
+			if !right.scalar {
+				sel.add(1, pos, n, false)
+				return
+			}
+			for i := 0; i < n; i++ {
+				sel.add(1, 0, 1, false)
+			}
+		}
+		bitmap = left.NullBitmapBytes()
+		offset = left.Data().Offset()
+	)
+
+	switch {
+	case left.scalar:
+		if !left.IsValid(0) {
+			fill(0, n)
+			break
+		}
+		for i := 0; i < n; i++ {
+			sel.add(0, 0, 1, false)
+		}
+	case left.NullN() == 0:
+		left.Retain()
+		return left.Interface, nil
+	case len(bitmap) == 0:
+		// all the values are null, e.g. an array of the NULL type.
+		fill(0, n)
+	default:
+		for pos := 0; pos < n; {
+			null := nextBit(bitmap, offset+pos, offset+n, false) - offset
+			sel.add(0, pos, null-pos, false)
+			valid := nextBit(bitmap, offset+null, offset+n, true) - offset
+			fill(null, valid-null)
+			pos = valid
+		}
+	}
+	return gather(mem, []array.Interface{left.Interface, right.Interface}, sel)
+}
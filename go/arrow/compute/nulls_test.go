@@ -0,0 +1,301 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// structOf builds a struct array of type dt from its fields. If valid is
+// not nil, the elements where it is false are null.
+func structOf(dt *arrow.StructType, n int, valid []bool, fields ...array.Interface) array.Interface {
+	var (
+		validity *memory.Buffer
+		nulls    int
+		children = make([]*array.Data, len(fields))
+	)
+	if valid != nil {
+		bitmap := make([]byte, bitutil.BytesForBits(int64(n)))
+		for i, v := range valid {
+			if v {
+				bitutil.SetBit(bitmap, i)
+			} else {
+				nulls++
+			}
+		}
+		validity = memory.NewBufferBytes(bitmap)
+	}
+	for i, f := range fields {
+		children[i] = f.Data()
+	}
+	data := array.NewData(dt, n, []*memory.Buffer{validity}, children, nulls, 0)
+	defer data.Release()
+	return array.MakeFromData(data)
+}
+
+func TestIsNullIsValid(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	valid := []bool{true, false, true, true, false, false, true, true, true, false, true}
+	values := make([]int64, len(valid))
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int64, values, valid)
+	defer arr.Release()
+	sliced := array.NewSlice(arr, 3, 11)
+	defer sliced.Release()
+
+	// an array without any validity buffer.
+	data := array.NewData(arrow.PrimitiveTypes.Int64, 4, []*memory.Buffer{nil, memory.NewBufferBytes(make([]byte, 32))}, nil, 0, 0)
+	noBitmap := array.NewInt64Data(data)
+	defer noBitmap.Release()
+	data.Release()
+
+	nulls := array.NewNull(3)
+	defer nulls.Release()
+
+	not := func(vs []bool) []bool {
+		out := make([]bool, len(vs))
+		for i, v := range vs {
+			out[i] = !v
+		}
+		return out
+	}
+
+	for _, tc := range []struct {
+		name  string
+		input compute.Datum
+		valid []bool
+	}{
+		{"array", &compute.ArrayDatum{Value: arr}, valid},
+		{"sliced", &compute.ArrayDatum{Value: sliced}, valid[3:]},
+		{"no-bitmap", &compute.ArrayDatum{Value: noBitmap}, []bool{true, true, true, true}},
+		{"null-type", &compute.ArrayDatum{Value: nulls}, []bool{false, false, false}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, fn := range []struct {
+				name string
+				fn   func(context.Context, compute.Datum) (compute.Datum, error)
+				want []bool
+			}{
+				{"is-valid", compute.IsValid, tc.valid},
+				{"is-null", compute.IsNull, not(tc.valid)},
+			} {
+				out, err := fn.fn(ctx, tc.input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := arrayOf(mem, arrow.FixedWidthTypes.Boolean, fn.want, nil)
+				got := out.(*compute.ArrayDatum).Value
+				if got.NullN() != 0 {
+					t.Errorf("%s: result should not have nulls", fn.name)
+				}
+				assertArrayEqual(t, want, got)
+				want.Release()
+				out.Release()
+			}
+		})
+	}
+
+	t.Run("scalar", func(t *testing.T) {
+		out, err := compute.IsNull(ctx, &compute.ScalarDatum{Value: scalar.MakeNullScalar(arrow.BinaryTypes.String)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+		if got := out.(*compute.ScalarDatum).Value.(*scalar.Boolean); !got.Value {
+			t.Fatalf("got=%v, want=true", got)
+		}
+	})
+}
+
+func TestFillNull(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	fill := func(ctx context.Context, input compute.Datum, fill scalar.Scalar, want array.Interface) {
+		t.Helper()
+		defer want.Release()
+		out, err := compute.FillNull(ctx, input, fill)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+		assertArrayEqual(t, want, out.(*compute.ArrayDatum).Value)
+	}
+
+	t.Run("int32", func(t *testing.T) {
+		arr := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 0, 3, 0, 5}, []bool{true, false, true, false, true})
+		defer arr.Release()
+		input := compute.NewDatum(arr)
+		defer input.Release()
+
+		// the fill value is cast to the type of the input.
+		fill(ctx, input, scalar.NewInt64Scalar(9),
+			arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 9, 3, 9, 5}, nil))
+		fill(ctx, input, scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32),
+			arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 0, 3, 0, 5}, []bool{true, false, true, false, true}))
+
+		_, err := compute.FillNull(ctx, input, scalar.NewStringScalar("x"))
+		if !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+	})
+
+	t.Run("sliced-string", func(t *testing.T) {
+		arr := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "", "", "d", ""}, []bool{true, false, false, true, false})
+		defer arr.Release()
+		sliced := array.NewSlice(arr, 2, 5)
+		defer sliced.Release()
+		input := compute.NewDatum(sliced)
+		defer input.Release()
+
+		fill(ctx, input, scalar.NewStringScalar("zz"),
+			arrayOf(mem, arrow.BinaryTypes.String, []string{"zz", "d", "zz"}, nil))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		dt := arrow.StructOf(
+			arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+			arrow.Field{Name: "y", Type: arrow.BinaryTypes.String, Nullable: true},
+		)
+		xs := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 0, 3}, []bool{true, false, true})
+		defer xs.Release()
+		ys := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "", "c"}, nil)
+		defer ys.Release()
+		arr := structOf(dt, 3, []bool{true, false, true}, xs, ys)
+		defer arr.Release()
+		input := compute.NewDatum(arr)
+		defer input.Release()
+
+		wxs := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 7, 3}, nil)
+		defer wxs.Release()
+		wys := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "b", "c"}, nil)
+		defer wys.Release()
+
+		value := scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt32Scalar(7), scalar.NewStringScalar("b")}, dt)
+		fill(ctx, input, value, structOf(dt, 3, nil, wxs, wys))
+	})
+
+	t.Run("no-nulls", func(t *testing.T) {
+		arr := arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{1, 2}, nil)
+		defer arr.Release()
+		input := compute.NewDatum(arr)
+		defer input.Release()
+
+		fill(ctx, input, scalar.NewFloat64Scalar(0), arrayOf(mem, arrow.PrimitiveTypes.Float64, []float64{1, 2}, nil))
+	})
+}
+
+func TestCoalesce(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	a := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{1, 0, 0, 0, 5, 0}, []bool{true, false, false, false, true, false})
+	defer a.Release()
+	b := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{0, 2, 0, 4, 0, 0}, []bool{false, true, false, true, false, false})
+	defer b.Release()
+
+	var chunks []array.Interface
+	for _, rng := range [][2]int64{{0, 2}, {2, 5}, {5, 6}} {
+		chunks = append(chunks, array.NewSlice(b, rng[0], rng[1]))
+	}
+	chunked := array.NewChunked(arrow.PrimitiveTypes.Int16, chunks)
+	defer chunked.Release()
+	for _, c := range chunks {
+		c.Release()
+	}
+
+	var (
+		da = compute.NewDatum(a)
+		db = compute.NewDatum(b)
+		dc = compute.NewDatum(chunked)
+		s  = &compute.ScalarDatum{Value: scalar.NewInt16Scalar(-1)}
+		sn = &compute.ScalarDatum{Value: scalar.MakeNullScalar(arrow.PrimitiveTypes.Int16)}
+	)
+	defer da.Release()
+	defer db.Release()
+	defer dc.Release()
+
+	for _, tc := range []struct {
+		name  string
+		args  []compute.Datum
+		want  []int16
+		valid []bool
+	}{
+		{"single", []compute.Datum{da}, []int16{1, 0, 0, 0, 5, 0}, []bool{true, false, false, false, true, false}},
+		{"arrays", []compute.Datum{da, db}, []int16{1, 2, 0, 4, 5, 0}, []bool{true, true, false, true, true, false}},
+		{"arrays-scalar", []compute.Datum{da, db, s}, []int16{1, 2, -1, 4, 5, -1}, nil},
+		{"null-scalar", []compute.Datum{sn, da, s}, []int16{1, -1, -1, -1, 5, -1}, nil},
+		{"chunked", []compute.Datum{dc, da, s}, []int16{1, 2, -1, 4, 5, -1}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := compute.Coalesce(ctx, tc.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+
+			want := arrayOf(mem, arrow.PrimitiveTypes.Int16, tc.want, tc.valid)
+			defer want.Release()
+			switch out := out.(type) {
+			case *compute.ArrayDatum:
+				assertArrayEqual(t, want, out.Value)
+			case *compute.ChunkedDatum:
+				off := int64(0)
+				for _, c := range out.Value.Chunks() {
+					w := array.NewSlice(want, off, off+int64(c.Len()))
+					defer w.Release()
+					assertArrayEqual(t, w, c)
+					off += int64(c.Len())
+				}
+			}
+		})
+	}
+
+	t.Run("scalars", func(t *testing.T) {
+		out, err := compute.Coalesce(ctx, sn, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+		if got := out.(*compute.ScalarDatum).Value.(*scalar.Int16); got.Value != -1 {
+			t.Fatalf("got=%v, want=-1", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := compute.Coalesce(ctx); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+		f := &compute.ScalarDatum{Value: scalar.NewFloat32Scalar(1)}
+		if _, err := compute.Coalesce(ctx, da, f); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+	})
+}
@@ -0,0 +1,264 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+// QuantileInterpolation selects how an exact quantile falling between two
+// values is computed.
+type QuantileInterpolation int8
+
+const (
+	// QuantileLinear interpolates linearly between the two values.
+	QuantileLinear QuantileInterpolation = iota
+	// QuantileLower selects the lower value.
+	QuantileLower
+	// QuantileHigher selects the higher value.
+	QuantileHigher
+	// QuantileNearest selects the nearest value, or the value of even
+	// rank if both are equally near.
+	QuantileNearest
+	// QuantileMidpoint selects the mean of the two values.
+	QuantileMidpoint
+)
+
+// QuantileOptions controls the behavior of Quantile and Median.
+type QuantileOptions struct {
+	// Interpolation is the interpolation of exact quantiles. It is
+	// ignored by approximate quantiles.
+	Interpolation QuantileInterpolation
+	// Approximate makes the quantiles be estimated with a TDigest, in a
+	// single pass and bounded memory.
+	Approximate bool
+	// Compression is the compression of the TDigest used by approximate
+	// quantiles. DefaultTDigestCompression is used if it is zero.
+	Compression float64
+	// SkipNulls makes the quantiles ignore null values. Otherwise, the
+	// quantiles are null if the input holds any null.
+	SkipNulls bool
+	// MinCount is the minimum number of valid values below which the
+	// quantiles are null.
+	MinCount int
+}
+
+// DefaultQuantileOptions returns the options used when nil options are
+// passed to Quantile or Median: exact quantiles are interpolated linearly,
+// and nulls are skipped.
+func DefaultQuantileOptions() *QuantileOptions {
+	return &QuantileOptions{SkipNulls: true}
+}
+
+// Quantile returns a float64 array holding the quantiles qs of the values
+// of a numeric datum. The quantiles must be between 0 and 1. NaNs are
+// ignored, and the quantiles are null if there are no values.
+//
+// Exact quantiles hold all the values in memory, and select the values
+// surrounding each quantile, which is interpolated according to
+// opts.Interpolation. Approximate quantiles are computed with a TDigest
+// per chunk, merged into a single one.
+func Quantile(ctx context.Context, input Datum, qs []float64, opts *QuantileOptions) (array.Interface, error) {
+	if opts == nil {
+		opts = DefaultQuantileOptions()
+	}
+	if id := input.DataType().ID(); !isInteger(id) && !isFloating(id) {
+		return nil, xerrors.Errorf("arrow/compute: quantile is not implemented for %v: %w", input.DataType(), ErrNotImplemented)
+	}
+	for _, q := range qs {
+		if !(q >= 0 && q <= 1) {
+			return nil, xerrors.Errorf("arrow/compute: quantile %v is not between 0 and 1: %w", q, ErrInvalid)
+		}
+	}
+	if opts.Interpolation < QuantileLinear || opts.Interpolation > QuantileMidpoint {
+		return nil, xerrors.Errorf("arrow/compute: invalid quantile interpolation %d: %w", opts.Interpolation, ErrInvalid)
+	}
+
+	mem := GetAllocator(ctx)
+	chunks, err := datumChunks(mem, input)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseArrays(chunks)
+
+	var (
+		out   []float64
+		count int
+		nulls int
+	)
+	if opts.Approximate {
+		td := NewTDigest(opts.Compression)
+		var vals []float64
+		for _, c := range chunks {
+			nulls += c.NullN()
+			vals, _ = appendFloats(vals[:0], c)
+			chunk := NewTDigest(opts.Compression)
+			for _, v := range vals {
+				chunk.Add(v)
+			}
+			td.Merge(chunk)
+		}
+		count = int(td.Count())
+		if count > 0 {
+			out = make([]float64, len(qs))
+			for i, q := range qs {
+				out[i] = td.Quantile(q)
+			}
+		}
+	} else {
+		var vals []float64
+		for _, c := range chunks {
+			nulls += c.NullN()
+			vals, _ = appendFloats(vals, c)
+		}
+		count = len(vals)
+		if count > 0 {
+			out = exactQuantiles(vals, qs, opts.Interpolation)
+		}
+	}
+
+	bldr := array.NewFloat64Builder(mem)
+	defer bldr.Release()
+	if count == 0 || count < opts.MinCount || (!opts.SkipNulls && nulls > 0) {
+		for range qs {
+			bldr.AppendNull()
+		}
+	} else {
+		bldr.AppendValues(out, nil)
+	}
+	return bldr.NewArray(), nil
+}
+
+// Median returns the median of the values of a numeric datum as a float64
+// scalar, computed as the quantile 0.5 by Quantile.
+func Median(ctx context.Context, input Datum, opts *QuantileOptions) (scalar.Scalar, error) {
+	arr, err := Quantile(ctx, input, []float64{0.5}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer arr.Release()
+	if arr.IsNull(0) {
+		return scalar.MakeNullScalar(arrow.PrimitiveTypes.Float64), nil
+	}
+	return scalar.NewFloat64Scalar(arr.(*array.Float64).Value(0)), nil
+}
+
+// exactQuantiles returns the quantiles qs of vals, reordering vals.
+func exactQuantiles(vals []float64, qs []float64, interp QuantileInterpolation) []float64 {
+	n := len(vals)
+	// ranks are the positions of the values surrounding each quantile in
+	// the sorted values, which are selected in increasing order so that
+	// each selection only partitions the values above the previous one.
+	ranks := make([]int, 0, 2*len(qs))
+	for _, q := range qs {
+		pos := q * float64(n-1)
+		ranks = append(ranks, int(math.Floor(pos)), int(math.Ceil(pos)))
+	}
+	sort.Ints(ranks)
+	lo := 0
+	for i, r := range ranks {
+		if i > 0 && r == ranks[i-1] {
+			continue
+		}
+		selectKth(vals[lo:], r-lo)
+		lo = r
+	}
+
+	out := make([]float64, len(qs))
+	for i, q := range qs {
+		pos := q * float64(n-1)
+		below, above := int(math.Floor(pos)), int(math.Ceil(pos))
+		frac := pos - float64(below)
+		x, y := vals[below], vals[above]
+		switch {
+		case below == above:
+			out[i] = x
+		case interp == QuantileLower:
+			out[i] = x
+		case interp == QuantileHigher:
+			out[i] = y
+		case interp == QuantileNearest:
+			if frac < 0.5 || (frac == 0.5 && below%2 == 0) {
+				out[i] = x
+			} else {
+				out[i] = y
+			}
+		case interp == QuantileMidpoint:
+			out[i] = x/2 + y/2
+		case x == y:
+			out[i] = x
+		case math.IsInf(x, 0) || math.IsInf(y, 0):
+			out[i] = x*(1-frac) + y*frac
+		default:
+			out[i] = x + (y-x)*frac
+		}
+	}
+	return out
+}
+
+// selectKth partially sorts vals so that vals[k] is the value it would hold
+// if vals was sorted, with lower values before it and greater values after
+// it.
+func selectKth(vals []float64, k int) {
+	lo, hi := 0, len(vals)-1
+	for hi > lo {
+		// median of three pivot, moved to vals[lo].
+		mid := lo + (hi-lo)/2
+		if vals[mid] < vals[lo] {
+			vals[mid], vals[lo] = vals[lo], vals[mid]
+		}
+		if vals[hi] < vals[lo] {
+			vals[hi], vals[lo] = vals[lo], vals[hi]
+		}
+		if vals[hi] < vals[mid] {
+			vals[hi], vals[mid] = vals[mid], vals[hi]
+		}
+		vals[lo], vals[mid] = vals[mid], vals[lo]
+		pivot := vals[lo]
+
+		// three-way partition, so that runs of equal values terminate.
+		lt, i, gt := lo, lo+1, hi
+		for i <= gt {
+			switch v := vals[i]; {
+			case v < pivot:
+				vals[lt], vals[i] = v, vals[lt]
+				lt++
+				i++
+			case v > pivot:
+				vals[gt], vals[i] = v, vals[gt]
+				gt--
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
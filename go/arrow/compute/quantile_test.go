@@ -0,0 +1,331 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+func TestQuantile(t *testing.T) {
+	var (
+		i32 = arrow.PrimitiveTypes.Int32
+		f64 = arrow.PrimitiveTypes.Float64
+
+		qs = []float64{0, 0.25, 0.5, 0.75, 1}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		opts   *compute.QuantileOptions
+		dt     arrow.DataType
+		values interface{}
+		valid  []bool
+		qs     []float64
+		want   []float64
+		nulls  bool
+		err    error
+	}{
+		{
+			name: "linear", dt: i32, values: []int32{4, 1, 3, 2, 5}, qs: qs,
+			want: []float64{1, 2, 3, 4, 5},
+		},
+		{
+			name: "linear-between", dt: i32, values: []int32{4, 1, 3, 2}, qs: qs,
+			want: []float64{1, 1.75, 2.5, 3.25, 4},
+		},
+		{
+			name: "lower", opts: &compute.QuantileOptions{Interpolation: compute.QuantileLower, SkipNulls: true},
+			dt: i32, values: []int32{4, 1, 3, 2}, qs: qs,
+			want: []float64{1, 1, 2, 3, 4},
+		},
+		{
+			name: "higher", opts: &compute.QuantileOptions{Interpolation: compute.QuantileHigher, SkipNulls: true},
+			dt: i32, values: []int32{4, 1, 3, 2}, qs: qs,
+			want: []float64{1, 2, 3, 4, 4},
+		},
+		{
+			name: "nearest", opts: &compute.QuantileOptions{Interpolation: compute.QuantileNearest, SkipNulls: true},
+			dt: i32, values: []int32{4, 1, 3, 2}, qs: []float64{0.1, 0.25, 0.5, 0.75, 0.9},
+			want: []float64{1, 2, 3, 3, 4},
+		},
+		{
+			name: "midpoint", opts: &compute.QuantileOptions{Interpolation: compute.QuantileMidpoint, SkipNulls: true},
+			dt: i32, values: []int32{4, 1, 3, 2}, qs: qs,
+			want: []float64{1, 1.5, 2.5, 3.5, 4},
+		},
+		{
+			name: "duplicates", dt: f64, values: []float64{2, 2, 1, 2, 2, 3, 2}, qs: qs,
+			want: []float64{1, 2, 2, 2, 3},
+		},
+		{
+			name: "nulls-and-nans", dt: f64,
+			values: []float64{math.NaN(), 10, -1, 1, 3, 2}, valid: []bool{true, true, false, true, true, true},
+			qs: []float64{0.5}, want: []float64{2.5},
+		},
+		{
+			name: "infinities", dt: f64, values: []float64{math.Inf(-1), 0, math.Inf(1)}, qs: qs,
+			want: []float64{math.Inf(-1), math.Inf(-1), 0, math.Inf(1), math.Inf(1)},
+		},
+		{
+			name: "keep-nulls", opts: &compute.QuantileOptions{},
+			dt: i32, values: []int32{1, 2}, valid: []bool{true, false}, qs: qs, nulls: true,
+		},
+		{
+			name: "min-count", opts: &compute.QuantileOptions{SkipNulls: true, MinCount: 3},
+			dt: i32, values: []int32{1, 2}, qs: qs, nulls: true,
+		},
+		{
+			name: "empty", dt: i32, values: []int32{}, qs: qs, nulls: true,
+		},
+		{
+			name: "all-nans", dt: f64, values: []float64{math.NaN()}, qs: qs, nulls: true,
+		},
+		{
+			name: "approximate-small", opts: &compute.QuantileOptions{Approximate: true, SkipNulls: true},
+			dt: i32, values: []int32{4, 1, 3, 2, 5}, qs: []float64{0, 0.5, 1},
+			want: []float64{1, 3, 5},
+		},
+		{
+			name: "invalid-quantile", dt: i32, values: []int32{1}, qs: []float64{1.5},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "nan-quantile", dt: i32, values: []int32{1}, qs: []float64{math.NaN()},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "invalid-type", dt: arrow.BinaryTypes.String, values: []string{"a"}, qs: qs,
+			err: compute.ErrNotImplemented,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			arr := arrayOf(mem, tc.dt, tc.values, tc.valid)
+			defer arr.Release()
+
+			d := compute.NewDatum(arr)
+			defer d.Release()
+
+			got, err := compute.Quantile(ctx, d, tc.qs, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("got error %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got.Len() != len(tc.qs) {
+				t.Fatalf("got %d quantiles, want %d", got.Len(), len(tc.qs))
+			}
+			if tc.nulls {
+				if got.NullN() != got.Len() {
+					t.Fatalf("got %v, want nulls", got)
+				}
+				return
+			}
+			for i, v := range got.(*array.Float64).Float64Values() {
+				if got.IsNull(i) || v != tc.want[i] {
+					t.Fatalf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	c1 := arrayOf(mem, arrow.PrimitiveTypes.Uint8, []uint8{7, 1, 0}, []bool{true, true, false})
+	c2 := arrayOf(mem, arrow.PrimitiveTypes.Uint8, []uint8{4, 2}, nil)
+	chunked := array.NewChunked(arrow.PrimitiveTypes.Uint8, []array.Interface{c1, c2})
+	c1.Release()
+	c2.Release()
+	d := compute.NewDatum(chunked)
+	chunked.Release()
+	defer d.Release()
+
+	got, err := compute.Median(ctx, d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := scalar.NewFloat64Scalar(3); !got.Equals(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	empty := arrayOf(mem, arrow.PrimitiveTypes.Uint8, []uint8{}, nil)
+	d = compute.NewDatum(empty)
+	empty.Release()
+	defer d.Release()
+
+	got, err = compute.Median(ctx, d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.IsValid() {
+		t.Fatalf("got %v, want null", got)
+	}
+}
+
+// rankError returns how far q is from the range of ranks of v in the sorted
+// values.
+func rankError(sorted []float64, v, q float64) float64 {
+	n := float64(len(sorted))
+	lo := float64(sort.SearchFloat64s(sorted, v)) / n
+	hi := float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > v })) / n
+	switch {
+	case q < lo:
+		return lo - q
+	case q > hi:
+		return q - hi
+	}
+	return 0
+}
+
+func TestQuantileApproximate(t *testing.T) {
+	const (
+		n       = 100000
+		nchunks = 10
+	)
+	qs := []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999}
+
+	for _, tc := range []struct {
+		name string
+		gen  func(r *rand.Rand) float64
+	}{
+		{"uniform", func(r *rand.Rand) float64 { return r.Float64() }},
+		{"normal", func(r *rand.Rand) float64 { return r.NormFloat64() }},
+		{"exponential", func(r *rand.Rand) float64 { return r.ExpFloat64() }},
+		{"lognormal", func(r *rand.Rand) float64 { return math.Exp(3 * r.NormFloat64()) }},
+		{"discrete", func(r *rand.Rand) float64 { return float64(r.Intn(1000)) }},
+		{"sorted", nil},
+	} {
+		for _, compression := range []float64{50, 100, 500} {
+			t.Run(fmt.Sprintf("%s-%v", tc.name, compression), func(t *testing.T) {
+				mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+				defer mem.AssertSize(t, 0)
+				ctx := compute.WithAllocator(context.Background(), mem)
+
+				r := rand.New(rand.NewSource(42))
+				values := make([]float64, n)
+				for i := range values {
+					if tc.gen == nil {
+						values[i] = float64(i)
+					} else {
+						values[i] = tc.gen(r)
+					}
+				}
+				chunks := make([]array.Interface, nchunks)
+				for i := range chunks {
+					chunks[i] = arrayOf(mem, arrow.PrimitiveTypes.Float64, values[i*n/nchunks:(i+1)*n/nchunks], nil)
+					defer chunks[i].Release()
+				}
+				chunked := array.NewChunked(arrow.PrimitiveTypes.Float64, chunks)
+				d := compute.NewDatum(chunked)
+				chunked.Release()
+				defer d.Release()
+
+				opts := &compute.QuantileOptions{Approximate: true, Compression: compression, SkipNulls: true}
+				got, err := compute.Quantile(ctx, d, qs, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+
+				sort.Float64s(values)
+				for i, q := range qs {
+					v := got.(*array.Float64).Value(i)
+					if e := rankError(values, v, q); e > 1/compression {
+						t.Errorf("quantile %v estimated as %v, with rank error %v", q, v, e)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestTDigest(t *testing.T) {
+	empty := compute.NewTDigest(0)
+	if empty.Compression() != compute.DefaultTDigestCompression {
+		t.Fatalf("got compression %v, want %v", empty.Compression(), compute.DefaultTDigestCompression)
+	}
+	if !math.IsNaN(empty.Quantile(0.5)) || !math.IsNaN(empty.Min()) || !math.IsNaN(empty.Max()) {
+		t.Fatalf("empty digest has values")
+	}
+
+	// digests of parts of the values merge into a digest as accurate as
+	// the digest of all the values.
+	r := rand.New(rand.NewSource(1))
+	values := make([]float64, 50000)
+	whole := compute.NewTDigest(100)
+	parts := make([]*compute.TDigest, 7)
+	for i := range parts {
+		parts[i] = compute.NewTDigest(100)
+	}
+	for i := range values {
+		values[i] = r.NormFloat64()
+		whole.Add(values[i])
+		parts[i%len(parts)].Add(values[i])
+	}
+	whole.Add(math.NaN())
+
+	merged := compute.NewTDigest(100)
+	merged.Merge(parts[:3]...)
+	merged.Merge(parts[3:]...)
+
+	sort.Float64s(values)
+	for _, td := range []*compute.TDigest{whole, merged} {
+		if got := td.Count(); got != int64(len(values)) {
+			t.Fatalf("got count %d, want %d", got, len(values))
+		}
+		if td.Min() != values[0] || td.Max() != values[len(values)-1] {
+			t.Fatalf("got min %v and max %v, want %v and %v", td.Min(), td.Max(), values[0], values[len(values)-1])
+		}
+		if td.Quantile(0) != values[0] || td.Quantile(1) != values[len(values)-1] {
+			t.Fatalf("extreme quantiles are not the minimum and maximum")
+		}
+		for _, q := range []float64{0.0001, 0.05, 0.5, 0.95, 0.9999} {
+			v := td.Quantile(q)
+			if e := rankError(values, v, q); e > 1.0/100 {
+				t.Errorf("quantile %v estimated as %v, with rank error %v", q, v, e)
+			}
+		}
+	}
+	if got := parts[0].Count(); got != int64((len(values)+len(parts)-1)/len(parts)) {
+		t.Fatalf("merge modified its input: count %d", got)
+	}
+}
@@ -0,0 +1,261 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// ExecCtx is the context the kernels of registered functions are executed
+// with.
+type ExecCtx struct {
+	// Context is the context the function was called with.
+	Context context.Context
+	// Mem is the allocator of the results, from GetAllocator(Context).
+	Mem memory.Allocator
+}
+
+func newExecCtx(ctx context.Context) *ExecCtx {
+	return &ExecCtx{Context: ctx, Mem: GetAllocator(ctx)}
+}
+
+// ScalarKernel is an implementation of a scalar function for some types of
+// arguments.
+type ScalarKernel struct {
+	// InputTypes are the types of the arguments the kernel accepts. The
+	// last type applies to all the remaining arguments of variadic
+	// functions, and a kernel without input types accepts any argument.
+	InputTypes []arrow.Type
+	// Exec computes the result of the function. Scalar arguments must give
+	// a scalar result, and array arguments an array of the same length.
+	Exec func(ctx *ExecCtx, args []Datum) (Datum, error)
+}
+
+func (k *ScalarKernel) accepts(types []arrow.DataType) bool {
+	if len(k.InputTypes) == 0 {
+		return true
+	}
+	if len(types) < len(k.InputTypes) {
+		return false
+	}
+	for i, dt := range types {
+		if dt.ID() != k.InputTypes[min(i, len(k.InputTypes)-1)] {
+			return false
+		}
+	}
+	return true
+}
+
+// OutputTypeResolver returns the type of the result of a function called
+// with arguments of the given types.
+type OutputTypeResolver func(args []arrow.DataType) (arrow.DataType, error)
+
+// function is a scalar function of a registry.
+type function struct {
+	name       string
+	arity      int // number of arguments, or -1 if variadic
+	kernels    []ScalarKernel
+	outputType OutputTypeResolver
+}
+
+func (f *function) checkArity(n int) error {
+	switch {
+	case f.arity >= 0 && n != f.arity:
+		return xerrors.Errorf("arrow/compute: function %q takes %d arguments, got %d: %w", f.name, f.arity, n, ErrInvalid)
+	case f.arity < 0 && n == 0:
+		return xerrors.Errorf("arrow/compute: function %q takes at least one argument: %w", f.name, ErrInvalid)
+	}
+	return nil
+}
+
+func (f *function) kernel(types []arrow.DataType) (*ScalarKernel, error) {
+	for i := range f.kernels {
+		if f.kernels[i].accepts(types) {
+			return &f.kernels[i], nil
+		}
+	}
+	names := make([]string, len(types))
+	for i, dt := range types {
+		names[i] = fmt.Sprint(dt)
+	}
+	return nil, xerrors.Errorf("arrow/compute: function %q has no kernel for arguments (%s): %w", f.name, strings.Join(names, ", "), ErrNotImplemented)
+}
+
+func (f *function) exec(ctx context.Context, args []Datum) (Datum, error) {
+	if err := f.checkArity(len(args)); err != nil {
+		return nil, err
+	}
+	types := make([]arrow.DataType, len(args))
+	for i, arg := range args {
+		types[i] = arg.DataType()
+	}
+	k, err := f.kernel(types)
+	if err != nil {
+		return nil, err
+	}
+	return k.Exec(newExecCtx(ctx), args)
+}
+
+// FunctionRegistry holds the functions which can be called by name with
+// CallFunction, and from expressions.
+//
+// The default registry holds the built-in functions of the package.
+// Registries made with NewFunctionRegistry extend the default registry.
+// A FunctionRegistry is safe for concurrent use.
+type FunctionRegistry struct {
+	mu     sync.RWMutex
+	parent *FunctionRegistry
+	funcs  map[string]*function
+}
+
+var defaultRegistry = newBuiltinRegistry()
+
+// DefaultFunctionRegistry returns the registry of the built-in functions.
+// Functions registered there are visible to all the registries.
+func DefaultFunctionRegistry() *FunctionRegistry { return defaultRegistry }
+
+// NewFunctionRegistry returns a new registry holding the functions of the
+// default registry, and the functions registered with it.
+func NewFunctionRegistry() *FunctionRegistry {
+	return &FunctionRegistry{parent: defaultRegistry, funcs: make(map[string]*function)}
+}
+
+// RegisterScalarFunction registers the scalar function name, taking arity
+// arguments, or any positive number of arguments if arity is -1. Calls are
+// dispatched to the first of kernels accepting the types of the arguments.
+//
+// The type of the result is given by outputType if it is not nil, and
+// found by calling the function over empty arrays otherwise.
+//
+// Registering a function whose name is already registered in r, or in the
+// registry r extends, fails with ErrInvalid.
+func (r *FunctionRegistry) RegisterScalarFunction(name string, arity int, kernels []ScalarKernel, outputType OutputTypeResolver) error {
+	switch {
+	case name == "":
+		return xerrors.Errorf("arrow/compute: invalid empty function name: %w", ErrInvalid)
+	case arity < -1:
+		return xerrors.Errorf("arrow/compute: invalid arity %d for function %q: %w", arity, name, ErrInvalid)
+	case len(kernels) == 0:
+		return xerrors.Errorf("arrow/compute: function %q has no kernels: %w", name, ErrInvalid)
+	}
+	for _, k := range kernels {
+		if k.Exec == nil {
+			return xerrors.Errorf("arrow/compute: function %q has a kernel without Exec: %w", name, ErrInvalid)
+		}
+	}
+
+	if r.parent != nil && r.parent.lookup(name) != nil {
+		return xerrors.Errorf("arrow/compute: function %q is already registered: %w", name, ErrInvalid)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.funcs[name]; dup {
+		return xerrors.Errorf("arrow/compute: function %q is already registered: %w", name, ErrInvalid)
+	}
+	r.funcs[name] = &function{
+		name:       name,
+		arity:      arity,
+		kernels:    append([]ScalarKernel(nil), kernels...),
+		outputType: outputType,
+	}
+	return nil
+}
+
+// FunctionNames returns the sorted names of the functions of r.
+func (r *FunctionRegistry) FunctionNames() []string {
+	var names []string
+	for reg := r; reg != nil; reg = reg.parent {
+		reg.mu.RLock()
+		for name := range reg.funcs {
+			names = append(names, name)
+		}
+		reg.mu.RUnlock()
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the function name of r, or nil.
+func (r *FunctionRegistry) lookup(name string) *function {
+	for reg := r; reg != nil; reg = reg.parent {
+		reg.mu.RLock()
+		fn := reg.funcs[name]
+		reg.mu.RUnlock()
+		if fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// Bind binds expr to schema, as Expression.Bind does, resolving the
+// functions called by expr in r.
+func (r *FunctionRegistry) Bind(expr Expression, schema *arrow.Schema) (Expression, error) {
+	return expr.bind(schema, r)
+}
+
+type registryCtxKey struct{}
+
+// WithRegistry returns a new context carrying reg, which CallFunction and
+// the execution of expressions will use to look up functions.
+func WithRegistry(ctx context.Context, reg *FunctionRegistry) context.Context {
+	return context.WithValue(ctx, registryCtxKey{}, reg)
+}
+
+// GetRegistry returns the registry stored in ctx by WithRegistry, or the
+// default registry if there is none.
+func GetRegistry(ctx context.Context) *FunctionRegistry {
+	reg, ok := ctx.Value(registryCtxKey{}).(*FunctionRegistry)
+	if !ok || reg == nil {
+		return defaultRegistry
+	}
+	return reg
+}
+
+// CallFunction calls the function name of the registry of ctx with args.
+//
+// The returned datum must be Release()'d after use.
+func CallFunction(ctx context.Context, name string, args ...Datum) (Datum, error) {
+	fn := GetRegistry(ctx).lookup(name)
+	if fn == nil {
+		return nil, xerrors.Errorf("arrow/compute: unknown function %q: %w", name, ErrInvalid)
+	}
+	return fn.exec(ctx, args)
+}
+
+func newBuiltinRegistry() *FunctionRegistry {
+	r := &FunctionRegistry{funcs: make(map[string]*function, len(builtinFunctions))}
+	for name, fn := range builtinFunctions {
+		exec := fn.exec
+		r.funcs[name] = &function{
+			name:  name,
+			arity: fn.arity,
+			kernels: []ScalarKernel{{Exec: func(ctx *ExecCtx, args []Datum) (Datum, error) {
+				return exec(ctx.Context, args)
+			}}},
+		}
+	}
+	return r
+}
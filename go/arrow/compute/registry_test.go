@@ -0,0 +1,247 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// stringKernel returns a kernel applying fn to the values of a string
+// argument.
+func stringKernel(fn func(string) string) compute.ScalarKernel {
+	return compute.ScalarKernel{
+		InputTypes: []arrow.Type{arrow.STRING},
+		Exec: func(ctx *compute.ExecCtx, args []compute.Datum) (compute.Datum, error) {
+			switch arg := args[0].(type) {
+			case *compute.ScalarDatum:
+				s := arg.Value.(*scalar.String)
+				if !s.Valid {
+					return &compute.ScalarDatum{Value: s}, nil
+				}
+				return &compute.ScalarDatum{Value: scalar.NewStringScalar(fn(s.Value))}, nil
+			case *compute.ArrayDatum:
+				arr := arg.Value.(*array.String)
+				bldr := array.NewStringBuilder(ctx.Mem)
+				defer bldr.Release()
+				for i := 0; i < arr.Len(); i++ {
+					if arr.IsNull(i) {
+						bldr.AppendNull()
+						continue
+					}
+					bldr.Append(fn(arr.Value(i)))
+				}
+				return &compute.ArrayDatum{Value: bldr.NewArray()}, nil
+			}
+			return nil, xerrors.Errorf("unexpected datum %v: %w", args[0].Kind(), compute.ErrNotImplemented)
+		},
+	}
+}
+
+func stringOutput(args []arrow.DataType) (arrow.DataType, error) {
+	return arrow.BinaryTypes.String, nil
+}
+
+func TestFunctionRegistry(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	reg := compute.NewFunctionRegistry()
+	if err := reg.RegisterScalarFunction("reverse", 1, []compute.ScalarKernel{stringKernel(reverseString)}, stringOutput); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := exprRecord(mem)
+	defer rec.Release()
+
+	// reverse(utf8_upper(c)) == "X"
+	expr := compute.NewCall("equal",
+		compute.NewCall("reverse", compute.NewCall("utf8_upper", compute.NewFieldRef("c"))),
+		compute.NewLiteral(scalar.NewStringScalar("X")))
+	if _, err := expr.Bind(rec.Schema()); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("binding to the default registry should fail, got=%v", err)
+	}
+	bound, err := reg.Bind(expr, rec.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bound.(*compute.Call).Args()[0].DataType(), arrow.BinaryTypes.String; !arrow.TypeEqual(got, want) {
+		t.Fatalf("invalid type of reverse: got=%v, want=%v", got, want)
+	}
+
+	got, err := compute.ExecuteScalarExpression(ctx, bound, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	want := arrayOf(mem, arrow.FixedWidthTypes.Boolean, []bool{true, false, false, true, false, true}, []bool{true, false, true, true, true, true})
+	defer want.Release()
+	assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+
+	// the registry of the context overrides the functions the expression
+	// was bound to.
+	override := compute.NewFunctionRegistry()
+	if err := override.RegisterScalarFunction("reverse", 1, []compute.ScalarKernel{stringKernel(func(s string) string { return s + s })}, stringOutput); err != nil {
+		t.Fatal(err)
+	}
+	got2, err := compute.ExecuteScalarExpression(compute.WithRegistry(ctx, override), bound.(*compute.Call).Args()[0], rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got2.Release()
+	want2 := arrayOf(mem, arrow.BinaryTypes.String, []string{"XX", "", "YY", "XX", "ZZ", "XX"}, []bool{true, false, true, true, true, true})
+	defer want2.Release()
+	assertArrayEqual(t, want2, got2.(*compute.ArrayDatum).Value)
+
+	// CallFunction dispatches to the registry of the context.
+	res, err := compute.CallFunction(compute.WithRegistry(ctx, reg), "reverse", &compute.ScalarDatum{Value: scalar.NewStringScalar("héllo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.(*compute.ScalarDatum).Value, scalar.NewStringScalar("olléh"); !got.Equals(want) {
+		t.Fatalf("invalid result: got=%v, want=%v", got, want)
+	}
+	if _, err := compute.CallFunction(ctx, "reverse", res); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("reverse should not be in the default registry, got=%v", err)
+	}
+
+	names := reg.FunctionNames()
+	if !sort.StringsAreSorted(names) {
+		t.Fatalf("function names are not sorted: %v", names)
+	}
+	for _, name := range []string{"add", "reverse", "utf8_upper"} {
+		if i := sort.SearchStrings(names, name); i == len(names) || names[i] != name {
+			t.Errorf("missing function %q in %v", name, names)
+		}
+	}
+}
+
+func TestFunctionRegistryDispatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	// bucket returns the kind of its argument.
+	kind := func(s string) compute.ScalarKernel {
+		return compute.ScalarKernel{
+			InputTypes: []arrow.Type{arrow.INT64},
+			Exec: func(ctx *compute.ExecCtx, args []compute.Datum) (compute.Datum, error) {
+				return &compute.ScalarDatum{Value: scalar.NewStringScalar(s)}, nil
+			},
+		}
+	}
+	str := kind("string")
+	str.InputTypes = []arrow.Type{arrow.STRING}
+	reg := compute.NewFunctionRegistry()
+	if err := reg.RegisterScalarFunction("bucket", -1, []compute.ScalarKernel{kind("int64"), str}, stringOutput); err != nil {
+		t.Fatal(err)
+	}
+	ctx = compute.WithRegistry(ctx, reg)
+
+	for _, tc := range []struct {
+		args []scalar.Scalar
+		want string
+		err  error
+	}{
+		{[]scalar.Scalar{scalar.NewInt64Scalar(1)}, "int64", nil},
+		{[]scalar.Scalar{scalar.NewInt64Scalar(1), scalar.NewInt64Scalar(2)}, "int64", nil},
+		{[]scalar.Scalar{scalar.NewStringScalar("a")}, "string", nil},
+		{[]scalar.Scalar{scalar.NewInt64Scalar(1), scalar.NewStringScalar("a")}, "", compute.ErrNotImplemented},
+		{[]scalar.Scalar{scalar.NewFloat64Scalar(1)}, "", compute.ErrNotImplemented},
+		{nil, "", compute.ErrInvalid},
+	} {
+		args := make([]compute.Datum, len(tc.args))
+		for i, arg := range tc.args {
+			args[i] = compute.NewDatum(arg)
+		}
+		res, err := compute.CallFunction(ctx, "bucket", args...)
+		switch {
+		case tc.err != nil:
+			if !xerrors.Is(err, tc.err) {
+				t.Errorf("bucket%v: invalid error: got=%v, want=%v", tc.args, err, tc.err)
+			}
+		case err != nil:
+			t.Errorf("bucket%v: %v", tc.args, err)
+		default:
+			if got := res.(*compute.ScalarDatum).Value.String(); got != tc.want {
+				t.Errorf("bucket%v: got=%q, want=%q", tc.args, got, tc.want)
+			}
+		}
+	}
+
+	// built-in functions are called through the registry too.
+	res, err := compute.CallFunction(ctx, "add", compute.NewDatum(scalar.NewInt64Scalar(1)), compute.NewDatum(scalar.NewInt64Scalar(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.(*compute.ScalarDatum).Value, scalar.NewInt64Scalar(3); !got.Equals(want) {
+		t.Fatalf("invalid sum: got=%v, want=%v", got, want)
+	}
+	if _, err := compute.CallFunction(ctx, "add", res); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error for wrong arity: got=%v, want=%v", err, compute.ErrInvalid)
+	}
+}
+
+func TestFunctionRegistryErrors(t *testing.T) {
+	noop := compute.ScalarKernel{Exec: func(ctx *compute.ExecCtx, args []compute.Datum) (compute.Datum, error) {
+		return args[0], nil
+	}}
+
+	reg := compute.NewFunctionRegistry()
+	if err := reg.RegisterScalarFunction("noop", 1, []compute.ScalarKernel{noop}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		arity   int
+		kernels []compute.ScalarKernel
+	}{
+		{"add", 2, []compute.ScalarKernel{noop}},
+		{"noop", 1, []compute.ScalarKernel{noop}},
+		{"", 1, []compute.ScalarKernel{noop}},
+		{"arity", -2, []compute.ScalarKernel{noop}},
+		{"kernels", 1, nil},
+		{"exec", 1, []compute.ScalarKernel{{}}},
+	} {
+		if err := reg.RegisterScalarFunction(tc.name, tc.arity, tc.kernels, nil); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Errorf("registering %q: invalid error: got=%v, want=%v", tc.name, err, compute.ErrInvalid)
+		}
+	}
+
+	// functions of other registries do not collide.
+	if err := compute.NewFunctionRegistry().RegisterScalarFunction("noop", 1, []compute.ScalarKernel{noop}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
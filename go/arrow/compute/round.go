@@ -0,0 +1,297 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"math"
+	"math/big"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// RoundMode selects how values are rounded to a given number of digits.
+type RoundMode int8
+
+const (
+	// RoundHalfToEven rounds to the nearest value, and halves to the
+	// nearest even value.
+	RoundHalfToEven RoundMode = iota
+	// RoundHalfAwayFromZero rounds to the nearest value, and halves away
+	// from zero.
+	RoundHalfAwayFromZero
+	// RoundDown rounds towards negative infinity.
+	RoundDown
+	// RoundUp rounds towards positive infinity.
+	RoundUp
+	// RoundTowardsZero rounds towards zero.
+	RoundTowardsZero
+)
+
+// RoundOptions controls the behavior of Round.
+type RoundOptions struct {
+	// NDigits is the number of fractional digits to round to. Negative
+	// values round to tens, hundreds, etc.
+	NDigits int
+	Mode    RoundMode
+}
+
+// Round rounds floating point and decimal values to opts.NDigits
+// fractional digits. If opts is nil, values are rounded half to even to
+// integers.
+//
+// Decimal values keep their type: the digits below NDigits are set to
+// zero, and ErrInvalid is returned if the rounded value does not fit the
+// precision of the type. Infinities and NaNs are returned unchanged, and
+// so are floating point values too large to have digits at NDigits.
+// Floating point values are rounded from their binary representation,
+// e.g. 2.675 is slightly below 2.675 and rounds to 2.67 with 2 digits.
+func Round(ctx context.Context, input Datum, opts *RoundOptions) (Datum, error) {
+	if opts == nil {
+		opts = &RoundOptions{}
+	}
+	return round(ctx, "round", input, opts.NDigits, opts.Mode)
+}
+
+// Floor rounds floating point and decimal values down to integers.
+func Floor(ctx context.Context, input Datum) (Datum, error) {
+	return round(ctx, "floor", input, 0, RoundDown)
+}
+
+// Ceil rounds floating point and decimal values up to integers.
+func Ceil(ctx context.Context, input Datum) (Datum, error) {
+	return round(ctx, "ceil", input, 0, RoundUp)
+}
+
+// Trunc rounds floating point and decimal values towards zero to integers.
+func Trunc(ctx context.Context, input Datum) (Datum, error) {
+	return round(ctx, "trunc", input, 0, RoundTowardsZero)
+}
+
+func round(ctx context.Context, name string, input Datum, ndigits int, mode RoundMode) (Datum, error) {
+	if mode < RoundHalfToEven || mode > RoundTowardsZero {
+		return nil, xerrors.Errorf("arrow/compute: invalid round mode %d: %w", mode, ErrInvalid)
+	}
+
+	var kernel unaryKernel
+	switch dt := input.DataType(); dt.ID() {
+	case arrow.FLOAT32, arrow.FLOAT64:
+		kernel = func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+			return roundFloats(mem, arr, ndigits, mode), nil
+		}
+	case arrow.DECIMAL, arrow.DECIMAL256:
+		kernel = func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+			return roundDecimals(mem, arr, ndigits, mode)
+		}
+	default:
+		return nil, xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+	}
+	return execUnary(GetAllocator(ctx), input, kernel)
+}
+
+// roundFloat rounds v to ndigits fractional digits.
+//
+// v is scaled by 10^ndigits and rounded to an integer. The scaling is not
+// exact, and a product landing on an integer or on a half may come from
+// a value on the other side of it, e.g. 2.675*100 is 267.5 while 2.675 is
+// slightly below 2.675: such products are compared to the exact ones.
+func roundFloat(v float64, ndigits int, mode RoundMode) float64 {
+	switch {
+	case math.IsInf(v, 0) || math.IsNaN(v):
+		return v
+	case ndigits >= 0 && v == math.Trunc(v):
+		return v
+	case ndigits < -maxPow10 || ndigits > maxPow10:
+		// 10^ndigits is not exact as a float64.
+		return roundFloatExact(v, ndigits, mode)
+	}
+
+	pow := math.Pow10(abs(ndigits))
+	scaled := v * pow
+	if ndigits < 0 {
+		scaled = v / pow
+	}
+	if math.Abs(scaled) >= 1<<52 {
+		// the fractional part of the product is lost.
+		return roundFloatExact(v, ndigits, mode)
+	}
+
+	var r float64
+	switch frac := scaled - math.Floor(scaled); {
+	case frac == 0:
+		// the exact value is an integer, or lies just around one.
+		switch c := cmpScaled(v, pow, scaled, ndigits); {
+		case c == 0:
+			return v
+		case c < 0 && (mode == RoundDown || mode == RoundTowardsZero && scaled > 0):
+			r = scaled - 1
+		case c > 0 && (mode == RoundUp || mode == RoundTowardsZero && scaled < 0):
+			r = scaled + 1
+		default:
+			r = scaled
+		}
+	case frac == 0.5 && (mode == RoundHalfToEven || mode == RoundHalfAwayFromZero):
+		// the exact value is a tie, or lies just around one.
+		switch c := cmpScaled(v, pow, scaled, ndigits); {
+		case c < 0:
+			r = math.Floor(scaled)
+		case c > 0:
+			r = math.Ceil(scaled)
+		default:
+			r = roundFn(mode)(scaled)
+		}
+	default:
+		r = roundFn(mode)(scaled)
+	}
+
+	if ndigits < 0 {
+		return r * pow
+	}
+	return r / pow
+}
+
+// maxPow10 is the largest n such that 10^n is exact as a float64.
+const maxPow10 = 22
+
+func roundFn(mode RoundMode) func(float64) float64 {
+	switch mode {
+	case RoundHalfToEven:
+		return math.RoundToEven
+	case RoundHalfAwayFromZero:
+		return math.Round
+	case RoundDown:
+		return math.Floor
+	case RoundUp:
+		return math.Ceil
+	default:
+		return math.Trunc
+	}
+}
+
+// cmpScaled compares the exact value of v scaled by 10^ndigits, where pow
+// is 10^|ndigits|, with its rounded value scaled.
+func cmpScaled(v, pow, scaled float64, ndigits int) int {
+	// the products of two float64 values are exact with 106 bits.
+	const prec = 128
+	var (
+		bv = new(big.Float).SetPrec(prec).SetFloat64(v)
+		bp = new(big.Float).SetPrec(prec).SetFloat64(pow)
+		bs = new(big.Float).SetPrec(prec).SetFloat64(scaled)
+	)
+	if ndigits < 0 {
+		// v/pow compares with scaled as v with scaled*pow.
+		return bv.Cmp(bs.Mul(bs, bp))
+	}
+	return bv.Mul(bv, bp).Cmp(bs)
+}
+
+// roundFloatExact rounds v to ndigits fractional digits with rationals.
+func roundFloatExact(v float64, ndigits int, mode RoundMode) float64 {
+	switch {
+	case ndigits > 1074:
+		// the fractional digits of float64 values stop at 2^-1074.
+		return v
+	case ndigits < -400:
+		// 10^400 rounds to infinity, like any larger power.
+		ndigits = -400
+	}
+
+	var (
+		x = new(big.Rat).SetFloat64(v)
+		p = new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(ndigits))), nil))
+	)
+	if ndigits < 0 {
+		p.Inv(p)
+	}
+	// the modes of compute are the ones of decimal128.
+	x.SetInt(decimal128.RoundMode(mode).Round(x.Mul(x, p)))
+	f, _ := x.Quo(x, p).Float64()
+	return f
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func roundFloats(mem memory.Allocator, arr array.Interface, ndigits int, mode RoundMode) array.Interface {
+	var (
+		n        = arr.Len()
+		validity = copyValidity(mem, arr)
+		values   *memory.Buffer
+	)
+	switch arr := arr.(type) {
+	case *array.Float32:
+		values = newBuffer(mem, arrow.Float32Traits.BytesRequired(n))
+		out := arrow.Float32Traits.CastFromBytes(values.Bytes())
+		for i, v := range arr.Float32Values() {
+			out[i] = float32(roundFloat(float64(v), ndigits, mode))
+		}
+	case *array.Float64:
+		values = newBuffer(mem, arrow.Float64Traits.BytesRequired(n))
+		out := arrow.Float64Traits.CastFromBytes(values.Bytes())
+		for i, v := range arr.Float64Values() {
+			out[i] = roundFloat(v, ndigits, mode)
+		}
+	}
+	return makeArray(arr.DataType(), n, []*memory.Buffer{validity, values}, nil, arr.NullN())
+}
+
+func roundDecimals(mem memory.Allocator, arr array.Interface, ndigits int, mode RoundMode) (array.Interface, error) {
+	var (
+		n           = arr.Len()
+		dt          = arr.DataType()
+		prec, scale = decimalParams(dt)
+		drop        = int64(scale) - int64(ndigits) // number of digits set to zero
+		nulls       = arr.NullN() > 0
+	)
+	if drop <= 0 {
+		arr.Retain()
+		return arr, nil
+	}
+	if drop > int64(prec) {
+		// all the digits are dropped, and rounding yields 0 or one unit of
+		// a digit which cannot be represented.
+		drop = int64(prec) + 1
+	}
+
+	var (
+		unit        = pow10(int32(drop))
+		values, set = newDecimalValues(mem, dt, n)
+	)
+	for i := 0; i < n; i++ {
+		if nulls && arr.IsNull(i) {
+			continue
+		}
+		// the modes of compute are the ones of decimal128.
+		q := decimal128.RoundMode(mode).Round(new(big.Rat).SetFrac(decimalValue(arr, i), unit))
+		q.Mul(q, unit)
+		if !fitsPrecision(q, prec) {
+			values.Release()
+			return nil, xerrors.Errorf("arrow/compute: rounded value of row %d overflows %v: %w", i, dt, ErrInvalid)
+		}
+		set(i, q)
+	}
+	validity := copyValidity(mem, arr)
+	return makeArray(dt, n, []*memory.Buffer{validity, values}, nil, arr.NullN()), nil
+}
@@ -0,0 +1,399 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+var roundModes = []compute.RoundMode{
+	compute.RoundHalfToEven,
+	compute.RoundHalfAwayFromZero,
+	compute.RoundDown,
+	compute.RoundUp,
+	compute.RoundTowardsZero,
+}
+
+// refRound rounds r to an integer with math/big.
+func refRound(r *big.Rat, mode compute.RoundMode) *big.Int {
+	floor := new(big.Int).Div(r.Num(), r.Denom())
+	frac := new(big.Rat).Sub(r, new(big.Rat).SetInt(floor))
+	up := new(big.Int).Add(floor, big.NewInt(1))
+	if frac.Sign() == 0 {
+		return floor
+	}
+	switch mode {
+	case compute.RoundDown:
+		return floor
+	case compute.RoundUp:
+		return up
+	case compute.RoundTowardsZero:
+		if r.Sign() > 0 {
+			return floor
+		}
+		return up
+	}
+	switch frac.Cmp(big.NewRat(1, 2)) {
+	case -1:
+		return floor
+	case 1:
+		return up
+	}
+	if mode == compute.RoundHalfAwayFromZero {
+		if r.Sign() > 0 {
+			return up
+		}
+		return floor
+	}
+	if floor.Bit(0) == 0 {
+		return floor
+	}
+	return up
+}
+
+// refRoundDecimal rounds the unscaled value v of the given scale to
+// ndigits fractional digits, and returns the unscaled result.
+func refRoundDecimal(v *big.Int, scale int32, ndigits int, mode compute.RoundMode) *big.Int {
+	if int(scale) <= ndigits {
+		return v
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(int(scale)-ndigits)), nil)
+	q := refRound(new(big.Rat).SetFrac(v, unit), mode)
+	return q.Mul(q, unit)
+}
+
+func toDecimal(v *big.Int) decimal128.Num {
+	lo := new(big.Int).And(v, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	hi := new(big.Int).Rsh(v, 64).Int64()
+	return decimal128.New(hi, lo)
+}
+
+func TestRoundDecimal(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	dt := &arrow.Decimal128Type{Precision: 14, Scale: 4}
+	rng := rand.New(rand.NewSource(1))
+	values := []int64{
+		// the halves which trip half to even rounding.
+		25000, 35000, -25000, -35000, 5000, -5000, 15000, 125, 135, -125, 1250, 1350,
+		0, 1, -1, 9999, -9999,
+	}
+	for i := 0; i < 500; i++ {
+		values = append(values, rng.Int63n(2e12)-1e12)
+	}
+	decs := make([]decimal128.Num, len(values))
+	valid := make([]bool, len(values))
+	for i, v := range values {
+		decs[i] = decimal128.FromI64(v)
+		valid[i] = i%7 != 3
+	}
+	arr := arrayOf(mem, dt, decs, valid)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	for _, mode := range roundModes {
+		for ndigits := -3; ndigits <= 5; ndigits++ {
+			t.Run(fmt.Sprintf("mode=%d/ndigits=%d", mode, ndigits), func(t *testing.T) {
+				out, err := compute.Round(ctx, input, &compute.RoundOptions{NDigits: ndigits, Mode: mode})
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer out.Release()
+
+				want := make([]decimal128.Num, len(values))
+				for i, v := range values {
+					want[i] = toDecimal(refRoundDecimal(big.NewInt(v), dt.Scale, ndigits, mode))
+				}
+				wantArr := arrayOf(mem, dt, want, valid)
+				defer wantArr.Release()
+				assertArrayEqual(t, wantArr, out.(*compute.ArrayDatum).Value)
+			})
+		}
+	}
+
+	t.Run("half-to-even", func(t *testing.T) {
+		out, err := compute.Round(ctx, input, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+		got := out.(*compute.ArrayDatum).Value.(*array.Decimal128)
+		// 2.5 -> 2, 3.5 -> 4, -2.5 -> -2, (null), 0.5 -> 0, -0.5 -> 0,
+		// 1.5 -> 2
+		for i, want := range []int64{20000, 40000, -20000, 0, 0, 0, 20000} {
+			if got.IsNull(i) {
+				continue
+			}
+			if got := got.Value(i); got != decimal128.FromI64(want) {
+				t.Errorf("row %d: got=%v, want=%d", i, got, want)
+			}
+		}
+	})
+}
+
+func TestRoundDecimalOverflow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	dt := &arrow.Decimal128Type{Precision: 3, Scale: 1}
+	arr := arrayOf(mem, dt, []decimal128.Num{dec(999)}, nil)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	if _, err := compute.Ceil(ctx, input); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("got err=%v, want ErrInvalid", err)
+	}
+	out, err := compute.Floor(ctx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Release()
+	if got, want := out.(*compute.ArrayDatum).Value.(*array.Decimal128).Value(0), dec(990); got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestRoundDecimal256(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	dt := &arrow.Decimal256Type{Precision: 70, Scale: 20}
+	rng := rand.New(rand.NewSource(1))
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(60), nil)
+	values := []*big.Int{big.NewInt(25), big.NewInt(-35), big.NewInt(0), new(big.Int).Sub(limit, big.NewInt(1))}
+	for i := 0; i < 500; i++ {
+		v := new(big.Int).Rand(rng, limit)
+		if i%2 == 0 {
+			v.Neg(v)
+		}
+		values = append(values, v)
+	}
+	decs := make([]decimal256.Num, len(values))
+	valid := make([]bool, len(values))
+	for i, v := range values {
+		decs[i], _ = decimal256.FromBigInt(v)
+		valid[i] = i%7 != 3
+	}
+	arr := arrayOf(mem, dt, decs, valid)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	for _, mode := range roundModes {
+		for _, ndigits := range []int{-30, -3, -1, 0, 1, 5, 19, 20, 25} {
+			t.Run(fmt.Sprintf("mode=%d/ndigits=%d", mode, ndigits), func(t *testing.T) {
+				out, err := compute.Round(ctx, input, &compute.RoundOptions{NDigits: ndigits, Mode: mode})
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer out.Release()
+
+				want := make([]decimal256.Num, len(values))
+				for i, v := range values {
+					want[i], _ = decimal256.FromBigInt(refRoundDecimal(v, dt.Scale, ndigits, mode))
+				}
+				wantArr := arrayOf(mem, dt, want, valid)
+				defer wantArr.Release()
+				assertArrayEqual(t, wantArr, out.(*compute.ArrayDatum).Value)
+			})
+		}
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		dt := &arrow.Decimal256Type{Precision: 76, Scale: 1}
+		arr := arrayOf(mem, dt, []decimal256.Num{dec256(strings.Repeat("9", 76))}, nil)
+		defer arr.Release()
+		input := compute.NewDatum(arr)
+		defer input.Release()
+
+		if _, err := compute.Ceil(ctx, input); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("got err=%v, want ErrInvalid", err)
+		}
+	})
+}
+
+// refRoundFloat rounds the exact value of v to ndigits fractional digits
+// with math/big, and returns the nearest float64.
+func refRoundFloat(v float64, ndigits int, mode compute.RoundMode) float64 {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return v
+	}
+	n := ndigits
+	if n < 0 {
+		n = -n
+	}
+	pow := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+	if ndigits < 0 {
+		pow.Inv(pow)
+	}
+	r := new(big.Rat).SetFloat64(v)
+	r.SetInt(refRound(r.Mul(r, pow), mode))
+	f, _ := r.Quo(r, pow).Float64()
+	return f
+}
+
+func TestRoundFloat(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	values := []float64{
+		// values whose scaled products are ties or integers, while the
+		// values themselves are not.
+		2.675, 1.005, 0.285, 1.125, 2.375, 2.5, 3.5, -2.5, -2.675, 0.29, 1.15,
+		0.4, -0.0051, 1250, -1249, 49, 5e-324, 1e308, -1e308, 1e22 + 1<<20,
+		0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1), math.NaN(),
+	}
+	for i := 0; i < 2000; i++ {
+		switch i % 3 {
+		case 0:
+			// decimal values, ending in 5 half of the time.
+			values = append(values, float64(rng.Int63n(2e6)-1e6)/math.Pow10(rng.Intn(8)))
+		case 1:
+			values = append(values, (float64(rng.Int63n(2e5)-1e5)+0.5)/math.Pow10(rng.Intn(6)))
+		default:
+			values = append(values, rng.NormFloat64()*math.Pow10(rng.Intn(40)-20))
+		}
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	for _, f32 := range []bool{false, true} {
+		dt := arrow.DataType(arrow.PrimitiveTypes.Float64)
+		vals := values
+		if f32 {
+			// float32 values are rounded as float64 values.
+			dt = arrow.PrimitiveTypes.Float32
+			vals = make([]float64, len(values))
+			for i, v := range values {
+				vals[i] = float64(float32(v))
+			}
+		}
+		valid := make([]bool, len(vals)+1)
+		for i := range vals {
+			valid[i] = true
+		}
+		arr := arrayOf(mem, dt, append(append([]float64(nil), vals...), 0), valid)
+		defer arr.Release()
+		input := compute.NewDatum(arr)
+		defer input.Release()
+
+		type roundFn func(context.Context, compute.Datum) (compute.Datum, error)
+		for _, tc := range []struct {
+			name    string
+			fn      roundFn
+			ndigits int
+			mode    compute.RoundMode
+		}{
+			{"floor", compute.Floor, 0, compute.RoundDown},
+			{"ceil", compute.Ceil, 0, compute.RoundUp},
+			{"trunc", compute.Trunc, 0, compute.RoundTowardsZero},
+			{"default", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+				return compute.Round(ctx, d, nil)
+			}, 0, compute.RoundHalfToEven},
+		} {
+			t.Run(fmt.Sprintf("%v/%s", dt, tc.name), func(t *testing.T) {
+				out, err := tc.fn(ctx, input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer out.Release()
+				assertRoundedFloats(t, out.(*compute.ArrayDatum).Value, vals, tc.ndigits, tc.mode)
+			})
+		}
+
+		for _, mode := range roundModes {
+			for _, ndigits := range []int{-500, -30, -23, -5, -2, -1, 0, 1, 2, 3, 5, 10, 15, 23, 30, 320, 1100} {
+				t.Run(fmt.Sprintf("%v/mode=%d/ndigits=%d", dt, mode, ndigits), func(t *testing.T) {
+					out, err := compute.Round(ctx, input, &compute.RoundOptions{NDigits: ndigits, Mode: mode})
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer out.Release()
+					assertRoundedFloats(t, out.(*compute.ArrayDatum).Value, vals, ndigits, mode)
+				})
+			}
+		}
+	}
+}
+
+// assertRoundedFloats checks that got holds the values rounded to ndigits
+// with mode, followed by a null.
+func assertRoundedFloats(t *testing.T, got array.Interface, values []float64, ndigits int, mode compute.RoundMode) {
+	t.Helper()
+	if !got.IsNull(len(values)) {
+		t.Fatalf("null row is valid")
+	}
+	for i, v := range values {
+		var g, want float64
+		switch got := got.(type) {
+		case *array.Float32:
+			g = float64(got.Value(i))
+			want = float64(float32(refRoundFloat(v, ndigits, mode)))
+		case *array.Float64:
+			g = got.Value(i)
+			want = refRoundFloat(v, ndigits, mode)
+		}
+		if g != want && !(math.IsNaN(g) && math.IsNaN(want)) {
+			t.Errorf("round(%v, ndigits=%d, mode=%d): got=%v, want=%v", v, ndigits, mode, g, want)
+		}
+	}
+}
+
+func TestRoundErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	ints := &compute.ScalarDatum{Value: scalar.NewInt32Scalar(1)}
+	if _, err := compute.Floor(ctx, ints); !xerrors.Is(err, compute.ErrNotImplemented) {
+		t.Fatalf("got err=%v, want ErrNotImplemented", err)
+	}
+	f := &compute.ScalarDatum{Value: scalar.NewFloat64Scalar(1.5)}
+	if _, err := compute.Round(ctx, f, &compute.RoundOptions{Mode: 42}); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("got err=%v, want ErrInvalid", err)
+	}
+
+	out, err := compute.Round(ctx, f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Release()
+	if got := out.(*compute.ScalarDatum).Value.(*scalar.Float64).Value; got != 2 {
+		t.Fatalf("got=%v, want=2", got)
+	}
+}
@@ -0,0 +1,269 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// RowEqualOptions controls the comparison of rows by RecordsEqualRows.
+type RowEqualOptions struct {
+	// Columns are the names of the compared columns. All the columns are
+	// compared if it is empty.
+	Columns []string
+	// NaNsEqual makes floating point NaNs equal to each other.
+	NaNsEqual bool
+	// NullsEqual makes null values equal to each other, at the top level
+	// as well as within nested values. A null is never equal to a valid
+	// value.
+	NullsEqual bool
+}
+
+// DefaultRowEqualOptions returns the options used when nil options are
+// passed to RecordsEqualRows: all the columns are compared, nulls are equal
+// to each other and NaNs are not.
+func DefaultRowEqualOptions() *RowEqualOptions {
+	return &RowEqualOptions{NullsEqual: true}
+}
+
+// RecordsEqualRows returns a boolean array with no nulls, telling for each
+// row whether it holds the same values in left and right. The records must
+// have the same number of rows, and the compared columns the same names and
+// types in both records.
+//
+// The columns are compared one after the other, each only on the rows
+// which were equal in all the previous columns, and the comparison stops
+// as soon as no row is left. Nested and dictionary columns are compared by
+// their logical values, so that dictionaries with different layouts may
+// hold equal values.
+//
+// The returned array must be Release()'d after use.
+func RecordsEqualRows(ctx context.Context, left, right array.Record, opts *RowEqualOptions) (array.Interface, error) {
+	if opts == nil {
+		opts = DefaultRowEqualOptions()
+	}
+	if left.NumRows() != right.NumRows() {
+		return nil, xerrors.Errorf("arrow/compute: cannot compare records with %d and %d rows: %w",
+			left.NumRows(), right.NumRows(), ErrInvalid)
+	}
+	cols, err := rowEqualColumns(left, right, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		n      = int(left.NumRows())
+		mem    = GetAllocator(ctx)
+		buf    = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		bitmap = buf.Bytes()
+		equal  = n
+	)
+	memory.Set(bitmap, 0xff)
+
+	for _, c := range cols {
+		if equal == 0 {
+			break
+		}
+		l, r := left.Column(c[0]), right.Column(c[1])
+		eq, err := rowComparator(l, r, opts)
+		if err != nil {
+			buf.Release()
+			return nil, xerrors.Errorf("arrow/compute: cannot compare column %q: %w", left.ColumnName(c[0]), err)
+		}
+		visitSetBitRuns(bitmap, 0, n, func(pos, m int) {
+			for i := pos; i < pos+m; i++ {
+				if !eq(i, i) {
+					bitutil.ClearBit(bitmap, i)
+					equal--
+				}
+			}
+		})
+	}
+	return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{nil, buf}, nil, 0), nil
+}
+
+// rowEqualColumns returns the positions of the compared columns in left
+// and right.
+func rowEqualColumns(left, right array.Record, names []string) ([][2]int, error) {
+	ls, rs := left.Schema(), right.Schema()
+	if len(names) == 0 {
+		if len(ls.Fields()) != len(rs.Fields()) {
+			return nil, xerrors.Errorf("arrow/compute: cannot compare records with %d and %d columns: %w",
+				len(ls.Fields()), len(rs.Fields()), ErrInvalid)
+		}
+		cols := make([][2]int, len(ls.Fields()))
+		for i := range cols {
+			lf, rf := ls.Field(i), rs.Field(i)
+			if lf.Name != rf.Name || !arrow.TypeEqual(lf.Type, rf.Type) {
+				return nil, xerrors.Errorf("arrow/compute: cannot compare column %s: %v with column %s: %v: %w",
+					lf.Name, lf.Type, rf.Name, rf.Type, ErrInvalid)
+			}
+			cols[i] = [2]int{i, i}
+		}
+		return cols, nil
+	}
+
+	cols := make([][2]int, len(names))
+	for i, name := range names {
+		li, ri := ls.FieldIndices(name), rs.FieldIndices(name)
+		if len(li) != 1 || len(ri) != 1 {
+			return nil, xerrors.Errorf("arrow/compute: column %q is missing or ambiguous: %w", name, ErrInvalid)
+		}
+		if lt, rt := ls.Field(li[0]).Type, rs.Field(ri[0]).Type; !arrow.TypeEqual(lt, rt) {
+			return nil, xerrors.Errorf("arrow/compute: cannot compare column %q of types %v and %v: %w", name, lt, rt, ErrInvalid)
+		}
+		cols[i] = [2]int{li[0], ri[0]}
+	}
+	return cols, nil
+}
+
+// rowComparator returns a function telling whether the i-th value of l is
+// equal to the j-th value of r. l and r must have the same type.
+func rowComparator(l, r array.Interface, opts *RowEqualOptions) (func(i, j int) bool, error) {
+	var eq func(i, j int) bool
+	switch l := l.(type) {
+	case *array.Null:
+		return func(i, j int) bool { return opts.NullsEqual }, nil
+	case *array.Boolean:
+		r := r.(*array.Boolean)
+		eq = func(i, j int) bool { return l.Value(i) == r.Value(j) }
+	case *array.Float16:
+		r := r.(*array.Float16)
+		eq = func(i, j int) bool {
+			return floatEqual(float64(l.Value(i).Float32()), float64(r.Value(j).Float32()), opts)
+		}
+	case *array.Float32:
+		r := r.(*array.Float32)
+		eq = func(i, j int) bool { return floatEqual(float64(l.Value(i)), float64(r.Value(j)), opts) }
+	case *array.Float64:
+		r := r.(*array.Float64)
+		eq = func(i, j int) bool { return floatEqual(l.Value(i), r.Value(j), opts) }
+	case *array.String:
+		r := r.(*array.String)
+		eq = func(i, j int) bool { return l.Value(i) == r.Value(j) }
+	case *array.Binary:
+		r := r.(*array.Binary)
+		eq = func(i, j int) bool { return bytes.Equal(l.Value(i), r.Value(j)) }
+	case *array.FixedSizeBinary:
+		r := r.(*array.FixedSizeBinary)
+		eq = func(i, j int) bool { return bytes.Equal(l.Value(i), r.Value(j)) }
+	case *array.Decimal128:
+		r := r.(*array.Decimal128)
+		eq = func(i, j int) bool { return l.Value(i) == r.Value(j) }
+	case *array.Decimal256:
+		r := r.(*array.Decimal256)
+		eq = func(i, j int) bool { return l.Value(i) == r.Value(j) }
+	case *array.List:
+		r := r.(*array.List)
+		values, err := rowComparator(l.ListValues(), r.ListValues(), opts)
+		if err != nil {
+			return nil, err
+		}
+		loffs, roffs := l.Offsets()[l.Data().Offset():], r.Offsets()[r.Data().Offset():]
+		eq = func(i, j int) bool {
+			lbeg, rbeg := int(loffs[i]), int(roffs[j])
+			n := int(loffs[i+1]) - lbeg
+			if int(roffs[j+1])-rbeg != n {
+				return false
+			}
+			for k := 0; k < n; k++ {
+				if !values(lbeg+k, rbeg+k) {
+					return false
+				}
+			}
+			return true
+		}
+	case *array.FixedSizeList:
+		r := r.(*array.FixedSizeList)
+		values, err := rowComparator(l.ListValues(), r.ListValues(), opts)
+		if err != nil {
+			return nil, err
+		}
+		n := int(l.DataType().(*arrow.FixedSizeListType).Len())
+		loff, roff := l.Data().Offset(), r.Data().Offset()
+		eq = func(i, j int) bool {
+			lbeg, rbeg := (loff+i)*n, (roff+j)*n
+			for k := 0; k < n; k++ {
+				if !values(lbeg+k, rbeg+k) {
+					return false
+				}
+			}
+			return true
+		}
+	case *array.Struct:
+		r := r.(*array.Struct)
+		fields := make([]func(i, j int) bool, l.NumField())
+		for k := range fields {
+			f, err := rowComparator(l.Field(k), r.Field(k), opts)
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = f
+		}
+		eq = func(i, j int) bool {
+			for _, f := range fields {
+				if !f(i, j) {
+					return false
+				}
+			}
+			return true
+		}
+	case *array.Dictionary:
+		r := r.(*array.Dictionary)
+		values, err := rowComparator(l.Dictionary(), r.Dictionary(), opts)
+		if err != nil {
+			return nil, err
+		}
+		eq = func(i, j int) bool { return values(l.GetValueIndex(i), r.GetValueIndex(j)) }
+	default:
+		fw, ok := l.DataType().(arrow.FixedWidthDataType)
+		if !ok || fw.BitWidth()%8 != 0 {
+			return nil, xerrors.Errorf("arrow/compute: row comparison is not implemented for %v: %w", l.DataType(), ErrNotImplemented)
+		}
+		// other fixed width values are equal if their bytes are.
+		w := fw.BitWidth() / 8
+		lvals, rvals := l.Data().Buffers()[1].Bytes(), r.Data().Buffers()[1].Bytes()
+		loff, roff := l.Data().Offset(), r.Data().Offset()
+		eq = func(i, j int) bool {
+			lbeg, rbeg := (loff+i)*w, (roff+j)*w
+			return bytes.Equal(lvals[lbeg:lbeg+w], rvals[rbeg:rbeg+w])
+		}
+	}
+
+	if l.NullN() == 0 && r.NullN() == 0 {
+		return eq, nil
+	}
+	return func(i, j int) bool {
+		lv, rv := l.IsValid(i), r.IsValid(j)
+		if lv && rv {
+			return eq(i, j)
+		}
+		return !lv && !rv && opts.NullsEqual
+	}, nil
+}
+
+func floatEqual(a, b float64, opts *RowEqualOptions) bool {
+	return a == b || (opts.NaNsEqual && math.IsNaN(a) && math.IsNaN(b))
+}
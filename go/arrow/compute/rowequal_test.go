@@ -0,0 +1,269 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+var (
+	rowDictType = &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	rowNestType = arrow.StructOf(arrow.Field{
+		Name: "l", Type: arrow.ListOf(arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int64})),
+	})
+	rowSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "s", Type: arrow.BinaryTypes.String},
+		{Name: "d", Type: rowDictType},
+		{Name: "n", Type: rowNestType, Nullable: true},
+	}, nil)
+)
+
+// nestedArray returns an array of type rowNestType, holding in each row a
+// list of structs with the values xs. Nil lists are null.
+func nestedArray(mem memory.Allocator, xs [][]int64) array.Interface {
+	bldr := array.NewStructBuilder(mem, rowNestType)
+	defer bldr.Release()
+	lb := bldr.FieldBuilder(0).(*array.ListBuilder)
+	sb := lb.ValueBuilder().(*array.StructBuilder)
+	xb := sb.FieldBuilder(0).(*array.Int64Builder)
+	for _, row := range xs {
+		bldr.Append(true)
+		if row == nil {
+			lb.AppendNull()
+			continue
+		}
+		lb.Append(true)
+		for _, x := range row {
+			sb.Append(true)
+			xb.Append(x)
+		}
+	}
+	return bldr.NewArray()
+}
+
+// rowRecord returns a record of schema rowSchema.
+func rowRecord(mem memory.Allocator, a []int32, aValid []bool, f []float64, s []string, dict []string, indices []int8, xs [][]int64) array.Record {
+	cols := []array.Interface{
+		arrayOf(mem, arrow.PrimitiveTypes.Int32, a, aValid),
+		arrayOf(mem, arrow.PrimitiveTypes.Float64, f, nil),
+		arrayOf(mem, arrow.BinaryTypes.String, s, nil),
+		nil,
+		nestedArray(mem, xs),
+	}
+	idx := arrayOf(mem, arrow.PrimitiveTypes.Int8, indices, nil)
+	values := arrayOf(mem, arrow.BinaryTypes.String, dict, nil)
+	cols[3] = array.NewDictionaryArray(rowDictType, idx, values)
+	idx.Release()
+	values.Release()
+	defer releaseAll(cols)
+	return array.NewRecord(rowSchema, cols, int64(len(a)))
+}
+
+func TestRecordsEqualRows(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	nan := math.NaN()
+	// rows: equal, different a, NaN f, null a, different nested x. The
+	// dictionaries are laid out differently but hold the same values.
+	left := rowRecord(mem,
+		[]int32{1, 2, 3, 0, 5}, []bool{true, true, true, false, true},
+		[]float64{0.5, 1, nan, 2, 3},
+		[]string{"a", "b", "c", "d", "e"},
+		[]string{"x", "y"}, []int8{0, 1, 0, 1, 0},
+		[][]int64{{1, 2}, {3}, nil, {}, {4, 5, 6}})
+	defer left.Release()
+	right := rowRecord(mem,
+		[]int32{1, 20, 3, 0, 5}, []bool{true, true, true, false, true},
+		[]float64{0.5, 1, nan, 2, 3},
+		[]string{"a", "b", "c", "d", "e"},
+		[]string{"y", "x"}, []int8{1, 0, 1, 0, 1},
+		[][]int64{{1, 2}, {3}, nil, {}, {4, 5, 7}})
+	defer right.Release()
+
+	for _, tc := range []struct {
+		name        string
+		left, right array.Record
+		opts        *compute.RowEqualOptions
+		want        []bool
+	}{
+		{
+			name: "default", left: left, right: right,
+			want: []bool{true, false, false, true, false},
+		},
+		{
+			name: "nans-equal", left: left, right: right,
+			opts: &compute.RowEqualOptions{NaNsEqual: true, NullsEqual: true},
+			want: []bool{true, false, true, true, false},
+		},
+		{
+			name: "nulls-not-equal", left: left, right: right,
+			opts: &compute.RowEqualOptions{NaNsEqual: true},
+			want: []bool{true, false, false, false, false},
+		},
+		{
+			name: "subset", left: left, right: right,
+			opts: &compute.RowEqualOptions{Columns: []string{"s", "d"}},
+			want: []bool{true, true, true, true, true},
+		},
+		{
+			name: "nested", left: left, right: right,
+			opts: &compute.RowEqualOptions{Columns: []string{"n"}},
+			want: []bool{true, true, false, true, false},
+		},
+		{
+			name: "nested-nulls-equal", left: left, right: right,
+			opts: &compute.RowEqualOptions{Columns: []string{"n"}, NullsEqual: true},
+			want: []bool{true, true, true, true, false},
+		},
+		{
+			name: "sliced", left: left.NewSlice(1, 4), right: right.NewSlice(2, 5),
+			opts: &compute.RowEqualOptions{Columns: []string{"s"}},
+			want: []bool{false, false, false},
+		},
+		{
+			name: "sliced-nested", left: left.NewSlice(2, 5), right: right.NewSlice(2, 5),
+			opts: &compute.RowEqualOptions{Columns: []string{"d", "n"}, NullsEqual: true},
+			want: []bool{true, true, false},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.left != left {
+				defer tc.left.Release()
+				defer tc.right.Release()
+			}
+			got, err := compute.RecordsEqualRows(ctx, tc.left, tc.right, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, arrow.FixedWidthTypes.Boolean, tc.want, nil)
+			defer want.Release()
+			assertArrayEqual(t, want, got)
+		})
+	}
+}
+
+func TestRecordsEqualRowsErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rec := exprRecord(mem)
+	defer rec.Release()
+	other := rowRecord(mem, []int32{1}, nil, []float64{1}, []string{"a"}, []string{"x"}, []int8{0}, [][]int64{{1}})
+	defer other.Release()
+	short := rec.NewSlice(0, 1)
+	defer short.Release()
+	col := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{1}, nil)
+	defer col.Release()
+	floats := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "f", Type: arrow.PrimitiveTypes.Int64}}, nil), []array.Interface{col}, 1)
+	defer floats.Release()
+
+	for _, tc := range []struct {
+		name        string
+		left, right array.Record
+		opts        *compute.RowEqualOptions
+	}{
+		{name: "rows", left: rec, right: short},
+		{name: "schemas", left: short, right: other},
+		{name: "missing-column", left: rec, right: rec, opts: &compute.RowEqualOptions{Columns: []string{"z"}}},
+		{name: "column-type", left: floats, right: other, opts: &compute.RowEqualOptions{Columns: []string{"f"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := compute.RecordsEqualRows(ctx, tc.left, tc.right, tc.opts)
+			if !xerrors.Is(err, compute.ErrInvalid) {
+				t.Fatalf("got error %v, want %v", err, compute.ErrInvalid)
+			}
+		})
+	}
+}
+
+func BenchmarkRecordsEqualRows(b *testing.B) {
+	const n = 10000
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	a := make([]int32, n)
+	f := make([]float64, n)
+	s := make([]string, n)
+	indices := make([]int8, n)
+	xs := make([][]int64, n)
+	for i := range a {
+		a[i] = int32(i)
+		f[i] = float64(i) / 3
+		s[i] = fmt.Sprint(i)
+		indices[i] = int8(i % 2)
+		xs[i] = []int64{int64(i), int64(i % 7)}
+	}
+	left := rowRecord(mem, a, nil, f, s, []string{"x", "y"}, indices, xs)
+	defer left.Release()
+	xs[n/2] = []int64{0}
+	right := rowRecord(mem, a, nil, f, s, []string{"x", "y"}, indices, xs)
+	defer right.Release()
+
+	b.Run("columns", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			got, err := compute.RecordsEqualRows(ctx, left, right, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			got.Release()
+		}
+	})
+
+	// rows compares the values of each row, materialized as scalars.
+	b.Run("rows", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			equal := make([]bool, n)
+			for row := range equal {
+				equal[row] = true
+				for c := 0; c < int(left.NumCols()) && equal[row]; c++ {
+					ls, err := scalar.GetScalar(left.Column(c), row)
+					if err != nil {
+						b.Fatal(err)
+					}
+					rs, err := scalar.GetScalar(right.Column(c), row)
+					if err != nil {
+						b.Fatal(err)
+					}
+					equal[row] = reflect.DeepEqual(ls, rs)
+					for _, s := range []scalar.Scalar{ls, rs} {
+						if r, ok := s.(interface{ Release() }); ok {
+							r.Release()
+						}
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,235 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// RunEndEncode returns the run-end encoded values of an array or a chunked
+// array: consecutive equal values are collapsed into a run, and so are
+// consecutive nulls. The run ends are of the integer type runEnds, Int16,
+// Int32 or Int64, and default to Int32. Dictionary values are compared by
+// their indices.
+//
+// The returned datum must be Release()'d after use.
+func RunEndEncode(ctx context.Context, input Datum, runEnds arrow.DataType) (Datum, error) {
+	if runEnds == nil {
+		runEnds = arrow.PrimitiveTypes.Int32
+	}
+	switch runEnds.ID() {
+	case arrow.INT16, arrow.INT32, arrow.INT64:
+	default:
+		return nil, xerrors.Errorf("arrow/compute: run ends must be int16, int32 or int64, got %v: %w", runEnds, ErrInvalid)
+	}
+	if err := checkRunEndInput("encode", input, false); err != nil {
+		return nil, err
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		return encodeRuns(mem, arr, runEnds)
+	})
+}
+
+// RunEndDecode returns the logical values of a run-end encoded array or
+// chunked array, the value of each run being repeated over its length.
+//
+// The returned datum must be Release()'d after use.
+func RunEndDecode(ctx context.Context, input Datum) (Datum, error) {
+	if err := checkRunEndInput("decode", input, true); err != nil {
+		return nil, err
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		return decodeRuns(mem, arr.(*array.RunEndEncoded))
+	})
+}
+
+// checkRunEndInput checks that input is an array or a chunked array, run-end
+// encoded or not as encoded tells.
+func checkRunEndInput(op string, input Datum, encoded bool) error {
+	if k := input.Kind(); k != KindArray && k != KindChunked {
+		return xerrors.Errorf("arrow/compute: cannot run-end %s a %v: %w", op, k, ErrInvalid)
+	}
+	if (input.DataType().ID() == arrow.RUN_END_ENCODED) != encoded {
+		return xerrors.Errorf("arrow/compute: cannot run-end %s %v values: %w", op, input.DataType(), ErrInvalid)
+	}
+	return nil
+}
+
+// encodeRuns returns the runs of equal values of arr, with run ends of
+// type runEnds.
+func encodeRuns(mem memory.Allocator, arr array.Interface, runEnds arrow.DataType) (array.Interface, error) {
+	var (
+		n    = arr.Len()
+		sel  = &selection{}
+		ends []int64
+	)
+	if n == 0 {
+		// the buffers of empty arrays may be missing.
+		return newRunEndEncoded(mem, runEnds, ends, []array.Interface{arr}, sel)
+	}
+
+	cmp := arr
+	if dict, ok := arr.(*array.Dictionary); ok {
+		cmp = dict.Indices()
+	}
+	eq, err := rowComparator(cmp, cmp, &RowEqualOptions{NaNsEqual: true, NullsEqual: true})
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && eq(i, j) {
+			j++
+		}
+		sel.add(0, i, 1, arr.IsNull(i))
+		ends = append(ends, int64(j))
+		i = j
+	}
+	return newRunEndEncoded(mem, runEnds, ends, []array.Interface{arr}, sel)
+}
+
+// decodeRuns returns the logical values of arr.
+func decodeRuns(mem memory.Allocator, arr *array.RunEndEncoded) (array.Interface, error) {
+	var (
+		n      = arr.Len()
+		off    = arr.Data().Offset()
+		values = arr.Values()
+		sel    = &selection{spans: make([]span, 0, arr.GetPhysicalLength())}
+	)
+	for j, pos := arr.GetPhysicalOffset(), 0; pos < n; j++ {
+		end := min(runEnd(arr.RunEndsArr(), j)-off, n)
+		null := values.IsNull(j)
+		for ; pos < end; pos++ {
+			sel.add(0, j, 1, null)
+		}
+	}
+	return gather(mem, []array.Interface{values}, sel)
+}
+
+// gatherRunEndEncoded gathers the logical values of run-end encoded
+// arrays, as runs of the values of the runs of srcs they fall in.
+func gatherRunEndEncoded(mem memory.Allocator, dt *arrow.RunEndEncodedType, srcs []array.Interface, sel *selection) (array.Interface, error) {
+	var (
+		values = make([]array.Interface, len(srcs))
+		vsel   = &selection{}
+		ends   []int64
+		n      int
+		last   = span{src: -1} // the run of srcs of the last run gathered
+	)
+	for i, src := range srcs {
+		values[i] = src.(*array.RunEndEncoded).Values()
+	}
+
+	// extend extends the last run by k values, or starts a new one when it
+	// is not the same run of srcs.
+	extend := func(run span, k int) {
+		n += k
+		if len(ends) > 0 && run == last {
+			ends[len(ends)-1] = int64(n)
+			return
+		}
+		vsel.add(run.src, run.pos, 1, run.null)
+		ends = append(ends, int64(n))
+		last = run
+	}
+
+	for _, sp := range sel.spans {
+		if sp.null {
+			extend(span{n: 1, null: true}, sp.n)
+			continue
+		}
+		src := srcs[sp.src].(*array.RunEndEncoded)
+		off := src.Data().Offset()
+		for pos, end := sp.pos, sp.pos+sp.n; pos < end; {
+			j := src.GetPhysicalIndex(pos)
+			k := min(end, runEnd(src.RunEndsArr(), j)-off) - pos
+			extend(span{src: sp.src, pos: j, n: 1}, k)
+			pos += k
+		}
+	}
+	return newRunEndEncoded(mem, dt.RunEnds(), ends, values, vsel)
+}
+
+// newRunEndEncoded returns a run-end encoded array of runs ending at ends,
+// with run ends of type runEnds, whose values are those of srcs selected by
+// sel.
+func newRunEndEncoded(mem memory.Allocator, runEnds arrow.DataType, ends []int64, srcs []array.Interface, sel *selection) (array.Interface, error) {
+	n := 0
+	if len(ends) > 0 {
+		n = int(ends[len(ends)-1])
+	}
+	if !fitsRunEnd(runEnds, n) {
+		return nil, xerrors.Errorf("arrow/compute: %d values overflow %v run ends: %w", n, runEnds, ErrInvalid)
+	}
+
+	values, err := gather(mem, srcs, sel)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+	re := makeRunEnds(mem, runEnds, ends)
+	defer re.Release()
+
+	return array.NewRunEndEncoded(re, values, n, 0), nil
+}
+
+// makeRunEnds returns an array of type dt holding the given run ends.
+func makeRunEnds(mem memory.Allocator, dt arrow.DataType, ends []int64) array.Interface {
+	bldr := array.NewBuilder(mem, dt)
+	defer bldr.Release()
+	bldr.Reserve(len(ends))
+	for _, v := range ends {
+		switch bldr := bldr.(type) {
+		case *array.Int16Builder:
+			bldr.UnsafeAppend(int16(v))
+		case *array.Int32Builder:
+			bldr.UnsafeAppend(int32(v))
+		case *array.Int64Builder:
+			bldr.UnsafeAppend(v)
+		}
+	}
+	return bldr.NewArray()
+}
+
+// fitsRunEnd reports whether the run ends type dt can represent v.
+func fitsRunEnd(dt arrow.DataType, v int) bool {
+	switch dt.ID() {
+	case arrow.INT16:
+		return v <= math.MaxInt16
+	case arrow.INT32:
+		return v <= math.MaxInt32
+	}
+	return true
+}
+
+// runEnd returns the j-th value of the run ends ends.
+func runEnd(ends array.Interface, j int) int {
+	switch ends := ends.(type) {
+	case *array.Int16:
+		return int(ends.Value(j))
+	case *array.Int32:
+		return int(ends.Value(j))
+	default:
+		return int(ends.(*array.Int64).Value(j))
+	}
+}
@@ -0,0 +1,416 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestRunEndEncode(t *testing.T) {
+	var (
+		i16 = arrow.PrimitiveTypes.Int16
+		i32 = arrow.PrimitiveTypes.Int32
+		i64 = arrow.PrimitiveTypes.Int64
+		str = arrow.BinaryTypes.String
+		f64 = arrow.PrimitiveTypes.Float64
+		nan = math.NaN()
+	)
+
+	for _, tc := range []struct {
+		name    string
+		dt      arrow.DataType
+		values  interface{}
+		valid   []bool
+		runEnds arrow.DataType
+		ends    []int64
+		runs    interface{}
+		rvalid  []bool
+	}{
+		{
+			name:    "int32",
+			dt:      i32,
+			values:  []int32{1, 1, 0, 0, 2, 2, 2, 1},
+			valid:   []bool{true, true, false, false, true, true, true, true},
+			runEnds: i16,
+			ends:    []int64{2, 4, 7, 8},
+			runs:    []int32{1, 0, 2, 1},
+			rvalid:  []bool{true, false, true, true},
+		},
+		{
+			name:    "string",
+			dt:      str,
+			values:  []string{"a", "a", "b", "a", "", ""},
+			runEnds: i32,
+			ends:    []int64{2, 3, 4, 6},
+			runs:    []string{"a", "b", "a", ""},
+		},
+		{
+			name:    "float64-nan",
+			dt:      f64,
+			values:  []float64{0, 0, nan, nan, 1},
+			runEnds: i64,
+			ends:    []int64{2, 4, 5},
+			runs:    []float64{0, nan, 1},
+		},
+		{
+			name:    "empty",
+			dt:      i32,
+			values:  []int32{},
+			runEnds: i32,
+			ends:    []int64{},
+			runs:    []int32{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			arr := arrayOf(mem, tc.dt, tc.values, tc.valid)
+			defer arr.Release()
+
+			ree := runEndEncode(t, ctx, arr, tc.runEnds)
+			defer ree.Release()
+
+			ends := arrayOf(mem, tc.runEnds, tc.ends, nil)
+			defer ends.Release()
+			runs := arrayOf(mem, tc.dt, tc.runs, tc.rvalid)
+			defer runs.Release()
+
+			if ree.Len() != arr.Len() {
+				t.Fatalf("invalid length: got=%d, want=%d", ree.Len(), arr.Len())
+			}
+			assertArrayEqual(t, ends, ree.RunEndsArr())
+			if !array.ArrayApproxEqual(runs, ree.Values(), array.WithNaNsEqual(true)) {
+				t.Fatalf("invalid runs: got=%v, want=%v", ree.Values(), runs)
+			}
+
+			dec := runEndDecode(t, ctx, ree)
+			defer dec.Release()
+			if !array.ArrayApproxEqual(arr, dec, array.WithNaNsEqual(true)) {
+				t.Fatalf("invalid decoded values: got=%v, want=%v", dec, arr)
+			}
+		})
+	}
+}
+
+func TestRunEndEncodeDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	dict := arrayOf(mem, dt.ValueType, []string{"x", "y", "x"}, nil)
+	defer dict.Release()
+	indices := arrayOf(mem, dt.IndexType, []int8{0, 2, 1, 1, 0, 0}, []bool{true, true, true, true, false, false})
+	defer indices.Release()
+	arr := array.NewDictionaryArray(dt, indices, dict)
+	defer arr.Release()
+
+	ree := runEndEncode(t, ctx, arr, nil)
+	defer ree.Release()
+
+	// runs are made of equal indices, regardless of the values.
+	ends := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 4, 6}, nil)
+	defer ends.Release()
+	assertArrayEqual(t, ends, ree.RunEndsArr())
+	if !arrow.TypeEqual(ree.Values().DataType(), dt) {
+		t.Fatalf("invalid type of runs: got=%v, want=%v", ree.Values().DataType(), dt)
+	}
+
+	dec := runEndDecode(t, ctx, ree)
+	defer dec.Release()
+	assertArrayEqual(t, arr, dec)
+}
+
+func TestRunEndEncodeErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int8, make([]int8, 1<<15), nil)
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	for _, tc := range []struct {
+		name    string
+		runEnds arrow.DataType
+		err     error
+	}{
+		{"overflow", arrow.PrimitiveTypes.Int16, compute.ErrInvalid},
+		{"unsigned", arrow.PrimitiveTypes.Uint32, compute.ErrInvalid},
+		{"int32", arrow.PrimitiveTypes.Int32, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := compute.RunEndEncode(ctx, input, tc.runEnds)
+			if err == nil {
+				out.Release()
+			}
+			if !xerrors.Is(err, tc.err) && err != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+
+	if _, err := compute.RunEndDecode(ctx, input); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error decoding %v values: %v", arr.DataType(), err)
+	}
+}
+
+func TestRunEndEncodeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	runEnds := []arrow.DataType{arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64}
+
+	for _, dt := range randomTypes {
+		t.Run(dt.Name(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			for iter := 0; iter < 20; iter++ {
+				arr := randomRuns(t, ctx, rng, dt, rng.Intn(200), 1+rng.Intn(10))
+				defer arr.Release()
+
+				ree := runEndEncode(t, ctx, arr, runEnds[iter%len(runEnds)])
+				defer ree.Release()
+				if ree.Values().Len() > arr.Len() {
+					t.Fatalf("%d runs for %d values", ree.Values().Len(), arr.Len())
+				}
+				dec := runEndDecode(t, ctx, ree)
+				defer dec.Release()
+				assertArrayEqual(t, arr, dec)
+
+				// the runs of slices only cover the slice.
+				i := rng.Intn(arr.Len() + 1)
+				j := i + rng.Intn(arr.Len()-i+1)
+				want := array.NewSlice(arr, int64(i), int64(j))
+				defer want.Release()
+				slice := array.NewSlice(ree, int64(i), int64(j))
+				defer slice.Release()
+				got := runEndDecode(t, ctx, slice.(*array.RunEndEncoded))
+				defer got.Release()
+				assertArrayEqual(t, want, got)
+
+				chunked := randomChunks(rng, arr)
+				defer chunked.Release()
+				enc, err := compute.RunEndEncode(ctx, &compute.ChunkedDatum{Value: chunked}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer enc.Release()
+				res, err := compute.RunEndDecode(ctx, enc)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer res.Release()
+				out := res.(*compute.ChunkedDatum).Value
+				if len(out.Chunks()) != len(chunked.Chunks()) {
+					t.Fatalf("invalid number of chunks: got=%d, want=%d", len(out.Chunks()), len(chunked.Chunks()))
+				}
+				for k, c := range chunked.Chunks() {
+					assertArrayEqual(t, c, out.Chunk(k))
+				}
+			}
+		})
+	}
+}
+
+func TestRunEndEncodedSelection(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, dt := range randomTypes {
+		t.Run(dt.Name(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			for iter := 0; iter < 20; iter++ {
+				arr := randomRuns(t, ctx, rng, dt, rng.Intn(200), 1+rng.Intn(10))
+				defer arr.Release()
+				ree := runEndEncode(t, ctx, arr, nil)
+				defer ree.Release()
+
+				// select from a slice of the runs.
+				i := rng.Intn(arr.Len() + 1)
+				j := i + rng.Intn(arr.Len()-i+1)
+				plain := array.NewSlice(arr, int64(i), int64(j))
+				defer plain.Release()
+				encoded := array.NewSlice(ree, int64(i), int64(j))
+				defer encoded.Release()
+				n := plain.Len()
+
+				mask := randomArray(mem, rng, arrow.FixedWidthTypes.Boolean, n, 0.1)
+				defer mask.Release()
+				for _, opts := range []*compute.FilterOptions{nil, {NullSelection: compute.EmitNulls}} {
+					want, err := compute.FilterArray(ctx, plain, mask, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer want.Release()
+					got, err := compute.FilterArray(ctx, encoded, mask, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer got.Release()
+					assertDecoded(t, ctx, want, got)
+				}
+
+				idx := make([]int64, rng.Intn(150))
+				valid := make([]bool, len(idx))
+				for k := range idx {
+					if n > 0 && rng.Intn(10) != 0 {
+						idx[k], valid[k] = int64(rng.Intn(n)), true
+					}
+				}
+				indices := arrayOf(mem, arrow.PrimitiveTypes.Int64, idx, valid)
+				defer indices.Release()
+				want, err := compute.TakeArray(ctx, plain, indices, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer want.Release()
+				got, err := compute.TakeArray(ctx, encoded, indices, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+				assertDecoded(t, ctx, want, got)
+
+				// the runs of several chunks are gathered together.
+				chunked := randomChunks(rng, ree)
+				defer chunked.Release()
+				all := make([]int64, ree.Len())
+				for k := range all {
+					all[k] = int64(k)
+				}
+				allIndices := arrayOf(mem, arrow.PrimitiveTypes.Int64, all, nil)
+				defer allIndices.Release()
+				res, err := compute.TakeChunked(ctx, chunked, allIndices, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer res.Release()
+				assertDecoded(t, ctx, arr, res.Chunk(0))
+			}
+		})
+	}
+}
+
+// randomRuns returns an array of n random values of type dt, in runs of up
+// to maxRun values.
+func randomRuns(t *testing.T, ctx context.Context, rng *rand.Rand, dt arrow.DataType, n, maxRun int) array.Interface {
+	t.Helper()
+	mem := compute.GetAllocator(ctx)
+	values := randomArray(mem, rng, dt, n, 0.2)
+	defer values.Release()
+
+	idx := make([]int64, 0, n)
+	for len(idx) < n {
+		v := int64(len(idx))
+		for k := 1 + rng.Intn(maxRun); k > 0 && len(idx) < n; k-- {
+			idx = append(idx, v)
+		}
+	}
+	indices := arrayOf(mem, arrow.PrimitiveTypes.Int64, idx, nil)
+	defer indices.Release()
+	arr, err := compute.TakeArray(ctx, values, indices, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return arr
+}
+
+func runEndEncode(t *testing.T, ctx context.Context, arr array.Interface, runEnds arrow.DataType) *array.RunEndEncoded {
+	t.Helper()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+	out, err := compute.RunEndEncode(ctx, input, runEnds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out.(*compute.ArrayDatum).Value.(*array.RunEndEncoded)
+}
+
+func runEndDecode(t *testing.T, ctx context.Context, arr *array.RunEndEncoded) array.Interface {
+	t.Helper()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+	out, err := compute.RunEndDecode(ctx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out.(*compute.ArrayDatum).Value
+}
+
+// assertDecoded checks that got is run-end encoded, and that its logical
+// values are those of want.
+func assertDecoded(t *testing.T, ctx context.Context, want, got array.Interface) {
+	t.Helper()
+	ree, ok := got.(*array.RunEndEncoded)
+	if !ok {
+		t.Fatalf("invalid array: got=%T, want=*array.RunEndEncoded", got)
+	}
+	dec := runEndDecode(t, ctx, ree)
+	defer dec.Release()
+	assertArrayEqual(t, want, dec)
+}
+
+func BenchmarkRunEndEncode(b *testing.B) {
+	const n = 1 << 16
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	// a sorted column of few distinct values.
+	categories := []string{"clothing", "electronics", "furniture", "groceries", "toys"}
+	values := make([]string, n)
+	for i := range values {
+		values[i] = categories[(i*7)%len(categories)]
+	}
+	sort.Strings(values)
+	bldr := array.NewStringBuilder(mem)
+	defer bldr.Release()
+	bldr.AppendValues(values, nil)
+	arr := bldr.NewArray()
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	var encoded int
+	b.SetBytes(int64(arraySize(arr.Data())))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out, err := compute.RunEndEncode(ctx, input, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		encoded = arraySize(out.(*compute.ArrayDatum).Value.Data())
+		out.Release()
+	}
+	b.ReportMetric(float64(arraySize(arr.Data())), "plain-B")
+	b.ReportMetric(float64(encoded), "encoded-B")
+}
@@ -0,0 +1,548 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// span is a run of consecutive values of one of the source arrays of a
+// selection. A null span stands for n null values.
+type span struct {
+	src  int
+	pos  int
+	n    int
+	null bool
+}
+
+// selection describes the values of the output of a selection kernel, such
+// as Filter or Take, as a sequence of spans over one or more source arrays
+// of the same type.
+type selection struct {
+	spans []span
+	n     int  // total number of values
+	nulls bool // whether any of the spans is null
+}
+
+// add appends a span to the selection, extending the last span when
+// possible.
+func (s *selection) add(src, pos, n int, null bool) {
+	if n == 0 {
+		return
+	}
+	s.n += n
+	s.nulls = s.nulls || null
+	if last := len(s.spans) - 1; last >= 0 {
+		prev := &s.spans[last]
+		switch {
+		case null && prev.null:
+			prev.n += n
+			return
+		case !null && !prev.null && src == prev.src && pos == prev.pos+prev.n:
+			prev.n += n
+			return
+		}
+	}
+	s.spans = append(s.spans, span{src: src, pos: pos, n: n, null: null})
+}
+
+// split returns a selection where the spans of s, whose positions are
+// logical positions over the concatenation of chunks, are split at chunk
+// boundaries and made relative to the chunk they belong to.
+func (s *selection) split(chunks []array.Interface) *selection {
+	bounds := make([]int, len(chunks)+1)
+	for i, c := range chunks {
+		bounds[i+1] = bounds[i] + c.Len()
+	}
+
+	out := &selection{spans: make([]span, 0, len(s.spans))}
+	chunk := 0
+	for _, sp := range s.spans {
+		if sp.null {
+			out.add(0, 0, sp.n, true)
+			continue
+		}
+		for pos, end := sp.pos, sp.pos+sp.n; pos < end; {
+			// consecutive spans often fall in the same chunk.
+			if pos < bounds[chunk] || pos >= bounds[chunk+1] {
+				chunk = sort.SearchInts(bounds[1:], pos+1)
+			}
+			n := min(end, bounds[chunk+1]) - pos
+			out.add(chunk, pos-bounds[chunk], n, false)
+			pos += n
+		}
+	}
+	return out
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// gather returns a new array made of the values of srcs described by sel.
+func gather(mem memory.Allocator, srcs []array.Interface, sel *selection) (array.Interface, error) {
+	dt := srcs[0].DataType()
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return array.NewNull(sel.n), nil
+	case *arrow.DictionaryType:
+		return gatherDictionary(mem, dt, srcs, sel)
+	case *arrow.RunEndEncodedType:
+		return gatherRunEndEncoded(mem, dt, srcs, sel)
+	}
+
+	validity, nulls := gatherValidity(mem, srcs, sel)
+	buffers := []*memory.Buffer{validity}
+	var children []*array.Data
+
+	release := func() {
+		for _, b := range buffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+		for _, c := range children {
+			c.Release()
+		}
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		buffers = append(buffers, gatherBits(mem, srcs, sel))
+
+	case arrow.FixedWidthDataType:
+		buffers = append(buffers, gatherFixedWidth(mem, srcs, sel, byteWidth(dt)))
+
+	case *arrow.StringType, *arrow.BinaryType:
+		buffers = append(buffers, gatherBinary(mem, srcs, sel)...)
+
+	case *arrow.ListType:
+		offsets, childSel := listChildSelection(mem, srcs, sel)
+		buffers = append(buffers, offsets)
+		child, err := gatherChild(mem, srcs, childSel, func(arr array.Interface) array.Interface {
+			return arr.(*array.List).ListValues()
+		})
+		if err != nil {
+			release()
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.FixedSizeListType:
+		width := int(dt.Len())
+		childSel := &selection{spans: make([]span, 0, len(sel.spans))}
+		for _, sp := range sel.spans {
+			if sp.null {
+				childSel.add(0, 0, sp.n*width, true)
+				continue
+			}
+			off := srcs[sp.src].Data().Offset()
+			childSel.add(sp.src, (off+sp.pos)*width, sp.n*width, false)
+		}
+		child, err := gatherChild(mem, srcs, childSel, func(arr array.Interface) array.Interface {
+			return arr.(*array.FixedSizeList).ListValues()
+		})
+		if err != nil {
+			release()
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.StructType:
+		for i := range dt.Fields() {
+			i := i
+			child, err := gatherChild(mem, srcs, sel, func(arr array.Interface) array.Interface {
+				return arr.(*array.Struct).Field(i)
+			})
+			if err != nil {
+				release()
+				return nil, err
+			}
+			children = append(children, child)
+		}
+
+	default:
+		release()
+		return nil, xerrors.Errorf("arrow/compute: selection of %v values: %w", dt, ErrNotImplemented)
+	}
+
+	data := array.NewData(dt, sel.n, buffers, children, nulls, 0)
+	defer data.Release()
+	release()
+	return array.MakeFromData(data), nil
+}
+
+// gatherChild gathers the values of a child array of srcs.
+func gatherChild(mem memory.Allocator, srcs []array.Interface, sel *selection, child func(array.Interface) array.Interface) (*array.Data, error) {
+	children := make([]array.Interface, len(srcs))
+	for i, src := range srcs {
+		children[i] = child(src)
+	}
+	out, err := gather(mem, children, sel)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Release()
+	data := out.Data()
+	data.Retain()
+	return data, nil
+}
+
+// gatherDictionary gathers the indices of dictionary arrays, which must all
+// share the same dictionary.
+func gatherDictionary(mem memory.Allocator, dt *arrow.DictionaryType, srcs []array.Interface, sel *selection) (array.Interface, error) {
+	dict := srcs[0].(*array.Dictionary).Dictionary()
+	indices := make([]array.Interface, len(srcs))
+	for i, src := range srcs {
+		src := src.(*array.Dictionary)
+		if d := src.Dictionary(); d.Data() != dict.Data() && !array.ArrayEqual(d, dict) {
+			return nil, xerrors.Errorf("arrow/compute: selection of %v values with different dictionaries: %w", dt, ErrNotImplemented)
+		}
+		indices[i] = src.Indices()
+	}
+	out, err := gather(mem, indices, sel)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Release()
+	return array.NewDictionaryArray(dt, out, dict), nil
+}
+
+// byteWidth returns the number of bytes of a value of the fixed width type dt.
+func byteWidth(dt arrow.FixedWidthDataType) int {
+	if dt.ID() == arrow.DECIMAL {
+		return arrow.Decimal128SizeBytes
+	}
+	return dt.BitWidth() / 8
+}
+
+func gatherValidity(mem memory.Allocator, srcs []array.Interface, sel *selection) (*memory.Buffer, int) {
+	hasNulls := sel.nulls
+	for _, src := range srcs {
+		hasNulls = hasNulls || src.NullN() > 0
+	}
+	if !hasNulls {
+		return nil, 0
+	}
+
+	buf := newBuffer(mem, int(bitutil.BytesForBits(int64(sel.n))))
+	out := buf.Bytes()
+	pos := 0
+	for _, sp := range sel.spans {
+		src := srcs[sp.src]
+		switch {
+		case sp.null:
+		case src.NullN() == 0:
+			setBits(out, pos, sp.n)
+		case src.NullN() == src.Len():
+		default:
+			copyBitmap(src.NullBitmapBytes(), src.Data().Offset()+sp.pos, out, pos, sp.n)
+		}
+		pos += sp.n
+	}
+	return buf, sel.n - bitutil.CountSetBits(out, 0, sel.n)
+}
+
+func gatherBits(mem memory.Allocator, srcs []array.Interface, sel *selection) *memory.Buffer {
+	buf := newBuffer(mem, int(bitutil.BytesForBits(int64(sel.n))))
+	out := buf.Bytes()
+	pos := 0
+	for _, sp := range sel.spans {
+		if !sp.null {
+			data := srcs[sp.src].Data()
+			copyBitmap(data.Buffers()[1].Bytes(), data.Offset()+sp.pos, out, pos, sp.n)
+		}
+		pos += sp.n
+	}
+	return buf
+}
+
+func gatherFixedWidth(mem memory.Allocator, srcs []array.Interface, sel *selection, width int) *memory.Buffer {
+	buf := newBuffer(mem, sel.n*width)
+	switch width {
+	case 8:
+		gatherUint64(srcs, sel, arrow.Uint64Traits.CastFromBytes(buf.Bytes()))
+		return buf
+	case 4:
+		gatherUint32(srcs, sel, arrow.Uint32Traits.CastFromBytes(buf.Bytes()))
+		return buf
+	}
+
+	out := buf.Bytes()
+	pos := 0
+	for _, sp := range sel.spans {
+		if !sp.null {
+			data := srcs[sp.src].Data()
+			beg := (data.Offset() + sp.pos) * width
+			copy(out[pos:], data.Buffers()[1].Bytes()[beg:beg+sp.n*width])
+		}
+		pos += sp.n * width
+	}
+	return buf
+}
+
+// gatherUint64 copies the 8-byte values of the selection to out. Spans of a
+// single value, the common case of sparse selections, avoid a call to copy.
+func gatherUint64(srcs []array.Interface, sel *selection, out []uint64) {
+	vals := make([][]uint64, len(srcs))
+	for i, src := range srcs {
+		if data := src.Data(); data.Len() > 0 {
+			vals[i] = arrow.Uint64Traits.CastFromBytes(data.Buffers()[1].Bytes())[data.Offset():]
+		}
+	}
+	pos := 0
+	for _, sp := range sel.spans {
+		switch {
+		case sp.null:
+		case sp.n == 1:
+			out[pos] = vals[sp.src][sp.pos]
+		default:
+			copy(out[pos:pos+sp.n], vals[sp.src][sp.pos:])
+		}
+		pos += sp.n
+	}
+}
+
+// gatherUint32 copies the 4-byte values of the selection to out.
+func gatherUint32(srcs []array.Interface, sel *selection, out []uint32) {
+	vals := make([][]uint32, len(srcs))
+	for i, src := range srcs {
+		if data := src.Data(); data.Len() > 0 {
+			vals[i] = arrow.Uint32Traits.CastFromBytes(data.Buffers()[1].Bytes())[data.Offset():]
+		}
+	}
+	pos := 0
+	for _, sp := range sel.spans {
+		switch {
+		case sp.null:
+		case sp.n == 1:
+			out[pos] = vals[sp.src][sp.pos]
+		default:
+			copy(out[pos:pos+sp.n], vals[sp.src][sp.pos:])
+		}
+		pos += sp.n
+	}
+}
+
+// valueOffsets returns the int32 offsets of the binary or list array arr,
+// starting at its first value.
+func valueOffsets(arr array.Interface) []int32 {
+	data := arr.Data()
+	if data.Buffers()[1] == nil {
+		return []int32{0}
+	}
+	offsets := arrow.Int32Traits.CastFromBytes(data.Buffers()[1].Bytes())
+	return offsets[data.Offset() : data.Offset()+data.Len()+1]
+}
+
+// gatherBinary returns the offsets and data buffers of the selection over
+// binary arrays, sizing the data buffer up front.
+func gatherBinary(mem memory.Allocator, srcs []array.Interface, sel *selection) []*memory.Buffer {
+	offsets := make([][]int32, len(srcs))
+	for i, src := range srcs {
+		offsets[i] = valueOffsets(src)
+	}
+
+	size := 0
+	for _, sp := range sel.spans {
+		if !sp.null {
+			off := offsets[sp.src]
+			size += int(off[sp.pos+sp.n] - off[sp.pos])
+		}
+	}
+
+	offsetsBuf := newBuffer(mem, arrow.Int32Traits.BytesRequired(sel.n+1))
+	dataBuf := newBuffer(mem, size)
+	outOffsets := arrow.Int32Traits.CastFromBytes(offsetsBuf.Bytes())
+	outData := dataBuf.Bytes()
+
+	var (
+		pos = 0
+		cur = int32(0)
+	)
+	for _, sp := range sel.spans {
+		if sp.null {
+			for i := 0; i < sp.n; i++ {
+				outOffsets[pos+i+1] = cur
+			}
+			pos += sp.n
+			continue
+		}
+		off := offsets[sp.src]
+		beg, end := off[sp.pos], off[sp.pos+sp.n]
+		if data := srcs[sp.src].Data().Buffers()[2]; data != nil {
+			copy(outData[cur:], data.Bytes()[beg:end])
+		}
+		for i := 1; i <= sp.n; i++ {
+			outOffsets[pos+i] = cur + off[sp.pos+i] - beg
+		}
+		cur += end - beg
+		pos += sp.n
+	}
+	return []*memory.Buffer{offsetsBuf, dataBuf}
+}
+
+// listChildSelection returns the offsets buffer of the selection over list
+// arrays, and the selection of their child values.
+func listChildSelection(mem memory.Allocator, srcs []array.Interface, sel *selection) (*memory.Buffer, *selection) {
+	offsets := make([][]int32, len(srcs))
+	for i, src := range srcs {
+		offsets[i] = valueOffsets(src)
+	}
+
+	var (
+		offsetsBuf = newBuffer(mem, arrow.Int32Traits.BytesRequired(sel.n+1))
+		outOffsets = arrow.Int32Traits.CastFromBytes(offsetsBuf.Bytes())
+		childSel   = &selection{spans: make([]span, 0, len(sel.spans))}
+		pos        = 0
+		cur        = int32(0)
+	)
+	for _, sp := range sel.spans {
+		if sp.null {
+			for i := 0; i < sp.n; i++ {
+				outOffsets[pos+i+1] = cur
+			}
+			pos += sp.n
+			continue
+		}
+		off := offsets[sp.src]
+		beg, end := off[sp.pos], off[sp.pos+sp.n]
+		childSel.add(sp.src, int(beg), int(end-beg), false)
+		for i := 1; i <= sp.n; i++ {
+			outOffsets[pos+i] = cur + off[sp.pos+i] - beg
+		}
+		cur += end - beg
+		pos += sp.n
+	}
+	return offsetsBuf, childSel
+}
+
+// selectRecord applies sel to each column of rec.
+func selectRecord(mem memory.Allocator, rec array.Record, sel *selection) (array.Record, error) {
+	cols := make([]array.Interface, 0, rec.NumCols())
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for _, col := range rec.Columns() {
+		out, err := gather(mem, []array.Interface{col}, sel)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, out)
+	}
+	return array.NewRecord(rec.Schema(), cols, int64(sel.n)), nil
+}
+
+// selectTable applies sel, whose positions span the rows of the table, to
+// each column of tbl.
+func selectTable(mem memory.Allocator, tbl array.Table, sel *selection) (array.Table, error) {
+	cols := make([]array.Column, 0, tbl.NumCols())
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	for i := 0; i < int(tbl.NumCols()); i++ {
+		col := tbl.Column(i)
+		chunked, err := selectChunked(mem, col.Data(), sel)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, *array.NewColumn(col.Field(), chunked))
+		chunked.Release()
+	}
+	return array.NewTable(tbl.Schema(), cols, int64(sel.n)), nil
+}
+
+// selectChunked applies sel, whose positions span the values of the chunked
+// array, to arr and returns a chunked array made of a single chunk.
+func selectChunked(mem memory.Allocator, arr *array.Chunked, sel *selection) (*array.Chunked, error) {
+	chunks := arr.Chunks()
+	if len(chunks) == 0 {
+		// the selection may still hold nulls.
+		empty := makeNullArray(mem, arr.DataType(), 0)
+		defer empty.Release()
+		chunks = []array.Interface{empty}
+	}
+	out, err := gather(mem, chunks, sel.split(chunks))
+	if err != nil {
+		return nil, err
+	}
+	defer out.Release()
+	return array.NewChunked(arr.DataType(), []array.Interface{out}), nil
+}
+
+// selectDatum applies sel to values, an array, a chunked array, a record or
+// a table, and returns a datum of the same kind.
+func selectDatum(mem memory.Allocator, values Datum, sel *selection) (Datum, error) {
+	switch v := values.(type) {
+	case *ArrayDatum:
+		out, err := gather(mem, []array.Interface{v.Value}, sel)
+		if err != nil {
+			return nil, err
+		}
+		return &ArrayDatum{Value: out}, nil
+	case *ChunkedDatum:
+		out, err := selectChunked(mem, v.Value, sel)
+		if err != nil {
+			return nil, err
+		}
+		return &ChunkedDatum{Value: out}, nil
+	case *RecordDatum:
+		out, err := selectRecord(mem, v.Value, sel)
+		if err != nil {
+			return nil, err
+		}
+		return &RecordDatum{Value: out}, nil
+	case *TableDatum:
+		out, err := selectTable(mem, v.Value, sel)
+		if err != nil {
+			return nil, err
+		}
+		return &TableDatum{Value: out}, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: cannot select values of a %v datum: %w", values.Kind(), ErrInvalid)
+}
+
+// concatenate returns the values of chunks, of type dt, as a single array.
+func concatenate(mem memory.Allocator, dt arrow.DataType, chunks []array.Interface) (array.Interface, error) {
+	switch len(chunks) {
+	case 0:
+		return makeNullArray(mem, dt, 0), nil
+	case 1:
+		chunks[0].Retain()
+		return chunks[0], nil
+	}
+	var sel selection
+	for i, c := range chunks {
+		sel.add(i, 0, c.Len(), false)
+	}
+	return gather(mem, chunks, &sel)
+}
@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// SetLookupOptions controls the behavior of IsIn and IndexIn.
+type SetLookupOptions struct {
+	// SkipNulls makes the nulls of the input match nothing. Otherwise,
+	// nulls match the nulls of the value set.
+	SkipNulls bool
+}
+
+// IsIn returns a boolean datum of the shape of values, telling whether
+// each value is in valueSet, an array or chunked array. The value set is
+// cast to the type of values if needed. Floating point NaNs are all
+// considered equal.
+//
+// The result has no nulls: nulls of values are in the value set if it has
+// a null, unless opts.SkipNulls is set.
+//
+// The returned datum must be Release()'d after use.
+func IsIn(ctx context.Context, values, valueSet Datum, opts *SetLookupOptions) (Datum, error) {
+	return setLookup(ctx, "is_in", values, valueSet, opts, func(mem memory.Allocator, n int, indices []int32, _ []int32) array.Interface {
+		buf := newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+		for i, idx := range indices {
+			if idx >= 0 {
+				bitutil.SetBit(buf.Bytes(), i)
+			}
+		}
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{nil, buf}, nil, 0)
+	})
+}
+
+// IndexIn returns an Int32 datum of the shape of values, holding the
+// position in valueSet, an array or chunked array, of the first occurrence
+// of each value, or null if the value is not in the set. The value set is
+// cast to the type of values if needed. Floating point NaNs are all
+// considered equal.
+//
+// Nulls of values match the first null of the value set, unless
+// opts.SkipNulls is set.
+//
+// The returned datum must be Release()'d after use.
+func IndexIn(ctx context.Context, values, valueSet Datum, opts *SetLookupOptions) (Datum, error) {
+	return setLookup(ctx, "index_in", values, valueSet, opts, func(mem memory.Allocator, n int, indices []int32, positions []int32) array.Interface {
+		var (
+			buf      = newBuffer(mem, arrow.Int32Traits.BytesRequired(n))
+			out      = arrow.Int32Traits.CastFromBytes(buf.Bytes())
+			validity = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+			nulls    = 0
+		)
+		for i, idx := range indices {
+			if idx < 0 {
+				nulls++
+				continue
+			}
+			out[i] = positions[idx]
+			bitutil.SetBit(validity.Bytes(), i)
+		}
+		if nulls == 0 {
+			validity.Release()
+			validity = nil
+		}
+		return makeArray(arrow.PrimitiveTypes.Int32, n, []*memory.Buffer{validity, buf}, nil, nulls)
+	})
+}
+
+// setLookupKernel builds the result of a set lookup over n values, from
+// the index of each value in the memo table of the value set, or -1, and
+// the position in the value set of each value of the table.
+type setLookupKernel func(mem memory.Allocator, n int, indices []int32, positions []int32) array.Interface
+
+func setLookup(ctx context.Context, name string, values, valueSet Datum, opts *SetLookupOptions, kernel setLookupKernel) (Datum, error) {
+	if opts == nil {
+		opts = &SetLookupOptions{}
+	}
+	mem := GetAllocator(ctx)
+	dt := values.DataType()
+	if dict, ok := dt.(*arrow.DictionaryType); ok {
+		dt = dict.ValueType
+	}
+	memo, positions, err := newValueSet(mem, name, valueSet, dt, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []int32
+	return execUnary(mem, values, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		if dict, ok := arr.(*array.Dictionary); ok {
+			decoded, err := decodeDictionary(mem, dict)
+			if err != nil {
+				return nil, err
+			}
+			defer decoded.Release()
+			arr = decoded
+		}
+		if cap(indices) < arr.Len() {
+			indices = make([]int32, arr.Len())
+		}
+		indices = indices[:arr.Len()]
+		memo.lookup(arr, indices)
+		return kernel(mem, arr.Len(), indices, positions), nil
+	})
+}
+
+// newValueSet returns the memo table of the values of valueSet, cast to
+// dt, and the position in valueSet of the first occurrence of each value
+// of the table.
+func newValueSet(mem memory.Allocator, name string, valueSet Datum, dt arrow.DataType, opts *SetLookupOptions) (memoTable, []int32, error) {
+	if valueSet.Kind() != KindArray && valueSet.Kind() != KindChunked {
+		return nil, nil, xerrors.Errorf("arrow/compute: %s: value set must be an array or a chunked array, got %v: %w", name, valueSet.Kind(), ErrInvalid)
+	}
+	memo, err := newMemoTable(dt, !opts.SkipNulls)
+	if err != nil {
+		return nil, nil, err
+	}
+	chunks, err := datumChunks(mem, valueSet)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer releaseArrays(chunks)
+
+	var (
+		positions []int32
+		indices   []int32
+		offset    int32
+	)
+	for _, c := range chunks {
+		if !arrow.TypeEqual(c.DataType(), dt) {
+			casted, err := castArray(mem, c, dt, SafeCastOptions())
+			if err != nil {
+				return nil, nil, err
+			}
+			defer casted.Release()
+			c = casted
+		}
+		if cap(indices) < c.Len() {
+			indices = make([]int32, c.Len())
+		}
+		indices = indices[:c.Len()]
+		memo.insert(c, indices)
+		for i, idx := range indices {
+			// indices are assigned in order, new values get the next one.
+			if int(idx) == len(positions) {
+				positions = append(positions, offset+int32(i))
+			}
+		}
+		offset += int32(c.Len())
+	}
+	return memo, positions, nil
+}
@@ -0,0 +1,392 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+func TestSetLookup(t *testing.T) {
+	var (
+		nan  = math.NaN()
+		skip = &compute.SetLookupOptions{SkipNulls: true}
+		ts   = &arrow.TimestampType{Unit: arrow.Millisecond}
+		dec2 = &arrow.Decimal128Type{Precision: 10, Scale: 2}
+	)
+
+	for _, tc := range []struct {
+		name      string
+		dt        arrow.DataType
+		values    interface{}
+		valid     []bool
+		setDT     arrow.DataType // defaults to dt
+		set       []interface{}
+		setValids [][]bool
+		opts      *compute.SetLookupOptions
+		isIn      []bool
+		index     []int32
+		idxValid  []bool
+	}{
+		{
+			name:   "int32",
+			dt:     arrow.PrimitiveTypes.Int32,
+			values: []int32{1, 2, 3, 4, 2},
+			set:    []interface{}{[]int32{4, 2, 4}},
+			isIn:   []bool{false, true, false, true, true},
+			index:  []int32{0, 1, 0, 0, 1}, idxValid: []bool{false, true, false, true, true},
+		},
+		{
+			name:      "nulls-match",
+			dt:        arrow.PrimitiveTypes.Int64,
+			values:    []int64{1, 0, 2},
+			valid:     []bool{true, false, true},
+			set:       []interface{}{[]int64{2, 0}, []int64{0}},
+			setValids: [][]bool{nil, {false}},
+			isIn:      []bool{false, true, true},
+			index:     []int32{0, 2, 0}, idxValid: []bool{false, true, true},
+		},
+		{
+			name:      "nulls-skipped",
+			dt:        arrow.PrimitiveTypes.Int64,
+			values:    []int64{1, 0, 2},
+			valid:     []bool{true, false, true},
+			set:       []interface{}{[]int64{2, 0}},
+			setValids: [][]bool{{true, false}},
+			opts:      skip,
+			isIn:      []bool{false, false, true},
+			index:     []int32{0, 0, 0}, idxValid: []bool{false, false, true},
+		},
+		{
+			name:   "nulls-not-in-set",
+			dt:     arrow.PrimitiveTypes.Uint8,
+			values: []uint8{1, 0},
+			valid:  []bool{true, false},
+			set:    []interface{}{[]uint8{1}},
+			isIn:   []bool{true, false},
+			index:  []int32{0, 0}, idxValid: []bool{true, false},
+		},
+		{
+			name:   "float-nan",
+			dt:     arrow.PrimitiveTypes.Float64,
+			values: []float64{nan, 1, 2, math.Inf(1)},
+			set:    []interface{}{[]float64{2, nan}},
+			isIn:   []bool{true, false, true, false},
+			index:  []int32{1, 0, 0, 0}, idxValid: []bool{true, false, true, false},
+		},
+		{
+			name:   "string-chunked",
+			dt:     arrow.BinaryTypes.String,
+			values: []string{"a", "", "b", "z"},
+			set:    []interface{}{[]string{"b"}, []string{}, []string{"", "a", "b"}},
+			isIn:   []bool{true, true, true, false},
+			index:  []int32{2, 1, 0, 0}, idxValid: []bool{true, true, true, false},
+		},
+		{
+			name:   "binary",
+			dt:     arrow.BinaryTypes.Binary,
+			values: [][]byte{[]byte("x"), []byte("y")},
+			set:    []interface{}{[][]byte{[]byte("y")}},
+			isIn:   []bool{false, true},
+			index:  []int32{0, 0}, idxValid: []bool{false, true},
+		},
+		{
+			name:   "timestamp",
+			dt:     ts,
+			values: []arrow.Timestamp{1, 2},
+			set:    []interface{}{[]arrow.Timestamp{2, 3}},
+			isIn:   []bool{false, true},
+			index:  []int32{0, 0}, idxValid: []bool{false, true},
+		},
+		{
+			name:   "decimal",
+			dt:     dec2,
+			values: []decimal128.Num{dec(1), dec(-1)},
+			set:    []interface{}{[]decimal128.Num{dec(-1)}},
+			isIn:   []bool{false, true},
+			index:  []int32{0, 0}, idxValid: []bool{false, true},
+		},
+		{
+			name:   "decimal256",
+			dt:     &arrow.Decimal256Type{Precision: 70, Scale: 2},
+			values: []decimal256.Num{dec256("1"), dec256("1" + strings.Repeat("0", 60)), dec256("-1")},
+			set:    []interface{}{[]decimal256.Num{dec256("-1"), dec256("1" + strings.Repeat("0", 60))}},
+			isIn:   []bool{false, true, true},
+			index:  []int32{0, 1, 0}, idxValid: []bool{false, true, true},
+		},
+		{
+			name:   "bool",
+			dt:     arrow.FixedWidthTypes.Boolean,
+			values: []bool{true, false},
+			set:    []interface{}{[]bool{false, false}},
+			isIn:   []bool{false, true},
+			index:  []int32{0, 0}, idxValid: []bool{false, true},
+		},
+		{
+			name:   "cast-set",
+			dt:     arrow.PrimitiveTypes.Int32,
+			values: []int32{1, 2},
+			setDT:  arrow.PrimitiveTypes.Int8,
+			set:    []interface{}{[]int8{3, 1}},
+			isIn:   []bool{true, false},
+			index:  []int32{1, 0}, idxValid: []bool{true, false},
+		},
+		{
+			name:   "empty-set",
+			dt:     arrow.PrimitiveTypes.Int32,
+			values: []int32{1},
+			set:    []interface{}{},
+			isIn:   []bool{false},
+			index:  []int32{0}, idxValid: []bool{false},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			setDT := tc.setDT
+			if setDT == nil {
+				setDT = tc.dt
+			}
+			chunks := make([]array.Interface, len(tc.set))
+			for i, c := range tc.set {
+				var valid []bool
+				if tc.setValids != nil {
+					valid = tc.setValids[i]
+				}
+				chunks[i] = arrayOf(mem, setDT, c, valid)
+				defer chunks[i].Release()
+			}
+			chunked := array.NewChunked(setDT, chunks)
+			defer chunked.Release()
+			set := compute.NewDatum(chunked)
+			defer set.Release()
+
+			values := datumOf(mem, tc.dt, tc.values, tc.valid, nil)
+			defer values.Release()
+
+			isIn, err := compute.IsIn(ctx, values, set, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer isIn.Release()
+			want := arrayOf(mem, arrow.FixedWidthTypes.Boolean, tc.isIn, nil)
+			defer want.Release()
+			assertArrayEqual(t, want, isIn.(*compute.ArrayDatum).Value)
+
+			index, err := compute.IndexIn(ctx, values, set, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer index.Release()
+			wantIndex := arrayOf(mem, arrow.PrimitiveTypes.Int32, tc.index, tc.idxValid)
+			defer wantIndex.Release()
+			assertArrayEqual(t, wantIndex, index.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestSetLookupDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values := datumOf(mem, arrow.BinaryTypes.String, []string{"a", "b", "a", "c"}, nil, nil)
+	defer values.Release()
+	encoded, err := compute.DictionaryEncode(ctx, values, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoded.Release()
+	set := datumOf(mem, arrow.BinaryTypes.String, []string{"c", "a"}, nil, nil)
+	defer set.Release()
+
+	// a dictionary-encoded value set is decoded as well.
+	for _, tc := range []struct {
+		values, set compute.Datum
+		want        []int32
+		valid       []bool
+	}{
+		{encoded, set, []int32{1, 0, 1, 0}, []bool{true, false, true, true}},
+		{values, encoded, []int32{0, 1, 0, 3}, nil},
+	} {
+		got, err := compute.IndexIn(ctx, tc.values, tc.set, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := arrayOf(mem, arrow.PrimitiveTypes.Int32, tc.want, tc.valid)
+		assertArrayEqual(t, want, got.(*compute.ArrayDatum).Value)
+		want.Release()
+		got.Release()
+	}
+}
+
+func TestSetLookupScalar(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	set := datumOf(mem, arrow.PrimitiveTypes.Int64, []int64{5, 7}, nil, nil)
+	defer set.Release()
+
+	got, err := compute.IndexIn(ctx, compute.NewDatum(scalar.NewInt64Scalar(7)), set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	if v := got.(*compute.ScalarDatum).Value.(*scalar.Int32); !v.Valid || v.Value != 1 {
+		t.Fatalf("got=%v, want=1", v)
+	}
+}
+
+func TestSetLookupErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values := datumOf(mem, arrow.PrimitiveTypes.Int8, []int8{1}, nil, nil)
+	defer values.Release()
+
+	_, err := compute.IsIn(ctx, values, compute.NewDatum(scalar.NewInt8Scalar(1)), nil)
+	if !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("scalar value set: got err=%v, want ErrInvalid", err)
+	}
+
+	// the value set does not fit the type of the values.
+	set := datumOf(mem, arrow.PrimitiveTypes.Int64, []int64{1000}, nil, nil)
+	defer set.Release()
+	if _, err := compute.IsIn(ctx, values, set, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("overflowing value set: got err=%v, want ErrInvalid", err)
+	}
+
+	nullArr := array.NewNull(1)
+	defer nullArr.Release()
+	nulls := compute.NewDatum(nullArr)
+	defer nulls.Release()
+	if _, err := compute.IndexIn(ctx, nulls, nulls, nil); !xerrors.Is(err, compute.ErrNotImplemented) {
+		t.Fatalf("null values: got err=%v, want ErrNotImplemented", err)
+	}
+}
+
+// lookupValues returns n random probe values and a set of setSize values,
+// half of the probe values being in the set.
+func lookupValues(n, setSize int) (values, set []int64) {
+	rng := rand.New(rand.NewSource(0))
+	set = make([]int64, setSize)
+	for i := range set {
+		set[i] = rng.Int63()
+	}
+	values = make([]int64, n)
+	for i := range values {
+		if i%2 == 0 {
+			values[i] = set[rng.Intn(setSize)]
+		} else {
+			values[i] = rng.Int63()
+		}
+	}
+	return values, set
+}
+
+func TestIsInLarge(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values, set := lookupValues(1000000, 100000)
+	index := make(map[int64]int32, len(set))
+	for i := len(set) - 1; i >= 0; i-- {
+		index[set[i]] = int32(i)
+	}
+
+	valuesDatum := datumOf(mem, arrow.PrimitiveTypes.Int64, values, nil, nil)
+	defer valuesDatum.Release()
+	setDatum := datumOf(mem, arrow.PrimitiveTypes.Int64, set, nil, nil)
+	defer setDatum.Release()
+
+	isIn, err := compute.IsIn(ctx, valuesDatum, setDatum, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer isIn.Release()
+	indexIn, err := compute.IndexIn(ctx, valuesDatum, setDatum, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer indexIn.Release()
+
+	var (
+		gotIsIn  = isIn.(*compute.ArrayDatum).Value.(*array.Boolean)
+		gotIndex = indexIn.(*compute.ArrayDatum).Value.(*array.Int32)
+	)
+	for i, v := range values {
+		idx, ok := index[v]
+		if gotIsIn.Value(i) != ok || gotIndex.IsValid(i) != ok || (ok && gotIndex.Value(i) != idx) {
+			t.Fatalf("row %d: got=(%v, %v), want=(%v, %d)", i, gotIsIn.Value(i), gotIndex.Value(i), ok, idx)
+		}
+	}
+}
+
+func BenchmarkIsIn(b *testing.B) {
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	values, set := lookupValues(1000000, 100000)
+	valuesDatum := datumOf(mem, arrow.PrimitiveTypes.Int64, values, nil, nil)
+	defer valuesDatum.Release()
+	setDatum := datumOf(mem, arrow.PrimitiveTypes.Int64, set, nil, nil)
+	defer setDatum.Release()
+
+	b.Run("kernel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			out, err := compute.IsIn(ctx, valuesDatum, setDatum, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		arr := valuesDatum.(*compute.ArrayDatum).Value.(*array.Int64)
+		for i := 0; i < b.N; i++ {
+			index := make(map[int64]struct{}, len(set))
+			for _, v := range set {
+				index[v] = struct{}{}
+			}
+			bldr := array.NewBooleanBuilder(mem)
+			bldr.Reserve(arr.Len())
+			for _, v := range arr.Int64Values() {
+				_, ok := index[v]
+				bldr.UnsafeAppend(ok)
+			}
+			bldr.NewArray().Release()
+			bldr.Release()
+		}
+	})
+}
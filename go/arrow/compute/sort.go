@@ -0,0 +1,448 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// SortOrder is the order of the values of a sort key.
+type SortOrder int8
+
+const (
+	Ascending SortOrder = iota
+	Descending
+)
+
+// NullPlacement is the position of null values in sorted results.
+type NullPlacement int8
+
+const (
+	NullsAtEnd NullPlacement = iota
+	NullsAtStart
+)
+
+// SortKey describes a key of a sort.
+type SortKey struct {
+	// Name is the name of the column to sort by. It is ignored when
+	// sorting arrays.
+	Name          string
+	Order         SortOrder
+	NullPlacement NullPlacement
+}
+
+// SortOptions controls the behavior of the sort functions.
+type SortOptions struct {
+	// Keys are the sort keys, by order of precedence. Arrays are sorted in
+	// ascending order with nulls at the end if no key is given.
+	Keys []SortKey
+	// ChunkSize is the number of rows of the chunks of the tables returned
+	// by SortTable, which are made of a single chunk if ChunkSize is 0.
+	ChunkSize int
+}
+
+// SortIndices returns the indices that would sort an array, a chunked
+// array, a record or a table, as an Uint64 array. Records and tables are
+// sorted by the columns named by the sort keys.
+//
+// The sort is stable: rows with equal keys keep their relative order.
+// Numeric, temporal, decimal, boolean, string and binary keys are
+// supported; strings and binary values are compared byte-wise. NaNs are
+// sorted after all the other values and before nulls, or after nulls when
+// they are placed at the start, whatever the order of the key.
+//
+// The returned array must be Release()'d after use.
+func SortIndices(ctx context.Context, input Datum, opts *SortOptions) (array.Interface, error) {
+	if opts == nil {
+		opts = &SortOptions{}
+	}
+	mem := GetAllocator(ctx)
+
+	cols, err := sortColumns(mem, input, opts.Keys)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, c := range cols {
+			c.arr.Release()
+		}
+	}()
+	return sortIndices(mem, cols, int(input.Len())), nil
+}
+
+// SortRecord returns a new record made of the rows of rec sorted by the
+// given keys. See SortIndices for the sort semantics.
+//
+// The returned record must be Release()'d after use.
+func SortRecord(ctx context.Context, rec array.Record, opts *SortOptions) (array.Record, error) {
+	input := NewDatum(rec)
+	defer input.Release()
+	indices, err := SortIndices(ctx, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer indices.Release()
+	return TakeRecord(ctx, rec, indices, &TakeOptions{})
+}
+
+// SortTable returns a new table made of the rows of tbl sorted by the given
+// keys, whose columns are split in chunks of opts.ChunkSize rows. The keys
+// are sorted over the logical rows of tbl, across its chunks. See
+// SortIndices for the sort semantics.
+//
+// The returned table must be Release()'d after use.
+func SortTable(ctx context.Context, tbl array.Table, opts *SortOptions) (array.Table, error) {
+	input := NewDatum(tbl)
+	defer input.Release()
+	indices, err := SortIndices(ctx, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer indices.Release()
+	sorted, err := TakeTable(ctx, tbl, indices, &TakeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil || opts.ChunkSize <= 0 || sorted.NumRows() <= int64(opts.ChunkSize) {
+		return sorted, nil
+	}
+	defer sorted.Release()
+	return rechunkTable(sorted, int64(opts.ChunkSize)), nil
+}
+
+// rechunkTable returns a table sharing the values of tbl, whose columns are
+// split in chunks of size rows.
+func rechunkTable(tbl array.Table, size int64) array.Table {
+	cols := make([]array.Column, tbl.NumCols())
+	for i := range cols {
+		col := tbl.Column(i)
+		var chunks []array.Interface
+		for beg := int64(0); beg < tbl.NumRows(); beg += size {
+			end := beg + size
+			if end > tbl.NumRows() {
+				end = tbl.NumRows()
+			}
+			slice := col.Data().NewSlice(beg, end)
+			for _, c := range slice.Chunks() {
+				c.Retain()
+				chunks = append(chunks, c)
+			}
+			slice.Release()
+		}
+		chunked := array.NewChunked(col.DataType(), chunks)
+		releaseArrays(chunks)
+		cols[i] = *array.NewColumn(col.Field(), chunked)
+		chunked.Release()
+	}
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	return array.NewTable(tbl.Schema(), cols, tbl.NumRows())
+}
+
+// the classes of values, in their order when nulls are placed at the end.
+const (
+	classValue = iota
+	classNaN
+	classNull
+)
+
+// sortColumn is an array of the values of a sort key.
+type sortColumn struct {
+	arr     array.Interface
+	key     SortKey
+	numeric bool               // whether arr is a numeric array
+	cmp     func(i, j int) int // three-way comparison of valid values
+	isNaN   func(i int) bool   // nil if the values cannot be NaN
+}
+
+func (c *sortColumn) class(i int) int {
+	switch {
+	case c.arr.IsNull(i):
+		return classNull
+	case c.isNaN != nil && c.isNaN(i):
+		return classNaN
+	}
+	return classValue
+}
+
+// sortColumns returns the columns of input for each key.
+func sortColumns(mem memory.Allocator, input Datum, keys []SortKey) ([]*sortColumn, error) {
+	var (
+		cols    []*sortColumn
+		col     array.Interface
+		err     error
+		release = func() {
+			for _, c := range cols {
+				c.arr.Release()
+			}
+		}
+	)
+
+	switch d := input.(type) {
+	case *ArrayDatum, *ChunkedDatum:
+		if len(keys) > 1 {
+			return nil, xerrors.Errorf("arrow/compute: arrays can only be sorted by one key, got %d: %w", len(keys), ErrInvalid)
+		}
+		key := SortKey{}
+		if len(keys) == 1 {
+			key = keys[0]
+		}
+		if a, ok := d.(*ArrayDatum); ok {
+			a.Value.Retain()
+			col = a.Value
+		} else {
+			chunked := d.(*ChunkedDatum).Value
+			if col, err = concatenate(mem, chunked.DataType(), chunked.Chunks()); err != nil {
+				return nil, err
+			}
+		}
+		c, err := newSortColumn(mem, col, key)
+		if err != nil {
+			return nil, err
+		}
+		return []*sortColumn{c}, nil
+
+	case *RecordDatum, *TableDatum:
+		if len(keys) == 0 {
+			return nil, xerrors.Errorf("arrow/compute: no sort key: %w", ErrInvalid)
+		}
+		schema := tabularSchema(d)
+		for _, key := range keys {
+			idx := schema.FieldIndices(key.Name)
+			if len(idx) == 0 {
+				release()
+				return nil, xerrors.Errorf("arrow/compute: no column named %q: %w", key.Name, ErrInvalid)
+			}
+			if r, ok := d.(*RecordDatum); ok {
+				col = r.Value.Column(idx[0])
+				col.Retain()
+			} else {
+				chunked := d.(*TableDatum).Value.Column(idx[0]).Data()
+				if col, err = concatenate(mem, chunked.DataType(), chunked.Chunks()); err != nil {
+					release()
+					return nil, err
+				}
+			}
+			c, err := newSortColumn(mem, col, key)
+			if err != nil {
+				release()
+				return nil, err
+			}
+			cols = append(cols, c)
+		}
+		return cols, nil
+	}
+	return nil, xerrors.Errorf("arrow/compute: cannot sort a %v: %w", input.Kind(), ErrNotImplemented)
+}
+
+func tabularSchema(d Datum) *arrow.Schema {
+	if r, ok := d.(*RecordDatum); ok {
+		return r.Value.Schema()
+	}
+	return d.(*TableDatum).Value.Schema()
+}
+
+// newSortColumn returns the sort column of arr, which it takes ownership
+// of.
+func newSortColumn(mem memory.Allocator, arr array.Interface, key SortKey) (*sortColumn, error) {
+	c := &sortColumn{arr: arr, key: key}
+	switch id := arr.DataType().ID(); {
+	case isInteger(id) || isFloating(id):
+		c.numeric = true
+		c.cmp, c.isNaN = numericComparator(arr)
+	case isTemporal(id):
+		c.arr = reinterpret(arr, storageType(arr.DataType()))
+		arr.Release()
+		c.numeric = true
+		c.cmp, _ = numericComparator(c.arr)
+	case id == arrow.DECIMAL:
+		vals := arr.(*array.Decimal128).Values()
+		c.cmp = func(i, j int) int {
+			switch {
+			case decimalLess(vals[i], vals[j]):
+				return -1
+			case decimalLess(vals[j], vals[i]):
+				return 1
+			}
+			return 0
+		}
+	case id == arrow.DECIMAL256:
+		vals := arr.(*array.Decimal256).Values()
+		c.cmp = func(i, j int) int { return vals[i].Cmp(vals[j]) }
+	case isBinaryLike(id):
+		offsets, data := binaryValues(arr)
+		c.cmp = func(i, j int) int {
+			return bytes.Compare(data[offsets[i]:offsets[i+1]], data[offsets[j]:offsets[j+1]])
+		}
+	case id == arrow.DICTIONARY:
+		// dictionary-encoded values are sorted by their decoded value.
+		values, err := decodeDictionary(mem, arr.(*array.Dictionary))
+		arr.Release()
+		if err != nil {
+			return nil, err
+		}
+		return newSortColumn(mem, values, key)
+	case id == arrow.BOOL:
+		a := arr.(*array.Boolean)
+		c.cmp = func(i, j int) int {
+			switch vi, vj := a.Value(i), a.Value(j); {
+			case vi == vj:
+				return 0
+			case vj:
+				return -1
+			}
+			return 1
+		}
+	default:
+		arr.Release()
+		return nil, xerrors.Errorf("arrow/compute: sorting is not implemented for %v: %w", arr.DataType(), ErrNotImplemented)
+	}
+	return c, nil
+}
+
+// compareRows compares the rows i and j over all the sort keys.
+func compareRows(cols []*sortColumn, i, j int) int {
+	for _, c := range cols {
+		ci, cj := c.class(i), c.class(j)
+		switch {
+		case ci != cj && c.key.NullPlacement == NullsAtStart:
+			return cj - ci
+		case ci != cj:
+			return ci - cj
+		case ci != classValue:
+			continue
+		}
+		v := c.cmp(i, j)
+		if c.key.Order == Descending {
+			v = -v
+		}
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// sortIndices returns the indices sorting the n rows of cols.
+func sortIndices(mem memory.Allocator, cols []*sortColumn, n int) array.Interface {
+	buf := newBuffer(mem, arrow.Uint64Traits.BytesRequired(n))
+	out := arrow.Uint64Traits.CastFromBytes(buf.Bytes())
+
+	// rows are partitioned by the class of their first key, which places
+	// nulls and NaNs without comparing them.
+	var (
+		first               = cols[0]
+		values, nans, nulls []uint64
+	)
+	values = make([]uint64, 0, n-first.arr.NullN())
+	for i := 0; i < n; i++ {
+		switch first.class(i) {
+		case classValue:
+			values = append(values, uint64(i))
+		case classNaN:
+			nans = append(nans, uint64(i))
+		default:
+			nulls = append(nulls, uint64(i))
+		}
+	}
+
+	sortRows := func(rows []uint64) {
+		sort.Slice(rows, func(a, b int) bool {
+			c := compareRows(cols, int(rows[a]), int(rows[b]))
+			return c < 0 || (c == 0 && rows[a] < rows[b])
+		})
+	}
+	if len(cols) == 1 && first.numeric {
+		sortNumeric(first.arr, values, first.key.Order == Descending)
+	} else {
+		sortRows(values)
+	}
+	if len(cols) > 1 {
+		sortRows(nans)
+		sortRows(nulls)
+	}
+
+	parts := [][]uint64{values, nans, nulls}
+	if first.key.NullPlacement == NullsAtStart {
+		parts = [][]uint64{nulls, nans, values}
+	}
+	pos := 0
+	for _, p := range parts {
+		pos += copy(out[pos:], p)
+	}
+	return makeArray(arrow.PrimitiveTypes.Uint64, n, []*memory.Buffer{nil, buf}, nil, 0)
+}
+
+// radixSortThreshold is the number of values from which integers are radix
+// sorted rather than compared.
+const radixSortThreshold = 256
+
+// radixSort sorts indices by their keys, with a stable least significant
+// digit radix sort over the bytes of the keys, skipping the bytes which
+// are equal for all the keys.
+func radixSort(indices, keys []uint64) {
+	if len(keys) == 0 {
+		return
+	}
+	var diff uint64
+	for _, k := range keys {
+		diff |= k ^ keys[0]
+	}
+
+	var (
+		out     = indices
+		tmpIdx  = make([]uint64, len(indices))
+		tmpKeys = make([]uint64, len(keys))
+		counts  [256]int
+		swapped bool
+	)
+	for shift := uint(0); shift < 64; shift += 8 {
+		if (diff>>shift)&0xff == 0 {
+			continue
+		}
+		counts = [256]int{}
+		for _, k := range keys {
+			counts[(k>>shift)&0xff]++
+		}
+		pos := 0
+		for b, c := range counts {
+			counts[b] = pos
+			pos += c
+		}
+		for j, k := range keys {
+			b := (k >> shift) & 0xff
+			tmpIdx[counts[b]], tmpKeys[counts[b]] = indices[j], k
+			counts[b]++
+		}
+		indices, tmpIdx = tmpIdx, indices
+		keys, tmpKeys = tmpKeys, keys
+		swapped = !swapped
+	}
+	if swapped {
+		copy(out, indices)
+	}
+}
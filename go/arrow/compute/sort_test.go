@@ -0,0 +1,564 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestSortIndicesArray(t *testing.T) {
+	var (
+		nan   = math.NaN()
+		desc  = compute.SortKey{Order: compute.Descending}
+		first = compute.SortKey{NullPlacement: compute.NullsAtStart}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		dt     arrow.DataType
+		values interface{}
+		valid  []bool
+		key    *compute.SortKey
+		want   []uint64
+	}{
+		{
+			name: "int32", dt: arrow.PrimitiveTypes.Int32,
+			values: []int32{3, -1, 2, -1, 0},
+			want:   []uint64{1, 3, 4, 2, 0},
+		},
+		{
+			name: "int32-desc", dt: arrow.PrimitiveTypes.Int32,
+			values: []int32{3, -1, 2, -1, 0}, key: &desc,
+			want: []uint64{0, 2, 4, 1, 3},
+		},
+		{
+			name: "uint8-nulls", dt: arrow.PrimitiveTypes.Uint8,
+			values: []uint8{5, 0, 1, 0}, valid: []bool{true, false, true, false},
+			want: []uint64{2, 0, 1, 3},
+		},
+		{
+			name: "uint8-nulls-first", dt: arrow.PrimitiveTypes.Uint8,
+			values: []uint8{5, 0, 1, 0}, valid: []bool{true, false, true, false}, key: &first,
+			want: []uint64{1, 3, 2, 0},
+		},
+		{
+			name: "float-nan", dt: arrow.PrimitiveTypes.Float64,
+			values: []float64{nan, 1, 0, nan, -1}, valid: []bool{true, true, false, true, true},
+			want: []uint64{4, 1, 0, 3, 2},
+		},
+		{
+			name: "float-nan-desc", dt: arrow.PrimitiveTypes.Float64,
+			values: []float64{nan, 1, 0, nan, -1}, valid: []bool{true, true, false, true, true}, key: &desc,
+			want: []uint64{1, 4, 0, 3, 2},
+		},
+		{
+			name: "float-nan-first", dt: arrow.PrimitiveTypes.Float32,
+			values: []float32{float32(nan), 1, 0, -1}, valid: []bool{true, true, false, true}, key: &first,
+			want: []uint64{2, 0, 3, 1},
+		},
+		{
+			name: "string", dt: arrow.BinaryTypes.String,
+			values: []string{"b", "", "ab", "a", "b"},
+			want:   []uint64{1, 3, 2, 0, 4},
+		},
+		{
+			name: "string-desc", dt: arrow.BinaryTypes.String,
+			values: []string{"b", "", "ab", "a", "b"}, key: &desc,
+			want: []uint64{0, 4, 2, 3, 1},
+		},
+		{
+			name: "timestamp", dt: &arrow.TimestampType{Unit: arrow.Second},
+			values: []arrow.Timestamp{30, 10, 20},
+			want:   []uint64{1, 2, 0},
+		},
+		{
+			name: "decimal", dt: &arrow.Decimal128Type{Precision: 20, Scale: 2},
+			values: []decimal128.Num{decimal128.New(1, 0), dec(-5), dec(7)},
+			want:   []uint64{1, 2, 0},
+		},
+		{
+			name: "decimal256", dt: &arrow.Decimal256Type{Precision: 60, Scale: 2},
+			values: []decimal256.Num{dec256("1" + strings.Repeat("0", 50)), dec256("-5"), dec256("7"), dec256("-1" + strings.Repeat("0", 50))},
+			valid:  []bool{true, true, false, true}, key: &desc,
+			want: []uint64{0, 1, 3, 2},
+		},
+		{
+			name: "bool", dt: arrow.FixedWidthTypes.Boolean,
+			values: []bool{true, false, true, false},
+			want:   []uint64{1, 3, 0, 2},
+		},
+		{
+			name: "empty", dt: arrow.PrimitiveTypes.Int64,
+			values: []int64{},
+			want:   []uint64{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			input := datumOf(mem, tc.dt, tc.values, tc.valid, nil)
+			defer input.Release()
+
+			opts := &compute.SortOptions{}
+			if tc.key != nil {
+				opts.Keys = []compute.SortKey{*tc.key}
+			}
+			got, err := compute.SortIndices(ctx, input, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			assertIndices(t, tc.want, got)
+		})
+	}
+}
+
+func assertIndices(t *testing.T, want []uint64, got array.Interface) {
+	t.Helper()
+	idx := got.(*array.Uint64).Uint64Values()
+	if len(idx) != len(want) || (len(want) > 0 && !reflect.DeepEqual(idx, want)) {
+		t.Fatalf("invalid indices: got=%v, want=%v", idx, want)
+	}
+}
+
+func TestSortIndicesChunked(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	c1 := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{4, 1}, nil)
+	defer c1.Release()
+	c2 := arrayOf(mem, arrow.PrimitiveTypes.Int16, []int16{3, 1, 0}, []bool{true, true, false})
+	defer c2.Release()
+	chunked := array.NewChunked(arrow.PrimitiveTypes.Int16, []array.Interface{c1, c2})
+	defer chunked.Release()
+	input := compute.NewDatum(chunked)
+	defer input.Release()
+
+	got, err := compute.SortIndices(ctx, input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	// equal values keep their relative order across chunks.
+	assertIndices(t, []uint64{1, 3, 2, 0, 4}, got)
+}
+
+func TestSortRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k1", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "k2", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "row", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	k1 := arrayOf(mem, arrow.BinaryTypes.String,
+		[]string{"b", "a", "b", "", "a", "b", "a"},
+		[]bool{true, true, true, false, true, true, true})
+	defer k1.Release()
+	k2 := arrayOf(mem, arrow.PrimitiveTypes.Int64,
+		[]int64{1, 2, 1, 5, 0, 2, 2},
+		[]bool{true, true, true, true, false, true, true})
+	defer k2.Release()
+	row := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{0, 1, 2, 3, 4, 5, 6}, nil)
+	defer row.Release()
+	rec := array.NewRecord(schema, []array.Interface{k1, k2, row}, 7)
+	defer rec.Release()
+
+	opts := &compute.SortOptions{Keys: []compute.SortKey{
+		{Name: "k1"},
+		{Name: "k2", Order: compute.Descending, NullPlacement: compute.NullsAtStart},
+	}}
+
+	// duplicate keys (rows 1 and 6, 0 and 2) keep their relative order.
+	want := []int64{4, 1, 6, 5, 0, 2, 3}
+
+	got, err := compute.SortRecord(ctx, rec, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	if rows := got.Column(2).(*array.Int64).Int64Values(); !reflect.DeepEqual(rows, want) {
+		t.Fatalf("invalid rows: got=%v, want=%v", rows, want)
+	}
+
+	r1, r2 := rec.NewSlice(0, 3), rec.NewSlice(3, 7)
+	defer r1.Release()
+	defer r2.Release()
+	tbl := array.NewTableFromRecords(schema, []array.Record{r1, r2})
+	defer tbl.Release()
+	input := compute.NewDatum(tbl)
+	defer input.Release()
+
+	idx, err := compute.SortIndices(ctx, input, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Release()
+	wantIdx := make([]uint64, len(want))
+	for i, v := range want {
+		wantIdx[i] = uint64(v)
+	}
+	assertIndices(t, wantIdx, idx)
+
+	for _, keys := range [][]compute.SortKey{nil, {{Name: "missing"}}} {
+		if _, err := compute.SortRecord(ctx, rec, &compute.SortOptions{Keys: keys}); !xerrors.Is(err, compute.ErrInvalid) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, compute.ErrInvalid)
+		}
+	}
+}
+
+func TestSortTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	dictType := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	tsType := &arrow.TimestampType{Unit: arrow.Millisecond}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "dict", Type: dictType},
+		{Name: "ts", Type: tsType, Nullable: true},
+		{Name: "row", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+
+	// the dictionary is not sorted, so that the keys sort by value rather
+	// than by index.
+	indices := arrayOf(mem, arrow.PrimitiveTypes.Int8, []int8{2, 1, 0, 1, 2, 1, 2, 0}, nil)
+	defer indices.Release()
+	values := arrayOf(mem, arrow.BinaryTypes.String, []string{"c", "a", "b"}, nil)
+	defer values.Release()
+	dict := array.NewDictionaryArray(dictType, indices, values)
+	defer dict.Release()
+	ts := arrayOf(mem, tsType, []int64{10, 0, 5, 20, 10, 20, 30, 0}, []bool{true, false, true, true, true, true, true, false})
+	defer ts.Release()
+	row := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{0, 1, 2, 3, 4, 5, 6, 7}, nil)
+	defer row.Release()
+	rec := array.NewRecord(schema, []array.Interface{dict, ts, row}, 8)
+	defer rec.Release()
+
+	opts := &compute.SortOptions{Keys: []compute.SortKey{
+		{Name: "dict"},
+		{Name: "ts", Order: compute.Descending, NullPlacement: compute.NullsAtEnd},
+	}}
+	// rows 3 and 5, and 0 and 4, have equal keys.
+	want := []int64{3, 5, 1, 6, 0, 4, 2, 7}
+
+	sorted, err := compute.SortRecord(ctx, rec, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sorted.Release()
+	if rows := sorted.Column(2).(*array.Int64).Int64Values(); !reflect.DeepEqual(rows, want) {
+		t.Fatalf("invalid record rows: got=%v, want=%v", rows, want)
+	}
+
+	var recs []array.Record
+	for _, bounds := range [][2]int64{{0, 3}, {3, 4}, {4, 8}} {
+		r := rec.NewSlice(bounds[0], bounds[1])
+		defer r.Release()
+		recs = append(recs, r)
+	}
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	for _, tc := range []struct {
+		size   int
+		chunks []int
+	}{
+		{0, []int{8}},
+		{3, []int{3, 3, 2}},
+		{8, []int{8}},
+		{100, []int{8}},
+	} {
+		opts.ChunkSize = tc.size
+		got, err := compute.SortTable(ctx, tbl, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Schema().Equal(schema) || got.NumRows() != 8 {
+			t.Fatalf("invalid table: schema=%v, rows=%d", got.Schema(), got.NumRows())
+		}
+		for i := 0; i < int(got.NumCols()); i++ {
+			var lens []int
+			for _, c := range got.Column(i).Data().Chunks() {
+				lens = append(lens, c.Len())
+			}
+			if !reflect.DeepEqual(lens, tc.chunks) {
+				t.Fatalf("chunk size %d: invalid chunks of %q: got=%v, want=%v", tc.size, got.Column(i).Name(), lens, tc.chunks)
+			}
+		}
+		var rows []int64
+		for _, c := range got.Column(2).Data().Chunks() {
+			rows = append(rows, c.(*array.Int64).Int64Values()...)
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Fatalf("chunk size %d: invalid table rows: got=%v, want=%v", tc.size, rows, want)
+		}
+		got.Release()
+	}
+
+	if _, err := compute.SortTable(ctx, tbl, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, compute.ErrInvalid)
+	}
+}
+
+func TestSortIndicesStable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	const n = 10000
+	var (
+		rng   = rand.New(rand.NewSource(0))
+		vals  = make([]int32, n)
+		valid = make([]bool, n)
+	)
+	for i := range vals {
+		vals[i], valid[i] = int32(rng.Intn(10)), rng.Intn(10) != 0
+	}
+	input := datumOf(mem, arrow.PrimitiveTypes.Int32, vals, valid, nil)
+	defer input.Release()
+
+	for _, key := range []compute.SortKey{{}, {Order: compute.Descending, NullPlacement: compute.NullsAtStart}} {
+		got, err := compute.SortIndices(ctx, input, &compute.SortOptions{Keys: []compute.SortKey{key}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		idx := got.(*array.Uint64).Uint64Values()
+		for i := 1; i < n; i++ {
+			a, b := idx[i-1], idx[i]
+			if valid[a] != valid[b] || (valid[a] && vals[a] != vals[b]) {
+				continue
+			}
+			if a > b {
+				t.Fatalf("unstable sort at %d: %d before %d", i, a, b)
+			}
+		}
+		got.Release()
+	}
+}
+
+func TestSortIndicesIntegers(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	const n = 1000
+	rng := rand.New(rand.NewSource(0))
+	for _, tc := range []struct {
+		dt     arrow.DataType
+		random func() int64
+	}{
+		{arrow.PrimitiveTypes.Int8, func() int64 { return int64(int8(rng.Uint32())) }},
+		{arrow.PrimitiveTypes.Int64, func() int64 {
+			if rng.Intn(10) == 0 {
+				return []int64{math.MinInt64, math.MaxInt64, 0, -1}[rng.Intn(4)]
+			}
+			return rng.Int63n(1<<40) - 1<<39
+		}},
+		{arrow.PrimitiveTypes.Uint16, func() int64 { return int64(uint16(rng.Uint32())) }},
+		{arrow.PrimitiveTypes.Uint32, func() int64 { return rng.Int63n(10) }},
+	} {
+		// nulls at the start, in the middle and at the end.
+		vals := make([]int64, n)
+		valid := make([]bool, n)
+		for i := range vals {
+			vals[i] = tc.random()
+			valid[i] = i >= 10 && i < n-10 && rng.Intn(10) != 0
+		}
+		arr := arrayOf(mem, tc.dt, vals, valid)
+		input := compute.NewDatum(arr)
+		arr.Release()
+
+		for _, key := range []compute.SortKey{
+			{},
+			{Order: compute.Descending},
+			{NullPlacement: compute.NullsAtStart},
+			{Order: compute.Descending, NullPlacement: compute.NullsAtStart},
+		} {
+			want := make([]uint64, n)
+			for i := range want {
+				want[i] = uint64(i)
+			}
+			sort.SliceStable(want, func(a, b int) bool {
+				i, j := want[a], want[b]
+				if valid[i] != valid[j] {
+					return valid[i] != (key.NullPlacement == compute.NullsAtStart)
+				}
+				if !valid[i] {
+					return false
+				}
+				if key.Order == compute.Descending {
+					return vals[i] > vals[j]
+				}
+				return vals[i] < vals[j]
+			})
+
+			got, err := compute.SortIndices(ctx, input, &compute.SortOptions{Keys: []compute.SortKey{key}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if idx := got.(*array.Uint64).Uint64Values(); !reflect.DeepEqual(idx, want) {
+				t.Fatalf("%v %+v: invalid indices", tc.dt, key)
+			}
+			got.Release()
+		}
+		input.Release()
+	}
+}
+
+func BenchmarkSortIndices(b *testing.B) {
+	const n = 1 << 18
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	var (
+		rng  = rand.New(rand.NewSource(0))
+		ints = make([]int64, n)
+		strs = make([]string, n)
+	)
+	for i := range ints {
+		ints[i] = rng.Int63n(1000)
+		strs[i] = string(rune('a' + rng.Intn(26)))
+	}
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "s", Type: arrow.BinaryTypes.String},
+	}, nil)
+	icol := arrayOf(mem, arrow.PrimitiveTypes.Int64, ints, nil)
+	defer icol.Release()
+	scol := arrayOf(mem, arrow.BinaryTypes.String, strs, nil)
+	defer scol.Release()
+	rec := array.NewRecord(schema, []array.Interface{icol, scol}, n)
+	defer rec.Release()
+	input := compute.NewDatum(rec)
+	defer input.Release()
+
+	for _, bc := range []struct {
+		name string
+		keys []compute.SortKey
+	}{
+		{"single-numeric", []compute.SortKey{{Name: "i"}}},
+		{"multi-key", []compute.SortKey{{Name: "s"}, {Name: "i", Order: compute.Descending}}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			opts := &compute.SortOptions{Keys: bc.keys}
+			for i := 0; i < b.N; i++ {
+				idx, err := compute.SortIndices(ctx, input, opts)
+				if err != nil {
+					b.Fatal(err)
+				}
+				idx.Release()
+			}
+		})
+	}
+}
+
+func BenchmarkSortIndicesInt64(b *testing.B) {
+	const n = 10000000
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	rng := rand.New(rand.NewSource(0))
+	ints := make([]int64, n)
+	for i := range ints {
+		ints[i] = rng.Int63() - math.MaxInt64/2
+	}
+	arr := arrayOf(mem, arrow.PrimitiveTypes.Int64, ints, nil)
+	input := compute.NewDatum(arr)
+	arr.Release()
+	defer input.Release()
+
+	b.SetBytes(n * 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx, err := compute.SortIndices(ctx, input, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		idx.Release()
+	}
+}
+
+func BenchmarkSortTable(b *testing.B) {
+	const (
+		n      = 10000000
+		chunks = 10
+	)
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "s", Type: arrow.BinaryTypes.String},
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}, nil)
+	rng := rand.New(rand.NewSource(0))
+	recs := make([]array.Record, chunks)
+	for k := range recs {
+		var (
+			strs  = make([]string, n/chunks)
+			ints  = make([]int64, n/chunks)
+			valid = make([]bool, n/chunks)
+		)
+		for i := range strs {
+			strs[i] = string(rune('a' + rng.Intn(26)))
+			ints[i], valid[i] = rng.Int63n(1000000), rng.Intn(100) != 0
+		}
+		scol := arrayOf(mem, arrow.BinaryTypes.String, strs, nil)
+		icol := arrayOf(mem, arrow.PrimitiveTypes.Int64, ints, valid)
+		recs[k] = array.NewRecord(schema, []array.Interface{scol, icol}, n/chunks)
+		defer recs[k].Release()
+		scol.Release()
+		icol.Release()
+	}
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	opts := &compute.SortOptions{
+		Keys:      []compute.SortKey{{Name: "s"}, {Name: "i", Order: compute.Descending}},
+		ChunkSize: n / chunks,
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out, err := compute.SortTable(ctx, tbl, opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		out.Release()
+	}
+}
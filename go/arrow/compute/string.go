@@ -0,0 +1,359 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// The string functions operate element-wise over string and binary
+// datums, and over dictionary-encoded strings by transforming only the
+// dictionary. Nulls are kept.
+//
+// Upper and Lower map each rune independently with the simple case
+// mappings of the unicode package: no special casing is applied, so "ß" is
+// left unchanged by Upper, and combining characters are mapped on their
+// own.
+
+// UpperASCII converts the ASCII letters of string or binary values to
+// upper case, leaving all other bytes unchanged.
+func UpperASCII(ctx context.Context, input Datum) (Datum, error) {
+	return transformStrings(ctx, "upper_ascii", input, false, asciiTransform(func(c byte) byte {
+		if 'a' <= c && c <= 'z' {
+			return c - ('a' - 'A')
+		}
+		return c
+	}))
+}
+
+// LowerASCII converts the ASCII letters of string or binary values to
+// lower case, leaving all other bytes unchanged.
+func LowerASCII(ctx context.Context, input Datum) (Datum, error) {
+	return transformStrings(ctx, "lower_ascii", input, false, asciiTransform(func(c byte) byte {
+		if 'A' <= c && c <= 'Z' {
+			return c + ('a' - 'A')
+		}
+		return c
+	}))
+}
+
+// Upper converts the letters of UTF-8 string values to upper case.
+func Upper(ctx context.Context, input Datum) (Datum, error) {
+	return transformStrings(ctx, "upper", input, true, runeTransform(unicode.ToUpper))
+}
+
+// Lower converts the letters of UTF-8 string values to lower case.
+func Lower(ctx context.Context, input Datum) (Datum, error) {
+	return transformStrings(ctx, "lower", input, true, runeTransform(unicode.ToLower))
+}
+
+// TrimWhitespace removes the leading and trailing Unicode white space of
+// string values.
+func TrimWhitespace(ctx context.Context, input Datum) (Datum, error) {
+	return transformStrings(ctx, "trim_whitespace", input, true, trimTransform(func(v []byte) []byte {
+		return bytes.TrimFunc(v, unicode.IsSpace)
+	}))
+}
+
+// Trim removes the leading and trailing runes contained in chars from
+// string or binary values.
+func Trim(ctx context.Context, input Datum, chars string) (Datum, error) {
+	return transformStrings(ctx, "trim", input, false, trimTransform(func(v []byte) []byte {
+		return bytes.Trim(v, chars)
+	}))
+}
+
+// ReplaceSubstring replaces the non-overlapping occurrences of old with
+// new in string or binary values, from left to right. At most
+// maxReplacements occurrences are replaced in each value, or all of them
+// if maxReplacements is negative.
+func ReplaceSubstring(ctx context.Context, input Datum, old, new string, maxReplacements int) (Datum, error) {
+	if old == "" {
+		return nil, xerrors.Errorf("arrow/compute: replace_substring pattern must not be empty: %w", ErrInvalid)
+	}
+	pattern, repl := []byte(old), []byte(new)
+	return transformStrings(ctx, "replace_substring", input, false, stringTransform{
+		size: func(v []byte) int {
+			n := bytes.Count(v, pattern)
+			if maxReplacements >= 0 && n > maxReplacements {
+				n = maxReplacements
+			}
+			return len(v) + n*(len(repl)-len(pattern))
+		},
+		write: func(dst, v []byte) {
+			for n := 0; n != maxReplacements; n++ {
+				i := bytes.Index(v, pattern)
+				if i < 0 {
+					break
+				}
+				dst = dst[copy(dst, v[:i]):]
+				dst = dst[copy(dst, repl):]
+				v = v[i+len(pattern):]
+			}
+			copy(dst, v)
+		},
+	})
+}
+
+// Utf8Length returns the number of runes of UTF-8 string values, as an
+// Int32 datum.
+func Utf8Length(ctx context.Context, input Datum) (Datum, error) {
+	return mapStrings(ctx, "utf8_length", input, true, lengthKernel(utf8.RuneCount))
+}
+
+// BinaryLength returns the number of bytes of string or binary values, as
+// an Int32 datum.
+func BinaryLength(ctx context.Context, input Datum) (Datum, error) {
+	return mapStrings(ctx, "binary_length", input, false, lengthKernel(func(v []byte) int { return len(v) }))
+}
+
+// MatchSubstring returns a boolean datum which is true where string or
+// binary values contain pattern.
+func MatchSubstring(ctx context.Context, input Datum, pattern string) (Datum, error) {
+	p := []byte(pattern)
+	return mapStrings(ctx, "match_substring", input, false, predicateKernel(func(v []byte) bool {
+		return bytes.Contains(v, p)
+	}))
+}
+
+// StartsWith returns a boolean datum which is true where string or binary
+// values start with prefix.
+func StartsWith(ctx context.Context, input Datum, prefix string) (Datum, error) {
+	p := []byte(prefix)
+	return mapStrings(ctx, "starts_with", input, false, predicateKernel(func(v []byte) bool {
+		return bytes.HasPrefix(v, p)
+	}))
+}
+
+// EndsWith returns a boolean datum which is true where string or binary
+// values end with suffix.
+func EndsWith(ctx context.Context, input Datum, suffix string) (Datum, error) {
+	p := []byte(suffix)
+	return mapStrings(ctx, "ends_with", input, false, predicateKernel(func(v []byte) bool {
+		return bytes.HasSuffix(v, p)
+	}))
+}
+
+// stringTransform transforms each value of a string or binary array. The
+// output buffers are sized by a first pass over the values calling size,
+// which returns the length of a transformed value, before write writes
+// the transformed value into dst, of exactly that length.
+type stringTransform struct {
+	size  func(v []byte) int
+	write func(dst, v []byte)
+}
+
+func asciiTransform(fn func(c byte) byte) stringTransform {
+	return stringTransform{
+		size: func(v []byte) int { return len(v) },
+		write: func(dst, v []byte) {
+			for i, c := range v {
+				dst[i] = fn(c)
+			}
+		},
+	}
+}
+
+// runeTransform maps each rune of the values with fn. Invalid UTF-8 bytes
+// are copied unchanged.
+func runeTransform(fn func(r rune) rune) stringTransform {
+	// the mapping of ASCII runes is computed once.
+	var ascii [utf8.RuneSelf]rune
+	for c := range ascii {
+		ascii[c] = fn(rune(c))
+	}
+	return stringTransform{
+		size: func(v []byte) int {
+			n := 0
+			for i := 0; i < len(v); {
+				if c := v[i]; c < utf8.RuneSelf {
+					n += utf8.RuneLen(ascii[c])
+					i++
+					continue
+				}
+				r, size := utf8.DecodeRune(v[i:])
+				if r == utf8.RuneError && size == 1 {
+					n++
+				} else {
+					n += utf8.RuneLen(fn(r))
+				}
+				i += size
+			}
+			return n
+		},
+		write: func(dst, v []byte) {
+			j := 0
+			for i := 0; i < len(v); {
+				if c := v[i]; c < utf8.RuneSelf && ascii[c] < utf8.RuneSelf {
+					dst[j] = byte(ascii[c])
+					i++
+					j++
+					continue
+				}
+				r, size := utf8.DecodeRune(v[i:])
+				if r == utf8.RuneError && size == 1 {
+					dst[j] = v[i]
+					j++
+				} else {
+					j += utf8.EncodeRune(dst[j:], fn(r))
+				}
+				i += size
+			}
+		},
+	}
+}
+
+func trimTransform(trim func(v []byte) []byte) stringTransform {
+	return stringTransform{
+		size:  func(v []byte) int { return len(trim(v)) },
+		write: func(dst, v []byte) { copy(dst, trim(v)) },
+	}
+}
+
+func (t stringTransform) kernel(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+	var (
+		n             = arr.Len()
+		offsets, data = binaryValues(arr)
+		outOffsets    = newBuffer(mem, arrow.Int32Traits.BytesRequired(n+1))
+		outOffs       = arrow.Int32Traits.CastFromBytes(outOffsets.Bytes())
+		nulls         = arr.NullN() > 0
+	)
+	for i := 0; i < n; i++ {
+		size := 0
+		if !nulls || arr.IsValid(i) {
+			size = t.size(data[offsets[i]:offsets[i+1]])
+		}
+		if int64(outOffs[i])+int64(size) > math.MaxInt32 {
+			outOffsets.Release()
+			return nil, xerrors.Errorf("arrow/compute: transformed %v values overflow the offsets: %w", arr.DataType(), ErrInvalid)
+		}
+		outOffs[i+1] = outOffs[i] + int32(size)
+	}
+
+	values := newBuffer(mem, int(outOffs[n]))
+	out := values.Bytes()
+	for i := 0; i < n; i++ {
+		if outOffs[i] != outOffs[i+1] {
+			t.write(out[outOffs[i]:outOffs[i+1]], data[offsets[i]:offsets[i+1]])
+		}
+	}
+	validity := copyValidity(mem, arr)
+	return makeArray(arr.DataType(), n, []*memory.Buffer{validity, outOffsets, values}, nil, arr.NullN()), nil
+}
+
+// lengthKernel returns a kernel computing the length of each value.
+func lengthKernel(length func(v []byte) int) unaryKernel {
+	return func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		var (
+			n             = arr.Len()
+			offsets, data = binaryValues(arr)
+			values        = newBuffer(mem, arrow.Int32Traits.BytesRequired(n))
+			out           = arrow.Int32Traits.CastFromBytes(values.Bytes())
+		)
+		for i := range out {
+			out[i] = int32(length(data[offsets[i]:offsets[i+1]]))
+		}
+		validity := copyValidity(mem, arr)
+		return makeArray(arrow.PrimitiveTypes.Int32, n, []*memory.Buffer{validity, values}, nil, arr.NullN()), nil
+	}
+}
+
+// predicateKernel returns a kernel evaluating fn over each value.
+func predicateKernel(fn func(v []byte) bool) unaryKernel {
+	return func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		var (
+			n             = arr.Len()
+			offsets, data = binaryValues(arr)
+			values        = newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+			out           = values.Bytes()
+		)
+		for i := 0; i < n; i++ {
+			if fn(data[offsets[i]:offsets[i+1]]) {
+				bitutil.SetBit(out, i)
+			}
+		}
+		validity := copyValidity(mem, arr)
+		return makeArray(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{validity, values}, nil, arr.NullN()), nil
+	}
+}
+
+// checkStrings returns an error if dt is not a string type, or a binary
+// type when utf8 is false, possibly dictionary-encoded.
+func checkStrings(name string, dt arrow.DataType, utf8 bool) error {
+	if dict, ok := dt.(*arrow.DictionaryType); ok {
+		dt = dict.ValueType
+	}
+	if dt.ID() == arrow.STRING || dt.ID() == arrow.BINARY && !utf8 {
+		return nil
+	}
+	return xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+}
+
+// transformStrings applies t to the values of input. The dictionary of
+// dictionary-encoded values is transformed, keeping the indices.
+func transformStrings(ctx context.Context, name string, input Datum, utf8 bool, t stringTransform) (Datum, error) {
+	if err := checkStrings(name, input.DataType(), utf8); err != nil {
+		return nil, err
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		dict, ok := arr.(*array.Dictionary)
+		if !ok {
+			return t.kernel(mem, arr)
+		}
+		values, err := t.kernel(mem, dict.Dictionary())
+		if err != nil {
+			return nil, err
+		}
+		defer values.Release()
+		return array.NewDictionaryArray(dict.DataType().(*arrow.DictionaryType), dict.Indices(), values), nil
+	})
+}
+
+// mapStrings applies kernel to the values of input. The kernel is applied
+// to the dictionary of dictionary-encoded values, and its results are
+// then looked up by index.
+func mapStrings(ctx context.Context, name string, input Datum, utf8 bool, kernel unaryKernel) (Datum, error) {
+	if err := checkStrings(name, input.DataType(), utf8); err != nil {
+		return nil, err
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		dict, ok := arr.(*array.Dictionary)
+		if !ok {
+			return kernel(mem, arr)
+		}
+		values, err := kernel(mem, dict.Dictionary())
+		if err != nil {
+			return nil, err
+		}
+		defer values.Release()
+		sel, err := takeSelection(dict.Indices(), values.Len(), DefaultTakeOptions())
+		if err != nil {
+			return nil, err
+		}
+		return gather(mem, []array.Interface{values}, sel)
+	})
+}
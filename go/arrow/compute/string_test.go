@@ -0,0 +1,217 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestStringFunctions(t *testing.T) {
+	var (
+		str   = arrow.BinaryTypes.String
+		bin   = arrow.BinaryTypes.Binary
+		i32   = arrow.PrimitiveTypes.Int32
+		boolT = arrow.FixedWidthTypes.Boolean
+		input = []string{"Straße", "", "  héllo wörld\t", "ÀÉÎ é", "aXa-aXa-aXa", ""}
+		valid = []bool{true, true, true, true, true, false}
+	)
+
+	for _, tc := range []struct {
+		name  string
+		fn    func(context.Context, compute.Datum) (compute.Datum, error)
+		dt    arrow.DataType
+		want  interface{}
+		wtype arrow.DataType
+	}{
+		{"upper-ascii", compute.UpperASCII, str,
+			[]string{"STRAßE", "", "  HéLLO WöRLD\t", "ÀÉÎ É", "AXA-AXA-AXA", ""}, str},
+		{"lower-ascii", compute.LowerASCII, bin,
+			[]string{"straße", "", "  héllo wörld\t", "ÀÉÎ é", "axa-axa-axa", ""}, bin},
+		// ß has no single rune upper case mapping and is kept.
+		{"upper", compute.Upper, str,
+			[]string{"STRAßE", "", "  HÉLLO WÖRLD\t", "ÀÉÎ É", "AXA-AXA-AXA", ""}, str},
+		{"lower", compute.Lower, str,
+			[]string{"straße", "", "  héllo wörld\t", "àéî é", "axa-axa-axa", ""}, str},
+		{"trim-whitespace", compute.TrimWhitespace, str,
+			[]string{"Straße", "", "héllo wörld", "ÀÉÎ é", "aXa-aXa-aXa", ""}, str},
+		{"trim", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+			return compute.Trim(ctx, d, "a-S")
+		}, str, []string{"traße", "", "  héllo wörld\t", "ÀÉÎ é", "Xa-aXa-aX", ""}, str},
+		{"replace-all", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+			return compute.ReplaceSubstring(ctx, d, "X", "üü", -1)
+		}, str, []string{"Straße", "", "  héllo wörld\t", "ÀÉÎ é", "aüüa-aüüa-aüüa", ""}, str},
+		{"replace-max", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+			return compute.ReplaceSubstring(ctx, d, "aXa", "", 2)
+		}, bin, []string{"Straße", "", "  héllo wörld\t", "ÀÉÎ é", "--aXa", ""}, bin},
+		{"utf8-length", compute.Utf8Length, str, []int32{6, 0, 14, 6, 11, 0}, i32},
+		{"binary-length", compute.BinaryLength, bin, []int32{7, 0, 16, 10, 11, 0}, i32},
+		{"match-substring", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+			return compute.MatchSubstring(ctx, d, "ö")
+		}, str, []bool{false, false, true, false, false, false}, boolT},
+		{"starts-with", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+			return compute.StartsWith(ctx, d, "aX")
+		}, bin, []bool{false, false, false, false, true, false}, boolT},
+		{"ends-with", func(ctx context.Context, d compute.Datum) (compute.Datum, error) {
+			return compute.EndsWith(ctx, d, "é")
+		}, str, []bool{false, false, false, true, false, false}, boolT},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			want := arrayOf(mem, tc.wtype, tc.want, valid)
+			defer want.Release()
+
+			arr := arrayOf(mem, tc.dt, input, valid)
+			defer arr.Release()
+			d := compute.NewDatum(arr)
+			defer d.Release()
+
+			out, err := tc.fn(ctx, d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+			assertArrayEqual(t, want, out.(*compute.ArrayDatum).Value)
+
+			// sliced inputs.
+			sliced := array.NewSlice(arr, 2, 5)
+			defer sliced.Release()
+			ds := compute.NewDatum(sliced)
+			defer ds.Release()
+			out, err = tc.fn(ctx, ds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+			wsliced := array.NewSlice(want, 2, 5)
+			defer wsliced.Release()
+			assertArrayEqual(t, wsliced, out.(*compute.ArrayDatum).Value)
+
+			// dictionary-encoded inputs.
+			enc, err := compute.DictionaryEncode(ctx, d, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer enc.Release()
+			out, err = tc.fn(ctx, enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+			got := out.(*compute.ArrayDatum).Value
+			if dict, ok := got.(*array.Dictionary); ok {
+				if dict.Dictionary().Len() != enc.(*compute.ArrayDatum).Value.(*array.Dictionary).Dictionary().Len() {
+					t.Fatalf("the dictionary should be transformed in place")
+				}
+				if got, err = compute.CastArray(ctx, dict, tc.wtype, nil); err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+			}
+			assertArrayEqual(t, want, got)
+		})
+	}
+}
+
+func TestStringFunctionsErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	bin := arrayOf(mem, arrow.BinaryTypes.Binary, []string{"a"}, nil)
+	defer bin.Release()
+	ints := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1}, nil)
+	defer ints.Release()
+	str := arrayOf(mem, arrow.BinaryTypes.String, []string{"a"}, nil)
+	defer str.Release()
+
+	for _, tc := range []struct {
+		name string
+		fn   func() (compute.Datum, error)
+		want error
+	}{
+		{"upper-binary", func() (compute.Datum, error) { return compute.Upper(ctx, &compute.ArrayDatum{Value: bin}) }, compute.ErrNotImplemented},
+		{"length-int32", func() (compute.Datum, error) { return compute.BinaryLength(ctx, &compute.ArrayDatum{Value: ints}) }, compute.ErrNotImplemented},
+		{"replace-empty", func() (compute.Datum, error) {
+			return compute.ReplaceSubstring(ctx, &compute.ArrayDatum{Value: str}, "", "x", -1)
+		}, compute.ErrInvalid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.fn(); !xerrors.Is(err, tc.want) {
+				t.Fatalf("got err=%v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func BenchmarkUpper(b *testing.B) {
+	const n = 10000000
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	bldr := array.NewStringBuilder(mem)
+	values := make([]string, n)
+	for i := range values {
+		values[i] = "value-" + strconv.Itoa(i%1000) + "-é"
+		bldr.Append(values[i])
+	}
+	arr := bldr.NewArray()
+	bldr.Release()
+	defer arr.Release()
+	input := compute.NewDatum(arr)
+	defer input.Release()
+
+	b.Run("kernel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			out, err := compute.Upper(ctx, input)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+		}
+	})
+	b.Run("kernel-ascii", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			out, err := compute.UpperASCII(ctx, input)
+			if err != nil {
+				b.Fatal(err)
+			}
+			out.Release()
+		}
+	})
+	b.Run("builder", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bldr := array.NewStringBuilder(mem)
+			for _, v := range values {
+				bldr.Append(strings.ToUpper(v))
+			}
+			bldr.NewArray().Release()
+			bldr.Release()
+		}
+	})
+}
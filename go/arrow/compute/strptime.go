@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// StrptimeOptions controls the parsing of strings as timestamps by
+// Strptime, and by CastArray when set in CastOptions.
+type StrptimeOptions struct {
+	// Format is the format of the strings. It is either a strftime-style
+	// format, such as "%Y-%m-%d %H:%M:%S", or a time.Parse layout if it
+	// holds no '%'. Fractional seconds are accepted after the seconds.
+	//
+	// The supported directives are %Y, %y, %m, %d, %e, %j, %H, %I, %M,
+	// %S, %p, %b, %h, %B, %a, %A, %z, %F, %T, %D, %R and %%. The literal
+	// text of a format must not hold elements of time.Parse layouts, such
+	// as digits.
+	Format string
+	// Unit is the unit of the timestamps returned by Strptime. It is
+	// ignored by CastArray, which uses the unit of the target type.
+	Unit arrow.TimeUnit
+	// ErrorIsNull makes strings which cannot be parsed null, instead of
+	// failing the whole conversion.
+	ErrorIsNull bool
+}
+
+// strftimeLayouts maps strftime directives to time.Parse layout elements.
+var strftimeLayouts = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'R': "15:04",
+	'%': "%",
+}
+
+// Strptime parses the values of a string or binary array with
+// opts.Format, and returns them as timestamps of unit opts.Unit along with
+// the number of values which could not be parsed. Null values stay null.
+//
+// If the format has a zone designator, such as %z, the timestamps are
+// converted to UTC and the result has the "UTC" time zone. Otherwise, the
+// result has no time zone, and holds the parsed wall clock times.
+//
+// Unless opts.ErrorIsNull is set, a value which cannot be parsed, or which
+// has more precision than opts.Unit, fails the conversion with ErrInvalid.
+//
+// The returned array must be Release()'d after use.
+func Strptime(ctx context.Context, arr array.Interface, opts *StrptimeOptions) (array.Interface, int, error) {
+	if opts == nil {
+		return nil, 0, xerrors.Errorf("arrow/compute: strptime requires a format: %w", ErrInvalid)
+	}
+	layout, err := strptimeLayout(opts.Format)
+	if err != nil {
+		return nil, 0, err
+	}
+	to := &arrow.TimestampType{Unit: opts.Unit}
+	if layoutHasZone(layout) {
+		to.TimeZone = "UTC"
+	}
+
+	mem := GetAllocator(ctx)
+	if dict, ok := arr.(*array.Dictionary); ok {
+		values, err := decodeDictionary(mem, dict)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer values.Release()
+		arr = values
+	}
+	if id := arr.DataType().ID(); id != arrow.STRING && id != arrow.BINARY {
+		return nil, 0, xerrors.Errorf("arrow/compute: strptime is not implemented for %v: %w", arr.DataType(), ErrNotImplemented)
+	}
+	return strptime(mem, arr, to, layout, opts.ErrorIsNull, SafeCastOptions())
+}
+
+// castStrptime casts the string or binary array arr to the timestamp type
+// to with the format of opts.Strptime. The format must have a zone
+// designator only if the target type has a time zone; otherwise, the
+// values are parsed in the time zone of the target type.
+func castStrptime(mem memory.Allocator, arr array.Interface, to *arrow.TimestampType, opts *CastOptions) (array.Interface, error) {
+	layout, err := strptimeLayout(opts.Strptime.Format)
+	if err != nil {
+		return nil, err
+	}
+	if layoutHasZone(layout) && to.TimeZone == "" {
+		return nil, xerrors.Errorf("arrow/compute: format %q has a zone designator, which %v lacks: %w",
+			opts.Strptime.Format, to, ErrInvalid)
+	}
+	out, _, err := strptime(mem, arr, to, layout, opts.Strptime.ErrorIsNull, opts)
+	return out, err
+}
+
+// strptime parses the values of the string or binary array arr with the
+// time.Parse layout, in the time zone of to. Values which cannot be
+// converted are made null if errorIsNull is set, and counted.
+func strptime(mem memory.Allocator, arr array.Interface, to *arrow.TimestampType, layout string, errorIsNull bool, opts *CastOptions) (array.Interface, int, error) {
+	loc, err := loadLocation(to.TimeZone)
+	if err != nil {
+		return nil, 0, err
+	}
+	perSec := unitsPerSecond(to.Unit)
+
+	bldr := array.NewTimestampBuilder(mem, to)
+	defer bldr.Release()
+	bldr.Reserve(arr.Len())
+
+	failed := 0
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			bldr.AppendNull()
+			continue
+		}
+		s := stringValue(arr, i)
+		t, ok := parseTime(s, []string{layout}, loc)
+		err := errCastParse(arr, i, s, to)
+		var v int64
+		if ok {
+			v, err = fromTime(arr, i, t, perSec, to, opts)
+		}
+		switch {
+		case err == nil:
+			bldr.Append(arrow.Timestamp(v))
+		case errorIsNull:
+			bldr.AppendNull()
+			failed++
+		default:
+			return nil, 0, err
+		}
+	}
+	return bldr.NewArray(), failed, nil
+}
+
+// strptimeLayout returns the time.Parse layout of a strftime-style format,
+// or the format itself if it holds no directive.
+func strptimeLayout(format string) (string, error) {
+	if format == "" {
+		return "", xerrors.Errorf("arrow/compute: strptime requires a format: %w", ErrInvalid)
+	}
+	if !strings.Contains(format, "%") {
+		return format, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			sb.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i == len(format) {
+			return "", xerrors.Errorf("arrow/compute: format %q ends with a lone %%: %w", format, ErrInvalid)
+		}
+		elem, ok := strftimeLayouts[format[i]]
+		if !ok {
+			return "", xerrors.Errorf("arrow/compute: unsupported directive %%%c in format %q: %w", format[i], format, ErrInvalid)
+		}
+		sb.WriteString(elem)
+	}
+	return sb.String(), nil
+}
+
+// layoutHasZone reports whether a time.Parse layout has a zone designator.
+func layoutHasZone(layout string) bool {
+	for _, elem := range []string{"Z07", "-07", "MST"} {
+		if strings.Contains(layout, elem) {
+			return true
+		}
+	}
+	return false
+}
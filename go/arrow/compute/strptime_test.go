@@ -0,0 +1,236 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+func TestStrptime(t *testing.T) {
+	var (
+		str   = arrow.BinaryTypes.String
+		s     = &arrow.TimestampType{Unit: arrow.Second}
+		ms    = &arrow.TimestampType{Unit: arrow.Millisecond}
+		msUTC = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		opts   *compute.StrptimeOptions
+		values []string
+		valid  []bool
+		want   array.Interface
+		failed int
+		err    error
+	}{
+		{
+			name:   "strftime",
+			opts:   &compute.StrptimeOptions{Format: "%Y-%m-%d %H:%M:%S", Unit: arrow.Millisecond},
+			values: []string{"2021-03-04 05:06:07", "", "1969-12-31 23:59:59.5"},
+			valid:  []bool{true, false, true},
+			want:   arrayOf(memory.DefaultAllocator, ms, []arrow.Timestamp{1614834367000, 0, -500}, []bool{true, false, true}),
+		},
+		{
+			name:   "error-is-null",
+			opts:   &compute.StrptimeOptions{Format: "%d/%m/%Y", Unit: arrow.Second, ErrorIsNull: true},
+			values: []string{"04/03/2021", "2021-03-04", "31/02/2021", "", "01/01/1970 extra"},
+			valid:  []bool{true, true, true, false, true},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{1614816000, 0, 0, 0, 0}, []bool{true, false, false, false, false}),
+			failed: 3,
+		},
+		{
+			name:   "invalid",
+			opts:   &compute.StrptimeOptions{Format: "%d/%m/%Y", Unit: arrow.Second},
+			values: []string{"04/03/2021", "2021-03-04"},
+			err:    compute.ErrInvalid,
+		},
+		{
+			name:   "truncated",
+			opts:   &compute.StrptimeOptions{Format: "%H:%M:%S %F", Unit: arrow.Second},
+			values: []string{"00:00:01.5 1970-01-01"},
+			err:    compute.ErrInvalid,
+		},
+		{
+			name:   "truncated-is-null",
+			opts:   &compute.StrptimeOptions{Format: "%H:%M:%S %F", Unit: arrow.Second, ErrorIsNull: true},
+			values: []string{"00:00:01.5 1970-01-01", "00:00:02 1970-01-01"},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{0, 2}, []bool{false, true}),
+			failed: 1,
+		},
+		{
+			name:   "zone",
+			opts:   &compute.StrptimeOptions{Format: "%Y-%m-%dT%H:%M:%S%z", Unit: arrow.Millisecond},
+			values: []string{"1970-01-01T01:00:00+0100", "1970-01-01T00:00:00-0030"},
+			want:   arrayOf(memory.DefaultAllocator, msUTC, []arrow.Timestamp{0, 1800000}, nil),
+		},
+		{
+			name:   "names",
+			opts:   &compute.StrptimeOptions{Format: "%a, %e %b %Y %I:%M %p", Unit: arrow.Second},
+			values: []string{"Thu,  1 Jan 1970 01:30 PM"},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{48600}, nil),
+		},
+		{
+			name:   "go-layout",
+			opts:   &compute.StrptimeOptions{Format: "Jan 2 2006 15h04", Unit: arrow.Second},
+			values: []string{"Jan 1 1970 00h01"},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{60}, nil),
+		},
+		{
+			name:   "percent",
+			opts:   &compute.StrptimeOptions{Format: "%Y%%%m", Unit: arrow.Second},
+			values: []string{"1970%02"},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{31 * 86400}, nil),
+		},
+		{
+			name: "unsupported-directive",
+			opts: &compute.StrptimeOptions{Format: "%Y %Q"},
+			err:  compute.ErrInvalid,
+		},
+		{
+			name: "lone-percent",
+			opts: &compute.StrptimeOptions{Format: "%Y %"},
+			err:  compute.ErrInvalid,
+		},
+		{
+			name: "no-format",
+			err:  compute.ErrInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+			if tc.want != nil {
+				defer tc.want.Release()
+			}
+
+			arr := arrayOf(mem, str, tc.values, tc.valid)
+			defer arr.Release()
+
+			got, failed, err := compute.Strptime(ctx, arr, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("got error %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			assertArrayEqual(t, tc.want, got)
+			if failed != tc.failed {
+				t.Fatalf("got %d failed values, want %d", failed, tc.failed)
+			}
+		})
+	}
+}
+
+func TestCastStrptime(t *testing.T) {
+	var (
+		str   = arrow.BinaryTypes.String
+		s     = &arrow.TimestampType{Unit: arrow.Second}
+		sZone = &arrow.TimestampType{Unit: arrow.Second, TimeZone: "+05:30"}
+		msUTC = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		opts   *compute.StrptimeOptions
+		to     arrow.DataType
+		values []string
+		want   array.Interface
+		err    error
+	}{
+		{
+			name:   "naive",
+			opts:   &compute.StrptimeOptions{Format: "%d.%m.%Y %H:%M"},
+			to:     s,
+			values: []string{"02.01.1970 00:00"},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{86400}, nil),
+		},
+		{
+			name:   "local-zone",
+			opts:   &compute.StrptimeOptions{Format: "%d.%m.%Y %H:%M"},
+			to:     sZone,
+			values: []string{"01.01.1970 05:30"},
+			want:   arrayOf(memory.DefaultAllocator, sZone, []arrow.Timestamp{0}, nil),
+		},
+		{
+			name:   "designator",
+			opts:   &compute.StrptimeOptions{Format: "%F %T %z", Unit: arrow.Nanosecond},
+			to:     msUTC,
+			values: []string{"1970-01-01 00:00:01.25 -0100"},
+			want:   arrayOf(memory.DefaultAllocator, msUTC, []arrow.Timestamp{3601250}, nil),
+		},
+		{
+			name:   "error-is-null",
+			opts:   &compute.StrptimeOptions{Format: "%F", ErrorIsNull: true},
+			to:     s,
+			values: []string{"1970-01-02", "yesterday"},
+			want:   arrayOf(memory.DefaultAllocator, s, []arrow.Timestamp{86400, 0}, []bool{true, false}),
+		},
+		{
+			name:   "invalid",
+			opts:   &compute.StrptimeOptions{Format: "%F"},
+			to:     s,
+			values: []string{"1970-01-02", "yesterday"},
+			err:    compute.ErrInvalid,
+		},
+		{
+			name:   "designator-without-zone",
+			opts:   &compute.StrptimeOptions{Format: "%F %z"},
+			to:     s,
+			values: []string{"1970-01-01 +0000"},
+			err:    compute.ErrInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+			if tc.want != nil {
+				defer tc.want.Release()
+			}
+
+			arr := arrayOf(mem, str, tc.values, nil)
+			defer arr.Release()
+
+			got, err := compute.CastArray(ctx, arr, tc.to, &compute.CastOptions{Strptime: tc.opts})
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("got error %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			assertArrayEqual(t, tc.want, got)
+		})
+	}
+}
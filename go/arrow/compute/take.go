@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"math"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"golang.org/x/xerrors"
+)
+
+// TakeOptions controls the behavior of the Take functions.
+type TakeOptions struct {
+	// BoundsCheck makes Take fail with ErrInvalid when an index is out of
+	// bounds. When false, out of bounds indices have undefined results, and
+	// callers must validate the indices beforehand.
+	BoundsCheck bool
+}
+
+// DefaultTakeOptions returns the options used when nil is passed to the
+// Take functions.
+func DefaultTakeOptions() *TakeOptions {
+	return &TakeOptions{BoundsCheck: true}
+}
+
+// TakeArray returns a new array made of the values of arr at the given
+// indices, which must be an array of any integer type. Null indices
+// produce null values.
+//
+// The returned array must be Release()'d after use.
+func TakeArray(ctx context.Context, arr, indices array.Interface, opts *TakeOptions) (array.Interface, error) {
+	sel, err := takeSelection(indices, arr.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return gather(GetAllocator(ctx), []array.Interface{arr}, sel)
+}
+
+// TakeChunked returns a new chunked array made of the values of arr at the
+// given logical indices, which are resolved across the chunks of arr.
+//
+// The returned array is made of a single chunk and must be Release()'d
+// after use.
+func TakeChunked(ctx context.Context, arr *array.Chunked, indices array.Interface, opts *TakeOptions) (*array.Chunked, error) {
+	sel, err := takeSelection(indices, arr.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectChunked(GetAllocator(ctx), arr, sel)
+}
+
+// TakeRecord returns a new record made of the rows of rec at the given
+// indices.
+//
+// The returned record must be Release()'d after use.
+func TakeRecord(ctx context.Context, rec array.Record, indices array.Interface, opts *TakeOptions) (array.Record, error) {
+	sel, err := takeSelection(indices, int(rec.NumRows()), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectRecord(GetAllocator(ctx), rec, sel)
+}
+
+// TakeTable returns a new table made of the rows of tbl at the given
+// indices.
+//
+// The columns of the returned table are made of a single chunk.
+// The returned table must be Release()'d after use.
+func TakeTable(ctx context.Context, tbl array.Table, indices array.Interface, opts *TakeOptions) (array.Table, error) {
+	sel, err := takeSelection(indices, int(tbl.NumRows()), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectTable(GetAllocator(ctx), tbl, sel)
+}
+
+// Take returns the values of the array, chunked array, record or table
+// values at the given indices, an array or a chunked array of any integer
+// type. Null indices produce null values, or null rows for records and
+// tables. The returned datum is of the same kind as values.
+//
+// The returned datum must be Release()'d after use.
+func Take(ctx context.Context, values, indices Datum, opts *TakeOptions) (Datum, error) {
+	if k := indices.Kind(); k != KindArray && k != KindChunked {
+		return nil, xerrors.Errorf("arrow/compute: take indices must be an array or a chunked array, got %v: %w", k, ErrInvalid)
+	}
+	mem := GetAllocator(ctx)
+	chunks, err := datumChunks(mem, indices)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := concatenate(mem, indices.DataType(), chunks)
+	releaseArrays(chunks)
+	if err != nil {
+		return nil, err
+	}
+	defer idx.Release()
+
+	sel, err := takeSelection(idx, int(values.Len()), opts)
+	if err != nil {
+		return nil, err
+	}
+	return selectDatum(mem, values, sel)
+}
+
+// takeSelection returns the selection of the given indices over n values.
+func takeSelection(indices array.Interface, n int, opts *TakeOptions) (*selection, error) {
+	if opts == nil {
+		opts = DefaultTakeOptions()
+	}
+	if !isInteger(indices.DataType().ID()) {
+		return nil, xerrors.Errorf("arrow/compute: take indices must be integers, got %v: %w", indices.DataType(), ErrInvalid)
+	}
+
+	var (
+		idx, _ = widenNumeric(indices)
+		nulls  = indices.NullN() > 0
+		sel    = &selection{spans: make([]span, 0, indices.Len())}
+	)
+	if idx.kind == kindUint {
+		idx.ints = make([]int64, len(idx.uints))
+		for i, v := range idx.uints {
+			if v > math.MaxInt64 {
+				// make sure it is detected as out of bounds below.
+				v = math.MaxInt64
+			}
+			idx.ints[i] = int64(v)
+		}
+	}
+
+	for i, v := range idx.ints {
+		if nulls && indices.IsNull(i) {
+			sel.add(0, 0, 1, true)
+			continue
+		}
+		if opts.BoundsCheck && (v < 0 || v >= int64(n)) {
+			return nil, xerrors.Errorf("arrow/compute: index %d at row %d is out of bounds for length %d: %w", v, i, n, ErrInvalid)
+		}
+		sel.add(0, int(v), 1, false)
+	}
+	return sel, nil
+}
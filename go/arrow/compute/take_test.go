@@ -0,0 +1,463 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"golang.org/x/xerrors"
+)
+
+func TestTakeArray(t *testing.T) {
+	var (
+		i8  = arrow.PrimitiveTypes.Int8
+		u32 = arrow.PrimitiveTypes.Uint32
+		i64 = arrow.PrimitiveTypes.Int64
+	)
+
+	for _, tc := range []struct {
+		name   string
+		dt     arrow.DataType
+		in     interface{}
+		valid  []bool
+		idt    arrow.DataType
+		idx    interface{}
+		ivalid []bool
+		opts   *compute.TakeOptions
+		want   interface{}
+		wvalid []bool
+		err    error
+	}{
+		{
+			name: "int32", dt: arrow.PrimitiveTypes.Int32,
+			in:  []int32{10, 11, 12, 13},
+			idt: i64, idx: []int64{3, 0, 0, 2, 1},
+			want: []int32{13, 10, 10, 12, 11},
+		},
+		{
+			name: "null-indices", dt: arrow.PrimitiveTypes.Float64,
+			in: []float64{10, 11, 12, 13}, valid: []bool{true, false, true, true},
+			idt: i8, idx: []int8{3, 0, 1, 2, 1}, ivalid: []bool{true, false, true, true, false},
+			want: []float64{13, 0, 0, 12, 0}, wvalid: []bool{true, false, false, true, false},
+		},
+		{
+			name: "uint-indices", dt: arrow.BinaryTypes.String,
+			in: []string{"a", "bb", "", "dddd"}, valid: []bool{true, true, false, true},
+			idt: u32, idx: []uint32{3, 2, 1, 0, 3},
+			want: []string{"dddd", "", "bb", "a", "dddd"}, wvalid: []bool{true, false, true, true, true},
+		},
+		{
+			name: "bool", dt: arrow.FixedWidthTypes.Boolean,
+			in:  []bool{true, false, true, false},
+			idt: i64, idx: []int64{1, 1, 0, 3, 2, 2},
+			want: []bool{false, false, true, false, true, true},
+		},
+		{
+			name: "empty", dt: arrow.BinaryTypes.Binary,
+			in:  [][]byte{[]byte("a")},
+			idt: i64, idx: []int64{},
+			want: [][]byte{},
+		},
+		{
+			name: "out-of-bounds", dt: arrow.PrimitiveTypes.Int32,
+			in:  []int32{10, 11, 12, 13},
+			idt: i64, idx: []int64{0, 4},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "negative", dt: arrow.PrimitiveTypes.Int32,
+			in:  []int32{10, 11, 12, 13},
+			idt: i8, idx: []int8{0, -1},
+			err: compute.ErrInvalid,
+		},
+		{
+			name: "out-of-bounds-null", dt: arrow.PrimitiveTypes.Int32,
+			in:  []int32{10, 11, 12, 13},
+			idt: i64, idx: []int64{0, 100}, ivalid: []bool{true, false},
+			want: []int32{10, 0}, wvalid: []bool{true, false},
+		},
+		{
+			name: "no-bounds-check", dt: arrow.PrimitiveTypes.Int32,
+			in:  []int32{10, 11, 12, 13},
+			idt: i64, idx: []int64{3, 2, 1},
+			opts: &compute.TakeOptions{},
+			want: []int32{13, 12, 11},
+		},
+		{
+			name: "float-indices", dt: arrow.PrimitiveTypes.Int32,
+			in:  []int32{10, 11, 12, 13},
+			idt: arrow.PrimitiveTypes.Float64, idx: []float64{0},
+			err: compute.ErrInvalid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			arr := arrayOf(mem, tc.dt, tc.in, tc.valid)
+			defer arr.Release()
+			idx := arrayOf(mem, tc.idt, tc.idx, tc.ivalid)
+			defer idx.Release()
+
+			got, err := compute.TakeArray(ctx, arr, idx, tc.opts)
+			if tc.err != nil {
+				if !xerrors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := arrayOf(mem, tc.dt, tc.want, tc.wvalid)
+			defer want.Release()
+			assertArrayEqual(t, want, got)
+		})
+	}
+}
+
+func TestTakeNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	lb := array.NewListBuilder(mem, arrow.BinaryTypes.String)
+	defer lb.Release()
+	vb := lb.ValueBuilder().(*array.StringBuilder)
+	appendRows := func(rows []int) {
+		for _, i := range rows {
+			switch i {
+			case -1:
+				lb.AppendNull()
+			case 0:
+				lb.Append(true)
+			default:
+				lb.Append(true)
+				for j := 0; j < i; j++ {
+					vb.Append(string(rune('a' + i)))
+				}
+			}
+		}
+	}
+	appendRows([]int{9, 1, 2, -1, 0, 3})
+	arr := lb.NewArray()
+	defer arr.Release()
+
+	appendRows([]int{3, -1, 1, 3, 0, -1, 2})
+	want := lb.NewArray()
+	defer want.Release()
+
+	slice := array.NewSlice(arr, 1, 6)
+	defer slice.Release()
+
+	idx := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{4, 0, 0, 4, 3, 2, 1}, []bool{true, false, true, true, true, true, true})
+	defer idx.Release()
+
+	got, err := compute.TakeArray(ctx, slice, idx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	assertArrayEqual(t, want, got)
+}
+
+func TestTakeChunked(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	c1 := arrayOf(mem, arrow.BinaryTypes.String, []string{"a", "b"}, nil)
+	defer c1.Release()
+	c2 := arrayOf(mem, arrow.BinaryTypes.String, []string{}, nil)
+	defer c2.Release()
+	c3 := arrayOf(mem, arrow.BinaryTypes.String, []string{"c", "d", "e"}, []bool{true, false, true})
+	defer c3.Release()
+	chunked := array.NewChunked(arrow.BinaryTypes.String, []array.Interface{c1, c2, c3})
+	defer chunked.Release()
+
+	idx := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{4, 0, 1, 2, 3, 1, 4, 2}, nil)
+	defer idx.Release()
+
+	got, err := compute.TakeChunked(ctx, chunked, idx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if n := len(got.Chunks()); n != 1 {
+		t.Fatalf("invalid number of chunks: %d", n)
+	}
+	want := arrayOf(mem, arrow.BinaryTypes.String,
+		[]string{"e", "a", "b", "c", "", "b", "e", "c"},
+		[]bool{true, true, true, true, false, true, true, true})
+	defer want.Release()
+	assertArrayEqual(t, want, got.Chunk(0))
+
+	oob := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{5}, nil)
+	defer oob.Release()
+	if _, err := compute.TakeChunked(ctx, chunked, oob, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func TestTakeRecordAndTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.BinaryTypes.String},
+	}, nil)
+	a := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 3}, nil)
+	defer a.Release()
+	b := arrayOf(mem, arrow.BinaryTypes.String, []string{"x", "y", "z"}, nil)
+	defer b.Release()
+	rec := array.NewRecord(schema, []array.Interface{a, b}, 3)
+	defer rec.Release()
+
+	idx := arrayOf(mem, arrow.PrimitiveTypes.Uint8, []uint8{2, 2, 0}, nil)
+	defer idx.Release()
+
+	wantA := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{3, 3, 1}, nil)
+	defer wantA.Release()
+	wantB := arrayOf(mem, arrow.BinaryTypes.String, []string{"z", "z", "x"}, nil)
+	defer wantB.Release()
+
+	gotRec, err := compute.TakeRecord(ctx, rec, idx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gotRec.Release()
+	assertArrayEqual(t, wantA, gotRec.Column(0))
+	assertArrayEqual(t, wantB, gotRec.Column(1))
+
+	tbl := array.NewTableFromRecords(schema, []array.Record{rec, rec})
+	defer tbl.Release()
+
+	idx2 := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{5, 5, 3}, nil)
+	defer idx2.Release()
+
+	gotTbl, err := compute.TakeTable(ctx, tbl, idx2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gotTbl.Release()
+
+	if gotTbl.NumRows() != 3 {
+		t.Fatalf("invalid number of rows: %d", gotTbl.NumRows())
+	}
+	assertArrayEqual(t, wantA, gotTbl.Column(0).Data().Chunk(0))
+	assertArrayEqual(t, wantB, gotTbl.Column(1).Data().Chunk(0))
+}
+
+// assertSelected checks that the values of got are the values of want at
+// positions refs, where negative positions are nulls. This is the naive
+// reference of the Take and Filter kernels.
+func assertSelected(t *testing.T, want, got array.Interface, refs []int) {
+	t.Helper()
+	if got.Len() != len(refs) {
+		t.Fatalf("invalid length: got=%d, want=%d", got.Len(), len(refs))
+	}
+	if !arrow.TypeEqual(got.DataType(), want.DataType()) {
+		t.Fatalf("invalid type: got=%v, want=%v", got.DataType(), want.DataType())
+	}
+	for i, ref := range refs {
+		if ref < 0 || want.IsNull(ref) {
+			if !got.IsNull(i) {
+				t.Fatalf("value %d: want null, got %v", i, got)
+			}
+			continue
+		}
+		w := array.NewSlice(want, int64(ref), int64(ref+1))
+		g := array.NewSlice(got, int64(i), int64(i+1))
+		ok := array.ArrayEqual(w, g)
+		if !ok {
+			t.Errorf("value %d: got=%v, want=%v", i, g, w)
+		}
+		w.Release()
+		g.Release()
+		if !ok {
+			t.FailNow()
+		}
+	}
+}
+
+func TestTakeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, dt := range randomTypes {
+		t.Run(dt.Name(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+			for iter := 0; iter < 20; iter++ {
+				n := rng.Intn(100)
+				values := randomArray(mem, rng, dt, n, 0.2)
+				defer values.Release()
+
+				refs := make([]int, rng.Intn(150))
+				idx := make([]int64, len(refs))
+				valid := make([]bool, len(refs))
+				for i := range refs {
+					refs[i] = -1
+					if n > 0 && rng.Intn(10) != 0 {
+						refs[i] = rng.Intn(n)
+						idx[i], valid[i] = int64(refs[i]), true
+					}
+				}
+				indices := arrayOf(mem, arrow.PrimitiveTypes.Int64, idx, valid)
+				defer indices.Release()
+
+				got, err := compute.TakeArray(ctx, values, indices, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+				assertSelected(t, values, got, refs)
+
+				chunked := randomChunks(rng, values)
+				defer chunked.Release()
+				chunkedIdx := randomChunks(rng, indices)
+				defer chunkedIdx.Release()
+
+				res, err := compute.Take(ctx, &compute.ChunkedDatum{Value: chunked}, &compute.ChunkedDatum{Value: chunkedIdx}, &compute.TakeOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer res.Release()
+				if res.Kind() != compute.KindChunked {
+					t.Fatalf("invalid datum kind: %v", res.Kind())
+				}
+				assertSelected(t, values, res.(*compute.ChunkedDatum).Value.Chunk(0), refs)
+			}
+		})
+	}
+}
+
+func TestTakeDatum(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+	}, nil)
+	a := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{1, 2, 3}, nil)
+	defer a.Release()
+	rec := array.NewRecord(schema, []array.Interface{a}, 3)
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(schema, []array.Record{rec})
+	defer tbl.Release()
+
+	idx := arrayOf(mem, arrow.PrimitiveTypes.Int8, []int8{2, 0, 2, 0}, []bool{true, true, false, true})
+	defer idx.Release()
+	indices := compute.NewDatum(idx)
+	defer indices.Release()
+	want := arrayOf(mem, arrow.PrimitiveTypes.Int32, []int32{3, 1, 0, 1}, []bool{true, true, false, true})
+	defer want.Release()
+
+	for _, tc := range []struct {
+		values interface{}
+		col    func(compute.Datum) array.Interface
+	}{
+		{a, func(d compute.Datum) array.Interface { return d.(*compute.ArrayDatum).Value }},
+		{rec, func(d compute.Datum) array.Interface { return d.(*compute.RecordDatum).Value.Column(0) }},
+		{tbl, func(d compute.Datum) array.Interface { return d.(*compute.TableDatum).Value.Column(0).Data().Chunk(0) }},
+	} {
+		values := compute.NewDatum(tc.values)
+		got, err := compute.Take(ctx, values, indices, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Kind() != values.Kind() {
+			t.Fatalf("invalid datum kind: got=%v, want=%v", got.Kind(), values.Kind())
+		}
+		assertArrayEqual(t, want, tc.col(got))
+		got.Release()
+		values.Release()
+	}
+
+	values := compute.NewDatum(a)
+	defer values.Release()
+	scalar := compute.NewDatum(scalar.NewInt64Scalar(0))
+	defer scalar.Release()
+	if _, err := compute.Take(ctx, values, scalar, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := compute.Take(ctx, scalar, indices, nil); !xerrors.Is(err, compute.ErrInvalid) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func BenchmarkTakeArray(b *testing.B) {
+	const n = 1 << 20
+
+	mem := memory.NewGoAllocator()
+	ctx := compute.WithAllocator(context.Background(), mem)
+	rng := rand.New(rand.NewSource(0))
+
+	vals := make([]int64, n)
+	strs := make([]string, n)
+	for i := range vals {
+		vals[i] = rng.Int63()
+		strs[i] = "value"[:rng.Intn(6)]
+	}
+	ints := arrayOf(mem, arrow.PrimitiveTypes.Int64, vals, nil)
+	defer ints.Release()
+	strings := arrayOf(mem, arrow.BinaryTypes.String, strs, nil)
+	defer strings.Release()
+
+	random := make([]int64, n)
+	sequential := make([]int64, n)
+	for i := range random {
+		random[i] = rng.Int63n(n)
+		sequential[i] = int64(i)
+	}
+
+	for _, idx := range []struct {
+		name string
+		vals []int64
+	}{
+		{"random", random},
+		{"sequential", sequential},
+	} {
+		indices := arrayOf(mem, arrow.PrimitiveTypes.Int64, idx.vals, nil)
+		for _, arr := range []array.Interface{ints, strings} {
+			b.Run(idx.name+"/"+arr.DataType().Name(), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					out, err := compute.TakeArray(ctx, arr, indices, nil)
+					if err != nil {
+						b.Fatal(err)
+					}
+					out.Release()
+				}
+			})
+		}
+		indices.Release()
+	}
+}
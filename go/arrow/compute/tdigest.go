@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultTDigestCompression is the compression of a TDigest created with a
+// zero compression.
+const DefaultTDigestCompression = 100
+
+// TDigest is a sketch of a distribution of numbers, estimating their
+// quantiles in bounded memory. It is a merging t-digest, as described by
+// Dunning and Ertl in "Computing Extremely Accurate Quantiles Using
+// t-Digests".
+//
+// The values are summarized by at most about Compression centroids, which
+// are smaller near the extremes of the distribution than near the median.
+// Unless the values have many duplicates, the rank of an estimated
+// quantile is within 1/Compression of the quantile, and much closer for
+// extreme quantiles. Digests of parts of a distribution can be merged, such as
+// the digests of the chunks of an array, or of data held by different
+// processes.
+//
+// The zero TDigest is not usable; digests are created with NewTDigest.
+type TDigest struct {
+	compression float64
+	centroids   []centroid // merged centroids, by increasing mean
+	buffer      []centroid // values added since the last merge
+	count       float64    // total weight of centroids and buffer
+	min, max    float64
+}
+
+// centroid is a cluster of values of a TDigest, summarized by their mean
+// and count.
+type centroid struct {
+	mean, weight float64
+}
+
+// NewTDigest returns an empty TDigest of the given compression. Larger
+// compressions use more memory, and are more accurate.
+// DefaultTDigestCompression is used if compression is not positive.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression > 0) {
+		compression = DefaultTDigestCompression
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Compression returns the compression of t.
+func (t *TDigest) Compression() float64 { return t.compression }
+
+// Count returns the number of values added to t, including those of the
+// digests merged into t.
+func (t *TDigest) Count() int64 { return int64(t.count) }
+
+// Min returns the minimum value added to t, or NaN if t is empty.
+func (t *TDigest) Min() float64 {
+	if t.count == 0 {
+		return math.NaN()
+	}
+	return t.min
+}
+
+// Max returns the maximum value added to t, or NaN if t is empty.
+func (t *TDigest) Max() float64 {
+	if t.count == 0 {
+		return math.NaN()
+	}
+	return t.max
+}
+
+// Add adds v to t. NaNs are ignored.
+func (t *TDigest) Add(v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	t.add(centroid{v, 1}, v, v)
+}
+
+// Merge merges the digests others into t, as if their values had been
+// added to t. The others are left unchanged.
+func (t *TDigest) Merge(others ...*TDigest) {
+	for _, o := range others {
+		if o.count == 0 {
+			continue
+		}
+		for _, c := range o.centroids {
+			t.add(c, o.min, o.max)
+		}
+		for _, c := range o.buffer {
+			t.add(c, o.min, o.max)
+		}
+	}
+}
+
+// Quantile returns an estimate of the quantile q of the values of t, or
+// NaN if t is empty. q is clamped between 0 and 1.
+func (t *TDigest) Quantile(q float64) float64 {
+	t.compress()
+	n := len(t.centroids)
+	switch {
+	case n == 0:
+		return math.NaN()
+	case q <= 0:
+		return t.min
+	case q >= 1:
+		return t.max
+	}
+
+	// the values are interpolated linearly between the means of the
+	// centroids, placed at the centers of their ranks, the minimum at rank
+	// 0 and the maximum at the rank count.
+	index := q * t.count
+	prevRank, prevValue := 0.0, t.min
+	rank := 0.0
+	for _, c := range t.centroids {
+		center := rank + c.weight/2
+		if index < center {
+			return interpolate(prevRank, prevValue, center, c.mean, index)
+		}
+		if c.weight == 1 && index < rank+1 {
+			// a single value spans its whole rank.
+			return c.mean
+		}
+		prevRank, prevValue = center, c.mean
+		rank += c.weight
+	}
+	return interpolate(prevRank, prevValue, t.count, t.max, index)
+}
+
+// interpolate returns the value at x of the line through (x0, y0) and
+// (x1, y1), with x0 <= x <= x1.
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+	if x1 <= x0 || y0 == y1 {
+		return y0
+	}
+	v := y0 + (y1-y0)*(x-x0)/(x1-x0)
+	return math.Max(math.Min(v, math.Max(y0, y1)), math.Min(y0, y1))
+}
+
+func (t *TDigest) add(c centroid, min, max float64) {
+	t.buffer = append(t.buffer, c)
+	t.count += c.weight
+	t.min = math.Min(t.min, min)
+	t.max = math.Max(t.max, max)
+	if len(t.buffer) >= t.bufferSize() {
+		t.compress()
+	}
+}
+
+// bufferSize is the number of values buffered before being merged into
+// the centroids.
+func (t *TDigest) bufferSize() int {
+	return int(5 * math.Ceil(t.compression))
+}
+
+// compress merges the buffered values into the centroids. Neighboring
+// centroids are merged as long as the merged centroid spans less than one
+// unit of the scale function k(q) = compression/(2π) * asin(2q-1), which
+// bounds the size of the centroids by q*(1-q).
+func (t *TDigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+	all := append(t.centroids, t.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := make([]centroid, 1, int(math.Ceil(t.compression))+1)
+	merged[0] = all[0]
+	soFar := 0.0 // weight of the centroids before the last merged one
+	limit := t.count * t.kInverse(t.k(0)+1)
+	for _, c := range all[1:] {
+		cur := &merged[len(merged)-1]
+		if soFar+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		soFar += cur.weight
+		limit = t.count * t.kInverse(t.k(soFar/t.count)+1)
+		merged = append(merged, c)
+	}
+	t.centroids = merged
+	t.buffer = t.buffer[:0]
+}
+
+func (t *TDigest) k(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+func (t *TDigest) kInverse(k float64) float64 {
+	x := k * 2 * math.Pi / t.compression
+	if x >= math.Pi/2 {
+		return 1
+	}
+	return (math.Sin(x) + 1) / 2
+}
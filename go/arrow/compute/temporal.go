@@ -0,0 +1,341 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// TemporalOptions controls how the temporal functions interpret
+// timestamps.
+type TemporalOptions struct {
+	// ErrorOnNaive makes the functions fail with ErrInvalid on timestamps
+	// without a time zone, which are otherwise interpreted as UTC.
+	ErrorOnNaive bool
+}
+
+// Year returns the year of date or timestamp values as an Int64 datum.
+// Timestamps with a time zone are converted to local time first.
+func Year(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "year", input, opts, componentDate, func(t time.Time) int64 {
+		return int64(t.Year())
+	})
+}
+
+// Month returns the month, from 1 to 12, of date or timestamp values as an
+// Int64 datum.
+func Month(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "month", input, opts, componentDate, func(t time.Time) int64 {
+		return int64(t.Month())
+	})
+}
+
+// Day returns the day of the month, from 1 to 31, of date or timestamp
+// values as an Int64 datum.
+func Day(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "day", input, opts, componentDate, func(t time.Time) int64 {
+		return int64(t.Day())
+	})
+}
+
+// DayOfWeek returns the day of the week of date or timestamp values as an
+// Int64 datum, from 0 for Monday to 6 for Sunday.
+func DayOfWeek(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "day_of_week", input, opts, componentDate, func(t time.Time) int64 {
+		return int64(t.Weekday()+6) % 7
+	})
+}
+
+// ISOWeek returns the ISO 8601 week number, from 1 to 53, of date or
+// timestamp values as an Int64 datum.
+func ISOWeek(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "iso_week", input, opts, componentDate, func(t time.Time) int64 {
+		_, week := t.ISOWeek()
+		return int64(week)
+	})
+}
+
+// Hour returns the hour, from 0 to 23, of time or timestamp values as an
+// Int64 datum.
+func Hour(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "hour", input, opts, componentTime, func(t time.Time) int64 {
+		return int64(t.Hour())
+	})
+}
+
+// Minute returns the minute, from 0 to 59, of time or timestamp values as
+// an Int64 datum.
+func Minute(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "minute", input, opts, componentTime, func(t time.Time) int64 {
+		return int64(t.Minute())
+	})
+}
+
+// Second returns the second, from 0 to 59, of time or timestamp values as
+// an Int64 datum. Fractions of seconds are discarded.
+func Second(ctx context.Context, input Datum, opts *TemporalOptions) (Datum, error) {
+	return temporalComponent(ctx, "second", input, opts, componentTime, func(t time.Time) int64 {
+		return int64(t.Second())
+	})
+}
+
+// the kinds of temporal components.
+const (
+	componentDate = iota
+	componentTime
+)
+
+func temporalComponent(ctx context.Context, name string, input Datum, opts *TemporalOptions, kind int, fn func(t time.Time) int64) (Datum, error) {
+	dt := input.DataType()
+	switch dt.ID() {
+	case arrow.TIMESTAMP:
+	case arrow.DATE32, arrow.DATE64:
+		if kind != componentDate {
+			return nil, xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+		}
+	case arrow.TIME32, arrow.TIME64:
+		if kind != componentTime {
+			return nil, xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+		}
+	default:
+		return nil, xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, dt, ErrNotImplemented)
+	}
+	loc, err := temporalLocation(dt, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	perSec := unitsPerSecond(timeUnitOf(dt))
+	if dt.ID() == arrow.DATE32 {
+		perSec = 1
+	}
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		var (
+			vals   = temporalValues(arr)
+			values = newBuffer(mem, arrow.Int64Traits.BytesRequired(len(vals)))
+			out    = arrow.Int64Traits.CastFromBytes(values.Bytes())
+			nulls  = arr.NullN() > 0
+		)
+		for i, v := range vals {
+			if nulls && arr.IsNull(i) {
+				continue
+			}
+			if dt.ID() == arrow.DATE32 {
+				v *= secondsPerDay
+			}
+			out[i] = fn(toTime(v, perSec, loc))
+		}
+		validity := copyValidity(mem, arr)
+		return makeArray(arrow.PrimitiveTypes.Int64, len(vals), []*memory.Buffer{validity, values}, nil, arr.NullN()), nil
+	})
+}
+
+// temporalLocation returns the location in which the values of dt are
+// interpreted.
+func temporalLocation(dt arrow.DataType, opts *TemporalOptions) (*time.Location, error) {
+	ts, ok := dt.(*arrow.TimestampType)
+	if !ok {
+		return time.UTC, nil
+	}
+	if ts.TimeZone == "" && opts != nil && opts.ErrorOnNaive {
+		return nil, xerrors.Errorf("arrow/compute: %v values have no time zone: %w", dt, ErrInvalid)
+	}
+	return loadLocation(ts.TimeZone)
+}
+
+// CalendarUnit is a unit of time used to round temporal values.
+type CalendarUnit int8
+
+const (
+	UnitNanosecond CalendarUnit = iota
+	UnitMicrosecond
+	UnitMillisecond
+	UnitSecond
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+var calendarUnitNames = [...]string{"nanosecond", "microsecond", "millisecond", "second", "minute", "hour", "day", "week", "month", "quarter", "year"}
+
+func (u CalendarUnit) String() string {
+	if u < 0 || int(u) >= len(calendarUnitNames) {
+		return fmt.Sprintf("CalendarUnit(%d)", int8(u))
+	}
+	return calendarUnitNames[u]
+}
+
+// FloorTemporal rounds timestamp values down to a multiple of the given
+// unit. Timestamps with a time zone are rounded in local time: days start
+// at local midnight, weeks on Mondays, and multiples of days, weeks,
+// months, quarters and years are counted from 1970-01-01. Units of an hour
+// or less are rounded keeping the UTC offset of the input value, so that
+// instants repeated by a daylight saving time transition stay distinct.
+func FloorTemporal(ctx context.Context, input Datum, multiple int, unit CalendarUnit, opts *TemporalOptions) (Datum, error) {
+	return roundTemporal(ctx, "floor_temporal", input, multiple, unit, opts, func(v, lo, hi int64) int64 {
+		return lo
+	})
+}
+
+// CeilTemporal rounds timestamp values up to a multiple of the given unit.
+// See FloorTemporal for the handling of time zones.
+func CeilTemporal(ctx context.Context, input Datum, multiple int, unit CalendarUnit, opts *TemporalOptions) (Datum, error) {
+	return roundTemporal(ctx, "ceil_temporal", input, multiple, unit, opts, func(v, lo, hi int64) int64 {
+		if v == lo {
+			return lo
+		}
+		return hi
+	})
+}
+
+// RoundTemporal rounds timestamp values to the nearest multiple of the
+// given unit, rounding half up. See FloorTemporal for the handling of time
+// zones.
+func RoundTemporal(ctx context.Context, input Datum, multiple int, unit CalendarUnit, opts *TemporalOptions) (Datum, error) {
+	return roundTemporal(ctx, "round_temporal", input, multiple, unit, opts, func(v, lo, hi int64) int64 {
+		if v-lo < hi-v {
+			return lo
+		}
+		return hi
+	})
+}
+
+func roundTemporal(ctx context.Context, name string, input Datum, multiple int, unit CalendarUnit, opts *TemporalOptions, pick func(v, lo, hi int64) int64) (Datum, error) {
+	dt, ok := input.DataType().(*arrow.TimestampType)
+	if !ok {
+		return nil, xerrors.Errorf("arrow/compute: %s is not implemented for %v: %w", name, input.DataType(), ErrNotImplemented)
+	}
+	if multiple <= 0 {
+		return nil, xerrors.Errorf("arrow/compute: %s multiple must be positive, got %d: %w", name, multiple, ErrInvalid)
+	}
+	loc, err := temporalLocation(dt, opts)
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := temporalBounds(dt, loc, multiple, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	return execUnary(GetAllocator(ctx), input, func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+		var (
+			vals   = arr.(*array.Timestamp).TimestampValues()
+			values = newBuffer(mem, arrow.Int64Traits.BytesRequired(len(vals)))
+			out    = arrow.Int64Traits.CastFromBytes(values.Bytes())
+			nulls  = arr.NullN() > 0
+		)
+		for i, v := range vals {
+			if nulls && arr.IsNull(i) {
+				continue
+			}
+			lo, hi := bounds(int64(v))
+			out[i] = pick(int64(v), lo, hi)
+		}
+		validity := copyValidity(mem, arr)
+		return makeArray(dt, len(vals), []*memory.Buffer{validity, values}, nil, arr.NullN()), nil
+	})
+}
+
+var unitNanos = [...]int64{
+	UnitNanosecond:  1,
+	UnitMicrosecond: 1e3,
+	UnitMillisecond: 1e6,
+	UnitSecond:      1e9,
+	UnitMinute:      60e9,
+	UnitHour:        3600e9,
+}
+
+// temporalBounds returns a function computing the bounds of the interval
+// of multiple units which contains a timestamp value of type dt, the lower
+// bound being included.
+func temporalBounds(dt *arrow.TimestampType, loc *time.Location, multiple int, unit CalendarUnit) (func(v int64) (lo, hi int64), error) {
+	perSec := unitsPerSecond(dt.Unit)
+	k := int64(multiple)
+
+	if unit <= UnitHour {
+		nanosPerTick := 1e9 / perSec
+		if unit < 0 || unitNanos[unit]*k%nanosPerTick != 0 {
+			return nil, xerrors.Errorf("arrow/compute: cannot round %v values to %d %v: %w", dt, multiple, unit, ErrInvalid)
+		}
+		width := unitNanos[unit] * k / nanosPerTick
+		return func(v int64) (int64, int64) {
+			off := localTicks(v, perSec, loc) - v
+			lo := floorDiv(v+off, width)*width - off
+			return lo, lo + width
+		}, nil
+	}
+
+	var (
+		index func(t time.Time) int64
+		start func(idx int64) time.Time
+	)
+	switch unit {
+	case UnitDay, UnitWeek:
+		// weeks start on Mondays, 1969-12-29 being the first Monday
+		// before the epoch.
+		width, shift := k, int64(0)
+		if unit == UnitWeek {
+			width, shift = 7*k, 3
+		}
+		index = func(t time.Time) int64 {
+			y, m, d := t.Date()
+			days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay
+			return floorDiv(days+shift, width)
+		}
+		start = func(idx int64) time.Time {
+			y, m, d := time.Unix((idx*width-shift)*secondsPerDay, 0).UTC().Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, loc)
+		}
+	case UnitMonth, UnitQuarter, UnitYear:
+		width := k
+		switch unit {
+		case UnitQuarter:
+			width *= 3
+		case UnitYear:
+			width *= 12
+		}
+		index = func(t time.Time) int64 {
+			months := int64(t.Year()-1970)*12 + int64(t.Month()-1)
+			return floorDiv(months, width)
+		}
+		start = func(idx int64) time.Time {
+			months := idx * width
+			return time.Date(1970+int(floorDiv(months, 12)), time.Month(months-floorDiv(months, 12)*12+1), 1, 0, 0, 0, 0, loc)
+		}
+	default:
+		return nil, xerrors.Errorf("arrow/compute: invalid calendar unit %v: %w", unit, ErrInvalid)
+	}
+
+	ticks := func(t time.Time) int64 {
+		return t.Unix()*perSec + int64(t.Nanosecond())/(1e9/perSec)
+	}
+	return func(v int64) (int64, int64) {
+		idx := index(toTime(v, perSec, loc))
+		return ticks(start(idx)), ticks(start(idx + 1))
+	}, nil
+}
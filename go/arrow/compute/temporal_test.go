@@ -0,0 +1,223 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// utc returns the UNIX time in seconds of the given UTC date and time.
+func utc(y int, m time.Month, d, hh, mm, ss int) int64 {
+	return time.Date(y, m, d, hh, mm, ss, 0, time.UTC).Unix()
+}
+
+type temporalFunc func(context.Context, compute.Datum, *compute.TemporalOptions) (compute.Datum, error)
+
+func TestTemporalComponents(t *testing.T) {
+	var (
+		ny    = &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"}
+		naive = &arrow.TimestampType{Unit: arrow.Millisecond}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		dt     arrow.DataType
+		values []int64
+		fn     temporalFunc
+		want   []int64
+	}{
+		// 2021-03-14 02:00 EST jumps to 03:00 EDT, 2021-11-07 02:00 EDT
+		// falls back to 01:00 EST.
+		{"ny-hour-spring", ny, []int64{utc(2021, 3, 14, 6, 59, 59), utc(2021, 3, 14, 7, 0, 0)}, compute.Hour, []int64{1, 3}},
+		{"ny-hour-fall", ny, []int64{utc(2021, 11, 7, 5, 30, 0), utc(2021, 11, 7, 6, 30, 0)}, compute.Hour, []int64{1, 1}},
+		{"ny-day", ny, []int64{utc(2021, 1, 1, 4, 59, 59), utc(2021, 1, 1, 5, 0, 0)}, compute.Day, []int64{31, 1}},
+		{"ny-year", ny, []int64{utc(2021, 1, 1, 4, 59, 59), utc(2021, 1, 1, 5, 0, 0)}, compute.Year, []int64{2020, 2021}},
+		{"ny-minute", ny, []int64{utc(2021, 11, 7, 6, 42, 13)}, compute.Minute, []int64{42}},
+		{"ny-second", ny, []int64{utc(2021, 11, 7, 6, 42, 13)}, compute.Second, []int64{13}},
+		{"naive-pre-epoch", naive, []int64{-1000, -86400001}, compute.Year, []int64{1969, 1969}},
+		{"naive-pre-epoch-month", naive, []int64{-1000, -86400001}, compute.Month, []int64{12, 12}},
+		{"naive-pre-epoch-day", naive, []int64{-1000, -86400001}, compute.Day, []int64{31, 30}},
+		{"naive-pre-epoch-hour", naive, []int64{-1000, -86400001}, compute.Hour, []int64{23, 23}},
+		// 1969-12-31 is a Wednesday of the first ISO week of 1970.
+		{"day-of-week", naive, []int64{-1000, 0, 3 * 86400000}, compute.DayOfWeek, []int64{2, 3, 6}},
+		{"iso-week", naive, []int64{-1000, utc(2021, 1, 3, 12, 0, 0) * 1000, utc(2020, 12, 31, 0, 0, 0) * 1000}, compute.ISOWeek, []int64{1, 53, 53}},
+		{"date32", arrow.FixedWidthTypes.Date32, []int64{-1, 18628}, compute.Month, []int64{12, 1}},
+		{"date64", arrow.FixedWidthTypes.Date64, []int64{-86400000, 18628 * 86400000}, compute.Day, []int64{31, 1}},
+		{"time64", arrow.FixedWidthTypes.Time64us, []int64{(13*3600 + 5*60 + 7) * 1e6}, compute.Minute, []int64{5}},
+		{"time32", arrow.FixedWidthTypes.Time32s, []int64{13*3600 + 5*60 + 7}, compute.Hour, []int64{13}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			values := append(tc.values, 0)
+			valid := make([]bool, len(values))
+			for i := range tc.values {
+				valid[i] = true
+			}
+			arr := arrayOf(mem, tc.dt, values, valid)
+			defer arr.Release()
+			input := compute.NewDatum(arr)
+			defer input.Release()
+
+			out, err := tc.fn(ctx, input, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+
+			want := arrayOf(mem, arrow.PrimitiveTypes.Int64, append(tc.want, 0), valid)
+			defer want.Release()
+			assertArrayEqual(t, want, out.(*compute.ArrayDatum).Value)
+		})
+	}
+}
+
+func TestTemporalErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	naive := arrayOf(mem, &arrow.TimestampType{Unit: arrow.Second}, []int64{0}, nil)
+	defer naive.Release()
+	date := arrayOf(mem, arrow.FixedWidthTypes.Date32, []int64{0}, nil)
+	defer date.Release()
+	ints := arrayOf(mem, arrow.PrimitiveTypes.Int64, []int64{0}, nil)
+	defer ints.Release()
+	ms := arrayOf(mem, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}, []int64{0}, nil)
+	defer ms.Release()
+
+	strict := &compute.TemporalOptions{ErrorOnNaive: true}
+	for _, tc := range []struct {
+		name string
+		fn   func() (compute.Datum, error)
+		want error
+	}{
+		{"naive", func() (compute.Datum, error) { return compute.Year(ctx, &compute.ArrayDatum{Value: naive}, strict) }, compute.ErrInvalid},
+		{"naive-floor", func() (compute.Datum, error) {
+			return compute.FloorTemporal(ctx, &compute.ArrayDatum{Value: naive}, 1, compute.UnitDay, strict)
+		}, compute.ErrInvalid},
+		{"date-hour", func() (compute.Datum, error) { return compute.Hour(ctx, &compute.ArrayDatum{Value: date}, nil) }, compute.ErrNotImplemented},
+		{"int64-year", func() (compute.Datum, error) { return compute.Year(ctx, &compute.ArrayDatum{Value: ints}, nil) }, compute.ErrNotImplemented},
+		{"sub-unit", func() (compute.Datum, error) {
+			return compute.FloorTemporal(ctx, &compute.ArrayDatum{Value: ms}, 500, compute.UnitMillisecond, nil)
+		}, compute.ErrInvalid},
+		{"zero-multiple", func() (compute.Datum, error) {
+			return compute.CeilTemporal(ctx, &compute.ArrayDatum{Value: ms}, 0, compute.UnitHour, nil)
+		}, compute.ErrInvalid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.fn(); !xerrors.Is(err, tc.want) {
+				t.Fatalf("got err=%v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRoundTemporal(t *testing.T) {
+	var (
+		ny    = &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"}
+		naive = &arrow.TimestampType{Unit: arrow.Millisecond}
+	)
+	type roundFunc func(context.Context, compute.Datum, int, compute.CalendarUnit, *compute.TemporalOptions) (compute.Datum, error)
+
+	for _, tc := range []struct {
+		name     string
+		dt       arrow.DataType
+		fn       roundFunc
+		multiple int
+		unit     compute.CalendarUnit
+		values   []int64
+		want     []int64
+	}{
+		// 01:50 EST, after the fall back, floors to 01:45 EST and not to
+		// 01:45 EDT, an hour earlier.
+		{"ny-floor-15min-fall", ny, compute.FloorTemporal, 15, compute.UnitMinute,
+			[]int64{utc(2021, 11, 7, 5, 50, 0), utc(2021, 11, 7, 6, 50, 0)},
+			[]int64{utc(2021, 11, 7, 5, 45, 0), utc(2021, 11, 7, 6, 45, 0)}},
+		{"ny-floor-hour-spring", ny, compute.FloorTemporal, 1, compute.UnitHour,
+			[]int64{utc(2021, 3, 14, 6, 59, 59), utc(2021, 3, 14, 7, 10, 0)},
+			[]int64{utc(2021, 3, 14, 6, 0, 0), utc(2021, 3, 14, 7, 0, 0)}},
+		// 2021-11-07 lasts 25 hours in New York.
+		{"ny-floor-day-fall", ny, compute.FloorTemporal, 1, compute.UnitDay,
+			[]int64{utc(2021, 11, 7, 6, 30, 0), utc(2021, 11, 8, 4, 59, 59)},
+			[]int64{utc(2021, 11, 7, 4, 0, 0), utc(2021, 11, 7, 4, 0, 0)}},
+		{"ny-ceil-day-fall", ny, compute.CeilTemporal, 1, compute.UnitDay,
+			[]int64{utc(2021, 11, 7, 6, 30, 0), utc(2021, 11, 7, 4, 0, 0)},
+			[]int64{utc(2021, 11, 8, 5, 0, 0), utc(2021, 11, 7, 4, 0, 0)}},
+		{"ny-floor-month", ny, compute.FloorTemporal, 1, compute.UnitMonth,
+			[]int64{utc(2021, 3, 14, 7, 0, 0), utc(2021, 4, 1, 3, 59, 59)},
+			[]int64{utc(2021, 3, 1, 5, 0, 0), utc(2021, 3, 1, 5, 0, 0)}},
+		{"ny-ceil-quarter", ny, compute.CeilTemporal, 1, compute.UnitQuarter,
+			[]int64{utc(2021, 2, 10, 0, 0, 0)},
+			[]int64{utc(2021, 4, 1, 4, 0, 0)}},
+		{"ny-round-week", ny, compute.RoundTemporal, 1, compute.UnitWeek,
+			// Thursday 2021-11-11 11:00 EST rounds down to Monday, and
+			// Thursday 13:00 EST rounds up to the next Monday.
+			[]int64{utc(2021, 11, 11, 16, 0, 0), utc(2021, 11, 11, 18, 0, 0)},
+			[]int64{utc(2021, 11, 8, 5, 0, 0), utc(2021, 11, 15, 5, 0, 0)}},
+		{"naive-floor-pre-epoch", naive, compute.FloorTemporal, 1, compute.UnitDay,
+			[]int64{-1000, -86400001},
+			[]int64{-86400000, -2 * 86400000}},
+		{"naive-floor-week-pre-epoch", naive, compute.FloorTemporal, 1, compute.UnitWeek,
+			[]int64{-1000, 0},
+			[]int64{utc(1969, 12, 29, 0, 0, 0) * 1000, utc(1969, 12, 29, 0, 0, 0) * 1000}},
+		{"naive-floor-10-years", naive, compute.FloorTemporal, 10, compute.UnitYear,
+			[]int64{utc(2023, 6, 1, 0, 0, 0) * 1000, utc(1965, 6, 1, 0, 0, 0) * 1000},
+			[]int64{utc(2020, 1, 1, 0, 0, 0) * 1000, utc(1960, 1, 1, 0, 0, 0) * 1000}},
+		{"naive-round-250ms", naive, compute.RoundTemporal, 250, compute.UnitMillisecond,
+			[]int64{124, 125, -126},
+			[]int64{0, 250, -250}},
+		{"naive-ceil-second", naive, compute.CeilTemporal, 1, compute.UnitSecond,
+			[]int64{1, 1000, -999},
+			[]int64{1000, 1000, 0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+			ctx := compute.WithAllocator(context.Background(), mem)
+
+			values := append(tc.values, 0)
+			valid := make([]bool, len(values))
+			for i := range tc.values {
+				valid[i] = true
+			}
+			arr := arrayOf(mem, tc.dt, values, valid)
+			defer arr.Release()
+			input := compute.NewDatum(arr)
+			defer input.Release()
+
+			out, err := tc.fn(ctx, input, tc.multiple, tc.unit, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer out.Release()
+
+			want := arrayOf(mem, tc.dt, append(tc.want, 0), valid)
+			defer want.Release()
+			assertArrayEqual(t, want, out.(*compute.ArrayDatum).Value)
+		})
+	}
+}
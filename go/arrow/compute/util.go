@@ -0,0 +1,178 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// newBuffer allocates a zero-initialized buffer of n bytes.
+func newBuffer(mem memory.Allocator, n int) *memory.Buffer {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(n)
+	memory.Set(buf.Bytes(), 0)
+	return buf
+}
+
+// copyBitmap copies length bits of src starting at bit srcOffset into dst
+// starting at bit dstOffset.
+func copyBitmap(src []byte, srcOffset int, dst []byte, dstOffset, length int) {
+	if srcOffset%8 == 0 && dstOffset%8 == 0 {
+		nbytes := length / 8
+		copy(dst[dstOffset/8:dstOffset/8+nbytes], src[srcOffset/8:srcOffset/8+nbytes])
+		for i := nbytes * 8; i < length; i++ {
+			bitutil.SetBitTo(dst, dstOffset+i, bitutil.BitIsSet(src, srcOffset+i))
+		}
+		return
+	}
+	for i := 0; i < length; i++ {
+		bitutil.SetBitTo(dst, dstOffset+i, bitutil.BitIsSet(src, srcOffset+i))
+	}
+}
+
+// copyValidity returns a copy of the validity bitmap of arr, re-aligned to
+// offset zero, or nil if arr has no nulls.
+func copyValidity(mem memory.Allocator, arr array.Interface) *memory.Buffer {
+	if arr.NullN() == 0 {
+		return nil
+	}
+	n := arr.Len()
+	buf := newBuffer(mem, int(bitutil.BytesForBits(int64(n))))
+	src := arr.NullBitmapBytes()
+	if len(src) == 0 {
+		// all values are null, e.g. an array of the NULL type.
+		return buf
+	}
+	copyBitmap(src, arr.Data().Offset(), buf.Bytes(), 0, n)
+	return buf
+}
+
+// makeArray wraps the given buffers into an array of type dt and releases
+// the caller's references to the buffers.
+func makeArray(dt arrow.DataType, n int, buffers []*memory.Buffer, childData []*array.Data, nulls int) array.Interface {
+	data := array.NewData(dt, n, buffers, childData, nulls, 0)
+	defer data.Release()
+	for _, b := range buffers {
+		if b != nil {
+			b.Release()
+		}
+	}
+	return array.MakeFromData(data)
+}
+
+// makeNullArray returns an array of type dt and length n where all values
+// are null.
+func makeNullArray(mem memory.Allocator, dt arrow.DataType, n int) array.Interface {
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return array.NewNull(n)
+	case *arrow.DictionaryType:
+		indices := makeNullArray(mem, dt.IndexType, n)
+		defer indices.Release()
+		dict := makeNullArray(mem, dt.ValueType, 0)
+		defer dict.Release()
+		return array.NewDictionaryArray(dt, indices, dict)
+	case *arrow.RunEndEncodedType:
+		// a single run of nulls.
+		var ends []int64
+		if n > 0 {
+			ends = []int64{int64(n)}
+		}
+		re := makeRunEnds(mem, dt.RunEnds(), ends)
+		defer re.Release()
+		values := makeNullArray(mem, dt.Encoded(), len(ends))
+		defer values.Release()
+		return array.NewRunEndEncoded(re, values, n, 0)
+	}
+	bldr := array.NewBuilder(mem, dt)
+	defer bldr.Release()
+	bldr.Reserve(n)
+	for i := 0; i < n; i++ {
+		bldr.AppendNull()
+	}
+	return bldr.NewArray()
+}
+
+// setBits sets the bits [pos, pos+n) of bitmap.
+func setBits(bitmap []byte, pos, n int) {
+	end := pos + n
+	for ; pos < end && pos%8 != 0; pos++ {
+		bitutil.SetBit(bitmap, pos)
+	}
+	if full := (end - pos) / 8; full > 0 {
+		memory.Set(bitmap[pos/8:pos/8+full], 0xff)
+		pos += full * 8
+	}
+	for ; pos < end; pos++ {
+		bitutil.SetBit(bitmap, pos)
+	}
+}
+
+// nextBit returns the position of the first bit in [pos, end) of bitmap
+// which is equal to set, or end if there is none. Runs of equal bits are
+// skipped a word at a time.
+func nextBit(bitmap []byte, pos, end int, set bool) int {
+	for pos < end {
+		if pos%8 == 0 && pos+64 <= end {
+			w := binary.LittleEndian.Uint64(bitmap[pos/8:])
+			if !set {
+				w = ^w
+			}
+			if w == 0 {
+				pos += 64
+				continue
+			}
+			pos += bits.TrailingZeros64(w)
+			break
+		}
+
+		b := bitmap[pos/8]
+		if !set {
+			b = ^b
+		}
+		if b >>= uint(pos % 8); b != 0 {
+			pos += bits.TrailingZeros8(b)
+			break
+		}
+		pos = (pos/8 + 1) * 8
+	}
+	if pos > end {
+		return end
+	}
+	return pos
+}
+
+// visitSetBitRuns calls fn for each run of consecutive set bits in the n
+// bits of bitmap starting at offset. Positions passed to fn are relative to
+// offset.
+func visitSetBitRuns(bitmap []byte, offset, n int, fn func(pos, n int)) {
+	end := offset + n
+	for pos := offset; pos < end; {
+		beg := nextBit(bitmap, pos, end, true)
+		if beg == end {
+			return
+		}
+		pos = nextBit(bitmap, beg, end, false)
+		fn(beg-offset, pos-beg)
+	}
+}
@@ -21,8 +21,10 @@ package csv
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
 	"github.com/apache/arrow/go/arrow/memory"
 )
 
@@ -159,13 +161,192 @@ func WithNullWriter(null string) Option {
 	}
 }
 
+// DefaultTimestampLayouts is the set of layouts, as understood by time.Parse,
+// tried in order when reading timestamp columns.
+var DefaultTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// DefaultDateLayouts is the set of layouts, as understood by time.Parse,
+// tried in order when reading date columns.
+var DefaultDateLayouts = []string{"2006-01-02"}
+
+// WithTimestampLayouts sets the layouts, as understood by time.Parse, tried in
+// order when reading or inferring timestamp columns. Values without a time
+// zone are read in the time zone of the column, or UTC.
+// Timestamps are written with the first layout, in the time zone of the
+// column.
+//
+// When no layout is given, the default set is taken from DefaultTimestampLayouts.
+func WithTimestampLayouts(layouts ...string) Option {
+	if len(layouts) == 0 {
+		layouts = DefaultTimestampLayouts
+	}
+	layouts = append([]string(nil), layouts...)
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.timestampLayouts = layouts
+		case *Writer:
+			cfg.timestampLayouts = layouts
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithDateLayouts sets the layouts, as understood by time.Parse, tried in
+// order when reading or inferring date columns. Dates are written with the
+// first layout.
+//
+// When no layout is given, the default set is taken from DefaultDateLayouts.
+func WithDateLayouts(layouts ...string) Option {
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+	layouts = append([]string(nil), layouts...)
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.dateLayouts = layouts
+		case *Writer:
+			cfg.dateLayouts = layouts
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumnLayout sets the layout, as understood by time.Parse, of the
+// timestamp or date column named column. It is used to write the column and
+// tried first to read it, before the layouts set with WithTimestampLayouts
+// or WithDateLayouts.
+func WithColumnLayout(column, layout string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			if cfg.columnLayouts == nil {
+				cfg.columnLayouts = make(map[string]string)
+			}
+			cfg.columnLayouts[column] = layout
+		case *Writer:
+			if cfg.columnLayouts == nil {
+				cfg.columnLayouts = make(map[string]string)
+			}
+			cfg.columnLayouts[column] = layout
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithBoolFormat sets the strings written for true and false boolean values.
+// They are also read as true and false, in addition to the default values
+// "true", "True", "1", "false", "False" and "0".
+// By default, booleans are written as "true" and "false".
+func WithBoolFormat(trueValue, falseValue string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.boolTrue, cfg.boolFalse = trueValue, falseValue
+		case *Writer:
+			cfg.boolTrue, cfg.boolFalse = trueValue, falseValue
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// QuoteMode describes when the fields of a CSV file are quoted.
+type QuoteMode int
+
+const (
+	// QuoteMinimal quotes the fields which contain the separator, a quote or
+	// a line break, or begin with a space.
+	QuoteMinimal QuoteMode = iota
+	// QuoteAlways quotes all the fields.
+	QuoteAlways
+	// QuoteNever never quotes the fields. Writing a field which needs quotes
+	// is an error.
+	QuoteNever
+)
+
+// WithQuoting sets when fields are quoted while writing CSV files.
+// The default value is QuoteMinimal.
+func WithQuoting(mode QuoteMode) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.quoting = mode
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// DefaultInferenceRows is the number of rows scanned by default to infer
+// the schema of a CSV file.
+const DefaultInferenceRows = 1000
+
+// WithInferenceRows sets the number of rows scanned by InferSchema and
+// NewInferringReader to infer the schema of a CSV file.
+// If n is zero or negative, all the rows are scanned.
+func WithInferenceRows(n int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.inferRows = n
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithDecimalInference enables the inference of decimal columns: columns
+// whose values all are decimal numbers, without an exponent, which fit in
+// the given precision and scale are inferred as decimal128 instead of
+// float64, or as decimal256 if precision is greater than
+// decimal128.MaxPrecision.
+func WithDecimalInference(precision, scale int32) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			if precision > decimal128.MaxPrecision {
+				cfg.decimal = &arrow.Decimal256Type{Precision: precision, Scale: scale}
+			} else {
+				cfg.decimal = &arrow.Decimal128Type{Precision: precision, Scale: scale}
+			}
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithLeadingZerosAsString sets whether columns with integers written with
+// leading zeros, such as zip codes or identifiers like "007", are inferred
+// as strings, so that the zeros are kept. The default value is false.
+func WithLeadingZerosAsString(v bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.leadingZeros = v
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
 func validate(schema *arrow.Schema) {
 	for i, f := range schema.Fields() {
 		switch ft := f.Type.(type) {
 		case *arrow.BooleanType:
 		case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
 		case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
-		case *arrow.Float32Type, *arrow.Float64Type:
+		case *arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type:
+		case *arrow.Decimal128Type, *arrow.Decimal256Type:
+		case *arrow.Date32Type, *arrow.TimestampType:
 		case *arrow.StringType:
 		default:
 			panic(fmt.Errorf("arrow/csv: field %d (%s) has invalid data type %T", i, f.Name, ft))
@@ -0,0 +1,228 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+)
+
+// InferSchema scans the first rows of the CSV file r, see WithInferenceRows,
+// and returns the schema inferred from their values, without reading the
+// rest of the file. It accepts the same options as NewInferringReader, so
+// that a schema checked by the caller can then be passed to NewReader.
+func InferSchema(r io.Reader, opts ...Option) (*arrow.Schema, error) {
+	rr := newReader(r, opts...)
+	if err := rr.infer(); err != nil {
+		return nil, err
+	}
+	return rr.schema, nil
+}
+
+// NewInferringReader returns a reader that reads a CSV file and creates
+// records with the schema inferred from its first rows, see InferSchema.
+//
+// Each column is given the first of the types bool, int64, decimal128 or
+// decimal256 (see WithDecimalInference), float64, date32, timestamp[ns] and string that all
+// its non-null values can be parsed as: values that conflict widen the column
+// to string. Columns without non-null values are strings. All the inferred
+// fields are nullable, and are named after the header, if any, or "f0",
+// "f1", ... otherwise.
+//
+// The NULL values, set with WithNullReader, default to DefaultNullValues.
+// Note that quotes are removed by the CSV parser, so quoted numbers, such as
+// "1", are inferred as numbers.
+//
+// Errors reading the rows scanned for the inference are reported by Err.
+func NewInferringReader(r io.Reader, opts ...Option) *Reader {
+	rr := newReader(r, opts...)
+	if err := rr.infer(); err != nil {
+		rr.err = err
+		rr.done = true
+		rr.schema = arrow.NewSchema(nil, nil)
+	}
+	rr.init()
+	return rr
+}
+
+// inferred types of a column, by order of preference.
+const (
+	inferBool = 1 << iota
+	inferInt
+	inferDecimal
+	inferFloat
+	inferDate
+	inferTimestamp
+
+	inferAll = inferBool | inferInt | inferDecimal | inferFloat | inferDate | inferTimestamp
+)
+
+// infer reads the rows used to infer the schema of the reader, which are kept
+// to be read again as records.
+func (r *Reader) infer() error {
+	if r.nulls == nil {
+		r.nulls = DefaultNullValues
+	}
+
+	var names []string
+	if r.header {
+		row, err := r.r.Read()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		names = append(names, row...)
+		r.header = false
+	}
+
+	var (
+		types []int
+		valid []bool
+	)
+	for r.inferRows <= 0 || len(r.pending) < r.inferRows {
+		row, err := r.r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		row = append([]string(nil), row...)
+		r.pending = append(r.pending, row)
+
+		for len(types) < len(row) {
+			types = append(types, inferAll)
+			valid = append(valid, false)
+		}
+		for i, str := range row {
+			if r.isNull(str) {
+				continue
+			}
+			valid[i] = true
+			types[i] &= r.inferTypes(str)
+		}
+	}
+
+	n := len(types)
+	if len(names) > n {
+		n = len(names)
+	}
+	fields := make([]arrow.Field, n)
+	for i := range fields {
+		fields[i] = arrow.Field{Type: arrow.BinaryTypes.String, Nullable: true}
+		if i < len(names) {
+			fields[i].Name = names[i]
+		} else {
+			fields[i].Name = "f" + strconv.Itoa(i)
+		}
+		if i < len(types) && valid[i] {
+			fields[i].Type = r.inferredType(types[i])
+		}
+	}
+	r.schema = arrow.NewSchema(fields, nil)
+	return nil
+}
+
+// inferTypes returns the types str can be parsed as.
+func (r *Reader) inferTypes(str string) int {
+	switch str {
+	case "true", "false", "True", "False":
+		return inferBool
+	}
+
+	var types int
+	if _, err := strconv.ParseInt(str, 10, 64); err == nil {
+		if r.leadingZeros && hasLeadingZeros(str) {
+			return 0
+		}
+		types |= inferInt | inferFloat
+		if r.decimal != nil && r.isDecimal(str) {
+			types |= inferDecimal
+		}
+	} else if _, err := strconv.ParseFloat(str, 64); err == nil {
+		types |= inferFloat
+		if r.decimal != nil && r.isDecimal(str) {
+			types |= inferDecimal
+		}
+	}
+	if types != 0 {
+		return types
+	}
+
+	if _, ok := parseTime(str, r.dateLayouts, time.UTC); ok {
+		types |= inferDate
+	}
+	if _, ok := parseTime(str, r.timestampLayouts, time.UTC); ok {
+		types |= inferTimestamp
+	}
+	return types
+}
+
+// isDecimal returns whether str is a decimal number, without an exponent,
+// which fits in the inferred decimal type.
+func (r *Reader) isDecimal(str string) bool {
+	if strings.ContainsAny(str, "eEnNiI") {
+		return false
+	}
+	var (
+		scale int32
+		err   error
+	)
+	switch dt := r.decimal.(type) {
+	case *arrow.Decimal128Type:
+		scale = dt.Scale
+		_, err = decimal128.FromString(str, dt.Precision, dt.Scale)
+	case *arrow.Decimal256Type:
+		scale = dt.Scale
+		_, err = decimal256.FromString(str, dt.Precision, dt.Scale)
+	}
+	if i := strings.IndexByte(str, '.'); i >= 0 && int32(len(str)-i-1) > scale {
+		return false
+	}
+	return err == nil
+}
+
+// hasLeadingZeros returns whether the integer str is written with leading
+// zeros.
+func hasLeadingZeros(str string) bool {
+	str = strings.TrimLeft(str, "+-")
+	return len(str) > 1 && str[0] == '0'
+}
+
+func (r *Reader) inferredType(types int) arrow.DataType {
+	switch {
+	case types&inferBool != 0:
+		return arrow.FixedWidthTypes.Boolean
+	case types&inferInt != 0:
+		return arrow.PrimitiveTypes.Int64
+	case types&inferDecimal != 0:
+		return r.decimal
+	case types&inferFloat != 0:
+		return arrow.PrimitiveTypes.Float64
+	case types&inferDate != 0:
+		return arrow.FixedWidthTypes.Date32
+	case types&inferTimestamp != 0:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
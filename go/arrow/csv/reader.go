@@ -23,9 +23,13 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
 	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/memory"
 	"golang.org/x/xerrors"
@@ -54,6 +58,21 @@ type Reader struct {
 
 	stringsCanBeNull bool
 	nulls            []string
+
+	timestampLayouts []string
+	dateLayouts      []string
+	columnLayouts    map[string]string
+	boolTrue         string
+	boolFalse        string
+
+	// inference options, see NewInferringReader.
+	inferRows    int
+	decimal      arrow.DataType // *arrow.Decimal128Type or *arrow.Decimal256Type
+	leadingZeros bool
+
+	// pending holds the rows read to infer the schema, which are read
+	// again before the rest of the file.
+	pending [][]string
 }
 
 // NewReader returns a reader that reads from the CSV file and creates
@@ -64,12 +83,21 @@ type Reader struct {
 func NewReader(r io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
 	validate(schema)
 
+	rr := newReader(r, opts...)
+	rr.schema = schema
+	rr.init()
+	return rr
+}
+
+func newReader(r io.Reader, opts ...Option) *Reader {
 	rr := &Reader{
 		r:                csv.NewReader(r),
-		schema:           schema,
 		refs:             1,
 		chunk:            1,
 		stringsCanBeNull: false,
+		timestampLayouts: DefaultTimestampLayouts,
+		dateLayouts:      DefaultDateLayouts,
+		inferRows:        DefaultInferenceRows,
 	}
 	rr.r.ReuseRecord = true
 	for _, opt := range opts {
@@ -79,7 +107,11 @@ func NewReader(r io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
 	if rr.mem == nil {
 		rr.mem = memory.DefaultAllocator
 	}
+	return rr
+}
 
+// init prepares the reader to build records of its schema.
+func (rr *Reader) init() {
 	rr.bld = array.NewRecordBuilder(rr.mem, rr.schema)
 
 	switch {
@@ -94,16 +126,25 @@ func NewReader(r io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
 	// Create a table of functions that will parse columns. This optimization
 	// allows us to specialize the implementation of each column's decoding
 	// and hoist type-based branches outside the inner loop.
-	rr.fieldConverter = make([]func(array.Builder, string), len(schema.Fields()))
-	for idx, field := range schema.Fields() {
+	rr.fieldConverter = make([]func(array.Builder, string), len(rr.schema.Fields()))
+	for idx, field := range rr.schema.Fields() {
 		rr.fieldConverter[idx] = rr.initFieldConverter(&field)
 	}
+}
 
-	return rr
+// readRow returns the next row, reading first the rows read to infer the
+// schema.
+func (r *Reader) readRow() ([]string, error) {
+	if len(r.pending) > 0 {
+		row := r.pending[0]
+		r.pending = r.pending[1:]
+		return row, nil
+	}
+	return r.r.Read()
 }
 
 func (r *Reader) readHeader() error {
-	records, err := r.r.Read()
+	records, err := r.readRow()
 	if err != nil {
 		return xerrors.Errorf("arrow/csv: could not read header from file: %w", err)
 	}
@@ -162,7 +203,7 @@ func (r *Reader) Next() bool {
 // from that row.
 func (r *Reader) next1() bool {
 	var recs []string
-	recs, r.err = r.r.Read()
+	recs, r.err = r.readRow()
 	if r.err != nil {
 		r.done = true
 		if r.err == io.EOF {
@@ -185,19 +226,19 @@ func (r *Reader) nextall() bool {
 		r.done = true
 	}()
 
-	var (
-		recs [][]string
-	)
-
-	recs, r.err = r.r.ReadAll()
-	if r.err != nil {
-		return false
-	}
-
-	for _, rec := range recs {
+	for {
+		var rec []string
+		rec, r.err = r.readRow()
+		if r.err != nil {
+			break
+		}
 		r.validate(rec)
 		r.read(rec)
 	}
+	if r.err != io.EOF {
+		return false
+	}
+	r.err = nil
 	r.cur = r.bld.NewRecord()
 
 	return true
@@ -212,7 +253,7 @@ func (r *Reader) nextn() bool {
 	)
 
 	for i := 0; i < r.chunk && !r.done; i++ {
-		recs, r.err = r.r.Read()
+		recs, r.err = r.readRow()
 		if r.err != nil {
 			r.done = true
 			break
@@ -298,6 +339,10 @@ func (r *Reader) initFieldConverter(field *arrow.Field) func(array.Builder, stri
 		return func(field array.Builder, str string) {
 			r.parseUint64(field, str)
 		}
+	case *arrow.Float16Type:
+		return func(field array.Builder, str string) {
+			r.parseFloat16(field, str)
+		}
 	case *arrow.Float32Type:
 		return func(field array.Builder, str string) {
 			r.parseFloat32(field, str)
@@ -306,6 +351,28 @@ func (r *Reader) initFieldConverter(field *arrow.Field) func(array.Builder, stri
 		return func(field array.Builder, str string) {
 			r.parseFloat64(field, str)
 		}
+	case *arrow.Decimal128Type:
+		dt := field.Type.(*arrow.Decimal128Type)
+		return func(field array.Builder, str string) {
+			r.parseDecimal128(field, str, dt)
+		}
+	case *arrow.Decimal256Type:
+		dt := field.Type.(*arrow.Decimal256Type)
+		return func(field array.Builder, str string) {
+			r.parseDecimal256(field, str, dt)
+		}
+	case *arrow.Date32Type:
+		layouts := r.layouts(field.Name, r.dateLayouts)
+		return func(field array.Builder, str string) {
+			r.parseDate32(field, str, layouts)
+		}
+	case *arrow.TimestampType:
+		dt := field.Type.(*arrow.TimestampType)
+		layouts := r.layouts(field.Name, r.timestampLayouts)
+		loc := location(dt.TimeZone)
+		return func(field array.Builder, str string) {
+			r.parseTimestamp(field, str, dt.Unit, layouts, loc)
+		}
 	case *arrow.StringType:
 		// specialize the implementation when we know we cannot have nulls
 		if r.stringsCanBeNull {
@@ -334,10 +401,14 @@ func (r *Reader) parseBool(field array.Builder, str string) {
 	}
 
 	var v bool
-	switch str {
-	case "false", "False", "0":
+	switch {
+	case str == "false", str == "False", str == "0":
+		v = false
+	case str == "true", str == "True", str == "1":
+		v = true
+	case r.boolFalse != "" && str == r.boolFalse:
 		v = false
-	case "true", "True", "1":
+	case r.boolTrue != "" && str == r.boolTrue:
 		v = true
 	default:
 		r.err = fmt.Errorf("Unrecognized boolean: %s", str)
@@ -476,6 +547,21 @@ func (r *Reader) parseUint64(field array.Builder, str string) {
 	field.(*array.Uint64Builder).Append(v)
 }
 
+func (r *Reader) parseFloat16(field array.Builder, str string) {
+	if r.isNull(str) {
+		field.AppendNull()
+		return
+	}
+
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil && r.err == nil {
+		r.err = err
+		field.AppendNull()
+		return
+	}
+	field.(*array.Float16Builder).Append(float16.FromFloat64(v))
+}
+
 func (r *Reader) parseFloat32(field array.Builder, str string) {
 	if r.isNull(str) {
 		field.AppendNull()
@@ -507,6 +593,122 @@ func (r *Reader) parseFloat64(field array.Builder, str string) {
 	field.(*array.Float64Builder).Append(v)
 }
 
+func (r *Reader) parseDecimal128(field array.Builder, str string, dt *arrow.Decimal128Type) {
+	if r.isNull(str) {
+		field.AppendNull()
+		return
+	}
+
+	v, err := decimal128.FromString(str, dt.Precision, dt.Scale)
+	if err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		field.AppendNull()
+		return
+	}
+	field.(*array.Decimal128Builder).Append(v)
+}
+
+func (r *Reader) parseDecimal256(field array.Builder, str string, dt *arrow.Decimal256Type) {
+	if r.isNull(str) {
+		field.AppendNull()
+		return
+	}
+
+	v, err := decimal256.FromString(str, dt.Precision, dt.Scale)
+	if err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		field.AppendNull()
+		return
+	}
+	field.(*array.Decimal256Builder).Append(v)
+}
+
+// layouts returns the layouts tried to read the column name, starting with
+// its own layout, if any.
+func (r *Reader) layouts(name string, layouts []string) []string {
+	layout, ok := r.columnLayouts[name]
+	if !ok {
+		return layouts
+	}
+	return append([]string{layout}, layouts...)
+}
+
+func (r *Reader) parseDate32(field array.Builder, str string, layouts []string) {
+	if r.isNull(str) {
+		field.AppendNull()
+		return
+	}
+
+	t, ok := parseTime(str, layouts, time.UTC)
+	if !ok {
+		if r.err == nil {
+			r.err = fmt.Errorf("Unrecognized date: %s", str)
+		}
+		field.AppendNull()
+		return
+	}
+	field.(*array.Date32Builder).Append(arrow.Date32(t.Unix() / (24 * 60 * 60)))
+}
+
+func (r *Reader) parseTimestamp(field array.Builder, str string, unit arrow.TimeUnit, layouts []string, loc *time.Location) {
+	if r.isNull(str) {
+		field.AppendNull()
+		return
+	}
+
+	t, ok := parseTime(str, layouts, loc)
+	if !ok {
+		if r.err == nil {
+			r.err = fmt.Errorf("Unrecognized timestamp: %s", str)
+		}
+		field.AppendNull()
+		return
+	}
+	field.(*array.TimestampBuilder).Append(toTimestamp(t, unit))
+}
+
+// parseTime parses str with the first of layouts that matches it. Layouts
+// without a time zone are interpreted in loc.
+func parseTime(str string, layouts []string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, str, loc)
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// location returns the location of the time zone tz, or UTC if tz is empty
+// or unknown.
+func location(tz string) *time.Location {
+	if tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func toTimestamp(t time.Time, unit arrow.TimeUnit) arrow.Timestamp {
+	switch unit {
+	case arrow.Second:
+		return arrow.Timestamp(t.Unix())
+	case arrow.Millisecond:
+		return arrow.Timestamp(t.UnixNano() / int64(time.Millisecond))
+	case arrow.Microsecond:
+		return arrow.Timestamp(t.UnixNano() / int64(time.Microsecond))
+	default:
+		return arrow.Timestamp(t.UnixNano())
+	}
+}
+
 // Retain increases the reference count by 1.
 // Retain may be called simultaneously from multiple goroutines.
 func (r *Reader) Retain() {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/csv"
@@ -537,6 +538,201 @@ rec[0]["str"]: ["str-0" "str-1" "str-2" "str-3" "str-4" "str-5" "str-6" "str-7"
 	}
 }
 
+func TestInferringReader(t *testing.T) {
+	const data = `bools,ints,floats,mixed,dates,ts,zeros,nulls,amounts
+true,1,1.5,1,2021-01-02,2021-01-02T03:04:05Z,007,NA,1.25
+False,"2",2,x,2021-01-03,2021-01-02 03:04:05.5,10,,-2
+NA,NA,NA,NA,NA,NA,NA,NA,NA
+`
+
+	for _, tc := range []struct {
+		name   string
+		opts   []csv.Option
+		schema string
+		want   string
+	}{
+		{
+			name: "default",
+			opts: []csv.Option{csv.WithNullReader(true, "", "NA")},
+			schema: `schema:
+  fields: 9
+    - bools: type=bool, nullable
+    - ints: type=int64, nullable
+    - floats: type=float64, nullable
+    - mixed: type=utf8, nullable
+    - dates: type=date32, nullable
+    - ts: type=timestamp[ns], nullable
+    - zeros: type=int64, nullable
+    - nulls: type=utf8, nullable
+    - amounts: type=float64, nullable`,
+			want: `rec[0]["bools"]: [true false (null)]
+rec[0]["ints"]: [1 2 (null)]
+rec[0]["floats"]: [1.5 2 (null)]
+rec[0]["mixed"]: ["1" "x" (null)]
+rec[0]["dates"]: [18629 18630 (null)]
+rec[0]["ts"]: [1609556645000000000 1609556645500000000 (null)]
+rec[0]["zeros"]: [7 10 (null)]
+rec[0]["nulls"]: [(null) (null) (null)]
+rec[0]["amounts"]: [1.25 -2 (null)]
+`,
+		},
+		{
+			name: "leading-zeros-decimal",
+			opts: []csv.Option{
+				csv.WithNullReader(true, "", "NA"),
+				csv.WithLeadingZerosAsString(true),
+				csv.WithDecimalInference(10, 2),
+			},
+			schema: `schema:
+  fields: 9
+    - bools: type=bool, nullable
+    - ints: type=int64, nullable
+    - floats: type=decimal(10, 2), nullable
+    - mixed: type=utf8, nullable
+    - dates: type=date32, nullable
+    - ts: type=timestamp[ns], nullable
+    - zeros: type=utf8, nullable
+    - nulls: type=utf8, nullable
+    - amounts: type=decimal(10, 2), nullable`,
+			want: `rec[0]["bools"]: [true false (null)]
+rec[0]["ints"]: [1 2 (null)]
+rec[0]["floats"]: [{150 0} {200 0} (null)]
+rec[0]["mixed"]: ["1" "x" (null)]
+rec[0]["dates"]: [18629 18630 (null)]
+rec[0]["ts"]: [1609556645000000000 1609556645500000000 (null)]
+rec[0]["zeros"]: ["007" "10" (null)]
+rec[0]["nulls"]: [(null) (null) (null)]
+rec[0]["amounts"]: [{125 0} {18446744073709551416 -1} (null)]
+`,
+		},
+		{
+			name: "decimal256",
+			opts: []csv.Option{
+				csv.WithNullReader(true, "", "NA"),
+				csv.WithDecimalInference(50, 2),
+			},
+			schema: `schema:
+  fields: 9
+    - bools: type=bool, nullable
+    - ints: type=int64, nullable
+    - floats: type=decimal256(50, 2), nullable
+    - mixed: type=utf8, nullable
+    - dates: type=date32, nullable
+    - ts: type=timestamp[ns], nullable
+    - zeros: type=int64, nullable
+    - nulls: type=utf8, nullable
+    - amounts: type=decimal256(50, 2), nullable`,
+			want: `rec[0]["bools"]: [true false (null)]
+rec[0]["ints"]: [1 2 (null)]
+rec[0]["floats"]: [1.50 2.00 (null)]
+rec[0]["mixed"]: ["1" "x" (null)]
+rec[0]["dates"]: [18629 18630 (null)]
+rec[0]["ts"]: [1609556645000000000 1609556645500000000 (null)]
+rec[0]["zeros"]: [7 10 (null)]
+rec[0]["nulls"]: [(null) (null) (null)]
+rec[0]["amounts"]: [1.25 -2.00 (null)]
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			opts := append([]csv.Option{
+				csv.WithAllocator(mem), csv.WithHeader(true), csv.WithChunk(-1),
+			}, tc.opts...)
+
+			schema, err := csv.InferSchema(bytes.NewBufferString(data), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := schema.String(), tc.schema; got != want {
+				t.Fatalf("invalid inferred schema:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+
+			r := csv.NewInferringReader(bytes.NewBufferString(data), opts...)
+			defer r.Release()
+
+			if !r.Schema().Equal(schema) {
+				t.Fatalf("invalid schema: got=%v, want=%v", r.Schema(), schema)
+			}
+
+			out := new(bytes.Buffer)
+			n := 0
+			for r.Next() {
+				rec := r.Record()
+				for i, col := range rec.Columns() {
+					fmt.Fprintf(out, "rec[%d][%q]: %v\n", n, rec.ColumnName(i), col)
+				}
+				n++
+			}
+			if r.Err() != nil {
+				t.Fatalf("unexpected error: %v", r.Err())
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestInferringReaderRows(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// the conflicting value comes after the rows used for the inference.
+	r := csv.NewInferringReader(
+		bytes.NewBufferString("1;2\n3;4\nx;5\n"),
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithInferenceRows(2),
+	)
+	defer r.Release()
+
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "f0", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "f1", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}, nil)
+	if !r.Schema().Equal(want) {
+		t.Fatalf("invalid schema: got=%v, want=%v", r.Schema(), want)
+	}
+
+	for r.Next() {
+	}
+	if r.Err() == nil {
+		t.Fatalf("expected an error reading %q", "x")
+	}
+}
+
+func TestCSVReaderTimestampLayouts(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}},
+		{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+	}, nil)
+
+	r := csv.NewReader(
+		bytes.NewBufferString("02/01/2021 03:04:05,02/01/2021\n2021-01-02T03:04:05Z,\n"),
+		schema,
+		csv.WithAllocator(mem), csv.WithChunk(-1), csv.WithNullReader(false),
+		csv.WithTimestampLayouts("02/01/2006 15:04:05", time.RFC3339),
+		csv.WithDateLayouts("02/01/2006"),
+	)
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := fmt.Sprint(rec.Column(0)), "[1609556645 1609556645]"; got != want {
+		t.Fatalf("invalid timestamps: got=%s, want=%s", got, want)
+	}
+	if got, want := fmt.Sprint(rec.Column(1)), "[18629 (null)]"; got != want {
+		t.Fatalf("invalid dates: got=%s, want=%s", got, want)
+	}
+}
+
 func BenchmarkRead(b *testing.B) {
 	gen := func(rows, cols int) []byte {
 		buf := new(bytes.Buffer)
@@ -17,10 +17,14 @@
 package csv
 
 import (
+	"bufio"
 	"encoding/csv"
+	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
@@ -33,6 +37,18 @@ type Writer struct {
 	header    bool
 	once      sync.Once
 	nullValue string
+
+	boolTrue         string
+	boolFalse        string
+	timestampLayouts []string
+	dateLayouts      []string
+	columnLayouts    map[string]string
+
+	// quoting is the quoting mode. Rows are written with w, unless the
+	// mode is not QuoteMinimal, in which case they are written with raw.
+	quoting QuoteMode
+	raw     *bufio.Writer
+	err     error
 }
 
 // NewWriter returns a writer that writes array.Records to the CSV file
@@ -44,13 +60,20 @@ func NewWriter(w io.Writer, schema *arrow.Schema, opts ...Option) *Writer {
 	validate(schema)
 
 	ww := &Writer{
-		w:         csv.NewWriter(w),
-		schema:    schema,
-		nullValue: "NULL", // override by passing WithNullWriter() as an option
+		w:                csv.NewWriter(w),
+		schema:           schema,
+		nullValue:        "NULL", // override by passing WithNullWriter() as an option
+		boolTrue:         "true",
+		boolFalse:        "false",
+		timestampLayouts: DefaultTimestampLayouts,
+		dateLayouts:      DefaultDateLayouts,
 	}
 	for _, opt := range opts {
 		opt(ww)
 	}
+	if ww.quoting != QuoteMinimal {
+		ww.raw = bufio.NewWriter(w)
+	}
 
 	return ww
 }
@@ -84,7 +107,11 @@ func (w *Writer) Write(record array.Record) error {
 			arr := col.(*array.Boolean)
 			for i := 0; i < arr.Len(); i++ {
 				if arr.IsValid(i) {
-					recs[i][j] = strconv.FormatBool(arr.Value(i))
+					if arr.Value(i) {
+						recs[i][j] = w.boolTrue
+					} else {
+						recs[i][j] = w.boolFalse
+					}
 				} else {
 					recs[i][j] = w.nullValue
 				}
@@ -161,6 +188,15 @@ func (w *Writer) Write(record array.Record) error {
 					recs[i][j] = w.nullValue
 				}
 			}
+		case *arrow.Float16Type:
+			arr := col.(*array.Float16)
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					recs[i][j] = arr.Value(i).String()
+				} else {
+					recs[i][j] = w.nullValue
+				}
+			}
 		case *arrow.Float32Type:
 			arr := col.(*array.Float32)
 			for i := 0; i < arr.Len(); i++ {
@@ -179,6 +215,49 @@ func (w *Writer) Write(record array.Record) error {
 					recs[i][j] = w.nullValue
 				}
 			}
+		case *arrow.Decimal128Type:
+			arr := col.(*array.Decimal128)
+			scale := w.schema.Field(j).Type.(*arrow.Decimal128Type).Scale
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					recs[i][j] = arr.Value(i).ToString(scale)
+				} else {
+					recs[i][j] = w.nullValue
+				}
+			}
+		case *arrow.Decimal256Type:
+			arr := col.(*array.Decimal256)
+			scale := w.schema.Field(j).Type.(*arrow.Decimal256Type).Scale
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					recs[i][j] = arr.Value(i).ToString(scale)
+				} else {
+					recs[i][j] = w.nullValue
+				}
+			}
+		case *arrow.Date32Type:
+			arr := col.(*array.Date32)
+			layout := w.layout(w.schema.Field(j).Name, w.dateLayouts)
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					t := time.Unix(int64(arr.Value(i))*24*60*60, 0).UTC()
+					recs[i][j] = t.Format(layout)
+				} else {
+					recs[i][j] = w.nullValue
+				}
+			}
+		case *arrow.TimestampType:
+			arr := col.(*array.Timestamp)
+			dt := w.schema.Field(j).Type.(*arrow.TimestampType)
+			layout := w.layout(w.schema.Field(j).Name, w.timestampLayouts)
+			loc := location(dt.TimeZone)
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					recs[i][j] = fromTimestamp(arr.Value(i), dt.Unit).In(loc).Format(layout)
+				} else {
+					recs[i][j] = w.nullValue
+				}
+			}
 		case *arrow.StringType:
 			arr := col.(*array.String)
 			for i := 0; i < arr.Len(); i++ {
@@ -191,18 +270,42 @@ func (w *Writer) Write(record array.Record) error {
 		}
 	}
 
-	return w.w.WriteAll(recs)
+	return w.writeAll(recs)
+}
+
+// WriteTable writes the rows of the table tbl to the CSV file, reading its
+// columns by slices of at most chunkSize rows, without concatenating their
+// chunks. If chunkSize is <= 0, the biggest possible slices are read.
+func (w *Writer) WriteTable(tbl array.Table, chunkSize int64) error {
+	tr := array.NewTableReader(tbl, chunkSize)
+	defer tr.Release()
+
+	for tr.Next() {
+		if err := w.Write(tr.Record()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Flush writes any buffered data to the underlying csv Writer.
 // If an error occurred during the Flush, return it
 func (w *Writer) Flush() error {
+	if w.raw != nil {
+		if err := w.raw.Flush(); err != nil && w.err == nil {
+			w.err = err
+		}
+		return w.err
+	}
 	w.w.Flush()
 	return w.w.Error()
 }
 
 // Error reports any error that has occurred during a previous Write or Flush.
 func (w *Writer) Error() error {
+	if w.raw != nil {
+		return w.err
+	}
 	return w.w.Error()
 }
 
@@ -211,8 +314,64 @@ func (w *Writer) writeHeader() error {
 	for i := range headers {
 		headers[i] = w.schema.Field(i).Name
 	}
-	if err := w.w.Write(headers); err != nil {
-		return err
+	return w.writeAll([][]string{headers})
+}
+
+// layout returns the layout used to write the column name.
+func (w *Writer) layout(name string, layouts []string) string {
+	if layout, ok := w.columnLayouts[name]; ok {
+		return layout
+	}
+	return layouts[0]
+}
+
+// writeAll writes the rows recs with the quoting mode of the writer, and
+// flushes them as csv.Writer.WriteAll does.
+func (w *Writer) writeAll(recs [][]string) error {
+	if w.raw == nil {
+		return w.w.WriteAll(recs)
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	eol := "\n"
+	if w.w.UseCRLF {
+		eol = "\r\n"
+	}
+	for _, rec := range recs {
+		for i, field := range rec {
+			if i > 0 {
+				w.raw.WriteRune(w.w.Comma)
+			}
+			switch w.quoting {
+			case QuoteAlways:
+				w.raw.WriteByte('"')
+				w.raw.WriteString(strings.Replace(field, `"`, `""`, -1))
+				w.raw.WriteByte('"')
+			case QuoteNever:
+				if strings.ContainsRune(field, w.w.Comma) || strings.ContainsAny(field, "\"\r\n") {
+					w.err = fmt.Errorf("arrow/csv: field %q cannot be written without quotes", field)
+					return w.err
+				}
+				w.raw.WriteString(field)
+			}
+		}
+		w.raw.WriteString(eol)
+	}
+	w.err = w.raw.Flush()
+	return w.err
+}
+
+func fromTimestamp(v arrow.Timestamp, unit arrow.TimeUnit) time.Time {
+	switch unit {
+	case arrow.Second:
+		return time.Unix(int64(v), 0)
+	case arrow.Millisecond:
+		return time.Unix(0, int64(v)*int64(time.Millisecond))
+	case arrow.Microsecond:
+		return time.Unix(0, int64(v)*int64(time.Microsecond))
+	default:
+		return time.Unix(0, int64(v))
 	}
-	return nil
 }
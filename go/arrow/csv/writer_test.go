@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/csv"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
 	"github.com/apache/arrow/go/arrow/memory"
 )
 
@@ -214,6 +217,171 @@ null;null;null;null;null;null;null;null;null;null;null;null
 	}
 }
 
+func TestCSVWriterQuoting(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b,c", "d\"e", "f\ng"}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		mode csv.QuoteMode
+		want string
+		err  bool
+	}{
+		{
+			name: "minimal",
+			mode: csv.QuoteMinimal,
+			want: "1,a\n2,\"b,c\"\n3,\"d\"\"e\"\n4,\"f\ng\"\n",
+		},
+		{
+			name: "always",
+			mode: csv.QuoteAlways,
+			want: "\"1\",\"a\"\n\"2\",\"b,c\"\n\"3\",\"d\"\"e\"\n\"4\",\"f\ng\"\n",
+		},
+		{
+			name: "never",
+			mode: csv.QuoteNever,
+			err:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := new(bytes.Buffer)
+			w := csv.NewWriter(f, schema, csv.WithQuoting(tc.mode))
+			err := w.Write(rec)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := f.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot=%q\nwant=%q\n", got, want)
+			}
+
+			r := csv.NewReader(f, schema, csv.WithAllocator(pool), csv.WithChunk(-1))
+			defer r.Release()
+			if !r.Next() {
+				t.Fatalf("could not read record: %v", r.Err())
+			}
+			if !array.RecordEqual(r.Record(), rec) {
+				t.Fatalf("invalid record:\ngot=%v\nwant=%v\n", r.Record(), rec)
+			}
+		})
+	}
+}
+
+func TestCSVWriterTableRoundTrip(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "bool", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+			{Name: "dec", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}, Nullable: true},
+			{Name: "date", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+			{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, Nullable: true},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i := 0; i < 3; i++ {
+		b.Field(0).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+		b.Field(1).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(int64(i)*100 + 25), decimal128.FromI64(-1)}, nil)
+		b.Field(2).(*array.Date32Builder).AppendValues([]arrow.Date32{arrow.Date32(18629 + i), 0}, nil)
+		b.Field(3).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{arrow.Timestamp(1609556645123 + i), 0}, nil)
+		b.Field(4).(*array.StringBuilder).AppendValues([]string{fmt.Sprintf("str;%d", i), ""}, nil)
+		for _, field := range b.Fields() {
+			field.AppendNull()
+		}
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	opts := []csv.Option{
+		csv.WithComma(';'),
+		csv.WithHeader(true),
+		csv.WithBoolFormat("Y", "N"),
+		csv.WithDateLayouts("02/01/2006"),
+		csv.WithColumnLayout("ts", "2006-01-02 15:04:05.000"),
+		csv.WithNullWriter("NA"),
+	}
+
+	f := new(bytes.Buffer)
+	w := csv.NewWriter(f, schema, opts...)
+	if err := w.WriteTable(tbl, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `bool;dec;date;ts;str
+Y;0.25;02/01/2021;2021-01-02 03:04:05.123;"str;0"
+N;-0.01;01/01/1970;1970-01-01 00:00:00.000;
+NA;NA;NA;NA;NA
+Y;1.25;03/01/2021;2021-01-02 03:04:05.124;"str;1"
+N;-0.01;01/01/1970;1970-01-01 00:00:00.000;
+NA;NA;NA;NA;NA
+Y;2.25;04/01/2021;2021-01-02 03:04:05.125;"str;2"
+N;-0.01;01/01/1970;1970-01-01 00:00:00.000;
+NA;NA;NA;NA;NA
+`
+	if got := f.String(); got != want {
+		t.Fatalf("invalid output:\ngot=%s\nwant=%s\n", got, want)
+	}
+
+	r := csv.NewReader(f, schema, append(opts[:len(opts)-1],
+		csv.WithAllocator(pool), csv.WithChunk(3), csv.WithNullReader(true, "NA"),
+	)...)
+	defer r.Release()
+
+	n := 0
+	for r.Next() {
+		if !array.RecordEqual(r.Record(), recs[n]) {
+			t.Fatalf("invalid record %d:\ngot=%v\nwant=%v\n", n, r.Record(), recs[n])
+		}
+		n++
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+	}
+}
+
 func BenchmarkWrite(b *testing.B) {
 	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
 	defer pool.AssertSize(b, 0)
@@ -272,3 +440,104 @@ func BenchmarkWrite(b *testing.B) {
 		}
 	}
 }
+
+func TestCSVDecimal256RoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "dec", Type: &arrow.Decimal256Type{Precision: 60, Scale: 3}, Nullable: true},
+	}, nil)
+
+	const data = `dec
+123456789012345678901234567890123456789012345678901234567.890
+-0.001
+NA
+0.000
+`
+	r := csv.NewReader(bytes.NewBufferString(data), schema,
+		csv.WithAllocator(mem), csv.WithHeader(true), csv.WithChunk(-1),
+		csv.WithNullReader(true, "NA"),
+	)
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	dec := rec.Column(0).(*array.Decimal256)
+	want, err := decimal256.FromString("123456789012345678901234567890123456789012345678901234567.89", 60, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dec.Value(0); got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got.ToString(3), want.ToString(3))
+	}
+
+	out := new(bytes.Buffer)
+	w := csv.NewWriter(out, schema, csv.WithHeader(true), csv.WithNullWriter("NA"))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != data {
+		t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, data)
+	}
+
+	// values which do not fit in the precision are errors.
+	r = csv.NewReader(bytes.NewBufferString("1"+strings.Repeat("0", 57)+"\n"), schema, csv.WithAllocator(mem))
+	defer r.Release()
+	if r.Next(); r.Err() == nil {
+		t.Fatalf("expected an overflow error")
+	}
+}
+
+func TestCSVFloat16RoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "f16", Type: arrow.FixedWidthTypes.Float16, Nullable: true},
+	}, nil)
+
+	const data = `f16
+1.5
+-0
+5.9604645e-08
+65504
++Inf
+NaN
+NA
+`
+	r := csv.NewReader(bytes.NewBufferString(data), schema,
+		csv.WithAllocator(mem), csv.WithHeader(true), csv.WithChunk(-1),
+		csv.WithNullReader(true, "NA"),
+	)
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	bits := make([]uint16, rec.NumRows())
+	for i, v := range rec.Column(0).(*array.Float16).Values() {
+		bits[i] = v.Uint16()
+	}
+	if got, want := bits[:6], []uint16{0x3e00, 0x8000, 0x0001, 0x7bff, 0x7c00, 0x7e00}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%#04x, want=%#04x", got, want)
+	}
+
+	out := new(bytes.Buffer)
+	w := csv.NewWriter(out, schema, csv.WithHeader(true), csv.WithNullWriter("NA"))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != data {
+		t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, data)
+	}
+}
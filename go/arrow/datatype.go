@@ -120,6 +120,21 @@ const (
 	// Measure of elapsed time in either seconds, milliseconds, microseconds
 	// or nanoseconds.
 	DURATION
+
+	// RUN_END_ENCODED is a run-length encoding of some logical type, the
+	// values of a run being stored once along with the index of its end
+	RUN_END_ENCODED
+
+	// BINARY_VIEW is a variable-length byte type whose values are stored
+	// inline when short, or else referenced in one of several data buffers
+	BINARY_VIEW
+
+	// STRING_VIEW is a UTF8 variable-length string stored as BINARY_VIEW
+	STRING_VIEW
+
+	// DECIMAL256 is a precision- and scale-based decimal type, stored as a
+	// 256-bit integer
+	DECIMAL256
 )
 
 // DataType is the representation of an Arrow type.
@@ -141,3 +156,10 @@ type BinaryDataType interface {
 	DataType
 	binary()
 }
+
+// BinaryViewDataType is the representation of the variable-length binary
+// types whose values are stored as views, see ViewHeader.
+type BinaryViewDataType interface {
+	DataType
+	binaryView()
+}
@@ -30,12 +30,34 @@ func (t *StringType) Name() string   { return "utf8" }
 func (t *StringType) String() string { return "utf8" }
 func (t *StringType) binary()        {}
 
+// BinaryViewType is the type of the variable-length binary values stored
+// as views, see ViewHeader.
+type BinaryViewType struct{}
+
+func (t *BinaryViewType) ID() Type       { return BINARY_VIEW }
+func (t *BinaryViewType) Name() string   { return "binary_view" }
+func (t *BinaryViewType) String() string { return "binary_view" }
+func (t *BinaryViewType) binaryView()    {}
+
+// StringViewType is the type of the UTF8 strings stored as views, see
+// ViewHeader.
+type StringViewType struct{}
+
+func (t *StringViewType) ID() Type       { return STRING_VIEW }
+func (t *StringViewType) Name() string   { return "string_view" }
+func (t *StringViewType) String() string { return "string_view" }
+func (t *StringViewType) binaryView()    {}
+
 var (
 	BinaryTypes = struct {
-		Binary BinaryDataType
-		String BinaryDataType
+		Binary     BinaryDataType
+		String     BinaryDataType
+		BinaryView BinaryViewDataType
+		StringView BinaryViewDataType
 	}{
-		Binary: &BinaryType{},
-		String: &StringType{},
+		Binary:     &BinaryType{},
+		String:     &StringType{},
+		BinaryView: &BinaryViewType{},
+		StringView: &StringViewType{},
 	}
 )
@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+func TestDictionaryType(t *testing.T) {
+	for _, tc := range []struct {
+		dt   *arrow.DictionaryType
+		want string
+	}{
+		{
+			&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String},
+			"dictionary<values=utf8, indices=int32, ordered=false>",
+		},
+		{
+			&arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.PrimitiveTypes.Float64, Ordered: true},
+			"dictionary<values=float64, indices=int8, ordered=true>",
+		},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			if got, want := tc.dt.ID(), arrow.DICTIONARY; got != want {
+				t.Fatalf("invalid type ID: got=%v, want=%v", got, want)
+			}
+
+			if got, want := tc.dt.Name(), "dictionary"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+
+			if got, want := tc.dt.String(), tc.want; got != want {
+				t.Fatalf("invalid stringer: got=%q, want=%q", got, want)
+			}
+		})
+	}
+
+	a := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}
+	b := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}
+	c := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}
+	if !arrow.TypeEqual(a, b) {
+		t.Fatalf("%v and %v should be equal", a, b)
+	}
+	if arrow.TypeEqual(a, c) {
+		t.Fatalf("%v and %v should differ", a, c)
+	}
+}
@@ -0,0 +1,152 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"reflect"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// ExtensionNameKey is the key of the field metadata holding the name of
+	// the extension type of a field in IPC streams and files.
+	ExtensionNameKey = "ARROW:extension:name"
+
+	// ExtensionMetadataKey is the key of the field metadata holding the
+	// serialized parameters of the extension type of a field in IPC streams
+	// and files.
+	ExtensionMetadataKey = "ARROW:extension:metadata"
+)
+
+// ExtensionType is a user-defined logical type, whose values are stored as
+// an array of its storage type.
+//
+// In IPC streams and files, a field of an extension type is written with
+// its storage type, the name and serialized parameters of the extension
+// type being kept in the ExtensionNameKey and ExtensionMetadataKey
+// metadata of the field. Readers reconstruct the extension types
+// registered with RegisterExtensionType, or with a per-reader registry,
+// and read the other ones as their storage type, keeping the metadata.
+type ExtensionType interface {
+	DataType
+
+	// ArrayType returns the type of the arrays of the extension type: a
+	// struct type embedding array.ExtensionArrayBase, which the arrays of
+	// the extension type are created as a pointer to.
+	ArrayType() reflect.Type
+
+	// ExtensionName returns the name identifying the extension type.
+	ExtensionName() string
+
+	// StorageType returns the type of the arrays storing the values.
+	StorageType() DataType
+
+	// ExtensionEquals returns whether the extension type and other, of the
+	// same name, are equal.
+	ExtensionEquals(other ExtensionType) bool
+
+	// Serialize returns the parameters of the extension type, written in
+	// the ExtensionMetadataKey metadata of its fields.
+	Serialize() string
+
+	// Deserialize returns an extension type of the same name, with the
+	// given storage type and parameters, as returned by Serialize.
+	Deserialize(storage DataType, data string) (ExtensionType, error)
+}
+
+// ExtensionBase provides the ID and StorageType methods of extension types
+// embedding it.
+type ExtensionBase struct {
+	Storage DataType // type of the arrays storing the values
+}
+
+func (*ExtensionBase) ID() Type { return EXTENSION }
+
+// StorageType returns the type of the arrays storing the values.
+func (e *ExtensionBase) StorageType() DataType { return e.Storage }
+
+// ExtensionTypeRegistry maps the names of extension types to the types
+// reconstructed when reading them.
+// An ExtensionTypeRegistry may be used simultaneously from multiple
+// goroutines.
+type ExtensionTypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]ExtensionType
+}
+
+// NewExtensionTypeRegistry returns an empty registry.
+func NewExtensionTypeRegistry() *ExtensionTypeRegistry {
+	return &ExtensionTypeRegistry{types: make(map[string]ExtensionType)}
+}
+
+// Register adds typ to the registry, under its extension name.
+// Register fails if a type of the same name is already registered.
+func (r *ExtensionTypeRegistry) Register(typ ExtensionType) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := typ.ExtensionName()
+	if _, dup := r.types[name]; dup {
+		return xerrors.Errorf("arrow: extension type %q already registered", name)
+	}
+	r.types[name] = typ
+	return nil
+}
+
+// Unregister removes the type registered under name.
+// Unregister fails if no type is registered under name.
+func (r *ExtensionTypeRegistry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.types[name]; !ok {
+		return xerrors.Errorf("arrow: extension type %q not registered", name)
+	}
+	delete(r.types, name)
+	return nil
+}
+
+// Get returns the type registered under name, or nil.
+func (r *ExtensionTypeRegistry) Get(name string) ExtensionType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.types[name]
+}
+
+var extensionTypes = NewExtensionTypeRegistry()
+
+// RegisterExtensionType adds typ to the global registry of extension types,
+// reconstructed by all readers.
+// RegisterExtensionType fails if a type of the same name is already
+// registered.
+func RegisterExtensionType(typ ExtensionType) error {
+	return extensionTypes.Register(typ)
+}
+
+// UnregisterExtensionType removes the type registered under name from the
+// global registry of extension types.
+func UnregisterExtensionType(name string) error {
+	return extensionTypes.Unregister(name)
+}
+
+// GetExtensionType returns the type registered under name in the global
+// registry of extension types, or nil.
+func GetExtensionType(name string) ExtensionType {
+	return extensionTypes.Get(name)
+}
@@ -0,0 +1,104 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+type tagType struct {
+	arrow.ExtensionBase
+	tag string
+}
+
+func newTagType(tag string) *tagType {
+	return &tagType{ExtensionBase: arrow.ExtensionBase{Storage: arrow.BinaryTypes.String}, tag: tag}
+}
+
+func (*tagType) Name() string            { return "extension" }
+func (*tagType) ExtensionName() string   { return "test.tag" }
+func (t *tagType) Serialize() string     { return t.tag }
+func (*tagType) ArrayType() reflect.Type { return nil }
+func (t *tagType) ExtensionEquals(o arrow.ExtensionType) bool {
+	return t.tag == o.(*tagType).tag
+}
+func (*tagType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	return newTagType(data), nil
+}
+
+func TestExtensionTypeRegistry(t *testing.T) {
+	reg := arrow.NewExtensionTypeRegistry()
+	if got := reg.Get("test.tag"); got != nil {
+		t.Fatalf("unexpected type %v", got)
+	}
+
+	typ := newTagType("a")
+	if err := reg.Register(typ); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Register(newTagType("b")); err == nil {
+		t.Fatalf("expected an error registering a type twice")
+	}
+	if got := reg.Get("test.tag"); got != typ {
+		t.Fatalf("invalid type: got=%v, want=%v", got, typ)
+	}
+	if got := arrow.GetExtensionType("test.tag"); got != nil {
+		t.Fatalf("type should not be registered globally: %v", got)
+	}
+
+	if err := reg.Unregister("test.tag"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Unregister("test.tag"); err == nil {
+		t.Fatalf("expected an error unregistering a type twice")
+	}
+	if got := reg.Get("test.tag"); got != nil {
+		t.Fatalf("unexpected type %v", got)
+	}
+}
+
+func TestRegisterExtensionType(t *testing.T) {
+	typ := newTagType("a")
+	if err := arrow.RegisterExtensionType(typ); err != nil {
+		t.Fatal(err)
+	}
+	defer arrow.UnregisterExtensionType("test.tag")
+
+	if got := arrow.GetExtensionType("test.tag"); got != typ {
+		t.Fatalf("invalid type: got=%v, want=%v", got, typ)
+	}
+}
+
+func TestExtensionTypeEqual(t *testing.T) {
+	for _, tc := range []struct {
+		left, right arrow.DataType
+		want        bool
+	}{
+		{newTagType("a"), newTagType("a"), true},
+		{newTagType("a"), newTagType("b"), false},
+		{newTagType("a"), arrow.BinaryTypes.String, false},
+		{arrow.StructOf(arrow.Field{Name: "f", Type: newTagType("a")}), arrow.StructOf(arrow.Field{Name: "f", Type: newTagType("a")}), true},
+		{arrow.StructOf(arrow.Field{Name: "f", Type: newTagType("a")}), arrow.StructOf(arrow.Field{Name: "f", Type: newTagType("b")}), false},
+	} {
+		if got := arrow.TypeEqual(tc.left, tc.right); got != tc.want {
+			t.Errorf("TypeEqual(%v, %v) = %v, want %v", tc.left, tc.right, got, tc.want)
+		}
+	}
+}
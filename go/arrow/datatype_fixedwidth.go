@@ -18,7 +18,12 @@ package arrow
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
 )
 
 type BooleanType struct{}
@@ -61,6 +66,149 @@ const (
 
 func (u TimeUnit) String() string { return [...]string{"ns", "us", "ms", "s"}[uint(u)&3] }
 
+// Multiplier returns the duration of one u.
+func (u TimeUnit) Multiplier() time.Duration {
+	return [...]time.Duration{time.Nanosecond, time.Microsecond, time.Millisecond, time.Second}[uint(u)&3]
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// ToTime returns the instant of t, a number of unit since the UNIX epoch, in UTC.
+func (t Timestamp) ToTime(unit TimeUnit) time.Time {
+	return unitToTime(int64(t), unit).UTC()
+}
+
+// TimestampFromTime returns the instant t as a number of unit since the
+// UNIX epoch. The precision of t finer than unit is truncated towards the
+// past. It returns an error if t is out of the range of timestamps in unit.
+func TimestampFromTime(t time.Time, unit TimeUnit) (Timestamp, error) {
+	v, ok := timeToUnit(t, unit)
+	if !ok {
+		return 0, xerrors.Errorf("arrow: time %v out of range of timestamp[%v]", t, unit)
+	}
+	return Timestamp(v), nil
+}
+
+// ToTime returns the date d at midnight UTC.
+func (d Date32) ToTime() time.Time {
+	return time.Unix(int64(d)*secondsPerDay, 0).UTC()
+}
+
+// Date32FromTime returns the calendar date of t in its location.
+func Date32FromTime(t time.Time) Date32 {
+	return Date32(civilDays(t))
+}
+
+// ToTime returns the date d, a number of milliseconds since the UNIX epoch, in UTC.
+func (d Date64) ToTime() time.Time {
+	return unitToTime(int64(d), Millisecond).UTC()
+}
+
+// Date64FromTime returns the calendar date of t in its location, at midnight.
+func Date64FromTime(t time.Time) Date64 {
+	return Date64(civilDays(t) * secondsPerDay * 1000)
+}
+
+// ToTime returns the time of day t, a number of unit since midnight, on
+// the 1st of January 1970 UTC.
+func (t Time32) ToTime(unit TimeUnit) time.Time {
+	return unitToTime(int64(t), unit).UTC()
+}
+
+// Time32FromTime returns the time of day of t in its location as a number
+// of unit since midnight, truncating the precision finer than unit.
+func Time32FromTime(t time.Time, unit TimeUnit) Time32 {
+	return Time32(timeOfDay(t) / int64(unit.Multiplier()))
+}
+
+// ToTime returns the time of day t, a number of unit since midnight, on
+// the 1st of January 1970 UTC.
+func (t Time64) ToTime(unit TimeUnit) time.Time {
+	return unitToTime(int64(t), unit).UTC()
+}
+
+// Time64FromTime returns the time of day of t in its location as a number
+// of unit since midnight, truncating the precision finer than unit.
+func Time64FromTime(t time.Time, unit TimeUnit) Time64 {
+	return Time64(timeOfDay(t) / int64(unit.Multiplier()))
+}
+
+// ToDuration returns d, a number of unit, as a time.Duration.
+// Durations beyond about 292 years overflow.
+func (d Duration) ToDuration(unit TimeUnit) time.Duration {
+	return time.Duration(d) * unit.Multiplier()
+}
+
+// DurationFromTime returns d as a number of unit, truncated towards zero.
+func DurationFromTime(d time.Duration, unit TimeUnit) Duration {
+	return Duration(d / unit.Multiplier())
+}
+
+func unitToTime(v int64, unit TimeUnit) time.Time {
+	per := int64(time.Second / unit.Multiplier())
+	return time.Unix(v/per, v%per*int64(unit.Multiplier()))
+}
+
+func timeToUnit(t time.Time, unit TimeUnit) (int64, bool) {
+	var (
+		per  = int64(time.Second / unit.Multiplier())
+		sec  = t.Unix()
+		frac = int64(t.Nanosecond()) / int64(unit.Multiplier())
+	)
+	if sec > (math.MaxInt64-frac)/per || sec < math.MinInt64/per {
+		return 0, false
+	}
+	return sec*per + frac, true
+}
+
+func civilDays(t time.Time) int64 {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay
+}
+
+func timeOfDay(t time.Time) int64 {
+	h, m, s := t.Clock()
+	return (int64(h)*3600+int64(m)*60+int64(s))*int64(time.Second) + int64(t.Nanosecond())
+}
+
+var locations sync.Map // time zone -> *time.Location
+
+// LoadLocation returns the location of the time zone tz of a timestamp
+// type, which is either empty or "UTC", a name of the IANA time zone
+// database, or a fixed offset from UTC such as "+05:30" or "-08:00".
+// Locations are cached.
+func LoadLocation(tz string) (*time.Location, error) {
+	if tz == "" || tz == "UTC" {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(tz); ok {
+		return loc.(*time.Location), nil
+	}
+
+	var loc *time.Location
+	switch {
+	case len(tz) == 6 && (tz[0] == '+' || tz[0] == '-') && tz[3] == ':':
+		hh, err1 := strconv.Atoi(tz[1:3])
+		mm, err2 := strconv.Atoi(tz[4:6])
+		if err1 != nil || err2 != nil || tz[1] == '+' || tz[1] == '-' || hh > 23 || mm > 59 {
+			return nil, xerrors.Errorf("arrow: invalid time zone offset %q", tz)
+		}
+		offset := hh*3600 + mm*60
+		if tz[0] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone(tz, offset)
+	default:
+		var err error
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow: unknown time zone %q: %w", tz, err)
+		}
+	}
+	locations.Store(tz, loc)
+	return loc, nil
+}
+
 // TimestampType is encoded as a 64-bit signed integer since the UNIX epoch (2017-01-01T00:00:00Z).
 // The zero-value is a nanosecond and time zone neutral. Time zone neutral can be
 // considered UTC without having "UTC" as a time zone.
@@ -83,6 +231,30 @@ func (t *TimestampType) String() string {
 // BitWidth returns the number of bits required to store a single element of this data type in memory.
 func (*TimestampType) BitWidth() int { return 64 }
 
+// NewTimestampType returns the timestamp type of unit in the time zone tz,
+// or an error if tz is not a valid time zone, see LoadLocation.
+func NewTimestampType(unit TimeUnit, tz string) (*TimestampType, error) {
+	if _, err := LoadLocation(tz); err != nil {
+		return nil, err
+	}
+	return &TimestampType{Unit: unit, TimeZone: tz}, nil
+}
+
+// Location returns the location of the time zone of t.
+// Time zone neutral timestamps are in UTC.
+func (t *TimestampType) Location() (*time.Location, error) {
+	return LoadLocation(t.TimeZone)
+}
+
+// ToTime returns the instant of v in the time zone of t.
+func (t *TimestampType) ToTime(v Timestamp) (time.Time, error) {
+	loc, err := t.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return v.ToTime(t.Unit).In(loc), nil
+}
+
 // Time32Type is encoded as a 32-bit signed integer, representing either seconds or milliseconds since midnight.
 type Time32Type struct {
 	Unit TimeUnit
@@ -137,6 +309,19 @@ func (t *Decimal128Type) String() string {
 	return fmt.Sprintf("%s(%d, %d)", t.Name(), t.Precision, t.Scale)
 }
 
+// Decimal256Type represents a fixed-size 256-bit decimal type.
+type Decimal256Type struct {
+	Precision int32
+	Scale     int32
+}
+
+func (*Decimal256Type) ID() Type      { return DECIMAL256 }
+func (*Decimal256Type) Name() string  { return "decimal256" }
+func (*Decimal256Type) BitWidth() int { return 256 }
+func (t *Decimal256Type) String() string {
+	return fmt.Sprintf("%s(%d, %d)", t.Name(), t.Precision, t.Scale)
+}
+
 // MonthInterval represents a number of months.
 type MonthInterval int32
 
@@ -17,7 +17,9 @@
 package arrow_test
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/stretchr/testify/assert"
@@ -295,3 +297,188 @@ func TestMonthIntervalType(t *testing.T) {
 		t.Fatalf("invalid type stringer: got=%q, want=%q", got, want)
 	}
 }
+
+func TestTimestampRoundTrip(t *testing.T) {
+	tm := time.Date(2021, time.March, 14, 15, 9, 26, 535897932, time.UTC)
+	for _, tc := range []struct {
+		unit arrow.TimeUnit
+		v    arrow.Timestamp
+		want time.Time
+	}{
+		{arrow.Second, 1615734566, tm.Truncate(time.Second)},
+		{arrow.Millisecond, 1615734566535, tm.Truncate(time.Millisecond)},
+		{arrow.Microsecond, 1615734566535897, tm.Truncate(time.Microsecond)},
+		{arrow.Nanosecond, 1615734566535897932, tm},
+	} {
+		t.Run(tc.unit.String(), func(t *testing.T) {
+			v, err := arrow.TimestampFromTime(tm, tc.unit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != tc.v {
+				t.Fatalf("invalid timestamp: got=%d, want=%d", v, tc.v)
+			}
+			if got := v.ToTime(tc.unit); !got.Equal(tc.want) {
+				t.Fatalf("invalid time: got=%v, want=%v", got, tc.want)
+			}
+
+			// before the epoch, the precision is truncated towards the past.
+			neg := time.Unix(-1, 999999999)
+			v, err = arrow.TimestampFromTime(neg, tc.unit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := v.ToTime(tc.unit), neg.Truncate(tc.unit.Multiplier()); !got.Equal(want) {
+				t.Fatalf("invalid time before the epoch: got=%v, want=%v", got, want)
+			}
+		})
+	}
+
+	if _, err := arrow.TimestampFromTime(time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC), arrow.Nanosecond); err == nil {
+		t.Fatalf("expected an error for an out of range timestamp")
+	}
+	if _, err := arrow.TimestampFromTime(time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), arrow.Nanosecond); err == nil {
+		t.Fatalf("expected an error for an out of range timestamp")
+	}
+	v, err := arrow.TimestampFromTime(time.Unix(math.MaxInt64/1000, 807000000), arrow.Millisecond)
+	if err != nil || v != math.MaxInt64 {
+		t.Fatalf("invalid maximum timestamp: got=%d, err=%v", v, err)
+	}
+}
+
+func TestTimestampTypeTimeZone(t *testing.T) {
+	for _, tc := range []struct {
+		tz     string
+		offset int
+		err    bool
+	}{
+		{tz: "", offset: 0},
+		{tz: "UTC", offset: 0},
+		{tz: "+05:30", offset: 5*3600 + 30*60},
+		{tz: "-08:00", offset: -8 * 3600},
+		{tz: "Asia/Tokyo", offset: 9 * 3600},
+		{tz: "Not/AZone", err: true},
+		{tz: "+24:00", err: true},
+		{tz: "+5:30", err: true},
+		{tz: "+05:60", err: true},
+		{tz: "+-5:00", err: true},
+	} {
+		t.Run(tc.tz, func(t *testing.T) {
+			dt, err := arrow.NewTimestampType(arrow.Millisecond, tc.tz)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error for time zone %q", tc.tz)
+				}
+				_, err = (&arrow.TimestampType{TimeZone: tc.tz}).ToTime(0)
+				if err == nil {
+					t.Fatalf("expected an error for time zone %q", tc.tz)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := dt.ToTime(1615734566535)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, offset := got.Zone(); offset != tc.offset {
+				t.Fatalf("invalid offset: got=%d, want=%d", offset, tc.offset)
+			}
+			if want := time.Unix(1615734566, 535000000); !got.Equal(want) {
+				t.Fatalf("invalid time: got=%v, want=%v", got, want)
+			}
+
+			loc, err := dt.Location()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again, _ := arrow.LoadLocation(tc.tz); again != loc {
+				t.Fatalf("location of %q is not cached", tc.tz)
+			}
+		})
+	}
+}
+
+func TestDateTimeRoundTrip(t *testing.T) {
+	loc, err := arrow.LoadLocation("-08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2021-03-14 23:09:26.535897932 in UTC-8 is already the 15th in UTC.
+	tm := time.Date(2021, time.March, 14, 23, 9, 26, 535897932, loc)
+	day := time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+	d32 := arrow.Date32FromTime(tm)
+	if got, want := d32, arrow.Date32(18700); got != want {
+		t.Fatalf("invalid date32: got=%d, want=%d", got, want)
+	}
+	if got := d32.ToTime(); !got.Equal(day) {
+		t.Fatalf("invalid date32 time: got=%v, want=%v", got, day)
+	}
+	d64 := arrow.Date64FromTime(tm)
+	if got, want := d64, arrow.Date64(18700*86400000); got != want {
+		t.Fatalf("invalid date64: got=%d, want=%d", got, want)
+	}
+	if got := d64.ToTime(); !got.Equal(day) {
+		t.Fatalf("invalid date64 time: got=%v, want=%v", got, day)
+	}
+	if got, want := arrow.Date32FromTime(time.Unix(-1, 0).UTC()), arrow.Date32(-1); got != want {
+		t.Fatalf("invalid date32 before the epoch: got=%d, want=%d", got, want)
+	}
+
+	clock := time.Date(1970, time.January, 1, 23, 9, 26, 535897932, time.UTC)
+	for _, tc := range []struct {
+		unit arrow.TimeUnit
+		want int64
+	}{
+		{arrow.Second, 83366},
+		{arrow.Millisecond, 83366535},
+	} {
+		v := arrow.Time32FromTime(tm, tc.unit)
+		if int64(v) != tc.want {
+			t.Fatalf("invalid time32[%v]: got=%d, want=%d", tc.unit, v, tc.want)
+		}
+		if got, want := v.ToTime(tc.unit), clock.Truncate(tc.unit.Multiplier()); !got.Equal(want) {
+			t.Fatalf("invalid time32[%v] time: got=%v, want=%v", tc.unit, got, want)
+		}
+	}
+	for _, tc := range []struct {
+		unit arrow.TimeUnit
+		want int64
+	}{
+		{arrow.Microsecond, 83366535897},
+		{arrow.Nanosecond, 83366535897932},
+	} {
+		v := arrow.Time64FromTime(tm, tc.unit)
+		if int64(v) != tc.want {
+			t.Fatalf("invalid time64[%v]: got=%d, want=%d", tc.unit, v, tc.want)
+		}
+		if got, want := v.ToTime(tc.unit), clock.Truncate(tc.unit.Multiplier()); !got.Equal(want) {
+			t.Fatalf("invalid time64[%v] time: got=%v, want=%v", tc.unit, got, want)
+		}
+	}
+
+	d := 90*time.Minute + 1500*time.Microsecond
+	for _, tc := range []struct {
+		unit arrow.TimeUnit
+		want arrow.Duration
+	}{
+		{arrow.Second, 5400},
+		{arrow.Millisecond, 5400001},
+		{arrow.Microsecond, 5400001500},
+		{arrow.Nanosecond, 5400001500000},
+	} {
+		v := arrow.DurationFromTime(d, tc.unit)
+		if v != tc.want {
+			t.Fatalf("invalid duration[%v]: got=%d, want=%d", tc.unit, v, tc.want)
+		}
+		if got, want := v.ToDuration(tc.unit), d.Truncate(tc.unit.Multiplier()); got != want {
+			t.Fatalf("invalid duration[%v] time: got=%v, want=%v", tc.unit, got, want)
+		}
+	}
+	if got, want := arrow.DurationFromTime(-1500*time.Microsecond, arrow.Millisecond), arrow.Duration(-1); got != want {
+		t.Fatalf("invalid negative duration: got=%d, want=%d", got, want)
+	}
+}
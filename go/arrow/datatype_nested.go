@@ -80,6 +80,45 @@ func (t *FixedSizeListType) Elem() DataType { return t.elem }
 // Len returns the FixedSizeListType's size.
 func (t *FixedSizeListType) Len() int32 { return t.n }
 
+// RunEndEncodedType describes a nested type in which consecutive equal
+// values are stored once, along with the logical index at which their
+// run ends.
+type RunEndEncodedType struct {
+	ends   DataType // DataType of the run ends, a signed integer type
+	values DataType // DataType of the encoded values
+}
+
+// RunEndEncodedOf returns the run-end encoded type with run ends of type
+// runEnds and values of type values.
+// For example, RunEndEncodedOf(PrimitiveTypes.Int32, BinaryTypes.String)
+// represents runs of strings ending at int32 indices.
+//
+// RunEndEncodedOf panics if runEnds or values is nil.
+// RunEndEncodedOf panics if runEnds is not an int16, int32 or int64 type.
+func RunEndEncodedOf(runEnds, values DataType) *RunEndEncodedType {
+	if runEnds == nil || values == nil {
+		panic("arrow: nil DataType")
+	}
+	switch runEnds.ID() {
+	case INT16, INT32, INT64:
+	default:
+		panic(fmt.Errorf("arrow: invalid run ends type %v", runEnds))
+	}
+	return &RunEndEncodedType{ends: runEnds, values: values}
+}
+
+func (*RunEndEncodedType) ID() Type     { return RUN_END_ENCODED }
+func (*RunEndEncodedType) Name() string { return "run_end_encoded" }
+func (t *RunEndEncodedType) String() string {
+	return fmt.Sprintf("run_end_encoded<run_ends: %v, values: %v>", t.ends, t.values)
+}
+
+// RunEnds returns the RunEndEncodedType's run ends type.
+func (t *RunEndEncodedType) RunEnds() DataType { return t.ends }
+
+// Encoded returns the RunEndEncodedType's values type.
+func (t *RunEndEncodedType) Encoded() DataType { return t.values }
+
 // StructType describes a nested type parameterized by an ordered sequence
 // of relative types, called its fields.
 type StructType struct {
@@ -148,6 +187,148 @@ func (t *StructType) FieldByName(name string) (Field, bool) {
 	return t.fields[i], true
 }
 
+// UnionMode is the memory layout of a union type.
+type UnionMode int8
+
+const (
+	SparseMode UnionMode = iota // children have the length of the union
+	DenseMode                   // children are indexed by an offsets buffer
+)
+
+func (m UnionMode) String() string {
+	switch m {
+	case SparseMode:
+		return "sparse"
+	case DenseMode:
+		return "dense"
+	}
+	return fmt.Sprintf("UnionMode(%d)", int8(m))
+}
+
+// UnionTypeCode is the code identifying the child of a union type holding
+// a value of a union array.
+type UnionTypeCode = int8
+
+// MaxUnionTypeCode is the greatest valid union type code.
+const MaxUnionTypeCode UnionTypeCode = 127
+
+// InvalidUnionChildID is the child ID of the type codes not used by a
+// union type.
+const InvalidUnionChildID = -1
+
+// UnionType describes a nested type whose values each have the type of one
+// of its fields. The field of a value is identified by a type code, the
+// codes of the fields are not necessarily contiguous or sorted.
+type UnionType struct {
+	mode     UnionMode
+	fields   []Field
+	codes    []UnionTypeCode
+	childIDs [int(MaxUnionTypeCode) + 1]int
+}
+
+// UnionOf returns the union type of the given mode, with fields fs
+// identified by the type codes codes. If codes is nil, the fields are
+// identified by their position.
+// For example, SparseUnionOf([]Field{{Name: "a", Type: PrimitiveTypes.Int32}},
+// []UnionTypeCode{5}) represents int32 values identified by the code 5.
+//
+// UnionOf panics if there are not as many codes as fields, if a code is
+// negative or duplicated, or if a field has a nil DataType.
+func UnionOf(mode UnionMode, fs []Field, codes []UnionTypeCode) *UnionType {
+	if mode != SparseMode && mode != DenseMode {
+		panic(fmt.Errorf("arrow: invalid union mode %v", mode))
+	}
+	if codes == nil {
+		if len(fs) > int(MaxUnionTypeCode)+1 {
+			panic(fmt.Errorf("arrow: too many union fields (%d)", len(fs)))
+		}
+		codes = make([]UnionTypeCode, len(fs))
+		for i := range codes {
+			codes[i] = UnionTypeCode(i)
+		}
+	}
+	if len(codes) != len(fs) {
+		panic(fmt.Errorf("arrow: union with %d fields and %d type codes", len(fs), len(codes)))
+	}
+
+	t := &UnionType{
+		mode:   mode,
+		fields: make([]Field, len(fs)),
+		codes:  append([]UnionTypeCode{}, codes...),
+	}
+	for i := range t.childIDs {
+		t.childIDs[i] = InvalidUnionChildID
+	}
+	for i, f := range fs {
+		if f.Type == nil {
+			panic("arrow: field with nil DataType")
+		}
+		code := codes[i]
+		switch {
+		case code < 0:
+			panic(fmt.Errorf("arrow: invalid union type code %d", code))
+		case t.childIDs[code] != InvalidUnionChildID:
+			panic(fmt.Errorf("arrow: duplicate union type code %d", code))
+		}
+		t.childIDs[code] = i
+		t.fields[i] = Field{
+			Name:     f.Name,
+			Type:     f.Type,
+			Nullable: f.Nullable,
+			Metadata: f.Metadata.clone(),
+		}
+	}
+	return t
+}
+
+// SparseUnionOf returns the sparse union type with fields fs identified
+// by codes, see UnionOf.
+func SparseUnionOf(fs []Field, codes []UnionTypeCode) *UnionType {
+	return UnionOf(SparseMode, fs, codes)
+}
+
+// DenseUnionOf returns the dense union type with fields fs identified by
+// codes, see UnionOf.
+func DenseUnionOf(fs []Field, codes []UnionTypeCode) *UnionType {
+	return UnionOf(DenseMode, fs, codes)
+}
+
+func (*UnionType) ID() Type { return UNION }
+
+func (t *UnionType) Name() string { return t.mode.String() + "_union" }
+
+func (t *UnionType) String() string {
+	o := new(strings.Builder)
+	o.WriteString(t.Name())
+	o.WriteString("<")
+	for i, f := range t.fields {
+		if i > 0 {
+			o.WriteString(", ")
+		}
+		fmt.Fprintf(o, "%s: %v=%d", f.Name, f.Type, t.codes[i])
+	}
+	o.WriteString(">")
+	return o.String()
+}
+
+// Mode returns the memory layout of the union type.
+func (t *UnionType) Mode() UnionMode { return t.mode }
+
+func (t *UnionType) Fields() []Field   { return t.fields }
+func (t *UnionType) Field(i int) Field { return t.fields[i] }
+
+// TypeCodes returns the type code of each field.
+func (t *UnionType) TypeCodes() []UnionTypeCode { return t.codes }
+
+// ChildID returns the index of the field identified by code, or
+// InvalidUnionChildID if code is not used.
+func (t *UnionType) ChildID(code UnionTypeCode) int {
+	if code < 0 {
+		return InvalidUnionChildID
+	}
+	return t.childIDs[code]
+}
+
 type Field struct {
 	Name     string   // Field name
 	Type     DataType // The field's data type
@@ -177,4 +358,6 @@ func (f Field) String() string {
 var (
 	_ DataType = (*ListType)(nil)
 	_ DataType = (*StructType)(nil)
+	_ DataType = (*RunEndEncodedType)(nil)
+	_ DataType = (*UnionType)(nil)
 )
@@ -354,3 +354,74 @@ func TestFixedSizeListOf(t *testing.T) {
 		})
 	}
 }
+
+func TestUnionOf(t *testing.T) {
+	fields := []Field{
+		{Name: "a", Type: PrimitiveTypes.Int32, Nullable: true},
+		{Name: "b", Type: BinaryTypes.String, Nullable: true},
+	}
+
+	for _, tc := range []struct {
+		mode  UnionMode
+		codes []UnionTypeCode
+		name  string
+		str   string
+		want  []UnionTypeCode
+	}{
+		{SparseMode, []UnionTypeCode{2, 7}, "sparse_union", "sparse_union<a: int32=2, b: utf8=7>", []UnionTypeCode{2, 7}},
+		{DenseMode, []UnionTypeCode{7, 2}, "dense_union", "dense_union<a: int32=7, b: utf8=2>", []UnionTypeCode{7, 2}},
+		{DenseMode, nil, "dense_union", "dense_union<a: int32=0, b: utf8=1>", []UnionTypeCode{0, 1}},
+	} {
+		t.Run(tc.str, func(t *testing.T) {
+			dt := UnionOf(tc.mode, fields, tc.codes)
+			if got, want := dt.ID(), UNION; got != want {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			if got, want := dt.Name(), tc.name; got != want {
+				t.Fatalf("got=%q, want=%q", got, want)
+			}
+			if got, want := dt.String(), tc.str; got != want {
+				t.Fatalf("got=%q, want=%q", got, want)
+			}
+			if got, want := dt.Mode(), tc.mode; got != want {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			if got, want := dt.TypeCodes(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			for i, code := range tc.want {
+				if got, want := dt.ChildID(code), i; got != want {
+					t.Fatalf("child of %d: got=%d, want=%d", code, got, want)
+				}
+			}
+			if got, want := dt.ChildID(5), InvalidUnionChildID; got != want {
+				t.Fatalf("child of 5: got=%d, want=%d", got, want)
+			}
+			if !TypeEqual(dt, UnionOf(tc.mode, fields, tc.want)) {
+				t.Fatalf("types should be equal")
+			}
+		})
+	}
+
+	if TypeEqual(SparseUnionOf(fields, nil), DenseUnionOf(fields, nil)) {
+		t.Fatalf("sparse and dense unions should differ")
+	}
+
+	for _, tc := range []struct {
+		name  string
+		codes []UnionTypeCode
+	}{
+		{"missing codes", []UnionTypeCode{1}},
+		{"duplicate codes", []UnionTypeCode{1, 1}},
+		{"negative code", []UnionTypeCode{1, -1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if e := recover(); e == nil {
+					t.Fatalf("test should have panicked but did not")
+				}
+			}()
+			_ = SparseUnionOf(fields, tc.codes)
+		})
+	}
+}
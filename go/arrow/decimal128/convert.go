@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal128
+
+import (
+	"math"
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	bigMin = minDecimal128.ToBigInt()
+	bigMax = New(1<<63-1, 1<<64-1).ToBigInt()
+)
+
+// ToBigInt returns n as a big.Int.
+func (n Num) ToBigInt() *big.Int {
+	v := big.NewInt(n.hi)
+	v.Lsh(v, 64)
+	return v.Add(v, new(big.Int).SetUint64(n.lo))
+}
+
+// FromBigInt returns v as a Num, or ErrOverflow if v does not fit in 128
+// bits.
+func FromBigInt(v *big.Int) (Num, error) {
+	if v.Cmp(bigMin) < 0 || v.Cmp(bigMax) > 0 {
+		return Num{}, ErrOverflow
+	}
+	var (
+		mag = new(big.Int).Abs(v)
+		lo  = new(big.Int).And(mag, new(big.Int).SetUint64(1<<64-1)).Uint64()
+		hi  = new(big.Int).Rsh(mag, 64).Uint64()
+		out = New(int64(hi), lo)
+	)
+	if v.Sign() < 0 {
+		out = out.Negate()
+	}
+	return out, nil
+}
+
+// ToBigFloat returns the value of the decimal with unscaled value n and the
+// given scale, with enough precision for the scale digits of its
+// fractional part to be exact, e.g. when formatted with Text('f', scale).
+func (n Num) ToBigFloat(scale int32) *big.Float {
+	r := ratOf(n.ToBigInt(), scale)
+	prec := uint(2*(r.Num().BitLen()+r.Denom().BitLen()) + 64)
+	return new(big.Float).SetPrec(prec).SetRat(r)
+}
+
+// ToFloat64 returns the value of the decimal with unscaled value n and the
+// given scale, rounded to the nearest float64, with ties to even.
+func (n Num) ToFloat64(scale int32) float64 {
+	f, _ := ratOf(n.ToBigInt(), scale).Float64()
+	return f
+}
+
+// FromFloat64 returns the unscaled value of f as a decimal of the given
+// precision and scale, rounded half to even. FromFloat64 returns an error
+// if f is not finite, if precision is not in [1, MaxPrecision], or if the
+// value does not fit in precision digits.
+func FromFloat64(f float64, precision, scale int32) (Num, error) {
+	if precision < 1 || precision > MaxPrecision {
+		return Num{}, xerrors.Errorf("arrow/decimal128: invalid precision %d", precision)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Num{}, xerrors.Errorf("arrow/decimal128: cannot convert %v to a decimal", f)
+	}
+
+	v := new(big.Rat).SetFloat64(f)
+	v.Mul(v, ratOf(big.NewInt(1), -scale))
+	n, err := FromBigInt(RoundHalfToEven.Round(v))
+	if err != nil || !n.FitsInPrecision(precision) {
+		return Num{}, xerrors.Errorf("arrow/decimal128: %v does not fit in precision %d with scale %d: %w", f, precision, scale, ErrOverflow)
+	}
+	return n, nil
+}
+
+func bigPow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// ratOf returns v * 10^-scale.
+func ratOf(v *big.Int, scale int32) *big.Rat {
+	if scale < 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(v, bigPow10(-int64(scale))))
+	}
+	return new(big.Rat).SetFrac(v, bigPow10(int64(scale)))
+}
@@ -0,0 +1,173 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal128
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// refRound returns the exact value f * 10^scale rounded to the nearest
+// integer, with ties to even.
+func refRound(f float64, scale int32) *big.Int {
+	const prec = 4096
+	x := new(big.Float).SetPrec(prec).SetFloat64(f)
+	p := new(big.Float).SetPrec(prec).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(math.Abs(float64(scale)))), nil))
+	if scale >= 0 {
+		x.Mul(x, p)
+	} else {
+		x.Quo(x, p)
+	}
+
+	i, _ := x.Int(nil) // truncated towards zero
+	frac := new(big.Float).SetPrec(prec).Sub(x, new(big.Float).SetInt(i))
+	frac.Abs(frac)
+	switch c := frac.Cmp(big.NewFloat(0.5)); {
+	case c > 0, c == 0 && i.Bit(0) == 1:
+		i.Add(i, big.NewInt(int64(x.Sign())))
+	}
+	return i
+}
+
+// refFloat returns n * 10^-scale rounded to the nearest float64.
+func refFloat(n *big.Int, scale int32) float64 {
+	const prec = 4096
+	x := new(big.Float).SetPrec(prec).SetInt(n)
+	p := new(big.Float).SetPrec(prec).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(math.Abs(float64(scale)))), nil))
+	if scale >= 0 {
+		x.Quo(x, p)
+	} else {
+		x.Mul(x, p)
+	}
+	f, _ := x.Float64()
+	return f
+}
+
+func TestBigInt(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 10000; i++ {
+		n := randNum(r)
+		got, err := FromBigInt(n.ToBigInt())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != n {
+			t.Fatalf("round-trip: got=%v, want=%v", got, n)
+		}
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), 127)
+	if _, err := FromBigInt(limit); err == nil {
+		t.Fatalf("2^127 should overflow")
+	}
+	if _, err := FromBigInt(new(big.Int).Sub(new(big.Int).Neg(limit), big.NewInt(1))); err == nil {
+		t.Fatalf("-2^127-1 should overflow")
+	}
+	if got, err := FromBigInt(new(big.Int).Neg(limit)); err != nil || got != minDecimal128 {
+		t.Fatalf("-2^127: got=%v, err=%v", got, err)
+	}
+}
+
+func TestToFloat64(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for scale := int32(-10); scale <= 40; scale++ {
+		for i := 0; i < 200; i++ {
+			n := randNum(r)
+			if got, want := n.ToFloat64(scale), refFloat(n.ToBigInt(), scale); got != want {
+				t.Fatalf("to-float64(%v, %d): got=%v, want=%v", n.ToBigInt(), scale, got, want)
+			}
+		}
+	}
+}
+
+func TestToBigFloat(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for scale := int32(0); scale <= 40; scale++ {
+		for i := 0; i < 100; i++ {
+			n := randNum(r)
+			if got, want := n.ToBigFloat(scale).Text('f', int(scale)), n.ToString(scale); got != want {
+				t.Fatalf("to-big-float(%v, %d): got=%s, want=%s", n.ToBigInt(), scale, got, want)
+			}
+		}
+	}
+}
+
+func TestFromFloat64(t *testing.T) {
+	values := []float64{
+		0, 1, -1, 0.5, 1.5, 2.5, -2.5, 0.1, 0.125, 1.0 / 3, 123.456, -987654.321,
+		math.SmallestNonzeroFloat64, 2.2250738585072014e-308, 1e-300,
+		math.Ldexp(1, 126), -math.Ldexp(1, 126), math.Ldexp(1, 127),
+		1e37, 9.999999999999999e37, 1e38, math.MaxFloat64,
+	}
+	r := rand.New(rand.NewSource(8))
+	for i := 0; i < 200; i++ {
+		values = append(values, math.Ldexp(r.Float64()-0.5, r.Intn(260)-130))
+	}
+
+	for _, f := range values {
+		for scale := int32(-10); scale <= 40; scale++ {
+			want := refRound(f, scale)
+			fits := new(big.Int).Abs(want).Cmp(new(big.Int).Exp(big.NewInt(10), big.NewInt(MaxPrecision), nil)) < 0
+
+			got, err := FromFloat64(f, MaxPrecision, scale)
+			switch {
+			case !fits && err == nil:
+				t.Fatalf("from-float64(%v, %d): expected an error, got=%v", f, scale, got.ToBigInt())
+			case fits && err != nil:
+				t.Fatalf("from-float64(%v, %d): unexpected error: %v", f, scale, err)
+			case fits && got.ToBigInt().Cmp(want) != 0:
+				t.Fatalf("from-float64(%v, %d): got=%v, want=%v", f, scale, got.ToBigInt(), want)
+			}
+		}
+	}
+
+	for _, tc := range []struct {
+		f           float64
+		prec, scale int32
+		want        string
+		err         bool
+	}{
+		{f: 0.125, prec: 3, scale: 2, want: "0.12"},
+		{f: 0.375, prec: 3, scale: 2, want: "0.38"},
+		{f: -0.125, prec: 3, scale: 2, want: "-0.12"},
+		{f: 12345, prec: 3, scale: -2, want: "12300"},
+		{f: 12350, prec: 3, scale: -2, want: "12400"},
+		{f: 999.5, prec: 3, scale: 0, err: true},
+		{f: 1, prec: 0, scale: 0, err: true},
+		{f: math.NaN(), prec: 10, scale: 0, err: true},
+		{f: math.Inf(-1), prec: 10, scale: 0, err: true},
+	} {
+		t.Run(fmt.Sprintf("%v/%d/%d", tc.f, tc.prec, tc.scale), func(t *testing.T) {
+			got, err := FromFloat64(tc.f, tc.prec, tc.scale)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got=%v", got.ToBigInt())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := got.ToString(tc.scale); s != tc.want {
+				t.Fatalf("got=%q, want=%q", s, tc.want)
+			}
+		})
+	}
+}
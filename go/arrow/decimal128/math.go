@@ -0,0 +1,262 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal128
+
+import (
+	"math/big"
+	"math/bits"
+
+	"golang.org/x/xerrors"
+)
+
+// MaxPrecision is the maximum number of decimal digits of a decimal128
+// value.
+const MaxPrecision = 38
+
+var (
+	// ErrOverflow is returned when the result of an operation does not
+	// fit in 128 bits, or in the requested precision.
+	ErrOverflow = xerrors.New("arrow/decimal128: overflow")
+	// ErrDivideByZero is returned when dividing by zero.
+	ErrDivideByZero = xerrors.New("arrow/decimal128: division by zero")
+)
+
+// RoundMode selects how the result of a division is rounded to an integer.
+type RoundMode int8
+
+const (
+	// RoundHalfToEven rounds to the nearest value, and halves to the
+	// nearest even value.
+	RoundHalfToEven RoundMode = iota
+	// RoundHalfAwayFromZero rounds to the nearest value, and halves away
+	// from zero.
+	RoundHalfAwayFromZero
+	// RoundDown rounds towards negative infinity.
+	RoundDown
+	// RoundUp rounds towards positive infinity.
+	RoundUp
+	// RoundTowardsZero rounds towards zero.
+	RoundTowardsZero
+)
+
+// Round returns r rounded to an integer with mode m.
+func (m RoundMode) Round(r *big.Rat) *big.Int {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+	// q is truncated towards zero: move it one unit away from zero when
+	// the mode asks for it.
+	away := false
+	switch m {
+	case RoundHalfToEven, RoundHalfAwayFromZero:
+		// compare 2*|rem| with the denominator to find the nearest integer.
+		switch c := rem.Abs(rem).Lsh(rem, 1).Cmp(r.Denom()); {
+		case c > 0:
+			away = true
+		case c == 0:
+			away = m == RoundHalfAwayFromZero || q.Bit(0) == 1
+		}
+	case RoundDown:
+		away = r.Sign() < 0
+	case RoundUp:
+		away = r.Sign() > 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	return q
+}
+
+var (
+	minDecimal128 = New(-1<<63, 0)
+
+	// pow10s holds the powers of ten up to 10^MaxPrecision.
+	pow10s = func() [MaxPrecision + 1]Num {
+		var p [MaxPrecision + 1]Num
+		p[0] = FromU64(1)
+		for i := 1; i < len(p); i++ {
+			hi, lo := bits.Mul64(p[i-1].lo, 10)
+			p[i] = New(p[i-1].hi*10+int64(hi), lo)
+		}
+		return p
+	}()
+)
+
+// Cmp compares n and rhs and returns:
+//
+//	-1 if n <  rhs
+//	 0 if n == rhs
+//	+1 if n >  rhs
+func (n Num) Cmp(rhs Num) int {
+	switch {
+	case n.hi < rhs.hi, n.hi == rhs.hi && n.lo < rhs.lo:
+		return -1
+	case n == rhs:
+		return 0
+	}
+	return +1
+}
+
+// Negate returns -n. The negation of the minimum value wraps around.
+func (n Num) Negate() Num {
+	lo, borrow := bits.Sub64(0, n.lo, 0)
+	return New(-n.hi-int64(borrow), lo)
+}
+
+// Abs returns the absolute value of n, or ErrOverflow if n is the minimum
+// value, whose absolute value does not fit.
+func (n Num) Abs() (Num, error) {
+	if n == minDecimal128 {
+		return Num{}, ErrOverflow
+	}
+	return n.magnitude(), nil
+}
+
+// magnitude returns the absolute value of n, as an unsigned integer: the
+// magnitude of the minimum value is itself.
+func (n Num) magnitude() Num {
+	if n.Sign() < 0 {
+		return n.Negate()
+	}
+	return n
+}
+
+// Add returns n + rhs, or ErrOverflow if the sum does not fit in 128 bits.
+func (n Num) Add(rhs Num) (Num, error) {
+	lo, carry := bits.Add64(n.lo, rhs.lo, 0)
+	hi, _ := bits.Add64(uint64(n.hi), uint64(rhs.hi), carry)
+	out := New(int64(hi), lo)
+	// the sum overflows if the operands have the same sign, and the sum
+	// has the other one.
+	if (n.hi < 0) == (rhs.hi < 0) && (out.hi < 0) != (n.hi < 0) {
+		return Num{}, ErrOverflow
+	}
+	return out, nil
+}
+
+// Sub returns n - rhs, or ErrOverflow if the difference does not fit in
+// 128 bits.
+func (n Num) Sub(rhs Num) (Num, error) {
+	lo, borrow := bits.Sub64(n.lo, rhs.lo, 0)
+	hi, _ := bits.Sub64(uint64(n.hi), uint64(rhs.hi), borrow)
+	out := New(int64(hi), lo)
+	// the difference overflows if the operands have different signs, and
+	// the difference has the sign of rhs.
+	if (n.hi < 0) != (rhs.hi < 0) && (out.hi < 0) != (n.hi < 0) {
+		return Num{}, ErrOverflow
+	}
+	return out, nil
+}
+
+// Mul returns n * rhs, or ErrOverflow if the product does not fit in 128
+// bits.
+func (n Num) Mul(rhs Num) (Num, error) {
+	// multiply the magnitudes, as unsigned 128-bit integers.
+	a, b := n.magnitude(), rhs.magnitude()
+	ahi, bhi := uint64(a.hi), uint64(b.hi)
+	if ahi != 0 && bhi != 0 {
+		return Num{}, ErrOverflow
+	}
+	hi, lo := bits.Mul64(a.lo, b.lo)
+	c1, p1 := bits.Mul64(ahi, b.lo)
+	c2, p2 := bits.Mul64(a.lo, bhi)
+	if c1 != 0 || c2 != 0 {
+		return Num{}, ErrOverflow
+	}
+	var carry uint64
+	if hi, carry = bits.Add64(hi, p1, 0); carry != 0 {
+		return Num{}, ErrOverflow
+	}
+	if hi, carry = bits.Add64(hi, p2, 0); carry != 0 {
+		return Num{}, ErrOverflow
+	}
+
+	out := New(int64(hi), lo)
+	neg := n.Sign()*rhs.Sign() < 0
+	switch {
+	case out.hi >= 0 && neg:
+		return out.Negate(), nil
+	case out.hi >= 0:
+		return out, nil
+	case neg && out == minDecimal128:
+		// the magnitude of the minimum value is 2^127.
+		return out, nil
+	}
+	return Num{}, ErrOverflow
+}
+
+// Div returns the quotient n / rhs rounded to an integer with mode, and the
+// remainder n - quo*rhs. Div returns ErrDivideByZero if rhs is zero, and
+// ErrOverflow if the quotient does not fit in 128 bits.
+func (n Num) Div(rhs Num, mode RoundMode) (quo, rem Num, err error) {
+	if rhs == (Num{}) {
+		return Num{}, Num{}, ErrDivideByZero
+	}
+
+	v, d := n.ToBigInt(), rhs.ToBigInt()
+	q := mode.Round(new(big.Rat).SetFrac(v, d))
+	r := v.Sub(v, new(big.Int).Mul(q, d))
+
+	if quo, err = FromBigInt(q); err != nil {
+		return Num{}, Num{}, err
+	}
+	if rem, err = FromBigInt(r); err != nil {
+		return Num{}, Num{}, err
+	}
+	return quo, rem, nil
+}
+
+// Rescale returns n, the unscaled value of a decimal of scale fromScale,
+// as the unscaled value of a decimal of scale toScale. Digits dropped by
+// reducing the scale are rounded half to even. Rescale returns ErrOverflow
+// if the result does not fit in 128 bits.
+func (n Num) Rescale(fromScale, toScale int32) (Num, error) {
+	switch delta := int64(toScale) - int64(fromScale); {
+	case delta == 0:
+		return n, nil
+	case delta > 0:
+		if delta > MaxPrecision {
+			if n == (Num{}) {
+				return n, nil
+			}
+			return Num{}, ErrOverflow
+		}
+		return n.Mul(pow10s[delta])
+	default:
+		if -delta > MaxPrecision {
+			// |n| < 2^127 is less than half of 10^39.
+			return Num{}, nil
+		}
+		quo, _, err := n.Div(pow10s[-delta], RoundHalfToEven)
+		return quo, err
+	}
+}
+
+// FitsInPrecision returns whether n has at most prec decimal digits.
+func (n Num) FitsInPrecision(prec int32) bool {
+	if prec <= 0 {
+		return n == (Num{})
+	}
+	if prec > MaxPrecision {
+		return true
+	}
+	if n == minDecimal128 {
+		return false
+	}
+	return n.magnitude().Cmp(pow10s[prec]) < 0
+}
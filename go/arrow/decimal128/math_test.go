@@ -0,0 +1,347 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal128
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+// randNum returns a random value, with magnitudes spread across the range
+// of 128-bit integers.
+func randNum(r *rand.Rand) Num {
+	switch r.Intn(4) {
+	case 0:
+		return FromI64(r.Int63n(2000) - 1000)
+	case 1:
+		return FromI64(int64(r.Uint64()))
+	case 2:
+		n := New(int64(r.Uint64()), r.Uint64())
+		shift := uint(r.Intn(127))
+		v, _ := FromBigInt(new(big.Int).Rsh(n.ToBigInt(), shift))
+		return v
+	}
+	return New(int64(r.Uint64()), r.Uint64())
+}
+
+// fits returns the value of v if it fits in 128 bits.
+func fits(v *big.Int) (Num, bool) {
+	n, err := FromBigInt(v)
+	return n, err == nil
+}
+
+func TestArithmetic(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		a, b := randNum(r), randNum(r)
+		ba, bb := a.ToBigInt(), b.ToBigInt()
+
+		for _, op := range []struct {
+			name string
+			got  func() (Num, error)
+			want *big.Int
+		}{
+			{"add", func() (Num, error) { return a.Add(b) }, new(big.Int).Add(ba, bb)},
+			{"sub", func() (Num, error) { return a.Sub(b) }, new(big.Int).Sub(ba, bb)},
+			{"mul", func() (Num, error) { return a.Mul(b) }, new(big.Int).Mul(ba, bb)},
+		} {
+			got, err := op.got()
+			want, ok := fits(op.want)
+			switch {
+			case !ok && !xerrors.Is(err, ErrOverflow):
+				t.Fatalf("%s(%v, %v): expected an overflow, got=%v (err=%v)", op.name, ba, bb, got.ToBigInt(), err)
+			case ok && err != nil:
+				t.Fatalf("%s(%v, %v): unexpected error: %v", op.name, ba, bb, err)
+			case ok && got != want:
+				t.Fatalf("%s(%v, %v): got=%v, want=%v", op.name, ba, bb, got.ToBigInt(), op.want)
+			}
+		}
+
+		if got, want := a.Cmp(b), ba.Cmp(bb); got != want {
+			t.Fatalf("cmp(%v, %v): got=%d, want=%d", ba, bb, got, want)
+		}
+		if got, want := a.Sign(), ba.Sign(); got != want {
+			t.Fatalf("sign(%v): got=%d, want=%d", ba, got, want)
+		}
+		abs, err := a.Abs()
+		switch want, ok := fits(new(big.Int).Abs(ba)); {
+		case !ok:
+			if !xerrors.Is(err, ErrOverflow) {
+				t.Fatalf("abs(%v): expected an overflow, got=%v, err=%v", ba, abs.ToBigInt(), err)
+			}
+		case err != nil:
+			t.Fatalf("abs(%v): unexpected error: %v", ba, err)
+		case abs != want:
+			t.Fatalf("abs(%v): got=%v, want=%v", ba, abs.ToBigInt(), want.ToBigInt())
+		}
+	}
+
+	if _, err := minDecimal128.Abs(); !xerrors.Is(err, ErrOverflow) {
+		t.Fatalf("abs(min): expected an overflow, got %v", err)
+	}
+}
+
+// roundQuo returns a/b rounded with mode.
+func roundQuo(a, b *big.Int, mode RoundMode) *big.Int {
+	q := new(big.Rat).SetFrac(a, b)
+	fl := new(big.Int).Div(q.Num(), q.Denom()) // floor, as the denominator is positive
+	if q.IsInt() {
+		return fl
+	}
+	ce := new(big.Int).Add(fl, big.NewInt(1))
+	frac := new(big.Rat).Sub(q, new(big.Rat).SetInt(fl))
+	switch mode {
+	case RoundDown:
+		return fl
+	case RoundUp:
+		return ce
+	case RoundTowardsZero:
+		if q.Sign() < 0 {
+			return ce
+		}
+		return fl
+	}
+	switch c := frac.Cmp(big.NewRat(1, 2)); {
+	case c < 0:
+		return fl
+	case c > 0:
+		return ce
+	case mode == RoundHalfAwayFromZero && q.Sign() < 0:
+		return fl
+	case mode == RoundHalfAwayFromZero:
+		return ce
+	case fl.Bit(0) == 0:
+		return fl
+	}
+	return ce
+}
+
+func TestDiv(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	modes := []RoundMode{RoundHalfToEven, RoundHalfAwayFromZero, RoundDown, RoundUp, RoundTowardsZero}
+	for i := 0; i < 50000; i++ {
+		a, b := randNum(r), randNum(r)
+		if r.Intn(4) == 0 {
+			// small divisors produce ties and remainders more often.
+			b = FromI64(r.Int63n(20) - 10)
+		}
+		mode := modes[r.Intn(len(modes))]
+
+		quo, rem, err := a.Div(b, mode)
+		if b == (Num{}) {
+			if !xerrors.Is(err, ErrDivideByZero) {
+				t.Fatalf("div(%v, 0): expected a division by zero, got %v", a.ToBigInt(), err)
+			}
+			continue
+		}
+
+		ba, bb := a.ToBigInt(), b.ToBigInt()
+		want, ok := fits(roundQuo(ba, bb, mode))
+		switch {
+		case !ok:
+			if !xerrors.Is(err, ErrOverflow) {
+				t.Fatalf("div(%v, %v): expected an overflow, got %v", ba, bb, err)
+			}
+			continue
+		case err != nil:
+			t.Fatalf("div(%v, %v): unexpected error: %v", ba, bb, err)
+		case quo != want:
+			t.Fatalf("div(%v, %v, mode=%d): got=%v, want=%v", ba, bb, mode, quo.ToBigInt(), want.ToBigInt())
+		}
+
+		// a == quo*b + rem
+		back := new(big.Int).Mul(quo.ToBigInt(), bb)
+		back.Add(back, rem.ToBigInt())
+		if back.Cmp(ba) != 0 {
+			t.Fatalf("div(%v, %v, mode=%d): invalid remainder %v for quotient %v", ba, bb, mode, rem.ToBigInt(), quo.ToBigInt())
+		}
+	}
+
+	quo, _, err := minDecimal128.Div(FromI64(-1), RoundHalfToEven)
+	if !xerrors.Is(err, ErrOverflow) {
+		t.Fatalf("min/-1: expected an overflow, got=%v, err=%v", quo.ToBigInt(), err)
+	}
+}
+
+func TestRoundMode(t *testing.T) {
+	for _, tc := range []struct {
+		num, den int64
+		want     [5]int64 // by mode, in the order of the constants
+	}{
+		{5, 2, [5]int64{2, 3, 2, 3, 2}},
+		{-5, 2, [5]int64{-2, -3, -3, -2, -2}},
+		{7, 2, [5]int64{4, 4, 3, 4, 3}},
+		{-7, 3, [5]int64{-2, -2, -3, -2, -2}},
+		{8, 3, [5]int64{3, 3, 2, 3, 2}},
+		{6, 3, [5]int64{2, 2, 2, 2, 2}},
+	} {
+		for mode, want := range tc.want {
+			got := RoundMode(mode).Round(big.NewRat(tc.num, tc.den))
+			if got.Cmp(big.NewInt(want)) != 0 {
+				t.Errorf("round(%d/%d, mode=%d): got=%v, want=%d", tc.num, tc.den, mode, got, want)
+			}
+		}
+	}
+}
+
+func TestRescale(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 20000; i++ {
+		var (
+			n    = randNum(r)
+			from = int32(r.Intn(80) - 40)
+			to   = int32(r.Intn(80) - 40)
+			bn   = n.ToBigInt()
+		)
+
+		var want *big.Int
+		if delta := int64(to) - int64(from); delta >= 0 {
+			want = new(big.Int).Mul(bn, new(big.Int).Exp(big.NewInt(10), big.NewInt(delta), nil))
+		} else {
+			want = roundQuo(bn, new(big.Int).Exp(big.NewInt(10), big.NewInt(-delta), nil), RoundHalfToEven)
+		}
+
+		got, err := n.Rescale(from, to)
+		w, ok := fits(want)
+		switch {
+		case !ok && !xerrors.Is(err, ErrOverflow):
+			t.Fatalf("rescale(%v, %d, %d): expected an overflow, got=%v (err=%v)", bn, from, to, got.ToBigInt(), err)
+		case ok && err != nil:
+			t.Fatalf("rescale(%v, %d, %d): unexpected error: %v", bn, from, to, err)
+		case ok && got != w:
+			t.Fatalf("rescale(%v, %d, %d): got=%v, want=%v", bn, from, to, got.ToBigInt(), want)
+		}
+	}
+
+	for _, tc := range []struct {
+		n        int64
+		from, to int32
+		want     int64
+	}{
+		{125, 2, 1, 12},
+		{135, 2, 1, 14},
+		{-125, 2, 1, -12},
+		{-135, 2, 1, -14},
+		{126, 2, 1, 13},
+		{12, 1, 3, 1200},
+	} {
+		got, err := FromI64(tc.n).Rescale(tc.from, tc.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != FromI64(tc.want) {
+			t.Fatalf("rescale(%d, %d, %d): got=%v, want=%d", tc.n, tc.from, tc.to, got.ToBigInt(), tc.want)
+		}
+	}
+}
+
+func TestFitsInPrecision(t *testing.T) {
+	for _, tc := range []struct {
+		n    Num
+		prec int32
+		want bool
+	}{
+		{FromI64(0), 1, true},
+		{FromI64(9), 1, true},
+		{FromI64(-9), 1, true},
+		{FromI64(10), 1, false},
+		{FromI64(-10), 1, false},
+		{MaxDecimal128, MaxPrecision, true},
+		{MaxDecimal128.Negate(), MaxPrecision, true},
+		{pow10s[MaxPrecision], MaxPrecision, false},
+		{minDecimal128, MaxPrecision, false},
+	} {
+		if got := tc.n.FitsInPrecision(tc.prec); got != tc.want {
+			t.Fatalf("fits(%v, %d): got=%v, want=%v", tc.n.ToBigInt(), tc.prec, got, tc.want)
+		}
+	}
+}
+
+func TestFromString(t *testing.T) {
+	for _, tc := range []struct {
+		s           string
+		prec, scale int32
+		want        string
+		err         bool
+	}{
+		{s: "123.45", prec: 5, scale: 2, want: "123.45"},
+		{s: "-123.45", prec: 5, scale: 2, want: "-123.45"},
+		{s: "+0.5", prec: 1, scale: 1, want: "0.5"},
+		{s: "1.2e3", prec: 10, scale: 2, want: "1200.00"},
+		{s: "1.2E-3", prec: 10, scale: 4, want: "0.0012"},
+		{s: ".5", prec: 3, scale: 2, want: "0.50"},
+		{s: "7.", prec: 3, scale: 0, want: "7"},
+		{s: "0.125", prec: 3, scale: 2, want: "0.12"},
+		{s: "0.135", prec: 3, scale: 2, want: "0.14"},
+		{s: "-0.125", prec: 3, scale: 2, want: "-0.12"},
+		{s: "12345", prec: 3, scale: -2, want: "12300"},
+		{s: "99999999999999999999999999999999999999", prec: 38, scale: 0, want: "99999999999999999999999999999999999999"},
+		{s: "-9999999999999999999999999999999999999.9", prec: 38, scale: 1, want: "-9999999999999999999999999999999999999.9"},
+		{s: "100000000000000000000000000000000000000", prec: 38, scale: 0, err: true},
+		{s: "123.45", prec: 4, scale: 2, err: true},
+		{s: "1", prec: 39, scale: 0, err: true},
+		{s: "1", prec: 0, scale: 0, err: true},
+		{s: "", prec: 5, scale: 0, err: true},
+		{s: "-", prec: 5, scale: 0, err: true},
+		{s: ".", prec: 5, scale: 0, err: true},
+		{s: "1.2.3", prec: 5, scale: 0, err: true},
+		{s: "12a", prec: 5, scale: 0, err: true},
+		{s: "1e", prec: 5, scale: 0, err: true},
+		{s: "1e100000", prec: 5, scale: 0, err: true},
+	} {
+		t.Run(fmt.Sprintf("%q/%d/%d", tc.s, tc.prec, tc.scale), func(t *testing.T) {
+			n, err := FromString(tc.s, tc.prec, tc.scale)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got=%v", n.ToBigInt())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := n.ToString(tc.scale); got != tc.want {
+				t.Fatalf("got=%q, want=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 10000; i++ {
+		var (
+			n     = randNum(r)
+			scale = int32(r.Intn(50) - 5)
+		)
+		if !n.FitsInPrecision(MaxPrecision) {
+			continue
+		}
+		s := n.ToString(scale)
+		got, err := FromString(s, MaxPrecision, scale)
+		if err != nil {
+			t.Fatalf("from-string(%q, %d): %v", s, scale, err)
+		}
+		if got != n {
+			t.Fatalf("round-trip(%v, %d): got=%v via %q", n.ToBigInt(), scale, got.ToBigInt(), s)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decimal256 provides the value type of 256-bit decimals.
+package decimal256 // import "github.com/apache/arrow/go/arrow/decimal256"
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"golang.org/x/xerrors"
+)
+
+// MaxPrecision is the maximum number of decimal digits of a decimal256
+// value.
+const MaxPrecision = 76
+
+// ErrOverflow is returned when a value does not fit in 256 bits, or in the
+// requested precision.
+var ErrOverflow = xerrors.New("arrow/decimal256: overflow")
+
+// Num represents a signed 256-bit integer in two's complement, stored as
+// four 64-bit words from the least to the most significant.
+type Num struct {
+	arr [4]uint64
+}
+
+// New returns a new signed 256-bit integer value from its four 64-bit
+// words, from the most to the least significant.
+func New(hi, midhi, midlo, lo uint64) Num {
+	return Num{arr: [4]uint64{lo, midlo, midhi, hi}}
+}
+
+// FromU64 returns a new signed 256-bit integer value from the provided uint64 one.
+func FromU64(v uint64) Num {
+	return New(0, 0, 0, v)
+}
+
+// FromI64 returns a new signed 256-bit integer value from the provided int64 one.
+func FromI64(v int64) Num {
+	if v < 0 {
+		return New(math.MaxUint64, math.MaxUint64, math.MaxUint64, uint64(v))
+	}
+	return FromU64(uint64(v))
+}
+
+// FromDecimal128 returns the 256-bit integer value of n, sign-extended.
+func FromDecimal128(n decimal128.Num) Num {
+	ext := uint64(n.HighBits() >> 63)
+	return New(ext, ext, uint64(n.HighBits()), n.LowBits())
+}
+
+// Array returns the four 64-bit words of n, from the least to the most
+// significant.
+func (n Num) Array() [4]uint64 { return n.arr }
+
+// Sign returns:
+//
+//	-1 if x <  0
+//	 0 if x == 0
+//	+1 if x >  0
+func (n Num) Sign() int {
+	if n == (Num{}) {
+		return 0
+	}
+	return int(1 | (int64(n.arr[3]) >> 63))
+}
+
+// ToDecimal128 returns n as a decimal128.Num, or ErrOverflow if n does not
+// fit in 128 bits.
+func (n Num) ToDecimal128() (decimal128.Num, error) {
+	ext := uint64(int64(n.arr[1]) >> 63)
+	if n.arr[2] != ext || n.arr[3] != ext {
+		return decimal128.Num{}, ErrOverflow
+	}
+	return decimal128.New(int64(n.arr[1]), n.arr[0]), nil
+}
+
+// FitsInPrecision returns whether n has at most prec decimal digits.
+func (n Num) FitsInPrecision(prec int32) bool {
+	if prec > MaxPrecision {
+		return true
+	}
+	if prec <= 0 {
+		return n == (Num{})
+	}
+	return new(big.Int).Abs(n.ToBigInt()).Cmp(pow10(int64(prec))) < 0
+}
+
+var (
+	bigMin = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	bigMax = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+)
+
+// ToBigInt returns n as a big.Int.
+func (n Num) ToBigInt() *big.Int {
+	v := big.NewInt(int64(n.arr[3]))
+	for i := 2; i >= 0; i-- {
+		v.Lsh(v, 64)
+		v.Add(v, new(big.Int).SetUint64(n.arr[i]))
+	}
+	return v
+}
+
+// FromBigInt returns v as a Num, or ErrOverflow if v does not fit in 256
+// bits.
+func FromBigInt(v *big.Int) (Num, error) {
+	if v.Cmp(bigMin) < 0 || v.Cmp(bigMax) > 0 {
+		return Num{}, ErrOverflow
+	}
+	// the two's complement of v, as an unsigned 256-bit integer.
+	u := new(big.Int).Set(v)
+	if v.Sign() < 0 {
+		u.Add(u, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	var (
+		out  Num
+		mask = new(big.Int).SetUint64(math.MaxUint64)
+	)
+	for i := range out.arr {
+		out.arr[i] = new(big.Int).And(u, mask).Uint64()
+		u.Rsh(u, 64)
+	}
+	return out, nil
+}
+
+// ToBigFloat returns the value of the decimal with unscaled value n and the
+// given scale, with enough precision for the scale digits of its
+// fractional part to be exact, e.g. when formatted with Text('f', scale).
+func (n Num) ToBigFloat(scale int32) *big.Float {
+	r := ratOf(n.ToBigInt(), scale)
+	prec := uint(2*(r.Num().BitLen()+r.Denom().BitLen()) + 64)
+	return new(big.Float).SetPrec(prec).SetRat(r)
+}
+
+// ToFloat64 returns the value of the decimal with unscaled value n and the
+// given scale, rounded to the nearest float64, with ties to even.
+func (n Num) ToFloat64(scale int32) float64 {
+	f, _ := ratOf(n.ToBigInt(), scale).Float64()
+	return f
+}
+
+// FromFloat64 returns the unscaled value of f as a decimal of the given
+// precision and scale, rounded half to even. FromFloat64 returns an error
+// if f is not finite, if precision is not in [1, MaxPrecision], or if the
+// value does not fit in precision digits.
+func FromFloat64(f float64, precision, scale int32) (Num, error) {
+	if precision < 1 || precision > MaxPrecision {
+		return Num{}, xerrors.Errorf("arrow/decimal256: invalid precision %d", precision)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Num{}, xerrors.Errorf("arrow/decimal256: cannot convert %v to a decimal", f)
+	}
+
+	v := new(big.Rat).SetFloat64(f)
+	v.Mul(v, ratOf(big.NewInt(1), -scale))
+	n, err := FromBigInt(RoundHalfToEven.Round(v))
+	if err != nil || !n.FitsInPrecision(precision) {
+		return Num{}, xerrors.Errorf("arrow/decimal256: %v does not fit in precision %d with scale %d: %w", f, precision, scale, ErrOverflow)
+	}
+	return n, nil
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// ratOf returns v * 10^-scale.
+func ratOf(v *big.Int, scale int32) *big.Rat {
+	if scale < 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(v, pow10(-int64(scale))))
+	}
+	return new(big.Rat).SetFrac(v, pow10(int64(scale)))
+}
@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal256
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/decimal128"
+)
+
+func randNum(r *rand.Rand) Num {
+	n := New(r.Uint64(), r.Uint64(), r.Uint64(), r.Uint64())
+	v, _ := FromBigInt(new(big.Int).Rsh(n.ToBigInt(), uint(r.Intn(255))))
+	return v
+}
+
+func TestFromI64(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, 42, math.MaxInt64, math.MinInt64} {
+		n := FromI64(v)
+		if got, want := n.ToBigInt(), big.NewInt(v); got.Cmp(want) != 0 {
+			t.Fatalf("from-i64(%d): got=%v", v, got)
+		}
+		if got, want := n.Sign(), big.NewInt(v).Sign(); got != want {
+			t.Fatalf("sign(%d): got=%d, want=%d", v, got, want)
+		}
+	}
+	if got, want := FromU64(math.MaxUint64).ToBigInt(), new(big.Int).SetUint64(math.MaxUint64); got.Cmp(want) != 0 {
+		t.Fatalf("from-u64: got=%v, want=%v", got, want)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		n := randNum(r)
+		got, err := FromBigInt(n.ToBigInt())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != n {
+			t.Fatalf("round-trip: got=%v, want=%v", got.Array(), n.Array())
+		}
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), 255)
+	if _, err := FromBigInt(limit); err == nil {
+		t.Fatalf("2^255 should overflow")
+	}
+	if _, err := FromBigInt(new(big.Int).Sub(new(big.Int).Neg(limit), big.NewInt(1))); err == nil {
+		t.Fatalf("-2^255-1 should overflow")
+	}
+	if got, err := FromBigInt(new(big.Int).Neg(limit)); err != nil || got != New(1<<63, 0, 0, 0) {
+		t.Fatalf("-2^255: got=%v, err=%v", got.Array(), err)
+	}
+}
+
+func TestDecimal128(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		n := decimal128.New(int64(r.Uint64()), r.Uint64())
+		wide := FromDecimal128(n)
+		if got, want := wide.ToBigInt(), n.ToBigInt(); got.Cmp(want) != 0 {
+			t.Fatalf("widen(%v): got=%v", want, got)
+		}
+		back, err := wide.ToDecimal128()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != n {
+			t.Fatalf("narrow(%v): got=%v", n.ToBigInt(), back.ToBigInt())
+		}
+	}
+
+	for _, v := range []*big.Int{
+		new(big.Int).Lsh(big.NewInt(1), 127),
+		new(big.Int).Sub(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127)), big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(3), 200),
+	} {
+		n, _ := FromBigInt(v)
+		if _, err := n.ToDecimal128(); err == nil {
+			t.Fatalf("narrow(%v): expected an overflow", v)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	const prec = 4096
+	r := rand.New(rand.NewSource(3))
+	for scale := int32(-10); scale <= 80; scale += 3 {
+		p := new(big.Float).SetPrec(prec).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(math.Abs(float64(scale)))), nil))
+		for i := 0; i < 100; i++ {
+			n := randNum(r)
+
+			ref := new(big.Float).SetPrec(prec).SetInt(n.ToBigInt())
+			if scale >= 0 {
+				ref.Quo(ref, p)
+			} else {
+				ref.Mul(ref, p)
+			}
+			want, _ := ref.Float64()
+			if got := n.ToFloat64(scale); got != want {
+				t.Fatalf("to-float64(%v, %d): got=%v, want=%v", n.ToBigInt(), scale, got, want)
+			}
+
+			if scale >= 0 {
+				if got, want := n.ToBigFloat(scale).Text('f', int(scale)), ref.Text('f', int(scale)); got != want {
+					t.Fatalf("to-big-float(%v, %d): got=%s, want=%s", n.ToBigInt(), scale, got, want)
+				}
+			}
+		}
+	}
+
+	for _, tc := range []struct {
+		f           float64
+		prec, scale int32
+		want        *big.Int
+		err         bool
+	}{
+		{0.125, 3, 2, big.NewInt(12), false},
+		{-2.5, 3, 0, big.NewInt(-2), false},
+		{3.5, 3, 0, big.NewInt(4), false},
+		{math.SmallestNonzeroFloat64, 76, 76, big.NewInt(0), false},
+		{math.Ldexp(1, 200), 76, 0, new(big.Int).Lsh(big.NewInt(1), 200), false},
+		{1e70, 76, 10, nil, true},
+	} {
+		got, err := FromFloat64(tc.f, tc.prec, tc.scale)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("from-float64(%v, %d, %d): expected an error", tc.f, tc.prec, tc.scale)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ToBigInt().Cmp(tc.want) != 0 {
+			t.Fatalf("from-float64(%v, %d, %d): got=%v, want=%v", tc.f, tc.prec, tc.scale, got.ToBigInt(), tc.want)
+		}
+	}
+
+	if _, err := FromFloat64(math.NaN(), 10, 0); err == nil {
+		t.Fatalf("expected an error for NaN")
+	}
+	if _, err := FromFloat64(1, 77, 0); err == nil {
+		t.Fatalf("expected an error for an invalid precision")
+	}
+}
+
+func TestFitsInPrecision(t *testing.T) {
+	max := new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(MaxPrecision), nil), big.NewInt(1))
+	n, err := FromBigInt(max)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n.FitsInPrecision(MaxPrecision) {
+		t.Fatalf("10^76-1 should fit in %d digits", MaxPrecision)
+	}
+	n, err = FromBigInt(new(big.Int).Add(max, big.NewInt(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.FitsInPrecision(MaxPrecision) {
+		t.Fatalf("10^76 should not fit in %d digits", MaxPrecision)
+	}
+}
@@ -0,0 +1,232 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal256
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"golang.org/x/xerrors"
+)
+
+// ErrDivideByZero is returned when dividing by zero.
+var ErrDivideByZero = xerrors.New("arrow/decimal256: division by zero")
+
+// RoundMode selects how the result of a division is rounded to an integer.
+type RoundMode = decimal128.RoundMode
+
+// The rounding modes of Div, the ones of decimal128.
+const (
+	RoundHalfToEven       = decimal128.RoundHalfToEven
+	RoundHalfAwayFromZero = decimal128.RoundHalfAwayFromZero
+	RoundDown             = decimal128.RoundDown
+	RoundUp               = decimal128.RoundUp
+	RoundTowardsZero      = decimal128.RoundTowardsZero
+)
+
+var (
+	minDecimal256 = New(1<<63, 0, 0, 0)
+
+	// pow10s holds the powers of ten up to 10^MaxPrecision.
+	pow10s = func() [MaxPrecision + 1]Num {
+		var p [MaxPrecision + 1]Num
+		p[0] = FromU64(1)
+		for i := 1; i < len(p); i++ {
+			p[i], _ = p[i-1].Mul(FromU64(10))
+		}
+		return p
+	}()
+)
+
+func (n Num) negative() bool { return int64(n.arr[3]) < 0 }
+
+// Cmp compares n and rhs and returns:
+//
+//	-1 if n <  rhs
+//	 0 if n == rhs
+//	+1 if n >  rhs
+func (n Num) Cmp(rhs Num) int {
+	if hi, rhi := int64(n.arr[3]), int64(rhs.arr[3]); hi != rhi {
+		if hi < rhi {
+			return -1
+		}
+		return +1
+	}
+	for i := 2; i >= 0; i-- {
+		switch {
+		case n.arr[i] < rhs.arr[i]:
+			return -1
+		case n.arr[i] > rhs.arr[i]:
+			return +1
+		}
+	}
+	return 0
+}
+
+// Negate returns -n. The negation of the minimum value wraps around.
+func (n Num) Negate() Num {
+	var (
+		out    Num
+		borrow uint64
+	)
+	for i := range n.arr {
+		out.arr[i], borrow = bits.Sub64(0, n.arr[i], borrow)
+	}
+	return out
+}
+
+// Abs returns the absolute value of n, or ErrOverflow if n is the minimum
+// value, whose absolute value does not fit.
+func (n Num) Abs() (Num, error) {
+	if n == minDecimal256 {
+		return Num{}, ErrOverflow
+	}
+	return n.magnitude(), nil
+}
+
+// magnitude returns the absolute value of n, as an unsigned integer: the
+// magnitude of the minimum value is itself.
+func (n Num) magnitude() Num {
+	if n.negative() {
+		return n.Negate()
+	}
+	return n
+}
+
+// Add returns n + rhs, or ErrOverflow if the sum does not fit in 256 bits.
+func (n Num) Add(rhs Num) (Num, error) {
+	var (
+		out   Num
+		carry uint64
+	)
+	for i := range n.arr {
+		out.arr[i], carry = bits.Add64(n.arr[i], rhs.arr[i], carry)
+	}
+	// the sum overflows if the operands have the same sign, and the sum
+	// has the other one.
+	if n.negative() == rhs.negative() && out.negative() != n.negative() {
+		return Num{}, ErrOverflow
+	}
+	return out, nil
+}
+
+// Sub returns n - rhs, or ErrOverflow if the difference does not fit in
+// 256 bits.
+func (n Num) Sub(rhs Num) (Num, error) {
+	var (
+		out    Num
+		borrow uint64
+	)
+	for i := range n.arr {
+		out.arr[i], borrow = bits.Sub64(n.arr[i], rhs.arr[i], borrow)
+	}
+	// the difference overflows if the operands have different signs, and
+	// the difference has the sign of rhs.
+	if n.negative() != rhs.negative() && out.negative() != n.negative() {
+		return Num{}, ErrOverflow
+	}
+	return out, nil
+}
+
+// Mul returns n * rhs, or ErrOverflow if the product does not fit in 256
+// bits.
+func (n Num) Mul(rhs Num) (Num, error) {
+	// multiply the magnitudes, as unsigned 256-bit integers.
+	var (
+		a, b = n.magnitude(), rhs.magnitude()
+		prod [8]uint64
+	)
+	for i := range a.arr {
+		if a.arr[i] == 0 {
+			continue
+		}
+		var carry uint64
+		for j := range b.arr {
+			hi, lo := bits.Mul64(a.arr[i], b.arr[j])
+			var c uint64
+			lo, c = bits.Add64(lo, prod[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			prod[i+j], carry = lo, hi
+		}
+		prod[i+len(b.arr)] = carry
+	}
+	if prod[4]|prod[5]|prod[6]|prod[7] != 0 {
+		return Num{}, ErrOverflow
+	}
+
+	out := Num{arr: [4]uint64{prod[0], prod[1], prod[2], prod[3]}}
+	neg := n.Sign()*rhs.Sign() < 0
+	switch {
+	case !out.negative() && neg:
+		return out.Negate(), nil
+	case !out.negative():
+		return out, nil
+	case neg && out == minDecimal256:
+		return out, nil
+	}
+	return Num{}, ErrOverflow
+}
+
+// Div returns the quotient n / rhs rounded to an integer with mode, and the
+// remainder n - quo*rhs. Div returns ErrDivideByZero if rhs is zero, and
+// ErrOverflow if the quotient does not fit in 256 bits.
+func (n Num) Div(rhs Num, mode RoundMode) (quo, rem Num, err error) {
+	if rhs == (Num{}) {
+		return Num{}, Num{}, ErrDivideByZero
+	}
+
+	v, d := n.ToBigInt(), rhs.ToBigInt()
+	q := mode.Round(new(big.Rat).SetFrac(v, d))
+	r := v.Sub(v, new(big.Int).Mul(q, d))
+
+	if quo, err = FromBigInt(q); err != nil {
+		return Num{}, Num{}, err
+	}
+	if rem, err = FromBigInt(r); err != nil {
+		return Num{}, Num{}, err
+	}
+	return quo, rem, nil
+}
+
+// Rescale returns n, the unscaled value of a decimal of scale fromScale,
+// as the unscaled value of a decimal of scale toScale. Digits dropped by
+// reducing the scale are rounded half to even. Rescale returns ErrOverflow
+// if the result does not fit in 256 bits.
+func (n Num) Rescale(fromScale, toScale int32) (Num, error) {
+	switch delta := int64(toScale) - int64(fromScale); {
+	case delta == 0:
+		return n, nil
+	case delta > 0:
+		if delta > MaxPrecision {
+			if n == (Num{}) {
+				return n, nil
+			}
+			return Num{}, ErrOverflow
+		}
+		return n.Mul(pow10s[delta])
+	default:
+		if -delta > MaxPrecision {
+			// |n| <= 2^255 is less than half of 10^77.
+			return Num{}, nil
+		}
+		quo, _, err := n.Div(pow10s[-delta], RoundHalfToEven)
+		return quo, err
+	}
+}
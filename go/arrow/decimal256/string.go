@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal256
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// maxExponent bounds the exponent of the strings parsed by FromString.
+const maxExponent = 1000
+
+// FromString parses s as a decimal number, such as "-123.45" or "1.2e-3",
+// and returns its unscaled value at the given scale. Digits past the scale
+// are rounded half to even.
+//
+// FromString returns an error if s is not a decimal number, if precision is
+// not in [1, MaxPrecision], or if the value does not fit in precision
+// digits.
+func FromString(s string, precision, scale int32) (Num, error) {
+	if precision < 1 || precision > MaxPrecision {
+		return Num{}, xerrors.Errorf("arrow/decimal256: invalid precision %d", precision)
+	}
+
+	mant, exp, err := parseDecimal(s)
+	if err != nil {
+		return Num{}, err
+	}
+
+	// the value is mant * 10^exp, and its unscaled value is
+	// mant * 10^(exp+scale).
+	switch shift := exp + int64(scale); {
+	case shift > 0:
+		mant.Mul(mant, new(big.Int).Exp(big.NewInt(10), big.NewInt(shift), nil))
+	case shift < 0:
+		d := new(big.Int).Exp(big.NewInt(10), big.NewInt(-shift), nil)
+		mant = RoundHalfToEven.Round(new(big.Rat).SetFrac(mant, d))
+	}
+
+	n, err := FromBigInt(mant)
+	if err != nil || !n.FitsInPrecision(precision) {
+		return Num{}, xerrors.Errorf("arrow/decimal256: %q does not fit in precision %d with scale %d: %w", s, precision, scale, ErrOverflow)
+	}
+	return n, nil
+}
+
+// parseDecimal parses s as mant * 10^exp.
+func parseDecimal(s string) (mant *big.Int, exp int64, err error) {
+	invalid := func() error {
+		return xerrors.Errorf("arrow/decimal256: invalid decimal string %q", s)
+	}
+
+	v := s
+	if i := strings.IndexAny(v, "eE"); i >= 0 {
+		exp, err = strconv.ParseInt(v[i+1:], 10, 64)
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return nil, 0, invalid()
+		}
+		v = v[:i]
+	}
+
+	neg := false
+	switch {
+	case strings.HasPrefix(v, "-"):
+		neg = true
+		v = v[1:]
+	case strings.HasPrefix(v, "+"):
+		v = v[1:]
+	}
+
+	digits := v
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		digits = v[:i] + v[i+1:]
+		exp -= int64(len(v) - i - 1)
+	}
+	if digits == "" {
+		return nil, 0, invalid()
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, 0, invalid()
+		}
+	}
+
+	mant, _ = new(big.Int).SetString(digits, 10)
+	if neg {
+		mant.Neg(mant)
+	}
+	return mant, exp, nil
+}
+
+// ToString returns the decimal representation of n as the unscaled value
+// of a decimal with the given scale, such as "-123.45" for n = -12345 and
+// scale = 2. A negative scale appends zeros to the digits of n.
+func (n Num) ToString(scale int32) string {
+	v := n.ToBigInt()
+	digits := new(big.Int).Abs(v).String()
+	switch {
+	case scale < 0:
+		if v.Sign() != 0 {
+			digits += strings.Repeat("0", int(-scale))
+		}
+	case scale > 0:
+		if pad := int(scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
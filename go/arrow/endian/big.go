@@ -22,4 +22,14 @@ import "encoding/binary"
 
 var Native = binary.BigEndian
 
+// NonNative is the byte order opposite to the native byte order.
+var NonNative = binary.LittleEndian
+
 const IsBigEndian = true
+
+const (
+	// NativeEndian is the byte order of the host.
+	NativeEndian = Big
+	// NonNativeEndian is the byte order opposite to the byte order of the host.
+	NonNativeEndian = Little
+)
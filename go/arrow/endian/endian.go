@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package endian provides the byte order of the host and utilities to
+// swap the byte order of Arrow buffers.
+package endian
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Endianness is the byte order of the values of Arrow buffers.
+type Endianness int8
+
+const (
+	Little Endianness = iota
+	Big
+)
+
+func (e Endianness) String() string {
+	switch e {
+	case Little:
+		return "little"
+	case Big:
+		return "big"
+	}
+	return fmt.Sprintf("Endianness(%d)", int8(e))
+}
+
+// SwapBuffer reverses in place the byte order of the values of buf, each
+// byteWidth bytes wide. A value of 16 bytes, such as a decimal128, is
+// swapped as a single 128-bit integer: the byte order of its two 64-bit
+// words is reversed, and so is the order of the words. So is a value of 32
+// bytes, such as a decimal256, with its four words.
+//
+// Trailing bytes of buf not making up a whole value are left untouched.
+// SwapBuffer panics if byteWidth is not 1, 2, 4, 8, 16 or 32.
+func SwapBuffer(buf []byte, byteWidth int) {
+	n := len(buf) - len(buf)%8
+	switch byteWidth {
+	case 1:
+	case 2:
+		for i := 0; i < n; i += 8 {
+			v := binary.LittleEndian.Uint64(buf[i:])
+			v = (v&0x00ff00ff00ff00ff)<<8 | (v>>8)&0x00ff00ff00ff00ff
+			binary.LittleEndian.PutUint64(buf[i:], v)
+		}
+		for i := n; i+2 <= len(buf); i += 2 {
+			buf[i], buf[i+1] = buf[i+1], buf[i]
+		}
+	case 4:
+		for i := 0; i < n; i += 8 {
+			v := binary.LittleEndian.Uint64(buf[i:])
+			// reversing the 8 bytes also swaps the two 32-bit values.
+			v = bits.RotateLeft64(bits.ReverseBytes64(v), 32)
+			binary.LittleEndian.PutUint64(buf[i:], v)
+		}
+		for i := n; i+4 <= len(buf); i += 4 {
+			binary.LittleEndian.PutUint32(buf[i:], bits.ReverseBytes32(binary.LittleEndian.Uint32(buf[i:])))
+		}
+	case 8:
+		for i := 0; i < n; i += 8 {
+			binary.LittleEndian.PutUint64(buf[i:], bits.ReverseBytes64(binary.LittleEndian.Uint64(buf[i:])))
+		}
+	case 16:
+		for i := 0; i+16 <= len(buf); i += 16 {
+			lo := binary.LittleEndian.Uint64(buf[i:])
+			hi := binary.LittleEndian.Uint64(buf[i+8:])
+			binary.LittleEndian.PutUint64(buf[i:], bits.ReverseBytes64(hi))
+			binary.LittleEndian.PutUint64(buf[i+8:], bits.ReverseBytes64(lo))
+		}
+	case 32:
+		for i := 0; i+32 <= len(buf); i += 32 {
+			for j := 0; j < 16; j += 8 {
+				lo := binary.LittleEndian.Uint64(buf[i+j:])
+				hi := binary.LittleEndian.Uint64(buf[i+24-j:])
+				binary.LittleEndian.PutUint64(buf[i+j:], bits.ReverseBytes64(hi))
+				binary.LittleEndian.PutUint64(buf[i+24-j:], bits.ReverseBytes64(lo))
+			}
+		}
+	default:
+		panic(fmt.Errorf("arrow/endian: invalid byte width %d", byteWidth))
+	}
+}
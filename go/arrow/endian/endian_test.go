@@ -0,0 +1,116 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endian_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/endian"
+)
+
+func TestSwapBuffer(t *testing.T) {
+	in := []byte{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+		0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x10, 0x11, 0x12,
+	}
+	for _, tc := range []struct {
+		width int
+		want  []byte
+	}{
+		{1, in},
+		{2, []byte{
+			0x01, 0x00, 0x03, 0x02, 0x05, 0x04, 0x07, 0x06,
+			0x09, 0x08, 0x0b, 0x0a, 0x0d, 0x0c, 0x0f, 0x0e,
+			0x11, 0x10, 0x12,
+		}},
+		{4, []byte{
+			0x03, 0x02, 0x01, 0x00, 0x07, 0x06, 0x05, 0x04,
+			0x0b, 0x0a, 0x09, 0x08, 0x0f, 0x0e, 0x0d, 0x0c,
+			0x10, 0x11, 0x12,
+		}},
+		{8, []byte{
+			0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x00,
+			0x0f, 0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x09, 0x08,
+			0x10, 0x11, 0x12,
+		}},
+		{16, []byte{
+			0x0f, 0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x09, 0x08,
+			0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x00,
+			0x10, 0x11, 0x12,
+		}},
+	} {
+		buf := append([]byte(nil), in...)
+		endian.SwapBuffer(buf, tc.width)
+		if !bytes.Equal(buf, tc.want) {
+			t.Fatalf("width=%d: got=%#v, want=%#v", tc.width, buf, tc.want)
+		}
+
+		endian.SwapBuffer(buf, tc.width)
+		if !bytes.Equal(buf, in) {
+			t.Fatalf("width=%d: double swap: got=%#v, want=%#v", tc.width, buf, in)
+		}
+	}
+}
+
+func TestSwapBuffer256(t *testing.T) {
+	in := make([]byte, 35)
+	for i := range in {
+		in[i] = byte(i)
+	}
+	want := append([]byte(nil), in...)
+	for i := 0; i < 32; i++ {
+		want[i] = in[31-i]
+	}
+
+	buf := append([]byte(nil), in...)
+	endian.SwapBuffer(buf, 32)
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("got=%#v, want=%#v", buf, want)
+	}
+	endian.SwapBuffer(buf, 32)
+	if !bytes.Equal(buf, in) {
+		t.Fatalf("double swap: got=%#v, want=%#v", buf, in)
+	}
+}
+
+func TestSwapBufferInvalid(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	endian.SwapBuffer(make([]byte, 6), 3)
+}
+
+func TestNativeEndian(t *testing.T) {
+	buf := []byte{1, 0}
+	want := endian.Little
+	if endian.Native.Uint16(buf) != 1 {
+		want = endian.Big
+	}
+	if endian.NativeEndian != want {
+		t.Fatalf("invalid native endianness: got=%v, want=%v", endian.NativeEndian, want)
+	}
+	if endian.NonNativeEndian == endian.NativeEndian {
+		t.Fatalf("non-native endianness should differ from native endianness")
+	}
+	if got, want := endian.NonNative.Uint16(buf), uint16(0x0100); got != want {
+		t.Fatalf("invalid non-native byte order: got=%#x, want=%#x", got, want)
+	}
+}
@@ -22,4 +22,14 @@ import "encoding/binary"
 
 var Native = binary.LittleEndian
 
-var IsBigEndian = false
+// NonNative is the byte order opposite to the native byte order.
+var NonNative = binary.BigEndian
+
+const IsBigEndian = false
+
+const (
+	// NativeEndian is the byte order of the host.
+	NativeEndian = Little
+	// NonNativeEndian is the byte order opposite to the byte order of the host.
+	NonNativeEndian = Big
+)
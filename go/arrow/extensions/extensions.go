@@ -0,0 +1,35 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package extensions provides the canonical extension types of Arrow,
+// registered with arrow.RegisterExtensionType so that IPC readers
+// reconstruct them:
+//
+//   - UUIDType, "arrow.uuid", stored as fixed_size_binary[16];
+//   - JSONType, "arrow.json", stored as utf8.
+package extensions // import "github.com/apache/arrow/go/arrow/extensions"
+
+import (
+	"github.com/apache/arrow/go/arrow"
+)
+
+func init() {
+	for _, typ := range []arrow.ExtensionType{NewUUIDType(), NewJSONType()} {
+		if err := arrow.RegisterExtensionType(typ); err != nil {
+			panic(err)
+		}
+	}
+}
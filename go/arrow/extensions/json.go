@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// JSONType is the canonical "arrow.json" extension type, whose values are
+// JSON documents stored as utf8.
+type JSONType struct {
+	arrow.ExtensionBase
+}
+
+// NewJSONType returns the JSON extension type.
+func NewJSONType() *JSONType {
+	return &JSONType{ExtensionBase: arrow.ExtensionBase{Storage: arrow.BinaryTypes.String}}
+}
+
+func (*JSONType) Name() string          { return "extension" }
+func (*JSONType) String() string        { return "extension<arrow.json>" }
+func (*JSONType) ExtensionName() string { return "arrow.json" }
+func (*JSONType) Serialize() string     { return "" }
+
+// ArrayType returns the type of the arrays of JSON documents, JSONArray.
+func (*JSONType) ArrayType() reflect.Type { return reflect.TypeOf(JSONArray{}) }
+
+// ExtensionEquals returns whether other is the JSON extension type.
+func (*JSONType) ExtensionEquals(other arrow.ExtensionType) bool {
+	_, ok := other.(*JSONType)
+	return ok
+}
+
+// Deserialize returns the JSON extension type, whose storage type must be
+// utf8.
+func (*JSONType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	if storage.ID() != arrow.STRING {
+		return nil, xerrors.Errorf("arrow/extensions: invalid storage type %v for arrow.json", storage)
+	}
+	return NewJSONType(), nil
+}
+
+// NewBuilder returns a builder of arrays of JSON documents.
+func (*JSONType) NewBuilder(mem memory.Allocator) array.Builder {
+	return NewJSONBuilder(mem)
+}
+
+// JSONArray is an array of JSON documents.
+type JSONArray struct {
+	array.ExtensionArrayBase
+}
+
+// Value returns the JSON document at i.
+func (a *JSONArray) Value(i int) json.RawMessage {
+	return json.RawMessage(a.Storage().(*array.String).Value(i))
+}
+
+// Unmarshal decodes the JSON document at i into v.
+func (a *JSONArray) Unmarshal(i int, v interface{}) error {
+	return json.Unmarshal(a.Value(i), v)
+}
+
+func (a *JSONArray) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			o.Write(a.Value(i))
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+// JSONBuilder builds arrays of JSON documents.
+type JSONBuilder struct {
+	*array.ExtensionBuilder
+}
+
+// NewJSONBuilder returns a builder of arrays of JSON documents.
+func NewJSONBuilder(mem memory.Allocator) *JSONBuilder {
+	return &JSONBuilder{ExtensionBuilder: array.NewExtensionBuilder(mem, NewJSONType())}
+}
+
+// Append appends the JSON document v.
+// Append panics if v is not valid JSON.
+func (b *JSONBuilder) Append(v json.RawMessage) {
+	if !json.Valid(v) {
+		panic(xerrors.Errorf("arrow/extensions: invalid JSON document %q", v))
+	}
+	b.StorageBuilder().(*array.StringBuilder).Append(string(v))
+}
+
+// AppendValue appends the JSON encoding of v.
+func (b *JSONBuilder) AppendValue(v interface{}) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return xerrors.Errorf("arrow/extensions: could not encode JSON document: %w", err)
+	}
+	b.StorageBuilder().(*array.StringBuilder).Append(string(doc))
+	return nil
+}
+
+// NewJSONArray creates a new array of JSON documents from the memory
+// buffers used by the builder and resets the builder so it can be used to
+// build a new array.
+func (b *JSONBuilder) NewJSONArray() *JSONArray {
+	return b.NewExtensionArray().(*JSONArray)
+}
+
+var (
+	_ arrow.ExtensionType          = (*JSONType)(nil)
+	_ array.CustomExtensionBuilder = (*JSONType)(nil)
+	_ array.ExtensionArray         = (*JSONArray)(nil)
+)
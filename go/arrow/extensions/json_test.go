@@ -0,0 +1,82 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/extensions"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestJSONArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	b := array.NewBuilder(pool, extensions.NewJSONType()).(*extensions.JSONBuilder)
+	defer b.Release()
+
+	b.Append(json.RawMessage(`{"a":1}`))
+	b.AppendNull()
+	if err := b.AppendValue([]int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AppendValue(make(chan int)); err == nil {
+		t.Fatalf("expected an error encoding a channel")
+	}
+
+	arr := b.NewJSONArray()
+	defer arr.Release()
+
+	if got, want := arr.DataType().(fmt.Stringer).String(), "extension<arrow.json>"; got != want {
+		t.Fatalf("invalid type: got=%q, want=%q", got, want)
+	}
+	if got := arr.Storage().DataType(); got.ID() != arrow.STRING {
+		t.Fatalf("invalid storage type %v", got)
+	}
+	if got, want := arr.String(), `[{"a":1} (null) [1,2]]`; got != want {
+		t.Fatalf("invalid string: got=%s, want=%s", got, want)
+	}
+
+	var v map[string]int
+	if err := arr.Unmarshal(0, &v); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"a": 1}; !reflect.DeepEqual(v, want) {
+		t.Fatalf("invalid value: got=%v, want=%v", v, want)
+	}
+}
+
+func TestJSONBuilderInvalid(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	b := extensions.NewJSONBuilder(pool)
+	defer b.Release()
+
+	defer func() {
+		if e := recover(); e == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	b.Append(json.RawMessage(`{"a":`))
+}
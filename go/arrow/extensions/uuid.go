@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// UUID is a universally unique identifier, as defined by RFC 4122.
+type UUID [16]byte
+
+// ParseUUID parses a UUID from its hyphenated hexadecimal form, such as
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, xerrors.Errorf("arrow/extensions: invalid UUID %q", s)
+	}
+	_, err := hex.Decode(u[:], []byte(strings.Replace(s, "-", "", -1)))
+	if err != nil {
+		return u, xerrors.Errorf("arrow/extensions: invalid UUID %q: %w", s, err)
+	}
+	return u, nil
+}
+
+// String returns the hyphenated hexadecimal form of u.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// UUIDType is the canonical "arrow.uuid" extension type, whose values are
+// UUIDs stored as fixed_size_binary[16].
+type UUIDType struct {
+	arrow.ExtensionBase
+}
+
+// NewUUIDType returns the UUID extension type.
+func NewUUIDType() *UUIDType {
+	return &UUIDType{ExtensionBase: arrow.ExtensionBase{
+		Storage: &arrow.FixedSizeBinaryType{ByteWidth: 16},
+	}}
+}
+
+func (*UUIDType) Name() string          { return "extension" }
+func (*UUIDType) String() string        { return "extension<arrow.uuid>" }
+func (*UUIDType) ExtensionName() string { return "arrow.uuid" }
+func (*UUIDType) Serialize() string     { return "" }
+
+// ArrayType returns the type of the arrays of UUIDs, UUIDArray.
+func (*UUIDType) ArrayType() reflect.Type { return reflect.TypeOf(UUIDArray{}) }
+
+// ExtensionEquals returns whether other is the UUID extension type.
+func (*UUIDType) ExtensionEquals(other arrow.ExtensionType) bool {
+	_, ok := other.(*UUIDType)
+	return ok
+}
+
+// Deserialize returns the UUID extension type, whose storage type must be
+// fixed_size_binary[16].
+func (*UUIDType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	if dt, ok := storage.(*arrow.FixedSizeBinaryType); !ok || dt.ByteWidth != 16 {
+		return nil, xerrors.Errorf("arrow/extensions: invalid storage type %v for arrow.uuid", storage)
+	}
+	return NewUUIDType(), nil
+}
+
+// NewBuilder returns a builder of arrays of UUIDs.
+func (*UUIDType) NewBuilder(mem memory.Allocator) array.Builder {
+	return NewUUIDBuilder(mem)
+}
+
+// UUIDArray is an array of UUIDs.
+type UUIDArray struct {
+	array.ExtensionArrayBase
+}
+
+// Value returns the UUID at i.
+func (a *UUIDArray) Value(i int) UUID {
+	var u UUID
+	copy(u[:], a.Storage().(*array.FixedSizeBinary).Value(i))
+	return u
+}
+
+func (a *UUIDArray) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < a.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			fmt.Fprintf(o, "%q", a.Value(i))
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+// UUIDBuilder builds arrays of UUIDs.
+type UUIDBuilder struct {
+	*array.ExtensionBuilder
+}
+
+// NewUUIDBuilder returns a builder of arrays of UUIDs.
+func NewUUIDBuilder(mem memory.Allocator) *UUIDBuilder {
+	return &UUIDBuilder{ExtensionBuilder: array.NewExtensionBuilder(mem, NewUUIDType())}
+}
+
+// Append appends the UUID v.
+func (b *UUIDBuilder) Append(v UUID) {
+	b.StorageBuilder().(*array.FixedSizeBinaryBuilder).Append(v[:])
+}
+
+// AppendValues appends the UUIDs of v, and their validity, all the values
+// being valid if valid is empty.
+func (b *UUIDBuilder) AppendValues(v []UUID, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+	for i, u := range v {
+		if len(valid) != 0 && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		b.Append(u)
+	}
+}
+
+// NewUUIDArray creates a new array of UUIDs from the memory buffers used by
+// the builder and resets the builder so it can be used to build a new array.
+func (b *UUIDBuilder) NewUUIDArray() *UUIDArray {
+	return b.NewExtensionArray().(*UUIDArray)
+}
+
+var (
+	_ arrow.ExtensionType          = (*UUIDType)(nil)
+	_ array.CustomExtensionBuilder = (*UUIDType)(nil)
+	_ array.ExtensionArray         = (*UUIDArray)(nil)
+)
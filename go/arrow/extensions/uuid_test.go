@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/extensions"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestParseUUID(t *testing.T) {
+	const s = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	u, err := extensions.ParseUUID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.String(); got != s {
+		t.Fatalf("invalid UUID: got=%q, want=%q", got, s)
+	}
+	if u[0] != 0xf8 || u[15] != 0xf6 {
+		t.Fatalf("invalid UUID bytes: %x", u[:])
+	}
+
+	for _, s := range []string{
+		"",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bfz",
+		"f81d4fae-7dec-11d0-a765_00a0c91e6bf6",
+	} {
+		if _, err := extensions.ParseUUID(s); err == nil {
+			t.Errorf("expected an error parsing %q", s)
+		}
+	}
+}
+
+func TestUUIDArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	u1, _ := extensions.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	u2, _ := extensions.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+
+	b := array.NewBuilder(pool, extensions.NewUUIDType()).(*extensions.UUIDBuilder)
+	defer b.Release()
+	b.AppendValues([]extensions.UUID{u1, {}, u2}, []bool{true, false, true})
+
+	arr := b.NewUUIDArray()
+	defer arr.Release()
+
+	if got, want := arr.DataType().(fmt.Stringer).String(), "extension<arrow.uuid>"; got != want {
+		t.Fatalf("invalid type: got=%q, want=%q", got, want)
+	}
+	if got := arr.Storage().DataType(); !arrow.TypeEqual(got, &arrow.FixedSizeBinaryType{ByteWidth: 16}) {
+		t.Fatalf("invalid storage type %v", got)
+	}
+	if arr.Value(0) != u1 || !arr.IsNull(1) || arr.Value(2) != u2 {
+		t.Fatalf("invalid values: %v", arr)
+	}
+
+	want := `["f81d4fae-7dec-11d0-a765-00a0c91e6bf6" (null) "123e4567-e89b-12d3-a456-426614174000"]`
+	if got := arr.String(); got != want {
+		t.Fatalf("invalid string:\ngot= %s\nwant=%s", got, want)
+	}
+
+	slice := array.NewSlice(arr, 1, 3).(*extensions.UUIDArray)
+	defer slice.Release()
+	if !slice.IsNull(0) || slice.Value(1) != u2 {
+		t.Fatalf("invalid slice: %v", slice)
+	}
+}
+
+func TestUUIDTypeDeserialize(t *testing.T) {
+	typ := extensions.NewUUIDType()
+	got, err := typ.Deserialize(&arrow.FixedSizeBinaryType{ByteWidth: 16}, typ.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !arrow.TypeEqual(got, typ) {
+		t.Fatalf("invalid type: got=%v, want=%v", got, typ)
+	}
+
+	if _, err := typ.Deserialize(&arrow.FixedSizeBinaryType{ByteWidth: 8}, ""); err == nil {
+		t.Fatalf("expected an error with an invalid storage type")
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	for _, name := range []string{"arrow.uuid", "arrow.json"} {
+		if arrow.GetExtensionType(name) == nil {
+			t.Errorf("extension type %q not registered", name)
+		}
+	}
+}
@@ -44,6 +44,30 @@ type FlightServiceClient interface {
 	// service.
 	GetFlightInfo(ctx context.Context, in *FlightDescriptor, opts ...grpc.CallOption) (*FlightInfo, error)
 	//
+	// For a given FlightDescriptor, start a query and get information
+	// to poll its execution status. This is a useful interface if the
+	// query may be a long-running query. The first PollFlightInfo call
+	// should return as quickly as possible. (GetFlightInfo doesn't
+	// return until the query is complete.)
+	//
+	// A client can consume any available results before
+	// the query is completed. See PollInfo.info for details.
+	//
+	// A client can poll the updated query status by calling
+	// PollFlightInfo() with PollInfo.flight_descriptor. A server
+	// should not respond until the result would be different from last
+	// time. That way, the client can "long poll" for updates
+	// without constantly making requests. Clients can set a short timeout
+	// to avoid blocking calls if desired.
+	//
+	// A client can't use PollInfo.flight_descriptor after
+	// PollInfo.expiration_time passes. A server might not accept the
+	// retry descriptor anymore and the query may be cancelled.
+	//
+	// A client may use the CancelFlightInfo action with
+	// PollInfo.info to cancel the running query.
+	PollFlightInfo(ctx context.Context, in *FlightDescriptor, opts ...grpc.CallOption) (*PollInfo, error)
+	//
 	// For a given FlightDescriptor, get the Schema as described in Schema.fbs::Schema
 	// This is used when a consumer needs the Schema of flight stream. Similar to
 	// GetFlightInfo this interface may generate a new flight that was not previously
@@ -180,6 +204,19 @@ func (c *flightServiceClient) GetFlightInfo(ctx context.Context, in *FlightDescr
 	return out, nil
 }
 
+var flightServicePollFlightInfoStreamDesc = &grpc.StreamDesc{
+	StreamName: "PollFlightInfo",
+}
+
+func (c *flightServiceClient) PollFlightInfo(ctx context.Context, in *FlightDescriptor, opts ...grpc.CallOption) (*PollInfo, error) {
+	out := new(PollInfo)
+	err := c.cc.Invoke(ctx, "/arrow.flight.protocol.FlightService/PollFlightInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 var flightServiceGetSchemaStreamDesc = &grpc.StreamDesc{
 	StreamName: "GetSchema",
 }
@@ -410,6 +447,30 @@ type FlightServiceService struct {
 	// service.
 	GetFlightInfo func(context.Context, *FlightDescriptor) (*FlightInfo, error)
 	//
+	// For a given FlightDescriptor, start a query and get information
+	// to poll its execution status. This is a useful interface if the
+	// query may be a long-running query. The first PollFlightInfo call
+	// should return as quickly as possible. (GetFlightInfo doesn't
+	// return until the query is complete.)
+	//
+	// A client can consume any available results before
+	// the query is completed. See PollInfo.info for details.
+	//
+	// A client can poll the updated query status by calling
+	// PollFlightInfo() with PollInfo.flight_descriptor. A server
+	// should not respond until the result would be different from last
+	// time. That way, the client can "long poll" for updates
+	// without constantly making requests. Clients can set a short timeout
+	// to avoid blocking calls if desired.
+	//
+	// A client can't use PollInfo.flight_descriptor after
+	// PollInfo.expiration_time passes. A server might not accept the
+	// retry descriptor anymore and the query may be cancelled.
+	//
+	// A client may use the CancelFlightInfo action with
+	// PollInfo.info to cancel the running query.
+	PollFlightInfo func(context.Context, *FlightDescriptor) (*PollInfo, error)
+	//
 	// For a given FlightDescriptor, get the Schema as described in Schema.fbs::Schema
 	// This is used when a consumer needs the Schema of flight stream. Similar to
 	// GetFlightInfo this interface may generate a new flight that was not previously
@@ -478,6 +539,23 @@ func (s *FlightServiceService) getFlightInfo(_ interface{}, ctx context.Context,
 	}
 	return interceptor(ctx, in, info, handler)
 }
+func (s *FlightServiceService) pollFlightInfo(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlightDescriptor)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return s.PollFlightInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     s,
+		FullMethod: "/arrow.flight.protocol.FlightService/PollFlightInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.PollFlightInfo(ctx, req.(*FlightDescriptor))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 func (s *FlightServiceService) getSchema(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FlightDescriptor)
 	if err := dec(in); err != nil {
@@ -659,6 +737,11 @@ func RegisterFlightServiceService(s grpc.ServiceRegistrar, srv *FlightServiceSer
 			return nil, status.Errorf(codes.Unimplemented, "method GetFlightInfo not implemented")
 		}
 	}
+	if srvCopy.PollFlightInfo == nil {
+		srvCopy.PollFlightInfo = func(context.Context, *FlightDescriptor) (*PollInfo, error) {
+			return nil, status.Errorf(codes.Unimplemented, "method PollFlightInfo not implemented")
+		}
+	}
 	if srvCopy.GetSchema == nil {
 		srvCopy.GetSchema = func(context.Context, *FlightDescriptor) (*SchemaResult, error) {
 			return nil, status.Errorf(codes.Unimplemented, "method GetSchema not implemented")
@@ -696,6 +779,10 @@ func RegisterFlightServiceService(s grpc.ServiceRegistrar, srv *FlightServiceSer
 				MethodName: "GetFlightInfo",
 				Handler:    srvCopy.getFlightInfo,
 			},
+			{
+				MethodName: "PollFlightInfo",
+				Handler:    srvCopy.pollFlightInfo,
+			},
 			{
 				MethodName: "GetSchema",
 				Handler:    srvCopy.getSchema,
@@ -770,6 +857,11 @@ func NewFlightServiceService(s interface{}) *FlightServiceService {
 	}); ok {
 		ns.GetFlightInfo = h.GetFlightInfo
 	}
+	if h, ok := s.(interface {
+		PollFlightInfo(context.Context, *FlightDescriptor) (*PollInfo, error)
+	}); ok {
+		ns.PollFlightInfo = h.PollFlightInfo
+	}
 	if h, ok := s.(interface {
 		GetSchema(context.Context, *FlightDescriptor) (*SchemaResult, error)
 	}); ok {
@@ -835,6 +927,30 @@ type UnstableFlightServiceService interface {
 	// service.
 	GetFlightInfo(context.Context, *FlightDescriptor) (*FlightInfo, error)
 	//
+	// For a given FlightDescriptor, start a query and get information
+	// to poll its execution status. This is a useful interface if the
+	// query may be a long-running query. The first PollFlightInfo call
+	// should return as quickly as possible. (GetFlightInfo doesn't
+	// return until the query is complete.)
+	//
+	// A client can consume any available results before
+	// the query is completed. See PollInfo.info for details.
+	//
+	// A client can poll the updated query status by calling
+	// PollFlightInfo() with PollInfo.flight_descriptor. A server
+	// should not respond until the result would be different from last
+	// time. That way, the client can "long poll" for updates
+	// without constantly making requests. Clients can set a short timeout
+	// to avoid blocking calls if desired.
+	//
+	// A client can't use PollInfo.flight_descriptor after
+	// PollInfo.expiration_time passes. A server might not accept the
+	// retry descriptor anymore and the query may be cancelled.
+	//
+	// A client may use the CancelFlightInfo action with
+	// PollInfo.info to cancel the running query.
+	PollFlightInfo(context.Context, *FlightDescriptor) (*PollInfo, error)
+	//
 	// For a given FlightDescriptor, get the Schema as described in Schema.fbs::Schema
 	// This is used when a consumer needs the Schema of flight stream. Similar to
 	// GetFlightInfo this interface may generate a new flight that was not previously
@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ActionFunc is the handler of an action registered with
+// Server.RegisterAction. It receives the body of the action and sends its
+// results on results, each result being sent to the client as it is
+// received rather than once the handler returns. ctx is the context of the
+// call, AuthFromContext returns the identity of its client, and it is
+// cancelled when the client goes away, after which the results are
+// dropped.
+type ActionFunc func(ctx context.Context, body []byte, results chan<- *Result) error
+
+type registeredAction struct {
+	typ *ActionType
+	fn  ActionFunc
+}
+
+// actionRegistry dispatches DoAction calls to the registered actions and
+// lists them for ListActions, in the order they were registered.
+type actionRegistry struct {
+	mu       sync.RWMutex
+	actions  []registeredAction
+	fallback ActionFunc
+}
+
+func (r *actionRegistry) register(name, description string, fn ActionFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name == "" {
+		r.fallback = fn
+		return
+	}
+
+	action := registeredAction{typ: &ActionType{Type: name, Description: description}, fn: fn}
+	for i, a := range r.actions {
+		if a.typ.Type == name {
+			r.actions[i] = action
+			return
+		}
+	}
+	r.actions = append(r.actions, action)
+}
+
+func (r *actionRegistry) lookup(name string) ActionFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, a := range r.actions {
+		if a.typ.Type == name {
+			return a.fn
+		}
+	}
+	return r.fallback
+}
+
+func (r *actionRegistry) listActions(_ *Empty, stream FlightService_ListActionsServer) error {
+	r.mu.RLock()
+	types := make([]*ActionType, len(r.actions))
+	for i, a := range r.actions {
+		types[i] = a.typ
+	}
+	r.mu.RUnlock()
+
+	for _, typ := range types {
+		if err := stream.Send(typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *actionRegistry) doAction(action *Action, stream FlightService_DoActionServer) error {
+	fn := r.lookup(action.GetType())
+	if fn == nil {
+		return status.Errorf(codes.Unimplemented, "flight: unknown action %q", action.GetType())
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var (
+		results = make(chan *Result)
+		errc    = make(chan error, 1)
+	)
+	go func() {
+		defer close(results)
+		errc <- fn(ctx, action.GetBody(), results)
+	}()
+
+	// keep draining the results once sending failed, so that the handler
+	// returns.
+	var sendErr error
+	for res := range results {
+		if sendErr != nil {
+			continue
+		}
+		if sendErr = stream.Send(res); sendErr != nil {
+			cancel()
+		}
+	}
+
+	if err := <-errc; err != nil {
+		return err
+	}
+	return sendErr
+}
@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// collectResults returns the bodies of the results of the action typ.
+func collectResults(ctx context.Context, client flight.Client, typ string, body []byte) ([]string, error) {
+	stream, err := client.DoAction(ctx, &flight.Action{Type: typ, Body: body})
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, string(res.Body))
+	}
+}
+
+func TestActionRegistry(t *testing.T) {
+	s := flight.NewFlightServer(&servAuth{})
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{})
+
+	// countdown streams its results one at a time, waiting for the client
+	// to acknowledge each of them.
+	acks := make(chan struct{})
+	s.RegisterAction("countdown", "Counts down from the body.", func(ctx context.Context, body []byte, results chan<- *flight.Result) error {
+		n, err := strconv.Atoi(string(body))
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid count %q", body)
+		}
+		for i := n; i > 0; i-- {
+			results <- &flight.Result{Body: []byte(strconv.Itoa(i))}
+			if string(body) == "3" {
+				<-acks
+			}
+		}
+		return nil
+	})
+	s.RegisterAction("whoami", "Returns the identity of the client.", func(ctx context.Context, _ []byte, results chan<- *flight.Result) error {
+		results <- &flight.Result{Body: []byte(fmt.Sprint(flight.AuthFromContext(ctx)))}
+		return nil
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), &clientAuth{}, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Authenticate(context.WithValue(context.Background(), ctxauth{}, []byte("foobar"))); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), ctxauth{}, "baz")
+
+	t.Run("list", func(t *testing.T) {
+		stream, err := client.ListActions(ctx, &flight.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var types []string
+		for {
+			typ, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			types = append(types, typ.Type+": "+typ.Description)
+		}
+		want := []string{"countdown: Counts down from the body.", "whoami: Returns the identity of the client."}
+		if fmt.Sprint(types) != fmt.Sprint(want) {
+			t.Fatalf("invalid actions: got=%q, want=%q", types, want)
+		}
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		stream, err := client.DoAction(ctx, &flight.Action{Type: "countdown", Body: []byte("3")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// each result is received before the next one is produced.
+		for _, want := range []string{"3", "2", "1"} {
+			res, err := stream.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(res.Body) != want {
+				t.Fatalf("invalid result: got=%q, want=%q", res.Body, want)
+			}
+			acks <- struct{}{}
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("auth", func(t *testing.T) {
+		got, err := collectResults(ctx, client, "whoami", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0] != "bar" {
+			t.Fatalf("invalid identity: %q", got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := collectResults(ctx, client, "countdown", []byte("many")); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := collectResults(ctx, client, "unknown", nil); status.Code(err) != codes.Unimplemented {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		s.RegisterAction("", "", func(ctx context.Context, body []byte, results chan<- *flight.Result) error {
+			results <- &flight.Result{Body: []byte("fallback")}
+			return nil
+		})
+		got, err := collectResults(ctx, client, "unknown", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0] != "fallback" {
+			t.Fatalf("invalid results: %q", got)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 1; i <= 16; i++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				got, err := collectResults(ctx, client, "countdown", []byte(strconv.Itoa(n+3)))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if len(got) != n+3 || got[0] != strconv.Itoa(n+3) {
+					t.Errorf("invalid results for %d: %q", n+3, got)
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+func TestActionRegistryServiceHandlers(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoAction: func(action *flight.Action, stream flight.FlightService_DoActionServer) error {
+			return stream.Send(&flight.Result{Body: []byte("service")})
+		},
+	})
+	s.RegisterAction("registered", "", func(ctx context.Context, body []byte, results chan<- *flight.Result) error {
+		results <- &flight.Result{Body: []byte("registered")}
+		return nil
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// the DoAction handler of the service takes precedence.
+	got, err := collectResults(context.Background(), client, "registered", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "service" {
+		t.Fatalf("invalid results: %q", got)
+	}
+}
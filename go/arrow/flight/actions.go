@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The types of the well-known actions of the Flight protocol, see
+// ActionHandler.
+const (
+	CancelFlightInfoActionType    = "CancelFlightInfo"
+	RenewFlightEndpointActionType = "RenewFlightEndpoint"
+)
+
+var (
+	// CancelFlightInfoAction describes the CancelFlightInfo action for
+	// ListActions.
+	CancelFlightInfoAction = &ActionType{
+		Type:        CancelFlightInfoActionType,
+		Description: "Explicitly cancel a running FlightInfo.\nRequest Message: CancelFlightInfoRequest\nResponse Message: CancelFlightInfoResult",
+	}
+	// RenewFlightEndpointAction describes the RenewFlightEndpoint action
+	// for ListActions.
+	RenewFlightEndpointAction = &ActionType{
+		Type:        RenewFlightEndpointActionType,
+		Description: "Extend expiration time of the given FlightEndpoint.\nRequest Message: RenewFlightEndpointRequest\nResponse Message: Renewed FlightEndpoint",
+	}
+)
+
+func (c *client) CancelFlightInfo(ctx context.Context, request *CancelFlightInfoRequest, opts ...grpc.CallOption) (CancelStatus, error) {
+	var result CancelFlightInfoResult
+	if err := c.doWellKnownAction(ctx, CancelFlightInfoActionType, request, &result, opts...); err != nil {
+		return CancelStatus_CANCEL_STATUS_UNSPECIFIED, err
+	}
+	return result.Status, nil
+}
+
+func (c *client) RenewFlightEndpoint(ctx context.Context, request *RenewFlightEndpointRequest, opts ...grpc.CallOption) (*FlightEndpoint, error) {
+	var endpoint FlightEndpoint
+	if err := c.doWellKnownAction(ctx, RenewFlightEndpointActionType, request, &endpoint, opts...); err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// doWellKnownAction runs the action typ with request as its body, and
+// decodes its single result into result.
+func (c *client) doWellKnownAction(ctx context.Context, typ string, request, result proto.Message, opts ...grpc.CallOption) error {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "flight: could not encode %s request: %v", typ, err)
+	}
+
+	stream, err := c.FlightServiceClient.DoAction(ctx, &Action{Type: typ, Body: body}, opts...)
+	if err != nil {
+		return err
+	}
+
+	res, err := stream.Recv()
+	if err == io.EOF {
+		return status.Errorf(codes.Internal, "flight: no result for action %s", typ)
+	}
+	if err != nil {
+		return err
+	}
+
+	// drain the stream so that the call completes.
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if err := proto.Unmarshal(res.Body, result); err != nil {
+		return status.Errorf(codes.Internal, "flight: could not decode %s result: %v", typ, err)
+	}
+	return nil
+}
+
+// ActionHandlers holds the handlers of the well-known actions of the
+// Flight protocol, see ActionHandler. A nil handler leaves its action to
+// the DoAction handler of the service.
+type ActionHandlers struct {
+	// CancelFlightInfo cancels the query of the request info, returning
+	// how far the cancellation went.
+	CancelFlightInfo func(context.Context, *CancelFlightInfoRequest) (CancelStatus, error)
+	// RenewFlightEndpoint extends the expiration time of the request
+	// endpoint, returning the renewed endpoint.
+	RenewFlightEndpoint func(context.Context, *RenewFlightEndpointRequest) (*FlightEndpoint, error)
+}
+
+// ActionHandler returns a DoAction handler for FlightServiceService which
+// decodes the requests of the well-known actions, passes them to their
+// handler in handlers and encodes their result. Other actions are passed
+// to doAction, and fail with an Unimplemented error when it is nil.
+func ActionHandler(handlers ActionHandlers, doAction func(*Action, FlightService_DoActionServer) error) func(*Action, FlightService_DoActionServer) error {
+	return func(action *Action, stream FlightService_DoActionServer) error {
+		var (
+			result proto.Message
+			err    error
+		)
+		switch {
+		case action.GetType() == CancelFlightInfoActionType && handlers.CancelFlightInfo != nil:
+			var request CancelFlightInfoRequest
+			if err := unmarshalActionBody(action, &request); err != nil {
+				return err
+			}
+			var st CancelStatus
+			st, err = handlers.CancelFlightInfo(stream.Context(), &request)
+			result = &CancelFlightInfoResult{Status: st}
+		case action.GetType() == RenewFlightEndpointActionType && handlers.RenewFlightEndpoint != nil:
+			var request RenewFlightEndpointRequest
+			if err := unmarshalActionBody(action, &request); err != nil {
+				return err
+			}
+			result, err = handlers.RenewFlightEndpoint(stream.Context(), &request)
+		case doAction != nil:
+			return doAction(action, stream)
+		default:
+			return status.Errorf(codes.Unimplemented, "action %s not implemented", action.GetType())
+		}
+		if err != nil {
+			return err
+		}
+
+		body, err := proto.Marshal(result)
+		if err != nil {
+			return status.Errorf(codes.Internal, "could not encode %s result: %v", action.GetType(), err)
+		}
+		return stream.Send(&Result{Body: body})
+	}
+}
+
+func unmarshalActionBody(action *Action, request proto.Message) error {
+	if err := proto.Unmarshal(action.GetBody(), request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid %s request: %v", action.GetType(), err)
+	}
+	return nil
+}
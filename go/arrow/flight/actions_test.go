@@ -0,0 +1,229 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// cancellableQueries serves queries whose DoGet streams records until
+// they are cancelled by the CancelFlightInfo action.
+type cancellableQueries struct {
+	mu        sync.Mutex
+	cancelled map[string]chan struct{}
+	observed  chan string
+}
+
+func (q *cancellableQueries) done(ticket string) chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ch, ok := q.cancelled[ticket]
+	if !ok {
+		ch = make(chan struct{})
+		q.cancelled[ticket] = ch
+	}
+	return ch
+}
+
+func (q *cancellableQueries) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	recs := arrdata.Records["primitives"]
+	done := q.done(string(tkt.GetTicket()))
+
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(recs[0].Schema()))
+	defer w.Close()
+	for {
+		select {
+		case <-done:
+			q.observed <- string(tkt.GetTicket())
+			return status.Error(codes.Canceled, "query cancelled")
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		default:
+		}
+		if err := w.Write(recs[0]); err != nil {
+			return err
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (q *cancellableQueries) CancelFlightInfo(_ context.Context, req *flight.CancelFlightInfoRequest) (flight.CancelStatus, error) {
+	for _, ep := range req.GetInfo().GetEndpoint() {
+		close(q.done(string(ep.GetTicket().GetTicket())))
+	}
+	return flight.CancelStatus_CANCEL_STATUS_CANCELLED, nil
+}
+
+func (q *cancellableQueries) RenewFlightEndpoint(_ context.Context, req *flight.RenewFlightEndpointRequest) (*flight.FlightEndpoint, error) {
+	ep := req.GetEndpoint()
+	if ep == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing endpoint")
+	}
+	ep.ExpirationTime = timestamppb.New(ep.GetExpirationTime().AsTime().Add(time.Hour))
+	return ep, nil
+}
+
+func TestWellKnownActions(t *testing.T) {
+	q := &cancellableQueries{cancelled: make(map[string]chan struct{}), observed: make(chan string, 1)}
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: q.DoGet,
+		DoAction: flight.ActionHandler(flight.ActionHandlers{
+			CancelFlightInfo:    q.CancelFlightInfo,
+			RenewFlightEndpoint: q.RenewFlightEndpoint,
+		}, func(action *flight.Action, stream flight.FlightService_DoActionServer) error {
+			return stream.Send(&flight.Result{Body: []byte("custom " + action.GetType())})
+		}),
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	t.Run("cancel", func(t *testing.T) {
+		ep := &flight.FlightEndpoint{Ticket: &flight.Ticket{Ticket: []byte("query-1")}}
+		stream, err := client.DoGet(context.Background(), ep.Ticket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := flight.NewRecordReader(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+		if !r.Next() {
+			t.Fatalf("no record before cancellation: %v", r.Err())
+		}
+
+		st, err := client.CancelFlightInfo(context.Background(), &flight.CancelFlightInfoRequest{
+			Info: &flight.FlightInfo{Endpoint: []*flight.FlightEndpoint{ep}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st != flight.CancelStatus_CANCEL_STATUS_CANCELLED {
+			t.Fatalf("invalid cancel status: %v", st)
+		}
+
+		select {
+		case got := <-q.observed:
+			if got != "query-1" {
+				t.Fatalf("invalid cancelled query: %q", got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("DoGet did not observe the cancellation")
+		}
+
+		for r.Next() {
+		}
+		if status.Code(r.Err()) != codes.Canceled {
+			t.Fatalf("expected a Canceled error, got %v", r.Err())
+		}
+	})
+
+	t.Run("renew", func(t *testing.T) {
+		expiration := time.Unix(1600000000, 0)
+		ep, err := client.RenewFlightEndpoint(context.Background(), &flight.RenewFlightEndpointRequest{
+			Endpoint: &flight.FlightEndpoint{
+				Ticket:         &flight.Ticket{Ticket: []byte("query-2")},
+				ExpirationTime: timestamppb.New(expiration),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(ep.GetTicket().GetTicket()) != "query-2" {
+			t.Fatalf("invalid renewed endpoint: %v", ep)
+		}
+		if got, want := ep.GetExpirationTime().AsTime(), expiration.Add(time.Hour); !got.Equal(want) {
+			t.Fatalf("invalid expiration time: got=%v, want=%v", got, want)
+		}
+
+		_, err = client.RenewFlightEndpoint(context.Background(), &flight.RenewFlightEndpointRequest{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected an InvalidArgument error, got %v", err)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		stream, err := client.DoAction(context.Background(), &flight.Action{Type: flight.CancelFlightInfoActionType, Body: []byte{0xff}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected an InvalidArgument error, got %v", err)
+		}
+	})
+
+	t.Run("other actions", func(t *testing.T) {
+		stream, err := client.DoAction(context.Background(), &flight.Action{Type: "custom"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(res.Body) != "custom custom" {
+			t.Fatalf("invalid result: %q", res.Body)
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Fatalf("expected the end of the results, got %v", err)
+		}
+	})
+}
+
+func TestWellKnownActionsUnimplemented(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoAction: flight.ActionHandler(flight.ActionHandlers{}, nil),
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, err = client.CancelFlightInfo(context.Background(), &flight.CancelFlightInfoRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected an Unimplemented error, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// appMetadataService acknowledges each message of a DoPut call with a
+// PutResult as soon as it is received, and sends its records with their
+// metadata interleaved with metadata only messages on DoGet.
+func appMetadataService(recs []array.Record) *flight.FlightServiceService {
+	return &flight.FlightServiceService{
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			r := flight.NewReader(stream)
+			defer r.Release()
+
+			for r.Next() {
+				kind := "batch"
+				if r.Record() == nil {
+					kind = "meta"
+				}
+				if kind == "meta" && r.LatestAppMetadata() == nil {
+					return status.Error(codes.InvalidArgument, "metadata before any batch")
+				}
+				md := fmt.Sprintf("%s:%s", kind, r.AppMetadata())
+				if err := stream.Send(&flight.PutResult{AppMetadata: []byte(md)}); err != nil {
+					return err
+				}
+			}
+			return r.Err()
+		},
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewWriter(stream)
+			defer w.Close()
+			for i, rec := range recs {
+				if err := w.WriteWithAppMetadata(rec, []byte(fmt.Sprint("batch ", i))); err != nil {
+					return err
+				}
+				if err := w.WriteMetadata([]byte(fmt.Sprint("after ", i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestAppMetadata(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(appMetadataService(recs))
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	t.Run("DoPut", func(t *testing.T) {
+		w, results, err := client.PutStream(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// every message is acknowledged before the next one is written,
+		// so the results are received while the call is still sending.
+		next := func(want string) {
+			t.Helper()
+			if !results.Next() {
+				t.Fatalf("missing result %q: %v", want, results.Err())
+			}
+			if got := string(results.AppMetadata()); got != want {
+				t.Fatalf("invalid result: got=%q, want=%q", got, want)
+			}
+		}
+		for i, rec := range recs {
+			if err := w.WriteWithAppMetadata(rec, []byte(fmt.Sprint(i))); err != nil {
+				t.Fatal(err)
+			}
+			next(fmt.Sprint("batch:", i))
+			if err := w.WriteMetadata([]byte(fmt.Sprint("progress ", i))); err != nil {
+				t.Fatal(err)
+			}
+			next(fmt.Sprint("meta:progress ", i))
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if results.Next() {
+			t.Fatalf("unexpected result %q", results.AppMetadata())
+		}
+		if err := results.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("DoPut error", func(t *testing.T) {
+		w, results, err := client.PutStream(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.WriteMetadata([]byte("progress"))
+		w.Close()
+		if results.Next() {
+			t.Fatalf("unexpected result %q", results.AppMetadata())
+		}
+		if code := status.Code(results.Err()); code != codes.InvalidArgument {
+			t.Fatalf("expected an invalid argument error, got %v", results.Err())
+		}
+	})
+
+	t.Run("DoGet", func(t *testing.T) {
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := flight.NewReader(stream)
+		defer r.Release()
+
+		var n int
+		for i := range recs {
+			if !r.Next() {
+				t.Fatalf("missing batch %d: %v", i, r.Err())
+			}
+			want := fmt.Sprint("batch ", i)
+			switch {
+			case r.Record() == nil || !array.RecordEqual(r.Record(), recs[i]):
+				t.Fatalf("records[%d] differ", i)
+			case string(r.AppMetadata()) != want || string(r.LatestAppMetadata()) != want:
+				t.Fatalf("invalid metadata: got=%q and %q, want=%q", r.AppMetadata(), r.LatestAppMetadata(), want)
+			}
+
+			if !r.Next() {
+				t.Fatalf("missing metadata %d: %v", i, r.Err())
+			}
+			switch {
+			case r.Record() != nil:
+				t.Fatalf("unexpected record after batch %d", i)
+			case string(r.AppMetadata()) != fmt.Sprint("after ", i):
+				t.Fatalf("invalid metadata: got=%q, want=%q", r.AppMetadata(), fmt.Sprint("after ", i))
+			case string(r.LatestAppMetadata()) != want:
+				t.Fatalf("invalid latest metadata: got=%q, want=%q", r.LatestAppMetadata(), want)
+			}
+			n++
+		}
+		if r.Next() {
+			t.Fatalf("unexpected message after %d batches", n)
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -18,7 +18,11 @@ package flight_test
 
 import (
 	"context"
+	"encoding/base64"
 	"io"
+	"net"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/apache/arrow/go/arrow/flight"
@@ -26,6 +30,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	status "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 const (
@@ -203,3 +208,297 @@ func TestBasicAuthHelpers(t *testing.T) {
 		t.Fatal("should have received carebears")
 	}
 }
+
+func TestMalformedAuthHeaders(t *testing.T) {
+	unary, stream := flight.CreateServerBearerTokenAuthInterceptors(&validator{})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	f := &HeaderAuthTestFlight{}
+	flight.RegisterFlightServiceService(s, &flight.FlightServiceService{
+		Handshake:   func(flight.FlightService_HandshakeServer) error { return nil },
+		ListFlights: f.ListFlights,
+		GetSchema:   f.GetSchema,
+	})
+
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := flight.NewFlightServiceClient(conn)
+
+	b64 := func(s string) string { return base64.RawStdEncoding.EncodeToString([]byte(s)) }
+
+	type call func(ctx context.Context) error
+	var (
+		handshake call = func(ctx context.Context) error {
+			hs, err := client.Handshake(ctx)
+			if err != nil {
+				return err
+			}
+			_, err = hs.Recv()
+			return err
+		}
+		listFlights call = func(ctx context.Context) error {
+			fs, err := client.ListFlights(ctx, &flight.Criteria{})
+			if err != nil {
+				return err
+			}
+			_, err = fs.Recv()
+			return err
+		}
+		getSchema call = func(ctx context.Context) error {
+			_, err := client.GetSchema(ctx, &flight.FlightDescriptor{})
+			return err
+		}
+	)
+
+	for _, tc := range []struct {
+		name   string
+		call   call
+		header string
+		err    string
+	}{
+		{"handshake/no header", handshake, "", "must authenticate first"},
+		{"handshake/scheme only", handshake, "Basic", "expected 2 space separated parts, got 1"},
+		{"handshake/empty credentials", handshake, "Basic ", "missing Basic credentials"},
+		{"handshake/too many parts", handshake, "Basic a b", "expected 2 space separated parts, got 3"},
+		{"handshake/bearer", handshake, "Bearer " + validBearer, "only Basic Auth implemented"},
+		{"handshake/bad base64", handshake, "Basic ****", "invalid basic auth encoding"},
+		{"handshake/missing colon", handshake, "Basic " + b64(validUsername+validPassword), "missing ':' separator"},
+		{"handshake/invalid credentials", handshake, "Basic " + b64(invalidUsername+":"+invalidPassword), "invalid user/password"},
+		{"stream/no header", listFlights, "", "must authenticate first"},
+		{"stream/scheme only", listFlights, "Bearer", "expected 2 space separated parts, got 1"},
+		{"stream/empty token", listFlights, "Bearer ", "missing Bearer credentials"},
+		{"stream/too many parts", listFlights, "Bearer a b", "expected 2 space separated parts, got 3"},
+		{"stream/basic", listFlights, "Basic " + b64(validUsername+":"+validPassword), "only bearer token auth implemented"},
+		{"stream/invalid token", listFlights, "Bearer " + invalidBearer, "invalid authentication"},
+		{"unary/no header", getSchema, "", "must authenticate first"},
+		{"unary/scheme only", getSchema, "Bearer", "expected 2 space separated parts, got 1"},
+		{"unary/empty token", getSchema, "Bearer ", "missing Bearer credentials"},
+		{"unary/missing prefix", getSchema, validBearer, "expected 2 space separated parts, got 1"},
+		{"unary/other scheme", getSchema, "Token " + validBearer, "only bearer token auth implemented"},
+		{"unary/invalid token", getSchema, "Bearer " + invalidBearer, "invalid authentication"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.header != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tc.header)
+			}
+
+			err := tc.call(ctx)
+			if got := status.Code(err); got != codes.Unauthenticated {
+				t.Fatalf("invalid status code: got=%v, want=%v (err=%v)", got, codes.Unauthenticated, err)
+			}
+			if msg := status.Convert(err).Message(); !strings.Contains(msg, tc.err) {
+				t.Fatalf("invalid error message: got=%q, want=%q", msg, tc.err)
+			}
+		})
+	}
+
+	// the server keeps serving valid requests.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+validBearer)
+	if err := getSchema(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+b64(validUsername+":"+validPassword))
+	if err := handshake(ctx); err != io.EOF {
+		t.Fatalf("valid handshake failed: %v", err)
+	}
+}
+
+func TestBasicAuthEncodings(t *testing.T) {
+	unary, stream := flight.CreateServerBearerTokenAuthInterceptors(&validator{})
+	s := flight.NewFlightServer(nil, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	s.Init("localhost:0")
+	f := &HeaderAuthTestFlight{}
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: f.ListFlights,
+		GetSchema:   f.GetSchema,
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	creds := []byte(validUsername + ":" + validPassword)
+	if len(creds)%3 == 0 {
+		t.Fatalf("credentials must need padding to exercise it")
+	}
+
+	for _, tc := range []struct {
+		name, header string
+	}{
+		{"padded", "Basic " + base64.StdEncoding.EncodeToString(creds)},
+		{"unpadded", "Basic " + base64.RawStdEncoding.EncodeToString(creds)},
+		{"lower case", "basic " + base64.StdEncoding.EncodeToString(creds)},
+		{"upper case", "BASIC " + base64.RawStdEncoding.EncodeToString(creds)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var trailer metadata.MD
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", tc.header)
+			hs, err := client.Handshake(ctx, grpc.Trailer(&trailer))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := hs.Recv(); err != io.EOF {
+				t.Fatalf("handshake failed: %v", err)
+			}
+
+			token := trailer.Get("authorization")
+			if len(token) == 0 {
+				t.Fatalf("no token in the handshake trailer")
+			}
+
+			// the scheme of the returned token is case-insensitive too.
+			ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", strings.ToLower(token[0][:len("Bearer")])+token[0][len("Bearer"):])
+			sc, err := client.GetSchema(ctx, &flight.FlightDescriptor{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(sc.Schema), "carebears"; got != want {
+				t.Fatalf("invalid identity: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+// rotatingValidator issues a new token on every GetSchema call, and
+// accepts all tokens it issued.
+type rotatingValidator struct {
+	mu     sync.Mutex
+	issued map[string]bool
+	n      int
+}
+
+func (v *rotatingValidator) newToken() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.n++
+	tok := validBearer + "-" + string(rune('a'+v.n%26)) + strings.Repeat("x", v.n/26)
+	v.issued[tok] = true
+	return tok
+}
+
+func (v *rotatingValidator) Validate(username, password string) (string, error) {
+	if username == validUsername && password == validPassword {
+		return v.newToken(), nil
+	}
+	return "", status.Errorf(codes.Unauthenticated, "invalid user/password")
+}
+
+func (v *rotatingValidator) IsValid(bearerToken string) (interface{}, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.issued[bearerToken] {
+		return bearerToken, nil
+	}
+	return "", status.Errorf(codes.Unauthenticated, "invalid authentication")
+}
+
+func TestClientBasicAuthCredentials(t *testing.T) {
+	v := &rotatingValidator{issued: make(map[string]bool)}
+	unary, stream := flight.CreateServerBearerTokenAuthInterceptors(v)
+	s := flight.NewFlightServer(nil, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	s.Init("localhost:0")
+	f := &HeaderAuthTestFlight{}
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: f.ListFlights,
+		GetSchema: func(ctx context.Context, in *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			// re-issue a token with every response.
+			grpc.SetTrailer(ctx, metadata.Pairs("authorization", "Bearer "+v.newToken()))
+			return f.GetSchema(ctx, in)
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	creds := flight.NewClientBasicAuthCredentials(validUsername, validPassword)
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, append(creds.DialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if tok := creds.Token(); tok != "" {
+		t.Fatalf("unexpected token before the handshake: %q", tok)
+	}
+
+	// the first call performs the handshake.
+	fs, err := client.ListFlights(context.Background(), &flight.Criteria{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := fs.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(info.Schema), "foobar"; got != want {
+		t.Fatalf("invalid flight info: got=%q, want=%q", got, want)
+	}
+	first := creds.Token()
+	if first == "" {
+		t.Fatalf("no token after the handshake")
+	}
+
+	sc, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(sc.Schema); got != first {
+		t.Fatalf("call authenticated with %q, want %q", got, first)
+	}
+	second := creds.Token()
+	if second == first {
+		t.Fatalf("re-issued token was not captured")
+	}
+
+	// concurrent calls use and refresh the token safely.
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{}); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	latest := creds.Token()
+	sc, err = client.GetSchema(context.Background(), &flight.FlightDescriptor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(sc.Schema); got != latest {
+		t.Fatalf("call authenticated with %q, want the latest token %q", got, latest)
+	}
+
+	bad := flight.NewClientBasicAuthCredentials(invalidUsername, invalidPassword)
+	badClient, err := flight.NewFlightClient(s.Addr().String(), nil, append(bad.DialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer badClient.Close()
+	_, err = badClient.GetSchema(context.Background(), &flight.FlightDescriptor{})
+	if got := status.Code(err); got != codes.Unauthenticated {
+		t.Fatalf("invalid status code: got=%v, want=%v (err=%v)", got, codes.Unauthenticated, err)
+	}
+}
@@ -0,0 +1,176 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+func TestRecordReaderBufferReuse(t *testing.T) {
+	rec := largeRecord()
+	defer rec.Release()
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(rec.Schema()), ipc.WithMaxMessageSize(16<<10))
+			defer w.Close()
+			for i := 0; i < 4; i++ {
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream, ipc.WithAllocator(mem), ipc.WithBufferReuse())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// some records are retained and checked by other goroutines, while
+	// the reader goes on with the memory of the records released.
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		bad  int
+		n    int
+		rows int64
+	)
+	for r.Next() {
+		got := r.Record()
+		want := rec.NewSlice(rows%rec.NumRows(), rows%rec.NumRows()+got.NumRows())
+		if !array.RecordEqual(got, want) {
+			t.Fatalf("records[%d] differ", n)
+		}
+		if n%3 == 0 {
+			got.Retain()
+			wg.Add(1)
+			go func(got, want array.Record) {
+				defer wg.Done()
+				defer got.Release()
+				defer want.Release()
+				for i := 0; i < 3; i++ {
+					if !array.RecordEqual(got, want) {
+						mu.Lock()
+						bad++
+						mu.Unlock()
+					}
+				}
+			}(got, want)
+		} else {
+			want.Release()
+		}
+		rows += got.NumRows()
+		n++
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	r.Release()
+	wg.Wait()
+
+	if rows != 4*rec.NumRows() {
+		t.Fatalf("invalid number of rows. got=%d, want=%d", rows, 4*rec.NumRows())
+	}
+	if bad != 0 {
+		t.Fatalf("%d retained records changed", bad)
+	}
+}
+
+// memoryStream replays the flight data messages sent to it.
+type memoryStream struct {
+	msgs []*flight.FlightData
+	next int
+}
+
+func (s *memoryStream) Send(fd *flight.FlightData) error {
+	s.msgs = append(s.msgs, &flight.FlightData{
+		DataHeader: append([]byte(nil), fd.DataHeader...),
+		DataBody:   append([]byte(nil), fd.DataBody...),
+	})
+	return nil
+}
+
+func (s *memoryStream) Recv() (*flight.FlightData, error) {
+	if s.next == len(s.msgs) {
+		return nil, io.EOF
+	}
+	s.next++
+	return s.msgs[s.next-1], nil
+}
+
+func BenchmarkRecordReader(b *testing.B) {
+	rec := largeRecord()
+	defer rec.Release()
+
+	stream := &memoryStream{}
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(rec.Schema()), ipc.WithMaxMessageSize(64<<10))
+	if err := w.Write(rec); err != nil {
+		b.Fatal(err)
+	}
+	w.Close()
+
+	for _, bench := range []struct {
+		name string
+		opts []ipc.Option
+	}{
+		{"Default", nil},
+		{"BufferReuse", []ipc.Option{ipc.WithBufferReuse()}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				stream.next = 0
+				r, err := flight.NewRecordReader(stream, bench.opts...)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for r.Next() {
+				}
+				r.Release()
+			}
+		})
+	}
+}
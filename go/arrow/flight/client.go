@@ -18,10 +18,10 @@ package flight
 
 import (
 	"context"
-	"encoding/base64"
 	"io"
-	"strings"
 
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
 	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -39,6 +39,43 @@ type Client interface {
 	// in order to use the Handshake endpoints of the service.
 	Authenticate(context.Context, ...grpc.CallOption) error
 	AuthenticateBasicToken(ctx context.Context, username string, password string, opts ...grpc.CallOption) (context.Context, error)
+	// ExchangeRecords starts a DoExchange call, returning a writer of the
+	// record batches sent to the server and a reader of those it sends back.
+	// They may be used concurrently, and are closed independently: closing
+	// the writer ends the sending side of the call, releasing the reader
+	// does not end the call.
+	ExchangeRecords(ctx context.Context, opts ...grpc.CallOption) (*Writer, *Reader, error)
+	// PutStream starts a DoPut call, returning a writer of the record
+	// batches sent to the server and a reader of the PutResult messages it
+	// sends back. The results may be read while record batches are still
+	// written, as acknowledgements of the batches received. The results are
+	// received as they come, so that the writer fails with the error of the
+	// server, as a StatusError, as soon as the server rejected the call.
+	PutStream(ctx context.Context, opts ...grpc.CallOption) (*Writer, *PutResultReader, error)
+	// PutRecords uploads the record batches of rdr to the flight desc with
+	// a DoPut call, and returns the application metadata of the PutResult
+	// messages of the server. An error of the server stops the upload as
+	// soon as it is received. See WithPutAllocator.
+	PutRecords(ctx context.Context, desc *FlightDescriptor, rdr array.RecordReader, opts ...grpc.CallOption) ([][]byte, error)
+	// PutTable uploads tbl as with PutRecords, in record batches of up to
+	// chunkSize rows, or as few as the chunks of its columns allow when
+	// chunkSize is 0 or less.
+	PutTable(ctx context.Context, desc *FlightDescriptor, tbl array.Table, chunkSize int64, opts ...grpc.CallOption) ([][]byte, error)
+	// GetSchemaDeserialized runs GetSchema for desc and returns the schema
+	// it deserialized. With WithSchemaCache, the schemas are cached by
+	// descriptor, unless called with BypassSchemaCache.
+	GetSchemaDeserialized(ctx context.Context, desc *FlightDescriptor, opts ...grpc.CallOption) (*arrow.Schema, error)
+	// InvalidateSchemas drops the schemas of descs from the cache of
+	// WithSchemaCache, or all the schemas cached when called without
+	// descriptors.
+	InvalidateSchemas(descs ...*FlightDescriptor)
+	// CancelFlightInfo runs the CancelFlightInfo action, asking the server
+	// to cancel the query of request.Info.
+	CancelFlightInfo(ctx context.Context, request *CancelFlightInfoRequest, opts ...grpc.CallOption) (CancelStatus, error)
+	// RenewFlightEndpoint runs the RenewFlightEndpoint action, asking the
+	// server to extend the expiration time of request.Endpoint, and returns
+	// the renewed endpoint.
+	RenewFlightEndpoint(ctx context.Context, request *RenewFlightEndpointRequest, opts ...grpc.CallOption) (*FlightEndpoint, error)
 	Close() error
 	// join the interface from the FlightServiceClient instead of re-defining all
 	// the endpoints here.
@@ -48,6 +85,7 @@ type Client interface {
 type client struct {
 	conn        *grpc.ClientConn
 	authHandler ClientAuthHandler
+	schemas     *schemaCache
 
 	FlightServiceClient
 }
@@ -61,6 +99,27 @@ type client struct {
 // grpc generated client code is still exported. This exists to add utility and helpers
 // around the authentication and passing the token with requests.
 func NewFlightClient(addr string, auth ClientAuthHandler, opts ...grpc.DialOption) (Client, error) {
+	for _, o := range opts {
+		if o, ok := o.(retryOption); ok {
+			policy := o.policy
+			opts = append([]grpc.DialOption{
+				grpc.WithChainStreamInterceptor(createClientRetryStreamInterceptor(&policy)),
+				grpc.WithChainUnaryInterceptor(createClientRetryUnaryInterceptor(&policy)),
+			}, opts...)
+			break
+		}
+	}
+
+	for _, o := range opts {
+		if o, ok := o.(maxCallMessageSizeOption); ok {
+			opts = append([]grpc.DialOption{
+				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(o.n), grpc.MaxCallSendMsgSize(o.n)),
+				grpc.WithChainStreamInterceptor(createClientMaxMessageSizeStreamInterceptor(o.n)),
+			}, opts...)
+			break
+		}
+	}
+
 	if auth != nil {
 		opts = append([]grpc.DialOption{
 			grpc.WithChainStreamInterceptor(createClientAuthStreamInterceptor(auth)),
@@ -73,11 +132,17 @@ func NewFlightClient(addr string, auth ClientAuthHandler, opts ...grpc.DialOptio
 		return nil, err
 	}
 
-	return &client{conn: conn, FlightServiceClient: NewFlightServiceClient(conn), authHandler: auth}, nil
+	c := &client{conn: conn, FlightServiceClient: NewFlightServiceClient(conn), authHandler: auth}
+	for _, o := range opts {
+		if o, ok := o.(schemaCacheOption); ok {
+			c.schemas = newSchemaCache(o.ttl)
+		}
+	}
+	return c, nil
 }
 
 func (c *client) AuthenticateBasicToken(ctx context.Context, username, password string, opts ...grpc.CallOption) (context.Context, error) {
-	authCtx := metadata.AppendToOutgoingContext(ctx, "Authorization", "Basic "+base64.RawStdEncoding.EncodeToString([]byte(strings.Join([]string{username, password}, ":"))))
+	authCtx := metadata.AppendToOutgoingContext(ctx, "Authorization", FormatBasicAuthHeader(username, password))
 
 	stream, err := c.FlightServiceClient.Handshake(authCtx, opts...)
 	if err != nil {
@@ -110,6 +175,56 @@ func (c *client) AuthenticateBasicToken(ctx context.Context, username, password
 	return ctx, xerrors.Errorf("flight: no authorization header on the response")
 }
 
+func (c *client) ExchangeRecords(ctx context.Context, opts ...grpc.CallOption) (*Writer, *Reader, error) {
+	stream, err := c.FlightServiceClient.DoExchange(ctx, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewWriter(stream), NewReader(stream), nil
+}
+
+func (c *client) PutStream(ctx context.Context, opts ...grpc.CallOption) (*Writer, *PutResultReader, error) {
+	stream, err := c.FlightServiceClient.DoPut(ctx, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	call := newPutCall(stream)
+	return NewWriter(call), &PutResultReader{call: call}, nil
+}
+
+// PutResultReader reads the PutResult messages of a DoPut call. It may be
+// used concurrently with the Writer of the call.
+type PutResultReader struct {
+	call     *putCall
+	metadata []byte
+	done     bool
+	err      error
+}
+
+// Next reads the next PutResult message. It returns false at the end of
+// the call or on error, see Err. The end of the call is only reached once
+// the Writer of the call is closed.
+func (r *PutResultReader) Next() bool {
+	r.metadata = nil
+	if r.done {
+		return false
+	}
+	md, ok, err := r.call.result()
+	if !ok {
+		r.done, r.err = true, err
+		return false
+	}
+	r.metadata = md
+	return true
+}
+
+// AppMetadata returns the application metadata of the current PutResult.
+func (r *PutResultReader) AppMetadata() []byte { return r.metadata }
+
+// Err returns the error that stopped Next, if any. The errors of the call
+// are reported as StatusError.
+func (r *PutResultReader) Err() error { return r.err }
+
 func (c *client) Authenticate(ctx context.Context, opts ...grpc.CallOption) error {
 	if c.authHandler == nil {
 		return status.Error(codes.NotFound, "cannot authenticate without an auth-handler")
@@ -18,7 +18,9 @@ package flight
 
 import (
 	"context"
+	"io"
 	"strings"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -35,6 +37,14 @@ type ClientAuthHandler interface {
 	GetToken(context.Context) (string, error)
 }
 
+// ClientTokenRenewer may be implemented by a ClientAuthHandler to be
+// notified of the tokens renewed by the server, see ExpiredTokenError.
+// SetToken may be called concurrently by several requests; the token it
+// receives is to be returned by subsequent calls to GetToken.
+type ClientTokenRenewer interface {
+	SetToken(token string)
+}
+
 type clientAuthConn struct {
 	stream FlightService_HandshakeClient
 }
@@ -65,10 +75,47 @@ func createClientAuthUnaryInterceptor(auth ClientAuthHandler) grpc.UnaryClientIn
 			return status.Errorf(codes.Unauthenticated, "error retrieving token: %s", err)
 		}
 
-		return invoker(metadata.NewOutgoingContext(ctx, metadata.Pairs(grpcAuthHeader, tok)), method, req, reply, cc, opts...)
+		renewer, ok := auth.(ClientTokenRenewer)
+		if !ok {
+			return invoker(metadata.NewOutgoingContext(ctx, metadata.Pairs(grpcAuthHeader, tok)), method, req, reply, cc, opts...)
+		}
+
+		var header metadata.MD
+		err = invoker(metadata.NewOutgoingContext(ctx, metadata.Pairs(grpcAuthHeader, tok)), method, req, reply, cc, append(opts, grpc.Header(&header))...)
+		renewToken(renewer, header)
+		return err
 	}
 }
 
+// renewToken passes the token renewed by the server in md, if any, to r.
+func renewToken(r ClientTokenRenewer, md metadata.MD) {
+	if vals := md.Get(grpcAuthHeader); len(vals) > 0 && vals[0] != "" {
+		r.SetToken(vals[0])
+	}
+}
+
+// renewingClientStream passes the token renewed by the server in the
+// headers of a stream to a ClientTokenRenewer.
+type renewingClientStream struct {
+	grpc.ClientStream
+	renewer ClientTokenRenewer
+	once    sync.Once
+}
+
+func (s *renewingClientStream) renew() {
+	s.once.Do(func() {
+		if header, err := s.Header(); err == nil {
+			renewToken(s.renewer, header)
+		}
+	})
+}
+
+func (s *renewingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	s.renew()
+	return err
+}
+
 func createClientAuthStreamInterceptor(auth ClientAuthHandler) grpc.StreamClientInterceptor {
 	if auth == nil {
 		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
@@ -86,6 +133,155 @@ func createClientAuthStreamInterceptor(auth ClientAuthHandler) grpc.StreamClient
 			return nil, status.Errorf(codes.Unauthenticated, "error retrieving token: %s", err)
 		}
 
-		return streamer(metadata.NewOutgoingContext(ctx, metadata.Pairs(grpcAuthHeader, tok)), desc, cc, method, opts...)
+		stream, err := streamer(metadata.NewOutgoingContext(ctx, metadata.Pairs(grpcAuthHeader, tok)), desc, cc, method, opts...)
+		if renewer, ok := auth.(ClientTokenRenewer); ok && err == nil {
+			return &renewingClientStream{ClientStream: stream, renewer: renewer}, nil
+		}
+		return stream, err
+	}
+}
+
+// ClientBasicAuthCredentials authenticates a client against servers using
+// the interceptors of CreateServerBearerTokenAuthInterceptors.
+//
+// The first call on a connection performs a Basic auth handshake with the
+// username and password, and stores the bearer token the server returns in
+// the handshake trailer. All calls then send that token, and a token
+// re-issued by the server in the headers or trailers of any call replaces
+// it. Credentials are safe for concurrent use.
+type ClientBasicAuthCredentials struct {
+	basic string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// NewClientBasicAuthCredentials returns credentials for the given username
+// and password. They are installed on a connection with DialOptions.
+func NewClientBasicAuthCredentials(username, password string) *ClientBasicAuthCredentials {
+	return &ClientBasicAuthCredentials{
+		basic: FormatBasicAuthHeader(username, password),
+	}
+}
+
+// DialOptions returns the options that install c on a client connection,
+// to be passed to NewFlightClient or grpc.Dial.
+func (c *ClientBasicAuthCredentials) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	}
+}
+
+// Token returns the current bearer token, or an empty string before the
+// first handshake.
+func (c *ClientBasicAuthCredentials) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// capture stores the bearer token found in md, if any.
+func (c *ClientBasicAuthCredentials) capture(md metadata.MD) {
+	for _, v := range md.Get(basicAuthHeader) {
+		token, err := ParseBearerAuthHeader(v)
+		if err != nil {
+			continue
+		}
+		c.mu.Lock()
+		c.token = token
+		c.mu.Unlock()
+		return
+	}
+}
+
+// withAuthorization returns ctx with its outgoing authorization header set
+// to v, replacing any existing one.
+func withAuthorization(ctx context.Context, v string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(basicAuthHeader, v)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// bearerContext returns ctx carrying the bearer token, performing the
+// handshake first if no token was issued yet.
+func (c *ClientBasicAuthCredentials) bearerContext(ctx context.Context, cc *grpc.ClientConn) (context.Context, error) {
+	if c.Token() == "" {
+		if err := c.handshake(ctx, cc); err != nil {
+			return nil, err
+		}
+	}
+	return withAuthorization(ctx, FormatBearerAuthHeader(c.Token())), nil
+}
+
+func (c *ClientBasicAuthCredentials) handshake(ctx context.Context, cc *grpc.ClientConn) error {
+	stream, err := NewFlightServiceClient(cc).Handshake(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if c.Token() == "" {
+		return status.Error(codes.Unauthenticated, "no bearer token in the handshake response")
+	}
+	return nil
+}
+
+func (c *ClientBasicAuthCredentials) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, err := c.bearerContext(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	var header, trailer metadata.MD
+	err = invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
+	c.capture(header)
+	c.capture(trailer)
+	return err
+}
+
+func (c *ClientBasicAuthCredentials) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if strings.HasSuffix(method, "/Handshake") {
+		ctx = withAuthorization(ctx, c.basic)
+	} else {
+		var err error
+		if ctx, err = c.bearerContext(ctx, cc); err != nil {
+			return nil, err
+		}
+	}
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &basicAuthClientStream{ClientStream: stream, creds: c}, nil
+}
+
+// basicAuthClientStream captures the tokens sent in the headers and
+// trailers of a stream.
+type basicAuthClientStream struct {
+	grpc.ClientStream
+	creds *ClientBasicAuthCredentials
+}
+
+func (s *basicAuthClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.creds.capture(s.Trailer())
+		return err
+	}
+	if header, herr := s.Header(); herr == nil {
+		s.creds.capture(header)
 	}
+	return nil
 }
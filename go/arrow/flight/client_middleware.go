@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientMiddleware hooks into every call made by a flight client, to
+// implement cross-cutting concerns such as tracing. See
+// NewClientWithMiddleware.
+type ClientMiddleware interface {
+	// SendingHeaders returns the headers to add to the request, or nil.
+	SendingHeaders(ctx context.Context) metadata.MD
+	// HeadersReceived is called with the headers of the response. For calls
+	// failing before the server sent any header, it is called with the
+	// trailers instead.
+	HeadersReceived(ctx context.Context, md metadata.MD)
+}
+
+// NewClientWithMiddleware is like NewFlightClient, except that middleware
+// run around every call of the client, streams included. They run after
+// the auth handler, in order on the way out and in reverse order on the
+// way in: the SendingHeaders hooks are called first to last, and the
+// HeadersReceived hooks last to first.
+func NewClientWithMiddleware(addr string, auth ClientAuthHandler, middleware []ClientMiddleware, opts ...grpc.DialOption) (Client, error) {
+	if len(middleware) > 0 {
+		mws := append([]ClientMiddleware(nil), middleware...)
+		opts = append([]grpc.DialOption{
+			grpc.WithChainStreamInterceptor(createClientMiddlewareStreamInterceptor(mws)),
+			grpc.WithChainUnaryInterceptor(createClientMiddlewareUnaryInterceptor(mws)),
+		}, opts...)
+	}
+	return NewFlightClient(addr, auth, opts...)
+}
+
+// sendingHeaders adds the headers of mws to the outgoing metadata of ctx.
+func sendingHeaders(ctx context.Context, mws []ClientMiddleware) context.Context {
+	var header metadata.MD
+	for _, mw := range mws {
+		header = metadata.Join(header, mw.SendingHeaders(ctx))
+	}
+	if len(header) == 0 {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		header = metadata.Join(md, header)
+	}
+	return metadata.NewOutgoingContext(ctx, header)
+}
+
+func headersReceived(ctx context.Context, mws []ClientMiddleware, md metadata.MD) {
+	for i := len(mws) - 1; i >= 0; i-- {
+		mws[i].HeadersReceived(ctx, md)
+	}
+}
+
+func createClientMiddlewareUnaryInterceptor(mws []ClientMiddleware) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header, trailer metadata.MD
+		opts = append(opts, grpc.Header(&header), grpc.Trailer(&trailer))
+
+		ctx = sendingHeaders(ctx, mws)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if len(header) == 0 {
+			header = trailer
+		}
+		headersReceived(ctx, mws, header)
+		return err
+	}
+}
+
+func createClientMiddlewareStreamInterceptor(mws []ClientMiddleware) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = sendingHeaders(ctx, mws)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			headersReceived(ctx, mws, nil)
+			return nil, err
+		}
+		return &middlewareClientStream{ClientStream: stream, mws: mws}, nil
+	}
+}
+
+// middlewareClientStream calls the HeadersReceived hooks once the headers
+// of the stream are known, that is when they are requested with Header or
+// when a message or the end of the stream is received.
+type middlewareClientStream struct {
+	grpc.ClientStream
+	mws []ClientMiddleware
+
+	mu       sync.Mutex
+	received bool
+}
+
+func (s *middlewareClientStream) headersReceived(done bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.received {
+		return
+	}
+
+	md, err := s.ClientStream.Header()
+	if err == nil && len(md) == 0 {
+		// either the server sent no header, or the stream is trailers-only,
+		// which is only known once it is done.
+		if !done {
+			return
+		}
+		md = s.ClientStream.Trailer()
+	} else if err != nil {
+		md = s.ClientStream.Trailer()
+	}
+
+	s.received = true
+	headersReceived(s.Context(), s.mws, md)
+}
+
+func (s *middlewareClientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	s.headersReceived(err != nil)
+	return md, err
+}
+
+func (s *middlewareClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	s.headersReceived(err != nil)
+	return err
+}
@@ -0,0 +1,166 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// echoServerMiddleware sends back the trace ids of the request.
+type echoServerMiddleware struct{}
+
+func (echoServerMiddleware) StartCall(ctx context.Context, incoming metadata.MD) (context.Context, error) {
+	return context.WithValue(ctx, ctxKey("trace"), incoming.Get("x-trace-id")), nil
+}
+
+func (echoServerMiddleware) SendingHeaders(ctx context.Context) metadata.MD {
+	ids, _ := ctx.Value(ctxKey("trace")).([]string)
+	return metadata.MD{"x-echo": ids}
+}
+
+func (echoServerMiddleware) CallCompleted(context.Context, error) metadata.MD { return nil }
+
+// tracingClientMiddleware sends its name as a trace id, and records the
+// echoed trace ids.
+type tracingClientMiddleware struct {
+	name string
+	mu   *sync.Mutex
+	log  *[]string
+}
+
+func (m *tracingClientMiddleware) SendingHeaders(context.Context) metadata.MD {
+	return metadata.Pairs("x-trace-id", m.name)
+}
+
+func (m *tracingClientMiddleware) HeadersReceived(_ context.Context, md metadata.MD) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*m.log = append(*m.log, m.name+":"+strings.Join(md.Get("x-echo"), ","))
+}
+
+func TestClientMiddleware(t *testing.T) {
+	unary, stream := flight.CreateServerMiddleware(echoServerMiddleware{})
+
+	f := &flightServer{}
+	s := flight.NewFlightServer(nil, grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: f.ListFlights,
+		GetSchema: func(_ context.Context, in *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			if in.Type == flight.FlightDescriptor_CMD {
+				return nil, status.Error(codes.InvalidArgument, "unexpected command")
+			}
+			return &flight.SchemaResult{}, nil
+		},
+		DoPut: func(fs flight.FlightService_DoPutServer) error {
+			for {
+				if _, err := fs.Recv(); err != nil {
+					if err == io.EOF {
+						return nil
+					}
+					return err
+				}
+			}
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	var (
+		mu  sync.Mutex
+		log []string
+	)
+	client, err := flight.NewClientWithMiddleware(s.Addr().String(), nil, []flight.ClientMiddleware{
+		&tracingClientMiddleware{name: "first", mu: &mu, log: &log},
+		&tracingClientMiddleware{name: "second", mu: &mu, log: &log},
+	}, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	want := []string{"second:first,second", "first:first,second"}
+	check := func(t *testing.T) {
+		t.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		if !reflect.DeepEqual(log, want) {
+			t.Fatalf("invalid received headers:\ngot= %q\nwant=%q", log, want)
+		}
+		log = nil
+	}
+
+	t.Run("unary", func(t *testing.T) {
+		if _, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{}); err != nil {
+			t.Fatal(err)
+		}
+		check(t)
+	})
+
+	t.Run("failed unary", func(t *testing.T) {
+		_, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("invalid status: %v", err)
+		}
+		check(t)
+	})
+
+	t.Run("server stream", func(t *testing.T) {
+		fs, err := client.ListFlights(context.Background(), &flight.Criteria{Expression: []byte("primitives")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			if _, err := fs.Recv(); err != nil {
+				if err == io.EOF {
+					break
+				}
+				t.Fatal(err)
+			}
+		}
+		check(t)
+	})
+
+	t.Run("bidi stream", func(t *testing.T) {
+		fs, err := client.DoPut(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Send(&flight.FlightData{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.CloseSend(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fs.Recv(); err != io.EOF {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		check(t)
+	})
+}
@@ -0,0 +1,231 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// ClientPool hands out clients for the locations of flight endpoints,
+// creating them on first use with the auth handler, middleware and dial
+// options of the pool, and reusing them afterwards. Endpoints without
+// locations are served by the origin client, the connection the flight
+// info was retrieved on.
+//
+// A ClientPool is safe for concurrent use.
+type ClientPool struct {
+	origin     Client
+	auth       ClientAuthHandler
+	middleware []ClientMiddleware
+	opts       []grpc.DialOption
+
+	mu      sync.Mutex
+	clients map[string]Client
+	closed  bool
+}
+
+// NewClientPool returns a pool of clients dialed with auth, middleware and
+// opts, as with NewClientForLocation. origin may be nil when every
+// endpoint has locations, it is not closed by the pool.
+func NewClientPool(origin Client, auth ClientAuthHandler, middleware []ClientMiddleware, opts ...grpc.DialOption) *ClientPool {
+	return &ClientPool{
+		origin:     origin,
+		auth:       auth,
+		middleware: append([]ClientMiddleware(nil), middleware...),
+		opts:       append([]grpc.DialOption(nil), opts...),
+		clients:    make(map[string]Client),
+	}
+}
+
+// Client returns the client of the pool for loc, dialing it if needed.
+func (p *ClientPool) Client(loc *Location) (Client, error) {
+	uri := loc.GetUri()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, xerrors.Errorf("flight: client pool is closed")
+	}
+	if c, ok := p.clients[uri]; ok {
+		return c, nil
+	}
+
+	c, err := NewClientForLocation(loc, p.auth, p.middleware, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[uri] = c
+	return c, nil
+}
+
+// DoGet retrieves the stream of endpoint. Its locations are tried in
+// order, moving on to the next one while they are Unavailable, other
+// errors being returned as is, possibly by the first Recv of the stream.
+// Endpoints without locations are retrieved from the origin client.
+func (p *ClientPool) DoGet(ctx context.Context, endpoint *FlightEndpoint, opts ...grpc.CallOption) (FlightService_DoGetClient, error) {
+	locs := endpoint.GetLocation()
+	if len(locs) == 0 {
+		if p.origin == nil {
+			return nil, xerrors.Errorf("flight: endpoint without location and no origin client")
+		}
+		return p.origin.DoGet(ctx, endpoint.GetTicket(), opts...)
+	}
+
+	var err error
+	for _, loc := range locs {
+		var c Client
+		c, err = p.Client(loc)
+		if err != nil {
+			return nil, err
+		}
+
+		var stream FlightService_DoGetClient
+		stream, err = c.DoGet(ctx, endpoint.GetTicket(), opts...)
+		if err == nil {
+			// the stream is only established once the server answered.
+			_, err = stream.Header()
+		}
+		switch {
+		case err == nil:
+			return stream, nil
+		case status.Code(err) != codes.Unavailable:
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// Close closes the clients of the pool, but not its origin client.
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var err error
+	for uri, c := range p.clients {
+		if e := c.Close(); e != nil && err == nil {
+			err = e
+		}
+		delete(p.clients, uri)
+	}
+	p.closed = true
+	return err
+}
+
+// locationTLSOption is the dial option of WithLocationTLSConfig, it leaves
+// the grpc options untouched.
+type locationTLSOption struct {
+	grpc.EmptyDialOption
+	cfg *tls.Config
+}
+
+// WithLocationTLSConfig returns a dial option for NewClientForLocation and
+// NewClientPool, the TLS configuration of the connections to grpc+tls
+// locations, such as their root CAs, the client certificates or a server
+// name overriding the host of the location. Without it, the server
+// certificates are verified with the system roots.
+func WithLocationTLSConfig(cfg *tls.Config) grpc.DialOption {
+	return locationTLSOption{cfg: cfg}
+}
+
+// NewClientForLocation returns a client of the server at loc, dialed with
+// auth, middleware and opts as with NewClientWithMiddleware. The transport
+// credentials follow the scheme of the location: grpc, grpc+tcp and
+// grpc+unix locations are dialed insecure, grpc+tls ones over TLS, see
+// WithLocationTLSConfig, so that opts must not set credentials. Other
+// schemes are rejected.
+func NewClientForLocation(loc *Location, auth ClientAuthHandler, middleware []ClientMiddleware, opts ...grpc.DialOption) (Client, error) {
+	uri := loc.GetUri()
+	target, err := locationTarget(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := grpc.WithInsecure()
+	if strings.HasPrefix(strings.ToLower(uri), "grpc+tls:") {
+		var cfg *tls.Config
+		for _, o := range opts {
+			if o, ok := o.(locationTLSOption); ok {
+				cfg = o.cfg
+			}
+		}
+		if cfg == nil {
+			cfg = &tls.Config{}
+		}
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(cfg))
+	}
+
+	c, err := NewClientWithMiddleware(target, auth, middleware, append([]grpc.DialOption{creds}, opts...)...)
+	if err != nil {
+		return nil, xerrors.Errorf("flight: could not dial location %q: %w", uri, err)
+	}
+	return c, nil
+}
+
+// locationTarget returns the grpc dial target of a location URI.
+func locationTarget(uri string) (string, error) {
+	network, addr, err := locationAddr(uri)
+	if err != nil {
+		return "", err
+	}
+	if network == "unix" {
+		return "unix:" + addr, nil
+	}
+	return addr, nil
+}
+
+// locationAddr returns the network and address of a location URI.
+func locationAddr(uri string) (network, addr string, err error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", xerrors.Errorf("flight: invalid location %q: %w", uri, err)
+	}
+
+	switch u.Scheme {
+	case "grpc", "grpc+tcp", "grpc+tls":
+		if u.Host == "" {
+			return "", "", xerrors.Errorf("flight: invalid location %q: missing host", uri)
+		}
+		return "tcp", u.Host, nil
+	case "grpc+unix":
+		if u.Path == "" {
+			return "", "", xerrors.Errorf("flight: invalid location %q: missing path", uri)
+		}
+		return "unix", u.Path, nil
+	}
+	return "", "", xerrors.Errorf("flight: unsupported location scheme %q", u.Scheme)
+}
+
+// LocationForAddr returns the location of a server listening on addr, as
+// returned by its Addr method, for the endpoints of its flights.
+func LocationForAddr(addr net.Addr) *Location {
+	if addr.Network() == "unix" {
+		return &Location{Uri: (&url.URL{Scheme: "grpc+unix", Path: addr.String()}).String()}
+	}
+	return &Location{Uri: "grpc+tcp://" + addr.String()}
+}
@@ -0,0 +1,177 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// namedServer starts a server whose DoGet sends back its name.
+func namedServer(t *testing.T, name string) flight.Server {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			if string(tkt.GetTicket()) == "missing" {
+				return status.Error(codes.NotFound, "no such ticket")
+			}
+			return stream.Send(&flight.FlightData{AppMetadata: []byte(name)})
+		},
+	})
+	go s.Serve()
+	return s
+}
+
+func location(addr net.Addr) *flight.Location {
+	return &flight.Location{Uri: "grpc+tcp://" + addr.String()}
+}
+
+// deadLocation returns a location nothing listens on.
+func deadLocation(t *testing.T) *flight.Location {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	return location(lis.Addr())
+}
+
+func TestClientPool(t *testing.T) {
+	origin, first, second := namedServer(t, "origin"), namedServer(t, "first"), namedServer(t, "second")
+	defer origin.Shutdown()
+	defer first.Shutdown()
+	defer second.Shutdown()
+
+	originClient, err := flight.NewFlightClient(origin.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer originClient.Close()
+
+	pool := flight.NewClientPool(originClient, nil, nil, grpc.WithInsecure())
+	defer pool.Close()
+
+	doGet := func(t *testing.T, ticket string, locs ...*flight.Location) (string, error) {
+		stream, err := pool.DoGet(context.Background(), &flight.FlightEndpoint{
+			Ticket:   &flight.Ticket{Ticket: []byte(ticket)},
+			Location: locs,
+		})
+		if err != nil {
+			return "", err
+		}
+		fd, err := stream.Recv()
+		if err != nil {
+			return "", err
+		}
+		return string(fd.AppMetadata), nil
+	}
+
+	for _, tc := range []struct {
+		name string
+		locs []*flight.Location
+		want string
+	}{
+		{"no location", nil, "origin"},
+		{"first", []*flight.Location{location(first.Addr()), location(second.Addr())}, "first"},
+		{"second", []*flight.Location{location(second.Addr()), location(first.Addr())}, "second"},
+		{"fallback", []*flight.Location{deadLocation(t), location(second.Addr()), location(first.Addr())}, "second"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := doGet(t, "tkt", tc.locs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("served by the wrong server: got=%q, want=%q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("all unavailable", func(t *testing.T) {
+		_, err := doGet(t, "tkt", deadLocation(t), deadLocation(t))
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("expected an Unavailable error, got %v", err)
+		}
+	})
+
+	t.Run("no fallback on other errors", func(t *testing.T) {
+		_, err := doGet(t, "missing", location(first.Addr()), location(second.Addr()))
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected a NotFound error, got %v", err)
+		}
+	})
+
+	t.Run("invalid location", func(t *testing.T) {
+		if _, err := doGet(t, "tkt", &flight.Location{Uri: "http://localhost:1234"}); err == nil {
+			t.Fatal("expected an error for an unsupported scheme")
+		}
+	})
+
+	t.Run("reuse", func(t *testing.T) {
+		loc := location(first.Addr())
+		want, err := pool.Client(loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		clients := make([]flight.Client, 8)
+		for i := range clients {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				clients[i], _ = pool.Client(&flight.Location{Uri: loc.Uri})
+			}(i)
+		}
+		wg.Wait()
+
+		for i, c := range clients {
+			if c != want {
+				t.Fatalf("client %d was not reused", i)
+			}
+		}
+	})
+}
+
+func TestClientPoolClose(t *testing.T) {
+	s := namedServer(t, "server")
+	defer s.Shutdown()
+
+	pool := flight.NewClientPool(nil, nil, nil, grpc.WithInsecure())
+	if _, err := pool.Client(location(s.Addr())); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Client(location(s.Addr())); err == nil {
+		t.Fatal("expected an error from a closed pool")
+	}
+
+	_, err := pool.DoGet(context.Background(), &flight.FlightEndpoint{Ticket: &flight.Ticket{}})
+	if err == nil {
+		t.Fatal("expected an error for an endpoint without location nor origin")
+	}
+}
@@ -0,0 +1,104 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow/ipc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// acceptCompressionHeader is the header with which clients list the codecs
+// they accept for the record batches they receive.
+const acceptCompressionHeader = "arrow-accept-compression"
+
+// compressionOption is the server option of WithStreamCompression, it
+// leaves the grpc server configuration untouched.
+type compressionOption struct {
+	grpc.EmptyServerOption
+	codec   ipc.CompressionType
+	level   int
+	minSize int64
+}
+
+// WithStreamCompression returns a server option for NewFlightServer that
+// compresses the record batches sent to clients accepting codec, see
+// WithAcceptCompression, at the given codec level. Buffers smaller than
+// minSize bytes are sent uncompressed. Handlers get the negotiated options
+// from CompressionOptions.
+func WithStreamCompression(codec ipc.CompressionType, level int, minSize int64) grpc.ServerOption {
+	return compressionOption{codec: codec, level: level, minSize: minSize}
+}
+
+type compressionCtxKey struct{}
+
+// CompressionOptions returns the ipc options compressing the record
+// batches of the call of ctx, as negotiated with the client under
+// WithStreamCompression, for handlers to pass to NewRecordWriter or
+// NewWriter. It returns nil when the record batches are to be sent
+// uncompressed. Handlers override the negotiated codec by passing a later
+// ipc.WithCompression, ipc.CompressionNone disabling compression:
+//
+//	opts := append(flight.CompressionOptions(stream.Context()), ipc.WithSchema(schema))
+//	w := flight.NewRecordWriter(stream, opts...)
+func CompressionOptions(ctx context.Context) []ipc.Option {
+	o, ok := ctx.Value(compressionCtxKey{}).(compressionOption)
+	if !ok {
+		return nil
+	}
+	return []ipc.Option{ipc.WithCompression(o.codec, o.level), ipc.WithMinCompressSize(o.minSize)}
+}
+
+// negotiate returns ctx with the compression options of o when
+// the client of the call of ctx accepts its codec.
+func (o compressionOption) negotiate(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(acceptCompressionHeader) {
+		for _, name := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(name), o.codec.String()) {
+				return context.WithValue(ctx, compressionCtxKey{}, o)
+			}
+		}
+	}
+	return ctx
+}
+
+func createServerCompressionStreamInterceptor(o compressionOption) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &authWrappedStream{ServerStream: stream, ctx: o.negotiate(stream.Context())})
+	}
+}
+
+// WithAcceptCompression returns a dial option for NewFlightClient
+// advertising the codecs with which the client accepts to receive
+// compressed record batches from servers using WithStreamCompression.
+// Readers decompress record batches regardless of this option.
+func WithAcceptCompression(codecs ...ipc.CompressionType) grpc.DialOption {
+	names := make([]string, len(codecs))
+	for i, c := range codecs {
+		names[i] = c.String()
+	}
+	accept := strings.Join(names, ",")
+
+	return grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = metadata.AppendToOutgoingContext(ctx, acceptCompressionHeader, accept)
+		return streamer(ctx, desc, cc, method, opts...)
+	})
+}
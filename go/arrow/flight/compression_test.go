@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+// compressibleRecord returns a record whose buffers compress well.
+func compressibleRecord() array.Record {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "str", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	bldr := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer bldr.Release()
+	for i := 0; i < 1<<12; i++ {
+		if i%7 == 0 {
+			bldr.Field(0).(*array.Int64Builder).AppendNull()
+		} else {
+			bldr.Field(0).(*array.Int64Builder).Append(int64(i % 16))
+		}
+		bldr.Field(1).(*array.StringBuilder).Append("flight")
+	}
+	return bldr.NewRecord()
+}
+
+// compressedDoGet sends rec with the negotiated compression, which the
+// ticket "none" disables and the ticket "lz4" overrides.
+func compressedDoGet(rec array.Record) func(*flight.Ticket, flight.FlightService_DoGetServer) error {
+	return func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+		opts := append(flight.CompressionOptions(stream.Context()), ipc.WithSchema(rec.Schema()))
+		switch string(tkt.Ticket) {
+		case "none":
+			opts = append(opts, ipc.WithCompression(ipc.CompressionNone, 0))
+		case "lz4":
+			opts = append(opts, ipc.WithCompression(ipc.CompressionLZ4Frame, 1))
+		}
+
+		w := flight.NewRecordWriter(stream, opts...)
+		defer w.Close()
+		return w.Write(rec)
+	}
+}
+
+func TestStreamCompression(t *testing.T) {
+	rec := compressibleRecord()
+	defer rec.Release()
+
+	s := flight.NewFlightServer(nil, flight.WithStreamCompression(ipc.CompressionZstd, 0, 64))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{DoGet: compressedDoGet(rec)})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	// doGet returns the size of the record batch bodies received.
+	doGet := func(t *testing.T, ticket string, opts ...grpc.DialOption) int {
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, append(opts, grpc.WithInsecure())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte(ticket)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			data []*flight.FlightData
+			size int
+		)
+		for {
+			fd, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = append(data, fd)
+			size += len(fd.DataBody)
+		}
+
+		r, err := flight.NewRecordReader(&replayStream{data: data})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+		if !r.Next() || !array.RecordEqual(r.Record(), rec) {
+			t.Fatalf("invalid record: %v", r.Err())
+		}
+		if r.Next() {
+			t.Fatal("unexpected record")
+		}
+		return size
+	}
+
+	plain := doGet(t, "")
+	for _, tc := range []struct {
+		name       string
+		ticket     string
+		accept     []ipc.CompressionType
+		compressed bool
+	}{
+		{name: "negotiated", accept: []ipc.CompressionType{ipc.CompressionLZ4Frame, ipc.CompressionZstd}, compressed: true},
+		{name: "unsupported", accept: []ipc.CompressionType{ipc.CompressionLZ4Frame}},
+		{name: "disabled", ticket: "none", accept: []ipc.CompressionType{ipc.CompressionZstd}},
+		{name: "overridden", ticket: "lz4", accept: []ipc.CompressionType{ipc.CompressionZstd}, compressed: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			size := doGet(t, tc.ticket, flight.WithAcceptCompression(tc.accept...))
+			if compressed := size < plain/4; compressed != tc.compressed {
+				t.Fatalf("invalid body size: got=%d, plain=%d", size, plain)
+			}
+		})
+	}
+}
+
+// replayStream replays received flight data to a record reader.
+type replayStream struct {
+	data []*flight.FlightData
+}
+
+func (s *replayStream) Recv() (*flight.FlightData, error) {
+	if len(s.data) == 0 {
+		return nil, io.EOF
+	}
+	fd := s.data[0]
+	s.data = s.data[1:]
+	return fd, nil
+}
@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewCookieMiddleware returns a client middleware keeping the cookies set
+// by the server with "set-cookie" headers, and sending them back with a
+// "cookie" header on the following calls. Max-Age and Expires are honored,
+// so expired or deleted cookies are no longer sent. Each middleware has its
+// own cookie jar, it is meant to be used by a single client.
+func NewCookieMiddleware() ClientMiddleware {
+	return &cookieMiddleware{jar: make(map[string]jarCookie)}
+}
+
+type jarCookie struct {
+	value   string
+	expires time.Time // zero for session cookies.
+}
+
+type cookieMiddleware struct {
+	mu  sync.Mutex
+	jar map[string]jarCookie
+}
+
+func (m *cookieMiddleware) SendingHeaders(context.Context) metadata.MD {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	pairs := make([]string, 0, len(m.jar))
+	for name, c := range m.jar {
+		if !c.expires.IsZero() && !c.expires.After(now) {
+			delete(m.jar, name)
+			continue
+		}
+		pairs = append(pairs, name+"="+c.value)
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	sort.Strings(pairs)
+	return metadata.Pairs("cookie", strings.Join(pairs, "; "))
+}
+
+func (m *cookieMiddleware) HeadersReceived(_ context.Context, md metadata.MD) {
+	cookies := (&http.Response{Header: http.Header{"Set-Cookie": getHeader(md, "set-cookie")}}).Cookies()
+	if len(cookies) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, c := range cookies {
+		var expires time.Time
+		switch {
+		case c.MaxAge < 0:
+			delete(m.jar, c.Name)
+			continue
+		case c.MaxAge > 0:
+			expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		case !c.Expires.IsZero():
+			if !c.Expires.After(now) {
+				delete(m.jar, c.Name)
+				continue
+			}
+			expires = c.Expires
+		}
+		m.jar[c.Name] = jarCookie{value: c.Value, expires: expires}
+	}
+}
+
+// getHeader returns the values of key in md, regardless of the case of the
+// keys of md.
+func getHeader(md metadata.MD, key string) []string {
+	var vals []string
+	for k, v := range md {
+		if strings.EqualFold(k, key) {
+			vals = append(vals, v...)
+		}
+	}
+	return vals
+}
+
+// SetCookie adds a "set-cookie" header for c to the response of the call
+// of ctx, the context of a flight handler. It must be called before the
+// headers of the response are sent.
+func SetCookie(ctx context.Context, c *http.Cookie) error {
+	v := c.String()
+	if v == "" {
+		return status.Errorf(codes.Internal, "flight: invalid cookie %q", c.Name)
+	}
+	return grpc.SetHeader(ctx, metadata.Pairs("set-cookie", v))
+}
+
+// CookiesFromContext returns the cookies sent by the client of the call of
+// ctx, the context of a flight handler.
+func CookiesFromContext(ctx context.Context) []*http.Cookie {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return (&http.Request{Header: http.Header{"Cookie": getHeader(md, "cookie")}}).Cookies()
+}
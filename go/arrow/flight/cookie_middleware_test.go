@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCookieMiddleware(t *testing.T) {
+	expired := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+	for _, tc := range []struct {
+		name     string
+		received []metadata.MD
+		want     []string
+	}{
+		{"no cookies", nil, nil},
+		{"session", []metadata.MD{metadata.Pairs("set-cookie", "id=42")}, []string{"id=42"}},
+		{"several cookies", []metadata.MD{
+			{"set-cookie": {"b=2; Path=/", "a=1; HttpOnly"}},
+		}, []string{"a=1; b=2"}},
+		{"header case", []metadata.MD{{"Set-Cookie": {"id=42"}}}, []string{"id=42"}},
+		{"replaced", []metadata.MD{
+			metadata.Pairs("set-cookie", "id=1"),
+			metadata.Pairs("set-cookie", "id=2"),
+		}, []string{"id=2"}},
+		{"max-age", []metadata.MD{metadata.Pairs("set-cookie", "id=42; Max-Age=3600")}, []string{"id=42"}},
+		{"expires", []metadata.MD{metadata.Pairs("set-cookie", "id=42; Expires="+future)}, []string{"id=42"}},
+		{"deleted with max-age", []metadata.MD{
+			metadata.Pairs("set-cookie", "id=42", "set-cookie", "other=1"),
+			metadata.Pairs("set-cookie", "id=; Max-Age=0"),
+		}, []string{"other=1"}},
+		{"deleted with expires", []metadata.MD{
+			metadata.Pairs("set-cookie", "id=42"),
+			metadata.Pairs("set-cookie", "id=; Expires="+expired),
+		}, nil},
+		{"max-age over expires", []metadata.MD{
+			metadata.Pairs("set-cookie", "id=42; Expires="+expired+"; Max-Age=3600"),
+		}, []string{"id=42"}},
+		{"invalid", []metadata.MD{metadata.Pairs("set-cookie", "garbage")}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mw := flight.NewCookieMiddleware()
+			ctx := context.Background()
+			for _, md := range tc.received {
+				mw.HeadersReceived(ctx, md)
+			}
+			got := mw.SendingHeaders(ctx).Get("cookie")
+			if len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) {
+				t.Fatalf("invalid cookie header: got=%q, want=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCookieMiddlewareExpiry(t *testing.T) {
+	mw := flight.NewCookieMiddleware()
+	ctx := context.Background()
+	mw.HeadersReceived(ctx, metadata.Pairs("set-cookie", "id=42; Max-Age=1", "set-cookie", "other=1"))
+	if got := mw.SendingHeaders(ctx).Get("cookie"); len(got) != 1 || got[0] != "id=42; other=1" {
+		t.Fatalf("invalid cookie header: %q", got)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if got := mw.SendingHeaders(ctx).Get("cookie"); len(got) != 1 || got[0] != "other=1" {
+		t.Fatalf("invalid cookie header after expiry: %q", got)
+	}
+}
+
+func TestCookieSession(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetSchema: func(ctx context.Context, _ *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			for _, c := range flight.CookiesFromContext(ctx) {
+				if c.Name == "session" {
+					return &flight.SchemaResult{Schema: []byte(c.Value)}, nil
+				}
+			}
+			if err := flight.SetCookie(ctx, &http.Cookie{Name: "session", Value: "s1", MaxAge: 3600}); err != nil {
+				return nil, err
+			}
+			return &flight.SchemaResult{}, nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewClientWithMiddleware(s.Addr().String(), nil,
+		[]flight.ClientMiddleware{flight.NewCookieMiddleware()}, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i, want := range []string{"", "s1", "s1"} {
+		sc, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(sc.Schema); got != want {
+			t.Fatalf("call %d: invalid session: got=%q, want=%q", i, got, want)
+		}
+	}
+
+	if err := flight.SetCookie(context.Background(), &http.Cookie{Name: "bad name"}); err == nil {
+		t.Fatal("expected an error for an invalid cookie")
+	}
+}
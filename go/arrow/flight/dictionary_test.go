@@ -0,0 +1,165 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+var tagSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "tag", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}},
+}, nil)
+
+// tagRecords returns records of tagSchema, one for each of the comma
+// separated dictionaries of dicts, whose rows point to the values of their
+// dictionary in reverse order.
+func tagRecords(mem memory.Allocator, dicts ...string) []array.Record {
+	dt := tagSchema.Field(0).Type.(*arrow.DictionaryType)
+	recs := make([]array.Record, len(dicts))
+	for i, dict := range dicts {
+		values := strings.Split(dict, ",")
+
+		sb := array.NewStringBuilder(mem)
+		sb.AppendValues(values, nil)
+		vals := sb.NewArray()
+		sb.Release()
+
+		ib := array.NewInt32Builder(mem)
+		for j := len(values) - 1; j >= 0; j-- {
+			ib.Append(int32(j))
+		}
+		indices := ib.NewArray()
+		ib.Release()
+
+		arr := array.NewDictionaryArray(dt, indices, vals)
+		recs[i] = array.NewRecord(tagSchema, []array.Interface{arr}, int64(arr.Len()))
+		arr.Release()
+		indices.Release()
+		vals.Release()
+	}
+	return recs
+}
+
+func TestDoGetDictionaries(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	streams := map[string][]array.Record{
+		// the dictionary grows, sent as deltas.
+		"grow": tagRecords(mem, "a", "a,b", "a,b", "a,b,c,d"),
+		// the dictionary is replaced.
+		"replace": tagRecords(mem, "a,b", "c", "d,e,f", "d,e,f"),
+	}
+	defer func() {
+		for _, recs := range streams {
+			for _, rec := range recs {
+				rec.Release()
+			}
+		}
+	}()
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(tagSchema), ipc.WithDictionaryDeltas(true))
+			defer w.Close()
+			for _, rec := range streams[string(tkt.Ticket)] {
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	check := func(t *testing.T, i int, got, want array.Record) {
+		t.Helper()
+		if !array.RecordEqual(got, want) {
+			t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", i, got, want)
+		}
+	}
+
+	for name, want := range streams {
+		t.Run(name, func(t *testing.T) {
+			rmem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer rmem.AssertSize(t, 0)
+
+			stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte(name)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := flight.NewRecordReader(stream, ipc.WithAllocator(rmem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			var n int
+			for ; r.Next(); n++ {
+				check(t, n, r.Record(), want[n])
+			}
+			if err := r.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if n != len(want) {
+				t.Fatalf("invalid number of records: got=%d, want=%d", n, len(want))
+			}
+		})
+
+		t.Run(name+" reader", func(t *testing.T) {
+			rmem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer rmem.AssertSize(t, 0)
+
+			stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte(name)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := flight.NewReader(stream, ipc.WithAllocator(rmem))
+			defer r.Release()
+
+			var n int
+			for ; r.Next(); n++ {
+				check(t, n, r.Record(), want[n])
+			}
+			if err := r.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if n != len(want) {
+				t.Fatalf("invalid number of records: got=%d, want=%d", n, len(want))
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// StatusError is the error of a flight call, of a category given by its
+// grpc code and with optional detail bytes, an opaque payload from the
+// server. Handlers return it as is, the grpc server sends its code and
+// message along with its detail bytes as a status detail, from which
+// StatusFromError recovers it on the client.
+//
+// StatusError matches the sentinel error of its category with xerrors.Is:
+//
+//	if xerrors.Is(err, flight.ErrNotFound) { ... }
+type StatusError struct {
+	Code    codes.Code
+	Message string
+	Detail  []byte
+}
+
+// The sentinel errors of the categories of flight errors, mirroring the
+// status codes of the other Flight implementations.
+var (
+	ErrUnknown           = &StatusError{Code: codes.Unknown, Message: "failed"}
+	ErrInternal          = &StatusError{Code: codes.Internal, Message: "internal error"}
+	ErrInvalidArgument   = &StatusError{Code: codes.InvalidArgument, Message: "invalid argument"}
+	ErrNotFound          = &StatusError{Code: codes.NotFound, Message: "not found"}
+	ErrAlreadyExists     = &StatusError{Code: codes.AlreadyExists, Message: "already exists"}
+	ErrUnimplemented     = &StatusError{Code: codes.Unimplemented, Message: "not implemented"}
+	ErrUnauthenticated   = &StatusError{Code: codes.Unauthenticated, Message: "unauthenticated"}
+	ErrUnauthorized      = &StatusError{Code: codes.PermissionDenied, Message: "unauthorized"}
+	ErrUnavailable       = &StatusError{Code: codes.Unavailable, Message: "unavailable"}
+	ErrTimedOut          = &StatusError{Code: codes.DeadlineExceeded, Message: "timed out"}
+	ErrCancelled         = &StatusError{Code: codes.Canceled, Message: "cancelled"}
+	ErrResourceExhausted = &StatusError{Code: codes.ResourceExhausted, Message: "resource exhausted"}
+)
+
+// NewStatusError returns an error of the category of kind, one of the
+// sentinel errors such as ErrNotFound, with the formatted message and the
+// detail bytes, which may be nil.
+func NewStatusError(kind *StatusError, detail []byte, format string, args ...interface{}) *StatusError {
+	return &StatusError{Code: kind.Code, Message: fmt.Sprintf(format, args...), Detail: detail}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("flight: %s: %s", e.Code, e.Message)
+}
+
+// Is reports whether target is the sentinel error, or any StatusError, of
+// the category of e.
+func (e *StatusError) Is(target error) bool {
+	t, ok := target.(*StatusError)
+	return ok && t.Code == e.Code
+}
+
+// GRPCStatus returns the grpc status of e, carrying its detail bytes as a
+// status detail, so that grpc servers send it and status.Code reads its
+// code.
+func (e *StatusError) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Message)
+	if e.Detail == nil {
+		return st
+	}
+	if withDetail, err := st.WithDetails(wrapperspb.Bytes(e.Detail)); err == nil {
+		return withDetail
+	}
+	return st
+}
+
+// StatusFromError returns the StatusError of err, from a StatusError it
+// wraps or from the grpc status of the error of a call, along with its
+// detail bytes. It returns false for a nil error and for errors not
+// carrying a grpc status, whose category is then ErrUnknown.
+func StatusFromError(err error) (*StatusError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var se *StatusError
+	if xerrors.As(err, &se) {
+		return se, true
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return &StatusError{Code: codes.Unknown, Message: err.Error()}, false
+	}
+
+	se = &StatusError{Code: st.Code(), Message: st.Message()}
+	for _, d := range st.Details() {
+		if b, ok := d.(*wrapperspb.BytesValue); ok {
+			se.Detail = b.GetValue()
+			break
+		}
+	}
+	return se, true
+}
+
+// streamError translates the error of a stream other than io.EOF to a
+// StatusError.
+func streamError(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	if se, ok := StatusFromError(err); ok {
+		return se
+	}
+	return err
+}
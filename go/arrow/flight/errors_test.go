@@ -0,0 +1,137 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusErrorRoundTrip(t *testing.T) {
+	detail := []byte{0x00, 0xde, 0xad, 0xbe, 0xef}
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i32", Type: arrow.PrimitiveTypes.Int32}}, nil)
+	bldr := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetFlightInfo: func(_ context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+			return nil, flight.NewStatusError(flight.ErrNotFound, detail, "no flight %q", desc.Cmd)
+		},
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewWriter(stream)
+			if err := w.Write(rec); err != nil {
+				return err
+			}
+			return flight.NewStatusError(flight.ErrUnavailable, detail, "backend went away")
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	t.Run("unary", func(t *testing.T) {
+		_, err := client.GetFlightInfo(context.Background(), &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("missing")})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		se, ok := flight.StatusFromError(err)
+		if !ok {
+			t.Fatalf("no status in %v", err)
+		}
+		if !xerrors.Is(se, flight.ErrNotFound) || xerrors.Is(se, flight.ErrUnavailable) {
+			t.Fatalf("invalid category: %v", se)
+		}
+		if se.Message != `no flight "missing"` {
+			t.Fatalf("invalid message: %q", se.Message)
+		}
+		if !bytes.Equal(se.Detail, detail) {
+			t.Fatalf("invalid detail: got=%x, want=%x", se.Detail, detail)
+		}
+	})
+
+	t.Run("mid-stream", func(t *testing.T) {
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := flight.NewRecordReader(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+
+		if !r.Next() {
+			t.Fatalf("expected a record: %v", r.Err())
+		}
+		if r.Next() {
+			t.Fatal("unexpected record")
+		}
+
+		err = r.Err()
+		if !xerrors.Is(err, flight.ErrUnavailable) || status.Code(err) != codes.Unavailable {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		se, _ := flight.StatusFromError(err)
+		if !bytes.Equal(se.Detail, detail) {
+			t.Fatalf("invalid detail: got=%x, want=%x", se.Detail, detail)
+		}
+	})
+}
+
+func TestStatusFromError(t *testing.T) {
+	if _, ok := flight.StatusFromError(nil); ok {
+		t.Fatal("status from nil error")
+	}
+
+	se, ok := flight.StatusFromError(io.ErrUnexpectedEOF)
+	if ok || !xerrors.Is(se, flight.ErrUnknown) {
+		t.Fatalf("unexpected status: %v, %v", se, ok)
+	}
+
+	wrapped := xerrors.Errorf("reading: %w", flight.NewStatusError(flight.ErrTimedOut, nil, "too slow"))
+	se, ok = flight.StatusFromError(wrapped)
+	if !ok || se.Code != codes.DeadlineExceeded || se.Detail != nil {
+		t.Fatalf("unexpected status: %v, %v", se, ok)
+	}
+	if !xerrors.Is(wrapped, flight.ErrTimedOut) {
+		t.Fatalf("%v is not a timeout", wrapped)
+	}
+}
@@ -0,0 +1,235 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// echoExchange sends back the path of the descriptor of the call, then
+// every message of the client.
+func echoExchange(_ context.Context, r *flight.Reader, w *flight.Writer) error {
+	desc := r.Descriptor()
+	if desc == nil {
+		return status.Error(codes.InvalidArgument, "missing descriptor")
+	}
+	if err := w.WriteMetadata([]byte(strings.Join(desc.Path, "/"))); err != nil {
+		return err
+	}
+
+	for r.Next() {
+		var err error
+		if rec := r.Record(); rec != nil {
+			err = w.WriteWithAppMetadata(rec, r.AppMetadata())
+		} else {
+			err = w.WriteMetadata(r.AppMetadata())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return r.Err()
+}
+
+// producerExchange sends the primitives records first, then counts the
+// records of the client and sends back their number.
+func producerExchange(_ context.Context, r *flight.Reader, w *flight.Writer) error {
+	for _, rec := range arrdata.Records["primitives"] {
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+
+	n := 0
+	for r.Next() {
+		n++
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+	return w.WriteMetadata([]byte(strconv.Itoa(n)))
+}
+
+func TestExchange(t *testing.T) {
+	v := flight.NewSimpleBasicAuthValidator(map[string]string{"alice": "secret"}, time.Minute)
+	unary, stream := flight.CreateServerBearerTokenAuthInterceptors(v)
+	s := flight.NewFlightServer(nil, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	s.Init("localhost:0")
+
+	exchange := flight.ExchangeHandler(echoExchange)
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoExchange: func(stream flight.FlightService_DoExchangeServer) error {
+			if flight.AuthFromContext(stream.Context()) == nil {
+				return status.Error(codes.Internal, "unauthenticated exchange")
+			}
+			return exchange(stream)
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	creds := flight.NewClientBasicAuthCredentials("alice", "secret")
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, append(creds.DialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	names := make([]string, 0, len(arrdata.Records))
+	for name := range arrdata.Records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		recs := arrdata.Records[name]
+		t.Run(name, func(t *testing.T) {
+			w, r, err := client.ExchangeRecords(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			// the records are written while the echoes are read.
+			errc := make(chan error, 1)
+			go func() {
+				w.SetFlightDescriptor(&flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"echo", name}})
+				for i, rec := range recs {
+					if err := w.WriteWithAppMetadata(rec, []byte(strconv.Itoa(i))); err != nil {
+						errc <- err
+						return
+					}
+					if err := w.WriteMetadata([]byte("metadata " + strconv.Itoa(i))); err != nil {
+						errc <- err
+						return
+					}
+				}
+				errc <- w.Close()
+			}()
+
+			if !r.Next() || r.Record() != nil || string(r.AppMetadata()) != "echo/"+name {
+				t.Fatalf("invalid descriptor echo: %v, %q (err=%v)", r.Record(), r.AppMetadata(), r.Err())
+			}
+			for i, rec := range recs {
+				if !r.Next() {
+					t.Fatalf("missing record %d: %v", i, r.Err())
+				}
+				if !array.RecordEqual(r.Record(), rec) {
+					t.Fatalf("invalid record %d:\ngot= %v\nwant=%v", i, r.Record(), rec)
+				}
+				if got, want := string(r.AppMetadata()), strconv.Itoa(i); got != want {
+					t.Fatalf("invalid metadata of record %d: got=%q, want=%q", i, got, want)
+				}
+				if !r.Next() || r.Record() != nil || string(r.AppMetadata()) != "metadata "+strconv.Itoa(i) {
+					t.Fatalf("invalid metadata message %d: %v, %q (err=%v)", i, r.Record(), r.AppMetadata(), r.Err())
+				}
+			}
+			if r.Next() {
+				t.Fatalf("unexpected message: %v, %q", r.Record(), r.AppMetadata())
+			}
+			if err := r.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if !r.Schema().Equal(recs[0].Schema()) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), recs[0].Schema())
+			}
+			if err := <-errc; err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		_, r, err := client.ExchangeRecords(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+		if r.Next() || status.Code(r.Err()) != codes.Unauthenticated {
+			t.Fatalf("expected an Unauthenticated error, got %v", r.Err())
+		}
+	})
+}
+
+func TestExchangeServerFirst(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{DoExchange: flight.ExchangeHandler(producerExchange)})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	w, r, err := client.ExchangeRecords(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	// the server sends its schema and records before the client sent
+	// anything.
+	want := arrdata.Records["primitives"]
+	for i, rec := range want {
+		if !r.Next() {
+			t.Fatalf("missing record %d: %v", i, r.Err())
+		}
+		if !array.RecordEqual(r.Record(), rec) {
+			t.Fatalf("invalid record %d:\ngot= %v\nwant=%v", i, r.Record(), rec)
+		}
+	}
+
+	recs := arrdata.Records["strings"]
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Next() || r.Record() != nil || string(r.AppMetadata()) != strconv.Itoa(len(recs)) {
+		t.Fatalf("invalid record count: %v, %q (err=%v)", r.Record(), r.AppMetadata(), r.Err())
+	}
+	if r.Next() || r.Err() != nil {
+		t.Fatalf("unexpected end of stream: %v", r.Err())
+	}
+}
@@ -0,0 +1,176 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/extensions"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+// celsiusType is an extension type of temperatures, stored as float64,
+// which is not registered globally.
+type celsiusType struct {
+	arrow.ExtensionBase
+}
+
+func newCelsiusType() *celsiusType {
+	return &celsiusType{ExtensionBase: arrow.ExtensionBase{Storage: arrow.PrimitiveTypes.Float64}}
+}
+
+func (*celsiusType) Name() string            { return "extension" }
+func (*celsiusType) ExtensionName() string   { return "test.celsius" }
+func (*celsiusType) Serialize() string       { return "°C" }
+func (*celsiusType) ArrayType() reflect.Type { return reflect.TypeOf(celsiusArray{}) }
+func (*celsiusType) ExtensionEquals(o arrow.ExtensionType) bool {
+	_, ok := o.(*celsiusType)
+	return ok
+}
+func (*celsiusType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	return newCelsiusType(), nil
+}
+
+type celsiusArray struct {
+	array.ExtensionArrayBase
+}
+
+func TestExtensionTypes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	u, _ := extensions.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	md := arrow.NewMetadata([]string{"k"}, []string{"v"})
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "uuid", Type: extensions.NewUUIDType(), Nullable: true},
+		{Name: "json", Type: extensions.NewJSONType(), Nullable: true},
+		{Name: "temp", Type: newCelsiusType(), Nullable: true, Metadata: md},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*extensions.UUIDBuilder).AppendValues([]extensions.UUID{u, {}}, []bool{true, false})
+	b.Field(1).(*extensions.JSONBuilder).AppendValue(map[string]string{"a": "b"})
+	b.Field(1).AppendNull()
+	b.Field(2).(*array.ExtensionBuilder).StorageBuilder().(*array.Float64Builder).AppendValues([]float64{21.5, -4}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	reg := arrow.NewExtensionTypeRegistry()
+	if err := reg.Register(newCelsiusType()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the server sends rec, and checks that the records put back, without
+	// the client knowing test.celsius, are rec again.
+	put := make(chan error, 1)
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(schema))
+			defer w.Close()
+			return w.Write(rec)
+		},
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			put <- func() error {
+				r := flight.NewReader(stream, ipc.WithExtensionTypes(reg), ipc.WithAllocator(mem))
+				defer r.Release()
+				var n int
+				for ; r.Next(); n++ {
+					if !r.Schema().Equal(schema) {
+						return fmt.Errorf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), schema)
+					}
+					if !array.RecordEqual(r.Record(), rec) {
+						return fmt.Errorf("records differ:\ngot= %v\nwant=%v", r.Record(), rec)
+					}
+				}
+				if n != 1 {
+					return fmt.Errorf("invalid number of records: got=%d, want=1", n)
+				}
+				return r.Err()
+			}()
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte("extensions")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	field := r.Schema().Field(2)
+	if !arrow.TypeEqual(field.Type, arrow.PrimitiveTypes.Float64) {
+		t.Fatalf("invalid storage type %v", field.Type)
+	}
+	want := arrow.NewMetadata(
+		[]string{"k", arrow.ExtensionMetadataKey, arrow.ExtensionNameKey},
+		[]string{"v", "°C", "test.celsius"},
+	)
+	if !reflect.DeepEqual(field.Metadata, want) {
+		t.Fatalf("invalid metadata:\ngot= %v\nwant=%v", field.Metadata, want)
+	}
+
+	if !r.Next() {
+		t.Fatalf("missing record: %v", r.Err())
+	}
+	got := r.Record()
+	uuids, ok := got.Column(0).(*extensions.UUIDArray)
+	if !ok {
+		t.Fatalf("invalid uuid column type %T", got.Column(0))
+	}
+	if uuids.Value(0) != u || !uuids.IsNull(1) {
+		t.Fatalf("invalid uuids: %v", uuids)
+	}
+	if got, want := fmt.Sprint(got.Column(1)), `[{"a":"b"} (null)]`; got != want {
+		t.Fatalf("invalid json column: got=%s, want=%s", got, want)
+	}
+
+	rdr, err := array.NewRecordReader(r.Schema(), []array.Record{got})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rdr.Release()
+	if _, err := client.PutRecords(context.Background(), &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"extensions"}}, rdr, flight.WithPutAllocator(mem)); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-put; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,154 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"encoding/binary"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ipcContinuation is the marker the encapsulated ipc messages start with,
+// which the serialized schema of a FlightInfo must carry.
+const ipcContinuation = 0xFFFFFFFF
+
+// FlightInfoOption configures the FlightInfo built by NewFlightInfo.
+type FlightInfoOption func(*FlightInfo)
+
+// WithTotalRecords sets the total number of records of a FlightInfo,
+// which is -1, unknown, by default.
+func WithTotalRecords(n int64) FlightInfoOption {
+	return func(info *FlightInfo) { info.TotalRecords = n }
+}
+
+// WithTotalBytes sets the total size of a FlightInfo in bytes, which is
+// -1, unknown, by default.
+func WithTotalBytes(n int64) FlightInfoOption {
+	return func(info *FlightInfo) { info.TotalBytes = n }
+}
+
+// WithOrdered marks the endpoints of a FlightInfo as being in the same
+// order as the data.
+func WithOrdered() FlightInfoOption {
+	return func(info *FlightInfo) { info.Ordered = true }
+}
+
+// WithExpiration sets the expiration time of the endpoints of a FlightInfo
+// which do not have one.
+func WithExpiration(t time.Time) FlightInfoOption {
+	return func(info *FlightInfo) {
+		for _, ep := range info.Endpoint {
+			if ep.ExpirationTime == nil {
+				ep.ExpirationTime = timestamppb.New(t)
+			}
+		}
+	}
+}
+
+// NewFlightInfo returns the FlightInfo of the flight desc, whose data has
+// the given schema and is served by endpoints. The schema is serialized as
+// an encapsulated ipc message, as the other implementations expect, and
+// the totals are unknown unless set with WithTotalRecords and
+// WithTotalBytes.
+func NewFlightInfo(schema *arrow.Schema, desc *FlightDescriptor, endpoints []*FlightEndpoint, opts ...FlightInfoOption) *FlightInfo {
+	info := &FlightInfo{
+		Schema:           SerializeSchema(schema, memory.DefaultAllocator),
+		FlightDescriptor: desc,
+		Endpoint:         endpoints,
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}
+	for _, opt := range opts {
+		opt(info)
+	}
+	return info
+}
+
+// Validate checks that the schema of info is serialized as an encapsulated
+// ipc message, that its totals are -1 or more, and that its endpoints have
+// a ticket and locations with valid URIs.
+func (info *FlightInfo) Validate() error {
+	schema := info.GetSchema()
+	if len(schema) < 8 || binary.LittleEndian.Uint32(schema) != ipcContinuation {
+		return xerrors.New("flight: schema is not an encapsulated ipc message")
+	}
+	if _, err := DeserializeSchema(schema, memory.DefaultAllocator); err != nil {
+		return xerrors.Errorf("flight: invalid schema: %w", err)
+	}
+	if info.GetTotalRecords() < -1 {
+		return xerrors.Errorf("flight: invalid total records %d", info.GetTotalRecords())
+	}
+	if info.GetTotalBytes() < -1 {
+		return xerrors.Errorf("flight: invalid total bytes %d", info.GetTotalBytes())
+	}
+	for i, ep := range info.GetEndpoint() {
+		if len(ep.GetTicket().GetTicket()) == 0 {
+			return xerrors.Errorf("flight: endpoint %d has no ticket", i)
+		}
+		for _, loc := range ep.GetLocation() {
+			u, err := url.Parse(loc.GetUri())
+			if err != nil {
+				return xerrors.Errorf("flight: endpoint %d: invalid location %q: %w", i, loc.GetUri(), err)
+			}
+			if u.Scheme == "" {
+				return xerrors.Errorf("flight: endpoint %d: invalid location %q: missing scheme", i, loc.GetUri())
+			}
+		}
+	}
+	return nil
+}
+
+// Info wraps a FlightInfo, as received by a client, deserializing its
+// schema once, when first needed. It may be used concurrently.
+type Info struct {
+	*FlightInfo
+
+	once   sync.Once
+	schema *arrow.Schema
+	err    error
+}
+
+// NewInfo returns info wrapped in an Info.
+func NewInfo(info *FlightInfo) *Info {
+	return &Info{FlightInfo: info}
+}
+
+// ArrowSchema returns the deserialized schema of the FlightInfo.
+func (i *Info) ArrowSchema() (*arrow.Schema, error) {
+	i.once.Do(func() {
+		i.schema, i.err = DeserializeSchema(i.GetSchema(), memory.DefaultAllocator)
+	})
+	return i.schema, i.err
+}
+
+// Records returns the total number of records of the FlightInfo, and
+// whether it is known.
+func (i *Info) Records() (int64, bool) {
+	return i.GetTotalRecords(), i.GetTotalRecords() >= 0
+}
+
+// Bytes returns the total size of the FlightInfo in bytes, and
+// whether it is known.
+func (i *Info) Bytes() (int64, bool) {
+	return i.GetTotalBytes(), i.GetTotalBytes() >= 0
+}
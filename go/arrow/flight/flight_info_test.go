@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// infoSchemaVector is the schema {id: int64, name: utf8 nullable} in the
+// ipc stream format read by the other implementations: the continuation
+// marker, the little-endian length of the flatbuffer Schema message padded
+// to 8 bytes, the message, then the end of stream marker.
+const infoSchemaVector = "ffffffffb80000001000000000000a000c000a00090004000a0000001000000000010300080008000000040008000000040000000200000054000000140000001000140010000f000e000800000004001000000010000000140000000000050110000000000000000400040004000000040000006e616d650000000010001400100000000f00080000000400100000001000000018000000000000021c0000000000000008000c00080007000800000000000001400000000200000069640000ffffffff00000000"
+
+func infoSchema() *arrow.Schema {
+	return arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+}
+
+func infoEndpoint(ticket string, locs ...string) *flight.FlightEndpoint {
+	ep := &flight.FlightEndpoint{Ticket: &flight.Ticket{Ticket: []byte(ticket)}}
+	for _, loc := range locs {
+		ep.Location = append(ep.Location, &flight.Location{Uri: loc})
+	}
+	return ep
+}
+
+func TestNewFlightInfo(t *testing.T) {
+	desc := &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("query")}
+	expiration := time.Unix(1600000000, 0).UTC()
+	expires := timestamppb.New(expiration.Add(time.Hour))
+
+	info := flight.NewFlightInfo(infoSchema(), desc, []*flight.FlightEndpoint{
+		infoEndpoint("a", "grpc+tcp://localhost:1234"),
+		{Ticket: &flight.Ticket{Ticket: []byte("b")}, ExpirationTime: expires},
+	})
+	if got := hex.EncodeToString(info.Schema); got != infoSchemaVector {
+		t.Fatalf("invalid serialized schema:\ngot= %s\nwant=%s", got, infoSchemaVector)
+	}
+	if info.TotalRecords != -1 || info.TotalBytes != -1 || info.Ordered {
+		t.Fatalf("invalid defaults: records=%d, bytes=%d, ordered=%v", info.TotalRecords, info.TotalBytes, info.Ordered)
+	}
+	if !proto.Equal(info.FlightDescriptor, desc) || len(info.Endpoint) != 2 {
+		t.Fatalf("invalid flight info: %v", info)
+	}
+	if err := info.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	info = flight.NewFlightInfo(infoSchema(), desc, info.Endpoint,
+		flight.WithTotalRecords(10), flight.WithTotalBytes(1024), flight.WithOrdered(), flight.WithExpiration(expiration))
+	if info.TotalRecords != 10 || info.TotalBytes != 1024 || !info.Ordered {
+		t.Fatalf("invalid options: records=%d, bytes=%d, ordered=%v", info.TotalRecords, info.TotalBytes, info.Ordered)
+	}
+	if got := info.Endpoint[0].GetExpirationTime().AsTime(); !got.Equal(expiration) {
+		t.Fatalf("invalid expiration: got=%v, want=%v", got, expiration)
+	}
+	if got := info.Endpoint[1].GetExpirationTime(); !proto.Equal(got, expires) {
+		t.Fatalf("expiration overridden: got=%v, want=%v", got, expires)
+	}
+}
+
+func TestSchemaVector(t *testing.T) {
+	raw, err := hex.DecodeString(infoSchemaVector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.LittleEndian.Uint32(raw) != 0xFFFFFFFF {
+		t.Fatalf("missing continuation marker")
+	}
+	n := int(binary.LittleEndian.Uint32(raw[4:]))
+	if n%8 != 0 || len(raw) != 8+n+8 {
+		t.Fatalf("invalid message length %d for %d bytes", n, len(raw))
+	}
+	if eos := hex.EncodeToString(raw[8+n:]); eos != "ffffffff00000000" {
+		t.Fatalf("invalid end of stream marker %s", eos)
+	}
+
+	schema, err := flight.NewInfo(&flight.FlightInfo{Schema: raw}).ArrowSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !schema.Equal(infoSchema()) {
+		t.Fatalf("invalid schema: got=%v, want=%v", schema, infoSchema())
+	}
+}
+
+func TestFlightInfoValidate(t *testing.T) {
+	valid := func() *flight.FlightInfo {
+		return flight.NewFlightInfo(infoSchema(), nil, []*flight.FlightEndpoint{
+			infoEndpoint("a", "grpc+tcp://localhost:1234", "grpc+unix:///tmp/flight.sock"),
+			infoEndpoint("b"),
+		})
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(*flight.FlightInfo)
+		err    string
+	}{
+		{"no schema", func(info *flight.FlightInfo) { info.Schema = nil }, "encapsulated"},
+		{"bare message", func(info *flight.FlightInfo) { info.Schema = info.Schema[8:] }, "encapsulated"},
+		{"truncated schema", func(info *flight.FlightInfo) { info.Schema = info.Schema[:16] }, "invalid schema"},
+		{"total records", func(info *flight.FlightInfo) { info.TotalRecords = -2 }, "total records"},
+		{"total bytes", func(info *flight.FlightInfo) { info.TotalBytes = -2 }, "total bytes"},
+		{"no ticket", func(info *flight.FlightInfo) { info.Endpoint[1].Ticket = nil }, "endpoint 1 has no ticket"},
+		{"empty ticket", func(info *flight.FlightInfo) { info.Endpoint[0].Ticket.Ticket = nil }, "endpoint 0 has no ticket"},
+		{"bad location", func(info *flight.FlightInfo) { info.Endpoint[0].Location[1].Uri = "grpc+tcp://[::1" }, "invalid location"},
+		{"no scheme", func(info *flight.FlightInfo) { info.Endpoint[0].Location[0].Uri = "/tmp/flight.sock" }, "missing scheme"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			info := valid()
+			tc.mutate(info)
+			err := info.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected a %q error, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestInfo(t *testing.T) {
+	info := flight.NewInfo(flight.NewFlightInfo(infoSchema(), nil, nil, flight.WithTotalRecords(42)))
+
+	var wg sync.WaitGroup
+	schemas := make([]*arrow.Schema, 8)
+	for i := range schemas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			schema, err := info.ArrowSchema()
+			if err != nil {
+				t.Error(err)
+			}
+			schemas[i] = schema
+		}(i)
+	}
+	wg.Wait()
+	for _, schema := range schemas[1:] {
+		if schema != schemas[0] {
+			t.Fatalf("the schema was deserialized more than once")
+		}
+	}
+	if !schemas[0].Equal(infoSchema()) {
+		t.Fatalf("invalid schema: got=%v, want=%v", schemas[0], infoSchema())
+	}
+
+	if n, ok := info.Records(); n != 42 || !ok {
+		t.Fatalf("invalid records: got=%d, %v", n, ok)
+	}
+	if n, ok := info.Bytes(); n != -1 || ok {
+		t.Fatalf("invalid bytes: got=%d, %v", n, ok)
+	}
+
+	info = flight.NewInfo(&flight.FlightInfo{Schema: []byte("invalid")})
+	if _, err := info.ArrowSchema(); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
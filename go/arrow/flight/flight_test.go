@@ -19,17 +19,25 @@ package flight_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/flight"
 	"github.com/apache/arrow/go/arrow/internal/arrdata"
 	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type flightServer struct {
@@ -311,3 +319,573 @@ func TestServer(t *testing.T) {
 		t.Fatalf("got %d, want %d", numRows, fi.TotalRecords)
 	}
 }
+
+func TestAuthExemptions(t *testing.T) {
+	f := &flightServer{}
+	for _, tc := range []struct {
+		name   string
+		exempt func(string) bool
+		unary  codes.Code
+		stream codes.Code
+	}{
+		{"default", nil, codes.Unauthenticated, codes.Unauthenticated},
+		{"exempt", flight.ExemptMethods("/grpc.health.v1.Health/Check", "/grpc.health.v1.Health/Watch"), codes.OK, codes.OK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := flight.NewFlightServerWithAuthExemptions(&servAuth{}, tc.exempt)
+			s.Init("localhost:0")
+			s.RegisterFlightService(&flight.FlightServiceService{ListFlights: f.ListFlights})
+			grpc_health_v1.RegisterHealthService(s, grpc_health_v1.NewHealthService(health.NewServer()))
+
+			go s.Serve()
+			defer s.Shutdown()
+
+			conn, err := grpc.Dial(s.Addr().String(), grpc.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			hc := grpc_health_v1.NewHealthClient(conn)
+			_, err = hc.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+			if got := status.Code(err); got != tc.unary {
+				t.Fatalf("invalid unary status: got=%v, want=%v (err=%v)", got, tc.unary, err)
+			}
+
+			watch, err := hc.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = watch.Recv()
+			if got := status.Code(err); got != tc.stream {
+				t.Fatalf("invalid stream status: got=%v, want=%v (err=%v)", got, tc.stream, err)
+			}
+
+			// flight methods still require authentication.
+			fs, err := flight.NewFlightServiceClient(conn).ListFlights(context.Background(), &flight.Criteria{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = fs.Recv()
+			if got := status.Code(err); got != codes.Unauthenticated {
+				t.Fatalf("invalid flight status: got=%v, want=%v (err=%v)", got, codes.Unauthenticated, err)
+			}
+		})
+	}
+}
+
+// statusAuth accepts the token "baz" and fails every other token with the
+// error stored for it.
+type statusAuth struct {
+	servAuth
+	errs map[string]error
+}
+
+func (a *statusAuth) IsValid(token string) (interface{}, error) {
+	if token == "baz" {
+		return "bar", nil
+	}
+	return nil, a.errs[token]
+}
+
+func TestAuthErrorStatus(t *testing.T) {
+	expired, err := status.New(codes.Unauthenticated, "token expired").WithDetails(&errdetails.ErrorInfo{Reason: "TOKEN_EXPIRED"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := &statusAuth{errs: map[string]error{
+		"expired": expired.Err(),
+		"denied":  status.Error(codes.PermissionDenied, "no access to this resource"),
+		"plain":   errors.New("invalid token"),
+	}}
+
+	f := &flightServer{}
+	s := flight.NewFlightServer(auth)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: f.ListFlights,
+		GetSchema:   f.GetSchema,
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	conn, err := grpc.Dial(s.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := flight.NewFlightServiceClient(conn)
+
+	for _, tc := range []struct {
+		token  string
+		code   codes.Code
+		msg    string
+		reason string
+	}{
+		{"expired", codes.Unauthenticated, "token expired", "TOKEN_EXPIRED"},
+		{"denied", codes.PermissionDenied, "no access to this resource", ""},
+		{"plain", codes.Unauthenticated, "auth-error: invalid token", ""},
+	} {
+		t.Run(tc.token, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "auth-token-bin", tc.token)
+
+			check := func(t *testing.T, err error) {
+				t.Helper()
+				st := status.Convert(err)
+				if st.Code() != tc.code || st.Message() != tc.msg {
+					t.Fatalf("invalid status: got=(%v, %q), want=(%v, %q)", st.Code(), st.Message(), tc.code, tc.msg)
+				}
+				var reason string
+				for _, d := range st.Details() {
+					if info, ok := d.(*errdetails.ErrorInfo); ok {
+						reason = info.Reason
+					}
+				}
+				if reason != tc.reason {
+					t.Fatalf("invalid status details: got reason %q, want %q", reason, tc.reason)
+				}
+			}
+
+			t.Run("unary", func(t *testing.T) {
+				_, err := client.GetSchema(ctx, &flight.FlightDescriptor{})
+				check(t, err)
+			})
+
+			t.Run("stream", func(t *testing.T) {
+				fs, err := client.ListFlights(ctx, &flight.Criteria{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = fs.Recv()
+				check(t, err)
+			})
+		})
+	}
+}
+
+// renewingServerAuth issues tokens which expire after two uses, and are
+// then renewed once.
+type renewingServerAuth struct {
+	mu     sync.Mutex
+	n      int
+	uses   map[string]int
+	tokens []string
+}
+
+func (a *renewingServerAuth) issue() string {
+	tok := fmt.Sprintf("token-%d", a.n)
+	a.n++
+	a.uses[tok] = 0
+	return tok
+}
+
+func (a *renewingServerAuth) Authenticate(c flight.AuthConn) error {
+	if _, err := c.Read(); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	tok := a.issue()
+	a.mu.Unlock()
+	return c.Send([]byte(tok))
+}
+
+func (a *renewingServerAuth) IsValid(token string) (interface{}, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tokens = append(a.tokens, token)
+	uses, ok := a.uses[token]
+	switch {
+	case !ok:
+		return nil, status.Error(codes.Unauthenticated, "unknown token")
+	case uses == 2:
+		delete(a.uses, token)
+		return nil, &flight.ExpiredTokenError{Identity: "bar", NewToken: a.issue()}
+	}
+	a.uses[token]++
+	return "bar", nil
+}
+
+// renewingClientAuth stores the token of the handshake and the tokens
+// renewed by the server.
+type renewingClientAuth struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (a *renewingClientAuth) Authenticate(ctx context.Context, c flight.AuthConn) error {
+	if err := c.Send([]byte("user")); err != nil {
+		return err
+	}
+	tok, err := c.Read()
+	if err != nil {
+		return err
+	}
+	a.SetToken(string(tok))
+	return nil
+}
+
+func (a *renewingClientAuth) GetToken(context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.token, nil
+}
+
+func (a *renewingClientAuth) SetToken(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = token
+}
+
+func TestAuthTokenRenewal(t *testing.T) {
+	f := &flightServer{}
+	auth := &renewingServerAuth{uses: make(map[string]int)}
+	s := flight.NewFlightServer(auth)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: f.ListFlights,
+		GetSchema:   f.GetSchema,
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), &renewingClientAuth{}, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Authenticate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 12; i++ {
+		if i%2 == 0 {
+			if _, err := client.GetSchema(ctx, &flight.FlightDescriptor{Path: []string{"primitives"}}); err != nil {
+				t.Fatalf("call %d: %v", i, err)
+			}
+			continue
+		}
+
+		fs, err := client.ListFlights(ctx, &flight.Criteria{Expression: []byte("primitives")})
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		info, err := fs.Recv()
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if got := info.FlightDescriptor.GetPath()[1]; got != "bar" {
+			t.Fatalf("call %d: invalid identity %q", i, got)
+		}
+	}
+
+	// every token is used twice, then renewed by the third call.
+	want := []string{
+		"token-0", "token-0", "token-0",
+		"token-1", "token-1", "token-1",
+		"token-2", "token-2", "token-2",
+		"token-3", "token-3", "token-3",
+	}
+	if fmt.Sprint(auth.tokens) != fmt.Sprint(want) {
+		t.Fatalf("invalid tokens:\ngot= %v\nwant=%v", auth.tokens, want)
+	}
+}
+
+// slowAuth is a handshake waiting on a second request, or on an external
+// service if wait is set, once it got the first one.
+type slowAuth struct {
+	servAuth
+	wait    bool
+	started chan struct{}
+	done    chan error
+}
+
+func (a *slowAuth) AuthenticateWithContext(ctx context.Context, c flight.AuthConn) (err error) {
+	defer func() { a.done <- err }()
+	if _, err := c.Read(); err != nil {
+		return err
+	}
+	close(a.started)
+
+	if a.wait {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	_, err = c.Read()
+	return err
+}
+
+func TestAuthenticateWithContext(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		wait     bool
+		deadline bool
+	}{
+		{"cancel read", false, false},
+		{"cancel wait", true, false},
+		{"deadline read", false, true},
+		{"deadline wait", true, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := &slowAuth{wait: tc.wait, started: make(chan struct{}), done: make(chan error, 1)}
+			s := flight.NewFlightServer(auth)
+			s.Init("localhost:0")
+			s.RegisterFlightService(&flight.FlightServiceService{})
+
+			go s.Serve()
+			defer s.Shutdown()
+
+			client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.deadline {
+				ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+			}
+			defer cancel()
+
+			stream, err := client.Handshake(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := stream.Send(&flight.HandshakeRequest{Payload: []byte("foobar")}); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case <-auth.started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("handshake not started")
+			}
+			if !tc.deadline {
+				cancel()
+			}
+
+			select {
+			case err := <-auth.done:
+				if err == nil {
+					t.Fatal("handshake succeeded after the client went away")
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("handshake not abandoned")
+			}
+		})
+	}
+}
+
+func TestAuthAuditMode(t *testing.T) {
+	identity := func(ctx context.Context) []byte {
+		return []byte(fmt.Sprint(flight.AuthFromContext(ctx)))
+	}
+	service := &flight.FlightServiceService{
+		GetSchema: func(ctx context.Context, _ *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			return &flight.SchemaResult{Schema: identity(ctx)}, nil
+		},
+		ListFlights: func(_ *flight.Criteria, fs flight.FlightService_ListFlightsServer) error {
+			return fs.Send(&flight.FlightInfo{Schema: identity(fs.Context())})
+		},
+	}
+
+	type failure struct {
+		method string
+		code   codes.Code
+	}
+
+	for _, tc := range []struct {
+		name  string
+		audit bool
+	}{
+		{"enforce", false},
+		{"audit", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				failures []failure
+			)
+			var opts []grpc.ServerOption
+			if tc.audit {
+				opts = append(opts, flight.WithAuthAuditMode(func(method string, err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					failures = append(failures, failure{method, status.Code(err)})
+				}))
+			}
+
+			s := flight.NewFlightServer(&servAuth{}, opts...)
+			s.Init("localhost:0")
+			s.RegisterFlightService(service)
+
+			go s.Serve()
+			defer s.Shutdown()
+
+			client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			for _, token := range []string{"baz", "invalid"} {
+				ctx := metadata.AppendToOutgoingContext(context.Background(), "auth-token-bin", token)
+				want, code := "bar", codes.OK
+				if token != "baz" {
+					want = "<nil>"
+					if !tc.audit {
+						code = codes.Unauthenticated
+					}
+				}
+
+				sc, err := client.GetSchema(ctx, &flight.FlightDescriptor{})
+				if status.Code(err) != code {
+					t.Fatalf("token %q: invalid unary status: %v", token, err)
+				}
+				if err == nil && string(sc.Schema) != want {
+					t.Fatalf("token %q: invalid unary identity: got=%q, want=%q", token, sc.Schema, want)
+				}
+
+				fs, err := client.ListFlights(ctx, &flight.Criteria{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				info, err := fs.Recv()
+				if status.Code(err) != code {
+					t.Fatalf("token %q: invalid stream status: %v", token, err)
+				}
+				if err == nil && string(info.Schema) != want {
+					t.Fatalf("token %q: invalid stream identity: got=%q, want=%q", token, info.Schema, want)
+				}
+			}
+
+			var want []failure
+			if tc.audit {
+				want = []failure{
+					{"/arrow.flight.protocol.FlightService/GetSchema", codes.Unauthenticated},
+					{"/arrow.flight.protocol.FlightService/ListFlights", codes.Unauthenticated},
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(failures) != len(want) {
+				t.Fatalf("invalid audited failures: got=%v, want=%v", failures, want)
+			}
+			for i := range want {
+				if failures[i] != want[i] {
+					t.Fatalf("invalid audited failures: got=%v, want=%v", failures, want)
+				}
+			}
+		})
+	}
+}
+
+// pollQuery is a query producing one endpoint per poll, over three polls.
+// Its retry descriptors carry the number of endpoints already available.
+func pollQuery(_ context.Context, desc *flight.FlightDescriptor) (*flight.PollInfo, error) {
+	const total = 3
+
+	n := 0
+	if cmd := string(desc.GetCmd()); cmd != "query" {
+		if _, err := fmt.Sscanf(cmd, "query/%d", &n); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid retry descriptor %q", cmd)
+		}
+	}
+	n++
+
+	info := &flight.FlightInfo{
+		FlightDescriptor: &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("query")},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+		Ordered:          true,
+	}
+	for i := 0; i < n; i++ {
+		info.Endpoint = append(info.Endpoint, &flight.FlightEndpoint{
+			Ticket:         &flight.Ticket{Ticket: []byte(fmt.Sprintf("part-%d", i))},
+			ExpirationTime: timestamppb.New(time.Unix(int64(1600000000+i), 0)),
+		})
+	}
+
+	progress := float64(n) / total
+	poll := &flight.PollInfo{Info: info, Progress: &progress}
+	if n < total {
+		poll.FlightDescriptor = &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte(fmt.Sprintf("query/%d", n))}
+		poll.ExpirationTime = timestamppb.New(time.Now().Add(time.Minute))
+	}
+	return poll, nil
+}
+
+func TestPollFlightInfo(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{PollFlightInfo: pollQuery})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var (
+		desc  = &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("query")}
+		polls = 0
+		info  *flight.PollInfo
+	)
+	for desc != nil {
+		info, err = client.PollFlightInfo(context.Background(), desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		polls++
+
+		if got, want := len(info.GetInfo().GetEndpoint()), polls; got != want {
+			t.Fatalf("poll %d: invalid number of endpoints: got=%d, want=%d", polls, got, want)
+		}
+		if info.Progress == nil || info.GetProgress() != float64(polls)/3 {
+			t.Fatalf("poll %d: invalid progress: %v", polls, info.Progress)
+		}
+		if !info.GetInfo().GetOrdered() {
+			t.Fatalf("poll %d: flight info is not ordered", polls)
+		}
+
+		desc = info.GetFlightDescriptor()
+		if desc != nil && !info.GetExpirationTime().AsTime().After(time.Now()) {
+			t.Fatalf("poll %d: invalid expiration time: %v", polls, info.GetExpirationTime())
+		}
+	}
+
+	if polls != 3 {
+		t.Fatalf("invalid number of polls: got=%d, want=3", polls)
+	}
+	for i, ep := range info.GetInfo().GetEndpoint() {
+		if got, want := ep.GetExpirationTime().AsTime(), time.Unix(int64(1600000000+i), 0); !got.Equal(want) {
+			t.Fatalf("endpoint %d: invalid expiration time: got=%v, want=%v", i, got, want)
+		}
+	}
+}
+
+func TestPollFlightInfoUnimplemented(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, err = client.PollFlightInfo(context.Background(), &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("query")})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected an Unimplemented error, got %v", err)
+	}
+}
@@ -0,0 +1,3002 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+// <p>
+// http://www.apache.org/licenses/LICENSE-2.0
+// <p>
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.9.1
+// source: FlightSql.proto
+
+package flightsql
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type ActionEndTransactionRequest_EndTransaction int32
+
+const (
+	ActionEndTransactionRequest_END_TRANSACTION_UNSPECIFIED ActionEndTransactionRequest_EndTransaction = 0
+	// Commit the transaction.
+	ActionEndTransactionRequest_END_TRANSACTION_COMMIT ActionEndTransactionRequest_EndTransaction = 1
+	// Roll back the transaction.
+	ActionEndTransactionRequest_END_TRANSACTION_ROLLBACK ActionEndTransactionRequest_EndTransaction = 2
+)
+
+// Enum value maps for ActionEndTransactionRequest_EndTransaction.
+var (
+	ActionEndTransactionRequest_EndTransaction_name = map[int32]string{
+		0: "END_TRANSACTION_UNSPECIFIED",
+		1: "END_TRANSACTION_COMMIT",
+		2: "END_TRANSACTION_ROLLBACK",
+	}
+	ActionEndTransactionRequest_EndTransaction_value = map[string]int32{
+		"END_TRANSACTION_UNSPECIFIED": 0,
+		"END_TRANSACTION_COMMIT":      1,
+		"END_TRANSACTION_ROLLBACK":    2,
+	}
+)
+
+func (x ActionEndTransactionRequest_EndTransaction) Enum() *ActionEndTransactionRequest_EndTransaction {
+	p := new(ActionEndTransactionRequest_EndTransaction)
+	*p = x
+	return p
+}
+
+func (x ActionEndTransactionRequest_EndTransaction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionEndTransactionRequest_EndTransaction) Descriptor() protoreflect.EnumDescriptor {
+	return file_FlightSql_proto_enumTypes[0].Descriptor()
+}
+
+func (ActionEndTransactionRequest_EndTransaction) Type() protoreflect.EnumType {
+	return &file_FlightSql_proto_enumTypes[0]
+}
+
+func (x ActionEndTransactionRequest_EndTransaction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionEndTransactionRequest_EndTransaction.Descriptor instead.
+func (ActionEndTransactionRequest_EndTransaction) EnumDescriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type ActionEndSavepointRequest_EndSavepoint int32
+
+const (
+	ActionEndSavepointRequest_END_SAVEPOINT_UNSPECIFIED ActionEndSavepointRequest_EndSavepoint = 0
+	// Release the savepoint.
+	ActionEndSavepointRequest_END_SAVEPOINT_RELEASE ActionEndSavepointRequest_EndSavepoint = 1
+	// Roll back to the savepoint.
+	ActionEndSavepointRequest_END_SAVEPOINT_ROLLBACK ActionEndSavepointRequest_EndSavepoint = 2
+)
+
+// Enum value maps for ActionEndSavepointRequest_EndSavepoint.
+var (
+	ActionEndSavepointRequest_EndSavepoint_name = map[int32]string{
+		0: "END_SAVEPOINT_UNSPECIFIED",
+		1: "END_SAVEPOINT_RELEASE",
+		2: "END_SAVEPOINT_ROLLBACK",
+	}
+	ActionEndSavepointRequest_EndSavepoint_value = map[string]int32{
+		"END_SAVEPOINT_UNSPECIFIED": 0,
+		"END_SAVEPOINT_RELEASE":     1,
+		"END_SAVEPOINT_ROLLBACK":    2,
+	}
+)
+
+func (x ActionEndSavepointRequest_EndSavepoint) Enum() *ActionEndSavepointRequest_EndSavepoint {
+	p := new(ActionEndSavepointRequest_EndSavepoint)
+	*p = x
+	return p
+}
+
+func (x ActionEndSavepointRequest_EndSavepoint) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionEndSavepointRequest_EndSavepoint) Descriptor() protoreflect.EnumDescriptor {
+	return file_FlightSql_proto_enumTypes[1].Descriptor()
+}
+
+func (ActionEndSavepointRequest_EndSavepoint) Type() protoreflect.EnumType {
+	return &file_FlightSql_proto_enumTypes[1]
+}
+
+func (x ActionEndSavepointRequest_EndSavepoint) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionEndSavepointRequest_EndSavepoint.Descriptor instead.
+func (ActionEndSavepointRequest_EndSavepoint) EnumDescriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{15, 0}
+}
+
+// The action to take if the target table does not exist.
+type CommandStatementIngest_TableDefinitionOptions_TableNotExistOption int32
+
+const (
+	CommandStatementIngest_TableDefinitionOptions_TABLE_NOT_EXIST_OPTION_UNSPECIFIED CommandStatementIngest_TableDefinitionOptions_TableNotExistOption = 0
+	// Create the table.
+	CommandStatementIngest_TableDefinitionOptions_TABLE_NOT_EXIST_OPTION_CREATE CommandStatementIngest_TableDefinitionOptions_TableNotExistOption = 1
+	// Fail the ingestion.
+	CommandStatementIngest_TableDefinitionOptions_TABLE_NOT_EXIST_OPTION_FAIL CommandStatementIngest_TableDefinitionOptions_TableNotExistOption = 2
+)
+
+// Enum value maps for CommandStatementIngest_TableDefinitionOptions_TableNotExistOption.
+var (
+	CommandStatementIngest_TableDefinitionOptions_TableNotExistOption_name = map[int32]string{
+		0: "TABLE_NOT_EXIST_OPTION_UNSPECIFIED",
+		1: "TABLE_NOT_EXIST_OPTION_CREATE",
+		2: "TABLE_NOT_EXIST_OPTION_FAIL",
+	}
+	CommandStatementIngest_TableDefinitionOptions_TableNotExistOption_value = map[string]int32{
+		"TABLE_NOT_EXIST_OPTION_UNSPECIFIED": 0,
+		"TABLE_NOT_EXIST_OPTION_CREATE":      1,
+		"TABLE_NOT_EXIST_OPTION_FAIL":        2,
+	}
+)
+
+func (x CommandStatementIngest_TableDefinitionOptions_TableNotExistOption) Enum() *CommandStatementIngest_TableDefinitionOptions_TableNotExistOption {
+	p := new(CommandStatementIngest_TableDefinitionOptions_TableNotExistOption)
+	*p = x
+	return p
+}
+
+func (x CommandStatementIngest_TableDefinitionOptions_TableNotExistOption) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommandStatementIngest_TableDefinitionOptions_TableNotExistOption) Descriptor() protoreflect.EnumDescriptor {
+	return file_FlightSql_proto_enumTypes[2].Descriptor()
+}
+
+func (CommandStatementIngest_TableDefinitionOptions_TableNotExistOption) Type() protoreflect.EnumType {
+	return &file_FlightSql_proto_enumTypes[2]
+}
+
+func (x CommandStatementIngest_TableDefinitionOptions_TableNotExistOption) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommandStatementIngest_TableDefinitionOptions_TableNotExistOption.Descriptor instead.
+func (CommandStatementIngest_TableDefinitionOptions_TableNotExistOption) EnumDescriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{16, 0, 0}
+}
+
+// The action to take if the target table already exists.
+type CommandStatementIngest_TableDefinitionOptions_TableExistsOption int32
+
+const (
+	CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_UNSPECIFIED CommandStatementIngest_TableDefinitionOptions_TableExistsOption = 0
+	// Fail the ingestion.
+	CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_FAIL CommandStatementIngest_TableDefinitionOptions_TableExistsOption = 1
+	// Append the data to the table.
+	CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_APPEND CommandStatementIngest_TableDefinitionOptions_TableExistsOption = 2
+	// Drop and recreate the table, with the schema of the data.
+	CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_REPLACE CommandStatementIngest_TableDefinitionOptions_TableExistsOption = 3
+)
+
+// Enum value maps for CommandStatementIngest_TableDefinitionOptions_TableExistsOption.
+var (
+	CommandStatementIngest_TableDefinitionOptions_TableExistsOption_name = map[int32]string{
+		0: "TABLE_EXISTS_OPTION_UNSPECIFIED",
+		1: "TABLE_EXISTS_OPTION_FAIL",
+		2: "TABLE_EXISTS_OPTION_APPEND",
+		3: "TABLE_EXISTS_OPTION_REPLACE",
+	}
+	CommandStatementIngest_TableDefinitionOptions_TableExistsOption_value = map[string]int32{
+		"TABLE_EXISTS_OPTION_UNSPECIFIED": 0,
+		"TABLE_EXISTS_OPTION_FAIL":        1,
+		"TABLE_EXISTS_OPTION_APPEND":      2,
+		"TABLE_EXISTS_OPTION_REPLACE":     3,
+	}
+)
+
+func (x CommandStatementIngest_TableDefinitionOptions_TableExistsOption) Enum() *CommandStatementIngest_TableDefinitionOptions_TableExistsOption {
+	p := new(CommandStatementIngest_TableDefinitionOptions_TableExistsOption)
+	*p = x
+	return p
+}
+
+func (x CommandStatementIngest_TableDefinitionOptions_TableExistsOption) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommandStatementIngest_TableDefinitionOptions_TableExistsOption) Descriptor() protoreflect.EnumDescriptor {
+	return file_FlightSql_proto_enumTypes[3].Descriptor()
+}
+
+func (CommandStatementIngest_TableDefinitionOptions_TableExistsOption) Type() protoreflect.EnumType {
+	return &file_FlightSql_proto_enumTypes[3]
+}
+
+func (x CommandStatementIngest_TableDefinitionOptions_TableExistsOption) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommandStatementIngest_TableDefinitionOptions_TableExistsOption.Descriptor instead.
+func (CommandStatementIngest_TableDefinitionOptions_TableExistsOption) EnumDescriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{16, 0, 1}
+}
+
+type SetSessionOptionsResult_ErrorValue int32
+
+const (
+	// Protobuf deserialization fallback value: the option was not set for
+	// an unknown reason.
+	SetSessionOptionsResult_UNSPECIFIED SetSessionOptionsResult_ErrorValue = 0
+	// The option name is not recognized by the server.
+	SetSessionOptionsResult_INVALID_NAME SetSessionOptionsResult_ErrorValue = 1
+	// The option value is not valid for the option.
+	SetSessionOptionsResult_INVALID_VALUE SetSessionOptionsResult_ErrorValue = 2
+	// The option could not be set.
+	SetSessionOptionsResult_ERROR SetSessionOptionsResult_ErrorValue = 3
+)
+
+// Enum value maps for SetSessionOptionsResult_ErrorValue.
+var (
+	SetSessionOptionsResult_ErrorValue_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "INVALID_NAME",
+		2: "INVALID_VALUE",
+		3: "ERROR",
+	}
+	SetSessionOptionsResult_ErrorValue_value = map[string]int32{
+		"UNSPECIFIED":   0,
+		"INVALID_NAME":  1,
+		"INVALID_VALUE": 2,
+		"ERROR":         3,
+	}
+)
+
+func (x SetSessionOptionsResult_ErrorValue) Enum() *SetSessionOptionsResult_ErrorValue {
+	p := new(SetSessionOptionsResult_ErrorValue)
+	*p = x
+	return p
+}
+
+func (x SetSessionOptionsResult_ErrorValue) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SetSessionOptionsResult_ErrorValue) Descriptor() protoreflect.EnumDescriptor {
+	return file_FlightSql_proto_enumTypes[4].Descriptor()
+}
+
+func (SetSessionOptionsResult_ErrorValue) Type() protoreflect.EnumType {
+	return &file_FlightSql_proto_enumTypes[4]
+}
+
+func (x SetSessionOptionsResult_ErrorValue) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SetSessionOptionsResult_ErrorValue.Descriptor instead.
+func (SetSessionOptionsResult_ErrorValue) EnumDescriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{23, 0}
+}
+
+type CloseSessionResult_Status int32
+
+const (
+	// Protobuf deserialization fallback value: the session is in an unknown
+	// state.
+	CloseSessionResult_UNSPECIFIED CloseSessionResult_Status = 0
+	// The session is closed.
+	CloseSessionResult_CLOSED CloseSessionResult_Status = 1
+	// The session is being closed.
+	CloseSessionResult_CLOSING CloseSessionResult_Status = 2
+	// The session cannot be closed.
+	CloseSessionResult_NOT_CLOSEABLE CloseSessionResult_Status = 3
+)
+
+// Enum value maps for CloseSessionResult_Status.
+var (
+	CloseSessionResult_Status_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "CLOSED",
+		2: "CLOSING",
+		3: "NOT_CLOSEABLE",
+	}
+	CloseSessionResult_Status_value = map[string]int32{
+		"UNSPECIFIED":   0,
+		"CLOSED":        1,
+		"CLOSING":       2,
+		"NOT_CLOSEABLE": 3,
+	}
+)
+
+func (x CloseSessionResult_Status) Enum() *CloseSessionResult_Status {
+	p := new(CloseSessionResult_Status)
+	*p = x
+	return p
+}
+
+func (x CloseSessionResult_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CloseSessionResult_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_FlightSql_proto_enumTypes[5].Descriptor()
+}
+
+func (CloseSessionResult_Status) Type() protoreflect.EnumType {
+	return &file_FlightSql_proto_enumTypes[5]
+}
+
+func (x CloseSessionResult_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CloseSessionResult_Status.Descriptor instead.
+func (CloseSessionResult_Status) EnumDescriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{27, 0}
+}
+
+// Represents a SQL query. Used in the command member of FlightDescriptor
+// for GetFlightInfo. The endpoints of the returned FlightInfo carry a
+// TicketStatementQuery.
+type CommandStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The SQL syntax.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Include the query as part of this transaction, if not empty.
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *CommandStatementQuery) Reset() {
+	*x = CommandStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandStatementQuery) ProtoMessage() {}
+
+func (x *CommandStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandStatementQuery.ProtoReflect.Descriptor instead.
+func (*CommandStatementQuery) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CommandStatementQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CommandStatementQuery) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// Represents a ticket resulting from GetFlightInfo with a
+// CommandStatementQuery, used as the ticket of DoGet.
+type TicketStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique identifier for the instance of the statement to execute.
+	StatementHandle []byte `protobuf:"bytes,1,opt,name=statement_handle,json=statementHandle,proto3" json:"statement_handle,omitempty"`
+}
+
+func (x *TicketStatementQuery) Reset() {
+	*x = TicketStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TicketStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketStatementQuery) ProtoMessage() {}
+
+func (x *TicketStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketStatementQuery.ProtoReflect.Descriptor instead.
+func (*TicketStatementQuery) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TicketStatementQuery) GetStatementHandle() []byte {
+	if x != nil {
+		return x.StatementHandle
+	}
+	return nil
+}
+
+// Represents a SQL update query. Used in the command member of
+// FlightDescriptor for DoPut, whose PutResult carries a DoPutUpdateResult.
+type CommandStatementUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The SQL syntax.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Include the query as part of this transaction, if not empty.
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *CommandStatementUpdate) Reset() {
+	*x = CommandStatementUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandStatementUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandStatementUpdate) ProtoMessage() {}
+
+func (x *CommandStatementUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandStatementUpdate.ProtoReflect.Descriptor instead.
+func (*CommandStatementUpdate) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CommandStatementUpdate) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CommandStatementUpdate) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// Represents an instance of executing a prepared statement. Used in the
+// command member of FlightDescriptor for GetFlightInfo, and for DoPut to
+// bind the parameters of the statement, and as the ticket of DoGet.
+type CommandPreparedStatementQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+}
+
+func (x *CommandPreparedStatementQuery) Reset() {
+	*x = CommandPreparedStatementQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandPreparedStatementQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandPreparedStatementQuery) ProtoMessage() {}
+
+func (x *CommandPreparedStatementQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandPreparedStatementQuery.ProtoReflect.Descriptor instead.
+func (*CommandPreparedStatementQuery) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CommandPreparedStatementQuery) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+// Represents a SQL update query executed with a prepared statement. Used in
+// the command member of FlightDescriptor for DoPut, along with the
+// parameters of the statement.
+type CommandPreparedStatementUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+}
+
+func (x *CommandPreparedStatementUpdate) Reset() {
+	*x = CommandPreparedStatementUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandPreparedStatementUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandPreparedStatementUpdate) ProtoMessage() {}
+
+func (x *CommandPreparedStatementUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandPreparedStatementUpdate.ProtoReflect.Descriptor instead.
+func (*CommandPreparedStatementUpdate) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CommandPreparedStatementUpdate) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+// Returned from DoPut in the app_metadata of the PutResult of a
+// CommandStatementUpdate or a CommandPreparedStatementUpdate.
+type DoPutUpdateResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of records updated. A return value of -1 represents an
+	// unknown updated record count.
+	RecordCount int64 `protobuf:"varint,1,opt,name=record_count,json=recordCount,proto3" json:"record_count,omitempty"`
+}
+
+func (x *DoPutUpdateResult) Reset() {
+	*x = DoPutUpdateResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DoPutUpdateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoPutUpdateResult) ProtoMessage() {}
+
+func (x *DoPutUpdateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoPutUpdateResult.ProtoReflect.Descriptor instead.
+func (*DoPutUpdateResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DoPutUpdateResult) GetRecordCount() int64 {
+	if x != nil {
+		return x.RecordCount
+	}
+	return 0
+}
+
+// Returned from DoPut in the app_metadata of the PutResult binding the
+// parameters of a CommandPreparedStatementQuery.
+type DoPutPreparedStatementResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The handle replacing the handle of the prepared statement, when the
+	// server invalidated it while binding the parameters. An empty handle
+	// keeps the current handle.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+}
+
+func (x *DoPutPreparedStatementResult) Reset() {
+	*x = DoPutPreparedStatementResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DoPutPreparedStatementResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoPutPreparedStatementResult) ProtoMessage() {}
+
+func (x *DoPutPreparedStatementResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoPutPreparedStatementResult.ProtoReflect.Descriptor instead.
+func (*DoPutPreparedStatementResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DoPutPreparedStatementResult) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+// Request message for the "CreatePreparedStatement" action.
+type ActionCreatePreparedStatementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The valid SQL string to create a prepared statement for.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Create/execute the prepared statement as part of this transaction, if
+	// not empty.
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *ActionCreatePreparedStatementRequest) Reset() {
+	*x = ActionCreatePreparedStatementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCreatePreparedStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCreatePreparedStatementRequest) ProtoMessage() {}
+
+func (x *ActionCreatePreparedStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCreatePreparedStatementRequest.ProtoReflect.Descriptor instead.
+func (*ActionCreatePreparedStatementRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ActionCreatePreparedStatementRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ActionCreatePreparedStatementRequest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// Wrap the result of a "CreatePreparedStatement" action.
+type ActionCreatePreparedStatementResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+	// If a result set generating query was provided, dataset_schema contains
+	// the serialized schema of the result set. It is empty otherwise.
+	DatasetSchema []byte `protobuf:"bytes,2,opt,name=dataset_schema,json=datasetSchema,proto3" json:"dataset_schema,omitempty"`
+	// If the query provided contained parameters, parameter_schema contains
+	// the serialized schema of the parameters. It is empty otherwise.
+	ParameterSchema []byte `protobuf:"bytes,3,opt,name=parameter_schema,json=parameterSchema,proto3" json:"parameter_schema,omitempty"`
+}
+
+func (x *ActionCreatePreparedStatementResult) Reset() {
+	*x = ActionCreatePreparedStatementResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCreatePreparedStatementResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCreatePreparedStatementResult) ProtoMessage() {}
+
+func (x *ActionCreatePreparedStatementResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCreatePreparedStatementResult.ProtoReflect.Descriptor instead.
+func (*ActionCreatePreparedStatementResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ActionCreatePreparedStatementResult) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+func (x *ActionCreatePreparedStatementResult) GetDatasetSchema() []byte {
+	if x != nil {
+		return x.DatasetSchema
+	}
+	return nil
+}
+
+func (x *ActionCreatePreparedStatementResult) GetParameterSchema() []byte {
+	if x != nil {
+		return x.ParameterSchema
+	}
+	return nil
+}
+
+// Request message for the "ClosePreparedStatement" action.
+type ActionClosePreparedStatementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the prepared statement on the server.
+	PreparedStatementHandle []byte `protobuf:"bytes,1,opt,name=prepared_statement_handle,json=preparedStatementHandle,proto3" json:"prepared_statement_handle,omitempty"`
+}
+
+func (x *ActionClosePreparedStatementRequest) Reset() {
+	*x = ActionClosePreparedStatementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionClosePreparedStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionClosePreparedStatementRequest) ProtoMessage() {}
+
+func (x *ActionClosePreparedStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionClosePreparedStatementRequest.ProtoReflect.Descriptor instead.
+func (*ActionClosePreparedStatementRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ActionClosePreparedStatementRequest) GetPreparedStatementHandle() []byte {
+	if x != nil {
+		return x.PreparedStatementHandle
+	}
+	return nil
+}
+
+// Request message for the "BeginTransaction" action. Begins a transaction,
+// which the queries and prepared statements given its id are part of.
+type ActionBeginTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ActionBeginTransactionRequest) Reset() {
+	*x = ActionBeginTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionBeginTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionBeginTransactionRequest) ProtoMessage() {}
+
+func (x *ActionBeginTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionBeginTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ActionBeginTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{10}
+}
+
+// The result of a "BeginTransaction" action.
+type ActionBeginTransactionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the transaction on the server.
+	TransactionId []byte `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *ActionBeginTransactionResult) Reset() {
+	*x = ActionBeginTransactionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionBeginTransactionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionBeginTransactionResult) ProtoMessage() {}
+
+func (x *ActionBeginTransactionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionBeginTransactionResult.ProtoReflect.Descriptor instead.
+func (*ActionBeginTransactionResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ActionBeginTransactionResult) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// Request message for the "EndTransaction" action. Commits or rolls back a
+// transaction, releasing its savepoints.
+type ActionEndTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the transaction on the server.
+	TransactionId []byte `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Whether to commit or roll back the transaction.
+	Action ActionEndTransactionRequest_EndTransaction `protobuf:"varint,2,opt,name=action,proto3,enum=arrow.flight.protocol.sql.ActionEndTransactionRequest_EndTransaction" json:"action,omitempty"`
+}
+
+func (x *ActionEndTransactionRequest) Reset() {
+	*x = ActionEndTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionEndTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionEndTransactionRequest) ProtoMessage() {}
+
+func (x *ActionEndTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionEndTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ActionEndTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ActionEndTransactionRequest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *ActionEndTransactionRequest) GetAction() ActionEndTransactionRequest_EndTransaction {
+	if x != nil {
+		return x.Action
+	}
+	return ActionEndTransactionRequest_END_TRANSACTION_UNSPECIFIED
+}
+
+// Request message for the "BeginSavepoint" action. Creates a savepoint
+// within a transaction.
+type ActionBeginSavepointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The transaction to which the savepoint belongs.
+	TransactionId []byte `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Name for the savepoint.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ActionBeginSavepointRequest) Reset() {
+	*x = ActionBeginSavepointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionBeginSavepointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionBeginSavepointRequest) ProtoMessage() {}
+
+func (x *ActionBeginSavepointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionBeginSavepointRequest.ProtoReflect.Descriptor instead.
+func (*ActionBeginSavepointRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ActionBeginSavepointRequest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *ActionBeginSavepointRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// The result of a "BeginSavepoint" action.
+type ActionBeginSavepointResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the savepoint on the server.
+	SavepointId []byte `protobuf:"bytes,1,opt,name=savepoint_id,json=savepointId,proto3" json:"savepoint_id,omitempty"`
+}
+
+func (x *ActionBeginSavepointResult) Reset() {
+	*x = ActionBeginSavepointResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionBeginSavepointResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionBeginSavepointResult) ProtoMessage() {}
+
+func (x *ActionBeginSavepointResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionBeginSavepointResult.ProtoReflect.Descriptor instead.
+func (*ActionBeginSavepointResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ActionBeginSavepointResult) GetSavepointId() []byte {
+	if x != nil {
+		return x.SavepointId
+	}
+	return nil
+}
+
+// Request message for the "EndSavepoint" action. Releases a savepoint, or
+// rolls the transaction back to it.
+type ActionEndSavepointRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Opaque handle for the savepoint on the server.
+	SavepointId []byte `protobuf:"bytes,1,opt,name=savepoint_id,json=savepointId,proto3" json:"savepoint_id,omitempty"`
+	// Whether to release or roll back to the savepoint.
+	Action ActionEndSavepointRequest_EndSavepoint `protobuf:"varint,2,opt,name=action,proto3,enum=arrow.flight.protocol.sql.ActionEndSavepointRequest_EndSavepoint" json:"action,omitempty"`
+}
+
+func (x *ActionEndSavepointRequest) Reset() {
+	*x = ActionEndSavepointRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionEndSavepointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionEndSavepointRequest) ProtoMessage() {}
+
+func (x *ActionEndSavepointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionEndSavepointRequest.ProtoReflect.Descriptor instead.
+func (*ActionEndSavepointRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ActionEndSavepointRequest) GetSavepointId() []byte {
+	if x != nil {
+		return x.SavepointId
+	}
+	return nil
+}
+
+func (x *ActionEndSavepointRequest) GetAction() ActionEndSavepointRequest_EndSavepoint {
+	if x != nil {
+		return x.Action
+	}
+	return ActionEndSavepointRequest_END_SAVEPOINT_UNSPECIFIED
+}
+
+// Represents a bulk ingestion request. Used in the command member of
+// FlightDescriptor for the DoPut RPC, the data of the table following as
+// record batches. The server returns a DoPutUpdateResult with the number of
+// rows ingested in the app_metadata of its PutResult.
+type CommandStatementIngest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The behavior for handling the table definition.
+	TableDefinitionOptions *CommandStatementIngest_TableDefinitionOptions `protobuf:"bytes,1,opt,name=table_definition_options,json=tableDefinitionOptions,proto3" json:"table_definition_options,omitempty"`
+	// The table to load data into.
+	Table string `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	// The db_schema of the table, the default one of the server if empty.
+	Schema string `protobuf:"bytes,3,opt,name=schema,proto3" json:"schema,omitempty"`
+	// The catalog of the table, the default one of the server if empty.
+	Catalog string `protobuf:"bytes,4,opt,name=catalog,proto3" json:"catalog,omitempty"`
+	// Store the data in a temporary table.
+	Temporary bool `protobuf:"varint,5,opt,name=temporary,proto3" json:"temporary,omitempty"`
+	// Perform the ingestion as part of this transaction, if not empty.
+	TransactionId []byte `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Backend-specific options.
+	Options map[string]string `protobuf:"bytes,1000,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CommandStatementIngest) Reset() {
+	*x = CommandStatementIngest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandStatementIngest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandStatementIngest) ProtoMessage() {}
+
+func (x *CommandStatementIngest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandStatementIngest.ProtoReflect.Descriptor instead.
+func (*CommandStatementIngest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CommandStatementIngest) GetTableDefinitionOptions() *CommandStatementIngest_TableDefinitionOptions {
+	if x != nil {
+		return x.TableDefinitionOptions
+	}
+	return nil
+}
+
+func (x *CommandStatementIngest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *CommandStatementIngest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *CommandStatementIngest) GetCatalog() string {
+	if x != nil {
+		return x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandStatementIngest) GetTemporary() bool {
+	if x != nil {
+		return x.Temporary
+	}
+	return false
+}
+
+func (x *CommandStatementIngest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *CommandStatementIngest) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Represents a request to retrieve the list of catalogs on a Flight SQL
+// enabled backend. Used in the command member of FlightDescriptor for
+// GetFlightInfo, and in the ticket of the endpoints of the result.
+//
+// The returned Arrow schema is:
+// <
+//
+//	catalog_name: utf8 not null
+//
+// >
+// The returned data is ordered by catalog_name.
+type CommandGetCatalogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CommandGetCatalogs) Reset() {
+	*x = CommandGetCatalogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetCatalogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetCatalogs) ProtoMessage() {}
+
+func (x *CommandGetCatalogs) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetCatalogs.ProtoReflect.Descriptor instead.
+func (*CommandGetCatalogs) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{17}
+}
+
+// Represents a request to retrieve the list of database schemas on a
+// Flight SQL enabled backend.
+//
+// The returned Arrow schema is:
+// <
+//
+//	catalog_name: utf8,
+//	db_schema_name: utf8 not null
+//
+// >
+// The returned data is ordered by catalog_name, then db_schema_name.
+type CommandGetDbSchemas struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The catalog to search for schemas. An empty string retrieves the
+	// schemas without a catalog, and no catalog does not filter on it.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	// A LIKE pattern filtering the schemas, "%" matching any substring and
+	// "_" any character. No pattern does not filter on the schema.
+	DbSchemaFilterPattern *string `protobuf:"bytes,2,opt,name=db_schema_filter_pattern,json=dbSchemaFilterPattern,proto3,oneof" json:"db_schema_filter_pattern,omitempty"`
+}
+
+func (x *CommandGetDbSchemas) Reset() {
+	*x = CommandGetDbSchemas{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetDbSchemas) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetDbSchemas) ProtoMessage() {}
+
+func (x *CommandGetDbSchemas) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetDbSchemas.ProtoReflect.Descriptor instead.
+func (*CommandGetDbSchemas) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CommandGetDbSchemas) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetDbSchemas) GetDbSchemaFilterPattern() string {
+	if x != nil && x.DbSchemaFilterPattern != nil {
+		return *x.DbSchemaFilterPattern
+	}
+	return ""
+}
+
+// Represents a request to retrieve the list of tables, and optionally
+// their schemas, on a Flight SQL enabled backend.
+//
+// The returned Arrow schema is:
+// <
+//
+//	catalog_name: utf8,
+//	db_schema_name: utf8,
+//	table_name: utf8 not null,
+//	table_type: utf8 not null,
+//	[optional] table_schema: bytes not null
+//
+// >
+// The table_schema column, present when include_schema is set, holds the
+// schema of the table as serialized by an IPC message. The returned data
+// is ordered by catalog_name, db_schema_name, table_name, then table_type.
+type CommandGetTables struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The catalog to search for tables, as in CommandGetDbSchemas.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	// A LIKE pattern filtering the schemas of the tables, as in
+	// CommandGetDbSchemas.
+	DbSchemaFilterPattern *string `protobuf:"bytes,2,opt,name=db_schema_filter_pattern,json=dbSchemaFilterPattern,proto3,oneof" json:"db_schema_filter_pattern,omitempty"`
+	// A LIKE pattern filtering the tables. No pattern does not filter on the
+	// table name.
+	TableNameFilterPattern *string `protobuf:"bytes,3,opt,name=table_name_filter_pattern,json=tableNameFilterPattern,proto3,oneof" json:"table_name_filter_pattern,omitempty"`
+	// The table types to retrieve, such as TABLE or VIEW. No type does not
+	// filter on the table type.
+	TableTypes []string `protobuf:"bytes,4,rep,name=table_types,json=tableTypes,proto3" json:"table_types,omitempty"`
+	// Include the schemas of the tables in the results.
+	IncludeSchema bool `protobuf:"varint,5,opt,name=include_schema,json=includeSchema,proto3" json:"include_schema,omitempty"`
+}
+
+func (x *CommandGetTables) Reset() {
+	*x = CommandGetTables{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetTables) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetTables) ProtoMessage() {}
+
+func (x *CommandGetTables) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetTables.ProtoReflect.Descriptor instead.
+func (*CommandGetTables) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CommandGetTables) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetDbSchemaFilterPattern() string {
+	if x != nil && x.DbSchemaFilterPattern != nil {
+		return *x.DbSchemaFilterPattern
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetTableNameFilterPattern() string {
+	if x != nil && x.TableNameFilterPattern != nil {
+		return *x.TableNameFilterPattern
+	}
+	return ""
+}
+
+func (x *CommandGetTables) GetTableTypes() []string {
+	if x != nil {
+		return x.TableTypes
+	}
+	return nil
+}
+
+func (x *CommandGetTables) GetIncludeSchema() bool {
+	if x != nil {
+		return x.IncludeSchema
+	}
+	return false
+}
+
+// Represents a request to retrieve the primary keys of a table on a Flight
+// SQL enabled backend.
+//
+// The returned Arrow schema is:
+// <
+//
+//	catalog_name: utf8,
+//	db_schema_name: utf8,
+//	table_name: utf8 not null,
+//	column_name: utf8 not null,
+//	key_name: utf8,
+//	key_sequence: int32 not null
+//
+// >
+// The returned data is ordered by catalog_name, db_schema_name,
+// table_name, key_name, then key_sequence.
+type CommandGetPrimaryKeys struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The catalog of the table, as in CommandGetDbSchemas.
+	Catalog *string `protobuf:"bytes,1,opt,name=catalog,proto3,oneof" json:"catalog,omitempty"`
+	// The schema of the table. An empty string retrieves the tables without
+	// a schema, and no schema does not filter on it.
+	DbSchema *string `protobuf:"bytes,2,opt,name=db_schema,json=dbSchema,proto3,oneof" json:"db_schema,omitempty"`
+	// The table to get the primary keys of.
+	Table string `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (x *CommandGetPrimaryKeys) Reset() {
+	*x = CommandGetPrimaryKeys{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandGetPrimaryKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandGetPrimaryKeys) ProtoMessage() {}
+
+func (x *CommandGetPrimaryKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandGetPrimaryKeys.ProtoReflect.Descriptor instead.
+func (*CommandGetPrimaryKeys) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CommandGetPrimaryKeys) GetCatalog() string {
+	if x != nil && x.Catalog != nil {
+		return *x.Catalog
+	}
+	return ""
+}
+
+func (x *CommandGetPrimaryKeys) GetDbSchema() string {
+	if x != nil && x.DbSchema != nil {
+		return *x.DbSchema
+	}
+	return ""
+}
+
+func (x *CommandGetPrimaryKeys) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+// The value of a session option. An unset value removes the option when
+// setting it.
+type SessionOptionValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to OptionValue:
+	//	*SessionOptionValue_StringValue
+	//	*SessionOptionValue_BoolValue
+	//	*SessionOptionValue_Int64Value
+	//	*SessionOptionValue_DoubleValue
+	//	*SessionOptionValue_StringListValue_
+	OptionValue isSessionOptionValue_OptionValue `protobuf_oneof:"option_value"`
+}
+
+func (x *SessionOptionValue) Reset() {
+	*x = SessionOptionValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionOptionValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionOptionValue) ProtoMessage() {}
+
+func (x *SessionOptionValue) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionOptionValue.ProtoReflect.Descriptor instead.
+func (*SessionOptionValue) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{21}
+}
+
+func (m *SessionOptionValue) GetOptionValue() isSessionOptionValue_OptionValue {
+	if m != nil {
+		return m.OptionValue
+	}
+	return nil
+}
+
+func (x *SessionOptionValue) GetStringValue() string {
+	if x, ok := x.GetOptionValue().(*SessionOptionValue_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+func (x *SessionOptionValue) GetBoolValue() bool {
+	if x, ok := x.GetOptionValue().(*SessionOptionValue_BoolValue); ok {
+		return x.BoolValue
+	}
+	return false
+}
+
+func (x *SessionOptionValue) GetInt64Value() int64 {
+	if x, ok := x.GetOptionValue().(*SessionOptionValue_Int64Value); ok {
+		return x.Int64Value
+	}
+	return 0
+}
+
+func (x *SessionOptionValue) GetDoubleValue() float64 {
+	if x, ok := x.GetOptionValue().(*SessionOptionValue_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (x *SessionOptionValue) GetStringListValue() *SessionOptionValue_StringListValue {
+	if x, ok := x.GetOptionValue().(*SessionOptionValue_StringListValue_); ok {
+		return x.StringListValue
+	}
+	return nil
+}
+
+type isSessionOptionValue_OptionValue interface {
+	isSessionOptionValue_OptionValue()
+}
+
+type SessionOptionValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type SessionOptionValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type SessionOptionValue_Int64Value struct {
+	Int64Value int64 `protobuf:"fixed64,3,opt,name=int64_value,json=int64Value,proto3,oneof"`
+}
+
+type SessionOptionValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type SessionOptionValue_StringListValue_ struct {
+	StringListValue *SessionOptionValue_StringListValue `protobuf:"bytes,5,opt,name=string_list_value,json=stringListValue,proto3,oneof"`
+}
+
+func (*SessionOptionValue_StringValue) isSessionOptionValue_OptionValue() {}
+
+func (*SessionOptionValue_BoolValue) isSessionOptionValue_OptionValue() {}
+
+func (*SessionOptionValue_Int64Value) isSessionOptionValue_OptionValue() {}
+
+func (*SessionOptionValue_DoubleValue) isSessionOptionValue_OptionValue() {}
+
+func (*SessionOptionValue_StringListValue_) isSessionOptionValue_OptionValue() {}
+
+// Request message for the "SetSessionOptions" action. Sets options of the
+// session of the client, removing the ones without value.
+type SetSessionOptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionOptions map[string]*SessionOptionValue `protobuf:"bytes,1,rep,name=session_options,json=sessionOptions,proto3" json:"session_options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SetSessionOptionsRequest) Reset() {
+	*x = SetSessionOptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSessionOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSessionOptionsRequest) ProtoMessage() {}
+
+func (x *SetSessionOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSessionOptionsRequest.ProtoReflect.Descriptor instead.
+func (*SetSessionOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetSessionOptionsRequest) GetSessionOptions() map[string]*SessionOptionValue {
+	if x != nil {
+		return x.SessionOptions
+	}
+	return nil
+}
+
+// The result of a "SetSessionOptions" action, giving the error of each
+// option which could not be set.
+type SetSessionOptionsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Errors map[string]*SetSessionOptionsResult_Error `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SetSessionOptionsResult) Reset() {
+	*x = SetSessionOptionsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSessionOptionsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSessionOptionsResult) ProtoMessage() {}
+
+func (x *SetSessionOptionsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSessionOptionsResult.ProtoReflect.Descriptor instead.
+func (*SetSessionOptionsResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetSessionOptionsResult) GetErrors() map[string]*SetSessionOptionsResult_Error {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// Request message for the "GetSessionOptions" action.
+type GetSessionOptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetSessionOptionsRequest) Reset() {
+	*x = GetSessionOptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionOptionsRequest) ProtoMessage() {}
+
+func (x *GetSessionOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionOptionsRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{24}
+}
+
+// The result of a "GetSessionOptions" action, the options of the session
+// of the client.
+type GetSessionOptionsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionOptions map[string]*SessionOptionValue `protobuf:"bytes,1,rep,name=session_options,json=sessionOptions,proto3" json:"session_options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GetSessionOptionsResult) Reset() {
+	*x = GetSessionOptionsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionOptionsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionOptionsResult) ProtoMessage() {}
+
+func (x *GetSessionOptionsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionOptionsResult.ProtoReflect.Descriptor instead.
+func (*GetSessionOptionsResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetSessionOptionsResult) GetSessionOptions() map[string]*SessionOptionValue {
+	if x != nil {
+		return x.SessionOptions
+	}
+	return nil
+}
+
+// Request message for the "CloseSession" action. Closes the session of the
+// client, discarding its options.
+type CloseSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CloseSessionRequest) Reset() {
+	*x = CloseSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseSessionRequest) ProtoMessage() {}
+
+func (x *CloseSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseSessionRequest.ProtoReflect.Descriptor instead.
+func (*CloseSessionRequest) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{26}
+}
+
+// The result of a "CloseSession" action.
+type CloseSessionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status CloseSessionResult_Status `protobuf:"varint,1,opt,name=status,proto3,enum=arrow.flight.protocol.sql.CloseSessionResult_Status" json:"status,omitempty"`
+}
+
+func (x *CloseSessionResult) Reset() {
+	*x = CloseSessionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseSessionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseSessionResult) ProtoMessage() {}
+
+func (x *CloseSessionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseSessionResult.ProtoReflect.Descriptor instead.
+func (*CloseSessionResult) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CloseSessionResult) GetStatus() CloseSessionResult_Status {
+	if x != nil {
+		return x.Status
+	}
+	return CloseSessionResult_UNSPECIFIED
+}
+
+// Options for table definition behavior.
+type CommandStatementIngest_TableDefinitionOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IfNotExist CommandStatementIngest_TableDefinitionOptions_TableNotExistOption `protobuf:"varint,1,opt,name=if_not_exist,json=ifNotExist,proto3,enum=arrow.flight.protocol.sql.CommandStatementIngest_TableDefinitionOptions_TableNotExistOption" json:"if_not_exist,omitempty"`
+	IfExists   CommandStatementIngest_TableDefinitionOptions_TableExistsOption   `protobuf:"varint,2,opt,name=if_exists,json=ifExists,proto3,enum=arrow.flight.protocol.sql.CommandStatementIngest_TableDefinitionOptions_TableExistsOption" json:"if_exists,omitempty"`
+}
+
+func (x *CommandStatementIngest_TableDefinitionOptions) Reset() {
+	*x = CommandStatementIngest_TableDefinitionOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandStatementIngest_TableDefinitionOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandStatementIngest_TableDefinitionOptions) ProtoMessage() {}
+
+func (x *CommandStatementIngest_TableDefinitionOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandStatementIngest_TableDefinitionOptions.ProtoReflect.Descriptor instead.
+func (*CommandStatementIngest_TableDefinitionOptions) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *CommandStatementIngest_TableDefinitionOptions) GetIfNotExist() CommandStatementIngest_TableDefinitionOptions_TableNotExistOption {
+	if x != nil {
+		return x.IfNotExist
+	}
+	return CommandStatementIngest_TableDefinitionOptions_TABLE_NOT_EXIST_OPTION_UNSPECIFIED
+}
+
+func (x *CommandStatementIngest_TableDefinitionOptions) GetIfExists() CommandStatementIngest_TableDefinitionOptions_TableExistsOption {
+	if x != nil {
+		return x.IfExists
+	}
+	return CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_UNSPECIFIED
+}
+
+type SessionOptionValue_StringListValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SessionOptionValue_StringListValue) Reset() {
+	*x = SessionOptionValue_StringListValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionOptionValue_StringListValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionOptionValue_StringListValue) ProtoMessage() {}
+
+func (x *SessionOptionValue_StringListValue) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionOptionValue_StringListValue.ProtoReflect.Descriptor instead.
+func (*SessionOptionValue_StringListValue) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{21, 0}
+}
+
+func (x *SessionOptionValue_StringListValue) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type SetSessionOptionsResult_Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value SetSessionOptionsResult_ErrorValue `protobuf:"varint,1,opt,name=value,proto3,enum=arrow.flight.protocol.sql.SetSessionOptionsResult_ErrorValue" json:"value,omitempty"`
+}
+
+func (x *SetSessionOptionsResult_Error) Reset() {
+	*x = SetSessionOptionsResult_Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_FlightSql_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSessionOptionsResult_Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSessionOptionsResult_Error) ProtoMessage() {}
+
+func (x *SetSessionOptionsResult_Error) ProtoReflect() protoreflect.Message {
+	mi := &file_FlightSql_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSessionOptionsResult_Error.ProtoReflect.Descriptor instead.
+func (*SetSessionOptionsResult_Error) Descriptor() ([]byte, []int) {
+	return file_FlightSql_proto_rawDescGZIP(), []int{23, 0}
+}
+
+func (x *SetSessionOptionsResult_Error) GetValue() SetSessionOptionsResult_ErrorValue {
+	if x != nil {
+		return x.Value
+	}
+	return SetSessionOptionsResult_UNSPECIFIED
+}
+
+var File_FlightSql_proto protoreflect.FileDescriptor
+
+var file_FlightSql_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x53, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x19, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x22, 0x54, 0x0a, 0x15,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0x41, 0x0a, 0x14, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x55, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x1d,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x3a, 0x0a,
+	0x19, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x17, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x5c, 0x0a, 0x1e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x70,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17,
+	0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x36, 0x0a, 0x11, 0x44, 0x6f, 0x50, 0x75, 0x74,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x5a, 0x0a, 0x1c, 0x44, 0x6f, 0x50, 0x75, 0x74, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x3a, 0x0a, 0x19, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x17, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x63, 0x0a, 0x24, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61,
+	0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0xb3, 0x01, 0x0a, 0x23, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3a, 0x0a, 0x19, 0x70, 0x72, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x68,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x70, 0x72, 0x65,
+	0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x61, 0x0a, 0x23, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a,
+	0x19, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x17, 0x70, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x1f, 0x0a, 0x1d, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x45, 0x0a, 0x1c, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x22, 0x90, 0x02, 0x0a, 0x1b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x5d, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x45, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77,
+	0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x45, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6b, 0x0a, 0x0e, 0x45, 0x6e, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x4e, 0x44,
+	0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x4e,
+	0x44, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f,
+	0x4d, 0x4d, 0x49, 0x54, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x4e, 0x44, 0x5f, 0x54, 0x52,
+	0x41, 0x4e, 0x53, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x42, 0x41,
+	0x43, 0x4b, 0x10, 0x02, 0x22, 0x58, 0x0a, 0x1b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x65,
+	0x67, 0x69, 0x6e, 0x53, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3f,
+	0x0a, 0x1a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x53, 0x61, 0x76,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x73, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x64, 0x22,
+	0xff, 0x01, 0x0a, 0x19, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x53, 0x61, 0x76,
+	0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x59, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x41, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x53, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x64, 0x53, 0x61, 0x76, 0x65, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x64, 0x0a, 0x0c, 0x45,
+	0x6e, 0x64, 0x53, 0x61, 0x76, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x19, 0x45,
+	0x4e, 0x44, 0x5f, 0x53, 0x41, 0x56, 0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x4e,
+	0x44, 0x5f, 0x53, 0x41, 0x56, 0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x4c, 0x45,
+	0x41, 0x53, 0x45, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x4e, 0x44, 0x5f, 0x53, 0x41, 0x56,
+	0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10,
+	0x02, 0x22, 0xf3, 0x07, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x12, 0x82, 0x01, 0x0a,
+	0x18, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x48, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x67, 0x65,
+	0x73, 0x74, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x16, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x65, 0x6d,
+	0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x65,
+	0x6d, 0x70, 0x6f, 0x72, 0x61, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x59,
+	0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe8, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x3e, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xaf, 0x04, 0x0a, 0x16, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x7e, 0x0a, 0x0c, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x65,
+	0x78, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x5c, 0x2e, 0x61, 0x72, 0x72,
+	0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x69, 0x66, 0x4e, 0x6f, 0x74, 0x45,
+	0x78, 0x69, 0x73, 0x74, 0x12, 0x77, 0x0a, 0x09, 0x69, 0x66, 0x5f, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x5a, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
+	0x73, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x66, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x81, 0x01,
+	0x0a, 0x13, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x22, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x5f, 0x4e,
+	0x4f, 0x54, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a,
+	0x1d, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54,
+	0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01,
+	0x12, 0x1f, 0x0a, 0x1b, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x58,
+	0x49, 0x53, 0x54, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x10,
+	0x02, 0x22, 0x97, 0x01, 0x0a, 0x11, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x1f, 0x54, 0x41, 0x42, 0x4c, 0x45,
+	0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18,
+	0x54, 0x41, 0x42, 0x4c, 0x45, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x5f, 0x4f, 0x50, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x41,
+	0x42, 0x4c, 0x45, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x54, 0x41,
+	0x42, 0x4c, 0x45, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x10, 0x03, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x47, 0x65, 0x74, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0x9b, 0x01,
+	0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x44, 0x62, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
+	0x67, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x18, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x15, 0x64, 0x62, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88,
+	0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x42, 0x1b,
+	0x0a, 0x19, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0xbe, 0x02, 0x0a, 0x10,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01, 0x12,
+	0x3c, 0x0a, 0x18, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x15, 0x64, 0x62, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a,
+	0x19, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x02, 0x52, 0x16, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
+	0x67, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x1c,
+	0x0a, 0x1a, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x88, 0x01, 0x0a,
+	0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x63, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x64, 0x62, 0x5f, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x64, 0x62, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x64, 0x62,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0xca, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23,
+	0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x10, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e, 0x74,
+	0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x64, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
+	0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x6b, 0x0a, 0x11,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
+	0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x29, 0x0a, 0x0f, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0xfe, 0x01, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x70, 0x0a, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x47, 0x2e, 0x61, 0x72, 0x72,
+	0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x1a, 0x70, 0x0a, 0x13, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61, 0x72,
+	0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x93, 0x03, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x56, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x3e, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65,
+	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0x5c, 0x0a, 0x05, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x53, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x3d, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65,
+	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x73, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
+	0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x0a,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x49,
+	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x11, 0x0a,
+	0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x22, 0x1a, 0x0a, 0x18, 0x47,
+	0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xfc, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x6f, 0x0a, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x61,
+	0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x70, 0x0a, 0x13, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x43, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61,
+	0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa9, 0x01,
+	0x0a, 0x12, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x4c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x34, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x73, 0x71, 0x6c,
+	0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0f, 0x0a, 0x0b,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a,
+	0x06, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4c, 0x4f,
+	0x53, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x54, 0x5f, 0x43, 0x4c,
+	0x4f, 0x53, 0x45, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x42, 0x5f, 0x0a, 0x20, 0x6f, 0x72, 0x67,
+	0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2e, 0x66, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x2e, 0x73, 0x71, 0x6c, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x5a, 0x3b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65,
+	0x2f, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x2f,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x71, 0x6c,
+	0x3b, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x73, 0x71, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_FlightSql_proto_rawDescOnce sync.Once
+	file_FlightSql_proto_rawDescData = file_FlightSql_proto_rawDesc
+)
+
+func file_FlightSql_proto_rawDescGZIP() []byte {
+	file_FlightSql_proto_rawDescOnce.Do(func() {
+		file_FlightSql_proto_rawDescData = protoimpl.X.CompressGZIP(file_FlightSql_proto_rawDescData)
+	})
+	return file_FlightSql_proto_rawDescData
+}
+
+var file_FlightSql_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_FlightSql_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_FlightSql_proto_goTypes = []interface{}{
+	(ActionEndTransactionRequest_EndTransaction)(0),                        // 0: arrow.flight.protocol.sql.ActionEndTransactionRequest.EndTransaction
+	(ActionEndSavepointRequest_EndSavepoint)(0),                            // 1: arrow.flight.protocol.sql.ActionEndSavepointRequest.EndSavepoint
+	(CommandStatementIngest_TableDefinitionOptions_TableNotExistOption)(0), // 2: arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions.TableNotExistOption
+	(CommandStatementIngest_TableDefinitionOptions_TableExistsOption)(0),   // 3: arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions.TableExistsOption
+	(SetSessionOptionsResult_ErrorValue)(0),                                // 4: arrow.flight.protocol.sql.SetSessionOptionsResult.ErrorValue
+	(CloseSessionResult_Status)(0),                                         // 5: arrow.flight.protocol.sql.CloseSessionResult.Status
+	(*CommandStatementQuery)(nil),                                          // 6: arrow.flight.protocol.sql.CommandStatementQuery
+	(*TicketStatementQuery)(nil),                                           // 7: arrow.flight.protocol.sql.TicketStatementQuery
+	(*CommandStatementUpdate)(nil),                                         // 8: arrow.flight.protocol.sql.CommandStatementUpdate
+	(*CommandPreparedStatementQuery)(nil),                                  // 9: arrow.flight.protocol.sql.CommandPreparedStatementQuery
+	(*CommandPreparedStatementUpdate)(nil),                                 // 10: arrow.flight.protocol.sql.CommandPreparedStatementUpdate
+	(*DoPutUpdateResult)(nil),                                              // 11: arrow.flight.protocol.sql.DoPutUpdateResult
+	(*DoPutPreparedStatementResult)(nil),                                   // 12: arrow.flight.protocol.sql.DoPutPreparedStatementResult
+	(*ActionCreatePreparedStatementRequest)(nil),                           // 13: arrow.flight.protocol.sql.ActionCreatePreparedStatementRequest
+	(*ActionCreatePreparedStatementResult)(nil),                            // 14: arrow.flight.protocol.sql.ActionCreatePreparedStatementResult
+	(*ActionClosePreparedStatementRequest)(nil),                            // 15: arrow.flight.protocol.sql.ActionClosePreparedStatementRequest
+	(*ActionBeginTransactionRequest)(nil),                                  // 16: arrow.flight.protocol.sql.ActionBeginTransactionRequest
+	(*ActionBeginTransactionResult)(nil),                                   // 17: arrow.flight.protocol.sql.ActionBeginTransactionResult
+	(*ActionEndTransactionRequest)(nil),                                    // 18: arrow.flight.protocol.sql.ActionEndTransactionRequest
+	(*ActionBeginSavepointRequest)(nil),                                    // 19: arrow.flight.protocol.sql.ActionBeginSavepointRequest
+	(*ActionBeginSavepointResult)(nil),                                     // 20: arrow.flight.protocol.sql.ActionBeginSavepointResult
+	(*ActionEndSavepointRequest)(nil),                                      // 21: arrow.flight.protocol.sql.ActionEndSavepointRequest
+	(*CommandStatementIngest)(nil),                                         // 22: arrow.flight.protocol.sql.CommandStatementIngest
+	(*CommandGetCatalogs)(nil),                                             // 23: arrow.flight.protocol.sql.CommandGetCatalogs
+	(*CommandGetDbSchemas)(nil),                                            // 24: arrow.flight.protocol.sql.CommandGetDbSchemas
+	(*CommandGetTables)(nil),                                               // 25: arrow.flight.protocol.sql.CommandGetTables
+	(*CommandGetPrimaryKeys)(nil),                                          // 26: arrow.flight.protocol.sql.CommandGetPrimaryKeys
+	(*SessionOptionValue)(nil),                                             // 27: arrow.flight.protocol.sql.SessionOptionValue
+	(*SetSessionOptionsRequest)(nil),                                       // 28: arrow.flight.protocol.sql.SetSessionOptionsRequest
+	(*SetSessionOptionsResult)(nil),                                        // 29: arrow.flight.protocol.sql.SetSessionOptionsResult
+	(*GetSessionOptionsRequest)(nil),                                       // 30: arrow.flight.protocol.sql.GetSessionOptionsRequest
+	(*GetSessionOptionsResult)(nil),                                        // 31: arrow.flight.protocol.sql.GetSessionOptionsResult
+	(*CloseSessionRequest)(nil),                                            // 32: arrow.flight.protocol.sql.CloseSessionRequest
+	(*CloseSessionResult)(nil),                                             // 33: arrow.flight.protocol.sql.CloseSessionResult
+	(*CommandStatementIngest_TableDefinitionOptions)(nil),                  // 34: arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions
+	nil, // 35: arrow.flight.protocol.sql.CommandStatementIngest.OptionsEntry
+	(*SessionOptionValue_StringListValue)(nil), // 36: arrow.flight.protocol.sql.SessionOptionValue.StringListValue
+	nil,                                   // 37: arrow.flight.protocol.sql.SetSessionOptionsRequest.SessionOptionsEntry
+	(*SetSessionOptionsResult_Error)(nil), // 38: arrow.flight.protocol.sql.SetSessionOptionsResult.Error
+	nil,                                   // 39: arrow.flight.protocol.sql.SetSessionOptionsResult.ErrorsEntry
+	nil,                                   // 40: arrow.flight.protocol.sql.GetSessionOptionsResult.SessionOptionsEntry
+}
+var file_FlightSql_proto_depIdxs = []int32{
+	0,  // 0: arrow.flight.protocol.sql.ActionEndTransactionRequest.action:type_name -> arrow.flight.protocol.sql.ActionEndTransactionRequest.EndTransaction
+	1,  // 1: arrow.flight.protocol.sql.ActionEndSavepointRequest.action:type_name -> arrow.flight.protocol.sql.ActionEndSavepointRequest.EndSavepoint
+	34, // 2: arrow.flight.protocol.sql.CommandStatementIngest.table_definition_options:type_name -> arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions
+	35, // 3: arrow.flight.protocol.sql.CommandStatementIngest.options:type_name -> arrow.flight.protocol.sql.CommandStatementIngest.OptionsEntry
+	36, // 4: arrow.flight.protocol.sql.SessionOptionValue.string_list_value:type_name -> arrow.flight.protocol.sql.SessionOptionValue.StringListValue
+	37, // 5: arrow.flight.protocol.sql.SetSessionOptionsRequest.session_options:type_name -> arrow.flight.protocol.sql.SetSessionOptionsRequest.SessionOptionsEntry
+	39, // 6: arrow.flight.protocol.sql.SetSessionOptionsResult.errors:type_name -> arrow.flight.protocol.sql.SetSessionOptionsResult.ErrorsEntry
+	40, // 7: arrow.flight.protocol.sql.GetSessionOptionsResult.session_options:type_name -> arrow.flight.protocol.sql.GetSessionOptionsResult.SessionOptionsEntry
+	5,  // 8: arrow.flight.protocol.sql.CloseSessionResult.status:type_name -> arrow.flight.protocol.sql.CloseSessionResult.Status
+	2,  // 9: arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions.if_not_exist:type_name -> arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions.TableNotExistOption
+	3,  // 10: arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions.if_exists:type_name -> arrow.flight.protocol.sql.CommandStatementIngest.TableDefinitionOptions.TableExistsOption
+	27, // 11: arrow.flight.protocol.sql.SetSessionOptionsRequest.SessionOptionsEntry.value:type_name -> arrow.flight.protocol.sql.SessionOptionValue
+	4,  // 12: arrow.flight.protocol.sql.SetSessionOptionsResult.Error.value:type_name -> arrow.flight.protocol.sql.SetSessionOptionsResult.ErrorValue
+	38, // 13: arrow.flight.protocol.sql.SetSessionOptionsResult.ErrorsEntry.value:type_name -> arrow.flight.protocol.sql.SetSessionOptionsResult.Error
+	27, // 14: arrow.flight.protocol.sql.GetSessionOptionsResult.SessionOptionsEntry.value:type_name -> arrow.flight.protocol.sql.SessionOptionValue
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_FlightSql_proto_init() }
+func file_FlightSql_proto_init() {
+	if File_FlightSql_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_FlightSql_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandStatementUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandPreparedStatementQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandPreparedStatementUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DoPutUpdateResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DoPutPreparedStatementResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionCreatePreparedStatementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionCreatePreparedStatementResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionClosePreparedStatementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionBeginTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionBeginTransactionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionEndTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionBeginSavepointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionBeginSavepointResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionEndSavepointRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandStatementIngest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetCatalogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetDbSchemas); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetTables); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandGetPrimaryKeys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionOptionValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSessionOptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSessionOptionsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionOptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionOptionsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseSessionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandStatementIngest_TableDefinitionOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionOptionValue_StringListValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_FlightSql_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSessionOptionsResult_Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_FlightSql_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_FlightSql_proto_msgTypes[19].OneofWrappers = []interface{}{}
+	file_FlightSql_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_FlightSql_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*SessionOptionValue_StringValue)(nil),
+		(*SessionOptionValue_BoolValue)(nil),
+		(*SessionOptionValue_Int64Value)(nil),
+		(*SessionOptionValue_DoubleValue)(nil),
+		(*SessionOptionValue_StringListValue_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_FlightSql_proto_rawDesc,
+			NumEnums:      6,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_FlightSql_proto_goTypes,
+		DependencyIndexes: file_FlightSql_proto_depIdxs,
+		EnumInfos:         file_FlightSql_proto_enumTypes,
+		MessageInfos:      file_FlightSql_proto_msgTypes,
+	}.Build()
+	File_FlightSql_proto = out.File
+	file_FlightSql_proto_rawDesc = nil
+	file_FlightSql_proto_goTypes = nil
+	file_FlightSql_proto_depIdxs = nil
+}
@@ -0,0 +1,329 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+// The schemas of the results of the catalog metadata commands.
+var (
+	// CatalogsSchema is the schema of the results of CommandGetCatalogs.
+	CatalogsSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	// DBSchemasSchema is the schema of the results of CommandGetDbSchemas.
+	DBSchemasSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "db_schema_name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	// TablesSchema is the schema of the results of CommandGetTables.
+	TablesSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "db_schema_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "table_name", Type: arrow.BinaryTypes.String},
+		{Name: "table_type", Type: arrow.BinaryTypes.String},
+	}, nil)
+	// TablesSchemaWithIncludedSchema is the schema of the results of
+	// CommandGetTables including the schemas of the tables.
+	TablesSchemaWithIncludedSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "db_schema_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "table_name", Type: arrow.BinaryTypes.String},
+		{Name: "table_type", Type: arrow.BinaryTypes.String},
+		{Name: "table_schema", Type: arrow.BinaryTypes.Binary},
+	}, nil)
+	// PrimaryKeysSchema is the schema of the results of
+	// CommandGetPrimaryKeys.
+	PrimaryKeysSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "catalog_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "db_schema_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "table_name", Type: arrow.BinaryTypes.String},
+		{Name: "column_name", Type: arrow.BinaryTypes.String},
+		{Name: "key_name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "key_sequence", Type: arrow.PrimitiveTypes.Int32},
+	}, nil)
+)
+
+// The values of the nullable columns of the catalog metadata are empty
+// strings for nulls.
+
+// DBSchema is a database schema, a row of the results of
+// CommandGetDbSchemas.
+type DBSchema struct {
+	Catalog string
+	Name    string
+}
+
+// Table is a table, a row of the results of CommandGetTables.
+type Table struct {
+	Catalog  string
+	DBSchema string
+	Name     string
+	Type     string
+	// Schema is the schema of the table, only sent when the command
+	// includes the schemas.
+	Schema *arrow.Schema
+}
+
+// PrimaryKey is a column of the primary key of a table, a row of the
+// results of CommandGetPrimaryKeys.
+type PrimaryKey struct {
+	Catalog  string
+	DBSchema string
+	Table    string
+	Column   string
+	KeyName  string
+	// KeySequence is the position of the column in the key, from 1.
+	KeySequence int32
+}
+
+func appendNullableString(b *array.StringBuilder, v string) {
+	if v == "" {
+		b.AppendNull()
+		return
+	}
+	b.Append(v)
+}
+
+func nullableString(col *array.String, i int) string {
+	if col.IsNull(i) {
+		return ""
+	}
+	return col.Value(i)
+}
+
+// NewCatalogsRecord returns the record of the catalogs, with the schema
+// CatalogsSchema.
+func NewCatalogsRecord(mem memory.Allocator, catalogs []string) array.Record {
+	bldr := array.NewRecordBuilder(mem, CatalogsSchema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.StringBuilder).AppendValues(catalogs, nil)
+	return bldr.NewRecord()
+}
+
+// NewDBSchemasRecord returns the record of the database schemas, with the
+// schema DBSchemasSchema.
+func NewDBSchemasRecord(mem memory.Allocator, schemas []DBSchema) array.Record {
+	bldr := array.NewRecordBuilder(mem, DBSchemasSchema)
+	defer bldr.Release()
+	var (
+		catalogs = bldr.Field(0).(*array.StringBuilder)
+		names    = bldr.Field(1).(*array.StringBuilder)
+	)
+	for _, s := range schemas {
+		appendNullableString(catalogs, s.Catalog)
+		names.Append(s.Name)
+	}
+	return bldr.NewRecord()
+}
+
+// NewTablesRecord returns the record of the tables, with the schema
+// TablesSchemaWithIncludedSchema when includeSchema is set, TablesSchema
+// otherwise. Tables without schema get an empty one.
+func NewTablesRecord(mem memory.Allocator, tables []Table, includeSchema bool) array.Record {
+	schema := TablesSchema
+	if includeSchema {
+		schema = TablesSchemaWithIncludedSchema
+	}
+	bldr := array.NewRecordBuilder(mem, schema)
+	defer bldr.Release()
+	var (
+		catalogs  = bldr.Field(0).(*array.StringBuilder)
+		dbSchemas = bldr.Field(1).(*array.StringBuilder)
+		names     = bldr.Field(2).(*array.StringBuilder)
+		types     = bldr.Field(3).(*array.StringBuilder)
+	)
+	for _, t := range tables {
+		appendNullableString(catalogs, t.Catalog)
+		appendNullableString(dbSchemas, t.DBSchema)
+		names.Append(t.Name)
+		types.Append(t.Type)
+		if includeSchema {
+			s := t.Schema
+			if s == nil {
+				s = arrow.NewSchema(nil, nil)
+			}
+			bldr.Field(4).(*array.BinaryBuilder).Append(flight.SerializeSchema(s, mem))
+		}
+	}
+	return bldr.NewRecord()
+}
+
+// NewPrimaryKeysRecord returns the record of the primary key columns, with
+// the schema PrimaryKeysSchema.
+func NewPrimaryKeysRecord(mem memory.Allocator, keys []PrimaryKey) array.Record {
+	bldr := array.NewRecordBuilder(mem, PrimaryKeysSchema)
+	defer bldr.Release()
+	var (
+		catalogs  = bldr.Field(0).(*array.StringBuilder)
+		dbSchemas = bldr.Field(1).(*array.StringBuilder)
+		tables    = bldr.Field(2).(*array.StringBuilder)
+		columns   = bldr.Field(3).(*array.StringBuilder)
+		keyNames  = bldr.Field(4).(*array.StringBuilder)
+		sequences = bldr.Field(5).(*array.Int32Builder)
+	)
+	for _, k := range keys {
+		appendNullableString(catalogs, k.Catalog)
+		appendNullableString(dbSchemas, k.DBSchema)
+		tables.Append(k.Table)
+		columns.Append(k.Column)
+		appendNullableString(keyNames, k.KeyName)
+		sequences.Append(k.KeySequence)
+	}
+	return bldr.NewRecord()
+}
+
+// GetCatalogs returns the catalogs of the server.
+func (c *Client) GetCatalogs(ctx context.Context, opts ...grpc.CallOption) ([]string, error) {
+	var catalogs []string
+	err := c.fetch(ctx, &CommandGetCatalogs{}, CatalogsSchema, func(rec array.Record) error {
+		col := rec.Column(0).(*array.String)
+		for i := 0; i < col.Len(); i++ {
+			catalogs = append(catalogs, col.Value(i))
+		}
+		return nil
+	}, opts...)
+	return catalogs, err
+}
+
+// GetDBSchemas returns the database schemas of the server matching the
+// filters of cmd.
+func (c *Client) GetDBSchemas(ctx context.Context, cmd *CommandGetDbSchemas, opts ...grpc.CallOption) ([]DBSchema, error) {
+	var schemas []DBSchema
+	err := c.fetch(ctx, cmd, DBSchemasSchema, func(rec array.Record) error {
+		var (
+			catalogs = rec.Column(0).(*array.String)
+			names    = rec.Column(1).(*array.String)
+		)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			schemas = append(schemas, DBSchema{
+				Catalog: nullableString(catalogs, i),
+				Name:    names.Value(i),
+			})
+		}
+		return nil
+	}, opts...)
+	return schemas, err
+}
+
+// GetTables returns the tables of the server matching the filters of cmd,
+// along with their schemas when cmd includes them.
+func (c *Client) GetTables(ctx context.Context, cmd *CommandGetTables, opts ...grpc.CallOption) ([]Table, error) {
+	schema := TablesSchema
+	if cmd.GetIncludeSchema() {
+		schema = TablesSchemaWithIncludedSchema
+	}
+
+	var tables []Table
+	err := c.fetch(ctx, cmd, schema, func(rec array.Record) error {
+		var (
+			catalogs  = rec.Column(0).(*array.String)
+			dbSchemas = rec.Column(1).(*array.String)
+			names     = rec.Column(2).(*array.String)
+			types     = rec.Column(3).(*array.String)
+		)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			t := Table{
+				Catalog:  nullableString(catalogs, i),
+				DBSchema: nullableString(dbSchemas, i),
+				Name:     names.Value(i),
+				Type:     types.Value(i),
+			}
+			if cmd.GetIncludeSchema() {
+				s, err := flight.DeserializeSchema(rec.Column(4).(*array.Binary).Value(i), c.alloc())
+				if err != nil {
+					return xerrors.Errorf("flightsql: could not decode schema of table %q: %w", t.Name, err)
+				}
+				t.Schema = s
+			}
+			tables = append(tables, t)
+		}
+		return nil
+	}, opts...)
+	return tables, err
+}
+
+// GetPrimaryKeys returns the columns of the primary key of the table of
+// cmd.
+func (c *Client) GetPrimaryKeys(ctx context.Context, cmd *CommandGetPrimaryKeys, opts ...grpc.CallOption) ([]PrimaryKey, error) {
+	var keys []PrimaryKey
+	err := c.fetch(ctx, cmd, PrimaryKeysSchema, func(rec array.Record) error {
+		var (
+			catalogs  = rec.Column(0).(*array.String)
+			dbSchemas = rec.Column(1).(*array.String)
+			tables    = rec.Column(2).(*array.String)
+			columns   = rec.Column(3).(*array.String)
+			keyNames  = rec.Column(4).(*array.String)
+			sequences = rec.Column(5).(*array.Int32)
+		)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			keys = append(keys, PrimaryKey{
+				Catalog:     nullableString(catalogs, i),
+				DBSchema:    nullableString(dbSchemas, i),
+				Table:       tables.Value(i),
+				Column:      columns.Value(i),
+				KeyName:     nullableString(keyNames, i),
+				KeySequence: sequences.Value(i),
+			})
+		}
+		return nil
+	}, opts...)
+	return keys, err
+}
+
+// fetch executes the metadata command cmd, calling fn with each record of
+// its results, which must have the given schema.
+func (c *Client) fetch(ctx context.Context, cmd proto.Message, schema *arrow.Schema, fn func(array.Record) error, opts ...grpc.CallOption) error {
+	info, err := c.getFlightInfo(ctx, cmd, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, ep := range info.GetEndpoint() {
+		if err := c.fetchEndpoint(ctx, ep, schema, fn, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) fetchEndpoint(ctx context.Context, ep *flight.FlightEndpoint, schema *arrow.Schema, fn func(array.Record) error, opts ...grpc.CallOption) error {
+	rdr, err := c.DoGet(ctx, ep.GetTicket(), opts...)
+	if err != nil {
+		return err
+	}
+	defer rdr.Release()
+
+	if !rdr.Schema().Equal(schema) {
+		return xerrors.Errorf("flightsql: unexpected schema of results: got=%v, want=%v", rdr.Schema(), schema)
+	}
+	for rdr.Next() {
+		if err := fn(rdr.Record()); err != nil {
+			return err
+		}
+	}
+	return rdr.Err()
+}
@@ -0,0 +1,313 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var stubTables = []flightsql.Table{
+	{Catalog: "main", DBSchema: "public", Name: "users", Type: "TABLE", Schema: paramsSchema},
+	{Catalog: "main", DBSchema: "public", Name: "user_names", Type: "VIEW", Schema: namesSchema},
+	{Catalog: "main", DBSchema: "audit", Name: "logins", Type: "TABLE"},
+	{DBSchema: "temp", Name: "scratch", Type: "TABLE"},
+}
+
+// like reports whether s matches the SQL LIKE pattern, when there is one.
+func like(pattern *string, s string) bool {
+	if pattern == nil {
+		return true
+	}
+	expr := regexp.QuoteMeta(*pattern)
+	expr = strings.NewReplacer("%", ".*", "_", ".").Replace(expr)
+	return regexp.MustCompile("^" + expr + "$").MatchString(s)
+}
+
+func matches(want *string, s string) bool { return want == nil || *want == s }
+
+func (s *sqlStub) GetCatalogs(ctx context.Context, cmd *flightsql.CommandGetCatalogs) ([]string, error) {
+	return []string{"main"}, nil
+}
+
+func (s *sqlStub) GetDBSchemas(ctx context.Context, cmd *flightsql.CommandGetDbSchemas) ([]flightsql.DBSchema, error) {
+	var schemas []flightsql.DBSchema
+	seen := make(map[flightsql.DBSchema]bool)
+	for _, t := range stubTables {
+		sc := flightsql.DBSchema{Catalog: t.Catalog, Name: t.DBSchema}
+		if seen[sc] || !matches(cmd.Catalog, t.Catalog) || !like(cmd.DbSchemaFilterPattern, t.DBSchema) {
+			continue
+		}
+		seen[sc] = true
+		schemas = append(schemas, sc)
+	}
+	return schemas, nil
+}
+
+func (s *sqlStub) GetTables(ctx context.Context, cmd *flightsql.CommandGetTables) ([]flightsql.Table, error) {
+	var tables []flightsql.Table
+	for _, t := range stubTables {
+		if !matches(cmd.Catalog, t.Catalog) || !like(cmd.DbSchemaFilterPattern, t.DBSchema) || !like(cmd.TableNameFilterPattern, t.Name) {
+			continue
+		}
+		if len(cmd.GetTableTypes()) > 0 && !containsString(cmd.GetTableTypes(), t.Type) {
+			continue
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sqlStub) GetPrimaryKeys(ctx context.Context, cmd *flightsql.CommandGetPrimaryKeys) ([]flightsql.PrimaryKey, error) {
+	if cmd.GetTable() != "users" {
+		return nil, status.Errorf(codes.NotFound, "no table %q", cmd.GetTable())
+	}
+	return []flightsql.PrimaryKey{
+		{Catalog: "main", DBSchema: "public", Table: "users", Column: "id", KeyName: "users_pk", KeySequence: 1},
+	}, nil
+}
+
+// specField is a field of the result schemas as given by the
+// specification.
+type specField struct {
+	name     string
+	typ      arrow.DataType
+	nullable bool
+}
+
+func TestCatalogSchemas(t *testing.T) {
+	utf8, binary := arrow.BinaryTypes.String, arrow.BinaryTypes.Binary
+	for _, tc := range []struct {
+		name   string
+		schema *arrow.Schema
+		rec    array.Record
+		want   []specField
+	}{
+		{
+			name:   "catalogs",
+			schema: flightsql.CatalogsSchema,
+			rec:    flightsql.NewCatalogsRecord(memory.DefaultAllocator, []string{"main"}),
+			want:   []specField{{"catalog_name", utf8, false}},
+		},
+		{
+			name:   "db_schemas",
+			schema: flightsql.DBSchemasSchema,
+			rec:    flightsql.NewDBSchemasRecord(memory.DefaultAllocator, []flightsql.DBSchema{{Name: "public"}}),
+			want:   []specField{{"catalog_name", utf8, true}, {"db_schema_name", utf8, false}},
+		},
+		{
+			name:   "tables",
+			schema: flightsql.TablesSchema,
+			rec:    flightsql.NewTablesRecord(memory.DefaultAllocator, stubTables, false),
+			want: []specField{
+				{"catalog_name", utf8, true}, {"db_schema_name", utf8, true},
+				{"table_name", utf8, false}, {"table_type", utf8, false},
+			},
+		},
+		{
+			name:   "tables_with_schema",
+			schema: flightsql.TablesSchemaWithIncludedSchema,
+			rec:    flightsql.NewTablesRecord(memory.DefaultAllocator, stubTables, true),
+			want: []specField{
+				{"catalog_name", utf8, true}, {"db_schema_name", utf8, true},
+				{"table_name", utf8, false}, {"table_type", utf8, false},
+				{"table_schema", binary, false},
+			},
+		},
+		{
+			name:   "primary_keys",
+			schema: flightsql.PrimaryKeysSchema,
+			rec:    flightsql.NewPrimaryKeysRecord(memory.DefaultAllocator, []flightsql.PrimaryKey{{Table: "users", Column: "id", KeySequence: 1}}),
+			want: []specField{
+				{"catalog_name", utf8, true}, {"db_schema_name", utf8, true},
+				{"table_name", utf8, false}, {"column_name", utf8, false},
+				{"key_name", utf8, true}, {"key_sequence", arrow.PrimitiveTypes.Int32, false},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.rec.Release()
+			if !tc.rec.Schema().Equal(tc.schema) {
+				t.Fatalf("invalid record schema: %v", tc.rec.Schema())
+			}
+			fields := tc.schema.Fields()
+			if len(fields) != len(tc.want) {
+				t.Fatalf("invalid number of fields: got=%d, want=%d", len(fields), len(tc.want))
+			}
+			for i, f := range fields {
+				want := tc.want[i]
+				if f.Name != want.name || !arrow.TypeEqual(f.Type, want.typ) || f.Nullable != want.nullable {
+					t.Errorf("field %d: got=%v, want=%s: type=%v, nullable=%v", i, f, want.name, want.typ, want.nullable)
+				}
+			}
+		})
+	}
+}
+
+func TestGetCatalogs(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	catalogs, err := client.GetCatalogs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(catalogs); got != "[main]" {
+		t.Fatalf("invalid catalogs: %s", got)
+	}
+}
+
+func TestGetDBSchemas(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		cmd  *flightsql.CommandGetDbSchemas
+		want string
+	}{
+		{cmd: &flightsql.CommandGetDbSchemas{}, want: "[{main public} {main audit} { temp}]"},
+		{cmd: &flightsql.CommandGetDbSchemas{Catalog: proto.String("main")}, want: "[{main public} {main audit}]"},
+		{cmd: &flightsql.CommandGetDbSchemas{Catalog: proto.String("")}, want: "[{ temp}]"},
+		{cmd: &flightsql.CommandGetDbSchemas{DbSchemaFilterPattern: proto.String("%u%")}, want: "[{main public} {main audit}]"},
+	} {
+		schemas, err := client.GetDBSchemas(ctx, tc.cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(schemas); got != tc.want {
+			t.Errorf("invalid schemas for %v: got=%s, want=%s", tc.cmd, got, tc.want)
+		}
+	}
+}
+
+func TestGetTables(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		cmd  *flightsql.CommandGetTables
+		want []string
+	}{
+		{cmd: &flightsql.CommandGetTables{}, want: []string{"users", "user_names", "logins", "scratch"}},
+		{cmd: &flightsql.CommandGetTables{TableNameFilterPattern: proto.String("user%")}, want: []string{"users", "user_names"}},
+		{cmd: &flightsql.CommandGetTables{TableNameFilterPattern: proto.String("user_")}, want: []string{"users"}},
+		{cmd: &flightsql.CommandGetTables{DbSchemaFilterPattern: proto.String("audit")}, want: []string{"logins"}},
+		{cmd: &flightsql.CommandGetTables{TableTypes: []string{"VIEW"}}, want: []string{"user_names"}},
+		{cmd: &flightsql.CommandGetTables{Catalog: proto.String(""), TableTypes: []string{"TABLE"}}, want: []string{"scratch"}},
+	} {
+		tables, err := client.GetTables(ctx, tc.cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, tbl := range tables {
+			if tbl.Schema != nil {
+				t.Fatalf("unexpected schema of %s: %v", tbl.Name, tbl.Schema)
+			}
+			names = append(names, tbl.Name)
+		}
+		if got, want := fmt.Sprint(names), fmt.Sprint(tc.want); got != want {
+			t.Errorf("invalid tables for %v: got=%s, want=%s", tc.cmd, got, want)
+		}
+	}
+
+	tables, err := client.GetTables(ctx, &flightsql.CommandGetTables{IncludeSchema: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != len(stubTables) {
+		t.Fatalf("invalid tables: %v", tables)
+	}
+	for i, tbl := range tables {
+		want := stubTables[i]
+		if want.Schema == nil {
+			want.Schema = arrow.NewSchema(nil, nil)
+		}
+		if tbl.Catalog != want.Catalog || tbl.DBSchema != want.DBSchema || tbl.Name != want.Name || tbl.Type != want.Type {
+			t.Errorf("invalid table: got=%+v, want=%+v", tbl, want)
+		}
+		if !tbl.Schema.Equal(want.Schema) {
+			t.Errorf("invalid schema of %s: got=%v, want=%v", tbl.Name, tbl.Schema, want.Schema)
+		}
+	}
+}
+
+func TestGetPrimaryKeys(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	keys, err := client.GetPrimaryKeys(ctx, &flightsql.CommandGetPrimaryKeys{Table: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := flightsql.PrimaryKey{Catalog: "main", DBSchema: "public", Table: "users", Column: "id", KeyName: "users_pk", KeySequence: 1}
+	if len(keys) != 1 || keys[0] != want {
+		t.Fatalf("invalid keys: %+v", keys)
+	}
+
+	// the error of the server is only received while reading the results.
+	if _, err := client.GetPrimaryKeys(ctx, &flightsql.CommandGetPrimaryKeys{Table: "unknown"}); !xerrors.Is(err, flight.ErrNotFound) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCatalogFlightInfo(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	// the FlightInfo of the commands carries the schema of their results.
+	desc, err := flightsql.NewDescriptor(&flightsql.CommandGetTables{IncludeSchema: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := client.Client.GetFlightInfo(context.Background(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := flight.DeserializeSchema(info.GetSchema(), memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !schema.Equal(flightsql.TablesSchemaWithIncludedSchema) {
+		t.Fatalf("invalid schema: %v", schema)
+	}
+}
@@ -0,0 +1,364 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+// Client is a Flight SQL client, sending the Flight SQL commands over a
+// flight client.
+type Client struct {
+	Client flight.Client
+	// Alloc allocates the record batches read, it defaults to
+	// memory.DefaultAllocator.
+	Alloc memory.Allocator
+}
+
+// NewClient returns a Flight SQL client connected to addr, see
+// flight.NewFlightClient.
+func NewClient(addr string, auth flight.ClientAuthHandler, opts ...grpc.DialOption) (*Client, error) {
+	client, err := flight.NewFlightClient(addr, auth, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Client: client}, nil
+}
+
+// Close closes the connection of the underlying flight client.
+func (c *Client) Close() error {
+	return c.Client.Close()
+}
+
+func (c *Client) alloc() memory.Allocator {
+	if c.Alloc == nil {
+		return memory.DefaultAllocator
+	}
+	return c.Alloc
+}
+
+// Execute executes the query, returning the FlightInfo whose endpoints
+// give its results, read with DoGet.
+func (c *Client) Execute(ctx context.Context, query string, opts ...grpc.CallOption) (*flight.FlightInfo, error) {
+	return c.execute(ctx, query, nil, opts...)
+}
+
+// ExecuteUpdate executes the update query, returning the number of
+// records it updated, or -1 when the server does not know it.
+func (c *Client) ExecuteUpdate(ctx context.Context, query string, opts ...grpc.CallOption) (int64, error) {
+	return c.executeUpdate(ctx, query, nil, opts...)
+}
+
+// DoGet returns a reader of the record batches of ticket, from an endpoint
+// of the FlightInfo of an execution.
+func (c *Client) DoGet(ctx context.Context, ticket *flight.Ticket, opts ...grpc.CallOption) (*ipc.Reader, error) {
+	stream, err := c.Client.DoGet(ctx, ticket, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return flight.NewRecordReader(stream, ipc.WithAllocator(c.alloc()))
+}
+
+// Prepare creates a prepared statement of the query on the server. The
+// statement must be closed once done with.
+func (c *Client) Prepare(ctx context.Context, query string, opts ...grpc.CallOption) (*PreparedStatement, error) {
+	return c.prepare(ctx, query, nil, opts...)
+}
+
+func (c *Client) execute(ctx context.Context, query string, txn []byte, opts ...grpc.CallOption) (*flight.FlightInfo, error) {
+	return c.getFlightInfo(ctx, &CommandStatementQuery{Query: query, TransactionId: txn}, opts...)
+}
+
+func (c *Client) executeUpdate(ctx context.Context, query string, txn []byte, opts ...grpc.CallOption) (int64, error) {
+	return c.doPutUpdate(ctx, &CommandStatementUpdate{Query: query, TransactionId: txn}, nil, opts...)
+}
+
+func (c *Client) prepare(ctx context.Context, query string, txn []byte, opts ...grpc.CallOption) (*PreparedStatement, error) {
+	var result ActionCreatePreparedStatementResult
+	request := &ActionCreatePreparedStatementRequest{Query: query, TransactionId: txn}
+	if err := c.doAction(ctx, CreatePreparedStatementActionType, request, &result, opts...); err != nil {
+		return nil, err
+	}
+
+	stmt := &PreparedStatement{client: c, handle: result.PreparedStatementHandle}
+	var err error
+	if len(result.DatasetSchema) > 0 {
+		if stmt.datasetSchema, err = flight.DeserializeSchema(result.DatasetSchema, c.alloc()); err != nil {
+			return nil, xerrors.Errorf("flightsql: could not decode dataset schema: %w", err)
+		}
+	}
+	if len(result.ParameterSchema) > 0 {
+		if stmt.paramSchema, err = flight.DeserializeSchema(result.ParameterSchema, c.alloc()); err != nil {
+			return nil, xerrors.Errorf("flightsql: could not decode parameter schema: %w", err)
+		}
+	}
+	return stmt, nil
+}
+
+func (c *Client) getFlightInfo(ctx context.Context, cmd proto.Message, opts ...grpc.CallOption) (*flight.FlightInfo, error) {
+	desc, err := NewDescriptor(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.GetFlightInfo(ctx, desc, opts...)
+}
+
+// ExecuteIngest loads the records of rdr into the table of cmd, returning
+// the number of rows ingested, or -1 when the server does not know it. The
+// records are streamed to the server as they are read from rdr.
+func (c *Client) ExecuteIngest(ctx context.Context, rdr array.RecordReader, cmd *CommandStatementIngest, opts ...grpc.CallOption) (int64, error) {
+	return c.doPutUpdate(ctx, cmd, rdr, opts...)
+}
+
+// doPut sends cmd along with the records of rdr, which may be nil, and
+// returns the application metadata of the first result of the server.
+func (c *Client) doPut(ctx context.Context, cmd proto.Message, rdr array.RecordReader, opts ...grpc.CallOption) ([]byte, error) {
+	desc, err := NewDescriptor(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.Client.DoPut(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rdr != nil {
+		w := flight.NewWriter(stream, ipc.WithAllocator(c.alloc()))
+		w.SetFlightDescriptor(desc)
+		// a server failing before reading all the records ends the
+		// stream, its error is then the one received below.
+		if err := c.writeRecords(w, rdr); err != nil && !xerrors.Is(err, io.EOF) {
+			return nil, err
+		}
+	} else {
+		if err := stream.Send(&flight.FlightData{FlightDescriptor: desc}); err != nil {
+			return nil, err
+		}
+		if err := stream.CloseSend(); err != nil {
+			return nil, err
+		}
+	}
+
+	var md []byte
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return md, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if md == nil {
+			md = res.GetAppMetadata()
+		}
+	}
+}
+
+// writeRecords writes the records of rdr to w and closes it. An empty
+// record is written when rdr has none, for the schema to be sent.
+func (c *Client) writeRecords(w *flight.Writer, rdr array.RecordReader) error {
+	n := 0
+	for rdr.Next() {
+		if err := w.Write(rdr.Record()); err != nil {
+			return err
+		}
+		n++
+	}
+	if n == 0 {
+		bldr := array.NewRecordBuilder(c.alloc(), rdr.Schema())
+		defer bldr.Release()
+		rec := bldr.NewRecord()
+		defer rec.Release()
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func (c *Client) doPutUpdate(ctx context.Context, cmd proto.Message, rdr array.RecordReader, opts ...grpc.CallOption) (int64, error) {
+	md, err := c.doPut(ctx, cmd, rdr, opts...)
+	if err != nil {
+		return 0, err
+	}
+	var result DoPutUpdateResult
+	if err := proto.Unmarshal(md, &result); err != nil {
+		return 0, xerrors.Errorf("flightsql: could not decode update result: %w", err)
+	}
+	return result.RecordCount, nil
+}
+
+// doAction runs the action typ with request as its body, decoding its
+// first result into result, unless result is nil for actions without
+// results.
+func (c *Client) doAction(ctx context.Context, typ string, request, result proto.Message, opts ...grpc.CallOption) error {
+	body, err := packCommand(request)
+	if err != nil {
+		return err
+	}
+
+	stream, err := c.Client.DoAction(ctx, &flight.Action{Type: typ, Body: body}, opts...)
+	if err != nil {
+		return err
+	}
+
+	decoded := result == nil
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !decoded {
+			if err := unpackMessage(res.GetBody(), result); err != nil {
+				return err
+			}
+			decoded = true
+		}
+	}
+	if !decoded {
+		return xerrors.Errorf("flightsql: no result for action %s", typ)
+	}
+	return nil
+}
+
+var errStatementClosed = xerrors.New("flightsql: prepared statement is closed")
+
+// PreparedStatement is a prepared statement on a Flight SQL server,
+// created with Client.Prepare. It is not safe for concurrent use.
+type PreparedStatement struct {
+	client *Client
+	handle []byte
+
+	datasetSchema *arrow.Schema
+	paramSchema   *arrow.Schema
+
+	params array.Record
+	// bound reports whether params were bound on the server.
+	bound  bool
+	closed bool
+}
+
+// Handle returns the opaque handle of the statement on the server, which
+// the server may replace while binding parameters.
+func (p *PreparedStatement) Handle() []byte { return p.handle }
+
+// DatasetSchema returns the schema of the results of the statement, or nil
+// when the server did not provide it.
+func (p *PreparedStatement) DatasetSchema() *arrow.Schema { return p.datasetSchema }
+
+// ParameterSchema returns the schema of the parameters of the statement,
+// or nil when it takes no parameter.
+func (p *PreparedStatement) ParameterSchema() *arrow.Schema { return p.paramSchema }
+
+// SetParameters sets the parameters of the next executions of the
+// statement, one execution per row of rec. They are sent to the server on
+// the next execution, and replace the parameters set before.
+func (p *PreparedStatement) SetParameters(rec array.Record) {
+	if p.params != nil {
+		p.params.Release()
+	}
+	p.params = rec
+	if rec != nil {
+		rec.Retain()
+	}
+	p.bound = false
+}
+
+// Execute executes the statement with its parameters, returning the
+// FlightInfo whose endpoints give its results, read with Client.DoGet.
+func (p *PreparedStatement) Execute(ctx context.Context, opts ...grpc.CallOption) (*flight.FlightInfo, error) {
+	if p.closed {
+		return nil, errStatementClosed
+	}
+
+	if p.params != nil && !p.bound {
+		rdr, err := p.paramReader()
+		if err != nil {
+			return nil, err
+		}
+		md, err := p.client.doPut(ctx, &CommandPreparedStatementQuery{PreparedStatementHandle: p.handle}, rdr, opts...)
+		rdr.Release()
+		if err != nil {
+			return nil, err
+		}
+		var result DoPutPreparedStatementResult
+		if err := proto.Unmarshal(md, &result); err != nil {
+			return nil, xerrors.Errorf("flightsql: could not decode bind result: %w", err)
+		}
+		if len(result.PreparedStatementHandle) > 0 {
+			p.handle = result.PreparedStatementHandle
+		}
+		p.bound = true
+	}
+
+	return p.client.getFlightInfo(ctx, &CommandPreparedStatementQuery{PreparedStatementHandle: p.handle}, opts...)
+}
+
+// ExecuteUpdate executes the update statement with its parameters,
+// returning the number of records it updated, or -1 when the server does
+// not know it.
+func (p *PreparedStatement) ExecuteUpdate(ctx context.Context, opts ...grpc.CallOption) (int64, error) {
+	if p.closed {
+		return 0, errStatementClosed
+	}
+	if p.params == nil {
+		return p.client.doPutUpdate(ctx, &CommandPreparedStatementUpdate{PreparedStatementHandle: p.handle}, nil, opts...)
+	}
+
+	rdr, err := p.paramReader()
+	if err != nil {
+		return 0, err
+	}
+	defer rdr.Release()
+	return p.client.doPutUpdate(ctx, &CommandPreparedStatementUpdate{PreparedStatementHandle: p.handle}, rdr, opts...)
+}
+
+// paramReader returns a reader of the parameters of the statement, which
+// must be set.
+func (p *PreparedStatement) paramReader() (array.RecordReader, error) {
+	rdr, err := array.NewRecordReader(p.params.Schema(), []array.Record{p.params})
+	if err != nil {
+		return nil, xerrors.Errorf("flightsql: invalid parameters: %w", err)
+	}
+	return rdr, nil
+}
+
+// Close closes the statement on the server and releases its parameters.
+// Closing a closed statement does nothing.
+func (p *PreparedStatement) Close(ctx context.Context, opts ...grpc.CallOption) error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	p.SetParameters(nil)
+
+	return p.client.doAction(ctx, ClosePreparedStatementActionType, &ActionClosePreparedStatementRequest{PreparedStatementHandle: p.handle}, nil, opts...)
+}
@@ -0,0 +1,133 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql
+
+import (
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"golang.org/x/xerrors"
+)
+
+// The types of the Flight SQL actions.
+const (
+	CreatePreparedStatementActionType = "CreatePreparedStatement"
+	ClosePreparedStatementActionType  = "ClosePreparedStatement"
+	BeginTransactionActionType        = "BeginTransaction"
+	EndTransactionActionType          = "EndTransaction"
+	BeginSavepointActionType          = "BeginSavepoint"
+	EndSavepointActionType            = "EndSavepoint"
+)
+
+var (
+	// CreatePreparedStatementAction describes the CreatePreparedStatement
+	// action for ListActions.
+	CreatePreparedStatementAction = &flight.ActionType{
+		Type:        CreatePreparedStatementActionType,
+		Description: "Creates a reusable prepared statement resource on the server.\nRequest Message: ActionCreatePreparedStatementRequest\nResponse Message: ActionCreatePreparedStatementResult",
+	}
+	// ClosePreparedStatementAction describes the ClosePreparedStatement
+	// action for ListActions.
+	ClosePreparedStatementAction = &flight.ActionType{
+		Type:        ClosePreparedStatementActionType,
+		Description: "Closes a reusable prepared statement resource on the server.\nRequest Message: ActionClosePreparedStatementRequest\nResponse Message: N/A",
+	}
+	// BeginTransactionAction describes the BeginTransaction action for
+	// ListActions.
+	BeginTransactionAction = &flight.ActionType{
+		Type:        BeginTransactionActionType,
+		Description: "Begins a transaction.\nRequest Message: ActionBeginTransactionRequest\nResponse Message: ActionBeginTransactionResult",
+	}
+	// EndTransactionAction describes the EndTransaction action for
+	// ListActions.
+	EndTransactionAction = &flight.ActionType{
+		Type:        EndTransactionActionType,
+		Description: "Commits or rolls back a transaction.\nRequest Message: ActionEndTransactionRequest\nResponse Message: N/A",
+	}
+	// BeginSavepointAction describes the BeginSavepoint action for
+	// ListActions.
+	BeginSavepointAction = &flight.ActionType{
+		Type:        BeginSavepointActionType,
+		Description: "Creates a savepoint within a transaction.\nRequest Message: ActionBeginSavepointRequest\nResponse Message: ActionBeginSavepointResult",
+	}
+	// EndSavepointAction describes the EndSavepoint action for
+	// ListActions.
+	EndSavepointAction = &flight.ActionType{
+		Type:        EndSavepointActionType,
+		Description: "Releases a savepoint or rolls back to it.\nRequest Message: ActionEndSavepointRequest\nResponse Message: N/A",
+	}
+)
+
+// packCommand returns the serialized Any packing cmd.
+func packCommand(cmd proto.Message) ([]byte, error) {
+	a, err := ptypes.MarshalAny(cmd)
+	if err != nil {
+		return nil, xerrors.Errorf("flightsql: could not pack command: %w", err)
+	}
+	return proto.Marshal(a)
+}
+
+// unpackCommand returns the command packed in the serialized Any b.
+func unpackCommand(b []byte) (proto.Message, error) {
+	var a any.Any
+	if err := proto.Unmarshal(b, &a); err != nil {
+		return nil, xerrors.Errorf("flightsql: could not decode command: %w", err)
+	}
+	cmd, err := ptypes.Empty(&a)
+	if err != nil {
+		return nil, xerrors.Errorf("flightsql: unknown command: %w", err)
+	}
+	if err := ptypes.UnmarshalAny(&a, cmd); err != nil {
+		return nil, xerrors.Errorf("flightsql: could not decode command: %w", err)
+	}
+	return cmd, nil
+}
+
+// unpackMessage decodes the message packed in the serialized Any b into
+// msg, which must be of the packed type.
+func unpackMessage(b []byte, msg proto.Message) error {
+	var a any.Any
+	if err := proto.Unmarshal(b, &a); err != nil {
+		return xerrors.Errorf("flightsql: could not decode %T: %w", msg, err)
+	}
+	if err := ptypes.UnmarshalAny(&a, msg); err != nil {
+		return xerrors.Errorf("flightsql: could not decode %T: %w", msg, err)
+	}
+	return nil
+}
+
+// NewDescriptor returns the flight descriptor of the Flight SQL command
+// cmd, such as a CommandStatementQuery.
+func NewDescriptor(cmd proto.Message) (*flight.FlightDescriptor, error) {
+	b, err := packCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: b}, nil
+}
+
+// NewTicket returns the DoGet ticket of the Flight SQL command cmd, a
+// TicketStatementQuery or a CommandPreparedStatementQuery, for servers to
+// return in the endpoints of their FlightInfo.
+func NewTicket(cmd proto.Message) (*flight.Ticket, error) {
+	b, err := packCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &flight.Ticket{Ticket: b}, nil
+}
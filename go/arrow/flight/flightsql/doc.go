@@ -0,0 +1,26 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flightsql implements the Flight SQL protocol, for interacting
+// with SQL databases over Arrow Flight, on top of the flight package.
+//
+// Flight SQL commands are protobuf messages sent packed in a
+// google.protobuf.Any, as the command of a flight descriptor, the ticket of
+// DoGet or the body of an action. A Client sends them for its caller, and
+// NewService dispatches them to the methods of a Server.
+package flightsql // import "github.com/apache/arrow/go/arrow/flight/flightsql"
+
+//go:generate protoc -I../../../../format --go_out=. --go_opt=paths=source_relative FlightSql.proto
@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// The values of the tls parameter of a DSN.
+const (
+	TLSDisabled   = "disabled"
+	TLSEnabled    = "enabled"
+	TLSSkipVerify = "skip-verify"
+)
+
+// Config is the configuration of the connections to a Flight SQL server,
+// as parsed from a DSN by ParseDSN.
+type Config struct {
+	// Address is the host:port address of the server.
+	Address string
+	// Username and Password authenticate the connections with a Basic
+	// auth handshake, see flight.NewClientBasicAuthCredentials.
+	Username string
+	Password string
+	// Token authenticates the calls with a bearer token instead.
+	Token string
+	// TLS is the TLS configuration of the connections, which are not
+	// encrypted when it is nil.
+	TLS *tls.Config
+	// DialOptions are passed to grpc.Dial, after the ones of the
+	// configuration.
+	DialOptions []grpc.DialOption
+}
+
+// ParseDSN parses a data source name of the form
+//
+//	flightsql://[username[:password]@]host:port[?param=value[&...]]
+//
+// whose parameters are:
+//   - token: the bearer token authenticating the calls, instead of a
+//     username and password;
+//   - tls: one of "disabled", the default, "enabled", or "skip-verify"
+//     for not verifying the certificate of the server.
+func ParseDSN(dsn string) (*Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, xerrors.Errorf("flightsql: invalid DSN: %w", err)
+	}
+	if u.Scheme != "flightsql" {
+		return nil, xerrors.Errorf("flightsql: invalid DSN scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, xerrors.New("flightsql: missing host in DSN")
+	}
+
+	cfg := &Config{Address: u.Host}
+	if u.User != nil {
+		cfg.Username = u.User.Username()
+		cfg.Password, _ = u.User.Password()
+	}
+
+	for key, values := range u.Query() {
+		v := values[len(values)-1]
+		switch strings.ToLower(key) {
+		case "token":
+			cfg.Token = v
+		case "tls":
+			switch strings.ToLower(v) {
+			case TLSDisabled:
+			case TLSEnabled:
+				cfg.TLS = &tls.Config{}
+			case TLSSkipVerify:
+				cfg.TLS = &tls.Config{InsecureSkipVerify: true}
+			default:
+				return nil, xerrors.Errorf("flightsql: invalid tls parameter %q", v)
+			}
+		default:
+			return nil, xerrors.Errorf("flightsql: unknown DSN parameter %q", key)
+		}
+	}
+
+	if cfg.Token != "" && cfg.Username != "" {
+		return nil, xerrors.New("flightsql: both a token and a username in DSN")
+	}
+	return cfg, nil
+}
+
+// dialOptions returns the options dialing the server of the configuration.
+func (c *Config) dialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.TLS != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(c.TLS.Clone())))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	switch {
+	case c.Token != "":
+		header := flight.FormatBearerAuthHeader(c.Token)
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", header), method, req, reply, cc, opts...)
+			}),
+			grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return streamer(metadata.AppendToOutgoingContext(ctx, "authorization", header), desc, cc, method, opts...)
+			}))
+	case c.Username != "":
+		opts = append(opts, flight.NewClientBasicAuthCredentials(c.Username, c.Password).DialOptions()...)
+	}
+	return append(opts, c.DialOptions...)
+}
@@ -0,0 +1,652 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/arrow/flight/flightsql/driver"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	typesQuery    = "SELECT * FROM types"
+	rangeQueryFmt = "SELECT n FROM range(%d)"
+	rangeParam    = "SELECT n FROM range(?)"
+	updateQuery   = "UPDATE t SET x = 1"
+	rangeBatch    = 1000
+)
+
+var rangeSchema = arrow.NewSchema([]arrow.Field{{Name: "n", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+// exampleServer is a Flight SQL server of generated data: a table of all
+// the types, and ranges of integers read lazily in batches of rangeBatch
+// rows, from two endpoints.
+type exampleServer struct {
+	flightsql.BaseServer
+
+	// produced counts the batches of ranges produced.
+	produced int64
+
+	mu        sync.Mutex
+	params    map[string]int64
+	updates   []string
+	committed []string
+	next      int
+}
+
+func newExampleServer() *exampleServer {
+	return &exampleServer{params: make(map[string]int64)}
+}
+
+func (s *exampleServer) flightInfo(desc *flight.FlightDescriptor, schema *arrow.Schema, handles ...string) (*flight.FlightInfo, error) {
+	info := &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(schema, memory.DefaultAllocator),
+		FlightDescriptor: desc,
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}
+	for _, h := range handles {
+		ticket, err := flightsql.NewTicket(&flightsql.TicketStatementQuery{StatementHandle: []byte(h)})
+		if err != nil {
+			return nil, err
+		}
+		info.Endpoint = append(info.Endpoint, &flight.FlightEndpoint{Ticket: ticket})
+	}
+	return info, nil
+}
+
+func (s *exampleServer) rangeInfo(desc *flight.FlightDescriptor, n int64) (*flight.FlightInfo, error) {
+	return s.flightInfo(desc, rangeSchema, fmt.Sprintf("range:0:%d", n/2), fmt.Sprintf("range:%d:%d", n/2, n))
+}
+
+func (s *exampleServer) GetFlightInfoStatement(ctx context.Context, cmd *flightsql.CommandStatementQuery, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	if cmd.GetQuery() == typesQuery {
+		return s.flightInfo(desc, typesSchema, "types")
+	}
+	var n int64
+	if _, err := fmt.Sscanf(cmd.GetQuery(), rangeQueryFmt, &n); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported query %q", cmd.GetQuery())
+	}
+	return s.rangeInfo(desc, n)
+}
+
+func (s *exampleServer) DoGetStatement(ctx context.Context, ticket *flightsql.TicketStatementQuery) (array.RecordReader, error) {
+	handle := string(ticket.GetStatementHandle())
+	if handle == "types" {
+		rec := typesRecord()
+		defer rec.Release()
+		return array.NewRecordReader(typesSchema, []array.Record{rec})
+	}
+	var lo, hi int64
+	if _, err := fmt.Sscanf(handle, "range:%d:%d", &lo, &hi); err != nil {
+		return nil, status.Errorf(codes.NotFound, "no statement %q", handle)
+	}
+	return &rangeReader{srv: s, next: lo, end: hi}, nil
+}
+
+func (s *exampleServer) ExecuteUpdate(ctx context.Context, cmd *flightsql.CommandStatementUpdate) (int64, error) {
+	if cmd.GetQuery() != updateQuery {
+		return 0, status.Errorf(codes.InvalidArgument, "unsupported update %q", cmd.GetQuery())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updates = append(s.updates, string(cmd.GetTransactionId()))
+	return 42, nil
+}
+
+func (s *exampleServer) CreatePreparedStatement(ctx context.Context, req *flightsql.ActionCreatePreparedStatementRequest) (*flightsql.PreparedStatementResult, error) {
+	if req.GetQuery() != rangeParam {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported query %q", req.GetQuery())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	handle := fmt.Sprintf("stmt-%d", s.next)
+	s.params[handle] = 0
+	return &flightsql.PreparedStatementResult{
+		Handle:          []byte(handle),
+		DatasetSchema:   rangeSchema,
+		ParameterSchema: arrow.NewSchema([]arrow.Field{{Name: "n", Type: arrow.PrimitiveTypes.Int32}}, nil),
+	}, nil
+}
+
+func (s *exampleServer) BindParameters(ctx context.Context, cmd *flightsql.CommandPreparedStatementQuery, params array.RecordReader) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if params == nil || !params.Next() || params.Record().NumRows() != 1 {
+		return nil, status.Error(codes.InvalidArgument, "expected a single row of parameters")
+	}
+	s.params[string(cmd.GetPreparedStatementHandle())] = int64(params.Record().Column(0).(*array.Int32).Value(0))
+	return nil, nil
+}
+
+func (s *exampleServer) ExecutePreparedStatement(ctx context.Context, cmd *flightsql.CommandPreparedStatementQuery, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	s.mu.Lock()
+	n, ok := s.params[string(cmd.GetPreparedStatementHandle())]
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no statement %q", cmd.GetPreparedStatementHandle())
+	}
+	return s.rangeInfo(desc, n)
+}
+
+func (s *exampleServer) ClosePreparedStatement(ctx context.Context, req *flightsql.ActionClosePreparedStatementRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.params, string(req.GetPreparedStatementHandle()))
+	return nil
+}
+
+func (s *exampleServer) BeginTransaction(ctx context.Context, req *flightsql.ActionBeginTransactionRequest) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	return []byte(fmt.Sprintf("txn-%d", s.next)), nil
+}
+
+func (s *exampleServer) EndTransaction(ctx context.Context, req *flightsql.ActionEndTransactionRequest) error {
+	if req.GetAction() == flightsql.ActionEndTransactionRequest_END_TRANSACTION_COMMIT {
+		s.mu.Lock()
+		s.committed = append(s.committed, string(req.GetTransactionId()))
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// rangeReader lazily reads the integers of [next, end).
+type rangeReader struct {
+	srv       *exampleServer
+	next, end int64
+	rec       array.Record
+}
+
+func (r *rangeReader) Retain()               {}
+func (r *rangeReader) Schema() *arrow.Schema { return rangeSchema }
+func (r *rangeReader) Record() array.Record  { return r.rec }
+
+func (r *rangeReader) Release() {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+}
+
+func (r *rangeReader) Next() bool {
+	r.Release()
+	if r.next >= r.end {
+		return false
+	}
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, rangeSchema)
+	defer bldr.Release()
+	for i := 0; i < rangeBatch && r.next < r.end; i++ {
+		bldr.Field(0).(*array.Int64Builder).Append(r.next)
+		r.next++
+	}
+	r.rec = bldr.NewRecord()
+	atomic.AddInt64(&r.srv.produced, 1)
+	return true
+}
+
+var typesSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "bool", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	{Name: "int8", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+	{Name: "int16", Type: arrow.PrimitiveTypes.Int16, Nullable: true},
+	{Name: "int32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	{Name: "int64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "uint8", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
+	{Name: "uint16", Type: arrow.PrimitiveTypes.Uint16, Nullable: true},
+	{Name: "uint32", Type: arrow.PrimitiveTypes.Uint32, Nullable: true},
+	{Name: "uint64", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	{Name: "float16", Type: arrow.FixedWidthTypes.Float16, Nullable: true},
+	{Name: "float32", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	{Name: "float64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "string", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "binary", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "fixed", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}, Nullable: true},
+	{Name: "date32", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+	{Name: "date64", Type: arrow.FixedWidthTypes.Date64, Nullable: true},
+	{Name: "time32", Type: arrow.FixedWidthTypes.Time32ms, Nullable: true},
+	{Name: "time64", Type: arrow.FixedWidthTypes.Time64us, Nullable: true},
+	{Name: "timestamp", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "+02:00"}, Nullable: true},
+	{Name: "decimal", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}, Nullable: true},
+	{Name: "null", Type: arrow.Null, Nullable: true},
+}, nil)
+
+// typesRecord returns a record of typesSchema of a row of values, a row
+// of nulls and a row of other values.
+func typesRecord() array.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, typesSchema)
+	defer bldr.Release()
+
+	const (
+		date      = 19000 // 2022-01-08
+		timestamp = 1600000000123456
+	)
+	for _, row := range []int64{1, 0, -1} {
+		if row == 0 {
+			for _, f := range bldr.Fields() {
+				f.AppendNull()
+			}
+			continue
+		}
+		bldr.Field(0).(*array.BooleanBuilder).Append(row > 0)
+		bldr.Field(1).(*array.Int8Builder).Append(int8(-8 * row))
+		bldr.Field(2).(*array.Int16Builder).Append(int16(-16 * row))
+		bldr.Field(3).(*array.Int32Builder).Append(int32(-32 * row))
+		bldr.Field(4).(*array.Int64Builder).Append(-64 * row)
+		bldr.Field(5).(*array.Uint8Builder).Append(8)
+		bldr.Field(6).(*array.Uint16Builder).Append(16)
+		bldr.Field(7).(*array.Uint32Builder).Append(32)
+		bldr.Field(8).(*array.Uint64Builder).Append(1<<63 + 1)
+		bldr.Field(9).(*array.Float16Builder).Append(float16.New(1.5))
+		bldr.Field(10).(*array.Float32Builder).Append(2.5)
+		bldr.Field(11).(*array.Float64Builder).Append(3.25 * float64(row))
+		bldr.Field(12).(*array.StringBuilder).Append("héllo")
+		bldr.Field(13).(*array.BinaryBuilder).Append([]byte{0, 1})
+		bldr.Field(14).(*array.FixedSizeBinaryBuilder).Append([]byte("ab"))
+		bldr.Field(15).(*array.Date32Builder).Append(date)
+		bldr.Field(16).(*array.Date64Builder).Append(date * 86400000)
+		bldr.Field(17).(*array.Time32Builder).Append(3723004)
+		bldr.Field(18).(*array.Time64Builder).Append(3723000005)
+		bldr.Field(19).(*array.TimestampBuilder).Append(timestamp)
+		bldr.Field(20).(*array.Decimal128Builder).Append(decimal128.FromI64(-12345 * row))
+		bldr.Field(21).AppendNull()
+	}
+	return bldr.NewRecord()
+}
+
+// startServer serves srv, with the server options opts, returning its
+// address and a function stopping it.
+func startServer(t *testing.T, srv flightsql.Server, opts ...grpc.ServerOption) (string, func()) {
+	s := flight.NewFlightServer(nil, opts...)
+	if err := s.Init("localhost:0"); err != nil {
+		t.Fatal(err)
+	}
+	s.RegisterFlightService(flightsql.NewService(srv))
+	go s.Serve()
+	return s.Addr().String(), s.Shutdown
+}
+
+func openDB(t *testing.T, srv flightsql.Server) (*sql.DB, func()) {
+	addr, stop := startServer(t, srv)
+	db, err := sql.Open("flightsql", "flightsql://"+addr)
+	if err != nil {
+		stop()
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		stop()
+	}
+}
+
+func TestParseDSN(t *testing.T) {
+	for _, tc := range []struct {
+		dsn  string
+		want driver.Config
+		tls  string
+		err  string
+	}{
+		{dsn: "flightsql://localhost:1234", want: driver.Config{Address: "localhost:1234"}},
+		{dsn: "flightsql://bob:s%3Acret@db:1234?tls=enabled", want: driver.Config{Address: "db:1234", Username: "bob", Password: "s:cret"}, tls: driver.TLSEnabled},
+		{dsn: "flightsql://db:1234?token=abc&tls=skip-verify", want: driver.Config{Address: "db:1234", Token: "abc"}, tls: driver.TLSSkipVerify},
+		{dsn: "flightsql://db:1234?tls=disabled", want: driver.Config{Address: "db:1234"}},
+		{dsn: "postgres://db:1234", err: "invalid DSN scheme"},
+		{dsn: "flightsql://", err: "missing host"},
+		{dsn: "flightsql://db:1234?tls=maybe", err: "invalid tls parameter"},
+		{dsn: "flightsql://db:1234?timeout=1s", err: "unknown DSN parameter"},
+		{dsn: "flightsql://bob@db:1234?token=abc", err: "both a token and a username"},
+	} {
+		cfg, err := driver.ParseDSN(tc.dsn)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%s: unexpected error: %v", tc.dsn, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.dsn, err)
+			continue
+		}
+
+		tlsMode := driver.TLSDisabled
+		switch {
+		case cfg.TLS != nil && cfg.TLS.InsecureSkipVerify:
+			tlsMode = driver.TLSSkipVerify
+		case cfg.TLS != nil:
+			tlsMode = driver.TLSEnabled
+		}
+		if tc.tls == "" {
+			tc.tls = driver.TLSDisabled
+		}
+		cfg.TLS = nil
+		if !reflect.DeepEqual(*cfg, tc.want) || tlsMode != tc.tls {
+			t.Errorf("%s: got=%+v (tls=%s), want=%+v (tls=%s)", tc.dsn, *cfg, tlsMode, tc.want, tc.tls)
+		}
+	}
+}
+
+func TestQueryTypes(t *testing.T) {
+	db, stop := openDB(t, newExampleServer())
+	defer stop()
+
+	rows, err := db.Query(typesQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != len(typesSchema.Fields()) || cols[0] != "bool" {
+		t.Fatalf("invalid columns: %v", cols)
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := types[19].DatabaseTypeName(); name != "TIMESTAMP" {
+		t.Fatalf("invalid type name: %s", name)
+	}
+	if nullable, ok := types[0].Nullable(); !nullable || !ok {
+		t.Fatalf("invalid nullability: %v, %v", nullable, ok)
+	}
+
+	zone := time.FixedZone("+02:00", 2*3600)
+	date := time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC)
+	want := [][]interface{}{
+		{
+			true, int64(-8), int64(-16), int64(-32), int64(-64), int64(8), int64(16), int64(32), "9223372036854775809",
+			1.5, 2.5, 3.25, "héllo", []byte{0, 1}, []byte("ab"), date, date, "01:02:03.004", "01:02:03.000005",
+			time.Date(2020, 9, 13, 14, 26, 40, 123456000, zone), "-123.45", nil,
+		},
+		make([]interface{}, len(typesSchema.Fields())),
+		{
+			false, int64(8), int64(16), int64(32), int64(64), int64(8), int64(16), int64(32), "9223372036854775809",
+			1.5, 2.5, -3.25, "héllo", []byte{0, 1}, []byte("ab"), date, date, "01:02:03.004", "01:02:03.000005",
+			time.Date(2020, 9, 13, 14, 26, 40, 123456000, zone), "123.45", nil,
+		},
+	}
+
+	for i := 0; rows.Next(); i++ {
+		got := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for j := range got {
+			ptrs[j] = &got[j]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			t.Fatal(err)
+		}
+		for j := range got {
+			g, w := got[j], want[i][j]
+			if gt, ok := g.(time.Time); ok {
+				if wt, ok := w.(time.Time); !ok || !gt.Equal(wt) {
+					t.Errorf("row %d, column %s: got=%v, want=%v", i, cols[j], g, w)
+				}
+				continue
+			}
+			if !reflect.DeepEqual(g, w) {
+				t.Errorf("row %d, column %s: got=%#v, want=%#v", i, cols[j], g, w)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the values are also scanned into typed variables.
+	var (
+		u64 uint64
+		ts  time.Time
+		f32 float32
+		ns  sql.NullString
+	)
+	if err := db.QueryRow(typesQuery).Scan(new(bool), new(int8), new(int16), new(int32), new(int64), new(uint8), new(uint16), new(uint32), &u64,
+		new(float32), &f32, new(float64), new(string), new([]byte), new([]byte), new(time.Time), new(time.Time), new(string), new(string), &ts, new(string), &ns); err != nil {
+		t.Fatal(err)
+	}
+	if u64 != 1<<63+1 || f32 != 2.5 || ts.Location().String() != "+02:00" || ns.Valid {
+		t.Fatalf("invalid values: %v, %v, %v, %v", u64, f32, ts, ns)
+	}
+}
+
+// sumRange returns the number of rows and the sum of the ranges of rows.
+func sumRange(t *testing.T, rows *sql.Rows) (n, sum int64) {
+	t.Helper()
+	defer rows.Close()
+	for rows.Next() {
+		var v int64
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		n++
+		sum += v
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return n, sum
+}
+
+func TestQueryLargeResult(t *testing.T) {
+	srv := newExampleServer()
+	db, stop := openDB(t, srv)
+	defer stop()
+
+	const total = 25500
+	rows, err := db.Query(fmt.Sprintf(rangeQueryFmt, total))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, sum := sumRange(t, rows); n != total || sum != total*(total-1)/2 {
+		t.Fatalf("invalid results: rows=%d, sum=%d", n, sum)
+	}
+	// the two endpoints of 12750 rows were read in batches of 1000 rows.
+	if produced := atomic.LoadInt64(&srv.produced); produced != 26 {
+		t.Fatalf("invalid number of batches: %d", produced)
+	}
+}
+
+func TestQueryCancel(t *testing.T) {
+	srv := newExampleServer()
+	db, stop := openDB(t, srv)
+	defer stop()
+
+	const total = 100000000
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(rangeQueryFmt, total))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatalf("no rows: %v", rows.Err())
+	}
+	// the rows are streamed rather than read at once.
+	if produced := atomic.LoadInt64(&srv.produced); produced >= total/rangeBatch/2 {
+		t.Fatalf("results were buffered: %d batches produced", produced)
+	}
+
+	cancel()
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExec(t *testing.T) {
+	srv := newExampleServer()
+	db, stop := openDB(t, srv)
+	defer stop()
+
+	res, err := db.Exec(updateQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 42 {
+		t.Fatalf("invalid rows affected: %d, %v", n, err)
+	}
+	if _, err := res.LastInsertId(); err == nil {
+		t.Fatal("unexpected last insert id")
+	}
+
+	if _, err := db.Exec("DROP TABLE t"); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPreparedStatements(t *testing.T) {
+	srv := newExampleServer()
+	db, stop := openDB(t, srv)
+	defer stop()
+
+	// a query with arguments runs as a prepared statement, closed along
+	// with its rows.
+	rows, err := db.Query(rangeParam, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, sum := sumRange(t, rows); n != 10 || sum != 45 {
+		t.Fatalf("invalid results: rows=%d, sum=%d", n, sum)
+	}
+
+	stmt, err := db.Prepare(rangeParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int64{3, 2500} {
+		rows, err := stmt.Query(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, sum := sumRange(t, rows); got != n || sum != n*(n-1)/2 {
+			t.Fatalf("invalid results for %d: rows=%d, sum=%d", n, got, sum)
+		}
+	}
+
+	// the argument is converted to the parameter type of the server.
+	if _, err := stmt.Query(int64(1) << 40); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.params) != 0 {
+		t.Fatalf("statements left open: %v", srv.params)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	srv := newExampleServer()
+	db, stop := openDB(t, srv)
+	defer stop()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec(updateQuery); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != sql.ErrTxDone {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := db.Exec(updateQuery); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.committed) != 1 || fmt.Sprint(srv.updates) != fmt.Sprintf("[%s ]", srv.committed[0]) {
+		t.Fatalf("invalid transactions: updates=%q, committed=%q", srv.updates, srv.committed)
+	}
+}
+
+type tokenValidator struct{}
+
+func (tokenValidator) Validate(username, password string) (string, error) {
+	if username != "bob" || password != "s:cret" {
+		return "", status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	return "secret", nil
+}
+
+func (tokenValidator) IsValid(token string) (interface{}, error) {
+	if token != "secret" {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return "bob", nil
+}
+
+func TestAuthentication(t *testing.T) {
+	unary, stream := flight.CreateServerBearerTokenAuthInterceptors(tokenValidator{})
+	addr, stop := startServer(t, newExampleServer(), grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	defer stop()
+
+	for _, tc := range []struct {
+		dsn string
+		ok  bool
+	}{
+		{dsn: "flightsql://bob:s%3Acret@" + addr, ok: true},
+		{dsn: "flightsql://" + addr + "?token=secret", ok: true},
+		{dsn: "flightsql://bob:wrong@" + addr},
+		{dsn: "flightsql://" + addr + "?token=wrong"},
+		{dsn: "flightsql://" + addr},
+	} {
+		db, err := sql.Open("flightsql", tc.dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.Exec(updateQuery)
+		db.Close()
+		if tc.ok && err != nil {
+			t.Errorf("%s: %v", tc.dsn, err)
+		}
+		if !tc.ok && status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: unexpected error: %v", tc.dsn, err)
+		}
+	}
+}
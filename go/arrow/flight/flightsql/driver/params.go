@@ -0,0 +1,183 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// newParameters returns the record of a single row of the parameters args,
+// with the parameter schema of the statement when the server gave one, or
+// types inferred from args otherwise.
+func newParameters(schema *arrow.Schema, args []driver.NamedValue) (array.Record, error) {
+	if schema == nil || len(schema.Fields()) != len(args) {
+		fields := make([]arrow.Field, len(args))
+		for i, arg := range args {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("$%d", arg.Ordinal)
+			}
+			fields[i] = arrow.Field{Name: name, Type: parameterType(arg.Value), Nullable: true}
+		}
+		schema = arrow.NewSchema(fields, nil)
+	}
+
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer bldr.Release()
+	for i, arg := range args {
+		if err := appendValue(bldr.Field(i), schema.Field(i).Type, arg.Value); err != nil {
+			return nil, xerrors.Errorf("flightsql: parameter %d: %w", arg.Ordinal, err)
+		}
+	}
+	return bldr.NewRecord(), nil
+}
+
+// parameterType returns the type of the parameter value v.
+func parameterType(v driver.Value) arrow.DataType {
+	switch v.(type) {
+	case int64:
+		return arrow.PrimitiveTypes.Int64
+	case float64:
+		return arrow.PrimitiveTypes.Float64
+	case bool:
+		return arrow.FixedWidthTypes.Boolean
+	case string:
+		return arrow.BinaryTypes.String
+	case []byte:
+		return arrow.BinaryTypes.Binary
+	case time.Time:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	}
+	return arrow.Null
+}
+
+// appendValue appends the parameter value v to b, converting it to the type
+// dt of b.
+func appendValue(b array.Builder, dt arrow.DataType, v driver.Value) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		n, err := intValue(v, -1<<7, 1<<7-1)
+		b.Append(int8(n))
+		return err
+	case *array.Int16Builder:
+		n, err := intValue(v, -1<<15, 1<<15-1)
+		b.Append(int16(n))
+		return err
+	case *array.Int32Builder:
+		n, err := intValue(v, -1<<31, 1<<31-1)
+		b.Append(int32(n))
+		return err
+	case *array.Int64Builder:
+		n, err := intValue(v, -1<<63, 1<<63-1)
+		b.Append(n)
+		return err
+	case *array.Uint8Builder:
+		n, err := intValue(v, 0, 1<<8-1)
+		b.Append(uint8(n))
+		return err
+	case *array.Uint16Builder:
+		n, err := intValue(v, 0, 1<<16-1)
+		b.Append(uint16(n))
+		return err
+	case *array.Uint32Builder:
+		n, err := intValue(v, 0, 1<<32-1)
+		b.Append(uint32(n))
+		return err
+	case *array.Uint64Builder:
+		n, err := intValue(v, 0, 1<<63-1)
+		b.Append(uint64(n))
+		return err
+	case *array.Float32Builder:
+		f, err := floatValue(v)
+		b.Append(float32(f))
+		return err
+	case *array.Float64Builder:
+		f, err := floatValue(v)
+		b.Append(f)
+		return err
+	case *array.BooleanBuilder:
+		if v, ok := v.(bool); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.StringBuilder:
+		switch v := v.(type) {
+		case string:
+			b.Append(v)
+			return nil
+		case []byte:
+			b.Append(string(v))
+			return nil
+		}
+	case *array.BinaryBuilder:
+		switch v := v.(type) {
+		case []byte:
+			b.Append(v)
+			return nil
+		case string:
+			b.AppendString(v)
+			return nil
+		}
+	case *array.TimestampBuilder:
+		if v, ok := v.(time.Time); ok {
+			unit := dt.(*arrow.TimestampType).Unit
+			b.Append(arrow.Timestamp(v.UnixNano() / (1e9 / unitsPerSecond(unit))))
+			return nil
+		}
+	case *array.Date32Builder:
+		if v, ok := v.(time.Time); ok {
+			b.Append(arrow.Date32(v.Unix() / 86400))
+			return nil
+		}
+	}
+
+	b.AppendNull()
+	return xerrors.Errorf("cannot convert %T to %v", v, dt)
+}
+
+func intValue(v driver.Value, min, max int64) (int64, error) {
+	n, ok := v.(int64)
+	if !ok {
+		return 0, xerrors.Errorf("cannot convert %T to an integer", v)
+	}
+	if n < min || n > max {
+		return 0, xerrors.Errorf("%d out of range [%d, %d]", n, min, max)
+	}
+	return n, nil
+}
+
+func floatValue(v driver.Value) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	}
+	return 0, xerrors.Errorf("cannot convert %T to a float", v)
+}
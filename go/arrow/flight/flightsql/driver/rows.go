@@ -0,0 +1,319 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// rows reads the results of a query from the endpoints of its FlightInfo,
+// one record batch at a time.
+type rows struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	client    *flightsql.Client
+	endpoints []*flight.FlightEndpoint
+	schema    *arrow.Schema
+
+	rdr *ipc.Reader
+	rec array.Record
+	row int
+
+	// onClose, when set, is called once the rows are closed.
+	onClose func() error
+}
+
+func newRows(ctx context.Context, client *flightsql.Client, info *flight.FlightInfo) (*rows, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	r := &rows{ctx: ctx, cancel: cancel, client: client, endpoints: info.GetEndpoint()}
+
+	// the schema of the rows is the one of their first endpoint, or the
+	// one of the FlightInfo when there is none.
+	if len(r.endpoints) == 0 {
+		schema, err := flight.DeserializeSchema(info.GetSchema(), memory.DefaultAllocator)
+		if err != nil {
+			cancel()
+			return nil, xerrors.Errorf("flightsql: could not decode schema of results: %w", err)
+		}
+		r.schema = schema
+		return r, nil
+	}
+	if err := r.nextEndpoint(); err != nil {
+		cancel()
+		return nil, err
+	}
+	r.schema = r.rdr.Schema()
+	return r, nil
+}
+
+// nextEndpoint starts reading the next endpoint.
+func (r *rows) nextEndpoint() error {
+	ep := r.endpoints[0]
+	r.endpoints = r.endpoints[1:]
+
+	rdr, err := r.client.DoGet(r.ctx, ep.GetTicket())
+	if err != nil {
+		return err
+	}
+	if r.schema != nil && !rdr.Schema().Equal(r.schema) {
+		rdr.Release()
+		return xerrors.Errorf("flightsql: endpoints with different schemas: %v and %v", r.schema, rdr.Schema())
+	}
+	r.rdr = rdr
+	return nil
+}
+
+// nextRecord moves to the next record of the results, returning io.EOF
+// after the last one.
+func (r *rows) nextRecord() error {
+	r.rec = nil
+	for {
+		if r.rdr != nil {
+			if r.rdr.Next() {
+				r.rec, r.row = r.rdr.Record(), 0
+				return nil
+			}
+			err := r.rdr.Err()
+			r.rdr.Release()
+			r.rdr = nil
+			if err != nil {
+				return err
+			}
+		}
+		if len(r.endpoints) == 0 {
+			return io.EOF
+		}
+		if err := r.nextEndpoint(); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.schema.Fields()))
+	for i, f := range r.schema.Fields() {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func (r *rows) Close() error {
+	r.cancel()
+	if r.rdr != nil {
+		r.rdr.Release()
+		r.rdr = nil
+	}
+	r.rec, r.endpoints = nil, nil
+
+	if r.onClose != nil {
+		onClose := r.onClose
+		r.onClose = nil
+		return onClose()
+	}
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	for r.rec == nil || r.row >= int(r.rec.NumRows()) {
+		if err := r.nextRecord(); err != nil {
+			// a stream failing for the cancellation of the query
+			// reports the error of its context.
+			if ctxErr := r.ctx.Err(); ctxErr != nil && err != io.EOF {
+				return ctxErr
+			}
+			return err
+		}
+	}
+
+	for i, col := range r.rec.Columns() {
+		v, err := columnValue(col, r.row, r.schema.Field(i).Type)
+		if err != nil {
+			return xerrors.Errorf("flightsql: column %q: %w", r.schema.Field(i).Name, err)
+		}
+		dest[i] = v
+	}
+	r.row++
+	return nil
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(i int) string {
+	return strings.ToUpper(r.schema.Field(i).Type.Name())
+}
+
+func (r *rows) ColumnTypeNullable(i int) (nullable, ok bool) {
+	return r.schema.Field(i).Nullable, true
+}
+
+func (r *rows) ColumnTypeScanType(i int) reflect.Type {
+	switch r.schema.Field(i).Type.ID() {
+	case arrow.NULL:
+		return reflect.TypeOf((*interface{})(nil)).Elem()
+	case arrow.BOOL:
+		return reflect.TypeOf(false)
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64, arrow.UINT8, arrow.UINT16, arrow.UINT32:
+		return reflect.TypeOf(int64(0))
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return reflect.TypeOf(float64(0))
+	case arrow.BINARY, arrow.FIXED_SIZE_BINARY:
+		return reflect.TypeOf([]byte(nil))
+	case arrow.DATE32, arrow.DATE64, arrow.TIMESTAMP:
+		return reflect.TypeOf(time.Time{})
+	}
+	return reflect.TypeOf("")
+}
+
+var (
+	_ driver.Rows                           = (*rows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+)
+
+// columnValue returns the value of the row i of col, of type dt, as an
+// int64, a float64, a bool, a []byte, a string or a time.Time. The uint64
+// values, which may overflow int64, the times of day and the decimals are
+// returned as strings.
+func columnValue(col array.Interface, i int, dt arrow.DataType) (driver.Value, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	switch col := col.(type) {
+	case *array.Null:
+		return nil, nil
+	case *array.Boolean:
+		return col.Value(i), nil
+	case *array.Int8:
+		return int64(col.Value(i)), nil
+	case *array.Int16:
+		return int64(col.Value(i)), nil
+	case *array.Int32:
+		return int64(col.Value(i)), nil
+	case *array.Int64:
+		return col.Value(i), nil
+	case *array.Uint8:
+		return int64(col.Value(i)), nil
+	case *array.Uint16:
+		return int64(col.Value(i)), nil
+	case *array.Uint32:
+		return int64(col.Value(i)), nil
+	case *array.Uint64:
+		return strconv.FormatUint(col.Value(i), 10), nil
+	case *array.Float16:
+		return float64(col.Value(i).Float32()), nil
+	case *array.Float32:
+		return float64(col.Value(i)), nil
+	case *array.Float64:
+		return col.Value(i), nil
+	case *array.String:
+		return col.Value(i), nil
+	case *array.Binary:
+		return append([]byte(nil), col.Value(i)...), nil
+	case *array.FixedSizeBinary:
+		return append([]byte(nil), col.Value(i)...), nil
+	case *array.Date32:
+		return time.Unix(int64(col.Value(i))*86400, 0).UTC(), nil
+	case *array.Date64:
+		return unixTime(int64(col.Value(i)), arrow.Millisecond, time.UTC), nil
+	case *array.Time32:
+		return timeOfDay(int64(col.Value(i)), dt.(*arrow.Time32Type).Unit), nil
+	case *array.Time64:
+		return timeOfDay(int64(col.Value(i)), dt.(*arrow.Time64Type).Unit), nil
+	case *array.Timestamp:
+		ts := dt.(*arrow.TimestampType)
+		loc, err := location(ts.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		return unixTime(int64(col.Value(i)), ts.Unit, loc), nil
+	case *array.Decimal128:
+		return decimalString(col.Value(i), dt.(*arrow.Decimal128Type).Scale), nil
+	}
+	return nil, xerrors.Errorf("unsupported type %v", dt)
+}
+
+// unitsPerSecond returns the number of time units in a second.
+func unitsPerSecond(unit arrow.TimeUnit) int64 {
+	return [...]int64{1e9, 1e6, 1e3, 1}[unit&3]
+}
+
+func unixTime(v int64, unit arrow.TimeUnit, loc *time.Location) time.Time {
+	n := unitsPerSecond(unit)
+	sec, frac := v/n, v%n
+	if frac < 0 {
+		sec, frac = sec-1, frac+n
+	}
+	return time.Unix(sec, frac*(1e9/n)).In(loc)
+}
+
+func timeOfDay(v int64, unit arrow.TimeUnit) string {
+	return unixTime(v, unit, time.UTC).Format("15:04:05.999999999")
+}
+
+// location returns the location of the time zone of a timestamp type, a
+// name of the IANA database or an offset such as +07:00. Timestamps
+// without time zone are in UTC.
+func location(tz string) (*time.Location, error) {
+	if tz == "" {
+		return time.UTC, nil
+	}
+	if t, err := time.Parse("-07:00", tz); err == nil {
+		_, offset := t.Zone()
+		return time.FixedZone(tz, offset), nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid time zone %q: %w", tz, err)
+	}
+	return loc, nil
+}
+
+// decimalString returns the decimal n with scale digits after the point.
+func decimalString(n decimal128.Num, scale int32) string {
+	v := new(big.Int).Lsh(big.NewInt(n.HighBits()), 64)
+	v.Or(v, new(big.Int).SetUint64(n.LowBits()))
+	if scale <= 0 {
+		return v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil)).String()
+	}
+
+	neg := v.Sign() < 0
+	digits := v.Abs(v).String()
+	if pad := int(scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	s := digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,616 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	selectAll     = "SELECT name FROM users"
+	selectByID    = "SELECT name FROM users WHERE id = ?"
+	deleteByID    = "DELETE FROM users WHERE id = ?"
+	deleteByIDFmt = "DELETE FROM users WHERE id = %d"
+)
+
+var (
+	namesSchema  = arrow.NewSchema([]arrow.Field{{Name: "name", Type: arrow.BinaryTypes.String}}, nil)
+	paramsSchema = arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+)
+
+type stubStatement struct {
+	query  string
+	params []int64
+	txn    string
+}
+
+type stubSavepoint struct {
+	txn   string
+	users map[int64]string
+}
+
+// sqlStub is an in-memory Flight SQL server of a users table, supporting
+// a handful of queries. Binding parameters re-prepares the statement under
+// a new handle, invalidating the previous one. Transactions work on copies
+// of the table, replacing it on commit.
+type sqlStub struct {
+	flightsql.BaseServer
+
+	mu         sync.Mutex
+	users      map[int64]string
+	stmts      map[string]*stubStatement
+	txns       map[string]map[int64]string
+	savepoints map[string]stubSavepoint
+	tables     map[string]*stubTable
+	next       int
+}
+
+func newSQLStub() *sqlStub {
+	return &sqlStub{
+		users:      map[int64]string{1: "ada", 2: "brian", 3: "claude", 4: "dennis"},
+		stmts:      make(map[string]*stubStatement),
+		txns:       make(map[string]map[int64]string),
+		savepoints: make(map[string]stubSavepoint),
+		tables:     make(map[string]*stubTable),
+	}
+}
+
+func copyUsers(users map[int64]string) map[int64]string {
+	cp := make(map[int64]string, len(users))
+	for id, name := range users {
+		cp[id] = name
+	}
+	return cp
+}
+
+// table returns the users table seen by the transaction txn, or outside
+// of any when it is empty.
+func (s *sqlStub) table(txn string) (map[int64]string, error) {
+	if txn == "" {
+		return s.users, nil
+	}
+	users, ok := s.txns[txn]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no transaction %q", txn)
+	}
+	return users, nil
+}
+
+func (s *sqlStub) newHandle(stmt *stubStatement) []byte {
+	s.next++
+	handle := fmt.Sprintf("stmt-%d", s.next)
+	s.stmts[handle] = stmt
+	return []byte(handle)
+}
+
+func (s *sqlStub) statement(handle []byte) (*stubStatement, error) {
+	stmt, ok := s.stmts[string(handle)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no prepared statement %q", handle)
+	}
+	return stmt, nil
+}
+
+func (s *sqlStub) run(stmt *stubStatement) (array.RecordReader, error) {
+	users, err := s.table(stmt.txn)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	switch stmt.query {
+	case selectAll:
+		for id := range users {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	case selectByID:
+		ids = stmt.params
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported query %q", stmt.query)
+	}
+
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, namesSchema)
+	defer bldr.Release()
+	for _, id := range ids {
+		if name, ok := users[id]; ok {
+			bldr.Field(0).(*array.StringBuilder).Append(name)
+		}
+	}
+	rec := bldr.NewRecord()
+	defer rec.Release()
+	return array.NewRecordReader(namesSchema, []array.Record{rec})
+}
+
+func (s *sqlStub) delete(txn string, ids []int64) (int64, error) {
+	users, err := s.table(txn)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, id := range ids {
+		if _, ok := users[id]; ok {
+			delete(users, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func readIDs(params array.RecordReader) []int64 {
+	var ids []int64
+	if params == nil {
+		return ids
+	}
+	for params.Next() {
+		ids = append(ids, params.Record().Column(0).(*array.Int64).Int64Values()...)
+	}
+	return ids
+}
+
+func (s *sqlStub) GetFlightInfoStatement(ctx context.Context, cmd *flightsql.CommandStatementQuery, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	s.mu.Lock()
+	handle := s.newHandle(&stubStatement{query: cmd.GetQuery(), txn: string(cmd.GetTransactionId())})
+	s.mu.Unlock()
+
+	ticket, err := flightsql.NewTicket(&flightsql.TicketStatementQuery{StatementHandle: handle})
+	if err != nil {
+		return nil, err
+	}
+	return &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(namesSchema, memory.DefaultAllocator),
+		FlightDescriptor: desc,
+		Endpoint:         []*flight.FlightEndpoint{{Ticket: ticket}},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}, nil
+}
+
+func (s *sqlStub) DoGetStatement(ctx context.Context, ticket *flightsql.TicketStatementQuery) (array.RecordReader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stmt, err := s.statement(ticket.GetStatementHandle())
+	if err != nil {
+		return nil, err
+	}
+	delete(s.stmts, string(ticket.GetStatementHandle()))
+	return s.run(stmt)
+}
+
+func (s *sqlStub) ExecuteUpdate(ctx context.Context, cmd *flightsql.CommandStatementUpdate) (int64, error) {
+	var id int64
+	if _, err := fmt.Sscanf(cmd.GetQuery(), deleteByIDFmt, &id); err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "unsupported update %q", cmd.GetQuery())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.delete(string(cmd.GetTransactionId()), []int64{id})
+}
+
+func (s *sqlStub) CreatePreparedStatement(ctx context.Context, req *flightsql.ActionCreatePreparedStatementRequest) (*flightsql.PreparedStatementResult, error) {
+	result := &flightsql.PreparedStatementResult{}
+	switch req.GetQuery() {
+	case selectAll:
+		result.DatasetSchema = namesSchema
+	case selectByID:
+		result.DatasetSchema, result.ParameterSchema = namesSchema, paramsSchema
+	case deleteByID:
+		result.ParameterSchema = paramsSchema
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported query %q", req.GetQuery())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result.Handle = s.newHandle(&stubStatement{query: req.GetQuery(), txn: string(req.GetTransactionId())})
+	return result, nil
+}
+
+func (s *sqlStub) BindParameters(ctx context.Context, cmd *flightsql.CommandPreparedStatementQuery, params array.RecordReader) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stmt, err := s.statement(cmd.GetPreparedStatementHandle())
+	if err != nil {
+		return nil, err
+	}
+	delete(s.stmts, string(cmd.GetPreparedStatementHandle()))
+	return s.newHandle(&stubStatement{query: stmt.query, params: readIDs(params), txn: stmt.txn}), nil
+}
+
+func (s *sqlStub) ExecutePreparedStatement(ctx context.Context, cmd *flightsql.CommandPreparedStatementQuery, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	s.mu.Lock()
+	_, err := s.statement(cmd.GetPreparedStatementHandle())
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := flightsql.NewTicket(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(namesSchema, memory.DefaultAllocator),
+		FlightDescriptor: desc,
+		Endpoint:         []*flight.FlightEndpoint{{Ticket: ticket}},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}, nil
+}
+
+func (s *sqlStub) DoGetPreparedStatement(ctx context.Context, cmd *flightsql.CommandPreparedStatementQuery) (array.RecordReader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stmt, err := s.statement(cmd.GetPreparedStatementHandle())
+	if err != nil {
+		return nil, err
+	}
+	return s.run(stmt)
+}
+
+func (s *sqlStub) ExecutePreparedStatementUpdate(ctx context.Context, cmd *flightsql.CommandPreparedStatementUpdate, params array.RecordReader) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stmt, err := s.statement(cmd.GetPreparedStatementHandle())
+	if err != nil {
+		return 0, err
+	}
+	return s.delete(stmt.txn, readIDs(params))
+}
+
+func (s *sqlStub) ClosePreparedStatement(ctx context.Context, req *flightsql.ActionClosePreparedStatementRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.statement(req.GetPreparedStatementHandle()); err != nil {
+		return err
+	}
+	delete(s.stmts, string(req.GetPreparedStatementHandle()))
+	return nil
+}
+
+func (s *sqlStub) BeginTransaction(ctx context.Context, req *flightsql.ActionBeginTransactionRequest) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	id := fmt.Sprintf("txn-%d", s.next)
+	s.txns[id] = copyUsers(s.users)
+	return []byte(id), nil
+}
+
+func (s *sqlStub) EndTransaction(ctx context.Context, req *flightsql.ActionEndTransactionRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn := string(req.GetTransactionId())
+	users, err := s.table(txn)
+	if err != nil {
+		return err
+	}
+	switch req.GetAction() {
+	case flightsql.ActionEndTransactionRequest_END_TRANSACTION_COMMIT:
+		s.users = users
+	case flightsql.ActionEndTransactionRequest_END_TRANSACTION_ROLLBACK:
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid action %v", req.GetAction())
+	}
+	delete(s.txns, txn)
+	for id, sp := range s.savepoints {
+		if sp.txn == txn {
+			delete(s.savepoints, id)
+		}
+	}
+	return nil
+}
+
+func (s *sqlStub) BeginSavepoint(ctx context.Context, req *flightsql.ActionBeginSavepointRequest) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn := string(req.GetTransactionId())
+	users, err := s.table(txn)
+	if err != nil {
+		return nil, err
+	}
+	id := txn + "/" + req.GetName()
+	s.savepoints[id] = stubSavepoint{txn: txn, users: copyUsers(users)}
+	return []byte(id), nil
+}
+
+func (s *sqlStub) EndSavepoint(ctx context.Context, req *flightsql.ActionEndSavepointRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := string(req.GetSavepointId())
+	sp, ok := s.savepoints[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "no savepoint %q", id)
+	}
+	switch req.GetAction() {
+	case flightsql.ActionEndSavepointRequest_END_SAVEPOINT_RELEASE:
+	case flightsql.ActionEndSavepointRequest_END_SAVEPOINT_ROLLBACK:
+		s.txns[sp.txn] = sp.users
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid action %v", req.GetAction())
+	}
+	delete(s.savepoints, id)
+	return nil
+}
+
+// startSQLStub serves a new stub, returning it along with a client of it
+// and a function stopping them.
+func startSQLStub(t *testing.T) (*sqlStub, *flightsql.Client, func()) {
+	stub := newSQLStub()
+	s := flight.NewFlightServer(nil)
+	if err := s.Init("localhost:0"); err != nil {
+		t.Fatal(err)
+	}
+	s.RegisterFlightService(flightsql.NewService(stub))
+	go s.Serve()
+
+	client, err := flightsql.NewClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		s.Shutdown()
+		t.Fatal(err)
+	}
+	return stub, client, func() {
+		client.Close()
+		s.Shutdown()
+	}
+}
+
+// fetchNames returns the names of the results of info.
+func fetchNames(t *testing.T, client *flightsql.Client, info *flight.FlightInfo) []string {
+	t.Helper()
+	var names []string
+	for _, ep := range info.GetEndpoint() {
+		rdr, err := client.DoGet(context.Background(), ep.GetTicket())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rdr.Next() {
+			col := rdr.Record().Column(0).(*array.String)
+			for i := 0; i < col.Len(); i++ {
+				names = append(names, col.Value(i))
+			}
+		}
+		if err := rdr.Err(); err != nil {
+			t.Fatal(err)
+		}
+		rdr.Release()
+	}
+	return names
+}
+
+func int64Params(ids ...int64) array.Record {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, paramsSchema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+	return bldr.NewRecord()
+}
+
+func TestStatements(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	info, err := client.Execute(ctx, selectAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(fetchNames(t, client, info)); got != "[ada brian claude dennis]" {
+		t.Fatalf("invalid results: %s", got)
+	}
+
+	n, err := client.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("invalid update count: %d", n)
+	}
+
+	info, err = client.Execute(ctx, selectAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(fetchNames(t, client, info)); got != "[ada claude dennis]" {
+		t.Fatalf("invalid results: %s", got)
+	}
+}
+
+func TestPreparedStatement(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	stmt, err := client.Prepare(ctx, selectByID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close(ctx)
+
+	if !stmt.DatasetSchema().Equal(namesSchema) || !stmt.ParameterSchema().Equal(paramsSchema) {
+		t.Fatalf("invalid schemas: %v, %v", stmt.DatasetSchema(), stmt.ParameterSchema())
+	}
+
+	// the same statement runs twice with different bound values, the stub
+	// invalidating the handle of the statement on each binding.
+	for _, tc := range []struct {
+		ids  []int64
+		want string
+	}{
+		{ids: []int64{1}, want: "[ada]"},
+		{ids: []int64{4, 3}, want: "[dennis claude]"},
+	} {
+		handle := stmt.Handle()
+		params := int64Params(tc.ids...)
+		stmt.SetParameters(params)
+		params.Release()
+
+		info, err := stmt.Execute(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(stmt.Handle(), handle) {
+			t.Fatalf("handle was not replaced: %q", handle)
+		}
+		if got := fmt.Sprint(fetchNames(t, client, info)); got != tc.want {
+			t.Fatalf("invalid results for %v: got=%s, want=%s", tc.ids, got, tc.want)
+		}
+
+		// executing again reuses the bound parameters.
+		info, err = stmt.Execute(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(fetchNames(t, client, info)); got != tc.want {
+			t.Fatalf("invalid results for %v: got=%s, want=%s", tc.ids, got, tc.want)
+		}
+	}
+
+	if err := stmt.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := stmt.Close(ctx); err != nil {
+		t.Fatalf("closing twice: %v", err)
+	}
+	if _, err := stmt.Execute(ctx); err == nil {
+		t.Fatal("executed a closed statement")
+	}
+	if len(stub.stmts) != 0 {
+		t.Fatalf("statements left open: %v", stub.stmts)
+	}
+}
+
+func TestPreparedStatementNoParameters(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	stmt, err := client.Prepare(ctx, selectAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close(ctx)
+
+	if stmt.ParameterSchema() != nil {
+		t.Fatalf("unexpected parameter schema: %v", stmt.ParameterSchema())
+	}
+	info, err := stmt.Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(fetchNames(t, client, info)); got != "[ada brian claude dennis]" {
+		t.Fatalf("invalid results: %s", got)
+	}
+}
+
+func TestPreparedStatementUpdate(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	stmt, err := client.Prepare(ctx, deleteByID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close(ctx)
+
+	params := int64Params(1, 3, 42)
+	stmt.SetParameters(params)
+	params.Release()
+
+	n, err := stmt.ExecuteUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || len(stub.users) != 2 {
+		t.Fatalf("invalid update: count=%d, users=%v", n, stub.users)
+	}
+
+	// without parameters, nothing is deleted.
+	stmt.SetParameters(nil)
+	if n, err = stmt.ExecuteUpdate(ctx); err != nil || n != 0 {
+		t.Fatalf("invalid update without parameters: count=%d, err=%v", n, err)
+	}
+}
+
+func TestInvalidatedHandle(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	stmt, err := client.Prepare(ctx, selectAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the server drops the statement behind the back of the client.
+	stub.mu.Lock()
+	delete(stub.stmts, string(stmt.Handle()))
+	stub.mu.Unlock()
+
+	if _, err := stmt.Execute(ctx); status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stmt.Close(ctx); status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUnimplemented(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(flightsql.NewService(flightsql.BaseServer{}))
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flightsql.NewClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.Execute(ctx, selectAll); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.ExecuteUpdate(ctx, deleteByID); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Prepare(ctx, selectAll); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.BeginTransaction(ctx); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetCatalogs(ctx); !xerrors.Is(err, flight.ErrUnimplemented) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubTable is a table ingested into the stub, of which only the shape is
+// kept.
+type stubTable struct {
+	schema    *arrow.Schema
+	temporary bool
+	batches   int
+	rows      int64
+}
+
+func (s *sqlStub) ExecuteIngest(ctx context.Context, cmd *flightsql.CommandStatementIngest, rdr array.RecordReader) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	opts := cmd.GetTableDefinitionOptions()
+	tbl, ok := s.tables[cmd.GetTable()]
+	switch {
+	case !ok && opts.GetIfNotExist() == flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_NOT_EXIST_OPTION_FAIL:
+		return 0, status.Errorf(codes.NotFound, "no table %q", cmd.GetTable())
+	case !ok, opts.GetIfExists() == flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_REPLACE:
+		tbl = &stubTable{schema: rdr.Schema(), temporary: cmd.GetTemporary()}
+	case opts.GetIfExists() == flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_APPEND:
+		if !tbl.schema.Equal(rdr.Schema()) {
+			return 0, status.Errorf(codes.InvalidArgument, "incompatible schema for table %q: %v", cmd.GetTable(), rdr.Schema())
+		}
+	default:
+		return 0, status.Errorf(codes.AlreadyExists, "table %q exists", cmd.GetTable())
+	}
+
+	var n int64
+	for rdr.Next() {
+		tbl.batches++
+		n += rdr.Record().NumRows()
+	}
+	tbl.rows += n
+	s.tables[cmd.GetTable()] = tbl
+	return n, nil
+}
+
+var ingestSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+// batchReader lazily generates batches of rows records of schema, with
+// the ids of their rows.
+type batchReader struct {
+	schema  *arrow.Schema
+	batches int
+	rows    int
+	next    int64
+	rec     array.Record
+}
+
+func (r *batchReader) Retain()               {}
+func (r *batchReader) Release()              { r.releaseRecord() }
+func (r *batchReader) Schema() *arrow.Schema { return r.schema }
+func (r *batchReader) Record() array.Record  { return r.rec }
+
+func (r *batchReader) releaseRecord() {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+}
+
+func (r *batchReader) Next() bool {
+	r.releaseRecord()
+	if r.batches == 0 {
+		return false
+	}
+	r.batches--
+
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, r.schema)
+	defer bldr.Release()
+	for i := 0; i < r.rows; i++ {
+		bldr.Field(0).(*array.Int64Builder).Append(r.next)
+		for _, f := range bldr.Fields()[1:] {
+			f.AppendNull()
+		}
+		r.next++
+	}
+	r.rec = bldr.NewRecord()
+	return true
+}
+
+func ingestCommand(table string, exists flightsql.CommandStatementIngest_TableDefinitionOptions_TableExistsOption) *flightsql.CommandStatementIngest {
+	return &flightsql.CommandStatementIngest{
+		Table: table,
+		TableDefinitionOptions: &flightsql.CommandStatementIngest_TableDefinitionOptions{
+			IfNotExist: flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_NOT_EXIST_OPTION_CREATE,
+			IfExists:   exists,
+		},
+	}
+}
+
+func TestExecuteIngest(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	cmd := ingestCommand("events", flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_APPEND)
+	cmd.Temporary = true
+
+	n, err := client.ExecuteIngest(ctx, &batchReader{schema: ingestSchema, batches: 3, rows: 100}, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 300 {
+		t.Fatalf("invalid ingested rows: %d", n)
+	}
+	tbl := stub.tables["events"]
+	if tbl == nil || !tbl.schema.Equal(ingestSchema) || !tbl.temporary || tbl.batches != 3 || tbl.rows != 300 {
+		t.Fatalf("invalid table: %+v", tbl)
+	}
+
+	// appending to the table.
+	if n, err = client.ExecuteIngest(ctx, &batchReader{schema: ingestSchema, batches: 2, rows: 10}, cmd); err != nil || n != 20 {
+		t.Fatalf("invalid append: rows=%d, err=%v", n, err)
+	}
+	if tbl.rows != 320 {
+		t.Fatalf("invalid table rows: %d", tbl.rows)
+	}
+
+	// failing when the table exists.
+	cmd = ingestCommand("events", flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_FAIL)
+	if _, err = client.ExecuteIngest(ctx, &batchReader{schema: ingestSchema, batches: 1, rows: 1}, cmd); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExecuteIngestEmpty(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	cmd := ingestCommand("empty", flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_FAIL)
+	n, err := client.ExecuteIngest(context.Background(), &batchReader{schema: ingestSchema}, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("invalid ingested rows: %d", n)
+	}
+	// the table is created with the schema of the reader.
+	if tbl := stub.tables["empty"]; tbl == nil || !tbl.schema.Equal(ingestSchema) || tbl.rows != 0 {
+		t.Fatalf("invalid table: %+v", tbl)
+	}
+}
+
+func TestExecuteIngestIncompatibleSchema(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	cmd := ingestCommand("events", flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_APPEND)
+	if _, err := client.ExecuteIngest(ctx, &batchReader{schema: ingestSchema, batches: 1, rows: 10}, cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	// the server rejects the upload while the client is still streaming
+	// it, the client reporting the error of the server.
+	other := arrow.NewSchema([]arrow.Field{{Name: "key", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	_, err := client.ExecuteIngest(ctx, &batchReader{schema: other, batches: 1000, rows: 1000}, cmd)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tbl := stub.tables["events"]; !tbl.schema.Equal(ingestSchema) || tbl.rows != 10 {
+		t.Fatalf("table was modified: %+v", tbl)
+	}
+
+	// replacing the table accepts the new schema.
+	cmd = ingestCommand("events", flightsql.CommandStatementIngest_TableDefinitionOptions_TABLE_EXISTS_OPTION_REPLACE)
+	if n, err := client.ExecuteIngest(ctx, &batchReader{schema: other, batches: 1, rows: 5}, cmd); err != nil || n != 5 {
+		t.Fatalf("invalid replace: rows=%d, err=%v", n, err)
+	}
+	if tbl := stub.tables["events"]; !tbl.schema.Equal(other) || tbl.rows != 5 {
+		t.Fatalf("invalid table: %+v", tbl)
+	}
+}
@@ -0,0 +1,571 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PreparedStatementResult is the prepared statement created by
+// Server.CreatePreparedStatement.
+type PreparedStatementResult struct {
+	// Handle is the opaque handle of the statement, sent back by clients
+	// to execute and close it.
+	Handle []byte
+	// DatasetSchema is the schema of the results of the statement, nil
+	// for statements without results.
+	DatasetSchema *arrow.Schema
+	// ParameterSchema is the schema of the parameters of the statement,
+	// nil for statements without parameters.
+	ParameterSchema *arrow.Schema
+}
+
+// Server is the interface of Flight SQL servers, whose methods handle the
+// Flight SQL commands dispatched by NewService. Servers embed BaseServer
+// for the commands they do not support.
+//
+// The record readers of parameters are nil when clients send no
+// parameters, and are released once the methods return.
+//
+// Queries and prepared statements run within the transaction of the
+// TransactionId of their command, or outside of any when it is empty.
+//
+// The catalog metadata methods return the rows matching the filters of
+// their command, which NewService encodes into the records of the schemas
+// of the specification, see NewTablesRecord.
+type Server interface {
+	// GetFlightInfoStatement returns the FlightInfo of the execution of a
+	// query, whose endpoints carry the tickets of its results, see
+	// NewTicket and DoGetStatement.
+	GetFlightInfoStatement(context.Context, *CommandStatementQuery, *flight.FlightDescriptor) (*flight.FlightInfo, error)
+	// DoGetStatement returns the results of a TicketStatementQuery
+	// ticket. They are released once sent.
+	DoGetStatement(context.Context, *TicketStatementQuery) (array.RecordReader, error)
+	// ExecuteUpdate executes an update query, returning the number of
+	// records it updated, or -1 when it is unknown.
+	ExecuteUpdate(context.Context, *CommandStatementUpdate) (int64, error)
+	// ExecuteIngest loads the records uploaded by the client into the
+	// table of a bulk ingestion command, returning the number of rows
+	// ingested, or -1 when it is unknown. The records are read as they
+	// arrive, so that the table is not held in memory.
+	ExecuteIngest(context.Context, *CommandStatementIngest, array.RecordReader) (int64, error)
+
+	// CreatePreparedStatement creates a prepared statement of a query.
+	CreatePreparedStatement(context.Context, *ActionCreatePreparedStatementRequest) (*PreparedStatementResult, error)
+	// BindParameters binds the parameters of a prepared statement for its
+	// next executions. It may invalidate the handle of the statement and
+	// return the handle replacing it, or nil to keep it.
+	BindParameters(context.Context, *CommandPreparedStatementQuery, array.RecordReader) ([]byte, error)
+	// ExecutePreparedStatement returns the FlightInfo of the execution of
+	// a prepared statement with its bound parameters, whose endpoints
+	// carry the tickets of its results, see NewTicket and
+	// DoGetPreparedStatement.
+	ExecutePreparedStatement(context.Context, *CommandPreparedStatementQuery, *flight.FlightDescriptor) (*flight.FlightInfo, error)
+	// DoGetPreparedStatement returns the results of a
+	// CommandPreparedStatementQuery ticket. They are released once sent.
+	DoGetPreparedStatement(context.Context, *CommandPreparedStatementQuery) (array.RecordReader, error)
+	// ExecutePreparedStatementUpdate executes an update prepared
+	// statement with the given parameters, returning the number of
+	// records it updated, or -1 when it is unknown.
+	ExecutePreparedStatementUpdate(context.Context, *CommandPreparedStatementUpdate, array.RecordReader) (int64, error)
+	// ClosePreparedStatement closes a prepared statement.
+	ClosePreparedStatement(context.Context, *ActionClosePreparedStatementRequest) error
+
+	// GetCatalogs returns the catalogs.
+	GetCatalogs(context.Context, *CommandGetCatalogs) ([]string, error)
+	// GetDBSchemas returns the database schemas matching the filters of
+	// the command.
+	GetDBSchemas(context.Context, *CommandGetDbSchemas) ([]DBSchema, error)
+	// GetTables returns the tables matching the filters of the command,
+	// along with their schemas when the command includes them.
+	GetTables(context.Context, *CommandGetTables) ([]Table, error)
+	// GetPrimaryKeys returns the columns of the primary key of the table
+	// of the command.
+	GetPrimaryKeys(context.Context, *CommandGetPrimaryKeys) ([]PrimaryKey, error)
+
+	// BeginTransaction begins a transaction, returning its opaque id.
+	BeginTransaction(context.Context, *ActionBeginTransactionRequest) ([]byte, error)
+	// EndTransaction commits or rolls back a transaction, releasing its
+	// savepoints.
+	EndTransaction(context.Context, *ActionEndTransactionRequest) error
+	// BeginSavepoint creates a savepoint within a transaction, returning
+	// its opaque id.
+	BeginSavepoint(context.Context, *ActionBeginSavepointRequest) ([]byte, error)
+	// EndSavepoint releases a savepoint, or rolls its transaction back to
+	// it.
+	EndSavepoint(context.Context, *ActionEndSavepointRequest) error
+}
+
+// BaseServer implements the methods of Server by returning an
+// Unimplemented error, for servers to embed.
+type BaseServer struct{}
+
+func (BaseServer) GetFlightInfoStatement(context.Context, *CommandStatementQuery, *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: GetFlightInfoStatement not implemented")
+}
+
+func (BaseServer) DoGetStatement(context.Context, *TicketStatementQuery) (array.RecordReader, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: DoGetStatement not implemented")
+}
+
+func (BaseServer) ExecuteUpdate(context.Context, *CommandStatementUpdate) (int64, error) {
+	return 0, status.Error(codes.Unimplemented, "flightsql: ExecuteUpdate not implemented")
+}
+
+func (BaseServer) ExecuteIngest(context.Context, *CommandStatementIngest, array.RecordReader) (int64, error) {
+	return 0, status.Error(codes.Unimplemented, "flightsql: ExecuteIngest not implemented")
+}
+
+func (BaseServer) CreatePreparedStatement(context.Context, *ActionCreatePreparedStatementRequest) (*PreparedStatementResult, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: CreatePreparedStatement not implemented")
+}
+
+func (BaseServer) BindParameters(context.Context, *CommandPreparedStatementQuery, array.RecordReader) ([]byte, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: BindParameters not implemented")
+}
+
+func (BaseServer) ExecutePreparedStatement(context.Context, *CommandPreparedStatementQuery, *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: ExecutePreparedStatement not implemented")
+}
+
+func (BaseServer) DoGetPreparedStatement(context.Context, *CommandPreparedStatementQuery) (array.RecordReader, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: DoGetPreparedStatement not implemented")
+}
+
+func (BaseServer) ExecutePreparedStatementUpdate(context.Context, *CommandPreparedStatementUpdate, array.RecordReader) (int64, error) {
+	return 0, status.Error(codes.Unimplemented, "flightsql: ExecutePreparedStatementUpdate not implemented")
+}
+
+func (BaseServer) ClosePreparedStatement(context.Context, *ActionClosePreparedStatementRequest) error {
+	return status.Error(codes.Unimplemented, "flightsql: ClosePreparedStatement not implemented")
+}
+
+func (BaseServer) GetCatalogs(context.Context, *CommandGetCatalogs) ([]string, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: GetCatalogs not implemented")
+}
+
+func (BaseServer) GetDBSchemas(context.Context, *CommandGetDbSchemas) ([]DBSchema, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: GetDBSchemas not implemented")
+}
+
+func (BaseServer) GetTables(context.Context, *CommandGetTables) ([]Table, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: GetTables not implemented")
+}
+
+func (BaseServer) GetPrimaryKeys(context.Context, *CommandGetPrimaryKeys) ([]PrimaryKey, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: GetPrimaryKeys not implemented")
+}
+
+func (BaseServer) BeginTransaction(context.Context, *ActionBeginTransactionRequest) ([]byte, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: BeginTransaction not implemented")
+}
+
+func (BaseServer) EndTransaction(context.Context, *ActionEndTransactionRequest) error {
+	return status.Error(codes.Unimplemented, "flightsql: EndTransaction not implemented")
+}
+
+func (BaseServer) BeginSavepoint(context.Context, *ActionBeginSavepointRequest) ([]byte, error) {
+	return nil, status.Error(codes.Unimplemented, "flightsql: BeginSavepoint not implemented")
+}
+
+func (BaseServer) EndSavepoint(context.Context, *ActionEndSavepointRequest) error {
+	return status.Error(codes.Unimplemented, "flightsql: EndSavepoint not implemented")
+}
+
+var _ Server = BaseServer{}
+
+// NewService returns the flight service dispatching the Flight SQL
+// commands to srv, for flight.Server.RegisterFlightService.
+func NewService(srv Server) *flight.FlightServiceService {
+	s := &service{srv: srv, mem: memory.DefaultAllocator}
+	return &flight.FlightServiceService{
+		GetFlightInfo: s.getFlightInfo,
+		DoGet:         s.doGet,
+		DoPut:         s.doPut,
+		DoAction:      s.doAction,
+		ListActions:   s.listActions,
+	}
+}
+
+type service struct {
+	srv Server
+	mem memory.Allocator
+}
+
+// invalidCommand returns the error of a command the service does not
+// handle.
+func invalidCommand(err error, cmd proto.Message) error {
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Errorf(codes.InvalidArgument, "flightsql: unsupported command %T", cmd)
+}
+
+func (s *service) getFlightInfo(ctx context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	cmd, err := unpackCommand(desc.GetCmd())
+	switch cmd := cmd.(type) {
+	case *CommandStatementQuery:
+		return s.srv.GetFlightInfoStatement(ctx, cmd, desc)
+	case *CommandPreparedStatementQuery:
+		return s.srv.ExecutePreparedStatement(ctx, cmd, desc)
+	case *CommandGetCatalogs:
+		return s.metadataInfo(cmd, desc, CatalogsSchema)
+	case *CommandGetDbSchemas:
+		return s.metadataInfo(cmd, desc, DBSchemasSchema)
+	case *CommandGetTables:
+		if cmd.GetIncludeSchema() {
+			return s.metadataInfo(cmd, desc, TablesSchemaWithIncludedSchema)
+		}
+		return s.metadataInfo(cmd, desc, TablesSchema)
+	case *CommandGetPrimaryKeys:
+		return s.metadataInfo(cmd, desc, PrimaryKeysSchema)
+	}
+	return nil, invalidCommand(err, cmd)
+}
+
+// metadataInfo returns the FlightInfo of a catalog metadata command, of a
+// single endpoint whose ticket is the command itself.
+func (s *service) metadataInfo(cmd proto.Message, desc *flight.FlightDescriptor, schema *arrow.Schema) (*flight.FlightInfo, error) {
+	ticket, err := NewTicket(cmd)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(schema, s.mem),
+		FlightDescriptor: desc,
+		Endpoint:         []*flight.FlightEndpoint{{Ticket: ticket}},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}, nil
+}
+
+// metadataRecord returns the record of the results of the catalog
+// metadata command cmd.
+func (s *service) metadataRecord(ctx context.Context, cmd proto.Message) (array.Record, error) {
+	switch cmd := cmd.(type) {
+	case *CommandGetCatalogs:
+		catalogs, err := s.srv.GetCatalogs(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return NewCatalogsRecord(s.mem, catalogs), nil
+	case *CommandGetDbSchemas:
+		schemas, err := s.srv.GetDBSchemas(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return NewDBSchemasRecord(s.mem, schemas), nil
+	case *CommandGetTables:
+		tables, err := s.srv.GetTables(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return NewTablesRecord(s.mem, tables, cmd.GetIncludeSchema()), nil
+	case *CommandGetPrimaryKeys:
+		keys, err := s.srv.GetPrimaryKeys(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return NewPrimaryKeysRecord(s.mem, keys), nil
+	}
+	return nil, invalidCommand(nil, cmd)
+}
+
+func (s *service) doGet(ticket *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	var (
+		ctx      = stream.Context()
+		rdr      array.RecordReader
+		cmd, err = unpackCommand(ticket.GetTicket())
+	)
+	switch cmd := cmd.(type) {
+	case *TicketStatementQuery:
+		rdr, err = s.srv.DoGetStatement(ctx, cmd)
+	case *CommandPreparedStatementQuery:
+		rdr, err = s.srv.DoGetPreparedStatement(ctx, cmd)
+	case *CommandGetCatalogs, *CommandGetDbSchemas, *CommandGetTables, *CommandGetPrimaryKeys:
+		var rec array.Record
+		if rec, err = s.metadataRecord(ctx, cmd); err == nil {
+			rdr, err = array.NewRecordReader(rec.Schema(), []array.Record{rec})
+			rec.Release()
+		}
+	default:
+		return invalidCommand(err, cmd)
+	}
+	if err != nil {
+		return err
+	}
+	defer rdr.Release()
+
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(rdr.Schema()), ipc.WithAllocator(s.mem))
+	defer w.Close()
+	for rdr.Next() {
+		if err := w.Write(rdr.Record()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) doPut(stream flight.FlightService_DoPutServer) error {
+	fd, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	cmd, err := unpackCommand(fd.GetFlightDescriptor().GetCmd())
+	if err != nil {
+		return invalidCommand(err, nil)
+	}
+
+	params, err := s.newParameterReader(fd, stream)
+	if err != nil {
+		return err
+	}
+	if params != nil {
+		defer params.Release()
+	}
+
+	var (
+		ctx    = stream.Context()
+		result proto.Message
+	)
+	switch cmd := cmd.(type) {
+	case *CommandStatementUpdate:
+		n, err := s.srv.ExecuteUpdate(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		result = &DoPutUpdateResult{RecordCount: n}
+	case *CommandStatementIngest:
+		if params == nil {
+			return status.Error(codes.InvalidArgument, "flightsql: no data to ingest")
+		}
+		n, err := s.srv.ExecuteIngest(ctx, cmd, params)
+		if err != nil {
+			return err
+		}
+		result = &DoPutUpdateResult{RecordCount: n}
+	case *CommandPreparedStatementQuery:
+		handle, err := s.srv.BindParameters(ctx, cmd, params)
+		if err != nil {
+			return err
+		}
+		result = &DoPutPreparedStatementResult{PreparedStatementHandle: handle}
+	case *CommandPreparedStatementUpdate:
+		n, err := s.srv.ExecutePreparedStatementUpdate(ctx, cmd, params)
+		if err != nil {
+			return err
+		}
+		result = &DoPutUpdateResult{RecordCount: n}
+	default:
+		return invalidCommand(nil, cmd)
+	}
+
+	md, err := proto.Marshal(result)
+	if err != nil {
+		return status.Errorf(codes.Internal, "flightsql: could not encode result: %v", err)
+	}
+	return stream.Send(&flight.PutResult{AppMetadata: md})
+}
+
+// newParameterReader returns the reader of the parameters sent after, or
+// along with, the first message fd of a DoPut stream, or nil when the
+// client sent none.
+func (s *service) newParameterReader(fd *flight.FlightData, stream flight.DataStreamReader) (array.RecordReader, error) {
+	if len(fd.GetDataHeader()) == 0 {
+		var err error
+		fd, err = stream.Recv()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rdr, err := flight.NewRecordReader(&peekedStream{first: fd, DataStreamReader: stream}, ipc.WithAllocator(s.mem))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "flightsql: could not read parameters: %v", err)
+	}
+	return rdr, nil
+}
+
+// peekedStream replays its first message before the ones of its stream.
+type peekedStream struct {
+	first *flight.FlightData
+	flight.DataStreamReader
+}
+
+func (p *peekedStream) Recv() (*flight.FlightData, error) {
+	if fd := p.first; fd != nil {
+		p.first = nil
+		return fd, nil
+	}
+	return p.DataStreamReader.Recv()
+}
+
+func (s *service) doAction(action *flight.Action, stream flight.FlightService_DoActionServer) error {
+	ctx := stream.Context()
+	switch action.GetType() {
+	case CreatePreparedStatementActionType:
+		var request ActionCreatePreparedStatementRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		stmt, err := s.srv.CreatePreparedStatement(ctx, &request)
+		if err != nil {
+			return err
+		}
+
+		result := &ActionCreatePreparedStatementResult{PreparedStatementHandle: stmt.Handle}
+		if stmt.DatasetSchema != nil {
+			result.DatasetSchema = flight.SerializeSchema(stmt.DatasetSchema, s.mem)
+		}
+		if stmt.ParameterSchema != nil {
+			result.ParameterSchema = flight.SerializeSchema(stmt.ParameterSchema, s.mem)
+		}
+		return sendResult(stream, result)
+
+	case ClosePreparedStatementActionType:
+		var request ActionClosePreparedStatementRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return s.srv.ClosePreparedStatement(ctx, &request)
+
+	case BeginTransactionActionType:
+		var request ActionBeginTransactionRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		id, err := s.srv.BeginTransaction(ctx, &request)
+		if err != nil {
+			return err
+		}
+		return sendResult(stream, &ActionBeginTransactionResult{TransactionId: id})
+
+	case EndTransactionActionType:
+		var request ActionEndTransactionRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return s.srv.EndTransaction(ctx, &request)
+
+	case BeginSavepointActionType:
+		var request ActionBeginSavepointRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		id, err := s.srv.BeginSavepoint(ctx, &request)
+		if err != nil {
+			return err
+		}
+		return sendResult(stream, &ActionBeginSavepointResult{SavepointId: id})
+
+	case EndSavepointActionType:
+		var request ActionEndSavepointRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return s.srv.EndSavepoint(ctx, &request)
+
+	case SetSessionOptionsActionType, GetSessionOptionsActionType, CloseSessionActionType:
+		sessions, ok := s.srv.(SessionServer)
+		if !ok {
+			return status.Errorf(codes.Unimplemented, "flightsql: %s not implemented", action.GetType())
+		}
+		return s.doSessionAction(ctx, sessions, action, stream)
+	}
+	return status.Errorf(codes.Unimplemented, "flightsql: unknown action %q", action.GetType())
+}
+
+func (s *service) doSessionAction(ctx context.Context, sessions SessionServer, action *flight.Action, stream flight.FlightService_DoActionServer) error {
+	switch action.GetType() {
+	case SetSessionOptionsActionType:
+		var request SetSessionOptionsRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		errs, err := sessions.SetSessionOptions(ctx, decodeOptions(request.GetSessionOptions()))
+		if err != nil {
+			return err
+		}
+		result := &SetSessionOptionsResult{Errors: make(map[string]*SetSessionOptionsResult_Error, len(errs))}
+		for name, e := range errs {
+			result.Errors[name] = &SetSessionOptionsResult_Error{Value: e}
+		}
+		return sendResult(stream, result)
+
+	case GetSessionOptionsActionType:
+		var request GetSessionOptionsRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts, err := sessions.GetSessionOptions(ctx)
+		if err != nil {
+			return err
+		}
+		return sendResult(stream, &GetSessionOptionsResult{SessionOptions: encodeOptions(opts)})
+
+	default:
+		var request CloseSessionRequest
+		if err := unpackMessage(action.GetBody(), &request); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		st, err := sessions.CloseSession(ctx)
+		if err != nil {
+			return err
+		}
+		return sendResult(stream, &CloseSessionResult{Status: st})
+	}
+}
+
+// sendResult sends the packed result as the result of an action.
+func sendResult(stream flight.FlightService_DoActionServer, result proto.Message) error {
+	body, err := packCommand(result)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.Send(&flight.Result{Body: body})
+}
+
+func (s *service) listActions(_ *flight.Empty, stream flight.FlightService_ListActionsServer) error {
+	types := []*flight.ActionType{
+		CreatePreparedStatementAction, ClosePreparedStatementAction,
+		BeginTransactionAction, EndTransactionAction,
+		BeginSavepointAction, EndSavepointAction,
+	}
+	if _, ok := s.srv.(SessionServer); ok {
+		types = append(types, SetSessionOptionsAction, GetSessionOptionsAction, CloseSessionAction)
+	}
+	for _, typ := range types {
+		if err := stream.Send(typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The types of the session actions.
+const (
+	SetSessionOptionsActionType = "SetSessionOptions"
+	GetSessionOptionsActionType = "GetSessionOptions"
+	CloseSessionActionType      = "CloseSession"
+)
+
+var (
+	// SetSessionOptionsAction describes the SetSessionOptions action for
+	// ListActions.
+	SetSessionOptionsAction = &flight.ActionType{
+		Type:        SetSessionOptionsActionType,
+		Description: "Sets options of the session.\nRequest Message: SetSessionOptionsRequest\nResponse Message: SetSessionOptionsResult",
+	}
+	// GetSessionOptionsAction describes the GetSessionOptions action for
+	// ListActions.
+	GetSessionOptionsAction = &flight.ActionType{
+		Type:        GetSessionOptionsActionType,
+		Description: "Gets the options of the session.\nRequest Message: GetSessionOptionsRequest\nResponse Message: GetSessionOptionsResult",
+	}
+	// CloseSessionAction describes the CloseSession action for
+	// ListActions.
+	CloseSessionAction = &flight.ActionType{
+		Type:        CloseSessionActionType,
+		Description: "Closes the session.\nRequest Message: CloseSessionRequest\nResponse Message: CloseSessionResult",
+	}
+)
+
+// OptionValue is the value of a session option, one of StringOption,
+// BoolOption, Int64Option, DoubleOption and StringListOption. Setting an
+// option to a nil OptionValue removes it.
+type OptionValue interface {
+	// Value returns the value as a string, bool, int64, float64 or
+	// []string.
+	Value() interface{}
+
+	encode() *SessionOptionValue
+}
+
+// StringOption is a string session option value.
+type StringOption string
+
+// BoolOption is a boolean session option value.
+type BoolOption bool
+
+// Int64Option is an integer session option value.
+type Int64Option int64
+
+// DoubleOption is a floating-point session option value.
+type DoubleOption float64
+
+// StringListOption is a string list session option value.
+type StringListOption []string
+
+func (v StringOption) Value() interface{}     { return string(v) }
+func (v BoolOption) Value() interface{}       { return bool(v) }
+func (v Int64Option) Value() interface{}      { return int64(v) }
+func (v DoubleOption) Value() interface{}     { return float64(v) }
+func (v StringListOption) Value() interface{} { return []string(v) }
+
+func (v StringOption) encode() *SessionOptionValue {
+	return &SessionOptionValue{OptionValue: &SessionOptionValue_StringValue{StringValue: string(v)}}
+}
+
+func (v BoolOption) encode() *SessionOptionValue {
+	return &SessionOptionValue{OptionValue: &SessionOptionValue_BoolValue{BoolValue: bool(v)}}
+}
+
+func (v Int64Option) encode() *SessionOptionValue {
+	return &SessionOptionValue{OptionValue: &SessionOptionValue_Int64Value{Int64Value: int64(v)}}
+}
+
+func (v DoubleOption) encode() *SessionOptionValue {
+	return &SessionOptionValue{OptionValue: &SessionOptionValue_DoubleValue{DoubleValue: float64(v)}}
+}
+
+func (v StringListOption) encode() *SessionOptionValue {
+	list := &SessionOptionValue_StringListValue{Values: []string(v)}
+	return &SessionOptionValue{OptionValue: &SessionOptionValue_StringListValue_{StringListValue: list}}
+}
+
+// NewOptionValue returns the option value of v, a string, a bool, an
+// integer, a floating-point number, a []string, an OptionValue or nil.
+func NewOptionValue(v interface{}) (OptionValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case OptionValue:
+		return v, nil
+	case string:
+		return StringOption(v), nil
+	case bool:
+		return BoolOption(v), nil
+	case int:
+		return Int64Option(v), nil
+	case int8:
+		return Int64Option(v), nil
+	case int16:
+		return Int64Option(v), nil
+	case int32:
+		return Int64Option(v), nil
+	case int64:
+		return Int64Option(v), nil
+	case uint8:
+		return Int64Option(v), nil
+	case uint16:
+		return Int64Option(v), nil
+	case uint32:
+		return Int64Option(v), nil
+	case float32:
+		return DoubleOption(v), nil
+	case float64:
+		return DoubleOption(v), nil
+	case []string:
+		return StringListOption(v), nil
+	}
+	return nil, xerrors.Errorf("flightsql: invalid session option value of type %T", v)
+}
+
+// EncodeOptionValue returns the message of the option value v, without
+// value when v is nil.
+func EncodeOptionValue(v OptionValue) *SessionOptionValue {
+	if v == nil {
+		return &SessionOptionValue{}
+	}
+	return v.encode()
+}
+
+// DecodeOptionValue returns the option value of the message v, nil when it
+// has no value.
+func DecodeOptionValue(v *SessionOptionValue) OptionValue {
+	switch v := v.GetOptionValue().(type) {
+	case *SessionOptionValue_StringValue:
+		return StringOption(v.StringValue)
+	case *SessionOptionValue_BoolValue:
+		return BoolOption(v.BoolValue)
+	case *SessionOptionValue_Int64Value:
+		return Int64Option(v.Int64Value)
+	case *SessionOptionValue_DoubleValue:
+		return DoubleOption(v.DoubleValue)
+	case *SessionOptionValue_StringListValue_:
+		return StringListOption(v.StringListValue.GetValues())
+	}
+	return nil
+}
+
+func encodeOptions(opts map[string]OptionValue) map[string]*SessionOptionValue {
+	msgs := make(map[string]*SessionOptionValue, len(opts))
+	for name, v := range opts {
+		msgs[name] = EncodeOptionValue(v)
+	}
+	return msgs
+}
+
+func decodeOptions(msgs map[string]*SessionOptionValue) map[string]OptionValue {
+	opts := make(map[string]OptionValue, len(msgs))
+	for name, v := range msgs {
+		opts[name] = DecodeOptionValue(v)
+	}
+	return opts
+}
+
+// SetSessionOptions sets the options of the session of the client,
+// removing the ones set to nil. It returns the errors of the options the
+// server did not set.
+func (c *Client) SetSessionOptions(ctx context.Context, opts map[string]OptionValue, callOpts ...grpc.CallOption) (map[string]SetSessionOptionsResult_ErrorValue, error) {
+	var result SetSessionOptionsResult
+	request := &SetSessionOptionsRequest{SessionOptions: encodeOptions(opts)}
+	if err := c.doAction(ctx, SetSessionOptionsActionType, request, &result, callOpts...); err != nil {
+		return nil, err
+	}
+	errs := make(map[string]SetSessionOptionsResult_ErrorValue, len(result.Errors))
+	for name, e := range result.Errors {
+		errs[name] = e.GetValue()
+	}
+	return errs, nil
+}
+
+// GetSessionOptions returns the options of the session of the client.
+func (c *Client) GetSessionOptions(ctx context.Context, opts ...grpc.CallOption) (map[string]OptionValue, error) {
+	var result GetSessionOptionsResult
+	if err := c.doAction(ctx, GetSessionOptionsActionType, &GetSessionOptionsRequest{}, &result, opts...); err != nil {
+		return nil, err
+	}
+	return decodeOptions(result.SessionOptions), nil
+}
+
+// CloseSession closes the session of the client, returning its status.
+func (c *Client) CloseSession(ctx context.Context, opts ...grpc.CallOption) (CloseSessionResult_Status, error) {
+	var result CloseSessionResult
+	if err := c.doAction(ctx, CloseSessionActionType, &CloseSessionRequest{}, &result, opts...); err != nil {
+		return CloseSessionResult_UNSPECIFIED, err
+	}
+	return result.Status, nil
+}
+
+// SessionServer is implemented by the servers supporting sessions, as by
+// embedding a SessionStore. NewService answers the session actions with
+// an Unimplemented error for the other servers.
+type SessionServer interface {
+	// SetSessionOptions sets the options of the session of the call,
+	// removing the ones set to nil, and returns the errors of the options
+	// it did not set.
+	SetSessionOptions(context.Context, map[string]OptionValue) (map[string]SetSessionOptionsResult_ErrorValue, error)
+	// GetSessionOptions returns the options of the session of the call.
+	GetSessionOptions(context.Context) (map[string]OptionValue, error)
+	// CloseSession closes the session of the call.
+	CloseSession(context.Context) (CloseSessionResult_Status, error)
+}
+
+// SessionKeyFunc returns the key of the session of a call.
+type SessionKeyFunc func(ctx context.Context) (string, error)
+
+// SessionFromAuth keys the sessions by the identity of the authenticated
+// clients, see flight.AuthFromContext.
+func SessionFromAuth(ctx context.Context) (string, error) {
+	id := flight.AuthFromContext(ctx)
+	if id == nil {
+		return "", status.Error(codes.Unauthenticated, "flightsql: no session without authentication")
+	}
+	return fmt.Sprint(id), nil
+}
+
+// SessionFromCookie returns a SessionKeyFunc keying the sessions by the
+// value of the cookie name. The calls without the cookie get a new session
+// whose cookie is set on the response, for clients using
+// flight.NewCookieMiddleware to send it back.
+func SessionFromCookie(name string) SessionKeyFunc {
+	return func(ctx context.Context) (string, error) {
+		for _, c := range flight.CookiesFromContext(ctx) {
+			if c.Name == name {
+				return c.Value, nil
+			}
+		}
+
+		var b [16]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return "", status.Errorf(codes.Internal, "flightsql: could not create session: %v", err)
+		}
+		key := hex.EncodeToString(b[:])
+		if err := flight.SetCookie(ctx, &http.Cookie{Name: name, Value: key}); err != nil {
+			return "", err
+		}
+		return key, nil
+	}
+}
+
+// SessionStore is an in-memory SessionServer, for servers to embed. The
+// options of the session of a call are given by GetSessionOptions.
+type SessionStore struct {
+	// Validate, when set, returns the error of an option set by a client,
+	// or UNSPECIFIED for a valid option. The options removed are not
+	// validated.
+	Validate func(name string, v OptionValue) SetSessionOptionsResult_ErrorValue
+
+	key      SessionKeyFunc
+	mu       sync.Mutex
+	sessions map[string]map[string]OptionValue
+}
+
+// NewSessionStore returns an empty session store whose sessions are keyed
+// by key, such as SessionFromAuth.
+func NewSessionStore(key SessionKeyFunc) *SessionStore {
+	return &SessionStore{key: key, sessions: make(map[string]map[string]OptionValue)}
+}
+
+func (s *SessionStore) SetSessionOptions(ctx context.Context, opts map[string]OptionValue) (map[string]SetSessionOptionsResult_ErrorValue, error) {
+	key, err := s.key(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(map[string]SetSessionOptionsResult_ErrorValue)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session := s.sessions[key]
+	if session == nil {
+		session = make(map[string]OptionValue)
+		s.sessions[key] = session
+	}
+	for name, v := range opts {
+		if v == nil {
+			delete(session, name)
+			continue
+		}
+		if s.Validate != nil {
+			if e := s.Validate(name, v); e != SetSessionOptionsResult_UNSPECIFIED {
+				errs[name] = e
+				continue
+			}
+		}
+		session[name] = v
+	}
+	return errs, nil
+}
+
+func (s *SessionStore) GetSessionOptions(ctx context.Context) (map[string]OptionValue, error) {
+	key, err := s.key(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	opts := make(map[string]OptionValue, len(s.sessions[key]))
+	for name, v := range s.sessions[key] {
+		opts[name] = v
+	}
+	return opts, nil
+}
+
+func (s *SessionStore) CloseSession(ctx context.Context) (CloseSessionResult_Status, error) {
+	key, err := s.key(ctx)
+	if err != nil {
+		return CloseSessionResult_UNSPECIFIED, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, key)
+	return CloseSessionResult_CLOSED, nil
+}
+
+var _ SessionServer = (*SessionStore)(nil)
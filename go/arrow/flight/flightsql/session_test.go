@@ -0,0 +1,201 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOptionValueRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		in   interface{}
+		want flightsql.OptionValue
+	}{
+		{in: "utc", want: flightsql.StringOption("utc")},
+		{in: "", want: flightsql.StringOption("")},
+		{in: true, want: flightsql.BoolOption(true)},
+		{in: false, want: flightsql.BoolOption(false)},
+		{in: 42, want: flightsql.Int64Option(42)},
+		{in: int32(-7), want: flightsql.Int64Option(-7)},
+		{in: int64(-1 << 62), want: flightsql.Int64Option(-1 << 62)},
+		{in: 2.5, want: flightsql.DoubleOption(2.5)},
+		{in: float32(0.5), want: flightsql.DoubleOption(0.5)},
+		{in: []string{"a", "b"}, want: flightsql.StringListOption{"a", "b"}},
+		{in: flightsql.StringOption("x"), want: flightsql.StringOption("x")},
+		{in: nil, want: nil},
+	} {
+		v, err := flightsql.NewOptionValue(tc.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, tc.want) {
+			t.Fatalf("invalid value of %#v: got=%#v, want=%#v", tc.in, v, tc.want)
+		}
+
+		b, err := proto.Marshal(flightsql.EncodeOptionValue(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msg flightsql.SessionOptionValue
+		if err := proto.Unmarshal(b, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if got := flightsql.DecodeOptionValue(&msg); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid round trip of %#v: got=%#v, want=%#v", tc.in, got, tc.want)
+		}
+		if v != nil && reflect.TypeOf(v.Value()).Kind() != reflect.TypeOf(v).Kind() {
+			t.Fatalf("invalid Go value of %#v: %#v", v, v.Value())
+		}
+	}
+
+	// an empty list is still a value, unlike a missing one.
+	if v := flightsql.DecodeOptionValue(flightsql.EncodeOptionValue(flightsql.StringListOption{})); v == nil || len(v.(flightsql.StringListOption)) != 0 {
+		t.Fatalf("invalid empty list: %#v", v)
+	}
+
+	if _, err := flightsql.NewOptionValue(struct{}{}); err == nil {
+		t.Fatal("converted an invalid value")
+	}
+}
+
+type sessionServer struct {
+	flightsql.BaseServer
+	*flightsql.SessionStore
+}
+
+// startSessionServer serves sessions keyed by cookies, returning the
+// server along with a function creating new clients of it.
+func startSessionServer(t *testing.T) (flight.Server, func() *flightsql.Client) {
+	store := flightsql.NewSessionStore(flightsql.SessionFromCookie("session"))
+	store.Validate = func(name string, v flightsql.OptionValue) flightsql.SetSessionOptionsResult_ErrorValue {
+		switch {
+		case name == "unknown":
+			return flightsql.SetSessionOptionsResult_INVALID_NAME
+		case name == "timeout" && v.Value().(int64) < 0:
+			return flightsql.SetSessionOptionsResult_INVALID_VALUE
+		}
+		return flightsql.SetSessionOptionsResult_UNSPECIFIED
+	}
+
+	s := flight.NewFlightServer(nil)
+	if err := s.Init("localhost:0"); err != nil {
+		t.Fatal(err)
+	}
+	s.RegisterFlightService(flightsql.NewService(&sessionServer{SessionStore: store}))
+	go s.Serve()
+
+	return s, func() *flightsql.Client {
+		client, err := flight.NewClientWithMiddleware(s.Addr().String(), nil, []flight.ClientMiddleware{flight.NewCookieMiddleware()}, grpc.WithInsecure())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &flightsql.Client{Client: client}
+	}
+}
+
+func TestSessionOptions(t *testing.T) {
+	srv, newClient := startSessionServer(t)
+	defer srv.Shutdown()
+
+	ctx := context.Background()
+	client := newClient()
+	defer client.Close()
+
+	opts := map[string]flightsql.OptionValue{
+		"catalog": flightsql.StringOption("main"),
+		"verbose": flightsql.BoolOption(true),
+		"timeout": flightsql.Int64Option(30),
+		"ratio":   flightsql.DoubleOption(0.25),
+		"path":    flightsql.StringListOption{"public", "audit"},
+		"unknown": flightsql.StringOption("x"),
+	}
+	errs, err := client.SetSessionOptions(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]flightsql.SetSessionOptionsResult_ErrorValue{"unknown": flightsql.SetSessionOptionsResult_INVALID_NAME}; !reflect.DeepEqual(errs, want) {
+		t.Fatalf("invalid errors: %v", errs)
+	}
+
+	got, err := client.GetSessionOptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(opts, "unknown")
+	if !reflect.DeepEqual(got, opts) {
+		t.Fatalf("invalid options: got=%v, want=%v", got, opts)
+	}
+
+	// an invalid value leaves the option unchanged, and an option without
+	// value is removed.
+	errs, err = client.SetSessionOptions(ctx, map[string]flightsql.OptionValue{
+		"timeout": flightsql.Int64Option(-1),
+		"verbose": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs["timeout"] != flightsql.SetSessionOptionsResult_INVALID_VALUE {
+		t.Fatalf("invalid errors: %v", errs)
+	}
+	if got, err = client.GetSessionOptions(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["verbose"]; ok || got["timeout"] != flightsql.Int64Option(30) || len(got) != 4 {
+		t.Fatalf("invalid options: %v", got)
+	}
+
+	// the sessions of other clients are separate.
+	other := newClient()
+	defer other.Close()
+	if got, err := other.GetSessionOptions(ctx); err != nil || len(got) != 0 {
+		t.Fatalf("invalid options of another session: %v, %v", got, err)
+	}
+
+	st, err := client.CloseSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st != flightsql.CloseSessionResult_CLOSED {
+		t.Fatalf("invalid status: %v", st)
+	}
+	if got, err = client.GetSessionOptions(ctx); err != nil || len(got) != 0 {
+		t.Fatalf("invalid options of a closed session: %v, %v", got, err)
+	}
+}
+
+func TestSessionUnimplemented(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	if _, err := client.GetSessionOptions(ctx); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.CloseSession(ctx); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+var (
+	errTxnDone       = xerrors.New("flightsql: transaction is committed or rolled back")
+	errSavepointDone = xerrors.New("flightsql: savepoint is released or rolled back")
+)
+
+// BeginTransaction begins a transaction on the server. The transaction
+// must be committed or rolled back once done with; rolling it back in a
+// defer is safe since rolling back an ended transaction does nothing.
+func (c *Client) BeginTransaction(ctx context.Context, opts ...grpc.CallOption) (*Txn, error) {
+	var result ActionBeginTransactionResult
+	if err := c.doAction(ctx, BeginTransactionActionType, &ActionBeginTransactionRequest{}, &result, opts...); err != nil {
+		return nil, err
+	}
+	if len(result.TransactionId) == 0 {
+		return nil, xerrors.New("flightsql: server returned an empty transaction id")
+	}
+	return &Txn{client: c, id: result.TransactionId}, nil
+}
+
+// Txn is a transaction on a Flight SQL server, created with
+// Client.BeginTransaction. The queries and prepared statements created
+// from it run within the transaction. It is not safe for concurrent use.
+type Txn struct {
+	client *Client
+	id     []byte
+	done   bool
+}
+
+// ID returns the opaque id of the transaction on the server.
+func (tx *Txn) ID() []byte { return tx.id }
+
+// Execute executes the query within the transaction, see Client.Execute.
+func (tx *Txn) Execute(ctx context.Context, query string, opts ...grpc.CallOption) (*flight.FlightInfo, error) {
+	if tx.done {
+		return nil, errTxnDone
+	}
+	return tx.client.execute(ctx, query, tx.id, opts...)
+}
+
+// ExecuteUpdate executes the update query within the transaction, see
+// Client.ExecuteUpdate.
+func (tx *Txn) ExecuteUpdate(ctx context.Context, query string, opts ...grpc.CallOption) (int64, error) {
+	if tx.done {
+		return 0, errTxnDone
+	}
+	return tx.client.executeUpdate(ctx, query, tx.id, opts...)
+}
+
+// Prepare creates a prepared statement of the query whose executions run
+// within the transaction, see Client.Prepare.
+func (tx *Txn) Prepare(ctx context.Context, query string, opts ...grpc.CallOption) (*PreparedStatement, error) {
+	if tx.done {
+		return nil, errTxnDone
+	}
+	return tx.client.prepare(ctx, query, tx.id, opts...)
+}
+
+// ExecuteIngest loads the records of rdr into the table of cmd within the
+// transaction, see Client.ExecuteIngest. The TransactionId of cmd is
+// ignored.
+func (tx *Txn) ExecuteIngest(ctx context.Context, rdr array.RecordReader, cmd *CommandStatementIngest, opts ...grpc.CallOption) (int64, error) {
+	if tx.done {
+		return 0, errTxnDone
+	}
+	cmd = proto.Clone(cmd).(*CommandStatementIngest)
+	cmd.TransactionId = tx.id
+	return tx.client.ExecuteIngest(ctx, rdr, cmd, opts...)
+}
+
+// Commit commits the transaction. The transaction cannot be used once
+// committed.
+func (tx *Txn) Commit(ctx context.Context, opts ...grpc.CallOption) error {
+	if tx.done {
+		return errTxnDone
+	}
+	return tx.end(ctx, ActionEndTransactionRequest_END_TRANSACTION_COMMIT, opts...)
+}
+
+// Rollback rolls back the transaction. Rolling back a committed or rolled
+// back transaction does nothing.
+func (tx *Txn) Rollback(ctx context.Context, opts ...grpc.CallOption) error {
+	if tx.done {
+		return nil
+	}
+	return tx.end(ctx, ActionEndTransactionRequest_END_TRANSACTION_ROLLBACK, opts...)
+}
+
+func (tx *Txn) end(ctx context.Context, action ActionEndTransactionRequest_EndTransaction, opts ...grpc.CallOption) error {
+	request := &ActionEndTransactionRequest{TransactionId: tx.id, Action: action}
+	if err := tx.client.doAction(ctx, EndTransactionActionType, request, nil, opts...); err != nil {
+		return err
+	}
+	tx.done = true
+	return nil
+}
+
+// BeginSavepoint creates a savepoint named name within the transaction.
+func (tx *Txn) BeginSavepoint(ctx context.Context, name string, opts ...grpc.CallOption) (*Savepoint, error) {
+	if tx.done {
+		return nil, errTxnDone
+	}
+	var result ActionBeginSavepointResult
+	request := &ActionBeginSavepointRequest{TransactionId: tx.id, Name: name}
+	if err := tx.client.doAction(ctx, BeginSavepointActionType, request, &result, opts...); err != nil {
+		return nil, err
+	}
+	if len(result.SavepointId) == 0 {
+		return nil, xerrors.New("flightsql: server returned an empty savepoint id")
+	}
+	return &Savepoint{tx: tx, id: result.SavepointId}, nil
+}
+
+// Savepoint is a savepoint within a transaction, created with
+// Txn.BeginSavepoint. It is not safe for concurrent use.
+type Savepoint struct {
+	tx   *Txn
+	id   []byte
+	done bool
+}
+
+// ID returns the opaque id of the savepoint on the server.
+func (sp *Savepoint) ID() []byte { return sp.id }
+
+// Release releases the savepoint, keeping the changes made since.
+func (sp *Savepoint) Release(ctx context.Context, opts ...grpc.CallOption) error {
+	return sp.end(ctx, ActionEndSavepointRequest_END_SAVEPOINT_RELEASE, opts...)
+}
+
+// Rollback rolls the transaction back to the savepoint, discarding the
+// changes made since.
+func (sp *Savepoint) Rollback(ctx context.Context, opts ...grpc.CallOption) error {
+	return sp.end(ctx, ActionEndSavepointRequest_END_SAVEPOINT_ROLLBACK, opts...)
+}
+
+func (sp *Savepoint) end(ctx context.Context, action ActionEndSavepointRequest_EndSavepoint, opts ...grpc.CallOption) error {
+	switch {
+	case sp.tx.done:
+		return errTxnDone
+	case sp.done:
+		return errSavepointDone
+	}
+	request := &ActionEndSavepointRequest{SavepointId: sp.id, Action: action}
+	if err := sp.tx.client.doAction(ctx, EndSavepointActionType, request, nil, opts...); err != nil {
+		return err
+	}
+	sp.done = true
+	return nil
+}
@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flightsql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight/flightsql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// selectNames returns the names of all the users seen by the transaction
+// txn, or outside of any when it is nil.
+func selectNames(t *testing.T, client *flightsql.Client, txn *flightsql.Txn) string {
+	t.Helper()
+	ctx := context.Background()
+	execute := client.Execute
+	if txn != nil {
+		execute = txn.Execute
+	}
+	info, err := execute(ctx, selectAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fmt.Sprint(fetchNames(t, client, info))
+}
+
+func TestTransactionCommit(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	txn, err := client.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer txn.Rollback(ctx)
+
+	if n, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 1)); err != nil || n != 1 {
+		t.Fatalf("invalid update: count=%d, err=%v", n, err)
+	}
+
+	stmt, err := txn.Prepare(ctx, deleteByID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := int64Params(2)
+	stmt.SetParameters(params)
+	params.Release()
+	if n, err := stmt.ExecuteUpdate(ctx); err != nil || n != 1 {
+		t.Fatalf("invalid prepared update: count=%d, err=%v", n, err)
+	}
+	if err := stmt.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the changes are only seen within the transaction until committed.
+	if got := selectNames(t, client, txn); got != "[claude dennis]" {
+		t.Fatalf("invalid results within the transaction: %s", got)
+	}
+	if got := selectNames(t, client, nil); got != "[ada brian claude dennis]" {
+		t.Fatalf("invalid results outside the transaction: %s", got)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := selectNames(t, client, nil); got != "[claude dennis]" {
+		t.Fatalf("invalid results after commit: %s", got)
+	}
+
+	// the transaction cannot be used once committed, but rolling it back
+	// does nothing.
+	if _, err := txn.Execute(ctx, selectAll); err == nil {
+		t.Fatal("executed a query in a committed transaction")
+	}
+	if _, err := txn.Prepare(ctx, selectAll); err == nil {
+		t.Fatal("prepared a statement in a committed transaction")
+	}
+	if err := txn.Commit(ctx); err == nil {
+		t.Fatal("committed a transaction twice")
+	}
+	if err := txn.Rollback(ctx); err != nil {
+		t.Fatalf("rolling back a committed transaction: %v", err)
+	}
+	if len(stub.txns) != 0 {
+		t.Fatalf("transactions left open: %v", stub.txns)
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	txn, err := client.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if got := selectNames(t, client, txn); got != "[ada brian dennis]" {
+		t.Fatalf("invalid results within the transaction: %s", got)
+	}
+
+	if err := txn.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Rollback(ctx); err != nil {
+		t.Fatalf("rolling back twice: %v", err)
+	}
+	if _, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 3)); err == nil {
+		t.Fatal("executed an update in a rolled back transaction")
+	}
+	if got := selectNames(t, client, nil); got != "[ada brian claude dennis]" {
+		t.Fatalf("invalid results after rollback: %s", got)
+	}
+	if len(stub.txns) != 0 {
+		t.Fatalf("transactions left open: %v", stub.txns)
+	}
+}
+
+func TestSavepoints(t *testing.T) {
+	_, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	txn, err := client.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer txn.Rollback(ctx)
+
+	if _, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 1)); err != nil {
+		t.Fatal(err)
+	}
+	sp, err := txn.BeginSavepoint(ctx, "sp1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sp.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := sp.Release(ctx); err == nil {
+		t.Fatal("released a rolled back savepoint")
+	}
+	if got := selectNames(t, client, txn); got != "[brian claude dennis]" {
+		t.Fatalf("invalid results after rolling back to the savepoint: %s", got)
+	}
+
+	sp, err = txn.BeginSavepoint(ctx, "sp2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sp.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := txn.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := selectNames(t, client, nil); got != "[brian claude]" {
+		t.Fatalf("invalid results after commit: %s", got)
+	}
+}
+
+func TestTransactionDropped(t *testing.T) {
+	stub, client, stop := startSQLStub(t)
+	defer stop()
+
+	ctx := context.Background()
+	txn, err := client.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the server drops the transaction behind the back of the client.
+	stub.mu.Lock()
+	delete(stub.txns, string(txn.ID()))
+	stub.mu.Unlock()
+
+	if _, err := txn.ExecuteUpdate(ctx, fmt.Sprintf(deleteByIDFmt, 1)); status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := txn.Commit(ctx); status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := selectNames(t, client, nil); got != "[ada brian claude dennis]" {
+		t.Fatalf("invalid results: %s", got)
+	}
+}
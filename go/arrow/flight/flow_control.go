@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// FlowControl configures the flow control of a Writer, see
+// Writer.SetFlowControl.
+type FlowControl struct {
+	// MaxOutstandingBytes caps the bytes of the messages written but not
+	// yet sent, or not yet acknowledged with Acked. A message larger than
+	// the cap is written once nothing else is outstanding.
+	MaxOutstandingBytes int64
+	// Acked keeps the record batches sent outstanding until the peer
+	// acknowledges them, as reported with Writer.Ack, for instance from
+	// the PutResult messages a DoPut handler sends for each record.
+	// Writes waiting for acknowledgements fail once the context of the
+	// stream is done, as when the peer disconnects.
+	Acked bool
+	// Mem allocates the messages waiting to be sent, it defaults to
+	// memory.DefaultAllocator.
+	Mem memory.Allocator
+}
+
+// WriterStats are the statistics of the messages of a Writer under flow
+// control.
+type WriterStats struct {
+	// QueuedMessages and QueuedBytes are the messages written and waiting
+	// to be sent.
+	QueuedMessages int
+	QueuedBytes    int64
+	// OutstandingBytes are the bytes queued, and sent but not yet
+	// acknowledged under FlowControl.Acked.
+	OutstandingBytes int64
+	// SentMessages and SentBytes are the messages sent so far.
+	SentMessages int64
+	SentBytes    int64
+}
+
+type queuedMessage struct {
+	fd   *FlightData
+	buf  *memory.Buffer
+	size int64
+}
+
+// flowSender sends the messages queued by a writer from its own goroutine,
+// blocking the writer while the outstanding bytes are over the cap.
+type flowSender struct {
+	w  DataStreamWriter
+	fc FlowControl
+
+	mu      sync.Mutex
+	cond    sync.Cond
+	queue   []queuedMessage
+	unacked []int64
+	stats   WriterStats
+	err     error
+	closed  bool
+	done    chan struct{}
+}
+
+func newFlowSender(w DataStreamWriter, fc FlowControl) *flowSender {
+	if fc.Mem == nil {
+		fc.Mem = memory.DefaultAllocator
+	}
+	s := &flowSender{w: w, fc: fc, done: make(chan struct{})}
+	s.cond.L = &s.mu
+	go s.run()
+	if c, ok := w.(interface{ Context() context.Context }); ok {
+		go s.watch(c.Context())
+	}
+	return s
+}
+
+// watch fails the writer once ctx is done, as when the peer disconnects,
+// so that a writer waiting for acknowledgements that never come returns.
+func (s *flowSender) watch(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		s.mu.Lock()
+		if s.err == nil {
+			s.err = ctx.Err()
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+	case <-s.done:
+	}
+}
+
+// send queues fd, whose body is held by buf, once the outstanding bytes
+// leave room for it. buf is released once fd is sent, or on error.
+func (s *flowSender) send(fd *FlightData, buf *memory.Buffer) error {
+	size := int64(len(fd.DataHeader) + len(fd.DataBody) + len(fd.AppMetadata))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.err == nil && s.stats.OutstandingBytes > 0 && s.stats.OutstandingBytes+size > s.fc.MaxOutstandingBytes {
+		s.cond.Wait()
+	}
+	if s.err != nil {
+		if buf != nil {
+			buf.Release()
+		}
+		return s.err
+	}
+
+	s.queue = append(s.queue, queuedMessage{fd: fd, buf: buf, size: size})
+	s.stats.QueuedMessages++
+	s.stats.QueuedBytes += size
+	s.stats.OutstandingBytes += size
+	s.cond.Broadcast()
+	return nil
+}
+
+func (s *flowSender) run() {
+	defer close(s.done)
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		msg := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		err := s.w.Send(msg.fd)
+		if msg.buf != nil {
+			msg.buf.Release()
+		}
+
+		s.mu.Lock()
+		s.stats.QueuedMessages--
+		s.stats.QueuedBytes -= msg.size
+		s.stats.SentMessages++
+		s.stats.SentBytes += msg.size
+		if s.fc.Acked && isRecordBatch(msg.fd.DataHeader) {
+			s.unacked = append(s.unacked, msg.size)
+		} else {
+			s.stats.OutstandingBytes -= msg.size
+		}
+		if err != nil {
+			s.err = err
+			for _, msg := range s.queue {
+				if msg.buf != nil {
+					msg.buf.Release()
+				}
+			}
+			s.queue = nil
+			s.stats.QueuedMessages, s.stats.QueuedBytes = 0, 0
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// ack releases the n oldest record batches sent and not yet acknowledged.
+func (s *flowSender) ack(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n > len(s.unacked) {
+		n = len(s.unacked)
+	}
+	for _, size := range s.unacked[:n] {
+		s.stats.OutstandingBytes -= size
+	}
+	s.unacked = s.unacked[n:]
+	s.cond.Broadcast()
+}
+
+func (s *flowSender) statistics() WriterStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// close sends the messages still queued and returns the first error of
+// the stream.
+func (s *flowSender) close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	<-s.done
+	return s.err
+}
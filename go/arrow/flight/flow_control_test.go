@@ -0,0 +1,307 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+// countingAllocator tracks the current and peak bytes allocated.
+type countingAllocator struct {
+	mem  memory.Allocator
+	cur  int64
+	peak int64
+}
+
+func (a *countingAllocator) add(n int) {
+	cur := atomic.AddInt64(&a.cur, int64(n))
+	for {
+		peak := atomic.LoadInt64(&a.peak)
+		if cur <= peak || atomic.CompareAndSwapInt64(&a.peak, peak, cur) {
+			return
+		}
+	}
+}
+
+func (a *countingAllocator) Allocate(size int) []byte {
+	a.add(size)
+	return a.mem.Allocate(size)
+}
+
+func (a *countingAllocator) Reallocate(size int, b []byte) []byte {
+	a.add(size - len(b))
+	return a.mem.Reallocate(size, b)
+}
+
+func (a *countingAllocator) Free(b []byte) {
+	a.add(-len(b))
+	a.mem.Free(b)
+}
+
+var flowSchema = arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+// flowRecord returns a record of 4096 rows, 32KiB of values.
+func flowRecord(mem memory.Allocator, i int) array.Record {
+	bldr := array.NewRecordBuilder(mem, flowSchema)
+	defer bldr.Release()
+	for j := 0; j < 4096; j++ {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i*4096 + j))
+	}
+	return bldr.NewRecord()
+}
+
+func TestFlowControlSlowReader(t *testing.T) {
+	const (
+		nrecs = 64
+		max   = 128 << 10
+	)
+
+	mem := &countingAllocator{mem: memory.NewGoAllocator()}
+	var queued int64
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewWriter(stream, ipc.WithAllocator(mem))
+			w.SetFlowControl(flight.FlowControl{MaxOutstandingBytes: max, Mem: mem})
+			for i := 0; i < nrecs; i++ {
+				rec := flowRecord(mem, i)
+				err := w.Write(rec)
+				rec.Release()
+				if err != nil {
+					return err
+				}
+				if q := w.Stats().QueuedBytes; q > queued {
+					queued = q
+				}
+			}
+			if err := w.Close(); err != nil {
+				return err
+			}
+			if st := w.Stats(); st.QueuedMessages != 0 || st.SentMessages != nrecs+1 {
+				t.Errorf("invalid stats after close: %+v", st)
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	n := 0
+	for r.Next() {
+		time.Sleep(2 * time.Millisecond)
+		n++
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != nrecs {
+		t.Fatalf("invalid number of records. got=%d, want=%d", n, nrecs)
+	}
+
+	// the records in flight are bounded by the cap, where the records
+	// produced amount to 2MiB.
+	const recSize = 4096 * 8
+	if peak := atomic.LoadInt64(&mem.peak); peak > max+4*recSize {
+		t.Fatalf("server memory not bounded: peak=%d", peak)
+	}
+	if queued > max+recSize {
+		t.Fatalf("queue not bounded: got=%d", queued)
+	}
+	if cur := atomic.LoadInt64(&mem.cur); cur != 0 {
+		t.Fatalf("memory leaked: %d", cur)
+	}
+}
+
+func TestFlowControlAcks(t *testing.T) {
+	const (
+		nrecs = 16
+		max   = 2*4096*8 + 1024
+	)
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			r, err := flight.NewRecordReader(stream)
+			if err != nil {
+				return err
+			}
+			defer r.Release()
+
+			for r.Next() {
+				time.Sleep(time.Millisecond)
+				if err := stream.Send(&flight.PutResult{AppMetadata: []byte("ack")}); err != nil {
+					return err
+				}
+			}
+			return r.Err()
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	stream, err := client.DoPut(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := flight.NewWriter(stream)
+	w.SetFlightDescriptor(&flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"acked"}})
+	w.SetFlowControl(flight.FlowControl{MaxOutstandingBytes: max, Acked: true})
+
+	var (
+		wg   sync.WaitGroup
+		acks int
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			if _, err := stream.Recv(); err != nil {
+				if err != io.EOF {
+					t.Error(err)
+				}
+				return
+			}
+			acks++
+			w.Ack(1)
+		}
+	}()
+
+	var outstanding int64
+	for i := 0; i < nrecs; i++ {
+		rec := flowRecord(memory.DefaultAllocator, i)
+		err := w.Write(rec)
+		rec.Release()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o := w.Stats().OutstandingBytes; o > outstanding {
+			outstanding = o
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	if outstanding > max {
+		t.Fatalf("outstanding bytes over the cap: got=%d", outstanding)
+	}
+	if acks != nrecs {
+		t.Fatalf("invalid number of acks. got=%d, want=%d", acks, nrecs)
+	}
+	if st := w.Stats(); st.OutstandingBytes != 0 || st.SentMessages != nrecs+1 {
+		t.Fatalf("invalid stats: %+v", st)
+	}
+}
+
+func TestFlowControlCanceledWithoutAcks(t *testing.T) {
+	const max = 2*4096*8 + 1024
+
+	errs := make(chan error, 1)
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewWriter(stream)
+			w.SetFlowControl(flight.FlowControl{MaxOutstandingBytes: max, Acked: true})
+
+			// nothing acknowledges the records, the writes block once
+			// the cap is reached and until the client cancels.
+			var err error
+			for i := 0; err == nil; i++ {
+				rec := flowRecord(memory.DefaultAllocator, i)
+				err = w.Write(rec)
+				rec.Release()
+			}
+			w.Close()
+			errs <- err
+			return err
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.DoGet(ctx, &flight.Ticket{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Next() {
+		t.Fatalf("could not read a record: %v", r.Err())
+	}
+	cancel()
+	r.Release()
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatalf("expected an error writing to a canceled stream")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the handler is still blocked writing to a canceled stream")
+	}
+}
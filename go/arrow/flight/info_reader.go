@@ -0,0 +1,280 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+// infoReaderConfig is the configuration of a FlightInfoReader.
+type infoReaderConfig struct {
+	interleaved bool
+	mem         memory.Allocator
+	callOpts    []grpc.CallOption
+}
+
+// InfoReaderOption configures a FlightInfoReader.
+type InfoReaderOption func(*infoReaderConfig)
+
+// WithInterleaved makes a FlightInfoReader return the record batches of
+// the endpoints as they are received, rather than in the order of the
+// endpoints.
+func WithInterleaved() InfoReaderOption {
+	return func(cfg *infoReaderConfig) { cfg.interleaved = true }
+}
+
+// WithInfoReaderAllocator specifies the allocator of the record batches
+// read by a FlightInfoReader.
+func WithInfoReaderAllocator(mem memory.Allocator) InfoReaderOption {
+	return func(cfg *infoReaderConfig) { cfg.mem = mem }
+}
+
+// WithInfoReaderCallOptions specifies the call options of the DoGet calls
+// of a FlightInfoReader.
+func WithInfoReaderCallOptions(opts ...grpc.CallOption) InfoReaderOption {
+	return func(cfg *infoReaderConfig) { cfg.callOpts = opts }
+}
+
+// FlightInfoReader reads the record batches of all the endpoints of a
+// FlightInfo, retrieving up to a number of endpoints concurrently. By
+// default, the batches are returned in the order of the endpoints, as
+// FlightInfo.Ordered requires, see WithInterleaved.
+//
+// The first error of an endpoint stops the reader and cancels the calls
+// still running, as does the cancellation of its context.
+type FlightInfoReader struct {
+	refCount int64
+	schema   *arrow.Schema
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	// streams are the record batches of each endpoint, or a single
+	// channel for all of them when interleaved.
+	streams []chan array.Record
+	cur     int
+	rec     array.Record
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewFlightInfoReader starts retrieving the endpoints of info from pool,
+// up to parallelism at a time, or all at once when parallelism is 0 or
+// less. The endpoints without locations are retrieved from the origin
+// client of the pool.
+func NewFlightInfoReader(ctx context.Context, pool *ClientPool, info *FlightInfo, parallelism int, opts ...InfoReaderOption) (*FlightInfoReader, error) {
+	cfg := &infoReaderConfig{mem: memory.DefaultAllocator}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	schema, err := DeserializeSchema(info.GetSchema(), cfg.mem)
+	if err != nil {
+		return nil, xerrors.Errorf("flight: invalid flight info schema: %w", err)
+	}
+
+	endpoints := info.GetEndpoint()
+	if parallelism <= 0 || parallelism > len(endpoints) {
+		parallelism = len(endpoints)
+	}
+
+	r := &FlightInfoReader{refCount: 1, schema: schema, ctx: ctx}
+	ctx, r.cancel = context.WithCancel(ctx)
+	if cfg.interleaved {
+		r.streams = []chan array.Record{make(chan array.Record, parallelism)}
+	} else {
+		r.streams = make([]chan array.Record, len(endpoints))
+		for i := range r.streams {
+			r.streams[i] = make(chan array.Record, 1)
+		}
+	}
+
+	r.wg.Add(1)
+	go r.run(ctx, pool, endpoints, parallelism, cfg)
+	return r, nil
+}
+
+// run retrieves the endpoints, in order, parallelism at a time.
+func (r *FlightInfoReader) run(ctx context.Context, pool *ClientPool, endpoints []*FlightEndpoint, parallelism int, cfg *infoReaderConfig) {
+	defer r.wg.Done()
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, parallelism)
+		launched int
+	)
+	defer func() {
+		wg.Wait()
+		if cfg.interleaved {
+			close(r.streams[0])
+			return
+		}
+		// the endpoints not retrieved have no records.
+		for _, out := range r.streams[launched:] {
+			close(out)
+		}
+	}()
+
+	for i, ep := range endpoints {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			r.fail(ctx.Err())
+			return
+		}
+		launched++
+
+		out := r.streams[0]
+		if !cfg.interleaved {
+			out = r.streams[i]
+		}
+		wg.Add(1)
+		go func(ep *FlightEndpoint, out chan array.Record) {
+			defer func() { <-sem }()
+			defer wg.Done()
+			if !cfg.interleaved {
+				defer close(out)
+			}
+			if err := r.fetch(ctx, pool, ep, out, cfg); err != nil {
+				r.fail(err)
+			}
+		}(ep, out)
+	}
+}
+
+// fetch sends the record batches of ep to out.
+func (r *FlightInfoReader) fetch(ctx context.Context, pool *ClientPool, ep *FlightEndpoint, out chan<- array.Record, cfg *infoReaderConfig) error {
+	stream, err := pool.DoGet(ctx, ep, cfg.callOpts...)
+	if err != nil {
+		return err
+	}
+	rdr, err := NewRecordReader(stream, ipc.WithAllocator(cfg.mem))
+	if err != nil {
+		return err
+	}
+	defer rdr.Release()
+
+	if !rdr.Schema().Equal(r.schema) {
+		return xerrors.Errorf("flight: endpoint schema %v differs from the flight info schema %v", rdr.Schema(), r.schema)
+	}
+	for rdr.Next() {
+		rec := rdr.Record()
+		rec.Retain()
+		select {
+		case out <- rec:
+		case <-ctx.Done():
+			rec.Release()
+			return ctx.Err()
+		}
+	}
+	return rdr.Err()
+}
+
+// fail records the first error of the reader, or the error of its context
+// once done, and cancels the calls still running.
+func (r *FlightInfoReader) fail(err error) {
+	if e := r.ctx.Err(); e != nil {
+		err = e
+	}
+	r.mu.Lock()
+	if r.err == nil {
+		r.err = err
+	}
+	r.mu.Unlock()
+	r.cancel()
+}
+
+// Schema returns the schema of the flight info.
+func (r *FlightInfoReader) Schema() *arrow.Schema { return r.schema }
+
+// Next reads the next record batch, it returns false once all the
+// endpoints were read, or on error, see Err.
+func (r *FlightInfoReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	for r.cur < len(r.streams) && r.Err() == nil {
+		rec, ok := <-r.streams[r.cur]
+		if !ok {
+			r.cur++
+			continue
+		}
+		if r.Err() != nil {
+			rec.Release()
+			break
+		}
+		r.rec = rec
+		return true
+	}
+	return false
+}
+
+// Record returns the current record batch, it is valid until the next
+// call to Next.
+func (r *FlightInfoReader) Record() array.Record { return r.rec }
+
+// Err returns the error that stopped the reader, if any.
+func (r *FlightInfoReader) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *FlightInfoReader) Retain() {
+	atomic.AddInt64(&r.refCount, 1)
+}
+
+// Release decreases the reference count by 1. When the reference count
+// goes to zero, the calls still running are canceled and the record
+// batches not read are released.
+// Release may be called simultaneously from multiple goroutines.
+func (r *FlightInfoReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refCount, -1) == 0 {
+		r.cancel()
+		r.wg.Wait()
+		for _, out := range r.streams {
+			for rec := range out {
+				rec.Release()
+			}
+		}
+		if r.rec != nil {
+			r.rec.Release()
+			r.rec = nil
+		}
+	}
+}
+
+var (
+	_ array.RecordReader = (*FlightInfoReader)(nil)
+)
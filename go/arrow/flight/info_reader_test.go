@@ -0,0 +1,252 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var endpointSchema = arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+// endpointServer serves tickets of the form "i/n", n records of a single
+// value i*1000+j, the later endpoints being faster to answer, "fail", a
+// record then an error, and "block", a record then nothing until the call
+// is canceled.
+type endpointServer struct {
+	active, max int64
+	canceled    chan struct{}
+}
+
+func (s *endpointServer) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	if n := atomic.AddInt64(&s.active, 1); n > atomic.LoadInt64(&s.max) {
+		atomic.StoreInt64(&s.max, n)
+	}
+	defer atomic.AddInt64(&s.active, -1)
+
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(endpointSchema))
+	defer w.Close()
+	write := func(v int64) error {
+		bldr := array.NewInt64Builder(memory.DefaultAllocator)
+		defer bldr.Release()
+		bldr.Append(v)
+		arr := bldr.NewArray()
+		defer arr.Release()
+		rec := array.NewRecord(endpointSchema, []array.Interface{arr}, 1)
+		defer rec.Release()
+		return w.Write(rec)
+	}
+
+	switch tkt := string(tkt.Ticket); tkt {
+	case "fail":
+		write(-1)
+		return status.Error(codes.Internal, "endpoint failed")
+	case "block":
+		write(-1)
+		<-stream.Context().Done()
+		close(s.canceled)
+		return stream.Context().Err()
+	default:
+		parts := strings.Split(tkt, "/")
+		i, _ := strconv.Atoi(parts[0])
+		n, _ := strconv.Atoi(parts[1])
+		for j := 0; j < n; j++ {
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			if err := write(int64(i*1000 + j)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func endpointsInfo(tickets ...string) *flight.FlightInfo {
+	eps := make([]*flight.FlightEndpoint, len(tickets))
+	for i, tkt := range tickets {
+		eps[i] = &flight.FlightEndpoint{Ticket: &flight.Ticket{Ticket: []byte(tkt)}}
+	}
+	return flight.NewFlightInfo(endpointSchema, nil, eps, flight.WithOrdered())
+}
+
+func readValues(r *flight.FlightInfoReader) []int64 {
+	var vals []int64
+	for r.Next() {
+		vals = append(vals, r.Record().Column(0).(*array.Int64).Int64Values()...)
+	}
+	return vals
+}
+
+func TestFlightInfoReader(t *testing.T) {
+	srv := &endpointServer{}
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{DoGet: srv.DoGet})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	pool := flight.NewClientPool(client, nil, nil)
+	defer pool.Close()
+
+	tickets := []string{"0/5", "1/3", "2/0", "3/4", "4/2", "5/3"}
+	var want []int64
+	for _, tkt := range tickets {
+		parts := strings.Split(tkt, "/")
+		i, _ := strconv.Atoi(parts[0])
+		n, _ := strconv.Atoi(parts[1])
+		for j := 0; j < n; j++ {
+			want = append(want, int64(i*1000+j))
+		}
+	}
+
+	t.Run("ordered", func(t *testing.T) {
+		atomic.StoreInt64(&srv.max, 0)
+		r, err := flight.NewFlightInfoReader(context.Background(), pool, endpointsInfo(tickets...), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+
+		got := readValues(r)
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("invalid values: got=%v, want=%v", got, want)
+			}
+		}
+		if max := atomic.LoadInt64(&srv.max); max > 3 {
+			t.Fatalf("too many concurrent calls: %d", max)
+		}
+	})
+
+	t.Run("interleaved", func(t *testing.T) {
+		r, err := flight.NewFlightInfoReader(context.Background(), pool, endpointsInfo(tickets...), 0, flight.WithInterleaved())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+
+		got := readValues(r)
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if len(got) != len(want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("invalid values: got=%v, want=%v", got, want)
+			}
+		}
+	})
+
+	for _, interleaved := range []bool{false, true} {
+		var opts []flight.InfoReaderOption
+		name := "failure/ordered"
+		if interleaved {
+			opts, name = append(opts, flight.WithInterleaved()), "failure/interleaved"
+		}
+		t.Run(name, func(t *testing.T) {
+			srv.canceled = make(chan struct{})
+			r, err := flight.NewFlightInfoReader(context.Background(), pool, endpointsInfo("block", "0/2", "fail"), 0, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			readValues(r)
+			if code := status.Code(r.Err()); code != codes.Internal {
+				t.Fatalf("expected the endpoint error, got %v", r.Err())
+			}
+			select {
+			case <-srv.canceled:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("the blocked call was not canceled")
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		srv.canceled = make(chan struct{})
+		ctx, cancel := context.WithCancel(context.Background())
+		r, err := flight.NewFlightInfoReader(ctx, pool, endpointsInfo("0/1", "block", "1/1"), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+		readValues(r)
+		wg.Wait()
+		if r.Err() != context.Canceled {
+			t.Fatalf("expected a canceled error, got %v", r.Err())
+		}
+		<-srv.canceled
+	})
+
+	t.Run("release", func(t *testing.T) {
+		srv.canceled = make(chan struct{})
+		r, err := flight.NewFlightInfoReader(context.Background(), pool, endpointsInfo("0/5", "block"), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.Next() {
+			t.Fatal(r.Err())
+		}
+		r.Release()
+		<-srv.canceled
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := flight.NewFlightInfoReader(context.Background(), pool, &flight.FlightInfo{}, 0)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
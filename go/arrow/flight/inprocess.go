@@ -0,0 +1,47 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// inProcessBufferSize is the size of the in-memory buffers of in-process
+// connections.
+const inProcessBufferSize = 1 << 20
+
+// ServeInProcess starts serving s over an in-memory listener instead of a
+// socket, and returns a client connected to it, which makes testing
+// handlers cheap. s must not have been initialized, Shutdown stops it as
+// usual. opts must not set transport credentials.
+func ServeInProcess(s Server, auth ClientAuthHandler, opts ...grpc.DialOption) (Client, error) {
+	lis := bufconn.Listen(inProcessBufferSize)
+	s.InitListener(lis)
+	go s.Serve()
+
+	opts = append([]grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	}, opts...)
+	return NewFlightClient("bufnet", auth, opts...)
+}
@@ -0,0 +1,308 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+// listCriteriaPrefix starts the criteria expressions encoded by
+// ListCriteria, telling them from the custom encodings of the servers.
+const listCriteriaPrefix = "arrow.flight.ListCriteria:"
+
+// ListCriteria are simple criteria of ListFlights, understood by the
+// servers using ParseListCriteria or ServeListFlights, for catalogs which do
+// not need a custom encoding. They are encoded as the JSON object of their
+// fields, following "arrow.flight.ListCriteria:":
+//
+//	arrow.flight.ListCriteria:{"path_prefix":["sales","2023-"],"max_results":100,"after":"..."}
+//
+// The flights matching the criteria are listed in the order of their
+// ListToken, so that a listing continues after the last flight of the
+// previous one, see NewPagedFlightInfoIterator.
+type ListCriteria struct {
+	// PathPrefix selects the flights of path descriptors starting with its
+	// elements: all but the last must equal those of the path, the last
+	// one being a prefix of the element of the path. An empty prefix
+	// selects all the flights.
+	PathPrefix []string `json:"path_prefix,omitempty"`
+	// MaxResults is the maximum number of flights listed, all of them when
+	// 0 or less.
+	MaxResults int64 `json:"max_results,omitempty"`
+	// After is the continuation token of the listing: only the flights of
+	// a greater ListToken are listed.
+	After string `json:"after,omitempty"`
+}
+
+// Criteria returns the encoding of c.
+func (c ListCriteria) Criteria() *Criteria {
+	expr, err := json.Marshal(c)
+	if err != nil {
+		panic(xerrors.Errorf("flight: could not encode list criteria: %w", err))
+	}
+	return &Criteria{Expression: append([]byte(listCriteriaPrefix), expr...)}
+}
+
+// Match returns whether the flight of desc matches the path prefix of c.
+func (c ListCriteria) Match(desc *FlightDescriptor) bool {
+	if len(c.PathPrefix) == 0 {
+		return true
+	}
+	if desc.GetType() != FlightDescriptor_PATH || len(desc.GetPath()) < len(c.PathPrefix) {
+		return false
+	}
+	last := len(c.PathPrefix) - 1
+	for i, elem := range c.PathPrefix[:last] {
+		if desc.Path[i] != elem {
+			return false
+		}
+	}
+	return strings.HasPrefix(desc.Path[last], c.PathPrefix[last])
+}
+
+// ParseListCriteria decodes the criteria encoded by ListCriteria. It
+// returns false for the other criteria, including empty ones, which a
+// server then handles as it sees fit.
+func ParseListCriteria(criteria *Criteria) (ListCriteria, bool, error) {
+	var c ListCriteria
+	expr := criteria.GetExpression()
+	if !bytes.HasPrefix(expr, []byte(listCriteriaPrefix)) {
+		return c, false, nil
+	}
+	if err := json.Unmarshal(expr[len(listCriteriaPrefix):], &c); err != nil {
+		return c, false, NewStatusError(ErrInvalidArgument, nil, "invalid list criteria: %v", err)
+	}
+	return c, true, nil
+}
+
+// ListToken returns the continuation token of the flight of desc, which
+// orders the flights listed with ListCriteria.
+func ListToken(desc *FlightDescriptor) string {
+	if desc.GetType() == FlightDescriptor_CMD {
+		return "cmd:" + base64.StdEncoding.EncodeToString(desc.Cmd)
+	}
+	path, _ := json.Marshal(desc.GetPath())
+	return "path:" + string(path)
+}
+
+// ServeListFlights sends the flights of infos matching criteria to stream,
+// as a ListFlights handler of a catalog held in memory does. Criteria not
+// encoded by ListCriteria are ignored: all the flights are sent, in the
+// order of infos.
+func ServeListFlights(criteria *Criteria, infos []*FlightInfo, stream FlightService_ListFlightsServer) error {
+	c, ok, err := ParseListCriteria(criteria)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		for _, info := range infos {
+			if err := stream.Send(info); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	type flight struct {
+		token string
+		info  *FlightInfo
+	}
+	var flights []flight
+	for _, info := range infos {
+		if !c.Match(info.GetFlightDescriptor()) {
+			continue
+		}
+		token := ListToken(info.GetFlightDescriptor())
+		if c.After != "" && token <= c.After {
+			continue
+		}
+		flights = append(flights, flight{token, info})
+	}
+	sort.Slice(flights, func(i, j int) bool { return flights[i].token < flights[j].token })
+	if c.MaxResults > 0 && int64(len(flights)) > c.MaxResults {
+		flights = flights[:c.MaxResults]
+	}
+
+	for _, f := range flights {
+		if err := stream.Send(f.info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlightInfoIterator lazily reads the flights listed by a server, with a
+// single ListFlights call or page after page, see
+// NewPagedFlightInfoIterator.
+type FlightInfoIterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	client FlightServiceClient
+	opts   []grpc.CallOption
+
+	criteria *Criteria
+	paged    bool
+	list     ListCriteria // the criteria of the next page, when paged
+
+	stream  FlightService_ListFlightsClient
+	page    []*FlightInfo // the buffered flights of the current call
+	eof     bool          // whether the current call was read entirely
+	more    bool          // whether a page may follow the current one
+	started bool
+	done    bool
+
+	info *FlightInfo
+	err  error
+}
+
+// NewFlightInfoIterator returns an iterator of the flights listed by the
+// ListFlights call of client with criteria, which starts with the first
+// call to Next.
+func NewFlightInfoIterator(ctx context.Context, client FlightServiceClient, criteria *Criteria, opts ...grpc.CallOption) *FlightInfoIterator {
+	it := &FlightInfoIterator{client: client, opts: opts, criteria: criteria}
+	it.ctx, it.cancel = context.WithCancel(ctx)
+	return it
+}
+
+// NewPagedFlightInfoIterator returns an iterator of the flights matching
+// criteria, listed in pages of criteria.MaxResults flights, each one with a
+// ListFlights call continuing after the last flight of the previous one.
+// Without a maximum number of results, the flights are listed with a single
+// call.
+//
+// Servers ignoring the criteria are supported: their flights are listed
+// once, with a single call, and filtered by the path prefix of criteria.
+func NewPagedFlightInfoIterator(ctx context.Context, client FlightServiceClient, criteria ListCriteria, opts ...grpc.CallOption) *FlightInfoIterator {
+	it := &FlightInfoIterator{client: client, opts: opts, paged: true, list: criteria}
+	it.ctx, it.cancel = context.WithCancel(ctx)
+	return it
+}
+
+// Next reads the next flight. It returns false once all the flights were
+// read, on error, see Err, or once the iterator is closed.
+func (it *FlightInfoIterator) Next() bool {
+	it.info = nil
+	for !it.done && it.err == nil {
+		switch {
+		case len(it.page) > 0:
+			it.info, it.page = it.page[0], it.page[1:]
+		case it.stream != nil && !it.eof:
+			info, err := it.stream.Recv()
+			if err == io.EOF {
+				it.eof = true
+				continue
+			}
+			if err != nil {
+				it.err = streamError(err)
+				return false
+			}
+			it.info = info
+		case !it.started || it.more:
+			it.start()
+			continue
+		default:
+			it.Close()
+			return false
+		}
+
+		if it.paged {
+			if !it.list.Match(it.info.GetFlightDescriptor()) {
+				it.info = nil
+				continue
+			}
+			it.list.After = ListToken(it.info.GetFlightDescriptor())
+		}
+		return true
+	}
+	return false
+}
+
+// start starts the next ListFlights call. When paged, it buffers the page
+// to tell whether the server ignored the criteria.
+func (it *FlightInfoIterator) start() {
+	first := !it.started
+	it.started, it.more, it.eof = true, false, false
+
+	criteria := it.criteria
+	if it.paged {
+		criteria = it.list.Criteria()
+	}
+	stream, err := it.client.ListFlights(it.ctx, criteria, it.opts...)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.stream = stream
+	if !it.paged || it.list.MaxResults <= 0 {
+		return
+	}
+
+	// a server ignoring the criteria lists more flights than the maximum,
+	// or flights out of the order of their tokens.
+	var (
+		ignored bool
+		prev    = it.list.After
+	)
+	it.page = it.page[:0]
+	for !it.eof && !ignored {
+		info, err := stream.Recv()
+		if err == io.EOF {
+			it.eof = true
+			break
+		}
+		if err != nil {
+			it.err = streamError(err)
+			return
+		}
+		it.page = append(it.page, info)
+
+		token := ListToken(info.GetFlightDescriptor())
+		ignored = token <= prev || int64(len(it.page)) > it.list.MaxResults
+		prev = token
+	}
+
+	switch {
+	case !ignored:
+		it.more = int64(len(it.page)) == it.list.MaxResults
+	case !first:
+		// all the flights were listed by the first call.
+		it.Close()
+	}
+}
+
+// Info returns the current flight. It is valid until the next call to Next.
+func (it *FlightInfoIterator) Info() *FlightInfo { return it.info }
+
+// Err returns the error that stopped Next, if any. The errors of the calls
+// are reported as StatusError.
+func (it *FlightInfoIterator) Err() error { return it.err }
+
+// Close ends the listing, cancelling the call in progress.
+func (it *FlightInfoIterator) Close() {
+	it.cancel()
+	it.stream, it.page = nil, nil
+	it.done = true
+}
@@ -0,0 +1,294 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+func TestListCriteria(t *testing.T) {
+	want := flight.ListCriteria{PathPrefix: []string{"sales", "2023-"}, MaxResults: 100, After: "token"}
+	got, ok, err := flight.ParseListCriteria(want.Criteria())
+	if err != nil || !ok {
+		t.Fatalf("could not parse criteria: ok=%v, err=%v", ok, err)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("invalid criteria: got=%+v, want=%+v", got, want)
+	}
+
+	for _, c := range []*flight.Criteria{nil, {}, {Expression: []byte("primitives")}, {Expression: []byte(`{"max_results":1}`)}} {
+		if _, ok, err := flight.ParseListCriteria(c); ok || err != nil {
+			t.Fatalf("criteria %v: unexpected list criteria: ok=%v, err=%v", c, ok, err)
+		}
+	}
+	if _, _, err := flight.ParseListCriteria(&flight.Criteria{Expression: []byte("arrow.flight.ListCriteria:{")}); !xerrors.Is(err, flight.ErrInvalidArgument) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := func(elems ...string) *flight.FlightDescriptor {
+		return &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: elems}
+	}
+	for _, tc := range []struct {
+		prefix []string
+		desc   *flight.FlightDescriptor
+		want   bool
+	}{
+		{nil, path("a"), true},
+		{nil, &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("a")}, true},
+		{[]string{"a"}, &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("a")}, false},
+		{[]string{"sales", "2023-"}, path("sales", "2023-01"), true},
+		{[]string{"sales", "2023-"}, path("sales", "2023-01", "eu"), true},
+		{[]string{"sales", "2023-"}, path("sales", "2022-01"), false},
+		{[]string{"sales", "2023-"}, path("sales"), false},
+		{[]string{"sales", "2023-"}, path("sale", "2023-01"), false},
+		{[]string{"sales", ""}, path("sales", "x"), true},
+	} {
+		c := flight.ListCriteria{PathPrefix: tc.prefix}
+		if got := c.Match(tc.desc); got != tc.want {
+			t.Fatalf("prefix %q, descriptor %v: got=%v, want=%v", tc.prefix, tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestFlightInfoIterator(t *testing.T) {
+	const n = 10000
+
+	// the catalog is listed in reverse order of the tokens of its flights,
+	// which ServeListFlights sorts.
+	var infos []*flight.FlightInfo
+	for i := n - 1; i >= 0; i-- {
+		infos = append(infos, &flight.FlightInfo{
+			FlightDescriptor: &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"catalog", fmt.Sprintf("t%05d", i)}},
+			TotalRecords:     int64(i),
+		})
+	}
+	infos = append(infos,
+		&flight.FlightInfo{FlightDescriptor: &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"other"}}},
+		&flight.FlightInfo{FlightDescriptor: &flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte("select 1")}},
+	)
+
+	var (
+		calls     int64
+		cancelled = make(chan struct{}, 1)
+	)
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: func(c *flight.Criteria, stream flight.FlightService_ListFlightsServer) error {
+			atomic.AddInt64(&calls, 1)
+			if string(c.GetExpression()) != "endless" {
+				return flight.ServeListFlights(c, infos, stream)
+			}
+			// lists the catalog over and over, until cancelled.
+			for i := 0; ; i++ {
+				if err := stream.Send(infos[i%len(infos)]); err != nil {
+					cancelled <- struct{}{}
+					return err
+				}
+			}
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// check checks that the i-th flight of the catalog was read.
+	check := func(t *testing.T, it *flight.FlightInfoIterator, i int) {
+		t.Helper()
+		if got := it.Info().GetTotalRecords(); got != int64(i) {
+			t.Fatalf("invalid flight %d: got=%d", i, got)
+		}
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		atomic.StoreInt64(&calls, 0)
+		it := flight.NewPagedFlightInfoIterator(context.Background(), client, flight.ListCriteria{PathPrefix: []string{"catalog"}, MaxResults: 1000})
+		defer it.Close()
+
+		i := 0
+		for it.Next() {
+			check(t, it, i)
+			i++
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if i != n {
+			t.Fatalf("invalid number of flights: got=%d, want=%d", i, n)
+		}
+		// the last page, of 1000 flights, is followed by an empty one.
+		if got, want := atomic.LoadInt64(&calls), int64(n/1000+1); got != want {
+			t.Fatalf("invalid number of calls: got=%d, want=%d", got, want)
+		}
+	})
+
+	t.Run("pages-uneven", func(t *testing.T) {
+		atomic.StoreInt64(&calls, 0)
+		it := flight.NewPagedFlightInfoIterator(context.Background(), client, flight.ListCriteria{MaxResults: 3000})
+		defer it.Close()
+
+		i := 0
+		for it.Next() {
+			i++
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if i != n+2 {
+			t.Fatalf("invalid number of flights: got=%d, want=%d", i, n+2)
+		}
+		if got, want := atomic.LoadInt64(&calls), int64(4); got != want {
+			t.Fatalf("invalid number of calls: got=%d, want=%d", got, want)
+		}
+	})
+
+	t.Run("pages-close", func(t *testing.T) {
+		atomic.StoreInt64(&calls, 0)
+		it := flight.NewPagedFlightInfoIterator(context.Background(), client, flight.ListCriteria{PathPrefix: []string{"catalog", "t"}, MaxResults: 1000})
+
+		for i := 0; i < n/2; i++ {
+			if !it.Next() {
+				t.Fatalf("could not read flight %d: %v", i, it.Err())
+			}
+			check(t, it, i)
+		}
+		it.Close()
+		if it.Next() {
+			t.Fatalf("iterator should be closed")
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := atomic.LoadInt64(&calls), int64(n/2/1000); got != want {
+			t.Fatalf("invalid number of calls: got=%d, want=%d", got, want)
+		}
+	})
+
+	t.Run("single-call-close", func(t *testing.T) {
+		it := flight.NewFlightInfoIterator(context.Background(), client, &flight.Criteria{Expression: []byte("endless")})
+
+		for i := 0; i < n/2; i++ {
+			if !it.Next() {
+				t.Fatalf("could not read flight %d: %v", i, it.Err())
+			}
+			check(t, it, n-1-i)
+		}
+		it.Close()
+		if it.Next() {
+			t.Fatalf("iterator should be closed")
+		}
+
+		// the server sees the call cancelled.
+		select {
+		case <-cancelled:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("the call was not cancelled")
+		}
+	})
+}
+
+func TestFlightInfoIteratorIgnoredCriteria(t *testing.T) {
+	// the server lists all its flights whatever the criteria, in order or
+	// in reverse order.
+	var (
+		infos   []*flight.FlightInfo
+		ordered bool
+	)
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: func(_ *flight.Criteria, stream flight.FlightService_ListFlightsServer) error {
+			for i := range infos {
+				if !ordered {
+					i = len(infos) - 1 - i
+				}
+				if err := stream.Send(infos[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, tc := range []struct {
+		flights int
+		page    int64
+		ordered bool
+		prefix  []string
+		want    int
+	}{
+		{flights: 25, page: 10, want: 25},
+		{flights: 10, page: 10, want: 10},
+		{flights: 1, page: 1, want: 1},
+		{flights: 5, page: 10, want: 5},
+		{flights: 25, page: 0, want: 25},
+		{flights: 25, page: 10, prefix: []string{"t1"}, want: 10},
+		{flights: 25, page: 10, ordered: true, want: 25},
+		{flights: 10, page: 10, ordered: true, want: 10},
+		{flights: 20, page: 10, ordered: true, prefix: []string{"t0"}, want: 10},
+	} {
+		t.Run(fmt.Sprintf("flights=%d-page=%d-ordered=%v-prefix=%q", tc.flights, tc.page, tc.ordered, tc.prefix), func(t *testing.T) {
+			infos, ordered = infos[:0], tc.ordered
+			for i := 0; i < tc.flights; i++ {
+				infos = append(infos, &flight.FlightInfo{
+					FlightDescriptor: &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{fmt.Sprintf("t%02d", i)}},
+				})
+			}
+
+			it := flight.NewPagedFlightInfoIterator(context.Background(), client, flight.ListCriteria{PathPrefix: tc.prefix, MaxResults: tc.page})
+			defer it.Close()
+
+			seen := make(map[string]bool)
+			for it.Next() {
+				path := it.Info().GetFlightDescriptor().GetPath()[0]
+				if seen[path] {
+					t.Fatalf("flight %q listed twice", path)
+				}
+				seen[path] = true
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if len(seen) != tc.want {
+				t.Fatalf("invalid number of flights: got=%d, want=%d", len(seen), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// schemeServer starts a server answering GetSchema with its name, on
+// addr, either a host and port or a location URI.
+func schemeServer(t *testing.T, name, addr string, opts ...grpc.ServerOption) flight.Server {
+	t.Helper()
+	s := flight.NewFlightServer(nil, opts...)
+	if err := s.Init(addr); err != nil {
+		t.Fatal(err)
+	}
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetSchema: func(context.Context, *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			return &flight.SchemaResult{Schema: []byte(name)}, nil
+		},
+	})
+	go s.Serve()
+	return s
+}
+
+func TestClientForLocation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-arrow-flight-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca := newCert(t, "test-ca", nil, x509.ExtKeyUsageAny)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+	serverCert := newCert(t, "server", &ca, x509.ExtKeyUsageServerAuth)
+	serverCreds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}})
+
+	insecure := schemeServer(t, "insecure", "localhost:0")
+	defer insecure.Shutdown()
+	unix := schemeServer(t, "unix", "grpc+unix://"+filepath.Join(dir, "flight.sock"))
+	defer unix.Shutdown()
+	secure := schemeServer(t, "tls", "localhost:0", grpc.Creds(serverCreds))
+	defer secure.Shutdown()
+
+	// localhost is not among the names of the server certificate.
+	localhost := func(s flight.Server) string {
+		_, port, _ := net.SplitHostPort(s.Addr().String())
+		return "grpc+tls://localhost:" + port
+	}
+
+	for _, tc := range []struct {
+		name string
+		uri  string
+		opts []grpc.DialOption
+		want string
+		code codes.Code
+	}{
+		{"grpc", "grpc://" + insecure.Addr().String(), nil, "insecure", codes.OK},
+		{"grpc+tcp", "grpc+tcp://" + insecure.Addr().String(), nil, "insecure", codes.OK},
+		{"grpc+unix", flight.LocationForAddr(unix.Addr()).Uri, nil, "unix", codes.OK},
+		{"grpc+tls", "grpc+tls://" + secure.Addr().String(), []grpc.DialOption{flight.WithLocationTLSConfig(&tls.Config{RootCAs: roots})}, "tls", codes.OK},
+		{"server name", localhost(secure), []grpc.DialOption{flight.WithLocationTLSConfig(&tls.Config{RootCAs: roots, ServerName: "127.0.0.1"})}, "tls", codes.OK},
+		{"unknown authority", "grpc+tls://" + secure.Addr().String(), nil, "", codes.Unavailable},
+		{"wrong server name", localhost(secure), []grpc.DialOption{flight.WithLocationTLSConfig(&tls.Config{RootCAs: roots})}, "", codes.Unavailable},
+		{"tls to insecure", "grpc+tls://" + insecure.Addr().String(), []grpc.DialOption{flight.WithLocationTLSConfig(&tls.Config{RootCAs: roots})}, "", codes.Unavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := flight.NewClientForLocation(&flight.Location{Uri: tc.uri}, nil, nil, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			res, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{})
+			if code := status.Code(err); code != tc.code {
+				t.Fatalf("invalid status: got=%v, want=%v (%v)", code, tc.code, err)
+			}
+			if err == nil && string(res.Schema) != tc.want {
+				t.Fatalf("invalid server: got=%q, want=%q", res.Schema, tc.want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		uri string
+		err string
+	}{
+		{"http://localhost:1234", `unsupported location scheme "http"`},
+		{"localhost:1234", `unsupported location scheme "localhost"`},
+		{"grpc+tcp:///path", "missing host"},
+		{"grpc+unix://", "missing path"},
+		{"grpc+tls://[::1", "invalid location"},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			_, err := flight.NewClientForLocation(&flight.Location{Uri: tc.uri}, nil, nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected a %q error, got %v", tc.err, err)
+			}
+		})
+	}
+
+	t.Run("pool", func(t *testing.T) {
+		pool := flight.NewClientPool(nil, nil, nil, flight.WithLocationTLSConfig(&tls.Config{RootCAs: roots}))
+		defer pool.Close()
+
+		for _, loc := range []string{"grpc+tcp://" + insecure.Addr().String(), "grpc+tls://" + secure.Addr().String()} {
+			client, err := pool.Client(&flight.Location{Uri: loc})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{}); err != nil {
+				t.Fatalf("%s: %v", loc, err)
+			}
+		}
+	})
+}
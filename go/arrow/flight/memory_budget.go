@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+// memoryBudgetOption is the server option of WithMemoryBudget.
+type memoryBudgetOption struct {
+	grpc.EmptyServerOption
+	budget *memoryBudget
+}
+
+// WithMemoryBudget returns a server option for NewFlightServer that
+// accounts the memory of the record batches the server receives, and bounds
+// it per stream and for the whole server, in bytes. A budget of 0 or less
+// is unlimited.
+//
+// The readers created by handlers with NewRecordReader or NewReader
+// allocate the record batches they read with mem, in place of the
+// allocator of their options and with ipc.WithBufferReuse, and count them
+// against the budgets until they are released, along with the memory
+// kept for reuse until the reader is released. Before decoding a message,
+// they check that its body fits in the budgets: otherwise, they fail with
+// an ErrResourceExhausted
+// StatusError, which the handler returns to the client, and onExceeded,
+// if not nil, is called with the full method name of the call, the
+// identity of the client as returned by AuthFromContext, and the error.
+// The budgets are checked as the messages arrive: they may be exceeded by
+// the messages decoded concurrently by other streams.
+func WithMemoryBudget(mem memory.Allocator, perStream, perServer int64, onExceeded func(fullMethod string, peer interface{}, err error)) grpc.ServerOption {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	return memoryBudgetOption{budget: &memoryBudget{
+		mem:        mem,
+		perStream:  perStream,
+		perServer:  perServer,
+		onExceeded: onExceeded,
+	}}
+}
+
+// memoryBudget is the memory budget of a server.
+type memoryBudget struct {
+	mem        memory.Allocator
+	perStream  int64
+	perServer  int64
+	onExceeded func(fullMethod string, peer interface{}, err error)
+
+	used int64 // bytes in use by all the streams
+}
+
+// streamBudget is the memory budget of a stream, and the allocator of its
+// readers.
+type streamBudget struct {
+	budget *memoryBudget
+	ctx    context.Context
+	method string
+
+	used int64 // bytes in use by the stream
+}
+
+func (b *streamBudget) Allocate(size int) []byte {
+	b.add(size)
+	return b.budget.mem.Allocate(size)
+}
+
+func (b *streamBudget) Reallocate(size int, buf []byte) []byte {
+	b.add(size - len(buf))
+	return b.budget.mem.Reallocate(size, buf)
+}
+
+func (b *streamBudget) Free(buf []byte) {
+	b.add(-len(buf))
+	b.budget.mem.Free(buf)
+}
+
+func (b *streamBudget) add(n int) {
+	atomic.AddInt64(&b.used, int64(n))
+	atomic.AddInt64(&b.budget.used, int64(n))
+}
+
+// check returns an ErrResourceExhausted StatusError if n more bytes do not
+// fit in the budgets of the stream and the server.
+func (b *streamBudget) check(n int) error {
+	var err error
+	if used := atomic.LoadInt64(&b.used); b.budget.perStream > 0 && used+int64(n) > b.budget.perStream {
+		err = NewStatusError(ErrResourceExhausted, nil, "memory budget of the stream exceeded: %d bytes in use, %d more received, limit of %d bytes", used, n, b.budget.perStream)
+	} else if used := atomic.LoadInt64(&b.budget.used); b.budget.perServer > 0 && used+int64(n) > b.budget.perServer {
+		err = NewStatusError(ErrResourceExhausted, nil, "memory budget of the server exceeded: %d bytes in use, %d more received, limit of %d bytes", used, n, b.budget.perServer)
+	}
+	if err != nil && b.budget.onExceeded != nil {
+		b.budget.onExceeded(b.method, AuthFromContext(b.ctx), err)
+	}
+	return err
+}
+
+type memoryBudgetCtxKey struct{}
+
+func createServerMemoryBudgetStreamInterceptor(budget *memoryBudget) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		b := &streamBudget{budget: budget, ctx: stream.Context(), method: info.FullMethod}
+		return handler(srv, &authWrappedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), memoryBudgetCtxKey{}, b)})
+	}
+}
+
+// memoryBudgetOf returns the memory budget of the stream of r, if any.
+func memoryBudgetOf(r DataStreamReader) *streamBudget {
+	s, ok := r.(interface{ Context() context.Context })
+	if !ok {
+		return nil
+	}
+	b, _ := s.Context().Value(memoryBudgetCtxKey{}).(*streamBudget)
+	return b
+}
+
+// memoryBudgetOptions returns the ipc options allocating the record
+// batches read with the memory budget b, if any, after opts. The buffers
+// of the records are only allocated with the allocator of the reader when
+// reused, otherwise they are read to memory of the garbage collector.
+func memoryBudgetOptions(b *streamBudget, opts []ipc.Option) []ipc.Option {
+	if b == nil {
+		return opts
+	}
+	return append(opts[:len(opts):len(opts)], ipc.WithAllocator(b), ipc.WithBufferReuse())
+}
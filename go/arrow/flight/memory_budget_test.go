@@ -0,0 +1,250 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exceeded is a call of the callback of WithMemoryBudget.
+type exceeded struct {
+	method string
+	peer   interface{}
+	err    error
+}
+
+// newBudgetServer returns a server with the given memory budgets, whose
+// DoPut handler reads the uploads with NewRecordReader, or NewReader for
+// the "flight" reader, and holds their records until their end, and until
+// the func in hold, if any, returns.
+func newBudgetServer(t *testing.T, reader string, mem memory.Allocator, perStream, perServer int64, onExceeded func(exceeded), hold *atomic.Value) (flight.Server, flight.Client) {
+	s := flight.NewFlightServer(&servAuth{}, flight.WithMemoryBudget(mem, perStream, perServer, func(method string, peer interface{}, err error) {
+		onExceeded(exceeded{method, peer, err})
+	}))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			var recs []array.Record
+			defer func() {
+				if hold != nil {
+					if hold, ok := hold.Load().(func()); ok {
+						hold()
+					}
+				}
+				for _, rec := range recs {
+					rec.Release()
+				}
+			}()
+
+			if reader == "ipc" {
+				rr, err := flight.NewRecordReader(stream)
+				if err != nil {
+					return err
+				}
+				defer rr.Release()
+				for rr.Next() {
+					rr.Record().Retain()
+					recs = append(recs, rr.Record())
+				}
+				return rr.Err()
+			}
+
+			r := flight.NewReader(stream)
+			defer r.Release()
+			for r.Next() {
+				if rec := r.Record(); rec != nil {
+					rec.Retain()
+					recs = append(recs, rec)
+				}
+			}
+			return r.Err()
+		},
+	})
+	go s.Serve()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), &clientAuth{}, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(context.WithValue(context.Background(), ctxauth{}, []byte("foobar"))); err != nil {
+		t.Fatal(err)
+	}
+	return s, client
+}
+
+func TestMemoryBudget(t *testing.T) {
+	rec := largeRecord()
+	defer rec.Release()
+
+	// put uploads the first n slices of 1024 rows of rec.
+	put := func(client flight.Client, n int) error {
+		var recs []array.Record
+		for i := 0; i < n; i++ {
+			recs = append(recs, rec.NewSlice(int64(i*1024), int64(i+1)*1024))
+		}
+		defer func() {
+			for _, r := range recs {
+				r.Release()
+			}
+		}()
+		rdr, err := array.NewRecordReader(rec.Schema(), recs)
+		if err != nil {
+			return err
+		}
+		defer rdr.Release()
+
+		ctx := context.WithValue(context.Background(), ctxauth{}, "baz")
+		desc := &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"budget"}}
+		_, err = client.PutRecords(ctx, desc, rdr)
+		return err
+	}
+
+	// checkExhausted checks that err is the error of an exceeded budget.
+	checkExhausted := func(t *testing.T, err error) {
+		t.Helper()
+		if !xerrors.Is(err, flight.ErrResourceExhausted) || status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, reader := range []string{"ipc", "flight"} {
+		t.Run("stream-"+reader, func(t *testing.T) {
+			mem := &countingAllocator{mem: memory.NewGoAllocator()}
+
+			var calls []exceeded
+			s, client := newBudgetServer(t, reader, mem, 256<<10, 0, func(e exceeded) { calls = append(calls, e) }, nil)
+			defer s.Shutdown()
+			defer client.Close()
+
+			// a few batches fit in the budget of the stream.
+			if err := put(client, 2); err != nil {
+				t.Fatal(err)
+			}
+			if len(calls) != 0 {
+				t.Fatalf("unexpected callback: %+v", calls)
+			}
+
+			checkExhausted(t, put(client, 16))
+			if len(calls) != 1 {
+				t.Fatalf("invalid number of callbacks: got=%d, want=1", len(calls))
+			}
+			if got, want := calls[0].method, "/arrow.flight.protocol.FlightService/DoPut"; got != want {
+				t.Fatalf("invalid method: got=%q, want=%q", got, want)
+			}
+			if got, want := calls[0].peer, "bar"; got != want {
+				t.Fatalf("invalid peer: got=%v, want=%v", got, want)
+			}
+			checkExhausted(t, calls[0].err)
+
+			if got := atomic.LoadInt64(&mem.cur); got != 0 {
+				t.Fatalf("%d bytes still in use", got)
+			}
+		})
+	}
+
+	t.Run("server", func(t *testing.T) {
+		const uploads = 4
+
+		mem := &countingAllocator{mem: memory.NewGoAllocator()}
+
+		var (
+			mu    sync.Mutex
+			calls []exceeded
+			hold  atomic.Value
+		)
+		// the budget of the server holds about two uploads.
+		s, client := newBudgetServer(t, "ipc", mem, 0, 2<<20, func(e exceeded) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, e)
+		}, &hold)
+		defer s.Shutdown()
+		defer client.Close()
+
+		// one upload at a time fits.
+		for i := 0; i < uploads; i++ {
+			if err := put(client, 16); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(calls) != 0 {
+			t.Fatalf("unexpected callback: %+v", calls)
+		}
+
+		// the concurrent uploads hold their records until all of them
+		// were read.
+		var held sync.WaitGroup
+		held.Add(uploads)
+		hold.Store(func() {
+			held.Done()
+			held.Wait()
+		})
+
+		var (
+			wg   sync.WaitGroup
+			errs = make([]error, uploads)
+		)
+		for i := 0; i < uploads; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = put(client, 16)
+			}(i)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, err := range errs {
+			if err != nil {
+				checkExhausted(t, err)
+				failed++
+			}
+		}
+		if failed == 0 {
+			t.Fatalf("concurrent uploads did not exceed the budget of the server")
+		}
+		if len(calls) != failed {
+			t.Fatalf("invalid number of callbacks: got=%d, want=%d", len(calls), failed)
+		}
+		for _, call := range calls {
+			if call.peer != "bar" {
+				t.Fatalf("invalid peer: %v", call.peer)
+			}
+			checkExhausted(t, call.err)
+		}
+
+		// the budget is exceeded by the messages decoded concurrently, at
+		// most.
+		if got, max := atomic.LoadInt64(&mem.peak), int64(2<<20+uploads*256<<10); got > max {
+			t.Fatalf("peak memory over the budget: got=%d, max=%d", got, max)
+		}
+		if got := atomic.LoadInt64(&mem.cur); got != 0 {
+			t.Fatalf("%d bytes still in use", got)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow/ipc"
+	"google.golang.org/grpc"
+)
+
+// flightDataFraming is the room left, under the maximum message size, for
+// the protobuf framing of the flight data messages around the ipc message.
+const flightDataFraming = 1 << 10
+
+// maxMessageSizeOption is the server option of WithMaxMessageSize.
+type maxMessageSizeOption struct {
+	grpc.EmptyServerOption
+	n int
+}
+
+// WithMaxMessageSize returns a server option for NewFlightServer that sets
+// the maximum size, in bytes, of the messages the server receives and
+// sends, in place of the 4MB default of grpc. The writers created by
+// handlers with NewRecordWriter or NewWriter split the record batches
+// that would exceed it into zero-copy slices of fewer rows, readers see
+// the same rows in the same order across several records.
+func WithMaxMessageSize(n int) grpc.ServerOption {
+	return maxMessageSizeOption{n: n}
+}
+
+// maxCallMessageSizeOption is the dial option of WithMaxCallMessageSize.
+type maxCallMessageSizeOption struct {
+	grpc.EmptyDialOption
+	n int
+}
+
+// WithMaxCallMessageSize returns a dial option for NewFlightClient that
+// sets the maximum size, in bytes, of the messages the client receives and
+// sends, the client counterpart of WithMaxMessageSize. The writers created
+// with NewRecordWriter or NewWriter on the streams of the client split the
+// record batches they send accordingly.
+func WithMaxCallMessageSize(n int) grpc.DialOption {
+	return maxCallMessageSizeOption{n: n}
+}
+
+type maxMessageSizeCtxKey struct{}
+
+func withMaxMessageSize(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, maxMessageSizeCtxKey{}, n)
+}
+
+// maxMessageSizeOptions returns the ipc options splitting the record
+// batches written to w under the maximum message size of its stream, if
+// any.
+func maxMessageSizeOptions(w DataStreamWriter) []ipc.Option {
+	s, ok := w.(interface{ Context() context.Context })
+	if !ok {
+		return nil
+	}
+	n, ok := s.Context().Value(maxMessageSizeCtxKey{}).(int)
+	if !ok || n <= 0 {
+		return nil
+	}
+	if n > 2*flightDataFraming {
+		n -= flightDataFraming
+	}
+	return []ipc.Option{ipc.WithMaxMessageSize(int64(n))}
+}
+
+func createServerMaxMessageSizeStreamInterceptor(n int) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &authWrappedStream{ServerStream: stream, ctx: withMaxMessageSize(stream.Context(), n)})
+	}
+}
+
+func createClientMaxMessageSizeStreamInterceptor(n int) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withMaxMessageSize(ctx, n), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,226 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// largeRecord returns a record of about 1MB.
+func largeRecord() array.Record {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	bldr := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer bldr.Release()
+	for i := 0; i < 1<<14; i++ {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i))
+		if i%11 == 0 {
+			bldr.Field(1).(*array.StringBuilder).AppendNull()
+			continue
+		}
+		bldr.Field(1).(*array.StringBuilder).Append(strings.Repeat("f", i%97))
+	}
+	return bldr.NewRecord()
+}
+
+// readSplitRecord reads the records of r, checking that they are the
+// consecutive slices of want.
+func readSplitRecord(t *testing.T, r *ipc.Reader, want array.Record) {
+	var (
+		n    int
+		rows int64
+	)
+	for r.Next() {
+		got := r.Record()
+		slice := want.NewSlice(rows, rows+got.NumRows())
+		if !array.RecordEqual(got, slice) {
+			t.Errorf("records[%d] differ", n)
+		}
+		slice.Release()
+		rows += got.NumRows()
+		n++
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rows != want.NumRows() {
+		t.Fatalf("invalid number of rows. got=%d, want=%d", rows, want.NumRows())
+	}
+	if n < 2 {
+		t.Fatalf("record was not split: got %d records", n)
+	}
+}
+
+func TestMaxMessageSize(t *testing.T) {
+	const max = 64 << 10
+
+	rec := largeRecord()
+	defer rec.Release()
+
+	s := flight.NewFlightServer(nil, flight.WithMaxMessageSize(max))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(rec.Schema()))
+			defer w.Close()
+			return w.Write(rec)
+		},
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			r, err := flight.NewRecordReader(stream)
+			if err != nil {
+				return err
+			}
+			defer r.Release()
+
+			var rows int64
+			for r.Next() {
+				rows += r.Record().NumRows()
+			}
+			if rows != rec.NumRows() {
+				return status.Errorf(codes.InvalidArgument, "got %d rows", rows)
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure(), flight.WithMaxCallMessageSize(max))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	t.Run("DoGet", func(t *testing.T) {
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := flight.NewRecordReader(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+		readSplitRecord(t, r, rec)
+	})
+
+	t.Run("DoPut", func(t *testing.T) {
+		stream, err := client.DoPut(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := flight.NewWriter(stream)
+		w.SetFlightDescriptor(&flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"large"}})
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unsplit", func(t *testing.T) {
+		stream, err := client.DoPut(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := flight.NewWriter(stream, ipc.WithMaxMessageSize(0))
+		// the oversized message fails either on send or on the server.
+		err = w.Write(rec)
+		if err == nil {
+			w.Close()
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected a resource exhausted error, got %v", err)
+		}
+	})
+}
+
+func TestMaxMessageSizeSingleRow(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "str", Type: arrow.BinaryTypes.String}}, nil)
+	bldr := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.StringBuilder).AppendValues([]string{"small", strings.Repeat("large", 1<<12)}, nil)
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	s := flight.NewFlightServer(nil, flight.WithMaxMessageSize(16<<10))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(rec.Schema()))
+			defer w.Close()
+			if err := w.Write(rec); err != nil {
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Next() || r.Record().NumRows() != 1 {
+		t.Fatal("expected the small row")
+	}
+	if r.Next() {
+		t.Fatal("unexpected record")
+	}
+	err = r.Err()
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected a resource exhausted error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "single row") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+)
+
+// PrefetchReader reads the records of a record reader, such as one made by
+// NewRecordReader, ahead of the calls to Next: a goroutine receives and
+// decodes up to n records while the caller processes the current one, so
+// that network latency and decoding overlap with the work of the caller.
+//
+// The errors of the underlying reader are reported by Err once the records
+// read before them were handed over. The underlying reader must not be used
+// with ipc.WithBufferReuse, as its records are retained past its next call
+// to Next.
+type PrefetchReader struct {
+	refCount int64
+
+	schema *arrow.Schema
+	recs   chan array.Record
+	done   chan struct{}
+	exited chan struct{}
+
+	rec    array.Record
+	err    error // set by the goroutine before recs is closed
+	closed bool  // whether recs was closed
+}
+
+// NewPrefetchReader returns a reader of the records of r, prefetching up to
+// n records, at least 1. The returned reader takes ownership of r, which it
+// releases once exhausted or released.
+func NewPrefetchReader(r array.RecordReader, n int) *PrefetchReader {
+	if n < 1 {
+		n = 1
+	}
+	p := &PrefetchReader{
+		refCount: 1,
+		schema:   r.Schema(),
+		recs:     make(chan array.Record, n),
+		done:     make(chan struct{}),
+		exited:   make(chan struct{}),
+	}
+	go p.prefetch(r)
+	return p
+}
+
+func (p *PrefetchReader) prefetch(r array.RecordReader) {
+	defer close(p.exited)
+	defer r.Release()
+	defer close(p.recs)
+
+	for r.Next() {
+		rec := r.Record()
+		rec.Retain()
+		select {
+		case p.recs <- rec:
+		case <-p.done:
+			rec.Release()
+			return
+		}
+	}
+	if r, ok := r.(interface{ Err() error }); ok {
+		p.err = r.Err()
+	}
+}
+
+func (p *PrefetchReader) Schema() *arrow.Schema { return p.schema }
+
+// Next returns whether a record could be read, waiting for it unless it
+// was prefetched already.
+func (p *PrefetchReader) Next() bool {
+	if p.rec != nil {
+		p.rec.Release()
+		p.rec = nil
+	}
+	if p.closed {
+		return false
+	}
+	rec, ok := <-p.recs
+	if !ok {
+		p.closed = true
+		return false
+	}
+	p.rec = rec
+	return true
+}
+
+// Record returns the current record. It is valid until the next call to
+// Next.
+func (p *PrefetchReader) Record() array.Record { return p.rec }
+
+// Err returns the error of the underlying reader which stopped Next, if
+// any.
+func (p *PrefetchReader) Err() error {
+	if !p.closed {
+		return nil
+	}
+	return p.err
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (p *PrefetchReader) Retain() {
+	atomic.AddInt64(&p.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the prefetching stops, the records
+// prefetched are released, along with the underlying reader.
+//
+// Release waits for the pending call to Next of the underlying reader to
+// return: cancel the context of the stream to interrupt it.
+// Release may be called simultaneously from multiple goroutines.
+func (p *PrefetchReader) Release() {
+	debug.Assert(atomic.LoadInt64(&p.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&p.refCount, -1) == 0 {
+		close(p.done)
+		for rec := range p.recs {
+			rec.Release()
+		}
+		<-p.exited
+		if p.rec != nil {
+			p.rec.Release()
+			p.rec = nil
+		}
+	}
+}
+
+var (
+	_ array.RecordReader = (*PrefetchReader)(nil)
+)
@@ -0,0 +1,234 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// errorStream replays the flight data messages of a memoryStream, then
+// fails with err.
+type errorStream struct {
+	memoryStream
+	err error
+}
+
+func (s *errorStream) Recv() (*flight.FlightData, error) {
+	fd, err := s.memoryStream.Recv()
+	if err == io.EOF {
+		return nil, s.err
+	}
+	return fd, err
+}
+
+// latencyStream delays the flight data messages of a memoryStream.
+type latencyStream struct {
+	*memoryStream
+	delay time.Duration
+}
+
+func (s latencyStream) Recv() (*flight.FlightData, error) {
+	time.Sleep(s.delay)
+	return s.memoryStream.Recv()
+}
+
+// releaseCounter counts the calls to Release of a record reader.
+type releaseCounter struct {
+	array.RecordReader
+	n int
+}
+
+func (r *releaseCounter) Release() {
+	r.n++
+	r.RecordReader.Release()
+}
+
+func writeStream(t testing.TB, stream flight.DataStreamWriter, recs []array.Record) {
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(recs[0].Schema()))
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrefetchReader(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			stream := &memoryStream{}
+			writeStream(t, stream, recs)
+
+			for _, n := range []int{0, 1, 4, 2 * len(recs)} {
+				stream.next = 0
+				rr, err := flight.NewRecordReader(stream, ipc.WithAllocator(mem))
+				if err != nil {
+					t.Fatal(err)
+				}
+				r := flight.NewPrefetchReader(rr, n)
+
+				if !r.Schema().Equal(recs[0].Schema()) {
+					t.Fatalf("invalid schema: got=%v, want=%v", r.Schema(), recs[0].Schema())
+				}
+
+				i := 0
+				for r.Next() {
+					if i >= len(recs) {
+						t.Fatalf("too many records: %d", i+1)
+					}
+					if !array.RecordEqual(r.Record(), recs[i]) {
+						t.Fatalf("prefetch=%d: record %d differ:\ngot= %v\nwant=%v", n, i, r.Record(), recs[i])
+					}
+					i++
+				}
+				if err := r.Err(); err != nil {
+					t.Fatal(err)
+				}
+				if i != len(recs) {
+					t.Fatalf("prefetch=%d: invalid number of records: got=%d, want=%d", n, i, len(recs))
+				}
+				if r.Next() {
+					t.Fatalf("reader should be exhausted")
+				}
+				r.Release()
+			}
+		})
+	}
+}
+
+func TestPrefetchReaderError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	recs := arrdata.Records["primitives"]
+	want := xerrors.New("connection reset")
+	stream := &errorStream{err: want}
+	writeStream(t, &stream.memoryStream, recs)
+
+	rr, err := flight.NewRecordReader(stream, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := flight.NewPrefetchReader(rr, 2*len(recs))
+	defer r.Release()
+
+	// let the reader prefetch all the records and fail.
+	time.Sleep(10 * time.Millisecond)
+
+	i := 0
+	for r.Next() {
+		if r.Err() != nil {
+			t.Fatalf("error reported before record %d", i)
+		}
+		if !array.RecordEqual(r.Record(), recs[i]) {
+			t.Fatalf("record %d differ", i)
+		}
+		i++
+	}
+	if i != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", i, len(recs))
+	}
+	if err := r.Err(); !xerrors.Is(err, want) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, want)
+	}
+}
+
+func TestPrefetchReaderRelease(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	recs := arrdata.Records["primitives"]
+	stream := &memoryStream{}
+	writeStream(t, stream, append(append(append([]array.Record(nil), recs...), recs...), recs...))
+
+	for _, read := range []int{0, 1, len(recs)} {
+		stream.next = 0
+		rr, err := flight.NewRecordReader(stream, ipc.WithAllocator(mem))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc := &releaseCounter{RecordReader: rr}
+		r := flight.NewPrefetchReader(rc, 2)
+		for i := 0; i < read; i++ {
+			if !r.Next() {
+				t.Fatalf("could not read record %d", i)
+			}
+		}
+
+		// let the reader fill its buffer and block.
+		time.Sleep(10 * time.Millisecond)
+		r.Retain()
+		r.Release()
+		r.Release()
+
+		if rc.n != 1 {
+			t.Fatalf("underlying reader released %d times", rc.n)
+		}
+		mem.AssertSize(t, 0)
+	}
+}
+
+func BenchmarkPrefetchReader(b *testing.B) {
+	// receiving and decoding a record takes as long as processing it: the
+	// prefetching halves the time to read the stream.
+	const delay = 500 * time.Microsecond
+
+	recs := arrdata.Records["primitives"]
+	stream := &memoryStream{}
+	writeStream(b, stream, append(append(append([]array.Record(nil), recs...), recs...), recs...))
+
+	for _, bench := range []struct {
+		name     string
+		prefetch int
+	}{
+		{"NoPrefetch", 0},
+		{"Prefetch=1", 1},
+		{"Prefetch=4", 4},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				stream.next = 0
+				var r array.RecordReader
+				r, err := flight.NewRecordReader(latencyStream{stream, delay})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if bench.prefetch > 0 {
+					r = flight.NewPrefetchReader(r, bench.prefetch)
+				}
+				for r.Next() {
+					time.Sleep(delay)
+				}
+				r.Release()
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"google.golang.org/grpc"
+)
+
+func TestProgressMessages(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+
+	// the server reports its progress before each record batch, and once
+	// done.
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewWriter(stream)
+			defer w.Close()
+			for i, rec := range recs {
+				if err := w.WriteMetadata([]byte(fmt.Sprintf("%d/%d", i, len(recs)))); err != nil {
+					return err
+				}
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return w.WriteMetadata([]byte("done"))
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var want []string
+	for i := range recs {
+		want = append(want, fmt.Sprintf("progress %d/%d", i, len(recs)), fmt.Sprint("batch ", i))
+	}
+	want = append(want, "progress done")
+
+	t.Run("callback", func(t *testing.T) {
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		r, err := flight.NewRecordReaderWithAppMetadata(stream, func(md []byte) {
+			got = append(got, fmt.Sprint("progress ", string(md)))
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+
+		for n := 0; r.Next(); n++ {
+			if !array.RecordEqual(r.Record(), recs[n]) {
+				t.Fatalf("records[%d] differ", n)
+			}
+			got = append(got, fmt.Sprint("batch ", n))
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid messages:\ngot= %q\nwant=%q", got, want)
+		}
+	})
+
+	t.Run("events", func(t *testing.T) {
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := flight.NewReader(stream)
+		defer r.Release()
+
+		var got []string
+		for n := 0; r.Next(); {
+			if r.Record() == nil {
+				got = append(got, fmt.Sprint("progress ", string(r.AppMetadata())))
+				continue
+			}
+			if !array.RecordEqual(r.Record(), recs[n]) {
+				t.Fatalf("records[%d] differ", n)
+			}
+			got = append(got, fmt.Sprint("batch ", n))
+			n++
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid messages:\ngot= %q\nwant=%q", got, want)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := flight.NewRecordReader(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+
+		n := 0
+		for ; r.Next(); n++ {
+			if !array.RecordEqual(r.Record(), recs[n]) {
+				t.Fatalf("records[%d] differ", n)
+			}
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if n != len(recs) {
+			t.Fatalf("invalid number of records. got=%d, want=%d", n, len(recs))
+		}
+	})
+}
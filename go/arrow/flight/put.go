@@ -0,0 +1,210 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+// putCall receives the PutResult messages of a DoPut call in a goroutine,
+// as they come, so that its Send fails with the error of the server as soon
+// as the server rejected the call rather than at the end of the upload.
+type putCall struct {
+	FlightService_DoPutClient
+
+	mu      sync.Mutex
+	cond    sync.Cond
+	results [][]byte // application metadata of the results not yet read
+	done    bool
+	err     error // error of the call, once done
+}
+
+func newPutCall(stream FlightService_DoPutClient) *putCall {
+	c := &putCall{FlightService_DoPutClient: stream}
+	c.cond.L = &c.mu
+	go c.recv()
+	return c
+}
+
+func (c *putCall) recv() {
+	for {
+		res, err := c.FlightService_DoPutClient.Recv()
+
+		c.mu.Lock()
+		if err != nil {
+			if err != io.EOF {
+				c.err = streamError(err)
+			}
+			c.done = true
+		} else {
+			c.results = append(c.results, res.AppMetadata)
+		}
+		c.cond.Broadcast()
+		c.mu.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// result returns the application metadata of the next result, waiting for
+// it, or false and the error of the call at its end.
+func (c *putCall) result() ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.results) == 0 && !c.done {
+		c.cond.Wait()
+	}
+	if len(c.results) == 0 {
+		return nil, false, c.err
+	}
+	md := c.results[0]
+	c.results[0] = nil
+	c.results = c.results[1:]
+	return md, true, nil
+}
+
+// Send sends fd, unless the server already rejected the call. When the
+// server ended the call, it returns its error, if any, or io.EOF.
+func (c *putCall) Send(fd *FlightData) error {
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = c.FlightService_DoPutClient.Send(fd)
+	if err != io.EOF {
+		return err
+	}
+
+	// the status of the call follows the end of its stream.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.done {
+		c.cond.Wait()
+	}
+	if c.err != nil {
+		return c.err
+	}
+	return io.EOF
+}
+
+// Recv is not available: the results are read by recv.
+func (c *putCall) Recv() (*PutResult, error) {
+	panic("flight: the results of a DoPut call are read with its PutResultReader")
+}
+
+// putAllocatorOption is the call option of WithPutAllocator.
+type putAllocatorOption struct {
+	grpc.EmptyCallOption
+	mem memory.Allocator
+}
+
+// WithPutAllocator returns a call option for Client.PutRecords and
+// Client.PutTable, the allocator of the messages written, which defaults to
+// memory.DefaultAllocator.
+func WithPutAllocator(mem memory.Allocator) grpc.CallOption {
+	return putAllocatorOption{mem: mem}
+}
+
+func (c *client) PutRecords(ctx context.Context, desc *FlightDescriptor, rdr array.RecordReader, opts ...grpc.CallOption) ([][]byte, error) {
+	mem := memory.Allocator(memory.DefaultAllocator)
+	for _, o := range opts {
+		if o, ok := o.(putAllocatorOption); ok {
+			mem = o.mem
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.FlightServiceClient.DoPut(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	call := newPutCall(stream)
+	w := NewWriter(call, ipc.WithAllocator(mem))
+	w.SetFlightDescriptor(desc)
+	w.start(rdr.Schema())
+
+	// the results are read as the records are written, so that an error
+	// of the server stops the upload right away.
+	var (
+		results  = &PutResultReader{call: call}
+		metadata [][]byte
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		for results.Next() {
+			metadata = append(metadata, results.AppMetadata())
+		}
+	}()
+
+	err = func() error {
+		schema := rdr.Schema()
+		for i := 0; rdr.Next(); i++ {
+			select {
+			case <-done:
+				// the server ended the call before the upload did.
+				return io.EOF
+			default:
+			}
+			rec := rdr.Record()
+			if !rec.Schema().Equal(schema) {
+				return xerrors.Errorf("flight: schema mismatch of record %d with the schema of the reader:\n%v", i, arrow.SchemaDiff(schema, rec.Schema()))
+			}
+			if err := w.Write(rec); err != nil {
+				return err
+			}
+		}
+		return w.Close()
+	}()
+	if err != nil && !xerrors.Is(err, io.EOF) {
+		// a failure of the client, the call is abandoned.
+		cancel()
+		<-done
+		return metadata, err
+	}
+	// on io.EOF the server ended the call, its status tells why.
+	<-done
+	if rerr := results.Err(); rerr != nil {
+		return metadata, rerr
+	}
+	if err != nil {
+		return metadata, xerrors.New("flight: the server ended the call before the end of the upload")
+	}
+	return metadata, nil
+}
+
+func (c *client) PutTable(ctx context.Context, desc *FlightDescriptor, tbl array.Table, chunkSize int64, opts ...grpc.CallOption) ([][]byte, error) {
+	rdr := array.NewTableReader(tbl, chunkSize)
+	defer rdr.Release()
+	return c.PutRecords(ctx, desc, rdr, opts...)
+}
@@ -0,0 +1,203 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// putService acknowledges each record batch of a DoPut call with its
+// number of rows, and rejects the call after the second batch for the
+// path "reject".
+func putService() *flight.FlightServiceService {
+	return &flight.FlightServiceService{
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			r := flight.NewReader(stream)
+			defer r.Release()
+
+			var n int
+			for r.Next() {
+				if r.Descriptor() != nil && r.Descriptor().Path[0] == "reject" && n == 2 {
+					return status.Error(codes.ResourceExhausted, "no more batches")
+				}
+				md := []byte(fmt.Sprint(r.Record().NumRows()))
+				if err := stream.Send(&flight.PutResult{AppMetadata: md}); err != nil {
+					return err
+				}
+				n++
+			}
+			if r.Err() != nil {
+				return r.Err()
+			}
+			if r.Schema() == nil {
+				return status.Error(codes.InvalidArgument, "missing schema")
+			}
+			return nil
+		},
+	}
+}
+
+// schemaReader is a record reader reporting another schema than the one
+// of its records.
+type schemaReader struct {
+	array.RecordReader
+	schema *arrow.Schema
+}
+
+func (r schemaReader) Schema() *arrow.Schema { return r.schema }
+
+// countingReader counts the records read from a record reader.
+type countingReader struct {
+	array.RecordReader
+	n int
+}
+
+func (r *countingReader) Next() bool {
+	if !r.RecordReader.Next() {
+		return false
+	}
+	r.n++
+	return true
+}
+
+func putTable(mem memory.Allocator, chunks ...int) array.Table {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewInt64Builder(mem)
+	defer b.Release()
+
+	var recs []array.Record
+	for _, n := range chunks {
+		for i := 0; i < n; i++ {
+			b.Append(int64(i))
+		}
+		arr := b.NewArray()
+		recs = append(recs, array.NewRecord(schema, []array.Interface{arr}, int64(n)))
+		arr.Release()
+	}
+	tbl := array.NewTableFromRecords(schema, recs)
+	for _, rec := range recs {
+		rec.Release()
+	}
+	return tbl
+}
+
+func TestPutTable(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(putService())
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	desc := &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"table"}}
+
+	for _, tc := range []struct {
+		name      string
+		chunks    []int
+		chunkSize int64
+		want      []string
+	}{
+		{name: "empty", chunks: nil, chunkSize: 10},
+		{name: "whole chunks", chunks: []int{4, 4}, chunkSize: 0, want: []string{"4", "4"}},
+		{name: "rechunked", chunks: []int{4, 4}, chunkSize: 3, want: []string{"3", "1", "3", "1"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			tbl := putTable(mem, tc.chunks...)
+			defer tbl.Release()
+
+			results, err := client.PutTable(context.Background(), desc, tbl, tc.chunkSize, flight.WithPutAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != len(tc.want) {
+				t.Fatalf("invalid number of results: got=%q, want=%q", results, tc.want)
+			}
+			for i, want := range tc.want {
+				if got := string(results[i]); got != want {
+					t.Fatalf("invalid result %d: got=%q, want=%q", i, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer mem.AssertSize(t, 0)
+
+		const batches = 10000
+		chunks := make([]int, batches)
+		for i := range chunks {
+			chunks[i] = 1
+		}
+		tbl := putTable(mem, chunks...)
+		defer tbl.Release()
+
+		rdr := array.NewTableReader(tbl, 0)
+		defer rdr.Release()
+		counted := &countingReader{RecordReader: rdr}
+
+		reject := &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"reject"}}
+		results, err := client.PutRecords(context.Background(), reject, counted, flight.WithPutAllocator(mem))
+		if code := status.Code(err); code != codes.ResourceExhausted {
+			t.Fatalf("expected a resource exhausted error, got %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("invalid number of results: got=%q, want 2", results)
+		}
+		// the upload stops once the error is received, not at the end of
+		// the records.
+		if counted.n == batches {
+			t.Fatalf("all the %d batches were read", batches)
+		}
+	})
+	t.Run("schema mismatch", func(t *testing.T) {
+		mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer mem.AssertSize(t, 0)
+
+		tbl := putTable(mem, 2)
+		defer tbl.Release()
+
+		rdr := array.NewTableReader(tbl, 0)
+		defer rdr.Release()
+		schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int32}}, nil)
+
+		_, err := client.PutRecords(context.Background(), desc, schemaReader{rdr, schema}, flight.WithPutAllocator(mem))
+		if err == nil || !strings.Contains(err.Error(), "v: type: int32 != int64") {
+			t.Fatalf("expected a schema mismatch error, got %v", err)
+		}
+	})
+}
@@ -18,9 +18,11 @@ package flight
 
 import (
 	"bytes"
+	"io"
 	"sync/atomic"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/apache/arrow/go/arrow/memory"
@@ -33,7 +35,12 @@ type DataStreamReader interface {
 }
 
 type dataMessageReader struct {
-	rdr DataStreamReader
+	rdr    DataStreamReader
+	budget *streamBudget
+
+	// onMetadata is called with the application metadata of the messages
+	// carrying no ipc message, which are skipped otherwise.
+	onMetadata func([]byte)
 
 	refCount int64
 	msg      *ipc.Message
@@ -41,12 +48,28 @@ type dataMessageReader struct {
 }
 
 func (d *dataMessageReader) Message() (*ipc.Message, error) {
-	fd, err := d.rdr.Recv()
-	if err != nil {
-		return nil, err
-	}
+	for {
+		fd, err := d.rdr.Recv()
+		if err != nil {
+			if err != io.EOF {
+				d.err = streamError(err)
+			}
+			return nil, streamError(err)
+		}
+		if len(fd.DataHeader) == 0 {
+			if d.onMetadata != nil && len(fd.AppMetadata) > 0 {
+				d.onMetadata(fd.AppMetadata)
+			}
+			continue
+		}
+		if d.budget != nil {
+			if d.err = d.budget.check(len(fd.DataBody)); d.err != nil {
+				return nil, d.err
+			}
+		}
 
-	return ipc.NewMessage(memory.NewBufferBytes(fd.DataHeader), memory.NewBufferBytes(fd.DataBody)), nil
+		return ipc.NewMessage(memory.NewBufferBytes(fd.DataHeader), memory.NewBufferBytes(fd.DataBody)), nil
+	}
 }
 
 func (d *dataMessageReader) Retain() {
@@ -66,9 +89,47 @@ func (d *dataMessageReader) Release() {
 
 // NewRecordReader constructs an ipc reader using the flight data stream reader
 // as the source of the ipc messages, opts passed will be passed to the underlying
-// ipc.Reader such as ipc.WithSchema and ipc.WithAllocator
+// ipc.Reader such as ipc.WithSchema and ipc.WithAllocator. The errors of the
+// stream are reported as StatusError. The messages carrying only
+// application metadata, see Writer.WriteMetadata, are skipped.
+//
+// When the server fails after sending record batches, all of them are read
+// before Err reports the error of the server, with its code and detail
+// bytes; Next only returns false without error at the normal end of the
+// stream. When the server fails before sending the schema of the stream,
+// NewRecordReader returns its error.
+//
+// The readers of the streams of a server with a memory budget allocate the
+// records they read within it, see WithMemoryBudget.
+//
+// With ipc.WithBufferReuse, the caller promises not to retain the records
+// read past the next call to Next: they are then decoded to memory of the
+// allocator recycled across record batches, which saves most of the
+// allocations of reading a stream. A record retained keeps its memory until
+// released.
 func NewRecordReader(r DataStreamReader, opts ...ipc.Option) (*ipc.Reader, error) {
-	return ipc.NewReaderFromMessageReader(&dataMessageReader{rdr: r}, opts...)
+	return newRecordReader(&dataMessageReader{rdr: r, budget: memoryBudgetOf(r)}, opts...)
+}
+
+// NewRecordReaderWithAppMetadata is like NewRecordReader, calling
+// onMetadata with the application metadata of the messages carrying no
+// record batch, such as the progress a server reports during a long DoGet.
+// onMetadata is called from Next, in the order of the stream: the metadata
+// sent before a record batch is handed over before Next returns the batch.
+// NewRecordReader skips these messages. See Reader for reading the metadata
+// of the messages and their record batches as a single sequence.
+func NewRecordReaderWithAppMetadata(r DataStreamReader, onMetadata func([]byte), opts ...ipc.Option) (*ipc.Reader, error) {
+	return newRecordReader(&dataMessageReader{rdr: r, budget: memoryBudgetOf(r), onMetadata: onMetadata}, opts...)
+}
+
+func newRecordReader(d *dataMessageReader, opts ...ipc.Option) (*ipc.Reader, error) {
+	r, err := ipc.NewReaderFromMessageReader(d, memoryBudgetOptions(d.budget, opts)...)
+	if err != nil && d.err != nil {
+		// the stream failed before its schema, as when the server failed
+		// before sending anything: its error is reported as is.
+		return nil, d.err
+	}
+	return r, err
 }
 
 // DeserializeSchema takes the schema bytes from FlightInfo or SchemaResult
@@ -85,3 +146,167 @@ func DeserializeSchema(info []byte, mem memory.Allocator) (*arrow.Schema, error)
 	defer rdr.Release()
 	return rdr.Schema(), nil
 }
+
+// Reader reads the record batches of a flight data stream, along with the
+// flight descriptor and application metadata they come with. Unlike
+// NewRecordReader, it reads the schema of the stream along with the first
+// record, so that it can be created before the peer sent anything, as for
+// DoExchange.
+//
+// A Reader is not safe for concurrent use, it may however be used
+// concurrently with a Writer of the same stream.
+type Reader struct {
+	src    DataStreamReader
+	opts   []ipc.Option
+	budget *streamBudget
+
+	peeked *FlightData
+	msgs   pendingMessageReader
+	rdr    *ipc.Reader
+
+	desc     *FlightDescriptor
+	metadata []byte
+	latest   []byte
+	rec      array.Record
+	err      error
+}
+
+// NewReader returns a reader of the record batches of r. Options are
+// passed to ipc.NewReaderFromMessageReader.
+func NewReader(r DataStreamReader, opts ...ipc.Option) *Reader {
+	budget := memoryBudgetOf(r)
+	return &Reader{src: r, opts: memoryBudgetOptions(budget, opts), budget: budget}
+}
+
+func (r *Reader) recv() (*FlightData, error) {
+	if fd := r.peeked; fd != nil {
+		r.peeked = nil
+		return fd, nil
+	}
+	fd, err := r.src.Recv()
+	if err == nil && r.desc == nil {
+		r.desc = fd.FlightDescriptor
+	}
+	return fd, streamError(err)
+}
+
+// Descriptor returns the flight descriptor of the stream, sent with its
+// first message, reading that message if it was not yet. It returns nil if
+// the stream has no descriptor.
+func (r *Reader) Descriptor() *FlightDescriptor {
+	if r.desc == nil && r.peeked == nil && r.rdr == nil && r.err == nil {
+		r.peeked, r.err = r.src.Recv()
+		if r.err == io.EOF {
+			r.err = nil
+		}
+		r.err = streamError(r.err)
+		if r.peeked != nil {
+			r.desc = r.peeked.FlightDescriptor
+		}
+	}
+	return r.desc
+}
+
+// Schema returns the schema of the stream, or nil until it was read.
+func (r *Reader) Schema() *arrow.Schema {
+	if r.rdr == nil {
+		return nil
+	}
+	return r.rdr.Schema()
+}
+
+// Next reads the next record batch, or the next message carrying only
+// application metadata, for which Record returns nil. It returns false at
+// the end of the stream or on error, see Err.
+func (r *Reader) Next() bool {
+	r.rec, r.metadata = nil, nil
+	for r.err == nil {
+		fd, err := r.recv()
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			return false
+		}
+
+		if len(fd.DataHeader) == 0 {
+			if len(fd.AppMetadata) == 0 {
+				// a message carrying only the descriptor.
+				continue
+			}
+			r.metadata = fd.AppMetadata
+			return true
+		}
+
+		if r.budget != nil {
+			if r.err = r.budget.check(len(fd.DataBody)); r.err != nil {
+				return false
+			}
+		}
+		msg := ipc.NewMessage(memory.NewBufferBytes(fd.DataHeader), memory.NewBufferBytes(fd.DataBody))
+		r.msgs.msgs = append(r.msgs.msgs, msg)
+		if r.rdr == nil {
+			r.rdr, r.err = ipc.NewReaderFromMessageReader(&r.msgs, r.opts...)
+			continue
+		}
+		if msg.Type() == ipc.MessageDictionaryBatch {
+			// the dictionaries are read along with the record batch that
+			// follows them.
+			continue
+		}
+		if !r.rdr.Next() {
+			r.err = r.rdr.Err()
+			return false
+		}
+		r.rec, r.metadata = r.rdr.Record(), fd.AppMetadata
+		r.latest = fd.AppMetadata
+		return true
+	}
+	return false
+}
+
+// Record returns the current record batch, or nil for a message carrying
+// only application metadata. It is valid until the next call to Next.
+func (r *Reader) Record() array.Record { return r.rec }
+
+// AppMetadata returns the application metadata of the current message.
+func (r *Reader) AppMetadata() []byte { return r.metadata }
+
+// LatestAppMetadata returns the application metadata of the most recent
+// record batch read, which the messages carrying only application metadata
+// read since do not change.
+func (r *Reader) LatestAppMetadata() []byte { return r.latest }
+
+// Err returns the error that stopped Next, if any. The errors of the stream
+// are reported as StatusError, once the record batches received before them
+// were read.
+func (r *Reader) Err() error { return r.err }
+
+// Release releases the resources held by the reader, including the current
+// record batch.
+func (r *Reader) Release() {
+	if r.rdr != nil {
+		r.rdr.Release()
+		r.rdr = nil
+	}
+	r.rec = nil
+}
+
+// pendingMessageReader hands over the messages it holds to an ipc reader,
+// in order: a record batch along with the dictionary batches before it.
+type pendingMessageReader struct {
+	msgs []*ipc.Message
+}
+
+func (p *pendingMessageReader) Message() (*ipc.Message, error) {
+	if len(p.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := p.msgs[0]
+	p.msgs[0] = nil
+	p.msgs = p.msgs[1:]
+	return msg, nil
+}
+
+func (p *pendingMessageReader) Retain()  {}
+func (p *pendingMessageReader) Release() {}
@@ -20,6 +20,8 @@ import (
 	"bytes"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
 	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/apache/arrow/go/arrow/memory"
 )
@@ -34,6 +36,14 @@ type flightPayloadWriter struct {
 	w   DataStreamWriter
 	fd  FlightData
 	buf bytes.Buffer
+
+	// desc is sent with the next message, and metadata with the next
+	// record batch.
+	desc     *FlightDescriptor
+	metadata []byte
+
+	// flow sends the messages under flow control, when set.
+	flow *flowSender
 }
 
 func (f *flightPayloadWriter) Start() error { return nil }
@@ -46,19 +56,172 @@ func (f *flightPayloadWriter) WritePayload(payload ipc.Payload) error {
 
 	payload.SerializeBody(&f.buf)
 	f.fd.DataBody = f.buf.Bytes()
+
+	f.fd.FlightDescriptor, f.desc = f.desc, nil
+	f.fd.AppMetadata = nil
+	if isRecordBatch(f.fd.DataHeader) {
+		f.fd.AppMetadata, f.metadata = f.metadata, nil
+	}
+	if f.flow != nil {
+		return f.queue(&f.fd)
+	}
 	return f.w.Send(&f.fd)
 }
 
+// queue hands a copy of fd to the flow sender, the body being copied to
+// memory of its allocator until it is sent.
+func (f *flightPayloadWriter) queue(fd *FlightData) error {
+	var (
+		buf *memory.Buffer
+		msg = &FlightData{
+			FlightDescriptor: fd.FlightDescriptor,
+			DataHeader:       append([]byte(nil), fd.DataHeader...),
+			AppMetadata:      fd.AppMetadata,
+		}
+	)
+	if len(fd.DataBody) > 0 {
+		buf = memory.NewResizableBuffer(f.flow.fc.Mem)
+		buf.Resize(len(fd.DataBody))
+		copy(buf.Bytes(), fd.DataBody)
+		msg.DataBody = buf.Bytes()
+	}
+	return f.flow.send(msg, buf)
+}
+
 func (f *flightPayloadWriter) Close() error { return nil }
 
 // NewRecordWriter can be used to construct a writer for arrow flight via
 // the grpc stream handler to write flight data objects and write
 // record batches to the stream. Options passed here will be passed to
-// ipc.NewWriter
+// ipc.NewWriter, after the ones splitting record batches under the maximum
+// message size of the stream, see WithMaxMessageSize.
 func NewRecordWriter(w DataStreamWriter, opts ...ipc.Option) *ipc.Writer {
+	opts = append(maxMessageSizeOptions(w), opts...)
 	return ipc.NewWriterWithPayloadWriter(&flightPayloadWriter{w: w}, opts...)
 }
 
+// Writer writes record batches to a flight data stream, along with a
+// flight descriptor and application metadata. Unlike NewRecordWriter, it
+// takes the schema of the stream from its first record, so that it can be
+// created before the schema is known, as for DoExchange.
+//
+// A Writer is not safe for concurrent use, it may however be used
+// concurrently with a Reader of the same stream.
+type Writer struct {
+	pw   *flightPayloadWriter
+	opts []ipc.Option
+	w    *ipc.Writer
+}
+
+// NewWriter returns a writer of record batches to w. Options are passed to
+// ipc.NewWriter, along with the schema of the first record, as with
+// NewRecordWriter.
+func NewWriter(w DataStreamWriter, opts ...ipc.Option) *Writer {
+	opts = append(maxMessageSizeOptions(w), opts...)
+	return &Writer{pw: &flightPayloadWriter{w: w}, opts: opts}
+}
+
+// SetFlightDescriptor sets the flight descriptor to send with the next
+// message, as a DoPut or DoExchange client does with its first message.
+func (w *Writer) SetFlightDescriptor(desc *FlightDescriptor) {
+	w.pw.desc = desc
+}
+
+// Write writes rec to the stream, preceded by the schema of the stream for
+// the first record.
+func (w *Writer) Write(rec array.Record) error {
+	w.start(rec.Schema())
+	return w.w.Write(rec)
+}
+
+// start sets the schema of the stream, unless already set, so that it is
+// sent even without records.
+func (w *Writer) start(schema *arrow.Schema) {
+	if w.w == nil {
+		opts := append([]ipc.Option{ipc.WithSchema(schema)}, w.opts...)
+		w.w = ipc.NewWriterWithPayloadWriter(w.pw, opts...)
+	}
+}
+
+// SetFlowControl puts the writer under flow control: the messages written
+// are queued and sent from another goroutine, and Write blocks while the
+// messages outstanding are over fc.MaxOutstandingBytes, until the stream
+// drains. It must be called before the first message is written, and a
+// MaxOutstandingBytes of 0 or less leaves the writer sending messages
+// itself.
+func (w *Writer) SetFlowControl(fc FlowControl) {
+	if fc.MaxOutstandingBytes <= 0 {
+		return
+	}
+	w.pw.flow = newFlowSender(w.pw.w, fc)
+}
+
+// Ack acknowledges the n oldest record batches sent and not yet
+// acknowledged, under a FlowControl with Acked set, so that their bytes no
+// longer count as outstanding. Ack may be called concurrently with Write.
+func (w *Writer) Ack(n int) {
+	if w.pw.flow != nil {
+		w.pw.flow.ack(n)
+	}
+}
+
+// Stats returns the statistics of the messages of a writer under flow
+// control, as for monitoring the depth of its queue. Stats may be called
+// concurrently with Write, it returns zero statistics without flow
+// control.
+func (w *Writer) Stats() WriterStats {
+	if w.pw.flow == nil {
+		return WriterStats{}
+	}
+	return w.pw.flow.statistics()
+}
+
+// WriteWithAppMetadata writes rec to the stream with the application
+// metadata md, which is sent with the first record batch when rec is split.
+func (w *Writer) WriteWithAppMetadata(rec array.Record, md []byte) error {
+	w.pw.metadata = md
+	defer func() { w.pw.metadata = nil }()
+	return w.Write(rec)
+}
+
+// WriteMetadata writes a message carrying only the application metadata
+// md, without any record batch.
+func (w *Writer) WriteMetadata(md []byte) error {
+	fd := &FlightData{FlightDescriptor: w.pw.desc, AppMetadata: md}
+	w.pw.desc = nil
+	if w.pw.flow != nil {
+		return w.pw.flow.send(fd, nil)
+	}
+	return w.pw.w.Send(fd)
+}
+
+// Close closes the writer. Closing the writer of a client stream also
+// closes the sending side of the stream, the responses of the server can
+// still be read. Under flow control, Close returns once the messages
+// queued are sent.
+func (w *Writer) Close() error {
+	if w.w != nil {
+		if err := w.w.Close(); err != nil {
+			return err
+		}
+	}
+	if w.pw.flow != nil {
+		if err := w.pw.flow.close(); err != nil {
+			return err
+		}
+	}
+	if c, ok := w.pw.w.(interface{ CloseSend() error }); ok {
+		return c.CloseSend()
+	}
+	return nil
+}
+
+// isRecordBatch reports whether the data header of a flight data message
+// is the one of a record batch.
+func isRecordBatch(dataHeader []byte) bool {
+	return len(dataHeader) > 0 && flatbuf.GetRootAsMessage(dataHeader, 0).HeaderType() == flatbuf.MessageHeaderRecordBatch
+}
+
 // SerializeSchema returns the serialized schema bytes for use in Arrow Flight
 // protobuf messages.
 func SerializeSchema(rec *arrow.Schema, mem memory.Allocator) []byte {
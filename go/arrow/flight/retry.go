@@ -0,0 +1,237 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures the retries of the idempotent calls of a client,
+// see WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call, including
+	// the first one. Calls are not retried when it is less than 2.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, it defaults to
+	// 100ms. Each retry multiplies it by Multiplier, which defaults to 2,
+	// up to MaxBackoff when it is positive. Delays are randomized by up to
+	// half their duration.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Codes are the status codes of the errors to retry, they default to
+	// Unavailable.
+	Codes []codes.Code
+}
+
+// retried are the idempotent calls retried under a RetryPolicy.
+var retried = map[string]bool{
+	"/arrow.flight.protocol.FlightService/GetFlightInfo": true,
+	"/arrow.flight.protocol.FlightService/GetSchema":     true,
+	"/arrow.flight.protocol.FlightService/ListFlights":   true,
+	"/arrow.flight.protocol.FlightService/ListActions":   true,
+	"/arrow.flight.protocol.FlightService/DoGet":         true,
+}
+
+// retryOption is the dial option of WithRetryPolicy, it leaves the grpc
+// client configuration untouched.
+type retryOption struct {
+	grpc.EmptyDialOption
+	policy RetryPolicy
+}
+
+// WithRetryPolicy returns a dial option for NewFlightClient retrying the
+// idempotent calls GetFlightInfo, GetSchema, ListFlights, ListActions and
+// DoGet when they fail with one of the codes of policy. Streams are only
+// retried until their first message is received, never mid-stream. Retries
+// stop with the context of the call, and the last error is returned along
+// with the number of attempts, keeping its status code.
+func WithRetryPolicy(policy RetryPolicy) grpc.DialOption {
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = 100 * time.Millisecond
+	}
+	if policy.Multiplier <= 0 {
+		policy.Multiplier = 2
+	}
+	if len(policy.Codes) == 0 {
+		policy.Codes = []codes.Code{codes.Unavailable}
+	}
+	return retryOption{policy: policy}
+}
+
+func createClientRetryUnaryInterceptor(policy *RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !retried[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !policy.wait(ctx, attempt, err) {
+				return policy.lastError(attempt, err)
+			}
+		}
+	}
+}
+
+func createClientRetryStreamInterceptor(policy *RetryPolicy) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !retried[method] {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		newStream := func() (grpc.ClientStream, error) { return streamer(ctx, desc, cc, method, opts...) }
+		for attempt := 1; ; attempt++ {
+			cs, err := newStream()
+			if err == nil {
+				return &retryingClientStream{ClientStream: cs, ctx: ctx, policy: policy, newStream: newStream, attempt: attempt}, nil
+			}
+			if !policy.wait(ctx, attempt, err) {
+				return nil, policy.lastError(attempt, err)
+			}
+		}
+	}
+}
+
+// wait waits before the retry of a call which failed with err after
+// attempt attempts, returning false when the call is not to be retried.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || !p.retryable(err) {
+		return false
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= p.Multiplier
+	}
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	delay := time.Duration(backoff/2 + rand.Float64()*backoff/2)
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range p.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// lastError returns the error of the last attempt of a call.
+func (p *RetryPolicy) lastError(attempts int, err error) error {
+	if err == nil || attempts == 1 {
+		return err
+	}
+	return &retryError{attempts: attempts, err: err}
+}
+
+// retryError is the error of a call which failed after several attempts,
+// it has the status of the error of the last attempt.
+type retryError struct {
+	attempts int
+	err      error
+}
+
+func (e *retryError) Error() string {
+	return fmt.Sprintf("flight: call failed after %d attempts: %v", e.attempts, e.err)
+}
+
+func (e *retryError) Unwrap() error { return e.err }
+
+func (e *retryError) GRPCStatus() *status.Status { return status.Convert(e.err) }
+
+// retryingClientStream replays the request of a server-streaming call on
+// a new stream when its first message fails with a retryable error.
+type retryingClientStream struct {
+	grpc.ClientStream
+	ctx       context.Context
+	policy    *RetryPolicy
+	newStream func() (grpc.ClientStream, error)
+	attempt   int
+
+	req      interface{}
+	closed   bool
+	received bool
+}
+
+func (s *retryingClientStream) SendMsg(m interface{}) error {
+	s.req = m
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *retryingClientStream) CloseSend() error {
+	s.closed = true
+	return s.ClientStream.CloseSend()
+}
+
+func (s *retryingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	for !s.received && err != nil && s.policy.wait(s.ctx, s.attempt, err) {
+		s.attempt++
+		err = s.retry(m)
+	}
+	if !s.received {
+		s.received = true
+		if err != nil {
+			return s.policy.lastError(s.attempt, err)
+		}
+	}
+	return err
+}
+
+// retry starts a new stream, replaying the request, and receives its first
+// message into m.
+func (s *retryingClientStream) retry(m interface{}) error {
+	cs, err := s.newStream()
+	if err != nil {
+		return err
+	}
+	s.ClientStream = cs
+
+	if s.req != nil {
+		if err := cs.SendMsg(s.req); err != nil {
+			return err
+		}
+	}
+	if s.closed {
+		if err := cs.CloseSend(); err != nil {
+			return err
+		}
+	}
+	return cs.RecvMsg(m)
+}
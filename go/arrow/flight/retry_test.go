@@ -0,0 +1,265 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyServer fails the first calls of each method with code.
+type flakyServer struct {
+	failures int
+	code     codes.Code
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// fail returns an error for the first failures calls of method.
+func (f *flakyServer) fail(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+	if f.calls[method] <= f.failures {
+		return status.Errorf(f.code, "%s failure %d", method, f.calls[method])
+	}
+	return nil
+}
+
+func (f *flakyServer) count(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *flakyServer) service() *flight.FlightServiceService {
+	return &flight.FlightServiceService{
+		GetFlightInfo: func(context.Context, *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+			if err := f.fail("GetFlightInfo"); err != nil {
+				return nil, err
+			}
+			return &flight.FlightInfo{TotalRecords: 42}, nil
+		},
+		GetSchema: func(context.Context, *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			if err := f.fail("GetSchema"); err != nil {
+				return nil, err
+			}
+			return &flight.SchemaResult{Schema: []byte("schema")}, nil
+		},
+		ListFlights: func(_ *flight.Criteria, stream flight.FlightService_ListFlightsServer) error {
+			if err := f.fail("ListFlights"); err != nil {
+				return err
+			}
+			return stream.Send(&flight.FlightInfo{TotalRecords: 42})
+		},
+		ListActions: func(_ *flight.Empty, stream flight.FlightService_ListActionsServer) error {
+			if err := f.fail("ListActions"); err != nil {
+				return err
+			}
+			return stream.Send(&flight.ActionType{Type: "action"})
+		},
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			if string(tkt.GetTicket()) == "mid-stream" {
+				// fails after the first message, which must not be retried.
+				f.fail("DoGet")
+				if err := stream.Send(&flight.FlightData{AppMetadata: []byte("first")}); err != nil {
+					return err
+				}
+				return status.Error(codes.Unavailable, "mid-stream failure")
+			}
+			if err := f.fail("DoGet"); err != nil {
+				return err
+			}
+			return stream.Send(&flight.FlightData{AppMetadata: tkt.GetTicket()})
+		},
+	}
+}
+
+func startFlaky(t *testing.T, failures int, code codes.Code, policy flight.RetryPolicy) (*flakyServer, flight.Client, func()) {
+	f := &flakyServer{failures: failures, code: code, calls: make(map[string]int)}
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(f.service())
+	go s.Serve()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure(), flight.WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, client, func() {
+		client.Close()
+		s.Shutdown()
+	}
+}
+
+// recvSingle receives the single message of a stream, returning io.EOF
+// once the stream ended after it.
+func recvSingle(stream interface{ RecvMsg(interface{}) error }, m interface{}) error {
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return stream.RecvMsg(m)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := flight.RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond}
+	ctx := context.Background()
+
+	calls := map[string]func(flight.Client) error{
+		"GetFlightInfo": func(c flight.Client) error {
+			info, err := c.GetFlightInfo(ctx, &flight.FlightDescriptor{})
+			if err == nil && info.TotalRecords != 42 {
+				t.Errorf("invalid flight info: %v", info)
+			}
+			return err
+		},
+		"GetSchema": func(c flight.Client) error {
+			_, err := c.GetSchema(ctx, &flight.FlightDescriptor{})
+			return err
+		},
+		"ListFlights": func(c flight.Client) error {
+			stream, err := c.ListFlights(ctx, &flight.Criteria{})
+			if err != nil {
+				return err
+			}
+			if err := recvSingle(stream, &flight.FlightInfo{}); err != io.EOF {
+				return err
+			}
+			return nil
+		},
+		"ListActions": func(c flight.Client) error {
+			stream, err := c.ListActions(ctx, &flight.Empty{})
+			if err != nil {
+				return err
+			}
+			if err := recvSingle(stream, &flight.ActionType{}); err != io.EOF {
+				return err
+			}
+			return nil
+		},
+		"DoGet": func(c flight.Client) error {
+			stream, err := c.DoGet(ctx, &flight.Ticket{Ticket: []byte("tkt")})
+			if err != nil {
+				return err
+			}
+			fd, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			if string(fd.AppMetadata) != "tkt" {
+				t.Errorf("invalid flight data: %v", fd)
+			}
+			if _, err := stream.Recv(); err != io.EOF {
+				return err
+			}
+			return nil
+		},
+	}
+
+	for method, call := range calls {
+		t.Run(method, func(t *testing.T) {
+			t.Run("recovered", func(t *testing.T) {
+				f, client, stop := startFlaky(t, 3, codes.Unavailable, policy)
+				defer stop()
+
+				if err := call(client); err != nil {
+					t.Fatal(err)
+				}
+				if got := f.count(method); got != 4 {
+					t.Fatalf("invalid number of calls: got=%d, want=4", got)
+				}
+			})
+
+			t.Run("exhausted", func(t *testing.T) {
+				f, client, stop := startFlaky(t, 10, codes.Unavailable, policy)
+				defer stop()
+
+				err := call(client)
+				if status.Code(err) != codes.Unavailable {
+					t.Fatalf("expected an Unavailable error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), "after 4 attempts") || !strings.Contains(err.Error(), "failure 4") {
+					t.Fatalf("invalid error: %v", err)
+				}
+				if got := f.count(method); got != 4 {
+					t.Fatalf("invalid number of calls: got=%d, want=4", got)
+				}
+			})
+
+			t.Run("not retryable", func(t *testing.T) {
+				f, client, stop := startFlaky(t, 1, codes.Internal, policy)
+				defer stop()
+
+				if err := call(client); status.Code(err) != codes.Internal {
+					t.Fatalf("expected an Internal error, got %v", err)
+				}
+				if got := f.count(method); got != 1 {
+					t.Fatalf("invalid number of calls: got=%d, want=1", got)
+				}
+			})
+		})
+	}
+}
+
+func TestRetryPolicyMidStream(t *testing.T) {
+	f, client, stop := startFlaky(t, 0, codes.Unavailable, flight.RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond})
+	defer stop()
+
+	stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte("mid-stream")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected an Unavailable error, got %v", err)
+	}
+	if got := f.count("DoGet"); got != 1 {
+		t.Fatalf("stream retried mid-stream: %d calls", got)
+	}
+}
+
+func TestRetryPolicyDeadline(t *testing.T) {
+	f, client, stop := startFlaky(t, 10, codes.Unavailable, flight.RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour})
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetFlightInfo(ctx, &flight.FlightDescriptor{})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected an Unavailable error, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("retries did not respect the deadline: %v", time.Since(start))
+	}
+	if got := f.count("GetFlightInfo"); got != 1 {
+		t.Fatalf("invalid number of calls: got=%d, want=1", got)
+	}
+}
@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// schemaCacheOption is the dial option of WithSchemaCache, it leaves the
+// grpc options untouched.
+type schemaCacheOption struct {
+	grpc.EmptyDialOption
+	ttl time.Duration
+}
+
+// WithSchemaCache returns a dial option for NewFlightClient caching the
+// schemas returned by Client.GetSchemaDeserialized by descriptor, for ttl,
+// or until invalidated with Client.InvalidateSchemas when ttl is 0 or
+// less. The cache is shared by all the goroutines using the client.
+func WithSchemaCache(ttl time.Duration) grpc.DialOption {
+	return schemaCacheOption{ttl: ttl}
+}
+
+// bypassSchemaCacheOption is the call option of BypassSchemaCache.
+type bypassSchemaCacheOption struct {
+	grpc.EmptyCallOption
+}
+
+// BypassSchemaCache returns a call option for Client.GetSchemaDeserialized
+// fetching the schema from the server even though it is cached, the
+// schema fetched then replacing the one cached.
+func BypassSchemaCache() grpc.CallOption {
+	return bypassSchemaCacheOption{}
+}
+
+type schemaCacheEntry struct {
+	schema  *arrow.Schema
+	expires time.Time
+}
+
+// schemaCache holds the schemas of descriptors, keyed by their serialized
+// form.
+type schemaCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]schemaCacheEntry
+}
+
+func newSchemaCache(ttl time.Duration) *schemaCache {
+	return &schemaCache{ttl: ttl, entries: make(map[string]schemaCacheEntry)}
+}
+
+func schemaCacheKey(desc *FlightDescriptor) (string, error) {
+	b, err := proto.Marshal(desc)
+	return string(b), err
+}
+
+func (c *schemaCache) get(key string) *arrow.Schema {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if c.ttl > 0 && time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil
+	}
+	return e.schema
+}
+
+func (c *schemaCache) put(key string, schema *arrow.Schema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = schemaCacheEntry{schema: schema, expires: time.Now().Add(c.ttl)}
+}
+
+func (c *schemaCache) invalidate(keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if keys == nil {
+		c.entries = make(map[string]schemaCacheEntry)
+		return
+	}
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// copySchema returns a copy of schema, so that the schemas cached are not
+// shared with the callers.
+func copySchema(schema *arrow.Schema) *arrow.Schema {
+	md := schema.Metadata()
+	return arrow.NewSchema(schema.Fields(), &md)
+}
+
+func (c *client) GetSchemaDeserialized(ctx context.Context, desc *FlightDescriptor, opts ...grpc.CallOption) (*arrow.Schema, error) {
+	var (
+		key    string
+		bypass bool
+	)
+	if c.schemas != nil {
+		var err error
+		if key, err = schemaCacheKey(desc); err != nil {
+			return nil, err
+		}
+		for _, o := range opts {
+			if _, ok := o.(bypassSchemaCacheOption); ok {
+				bypass = true
+			}
+		}
+		if !bypass {
+			if schema := c.schemas.get(key); schema != nil {
+				return copySchema(schema), nil
+			}
+		}
+	}
+
+	res, err := c.FlightServiceClient.GetSchema(ctx, desc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := DeserializeSchema(res.GetSchema(), memory.DefaultAllocator)
+	if err != nil {
+		return nil, err
+	}
+	if c.schemas != nil {
+		c.schemas.put(key, schema)
+		schema = copySchema(schema)
+	}
+	return schema, nil
+}
+
+func (c *client) InvalidateSchemas(descs ...*FlightDescriptor) {
+	if c.schemas == nil {
+		return
+	}
+	if len(descs) == 0 {
+		c.schemas.invalidate(nil)
+		return
+	}
+	keys := make([]string, 0, len(descs))
+	for _, desc := range descs {
+		// descriptors which cannot be serialized cannot be cached either.
+		if key, err := schemaCacheKey(desc); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	c.schemas.invalidate(keys)
+}
@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+)
+
+// countingSchemaServer serves a schema with a single field named after the
+// path of the descriptor, counting the GetSchema calls.
+func countingSchemaServer(calls *int64) *flight.FlightServiceService {
+	return &flight.FlightServiceService{
+		GetSchema: func(_ context.Context, desc *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			atomic.AddInt64(calls, 1)
+			schema := arrow.NewSchema([]arrow.Field{
+				{Name: strings.Join(desc.Path, "."), Type: arrow.PrimitiveTypes.Int64},
+			}, nil)
+			return &flight.SchemaResult{Schema: flight.SerializeSchema(schema, memory.DefaultAllocator)}, nil
+		},
+	}
+}
+
+func TestGetSchemaDeserialized(t *testing.T) {
+	var calls int64
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(countingSchemaServer(&calls))
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	path := func(p ...string) *flight.FlightDescriptor {
+		return &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: p}
+	}
+	get := func(t *testing.T, client flight.Client, desc *flight.FlightDescriptor, wantCalls int64, opts ...grpc.CallOption) *arrow.Schema {
+		t.Helper()
+		schema, err := client.GetSchemaDeserialized(context.Background(), desc, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := schema.Field(0).Name, strings.Join(desc.Path, "."); got != want {
+			t.Fatalf("invalid schema: got field %q, want %q", got, want)
+		}
+		if got := atomic.LoadInt64(&calls); got != wantCalls {
+			t.Fatalf("invalid number of calls: got=%d, want=%d", got, wantCalls)
+		}
+		return schema
+	}
+
+	t.Run("uncached", func(t *testing.T) {
+		atomic.StoreInt64(&calls, 0)
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		get(t, client, path("a"), 1)
+		get(t, client, path("a"), 2)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		atomic.StoreInt64(&calls, 0)
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure(), flight.WithSchemaCache(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		first := get(t, client, path("a"), 1)
+		second := get(t, client, path("a"), 1)
+		if first == second {
+			t.Fatalf("the schemas cached are shared")
+		}
+		get(t, client, path("a", "b"), 2)
+		get(t, client, path("a"), 3, flight.BypassSchemaCache())
+		get(t, client, path("a"), 3)
+
+		client.InvalidateSchemas(path("a"))
+		get(t, client, path("a"), 4)
+		get(t, client, path("a", "b"), 4)
+
+		client.InvalidateSchemas()
+		get(t, client, path("a"), 5)
+		get(t, client, path("a", "b"), 6)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		atomic.StoreInt64(&calls, 0)
+		const ttl = 50 * time.Millisecond
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure(), flight.WithSchemaCache(ttl))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		get(t, client, path("a"), 1)
+		get(t, client, path("a"), 1)
+		time.Sleep(2 * ttl)
+		get(t, client, path("a"), 2)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure(), flight.WithSchemaCache(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 16)
+		for i := 0; i < cap(errs); i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				desc := path("p", string(rune('a'+i%4)))
+				for j := 0; j < 10; j++ {
+					schema, err := client.GetSchemaDeserialized(context.Background(), desc)
+					if err == nil && schema.Field(0).Name != strings.Join(desc.Path, ".") {
+						err = xerrors.Errorf("invalid schema %v for %v", schema, desc.Path)
+					}
+					if err != nil {
+						errs <- err
+						return
+					}
+					if j == 5 {
+						client.InvalidateSchemas(desc)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	})
+}
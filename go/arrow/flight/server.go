@@ -17,10 +17,15 @@
 package flight
 
 import (
+	"context"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/apache/arrow/go/arrow/ipc"
 	"google.golang.org/grpc"
 )
 
@@ -28,8 +33,13 @@ import (
 // it slightly easier to manage a flight service, slightly modeled after
 // the C++ implementation
 type Server interface {
-	// Init takes in the address to bind to and creates the listener
+	// Init takes in the address to bind to and creates the listener. The
+	// address is either a TCP host:port, or a location URI such as
+	// grpc+tcp://host:port or grpc+unix:///path/to/socket.
 	Init(addr string) error
+	// InitListener sets the listener to serve on instead of creating one
+	// with Init, such as a unix socket listener set up by the caller.
+	InitListener(lis net.Listener)
 	// Addr will return the address that was bound to for the service to listen on
 	Addr() net.Addr
 	// SetShutdownOnSignals sets notifications on the given signals to call GracefulStop
@@ -42,9 +52,30 @@ type Server interface {
 	// Shutdown will call GracefulStop on the grpc server so that it stops accepting connections
 	// and will wait until current methods complete
 	Shutdown()
+	// GracefulStop stops the server from accepting new connections and calls,
+	// and waits at most timeout for the current calls to complete, after
+	// which the remaining calls are forcibly terminated. It returns whether
+	// all calls completed in time. A non-positive timeout waits as long as
+	// Shutdown does.
+	GracefulStop(timeout time.Duration) bool
+	// Draining returns a channel which is closed once the server starts
+	// stopping, through Shutdown, GracefulStop or a shutdown signal, so that
+	// long-running handlers can stop producing new record batches.
+	Draining() <-chan struct{}
 	// RegisterFlightService sets up the handler for the Flight Endpoints as per
-	// normal Grpc setups
+	// normal Grpc setups. A service without DoAction and ListActions
+	// handlers gets the ones of the actions registered with RegisterAction.
 	RegisterFlightService(*FlightServiceService)
+	// RegisterAction registers the handler of the action name, listed by
+	// ListActions with description, see ActionFunc. Registering a name again
+	// replaces its handler, and the handler registered under the empty name
+	// runs the actions registered under no other name, which otherwise fail
+	// with an Unimplemented error. Actions may be registered while the
+	// server is serving.
+	RegisterAction(name, description string, fn ActionFunc)
+	// RegisterService registers an additional grpc service, such as health
+	// checks or server reflection, on the same server
+	RegisterService(desc *grpc.ServiceDesc, impl interface{})
 }
 
 type server struct {
@@ -54,6 +85,11 @@ type server struct {
 
 	authHandler ServerAuthHandler
 	server      *grpc.Server
+
+	draining  chan struct{}
+	drainOnce sync.Once
+
+	actions actionRegistry
 }
 
 // NewFlightServer takes in an auth handler for managing the handshake authentication
@@ -64,24 +100,110 @@ type server struct {
 // grpc server generated code is still being exported. This only exists to allow
 // the utility of the helpers
 func NewFlightServer(auth ServerAuthHandler, opt ...grpc.ServerOption) Server {
+	return NewFlightServerWithAuthExemptions(auth, nil, opt...)
+}
+
+// NewFlightServerWithAuthExemptions is like NewFlightServer, except that
+// the methods for which exempt returns true bypass the auth handler. exempt
+// receives full method names such as "/grpc.health.v1.Health/Check", see
+// ExemptMethods. Handshake is always exempt, and a nil exempt exempts
+// nothing else.
+func NewFlightServerWithAuthExemptions(auth ServerAuthHandler, exempt func(fullMethod string) bool, opt ...grpc.ServerOption) Server {
+	for _, o := range opt {
+		if o, ok := o.(compressionOption); ok {
+			opt = append([]grpc.ServerOption{
+				grpc.ChainStreamInterceptor(createServerCompressionStreamInterceptor(o)),
+			}, opt...)
+			break
+		}
+	}
+
+	for _, o := range opt {
+		if o, ok := o.(maxMessageSizeOption); ok {
+			opt = append([]grpc.ServerOption{
+				grpc.MaxRecvMsgSize(o.n),
+				grpc.MaxSendMsgSize(o.n),
+				grpc.ChainStreamInterceptor(createServerMaxMessageSizeStreamInterceptor(o.n)),
+			}, opt...)
+			break
+		}
+	}
+
+	// the memory budget interceptor runs after the auth one, for the
+	// identity of the client.
+	for _, o := range opt {
+		if o, ok := o.(memoryBudgetOption); ok {
+			opt = append([]grpc.ServerOption{
+				grpc.ChainStreamInterceptor(createServerMemoryBudgetStreamInterceptor(o.budget)),
+			}, opt...)
+			break
+		}
+	}
+
 	if auth != nil {
+		var audit func(string, error)
+		for _, o := range opt {
+			if o, ok := o.(authAuditOption); ok {
+				audit = o.onFailure
+			}
+		}
+
 		opt = append([]grpc.ServerOption{
-			grpc.ChainStreamInterceptor(createServerAuthStreamInterceptor(auth)),
-			grpc.ChainUnaryInterceptor(createServerAuthUnaryInterceptor(auth)),
+			grpc.ChainStreamInterceptor(createServerAuthStreamInterceptor(auth, exempt, audit)),
+			grpc.ChainUnaryInterceptor(createServerAuthUnaryInterceptor(auth, exempt, audit)),
 		}, opt...)
 	}
 
+	for _, o := range opt {
+		if o, ok := o.(statsOption); ok && o.h != nil {
+			opt = append([]grpc.ServerOption{
+				grpc.ChainStreamInterceptor(createServerStatsStreamInterceptor(o.h)),
+				grpc.ChainUnaryInterceptor(createServerStatsUnaryInterceptor(o.h)),
+			}, opt...)
+			break
+		}
+	}
+
 	return &server{
 		authHandler: auth,
 		server:      grpc.NewServer(opt...),
+		draining:    make(chan struct{}),
 	}
 }
 
+// authAuditOption is the server option of WithAuthAuditMode, it leaves the
+// grpc server configuration untouched.
+type authAuditOption struct {
+	grpc.EmptyServerOption
+	onFailure func(fullMethod string, err error)
+}
+
+// WithAuthAuditMode returns a server option for NewFlightServer that makes
+// the auth handler report-only, to measure which clients would be rejected
+// before enforcing authentication: calls failing authentication proceed
+// without an identity, AuthFromContext returns nil for them, and onFailure
+// is called with the full method name of the call and the error it would
+// have been rejected with. It has no effect without an auth handler.
+func WithAuthAuditMode(onFailure func(fullMethod string, err error)) grpc.ServerOption {
+	return authAuditOption{onFailure: onFailure}
+}
+
 func (s *server) Init(addr string) (err error) {
-	s.lis, err = net.Listen("tcp", addr)
+	network := "tcp"
+	if strings.Contains(addr, "://") {
+		network, addr, err = locationAddr(addr)
+		if err != nil {
+			return err
+		}
+	}
+	s.lis, err = net.Listen(network, addr)
 	return
 }
 
+func (s *server) InitListener(lis net.Listener) {
+	s.lis = lis
+}
+
 func (s *server) Addr() net.Addr {
 	return s.lis.Addr()
 }
@@ -97,7 +219,7 @@ func (s *server) Serve() error {
 	go func() {
 		select {
 		case <-s.sigChannel:
-			s.server.GracefulStop()
+			s.Shutdown()
 		case <-s.done:
 		}
 	}()
@@ -110,9 +232,76 @@ func (s *server) RegisterFlightService(svc *FlightServiceService) {
 	if svc.Handshake == nil {
 		svc.Handshake = s.handshake
 	}
+	if svc.DoAction == nil {
+		svc.DoAction = s.actions.doAction
+	}
+	if svc.ListActions == nil {
+		svc.ListActions = s.actions.listActions
+	}
 	RegisterFlightServiceService(s.server, svc)
 }
 
+func (s *server) RegisterAction(name, description string, fn ActionFunc) {
+	s.actions.register(name, description, fn)
+}
+
+func (s *server) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	s.server.RegisterService(desc, impl)
+}
+
 func (s *server) Shutdown() {
+	s.startDraining()
 	s.server.GracefulStop()
 }
+
+func (s *server) GracefulStop(timeout time.Duration) bool {
+	s.startDraining()
+	if timeout <= 0 {
+		s.server.GracefulStop()
+		return true
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		// terminates the remaining calls, and unblocks GracefulStop.
+		s.server.Stop()
+		<-done
+		return false
+	}
+}
+
+func (s *server) Draining() <-chan struct{} {
+	return s.draining
+}
+
+func (s *server) startDraining() {
+	s.drainOnce.Do(func() { close(s.draining) })
+}
+
+// ExchangeHandler returns a DoExchange implementation, to be set in
+// FlightServiceService, calling handle with a reader of the record batches
+// sent by the client and a writer of those sent back on the same stream.
+// The flight descriptor of the call is given by r.Descriptor. Either side
+// may send its schema first. Options are passed to both the reader and the
+// writer. The writer is closed once handle returns successfully.
+func ExchangeHandler(handle func(ctx context.Context, r *Reader, w *Writer) error, opts ...ipc.Option) func(FlightService_DoExchangeServer) error {
+	return func(stream FlightService_DoExchangeServer) error {
+		r, w := NewReader(stream, opts...), NewWriter(stream, opts...)
+		defer r.Release()
+
+		if err := handle(stream.Context(), r, w); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"strings"
 
+	"golang.org/x/xerrors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -47,7 +48,14 @@ type serverAuthConn struct {
 	stream FlightService_HandshakeServer
 }
 
+// Read returns the payload of the next handshake request. It fails with
+// the error of the context of the stream once it is done, that is when the
+// client goes away or the deadline of the handshake passes.
 func (a *serverAuthConn) Read() ([]byte, error) {
+	if err := a.stream.Context().Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
 	in, err := a.stream.Recv()
 	if err != nil {
 		return nil, err
@@ -63,11 +71,25 @@ func (a *serverAuthConn) Send(b []byte) error {
 // ServerAuthHandler defines an interface for the server to perform the handshake.
 // The token is expected to be sent as part of the context metadata in subsequent
 // requests with a key of "auth-token-bin" which will then call IsValid to validate
+//
+// Errors returned by IsValid that carry a grpc status, such as those created
+// with the status package, reach the client with their code and details
+// unchanged. Any other error is reported as codes.Unauthenticated.
 type ServerAuthHandler interface {
 	Authenticate(AuthConn) error
 	IsValid(token string) (interface{}, error)
 }
 
+// ServerAuthHandlerWithContext is a ServerAuthHandler whose handshake is
+// given the context of the Handshake call, done once the client goes away
+// or the deadline of the call passes, so that a slow handshake can be
+// abandoned. AuthenticateWithContext is called instead of Authenticate for
+// the handlers implementing it.
+type ServerAuthHandlerWithContext interface {
+	ServerAuthHandler
+	AuthenticateWithContext(ctx context.Context, conn AuthConn) error
+}
+
 type authCtxKey struct{}
 
 type authWrappedStream struct {
@@ -84,60 +106,124 @@ func AuthFromContext(ctx context.Context) interface{} {
 	return ctx.Value(authCtxKey{})
 }
 
-func createServerAuthUnaryInterceptor(auth ServerAuthHandler) grpc.UnaryServerInterceptor {
-	if auth == nil {
-		return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			return handler(ctx, req)
-		}
+// ExemptMethods returns a predicate for NewFlightServerWithAuthExemptions
+// exempting the given full method names from authentication.
+func ExemptMethods(fullMethods ...string) func(fullMethod string) bool {
+	set := make(map[string]bool, len(fullMethods))
+	for _, m := range fullMethods {
+		set[m] = true
 	}
+	return func(fullMethod string) bool { return set[fullMethod] }
+}
 
-	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		var authTok string
-		md, ok := metadata.FromIncomingContext(ctx)
-		if ok {
-			vals := md.Get(grpcAuthHeader)
-			if len(vals) > 0 {
-				authTok = vals[0]
-			}
-		}
+// authError returns err as is if it carries a grpc status, and as an
+// Unauthenticated status otherwise.
+func authError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.Unauthenticated, "auth-error: %s", err)
+}
+
+// ExpiredTokenError may be returned by ServerAuthHandler.IsValid for an
+// expired token that the handler renews. The request then proceeds with
+// Identity as its peer identity, and NewToken is sent back to the client in
+// the "auth-token-bin" response header. Client auth handlers implementing
+// ClientTokenRenewer pick it up for subsequent requests.
+type ExpiredTokenError struct {
+	Identity interface{}
+	NewToken string
+}
+
+func (e *ExpiredTokenError) Error() string { return "flight: auth token expired" }
+
+// validateToken validates token with auth, returning the renewed token if
+// auth renewed it.
+func validateToken(auth ServerAuthHandler, token string) (identity interface{}, renewed string, err error) {
+	identity, err = auth.IsValid(token)
+	var expired *ExpiredTokenError
+	switch {
+	case err == nil:
+		return identity, "", nil
+	case xerrors.As(err, &expired):
+		return expired.Identity, expired.NewToken, nil
+	}
+	return nil, "", authError(err)
+}
 
-		peerIdentity, err := auth.IsValid(authTok)
+// tokenFromContext returns the auth token sent by the client of ctx.
+func tokenFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(grpcAuthHeader); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// authenticate validates the call of ctx with auth, returning the
+// identity of the client and the token to send back if auth renewed it.
+func authenticate(ctx context.Context, auth ServerAuthHandler) (identity interface{}, renewed string, err error) {
+	if peerAuth, ok := auth.(peerAuthHandler); ok {
+		identity, err = peerAuth.validatePeer(ctx)
 		if err != nil {
-			return nil, status.Errorf(codes.PermissionDenied, "auth-error: %s", err)
+			return nil, "", authError(err)
 		}
+		return identity, "", nil
+	}
+	return validateToken(auth, tokenFromContext(ctx))
+}
 
-		return handler(context.WithValue(ctx, authCtxKey{}, peerIdentity), req)
+// auditFailure lets a call failing authentication proceed if audit is set,
+// reporting the failure to it, and returns err otherwise.
+func auditFailure(audit func(string, error), fullMethod string, err error) error {
+	if audit == nil {
+		return err
 	}
+	audit(fullMethod, err)
+	return nil
 }
 
-func createServerAuthStreamInterceptor(auth ServerAuthHandler) grpc.StreamServerInterceptor {
-	if auth == nil {
-		return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-			return handler(srv, stream)
+func createServerAuthUnaryInterceptor(auth ServerAuthHandler, exempt func(string) bool, audit func(string, error)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if auth == nil || exempt != nil && exempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		identity, renewed, err := authenticate(ctx, auth)
+		if err == nil && renewed != "" {
+			err = grpc.SetHeader(ctx, metadata.Pairs(grpcAuthHeader, renewed))
+		}
+		if err != nil {
+			if err := auditFailure(audit, info.FullMethod, err); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
 		}
+
+		return handler(context.WithValue(ctx, authCtxKey{}, identity), req)
 	}
+}
 
+func createServerAuthStreamInterceptor(auth ServerAuthHandler, exempt func(string) bool, audit func(string, error)) grpc.StreamServerInterceptor {
+	_, peerAuth := auth.(peerAuthHandler)
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if strings.HasSuffix(info.FullMethod, "/Handshake") {
+		// the handshake authenticates clients with tokens, not with peers.
+		if auth == nil || exempt != nil && exempt(info.FullMethod) || !peerAuth && strings.HasSuffix(info.FullMethod, "/Handshake") {
 			return handler(srv, stream)
 		}
 
-		var authTok string
-		md, ok := metadata.FromIncomingContext(stream.Context())
-		if ok {
-			vals := md.Get(grpcAuthHeader)
-			if len(vals) > 0 {
-				authTok = vals[0]
-			}
+		identity, renewed, err := authenticate(stream.Context(), auth)
+		if err == nil && renewed != "" {
+			err = stream.SetHeader(metadata.Pairs(grpcAuthHeader, renewed))
 		}
-
-		peerIdentity, err := auth.IsValid(authTok)
 		if err != nil {
-			return status.Errorf(codes.Unauthenticated, "auth-error: %s", err)
+			if err := auditFailure(audit, info.FullMethod, err); err != nil {
+				return err
+			}
+			return handler(srv, stream)
 		}
 
-		stream = &authWrappedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), authCtxKey{}, peerIdentity)}
-		return handler(srv, stream)
+		return handler(srv, &authWrappedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), authCtxKey{}, identity)})
 	}
 }
 
@@ -147,28 +233,121 @@ func (s *server) handshake(stream FlightService_HandshakeServer) error {
 		return nil
 	}
 
-	return s.authHandler.Authenticate(&serverAuthConn{stream})
+	conn := &serverAuthConn{stream}
+	if auth, ok := s.authHandler.(ServerAuthHandlerWithContext); ok {
+		return auth.AuthenticateWithContext(stream.Context(), conn)
+	}
+	return s.authHandler.Authenticate(conn)
 }
 
+// BasicAuthValidator validates the credentials of a Basic auth handshake,
+// returning a bearer token, and the bearer tokens of subsequent requests.
+// Errors are reported to clients as for ServerAuthHandler.IsValid.
 type BasicAuthValidator interface {
 	Validate(username, password string) (string, error)
 	IsValid(bearerToken string) (interface{}, error)
 }
 
+// authHeader returns the authorization header of md.
+func authHeader(md metadata.MD) (string, error) {
+	vals := md.Get(basicAuthHeader)
+	if len(vals) == 0 {
+		return "", status.Error(codes.Unauthenticated, "must authenticate first")
+	}
+	return vals[0], nil
+}
+
+// splitAuthHeader splits an authorization header into its scheme and
+// credentials, rejecting anything not of the form "<scheme> <credentials>".
+// Schemes are case-insensitive, as per RFC 7235.
+func splitAuthHeader(header string) (scheme, creds string, err error) {
+	parts := strings.Split(header, " ")
+	switch {
+	case len(parts) != 2:
+		return "", "", status.Errorf(codes.Unauthenticated, "malformed authorization header: expected 2 space separated parts, got %d", len(parts))
+	case parts[1] == "":
+		return "", "", status.Errorf(codes.Unauthenticated, "malformed authorization header: missing %s credentials", parts[0])
+	}
+	return parts[0], parts[1], nil
+}
+
+// ParseBasicAuthHeader returns the username and password of a Basic
+// authorization header, such as "Basic dXNlcjpwYXNz". The credentials may
+// be base64 encoded with or without padding. Errors are grpc status errors
+// with the Unauthenticated code.
+func ParseBasicAuthHeader(header string) (username, password string, err error) {
+	scheme, creds, err := splitAuthHeader(header)
+	if err != nil {
+		return "", "", err
+	}
+	if !strings.EqualFold(scheme, basicAuthPrefix) {
+		return "", "", status.Error(codes.Unauthenticated, "only Basic Auth implemented")
+	}
+	return parseBasicAuth(creds)
+}
+
+// ParseBearerAuthHeader returns the token of a Bearer authorization
+// header, such as "Bearer token". Errors are grpc status errors with the
+// Unauthenticated code.
+func ParseBearerAuthHeader(header string) (string, error) {
+	scheme, token, err := splitAuthHeader(header)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(scheme, bearerTokenPrefix) {
+		return "", status.Error(codes.Unauthenticated, "only bearer token auth implemented")
+	}
+	return token, nil
+}
+
+// FormatBasicAuthHeader returns the Basic authorization header for
+// username and password.
+func FormatBasicAuthHeader(username, password string) string {
+	return basicAuthPrefix + " " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// FormatBearerAuthHeader returns the Bearer authorization header for token.
+func FormatBearerAuthHeader(token string) string {
+	return bearerTokenPrefix + " " + token
+}
+
+// parseBearerToken returns the bearer token of the authorization header of md.
+func parseBearerToken(md metadata.MD) (string, error) {
+	header, err := authHeader(md)
+	if err != nil {
+		return "", err
+	}
+	return ParseBearerAuthHeader(header)
+}
+
+// parseBasicAuth decodes the base64 encoded "username:password" credentials
+// of a Basic authorization header, with or without padding.
+func parseBasicAuth(creds string) (username, password string, err error) {
+	// clients commonly send padded base64, accept it as well as the
+	// unpadded form.
+	val, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(creds, "="))
+	if err != nil {
+		return "", "", status.Errorf(codes.Unauthenticated, "invalid basic auth encoding: %s", err)
+	}
+
+	parts := strings.SplitN(string(val), ":", 2)
+	if len(parts) != 2 {
+		return "", "", status.Error(codes.Unauthenticated, "malformed basic auth credentials: missing ':' separator")
+	}
+	return parts[0], parts[1], nil
+}
+
 func createServerBearerTokenUnaryInterceptor(validator BasicAuthValidator) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		var auth string
-		md, ok := metadata.FromIncomingContext(ctx)
-		if ok {
-			vals := md.Get(basicAuthHeader)
-			if len(vals) > 0 && strings.HasPrefix(vals[0], bearerTokenPrefix) {
-				auth = vals[0][len(bearerTokenPrefix)+1:]
-			}
+		md, _ := metadata.FromIncomingContext(ctx)
+		token, err := parseBearerToken(md)
+		if err != nil {
+			return nil, err
 		}
 
-		identity, err := validator.IsValid(auth)
+		identity, err := validator.IsValid(token)
 		if err != nil {
-			return nil, err
+			return nil, authError(err)
 		}
 
 		return handler(context.WithValue(ctx, authCtxKey{}, identity), req)
@@ -177,46 +356,36 @@ func createServerBearerTokenUnaryInterceptor(validator BasicAuthValidator) grpc.
 
 func createServerBearerTokenStreamInterceptor(validator BasicAuthValidator) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		var auth []string
-		md, ok := metadata.FromIncomingContext(stream.Context())
-		if ok {
-			auth = md.Get(basicAuthHeader)
-			if len(auth) > 0 {
-				auth = strings.Split(auth[0], " ")
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		if strings.HasSuffix(info.FullMethod, "/Handshake") {
+			header, err := authHeader(md)
+			if err != nil {
+				return err
+			}
+			username, password, err := ParseBasicAuthHeader(header)
+			if err != nil {
+				return err
 			}
-		}
 
-		if auth == nil || len(auth) == 0 {
-			return status.Error(codes.Unauthenticated, "must authenticate first")
+			token, err := validator.Validate(username, password)
+			if err != nil {
+				return authError(err)
+			}
+
+			stream.SetTrailer(metadata.Pairs(basicAuthHeader, FormatBearerAuthHeader(token)))
+			return handler(srv, stream)
 		}
 
-		if strings.HasSuffix(info.FullMethod, "/Handshake") {
-			if auth[0] == basicAuthPrefix {
-				val, err := base64.RawStdEncoding.DecodeString(auth[1])
-				if err != nil {
-					return status.Errorf(codes.Unauthenticated, "invalid basic auth encoding: %s", err)
-				}
-
-				creds := strings.SplitN(string(val), ":", 2)
-				token, err := validator.Validate(creds[0], creds[1])
-				if err != nil {
-					return err
-				}
-
-				stream.SetTrailer(metadata.New(map[string]string{basicAuthHeader: strings.Join([]string{bearerTokenPrefix, token}, " ")}))
-				return handler(srv, stream)
-			}
-			return status.Errorf(codes.Unauthenticated, "only Basic Auth implemented")
+		token, err := parseBearerToken(md)
+		if err != nil {
+			return err
 		}
 
-		if auth[0] == bearerTokenPrefix {
-			identity, err := validator.IsValid(auth[1])
-			if err != nil {
-				return err
-			}
-			return handler(srv, &authWrappedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), authCtxKey{}, identity)})
+		identity, err := validator.IsValid(token)
+		if err != nil {
+			return authError(err)
 		}
-		return status.Errorf(codes.Unauthenticated, "Only bearer token auth implemented")
+		return handler(srv, &authWrappedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), authCtxKey{}, identity)})
 	}
 }
 
@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// peerAuthHandler is implemented by auth handlers which authenticate
+// requests from their connection rather than from a token. The server
+// interceptors call validatePeer for every request, Handshake included,
+// instead of IsValid.
+type peerAuthHandler interface {
+	validatePeer(ctx context.Context) (interface{}, error)
+}
+
+type tlsCertAuthHandler struct {
+	verify func(*x509.Certificate) (interface{}, error)
+}
+
+// NewTLSCertAuthHandler returns a ServerAuthHandler that authenticates
+// clients from the certificate they presented over mutual TLS, without any
+// handshake. verify is called with the leaf certificate of the first
+// verified chain of every request, and the identity it returns is
+// available to handlers through AuthFromContext. Errors of verify are
+// reported to clients as for ServerAuthHandler.IsValid.
+//
+// The server must be created with TLS credentials which verify client
+// certificates, such as tls.RequireAndVerifyClientCert or
+// tls.VerifyClientCertIfGiven. Requests on connections which are not TLS
+// or carry no verified client certificate fail with codes.Unauthenticated.
+func NewTLSCertAuthHandler(verify func(*x509.Certificate) (interface{}, error)) ServerAuthHandler {
+	if verify == nil {
+		panic("flight: verify function cannot be nil")
+	}
+	return &tlsCertAuthHandler{verify: verify}
+}
+
+// Authenticate accepts every handshake: authentication happens on the TLS
+// connection itself.
+func (h *tlsCertAuthHandler) Authenticate(AuthConn) error { return nil }
+
+// IsValid rejects every token, as requests are validated from their
+// connection.
+func (h *tlsCertAuthHandler) IsValid(string) (interface{}, error) {
+	return nil, status.Error(codes.Unauthenticated, "tls certificate auth does not use tokens")
+}
+
+func (h *tlsCertAuthHandler) validatePeer(ctx context.Context) (interface{}, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "no peer information for the request")
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "connection is not using TLS")
+	}
+
+	chains := info.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no verified client certificate")
+	}
+	return h.verify(chains[0][0])
+}
@@ -0,0 +1,202 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// newCert returns a certificate for cn signed by parent, or self-signed if
+// parent is nil.
+func newCert(t *testing.T, cn string, parent *tls.Certificate, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := tmpl, interface{}(key)
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestTLSCertAuthHandler(t *testing.T) {
+	ca := newCert(t, "test-ca", nil, x509.ExtKeyUsageAny)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+
+	serverCert := newCert(t, "server", &ca, x509.ExtKeyUsageServerAuth)
+	serverCreds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	})
+
+	auth := flight.NewTLSCertAuthHandler(func(cert *x509.Certificate) (interface{}, error) {
+		if cert.Subject.CommonName == "banned" {
+			return nil, status.Error(codes.PermissionDenied, "client is banned")
+		}
+		return cert.Subject.CommonName, nil
+	})
+
+	f := &flightServer{}
+	s := flight.NewFlightServer(auth, grpc.Creds(serverCreds))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: f.ListFlights,
+		GetSchema: func(ctx context.Context, _ *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			return &flight.SchemaResult{Schema: []byte(flight.AuthFromContext(ctx).(string))}, nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	dial := func(t *testing.T, certs ...tls.Certificate) flight.Client {
+		t.Helper()
+		creds := credentials.NewTLS(&tls.Config{RootCAs: pool, Certificates: certs})
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	for _, tc := range []struct {
+		name  string
+		certs []tls.Certificate
+		code  codes.Code
+		msg   string
+	}{
+		{"valid", []tls.Certificate{newCert(t, "client-1", &ca, x509.ExtKeyUsageClientAuth)}, codes.OK, ""},
+		{"no certificate", nil, codes.Unauthenticated, "no verified client certificate"},
+		{"rejected", []tls.Certificate{newCert(t, "banned", &ca, x509.ExtKeyUsageClientAuth)}, codes.PermissionDenied, "client is banned"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := dial(t, tc.certs...)
+			defer client.Close()
+
+			check := func(t *testing.T, err error) {
+				t.Helper()
+				st := status.Convert(err)
+				if st.Code() != tc.code || st.Message() != tc.msg {
+					t.Fatalf("invalid status: got=(%v, %q), want=(%v, %q)", st.Code(), st.Message(), tc.code, tc.msg)
+				}
+			}
+
+			ctx := context.Background()
+			sc, err := client.GetSchema(ctx, &flight.FlightDescriptor{})
+			check(t, err)
+			if err == nil && string(sc.Schema) != "client-1" {
+				t.Fatalf("invalid identity: got=%q, want=%q", sc.Schema, "client-1")
+			}
+
+			fs, err := client.ListFlights(ctx, &flight.Criteria{Expression: []byte("primitives")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			info, err := fs.Recv()
+			check(t, err)
+			if err == nil && info.FlightDescriptor.GetPath()[1] != "client-1" {
+				t.Fatalf("invalid identity: got=%q, want=%q", info.FlightDescriptor.GetPath()[1], "client-1")
+			}
+
+			// there is no handshake special case.
+			hs, err := client.Handshake(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = hs.Recv()
+			if tc.code == codes.OK {
+				if err != io.EOF {
+					t.Fatalf("handshake failed: %v", err)
+				}
+			} else {
+				check(t, err)
+			}
+		})
+	}
+}
+
+func TestTLSCertAuthHandlerInsecure(t *testing.T) {
+	auth := flight.NewTLSCertAuthHandler(func(cert *x509.Certificate) (interface{}, error) {
+		return cert.Subject.CommonName, nil
+	})
+
+	f := &flightServer{}
+	s := flight.NewFlightServer(auth)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{ListFlights: f.ListFlights})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	fs, err := client.ListFlights(context.Background(), &flight.Criteria{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fs.Recv()
+	if st := status.Convert(err); st.Code() != codes.Unauthenticated || st.Message() != "connection is not using TLS" {
+		t.Fatalf("invalid status: got=(%v, %q)", st.Code(), st.Message())
+	}
+}
@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServerMiddleware hooks into every call of a flight server, to implement
+// cross-cutting concerns such as logging or metrics. See
+// CreateServerMiddleware.
+type ServerMiddleware interface {
+	// StartCall is called when a call starts, with the headers sent by the
+	// client. The call proceeds with the returned context. An error fails
+	// the call without running its handler.
+	StartCall(ctx context.Context, incoming metadata.MD) (context.Context, error)
+	// SendingHeaders returns the headers to add to the response, or nil.
+	SendingHeaders(ctx context.Context) metadata.MD
+	// CallCompleted is called once the call is done, with the error it
+	// failed with, if any. It returns the trailers to add to the response,
+	// or nil.
+	CallCompleted(ctx context.Context, err error) metadata.MD
+}
+
+// CreateServerMiddleware returns the interceptors running middleware
+// around every call, Handshake included. They are installed with
+// grpc.ChainUnaryInterceptor and grpc.ChainStreamInterceptor, and compose
+// with the auth interceptors.
+//
+// Middleware run in order on the way in and in reverse order on the way
+// out: the StartCall hooks are called first to last, then the
+// SendingHeaders hooks first to last, and the CallCompleted hooks last to
+// first. When a StartCall hook fails, only the middleware started before it
+// see CallCompleted, with that error.
+func CreateServerMiddleware(middleware ...ServerMiddleware) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	mws := append([]ServerMiddleware(nil), middleware...)
+
+	unary := func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx, header, n, err := startCalls(ctx, mws)
+		if err == nil && len(header) > 0 {
+			err = grpc.SetHeader(ctx, header)
+		}
+		if err == nil {
+			resp, err = handler(ctx, req)
+		}
+		if trailer := completeCalls(ctx, mws[:n], err); len(trailer) > 0 {
+			grpc.SetTrailer(ctx, trailer)
+		}
+		return resp, err
+	}
+
+	stream := func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, header, n, err := startCalls(stream.Context(), mws)
+		if err == nil && len(header) > 0 {
+			err = stream.SetHeader(header)
+		}
+		if err == nil {
+			err = handler(srv, &authWrappedStream{ServerStream: stream, ctx: ctx})
+		}
+		if trailer := completeCalls(ctx, mws[:n], err); len(trailer) > 0 {
+			stream.SetTrailer(trailer)
+		}
+		return err
+	}
+
+	return unary, stream
+}
+
+// startCalls runs the StartCall and SendingHeaders hooks of mws. It
+// returns the context of the call, the headers to send, and the number of
+// middleware that started.
+func startCalls(ctx context.Context, mws []ServerMiddleware) (context.Context, metadata.MD, int, error) {
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	for i, mw := range mws {
+		next, err := mw.StartCall(ctx, incoming.Copy())
+		if err != nil {
+			return ctx, nil, i, err
+		}
+		ctx = next
+	}
+
+	var header metadata.MD
+	for _, mw := range mws {
+		header = metadata.Join(header, mw.SendingHeaders(ctx))
+	}
+	return ctx, header, len(mws), nil
+}
+
+// completeCalls runs the CallCompleted hooks of mws in reverse order, and
+// returns the trailers to send.
+func completeCalls(ctx context.Context, mws []ServerMiddleware, err error) metadata.MD {
+	var trailer metadata.MD
+	for i := len(mws) - 1; i >= 0; i-- {
+		trailer = metadata.Join(trailer, mws[i].CallCompleted(ctx, err))
+	}
+	return trailer
+}
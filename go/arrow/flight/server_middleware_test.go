@@ -0,0 +1,187 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type ctxKey string
+
+// recordingMiddleware logs its hooks into a shared log, and fails StartCall
+// for calls carrying a "fail" header naming it.
+type recordingMiddleware struct {
+	name string
+	mu   *sync.Mutex
+	log  *[]string
+}
+
+func (m *recordingMiddleware) record(s string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*m.log = append(*m.log, m.name+"."+s)
+}
+
+func (m *recordingMiddleware) StartCall(ctx context.Context, incoming metadata.MD) (context.Context, error) {
+	m.record("start")
+	for _, name := range incoming.Get("fail") {
+		if name == m.name {
+			return nil, status.Error(codes.PermissionDenied, m.name+" refused the call")
+		}
+	}
+	return context.WithValue(ctx, ctxKey(m.name), true), nil
+}
+
+func (m *recordingMiddleware) SendingHeaders(ctx context.Context) metadata.MD {
+	m.record("headers")
+	return metadata.Pairs("visited", m.name)
+}
+
+func (m *recordingMiddleware) CallCompleted(ctx context.Context, err error) metadata.MD {
+	m.record("completed:" + status.Code(err).String())
+	return metadata.Pairs("completed", m.name)
+}
+
+func TestServerMiddleware(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		log []string
+	)
+	first := &recordingMiddleware{name: "first", mu: &mu, log: &log}
+	second := &recordingMiddleware{name: "second", mu: &mu, log: &log}
+	unary, stream := flight.CreateServerMiddleware(first, second)
+
+	checkCtx := func(ctx context.Context) error {
+		if ctx.Value(ctxKey("first")) == nil || ctx.Value(ctxKey("second")) == nil {
+			return status.Error(codes.Internal, "missing middleware context")
+		}
+		return nil
+	}
+
+	f := &flightServer{}
+	s := flight.NewFlightServer(nil, grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		ListFlights: func(c *flight.Criteria, fs flight.FlightService_ListFlightsServer) error {
+			if err := checkCtx(fs.Context()); err != nil {
+				return err
+			}
+			return f.ListFlights(c, fs)
+		},
+		GetSchema: func(ctx context.Context, in *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			if err := checkCtx(ctx); err != nil {
+				return nil, err
+			}
+			return &flight.SchemaResult{}, nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	takeLog := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		out := log
+		log = nil
+		return out
+	}
+
+	wantOK := []string{
+		"first.start", "second.start",
+		"first.headers", "second.headers",
+		"second.completed:OK", "first.completed:OK",
+	}
+
+	t.Run("unary", func(t *testing.T) {
+		var header, trailer metadata.MD
+		_, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{}, grpc.Header(&header), grpc.Trailer(&trailer))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := takeLog(); !reflect.DeepEqual(got, wantOK) {
+			t.Fatalf("invalid hooks order:\ngot= %q\nwant=%q", got, wantOK)
+		}
+		if got, want := header.Get("visited"), []string{"first", "second"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid headers: got=%q, want=%q", got, want)
+		}
+		if got, want := trailer.Get("completed"), []string{"second", "first"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid trailers: got=%q, want=%q", got, want)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		fs, err := client.ListFlights(context.Background(), &flight.Criteria{Expression: []byte("primitives")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			_, err := fs.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := takeLog(); !reflect.DeepEqual(got, wantOK) {
+			t.Fatalf("invalid hooks order:\ngot= %q\nwant=%q", got, wantOK)
+		}
+		header, err := fs.Header()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := header.Get("visited"), []string{"first", "second"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid headers: got=%q, want=%q", got, want)
+		}
+		if got, want := fs.Trailer().Get("completed"), []string{"second", "first"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid trailers: got=%q, want=%q", got, want)
+		}
+	})
+
+	t.Run("failed start", func(t *testing.T) {
+		var trailer metadata.MD
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "fail", "second")
+		_, err := client.GetSchema(ctx, &flight.FlightDescriptor{}, grpc.Trailer(&trailer))
+		if st := status.Convert(err); st.Code() != codes.PermissionDenied || st.Message() != "second refused the call" {
+			t.Fatalf("invalid status: %v", err)
+		}
+		want := []string{"first.start", "second.start", "first.completed:PermissionDenied"}
+		if got := takeLog(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid hooks order:\ngot= %q\nwant=%q", got, want)
+		}
+		if got, want := trailer.Get("completed"), []string{"first"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid trailers: got=%q, want=%q", got, want)
+		}
+	})
+}
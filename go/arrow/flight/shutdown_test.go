@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"google.golang.org/grpc"
+)
+
+func TestGracefulStop(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// stuck handlers keep streaming after the server started draining.
+		stuck bool
+	}{
+		{"drained", false},
+		{"deadline", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := flight.NewFlightServer(nil)
+			s.Init("localhost:0")
+
+			ended := make(chan error, 1)
+			s.RegisterFlightService(&flight.FlightServiceService{
+				DoGet: func(_ *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+					recs := arrdata.Records["primitives"]
+					w := flight.NewRecordWriter(stream, ipc.WithSchema(recs[0].Schema()))
+					defer w.Close()
+
+					draining := s.Draining()
+					if tc.stuck {
+						draining = nil
+					}
+					for {
+						select {
+						case <-draining:
+							ended <- nil
+							return nil
+						case <-stream.Context().Done():
+							ended <- stream.Context().Err()
+							return stream.Context().Err()
+						case <-time.After(10 * time.Millisecond):
+						}
+						if err := w.Write(recs[0]); err != nil {
+							ended <- err
+							return err
+						}
+					}
+				},
+			})
+
+			go s.Serve()
+
+			client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte("slow")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := flight.NewRecordReader(stream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+			if !r.Next() {
+				t.Fatalf("no record before stopping: %v", r.Err())
+			}
+
+			stopped := make(chan bool, 1)
+			go func() { stopped <- s.GracefulStop(200 * time.Millisecond) }()
+
+			for r.Next() {
+			}
+
+			var drained bool
+			select {
+			case drained = <-stopped:
+			case <-time.After(5 * time.Second):
+				t.Fatal("server not stopped")
+			}
+
+			switch {
+			case tc.stuck:
+				if drained {
+					t.Fatal("stuck stream drained before the deadline")
+				}
+				if r.Err() == nil {
+					t.Fatal("expected the stuck stream to be terminated")
+				}
+				if err := <-ended; err == nil {
+					t.Fatal("expected the stuck handler to be cancelled")
+				}
+			default:
+				if !drained {
+					t.Fatal("stream not drained before the deadline")
+				}
+				if r.Err() != nil {
+					t.Fatalf("expected a clean end of stream, got %v", r.Err())
+				}
+				if err := <-ended; err != nil {
+					t.Fatalf("handler did not observe draining: %v", err)
+				}
+			}
+		})
+	}
+}
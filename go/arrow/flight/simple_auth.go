@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SimpleBasicAuthValidator is a BasicAuthValidator backed by a table of
+// users and passwords, issuing random opaque bearer tokens. It is safe for
+// concurrent use.
+//
+// Passwords are compared in constant time, including for unknown users,
+// and tokens are looked up by their SHA-256 digest, so that response times
+// leak nothing about valid credentials.
+type SimpleBasicAuthValidator struct {
+	users map[string][sha256.Size]byte
+	ttl   time.Duration
+
+	mu     sync.Mutex
+	tokens map[[sha256.Size]byte]issuedToken
+}
+
+type issuedToken struct {
+	username string
+	expires  time.Time // zero if the token never expires.
+}
+
+// NewSimpleBasicAuthValidator returns a validator for the given passwords,
+// keyed by username, issuing tokens valid for ttl, or forever if ttl is 0.
+func NewSimpleBasicAuthValidator(users map[string]string, ttl time.Duration) *SimpleBasicAuthValidator {
+	v := &SimpleBasicAuthValidator{
+		users:  make(map[string][sha256.Size]byte, len(users)),
+		ttl:    ttl,
+		tokens: make(map[[sha256.Size]byte]issuedToken),
+	}
+	for user, password := range users {
+		v.users[user] = sha256.Sum256([]byte(password))
+	}
+	return v
+}
+
+// Validate returns a new bearer token if password is the one of username.
+func (v *SimpleBasicAuthValidator) Validate(username, password string) (string, error) {
+	// digests have the same length whatever the passwords, so comparing
+	// them takes the same time, and unknown users are compared too.
+	want, known := v.users[username]
+	got := sha256.Sum256([]byte(password))
+	if subtle.ConstantTimeCompare(got[:], want[:]) != 1 || !known {
+		return "", status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", status.Errorf(codes.Internal, "could not generate token: %s", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf[:])
+
+	issued := issuedToken{username: username}
+	now := time.Now()
+	if v.ttl > 0 {
+		issued.expires = now.Add(v.ttl)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.prune(now)
+	v.tokens[sha256.Sum256([]byte(token))] = issued
+	return token, nil
+}
+
+// IsValid returns the username the token was issued to, if it was issued
+// by v and has not expired.
+func (v *SimpleBasicAuthValidator) IsValid(bearerToken string) (interface{}, error) {
+	key := sha256.Sum256([]byte(bearerToken))
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	issued, ok := v.tokens[key]
+	switch {
+	case !ok:
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	case issued.expired(time.Now()):
+		delete(v.tokens, key)
+		return nil, status.Error(codes.Unauthenticated, "token expired")
+	}
+	return issued.username, nil
+}
+
+// prune removes the expired tokens.
+func (v *SimpleBasicAuthValidator) prune(now time.Time) {
+	for key, issued := range v.tokens {
+		if issued.expired(now) {
+			delete(v.tokens, key)
+		}
+	}
+}
+
+func (t issuedToken) expired(now time.Time) bool {
+	return !t.expires.IsZero() && !now.Before(t.expires)
+}
@@ -0,0 +1,246 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSimpleBasicAuthValidator(t *testing.T) {
+	v := flight.NewSimpleBasicAuthValidator(map[string]string{
+		"alice": "secret",
+		"bob":   "",
+	}, 0)
+
+	for _, tc := range []struct {
+		name, username, password string
+		ok                       bool
+	}{
+		{"valid", "alice", "secret", true},
+		{"empty password", "bob", "", true},
+		{"wrong password", "alice", "Secret", false},
+		{"password prefix", "alice", "secre", false},
+		{"unknown user", "carol", "secret", false},
+		{"unknown user without password", "carol", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := v.Validate(tc.username, tc.password)
+			if !tc.ok {
+				if status.Code(err) != codes.Unauthenticated || token != "" {
+					t.Fatalf("expected an Unauthenticated error, got (%q, %v)", token, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			identity, err := v.IsValid(token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identity != tc.username {
+				t.Fatalf("invalid identity: got=%v, want=%q", identity, tc.username)
+			}
+		})
+	}
+
+	first, _ := v.Validate("alice", "secret")
+	second, _ := v.Validate("alice", "secret")
+	if first == second {
+		t.Fatalf("tokens are not unique: %q", first)
+	}
+
+	for _, token := range []string{"", "invalid", first[1:]} {
+		if _, err := v.IsValid(token); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("token %q: expected an Unauthenticated error, got %v", token, err)
+		}
+	}
+}
+
+func TestSimpleBasicAuthValidatorExpiry(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+	v := flight.NewSimpleBasicAuthValidator(map[string]string{"alice": "secret"}, ttl)
+
+	token, err := v.Validate("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.IsValid(token); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2 * ttl)
+	_, err = v.IsValid(token)
+	if st := status.Convert(err); st.Code() != codes.Unauthenticated || st.Message() != "token expired" {
+		t.Fatalf("expected an expired token, got %v", err)
+	}
+	// expired tokens are forgotten.
+	_, err = v.IsValid(token)
+	if st := status.Convert(err); st.Code() != codes.Unauthenticated || st.Message() != "invalid token" {
+		t.Fatalf("expected an invalid token, got %v", err)
+	}
+
+	token, err = v.Validate("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.IsValid(token); err != nil {
+		t.Fatalf("new token rejected: %v", err)
+	}
+}
+
+func TestSimpleBasicAuthValidatorConcurrency(t *testing.T) {
+	v := flight.NewSimpleBasicAuthValidator(map[string]string{"alice": "secret", "bob": "hunter2"}, time.Minute)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for i := 0; i < cap(errs); i++ {
+		user, password := "alice", "secret"
+		if i%2 == 1 {
+			user, password = "bob", "hunter2"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				token, err := v.Validate(user, password)
+				if err != nil {
+					errs <- err
+					return
+				}
+				identity, err := v.IsValid(token)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if identity != user {
+					errs <- status.Errorf(codes.Internal, "invalid identity %v for %s", identity, user)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestSimpleBasicAuthValidatorFlight(t *testing.T) {
+	v := flight.NewSimpleBasicAuthValidator(map[string]string{"alice": "secret"}, time.Minute)
+	unary, stream := flight.CreateServerBearerTokenAuthInterceptors(v)
+	s := flight.NewFlightServer(nil, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetSchema: func(ctx context.Context, _ *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			return &flight.SchemaResult{Schema: []byte(flight.AuthFromContext(ctx).(string))}, nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	for _, tc := range []struct {
+		password string
+		code     codes.Code
+	}{
+		{"secret", codes.OK},
+		{"wrong", codes.Unauthenticated},
+	} {
+		creds := flight.NewClientBasicAuthCredentials("alice", tc.password)
+		client, err := flight.NewFlightClient(s.Addr().String(), nil, append(creds.DialOptions(), grpc.WithInsecure())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		sc, err := client.GetSchema(context.Background(), &flight.FlightDescriptor{})
+		if status.Code(err) != tc.code {
+			t.Fatalf("password %q: invalid status: %v", tc.password, err)
+		}
+		if err == nil && string(sc.Schema) != "alice" {
+			t.Fatalf("invalid identity: %q", sc.Schema)
+		}
+	}
+}
+
+func TestAuthHeaders(t *testing.T) {
+	basic := flight.FormatBasicAuthHeader("user", "pa:ss")
+	if basic != "Basic dXNlcjpwYTpzcw==" {
+		t.Fatalf("invalid basic header: %q", basic)
+	}
+
+	for _, tc := range []struct {
+		header, username, password string
+		ok                         bool
+	}{
+		{basic, "user", "pa:ss", true},
+		{"basic dXNlcjpwYTpzcw", "user", "pa:ss", true},
+		{"Bearer dXNlcjpwYTpzcw==", "", "", false},
+		{"Basic", "", "", false},
+		{"Basic ", "", "", false},
+		{"Basic  dXNlcjpwYTpzcw==", "", "", false},
+		{"Basic !!!", "", "", false},
+		{"Basic dXNlcg==", "", "", false},
+	} {
+		username, password, err := flight.ParseBasicAuthHeader(tc.header)
+		switch {
+		case !tc.ok && status.Code(err) != codes.Unauthenticated:
+			t.Fatalf("%q: expected an Unauthenticated error, got %v", tc.header, err)
+		case tc.ok && err != nil:
+			t.Fatalf("%q: %v", tc.header, err)
+		case username != tc.username || password != tc.password:
+			t.Fatalf("%q: got=(%q, %q), want=(%q, %q)", tc.header, username, password, tc.username, tc.password)
+		}
+	}
+
+	bearer := flight.FormatBearerAuthHeader("tok")
+	if bearer != "Bearer tok" {
+		t.Fatalf("invalid bearer header: %q", bearer)
+	}
+
+	for _, tc := range []struct {
+		header, token string
+		ok            bool
+	}{
+		{bearer, "tok", true},
+		{"BEARER tok", "tok", true},
+		{"Basic tok", "", false},
+		{"Bearer", "", false},
+		{"Bearer tok extra", "", false},
+	} {
+		token, err := flight.ParseBearerAuthHeader(tc.header)
+		switch {
+		case !tc.ok && status.Code(err) != codes.Unauthenticated:
+			t.Fatalf("%q: expected an Unauthenticated error, got %v", tc.header, err)
+		case tc.ok && err != nil:
+			t.Fatalf("%q: %v", tc.header, err)
+		case token != tc.token:
+			t.Fatalf("%q: got=%q, want=%q", tc.header, token, tc.token)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
+	flatbuffers "github.com/google/flatbuffers/go"
+	"google.golang.org/grpc"
+)
+
+// StatsHandler receives the statistics of the calls of a server installed
+// with WithStatsHandler. Its methods are called concurrently for
+// concurrent calls.
+type StatsHandler interface {
+	// RPCStarted is called when a call starts, the call proceeds with the
+	// returned context, which the other methods get for the call.
+	RPCStarted(ctx context.Context, fullMethod string) context.Context
+	// BatchSent is called for each record batch the call sends, with its
+	// number of rows and the size of its ipc message.
+	BatchSent(ctx context.Context, rows, bytes int64)
+	// BatchReceived is called for each record batch the call receives.
+	BatchReceived(ctx context.Context, rows, bytes int64)
+	// RPCFinished is called once the handler of the call returned, with
+	// the error the call failed with, if any.
+	RPCFinished(ctx context.Context, err error)
+}
+
+// statsOption is the server option of WithStatsHandler.
+type statsOption struct {
+	grpc.EmptyServerOption
+	h StatsHandler
+}
+
+// WithStatsHandler returns a server option for NewFlightServer reporting
+// the calls of the server, and the record batches their streams send and
+// receive, to h, whichever reader or writer the handlers use.
+func WithStatsHandler(h StatsHandler) grpc.ServerOption {
+	return statsOption{h: h}
+}
+
+// statsStream reports the record batches of a stream to a stats handler.
+type statsStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	h   StatsHandler
+}
+
+func (s *statsStream) Context() context.Context { return s.ctx }
+
+func (s *statsStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if fd, ok := m.(*FlightData); ok && err == nil {
+		if rows, ok := recordBatchRows(fd.DataHeader); ok {
+			s.h.BatchSent(s.ctx, rows, int64(len(fd.DataHeader)+len(fd.DataBody)))
+		}
+	}
+	return err
+}
+
+func (s *statsStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if fd, ok := m.(*FlightData); ok && err == nil {
+		if rows, ok := recordBatchRows(fd.DataHeader); ok {
+			s.h.BatchReceived(s.ctx, rows, int64(len(fd.DataHeader)+len(fd.DataBody)))
+		}
+	}
+	return err
+}
+
+// recordBatchRows returns the number of rows of the record batch of a
+// flight data message, and false for other messages.
+func recordBatchRows(dataHeader []byte) (int64, bool) {
+	if !isRecordBatch(dataHeader) {
+		return 0, false
+	}
+
+	var (
+		tbl flatbuffers.Table
+		rec flatbuf.RecordBatch
+	)
+	if !flatbuf.GetRootAsMessage(dataHeader, 0).Header(&tbl) {
+		return 0, true
+	}
+	rec.Init(tbl.Bytes, tbl.Pos)
+	return rec.Length(), true
+}
+
+func createServerStatsUnaryInterceptor(h StatsHandler) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = h.RPCStarted(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		h.RPCFinished(ctx, err)
+		return resp, err
+	}
+}
+
+func createServerStatsStreamInterceptor(h StatsHandler) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := h.RPCStarted(stream.Context(), info.FullMethod)
+		err := handler(srv, &statsStream{ServerStream: stream, ctx: ctx, h: h})
+		h.RPCFinished(ctx, err)
+		return err
+	}
+}
+
+// MethodStats are the statistics of the calls of a method collected by a
+// StatsCollector.
+type MethodStats struct {
+	Calls    int64 `json:"calls"`
+	InFlight int64 `json:"in_flight"`
+	Errors   int64 `json:"errors"`
+	// Latency is the total duration of the calls finished.
+	Latency time.Duration `json:"latency_ns"`
+
+	BatchesSent     int64 `json:"batches_sent"`
+	RowsSent        int64 `json:"rows_sent"`
+	BytesSent       int64 `json:"bytes_sent"`
+	BatchesReceived int64 `json:"batches_received"`
+	RowsReceived    int64 `json:"rows_received"`
+	BytesReceived   int64 `json:"bytes_received"`
+}
+
+// StatsCollector is a StatsHandler counting the calls of a server, and the
+// record batches they send and receive, by full method name. Its String
+// method renders the statistics as JSON, so that it can be published with
+// expvar.Publish, and Stats returns them for other monitoring systems.
+type StatsCollector struct {
+	mu      sync.Mutex
+	methods map[string]*MethodStats
+}
+
+// NewStatsCollector returns a collector without statistics.
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{methods: make(map[string]*MethodStats)}
+}
+
+type collectorCtxKey struct{}
+
+type collectedRPC struct {
+	stats *MethodStats
+	start time.Time
+}
+
+func (c *StatsCollector) RPCStarted(ctx context.Context, fullMethod string) context.Context {
+	c.mu.Lock()
+	stats, ok := c.methods[fullMethod]
+	if !ok {
+		stats = &MethodStats{}
+		c.methods[fullMethod] = stats
+	}
+	c.mu.Unlock()
+
+	atomic.AddInt64(&stats.Calls, 1)
+	atomic.AddInt64(&stats.InFlight, 1)
+	return context.WithValue(ctx, collectorCtxKey{}, &collectedRPC{stats: stats, start: time.Now()})
+}
+
+func (c *StatsCollector) BatchSent(ctx context.Context, rows, bytes int64) {
+	if rpc, ok := ctx.Value(collectorCtxKey{}).(*collectedRPC); ok {
+		atomic.AddInt64(&rpc.stats.BatchesSent, 1)
+		atomic.AddInt64(&rpc.stats.RowsSent, rows)
+		atomic.AddInt64(&rpc.stats.BytesSent, bytes)
+	}
+}
+
+func (c *StatsCollector) BatchReceived(ctx context.Context, rows, bytes int64) {
+	if rpc, ok := ctx.Value(collectorCtxKey{}).(*collectedRPC); ok {
+		atomic.AddInt64(&rpc.stats.BatchesReceived, 1)
+		atomic.AddInt64(&rpc.stats.RowsReceived, rows)
+		atomic.AddInt64(&rpc.stats.BytesReceived, bytes)
+	}
+}
+
+func (c *StatsCollector) RPCFinished(ctx context.Context, err error) {
+	if rpc, ok := ctx.Value(collectorCtxKey{}).(*collectedRPC); ok {
+		atomic.AddInt64(&rpc.stats.InFlight, -1)
+		atomic.AddInt64((*int64)(&rpc.stats.Latency), int64(time.Since(rpc.start)))
+		if err != nil {
+			atomic.AddInt64(&rpc.stats.Errors, 1)
+		}
+	}
+}
+
+// Stats returns a snapshot of the statistics of the methods called so far.
+func (c *StatsCollector) Stats() map[string]MethodStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make(map[string]MethodStats, len(c.methods))
+	for method, s := range c.methods {
+		out[method] = MethodStats{
+			Calls:           atomic.LoadInt64(&s.Calls),
+			InFlight:        atomic.LoadInt64(&s.InFlight),
+			Errors:          atomic.LoadInt64(&s.Errors),
+			Latency:         time.Duration(atomic.LoadInt64((*int64)(&s.Latency))),
+			BatchesSent:     atomic.LoadInt64(&s.BatchesSent),
+			RowsSent:        atomic.LoadInt64(&s.RowsSent),
+			BytesSent:       atomic.LoadInt64(&s.BytesSent),
+			BatchesReceived: atomic.LoadInt64(&s.BatchesReceived),
+			RowsReceived:    atomic.LoadInt64(&s.RowsReceived),
+			BytesReceived:   atomic.LoadInt64(&s.BytesReceived),
+		}
+	}
+	return out
+}
+
+// String returns the statistics of Stats as a JSON object keyed by full
+// method name, as expected from an expvar.Var.
+func (c *StatsCollector) String() string {
+	b, err := json.Marshal(c.Stats())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+var _ StatsHandler = (*StatsCollector)(nil)
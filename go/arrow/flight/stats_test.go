@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatsCollector(t *testing.T) {
+	const nclients = 4
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i32", Type: arrow.PrimitiveTypes.Int32}}, nil)
+	bldr := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, nil)
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	stats := flight.NewStatsCollector()
+	var _ expvar.Var = stats
+
+	s := flight.NewFlightServer(nil, flight.WithStatsHandler(stats))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetFlightInfo: func(context.Context, *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+			return nil, status.Error(codes.NotFound, "no such flight")
+		},
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewWriter(stream)
+			defer w.Close()
+			for i := 0; i < 3; i++ {
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			r := flight.NewReader(stream)
+			defer r.Release()
+			for r.Next() {
+			}
+			return r.Err()
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < nclients; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := client.DoGet(context.Background(), &flight.Ticket{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for {
+				if _, err := stream.Recv(); err != nil {
+					if err != io.EOF {
+						t.Error(err)
+					}
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	put, err := client.DoPut(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := flight.NewWriter(put)
+	w.SetFlightDescriptor(&flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"stats"}})
+	for i := 0; i < 2; i++ {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := put.Recv(); err != io.EOF {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetFlightInfo(context.Background(), &flight.FlightDescriptor{}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := stats.Stats()
+
+	get := got["/arrow.flight.protocol.FlightService/DoGet"]
+	if get.Calls != nclients || get.InFlight != 0 || get.Errors != 0 {
+		t.Errorf("invalid DoGet calls: %+v", get)
+	}
+	if get.BatchesSent != 3*nclients || get.RowsSent != 30*nclients || get.BytesSent <= 0 {
+		t.Errorf("invalid DoGet batches: %+v", get)
+	}
+	if get.BatchesReceived != 0 || get.Latency <= 0 {
+		t.Errorf("invalid DoGet stats: %+v", get)
+	}
+
+	doPut := got["/arrow.flight.protocol.FlightService/DoPut"]
+	if doPut.Calls != 1 || doPut.BatchesReceived != 2 || doPut.RowsReceived != 20 || doPut.BytesReceived != get.BytesSent/(3*nclients)*2 {
+		t.Errorf("invalid DoPut stats: %+v", doPut)
+	}
+
+	info := got["/arrow.flight.protocol.FlightService/GetFlightInfo"]
+	if info.Calls != 1 || info.Errors != 1 || info.InFlight != 0 {
+		t.Errorf("invalid GetFlightInfo stats: %+v", info)
+	}
+
+	var decoded map[string]flight.MethodStats
+	if err := json.Unmarshal([]byte(stats.String()), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["/arrow.flight.protocol.FlightService/DoPut"] != doPut {
+		t.Errorf("invalid JSON stats: %s", stats.String())
+	}
+}
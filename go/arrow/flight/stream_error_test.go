@@ -0,0 +1,224 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkStatus checks that err is the error of the server, with its code and
+// detail bytes.
+func checkStatus(t *testing.T, err error, detail []byte) {
+	t.Helper()
+
+	if err == nil || xerrors.Is(err, io.EOF) {
+		t.Fatalf("expected the error of the server, got %v", err)
+	}
+	if !xerrors.Is(err, flight.ErrUnavailable) || status.Code(err) != codes.Unavailable {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	se, _ := flight.StatusFromError(err)
+	if !bytes.Equal(se.Detail, detail) {
+		t.Fatalf("invalid detail: got=%x, want=%x", se.Detail, detail)
+	}
+}
+
+func TestDoGetErrorAfterBatches(t *testing.T) {
+	detail := []byte("partial")
+	recs := arrdata.Records["primitives"]
+
+	// the ticket is the number of record batches sent before failing.
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			var n int
+			fmt.Sscan(string(tkt.Ticket), &n)
+			w := flight.NewWriter(stream)
+			for _, rec := range recs[:n] {
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return flight.NewStatusError(flight.ErrUnavailable, detail, "failed after %d batches", n)
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, n := range []int{0, 1, len(recs)} {
+		tkt := &flight.Ticket{Ticket: []byte(fmt.Sprint(n))}
+
+		// read reads the records of r, then checks its error.
+		read := func(t *testing.T, r interface {
+			Next() bool
+			Record() array.Record
+			Err() error
+		}) {
+			i := 0
+			for r.Next() {
+				if i >= n {
+					t.Fatalf("too many records: %d", i+1)
+				}
+				if !array.RecordEqual(r.Record(), recs[i]) {
+					t.Fatalf("record %d differ", i)
+				}
+				i++
+			}
+			if i != n {
+				t.Fatalf("invalid number of records: got=%d, want=%d", i, n)
+			}
+			checkStatus(t, r.Err(), detail)
+		}
+
+		t.Run(fmt.Sprintf("RecordReader-%d", n), func(t *testing.T) {
+			stream, err := client.DoGet(context.Background(), tkt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := flight.NewRecordReader(stream)
+			if n == 0 {
+				checkStatus(t, err, detail)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+			read(t, r)
+		})
+
+		t.Run(fmt.Sprintf("Reader-%d", n), func(t *testing.T) {
+			stream, err := client.DoGet(context.Background(), tkt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := flight.NewReader(stream)
+			defer r.Release()
+			read(t, r)
+		})
+
+		t.Run(fmt.Sprintf("PrefetchReader-%d", n), func(t *testing.T) {
+			if n == 0 {
+				t.Skip("no record reader without a schema")
+			}
+			stream, err := client.DoGet(context.Background(), tkt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr, err := flight.NewRecordReader(stream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := flight.NewPrefetchReader(rr, 4)
+			defer r.Release()
+			read(t, r)
+		})
+	}
+}
+
+func TestPutStreamRejection(t *testing.T) {
+	detail := []byte("rejected")
+	recs := arrdata.Records["primitives"]
+
+	// the server rejects the upload after its first record batch.
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoPut: func(stream flight.FlightService_DoPutServer) error {
+			r := flight.NewReader(stream)
+			defer r.Release()
+			if !r.Next() {
+				return r.Err()
+			}
+			return flight.NewStatusError(flight.ErrUnavailable, detail, "upload rejected")
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	t.Run("PutStream", func(t *testing.T) {
+		w, results, err := client.PutStream(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.SetFlightDescriptor(&flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"rejected"}})
+
+		// the writer fails with the error of the server while still
+		// writing, without the results being read.
+		deadline := time.Now().Add(10 * time.Second)
+		for i := 0; ; i++ {
+			err = w.Write(recs[i%len(recs)])
+			if err != nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("the rejection was not reported after %d batches", i+1)
+			}
+			time.Sleep(time.Millisecond)
+		}
+		checkStatus(t, err, detail)
+
+		if results.Next() {
+			t.Fatalf("unexpected result")
+		}
+		checkStatus(t, results.Err(), detail)
+		w.Close()
+	})
+
+	t.Run("PutRecords", func(t *testing.T) {
+		var many []array.Record
+		for i := 0; i < 100; i++ {
+			many = append(many, recs...)
+		}
+		rdr, err := array.NewRecordReader(recs[0].Schema(), many)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rdr.Release()
+
+		_, err = client.PutRecords(context.Background(), &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"rejected"}}, rdr)
+		checkStatus(t, err, detail)
+	})
+}
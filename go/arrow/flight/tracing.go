@@ -0,0 +1,315 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The attributes set on the spans of the tracing middleware.
+const (
+	TraceAttrTicketSize            = "flight.ticket.size"
+	TraceAttrDescriptorSize        = "flight.descriptor.size"
+	TraceAttrRecordBatchesSent     = "flight.record_batches.sent"
+	TraceAttrRecordBatchesReceived = "flight.record_batches.received"
+)
+
+// SpanContext identifies a span across processes, as carried by the W3C
+// traceparent and tracestate headers.
+type SpanContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	TraceFlags byte
+	TraceState string
+}
+
+// IsValid reports whether sc identifies a span.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SpanContext() SpanContext
+	SetAttribute(key string, value interface{})
+	// End ends the span, with the error the call failed with, if any.
+	End(err error)
+}
+
+// Tracer starts the spans of the tracing middleware. It is typically an
+// adapter to an OpenTelemetry tracer, which keeps this package free of a
+// dependency on a given OpenTelemetry version.
+type Tracer interface {
+	// Start starts a span named name, and returns it along with a context
+	// carrying it. The parent of the span is remote if it is valid, that is
+	// on the server side when the client sent a traceparent header, and the
+	// span of ctx otherwise.
+	Start(ctx context.Context, name string, remote SpanContext) (context.Context, Span)
+}
+
+// NewTracingServerMiddleware returns the interceptors starting a span of
+// tracer around every call of a server, named after the method, such as
+// "DoGet". The spans are children of the spans of the clients, propagated
+// with the W3C traceparent and tracestate headers.
+//
+// The spans record the size of the ticket or flight descriptor of the
+// call, and the number of record batches sent and received, see the
+// TraceAttr constants.
+func NewTracingServerMiddleware(tracer Tracer) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	start := func(ctx context.Context, fullMethod string) (context.Context, *tracedCall) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx, span := tracer.Start(ctx, path.Base(fullMethod), extractSpanContext(md))
+		return ctx, &tracedCall{span: span}
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, call := start(ctx, info.FullMethod)
+		call.received(req)
+		resp, err := handler(ctx, req)
+		call.end(err)
+		return resp, err
+	}
+
+	stream := func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, call := start(stream.Context(), info.FullMethod)
+		err := handler(srv, &tracedServerStream{ServerStream: stream, ctx: ctx, call: call})
+		call.end(err)
+		return err
+	}
+
+	return unary, stream
+}
+
+// NewTracingClientMiddleware returns the interceptors starting a span of
+// tracer around every call of a client, named after the method, such as
+// "DoGet", and propagating it to the server with the W3C traceparent and
+// tracestate headers. They are installed with grpc.WithChainUnaryInterceptor
+// and grpc.WithChainStreamInterceptor.
+//
+// The spans record the same attributes as NewTracingServerMiddleware. The
+// span of a stream ends once the stream is read to its end, fails, or its
+// context is cancelled.
+func NewTracingClientMiddleware(tracer Tracer) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	start := func(ctx context.Context, method string) (context.Context, *tracedCall) {
+		ctx, span := tracer.Start(ctx, path.Base(method), SpanContext{})
+		return injectSpanContext(ctx, span.SpanContext()), &tracedCall{span: span}
+	}
+
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, call := start(ctx, method)
+		call.sent(req)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		call.end(err)
+		return err
+	}
+
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, call := start(ctx, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			call.end(err)
+			return nil, err
+		}
+
+		// the context of the stream is done once the stream is over, the
+		// span of a stream cancelled by the caller is ended here as it may
+		// never be read again.
+		go func() {
+			<-cs.Context().Done()
+			if err := ctx.Err(); err != nil {
+				call.end(err)
+			}
+		}()
+		return &tracedClientStream{ClientStream: cs, call: call}, nil
+	}
+
+	return unary, stream
+}
+
+// tracedCall records the attributes of the span of a call, and ends it
+// once.
+type tracedCall struct {
+	span Span
+
+	mu                           sync.Mutex
+	ended                        bool
+	batchesSent, batchesReceived int64
+}
+
+func (c *tracedCall) sent(m interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batchesSent += c.inspect(m)
+}
+
+func (c *tracedCall) received(m interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batchesReceived += c.inspect(m)
+}
+
+// inspect records the ticket or descriptor size of m, and returns the
+// number of record batches m carries.
+func (c *tracedCall) inspect(m interface{}) int64 {
+	if c.ended {
+		return 0
+	}
+	switch m := m.(type) {
+	case *Ticket:
+		c.span.SetAttribute(TraceAttrTicketSize, len(m.Ticket))
+	case *FlightDescriptor:
+		c.span.SetAttribute(TraceAttrDescriptorSize, proto.Size(m))
+	case *FlightData:
+		if m.FlightDescriptor != nil {
+			c.span.SetAttribute(TraceAttrDescriptorSize, proto.Size(m.FlightDescriptor))
+		}
+		if isRecordBatch(m.DataHeader) {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (c *tracedCall) end(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ended {
+		return
+	}
+	c.ended = true
+	if c.batchesSent > 0 {
+		c.span.SetAttribute(TraceAttrRecordBatchesSent, c.batchesSent)
+	}
+	if c.batchesReceived > 0 {
+		c.span.SetAttribute(TraceAttrRecordBatchesReceived, c.batchesReceived)
+	}
+	c.span.End(err)
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	call *tracedCall
+}
+
+func (s *tracedServerStream) Context() context.Context { return s.ctx }
+
+func (s *tracedServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.call.sent(m)
+	}
+	return err
+}
+
+func (s *tracedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.received(m)
+	}
+	return err
+}
+
+type tracedClientStream struct {
+	grpc.ClientStream
+	call *tracedCall
+}
+
+func (s *tracedClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.sent(m)
+	}
+	return err
+}
+
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch err {
+	case nil:
+		s.call.received(m)
+	case io.EOF:
+		s.call.end(nil)
+	default:
+		s.call.end(err)
+	}
+	return err
+}
+
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
+// injectSpanContext adds the traceparent and tracestate headers for sc to
+// the outgoing metadata of ctx.
+func injectSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	kv := []string{traceparentHeader, "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + hex.EncodeToString([]byte{sc.TraceFlags})}
+	if sc.TraceState != "" {
+		kv = append(kv, tracestateHeader, sc.TraceState)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// extractSpanContext returns the span context of the traceparent and
+// tracestate headers of md, or the zero SpanContext if there is no valid
+// traceparent header.
+func extractSpanContext(md metadata.MD) SpanContext {
+	vals := md.Get(traceparentHeader)
+	if len(vals) != 1 {
+		return SpanContext{}
+	}
+
+	// version-traceid-spanid-flags, later versions may append fields.
+	parts := strings.Split(vals[0], "-")
+	if len(parts) < 4 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}
+	}
+
+	var (
+		sc             SpanContext
+		version, flags [1]byte
+	)
+	if !decodeHex(version[:], parts[0]) || !decodeHex(sc.TraceID[:], parts[1]) || !decodeHex(sc.SpanID[:], parts[2]) || !decodeHex(flags[:], parts[3]) || !sc.IsValid() {
+		return SpanContext{}
+	}
+	sc.TraceFlags = flags[0]
+	sc.TraceState = strings.Join(md.Get(tracestateHeader), ",")
+	return sc
+}
+
+// decodeHex decodes the lowercase hex string s into dst, which it must fill
+// exactly.
+func decodeHex(dst []byte, s string) bool {
+	if len(s) != 2*len(dst) || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
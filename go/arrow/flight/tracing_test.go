@@ -0,0 +1,317 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type spanKey struct{}
+
+type recordedSpan struct {
+	name   string
+	sc     flight.SpanContext
+	parent flight.SpanContext
+
+	mu    sync.Mutex
+	attrs map[string]interface{}
+	ended bool
+	err   error
+}
+
+func (s *recordedSpan) SpanContext() flight.SpanContext { return s.sc }
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *recordedSpan) End(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		panic("span ended twice")
+	}
+	s.ended, s.err = true, err
+}
+
+// waitEnd waits for the span to end, and returns the error it ended with.
+func (s *recordedSpan) waitEnd(t *testing.T) error {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		ended, err := s.ended, s.err
+		s.mu.Unlock()
+		if ended {
+			return err
+		}
+	}
+	t.Fatalf("span %s not ended", s.name)
+	return nil
+}
+
+func (s *recordedSpan) attr(key string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attrs[key]
+}
+
+// recordingTracer records the spans it starts, with sequential ids.
+type recordingTracer struct {
+	id byte
+
+	mu    sync.Mutex
+	next  byte
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, remote flight.SpanContext) (context.Context, flight.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parent := remote
+	if !parent.IsValid() {
+		if p, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+			parent = p.sc
+		}
+	}
+
+	r.next++
+	span := &recordedSpan{name: name, parent: parent, attrs: make(map[string]interface{})}
+	span.sc.TraceID = parent.TraceID
+	if !parent.IsValid() {
+		span.sc.TraceID = [16]byte{r.id, r.next}
+	}
+	span.sc.SpanID = [8]byte{r.id, r.next}
+	span.sc.TraceFlags = 1
+	span.sc.TraceState = "vendor=" + string('a'+r.id)
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (r *recordingTracer) span(t *testing.T, name string) *recordedSpan {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.spans) - 1; i >= 0; i-- {
+		if r.spans[i].name == name {
+			return r.spans[i]
+		}
+	}
+	t.Fatalf("no span %s", name)
+	return nil
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	serverTracer := &recordingTracer{id: 1}
+	unary, stream := flight.NewTracingServerMiddleware(serverTracer)
+
+	f := &flightServer{}
+	s := flight.NewFlightServer(nil, grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetSchema: f.GetSchema,
+		DoGet: func(tkt *flight.Ticket, fs flight.FlightService_DoGetServer) error {
+			if string(tkt.Ticket) != "blocking" {
+				return f.DoGet(tkt, fs)
+			}
+			recs := arrdata.Records["primitives"]
+			w := flight.NewRecordWriter(fs, ipc.WithSchema(recs[0].Schema()))
+			w.Write(recs[0])
+			<-fs.Context().Done()
+			return status.FromContextError(fs.Context().Err()).Err()
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	clientTracer := &recordingTracer{id: 2}
+	cunary, cstream := flight.NewTracingClientMiddleware(clientTracer)
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(cunary), grpc.WithChainStreamInterceptor(cstream))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	checkLink := func(t *testing.T, child, parent *recordedSpan) {
+		t.Helper()
+		if child.parent != parent.sc {
+			t.Fatalf("span %s is not a child of %s: got=%+v, want=%+v", child.name, parent.name, child.parent, parent.sc)
+		}
+		if child.sc.TraceID != parent.sc.TraceID {
+			t.Fatalf("span %s is not in the trace of %s", child.name, parent.name)
+		}
+	}
+
+	checkAttr := func(t *testing.T, span *recordedSpan, key string, want interface{}) {
+		t.Helper()
+		if got := span.attr(key); got != want {
+			t.Fatalf("span %s: invalid %s: got=%v (%T), want=%v (%T)", span.name, key, got, got, want, want)
+		}
+	}
+
+	t.Run("DoGet", func(t *testing.T) {
+		ctx, root := clientTracer.Start(context.Background(), "root", flight.SpanContext{})
+		fs, err := client.DoGet(ctx, &flight.Ticket{Ticket: []byte("primitives")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := flight.NewRecordReader(fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for r.Next() {
+			n++
+		}
+		r.Release()
+		if want := len(arrdata.Records["primitives"]); n != want {
+			t.Fatalf("invalid number of records: got=%d, want=%d", n, want)
+		}
+
+		clientSpan, serverSpan := clientTracer.span(t, "DoGet"), serverTracer.span(t, "DoGet")
+		if err := clientSpan.waitEnd(t); err != nil {
+			t.Fatalf("client span failed: %v", err)
+		}
+		if err := serverSpan.waitEnd(t); err != nil {
+			t.Fatalf("server span failed: %v", err)
+		}
+		checkLink(t, clientSpan, root.(*recordedSpan))
+		checkLink(t, serverSpan, clientSpan)
+
+		for _, span := range []*recordedSpan{clientSpan, serverSpan} {
+			checkAttr(t, span, flight.TraceAttrTicketSize, len("primitives"))
+		}
+		checkAttr(t, clientSpan, flight.TraceAttrRecordBatchesReceived, int64(n))
+		checkAttr(t, serverSpan, flight.TraceAttrRecordBatchesSent, int64(n))
+	})
+
+	t.Run("GetSchema", func(t *testing.T) {
+		desc := &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"primitives"}}
+		if _, err := client.GetSchema(context.Background(), desc); err != nil {
+			t.Fatal(err)
+		}
+
+		clientSpan, serverSpan := clientTracer.span(t, "GetSchema"), serverTracer.span(t, "GetSchema")
+		if clientSpan.parent.IsValid() {
+			t.Fatalf("unexpected parent for a root span: %+v", clientSpan.parent)
+		}
+		checkLink(t, serverSpan, clientSpan)
+		for _, span := range []*recordedSpan{clientSpan, serverSpan} {
+			if err := span.waitEnd(t); err != nil {
+				t.Fatalf("span failed: %v", err)
+			}
+			checkAttr(t, span, flight.TraceAttrDescriptorSize, 14)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		fs, err := client.DoGet(ctx, &flight.Ticket{Ticket: []byte("blocking")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fs.Recv(); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+
+		if err := clientTracer.span(t, "DoGet").waitEnd(t); err != context.Canceled {
+			t.Fatalf("invalid client span error: %v", err)
+		}
+		if err := serverTracer.span(t, "DoGet").waitEnd(t); status.Code(err) != codes.Canceled {
+			t.Fatalf("invalid server span error: %v", err)
+		}
+	})
+}
+
+func TestTracingHeaders(t *testing.T) {
+	serverTracer := &recordingTracer{id: 1}
+	unary, _ := flight.NewTracingServerMiddleware(serverTracer)
+
+	s := flight.NewFlightServer(nil, grpc.ChainUnaryInterceptor(unary))
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		GetSchema: func(context.Context, *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+			return &flight.SchemaResult{}, nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	valid := flight.SpanContext{
+		TraceID:    [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: 1,
+		TraceState: "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE",
+	}
+
+	for _, tc := range []struct {
+		name string
+		md   metadata.MD
+		want flight.SpanContext
+	}{
+		{"none", nil, flight.SpanContext{}},
+		{"valid", metadata.Pairs(
+			"traceparent", "00-"+traceID+"-"+spanID+"-01",
+			"tracestate", "rojo=00f067aa0ba902b7",
+			"tracestate", "congo=t61rcWkgMzE",
+		), valid},
+		{"future version", metadata.Pairs("traceparent", "01-"+traceID+"-"+spanID+"-01-extra"),
+			flight.SpanContext{TraceID: valid.TraceID, SpanID: valid.SpanID, TraceFlags: 1}},
+		{"invalid version", metadata.Pairs("traceparent", "ff-"+traceID+"-"+spanID+"-01"), flight.SpanContext{}},
+		{"extra field", metadata.Pairs("traceparent", "00-"+traceID+"-"+spanID+"-01-extra"), flight.SpanContext{}},
+		{"uppercase", metadata.Pairs("traceparent", "00-4BF92F3577B34DA6A3CE929D0E0E4736-"+spanID+"-01"), flight.SpanContext{}},
+		{"zero trace id", metadata.Pairs("traceparent", "00-00000000000000000000000000000000-"+spanID+"-01"), flight.SpanContext{}},
+		{"short span id", metadata.Pairs("traceparent", "00-"+traceID+"-00f067aa0ba902-01"), flight.SpanContext{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tc.md)
+			if _, err := client.GetSchema(ctx, &flight.FlightDescriptor{}); err != nil {
+				t.Fatal(err)
+			}
+			if got := serverTracer.span(t, "GetSchema").parent; got != tc.want {
+				t.Fatalf("invalid parent:\ngot= %+v\nwant=%+v", got, tc.want)
+			}
+		})
+	}
+}
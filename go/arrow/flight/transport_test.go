@@ -0,0 +1,207 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordStore keeps the records put to it, and serves them back from the
+// location of its server, or from the connection of the flight info when
+// loc is nil.
+type recordStore struct {
+	loc func() *flight.Location
+
+	mu   sync.Mutex
+	recs map[string][]array.Record
+}
+
+func (r *recordStore) GetFlightInfo(_ context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	ep := &flight.FlightEndpoint{Ticket: &flight.Ticket{Ticket: []byte(strings.Join(desc.GetPath(), "/"))}}
+	if r.loc != nil {
+		ep.Location = []*flight.Location{r.loc()}
+	}
+	return &flight.FlightInfo{
+		FlightDescriptor: desc,
+		Endpoint:         []*flight.FlightEndpoint{ep},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}, nil
+}
+
+func (r *recordStore) DoPut(stream flight.FlightService_DoPutServer) error {
+	rdr := flight.NewReader(stream)
+	defer rdr.Release()
+
+	desc := rdr.Descriptor()
+	if desc == nil {
+		return status.Error(codes.InvalidArgument, "missing descriptor")
+	}
+
+	var recs []array.Record
+	for rdr.Next() {
+		rec := rdr.Record()
+		rec.Retain()
+		recs = append(recs, rec)
+	}
+	if err := rdr.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recs[strings.Join(desc.GetPath(), "/")] = recs
+	return nil
+}
+
+func (r *recordStore) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	r.mu.Lock()
+	recs, ok := r.recs[string(tkt.GetTicket())]
+	r.mu.Unlock()
+	if !ok {
+		return status.Error(codes.NotFound, "flight not found")
+	}
+
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(recs[0].Schema()))
+	defer w.Close()
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *recordStore) service() *flight.FlightServiceService {
+	return &flight.FlightServiceService{GetFlightInfo: r.GetFlightInfo, DoPut: r.DoPut, DoGet: r.DoGet}
+}
+
+// putAndGet puts records to client, then gets them back through the
+// location of the endpoint of their flight.
+func putAndGet(t *testing.T, client flight.Client, pool *flight.ClientPool) {
+	ctx := context.Background()
+	recs := arrdata.Records["primitives"]
+	desc := &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{"primitives"}}
+
+	put, err := client.DoPut(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := flight.NewWriter(put)
+	w.SetFlightDescriptor(desc)
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := put.Recv(); err != io.EOF {
+		t.Fatalf("could not complete DoPut: %v", err)
+	}
+
+	info, err := client.GetFlightInfo(ctx, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := pool.DoGet(ctx, info.Endpoint[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	n := 0
+	for r.Next() {
+		if !array.RecordEqual(r.Record(), recs[n]) {
+			t.Fatalf("records[%d] differ", n)
+		}
+		n++
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-arrow-flight-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := flight.NewFlightServer(nil)
+	if err := s.Init("grpc+unix://" + filepath.Join(dir, "flight.sock")); err != nil {
+		t.Fatal(err)
+	}
+	store := &recordStore{recs: make(map[string][]array.Record), loc: func() *flight.Location { return flight.LocationForAddr(s.Addr()) }}
+	s.RegisterFlightService(store.service())
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	loc := flight.LocationForAddr(s.Addr())
+	if want := "grpc+unix://" + filepath.Join(dir, "flight.sock"); loc.Uri != want {
+		t.Fatalf("invalid location: got=%q, want=%q", loc.Uri, want)
+	}
+
+	pool := flight.NewClientPool(nil, nil, nil, grpc.WithInsecure())
+	defer pool.Close()
+	client, err := pool.Client(loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	putAndGet(t, client, pool)
+}
+
+func TestServeInProcess(t *testing.T) {
+	s := flight.NewFlightServer(nil)
+	store := &recordStore{recs: make(map[string][]array.Record)}
+	s.RegisterFlightService(store.service())
+
+	client, err := flight.ServeInProcess(s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Shutdown()
+	defer client.Close()
+
+	pool := flight.NewClientPool(client, nil, nil)
+	defer pool.Close()
+
+	putAndGet(t, client, pool)
+}
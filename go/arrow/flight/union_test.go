@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"google.golang.org/grpc"
+)
+
+func TestDoGetUnions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fields := []arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}
+	codes := []arrow.UnionTypeCode{2, 7}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "sparse", Type: arrow.SparseUnionOf(fields, codes)},
+		{Name: "dense", Type: arrow.DenseUnionOf(fields, codes)},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	sb := b.Field(0).(*array.SparseUnionBuilder)
+	db := b.Field(1).(*array.DenseUnionBuilder)
+	for i := 0; i < 5; i++ {
+		sb.Append(2).(*array.Int64Builder).Append(int64(i))
+		db.Append(7).(*array.StringBuilder).Append("v")
+	}
+	sb.Append(7).(*array.StringBuilder).Append("last")
+	db.AppendNull()
+	rec := b.NewRecord()
+	defer rec.Release()
+	slice := rec.NewSlice(3, 6)
+	defer slice.Release()
+	want := []array.Record{rec, slice}
+
+	s := flight.NewFlightServer(nil)
+	s.Init("localhost:0")
+	s.RegisterFlightService(&flight.FlightServiceService{
+		DoGet: func(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(schema))
+			defer w.Close()
+			for _, rec := range want {
+				if err := w.Write(rec); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	go s.Serve()
+	defer s.Shutdown()
+
+	client, err := flight.NewFlightClient(s.Addr().String(), nil, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	stream, err := client.DoGet(context.Background(), &flight.Ticket{Ticket: []byte("unions")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := flight.NewRecordReader(stream, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	var n int
+	for ; r.Next(); n++ {
+		if !array.RecordEqual(r.Record(), want[n]) {
+			t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", n, r.Record(), want[n])
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(want) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", n, len(want))
+	}
+}
@@ -29,35 +29,91 @@ type Num struct {
 	bits uint16
 }
 
+var (
+	// MaxNum is the largest finite half-precision value, 65504.
+	MaxNum = Num{bits: 0x7bff}
+	// MinNum is the smallest finite half-precision value, -65504.
+	MinNum = Num{bits: 0xfbff}
+)
+
 // New creates a new half-precision floating point value from the provided
-// float32 value.
+// float32 value, rounded to the nearest value, with ties to even.
 func New(f float32) Num {
-	b := math.Float32bits(f)
-	sn := uint16((b >> 31) & 0x1)
-	exp := (b >> 23) & 0xff
-	res := int16(exp) - 127 + 15
-	fc := uint16(b>>13) & 0x3ff
+	// the conversion to float64 is exact.
+	return FromFloat64(float64(f))
+}
+
+// FromBits returns the half-precision floating point value with the given
+// bits.
+func FromBits(b uint16) Num { return Num{bits: b} }
+
+// FromFloat64 creates a new half-precision floating point value from the
+// provided float64 value, rounded to the nearest value, with ties to even.
+// Values too large for a float16 become infinities; NaNs keep the most
+// significant bits of their payload.
+func FromFloat64(f float64) Num {
+	b := math.Float64bits(f)
+	sign := uint16(b>>48) & 0x8000
+	exp := int(b>>52) & 0x7ff
+	mant := b & (1<<52 - 1)
+
+	if exp == 0x7ff {
+		if mant == 0 {
+			return Num{bits: sign | 0x7c00}
+		}
+		payload := uint16(mant >> 42)
+		if payload == 0 {
+			payload = 0x200
+		}
+		return Num{bits: sign | 0x7c00 | payload}
+	}
+
+	// the exponent of f, biased for a float16.
+	e := exp - 1023 + 15
 	switch {
-	case exp == 0:
-		res = 0
-	case exp == 0xff:
-		res = 0x1f
-	case res > 0x1e:
-		res = 0x1f
-		fc = 0
-	case res < 0x01:
-		res = 0
-		fc = 0
+	case e >= 0x1f:
+		return Num{bits: sign | 0x7c00}
+	case e <= 0:
+		// a subnormal float16, or zero.
+		if e < -10 {
+			return Num{bits: sign}
+		}
+		mant |= 1 << 52
+		return Num{bits: sign | roundShift(mant, uint(42+1-e))}
 	}
-	return Num{bits: (sn << 15) | uint16(res<<10) | fc}
+	// a carry into the exponent rounds up to the next power of two, or to
+	// infinity.
+	return Num{bits: sign | (uint16(e)<<10 + roundShift(mant, 42))}
 }
 
+// roundShift returns v >> shift, rounded to the nearest value, with ties
+// to even.
+func roundShift(v uint64, shift uint) uint16 {
+	var (
+		h    = v >> shift
+		rem  = v & (1<<shift - 1)
+		half = uint64(1) << (shift - 1)
+	)
+	if rem > half || (rem == half && h&1 == 1) {
+		h++
+	}
+	return uint16(h)
+}
+
+// Float32 returns the value of f as a float32. The conversion is exact.
 func (f Num) Float32() float32 {
 	sn := uint32((f.bits >> 15) & 0x1)
 	exp := (f.bits >> 10) & 0x1f
 	res := uint32(exp) + 127 - 15
 	fc := uint32(f.bits & 0x3ff)
 	switch {
+	case exp == 0 && fc != 0:
+		// subnormal values are fc * 2^-24.
+		v := float32(fc) / (1 << 24)
+		if sn != 0 {
+			return -v
+		}
+		return v
 	case exp == 0:
 		res = 0
 	case exp == 0x1f:
@@ -66,5 +122,80 @@ func (f Num) Float32() float32 {
 	return math.Float32frombits((sn << 31) | (res << 23) | (fc << 13))
 }
 
+// FromFloat32s converts the float32 values of src to half-precision values
+// in dst, rounded to the nearest value, with ties to even. It converts
+// min(len(dst), len(src)) values and returns their number.
+func FromFloat32s(dst []Num, src []float32) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i, v := range src[:n] {
+		dst[i] = New(v)
+	}
+	return n
+}
+
+// ToFloat32s converts the half-precision values of src to float32 values
+// in dst. It converts min(len(dst), len(src)) values and returns their
+// number.
+func ToFloat32s(dst []float32, src []Num) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i, v := range src[:n] {
+		dst[i] = v.Float32()
+	}
+	return n
+}
+
+// Add returns f + rhs, rounded to the nearest half-precision value.
+func (f Num) Add(rhs Num) Num { return New(f.Float32() + rhs.Float32()) }
+
+// Sub returns f - rhs, rounded to the nearest half-precision value.
+func (f Num) Sub(rhs Num) Num { return New(f.Float32() - rhs.Float32()) }
+
+// Mul returns f * rhs, rounded to the nearest half-precision value.
+func (f Num) Mul(rhs Num) Num { return New(f.Float32() * rhs.Float32()) }
+
+// Div returns f / rhs, rounded to the nearest half-precision value.
+func (f Num) Div(rhs Num) Num { return New(f.Float32() / rhs.Float32()) }
+
+// Cmp compares f and rhs and returns:
+//
+//	-1 if f <  rhs
+//	 0 if f == rhs, or if f or rhs is a NaN
+//	+1 if f >  rhs
+func (f Num) Cmp(rhs Num) int {
+	switch {
+	case f.Less(rhs):
+		return -1
+	case f.Greater(rhs):
+		return +1
+	}
+	return 0
+}
+
+// Less returns whether f < rhs. Less is false if f or rhs is a NaN.
+func (f Num) Less(rhs Num) bool { return f.Float32() < rhs.Float32() }
+
+// Greater returns whether f > rhs. Greater is false if f or rhs is a NaN.
+func (f Num) Greater(rhs Num) bool { return f.Float32() > rhs.Float32() }
+
+// IsNaN returns whether f is a NaN.
+func (f Num) IsNaN() bool { return f.bits&0x7c00 == 0x7c00 && f.bits&0x03ff != 0 }
+
+// IsInf returns whether f is an infinity, according to sign.
+// If sign > 0, IsInf reports whether f is positive infinity.
+// If sign < 0, IsInf reports whether f is negative infinity.
+// If sign == 0, IsInf reports whether f is either infinity.
+func (f Num) IsInf(sign int) bool {
+	return (sign >= 0 && f.bits == 0x7c00) || (sign <= 0 && f.bits == 0xfc00)
+}
+
+// Signbit returns whether f is negative or negative zero.
+func (f Num) Signbit() bool { return f.bits&0x8000 != 0 }
+
 func (f Num) Uint16() uint16 { return f.bits }
 func (f Num) String() string { return strconv.FormatFloat(float64(f.Float32()), 'g', -1, 32) }
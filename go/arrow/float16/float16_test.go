@@ -18,6 +18,8 @@ package float16
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,3 +45,220 @@ func TestFloat16(t *testing.T) {
 		assert.Equal(t, k.String(), fmt.Sprintf("%v", v), "string representation differ")
 	}
 }
+
+func TestRoundTrip(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		f := FromBits(uint16(i))
+		if f.IsNaN() {
+			// the quiet bit may be set by the conversion, the rest of the
+			// payload is kept.
+			if got := New(f.Float32()); !got.IsNaN() || got.bits|0x200 != f.bits|0x200 {
+				t.Fatalf("%#04x: invalid NaN round-trip: got=%#04x", i, got.bits)
+			}
+			if got := FromFloat64(math.Float64frombits(uint64(f.bits&0x8000)<<48 | 0x7ff<<52 | uint64(f.bits&0x3ff)<<42)); got != f {
+				t.Fatalf("%#04x: invalid NaN payload: got=%#04x", i, got.bits)
+			}
+			continue
+		}
+		if got := New(f.Float32()); got != f {
+			t.Fatalf("%#04x: invalid float32 round-trip: got=%#04x (%v)", i, got.bits, f.Float32())
+		}
+		if got := FromFloat64(float64(f.Float32())); got != f {
+			t.Fatalf("%#04x: invalid float64 round-trip: got=%#04x", i, got.bits)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	// walk the pairs of consecutive positive values, up to infinity.
+	for i := 0; i < 0x7c00; i++ {
+		var (
+			lo  = FromBits(uint16(i))
+			hi  = FromBits(uint16(i + 1))
+			a   = float64(lo.Float32())
+			b   = float64(hi.Float32())
+			mid = (a + b) / 2
+		)
+		if hi.IsInf(1) {
+			// the value past the largest one, were the exponent unbounded.
+			b = 65536
+			mid = (a + b) / 2
+		}
+
+		even := lo
+		if lo.bits&1 == 1 {
+			even = hi
+		}
+		for _, tc := range []struct {
+			v    float64
+			want Num
+		}{
+			{mid, even},
+			{math.Nextafter(mid, 0), lo},
+			{math.Nextafter(mid, math.Inf(1)), hi},
+			{-mid, FromBits(even.bits | 0x8000)},
+		} {
+			if got := FromFloat64(tc.v); got != tc.want {
+				t.Fatalf("%v: got=%#04x, want=%#04x", tc.v, got.bits, tc.want.bits)
+			}
+		}
+	}
+
+	for _, tc := range []struct {
+		v    float64
+		want uint16
+	}{
+		{math.Ldexp(1, -25), 0x0000},
+		{math.Nextafter(math.Ldexp(1, -25), 1), 0x0001},
+		{math.Ldexp(1, -24), 0x0001},
+		{math.Ldexp(3, -25), 0x0002},
+		{math.SmallestNonzeroFloat64, 0x0000},
+		{-math.SmallestNonzeroFloat64, 0x8000},
+		{65504, 0x7bff},
+		{65519.99, 0x7bff},
+		{65520, 0x7c00},
+		{1e10, 0x7c00},
+		{-1e10, 0xfc00},
+		{math.Inf(1), 0x7c00},
+		{math.Inf(-1), 0xfc00},
+	} {
+		if got := FromFloat64(tc.v); got.bits != tc.want {
+			t.Fatalf("%v: got=%#04x, want=%#04x", tc.v, got.bits, tc.want)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100000; i++ {
+		a, b := FromBits(uint16(r.Uint32())), FromBits(uint16(r.Uint32()))
+		if a.IsNaN() || b.IsNaN() {
+			continue
+		}
+		fa, fb := float64(a.Float32()), float64(b.Float32())
+		for _, tc := range []struct {
+			name string
+			got  Num
+			want float64
+		}{
+			{"add", a.Add(b), fa + fb},
+			{"sub", a.Sub(b), fa - fb},
+			{"mul", a.Mul(b), fa * fb},
+			{"div", a.Div(b), fa / fb},
+		} {
+			want := FromFloat64(tc.want)
+			if want.IsNaN() {
+				if !tc.got.IsNaN() {
+					t.Fatalf("%s(%v, %v): got=%v, want=NaN", tc.name, a, b, tc.got)
+				}
+				continue
+			}
+			if tc.got != want {
+				t.Fatalf("%s(%v, %v): got=%v (%#04x), want=%v (%#04x)", tc.name, a, b, tc.got, tc.got.bits, want, want.bits)
+			}
+		}
+
+		var cmp int
+		switch {
+		case fa < fb:
+			cmp = -1
+		case fa > fb:
+			cmp = +1
+		}
+		if got := a.Cmp(b); got != cmp {
+			t.Fatalf("cmp(%v, %v): got=%d, want=%d", a, b, got, cmp)
+		}
+		if got, want := a.Less(b), fa < fb; got != want {
+			t.Fatalf("less(%v, %v): got=%v, want=%v", a, b, got, want)
+		}
+		if got, want := a.Greater(b), fa > fb; got != want {
+			t.Fatalf("greater(%v, %v): got=%v, want=%v", a, b, got, want)
+		}
+	}
+}
+
+func TestSpecialValues(t *testing.T) {
+	var (
+		nan    = FromFloat64(math.NaN())
+		inf    = FromFloat64(math.Inf(1))
+		negInf = FromFloat64(math.Inf(-1))
+		negZ   = FromFloat64(math.Copysign(0, -1))
+		one    = New(1)
+	)
+
+	assert.True(t, nan.IsNaN())
+	assert.False(t, inf.IsNaN())
+	assert.False(t, one.IsNaN())
+	assert.True(t, inf.IsInf(0))
+	assert.True(t, inf.IsInf(1))
+	assert.False(t, inf.IsInf(-1))
+	assert.True(t, negInf.IsInf(-1))
+	assert.False(t, nan.IsInf(0))
+	assert.True(t, negZ.Signbit())
+	assert.True(t, negInf.Signbit())
+	assert.False(t, one.Signbit())
+	assert.Equal(t, 0, negZ.Cmp(FromBits(0)))
+	assert.Equal(t, 0, nan.Cmp(one))
+	assert.False(t, nan.Less(one))
+	assert.False(t, nan.Greater(one))
+	assert.True(t, one.Add(nan).IsNaN())
+	assert.True(t, inf.Sub(inf).IsNaN())
+	assert.Equal(t, inf, one.Div(FromBits(0)))
+	assert.Equal(t, inf, MaxNum.Add(MaxNum))
+
+	assert.Equal(t, float32(65504), MaxNum.Float32())
+	assert.Equal(t, float32(-65504), MinNum.Float32())
+	assert.Equal(t, "65504", MaxNum.String())
+	assert.Equal(t, "NaN", nan.String())
+	assert.Equal(t, "+Inf", inf.String())
+	assert.Equal(t, "5.9604645e-08", FromBits(1).String())
+}
+
+func TestSlices(t *testing.T) {
+	var (
+		src = []float32{
+			0, float32(math.Copysign(0, -1)), 1, -2.5,
+			float32(math.Ldexp(1, -24)), float32(math.Ldexp(1, -25)), float32(math.Ldexp(1023, -24)), float32(math.Ldexp(1, -14)),
+			65504, 65520, -1e10, float32(math.Inf(1)), float32(math.Inf(-1)),
+		}
+		bits = []uint16{
+			0x0000, 0x8000, 0x3c00, 0xc100,
+			0x0001, 0x0000, 0x03ff, 0x0400,
+			0x7bff, 0x7c00, 0xfc00, 0x7c00, 0xfc00,
+		}
+	)
+
+	nums := make([]Num, len(src))
+	assert.Equal(t, len(src), FromFloat32s(nums, src))
+	for i, v := range nums {
+		assert.Equal(t, bits[i], v.Uint16(), "value %d (%v)", i, src[i])
+	}
+
+	back := make([]float32, len(nums))
+	assert.Equal(t, len(nums), ToFloat32s(back, nums))
+	for i, v := range back {
+		assert.Equal(t, nums[i].Float32(), v, "value %d", i)
+	}
+	assert.Equal(t, float32(math.Ldexp(1, -24)), back[4])
+	assert.Equal(t, float32(math.Ldexp(1023, -24)), back[6])
+	assert.True(t, math.Signbit(float64(back[1])))
+
+	// the number of converted values is bounded by the shortest slice.
+	assert.Equal(t, 2, FromFloat32s(nums[:2], src))
+	assert.Equal(t, 3, ToFloat32s(back, nums[:3]))
+	assert.Equal(t, 0, FromFloat32s(nil, src))
+
+	nans := make([]float32, 1)
+	ToFloat32s(nans, []Num{FromFloat64(math.NaN())})
+	assert.True(t, math.IsNaN(float64(nans[0])))
+}
+
+func TestAllocs(t *testing.T) {
+	a, b := New(1.5), New(-2.25)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = a.Add(b).Mul(b).Div(a).Sub(b).Cmp(FromFloat64(3.75))
+	})
+	if allocs != 0 {
+		t.Fatalf("arithmetic should not allocate: got %v allocations", allocs)
+	}
+}
@@ -0,0 +1,30 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashing provides a fast non-cryptographic hash function and the
+// hash tables built on top of it that kernels use to deduplicate values.
+//
+// The memo tables assign each distinct value a dense index in order of first
+// insertion, which is what dictionary encoding, unique, is-in and group-by
+// kernels need. A table may additionally hold a single null slot. Typed
+// tables exist for every primitive numeric type, and BinaryMemoTable handles
+// variable length values.
+//
+// HashArray computes one hash per slot of an array, combining the hashes of
+// children for nested types, so that equal rows hash equally.
+package hashing // import "github.com/apache/arrow/go/arrow/hashing"
+
+//go:generate go run ../_tools/tmpl/main.go -i -data=numeric.tmpldata memo_numeric.gen.go.tmpl
@@ -0,0 +1,98 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// The hash follows the structure of XXH3: short inputs are loaded into
+// a single word and finished with a strong bijective mixer, longer inputs are
+// consumed in 16-byte stripes with 64x64->128 bit multiplications folded back
+// into 64 bits. It is not compatible with the reference XXH3 output.
+
+const (
+	prime64_1 = 0x9E3779B185EBCA87
+	prime64_2 = 0xC2B2AE3D27D4EB4F
+	prime64_3 = 0x165667B19E3779F9
+)
+
+var secret = [8]uint64{
+	0xbe4ba423396cfeb8, 0x1cad21f72c81017c, 0xdb979083e96dd4de, 0x1f67b3b7a4a44072,
+	0x78e5c0cc4ee679cb, 0x2172ffcc7dd05a82, 0x8e2443f7744608b8, 0x4c263a81e69035e0,
+}
+
+// Hash returns the 64-bit hash of b for the given seed.
+func Hash(b []byte, seed uint64) uint64 {
+	n := len(b)
+	switch {
+	case n == 0:
+		return avalanche(seed ^ secret[4] ^ secret[5])
+	case n <= 8:
+		var v uint64
+		for i := n - 1; i >= 0; i-- {
+			v = v<<8 | uint64(b[i])
+		}
+		return rrmxmx(v^(secret[2]^secret[3]+seed), uint64(n))
+	case n <= 16:
+		lo := binary.LittleEndian.Uint64(b) ^ (secret[0] + seed)
+		hi := binary.LittleEndian.Uint64(b[n-8:]) ^ (secret[1] - seed)
+		return avalanche(uint64(n)*prime64_1 + bits.RotateLeft64(lo, 31) + hi + mix(lo, hi))
+	}
+
+	acc := uint64(n)*prime64_1 ^ seed
+	tail := b[n-16:]
+	for k := 0; len(b) > 16; b, k = b[16:], (k+2)&7 {
+		acc = mix(binary.LittleEndian.Uint64(b)^secret[k]^acc, binary.LittleEndian.Uint64(b[8:])^(secret[k+1]+seed)) + acc*prime64_2
+	}
+	acc = mix(binary.LittleEndian.Uint64(tail)^secret[6]^acc, binary.LittleEndian.Uint64(tail[8:])^(secret[7]+seed)) + acc*prime64_2
+	return avalanche(acc)
+}
+
+// HashUint64 returns the hash of a single 64-bit word. It is cheaper than
+// hashing the word's bytes and is what the numeric memo tables use.
+func HashUint64(v, seed uint64) uint64 {
+	return rrmxmx(v^(secret[0]^secret[1]-seed), 8)
+}
+
+// Combine mixes the hash v into the running hash h. It is not commutative,
+// so the order in which hashes are combined matters.
+func Combine(h, v uint64) uint64 {
+	return avalanche(mix(h^secret[2], v^secret[3]) + h*prime64_3)
+}
+
+// mix multiplies a and b to 128 bits and folds the result back to 64.
+func mix(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi ^ lo
+}
+
+func avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= 0x165667919E3779F9
+	h ^= h >> 32
+	return h
+}
+
+func rrmxmx(h, n uint64) uint64 {
+	h ^= bits.RotateLeft64(h, 49) ^ bits.RotateLeft64(h, 24)
+	h *= 0x9FB21C651E98DF25
+	h ^= (h >> 35) + n
+	h *= 0x9FB21C651E98DF25
+	return h ^ (h >> 28)
+}
@@ -0,0 +1,252 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing
+
+import (
+	"encoding/binary"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// HashArray returns the hash of every slot of arr. Slots holding equal
+// values hash alike, including across differently sliced arrays of the
+// same type. All nulls share a single hash.
+//
+// Nested types combine the hashes of their children: lists hash their
+// length and elements in order, structs hash their fields in order, and
+// unions hash the type code and the value of each slot. Dictionary and
+// run-end encoded arrays hash the decoded values, and extension arrays
+// their storage, so that they agree with the equivalent plain array.
+// Binary and string views hash like binary and string arrays. Floating
+// point values follow the memo tables: all NaNs hash alike, and so do both
+// zeros.
+//
+// HashArray panics if the type of arr cannot be hashed.
+func HashArray(arr array.Interface, seed uint64) []uint64 {
+	out := make([]uint64, arr.Len())
+	if len(out) == 0 {
+		return out
+	}
+	hashArray(arr, seed, out)
+	return out
+}
+
+func nullHash(seed uint64) uint64 { return avalanche(seed ^ secret[6] ^ secret[7]) }
+
+func hashArray(arr array.Interface, seed uint64, out []uint64) {
+	data := arr.Data()
+	switch a := arr.(type) {
+	case *array.Null:
+		for i := range out {
+			out[i] = nullHash(seed)
+		}
+		return
+	case *array.Boolean:
+		for i := range out {
+			var v uint64
+			if a.Value(i) {
+				v = 1
+			}
+			out[i] = HashUint64(v, seed)
+		}
+	case *array.Float16:
+		for i, v := range a.Values() {
+			out[i] = HashUint64(floatBits(float64(v.Float32())), seed)
+		}
+	case *array.Float32:
+		for i, v := range a.Float32Values() {
+			out[i] = HashUint64(floatBits(float64(v)), seed)
+		}
+	case *array.Float64:
+		for i, v := range a.Float64Values() {
+			out[i] = HashUint64(floatBits(v), seed)
+		}
+	case *array.BinaryView, *array.StringView:
+		var (
+			views = arr.(viewValues)
+			bufs  = views.DataBuffers()
+		)
+		for i := range out {
+			if arr.IsValid(i) {
+				out[i] = Hash(viewBytes(views.ValueHeader(i), bufs), seed)
+			}
+		}
+	case *array.Binary, *array.String:
+		var (
+			offsets = arrow.Int32Traits.CastFromBytes(data.Buffers()[1].Bytes())[data.Offset():]
+			values  []byte
+		)
+		if buf := data.Buffers()[2]; buf != nil {
+			values = buf.Bytes()
+		}
+		for i := range out {
+			out[i] = Hash(values[offsets[i]:offsets[i+1]], seed)
+		}
+	case *array.List:
+		offsets := a.Offsets()[data.Offset():]
+		beg, end := int64(offsets[0]), int64(offsets[len(out)])
+		elems := hashChildren(a.ListValues(), beg, end, seed)
+		for i := range out {
+			lo, hi := int64(offsets[i])-beg, int64(offsets[i+1])-beg
+			out[i] = combineAll(HashUint64(uint64(hi-lo), seed), elems[lo:hi])
+		}
+	case *array.FixedSizeList:
+		n := int64(a.DataType().(*arrow.FixedSizeListType).Len())
+		beg := int64(data.Offset()) * n
+		elems := hashChildren(a.ListValues(), beg, beg+int64(len(out))*n, seed)
+		for i := range out {
+			out[i] = combineAll(HashUint64(uint64(n), seed), elems[int64(i)*n:int64(i+1)*n])
+		}
+	case *array.Struct:
+		for i := range out {
+			out[i] = HashUint64(uint64(a.NumField()), seed)
+		}
+		for f := 0; f < a.NumField(); f++ {
+			for i, h := range HashArray(a.Field(f), seed) {
+				out[i] = Combine(out[i], h)
+			}
+		}
+	case *array.Dictionary:
+		dict := HashArray(a.Dictionary(), seed)
+		for i := range out {
+			if a.IsValid(i) {
+				out[i] = dict[a.GetValueIndex(i)]
+			}
+		}
+	case *array.RunEndEncoded:
+		values := a.LogicalValuesArr()
+		defer values.Release()
+		runs, beg := HashArray(values, seed), a.GetPhysicalOffset()
+		for i := range out {
+			out[i] = runs[a.GetPhysicalIndex(i)-beg]
+		}
+	case *array.SparseUnion:
+		hashUnion(a, func(i int) int { return i }, seed, out)
+	case *array.DenseUnion:
+		hashUnion(a, func(i int) int { return int(a.ValueOffset(i)) }, seed, out)
+	case array.ExtensionArray:
+		hashArray(a.Storage(), seed, out)
+	default:
+		hashFixedWidth(arr, seed, out)
+	}
+
+	if arr.NullN() > 0 {
+		for i := range out {
+			if arr.IsNull(i) {
+				out[i] = nullHash(seed)
+			}
+		}
+	}
+}
+
+// viewValues is implemented by the BinaryView and StringView arrays.
+type viewValues interface {
+	ValueHeader(i int) *arrow.ViewHeader
+	DataBuffers() []*memory.Buffer
+}
+
+// viewBytes returns the bytes of the value of the view h.
+func viewBytes(h *arrow.ViewHeader, bufs []*memory.Buffer) []byte {
+	if h.IsInline() {
+		return h.InlineBytes()
+	}
+	off := int(h.BufferOffset())
+	return bufs[h.BufferIndex()].Bytes()[off : off+h.Len()]
+}
+
+// unionValues is implemented by the SparseUnion and DenseUnion arrays.
+type unionValues interface {
+	array.Interface
+	NumFields() int
+	Field(pos int) array.Interface
+	TypeCode(i int) arrow.UnionTypeCode
+	ChildID(i int) int
+}
+
+// hashUnion hashes the slots of a union array, where index returns the
+// index in its child of the value of slot i.
+func hashUnion(a unionValues, index func(i int) int, seed uint64, out []uint64) {
+	children := make([][]uint64, a.NumFields())
+	for i := range out {
+		id := a.ChildID(i)
+		if children[id] == nil {
+			children[id] = HashArray(a.Field(id), seed)
+		}
+		h := children[id][index(i)]
+		if a.IsNull(i) {
+			out[i] = h
+			continue
+		}
+		out[i] = Combine(HashUint64(uint64(a.TypeCode(i)), seed), h)
+	}
+}
+
+// hashChildren returns the hashes of the slots [beg, end) of child.
+func hashChildren(child array.Interface, beg, end int64, seed uint64) []uint64 {
+	if beg == 0 && end == int64(child.Len()) {
+		return HashArray(child, seed)
+	}
+	sub := array.NewSlice(child, beg, end)
+	defer sub.Release()
+	return HashArray(sub, seed)
+}
+
+func combineAll(h uint64, hashes []uint64) uint64 {
+	for _, v := range hashes {
+		h = Combine(h, v)
+	}
+	return h
+}
+
+func hashFixedWidth(arr array.Interface, seed uint64, out []uint64) {
+	var width int
+	switch dt := arr.DataType().(type) {
+	case *arrow.Decimal128Type:
+		width = 16
+	case arrow.FixedWidthDataType:
+		width = dt.BitWidth() / 8
+	default:
+		panic("arrow/hashing: cannot hash arrays of type " + arr.DataType().Name())
+	}
+
+	data := arr.Data()
+	buf := data.Buffers()[1].Bytes()[data.Offset()*width:]
+	switch width {
+	case 1:
+		for i := range out {
+			out[i] = HashUint64(uint64(buf[i]), seed)
+		}
+	case 2:
+		for i := range out {
+			out[i] = HashUint64(uint64(binary.LittleEndian.Uint16(buf[2*i:])), seed)
+		}
+	case 4:
+		for i := range out {
+			out[i] = HashUint64(uint64(binary.LittleEndian.Uint32(buf[4*i:])), seed)
+		}
+	case 8:
+		for i := range out {
+			out[i] = HashUint64(binary.LittleEndian.Uint64(buf[8*i:]), seed)
+		}
+	default:
+		for i := range out {
+			out[i] = Hash(buf[i*width:(i+1)*width], seed)
+		}
+	}
+}
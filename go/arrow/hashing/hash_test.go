@@ -0,0 +1,406 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/extensions"
+	"github.com/apache/arrow/go/arrow/hashing"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestHash(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[uint64][]byte)
+	for n := 0; n <= 256; n++ {
+		for k := 0; k < 64; k++ {
+			b := make([]byte, n)
+			r.Read(b)
+			h := hashing.Hash(b, 0)
+			if h != hashing.Hash(append([]byte(nil), b...), 0) {
+				t.Fatalf("hash of %x is not deterministic", b)
+			}
+			if h == hashing.Hash(b, 1) && n > 0 {
+				t.Fatalf("hash of %x does not depend on the seed", b)
+			}
+			if prev, ok := seen[h]; ok && string(prev) != string(b) {
+				t.Fatalf("collision between %x and %x", prev, b)
+			}
+			seen[h] = b
+		}
+	}
+
+	// inputs differing only by trailing zeros or by a single bit.
+	for n := 0; n < 64; n++ {
+		b := make([]byte, n)
+		if hashing.Hash(b, 0) == hashing.Hash(append(b, 0), 0) {
+			t.Fatalf("%d and %d zero bytes hash alike", n, n+1)
+		}
+		for i := 0; i < 8*n; i++ {
+			c := append([]byte(nil), b...)
+			c[i/8] ^= 1 << uint(i%8)
+			if hashing.Hash(b, 0) == hashing.Hash(c, 0) {
+				t.Fatalf("flipping bit %d of %d bytes does not change the hash", i, n)
+			}
+		}
+	}
+}
+
+func TestHashUint64Collisions(t *testing.T) {
+	seen := make(map[uint64]uint64)
+	for i := uint64(0); i < 1<<16; i++ {
+		for _, v := range []uint64{i, i << 48, ^i} {
+			h := hashing.HashUint64(v, 0)
+			if prev, ok := seen[h]; ok && prev != v {
+				t.Fatalf("collision between %d and %d", prev, v)
+			}
+			seen[h] = v
+		}
+	}
+}
+
+// checkRowHashes checks that rows i and j of arr hash alike if and only if
+// eq reports them as equal.
+func checkRowHashes(t *testing.T, arr array.Interface, eq func(i, j int) bool) {
+	t.Helper()
+	hashes := hashing.HashArray(arr, 0)
+	if len(hashes) != arr.Len() {
+		t.Fatalf("invalid number of hashes: got=%d, want=%d", len(hashes), arr.Len())
+	}
+	for i := range hashes {
+		for j := range hashes {
+			if got, want := hashes[i] == hashes[j], eq(i, j); got != want {
+				t.Fatalf("rows %d and %d: hashes equal=%v, values equal=%v", i, j, got, want)
+			}
+		}
+	}
+	if other := hashing.HashArray(arr, 1); arr.Len() > 0 && other[0] == hashes[0] {
+		t.Fatalf("hashes do not depend on the seed")
+	}
+}
+
+func TestHashArrayPrimitive(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int64{1, 2, 1, 0, 0, -1}, []bool{true, true, true, false, true, true})
+	ints := ib.NewInt64Array()
+	defer ints.Release()
+	checkRowHashes(t, ints, func(i, j int) bool {
+		if ints.IsNull(i) || ints.IsNull(j) {
+			return ints.IsNull(i) == ints.IsNull(j)
+		}
+		return ints.Value(i) == ints.Value(j)
+	})
+
+	// hashes do not depend on the offset of the array.
+	full := hashing.HashArray(ints, 0)
+	sli := array.NewSlice(ints, 2, 5)
+	defer sli.Release()
+	for i, h := range hashing.HashArray(sli, 0) {
+		if h != full[i+2] {
+			t.Fatalf("invalid hash for slot %d of the slice", i)
+		}
+	}
+
+	fb := array.NewFloat64Builder(mem)
+	defer fb.Release()
+	fb.AppendValues([]float64{math.NaN(), 0, -math.NaN(), math.Copysign(0, -1), 1, math.Inf(1)}, nil)
+	floats := fb.NewFloat64Array()
+	defer floats.Release()
+	checkRowHashes(t, floats, func(i, j int) bool {
+		x, y := floats.Value(i), floats.Value(j)
+		return x == y || x != x && y != y
+	})
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"", "a", "", "ab", "a", "b"}, []bool{true, true, false, true, true, true})
+	strs := sb.NewStringArray()
+	defer strs.Release()
+	checkRowHashes(t, strs, func(i, j int) bool {
+		if strs.IsNull(i) || strs.IsNull(j) {
+			return strs.IsNull(i) == strs.IsNull(j)
+		}
+		return strs.Value(i) == strs.Value(j)
+	})
+
+	bb := array.NewBooleanBuilder(mem)
+	defer bb.Release()
+	bb.AppendValues([]bool{true, false, true}, nil)
+	bools := bb.NewBooleanArray()
+	defer bools.Release()
+	checkRowHashes(t, bools, func(i, j int) bool { return bools.Value(i) == bools.Value(j) })
+}
+
+func TestHashArrayNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// [[1 2] [] (null) [1 2] [2 1] [1] [1 2]]
+	lb := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int32)
+	defer lb.Release()
+	vb := lb.ValueBuilder().(*array.Int32Builder)
+	for _, row := range [][]int32{{1, 2}, {}, nil, {1, 2}, {2, 1}, {1}, {1, 2}} {
+		if row == nil {
+			lb.AppendNull()
+			continue
+		}
+		lb.Append(true)
+		vb.AppendValues(row, nil)
+	}
+	lists := lb.NewListArray()
+	defer lists.Release()
+	rows := []string{"[1 2]", "[]", "null", "[1 2]", "[2 1]", "[1]", "[1 2]"}
+	checkRowHashes(t, lists, func(i, j int) bool { return rows[i] == rows[j] })
+
+	sli := array.NewSlice(lists, 3, 7)
+	defer sli.Release()
+	checkRowHashes(t, sli, func(i, j int) bool { return rows[i+3] == rows[j+3] })
+	if hashing.HashArray(sli, 0)[0] != hashing.HashArray(lists, 0)[0] {
+		t.Fatalf("sliced list hashes differently")
+	}
+
+	dtype := arrow.StructOf(
+		arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "b", Type: arrow.BinaryTypes.String},
+	)
+	stb := array.NewStructBuilder(mem, dtype)
+	defer stb.Release()
+	ab := stb.FieldBuilder(0).(*array.Int32Builder)
+	sb := stb.FieldBuilder(1).(*array.StringBuilder)
+	for _, row := range []struct {
+		a int32
+		b string
+	}{{1, "x"}, {2, "x"}, {1, "x"}, {1, "y"}} {
+		stb.Append(true)
+		ab.Append(row.a)
+		sb.Append(row.b)
+	}
+	structs := stb.NewStructArray()
+	defer structs.Release()
+	srows := []string{"1x", "2x", "1x", "1y"}
+	checkRowHashes(t, structs, func(i, j int) bool { return srows[i] == srows[j] })
+
+	fsb := array.NewFixedSizeListBuilder(mem, 2, arrow.PrimitiveTypes.Int32)
+	defer fsb.Release()
+	fvb := fsb.ValueBuilder().(*array.Int32Builder)
+	for _, row := range [][]int32{{1, 2}, {2, 1}, {1, 2}} {
+		fsb.Append(true)
+		fvb.AppendValues(row, nil)
+	}
+	fsl := fsb.NewArray()
+	defer fsl.Release()
+	frows := []string{"12", "21", "12"}
+	checkRowHashes(t, fsl, func(i, j int) bool { return frows[i] == frows[j] })
+}
+
+func TestHashArrayDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	db := array.NewStringBuilder(mem)
+	defer db.Release()
+	db.AppendValues([]string{"a", "b", "c"}, nil)
+	dict := db.NewArray()
+	defer dict.Release()
+
+	ib := array.NewInt8Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int8{2, 0, 0, 1}, []bool{true, true, false, true})
+	indices := ib.NewArray()
+	defer indices.Release()
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	data := array.NewDataWithDictionary(dt, indices.Len(), indices.Data().Buffers(), indices.NullN(), 0, dict.Data())
+	defer data.Release()
+	arr := array.MakeFromData(data)
+	defer arr.Release()
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"c", "a", "", "b"}, []bool{true, true, false, true})
+	dense := sb.NewArray()
+	defer dense.Release()
+
+	got, want := hashing.HashArray(arr, 0), hashing.HashArray(dense, 0)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("slot %d: dictionary hash differs from the dense hash", i)
+		}
+	}
+}
+
+func TestHashArrayEncoded(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// the long strings are stored out of line in the views.
+	vals := []string{"a", "a long string value", "", "a", "a long string value", "b"}
+	valid := []bool{true, true, false, true, true, true}
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues(vals, valid)
+	dense := sb.NewArray()
+	defer dense.Release()
+
+	vb := array.NewStringViewBuilder(mem)
+	defer vb.Release()
+	vb.AppendValues(vals, valid)
+	views := vb.NewArray()
+	defer views.Release()
+
+	want := hashing.HashArray(dense, 0)
+	for i, h := range hashing.HashArray(views, 0) {
+		if h != want[i] {
+			t.Fatalf("slot %d: string view hash differs from the string hash", i)
+		}
+	}
+
+	// runs of [a a (null) a b] after slicing off the first value.
+	rb := array.NewStringBuilder(mem)
+	defer rb.Release()
+	rb.AppendValues([]string{"x", "a", "", "a", "b"}, []bool{true, true, false, true, true})
+	runValues := rb.NewArray()
+	defer runValues.Release()
+	eb := array.NewInt32Builder(mem)
+	defer eb.Release()
+	eb.AppendValues([]int32{1, 3, 4, 5, 6}, nil)
+	runEnds := eb.NewArray()
+	defer runEnds.Release()
+	ree := array.NewRunEndEncoded(runEnds, runValues, 5, 1)
+	defer ree.Release()
+
+	sb.AppendValues([]string{"a", "a", "", "a", "b"}, []bool{true, true, false, true, true})
+	decoded := sb.NewArray()
+	defer decoded.Release()
+
+	want = hashing.HashArray(decoded, 0)
+	for i, h := range hashing.HashArray(ree, 0) {
+		if h != want[i] {
+			t.Fatalf("slot %d: run-end encoded hash differs from the decoded hash", i)
+		}
+	}
+
+	ub := extensions.NewUUIDBuilder(mem)
+	defer ub.Release()
+	ub.AppendValues([]extensions.UUID{{1}, {2}, {1}}, []bool{true, true, true})
+	uuids := ub.NewArray().(array.ExtensionArray)
+	defer uuids.Release()
+
+	want = hashing.HashArray(uuids.Storage(), 0)
+	for i, h := range hashing.HashArray(uuids, 0) {
+		if h != want[i] {
+			t.Fatalf("slot %d: extension hash differs from the storage hash", i)
+		}
+	}
+}
+
+func TestHashArrayUnion(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fields := []arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "u", Type: arrow.PrimitiveTypes.Uint32, Nullable: true},
+	}
+	codes := []arrow.UnionTypeCode{3, 7}
+	// the same bits under different type codes are different values.
+	rows := []struct {
+		code arrow.UnionTypeCode
+		v    int32
+		null bool
+	}{{3, 1, false}, {7, 1, false}, {3, 0, true}, {3, 1, false}, {7, 2, false}, {3, 0, true}}
+	eq := func(i, j int) bool {
+		if rows[i].null || rows[j].null {
+			return rows[i].null == rows[j].null
+		}
+		return rows[i] == rows[j]
+	}
+
+	for _, mode := range []arrow.UnionMode{arrow.SparseMode, arrow.DenseMode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			dt := arrow.UnionOf(mode, fields, codes)
+			var b interface {
+				array.Builder
+				Append(arrow.UnionTypeCode) array.Builder
+			}
+			if mode == arrow.SparseMode {
+				b = array.NewSparseUnionBuilder(mem, dt)
+			} else {
+				b = array.NewDenseUnionBuilder(mem, dt)
+			}
+			defer b.Release()
+			for _, row := range rows {
+				if row.null {
+					b.AppendNull()
+					continue
+				}
+				switch cb := b.Append(row.code).(type) {
+				case *array.Int32Builder:
+					cb.Append(row.v)
+				case *array.Uint32Builder:
+					cb.Append(uint32(row.v))
+				}
+			}
+			arr := b.NewArray()
+			defer arr.Release()
+			checkRowHashes(t, arr, eq)
+
+			sli := array.NewSlice(arr, 1, 5)
+			defer sli.Release()
+			if hashing.HashArray(sli, 0)[2] != hashing.HashArray(arr, 0)[3] {
+				t.Fatalf("sliced union hashes differently")
+			}
+		})
+	}
+}
+
+func TestHashArrayRandom(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	const n = 1 << 16
+	r := rand.New(rand.NewSource(1))
+	bldr := array.NewUint64Builder(mem)
+	defer bldr.Release()
+	seen := make(map[uint64]bool, n)
+	for len(seen) < n {
+		v := r.Uint64() >> uint(r.Intn(64))
+		if !seen[v] {
+			seen[v] = true
+			bldr.Append(v)
+		}
+	}
+	arr := bldr.NewArray()
+	defer arr.Release()
+
+	hashes := make(map[uint64]bool, n)
+	for _, h := range hashing.HashArray(arr, 42) {
+		hashes[h] = true
+	}
+	if len(hashes) != n {
+		t.Fatalf("%d collisions among %d distinct values", n-len(hashes), n)
+	}
+}
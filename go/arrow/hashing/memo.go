@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing
+
+import (
+	"math"
+)
+
+var (
+	_ MemoTable = (*Int64MemoTable)(nil)
+	_ MemoTable = (*Float64MemoTable)(nil)
+	_ MemoTable = (*BinaryMemoTable)(nil)
+)
+
+// valid returns the validity slice for n values with the null slot
+// unset, or nil if there is no null slot.
+func (m *memoNull) valid(n int) []bool {
+	if m.nullIdx < 0 {
+		return nil
+	}
+	valid := make([]bool, n)
+	for i := range valid {
+		valid[i] = true
+	}
+	valid[m.nullIdx] = false
+	return valid
+}
+
+func hashInt8(v int8) uint64     { return HashUint64(uint64(v), 0) }
+func hashInt16(v int16) uint64   { return HashUint64(uint64(v), 0) }
+func hashInt32(v int32) uint64   { return HashUint64(uint64(v), 0) }
+func hashInt64(v int64) uint64   { return HashUint64(uint64(v), 0) }
+func hashUint8(v uint8) uint64   { return HashUint64(uint64(v), 0) }
+func hashUint16(v uint16) uint64 { return HashUint64(uint64(v), 0) }
+func hashUint32(v uint32) uint64 { return HashUint64(uint64(v), 0) }
+func hashUint64(v uint64) uint64 { return HashUint64(v, 0) }
+
+func hashFloat32(v float32) uint64 { return HashUint64(floatBits(float64(v)), 0) }
+func hashFloat64(v float64) uint64 { return HashUint64(floatBits(v), 0) }
+
+// floatBits returns the bits of v, mapping all NaNs to one value and both
+// zeros to another so that hashes agree with float equality.
+func floatBits(v float64) uint64 {
+	switch {
+	case v != v:
+		return 0x7ff8000000000001
+	case v == 0:
+		return 0
+	}
+	return math.Float64bits(v)
+}
@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing
+
+import (
+	"bytes"
+
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// BinaryMemoTable assigns a dense index to each distinct byte string in
+// order of first insertion. Values are stored back to back, in the same
+// offsets and data layout as a binary array.
+type BinaryMemoTable struct {
+	memoNull
+	tbl     table
+	offsets []int32
+	data    []byte
+}
+
+// NewBinaryMemoTable returns an empty table sized to hold capacity values
+// totalling dataCapacity bytes without growing.
+func NewBinaryMemoTable(capacity, dataCapacity int) *BinaryMemoTable {
+	offsets := make([]int32, 1, capacity+1)
+	return &BinaryMemoTable{
+		memoNull: memoNull{nullIdx: -1},
+		tbl:      newTable(capacity),
+		offsets:  offsets,
+		data:     make([]byte, 0, dataCapacity),
+	}
+}
+
+// Size returns the number of distinct values, including the null slot.
+func (m *BinaryMemoTable) Size() int { return len(m.offsets) - 1 }
+
+// Reset empties the table while retaining its allocated capacity.
+func (m *BinaryMemoTable) Reset() {
+	m.tbl.reset()
+	m.offsets = m.offsets[:1]
+	m.data = m.data[:0]
+	m.nullIdx = -1
+}
+
+// Value returns the value at index i. The returned slice aliases the
+// table's storage and is only valid until the next insertion.
+func (m *BinaryMemoTable) Value(i int) []byte {
+	return m.data[m.offsets[i]:m.offsets[i+1]:m.offsets[i+1]]
+}
+
+// ValuesSize returns the total length in bytes of all values.
+func (m *BinaryMemoTable) ValuesSize() int { return len(m.data) }
+
+func (m *BinaryMemoTable) lookup(v []byte) (pos, h uint64, idx int32, found bool) {
+	h = fixHash(Hash(v, 0))
+	mask := uint64(len(m.tbl.entries) - 1)
+	for pos, step := h&mask, uint64(1); ; pos, step = (pos+step)&mask, step+1 {
+		e := m.tbl.entries[pos]
+		if e.h == sentinel {
+			return pos, h, -1, false
+		}
+		if e.h == h && bytes.Equal(m.data[m.offsets[e.idx]:m.offsets[e.idx+1]], v) {
+			return pos, h, e.idx, true
+		}
+	}
+}
+
+// Get returns the index of v, or -1 and false if v is not in the table.
+func (m *BinaryMemoTable) Get(v []byte) (int, bool) {
+	_, _, idx, found := m.lookup(v)
+	return int(idx), found
+}
+
+// GetOrInsert returns the index of v, inserting a copy of it if needed.
+// found reports whether v was already present.
+func (m *BinaryMemoTable) GetOrInsert(v []byte) (idx int, found bool) {
+	pos, h, i, found := m.lookup(v)
+	if found {
+		return int(i), true
+	}
+	i = int32(m.Size())
+	m.data = append(m.data, v...)
+	m.offsets = append(m.offsets, int32(len(m.data)))
+	m.tbl.insert(pos, h, i)
+	return int(i), false
+}
+
+// GetOrInsertNull returns the index of the null slot, inserting it as an
+// empty value if needed. found reports whether it already existed.
+func (m *BinaryMemoTable) GetOrInsertNull() (idx int, found bool) {
+	if m.nullIdx >= 0 {
+		return int(m.nullIdx), true
+	}
+	m.nullIdx = int32(m.Size())
+	m.offsets = append(m.offsets, int32(len(m.data)))
+	return int(m.nullIdx), false
+}
+
+// CopyOffsets copies the Size+1 value offsets into out.
+func (m *BinaryMemoTable) CopyOffsets(out []int32) { copy(out, m.offsets) }
+
+// CopyValues copies the concatenated values into out, which must hold at
+// least ValuesSize bytes.
+func (m *BinaryMemoTable) CopyValues(out []byte) { copy(out, m.data) }
+
+// AppendValues appends the values in index order to b, appending the null
+// slot, if any, as a null.
+func (m *BinaryMemoTable) AppendValues(b *array.BinaryBuilder) {
+	b.Reserve(m.Size())
+	b.ReserveData(len(m.data))
+	for i := 0; i < m.Size(); i++ {
+		if i == int(m.nullIdx) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(m.Value(i))
+	}
+}
+
+// AppendStrings is like AppendValues, for a string builder.
+func (m *BinaryMemoTable) AppendStrings(b *array.StringBuilder) {
+	b.Reserve(m.Size())
+	for i := 0; i < m.Size(); i++ {
+		if i == int(m.nullIdx) {
+			b.AppendNull()
+			continue
+		}
+		b.Append(string(m.Value(i)))
+	}
+}
@@ -0,0 +1,283 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/hashing"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestInt64MemoTable(t *testing.T) {
+	const n = 100000
+	var (
+		r    = rand.New(rand.NewSource(1))
+		memo = hashing.NewInt64MemoTable(0)
+		ref  = make(map[int64]int)
+		vals []int64
+	)
+	for i := 0; i < n; i++ {
+		// a narrow range makes repeated values, the shifts make values
+		// that only differ in their high bits.
+		v := r.Int63n(n/4) << uint(r.Intn(40))
+		idx, found := memo.GetOrInsert(v)
+		want, ok := ref[v]
+		if !ok {
+			want = len(ref)
+			ref[v] = want
+			vals = append(vals, v)
+		}
+		if idx != want || found != ok {
+			t.Fatalf("GetOrInsert(%d) = (%d, %v), want (%d, %v)", v, idx, found, want, ok)
+		}
+	}
+	if got, want := memo.Size(), len(ref); got != want {
+		t.Fatalf("invalid size: got=%d, want=%d", got, want)
+	}
+	for v, want := range ref {
+		if idx, ok := memo.Get(v); !ok || idx != want {
+			t.Fatalf("Get(%d) = (%d, %v), want (%d, true)", v, idx, ok, want)
+		}
+	}
+	if idx, ok := memo.Get(-1); ok || idx != -1 {
+		t.Fatalf("Get of a missing value = (%d, %v)", idx, ok)
+	}
+
+	out := make([]int64, memo.Size())
+	memo.CopyValues(out)
+	for i := range out {
+		if out[i] != vals[i] {
+			t.Fatalf("invalid value %d: got=%d, want=%d", i, out[i], vals[i])
+		}
+	}
+
+	memo.Reset()
+	if memo.Size() != 0 {
+		t.Fatalf("invalid size after reset: %d", memo.Size())
+	}
+	if _, ok := memo.Get(vals[0]); ok {
+		t.Fatalf("value found after reset")
+	}
+	if idx, found := memo.GetOrInsert(vals[1]); idx != 0 || found {
+		t.Fatalf("GetOrInsert after reset = (%d, %v)", idx, found)
+	}
+}
+
+func TestMemoTableNull(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	memo := hashing.NewInt32MemoTable(0)
+	if _, ok := memo.GetNull(); ok {
+		t.Fatalf("empty table has a null slot")
+	}
+	memo.GetOrInsert(7)
+	if idx, found := memo.GetOrInsertNull(); idx != 1 || found {
+		t.Fatalf("GetOrInsertNull = (%d, %v), want (1, false)", idx, found)
+	}
+	if idx, found := memo.GetOrInsert(0); idx != 2 || found {
+		t.Fatalf("zero must not match the null slot: (%d, %v)", idx, found)
+	}
+	if idx, found := memo.GetOrInsertNull(); idx != 1 || !found {
+		t.Fatalf("GetOrInsertNull = (%d, %v), want (1, true)", idx, found)
+	}
+	if idx, ok := memo.GetNull(); idx != 1 || !ok {
+		t.Fatalf("GetNull = (%d, %v), want (1, true)", idx, ok)
+	}
+
+	bldr := array.NewInt32Builder(mem)
+	defer bldr.Release()
+	memo.AppendValues(bldr)
+	arr := bldr.NewInt32Array()
+	defer arr.Release()
+	if got, want := arr.String(), "[7 (null) 0]"; got != want {
+		t.Fatalf("invalid values: got=%s, want=%s", got, want)
+	}
+
+	bin := hashing.NewBinaryMemoTable(0, 0)
+	bin.GetOrInsert([]byte("a"))
+	bin.GetOrInsertNull()
+	bin.GetOrInsert([]byte(""))
+	bin.GetOrInsert([]byte("bc"))
+	if idx, found := bin.GetOrInsertNull(); idx != 1 || !found {
+		t.Fatalf("GetOrInsertNull = (%d, %v), want (1, true)", idx, found)
+	}
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	bin.AppendStrings(sb)
+	sarr := sb.NewStringArray()
+	defer sarr.Release()
+	if got, want := sarr.String(), `["a" (null) "" "bc"]`; got != want {
+		t.Fatalf("invalid values: got=%s, want=%s", got, want)
+	}
+}
+
+func TestFloat64MemoTable(t *testing.T) {
+	memo := hashing.NewFloat64MemoTable(0)
+	for i, tc := range []struct {
+		v     float64
+		idx   int
+		found bool
+	}{
+		{math.NaN(), 0, false},
+		{0, 1, false},
+		{math.Float64frombits(0x7ff0000000000f00), 0, true},
+		{math.Copysign(0, -1), 1, true},
+		{math.Inf(1), 2, false},
+		{math.Inf(-1), 3, false},
+		{1.5, 4, false},
+		{math.NaN(), 0, true},
+	} {
+		idx, found := memo.GetOrInsert(tc.v)
+		if idx != tc.idx || found != tc.found {
+			t.Errorf("%d: GetOrInsert(%v) = (%d, %v), want (%d, %v)", i, tc.v, idx, found, tc.idx, tc.found)
+		}
+	}
+
+	f32 := hashing.NewFloat32MemoTable(0)
+	f32.GetOrInsert(float32(math.NaN()))
+	if idx, found := f32.GetOrInsert(-float32(math.NaN())); idx != 0 || !found {
+		t.Errorf("NaNs are not memoized together: (%d, %v)", idx, found)
+	}
+}
+
+func TestBinaryMemoTable(t *testing.T) {
+	const n = 100000
+	var (
+		r    = rand.New(rand.NewSource(1))
+		memo = hashing.NewBinaryMemoTable(0, 0)
+		ref  = make(map[string]int)
+		vals []string
+	)
+	for i := 0; i < n; i++ {
+		v := make([]byte, r.Intn(40))
+		for j := range v {
+			v[j] = byte('a' + r.Intn(3))
+		}
+		idx, found := memo.GetOrInsert(v)
+		want, ok := ref[string(v)]
+		if !ok {
+			want = len(ref)
+			ref[string(v)] = want
+			vals = append(vals, string(v))
+		}
+		if idx != want || found != ok {
+			t.Fatalf("GetOrInsert(%q) = (%d, %v), want (%d, %v)", v, idx, found, want, ok)
+		}
+	}
+	if got, want := memo.Size(), len(ref); got != want {
+		t.Fatalf("invalid size: got=%d, want=%d", got, want)
+	}
+
+	offsets := make([]int32, memo.Size()+1)
+	data := make([]byte, memo.ValuesSize())
+	memo.CopyOffsets(offsets)
+	memo.CopyValues(data)
+	for i, v := range vals {
+		if got := string(data[offsets[i]:offsets[i+1]]); got != v {
+			t.Fatalf("invalid value %d: got=%q, want=%q", i, got, v)
+		}
+		if got := string(memo.Value(i)); got != v {
+			t.Fatalf("invalid value %d: got=%q, want=%q", i, got, v)
+		}
+		if idx, ok := memo.Get([]byte(v)); !ok || idx != i {
+			t.Fatalf("Get(%q) = (%d, %v), want (%d, true)", v, idx, ok, i)
+		}
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	bldr := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+	defer bldr.Release()
+	memo.AppendValues(bldr)
+	arr := bldr.NewBinaryArray()
+	defer arr.Release()
+	for i, v := range vals {
+		if got := arr.ValueString(i); got != v {
+			t.Fatalf("invalid value %d: got=%q, want=%q", i, got, v)
+		}
+	}
+}
+
+// binaryKeys returns n keys drawn from card distinct values.
+func binaryKeys(n, card int) [][]byte {
+	keys := make([][]byte, n)
+	r := rand.New(rand.NewSource(1))
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key-%08d", r.Intn(card)))
+	}
+	return keys
+}
+
+func BenchmarkBinaryMemoTable(b *testing.B) {
+	for _, card := range []int{100, 100000} {
+		keys := binaryKeys(1<<16, card)
+		b.Run(fmt.Sprintf("memo/card=%d", card), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				memo := hashing.NewBinaryMemoTable(0, 0)
+				for _, k := range keys {
+					memo.GetOrInsert(k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("map/card=%d", card), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				memo := make(map[string]int)
+				for _, k := range keys {
+					if _, ok := memo[string(k)]; !ok {
+						memo[string(k)] = len(memo)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkInt64MemoTable(b *testing.B) {
+	for _, card := range []int64{100, 100000} {
+		r := rand.New(rand.NewSource(1))
+		keys := make([]int64, 1<<16)
+		for i := range keys {
+			keys[i] = r.Int63n(card)
+		}
+		b.Run(fmt.Sprintf("memo/card=%d", card), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				memo := hashing.NewInt64MemoTable(0)
+				for _, k := range keys {
+					memo.GetOrInsert(k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("map/card=%d", card), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				memo := make(map[int64]int)
+				for _, k := range keys {
+					if _, ok := memo[k]; !ok {
+						memo[k] = len(memo)
+					}
+				}
+			}
+		})
+	}
+}
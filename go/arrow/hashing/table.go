@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashing
+
+// MemoTable is the set of operations shared by all memo tables.
+type MemoTable interface {
+	// Size returns the number of distinct values in the table, including
+	// the null slot if one was inserted.
+	Size() int
+	// Reset empties the table while retaining its allocated capacity.
+	Reset()
+	// GetNull returns the index of the null slot, and whether it exists.
+	GetNull() (idx int, found bool)
+	// GetOrInsertNull returns the index of the null slot, inserting it
+	// if needed. found reports whether it already existed.
+	GetOrInsertNull() (idx int, found bool)
+}
+
+const (
+	// sentinel marks an empty entry; hashes equal to it are remapped.
+	sentinel    uint64 = 0
+	minCapacity        = 8
+	// the table grows once more than half of its entries are in use.
+	loadFactor = 2
+)
+
+type entry struct {
+	h   uint64
+	idx int32
+}
+
+// table is an open-addressing hash table of (hash, index) entries. It does
+// not know about the values themselves: memo tables probe it with their
+// own equality checks and keep values in insertion order on the side.
+// Probing is triangular, which visits every entry of a power of two sized
+// table.
+type table struct {
+	entries []entry
+	size    int
+}
+
+func newTable(capacity int) table {
+	n := minCapacity
+	for n < capacity*loadFactor {
+		n <<= 1
+	}
+	return table{entries: make([]entry, n)}
+}
+
+func fixHash(h uint64) uint64 {
+	if h == sentinel {
+		return 42
+	}
+	return h
+}
+
+// insert stores idx at the empty entry pos, which the caller found by
+// probing for h, and grows the table when needed.
+func (t *table) insert(pos uint64, h uint64, idx int32) {
+	t.entries[pos] = entry{h: h, idx: idx}
+	t.size++
+	if t.size*loadFactor > len(t.entries) {
+		t.grow()
+	}
+}
+
+func (t *table) grow() {
+	old := t.entries
+	t.entries = make([]entry, len(old)*2)
+	mask := uint64(len(t.entries) - 1)
+	for _, e := range old {
+		if e.h == sentinel {
+			continue
+		}
+		pos, step := e.h&mask, uint64(1)
+		for t.entries[pos].h != sentinel {
+			pos = (pos + step) & mask
+			step++
+		}
+		t.entries[pos] = e
+	}
+}
+
+func (t *table) reset() {
+	for i := range t.entries {
+		t.entries[i] = entry{}
+	}
+	t.size = 0
+}
+
+// memoNull tracks the optional null slot of a memo table.
+type memoNull struct {
+	nullIdx int32
+}
+
+func (m *memoNull) GetNull() (int, bool) { return int(m.nullIdx), m.nullIdx >= 0 }
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
 	"github.com/apache/arrow/go/arrow/float16"
 	"github.com/apache/arrow/go/arrow/memory"
 )
@@ -45,6 +46,7 @@ func init() {
 	Records["intervals"] = makeIntervalsRecords()
 	Records["durations"] = makeDurationsRecords()
 	Records["decimal128"] = makeDecimal128sRecords()
+	Records["decimal256"] = makeDecimal256sRecords()
 
 	for k := range Records {
 		RecordNames = append(RecordNames, k)
@@ -643,6 +645,7 @@ func makeDurationsRecords() []array.Record {
 
 var (
 	decimal128Type = &arrow.Decimal128Type{Precision: 10, Scale: 1}
+	decimal256Type = &arrow.Decimal256Type{Precision: 72, Scale: 2}
 )
 
 func makeDecimal128sRecords() []array.Record {
@@ -690,6 +693,51 @@ func makeDecimal128sRecords() []array.Record {
 	return recs
 }
 
+func makeDecimal256sRecords() []array.Record {
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "dec256s", Type: decimal256Type, Nullable: true},
+		}, nil,
+	)
+
+	dec256s := func(vs []int64) []decimal256.Num {
+		o := make([]decimal256.Num, len(vs))
+		for i, v := range vs {
+			o[i] = decimal256.New(uint64(v), uint64(v), uint64(v), uint64(v))
+		}
+		return o
+	}
+
+	mask := []bool{true, false, false, true, true}
+	chunks := [][]array.Interface{
+		[]array.Interface{
+			arrayOf(mem, dec256s([]int64{31, 32, 33, 34, 35}), mask),
+		},
+		[]array.Interface{
+			arrayOf(mem, dec256s([]int64{41, 42, 43, 44, 45}), mask),
+		},
+		[]array.Interface{
+			arrayOf(mem, dec256s([]int64{51, 52, 53, 54, 55}), mask),
+		},
+	}
+
+	defer func() {
+		for _, chunk := range chunks {
+			for _, col := range chunk {
+				col.Release()
+			}
+		}
+	}()
+
+	recs := make([]array.Record, len(chunks))
+	for i, chunk := range chunks {
+		recs[i] = array.NewRecord(schema, chunk, -1)
+	}
+
+	return recs
+}
+
 func arrayOf(mem memory.Allocator, a interface{}, valids []bool) array.Interface {
 	if mem == nil {
 		mem = memory.NewGoAllocator()
@@ -791,6 +839,13 @@ func arrayOf(mem memory.Allocator, a interface{}, valids []bool) array.Interface
 		aa := bldr.NewDecimal128Array()
 		return aa
 
+	case []decimal256.Num:
+		bldr := array.NewDecimal256Builder(mem, decimal256Type)
+		defer bldr.Release()
+
+		bldr.AppendValues(a, valids)
+		return bldr.NewDecimal256Array()
+
 	case []string:
 		bldr := array.NewStringBuilder(mem)
 		defer bldr.Release()
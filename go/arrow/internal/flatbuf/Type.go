@@ -45,6 +45,9 @@ const (
 	TypeLargeBinary Type = 19
 	TypeLargeUtf8 Type = 20
 	TypeLargeList Type = 21
+	TypeRunEndEncoded Type = 22
+	TypeBinaryView Type = 23
+	TypeUtf8View Type = 24
 )
 
 var EnumNamesType = map[Type]string{
@@ -70,5 +73,8 @@ var EnumNamesType = map[Type]string{
 	TypeLargeBinary:"LargeBinary",
 	TypeLargeUtf8:"LargeUtf8",
 	TypeLargeList:"LargeList",
+	TypeRunEndEncoded:"RunEndEncoded",
+	TypeBinaryView:"BinaryView",
+	TypeUtf8View:"Utf8View",
 }
 
@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_integration
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	authUsername = "arrow"
+	authPassword = "flight"
+)
+
+// authBasicProtoScenario checks that the client authenticates with a
+// BasicAuth message in the handshake, and that unauthenticated calls are
+// rejected. The token is the username, which DoAction returns.
+type authBasicProtoScenario struct{}
+
+func (s *authBasicProtoScenario) MakeServer(port int) (flight.Server, error) {
+	return makeServer(port, &flight.FlightServiceService{
+		DoAction: func(_ *flight.Action, stream flight.FlightService_DoActionServer) error {
+			identity, _ := flight.AuthFromContext(stream.Context()).(string)
+			return stream.Send(&flight.Result{Body: []byte(identity)})
+		},
+	}, &basicProtoServerAuth{})
+}
+
+func (s *authBasicProtoScenario) RunClient(addr string, opts ...grpc.DialOption) error {
+	ctx := context.Background()
+
+	client, err := flight.NewFlightClient(addr, nil, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if _, err := doAction(ctx, client); status.Code(err) != codes.Unauthenticated {
+		return xerrors.Errorf("flight_integration: unauthenticated action: got error %v, want %v", err, codes.Unauthenticated)
+	}
+
+	authClient, err := flight.NewFlightClient(addr, &basicProtoClientAuth{
+		auth: &flight.BasicAuth{Username: authUsername, Password: authPassword},
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	defer authClient.Close()
+	if err := authClient.Authenticate(ctx); err != nil {
+		return err
+	}
+	identity, err := doAction(ctx, authClient)
+	if err != nil {
+		return err
+	}
+	if identity != authUsername {
+		return xerrors.Errorf("flight_integration: invalid identity: got=%q, want=%q", identity, authUsername)
+	}
+	return nil
+}
+
+// doAction returns the body of the result of an empty action.
+func doAction(ctx context.Context, client flight.Client) (string, error) {
+	stream, err := client.DoAction(ctx, &flight.Action{})
+	if err != nil {
+		return "", err
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return "", err
+	}
+	return string(res.Body), nil
+}
+
+type basicProtoServerAuth struct{}
+
+func (a *basicProtoServerAuth) Authenticate(c flight.AuthConn) error {
+	in, err := c.Read()
+	if err == io.EOF {
+		return status.Error(codes.Unauthenticated, "no auth info provided")
+	}
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, "error reading auth handshake")
+	}
+
+	var auth flight.BasicAuth
+	if err := proto.Unmarshal(in, &auth); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid BasicAuth message: %v", err)
+	}
+	if auth.Username != authUsername || auth.Password != authPassword {
+		return status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	return c.Send([]byte(auth.Username))
+}
+
+func (a *basicProtoServerAuth) IsValid(token string) (interface{}, error) {
+	if token != authUsername {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return token, nil
+}
+
+type basicProtoClientAuth struct {
+	auth  *flight.BasicAuth
+	token string
+}
+
+func (a *basicProtoClientAuth) Authenticate(_ context.Context, c flight.AuthConn) error {
+	out, err := proto.Marshal(a.auth)
+	if err != nil {
+		return err
+	}
+	if err := c.Send(out); err != nil {
+		return err
+	}
+	token, err := c.Read()
+	if err != nil {
+		return err
+	}
+	a.token = string(token)
+	return nil
+}
+
+func (a *basicProtoClientAuth) GetToken(context.Context) (string, error) {
+	return a.token, nil
+}
@@ -0,0 +1,49 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main // import "github.com/apache/arrow/go/arrow/internal/flight_integration/cmd/arrow-flight-integration-client"
+
+import (
+	"flag"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow/internal/flight_integration"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	log.SetPrefix("arrow-flight-integration-client: ")
+	log.SetFlags(0)
+
+	var (
+		host     = flag.String("host", "localhost", "server host to connect to")
+		port     = flag.Int("port", 31337, "server port to connect to")
+		scenario = flag.String("scenario", "", "name of the scenario to run")
+		path     = flag.String("path", "", "path to the JSON integration file of the default scenario")
+	)
+	flag.Parse()
+
+	s, err := flight_integration.GetScenario(*scenario, *path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = s.RunClient(net.JoinHostPort(*host, strconv.Itoa(*port)), grpc.WithInsecure())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main // import "github.com/apache/arrow/go/arrow/internal/flight_integration/cmd/arrow-flight-integration-server"
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/apache/arrow/go/arrow/internal/flight_integration"
+)
+
+func main() {
+	log.SetPrefix("arrow-flight-integration-server: ")
+	log.SetFlags(0)
+
+	var (
+		port     = flag.Int("port", 31337, "port to listen on, any free port if 0")
+		scenario = flag.String("scenario", "", "name of the scenario to run")
+	)
+	flag.Parse()
+
+	s, err := flight_integration.GetScenario(*scenario)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv, err := s.MakeServer(*port)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv.SetShutdownOnSignals(os.Interrupt, syscall.SIGTERM)
+
+	// the integration runner waits for this line before starting the client.
+	_, p, _ := net.SplitHostPort(srv.Addr().String())
+	fmt.Printf("Server listening on localhost:%s\n", p)
+	if err := srv.Serve(); err != nil {
+		log.Fatal(err)
+	}
+}
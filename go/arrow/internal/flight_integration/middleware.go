@@ -0,0 +1,116 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_integration
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/arrow/flight"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	middlewareHeader = "x-middleware"
+	middlewareValue  = "expected value"
+)
+
+// middlewareScenario checks that the client sends, and gets back, a header
+// set by a middleware, both on successful and failed calls.
+type middlewareScenario struct{}
+
+func (s *middlewareScenario) MakeServer(port int) (flight.Server, error) {
+	unary, stream := flight.CreateServerMiddleware(echoServerMiddleware{})
+	return makeServer(port, &flight.FlightServiceService{
+		GetFlightInfo: func(_ context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+			if desc.Type != flight.FlightDescriptor_CMD || string(desc.Cmd) != "success" {
+				return nil, status.Error(codes.Unknown, "unknown descriptor")
+			}
+			return &flight.FlightInfo{
+				FlightDescriptor: desc,
+				Endpoint: []*flight.FlightEndpoint{{
+					Ticket: &flight.Ticket{Ticket: []byte("foo")},
+				}},
+				TotalRecords: -1,
+				TotalBytes:   -1,
+			}, nil
+		},
+	}, nil, grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+}
+
+// echoServerMiddleware sends back the middleware header of the request.
+type echoServerMiddleware struct{}
+
+type echoKey struct{}
+
+func (echoServerMiddleware) StartCall(ctx context.Context, incoming metadata.MD) (context.Context, error) {
+	return context.WithValue(ctx, echoKey{}, incoming.Get(middlewareHeader)), nil
+}
+
+func (echoServerMiddleware) SendingHeaders(ctx context.Context) metadata.MD {
+	if vals, _ := ctx.Value(echoKey{}).([]string); len(vals) > 0 {
+		return metadata.Pairs(middlewareHeader, vals[0])
+	}
+	return nil
+}
+
+func (echoServerMiddleware) CallCompleted(context.Context, error) metadata.MD { return nil }
+
+// headerClientMiddleware sends the middleware header, and keeps the last one
+// received.
+type headerClientMiddleware struct {
+	received []string
+}
+
+func (m *headerClientMiddleware) SendingHeaders(context.Context) metadata.MD {
+	return metadata.Pairs(middlewareHeader, middlewareValue)
+}
+
+func (m *headerClientMiddleware) HeadersReceived(_ context.Context, md metadata.MD) {
+	m.received = md.Get(middlewareHeader)
+}
+
+func (s *middlewareScenario) RunClient(addr string, opts ...grpc.DialOption) error {
+	mw := &headerClientMiddleware{}
+	client, err := flight.NewClientWithMiddleware(addr, nil, []flight.ClientMiddleware{mw}, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	for _, tc := range []struct {
+		cmd     string
+		success bool
+	}{
+		{cmd: "", success: false},
+		{cmd: "success", success: true},
+	} {
+		mw.received = nil
+		_, err := client.GetFlightInfo(context.Background(),
+			&flight.FlightDescriptor{Type: flight.FlightDescriptor_CMD, Cmd: []byte(tc.cmd)})
+		if (err == nil) != tc.success {
+			return xerrors.Errorf("flight_integration: command %q: unexpected error %v", tc.cmd, err)
+		}
+		if vals := mw.received; len(vals) == 0 || vals[0] != middlewareValue {
+			return xerrors.Errorf("flight_integration: command %q: invalid %s header: %q", tc.cmd, middlewareHeader, vals)
+		}
+	}
+	return nil
+}
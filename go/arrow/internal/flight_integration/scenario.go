@@ -0,0 +1,380 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flight_integration implements the scenarios of the Arrow Flight
+// integration tests, which check that the clients and servers of the Arrow
+// implementations interoperate. The scenarios are run by the
+// arrow-flight-integration-client and arrow-flight-integration-server
+// commands.
+package flight_integration // import "github.com/apache/arrow/go/arrow/internal/flight_integration"
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/arrjson"
+	"github.com/apache/arrow/go/arrow/flight"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Scenario is an integration scenario, run by a client against a server
+// of another implementation.
+type Scenario interface {
+	// MakeServer returns the server of the scenario, listening on port,
+	// or on any free port if port is 0.
+	MakeServer(port int) (flight.Server, error)
+	// RunClient runs the client of the scenario against the server at
+	// addr, returning an error if the server does not behave as expected.
+	RunClient(addr string, opts ...grpc.DialOption) error
+}
+
+// GetScenario returns the scenario of the given name: the default
+// scenario, uploading and downloading the JSON integration file of path
+// args[0], if name is empty, "auth:basic_proto" or "middleware".
+func GetScenario(name string, args ...string) (Scenario, error) {
+	switch name {
+	case "":
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		return &defaultScenario{path: path}, nil
+	case "auth:basic_proto":
+		return &authBasicProtoScenario{}, nil
+	case "middleware":
+		return &middlewareScenario{}, nil
+	}
+	return nil, xerrors.Errorf("flight_integration: unknown scenario %q", name)
+}
+
+func makeServer(port int, svc *flight.FlightServiceService, auth flight.ServerAuthHandler, opts ...grpc.ServerOption) (flight.Server, error) {
+	s := flight.NewFlightServer(auth, opts...)
+	if err := s.Init(net.JoinHostPort("localhost", strconv.Itoa(port))); err != nil {
+		return nil, err
+	}
+	s.RegisterFlightService(svc)
+	return s, nil
+}
+
+// defaultScenario uploads the dataset of a JSON integration file, and
+// checks that it is downloaded unchanged from the locations returned by the
+// server.
+type defaultScenario struct {
+	path string
+}
+
+func (s *defaultScenario) MakeServer(port int) (flight.Server, error) {
+	srv := &datasetServer{datasets: make(map[string]dataset)}
+	server, err := makeServer(port, &flight.FlightServiceService{
+		GetFlightInfo: srv.GetFlightInfo,
+		DoGet:         srv.DoGet,
+		DoPut:         srv.DoPut,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	srv.addr = server.Addr().String()
+	return server, nil
+}
+
+func (s *defaultScenario) RunClient(addr string, opts ...grpc.DialOption) error {
+	if s.path == "" {
+		return xerrors.New("flight_integration: no JSON integration file")
+	}
+	f, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	mem := memory.NewGoAllocator()
+	r, err := arrjson.NewReader(f, arrjson.WithAllocator(mem))
+	if err != nil {
+		return xerrors.Errorf("flight_integration: could not read JSON file %q: %w", s.path, err)
+	}
+	defer r.Release()
+
+	recs := make([]array.Record, r.NumRecords())
+	for i := range recs {
+		if recs[i], err = r.Read(); err != nil {
+			return err
+		}
+	}
+
+	client, err := flight.NewFlightClient(addr, nil, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	desc := &flight.FlightDescriptor{Type: flight.FlightDescriptor_PATH, Path: []string{s.path}}
+	if err := uploadDataset(ctx, client, desc, r.Schema(), recs); err != nil {
+		return err
+	}
+
+	info, err := client.GetFlightInfo(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if len(info.Endpoint) == 0 {
+		return xerrors.New("flight_integration: no endpoint for the uploaded dataset")
+	}
+	for _, ep := range info.Endpoint {
+		if len(ep.Location) == 0 {
+			ep.Location = []*flight.Location{{Uri: "grpc+tcp://" + addr}}
+		}
+		for _, loc := range ep.Location {
+			if err := checkDataset(ctx, loc.Uri, ep.Ticket, r.Schema(), recs, opts...); err != nil {
+				return xerrors.Errorf("flight_integration: location %q: %w", loc.Uri, err)
+			}
+		}
+	}
+	return nil
+}
+
+// uploadDataset uploads recs with DoPut, with the index of each record as
+// application metadata, which the server must acknowledge.
+func uploadDataset(ctx context.Context, client flight.Client, desc *flight.FlightDescriptor, schema *arrow.Schema, recs []array.Record) error {
+	stream, err := client.DoPut(ctx)
+	if err != nil {
+		return err
+	}
+	mw := &metadataWriter{w: stream, desc: desc}
+	w := flight.NewRecordWriter(mw, ipc.WithSchema(schema))
+	for i, rec := range recs {
+		mw.metadata = []byte(strconv.Itoa(i))
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+		ack, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if string(ack.AppMetadata) != strconv.Itoa(i) {
+			return xerrors.Errorf("flight_integration: invalid acknowledgement of record %d: %q", i, ack.AppMetadata)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// checkDataset checks that the dataset of ticket, downloaded from uri, has
+// the given schema and records.
+func checkDataset(ctx context.Context, uri string, ticket *flight.Ticket, schema *arrow.Schema, recs []array.Record, opts ...grpc.DialOption) error {
+	addr := uri
+	if i := strings.Index(uri, "://"); i >= 0 {
+		addr = uri[i+3:]
+	}
+	client, err := flight.NewFlightClient(addr, nil, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	stream, err := client.DoGet(ctx, ticket)
+	if err != nil {
+		return err
+	}
+	mr := &metadataReader{r: stream}
+	r, err := flight.NewRecordReader(mr)
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+
+	if !r.Schema().Equal(schema) {
+		return xerrors.Errorf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), schema)
+	}
+	n := 0
+	for ; r.Next(); n++ {
+		if n >= len(recs) {
+			return xerrors.Errorf("got more than %d records", len(recs))
+		}
+		if !array.RecordEqual(r.Record(), recs[n]) {
+			return xerrors.Errorf("invalid record %d:\ngot= %v\nwant=%v", n, r.Record(), recs[n])
+		}
+		if string(mr.metadata) != strconv.Itoa(n) {
+			return xerrors.Errorf("invalid metadata of record %d: %q", n, mr.metadata)
+		}
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if n != len(recs) {
+		return xerrors.Errorf("got %d records, want %d", n, len(recs))
+	}
+	return nil
+}
+
+// metadataWriter sets the descriptor of the first message of a stream, and
+// the application metadata of the following ones.
+type metadataWriter struct {
+	w        flight.DataStreamWriter
+	desc     *flight.FlightDescriptor
+	metadata []byte
+}
+
+func (w *metadataWriter) Send(fd *flight.FlightData) error {
+	fd.FlightDescriptor, w.desc = w.desc, nil
+	fd.AppMetadata = w.metadata
+	return w.w.Send(fd)
+}
+
+// metadataReader keeps the descriptor of the first message of a stream,
+// and the application metadata of the last one.
+type metadataReader struct {
+	r        flight.DataStreamReader
+	desc     *flight.FlightDescriptor
+	metadata []byte
+}
+
+func (r *metadataReader) Recv() (*flight.FlightData, error) {
+	fd, err := r.r.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if r.desc == nil {
+		r.desc = fd.FlightDescriptor
+	}
+	r.metadata = fd.AppMetadata
+	return fd, nil
+}
+
+type dataset struct {
+	schema *arrow.Schema
+	recs   []array.Record
+}
+
+// datasetServer serves the datasets uploaded to it, keyed by the first
+// element of the path of their descriptor.
+type datasetServer struct {
+	addr string
+
+	mu       sync.Mutex
+	datasets map[string]dataset
+}
+
+func (s *datasetServer) lookup(key string) (dataset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ds, ok := s.datasets[key]
+	if !ok {
+		return dataset{}, status.Errorf(codes.NotFound, "unknown dataset %q", key)
+	}
+	return ds, nil
+}
+
+func (s *datasetServer) GetFlightInfo(ctx context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	if desc.Type != flight.FlightDescriptor_PATH || len(desc.Path) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "descriptor must be a path")
+	}
+	ds, err := s.lookup(desc.Path[0])
+	if err != nil {
+		return nil, err
+	}
+	var rows int64
+	for _, rec := range ds.recs {
+		rows += rec.NumRows()
+	}
+	return &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(ds.schema, memory.DefaultAllocator),
+		FlightDescriptor: desc,
+		Endpoint: []*flight.FlightEndpoint{{
+			Ticket:   &flight.Ticket{Ticket: []byte(desc.Path[0])},
+			Location: []*flight.Location{{Uri: "grpc+tcp://" + s.addr}},
+		}},
+		TotalRecords: rows,
+		TotalBytes:   -1,
+	}, nil
+}
+
+func (s *datasetServer) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	ds, err := s.lookup(string(tkt.Ticket))
+	if err != nil {
+		return err
+	}
+	mw := &metadataWriter{w: stream}
+	w := flight.NewRecordWriter(mw, ipc.WithSchema(ds.schema))
+	for i, rec := range ds.recs {
+		mw.metadata = []byte(strconv.Itoa(i))
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func (s *datasetServer) DoPut(stream flight.FlightService_DoPutServer) error {
+	mr := &metadataReader{r: stream}
+	r, err := flight.NewRecordReader(mr)
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+	if mr.desc == nil || mr.desc.Type != flight.FlightDescriptor_PATH || len(mr.desc.Path) == 0 {
+		return status.Error(codes.InvalidArgument, "descriptor must be a path")
+	}
+
+	ds := dataset{schema: r.Schema()}
+	for r.Next() {
+		rec := r.Record()
+		rec.Retain()
+		ds.recs = append(ds.recs, rec)
+		if err := stream.Send(&flight.PutResult{AppMetadata: mr.metadata}); err != nil {
+			return err
+		}
+	}
+	if err := r.Err(); err != nil {
+		for _, rec := range ds.recs {
+			rec.Release()
+		}
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.datasets[mr.desc.Path[0]]; ok {
+		for _, rec := range old.recs {
+			rec.Release()
+		}
+	}
+	s.datasets[mr.desc.Path[0]] = ds
+	return nil
+}
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flight_integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/arrjson"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/internal/flight_integration"
+	"google.golang.org/grpc"
+)
+
+func runScenario(t *testing.T, name string, args ...string) {
+	t.Helper()
+
+	s, err := flight_integration.GetScenario(name, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := s.MakeServer(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve()
+	defer srv.Shutdown()
+
+	if err := s.RunClient(srv.Addr().String(), grpc.WithInsecure()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultScenario(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "go-arrow-flight-integration-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, name := range arrdata.RecordNames {
+		t.Run(name, func(t *testing.T) {
+			switch name {
+			case "decimal128", "decimal256":
+				t.Skip() // decimals are not supported by the JSON format yet.
+			}
+			recs := arrdata.Records[name]
+			path := filepath.Join(tempDir, name+".json")
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			w, err := arrjson.NewWriter(f, recs[0].Schema())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, rec := range recs {
+				if err := w.Write(rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			runScenario(t, "", path)
+		})
+	}
+}
+
+func TestAuthBasicProtoScenario(t *testing.T) {
+	runScenario(t, "auth:basic_proto")
+}
+
+func TestMiddlewareScenario(t *testing.T) {
+	runScenario(t, "middleware")
+}
+
+func TestUnknownScenario(t *testing.T) {
+	if _, err := flight_integration.GetScenario("unknown"); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
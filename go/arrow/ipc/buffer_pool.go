@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"sync"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// bufferPool is the allocator of the buffers of the records read with
+// WithBufferReuse. The memory of the buffers released is kept for the next
+// records, instead of being handed back to mem, until the pool is closed.
+type bufferPool struct {
+	mem memory.Allocator
+
+	mu     sync.Mutex
+	free   [][]byte
+	closed bool
+
+	// loaded are the buffers of the record being loaded, released once the
+	// arrays of the record hold them. scratch is the memory compressed
+	// buffers are read to. Both are only used by the reader.
+	loaded  []*memory.Buffer
+	scratch []byte
+}
+
+func newBufferPool(mem memory.Allocator) *bufferPool {
+	return &bufferPool{mem: mem}
+}
+
+// Allocate returns the smallest memory kept which fits size bytes, or new
+// memory of the allocator of the pool, in which case a memory kept is
+// handed back to it so that the pool does not grow with the size of the
+// records.
+func (p *bufferPool) Allocate(size int) []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best := -1
+	for i, b := range p.free {
+		if len(b) >= size && (best < 0 || len(b) < len(p.free[best])) {
+			best = i
+		}
+	}
+	if best < 0 {
+		if n := len(p.free); n > 0 {
+			p.mem.Free(p.free[n-1])
+			p.free = p.free[:n-1]
+		}
+		return p.mem.Allocate(size)
+	}
+
+	b := p.free[best]
+	last := len(p.free) - 1
+	p.free[best] = p.free[last]
+	p.free[last] = nil
+	p.free = p.free[:last]
+	return b
+}
+
+func (p *bufferPool) Reallocate(size int, b []byte) []byte {
+	return p.mem.Reallocate(size, b)
+}
+
+func (p *bufferPool) Free(b []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		p.mem.Free(b)
+		return
+	}
+	p.free = append(p.free, b)
+}
+
+// buffer returns a buffer of n bytes, released once the record being
+// loaded is built.
+func (p *bufferPool) buffer(n int) *memory.Buffer {
+	buf := memory.NewResizableBuffer(p)
+	buf.Resize(n)
+	p.loaded = append(p.loaded, buf)
+	return buf
+}
+
+// scratchBytes returns n bytes of scratch memory, valid until the next call.
+func (p *bufferPool) scratchBytes(n int) []byte {
+	if cap(p.scratch) < n {
+		p.scratch = make([]byte, n)
+	}
+	return p.scratch[:n]
+}
+
+// releaseLoaded releases the buffers of the record just built, which its
+// arrays now hold.
+func (p *bufferPool) releaseLoaded() {
+	for i, buf := range p.loaded {
+		buf.Release()
+		p.loaded[i] = nil
+	}
+	p.loaded = p.loaded[:0]
+}
+
+// close hands the memory kept back to the allocator of the pool, along
+// with the memory of the buffers still held, once they are released.
+func (p *bufferPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, b := range p.free {
+		p.mem.Free(b)
+	}
+	p.free, p.scratch, p.closed = nil, nil, true
+}
+
+var (
+	_ memory.Allocator = (*bufferPool)(nil)
+)
@@ -23,7 +23,7 @@ import (
 
 	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/arrio"
-	"github.com/apache/arrow/go/arrow/internal/arrjson"
+	"github.com/apache/arrow/go/arrow/arrjson"
 	"github.com/apache/arrow/go/arrow/ipc"
 	"golang.org/x/xerrors"
 )
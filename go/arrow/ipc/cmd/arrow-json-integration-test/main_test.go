@@ -35,7 +35,8 @@ func TestIntegration(t *testing.T) {
 	const verbose = true
 	for name, recs := range arrdata.Records {
 		t.Run(name, func(t *testing.T) {
-			if name == "decimal128" {
+			switch name {
+			case "decimal128", "decimal256":
 				t.Skip() // FIXME(sbinet): implement full decimal128 support
 			}
 			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
@@ -0,0 +1,304 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"golang.org/x/xerrors"
+)
+
+// CompressionType represents the codec compressing the body buffers of
+// record batches, see WithCompression.
+type CompressionType int8
+
+const (
+	CompressionNone     = CompressionType(-1) // uncompressed bodies
+	CompressionLZ4Frame = CompressionType(flatbuf.CompressionTypeLZ4_FRAME)
+	CompressionZstd     = CompressionType(flatbuf.CompressionTypeZSTD)
+)
+
+func (c CompressionType) String() string {
+	if c == CompressionNone {
+		return "NONE"
+	}
+	if v, ok := flatbuf.EnumNamesCompressionType[int8(c)]; ok {
+		return v
+	}
+	return fmt.Sprintf("CompressionType(%d)", int8(c))
+}
+
+// uncompressedLen is the length prefix of buffers written uncompressed in
+// a compressed body.
+const uncompressedLen = -1
+
+type compressor interface {
+	// compress appends the compressed src to dst.
+	compress(dst, src []byte) ([]byte, error)
+}
+
+func newCompressor(codec CompressionType, level int) (compressor, error) {
+	switch codec {
+	case CompressionLZ4Frame:
+		lvl := lz4.Fast
+		switch {
+		case level > 9:
+			lvl = lz4.Level9
+		case level > 0:
+			lvl = lz4.Level1 << uint(level-1)
+		}
+		return &lz4Compressor{level: lvl}, nil
+	case CompressionZstd:
+		lvl := zstd.SpeedDefault
+		if level > 0 {
+			lvl = zstd.EncoderLevelFromZstd(level)
+		}
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(lvl), zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return zstdCompressor{enc}, nil
+	}
+	return nil, xerrors.Errorf("arrow/ipc: unsupported compression codec %v", codec)
+}
+
+type lz4Compressor struct {
+	level lz4.CompressionLevel
+	buf   bytes.Buffer
+}
+
+func (c *lz4Compressor) compress(dst, src []byte) ([]byte, error) {
+	c.buf.Reset()
+	w := lz4.NewWriter(&c.buf)
+	if err := w.Apply(lz4.CompressionLevelOption(c.level)); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return append(dst, c.buf.Bytes()...), nil
+}
+
+type zstdCompressor struct {
+	enc *zstd.Encoder
+}
+
+func (c zstdCompressor) compress(dst, src []byte) ([]byte, error) {
+	return c.enc.EncodeAll(src, dst), nil
+}
+
+// bodyCodec compresses the body buffers of the record batches written by
+// a writer, as configured by WithCompression, WithMinCompressSize and
+// WithCompressionConcurrency.
+type bodyCodec struct {
+	typ     CompressionType
+	minSize int64
+
+	// compressors are the compressors of the goroutines compressing the
+	// buffers of a body.
+	compressors []compressor
+}
+
+// newBodyCodec returns the codec configured in cfg, or nil when bodies are
+// written uncompressed.
+func newBodyCodec(cfg *config) (*bodyCodec, error) {
+	if cfg.codec.typ == CompressionNone {
+		return nil, nil
+	}
+	n := cfg.codec.concurrency
+	if n < 1 {
+		n = 1
+	}
+	codec := &bodyCodec{
+		typ:         cfg.codec.typ,
+		minSize:     cfg.codec.minSize,
+		compressors: make([]compressor, n),
+	}
+	for i := range codec.compressors {
+		c, err := newCompressor(cfg.codec.typ, cfg.codec.level)
+		if err != nil {
+			return nil, err
+		}
+		codec.compressors[i] = c
+	}
+	return codec, nil
+}
+
+// compressBody replaces the buffers of p with their compressed form: the
+// uncompressed length as a little-endian int64 followed by the compressed
+// bytes. Buffers smaller than minSize, or which would not shrink, are
+// written uncompressed after a length of -1.
+func compressBody(mem memory.Allocator, p *Payload, codec *bodyCodec) error {
+	outs := make([][]byte, len(p.body))
+	err := parallelize(len(p.body), len(codec.compressors), func(worker, i int) error {
+		buf := p.body[i]
+		if buf == nil || buf.Len() == 0 {
+			return nil
+		}
+		out, err := codec.compressBuffer(codec.compressors[worker], buf.Bytes())
+		if err != nil {
+			return xerrors.Errorf("arrow/ipc: could not compress buffer %d: %w", i, err)
+		}
+		outs[i] = out
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the buffers are allocated once compressed, allocators not being
+	// safe for concurrent use.
+	for i, out := range outs {
+		if out == nil {
+			continue
+		}
+		cbuf := memory.NewResizableBuffer(mem)
+		cbuf.Resize(len(out))
+		copy(cbuf.Bytes(), out)
+		p.body[i].Release()
+		p.body[i] = cbuf
+	}
+	return nil
+}
+
+// compressBuffer returns the compressed form of src, compressed with c.
+func (codec *bodyCodec) compressBuffer(c compressor, src []byte) ([]byte, error) {
+	out := make([]byte, 8, 8+len(src))
+	compressed := false
+	if int64(len(src)) >= codec.minSize {
+		var err error
+		out, err = c.compress(out, src)
+		if err != nil {
+			return nil, err
+		}
+		compressed = len(out) < 8+len(src)
+	}
+	length := int64(len(src))
+	if !compressed {
+		length = uncompressedLen
+		out = append(out[:8], src...)
+	}
+	binary.LittleEndian.PutUint64(out, uint64(length))
+	return out, nil
+}
+
+// parallelize calls f for the n items with at most workers goroutines,
+// with the index of the goroutine and of the item, returning the first
+// error.
+func parallelize(n, workers int, f func(worker, i int) error) error {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := f(0, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, workers)
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				if errs[w] = f(w, i); errs[w] != nil {
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+)
+
+// decompressBuffer returns the uncompressed bytes of a buffer of a body
+// compressed with codec, of at most max bytes.
+func decompressBuffer(codec CompressionType, raw []byte, max int64) ([]byte, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+	if len(raw) < 8 {
+		return nil, xerrors.Errorf("arrow/ipc: compressed buffer too short (%d bytes)", len(raw))
+	}
+
+	n := int64(binary.LittleEndian.Uint64(raw))
+	raw = raw[8:]
+	if n == uncompressedLen {
+		return raw, nil
+	}
+	if n < 0 {
+		return nil, xerrors.Errorf("arrow/ipc: invalid uncompressed buffer length %d", n)
+	}
+	if n > max {
+		return nil, &LimitError{Limit: "decompressed buffer size", Value: n, Max: max}
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	switch codec {
+	case CompressionLZ4Frame:
+		out = make([]byte, n)
+		_, err = io.ReadFull(lz4.NewReader(bytes.NewReader(raw)), out)
+	case CompressionZstd:
+		zstdDecoderOnce.Do(func() {
+			zstdDecoder, zstdDecoderErr = zstd.NewReader(nil)
+		})
+		if zstdDecoderErr != nil {
+			return nil, zstdDecoderErr
+		}
+		out, err = zstdDecoder.DecodeAll(raw, make([]byte, 0, n))
+	default:
+		return nil, xerrors.Errorf("arrow/ipc: unsupported compression codec %v", codec)
+	}
+	switch {
+	case err != nil:
+		return nil, xerrors.Errorf("arrow/ipc: could not decompress %v buffer: %w", codec, err)
+	case int64(len(out)) != n:
+		return nil, xerrors.Errorf("arrow/ipc: invalid decompressed buffer length (got=%d, want=%d)", len(out), n)
+	}
+	return out, nil
+}
@@ -0,0 +1,165 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestCompressedStream(t *testing.T) {
+	for _, codec := range []ipc.CompressionType{ipc.CompressionLZ4Frame, ipc.CompressionZstd} {
+		for name, recs := range arrdata.Records {
+			// append an empty batch to exercise empty body buffers.
+			recs = append(recs[:len(recs):len(recs)], recs[0].NewSlice(0, 0))
+			for _, minSize := range []int64{0, 1 << 20} {
+				for _, workers := range []int{1, 4} {
+					t.Run(fmt.Sprintf("%v/%s/workers=%d", codec, name, workers), func(t *testing.T) {
+						mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+						defer mem.AssertSize(t, 0)
+
+						var buf bytes.Buffer
+						w := ipc.NewWriter(&buf,
+							ipc.WithSchema(recs[0].Schema()),
+							ipc.WithAllocator(mem),
+							ipc.WithCompression(codec, 0),
+							ipc.WithMinCompressSize(minSize),
+							ipc.WithCompressionConcurrency(workers),
+						)
+						for i, rec := range recs {
+							if err := w.Write(rec); err != nil {
+								t.Fatalf("could not write record[%d]: %v", i, err)
+							}
+						}
+						if err := w.Close(); err != nil {
+							t.Fatal(err)
+						}
+
+						r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem), ipc.WithCompressionConcurrency(workers))
+						if err != nil {
+							t.Fatal(err)
+						}
+						defer r.Release()
+
+						n := 0
+						for r.Next() {
+							if !array.RecordEqual(r.Record(), recs[n]) {
+								t.Fatalf("records[%d] differ", n)
+							}
+							n++
+						}
+						if n != len(recs) {
+							t.Fatalf("invalid number of records. got=%d, want=%d", n, len(recs))
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestCompressionShrinksBody(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	bldr := array.NewRecordBuilder(mem, schema)
+	defer bldr.Release()
+	for i := 0; i < 1<<14; i++ {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i % 16))
+	}
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	size := func(opts ...ipc.Option) int {
+		var buf bytes.Buffer
+		w := ipc.NewWriter(&buf, append(opts, ipc.WithSchema(schema), ipc.WithAllocator(mem))...)
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Len()
+	}
+
+	plain := size()
+	for _, codec := range []ipc.CompressionType{ipc.CompressionLZ4Frame, ipc.CompressionZstd} {
+		if got := size(ipc.WithCompression(codec, 0)); got >= plain/10 {
+			t.Errorf("%v: compressed stream is too large: got=%d, plain=%d", codec, got, plain)
+		}
+		if got := size(ipc.WithCompression(codec, 0), ipc.WithMinCompressSize(1<<20)); got <= plain {
+			t.Errorf("%v: buffers under threshold were compressed: got=%d, plain=%d", codec, got, plain)
+		}
+	}
+}
+
+func TestCompressedFile(t *testing.T) {
+	recs := arrdata.Records["strings"]
+	f, err := ioutil.TempFile("", "go-arrow-compressed-file-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w, err := ipc.NewFileWriter(f, ipc.WithSchema(recs[0].Schema()), ipc.WithCompression(ipc.CompressionZstd, 3), ipc.WithCompressionConcurrency(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewFileReader(f, ipc.WithCompressionConcurrency(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i := range recs {
+		rec, err := r.Record(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !array.RecordEqual(rec, recs[i]) {
+			t.Fatalf("records[%d] differ", i)
+		}
+	}
+}
+
+func TestUnsupportedCompression(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+	w := ipc.NewWriter(ioutil.Discard, ipc.WithSchema(recs[0].Schema()), ipc.WithCompression(ipc.CompressionType(42), 0))
+	if err := w.Write(recs[0]); err == nil {
+		t.Fatal("expected an error for an unsupported codec")
+	}
+}
@@ -19,6 +19,7 @@ package ipc // import "github.com/apache/arrow/go/arrow/ipc"
 import (
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
 	"golang.org/x/xerrors"
 )
 
@@ -28,6 +29,10 @@ type dictTypeMap map[int64]arrow.Field
 type dictMemo struct {
 	dict2id map[array.Interface]int64
 	id2dict dictMap // map of dictionary ID to dictionary array
+
+	// fields holds the dictionary IDs of the dictionary-encoded fields of
+	// the schema, in depth-first order.
+	fields []int64
 }
 
 func newMemo() dictMemo {
@@ -75,6 +80,24 @@ func (memo dictMemo) HasID(id int64) bool {
 	return ok
 }
 
+// AddField records id as the dictionary ID of the next dictionary-encoded
+// field of the schema.
+func (memo *dictMemo) AddField(id int64) {
+	memo.fields = append(memo.fields, id)
+}
+
+// Replace sets the dictionary of id to v, releasing the dictionary it
+// replaces, if any.
+func (memo *dictMemo) Replace(id int64, v array.Interface) {
+	v.Retain()
+	if old, ok := memo.id2dict[id]; ok {
+		delete(memo.dict2id, old)
+		old.Release()
+	}
+	memo.id2dict[id] = v
+	memo.dict2id[v] = id
+}
+
 func (memo *dictMemo) Add(id int64, v array.Interface) {
 	if _, dup := memo.id2dict[id]; dup {
 		panic(xerrors.Errorf("arrow/ipc: duplicate id=%d", id))
@@ -83,3 +106,118 @@ func (memo *dictMemo) Add(id int64, v array.Interface) {
 	memo.id2dict[id] = v
 	memo.dict2id[v] = id
 }
+
+// dictionaryPayloads returns the payloads of the dictionary batches to
+// write before rec, for the dictionaries of rec not written yet or changed
+// since, recording them in memo. A dictionary only appended to is written
+// as a delta when deltas is set, other changes as a replacement, unless
+// replace is false, as for files, where they are an error. The body buffers
+// are aligned to align bytes.
+func dictionaryPayloads(memo *dictMemo, rec array.Record, mem memory.Allocator, codec *bodyCodec, align int64, deltas, replace bool) (payloads, error) {
+	var dicts []array.Interface
+	for _, col := range rec.Columns() {
+		dicts = collectDictionaries(dicts, col)
+	}
+	if len(dicts) != len(memo.fields) {
+		return nil, xerrors.Errorf("arrow/ipc: invalid number of dictionaries (got=%d, want=%d)", len(dicts), len(memo.fields))
+	}
+
+	var ps payloads
+	for i, dict := range dicts {
+		var (
+			id      = memo.fields[i]
+			values  = dict
+			isDelta = false
+		)
+		if prev, ok := memo.Dict(id); ok {
+			switch {
+			case prev.Data() == dict.Data() || array.ArrayEqual(prev, dict):
+				continue
+			case deltas && isDictionaryPrefix(prev, dict):
+				values = array.NewSlice(dict, int64(prev.Len()), int64(dict.Len()))
+				isDelta = true
+			case !replace:
+				ps.Release()
+				return nil, xerrors.Errorf("arrow/ipc: dictionary %d cannot be replaced", id)
+			}
+		}
+
+		const allow64b = true
+		var (
+			p   = Payload{msg: MessageDictionaryBatch}
+			enc = newRecordEncoder(mem, 0, kMaxNestingDepth, allow64b, codec, align)
+			err = enc.EncodeDictionary(&p, id, isDelta, values)
+		)
+		if isDelta {
+			values.Release()
+		}
+		if err != nil {
+			p.Release()
+			ps.Release()
+			return nil, err
+		}
+		ps = append(ps, p)
+		memo.Replace(id, dict)
+	}
+	return ps, nil
+}
+
+// collectDictionaries appends the dictionaries of the dictionary-encoded
+// arrays of arr to dicts, in depth-first order.
+func collectDictionaries(dicts []array.Interface, arr array.Interface) []array.Interface {
+	switch arr := arr.(type) {
+	case *array.Dictionary:
+		dicts = append(dicts, arr.Dictionary())
+	case *array.Struct:
+		for i := 0; i < arr.NumField(); i++ {
+			dicts = collectDictionaries(dicts, arr.Field(i))
+		}
+	case *array.List:
+		dicts = collectDictionaries(dicts, arr.ListValues())
+	case *array.FixedSizeList:
+		dicts = collectDictionaries(dicts, arr.ListValues())
+	}
+	return dicts
+}
+
+// isDictionaryPrefix returns whether the values of prev start the values of
+// dict, more values being appended to them.
+func isDictionaryPrefix(prev, dict array.Interface) bool {
+	if dict.Len() <= prev.Len() {
+		return false
+	}
+	head := array.NewSlice(dict, 0, int64(prev.Len()))
+	defer head.Release()
+	return array.ArrayEqual(prev, head)
+}
+
+// updateDictionary sets the dictionary id of memo to values, or to its
+// values followed by values when isDelta is set, releasing the dictionary
+// it supersedes. Deltas are concatenated with memory from mem.
+func updateDictionary(memo *dictMemo, id int64, values array.Interface, isDelta bool, mem memory.Allocator) error {
+	if isDelta {
+		prev, ok := memo.Dict(id)
+		if !ok {
+			return xerrors.Errorf("arrow/ipc: delta for missing dictionary with ID=%d", id)
+		}
+		dict, err := array.Concatenate([]array.Interface{prev, values}, mem)
+		if err != nil {
+			return xerrors.Errorf("arrow/ipc: could not append delta to dictionary with ID=%d: %w", id, err)
+		}
+		defer dict.Release()
+		values = dict
+	}
+	memo.Replace(id, values)
+	return nil
+}
+
+// missingDictionary returns the ID of a dictionary-encoded field of memo
+// without a dictionary, if any.
+func (memo *dictMemo) missingDictionary() (int64, bool) {
+	for _, id := range memo.fields {
+		if !memo.HasID(id) {
+			return id, true
+		}
+	}
+	return 0, false
+}
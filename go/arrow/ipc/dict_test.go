@@ -17,10 +17,17 @@
 package ipc // import "github.com/apache/arrow/go/arrow/ipc"
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
 	"testing"
 
+	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
 	"github.com/apache/arrow/go/arrow/memory"
 )
 
@@ -194,3 +201,431 @@ func TestDictMemoPanics(t *testing.T) {
 		})
 	}
 }
+
+var (
+	tagType  = &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int16, ValueType: arrow.BinaryTypes.String}
+	codeType = &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Uint8, ValueType: arrow.PrimitiveTypes.Int32, Ordered: true}
+
+	dictSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "tag", Type: tagType, Nullable: true},
+		{Name: "nested", Type: arrow.StructOf(arrow.Field{Name: "code", Type: codeType})},
+	}, nil)
+)
+
+// dictRecord returns a record of n rows of dictSchema, whose tags and
+// codes cycle through the values of their dictionaries, every third tag
+// being null.
+func dictRecord(mem memory.Allocator, tags []string, codes []int32, n int) array.Record {
+	var (
+		ids     = make([]int64, n)
+		tagIdx  = make([]int16, n)
+		valid   = make([]bool, n)
+		codeIdx = make([]uint8, n)
+	)
+	for i := 0; i < n; i++ {
+		ids[i] = int64(i)
+		tagIdx[i] = int16(i % len(tags))
+		valid[i] = i%3 != 2
+		codeIdx[i] = uint8(i % len(codes))
+	}
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	ib.AppendValues(ids, nil)
+	idArr := ib.NewArray()
+	defer idArr.Release()
+
+	tb := array.NewInt16Builder(mem)
+	defer tb.Release()
+	tb.AppendValues(tagIdx, valid)
+	tagIndices := tb.NewArray()
+	defer tagIndices.Release()
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues(tags, nil)
+	tagValues := sb.NewArray()
+	defer tagValues.Release()
+	tagArr := array.NewDictionaryArray(tagType, tagIndices, tagValues)
+	defer tagArr.Release()
+
+	cb := array.NewUint8Builder(mem)
+	defer cb.Release()
+	cb.AppendValues(codeIdx, nil)
+	codeIndices := cb.NewArray()
+	defer codeIndices.Release()
+	vb := array.NewInt32Builder(mem)
+	defer vb.Release()
+	vb.AppendValues(codes, nil)
+	codeValues := vb.NewArray()
+	defer codeValues.Release()
+	codeArr := array.NewDictionaryArray(codeType, codeIndices, codeValues)
+	defer codeArr.Release()
+
+	nestedType := dictSchema.Field(2).Type
+	nestedData := array.NewData(nestedType, n, []*memory.Buffer{nil}, []*array.Data{codeArr.Data()}, 0, 0)
+	defer nestedData.Release()
+	nested := array.MakeFromData(nestedData)
+	defer nested.Release()
+
+	return array.NewRecord(dictSchema, []array.Interface{idArr, tagArr, nested}, int64(n))
+}
+
+// dictMessages returns a description of the messages of the stream in buf,
+// with the ID, kind and length of dictionary batches.
+func dictMessages(t *testing.T, buf []byte) []string {
+	t.Helper()
+
+	r := NewMessageReader(bytes.NewReader(buf))
+	defer r.Release()
+
+	var msgs []string
+	for {
+		msg, err := r.Message()
+		if err == io.EOF {
+			return msgs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch msg.Type() {
+		case MessageDictionaryBatch:
+			var (
+				dict flatbuf.DictionaryBatch
+				md   flatbuf.RecordBatch
+			)
+			initFB(&dict, msg.msg.Header)
+			kind := "full"
+			if dict.IsDelta() {
+				kind = "delta"
+			}
+			msgs = append(msgs, fmt.Sprintf("dict %d %s %d", dict.Id(), kind, dict.Data(&md).Length()))
+		default:
+			msgs = append(msgs, msg.Type().String())
+		}
+	}
+}
+
+func TestStreamDictionaries(t *testing.T) {
+	type batch struct {
+		tags  []string
+		codes []int32
+	}
+	for _, tc := range []struct {
+		name    string
+		deltas  bool
+		batches []batch
+		want    []string
+	}{
+		{
+			name:   "deltas",
+			deltas: true,
+			batches: []batch{
+				{tags: []string{"a", "b"}, codes: []int32{1, 2}},
+				{tags: []string{"a", "b", "c"}, codes: []int32{1, 2}},
+				{tags: []string{"a", "b", "c"}, codes: []int32{1, 2}},
+				{tags: []string{"a", "b", "c", "d", "e"}, codes: []int32{7}},
+			},
+			want: []string{
+				"Schema",
+				"dict 0 full 2", "dict 1 full 2", "RecordBatch",
+				"dict 0 delta 1", "RecordBatch",
+				"RecordBatch",
+				"dict 0 delta 2", "dict 1 full 1", "RecordBatch",
+			},
+		},
+		{
+			name: "growth without deltas",
+			batches: []batch{
+				{tags: []string{"a", "b"}, codes: []int32{1, 2}},
+				{tags: []string{"a", "b", "c"}, codes: []int32{1, 2}},
+			},
+			want: []string{
+				"Schema",
+				"dict 0 full 2", "dict 1 full 2", "RecordBatch",
+				"dict 0 full 3", "RecordBatch",
+			},
+		},
+		{
+			name:   "replacements",
+			deltas: true,
+			batches: []batch{
+				{tags: []string{"a", "b"}, codes: []int32{1, 2}},
+				{tags: []string{"x", "y", "z"}, codes: []int32{1, 2}},
+				{tags: []string{"x"}, codes: []int32{3, 4}},
+			},
+			want: []string{
+				"Schema",
+				"dict 0 full 2", "dict 1 full 2", "RecordBatch",
+				"dict 0 full 3", "RecordBatch",
+				"dict 0 full 1", "dict 1 full 2", "RecordBatch",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			var recs []array.Record
+			defer func() {
+				for _, rec := range recs {
+					rec.Release()
+				}
+			}()
+			for i, b := range tc.batches {
+				recs = append(recs, dictRecord(mem, b.tags, b.codes, 5+i))
+			}
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf, WithSchema(dictSchema), WithAllocator(mem), WithDictionaryDeltas(tc.deltas))
+			for _, rec := range recs {
+				if err := w.Write(rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := dictMessages(t, buf.Bytes()); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid messages:\ngot= %q\nwant=%q", got, tc.want)
+			}
+
+			rmem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer rmem.AssertSize(t, 0)
+
+			r, err := NewReader(bytes.NewReader(buf.Bytes()), WithAllocator(rmem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			// the records read before a dictionary changes keep the values
+			// they were read with.
+			var got []array.Record
+			defer func() {
+				for _, rec := range got {
+					rec.Release()
+				}
+			}()
+			for r.Next() {
+				r.Record().Retain()
+				got = append(got, r.Record())
+			}
+			if err := r.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(recs) {
+				t.Fatalf("invalid number of records: got=%d, want=%d", len(got), len(recs))
+			}
+			for i := range recs {
+				if !array.RecordEqual(got[i], recs[i]) {
+					t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", i, got[i], recs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStreamDictionariesMemory(t *testing.T) {
+	const n = 50
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WithSchema(dictSchema), WithDictionaryDeltas(true))
+	for i := 0; i < n; i++ {
+		// the tags are replaced with every record.
+		tags := []string{fmt.Sprintf("tag-%03d", i), fmt.Sprintf("tag-%03d", i+1)}
+		rec := dictRecord(memory.DefaultAllocator, tags, []int32{1, 2}, 3)
+		err := w.Write(rec)
+		rec.Release()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), WithAllocator(mem), WithBufferReuse())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	// the dictionaries superseded are released, so that their memory is
+	// reused for the next ones.
+	var scope *memory.CheckedAllocatorScope
+	for i := 0; r.Next(); i++ {
+		if i == n/2 {
+			scope = memory.NewCheckedAllocatorScope(mem)
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	scope.CheckSize(t)
+}
+
+func TestFileDictionaries(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var recs []array.Record
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+	for _, tags := range [][]string{{"a"}, {"a", "b"}, {"a", "b", "c"}} {
+		recs = append(recs, dictRecord(mem, tags, []int32{1, 2}, 4))
+	}
+
+	f, err := ioutil.TempFile("", "arrow-ipc-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w, err := NewFileWriter(f, WithSchema(dictSchema), WithAllocator(mem), WithDictionaryDeltas(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// files do not allow dictionaries to be replaced.
+	replaced := dictRecord(mem, []string{"x"}, []int32{1, 2}, 4)
+	defer replaced.Release()
+	if err := w.Write(replaced); err == nil {
+		t.Fatal("expected an error replacing a dictionary")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewFileReader(f, WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if got, want := r.NumDictionaries(), 4; got != want {
+		t.Fatalf("invalid number of dictionaries: got=%d, want=%d", got, want)
+	}
+	// the dictionaries of a file are those of its last records, deltas
+	// included.
+	last := recs[len(recs)-1].Column(1).(*array.Dictionary).Dictionary()
+	for i := 0; i < r.NumRecords(); i++ {
+		rec, err := r.Record(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			got  = rec.Column(1).(*array.Dictionary)
+			want = recs[i].Column(1).(*array.Dictionary)
+		)
+		switch {
+		case !array.ArrayEqual(got.Indices(), want.Indices()):
+			t.Fatalf("records[%d]: invalid indices: got=%v, want=%v", i, got.Indices(), want.Indices())
+		case !array.ArrayEqual(got.Dictionary(), last):
+			t.Fatalf("records[%d]: invalid dictionary: got=%v, want=%v", i, got.Dictionary(), last)
+		case !array.ArrayEqual(rec.Column(2), recs[i].Column(2)):
+			t.Fatalf("records[%d]: invalid nested column: got=%v, want=%v", i, rec.Column(2), recs[i].Column(2))
+		}
+	}
+}
+
+func TestFileRecordAt(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var recs []array.Record
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+	tags := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for i := range tags {
+		recs = append(recs, dictRecord(mem, tags[:i+1], []int32{1, 2, 3}, 10))
+	}
+
+	f, err := ioutil.TempFile("", "arrow-ipc-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w, err := NewFileWriter(f, WithSchema(dictSchema), WithAllocator(mem), WithDictionaryDeltas(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewFileReader(f, WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if got, want := r.NumRecords(), len(recs); got != want {
+		t.Fatalf("invalid number of records: got=%d, want=%d", got, want)
+	}
+	for _, i := range []int{-1, len(recs)} {
+		if _, err := r.RecordAt(i); err == nil {
+			t.Fatalf("expected an error reading record %d", i)
+		}
+	}
+
+	// read the records in reverse order, from several goroutines.
+	errs := make(chan error, 4)
+	for g := 0; g < cap(errs); g++ {
+		go func() {
+			for i := len(recs) - 1; i >= 0; i-- {
+				rec, err := r.RecordAt(i)
+				if err != nil {
+					errs <- err
+					return
+				}
+				var (
+					got  = rec.Column(1).(*array.Dictionary)
+					want = recs[i].Column(1).(*array.Dictionary)
+				)
+				switch {
+				case !array.ArrayEqual(got.Indices(), want.Indices()):
+					err = fmt.Errorf("records[%d]: invalid indices: got=%v, want=%v", i, got.Indices(), want.Indices())
+				case !array.ArrayEqual(got.Dictionary(), recs[len(recs)-1].Column(1).(*array.Dictionary).Dictionary()):
+					err = fmt.Errorf("records[%d]: invalid dictionary: got=%v", i, got.Dictionary())
+				case !array.ArrayEqual(rec.Column(2), recs[i].Column(2)):
+					err = fmt.Errorf("records[%d]: invalid nested column: got=%v, want=%v", i, rec.Column(2), recs[i].Column(2))
+				}
+				rec.Release()
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- nil
+		}()
+	}
+	for g := 0; g < cap(errs); g++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/extensions"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// lengthType is an extension type of lengths in a unit, stored as float64,
+// which is not registered globally.
+type lengthType struct {
+	arrow.ExtensionBase
+	unit string
+}
+
+func newLengthType(unit string) *lengthType {
+	return &lengthType{ExtensionBase: arrow.ExtensionBase{Storage: arrow.PrimitiveTypes.Float64}, unit: unit}
+}
+
+func (*lengthType) Name() string            { return "extension" }
+func (*lengthType) ExtensionName() string   { return "test.length" }
+func (t *lengthType) Serialize() string     { return t.unit }
+func (*lengthType) ArrayType() reflect.Type { return reflect.TypeOf(lengthArray{}) }
+func (t *lengthType) ExtensionEquals(o arrow.ExtensionType) bool {
+	return t.unit == o.(*lengthType).unit
+}
+func (*lengthType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	if storage.ID() != arrow.FLOAT64 {
+		return nil, xerrors.Errorf("invalid storage type %v", storage)
+	}
+	return newLengthType(data), nil
+}
+
+type lengthArray struct {
+	array.ExtensionArrayBase
+}
+
+func extensionRecord(t *testing.T, mem memory.Allocator) array.Record {
+	t.Helper()
+
+	u1, _ := extensions.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	u2, _ := extensions.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+
+	md := arrow.NewMetadata([]string{"k"}, []string{"v"})
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "uuid", Type: extensions.NewUUIDType(), Nullable: true},
+		{Name: "length", Type: newLengthType("m"), Nullable: true, Metadata: md},
+		{Name: "uuids", Type: arrow.ListOf(extensions.NewUUIDType()), Nullable: true},
+		{Name: "json", Type: extensions.NewJSONType(), Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*extensions.UUIDBuilder).AppendValues([]extensions.UUID{u1, {}, u2}, []bool{true, false, true})
+	b.Field(1).(*array.ExtensionBuilder).StorageBuilder().(*array.Float64Builder).AppendValues([]float64{1, 2, 3}, nil)
+	lb := b.Field(2).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*extensions.UUIDBuilder)
+	lb.Append(true)
+	vb.Append(u2)
+	vb.Append(u1)
+	lb.AppendNull()
+	lb.Append(true)
+	jb := b.Field(3).(*extensions.JSONBuilder)
+	jb.AppendValue(map[string]int{"a": 1})
+	jb.AppendNull()
+	jb.AppendValue([]string{"b"})
+
+	return b.NewRecord()
+}
+
+func TestStreamExtension(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := extensionRecord(t, mem)
+	defer rec.Release()
+	slice := rec.NewSlice(1, 3)
+	defer slice.Release()
+	recs := []array.Record{rec, slice}
+
+	reg := arrow.NewExtensionTypeRegistry()
+	if err := reg.Register(newLengthType("")); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := streamBytes(t, recs, ipc.WithAllocator(mem))
+	readRecords := func(buf []byte, opts ...ipc.Option) (*arrow.Schema, []array.Record) {
+		r, err := ipc.NewReader(bytes.NewReader(buf), append(opts, ipc.WithAllocator(mem))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Release()
+		var got []array.Record
+		for r.Next() {
+			r.Record().Retain()
+			got = append(got, r.Record())
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return r.Schema(), got
+	}
+	release := func(recs []array.Record) {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}
+
+	// all the extension types are registered.
+	schema, got := readRecords(buf, ipc.WithExtensionTypes(reg))
+	defer release(got)
+	if !schema.Equal(rec.Schema()) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", schema, rec.Schema())
+	}
+	if len(got) != len(recs) {
+		t.Fatalf("invalid number of records: got=%d, want=%d", len(got), len(recs))
+	}
+	for i := range recs {
+		if !array.RecordEqual(got[i], recs[i]) {
+			t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", i, got[i], recs[i])
+		}
+	}
+	if _, ok := got[0].Column(0).(*extensions.UUIDArray); !ok {
+		t.Fatalf("invalid uuid column type %T", got[0].Column(0))
+	}
+	if _, ok := got[0].Column(1).(*lengthArray); !ok {
+		t.Fatalf("invalid length column type %T", got[0].Column(1))
+	}
+
+	// test.length is not registered: it is read as its storage, keeping
+	// the extension metadata, which is written again.
+	schema, raw := readRecords(buf)
+	defer release(raw)
+	field := schema.Field(1)
+	if !arrow.TypeEqual(field.Type, arrow.PrimitiveTypes.Float64) {
+		t.Fatalf("invalid storage type %v", field.Type)
+	}
+	want := arrow.NewMetadata(
+		[]string{"k", arrow.ExtensionMetadataKey, arrow.ExtensionNameKey},
+		[]string{"v", "m", "test.length"},
+	)
+	if !reflect.DeepEqual(field.Metadata, want) {
+		t.Fatalf("invalid metadata:\ngot= %v\nwant=%v", field.Metadata, want)
+	}
+	if _, ok := raw[0].Column(0).(*extensions.UUIDArray); !ok {
+		t.Fatalf("invalid uuid column type %T", raw[0].Column(0))
+	}
+
+	schema, got = readRecords(streamBytes(t, raw, ipc.WithAllocator(mem)), ipc.WithExtensionTypes(reg))
+	defer release(got)
+	if !schema.Equal(rec.Schema()) {
+		t.Fatalf("invalid schema after pass-through:\ngot= %v\nwant=%v", schema, rec.Schema())
+	}
+	for i := range recs {
+		if !array.RecordEqual(got[i], recs[i]) {
+			t.Fatalf("records[%d] differ after pass-through:\ngot= %v\nwant=%v", i, got[i], recs[i])
+		}
+	}
+}
+
+func TestFileExtension(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := extensionRecord(t, mem)
+	defer rec.Release()
+
+	f, err := ioutil.TempFile("", "go-arrow-extension-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w, err := ipc.NewFileWriter(f, ipc.WithSchema(rec.Schema()), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reg := arrow.NewExtensionTypeRegistry()
+	if err := reg.Register(newLengthType("")); err != nil {
+		t.Fatal(err)
+	}
+	r, err := ipc.NewFileReader(f, ipc.WithExtensionTypes(reg), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if !r.Schema().Equal(rec.Schema()) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), rec.Schema())
+	}
+	got, err := r.Record(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !array.RecordEqual(got, rec) {
+		t.Fatalf("records differ:\ngot= %v\nwant=%v", got, rec)
+	}
+}
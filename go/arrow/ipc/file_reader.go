@@ -26,6 +26,7 @@ import (
 	"github.com/apache/arrow/go/arrow/bitutil"
 	"github.com/apache/arrow/go/arrow/internal/flatbuf"
 	"github.com/apache/arrow/go/arrow/memory"
+	flatbuffers "github.com/google/flatbuffers/go"
 	"golang.org/x/xerrors"
 )
 
@@ -41,6 +42,12 @@ type FileReader struct {
 
 	fields dictTypeMap
 	memo   dictMemo
+	mem    memory.Allocator // allocates the dictionaries appended to
+
+	mapping *mapping // memory of the file, when mapped
+	limits  limits
+	workers int                          // number of goroutines decompressing buffers
+	exts    *arrow.ExtensionTypeRegistry // extension types reconstructed, with the global ones
 
 	schema *arrow.Schema
 	record array.Record
@@ -56,9 +63,13 @@ func NewFileReader(r ReadAtSeeker, opts ...Option) (*FileReader, error) {
 		err error
 
 		f = FileReader{
-			r:      r,
-			fields: make(dictTypeMap),
-			memo:   newMemo(),
+			r:       r,
+			fields:  make(dictTypeMap),
+			memo:    newMemo(),
+			mem:     cfg.alloc,
+			limits:  cfg.limits,
+			workers: cfg.codec.concurrency,
+			exts:    cfg.exts,
 		}
 	)
 
@@ -87,8 +98,8 @@ func NewFileReader(r ReadAtSeeker, opts ...Option) (*FileReader, error) {
 	return &f, err
 }
 
-func (f *FileReader) readFooter() error {
-	var err error
+func (f *FileReader) readFooter() (err error) {
+	defer recoverDecode(&err)
 
 	if f.footer.offset <= int64(len(Magic)*2+4) {
 		return xerrors.Errorf("arrow/ipc: file too small (size=%d)", f.footer.offset)
@@ -112,6 +123,9 @@ func (f *FileReader) readFooter() error {
 	if size <= 0 || size+int64(len(Magic)*2+4) > f.footer.offset {
 		return errInconsistentFileMetadata
 	}
+	if err := f.limits.checkMetadata(size); err != nil {
+		return err
+	}
 
 	buf = make([]byte, size)
 	n, err = f.r.ReadAt(buf, f.footer.offset-size-eof)
@@ -122,14 +136,52 @@ func (f *FileReader) readFooter() error {
 		return xerrors.Errorf("arrow/ipc: could not read %d bytes from footer data", len(buf))
 	}
 
+	footer := flatbuf.GetRootAsFooter(buf, 0)
+	// read the fields of the footer used afterwards without checks.
+	footer.Version()
+	if err := checkBlocksFB(footer, kFooterDictionaries, "dictionary"); err != nil {
+		return err
+	}
+	if err := checkBlocksFB(footer, kFooterRecordBatches, "record batch"); err != nil {
+		return err
+	}
+
 	f.footer.buffer = memory.NewBufferBytes(buf)
-	f.footer.data = flatbuf.GetRootAsFooter(buf, 0)
+	f.footer.data = footer
 	return err
 }
 
-func (f *FileReader) readSchema() error {
-	var err error
-	f.fields, err = dictTypesFromFB(f.footer.data.Schema(nil))
+const (
+	kFooterDictionaries  = 8  // vtable offset of Footer.dictionaries
+	kFooterRecordBatches = 10 // vtable offset of Footer.recordBatches
+	kBlockSize           = 24 // size of the flatbuf.Block struct
+)
+
+// checkBlocksFB checks that the vector of blocks of footer at the vtable
+// offset slot, whose length is read from the file, lies in the footer.
+func checkBlocksFB(footer *flatbuf.Footer, slot flatbuffers.VOffsetT, kind string) error {
+	tab := footer.Table()
+	o := flatbuffers.UOffsetT(tab.Offset(slot))
+	if o == 0 {
+		return nil
+	}
+	var (
+		pos = int64(tab.Vector(o))
+		n   = int64(tab.VectorLen(o))
+	)
+	if pos+n*kBlockSize > int64(len(tab.Bytes)) {
+		return xerrors.Errorf("arrow/ipc: invalid number of %s blocks %d for a footer of %d bytes", kind, n, len(tab.Bytes))
+	}
+	return nil
+}
+
+func (f *FileReader) readSchema() (err error) {
+	defer recoverDecode(&err)
+
+	if err := checkNestingFB(f.footer.data.Schema(nil), f.limits.nestingDepth); err != nil {
+		return err
+	}
+	f.fields, err = dictTypesFromFB(f.footer.data.Schema(nil), f.exts)
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could not load dictionary types from file: %w", err)
 	}
@@ -139,13 +191,8 @@ func (f *FileReader) readSchema() error {
 		if err != nil {
 			return xerrors.Errorf("arrow/ipc: could read dictionary[%d]: %w", i, err)
 		}
-		switch {
-		case !bitutil.IsMultipleOf8(blk.Offset):
-			return xerrors.Errorf("arrow/ipc: invalid file offset=%d for dictionary %d", blk.Offset, i)
-		case !bitutil.IsMultipleOf8(int64(blk.Meta)):
-			return xerrors.Errorf("arrow/ipc: invalid file metadata=%d position for dictionary %d", blk.Meta, i)
-		case !bitutil.IsMultipleOf8(blk.Body):
-			return xerrors.Errorf("arrow/ipc: invalid file body=%d position for dictionary %d", blk.Body, i)
+		if err := f.checkBlock(blk, "dictionary", i); err != nil {
+			return err
 		}
 
 		msg, err := blk.NewMessage()
@@ -153,20 +200,23 @@ func (f *FileReader) readSchema() error {
 			return err
 		}
 
-		id, dict, err := readDictionary(msg.meta, f.fields, f.r)
+		id, dict, isDelta, err := readDictionary(msg.meta, f.fields, bytes.NewReader(msg.body.Bytes()), nil, f.limits, f.workers)
 		msg.Release()
 		if err != nil {
 			return xerrors.Errorf("arrow/ipc: could not read dictionary %d from file: %w", i, err)
 		}
-		f.memo.Add(id, dict)
-		dict.Release() // memo.Add increases ref-count of dict.
+		err = updateDictionary(&f.memo, id, dict, isDelta, f.mem)
+		dict.Release() // the memo retains the dictionaries it holds.
+		if err != nil {
+			return xerrors.Errorf("arrow/ipc: could not read dictionary %d from file: %w", i, err)
+		}
 	}
 
 	schema := f.footer.data.Schema(nil)
 	if schema == nil {
 		return xerrors.Errorf("arrow/ipc: could not load schema from flatbuffer data")
 	}
-	f.schema, err = schemaFromFB(schema, &f.memo)
+	f.schema, err = schemaFromFB(schema, &f.memo, f.exts)
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could not read schema: %w", err)
 	}
@@ -188,6 +238,27 @@ func (f *FileReader) block(i int) (fileBlock, error) {
 	}, nil
 }
 
+// checkBlock checks the alignment of blk, the i-th block of the kind of
+// message, and the limits of its message.
+func (f *FileReader) checkBlock(blk fileBlock, kind string, i int) error {
+	switch {
+	case !bitutil.IsMultipleOf8(blk.Offset):
+		return xerrors.Errorf("arrow/ipc: invalid file offset=%d for %s %d", blk.Offset, kind, i)
+	case !bitutil.IsMultipleOf8(int64(blk.Meta)):
+		return xerrors.Errorf("arrow/ipc: invalid file metadata=%d position for %s %d", blk.Meta, kind, i)
+	case !bitutil.IsMultipleOf8(blk.Body):
+		return xerrors.Errorf("arrow/ipc: invalid file body=%d position for %s %d", blk.Body, kind, i)
+	case blk.Meta < 4 || blk.Body < 0:
+		return xerrors.Errorf("arrow/ipc: invalid file block (metadata=%d, body=%d) for %s %d", blk.Meta, blk.Body, kind, i)
+	case blk.Offset < 0 || blk.Offset > f.footer.offset-int64(blk.Meta)-blk.Body:
+		return xerrors.Errorf("arrow/ipc: file block [%d, %d) for %s %d out of the file of %d bytes", blk.Offset, blk.Offset+int64(blk.Meta)+blk.Body, kind, i, f.footer.offset)
+	}
+	if err := f.limits.checkMetadata(int64(blk.Meta)); err != nil {
+		return err
+	}
+	return f.limits.checkBody(blk.Body)
+}
+
 func (f *FileReader) dict(i int) (fileBlock, error) {
 	var blk flatbuf.Block
 	if !f.footer.data.Dictionaries(&blk, i) {
@@ -223,6 +294,9 @@ func (f *FileReader) Version() MetadataVersion {
 
 // Close cleans up resources used by the File.
 // Close does not close the underlying reader.
+//
+// For mapped files, Close returns an error if records read from the file
+// are still retained, the mapping being released with the last of them.
 func (f *FileReader) Close() error {
 	if f.footer.data != nil {
 		f.footer.data = nil
@@ -237,6 +311,11 @@ func (f *FileReader) Close() error {
 		f.record.Release()
 		f.record = nil
 	}
+	f.memo.delete()
+
+	if f.mapping != nil {
+		return f.mapping.close()
+	}
 	return nil
 }
 
@@ -244,24 +323,56 @@ func (f *FileReader) Close() error {
 // The returned value is valid until the next call to Record.
 // Users need to call Retain on that Record to keep it valid for longer.
 func (f *FileReader) Record(i int) (array.Record, error) {
-	if i < 0 || i > f.NumRecords() {
+	if i < 0 || i >= f.NumRecords() {
 		panic("arrow/ipc: record index out of bounds")
 	}
 
+	rec, err := f.RecordAt(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.record != nil {
+		f.record.Release()
+	}
+	f.record = rec
+	return f.record, nil
+}
+
+// RecordAt returns the i-th record from the file, reading only the
+// buffers of that record through the block offsets of the file footer.
+//
+// Unlike Record, RecordAt may be called concurrently from multiple
+// goroutines: each call returns a new record, owned by the caller, which
+// must call Release on it.
+func (f *FileReader) RecordAt(i int) (rec array.Record, err error) {
+	defer recoverDecode(&err)
+
+	if i < 0 || i >= f.NumRecords() {
+		return nil, xerrors.Errorf("arrow/ipc: record index %d out of bounds [0, %d)", i, f.NumRecords())
+	}
+
 	blk, err := f.block(i)
 	if err != nil {
 		return nil, err
 	}
-	switch {
-	case !bitutil.IsMultipleOf8(blk.Offset):
-		return nil, xerrors.Errorf("arrow/ipc: invalid file offset=%d for record %d", blk.Offset, i)
-	case !bitutil.IsMultipleOf8(int64(blk.Meta)):
-		return nil, xerrors.Errorf("arrow/ipc: invalid file metadata=%d position for record %d", blk.Meta, i)
-	case !bitutil.IsMultipleOf8(blk.Body):
-		return nil, xerrors.Errorf("arrow/ipc: invalid file body=%d position for record %d", blk.Body, i)
+	if err := f.checkBlock(blk, "record", i); err != nil {
+		return nil, err
 	}
 
-	msg, err := blk.NewMessage()
+	var (
+		msg  *Message
+		body ReadAtSeeker
+	)
+	switch f.mapping {
+	case nil:
+		msg, err = blk.NewMessage()
+		if err == nil {
+			body = bytes.NewReader(msg.body.Bytes())
+		}
+	default:
+		msg, body, err = blk.mappedMessage(f.mapping)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -270,13 +381,13 @@ func (f *FileReader) Record(i int) (array.Record, error) {
 	if msg.Type() != MessageRecordBatch {
 		return nil, xerrors.Errorf("arrow/ipc: message %d is not a Record", i)
 	}
-
-	if f.record != nil {
-		f.record.Release()
+	// all the dictionaries of a file are loaded with its schema, so that
+	// records may be read in any order without further updates of the memo.
+	if id, ok := f.memo.missingDictionary(); ok {
+		return nil, xerrors.Errorf("arrow/ipc: missing dictionary with ID=%d", id)
 	}
 
-	f.record = newRecord(f.schema, msg.meta, bytes.NewReader(msg.body.Bytes()))
-	return f.record, nil
+	return newRecord(f.schema, &f.memo, msg.meta, body, nil, f.limits, f.workers)
 }
 
 // Read reads the current record from the underlying stream and an error, if any.
@@ -298,33 +409,149 @@ func (f *FileReader) ReadAt(i int64) (array.Record, error) {
 	return f.Record(int(i))
 }
 
-func newRecord(schema *arrow.Schema, meta *memory.Buffer, body ReadAtSeeker) array.Record {
+// newRecord decodes the record batch of meta and body, with the
+// dictionaries of memo. The buffers of the record are allocated from pool
+// when not nil, instead of the Go heap.
+func newRecord(schema *arrow.Schema, memo *dictMemo, meta *memory.Buffer, body ReadAtSeeker, pool *bufferPool, lim limits, workers int) (rec array.Record, err error) {
 	var (
 		msg = flatbuf.GetRootAsMessage(meta.Bytes(), 0)
 		md  flatbuf.RecordBatch
 	)
-	initFB(&md, msg.Header)
+	ctx, err := newLoaderContext(msg, &md, body, pool, lim, workers)
+	if err != nil {
+		return nil, err
+	}
+	ctx.memo = memo
+
+	cols := make([]array.Interface, 0, len(schema.Fields()))
+	defer func() {
+		if pool != nil {
+			// the record holds the arrays, and so the buffers, so that
+			// releasing it hands their memory back to the pool.
+			pool.releaseLoaded()
+		}
+		if body, ok := body.(*mappedBody); ok {
+			body.releaseLoaded()
+		}
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	defer recoverDecode(&err)
+
 	rows := md.Length()
+	if rows < 0 {
+		return nil, xerrors.Errorf("arrow/ipc: invalid record batch length %d", rows)
+	}
+	for _, field := range schema.Fields() {
+		cols = append(cols, ctx.loadArray(field.Type))
+	}
+	return array.NewRecord(schema, cols, rows), nil
+}
+
+// newLoaderContext returns the context loading the arrays of the record
+// batch md, the header of msg, from body, after checking its limits.
+// Compressed buffers are decompressed by workers goroutines when more
+// than one.
+func newLoaderContext(msg *flatbuf.Message, md *flatbuf.RecordBatch, body ReadAtSeeker, pool *bufferPool, lim limits, workers int) (ctx *arrayLoaderContext, err error) {
+	defer recoverDecode(&err)
+
+	var tbl flatbuffers.Table
+	if !msg.Header(&tbl) {
+		return nil, xerrors.Errorf("arrow/ipc: message without header")
+	}
+	switch MessageType(msg.HeaderType()) {
+	case MessageRecordBatch:
+		md.Init(tbl.Bytes, tbl.Pos)
+	case MessageDictionaryBatch:
+		var dict flatbuf.DictionaryBatch
+		dict.Init(tbl.Bytes, tbl.Pos)
+		if dict.Data(md) == nil {
+			return nil, xerrors.Errorf("arrow/ipc: missing record batch of dictionary with ID=%d", dict.Id())
+		}
+	default:
+		return nil, xerrors.Errorf("arrow/ipc: invalid message type %v for a record batch", MessageType(msg.HeaderType()))
+	}
 
-	ctx := &arrayLoaderContext{
-		src: ipcSource{
-			meta: &md,
-			r:    body,
-		},
-		max: kMaxNestingDepth,
+	if n := md.BuffersLength(); n > lim.buffers {
+		return nil, &LimitError{Limit: "number of buffers", Value: int64(n), Max: int64(lim.buffers)}
+	}
+	if _, err := lenFB(md.BuffersLength(), 16, md.Table(), "buffers"); err != nil {
+		return nil, err
+	}
+	if _, err := lenFB(md.NodesLength(), 16, md.Table(), "field nodes"); err != nil {
+		return nil, err
+	}
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: could not retrieve message body size: %w", err)
 	}
 
-	cols := make([]array.Interface, len(schema.Fields()))
-	for i, field := range schema.Fields() {
-		cols[i] = ctx.loadArray(field.Type)
+	codec := CompressionNone
+	if c := md.Compression(nil); c != nil {
+		codec = CompressionType(c.Codec())
 	}
 
-	return array.NewRecord(schema, cols, rows)
+	ctx = &arrayLoaderContext{
+		src: ipcSource{
+			meta:  md,
+			r:     body,
+			size:  size,
+			codec: codec,
+			pool:  pool,
+			max:   lim.bodySize,
+		},
+		max: lim.nestingDepth,
+		lim: lim,
+	}
+	if codec != CompressionNone && workers > 1 {
+		if err := ctx.src.decompressAll(workers); err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
 }
 
 type ipcSource struct {
-	meta *flatbuf.RecordBatch
-	r    ReadAtSeeker
+	meta  *flatbuf.RecordBatch
+	r     ReadAtSeeker
+	size  int64 // size of the body read from r
+	codec CompressionType
+	pool  *bufferPool
+	max   int64 // maximum size of decompressed buffers
+
+	// decompressed are the buffers of the body, when decompressed ahead
+	// of loading the arrays, see decompressAll.
+	decompressed [][]byte
+}
+
+// decompressAll decompresses the buffers of the body with workers
+// goroutines.
+func (src *ipcSource) decompressAll(workers int) error {
+	src.decompressed = make([][]byte, src.meta.BuffersLength())
+	return parallelize(len(src.decompressed), workers, func(_, i int) error {
+		var buf flatbuf.Buffer
+		if !src.meta.Buffers(&buf, i) {
+			return xerrors.Errorf("arrow/ipc: could not read buffer %d metadata", i)
+		}
+		off, n := buf.Offset(), buf.Length()
+		if off < 0 || n < 0 || n > src.size-off {
+			return xerrors.Errorf("arrow/ipc: buffer [%d, %d) out of the message body of %d bytes", off, off+n, src.size)
+		}
+		if n == 0 {
+			return nil
+		}
+		raw := make([]byte, n)
+		if _, err := src.r.ReadAt(raw, off); err != nil {
+			return err
+		}
+		raw, err := decompressBuffer(src.codec, raw, src.max)
+		if err != nil {
+			return err
+		}
+		src.decompressed[i] = raw
+		return nil
+	})
 }
 
 func (src *ipcSource) buffer(i int) *memory.Buffer {
@@ -335,6 +562,24 @@ func (src *ipcSource) buffer(i int) *memory.Buffer {
 	if buf.Length() == 0 {
 		return memory.NewBufferBytes(nil)
 	}
+	if off, n := buf.Offset(), buf.Length(); off < 0 || n < 0 || n > src.size-off {
+		panic(xerrors.Errorf("arrow/ipc: buffer [%d, %d) out of the message body of %d bytes", off, off+n, src.size))
+	}
+	if src.decompressed != nil {
+		raw := src.decompressed[i]
+		if src.pool == nil {
+			return memory.NewBufferBytes(raw)
+		}
+		out := src.pool.buffer(len(raw))
+		copy(out.Bytes(), raw)
+		return out
+	}
+	if src.pool != nil {
+		return src.pooledBuffer(&buf)
+	}
+	if body, ok := src.r.(*mappedBody); ok && src.codec == CompressionNone {
+		return body.buffer(buf.Offset(), buf.Length())
+	}
 
 	raw := make([]byte, buf.Length())
 	_, err := src.r.ReadAt(raw, buf.Offset())
@@ -342,9 +587,40 @@ func (src *ipcSource) buffer(i int) *memory.Buffer {
 		panic(err)
 	}
 
+	if src.codec != CompressionNone {
+		raw, err = decompressBuffer(src.codec, raw, src.max)
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	return memory.NewBufferBytes(raw)
 }
 
+// pooledBuffer reads buf to memory of the pool of src. Compressed buffers
+// are read to scratch memory first, then copied once decompressed.
+func (src *ipcSource) pooledBuffer(buf *flatbuf.Buffer) *memory.Buffer {
+	if src.codec == CompressionNone {
+		out := src.pool.buffer(int(buf.Length()))
+		if _, err := src.r.ReadAt(out.Bytes(), buf.Offset()); err != nil {
+			panic(err)
+		}
+		return out
+	}
+
+	raw := src.pool.scratchBytes(int(buf.Length()))
+	if _, err := src.r.ReadAt(raw, buf.Offset()); err != nil {
+		panic(err)
+	}
+	raw, err := decompressBuffer(src.codec, raw, src.max)
+	if err != nil {
+		panic(err)
+	}
+	out := src.pool.buffer(len(raw))
+	copy(out.Bytes(), raw)
+	return out
+}
+
 func (src *ipcSource) fieldMetadata(i int) *flatbuf.FieldNode {
 	var node flatbuf.FieldNode
 	if !src.meta.Nodes(&node, i) {
@@ -358,6 +634,16 @@ type arrayLoaderContext struct {
 	ifield  int
 	ibuffer int
 	max     int
+	lim     limits
+
+	// memo holds the dictionaries of the dictionary-encoded fields, the
+	// idict-th one being loaded next.
+	memo  *dictMemo
+	idict int
+
+	// ivariadic is the index of the number of data buffers of the next
+	// field with a variable number of buffers.
+	ivariadic int
 }
 
 func (ctx *arrayLoaderContext) field() *flatbuf.FieldNode {
@@ -381,7 +667,7 @@ func (ctx *arrayLoaderContext) loadArray(dt arrow.DataType) array.Interface {
 		*arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
 		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type,
 		*arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type,
-		*arrow.Decimal128Type,
+		*arrow.Decimal128Type, *arrow.Decimal256Type,
 		*arrow.Time32Type, *arrow.Time64Type,
 		*arrow.TimestampType,
 		*arrow.Date32Type, *arrow.Date64Type,
@@ -392,6 +678,9 @@ func (ctx *arrayLoaderContext) loadArray(dt arrow.DataType) array.Interface {
 	case *arrow.BinaryType, *arrow.StringType:
 		return ctx.loadBinary(dt)
 
+	case *arrow.BinaryViewType, *arrow.StringViewType:
+		return ctx.loadBinaryView(dt)
+
 	case *arrow.FixedSizeBinaryType:
 		return ctx.loadFixedSizeBinary(dt)
 
@@ -404,6 +693,20 @@ func (ctx *arrayLoaderContext) loadArray(dt arrow.DataType) array.Interface {
 	case *arrow.StructType:
 		return ctx.loadStruct(dt)
 
+	case *arrow.RunEndEncodedType:
+		return ctx.loadRunEndEncoded(dt)
+
+	case *arrow.UnionType:
+		return ctx.loadUnion(dt)
+
+	case *arrow.DictionaryType:
+		return ctx.loadDictionary(dt)
+
+	case arrow.ExtensionType:
+		storage := ctx.loadArray(dt.StorageType())
+		defer storage.Release()
+		return array.NewExtensionArrayWithStorage(dt, storage)
+
 	default:
 		panic(xerrors.Errorf("array type %T not handled yet", dt))
 	}
@@ -427,7 +730,7 @@ func (ctx *arrayLoaderContext) loadCommon(nbufs int) (*flatbuf.FieldNode, []*mem
 
 func (ctx *arrayLoaderContext) loadChild(dt arrow.DataType) array.Interface {
 	if ctx.max == 0 {
-		panic("arrow/ipc: nested type limit reached")
+		panic(&LimitError{Limit: "nesting depth", Value: int64(ctx.lim.nestingDepth) + 1, Max: int64(ctx.lim.nestingDepth)})
 	}
 	ctx.max--
 	sub := ctx.loadArray(dt)
@@ -470,6 +773,35 @@ func (ctx *arrayLoaderContext) loadBinary(dt arrow.DataType) array.Interface {
 	return array.MakeFromData(data)
 }
 
+func (ctx *arrayLoaderContext) loadBinaryView(dt arrow.DataType) array.Interface {
+	n := ctx.variadicCount()
+	field, buffers := ctx.loadCommon(2 + n)
+	buffers = append(buffers, ctx.buffer())
+	for i := 0; i < n; i++ {
+		buffers = append(buffers, ctx.buffer())
+	}
+
+	data := array.NewData(dt, int(field.Length()), buffers, nil, int(field.NullCount()), 0)
+	defer data.Release()
+
+	return array.MakeFromData(data)
+}
+
+// variadicCount returns the number of data buffers of the next field with
+// a variable number of buffers.
+func (ctx *arrayLoaderContext) variadicCount() int {
+	meta := ctx.src.meta
+	if ctx.ivariadic >= meta.VariadicBufferCountsLength() {
+		panic(xerrors.Errorf("arrow/ipc: missing variadic buffer count of field %d", ctx.ifield))
+	}
+	n := meta.VariadicBufferCounts(ctx.ivariadic)
+	ctx.ivariadic++
+	if n < 0 || n > int64(meta.BuffersLength()-ctx.ibuffer) {
+		panic(xerrors.Errorf("arrow/ipc: invalid variadic buffer count %d of field %d", n, ctx.ifield))
+	}
+	return int(n)
+}
+
 func (ctx *arrayLoaderContext) loadFixedSizeBinary(dt *arrow.FixedSizeBinaryType) array.Interface {
 	field, buffers := ctx.loadCommon(2)
 	buffers = append(buffers, ctx.buffer())
@@ -526,45 +858,96 @@ func (ctx *arrayLoaderContext) loadStruct(dt *arrow.StructType) array.Interface
 	return array.NewStructData(data)
 }
 
-func readDictionary(meta *memory.Buffer, types dictTypeMap, r ReadAtSeeker) (int64, array.Interface, error) {
-	//	msg := flatbuf.GetRootAsMessage(meta.Bytes(), 0)
-	//	var dictBatch flatbuf.DictionaryBatch
-	//	initFB(&dictBatch, msg.Header)
-	//
-	//	id := dictBatch.Id()
-	//	v, ok := types[id]
-	//	if !ok {
-	//		return id, nil, errors.Errorf("arrow/ipc: no type metadata for dictionary with ID=%d", id)
-	//	}
-	//
-	//	fields := []arrow.Field{v}
-	//
-	//	// we need a schema for the record batch.
-	//	schema := arrow.NewSchema(fields, nil)
-	//
-	//	// the dictionary is embedded in a record batch with a single column.
-	//	recBatch := dictBatch.Data(nil)
-	//
-	//	var (
-	//		batchMeta *memory.Buffer
-	//		body      *memory.Buffer
-	//	)
-	//
-	//
-	//	ctx := &arrayLoaderContext{
-	//		src: ipcSource{
-	//			meta: &md,
-	//			r:    bytes.NewReader(body.Bytes()),
-	//		},
-	//		max: kMaxNestingDepth,
-	//	}
-	//
-	//	cols := make([]array.Interface, len(schema.Fields()))
-	//	for i, field := range schema.Fields() {
-	//		cols[i] = ctx.loadArray(field.Type)
-	//	}
-	//
-	//	batch := array.NewRecord(schema, cols, rows)
-
-	panic("not implemented")
+func (ctx *arrayLoaderContext) loadUnion(dt *arrow.UnionType) array.Interface {
+	// unions have no validity bitmap, but metadata V4 writers send an
+	// empty one.
+	nbufs := 2
+	if dt.Mode() == arrow.DenseMode {
+		nbufs = 3
+	}
+	field, buffers := ctx.loadCommon(nbufs)
+	if buffers[0] != nil {
+		buffers[0].Release()
+		buffers[0] = nil
+	}
+	for i := 1; i < nbufs; i++ {
+		buffers = append(buffers, ctx.buffer())
+	}
+
+	arrs := make([]array.Interface, len(dt.Fields()))
+	subs := make([]*array.Data, len(dt.Fields()))
+	for i, f := range dt.Fields() {
+		arrs[i] = ctx.loadChild(f.Type)
+		subs[i] = arrs[i].Data()
+	}
+	defer func() {
+		for i := range arrs {
+			arrs[i].Release()
+		}
+	}()
+
+	data := array.NewData(dt, int(field.Length()), buffers, subs, 0, 0)
+	defer data.Release()
+
+	return array.MakeFromData(data)
+}
+
+func (ctx *arrayLoaderContext) loadRunEndEncoded(dt *arrow.RunEndEncodedType) array.Interface {
+	// run-end encoded arrays have no buffers, their nulls are the ones of
+	// their values.
+	field := ctx.field()
+
+	ends := ctx.loadChild(dt.RunEnds())
+	defer ends.Release()
+	values := ctx.loadChild(dt.Encoded())
+	defer values.Release()
+
+	data := array.NewData(dt, int(field.Length()), []*memory.Buffer{nil}, []*array.Data{ends.Data(), values.Data()}, 0, 0)
+	defer data.Release()
+
+	return array.NewRunEndEncodedData(data)
+}
+
+func (ctx *arrayLoaderContext) loadDictionary(dt *arrow.DictionaryType) array.Interface {
+	indices := ctx.loadPrimitive(dt.IndexType)
+	defer indices.Release()
+
+	id := ctx.memo.fields[ctx.idict]
+	ctx.idict++
+	dict, ok := ctx.memo.Dict(id)
+	if !ok {
+		panic(xerrors.Errorf("arrow/ipc: missing dictionary with ID=%d", id))
+	}
+	return array.NewDictionaryArray(dt, indices, dict)
+}
+
+// readDictionary decodes the dictionary batch of meta and body, returning
+// the ID of the dictionary, its values, and whether they are a delta to
+// append to the values of the dictionary. The buffers of the values are
+// allocated from pool when not nil, instead of the Go heap.
+func readDictionary(meta *memory.Buffer, types dictTypeMap, body ReadAtSeeker, pool *bufferPool, lim limits, workers int) (id int64, values array.Interface, isDelta bool, err error) {
+	var (
+		msg  = flatbuf.GetRootAsMessage(meta.Bytes(), 0)
+		dict flatbuf.DictionaryBatch
+		md   flatbuf.RecordBatch
+	)
+	// the dictionary is embedded in a record batch with a single column.
+	ctx, err := newLoaderContext(msg, &md, body, pool, lim, workers)
+	if err != nil {
+		return 0, nil, false, err
+	}
+	initFB(&dict, msg.Header)
+
+	id = dict.Id()
+	field, ok := types[id]
+	if !ok {
+		return id, nil, false, xerrors.Errorf("arrow/ipc: no type metadata for dictionary with ID=%d", id)
+	}
+
+	if pool != nil {
+		defer pool.releaseLoaded()
+	}
+	defer recoverDecode(&err)
+	values = ctx.loadArray(field.Type)
+	return id, values, dict.IsDelta(), nil
 }
@@ -17,12 +17,23 @@
 package ipc_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"unsafe"
 
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/apache/arrow/go/arrow/memory"
+	flatbuffers "github.com/google/flatbuffers/go"
 )
 
 func TestFile(t *testing.T) {
@@ -48,3 +59,215 @@ func TestFile(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkFileRecordAt(b *testing.B) {
+	const (
+		nrecs = 1000
+		nrows = 100
+		index = 900
+	)
+
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	bldr := array.NewRecordBuilder(mem, schema)
+	defer bldr.Release()
+
+	f, err := ioutil.TempFile("", "go-arrow-file-")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w, err := ipc.NewFileWriter(f, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < nrecs; i++ {
+		for j := 0; j < nrows; j++ {
+			bldr.Field(0).(*array.Int64Builder).Append(int64(i*nrows + j))
+		}
+		rec := bldr.NewRecord()
+		err := w.Write(rec)
+		rec.Release()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		b.Fatal(err)
+	}
+
+	r, err := ipc.NewFileReader(f, ipc.WithAllocator(mem))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer r.Close()
+
+	b.Run("RecordAt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rec, err := r.RecordAt(index)
+			if err != nil {
+				b.Fatal(err)
+			}
+			rec.Release()
+		}
+	})
+
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			r, err := ipc.NewFileReader(f, ipc.WithAllocator(mem))
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j := 0; j <= index; j++ {
+				_, err := r.Read()
+				if err != nil && err != io.EOF {
+					b.Fatal(err)
+				}
+			}
+			r.Close()
+		}
+	})
+}
+
+func TestFileCorrupted(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "go-arrow-file-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, name := range arrdata.RecordNames {
+		t.Run(name, func(t *testing.T) {
+			recs := arrdata.Records[name]
+			f, err := ioutil.TempFile(tempDir, "go-arrow-file-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			arrdata.WriteFile(t, f, memory.NewGoAllocator(), recs[0].Schema(), recs)
+
+			buf, err := ioutil.ReadFile(f.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkCorrupted(t, buf, func(buf []byte, opts ...ipc.Option) error {
+				r, err := ipc.NewFileReader(bytes.NewReader(buf), opts...)
+				if err != nil {
+					return err
+				}
+				defer r.Close()
+
+				for i := 0; i < r.NumRecords(); i++ {
+					rec, err := r.RecordAt(i)
+					if err != nil {
+						return err
+					}
+					rec.Release()
+				}
+				return nil
+			})
+		})
+	}
+}
+
+func TestFileFooterBlocks(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+	fname := writeTempFile(t, recs)
+	defer os.Remove(fname)
+
+	raw, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		slot flatbuffers.VOffsetT
+	}{
+		{"dictionaries", 8},
+		{"record batches", 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := append([]byte(nil), raw...)
+
+			// declare more blocks than the footer holds.
+			var (
+				end    = len(buf) - len(ipc.Magic) - 4
+				size   = int(binary.LittleEndian.Uint32(buf[end:]))
+				footer = buf[end-size : end]
+				tab    = flatbuf.GetRootAsFooter(footer, 0).Table()
+				o      = flatbuffers.UOffsetT(tab.Offset(tc.slot))
+			)
+			binary.LittleEndian.PutUint32(footer[tab.Vector(o)-4:], 0xffffff00)
+
+			r, err := ipc.NewFileReader(bytes.NewReader(buf))
+			if err == nil {
+				defer r.Close()
+				t.Fatalf("expected an error, got %d records and %d dictionaries", r.NumRecords(), r.NumDictionaries())
+			}
+			if !strings.Contains(err.Error(), "invalid number of") {
+				t.Fatalf("invalid error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileAlignment(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+	for _, legacy := range []bool{false, true} {
+		for _, align := range []int{8, 64} {
+			t.Run(fmt.Sprintf("legacy=%v/align=%d", legacy, align), func(t *testing.T) {
+				fname := writeTempFile(t, recs, ipc.WithLegacyFormat(legacy), ipc.WithBufferAlignment(align))
+				defer os.Remove(fname)
+
+				raw, err := ioutil.ReadFile(fname)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// the memory of the Go allocator is 64-byte aligned.
+				buf := memory.NewGoAllocator().Allocate(len(raw))
+				copy(buf, raw)
+
+				r, err := ipc.NewMappedFileReader(buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := 0; i < r.NumRecords(); i++ {
+					rec, err := r.RecordAt(i)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !array.RecordEqual(rec, recs[i]) {
+						t.Fatalf("records[%d] differ", i)
+					}
+					for _, col := range rec.Columns() {
+						for _, b := range col.Data().Buffers() {
+							if b == nil || b.Len() == 0 {
+								continue
+							}
+							if p := uintptr(unsafe.Pointer(&b.Bytes()[0])); p%uintptr(align) != 0 {
+								t.Fatalf("records[%d]: buffer of %v not aligned to %d bytes", i, col.DataType(), align)
+							}
+						}
+					}
+					rec.Release()
+				}
+				if err := r.Close(); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+
+	f, err := ioutil.TempFile("", "go-arrow-file-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := ipc.NewFileWriter(f, ipc.WithSchema(recs[0].Schema()), ipc.WithBufferAlignment(32)); err == nil {
+		t.Fatal("expected an error with an invalid buffer alignment")
+	}
+}
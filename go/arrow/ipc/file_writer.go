@@ -22,7 +22,6 @@ import (
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
-	"github.com/apache/arrow/go/arrow/bitutil"
 	"github.com/apache/arrow/go/arrow/internal/flatbuf"
 	"github.com/apache/arrow/go/arrow/memory"
 	"golang.org/x/xerrors"
@@ -41,6 +40,9 @@ type pwriter struct {
 	w   io.WriteSeeker
 	pos int64
 
+	legacy    bool  // see WithLegacyFormat
+	alignment int64 // see WithBufferAlignment
+
 	schema *arrow.Schema
 	dicts  []fileBlock
 	recs   []fileBlock
@@ -54,13 +56,14 @@ func (w *pwriter) Start() error {
 		return xerrors.Errorf("arrow/ipc: could not update position while in start: %w", err)
 	}
 
-	// only necessary to align to 8-byte boundary at the start of the file
+	// align the start of the messages, so that the body buffers of the
+	// file are aligned too.
 	_, err = w.Write(Magic)
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could not write magic Arrow bytes: %w", err)
 	}
 
-	err = w.align(kArrowIPCAlignment)
+	err = w.align(int32(w.alignment))
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could not align start block: %w", err)
 	}
@@ -70,7 +73,7 @@ func (w *pwriter) Start() error {
 
 func (w *pwriter) WritePayload(p Payload) error {
 	blk := fileBlock{Offset: w.pos, Meta: 0, Body: p.size}
-	n, err := writeIPCPayload(w, p)
+	n, err := writeIPCPayload(w, p, w.legacy)
 	if err != nil {
 		return err
 	}
@@ -155,8 +158,8 @@ func (w *pwriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
-func writeIPCPayload(w io.Writer, p Payload) (int, error) {
-	n, err := writeMessage(p.meta, kArrowIPCAlignment, w)
+func writeIPCPayload(w io.Writer, p Payload, legacy bool) (int, error) {
+	n, err := writeMessage(p.meta, int32(p.alignment()), legacy, w)
 	if err != nil {
 		return n, err
 	}
@@ -171,7 +174,7 @@ func writeIPCPayload(w io.Writer, p Payload) (int, error) {
 		// the buffer might be null if we are handling zero row lengths.
 		if buf != nil {
 			size = int64(buf.Len())
-			padding = bitutil.CeilByte64(size) - size
+			padding = p.padding(size)
 		}
 
 		if size > 0 {
@@ -195,10 +198,23 @@ func writeIPCPayload(w io.Writer, p Payload) (int, error) {
 // Payload is the underlying message object which is passed to the payload writer
 // for actually writing out ipc messages
 type Payload struct {
-	msg  MessageType
-	meta *memory.Buffer
-	body []*memory.Buffer
-	size int64 // length of body
+	msg   MessageType
+	meta  *memory.Buffer
+	body  []*memory.Buffer
+	size  int64 // length of body
+	align int64 // alignment of the metadata and body buffers, 8 when unset
+}
+
+func (p *Payload) alignment() int64 {
+	if p.align == 0 {
+		return kArrowIPCAlignment
+	}
+	return p.align
+}
+
+// padding returns the padding of a body buffer of size bytes.
+func (p *Payload) padding(size int64) int64 {
+	return paddedLength(size, int32(p.alignment())) - size
 }
 
 // Meta returns the buffer containing the metadata for this payload,
@@ -219,7 +235,7 @@ func (p *Payload) SerializeBody(w io.Writer) error {
 		}
 
 		size := int64(data.Len())
-		padding := bitutil.CeilByte64(size) - size
+		padding := p.padding(size)
 		if size > 0 {
 			if _, err := w.Write(data.Bytes()); err != nil {
 				return xerrors.Errorf("arrow/ipc: could not write payload message body: %w", err)
@@ -275,6 +291,12 @@ type FileWriter struct {
 	pw PayloadWriter
 
 	schema *arrow.Schema
+	codec  *bodyCodec
+
+	// memo holds the dictionaries written last, by ID.
+	memo   dictMemo
+	deltas bool
+	align  int64
 }
 
 // NewFileWriter opens an Arrow file using the provided writer w.
@@ -284,11 +306,22 @@ func NewFileWriter(w io.WriteSeeker, opts ...Option) (*FileWriter, error) {
 		err error
 	)
 
+	if err := cfg.checkAlignment(); err != nil {
+		return nil, err
+	}
+
 	f := FileWriter{
 		w:      w,
-		pw:     &pwriter{w: w, schema: cfg.schema, pos: -1},
+		pw:     &pwriter{w: w, schema: cfg.schema, pos: -1, legacy: cfg.legacy, alignment: cfg.alignment},
 		mem:    cfg.alloc,
 		schema: cfg.schema,
+		deltas: cfg.dictDeltas,
+		align:  cfg.alignment,
+	}
+
+	f.codec, err = newBodyCodec(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	pos, err := f.w.Seek(0, io.SeekCurrent)
@@ -309,6 +342,7 @@ func (f *FileWriter) Close() error {
 	if f.footer.written {
 		return nil
 	}
+	f.memo.delete()
 
 	err = f.pw.Close()
 	if err != nil {
@@ -329,10 +363,22 @@ func (f *FileWriter) Write(rec array.Record) error {
 		return xerrors.Errorf("arrow/ipc: could not write header: %w", err)
 	}
 
+	// files may hold delta dictionaries, but not replacements.
+	ps, err := dictionaryPayloads(&f.memo, rec, f.mem, f.codec, f.align, f.deltas, false)
+	if err != nil {
+		return xerrors.Errorf("arrow/ipc: could not encode dictionaries: %w", err)
+	}
+	defer ps.Release()
+	for _, data := range ps {
+		if err := f.pw.WritePayload(data); err != nil {
+			return err
+		}
+	}
+
 	const allow64b = true
 	var (
 		data = Payload{msg: MessageRecordBatch}
-		enc  = newRecordEncoder(f.mem, 0, kMaxNestingDepth, allow64b)
+		enc  = newRecordEncoder(f.mem, 0, kMaxNestingDepth, allow64b, f.codec, f.align)
 	)
 	defer data.Release()
 
@@ -358,10 +404,11 @@ func (f *FileWriter) start() error {
 	}
 
 	// write out schema payloads
-	ps := payloadsFromSchema(f.schema, f.mem, nil)
+	ps := payloadsFromSchema(f.schema, f.mem, &f.memo)
 	defer ps.Release()
 
 	for _, data := range ps {
+		data.align = f.align
 		err = f.pw.WritePayload(data)
 		if err != nil {
 			return err
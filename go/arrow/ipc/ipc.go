@@ -17,11 +17,13 @@
 package ipc // import "github.com/apache/arrow/go/arrow/ipc"
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/arrio"
 	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
 )
 
 const (
@@ -31,6 +33,11 @@ const (
 	errMaxRecursion             = errString("arrow/ipc: max recursion depth reached")
 	errBigArray                 = errString("arrow/ipc: array larger than 2^31-1 in length")
 
+	kDefaultMaxMetadataSize = 64 << 20 // default limits of the messages read, see WithMaxMetadataSize
+	kDefaultMaxBodySize     = 256 << 20
+	kDefaultMaxBuffers      = 1 << 20
+	kReadChunkSize          = 64 << 10 // initial size of the memory messages are read to, see messageReader.read
+
 	kArrowAlignment    = 64 // buffers are padded to 64b boundaries (for SIMD)
 	kTensorAlignment   = 64 // tensors are padded to 64b boundaries
 	kArrowIPCAlignment = 8  // align on 8b boundaries in IPC
@@ -39,6 +46,7 @@ const (
 var (
 	paddingBytes  [kArrowAlignment]byte
 	kEOS                 = [8]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0} // end of stream message
+	kLegacyEOS           = [4]byte{0, 0, 0, 0}                         // end of stream message of the legacy format
 	kIPCContToken uint32 = 0xFFFFFFFF                                  // 32b continuation indicator for FlatBuffers 8b alignment
 )
 
@@ -53,6 +61,57 @@ func (s errString) Error() string {
 	return string(s)
 }
 
+// LimitError is the error returned by readers when a message exceeds one of
+// their limits, see WithMaxMetadataSize, WithMaxBodySize, WithMaxNestingDepth
+// and WithMaxBuffers.
+type LimitError struct {
+	Limit string // the limit exceeded, e.g. "body size"
+	Value int64  // the value declared by the message
+	Max   int64  // the maximum allowed
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("arrow/ipc: message %s %d exceeds the limit of %d", e.Limit, e.Value, e.Max)
+}
+
+// limits are the limits of the messages read, protecting readers from
+// untrusted input.
+type limits struct {
+	metadataSize int64
+	bodySize     int64
+	nestingDepth int
+	buffers      int
+}
+
+func (lim limits) checkMetadata(n int64) error {
+	if n > lim.metadataSize {
+		return &LimitError{Limit: "metadata size", Value: n, Max: lim.metadataSize}
+	}
+	return nil
+}
+
+func (lim limits) checkBody(n int64) error {
+	if n > lim.bodySize {
+		return &LimitError{Limit: "body size", Value: n, Max: lim.bodySize}
+	}
+	return nil
+}
+
+// recoverDecode turns the panics of decoding malformed messages into the
+// error *err. The lengths read from messages are checked before allocating
+// memory for them, see lenFB: recoverDecode is the last resort for the
+// accesses to flatbuffers left unchecked.
+// It must be deferred.
+func recoverDecode(err *error) {
+	switch e := recover().(type) {
+	case nil:
+	case error:
+		*err = xerrors.Errorf("arrow/ipc: invalid message: %w", e)
+	default:
+		*err = xerrors.Errorf("arrow/ipc: invalid message: %v", e)
+	}
+}
+
 type ReadAtSeeker interface {
 	io.Reader
 	io.Seeker
@@ -65,12 +124,34 @@ type config struct {
 	footer struct {
 		offset int64
 	}
+	codec struct {
+		typ         CompressionType
+		level       int
+		minSize     int64
+		concurrency int
+	}
+	maxMessageSize int64
+	reuseBuffers   bool
+	dictDeltas     bool
+	limits         limits
+	legacy         bool
+	alignment      int64
+	exts           *arrow.ExtensionTypeRegistry
 }
 
 func newConfig(opts ...Option) *config {
 	cfg := &config{
 		alloc: memory.NewGoAllocator(),
 	}
+	cfg.codec.typ = CompressionNone
+	cfg.codec.concurrency = 1
+	cfg.alignment = kArrowIPCAlignment
+	cfg.limits = limits{
+		metadataSize: kDefaultMaxMetadataSize,
+		bodySize:     kDefaultMaxBodySize,
+		nestingDepth: kMaxNestingDepth,
+		buffers:      kDefaultMaxBuffers,
+	}
 
 	for _, opt := range opts {
 		opt(cfg)
@@ -104,6 +185,146 @@ func WithSchema(schema *arrow.Schema) Option {
 	}
 }
 
+// WithCompression specifies the codec compressing the body buffers of the
+// record batches written, and its level, 0 being the default level of the
+// codec. CompressionNone, the default, disables compression. Readers
+// decompress record batches regardless of this option.
+func WithCompression(codec CompressionType, level int) Option {
+	return func(cfg *config) {
+		cfg.codec.typ = codec
+		cfg.codec.level = level
+	}
+}
+
+// WithCompressionConcurrency specifies the number of goroutines compressing
+// the body buffers of a record batch written, or decompressing those of a
+// record batch read, 1 by default.
+func WithCompressionConcurrency(n int) Option {
+	return func(cfg *config) {
+		cfg.codec.concurrency = n
+	}
+}
+
+// WithMinCompressSize specifies the size, in bytes, under which the body
+// buffers of compressed record batches are written uncompressed, as
+// compressing them would not pay off.
+func WithMinCompressSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.codec.minSize = n
+	}
+}
+
+// WithMaxMessageSize specifies the maximum size, in bytes, of the record
+// batch messages written to a stream, metadata included. Record batches
+// whose message would exceed it are split into zero-copy slices of fewer
+// rows. A value of 0, the default, disables splitting.
+func WithMaxMessageSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxMessageSize = n
+	}
+}
+
+// WithBufferReuse specifies that the records read from a stream are not
+// retained past the next call to Next, so that the memory of their buffers,
+// obtained from the allocator of the reader, is reused for the next
+// records, as is the memory the messages of the stream are read to.
+// Records retained keep their memory until released.
+func WithBufferReuse() Option {
+	return func(cfg *config) {
+		cfg.reuseBuffers = true
+	}
+}
+
+// WithDictionaryDeltas specifies whether the dictionaries of the records
+// written, which are only appended to between records, are written as
+// delta dictionary batches carrying the values appended. Otherwise, the
+// default, a dictionary that changes is written again in full, replacing
+// the previous one. Dictionaries changing otherwise are always written in
+// full, which files do not allow.
+func WithDictionaryDeltas(v bool) Option {
+	return func(cfg *config) {
+		cfg.dictDeltas = v
+	}
+}
+
+// WithLegacyFormat specifies whether the messages written use the legacy
+// envelope of the IPC format, prior to Arrow 0.15, for older readers: the
+// metadata of a message is then prefixed with its size alone, without the
+// continuation marker, and a stream ends with 4 zero bytes instead of 8.
+// Readers accept both envelopes regardless of this option.
+func WithLegacyFormat(v bool) Option {
+	return func(cfg *config) {
+		cfg.legacy = v
+	}
+}
+
+// WithBufferAlignment specifies the alignment, in bytes, of the body
+// buffers and of the metadata of the messages written, either 8, the
+// default, or 64, which some accelerators require. Writers fail on other
+// values.
+func WithBufferAlignment(n int) Option {
+	return func(cfg *config) {
+		cfg.alignment = int64(n)
+	}
+}
+
+// WithExtensionTypes specifies the registry of the extension types
+// reconstructed by readers, in addition to the extension types registered
+// with arrow.RegisterExtensionType, which reg takes precedence over.
+// Fields of other extension types are read with their storage type,
+// keeping the extension metadata.
+func WithExtensionTypes(reg *arrow.ExtensionTypeRegistry) Option {
+	return func(cfg *config) {
+		cfg.exts = reg
+	}
+}
+
+func (cfg *config) checkAlignment() error {
+	switch cfg.alignment {
+	case kArrowIPCAlignment, kArrowAlignment:
+		return nil
+	default:
+		return xerrors.Errorf("arrow/ipc: invalid buffer alignment %d (want %d or %d)", cfg.alignment, kArrowIPCAlignment, kArrowAlignment)
+	}
+}
+
+// WithMaxMetadataSize specifies the maximum size, in bytes, of the metadata
+// of the messages read, 64 MiB by default. Readers fail with a LimitError
+// on larger messages, before allocating memory for them.
+func WithMaxMetadataSize(n int64) Option {
+	return func(cfg *config) {
+		cfg.limits.metadataSize = n
+	}
+}
+
+// WithMaxBodySize specifies the maximum size, in bytes, of the body of the
+// messages read, and of their buffers once decompressed, 256 MiB by default.
+// Readers fail with a LimitError on larger messages, before allocating
+// memory for them.
+func WithMaxBodySize(n int64) Option {
+	return func(cfg *config) {
+		cfg.limits.bodySize = n
+	}
+}
+
+// WithMaxNestingDepth specifies the maximum nesting depth of the fields of
+// the schemas and records read, 64 by default. Readers fail with a
+// LimitError on deeper fields.
+func WithMaxNestingDepth(n int) Option {
+	return func(cfg *config) {
+		cfg.limits.nestingDepth = n
+	}
+}
+
+// WithMaxBuffers specifies the maximum number of buffers of the record
+// batches read, 2^20 by default. Readers fail with a LimitError on record
+// batches with more buffers.
+func WithMaxBuffers(n int) Option {
+	return func(cfg *config) {
+		cfg.limits.buffers = n
+	}
+}
+
 var (
 	_ arrio.Reader = (*Reader)(nil)
 	_ arrio.Writer = (*Writer)(nil)
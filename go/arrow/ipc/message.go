@@ -17,6 +17,7 @@
 package ipc // import "github.com/apache/arrow/go/arrow/ipc"
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -25,6 +26,7 @@ import (
 	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/internal/flatbuf"
 	"github.com/apache/arrow/go/arrow/memory"
+	flatbuffers "github.com/google/flatbuffers/go"
 	"golang.org/x/xerrors"
 )
 
@@ -77,6 +79,10 @@ type Message struct {
 	msg      *flatbuf.Message
 	meta     *memory.Buffer
 	body     *memory.Buffer
+
+	// err is set when the metadata is not a valid message, whose type is
+	// then reported as MessageNone.
+	err error
 }
 
 // NewMessage creates a new message from the metadata and body buffers.
@@ -87,12 +93,13 @@ func NewMessage(meta, body *memory.Buffer) *Message {
 	}
 	meta.Retain()
 	body.Retain()
-	return &Message{
+	msg := &Message{
 		refCount: 1,
-		msg:      flatbuf.GetRootAsMessage(meta.Bytes(), 0),
 		meta:     meta,
 		body:     body,
 	}
+	msg.msg, msg.err = messageFromFB(meta.Bytes())
+	return msg
 }
 
 func newMessageFromFB(meta *flatbuf.Message, body *memory.Buffer) *Message {
@@ -108,6 +115,24 @@ func newMessageFromFB(meta *flatbuf.Message, body *memory.Buffer) *Message {
 	}
 }
 
+// messageFromFB returns the message of the flatbuffer buf, checking that
+// the fields of the message lie within buf.
+func messageFromFB(buf []byte) (msg *flatbuf.Message, err error) {
+	defer recoverDecode(&err)
+	if len(buf) < 4 {
+		return nil, xerrors.Errorf("arrow/ipc: message metadata too short (%d bytes)", len(buf))
+	}
+	msg = flatbuf.GetRootAsMessage(buf, 0)
+	msg.Version()
+	msg.BodyLength()
+	msg.CustomMetadataLength()
+	var tbl flatbuffers.Table
+	if msg.HeaderType() != flatbuf.MessageHeaderNONE && !msg.Header(&tbl) {
+		return nil, xerrors.Errorf("arrow/ipc: message without header")
+	}
+	return msg, nil
+}
+
 // Retain increases the reference count by 1.
 // Retain may be called simultaneously from multiple goroutines.
 func (msg *Message) Retain() {
@@ -130,14 +155,23 @@ func (msg *Message) Release() {
 }
 
 func (msg *Message) Version() MetadataVersion {
+	if msg.err != nil {
+		return 0
+	}
 	return MetadataVersion(msg.msg.Version())
 }
 
 func (msg *Message) Type() MessageType {
+	if msg.err != nil {
+		return MessageNone
+	}
 	return MessageType(msg.msg.HeaderType())
 }
 
 func (msg *Message) BodyLen() int64 {
+	if msg.err != nil {
+		return 0
+	}
 	return msg.msg.BodyLength()
 }
 
@@ -153,11 +187,21 @@ type messageReader struct {
 
 	refCount int64
 	msg      *Message
+
+	// reuse is set by WithBufferReuse, the metadata and body of the
+	// messages are then read to meta and body, reused across messages.
+	reuse      bool
+	meta, body []byte
+
+	limits limits
 }
 
 // NewMessageReader returns a reader that reads messages from an input stream.
-func NewMessageReader(r io.Reader) MessageReader {
-	return &messageReader{r: r, refCount: 1}
+// With WithBufferReuse, the memory messages are read to is reused by the
+// next message.
+func NewMessageReader(r io.Reader, opts ...Option) MessageReader {
+	cfg := newConfig(opts...)
+	return &messageReader{r: r, refCount: 1, reuse: cfg.reuseBuffers, limits: cfg.limits}
 }
 
 // Retain increases the reference count by 1.
@@ -214,27 +258,80 @@ func (r *messageReader) Message() (*Message, error) {
 		msgLen = int32(cid)
 	}
 
-	buf = make([]byte, msgLen)
-	_, err = io.ReadFull(r.r, buf)
+	if r.msg != nil {
+		r.msg.Release()
+		r.msg = nil
+	}
+
+	if msgLen < 0 {
+		return nil, xerrors.Errorf("arrow/ipc: invalid message metadata length %d", msgLen)
+	}
+	if err := r.limits.checkMetadata(int64(msgLen)); err != nil {
+		return nil, err
+	}
+
+	buf, err = r.read(&r.meta, int(msgLen))
 	if err != nil {
 		return nil, xerrors.Errorf("arrow/ipc: could not read message metadata: %w", err)
 	}
 
-	meta := flatbuf.GetRootAsMessage(buf, 0)
+	meta, err := messageFromFB(buf)
+	if err != nil {
+		return nil, err
+	}
 	bodyLen := meta.BodyLength()
+	if bodyLen < 0 {
+		return nil, xerrors.Errorf("arrow/ipc: invalid message body length %d", bodyLen)
+	}
+	if err := r.limits.checkBody(bodyLen); err != nil {
+		return nil, err
+	}
 
-	buf = make([]byte, bodyLen)
-	_, err = io.ReadFull(r.r, buf)
+	buf, err = r.read(&r.body, int(bodyLen))
 	if err != nil {
 		return nil, xerrors.Errorf("arrow/ipc: could not read message body: %w", err)
 	}
 	body := memory.NewBufferBytes(buf)
 
-	if r.msg != nil {
-		r.msg.Release()
-		r.msg = nil
-	}
 	r.msg = newMessageFromFB(meta, body)
 
 	return r.msg, nil
 }
+
+// read reads the next n bytes of the stream to the memory in *p, grown as
+// needed, or to new memory when the reader does not reuse it.
+// Lengths read from the stream are not trusted: memory is grown in pieces
+// as the bytes arrive, so that a truncated or corrupted stream declaring a
+// large message does not allocate more than twice what it holds.
+func (r *messageReader) read(p *[]byte, n int) ([]byte, error) {
+	var buf []byte
+	if r.reuse {
+		buf = (*p)[:0]
+	}
+	if cap(buf) >= n {
+		buf = buf[:n]
+		_, err := io.ReadFull(r.r, buf)
+		return buf, err
+	}
+
+	if n < kReadChunkSize {
+		buf = make([]byte, 0, n)
+	} else {
+		buf = make([]byte, 0, kReadChunkSize)
+	}
+	w := bytes.NewBuffer(buf)
+	m, err := w.ReadFrom(io.LimitReader(r.r, int64(n)))
+	switch {
+	case err != nil:
+		return nil, err
+	case m == 0 && n > 0:
+		return nil, io.EOF
+	case m < int64(n):
+		return nil, io.ErrUnexpectedEOF
+	}
+	buf = w.Bytes()
+	if r.reuse {
+		*p = buf
+	}
+	return buf, nil
+}
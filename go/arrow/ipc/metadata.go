@@ -22,6 +22,8 @@ import (
 	"sort"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
 	"github.com/apache/arrow/go/arrow/internal/flatbuf"
 	"github.com/apache/arrow/go/arrow/memory"
 	flatbuffers "github.com/google/flatbuffers/go"
@@ -35,9 +37,6 @@ const (
 	currentMetadataVersion = MetadataV4
 	minMetadataVersion     = MetadataV4
 
-	kExtensionTypeKeyName = "arrow_extension_name"
-	kExtensionDataKeyName = "arrow_extension_data"
-
 	// ARROW-109: We set this number arbitrarily to help catch user mistakes. For
 	// deeply nested schemas, it is expected the user will indicate explicitly the
 	// maximum allowed recursion depth
@@ -88,18 +87,7 @@ func (blk fileBlock) NewMessage() (*Message, error) {
 		return nil, xerrors.Errorf("arrow/ipc: could not read message metadata: %w", err)
 	}
 
-	prefix := 0
-	switch binary.LittleEndian.Uint32(buf) {
-	case 0:
-	case kIPCContToken:
-		prefix = 8
-	default:
-		// ARROW-6314: backwards compatibility for reading old IPC
-		// messages produced prior to version 0.15.0
-		prefix = 4
-	}
-
-	meta := memory.NewBufferBytes(buf[prefix:]) // drop buf-size already known from blk.Meta
+	meta := memory.NewBufferBytes(buf[metaPrefix(buf):]) // drop buf-size already known from blk.Meta
 
 	buf = make([]byte, blk.Body)
 	_, err = io.ReadFull(r, buf)
@@ -111,6 +99,36 @@ func (blk fileBlock) NewMessage() (*Message, error) {
 	return NewMessage(meta, body), nil
 }
 
+// mappedMessage returns the message of blk from the mapped file data,
+// whose body aliases data.
+func (blk fileBlock) mappedMessage(m *mapping) (*Message, *mappedBody, error) {
+	end := blk.Offset + int64(blk.Meta) + blk.Body
+	if blk.Offset < 0 || blk.Meta < 4 || blk.Body < 0 || end > int64(len(m.data)) {
+		return nil, nil, xerrors.Errorf("arrow/ipc: file block [%d, %d) out of the mapped file of %d bytes", blk.Offset, end, len(m.data))
+	}
+
+	buf := m.data[blk.Offset : blk.Offset+int64(blk.Meta)]
+	meta := memory.NewBufferBytes(buf[metaPrefix(buf):])
+	body := m.body(blk.Offset+int64(blk.Meta), blk.Body)
+
+	return NewMessage(meta, memory.NewBufferBytes(body.data)), body, nil
+}
+
+// metaPrefix returns the size of the prefix of the metadata of a message
+// in buf, the continuation token and the size of the metadata.
+func metaPrefix(buf []byte) int {
+	switch binary.LittleEndian.Uint32(buf) {
+	case 0:
+		return 0
+	case kIPCContToken:
+		return 8
+	default:
+		// ARROW-6314: backwards compatibility for reading old IPC
+		// messages produced prior to version 0.15.0
+		return 4
+	}
+}
+
 func (blk fileBlock) section() io.Reader {
 	return io.NewSectionReader(blk.r, blk.Offset, int64(blk.Meta)+blk.Body)
 }
@@ -157,7 +175,7 @@ func initFB(t interface {
 	t.Init(tbl.Bytes, tbl.Pos)
 }
 
-func fieldFromFB(field *flatbuf.Field, memo *dictMemo) (arrow.Field, error) {
+func fieldFromFB(field *flatbuf.Field, memo *dictMemo, exts *arrow.ExtensionTypeRegistry) (arrow.Field, error) {
 	var (
 		err error
 		o   arrow.Field
@@ -173,26 +191,46 @@ func fieldFromFB(field *flatbuf.Field, memo *dictMemo) (arrow.Field, error) {
 	encoding := field.Dictionary(nil)
 	switch encoding {
 	case nil:
-		n := field.ChildrenLength()
+		n, err := lenFB(field.ChildrenLength(), kOffsetSize, field.Table(), "field children")
+		if err != nil {
+			return o, err
+		}
 		children := make([]arrow.Field, n)
 		for i := range children {
 			var childFB flatbuf.Field
 			if !field.Children(&childFB, i) {
 				return o, xerrors.Errorf("arrow/ipc: could not load field child %d", i)
 			}
-			child, err := fieldFromFB(&childFB, memo)
+			child, err := fieldFromFB(&childFB, memo, exts)
 			if err != nil {
 				return o, xerrors.Errorf("arrow/ipc: could not convert field child %d: %w", i, err)
 			}
 			children[i] = child
 		}
 
-		o.Type, err = typeFromFB(field, children, o.Metadata)
+		o.Type, err = typeFromFB(field, children, o.Metadata, exts)
 		if err != nil {
 			return o, xerrors.Errorf("arrow/ipc: could not convert field type: %w", err)
 		}
+		if _, ok := o.Type.(arrow.ExtensionType); ok {
+			o.Metadata = stripExtensionMetadata(o.Metadata)
+		}
 	default:
-		panic("not implemented") // FIXME(sbinet)
+		dfield, err := fieldFromFBDict(field, exts)
+		if err != nil {
+			return o, xerrors.Errorf("arrow/ipc: could not convert dictionary field type: %w", err)
+		}
+
+		// the type of the indices defaults to int32.
+		idx := arrow.DataType(arrow.PrimitiveTypes.Int32)
+		if idxFB := encoding.IndexType(nil); idxFB != nil {
+			idx, err = intFromFB(*idxFB)
+			if err != nil {
+				return o, xerrors.Errorf("arrow/ipc: could not convert dictionary index type: %w", err)
+			}
+		}
+		o.Type = &arrow.DictionaryType{IndexType: idx, ValueType: dfield.Type, Ordered: encoding.IsOrdered()}
+		memo.AddField(encoding.Id())
 	}
 
 	return o, nil
@@ -276,6 +314,14 @@ func (fv *fieldVisitor) visit(field arrow.Field) {
 		flatbuf.DecimalAddScale(fv.b, dt.Scale)
 		fv.offset = flatbuf.DecimalEnd(fv.b)
 
+	case *arrow.Decimal256Type:
+		fv.dtype = flatbuf.TypeDecimal
+		flatbuf.DecimalStart(fv.b)
+		flatbuf.DecimalAddPrecision(fv.b, dt.Precision)
+		flatbuf.DecimalAddScale(fv.b, dt.Scale)
+		flatbuf.DecimalAddBitWidth(fv.b, 256)
+		fv.offset = flatbuf.DecimalEnd(fv.b)
+
 	case *arrow.FixedSizeBinaryType:
 		fv.dtype = flatbuf.TypeFixedSizeBinary
 		flatbuf.FixedSizeBinaryStart(fv.b)
@@ -343,6 +389,28 @@ func (fv *fieldVisitor) visit(field arrow.Field) {
 		fv.offset = flatbuf.Struct_End(fv.b)
 		fv.kids = append(fv.kids, offsets...)
 
+	case *arrow.UnionType:
+		fv.dtype = flatbuf.TypeUnion
+		offsets := make([]flatbuffers.UOffsetT, len(dt.Fields()))
+		for i, field := range dt.Fields() {
+			offsets[i] = fieldToFB(fv.b, field, fv.memo)
+		}
+		codes := dt.TypeCodes()
+		flatbuf.UnionStartTypeIdsVector(fv.b, len(codes))
+		for i := len(codes) - 1; i >= 0; i-- {
+			fv.b.PrependInt32(int32(codes[i]))
+		}
+		ids := fv.b.EndVector(len(codes))
+		mode := flatbuf.UnionModeSparse
+		if dt.Mode() == arrow.DenseMode {
+			mode = flatbuf.UnionModeDense
+		}
+		flatbuf.UnionStart(fv.b)
+		flatbuf.UnionAddMode(fv.b, mode)
+		flatbuf.UnionAddTypeIds(fv.b, ids)
+		fv.offset = flatbuf.UnionEnd(fv.b)
+		fv.kids = append(fv.kids, offsets...)
+
 	case *arrow.ListType:
 		fv.dtype = flatbuf.TypeList
 		fv.kids = append(fv.kids, fieldToFB(fv.b, arrow.Field{Name: "item", Type: dt.Elem(), Nullable: field.Nullable}, fv.memo))
@@ -356,6 +424,25 @@ func (fv *fieldVisitor) visit(field arrow.Field) {
 		flatbuf.FixedSizeListAddListSize(fv.b, dt.Len())
 		fv.offset = flatbuf.FixedSizeListEnd(fv.b)
 
+	case *arrow.BinaryViewType:
+		fv.dtype = flatbuf.TypeBinaryView
+		flatbuf.BinaryViewStart(fv.b)
+		fv.offset = flatbuf.BinaryViewEnd(fv.b)
+
+	case *arrow.StringViewType:
+		fv.dtype = flatbuf.TypeUtf8View
+		flatbuf.Utf8ViewStart(fv.b)
+		fv.offset = flatbuf.Utf8ViewEnd(fv.b)
+
+	case *arrow.RunEndEncodedType:
+		fv.dtype = flatbuf.TypeRunEndEncoded
+		fv.kids = append(fv.kids,
+			fieldToFB(fv.b, arrow.Field{Name: "run_ends", Type: dt.RunEnds()}, fv.memo),
+			fieldToFB(fv.b, arrow.Field{Name: "values", Type: dt.Encoded(), Nullable: true}, fv.memo),
+		)
+		flatbuf.RunEndEncodedStart(fv.b)
+		fv.offset = flatbuf.RunEndEncodedEnd(fv.b)
+
 	case *arrow.MonthIntervalType:
 		fv.dtype = flatbuf.TypeInterval
 		flatbuf.IntervalStart(fv.b)
@@ -375,6 +462,18 @@ func (fv *fieldVisitor) visit(field arrow.Field) {
 		flatbuf.DurationAddUnit(fv.b, unit)
 		fv.offset = flatbuf.DurationEnd(fv.b)
 
+	case *arrow.DictionaryType:
+		// the field has the type of the dictionary values, the type of the
+		// indices is part of its dictionary encoding.
+		fv.visit(arrow.Field{Name: field.Name, Type: dt.ValueType, Nullable: field.Nullable})
+
+	case arrow.ExtensionType:
+		// the field has the storage type, the extension type is kept in
+		// its metadata.
+		fv.meta[arrow.ExtensionNameKey] = dt.ExtensionName()
+		fv.meta[arrow.ExtensionMetadataKey] = dt.Serialize()
+		fv.visit(arrow.Field{Name: field.Name, Type: dt.StorageType(), Nullable: field.Nullable})
+
 	default:
 		err := xerrors.Errorf("arrow/ipc: invalid data type %v", dt)
 		panic(err) // FIXME(sbinet): implement all data-types.
@@ -393,8 +492,8 @@ func (fv *fieldVisitor) result(field arrow.Field) flatbuffers.UOffsetT {
 	kidsFB := fv.b.EndVector(len(fv.kids))
 
 	var dictFB flatbuffers.UOffsetT
-	if field.Type.ID() == arrow.DICTIONARY {
-		panic("not implemented") // FIXME(sbinet)
+	if dt, ok := field.Type.(*arrow.DictionaryType); ok {
+		dictFB = dictEncodingToFB(fv.b, dt, fv.memo)
 	}
 
 	var (
@@ -402,6 +501,9 @@ func (fv *fieldVisitor) result(field arrow.Field) flatbuffers.UOffsetT {
 		kvs    []flatbuffers.UOffsetT
 	)
 	for i, k := range field.Metadata.Keys() {
+		if _, dup := fv.meta[k]; dup {
+			continue
+		}
 		v := field.Metadata.Values()[i]
 		kk := fv.b.CreateString(k)
 		vv := fv.b.CreateString(v)
@@ -448,7 +550,31 @@ func (fv *fieldVisitor) result(field arrow.Field) flatbuffers.UOffsetT {
 	return offset
 }
 
-func fieldFromFBDict(field *flatbuf.Field) (arrow.Field, error) {
+// dictEncodingToFB writes the dictionary encoding of a field of type dt,
+// with the next dictionary ID of memo, the IDs following the depth-first
+// order of the dictionary-encoded fields of the schema.
+func dictEncodingToFB(b *flatbuffers.Builder, dt *arrow.DictionaryType, memo *dictMemo) flatbuffers.UOffsetT {
+	var indexFB flatbuffers.UOffsetT
+	switch idx := dt.IndexType.(type) {
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
+		indexFB = intToFB(b, int32(idx.(arrow.FixedWidthDataType).BitWidth()), true)
+	case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		indexFB = intToFB(b, int32(idx.(arrow.FixedWidthDataType).BitWidth()), false)
+	default:
+		panic(xerrors.Errorf("arrow/ipc: invalid dictionary index type %v", idx))
+	}
+
+	id := int64(len(memo.fields))
+	memo.AddField(id)
+
+	flatbuf.DictionaryEncodingStart(b)
+	flatbuf.DictionaryEncodingAddId(b, id)
+	flatbuf.DictionaryEncodingAddIndexType(b, indexFB)
+	flatbuf.DictionaryEncodingAddIsOrdered(b, dt.Ordered)
+	return flatbuf.DictionaryEncodingEnd(b)
+}
+
+func fieldFromFBDict(field *flatbuf.Field, exts *arrow.ExtensionTypeRegistry) (arrow.Field, error) {
 	var (
 		o = arrow.Field{
 			Name:     string(field.Name()),
@@ -460,24 +586,25 @@ func fieldFromFBDict(field *flatbuf.Field) (arrow.Field, error) {
 
 	// any DictionaryEncoding set is ignored here.
 
-	kids := make([]arrow.Field, field.ChildrenLength())
+	n, err := lenFB(field.ChildrenLength(), kOffsetSize, field.Table(), "field children")
+	if err != nil {
+		return o, err
+	}
+	kids := make([]arrow.Field, n)
 	for i := range kids {
 		var kid flatbuf.Field
 		if !field.Children(&kid, i) {
 			return o, xerrors.Errorf("arrow/ipc: could not load field child %d", i)
 		}
-		kids[i], err = fieldFromFB(&kid, &memo)
+		kids[i], err = fieldFromFB(&kid, &memo, exts)
 		if err != nil {
 			return o, xerrors.Errorf("arrow/ipc: field from dict: %w", err)
 		}
 	}
 
-	meta, err := metadataFromFB(field)
-	if err != nil {
-		return o, xerrors.Errorf("arrow/ipc: metadata for field from dict: %w", err)
-	}
-
-	o.Type, err = typeFromFB(field, kids, meta)
+	// extension types of dictionary-encoded fields are not supported: the
+	// values have the storage type, the field keeping the extension metadata.
+	o.Type, err = typeFromFB(field, kids, arrow.Metadata{}, exts)
 	if err != nil {
 		return o, xerrors.Errorf("arrow/ipc: type for field from dict: %w", err)
 	}
@@ -485,7 +612,11 @@ func fieldFromFBDict(field *flatbuf.Field) (arrow.Field, error) {
 	return o, nil
 }
 
-func typeFromFB(field *flatbuf.Field, children []arrow.Field, md arrow.Metadata) (arrow.DataType, error) {
+// typeFromFB returns the type of field, given its children and metadata.
+// Fields of a registered extension type, looked up in exts and then in
+// the global registry, have the extension type. Fields of another
+// extension type have its storage type.
+func typeFromFB(field *flatbuf.Field, children []arrow.Field, md arrow.Metadata, exts *arrow.ExtensionTypeRegistry) (arrow.DataType, error) {
 	var data flatbuffers.Table
 	if !field.Type(&data) {
 		return nil, xerrors.Errorf("arrow/ipc: could not load field type data")
@@ -497,16 +628,47 @@ func typeFromFB(field *flatbuf.Field, children []arrow.Field, md arrow.Metadata)
 	}
 
 	// look for extension metadata in custom metadata field.
-	if md.Len() > 0 {
-		i := md.FindKey(kExtensionTypeKeyName)
-		if i < 0 {
-			return dt, err
-		}
+	i := md.FindKey(arrow.ExtensionNameKey)
+	if i < 0 {
+		return dt, err
+	}
 
-		panic("not implemented") // FIXME(sbinet)
+	name := md.Values()[i]
+	var ext arrow.ExtensionType
+	if exts != nil {
+		ext = exts.Get(name)
+	}
+	if ext == nil {
+		ext = arrow.GetExtensionType(name)
+	}
+	if ext == nil {
+		return dt, err
 	}
 
-	return dt, err
+	var params string
+	if j := md.FindKey(arrow.ExtensionMetadataKey); j >= 0 {
+		params = md.Values()[j]
+	}
+	ext, err = ext.Deserialize(dt, params)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: could not deserialize extension type %q: %w", name, err)
+	}
+	return ext, nil
+}
+
+// stripExtensionMetadata returns md without the keys holding the extension
+// type of a field.
+func stripExtensionMetadata(md arrow.Metadata) arrow.Metadata {
+	var keys, vals []string
+	for i, k := range md.Keys() {
+		switch k {
+		case arrow.ExtensionNameKey, arrow.ExtensionMetadataKey:
+			continue
+		}
+		keys = append(keys, k)
+		vals = append(vals, md.Values()[i])
+	}
+	return arrow.NewMetadata(keys, vals)
 }
 
 func concreteTypeFromFB(typ flatbuf.Type, data flatbuffers.Table, children []arrow.Field) (arrow.DataType, error) {
@@ -548,6 +710,12 @@ func concreteTypeFromFB(typ flatbuf.Type, data flatbuffers.Table, children []arr
 	case flatbuf.TypeUtf8:
 		return arrow.BinaryTypes.String, nil
 
+	case flatbuf.TypeBinaryView:
+		return arrow.BinaryTypes.BinaryView, nil
+
+	case flatbuf.TypeUtf8View:
+		return arrow.BinaryTypes.StringView, nil
+
 	case flatbuf.TypeBool:
 		return arrow.FixedWidthTypes.Boolean, nil
 
@@ -565,9 +733,25 @@ func concreteTypeFromFB(typ flatbuf.Type, data flatbuffers.Table, children []arr
 		}
 		return arrow.FixedSizeListOf(dt.ListSize(), children[0].Type), nil
 
+	case flatbuf.TypeRunEndEncoded:
+		if len(children) != 2 {
+			return nil, xerrors.Errorf("arrow/ipc: RunEndEncoded must have exactly 2 child fields (got=%d)", len(children))
+		}
+		switch children[0].Type.ID() {
+		case arrow.INT16, arrow.INT32, arrow.INT64:
+		default:
+			return nil, xerrors.Errorf("arrow/ipc: invalid RunEndEncoded run ends type %v", children[0].Type)
+		}
+		return arrow.RunEndEncodedOf(children[0].Type, children[1].Type), nil
+
 	case flatbuf.TypeStruct_:
 		return arrow.StructOf(children...), nil
 
+	case flatbuf.TypeUnion:
+		var dt flatbuf.Union
+		dt.Init(data.Bytes, data.Pos)
+		return unionFromFB(dt, children)
+
 	case flatbuf.TypeTime:
 		var dt flatbuf.Time
 		dt.Init(data.Bytes, data.Pos)
@@ -601,6 +785,44 @@ func concreteTypeFromFB(typ flatbuf.Type, data flatbuffers.Table, children []arr
 	return dt, err
 }
 
+// unionFromFB returns the union type described by data, whose fields are
+// children. When no type codes are given, the fields are identified by
+// their position.
+func unionFromFB(data flatbuf.Union, children []arrow.Field) (arrow.DataType, error) {
+	var mode arrow.UnionMode
+	switch data.Mode() {
+	case flatbuf.UnionModeSparse:
+		mode = arrow.SparseMode
+	case flatbuf.UnionModeDense:
+		mode = arrow.DenseMode
+	default:
+		return nil, xerrors.Errorf("arrow/ipc: invalid union mode %d", data.Mode())
+	}
+
+	var codes []arrow.UnionTypeCode
+	if n := data.TypeIdsLength(); n > 0 {
+		if _, err := lenFB(n, 4, data.Table(), "union type ids"); err != nil {
+			return nil, err
+		}
+		if n != len(children) {
+			return nil, xerrors.Errorf("arrow/ipc: union with %d child fields and %d type ids", len(children), n)
+		}
+		codes = make([]arrow.UnionTypeCode, n)
+		var seen [int(arrow.MaxUnionTypeCode) + 1]bool
+		for i := range codes {
+			id := data.TypeIds(i)
+			if id < 0 || id > int32(arrow.MaxUnionTypeCode) || seen[id] {
+				return nil, xerrors.Errorf("arrow/ipc: invalid union type id %d", id)
+			}
+			seen[id] = true
+			codes[i] = arrow.UnionTypeCode(id)
+		}
+	} else if len(children) > int(arrow.MaxUnionTypeCode)+1 {
+		return nil, xerrors.Errorf("arrow/ipc: too many union child fields (got=%d)", len(children))
+	}
+	return arrow.UnionOf(mode, children, codes), nil
+}
+
 func intFromFB(data flatbuf.Int) (arrow.DataType, error) {
 	bw := data.BitWidth()
 	if bw > 64 {
@@ -679,7 +901,34 @@ func floatToFB(b *flatbuffers.Builder, bw int32) flatbuffers.UOffsetT {
 }
 
 func decimalFromFB(data flatbuf.Decimal) (arrow.DataType, error) {
-	return &arrow.Decimal128Type{Precision: data.Precision(), Scale: data.Scale()}, nil
+	var (
+		bw      = data.BitWidth()
+		prec    = data.Precision()
+		scale   = data.Scale()
+		maxPrec int32
+	)
+	switch bw {
+	case 128:
+		maxPrec = decimal128.MaxPrecision
+	case 256:
+		maxPrec = decimal256.MaxPrecision
+	default:
+		return nil, xerrors.Errorf("arrow/ipc: Decimal type with %d bitwidth not implemented", bw)
+	}
+
+	// a corrupt precision or scale would size the buffers of the decimal
+	// casts and string conversions: reject them with the schema.
+	switch {
+	case prec < 1 || prec > maxPrec:
+		return nil, xerrors.Errorf("arrow/ipc: invalid decimal%d precision %d (want 1..%d)", bw, prec, maxPrec)
+	case scale > prec:
+		return nil, xerrors.Errorf("arrow/ipc: invalid decimal%d scale %d greater than precision %d", bw, scale, prec)
+	}
+
+	if bw == 128 {
+		return &arrow.Decimal128Type{Precision: prec, Scale: scale}, nil
+	}
+	return &arrow.Decimal256Type{Precision: prec, Scale: scale}, nil
 }
 
 func timeFromFB(data flatbuf.Time) (arrow.DataType, error) {
@@ -713,7 +962,11 @@ func timeFromFB(data flatbuf.Time) (arrow.DataType, error) {
 func timestampFromFB(data flatbuf.Timestamp) (arrow.DataType, error) {
 	unit := unitFromFB(data.Unit())
 	tz := string(data.Timezone())
-	return &arrow.TimestampType{Unit: unit, TimeZone: tz}, nil
+	dt, err := arrow.NewTimestampType(unit, tz)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: invalid Timestamp type: %w", err)
+	}
+	return dt, nil
 }
 
 func dateFromFB(data flatbuf.Date) (arrow.DataType, error) {
@@ -750,24 +1003,36 @@ func durationFromFB(data flatbuf.Duration) (arrow.DataType, error) {
 	return nil, xerrors.Errorf("arrow/ipc: Duration type with %d unit not implemented", data.Unit())
 }
 
+// kOffsetSize is the size of the offsets to the tables of flatbuffer vectors.
+const kOffsetSize = flatbuffers.SizeUOffsetT
+
+// lenFB returns n, the length of a vector of elements of size bytes read from
+// the flatbuffer of tab, after checking that the vector fits in the
+// flatbuffer: lengths read from messages are not trusted, and must not
+// allocate more memory than the messages hold.
+func lenFB(n, size int, tab flatbuffers.Table, what string) (int, error) {
+	if n < 0 || int64(n)*int64(size) > int64(len(tab.Bytes)) {
+		return 0, xerrors.Errorf("arrow/ipc: invalid number of %s %d for a flatbuffer of %d bytes", what, n, len(tab.Bytes))
+	}
+	return n, nil
+}
+
 type customMetadataer interface {
 	CustomMetadataLength() int
 	CustomMetadata(*flatbuf.KeyValue, int) bool
 }
 
 func metadataFromFB(md customMetadataer) (arrow.Metadata, error) {
-	var (
-		keys = make([]string, md.CustomMetadataLength())
-		vals = make([]string, md.CustomMetadataLength())
-	)
-
-	for i := range keys {
+	// the key-values are appended as they are read, so that a malformed
+	// length does not allocate more than the flatbuffer holds.
+	var keys, vals []string
+	for i := 0; i < md.CustomMetadataLength(); i++ {
 		var kv flatbuf.KeyValue
 		if !md.CustomMetadata(&kv, i) {
 			return arrow.Metadata{}, xerrors.Errorf("arrow/ipc: could not read key-value %d from flatbuffer", i)
 		}
-		keys[i] = string(kv.Key())
-		vals[i] = string(kv.Value())
+		keys = append(keys, string(kv.Key()))
+		vals = append(vals, string(kv.Value()))
 	}
 
 	return arrow.NewMetadata(keys, vals), nil
@@ -796,11 +1061,12 @@ func metadataToFB(b *flatbuffers.Builder, meta arrow.Metadata, start startVecFun
 	return b.EndVector(n)
 }
 
-func schemaFromFB(schema *flatbuf.Schema, memo *dictMemo) (*arrow.Schema, error) {
-	var (
-		err    error
-		fields = make([]arrow.Field, schema.FieldsLength())
-	)
+func schemaFromFB(schema *flatbuf.Schema, memo *dictMemo, exts *arrow.ExtensionTypeRegistry) (*arrow.Schema, error) {
+	n, err := lenFB(schema.FieldsLength(), kOffsetSize, schema.Table(), "schema fields")
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]arrow.Field, n)
 
 	for i := range fields {
 		var field flatbuf.Field
@@ -808,7 +1074,7 @@ func schemaFromFB(schema *flatbuf.Schema, memo *dictMemo) (*arrow.Schema, error)
 			return nil, xerrors.Errorf("arrow/ipc: could not read field %d from schema", i)
 		}
 
-		fields[i], err = fieldFromFB(&field, memo)
+		fields[i], err = fieldFromFB(&field, memo, exts)
 		if err != nil {
 			return nil, xerrors.Errorf("arrow/ipc: could not convert field %d from flatbuf: %w", i, err)
 		}
@@ -845,17 +1111,68 @@ func schemaToFB(b *flatbuffers.Builder, schema *arrow.Schema, memo *dictMemo) fl
 	return offset
 }
 
-func dictTypesFromFB(schema *flatbuf.Schema) (dictTypeMap, error) {
-	var (
-		err    error
-		fields = make(dictTypeMap, schema.FieldsLength())
-	)
-	for i := 0; i < schema.FieldsLength(); i++ {
+// checkNestingFB checks that the fields of schema are nested at most max
+// levels deep before they are decoded, along with their number, which
+// malformed metadata sharing the tables of fields could make exponential.
+func checkNestingFB(schema *flatbuf.Schema, max int) error {
+	if schema == nil {
+		return xerrors.Errorf("arrow/ipc: could not load schema from flatbuffer data")
+	}
+
+	// every field takes more than a byte of well-formed metadata.
+	n := len(schema.Table().Bytes)
+	var check func(field *flatbuf.Field, depth int) error
+	check = func(field *flatbuf.Field, depth int) error {
+		if depth > max {
+			return &LimitError{Limit: "nesting depth", Value: int64(depth), Max: int64(max)}
+		}
+		if n--; n < 0 {
+			return xerrors.Errorf("arrow/ipc: too many fields in schema")
+		}
+		nkids, err := lenFB(field.ChildrenLength(), kOffsetSize, field.Table(), "field children")
+		if err != nil {
+			return err
+		}
+		for i := 0; i < nkids; i++ {
+			var child flatbuf.Field
+			if !field.Children(&child, i) {
+				return xerrors.Errorf("arrow/ipc: could not load child %d from field", i)
+			}
+			if err := check(&child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	nfields, err := lenFB(schema.FieldsLength(), kOffsetSize, schema.Table(), "schema fields")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < nfields; i++ {
+		var field flatbuf.Field
+		if !schema.Fields(&field, i) {
+			return xerrors.Errorf("arrow/ipc: could not load field %d from schema", i)
+		}
+		if err := check(&field, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dictTypesFromFB(schema *flatbuf.Schema, exts *arrow.ExtensionTypeRegistry) (dictTypeMap, error) {
+	n, err := lenFB(schema.FieldsLength(), kOffsetSize, schema.Table(), "schema fields")
+	if err != nil {
+		return nil, err
+	}
+	fields := make(dictTypeMap, n)
+	for i := 0; i < n; i++ {
 		var field flatbuf.Field
 		if !schema.Fields(&field, i) {
 			return nil, xerrors.Errorf("arrow/ipc: could not load field %d from schema", i)
 		}
-		fields, err = visitField(&field, fields)
+		fields, err = visitField(&field, fields, exts)
 		if err != nil {
 			return nil, xerrors.Errorf("arrow/ipc: could not visit field %d from schema: %w", i, err)
 		}
@@ -863,7 +1180,7 @@ func dictTypesFromFB(schema *flatbuf.Schema) (dictTypeMap, error) {
 	return fields, err
 }
 
-func visitField(field *flatbuf.Field, dict dictTypeMap) (dictTypeMap, error) {
+func visitField(field *flatbuf.Field, dict dictTypeMap, exts *arrow.ExtensionTypeRegistry) (dictTypeMap, error) {
 	var err error
 	meta := field.Dictionary(nil)
 	switch meta {
@@ -875,7 +1192,7 @@ func visitField(field *flatbuf.Field, dict dictTypeMap) (dictTypeMap, error) {
 			if !field.Children(&child, i) {
 				return nil, xerrors.Errorf("arrow/ipc: could not visit child %d from field", i)
 			}
-			dict, err = visitField(&child, dict)
+			dict, err = visitField(&child, dict, exts)
 			if err != nil {
 				return nil, err
 			}
@@ -883,7 +1200,7 @@ func visitField(field *flatbuf.Field, dict dictTypeMap) (dictTypeMap, error) {
 	default:
 		// field is dictionary encoded.
 		// construct the data type for the dictionary: no descendants can be dict-encoded.
-		dfield, err := fieldFromFBDict(field)
+		dfield, err := fieldFromFBDict(field, exts)
 		if err != nil {
 			return nil, xerrors.Errorf("arrow/ipc: could not create data type for dictionary: %w", err)
 		}
@@ -893,22 +1210,16 @@ func visitField(field *flatbuf.Field, dict dictTypeMap) (dictTypeMap, error) {
 }
 
 // payloadsFromSchema returns a slice of payloads corresponding to the given schema.
+// The dictionaries of the dictionary-encoded fields are written with the
+// records, see dictionaryPayloads, memo holding their IDs.
 // Callers of payloadsFromSchema will need to call Release after use.
 func payloadsFromSchema(schema *arrow.Schema, mem memory.Allocator, memo *dictMemo) payloads {
 	dict := newMemo()
 
-	ps := make(payloads, 1, dict.Len()+1)
+	ps := make(payloads, 1)
 	ps[0].msg = MessageSchema
 	ps[0].meta = writeSchemaMessage(schema, mem, &dict)
 
-	// append dictionaries.
-	if dict.Len() > 0 {
-		panic("payloads-from-schema: not-implemented")
-		//		for id, arr := range dict.id2dict {
-		//			// GetSchemaPayloads: writer.cc:535
-		//		}
-	}
-
 	if memo != nil {
 		*memo = dict
 	}
@@ -966,20 +1277,57 @@ func writeFileFooter(schema *arrow.Schema, dicts, recs []fileBlock, w io.Writer)
 	return err
 }
 
-func writeRecordMessage(mem memory.Allocator, size, bodyLength int64, fields []fieldMetadata, meta []bufferMetadata) *memory.Buffer {
+func writeRecordMessage(mem memory.Allocator, size, bodyLength int64, fields []fieldMetadata, meta []bufferMetadata, variadic []int64, codec *bodyCodec) *memory.Buffer {
 	b := flatbuffers.NewBuilder(0)
-	recFB := recordToFB(b, size, bodyLength, fields, meta)
+	recFB := recordToFB(b, size, bodyLength, fields, meta, variadic, codec)
 	return writeMessageFB(b, mem, flatbuf.MessageHeaderRecordBatch, recFB, bodyLength)
 }
 
-func recordToFB(b *flatbuffers.Builder, size, bodyLength int64, fields []fieldMetadata, meta []bufferMetadata) flatbuffers.UOffsetT {
+func writeDictionaryMessage(mem memory.Allocator, id int64, isDelta bool, size, bodyLength int64, fields []fieldMetadata, meta []bufferMetadata, variadic []int64, codec *bodyCodec) *memory.Buffer {
+	b := flatbuffers.NewBuilder(0)
+	recFB := recordToFB(b, size, bodyLength, fields, meta, variadic, codec)
+
+	flatbuf.DictionaryBatchStart(b)
+	flatbuf.DictionaryBatchAddId(b, id)
+	flatbuf.DictionaryBatchAddData(b, recFB)
+	flatbuf.DictionaryBatchAddIsDelta(b, isDelta)
+	dictFB := flatbuf.DictionaryBatchEnd(b)
+	return writeMessageFB(b, mem, flatbuf.MessageHeaderDictionaryBatch, dictFB, bodyLength)
+}
+
+// recordToFB writes the metadata of a record batch, variadic holding the
+// number of data buffers of each field with a variable number of buffers.
+func recordToFB(b *flatbuffers.Builder, size, bodyLength int64, fields []fieldMetadata, meta []bufferMetadata, variadic []int64, codec *bodyCodec) flatbuffers.UOffsetT {
 	fieldsFB := writeFieldNodes(b, fields, flatbuf.RecordBatchStartNodesVector)
 	metaFB := writeBuffers(b, meta, flatbuf.RecordBatchStartBuffersVector)
 
+	var variadicFB flatbuffers.UOffsetT
+	if len(variadic) > 0 {
+		flatbuf.RecordBatchStartVariadicBufferCountsVector(b, len(variadic))
+		for i := len(variadic) - 1; i >= 0; i-- {
+			b.PrependInt64(variadic[i])
+		}
+		variadicFB = b.EndVector(len(variadic))
+	}
+
+	var compressionFB flatbuffers.UOffsetT
+	if codec != nil {
+		flatbuf.BodyCompressionStart(b)
+		flatbuf.BodyCompressionAddCodec(b, int8(codec.typ))
+		flatbuf.BodyCompressionAddMethod(b, flatbuf.BodyCompressionMethodBUFFER)
+		compressionFB = flatbuf.BodyCompressionEnd(b)
+	}
+
 	flatbuf.RecordBatchStart(b)
 	flatbuf.RecordBatchAddLength(b, size)
 	flatbuf.RecordBatchAddNodes(b, fieldsFB)
 	flatbuf.RecordBatchAddBuffers(b, metaFB)
+	if codec != nil {
+		flatbuf.RecordBatchAddCompression(b, compressionFB)
+	}
+	if len(variadic) > 0 {
+		flatbuf.RecordBatchAddVariadicBufferCounts(b, variadicFB)
+	}
 	return flatbuf.RecordBatchEnd(b)
 }
 
@@ -1006,14 +1354,20 @@ func writeBuffers(b *flatbuffers.Builder, buffers []bufferMetadata, start startV
 	return b.EndVector(len(buffers))
 }
 
-func writeMessage(msg *memory.Buffer, alignment int32, w io.Writer) (int, error) {
+func writeMessage(msg *memory.Buffer, alignment int32, legacy bool, w io.Writer) (int, error) {
 	var (
 		n   int
 		err error
 	)
 
+	// the legacy format prefixes the flatbuffer with its size alone.
+	prefix := int32(8)
+	if legacy {
+		prefix = 4
+	}
+
 	// ARROW-3212: we do not make any assumption on whether the output stream is aligned or not.
-	paddedMsgLen := int32(msg.Len()) + 8
+	paddedMsgLen := int32(msg.Len()) + prefix
 	remainder := paddedMsgLen % alignment
 	if remainder != 0 {
 		paddedMsgLen += alignment - remainder
@@ -1021,18 +1375,20 @@ func writeMessage(msg *memory.Buffer, alignment int32, w io.Writer) (int, error)
 
 	tmp := make([]byte, 4)
 
-	// write continuation indicator, to address 8-byte alignment requirement from FlatBuffers.
-	binary.LittleEndian.PutUint32(tmp, kIPCContToken)
-	_, err = w.Write(tmp)
-	if err != nil {
-		return 0, xerrors.Errorf("arrow/ipc: could not write continuation bit indicator: %w", err)
+	if !legacy {
+		// write continuation indicator, to address 8-byte alignment requirement from FlatBuffers.
+		binary.LittleEndian.PutUint32(tmp, kIPCContToken)
+		_, err = w.Write(tmp)
+		if err != nil {
+			return 0, xerrors.Errorf("arrow/ipc: could not write continuation bit indicator: %w", err)
+		}
 	}
 
 	// the returned message size includes the length prefix, the flatbuffer, + padding
 	n = int(paddedMsgLen)
 
 	// write the flatbuffer size prefix, including padding
-	sizeFB := paddedMsgLen - 8
+	sizeFB := paddedMsgLen - prefix
 	binary.LittleEndian.PutUint32(tmp, uint32(sizeFB))
 	_, err = w.Write(tmp)
 	if err != nil {
@@ -1046,7 +1402,7 @@ func writeMessage(msg *memory.Buffer, alignment int32, w io.Writer) (int, error)
 	}
 
 	// write any padding
-	padding := paddedMsgLen - int32(msg.Len()) - 8
+	padding := paddedMsgLen - int32(msg.Len()) - prefix
 	if padding > 0 {
 		_, err = w.Write(paddingBytes[:padding])
 		if err != nil {
@@ -40,6 +40,23 @@ func TestRWSchema(t *testing.T) {
 			}, &meta),
 			memo: newMemo(),
 		},
+		{
+			schema: dictSchema,
+			memo:   newMemo(),
+		},
+		{
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "ree", Type: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int16, arrow.ListOf(arrow.BinaryTypes.String)), Nullable: true},
+			}, nil),
+			memo: newMemo(),
+		},
+		{
+			schema: arrow.NewSchema([]arrow.Field{
+				{Name: "s", Type: arrow.BinaryTypes.StringView, Nullable: true},
+				{Name: "b", Type: arrow.ListOf(arrow.BinaryTypes.BinaryView)},
+			}, nil),
+			memo: newMemo(),
+		},
 	} {
 		t.Run("", func(t *testing.T) {
 			b := flatbuffers.NewBuilder(0)
@@ -50,7 +67,7 @@ func TestRWSchema(t *testing.T) {
 			buf := b.FinishedBytes()
 
 			fb := flatbuf.GetRootAsSchema(buf, 0)
-			got, err := schemaFromFB(fb, &tc.memo)
+			got, err := schemaFromFB(fb, &tc.memo, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -76,6 +93,53 @@ func TestRWSchema(t *testing.T) {
 	}
 }
 
+func TestSchemaInvalidDecimal(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		dt   arrow.DataType
+	}{
+		{"decimal128-zero-precision", &arrow.Decimal128Type{Precision: 0, Scale: 0}},
+		{"decimal128-precision", &arrow.Decimal128Type{Precision: 39, Scale: 2}},
+		{"decimal128-scale", &arrow.Decimal128Type{Precision: 10, Scale: 11}},
+		{"decimal256-precision", &arrow.Decimal256Type{Precision: 77, Scale: 2}},
+		{"decimal256-scale", &arrow.Decimal256Type{Precision: 76, Scale: 0x4b000002}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			memo := newMemo()
+			schema := arrow.NewSchema([]arrow.Field{{Name: "d", Type: tc.dt}}, nil)
+
+			b := flatbuffers.NewBuilder(0)
+			b.Finish(schemaToFB(b, schema, &memo))
+
+			fb := flatbuf.GetRootAsSchema(b.FinishedBytes(), 0)
+			if _, err := schemaFromFB(fb, &memo, nil); err == nil {
+				t.Fatalf("expected an error decoding %v", tc.dt)
+			}
+		})
+	}
+
+	for _, dt := range []arrow.DataType{
+		&arrow.Decimal128Type{Precision: 38, Scale: 38},
+		&arrow.Decimal128Type{Precision: 5, Scale: -3},
+		&arrow.Decimal256Type{Precision: 76, Scale: 10},
+	} {
+		memo := newMemo()
+		schema := arrow.NewSchema([]arrow.Field{{Name: "d", Type: dt}}, nil)
+
+		b := flatbuffers.NewBuilder(0)
+		b.Finish(schemaToFB(b, schema, &memo))
+
+		fb := flatbuf.GetRootAsSchema(b.FinishedBytes(), 0)
+		got, err := schemaFromFB(fb, &memo, nil)
+		if err != nil {
+			t.Fatalf("%v: %+v", dt, err)
+		}
+		if !got.Equal(schema) {
+			t.Fatalf("r/w schema failed:\ngot = %v\nwant= %v", got, schema)
+		}
+	}
+}
+
 func TestRWFooter(t *testing.T) {
 	for _, tc := range []struct {
 		schema *arrow.Schema
@@ -114,7 +178,7 @@ func TestRWFooter(t *testing.T) {
 				t.Errorf("invalid metadata version: got=%[1]d %#[1]x, want=%[2]d %#[2]x", int16(got), int16(want))
 			}
 
-			schema, err := schemaFromFB(footer.Schema(nil), nil)
+			schema, err := schemaFromFB(footer.Schema(nil), nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"bytes"
+	"sync"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// NewMappedFileReader opens the Arrow file held by data, typically a
+// memory-mapped region of that file. The buffers of the records read alias
+// data instead of being copied to the heap, with the exception of
+// dictionaries and compressed buffers, which are decompressed to the heap.
+//
+// data must be 8-byte aligned and must stay valid until Close returns
+// without error.
+func NewMappedFileReader(data []byte, opts ...Option) (*FileReader, error) {
+	return newMappedFileReader(&mapping{data: data}, opts...)
+}
+
+func newMappedFileReader(m *mapping, opts ...Option) (*FileReader, error) {
+	if len(m.data) > 0 && uintptr(unsafe.Pointer(&m.data[0]))%8 != 0 {
+		return nil, xerrors.Errorf("arrow/ipc: mapped file data is not 8-byte aligned")
+	}
+
+	f, err := NewFileReader(bytes.NewReader(m.data), opts...)
+	if err != nil {
+		return nil, err
+	}
+	f.mapping = m
+	return f, nil
+}
+
+// mapping is the memory of a mapped file, aliased by the buffers of the
+// records read from it. The mapping is unmapped once closed and once all
+// those buffers are released.
+type mapping struct {
+	data  []byte
+	unmap func([]byte) error // nil when the mapping is owned by the user
+
+	mu     sync.Mutex
+	live   int // number of buffers aliasing data
+	closed bool
+}
+
+func (m *mapping) Allocate(size int) []byte {
+	panic("arrow/ipc: mapped file memory can not be allocated")
+}
+
+func (m *mapping) Reallocate(size int, b []byte) []byte {
+	panic("arrow/ipc: mapped file memory can not be reallocated")
+}
+
+// Free releases one of the buffers of m, unmapping it with the last one
+// if m is closed.
+func (m *mapping) Free(b []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.live--
+	if m.live == 0 && m.closed && m.unmap != nil {
+		_ = m.unmap(m.data)
+		m.data = nil
+	}
+}
+
+// close closes m, unmapping it unless buffers still alias it, in which case
+// an error is returned and m is unmapped once they are released.
+func (m *mapping) close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	if m.live > 0 {
+		return xerrors.Errorf("arrow/ipc: %d buffers of mapped file still retained", m.live)
+	}
+	if m.unmap == nil {
+		return nil
+	}
+	err := m.unmap(m.data)
+	m.data = nil
+	return err
+}
+
+// body returns the body of the message stored at data[offset:offset+n].
+func (m *mapping) body(offset, n int64) *mappedBody {
+	data := m.data[offset : offset+n]
+	return &mappedBody{Reader: bytes.NewReader(data), m: m, data: data}
+}
+
+// mappedBody is the body of a message of a mapped file, whose buffers
+// alias the mapping.
+type mappedBody struct {
+	*bytes.Reader
+	m    *mapping
+	data []byte
+
+	// loaded are the buffers of the record being loaded, released once
+	// the arrays of the record hold them.
+	loaded []*memory.Buffer
+}
+
+// buffer returns the n bytes of the body at offset, without copying them.
+func (body *mappedBody) buffer(offset, n int64) *memory.Buffer {
+	switch {
+	case !bitutil.IsMultipleOf8(offset):
+		panic(xerrors.Errorf("arrow/ipc: invalid buffer offset=%d in mapped file", offset))
+	case offset < 0 || n < 0 || offset+n > int64(len(body.data)):
+		panic(xerrors.Errorf("arrow/ipc: buffer [%d, %d) out of the message body of %d bytes", offset, offset+n, len(body.data)))
+	}
+
+	body.m.mu.Lock()
+	body.m.live++
+	body.m.mu.Unlock()
+
+	buf := memory.NewBufferWithAllocator(body.data[offset:offset+n:offset+n], body.m)
+	body.loaded = append(body.loaded, buf)
+	return buf
+}
+
+// releaseLoaded releases the buffers of the record just built, which its
+// arrays now hold.
+func (body *mappedBody) releaseLoaded() {
+	for i, buf := range body.loaded {
+		buf.Release()
+		body.loaded[i] = nil
+	}
+	body.loaded = body.loaded[:0]
+}
+
+var (
+	_ memory.Allocator = (*mapping)(nil)
+)
@@ -0,0 +1,31 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"golang.org/x/xerrors"
+)
+
+// OpenMappedFile memory-maps the Arrow file at path and opens it with
+// NewMappedFileReader. Memory-mapped files are not supported on this
+// platform: use NewMappedFileReader with a region mapped by other means,
+// or NewFileReader.
+func OpenMappedFile(path string, opts ...Option) (*FileReader, error) {
+	return nil, xerrors.Errorf("arrow/ipc: memory-mapped files are not supported on this platform")
+}
@@ -0,0 +1,205 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// checkAliases checks that the buffers of data and of its children alias
+// the memory of buf, and returns their number.
+func checkAliases(t *testing.T, data *array.Data, buf []byte) int {
+	t.Helper()
+
+	n := 0
+	var (
+		beg = uintptr(unsafe.Pointer(&buf[0]))
+		end = beg + uintptr(len(buf))
+	)
+	for _, b := range data.Buffers() {
+		if b == nil || b.Len() == 0 {
+			continue
+		}
+		if p := uintptr(unsafe.Pointer(&b.Bytes()[0])); p < beg || p >= end {
+			t.Fatalf("buffer of %v does not alias the mapped file", data.DataType())
+		}
+		n++
+	}
+	for _, child := range data.Children() {
+		n += checkAliases(t, child, buf)
+	}
+	return n
+}
+
+func writeTempFile(t *testing.T, recs []array.Record, opts ...ipc.Option) string {
+	t.Helper()
+
+	f, err := ioutil.TempFile("", "go-arrow-mmap-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w, err := ipc.NewFileWriter(f, append([]ipc.Option{ipc.WithSchema(recs[0].Schema())}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatalf("could not write record[%d]: %v", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestMappedFile(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			fname := writeTempFile(t, recs)
+			defer os.Remove(fname)
+
+			buf, err := ioutil.ReadFile(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mem := &countingAllocator{Allocator: memory.NewGoAllocator()}
+			r, err := ipc.NewMappedFileReader(buf, ipc.WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				got     []array.Record
+				aliases int
+			)
+			for i := 0; i < r.NumRecords(); i++ {
+				rec, err := r.RecordAt(i)
+				if err != nil {
+					t.Fatalf("could not read record %d: %v", i, err)
+				}
+				got = append(got, rec)
+				if !array.RecordEqual(rec, recs[i]) {
+					t.Fatalf("records[%d] differ", i)
+				}
+				for _, col := range rec.Columns() {
+					aliases += checkAliases(t, col.Data(), buf)
+				}
+			}
+			if mem.n != 0 {
+				t.Fatalf("invalid number of allocations: got=%d, want=0", mem.n)
+			}
+
+			switch err := r.Close(); {
+			case aliases > 0 && err == nil:
+				t.Fatal("expected an error closing a mapped file with retained records")
+			case aliases == 0 && err != nil:
+				t.Fatal(err)
+			}
+			for _, rec := range got {
+				rec.Release()
+			}
+		})
+	}
+}
+
+func TestOpenMappedFile(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+	fname := writeTempFile(t, recs)
+	defer os.Remove(fname)
+
+	r, err := ipc.OpenMappedFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := r.RecordAt(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !array.RecordEqual(rec, recs[0]) {
+		t.Fatalf("records[0] differ")
+	}
+	if err := r.Close(); err == nil {
+		t.Fatal("expected an error closing a mapped file with retained records")
+	}
+
+	// the mapping is kept until the record is released.
+	if !array.RecordEqual(rec, recs[0]) {
+		t.Fatalf("records[0] differ after close")
+	}
+	rec.Release()
+
+	r, err = ipc.OpenMappedFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < r.NumRecords(); i++ {
+		if _, err := r.Record(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMappedFileCompressed(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+	fname := writeTempFile(t, recs, ipc.WithCompression(ipc.CompressionZstd, 0))
+	defer os.Remove(fname)
+
+	buf, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewMappedFileReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < r.NumRecords(); i++ {
+		rec, err := r.RecordAt(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !array.RecordEqual(rec, recs[i]) {
+			t.Fatalf("records[%d] differ", i)
+		}
+		rec.Release()
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	unaligned := make([]byte, len(buf)+1)
+	copy(unaligned[1:], buf)
+	if _, err := ipc.NewMappedFileReader(unaligned[1:]); err == nil {
+		t.Fatal("expected an error opening unaligned data")
+	}
+}
@@ -0,0 +1,57 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package ipc // import "github.com/apache/arrow/go/arrow/ipc"
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/xerrors"
+)
+
+// OpenMappedFile memory-maps the Arrow file at path and opens it with
+// NewMappedFileReader. The file is unmapped once the reader is closed and
+// all the records read from it are released.
+func OpenMappedFile(path string, opts ...Option) (*FileReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: could not open mapped file: %w", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: could not stat mapped file: %w", err)
+	}
+	if fi.Size() == 0 {
+		return nil, xerrors.Errorf("arrow/ipc: file too small (size=0)")
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: could not map file: %w", err)
+	}
+
+	r, err := newMappedFileReader(&mapping{data: data, unmap: syscall.Munmap}, opts...)
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	return r, nil
+}
@@ -43,7 +43,14 @@ type Reader struct {
 	types dictTypeMap
 	memo  dictMemo
 
-	mem memory.Allocator
+	mem     memory.Allocator
+	limits  limits
+	workers int                          // number of goroutines decompressing buffers
+	exts    *arrow.ExtensionTypeRegistry // extension types reconstructed, with the global ones
+
+	// pool and body are reused across records, see WithBufferReuse.
+	pool *bufferPool
+	body bytes.Reader
 
 	done bool
 }
@@ -58,10 +65,17 @@ func NewReaderFromMessageReader(r MessageReader, opts ...Option) (*Reader, error
 	}
 
 	rr := &Reader{
-		r:     r,
-		types: make(dictTypeMap),
-		memo:  newMemo(),
-		mem:   cfg.alloc,
+		r:        r,
+		refCount: 1,
+		types:    make(dictTypeMap),
+		memo:     newMemo(),
+		mem:      cfg.alloc,
+		limits:   cfg.limits,
+		workers:  cfg.codec.concurrency,
+		exts:     cfg.exts,
+	}
+	if cfg.reuseBuffers {
+		rr.pool = newBufferPool(cfg.alloc)
 	}
 
 	err := rr.readSchema(cfg.schema)
@@ -74,7 +88,7 @@ func NewReaderFromMessageReader(r MessageReader, opts ...Option) (*Reader, error
 
 // NewReader returns a reader that reads records from an input stream.
 func NewReader(r io.Reader, opts ...Option) (*Reader, error) {
-	return NewReaderFromMessageReader(NewMessageReader(r), opts...)
+	return NewReaderFromMessageReader(NewMessageReader(r, opts...), opts...)
 }
 
 // Err returns the last error encountered during the iteration over the
@@ -83,11 +97,16 @@ func (r *Reader) Err() error { return r.err }
 
 func (r *Reader) Schema() *arrow.Schema { return r.schema }
 
-func (r *Reader) readSchema(schema *arrow.Schema) error {
+func (r *Reader) readSchema(schema *arrow.Schema) (err error) {
+	defer recoverDecode(&err)
+
 	msg, err := r.r.Message()
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could not read message schema: %w", err)
 	}
+	if err := r.checkMessage(msg); err != nil {
+		return err
+	}
 
 	if msg.Type() != MessageSchema {
 		return xerrors.Errorf("arrow/ipc: invalid message type (got=%v, want=%v)", msg.Type(), MessageSchema)
@@ -97,18 +116,19 @@ func (r *Reader) readSchema(schema *arrow.Schema) error {
 	var schemaFB flatbuf.Schema
 	initFB(&schemaFB, msg.msg.Header)
 
-	r.types, err = dictTypesFromFB(&schemaFB)
+	if err := checkNestingFB(&schemaFB, r.limits.nestingDepth); err != nil {
+		return err
+	}
+
+	r.types, err = dictTypesFromFB(&schemaFB, r.exts)
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could read dictionary types from message schema: %w", err)
 	}
 
-	// TODO(sbinet): in the future, we may want to reconcile IDs in the stream with
-	// those found in the schema.
-	for range r.types {
-		panic("not implemented") // FIXME(sbinet): ReadNextDictionary
-	}
+	// the dictionaries are read as they come in the stream, before the
+	// records using them, see readDictionary.
 
-	r.schema, err = schemaFromFB(&schemaFB, &r.memo)
+	r.schema, err = schemaFromFB(&schemaFB, &r.memo, r.exts)
 	if err != nil {
 		return xerrors.Errorf("arrow/ipc: could not decode schema from message schema: %w", err)
 	}
@@ -121,6 +141,18 @@ func (r *Reader) readSchema(schema *arrow.Schema) error {
 	return nil
 }
 
+// checkMessage checks that msg is valid and within the limits of the
+// reader, for the messages of readers which do not check them.
+func (r *Reader) checkMessage(msg *Message) error {
+	if msg.err != nil {
+		return msg.err
+	}
+	if err := r.limits.checkMetadata(int64(msg.meta.Len())); err != nil {
+		return err
+	}
+	return r.limits.checkBody(int64(msg.body.Len()))
+}
+
 // Retain increases the reference count by 1.
 // Retain may be called simultaneously from multiple goroutines.
 func (r *Reader) Retain() {
@@ -142,6 +174,10 @@ func (r *Reader) Release() {
 			r.r.Release()
 			r.r = nil
 		}
+		r.memo.delete()
+		if r.pool != nil {
+			r.pool.close()
+		}
 	}
 }
 
@@ -161,22 +197,64 @@ func (r *Reader) Next() bool {
 
 func (r *Reader) next() bool {
 	var msg *Message
-	msg, r.err = r.r.Message()
-	if r.err != nil {
-		r.done = true
-		if r.err == io.EOF {
-			r.err = nil
+	for {
+		msg, r.err = r.r.Message()
+		if r.err != nil {
+			r.done = true
+			if r.err == io.EOF {
+				r.err = nil
+			}
+			return false
+		}
+		if r.err = r.checkMessage(msg); r.err != nil {
+			return false
+		}
+
+		if msg.Type() != MessageDictionaryBatch {
+			break
+		}
+		if r.err = r.readDictionary(msg); r.err != nil {
+			return false
 		}
-		return false
 	}
 
 	if got, want := msg.Type(), MessageRecordBatch; got != want {
 		r.err = xerrors.Errorf("arrow/ipc: invalid message type (got=%v, want=%v", got, want)
 		return false
 	}
+	if id, ok := r.memo.missingDictionary(); ok {
+		r.err = xerrors.Errorf("arrow/ipc: record batch before the dictionary with ID=%d", id)
+		return false
+	}
 
-	r.rec = newRecord(r.schema, msg.meta, bytes.NewReader(msg.body.Bytes()))
-	return true
+	if r.pool != nil {
+		r.body.Reset(msg.body.Bytes())
+		r.rec, r.err = newRecord(r.schema, &r.memo, msg.meta, &r.body, r.pool, r.limits, r.workers)
+	} else {
+		r.rec, r.err = newRecord(r.schema, &r.memo, msg.meta, bytes.NewReader(msg.body.Bytes()), nil, r.limits, r.workers)
+	}
+	return r.err == nil
+}
+
+// readDictionary reads the dictionary batch msg, which replaces the
+// dictionary of its ID or, for a delta, appends values to it. The records
+// read before keep the dictionary they were read with.
+func (r *Reader) readDictionary(msg *Message) error {
+	var (
+		body ReadAtSeeker = bytes.NewReader(msg.body.Bytes())
+		mem               = r.mem
+	)
+	if r.pool != nil {
+		r.body.Reset(msg.body.Bytes())
+		body, mem = &r.body, r.pool
+	}
+
+	id, dict, isDelta, err := readDictionary(msg.meta, r.types, body, r.pool, r.limits, r.workers)
+	if err != nil {
+		return xerrors.Errorf("arrow/ipc: could not read dictionary: %w", err)
+	}
+	defer dict.Release()
+	return updateDictionary(&r.memo, id, dict, isDelta, mem)
 }
 
 // Record returns the current record that has been extracted from the
@@ -17,13 +17,28 @@
 package ipc_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"os"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
 	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/apache/arrow/go/arrow/memory"
+	flatbuffers "github.com/google/flatbuffers/go"
+	"golang.org/x/xerrors"
 )
 
 func TestStream(t *testing.T) {
@@ -60,3 +75,887 @@ func TestStream(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamSlicedRecords(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			var slices []array.Record
+			for _, rec := range recs {
+				n := rec.NumRows()
+				if n == 0 {
+					continue
+				}
+				for _, rng := range [][2]int64{{1, n}, {0, n - 1}, {n / 2, n/2 + 1}} {
+					slice := rec.NewSlice(rng[0], rng[1])
+					defer slice.Release()
+					slices = append(slices, slice)
+				}
+			}
+
+			var buf bytes.Buffer
+			w := ipc.NewWriter(&buf, ipc.WithSchema(recs[0].Schema()), ipc.WithAllocator(mem))
+			for i, rec := range slices {
+				if err := w.Write(rec); err != nil {
+					t.Fatalf("could not write record[%d]: %v", i, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			n := 0
+			for r.Next() {
+				if !array.RecordEqual(r.Record(), slices[n]) {
+					t.Fatalf("records[%d] differ", n)
+				}
+				n++
+			}
+			if n != len(slices) {
+				t.Fatalf("invalid number of records. got=%d, want=%d", n, len(slices))
+			}
+		})
+	}
+}
+
+func TestStreamMaxMessageSize(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	bldr := array.NewRecordBuilder(mem, schema)
+	defer bldr.Release()
+	for i := 0; i < 1000; i++ {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i))
+		if i%7 == 0 {
+			bldr.Field(1).(*array.StringBuilder).AppendNull()
+			continue
+		}
+		bldr.Field(1).(*array.StringBuilder).Append(strings.Repeat("x", i%13))
+	}
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	const max = 2048
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(mem), ipc.WithMaxMessageSize(max))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	var (
+		n    int
+		rows int64
+	)
+	for r.Next() {
+		got := r.Record()
+		want := rec.NewSlice(rows, rows+got.NumRows())
+		if !array.RecordEqual(got, want) {
+			t.Errorf("records[%d] differ", n)
+		}
+		want.Release()
+		rows += got.NumRows()
+		n++
+	}
+	if rows != rec.NumRows() {
+		t.Fatalf("invalid number of rows. got=%d, want=%d", rows, rec.NumRows())
+	}
+	if n < 2 {
+		t.Fatalf("record was not split: got %d records", n)
+	}
+
+	w = ipc.NewWriter(ioutil.Discard, ipc.WithSchema(schema), ipc.WithAllocator(mem), ipc.WithMaxMessageSize(64))
+	err = w.Write(rec)
+	if err == nil || !strings.Contains(err.Error(), "single row") {
+		t.Fatalf("expected a single row error, got %v", err)
+	}
+	w.Close()
+}
+
+// countingAllocator counts the allocations of its allocator.
+type countingAllocator struct {
+	memory.Allocator
+	n int
+}
+
+func (a *countingAllocator) Allocate(size int) []byte {
+	a.n++
+	return a.Allocator.Allocate(size)
+}
+
+func TestStreamBufferReuse(t *testing.T) {
+	for _, codec := range []ipc.CompressionType{ipc.CompressionNone, ipc.CompressionLZ4Frame} {
+		for name, recs := range arrdata.Records {
+			t.Run(codec.String()+"/"+name, func(t *testing.T) {
+				mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+				defer mem.AssertSize(t, 0)
+
+				var buf bytes.Buffer
+				w := ipc.NewWriter(&buf, ipc.WithSchema(recs[0].Schema()), ipc.WithAllocator(mem), ipc.WithCompression(codec, 0))
+				for _, rec := range recs {
+					if err := w.Write(rec); err != nil {
+						t.Fatal(err)
+					}
+				}
+				// read the records twice, the second time with the
+				// memory of the first.
+				for _, rec := range recs {
+					if err := w.Write(rec); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem), ipc.WithBufferReuse(), ipc.WithCompressionConcurrency(2))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var (
+					n        int
+					retained array.Record
+				)
+				for r.Next() {
+					if !array.RecordEqual(r.Record(), recs[n%len(recs)]) {
+						t.Fatalf("records[%d] differ", n)
+					}
+					if n == 0 {
+						retained = r.Record()
+						retained.Retain()
+					}
+					n++
+				}
+				if err := r.Err(); err != nil {
+					t.Fatal(err)
+				}
+				if n != 2*len(recs) {
+					t.Fatalf("invalid number of records. got=%d, want=%d", n, 2*len(recs))
+				}
+				r.Release()
+
+				// the retained record keeps its memory.
+				if !array.RecordEqual(retained, recs[0]) {
+					t.Fatalf("retained record differs")
+				}
+				retained.Release()
+			})
+		}
+	}
+}
+
+func TestStreamBufferReuseAllocations(t *testing.T) {
+	mem := &countingAllocator{Allocator: memory.NewGoAllocator()}
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	bldr := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer bldr.Release()
+	for i := 0; i < 100; i++ {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i))
+		bldr.Field(1).(*array.StringBuilder).Append("value")
+	}
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	const nrecs = 50
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema))
+	for i := 0; i < nrecs; i++ {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem), ipc.WithBufferReuse())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	n := 0
+	for r.Next() {
+		n++
+	}
+	if n != nrecs {
+		t.Fatalf("invalid number of records. got=%d, want=%d", n, nrecs)
+	}
+	// the buffers of the first record are allocated, along with those of
+	// the second, read before the first is released.
+	if max := 2 * 4; mem.n > max {
+		t.Fatalf("too many allocations: got=%d, want<=%d", mem.n, max)
+	}
+}
+
+func streamBytes(t *testing.T, recs []array.Record, opts ...ipc.Option) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, append([]ipc.Option{ipc.WithSchema(recs[0].Schema())}, opts...)...)
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readStream reads all the records of the stream in buf, returning the
+// first error.
+func readStream(buf []byte, opts ...ipc.Option) error {
+	r, err := ipc.NewReader(bytes.NewReader(buf), opts...)
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+
+	for r.Next() {
+	}
+	return r.Err()
+}
+
+func TestStreamLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		opt   ipc.Option
+		limit string
+	}{
+		{"structs", ipc.WithMaxMetadataSize(64), "metadata size"},
+		{"primitives", ipc.WithMaxBodySize(16), "body size"},
+		{"lists", ipc.WithMaxNestingDepth(0), "nesting depth"},
+		{"structs", ipc.WithMaxNestingDepth(0), "nesting depth"},
+		{"primitives", ipc.WithMaxBuffers(2), "number of buffers"},
+	} {
+		t.Run(tc.limit, func(t *testing.T) {
+			buf := streamBytes(t, arrdata.Records[tc.name])
+			if err := readStream(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			err := readStream(buf, tc.opt)
+			var lerr *ipc.LimitError
+			if !xerrors.As(err, &lerr) {
+				t.Fatalf("expected a limit error, got %v", err)
+			}
+			if lerr.Limit != tc.limit {
+				t.Fatalf("invalid limit: got=%q, want=%q", lerr.Limit, tc.limit)
+			}
+		})
+	}
+}
+
+// checkCorrupted reads the corruptions of buf with read, checking that
+// reading neither panics nor allocates more than the limits of the reader
+// allow.
+func checkCorrupted(t *testing.T, buf []byte, read func([]byte, ...ipc.Option) error) {
+	t.Helper()
+
+	const max = 1 << 20
+	opts := []ipc.Option{ipc.WithMaxMetadataSize(max), ipc.WithMaxBodySize(max)}
+
+	check := func(what string, buf []byte) {
+		t.Helper()
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		func() {
+			defer func() {
+				if e := recover(); e != nil {
+					t.Fatalf("%s: panic: %v", what, e)
+				}
+			}()
+			read(buf, opts...)
+		}()
+		runtime.ReadMemStats(&after)
+		if n := after.TotalAlloc - before.TotalAlloc; n > 16*max {
+			t.Fatalf("%s: %d bytes allocated", what, n)
+		}
+	}
+
+	for n := 0; n < len(buf); n += 3 {
+		check("truncated", buf[:n])
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	corrupted := make([]byte, len(buf))
+	for i := 0; i < 500; i++ {
+		copy(corrupted, buf)
+		for j := 0; j < 1+i%4; j++ {
+			corrupted[rnd.Intn(len(corrupted))] = byte(rnd.Intn(256))
+		}
+		check("corrupted", corrupted)
+	}
+}
+
+func TestStreamCorrupted(t *testing.T) {
+	for _, name := range arrdata.RecordNames {
+		t.Run(name, func(t *testing.T) {
+			checkCorrupted(t, streamBytes(t, arrdata.Records[name]), readStream)
+		})
+	}
+}
+
+func TestStreamTruncatedBody(t *testing.T) {
+	buf := streamBytes(t, arrdata.Records["primitives"])
+
+	// declare a large body in the first record batch, and drop the
+	// bytes of the stream after its metadata.
+	var end int
+	for pos := 0; ; {
+		var (
+			start = pos + 8
+			size  = int(binary.LittleEndian.Uint32(buf[pos+4:]))
+			msg   = flatbuf.GetRootAsMessage(buf[start:start+size], 0)
+		)
+		if msg.HeaderType() == flatbuf.MessageHeaderRecordBatch {
+			msg.MutateBodyLength(200 << 20)
+			end = start + size + 64
+			break
+		}
+		pos = start + size + int(msg.BodyLength())
+	}
+	buf = buf[:end]
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	err := readStream(buf)
+	runtime.ReadMemStats(&after)
+	if err == nil {
+		t.Fatalf("expected an error reading a truncated stream")
+	}
+	if n := after.TotalAlloc - before.TotalAlloc; n > 1<<20 {
+		t.Fatalf("%d bytes allocated reading a stream of %d bytes", n, len(buf))
+	}
+}
+
+func TestStreamSchemaFields(t *testing.T) {
+	buf := streamBytes(t, arrdata.Records["structs"])
+
+	// declare more fields than the schema message holds.
+	var (
+		size = int(binary.LittleEndian.Uint32(buf[4:]))
+		meta = buf[8 : 8+size]
+		msg  = flatbuf.GetRootAsMessage(meta, 0)
+		tbl  flatbuffers.Table
+	)
+	if !msg.Header(&tbl) {
+		t.Fatalf("could not read the schema message")
+	}
+	var schema flatbuf.Schema
+	schema.Init(tbl.Bytes, tbl.Pos)
+	tab := schema.Table()
+	o := flatbuffers.UOffsetT(tab.Offset(6))
+	binary.LittleEndian.PutUint32(meta[tab.Vector(o)-4:], 1<<28)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	err := readStream(buf)
+	runtime.ReadMemStats(&after)
+	if err == nil || !strings.Contains(err.Error(), "invalid number of schema fields") {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if n := after.TotalAlloc - before.TotalAlloc; n > 1<<20 {
+		t.Fatalf("%d bytes allocated reading a stream of %d bytes", n, len(buf))
+	}
+}
+
+func TestStreamEnvelope(t *testing.T) {
+	recs := arrdata.Records["primitives"]
+	for _, tc := range []struct {
+		legacy bool
+		align  int
+		prefix []byte // the prefix of the size of the metadata
+		eos    []byte
+	}{
+		{false, 8, []byte{0xff, 0xff, 0xff, 0xff}, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}},
+		{false, 64, []byte{0xff, 0xff, 0xff, 0xff}, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}},
+		{true, 8, []byte{}, []byte{0, 0, 0, 0}},
+		{true, 64, []byte{}, []byte{0, 0, 0, 0}},
+	} {
+		t.Run(fmt.Sprintf("legacy=%v/align=%d", tc.legacy, tc.align), func(t *testing.T) {
+			buf := streamBytes(t, recs, ipc.WithLegacyFormat(tc.legacy), ipc.WithBufferAlignment(tc.align))
+
+			var types []ipc.MessageType
+			for pos := 0; ; {
+				rest := buf[pos:]
+				if bytes.Equal(rest, tc.eos) {
+					break
+				}
+				if !bytes.HasPrefix(rest, tc.prefix) {
+					t.Fatalf("message at %d: invalid prefix % x", pos, rest[:8])
+				}
+				var (
+					start = len(tc.prefix) + 4
+					size  = int(binary.LittleEndian.Uint32(rest[len(tc.prefix):]))
+				)
+				if (start+size)%tc.align != 0 {
+					t.Fatalf("message at %d: metadata of %d bytes not padded to %d bytes", pos, size, tc.align)
+				}
+
+				msg := flatbuf.GetRootAsMessage(rest[start:start+size], 0)
+				types = append(types, ipc.MessageType(msg.HeaderType()))
+				if msg.BodyLength()%int64(tc.align) != 0 {
+					t.Fatalf("message at %d: body of %d bytes not padded to %d bytes", pos, msg.BodyLength(), tc.align)
+				}
+				if msg.HeaderType() == flatbuf.MessageHeaderRecordBatch {
+					var (
+						tbl flatbuffers.Table
+						md  flatbuf.RecordBatch
+						b   flatbuf.Buffer
+					)
+					msg.Header(&tbl)
+					md.Init(tbl.Bytes, tbl.Pos)
+					for i := 0; i < md.BuffersLength(); i++ {
+						md.Buffers(&b, i)
+						if b.Offset()%int64(tc.align) != 0 {
+							t.Fatalf("message at %d: buffer %d at offset %d not aligned to %d bytes", pos, i, b.Offset(), tc.align)
+						}
+					}
+				}
+				pos += start + size + int(msg.BodyLength())
+			}
+			if got, want := len(types), 1+len(recs); got != want {
+				t.Fatalf("invalid number of messages: got=%d, want=%d", got, want)
+			}
+
+			r, err := ipc.NewReader(bytes.NewReader(buf))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+			n := 0
+			for ; r.Next(); n++ {
+				if !array.RecordEqual(r.Record(), recs[n]) {
+					t.Fatalf("records[%d] differ", n)
+				}
+			}
+			if err := r.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if n != len(recs) {
+				t.Fatalf("invalid number of records. got=%d, want=%d", n, len(recs))
+			}
+		})
+	}
+
+	w := ipc.NewWriter(ioutil.Discard, ipc.WithSchema(recs[0].Schema()), ipc.WithBufferAlignment(16))
+	if err := w.Write(recs[0]); err == nil {
+		t.Fatal("expected an error with an invalid buffer alignment")
+	}
+}
+
+func TestStreamRunEndEncoded(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ree", Type: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String), Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	rb := b.Field(0).(*array.RunEndEncodedBuilder)
+	vb := rb.ValueBuilder().(*array.StringBuilder)
+	rb.AppendRuns([]uint64{3, 1, 2})
+	vb.AppendValues([]string{"a", "", "b"}, []bool{true, false, true})
+	rb.ContinueRun(4)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	recs := []array.Record{rec}
+	for _, rng := range [][2]int64{{1, 10}, {2, 5}, {4, 6}, {0, 3}} {
+		slice := rec.NewSlice(rng[0], rng[1])
+		defer slice.Release()
+		recs = append(recs, slice)
+	}
+
+	buf := streamBytes(t, recs, ipc.WithAllocator(mem))
+
+	// run-end encoded arrays have no buffers of their own, only their run
+	// ends and values have.
+	{
+		var (
+			pos = 8 + int(binary.LittleEndian.Uint32(buf[4:])) // skip the schema.
+			end = pos + 8 + int(binary.LittleEndian.Uint32(buf[pos+4:]))
+			msg = flatbuf.GetRootAsMessage(buf[pos+8:end], 0)
+			tbl flatbuffers.Table
+			md  flatbuf.RecordBatch
+		)
+		msg.Header(&tbl)
+		md.Init(tbl.Bytes, tbl.Pos)
+		if got, want := md.NodesLength(), 3; got != want {
+			t.Fatalf("invalid number of field nodes: got=%d, want=%d", got, want)
+		}
+		if got, want := md.BuffersLength(), 2+3; got != want {
+			t.Fatalf("invalid number of buffers: got=%d, want=%d", got, want)
+		}
+	}
+
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), schema)
+	}
+
+	n := 0
+	for ; r.Next(); n++ {
+		got, want := r.Record().Column(0).(*array.RunEndEncoded), recs[n].Column(0).(*array.RunEndEncoded)
+		if !array.ArrayEqual(got, want) {
+			t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", n, got, want)
+		}
+		// only the runs of the slices are sent.
+		if got, want := got.RunEndsArr().Len(), want.GetPhysicalLength(); got != want {
+			t.Fatalf("records[%d]: invalid number of runs: got=%d, want=%d", n, got, want)
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records. got=%d, want=%d", n, len(recs))
+	}
+}
+
+func TestStreamUnion(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// the type codes are not contiguous and the float64 child is empty.
+	fields := []arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}
+	codes := []arrow.UnionTypeCode{2, 7, 5}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "sparse", Type: arrow.SparseUnionOf(fields, codes)},
+		{Name: "dense", Type: arrow.DenseUnionOf(fields, codes)},
+		{Name: "list", Type: arrow.ListOf(arrow.DenseUnionOf(fields, codes)), Nullable: true},
+	}, nil)
+
+	type unionBuilder interface {
+		Append(arrow.UnionTypeCode) array.Builder
+		AppendNull()
+	}
+	appendValue := func(b unionBuilder, i int) {
+		switch i % 3 {
+		case 0:
+			b.Append(2).(*array.Int32Builder).Append(int32(i))
+		case 1:
+			b.Append(7).(*array.StringBuilder).Append(fmt.Sprint(i))
+		default:
+			b.AppendNull()
+		}
+	}
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	lb := b.Field(2).(*array.ListBuilder)
+	for i := 0; i < 10; i++ {
+		appendValue(b.Field(0).(*array.SparseUnionBuilder), i)
+		appendValue(b.Field(1).(*array.DenseUnionBuilder), i)
+		lb.Append(true)
+		for j := 0; j < i%3; j++ {
+			appendValue(lb.ValueBuilder().(*array.DenseUnionBuilder), i+j)
+		}
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	recs := []array.Record{rec}
+	for _, rng := range [][2]int64{{1, 10}, {2, 5}, {4, 6}, {3, 3}} {
+		slice := rec.NewSlice(rng[0], rng[1])
+		defer slice.Release()
+		recs = append(recs, slice)
+	}
+
+	buf := streamBytes(t, recs, ipc.WithAllocator(mem))
+
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), schema)
+	}
+
+	n := 0
+	for ; r.Next(); n++ {
+		if !array.RecordEqual(r.Record(), recs[n]) {
+			t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", n, r.Record(), recs[n])
+		}
+		// only the values of the slices are sent.
+		dense := r.Record().Column(1).(*array.DenseUnion)
+		var size int
+		for i := 0; i < dense.NumFields(); i++ {
+			size += dense.Field(i).Len()
+		}
+		if got, want := size, dense.Len(); got != want {
+			t.Fatalf("records[%d]: invalid number of dense union values: got=%d, want=%d", n, got, want)
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records. got=%d, want=%d", n, len(recs))
+	}
+}
+
+func TestStreamBinaryView(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "s", Type: arrow.BinaryTypes.StringView, Nullable: true},
+		{Name: "b", Type: arrow.BinaryTypes.BinaryView, Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	sb := b.Field(0).(*array.StringViewBuilder)
+	bb := b.Field(1).(*array.BinaryViewBuilder)
+	for i := 0; i < 100; i++ {
+		switch {
+		case i%7 == 0:
+			sb.AppendNull()
+			bb.AppendNull()
+		default:
+			sb.Append(strings.Repeat("s", i))
+			bb.Append(bytes.Repeat([]byte{byte(i)}, 500*i))
+		}
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	if n := len(rec.Column(1).(*array.BinaryView).DataBuffers()); n < 2 {
+		t.Fatalf("binary views should span several data buffers, got %d", n)
+	}
+
+	recs := []array.Record{rec}
+	for _, rng := range [][2]int64{{1, 10}, {7, 8}, {50, 100}, {0, 0}} {
+		slice := rec.NewSlice(rng[0], rng[1])
+		defer slice.Release()
+		recs = append(recs, slice)
+	}
+
+	buf := streamBytes(t, recs, ipc.WithAllocator(mem))
+
+	// the number of data buffers of each view array follows the views.
+	{
+		var (
+			pos = 8 + int(binary.LittleEndian.Uint32(buf[4:])) // skip the schema.
+			end = pos + 8 + int(binary.LittleEndian.Uint32(buf[pos+4:]))
+			msg = flatbuf.GetRootAsMessage(buf[pos+8:end], 0)
+			tbl flatbuffers.Table
+			md  flatbuf.RecordBatch
+		)
+		msg.Header(&tbl)
+		md.Init(tbl.Bytes, tbl.Pos)
+
+		counts := []int64{
+			int64(len(rec.Column(0).(*array.StringView).DataBuffers())),
+			int64(len(rec.Column(1).(*array.BinaryView).DataBuffers())),
+		}
+		if got, want := md.VariadicBufferCountsLength(), len(counts); got != want {
+			t.Fatalf("invalid number of variadic buffer counts: got=%d, want=%d", got, want)
+		}
+		for i, want := range counts {
+			if got := md.VariadicBufferCounts(i); got != want {
+				t.Fatalf("invalid variadic buffer count %d: got=%d, want=%d", i, got, want)
+			}
+		}
+		if got, want := md.BuffersLength(), 2*2+int(counts[0]+counts[1]); got != want {
+			t.Fatalf("invalid number of buffers: got=%d, want=%d", got, want)
+		}
+	}
+
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), schema)
+	}
+
+	n := 0
+	for ; r.Next(); n++ {
+		if !array.RecordEqual(r.Record(), recs[n]) {
+			t.Fatalf("records[%d] differ:\ngot= %v\nwant=%v", n, r.Record(), recs[n])
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(recs) {
+		t.Fatalf("invalid number of records. got=%d, want=%d", n, len(recs))
+	}
+}
+
+func TestStreamDecimal256(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "d128", Type: &arrow.Decimal128Type{Precision: 38, Scale: 2}},
+		{Name: "d256", Type: &arrow.Decimal256Type{Precision: 76, Scale: 2}, Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(1), decimal128.FromI64(-2), decimal128.New(1, 2)}, nil)
+	b.Field(1).(*array.Decimal256Builder).AppendValues([]decimal256.Num{decimal256.FromI64(1), decimal256.FromI64(-2), decimal256.New(4, 3, 2, 1)}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	buf := streamBytes(t, []array.Record{rec}, ipc.WithAllocator(mem))
+
+	// the schema holds the bit width of the decimals, 128 by default.
+	pos := 8 + int(binary.LittleEndian.Uint32(buf[4:]))
+	{
+		var (
+			msg    = flatbuf.GetRootAsMessage(buf[8:pos], 0)
+			tbl    flatbuffers.Table
+			fbs    flatbuf.Schema
+			widths []int32
+		)
+		msg.Header(&tbl)
+		fbs.Init(tbl.Bytes, tbl.Pos)
+		for i := 0; i < fbs.FieldsLength(); i++ {
+			var (
+				field flatbuf.Field
+				dec   flatbuf.Decimal
+			)
+			fbs.Fields(&field, i)
+			field.Type(&tbl)
+			dec.Init(tbl.Bytes, tbl.Pos)
+			widths = append(widths, dec.BitWidth())
+		}
+		if got, want := fmt.Sprint(widths), "[128 256]"; got != want {
+			t.Fatalf("invalid bit widths: got=%s, want=%s", got, want)
+		}
+	}
+
+	// decimal256 values are four 64-bit little-endian words, from the
+	// least to the most significant one.
+	{
+		var (
+			end  = pos + 8 + int(binary.LittleEndian.Uint32(buf[pos+4:]))
+			msg  = flatbuf.GetRootAsMessage(buf[pos+8:end], 0)
+			tbl  flatbuffers.Table
+			md   flatbuf.RecordBatch
+			desc flatbuf.Buffer
+		)
+		msg.Header(&tbl)
+		md.Init(tbl.Bytes, tbl.Pos)
+		md.Buffers(&desc, 3)
+
+		want := make([]byte, 3*32)
+		want[0] = 0x01
+		want[32] = 0xfe
+		for i := 33; i < 64; i++ {
+			want[i] = 0xff
+		}
+		want[64], want[72], want[80], want[88] = 1, 2, 3, 4
+		if got := buf[end+int(desc.Offset()) : end+int(desc.Offset()+desc.Length())]; !bytes.Equal(got[:len(want)], want) {
+			t.Fatalf("invalid decimal256 layout:\ngot= %x\nwant=%x", got, want)
+		}
+	}
+
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), schema)
+	}
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	if !array.RecordEqual(r.Record(), rec) {
+		t.Fatalf("invalid record:\ngot= %v\nwant=%v", r.Record(), rec)
+	}
+}
+
+func TestStreamFloat16(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "f16", Type: arrow.FixedWidthTypes.Float16, Nullable: true},
+	}, nil)
+
+	// subnormals, infinities, signed zeros and NaN payloads are kept bit
+	// for bit.
+	bits := []uint16{0x0001, 0x03ff, 0x0400, 0x7bff, 0x7c00, 0xfc00, 0x8000, 0x7e01, 0xfd00, 0x3c00}
+	vals := make([]float16.Num, len(bits))
+	for i, v := range bits {
+		vals[i] = float16.FromBits(v)
+	}
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.Float16Builder).AppendValues(vals, nil)
+	b.Field(0).AppendNull()
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	buf := streamBytes(t, []array.Record{rec}, ipc.WithAllocator(mem))
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	arr := r.Record().Column(0).(*array.Float16)
+	if arr.Len() != len(bits)+1 || !arr.IsNull(len(bits)) {
+		t.Fatalf("invalid array: %v", arr)
+	}
+	for i, v := range arr.Values()[:len(bits)] {
+		if v.Uint16() != bits[i] {
+			t.Fatalf("value %d: got=%#04x, want=%#04x", i, v.Uint16(), bits[i])
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/tensor"
+	flatbuffers "github.com/google/flatbuffers/go"
+	"golang.org/x/xerrors"
+)
+
+// WriteTensor writes t to w as an encapsulated Tensor IPC message, whose
+// body is padded to 64 bytes. Tensors which are not contiguous are written
+// in row-major order.
+func WriteTensor(w io.Writer, t tensor.Interface, opts ...Option) error {
+	cfg := newConfig(opts...)
+	if !t.IsContiguous() {
+		t = tensor.ConvertLayout(t, tensor.RowMajor, cfg.alloc)
+		defer t.Release()
+	}
+
+	var (
+		data = t.Data()
+		bw   = int64(byteWidth(t.DataType().(arrow.FixedWidthDataType)))
+		beg  = int64(data.Offset()) * bw
+		size = int64(t.Len()) * bw
+		body = memory.NewBufferBytes(nil)
+	)
+	if size > 0 {
+		body = memory.NewBufferBytes(data.Buffers()[1].Bytes()[beg : beg+size])
+	}
+
+	p := Payload{
+		msg:   MessageTensor,
+		meta:  writeTensorMessage(t, paddedLength(size, kTensorAlignment), cfg.alloc),
+		body:  []*memory.Buffer{body},
+		size:  paddedLength(size, kTensorAlignment),
+		align: kTensorAlignment,
+	}
+	defer p.Release()
+
+	if _, err := writeIPCPayload(w, p, cfg.legacy); err != nil {
+		return xerrors.Errorf("arrow/ipc: could not write tensor: %w", err)
+	}
+	return nil
+}
+
+func writeTensorMessage(t tensor.Interface, bodyLength int64, mem memory.Allocator) *memory.Buffer {
+	b := flatbuffers.NewBuilder(1024)
+
+	fv := fieldVisitor{b: b, meta: make(map[string]string)}
+	fv.visit(arrow.Field{Type: t.DataType()})
+
+	dims := make([]flatbuffers.UOffsetT, t.NumDims())
+	for i, size := range t.Shape() {
+		var name flatbuffers.UOffsetT
+		if t.DimNames() != nil {
+			name = b.CreateString(t.DimName(i))
+		}
+		flatbuf.TensorDimStart(b)
+		flatbuf.TensorDimAddSize(b, size)
+		if t.DimNames() != nil {
+			flatbuf.TensorDimAddName(b, name)
+		}
+		dims[i] = flatbuf.TensorDimEnd(b)
+	}
+	flatbuf.TensorStartShapeVector(b, len(dims))
+	for i := len(dims) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(dims[i])
+	}
+	shapeFB := b.EndVector(len(dims))
+
+	strides := t.Strides()
+	flatbuf.TensorStartStridesVector(b, len(strides))
+	for i := len(strides) - 1; i >= 0; i-- {
+		b.PrependInt64(strides[i])
+	}
+	stridesFB := b.EndVector(len(strides))
+
+	bw := int64(byteWidth(t.DataType().(arrow.FixedWidthDataType)))
+
+	flatbuf.TensorStart(b)
+	flatbuf.TensorAddTypeType(b, fv.dtype)
+	flatbuf.TensorAddType(b, fv.offset)
+	flatbuf.TensorAddShape(b, shapeFB)
+	flatbuf.TensorAddStrides(b, stridesFB)
+	flatbuf.TensorAddData(b, flatbuf.CreateBuffer(b, 0, int64(t.Len())*bw))
+	tensorFB := flatbuf.TensorEnd(b)
+
+	return writeMessageFB(b, mem, flatbuf.MessageHeaderTensor, tensorFB, bodyLength)
+}
+
+// ReadTensor reads a tensor from the encapsulated Tensor IPC message of r.
+// The shape, strides and data of the tensor are validated against the
+// body of the message, whose memory is allocated from the allocator of
+// WithAllocator.
+//
+// The returned tensor must be Release()'d after use.
+func ReadTensor(r io.Reader, opts ...Option) (tensor.Interface, error) {
+	cfg := newConfig(opts...)
+	mr := NewMessageReader(r, opts...)
+	defer mr.Release()
+
+	msg, err := mr.Message()
+	if err != nil {
+		return nil, err
+	}
+	if msg.Type() != MessageTensor {
+		return nil, xerrors.Errorf("arrow/ipc: invalid message type (got=%v, want=%v)", msg.Type(), MessageTensor)
+	}
+	return tensorFromMessage(msg, cfg.alloc)
+}
+
+func tensorFromMessage(msg *Message, mem memory.Allocator) (tsr tensor.Interface, err error) {
+	defer recoverDecode(&err)
+
+	var (
+		tbl flatbuffers.Table
+		md  flatbuf.Tensor
+	)
+	msg.msg.Header(&tbl)
+	md.Init(tbl.Bytes, tbl.Pos)
+
+	var typ flatbuffers.Table
+	if !md.Type(&typ) {
+		return nil, xerrors.Errorf("arrow/ipc: tensor without a data type")
+	}
+	dt, err := concreteTypeFromFB(flatbuf.Type(md.TypeType()), typ, nil)
+	if err != nil {
+		return nil, err
+	}
+	fw, ok := dt.(arrow.FixedWidthDataType)
+	if !ok || fw.BitWidth() < 8 || fw.BitWidth()%8 != 0 {
+		return nil, xerrors.Errorf("arrow/ipc: invalid tensor data type %v", dt)
+	}
+	bw := int64(byteWidth(fw))
+
+	ndims, err := lenFB(md.ShapeLength(), kOffsetSize, md.Table(), "tensor dimensions")
+	if err != nil {
+		return nil, err
+	}
+	nstrides, err := lenFB(md.StridesLength(), 8, md.Table(), "tensor strides")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dim     flatbuf.TensorDim
+		shape   = make([]int64, ndims)
+		names   []string
+		strides []int64
+	)
+	for i := range shape {
+		md.Shape(&dim, i)
+		shape[i] = dim.Size()
+		if name := dim.Name(); name != nil {
+			if names == nil {
+				names = make([]string, len(shape))
+			}
+			names[i] = string(name)
+		}
+	}
+	if nstrides > 0 {
+		strides = make([]int64, nstrides)
+		for i := range strides {
+			strides[i] = md.Strides(i)
+		}
+	}
+
+	var (
+		buf  = md.Data(nil)
+		body = msg.body.Bytes()
+	)
+	if buf == nil {
+		return nil, xerrors.Errorf("arrow/ipc: tensor without data")
+	}
+	beg, size := buf.Offset(), buf.Length()
+	if beg < 0 || size < 0 || beg > int64(len(body)) || size > int64(len(body))-beg || size%bw != 0 {
+		return nil, xerrors.Errorf("arrow/ipc: tensor data (offset=%d, length=%d) out of the body of %d bytes", beg, size, len(body))
+	}
+
+	values := memory.NewResizableBuffer(mem)
+	defer values.Release()
+	values.Resize(int(size))
+	copy(values.Bytes(), body[beg:beg+size])
+
+	data := array.NewData(dt, int(size/bw), []*memory.Buffer{nil, values}, nil, 0, 0)
+	defer data.Release()
+	arr := array.MakeFromData(data)
+	defer arr.Release()
+
+	tsr, err = tensor.FromArray(arr, shape, strides, names)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/ipc: invalid tensor: %w", err)
+	}
+	return tsr, nil
+}
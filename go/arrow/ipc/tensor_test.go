@@ -0,0 +1,197 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/tensor"
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+func TestTensorRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewFloat64Builder(mem)
+	defer bld.Release()
+	for i := 0; i < 24; i++ {
+		bld.Append(float64(i) / 2)
+	}
+	arr := bld.NewFloat64Array()
+	defer arr.Release()
+
+	base, err := tensor.FromArray(arr, []int64{2, 3, 4}, nil, []string{"x", "y", "z"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer base.Release()
+	colMajor := tensor.ConvertLayout(base, tensor.ColMajor, mem)
+	defer colMajor.Release()
+	view := tensor.NewSlice(base, 2, 1, 3)
+	defer view.Release()
+	empty := tensor.NewSlice(base, 1, 3, 3)
+	defer empty.Release()
+
+	for _, tc := range []struct {
+		name    string
+		tsr     tensor.Interface
+		strides []int64
+	}{
+		{"row-major", base, []int64{96, 32, 8}},
+		{"col-major", colMajor, []int64{8, 16, 48}},
+		{"strided", view, []int64{48, 16, 8}},
+		{"empty", empty, []int64{96, 32, 8}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ipc.WriteTensor(&buf, tc.tsr, ipc.WithAllocator(mem)); err != nil {
+				t.Fatal(err)
+			}
+
+			// the body of the message is padded to 64 bytes.
+			meta := 8 + int(binary.LittleEndian.Uint32(buf.Bytes()[4:]))
+			if body := buf.Len() - meta; body%64 != 0 {
+				t.Fatalf("invalid body length %d", body)
+			}
+
+			got, err := ipc.ReadTensor(&buf, ipc.WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if !arrow.TypeEqual(got.DataType(), tc.tsr.DataType()) {
+				t.Fatalf("invalid type: got=%v, want=%v", got.DataType(), tc.tsr.DataType())
+			}
+			if !reflect.DeepEqual(got.Shape(), tc.tsr.Shape()) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got.Shape(), tc.tsr.Shape())
+			}
+			if !reflect.DeepEqual(got.Strides(), tc.strides) {
+				t.Fatalf("invalid strides: got=%v, want=%v", got.Strides(), tc.strides)
+			}
+			if !reflect.DeepEqual(got.DimNames(), tc.tsr.DimNames()) {
+				t.Fatalf("invalid names: got=%q, want=%q", got.DimNames(), tc.tsr.DimNames())
+			}
+			visitIndices(tc.tsr.Shape(), func(idx []int64) {
+				if got, want := tensor.Value(got, idx), tensor.Value(tc.tsr, idx); got != want {
+					t.Fatalf("tensor[%v]: got=%v, want=%v", idx, got, want)
+				}
+			})
+		})
+	}
+}
+
+func TestReadTensorInvalid(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewInt32Builder(mem)
+	defer bld.Release()
+	bld.AppendValues([]int32{1, 2, 3, 4, 5, 6}, nil)
+	arr := bld.NewInt32Array()
+	defer arr.Release()
+
+	tsr, err := tensor.FromArray(arr, []int64{2, 3}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tsr.Release()
+
+	var raw bytes.Buffer
+	if err := ipc.WriteTensor(&raw, tsr); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(md *flatbuf.Tensor)
+	}{
+		{"shape", func(md *flatbuf.Tensor) {
+			var dim flatbuf.TensorDim
+			md.Shape(&dim, 0)
+			dim.MutateSize(3)
+		}},
+		{"overflow", func(md *flatbuf.Tensor) {
+			var dim flatbuf.TensorDim
+			md.Shape(&dim, 0)
+			dim.MutateSize(1 << 62)
+		}},
+		{"strides", func(md *flatbuf.Tensor) { md.MutateStrides(0, 16) }},
+		{"data", func(md *flatbuf.Tensor) { md.Data(nil).MutateLength(1 << 20) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := append([]byte(nil), raw.Bytes()...)
+			var (
+				msg = flatbuf.GetRootAsMessage(buf[8:], 0)
+				tbl flatbuffers.Table
+				md  flatbuf.Tensor
+			)
+			msg.Header(&tbl)
+			md.Init(tbl.Bytes, tbl.Pos)
+			tc.modify(&md)
+
+			got, err := ipc.ReadTensor(bytes.NewReader(buf), ipc.WithAllocator(mem))
+			if err == nil {
+				got.Release()
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	t.Run("record-batch", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := ipc.NewWriter(&buf, ipc.WithSchema(arrow.NewSchema([]arrow.Field{{Name: "i32", Type: arrow.PrimitiveTypes.Int32}}, nil)))
+		w.Close()
+		if got, err := ipc.ReadTensor(&buf); err == nil {
+			got.Release()
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+// visitIndices calls fn with every index of a tensor of the given shape,
+// in row-major order.
+func visitIndices(shape []int64, fn func(idx []int64)) {
+	for _, v := range shape {
+		if v == 0 {
+			return
+		}
+	}
+	idx := make([]int64, len(shape))
+	for {
+		fn(idx)
+		i := len(idx) - 1
+		for ; i >= 0; i-- {
+			if idx[i]++; idx[i] < shape[i] {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
@@ -28,18 +28,23 @@ import (
 )
 
 type swriter struct {
-	w   io.Writer
-	pos int64
+	w      io.Writer
+	pos    int64
+	legacy bool // see WithLegacyFormat
 }
 
 func (w *swriter) Start() error { return nil }
 func (w *swriter) Close() error {
-	_, err := w.Write(kEOS[:])
+	eos := kEOS[:]
+	if w.legacy {
+		eos = kLegacyEOS[:]
+	}
+	_, err := w.Write(eos)
 	return err
 }
 
 func (w *swriter) WritePayload(p Payload) error {
-	_, err := writeIPCPayload(w, p)
+	_, err := writeIPCPayload(w, p, w.legacy)
 	if err != nil {
 		return err
 	}
@@ -61,6 +66,11 @@ type Writer struct {
 
 	started bool
 	schema  *arrow.Schema
+	cfg     *config
+	codec   *bodyCodec
+
+	// memo holds the dictionaries written last, by ID.
+	memo dictMemo
 }
 
 // NewWriterWithPayloadWriter constructs a writer with the provided payload writer
@@ -72,6 +82,7 @@ func NewWriterWithPayloadWriter(pw PayloadWriter, opts ...Option) *Writer {
 		mem:    cfg.alloc,
 		pw:     pw,
 		schema: cfg.schema,
+		cfg:    cfg,
 	}
 }
 
@@ -81,8 +92,9 @@ func NewWriter(w io.Writer, opts ...Option) *Writer {
 	return &Writer{
 		w:      w,
 		mem:    cfg.alloc,
-		pw:     &swriter{w: w},
+		pw:     &swriter{w: w, legacy: cfg.legacy},
 		schema: cfg.schema,
+		cfg:    cfg,
 	}
 }
 
@@ -97,6 +109,7 @@ func (w *Writer) Close() error {
 	if w.pw == nil {
 		return nil
 	}
+	w.memo.delete()
 
 	err := w.pw.Close()
 	if err != nil {
@@ -120,10 +133,27 @@ func (w *Writer) Write(rec array.Record) error {
 		return errInconsistentSchema
 	}
 
+	ps, err := dictionaryPayloads(&w.memo, rec, w.mem, w.codec, w.cfg.alignment, w.cfg.dictDeltas, true)
+	if err != nil {
+		return xerrors.Errorf("arrow/ipc: could not encode dictionaries: %w", err)
+	}
+	defer ps.Release()
+	for _, data := range ps {
+		if err := w.pw.WritePayload(data); err != nil {
+			return err
+		}
+	}
+
+	return w.write(rec)
+}
+
+// write encodes and writes out rec, splitting it in two halves of rows
+// when its message exceeds the maximum message size.
+func (w *Writer) write(rec array.Record) error {
 	const allow64b = true
 	var (
 		data = Payload{msg: MessageRecordBatch}
-		enc  = newRecordEncoder(w.mem, 0, kMaxNestingDepth, allow64b)
+		enc  = newRecordEncoder(w.mem, 0, kMaxNestingDepth, allow64b, w.codec, w.cfg.alignment)
 	)
 	defer data.Release()
 
@@ -131,17 +161,45 @@ func (w *Writer) Write(rec array.Record) error {
 		return xerrors.Errorf("arrow/ipc: could not encode record to payload: %w", err)
 	}
 
+	max := w.cfg.maxMessageSize
+	if size := int64(data.meta.Len()) + data.size; max > 0 && size > max {
+		n := rec.NumRows()
+		if n <= 1 {
+			return xerrors.Errorf("arrow/ipc: a single row of %d bytes exceeds the maximum message size of %d bytes", size, max)
+		}
+
+		for _, rng := range [][2]int64{{0, n / 2}, {n / 2, n}} {
+			slice := rec.NewSlice(rng[0], rng[1])
+			err := w.write(slice)
+			slice.Release()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	return w.pw.WritePayload(data)
 }
 
 func (w *Writer) start() error {
 	w.started = true
 
+	if err := w.cfg.checkAlignment(); err != nil {
+		return err
+	}
+	codec, err := newBodyCodec(w.cfg)
+	if err != nil {
+		return err
+	}
+	w.codec = codec
+
 	// write out schema payloads
-	ps := payloadsFromSchema(w.schema, w.mem, nil)
+	ps := payloadsFromSchema(w.schema, w.mem, &w.memo)
 	defer ps.Release()
 
 	for _, data := range ps {
+		data.align = w.cfg.alignment
 		err := w.pw.WritePayload(data)
 		if err != nil {
 			return err
@@ -154,20 +212,25 @@ func (w *Writer) start() error {
 type recordEncoder struct {
 	mem memory.Allocator
 
-	fields []fieldMetadata
-	meta   []bufferMetadata
+	fields   []fieldMetadata
+	meta     []bufferMetadata
+	variadic []int64 // number of data buffers of the view arrays
 
 	depth    int64
 	start    int64
 	allow64b bool
+	codec    *bodyCodec
+	align    int64 // alignment of the body buffers
 }
 
-func newRecordEncoder(mem memory.Allocator, startOffset, maxDepth int64, allow64b bool) *recordEncoder {
+func newRecordEncoder(mem memory.Allocator, startOffset, maxDepth int64, allow64b bool, codec *bodyCodec, align int64) *recordEncoder {
 	return &recordEncoder{
 		mem:      mem,
 		start:    startOffset,
 		depth:    maxDepth,
 		allow64b: allow64b,
+		codec:    codec,
+		align:    align,
 	}
 }
 
@@ -181,10 +244,39 @@ func (w *recordEncoder) Encode(p *Payload, rec array.Record) error {
 		}
 	}
 
+	if err := w.encodeBody(p); err != nil {
+		return err
+	}
+	return w.encodeMetadata(p, rec.NumRows())
+}
+
+// EncodeDictionary encodes the values of the dictionary id, or the values
+// appended to it when isDelta is set, as a dictionary batch.
+func (w *recordEncoder) EncodeDictionary(p *Payload, id int64, isDelta bool, dict array.Interface) error {
+	if err := w.visit(p, dict); err != nil {
+		return xerrors.Errorf("arrow/ipc: could not encode dictionary %d: %w", id, err)
+	}
+	if err := w.encodeBody(p); err != nil {
+		return err
+	}
+	p.meta = writeDictionaryMessage(w.mem, id, isDelta, int64(dict.Len()), p.size, w.fields, w.meta, w.variadic, w.codec)
+	return nil
+}
+
+// encodeBody compresses the body buffers of p, when needed, and computes
+// their metadata.
+func (w *recordEncoder) encodeBody(p *Payload) error {
+	if w.codec != nil {
+		if err := compressBody(w.mem, p, w.codec); err != nil {
+			return err
+		}
+	}
+
 	// position for the start of a buffer relative to the passed frame of reference.
 	// may be 0 or some other position in an address space.
 	offset := w.start
 	w.meta = make([]bufferMetadata, len(p.body))
+	p.align = w.align
 
 	// construct the metadata for the record batch header
 	for i, buf := range p.body {
@@ -195,12 +287,16 @@ func (w *recordEncoder) Encode(p *Payload, rec array.Record) error {
 		// the buffer might be null if we are handling zero row lengths.
 		if buf != nil {
 			size = int64(buf.Len())
-			padding = bitutil.CeilByte64(size) - size
+			padding = p.padding(size)
 		}
 		w.meta[i] = bufferMetadata{
 			Offset: offset,
 			Len:    size + padding,
 		}
+		if w.codec != nil {
+			// compressed buffers must be read back without their padding.
+			w.meta[i].Len = size
+		}
 		offset += size + padding
 	}
 
@@ -209,7 +305,7 @@ func (w *recordEncoder) Encode(p *Payload, rec array.Record) error {
 		panic("not aligned")
 	}
 
-	return w.encodeMetadata(p, rec.NumRows())
+	return nil
 }
 
 func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
@@ -217,6 +313,11 @@ func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
 		return errMaxRecursion
 	}
 
+	if ext, ok := arr.(array.ExtensionArray); ok {
+		// extension arrays are written as their storage.
+		return w.visit(p, ext.Storage())
+	}
+
 	if !w.allow64b && arr.Len() > math.MaxInt32 {
 		return errBigArray
 	}
@@ -228,8 +329,11 @@ func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
 		Offset: 0,
 	})
 
-	switch arr.NullN() {
-	case 0:
+	switch {
+	case arr.DataType().ID() == arrow.RUN_END_ENCODED:
+		// run-end encoded arrays have no buffers, their nulls are the
+		// ones of their values.
+	case arr.NullN() == 0:
 		p.body = append(p.body, nil)
 	default:
 		switch arr.DataType().ID() {
@@ -258,90 +362,39 @@ func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
 		p.body = append(p.body, bitm)
 
 	case arrow.FixedWidthDataType:
-		data := arr.Data()
-		values := data.Buffers()[1]
-		arrLen := int64(arr.Len())
-		typeWidth := int64(dtype.BitWidth() / 8)
-		minLength := paddedLength(arrLen*typeWidth, kArrowAlignment)
-
-		switch {
-		case needTruncate(int64(data.Offset()), values, minLength):
-			// non-zero offset: slice the buffer
-			offset := int64(data.Offset()) * typeWidth
-			// send padding if available
-			len := minI64(bitutil.CeilByte64(arrLen*typeWidth), int64(data.Len())-offset)
-			data = array.NewSliceData(data, offset, offset+len)
-			defer data.Release()
-			values = data.Buffers()[1]
-		}
-		if values != nil {
-			values.Retain()
-		}
-		p.body = append(p.body, values)
+		p.body = append(p.body, fixedWidthValues(arr.Data(), byteWidth(dtype)))
+
+	case *arrow.DictionaryType:
+		// only the indices are sent with the record, the dictionary values
+		// are sent as dictionary batches.
+		width := byteWidth(dtype.IndexType.(arrow.FixedWidthDataType))
+		p.body = append(p.body, fixedWidthValues(arr.Data(), width))
 
-	case *arrow.BinaryType:
-		arr := arr.(*array.Binary)
-		voffsets, err := w.getZeroBasedValueOffsets(arr)
+	case *arrow.BinaryType, *arrow.StringType:
+		voffsets, beg, end, err := w.getZeroBasedValueOffsets(arr)
 		if err != nil {
 			return xerrors.Errorf("could not retrieve zero-based value offsets from %T: %w", arr, err)
 		}
-		data := arr.Data()
-		values := data.Buffers()[2]
-
-		var totalDataBytes int64
-		if voffsets != nil {
-			totalDataBytes = int64(len(arr.ValueBytes()))
-		}
-
-		switch {
-		case needTruncate(int64(data.Offset()), values, totalDataBytes):
-			// slice data buffer to include the range we need now.
-			var (
-				beg = int64(arr.ValueOffset(0))
-				len = minI64(paddedLength(totalDataBytes, kArrowAlignment), int64(data.Len())-beg)
-			)
-			data = array.NewSliceData(data, beg, beg+len)
-			defer data.Release()
-			values = data.Buffers()[2]
-		default:
-			if values != nil {
-				values.Retain()
-			}
+		values := arr.Data().Buffers()[2]
+		if values != nil {
+			values = newSlicedBuffer(values, beg, end)
 		}
 		p.body = append(p.body, voffsets)
 		p.body = append(p.body, values)
 
-	case *arrow.StringType:
-		arr := arr.(*array.String)
-		voffsets, err := w.getZeroBasedValueOffsets(arr)
-		if err != nil {
-			return xerrors.Errorf("could not retrieve zero-based value offsets from %T: %w", arr, err)
-		}
+	case arrow.BinaryViewDataType:
+		// the views do not depend on their position: send the ones of the
+		// slice, along with all the data buffers they may refer to.
 		data := arr.Data()
-		values := data.Buffers()[2]
-
-		var totalDataBytes int64
-		if voffsets != nil {
-			totalDataBytes = int64(arr.ValueOffset(arr.Len()) - arr.ValueOffset(0))
-		}
-
-		switch {
-		case needTruncate(int64(data.Offset()), values, totalDataBytes):
-			// slice data buffer to include the range we need now.
-			var (
-				beg = int64(arr.ValueOffset(0))
-				len = minI64(paddedLength(totalDataBytes, kArrowAlignment), int64(data.Len())-beg)
-			)
-			data = array.NewSliceData(data, beg, beg+len)
-			defer data.Release()
-			values = data.Buffers()[2]
-		default:
-			if values != nil {
-				values.Retain()
+		p.body = append(p.body, fixedWidthValues(data, arrow.ViewHeaderSizeBytes))
+		buffers := data.Buffers()[2:]
+		for _, buf := range buffers {
+			if buf != nil {
+				buf.Retain()
 			}
+			p.body = append(p.body, buf)
 		}
-		p.body = append(p.body, voffsets)
-		p.body = append(p.body, values)
+		w.variadic = append(w.variadic, int64(len(buffers)))
 
 	case *arrow.StructType:
 		w.depth--
@@ -354,37 +407,49 @@ func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
 		}
 		w.depth++
 
+	case *arrow.UnionType:
+		// unions have no validity bitmap of their own: its slot is left
+		// empty, as metadata V4 readers expect one.
+		p.body = append(p.body, fixedWidthValues(arr.Data(), 1))
+
+		w.depth--
+		switch arr := arr.(type) {
+		case *array.SparseUnion:
+			// the children of a sparse union are sliced as the union is.
+			for i := 0; i < arr.NumFields(); i++ {
+				if err := w.visit(p, arr.Field(i)); err != nil {
+					return xerrors.Errorf("could not visit field %d of sparse union: %w", i, err)
+				}
+			}
+		case *array.DenseUnion:
+			offsets, children := w.getZeroBasedUnionOffsets(arr)
+			defer func() {
+				for _, c := range children {
+					c.Release()
+				}
+			}()
+			p.body = append(p.body, offsets)
+			for i, c := range children {
+				if err := w.visit(p, c); err != nil {
+					return xerrors.Errorf("could not visit field %d of dense union: %w", i, err)
+				}
+			}
+		}
+		w.depth++
+
 	case *arrow.ListType:
 		arr := arr.(*array.List)
-		voffsets, err := w.getZeroBasedValueOffsets(arr)
+		voffsets, beg, end, err := w.getZeroBasedValueOffsets(arr)
 		if err != nil {
 			return xerrors.Errorf("could not retrieve zero-based value offsets for array %T: %w", arr, err)
 		}
 		p.body = append(p.body, voffsets)
 
 		w.depth--
-		var (
-			values        = arr.ListValues()
-			mustRelease   = false
-			values_offset int64
-			values_length int64
-		)
-		defer func() {
-			if mustRelease {
-				values.Release()
-			}
-		}()
-
-		if voffsets != nil {
-			values_offset = int64(arr.Offsets()[0])
-			values_length = int64(arr.Offsets()[arr.Len()]) - values_offset
-		}
+		// only send the values of the elements of the slice.
+		values := array.NewSlice(arr.ListValues(), beg, end)
+		defer values.Release()
 
-		if len(arr.Offsets()) != 0 || values_length < int64(values.Len()) {
-			// must also slice the values
-			values = array.NewSlice(values, values_offset, values_length)
-			mustRelease = true
-		}
 		err = w.visit(p, values)
 
 		if err != nil {
@@ -411,6 +476,28 @@ func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
 		}
 		w.depth++
 
+	case *arrow.RunEndEncodedType:
+		arr := arr.(*array.RunEndEncoded)
+
+		w.depth--
+
+		// only send the runs of the slice, with run ends relative to its
+		// start.
+		ends := arr.LogicalRunEndsArr(w.mem)
+		defer ends.Release()
+
+		if err := w.visit(p, ends); err != nil {
+			return xerrors.Errorf("could not visit run ends for array %T: %w", arr, err)
+		}
+
+		values := arr.LogicalValuesArr()
+		defer values.Release()
+
+		if err := w.visit(p, values); err != nil {
+			return xerrors.Errorf("could not visit values for array %T: %w", arr, err)
+		}
+		w.depth++
+
 	default:
 		panic(xerrors.Errorf("arrow/ipc: unknown array %T (dtype=%T)", arr, dtype))
 	}
@@ -418,53 +505,125 @@ func (w *recordEncoder) visit(p *Payload, arr array.Interface) error {
 	return nil
 }
 
-func (w *recordEncoder) getZeroBasedValueOffsets(arr array.Interface) (*memory.Buffer, error) {
+// getZeroBasedValueOffsets returns the value offsets of an offset-based
+// array, rebased to start at zero when the array is a slice, along with
+// the range of the values they point to.
+func (w *recordEncoder) getZeroBasedValueOffsets(arr array.Interface) (voffsets *memory.Buffer, beg, end int64, err error) {
 	data := arr.Data()
-	voffsets := data.Buffers()[1]
-	if data.Offset() != 0 {
-		// FIXME(sbinet): writer.cc:231
-		panic(xerrors.Errorf("not implemented offset=%d", data.Offset()))
+	buf := data.Buffers()[1]
+	if buf == nil || buf.Len() == 0 {
+		return nil, 0, 0, nil
 	}
-	if voffsets == nil || voffsets.Len() == 0 {
-		return nil, nil
+
+	offsets := arrow.Int32Traits.CastFromBytes(buf.Bytes())[data.Offset() : data.Offset()+data.Len()+1]
+	beg, end = int64(offsets[0]), int64(offsets[len(offsets)-1])
+	if beg == 0 {
+		// the offsets of a slice starting with empty values may start at
+		// zero too: send them as they are.
+		start := int64(arrow.Int32Traits.BytesRequired(data.Offset()))
+		return newSlicedBuffer(buf, start, start+int64(arrow.Int32Traits.BytesRequired(len(offsets)))), beg, end, nil
 	}
 
-	voffsets.Retain()
-	return voffsets, nil
+	voffsets = memory.NewResizableBuffer(w.mem)
+	voffsets.Resize(arrow.Int32Traits.BytesRequired(len(offsets)))
+	shifted := arrow.Int32Traits.CastFromBytes(voffsets.Bytes())
+	for i, o := range offsets {
+		shifted[i] = o - int32(beg)
+	}
+	return voffsets, beg, end, nil
 }
 
 func (w *recordEncoder) encodeMetadata(p *Payload, nrows int64) error {
-	p.meta = writeRecordMessage(w.mem, nrows, p.size, w.fields, w.meta)
+	p.meta = writeRecordMessage(w.mem, nrows, p.size, w.fields, w.meta, w.variadic, w.codec)
 	return nil
 }
 
-func newTruncatedBitmap(mem memory.Allocator, offset, length int64, input *memory.Buffer) *memory.Buffer {
-	if input != nil {
-		input.Retain()
-		return input
+// byteWidth returns the number of bytes of a value of the fixed width type dt.
+func byteWidth(dt arrow.FixedWidthDataType) int {
+	if dt.ID() == arrow.DECIMAL {
+		return arrow.Decimal128SizeBytes
 	}
+	return dt.BitWidth() / 8
+}
 
-	minLength := paddedLength(bitutil.BytesForBits(length), kArrowAlignment)
-	switch {
-	case offset != 0 || minLength < int64(input.Len()):
-		// with a sliced array / non-zero offset, we must copy the bitmap
-		panic("not implemented") // FIXME(sbinet): writer.cc:75
-	default:
-		input.Retain()
-		return input
+// getZeroBasedUnionOffsets returns the value offsets of the dense union
+// arr, relative to the first value of each child it refers to, along with
+// the slices of its children it refers to, which must be released.
+func (w *recordEncoder) getZeroBasedUnionOffsets(arr *array.DenseUnion) (*memory.Buffer, []array.Interface) {
+	n := arr.NumFields()
+	beg := make([]int32, n)
+	end := make([]int32, n)
+	for i := range beg {
+		beg[i] = math.MaxInt32
 	}
+	offsets := arr.ValueOffsets()
+	for i, off := range offsets {
+		id := arr.ChildID(i)
+		if off < beg[id] {
+			beg[id] = off
+		}
+		if off >= end[id] {
+			end[id] = off + 1
+		}
+	}
+
+	buf := memory.NewResizableBuffer(w.mem)
+	buf.Resize(arrow.Int32Traits.BytesRequired(len(offsets)))
+	vs := arrow.Int32Traits.CastFromBytes(buf.Bytes())
+	for i, off := range offsets {
+		vs[i] = off - beg[arr.ChildID(i)]
+	}
+
+	children := make([]array.Interface, n)
+	for i := range children {
+		if end[i] == 0 {
+			beg[i] = 0
+		}
+		children[i] = array.NewSlice(arr.Field(i), int64(beg[i]), int64(end[i]))
+	}
+	return buf, children
+}
+
+// fixedWidthValues returns the values buffer of the slice of data, for
+// values of width bytes.
+func fixedWidthValues(data *array.Data, width int) *memory.Buffer {
+	values := data.Buffers()[1]
+	if values == nil {
+		return nil
+	}
+	// only send the values of the slice of the buffer.
+	beg := int64(data.Offset()) * int64(width)
+	end := beg + int64(data.Len())*int64(width)
+	return newSlicedBuffer(values, beg, end)
 }
 
-func needTruncate(offset int64, buf *memory.Buffer, minLength int64) bool {
-	if buf == nil {
-		return false
+// newTruncatedBitmap returns the length bits of the input bitmap starting
+// at offset, copying them when they do not start on a byte boundary.
+func newTruncatedBitmap(mem memory.Allocator, offset, length int64, input *memory.Buffer) *memory.Buffer {
+	if input == nil {
+		return nil
+	}
+
+	if offset%8 == 0 {
+		beg := offset / 8
+		return newSlicedBuffer(input, beg, beg+bitutil.BytesForBits(length))
+	}
+
+	bitmap := memory.NewResizableBuffer(mem)
+	bitmap.Resize(int(bitutil.BytesForBits(length)))
+	src, dst := input.Bytes(), bitmap.Bytes()
+	for i := 0; i < int(length); i++ {
+		bitutil.SetBitTo(dst, i, bitutil.BitIsSet(src, int(offset)+i))
 	}
-	return offset != 0 || minLength < int64(buf.Len())
+	return bitmap
 }
 
-func minI64(a, b int64) int64 {
-	if a < b {
-		return a
+// newSlicedBuffer returns the bytes [beg, end) of buf without copying
+// them, retaining buf when they span all of it.
+func newSlicedBuffer(buf *memory.Buffer, beg, end int64) *memory.Buffer {
+	if beg == 0 && end == int64(buf.Len()) {
+		buf.Retain()
+		return buf
 	}
-	return b
+	return memory.NewBufferBytes(buf.Bytes()[beg:end])
 }
@@ -0,0 +1,515 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marshal
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"golang.org/x/xerrors"
+)
+
+// Option configures DecodeRecords.
+type Option func(*config)
+
+type config struct {
+	ignoreUnknown bool
+}
+
+// WithIgnoreUnknownColumns specifies whether columns, and fields of
+// structs, with no matching struct field are ignored. They are reported as
+// errors by default.
+func WithIgnoreUnknownColumns(ignore bool) Option {
+	return func(cfg *config) {
+		cfg.ignoreUnknown = ignore
+	}
+}
+
+// DecodeRecords appends the rows of rec to the slice pointed to by out, a
+// pointer to a slice of structs or of pointers to structs.
+//
+// Columns are matched to struct fields by name, as in SchemaFromStruct.
+// Fields without a column are left to their zero value, and nulls decode
+// to zero values. Integers and floats decode from any Arrow type of the
+// same kind as long as they fit, times from timestamps and dates, and
+// dictionaries as their values. Any other mismatch between the type of a
+// column and the type of its field is an error naming the field.
+//
+// The decoded values do not reference the memory of rec.
+func DecodeRecords(rec array.Record, out interface{}, opts ...Option) error {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p := reflect.ValueOf(out)
+	if p.Kind() != reflect.Ptr || p.IsNil() || p.Elem().Kind() != reflect.Slice {
+		return xerrors.Errorf("arrow/marshal: cannot decode into %T, want a pointer to a slice of structs", out)
+	}
+	t, err := rowType(p.Elem().Type())
+	if err != nil {
+		return err
+	}
+	dec, err := newStructDecoder(t, rec.Schema().Fields(), "", &cfg)
+	if err != nil {
+		return err
+	}
+
+	rows := p.Elem()
+	n := rows.Len()
+	size := n + int(rec.NumRows())
+	if size > rows.Cap() {
+		grown := reflect.MakeSlice(rows.Type(), n, size)
+		reflect.Copy(grown, rows)
+		rows.Set(grown)
+	}
+	rows.SetLen(size)
+	for i := 0; i < int(rec.NumRows()); i++ {
+		row := rows.Index(n + i)
+		if row.Kind() == reflect.Ptr {
+			row.Set(reflect.New(t))
+			row = row.Elem()
+		}
+		if err := dec(rec.Columns(), i, row); err != nil {
+			rows.SetLen(n)
+			return err
+		}
+	}
+	return nil
+}
+
+// decoder sets v to the i-th value of arr.
+type decoder func(arr array.Interface, i int, v reflect.Value) error
+
+// structDecoder sets the fields of v to the i-th values of cols.
+type structDecoder func(cols []array.Interface, i int, v reflect.Value) error
+
+// newStructDecoder returns the decoder of the columns with the given fields
+// into the struct type t, held by the field at path.
+func newStructDecoder(t reflect.Type, fields []arrow.Field, path string, cfg *config) (structDecoder, error) {
+	sfs, err := structFields(t, path, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]structField, len(sfs))
+	for _, sf := range sfs {
+		byName[sf.field.Name] = sf
+	}
+
+	var (
+		cols  []int
+		index []int
+		decs  []decoder
+	)
+	for j, f := range fields {
+		sf, ok := byName[f.Name]
+		if !ok {
+			if cfg.ignoreUnknown {
+				continue
+			}
+			if path != "" {
+				return nil, xerrors.Errorf("arrow/marshal: field %q: unknown field %q", path, f.Name)
+			}
+			return nil, xerrors.Errorf("arrow/marshal: unknown column %q", f.Name)
+		}
+		dec, err := newDecoder(t.Field(sf.index).Type, f.Type, sf.path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, j)
+		index = append(index, sf.index)
+		decs = append(decs, dec)
+	}
+	return func(arrs []array.Interface, i int, v reflect.Value) error {
+		for k, dec := range decs {
+			if err := dec(arrs[cols[k]], i, v.Field(index[k])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// newDecoder returns the decoder of arrays of type dt into values of t,
+// held by the field at path.
+func newDecoder(t reflect.Type, dt arrow.DataType, path string, cfg *config) (decoder, error) {
+	if dt, ok := dt.(*arrow.DictionaryType); ok {
+		dec, err := newDecoder(t, dt.ValueType, path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			a := arr.(*array.Dictionary)
+			if a.IsNull(i) {
+				v.Set(reflect.Zero(v.Type()))
+				return nil
+			}
+			return dec(a.Dictionary(), a.GetValueIndex(i), v)
+		}, nil
+	}
+
+	if t.Kind() == reflect.Ptr {
+		dec, err := newDecoder(t.Elem(), dt, path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			if arr.IsNull(i) {
+				v.Set(reflect.Zero(v.Type()))
+				return nil
+			}
+			v.Set(reflect.New(t.Elem()))
+			return dec(arr, i, v.Elem())
+		}, nil
+	}
+
+	dec, err := valueDecoder(t, dt, path, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if dec == nil {
+		return nil, xerrors.Errorf("arrow/marshal: field %q: cannot decode %v into %v", path, dt, t)
+	}
+	return func(arr array.Interface, i int, v reflect.Value) error {
+		if arr.IsNull(i) {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		return dec(arr, i, v)
+	}, nil
+}
+
+// valueDecoder returns the decoder of the valid values of arrays of type dt
+// into values of t, or nil if they cannot be decoded. The error is the one
+// of the decoders of the elements or fields of t.
+func valueDecoder(t reflect.Type, dt arrow.DataType, path string, cfg *config) (decoder, error) {
+	switch t {
+	case timeType:
+		return timeDecoder(dt), nil
+	case durationType:
+		dt, ok := dt.(*arrow.DurationType)
+		if !ok {
+			return nil, nil
+		}
+		per := int64(unitDuration(dt.Unit))
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			v.SetInt(int64(arr.(*array.Duration).Value(i)) * per)
+			return nil
+		}, nil
+	case decimalType:
+		if _, ok := dt.(*arrow.Decimal128Type); !ok {
+			return nil, nil
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			v.Set(reflect.ValueOf(arr.(*array.Decimal128).Value(i)))
+			return nil
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if dt.ID() != arrow.BOOL {
+			return nil, nil
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			v.SetBool(arr.(*array.Boolean).Value(i))
+			return nil
+		}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value := intValue(dt)
+		if value == nil {
+			return nil, nil
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			x := value(arr, i)
+			if v.OverflowInt(x) {
+				return xerrors.Errorf("arrow/marshal: field %q: value %d overflows %v", path, x, v.Type())
+			}
+			v.SetInt(x)
+			return nil
+		}, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value := uintValue(dt)
+		if value == nil {
+			return nil, nil
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			x := value(arr, i)
+			if v.OverflowUint(x) {
+				return xerrors.Errorf("arrow/marshal: field %q: value %d overflows %v", path, x, v.Type())
+			}
+			v.SetUint(x)
+			return nil
+		}, nil
+
+	case reflect.Float32, reflect.Float64:
+		switch dt.ID() {
+		case arrow.FLOAT32:
+			return func(arr array.Interface, i int, v reflect.Value) error {
+				v.SetFloat(float64(arr.(*array.Float32).Value(i)))
+				return nil
+			}, nil
+		case arrow.FLOAT64:
+			return func(arr array.Interface, i int, v reflect.Value) error {
+				v.SetFloat(arr.(*array.Float64).Value(i))
+				return nil
+			}, nil
+		}
+		return nil, nil
+
+	case reflect.String:
+		value := bytesValue(dt)
+		if value == nil {
+			return nil, nil
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			v.SetString(string(value(arr, i)))
+			return nil
+		}, nil
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			value := bytesValue(dt)
+			if value == nil {
+				return nil, nil
+			}
+			return func(arr array.Interface, i int, v reflect.Value) error {
+				b := value(arr, i)
+				v.SetBytes(append(make([]byte, 0, len(b)), b...))
+				return nil
+			}, nil
+		}
+		lt, ok := dt.(*arrow.ListType)
+		if !ok {
+			return nil, nil
+		}
+		dec, err := newDecoder(t.Elem(), lt.Elem(), path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			a := arr.(*array.List)
+			beg, end := listBounds(a, i)
+			s := reflect.MakeSlice(t, end-beg, end-beg)
+			for k := beg; k < end; k++ {
+				if err := dec(a.ListValues(), k, s.Index(k-beg)); err != nil {
+					return err
+				}
+			}
+			v.Set(s)
+			return nil
+		}, nil
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if dt, ok := dt.(*arrow.FixedSizeBinaryType); !ok || dt.ByteWidth != t.Len() {
+				return nil, nil
+			}
+			return func(arr array.Interface, i int, v reflect.Value) error {
+				reflect.Copy(v, reflect.ValueOf(arr.(*array.FixedSizeBinary).Value(i)))
+				return nil
+			}, nil
+		}
+		lt, ok := dt.(*arrow.FixedSizeListType)
+		if !ok || int(lt.Len()) != t.Len() {
+			return nil, nil
+		}
+		dec, err := newDecoder(t.Elem(), lt.Elem(), path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		n := t.Len()
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			a := arr.(*array.FixedSizeList)
+			beg := (i + a.Data().Offset()) * n
+			for k := 0; k < n; k++ {
+				if err := dec(a.ListValues(), beg+k, v.Index(k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+
+	case reflect.Map:
+		lt, ok := dt.(*arrow.ListType)
+		if !ok {
+			return nil, nil
+		}
+		st, ok := lt.Elem().(*arrow.StructType)
+		if !ok || len(st.Fields()) != 2 {
+			return nil, nil
+		}
+		kdec, err := newDecoder(t.Key(), st.Field(0).Type, path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		vdec, err := newDecoder(t.Elem(), st.Field(1).Type, path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			a := arr.(*array.List)
+			entries := a.ListValues().(*array.Struct)
+			beg, end := listBounds(a, i)
+			m := reflect.MakeMapWithSize(t, end-beg)
+			for k := beg; k < end; k++ {
+				key, value := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+				if err := kdec(entries.Field(0), k, key); err != nil {
+					return err
+				}
+				if err := vdec(entries.Field(1), k, value); err != nil {
+					return err
+				}
+				m.SetMapIndex(key, value)
+			}
+			v.Set(m)
+			return nil
+		}, nil
+
+	case reflect.Struct:
+		st, ok := dt.(*arrow.StructType)
+		if !ok {
+			return nil, nil
+		}
+		dec, err := newStructDecoder(t, st.Fields(), path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			a := arr.(*array.Struct)
+			cols := make([]array.Interface, a.NumField())
+			for j := range cols {
+				cols[j] = a.Field(j)
+			}
+			return dec(cols, i, v)
+		}, nil
+	}
+	return nil, nil
+}
+
+// listBounds returns the bounds of the i-th list of a in its values.
+func listBounds(a *array.List, i int) (beg, end int) {
+	j := i + a.Data().Offset()
+	return int(a.Offsets()[j]), int(a.Offsets()[j+1])
+}
+
+// timeDecoder returns the decoder of timestamps and dates into time.Time
+// values, in the time zone of the timestamps.
+func timeDecoder(dt arrow.DataType) decoder {
+	switch dt := dt.(type) {
+	case *arrow.TimestampType:
+		per := int64(time.Second / unitDuration(dt.Unit))
+		loc := location(dt.TimeZone)
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			x := int64(arr.(*array.Timestamp).Value(i))
+			sec, nsec := x/per, x%per*int64(unitDuration(dt.Unit))
+			if nsec < 0 {
+				sec, nsec = sec-1, nsec+int64(time.Second)
+			}
+			v.Set(reflect.ValueOf(time.Unix(sec, nsec).In(loc)))
+			return nil
+		}
+	case *arrow.Date32Type:
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			days := int64(arr.(*array.Date32).Value(i))
+			v.Set(reflect.ValueOf(time.Unix(days*secondsPerDay, 0).UTC()))
+			return nil
+		}
+	case *arrow.Date64Type:
+		return func(arr array.Interface, i int, v reflect.Value) error {
+			ms := int64(arr.(*array.Date64).Value(i))
+			v.Set(reflect.ValueOf(time.Unix(0, 0).Add(time.Duration(ms) * time.Millisecond).UTC()))
+			return nil
+		}
+	}
+	return nil
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// location returns the location of an Arrow time zone, which is either
+// a name from the time zone database or a fixed offset such as "+07:30".
+// Time zones which cannot be loaded are UTC.
+func location(tz string) *time.Location {
+	switch tz {
+	case "", "UTC":
+		return time.UTC
+	}
+	if loc, err := time.LoadLocation(tz); err == nil {
+		return loc
+	}
+	if t, err := time.Parse("-07:00", tz); err == nil {
+		_, offset := t.Zone()
+		return time.FixedZone(tz, offset)
+	}
+	return time.UTC
+}
+
+// intValue returns a function reading the values of signed integer arrays
+// of type dt, or nil if dt is not a signed integer type.
+func intValue(dt arrow.DataType) func(arr array.Interface, i int) int64 {
+	switch dt.ID() {
+	case arrow.INT8:
+		return func(arr array.Interface, i int) int64 { return int64(arr.(*array.Int8).Value(i)) }
+	case arrow.INT16:
+		return func(arr array.Interface, i int) int64 { return int64(arr.(*array.Int16).Value(i)) }
+	case arrow.INT32:
+		return func(arr array.Interface, i int) int64 { return int64(arr.(*array.Int32).Value(i)) }
+	case arrow.INT64:
+		return func(arr array.Interface, i int) int64 { return arr.(*array.Int64).Value(i) }
+	}
+	return nil
+}
+
+// uintValue returns a function reading the values of unsigned integer
+// arrays of type dt, or nil if dt is not an unsigned integer type.
+func uintValue(dt arrow.DataType) func(arr array.Interface, i int) uint64 {
+	switch dt.ID() {
+	case arrow.UINT8:
+		return func(arr array.Interface, i int) uint64 { return uint64(arr.(*array.Uint8).Value(i)) }
+	case arrow.UINT16:
+		return func(arr array.Interface, i int) uint64 { return uint64(arr.(*array.Uint16).Value(i)) }
+	case arrow.UINT32:
+		return func(arr array.Interface, i int) uint64 { return uint64(arr.(*array.Uint32).Value(i)) }
+	case arrow.UINT64:
+		return func(arr array.Interface, i int) uint64 { return arr.(*array.Uint64).Value(i) }
+	}
+	return nil
+}
+
+// bytesValue returns a function reading the values of string and binary
+// arrays of type dt, or nil if dt is not such a type. The values may
+// reference the memory of the arrays.
+func bytesValue(dt arrow.DataType) func(arr array.Interface, i int) []byte {
+	switch dt.ID() {
+	case arrow.STRING:
+		return func(arr array.Interface, i int) []byte { return []byte(arr.(*array.String).Value(i)) }
+	case arrow.BINARY:
+		return func(arr array.Interface, i int) []byte { return arr.(*array.Binary).Value(i) }
+	case arrow.STRING_VIEW:
+		return func(arr array.Interface, i int) []byte { return []byte(arr.(*array.StringView).Value(i)) }
+	case arrow.BINARY_VIEW:
+		return func(arr array.Interface, i int) []byte { return arr.(*array.BinaryView).Value(i) }
+	case arrow.FIXED_SIZE_BINARY:
+		return func(arr array.Interface, i int) []byte { return arr.(*array.FixedSizeBinary).Value(i) }
+	}
+	return nil
+}
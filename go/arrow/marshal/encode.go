@@ -0,0 +1,304 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marshal
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// EncodeRecords encodes rows, a slice of structs or of pointers to structs,
+// to records of at most chunkSize rows, or to a single record if chunkSize
+// is not positive. The schema of the records is the one returned by
+// SchemaFromStruct. No record is returned for an empty slice.
+//
+// The records must be released by the caller.
+func EncodeRecords(mem memory.Allocator, rows interface{}, chunkSize int) ([]array.Record, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return nil, xerrors.Errorf("arrow/marshal: cannot encode %T, want a slice of structs", rows)
+	}
+	t, err := rowType(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	fields, err := structFields(t, "", map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	schema := arrow.NewSchema(arrowFields(fields), nil)
+	encs := make([]encoder, len(fields))
+	for i, f := range fields {
+		encs[i] = newEncoder(t.Field(f.index).Type, f.field.Type)
+	}
+
+	n := v.Len()
+	if chunkSize <= 0 {
+		chunkSize = n
+	}
+	var recs []array.Record
+	for beg := 0; beg < n; beg += chunkSize {
+		end := beg + chunkSize
+		if end > n {
+			end = n
+		}
+		rec, err := encodeRecord(mem, schema, fields, encs, v, beg, end)
+		if err != nil {
+			for _, rec := range recs {
+				rec.Release()
+			}
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+// encodeRecord encodes the rows [beg, end) of v to a record.
+func encodeRecord(mem memory.Allocator, schema *arrow.Schema, fields []structField, encs []encoder, v reflect.Value, beg, end int) (array.Record, error) {
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Reserve(end - beg)
+
+	for i := beg; i < end; i++ {
+		row := v.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				return nil, xerrors.Errorf("arrow/marshal: cannot encode nil row %d", i)
+			}
+			row = row.Elem()
+		}
+		for j, f := range fields {
+			encs[j](b.Field(j), row.Field(f.index))
+		}
+	}
+	return b.NewRecord(), nil
+}
+
+// encoder appends a Go value to a builder.
+type encoder func(b array.Builder, v reflect.Value)
+
+// newEncoder returns the encoder of the values of t, whose Arrow type is dt
+// as returned by typeOf.
+func newEncoder(t reflect.Type, dt arrow.DataType) encoder {
+	switch t {
+	case timeType:
+		unit := dt.(*arrow.TimestampType).Unit
+		return func(b array.Builder, v reflect.Value) {
+			b.(*array.TimestampBuilder).Append(arrow.Timestamp(timeToUnit(v.Interface().(time.Time), unit)))
+		}
+	case durationType:
+		per := int64(unitDuration(dt.(*arrow.DurationType).Unit))
+		return func(b array.Builder, v reflect.Value) {
+			b.(*array.DurationBuilder).Append(arrow.Duration(v.Int() / per))
+		}
+	case decimalType:
+		return func(b array.Builder, v reflect.Value) {
+			b.(*array.Decimal128Builder).Append(v.Interface().(decimal128.Num))
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(b array.Builder, v reflect.Value) { b.(*array.BooleanBuilder).Append(v.Bool()) }
+	case reflect.Int8:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Int8Builder).Append(int8(v.Int())) }
+	case reflect.Int16:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Int16Builder).Append(int16(v.Int())) }
+	case reflect.Int32:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Int32Builder).Append(int32(v.Int())) }
+	case reflect.Int64, reflect.Int:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Int64Builder).Append(v.Int()) }
+	case reflect.Uint8:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Uint8Builder).Append(uint8(v.Uint())) }
+	case reflect.Uint16:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Uint16Builder).Append(uint16(v.Uint())) }
+	case reflect.Uint32:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Uint32Builder).Append(uint32(v.Uint())) }
+	case reflect.Uint64, reflect.Uint:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Uint64Builder).Append(v.Uint()) }
+	case reflect.Float32:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Float32Builder).Append(float32(v.Float())) }
+	case reflect.Float64:
+		return func(b array.Builder, v reflect.Value) { b.(*array.Float64Builder).Append(v.Float()) }
+	case reflect.String:
+		return func(b array.Builder, v reflect.Value) { b.(*array.StringBuilder).Append(v.String()) }
+
+	case reflect.Ptr:
+		enc := newEncoder(t.Elem(), dt)
+		null := nullEncoder(dt)
+		return func(b array.Builder, v reflect.Value) {
+			if v.IsNil() {
+				null(b)
+				return
+			}
+			enc(b, v.Elem())
+		}
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return func(b array.Builder, v reflect.Value) {
+				if v.IsNil() {
+					b.AppendNull()
+					return
+				}
+				b.(*array.BinaryBuilder).Append(v.Bytes())
+			}
+		}
+		enc := newEncoder(t.Elem(), dt.(*arrow.ListType).Elem())
+		return func(b array.Builder, v reflect.Value) {
+			lb := b.(*array.ListBuilder)
+			if v.IsNil() {
+				lb.AppendNull()
+				return
+			}
+			lb.Append(true)
+			vb := lb.ValueBuilder()
+			for i := 0; i < v.Len(); i++ {
+				enc(vb, v.Index(i))
+			}
+		}
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			buf := reflect.New(t).Elem()
+			return func(b array.Builder, v reflect.Value) {
+				// v may not be addressable, and thus not sliceable.
+				buf.Set(v)
+				b.(*array.FixedSizeBinaryBuilder).Append(buf.Slice(0, buf.Len()).Bytes())
+			}
+		}
+		enc := newEncoder(t.Elem(), dt.(*arrow.FixedSizeListType).Elem())
+		return func(b array.Builder, v reflect.Value) {
+			lb := b.(*array.FixedSizeListBuilder)
+			lb.Append(true)
+			vb := lb.ValueBuilder()
+			for i := 0; i < v.Len(); i++ {
+				enc(vb, v.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		st := dt.(*arrow.ListType).Elem().(*arrow.StructType)
+		kenc := newEncoder(t.Key(), st.Field(0).Type)
+		venc := newEncoder(t.Elem(), st.Field(1).Type)
+		return func(b array.Builder, v reflect.Value) {
+			lb := b.(*array.ListBuilder)
+			if v.IsNil() {
+				lb.AppendNull()
+				return
+			}
+			lb.Append(true)
+			sb := lb.ValueBuilder().(*array.StructBuilder)
+			for _, k := range sortedKeys(v) {
+				sb.Append(true)
+				kenc(sb.FieldBuilder(0), k)
+				venc(sb.FieldBuilder(1), v.MapIndex(k))
+			}
+		}
+
+	case reflect.Struct:
+		st := dt.(*arrow.StructType)
+		var (
+			index []int
+			encs  []encoder
+		)
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.PkgPath != "" || sf.Tag.Get("arrow") == "-" {
+				continue
+			}
+			f := st.Field(len(encs))
+			index = append(index, i)
+			encs = append(encs, newEncoder(sf.Type, f.Type))
+		}
+		return func(b array.Builder, v reflect.Value) {
+			sb := b.(*array.StructBuilder)
+			sb.Append(true)
+			for j, enc := range encs {
+				enc(sb.FieldBuilder(j), v.Field(index[j]))
+			}
+		}
+	}
+	panic(xerrors.Errorf("arrow/marshal: unsupported type %v", t))
+}
+
+// nullEncoder returns a function appending a null of type dt to a builder.
+// Unlike the builders, it appends nulls to the children of fixed size
+// lists, which have a value for each element of a null list.
+func nullEncoder(dt arrow.DataType) func(b array.Builder) {
+	switch dt := dt.(type) {
+	case *arrow.FixedSizeListType:
+		n := int(dt.Len())
+		null := nullEncoder(dt.Elem())
+		return func(b array.Builder) {
+			lb := b.(*array.FixedSizeListBuilder)
+			lb.AppendNull()
+			for i := 0; i < n; i++ {
+				null(lb.ValueBuilder())
+			}
+		}
+	case *arrow.StructType:
+		nulls := make([]func(array.Builder), len(dt.Fields()))
+		for i, f := range dt.Fields() {
+			nulls[i] = nullEncoder(f.Type)
+		}
+		return func(b array.Builder) {
+			sb := b.(*array.StructBuilder)
+			sb.AppendValues([]bool{false})
+			for i, null := range nulls {
+				null(sb.FieldBuilder(i))
+			}
+		}
+	}
+	return func(b array.Builder) { b.AppendNull() }
+}
+
+// sortedKeys returns the keys of the map v, sorted if they are booleans,
+// numbers or strings so that maps are encoded deterministically.
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	var less func(a, b reflect.Value) bool
+	switch v.Type().Key().Kind() {
+	case reflect.Bool:
+		less = func(a, b reflect.Value) bool { return !a.Bool() && b.Bool() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		less = func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+	default:
+		return keys
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys
+}
+
+func timeToUnit(t time.Time, u arrow.TimeUnit) int64 {
+	per := int64(time.Second / unitDuration(u))
+	return t.Unix()*per + int64(t.Nanosecond())/int64(unitDuration(u))
+}
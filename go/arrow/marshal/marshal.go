@@ -0,0 +1,317 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package marshal converts between slices of Go structs and records.
+//
+// The columns of the records are the exported fields of the structs, in
+// order. The arrow struct tag of a field holds the name of its column,
+// followed by comma-separated options:
+//
+//	nullable          the column is nullable
+//	unit=s|ms|us|ns   the unit of a time.Time or time.Duration, ns by default
+//	tz=name           the time zone of a time.Time, none by default
+//	precision=n       the precision of a decimal128.Num, 38 by default
+//	scale=n           the scale of a decimal128.Num, 0 by default
+//
+// A field without a name in its tag keeps its Go name, and fields tagged
+// "-" are skipped. Options of slices, arrays, maps and pointers apply to
+// their elements.
+//
+// Go types are mapped to Arrow types as follows:
+//
+//	bool, integers, floats    the Arrow type of the same size; int and uint are 64 bits
+//	string                    string
+//	[]byte, [n]byte           binary, fixed size binary
+//	time.Time                 timestamp
+//	time.Duration             duration
+//	decimal128.Num            decimal128
+//	struct                    struct
+//	[]T, [n]T                 list, fixed size list
+//	map[K]V                   list of struct<key: K, value: V>
+//	*T                        T
+//
+// Pointers, slices and maps are nullable, nil being null.
+package marshal // import "github.com/apache/arrow/go/arrow/marshal"
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"golang.org/x/xerrors"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	decimalType  = reflect.TypeOf(decimal128.Num{})
+)
+
+// SchemaFromStruct returns the schema of the records holding values of the
+// struct type of v, which is either a struct, a pointer to a struct, or a
+// slice of either.
+func SchemaFromStruct(v interface{}) (*arrow.Schema, error) {
+	t, err := rowType(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	fields, err := structFields(t, "", map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return arrow.NewSchema(arrowFields(fields), nil), nil
+}
+
+// rowType returns the struct type of the rows of t, a struct, a pointer to
+// a struct, or a slice of either.
+func rowType(t reflect.Type) (reflect.Type, error) {
+	if t != nil && t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, xerrors.Errorf("arrow/marshal: %v is not a struct type", t)
+	}
+	return t, nil
+}
+
+// tagOptions are the options of the arrow tag of a struct field.
+type tagOptions struct {
+	name      string
+	nullable  bool
+	unit      arrow.TimeUnit
+	tz        string
+	precision int32
+	scale     int32
+}
+
+func parseTag(sf reflect.StructField) (tagOptions, error) {
+	opts := tagOptions{name: sf.Name, unit: arrow.Nanosecond, precision: 38}
+	tag, ok := sf.Tag.Lookup("arrow")
+	if !ok {
+		return opts, nil
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		opts.name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		var (
+			key = opt
+			val string
+			err error
+		)
+		if i := strings.Index(opt, "="); i >= 0 {
+			key, val = opt[:i], opt[i+1:]
+		}
+		switch key {
+		case "nullable":
+			opts.nullable = true
+		case "unit":
+			switch val {
+			case "s":
+				opts.unit = arrow.Second
+			case "ms":
+				opts.unit = arrow.Millisecond
+			case "us":
+				opts.unit = arrow.Microsecond
+			case "ns":
+				opts.unit = arrow.Nanosecond
+			default:
+				err = xerrors.Errorf("invalid unit %q", val)
+			}
+		case "tz":
+			opts.tz = val
+		case "precision", "scale":
+			var n int64
+			n, err = strconv.ParseInt(val, 10, 32)
+			if key == "precision" {
+				opts.precision = int32(n)
+			} else {
+				opts.scale = int32(n)
+			}
+		default:
+			err = xerrors.Errorf("unknown option %q", opt)
+		}
+		if err != nil {
+			return opts, xerrors.Errorf("arrow/marshal: field %q: invalid tag: %w", sf.Name, err)
+		}
+	}
+	if opts.precision <= 0 || opts.precision > 38 {
+		return opts, xerrors.Errorf("arrow/marshal: field %q: invalid decimal precision %d", sf.Name, opts.precision)
+	}
+	return opts, nil
+}
+
+// structField is an exported field of a struct, with its column.
+type structField struct {
+	index int
+	path  string
+	field arrow.Field
+	opts  tagOptions
+}
+
+// structFields returns the fields of the struct type t, whose path is the
+// one of the field holding it. seen holds the struct types being visited,
+// to reject recursive types.
+func structFields(t reflect.Type, path string, seen map[reflect.Type]bool) ([]structField, error) {
+	if seen[t] {
+		return nil, xerrors.Errorf("arrow/marshal: field %q: recursive type %v", path, t)
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" || sf.Tag.Get("arrow") == "-" {
+			continue
+		}
+		opts, err := parseTag(sf)
+		if err != nil {
+			return nil, err
+		}
+		f := structField{index: i, path: opts.name, opts: opts}
+		if path != "" {
+			f.path = path + "." + opts.name
+		}
+		dt, err := typeOf(sf.Type, opts, f.path, seen)
+		if err != nil {
+			return nil, err
+		}
+		f.field = arrow.Field{Name: opts.name, Type: dt, Nullable: opts.nullable || isNullable(sf.Type)}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func arrowFields(fields []structField) []arrow.Field {
+	fs := make([]arrow.Field, len(fields))
+	for i, f := range fields {
+		fs[i] = f.field
+	}
+	return fs
+}
+
+// isNullable returns whether nil values of t are encoded as nulls.
+func isNullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// typeOf returns the Arrow type of the values of t, held by the field at
+// path with the given tag options.
+func typeOf(t reflect.Type, opts tagOptions, path string, seen map[reflect.Type]bool) (arrow.DataType, error) {
+	switch t {
+	case timeType:
+		return &arrow.TimestampType{Unit: opts.unit, TimeZone: opts.tz}, nil
+	case durationType:
+		return &arrow.DurationType{Unit: opts.unit}, nil
+	case decimalType:
+		return &arrow.Decimal128Type{Precision: opts.precision, Scale: opts.scale}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case reflect.Int64, reflect.Int:
+		return arrow.PrimitiveTypes.Int64, nil
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case reflect.Uint64, reflect.Uint:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case reflect.String:
+		return arrow.BinaryTypes.String, nil
+	case reflect.Ptr:
+		return typeOf(t.Elem(), opts, path, seen)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return arrow.BinaryTypes.Binary, nil
+		}
+		elem, err := typeOf(t.Elem(), opts, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elem), nil
+	case reflect.Array:
+		if t.Len() == 0 {
+			break
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &arrow.FixedSizeBinaryType{ByteWidth: t.Len()}, nil
+		}
+		elem, err := typeOf(t.Elem(), opts, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.FixedSizeListOf(int32(t.Len()), elem), nil
+	case reflect.Map:
+		if t.Key().Kind() == reflect.Ptr {
+			break
+		}
+		key, err := typeOf(t.Key(), opts, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		value, err := typeOf(t.Elem(), opts, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		return mapType(key, value, isNullable(t.Elem())), nil
+	case reflect.Struct:
+		fields, err := structFields(t, path, seen)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.StructOf(arrowFields(fields)...), nil
+	}
+	return nil, xerrors.Errorf("arrow/marshal: field %q: unsupported type %v", path, t)
+}
+
+// mapType returns the type of maps with the given key and value types: a
+// list of key-value structs.
+func mapType(key, value arrow.DataType, nullable bool) arrow.DataType {
+	return arrow.ListOf(arrow.StructOf(
+		arrow.Field{Name: "key", Type: key},
+		arrow.Field{Name: "value", Type: value, Nullable: nullable},
+	))
+}
+
+func unitDuration(u arrow.TimeUnit) time.Duration {
+	return [...]time.Duration{time.Nanosecond, time.Microsecond, time.Millisecond, time.Second}[u]
+}
@@ -0,0 +1,417 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marshal_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/marshal"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+type point struct {
+	X, Y float64
+}
+
+type row struct {
+	ID       int64             `arrow:"id"`
+	Name     string            `arrow:"name"`
+	Score    *float32          `arrow:"score"`
+	Tags     []string          `arrow:"tags"`
+	Attrs    map[string]int32  `arrow:"attrs"`
+	Created  time.Time         `arrow:"created,unit=ms,tz=UTC"`
+	Elapsed  time.Duration     `arrow:"elapsed,unit=us"`
+	Price    decimal128.Num    `arrow:"price,precision=10,scale=2"`
+	Digest   [4]byte           `arrow:"digest"`
+	Data     []byte            `arrow:"data"`
+	Origin   point             `arrow:"origin"`
+	Path     []point           `arrow:"path"`
+	Corners  [2]*point         `arrow:"corners"`
+	Comment  string            `arrow:"comment,nullable"`
+	Counters map[uint8][]int16 `arrow:"counters"`
+	Skipped  int               `arrow:"-"`
+	internal int
+}
+
+func rows() []row {
+	score := float32(0.5)
+	return []row{
+		{
+			ID: 1, Name: "a", Score: &score, Tags: []string{"x", "y"},
+			Attrs:   map[string]int32{"k1": 1, "k2": 2},
+			Created: time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.UTC),
+			Elapsed: 1500 * time.Microsecond, Price: decimal128.FromI64(1234),
+			Digest: [4]byte{1, 2, 3, 4}, Data: []byte("data"),
+			Origin: point{1, 2}, Path: []point{{3, 4}, {5, 6}},
+			Corners:  [2]*point{{7, 8}, nil},
+			Counters: map[uint8][]int16{1: {1, 2}, 2: nil},
+		},
+		{ID: 2, Name: "b", Tags: []string{}, Attrs: map[string]int32{}, Data: []byte{}, Path: []point{}},
+		{ID: 3, Comment: "c", Created: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)},
+	}
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	schema, err := marshal.SchemaFromStruct([]*row(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt := arrow.StructOf(
+		arrow.Field{Name: "X", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "Y", Type: arrow.PrimitiveTypes.Float64},
+	)
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+		{Name: "attrs", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "key", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "value", Type: arrow.PrimitiveTypes.Int32},
+		)), Nullable: true},
+		{Name: "created", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+		{Name: "elapsed", Type: &arrow.DurationType{Unit: arrow.Microsecond}},
+		{Name: "price", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{Name: "digest", Type: &arrow.FixedSizeBinaryType{ByteWidth: 4}},
+		{Name: "data", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "origin", Type: pt},
+		{Name: "path", Type: arrow.ListOf(pt), Nullable: true},
+		{Name: "corners", Type: arrow.FixedSizeListOf(2, pt)},
+		{Name: "comment", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "counters", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "key", Type: arrow.PrimitiveTypes.Uint8},
+			arrow.Field{Name: "value", Type: arrow.ListOf(arrow.PrimitiveTypes.Int16), Nullable: true},
+		)), Nullable: true},
+	}, nil)
+	if !schema.Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", schema, want)
+	}
+
+	for _, v := range []interface{}{
+		0,
+		[]int{},
+		struct{ C chan int }{},
+		struct{ M map[*int]int }{},
+		struct {
+			T time.Time `arrow:",unit=h"`
+		}{},
+		struct {
+			D decimal128.Num `arrow:",precision=40"`
+		}{},
+		struct {
+			X int `arrow:",unknown"`
+		}{},
+	} {
+		if _, err := marshal.SchemaFromStruct(v); err == nil {
+			t.Errorf("%T: expected an error", v)
+		}
+	}
+
+	type node struct {
+		Children []node
+	}
+	if _, err := marshal.SchemaFromStruct(node{}); err == nil || !strings.Contains(err.Error(), "recursive") {
+		t.Errorf("invalid error for a recursive type: %v", err)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	want := rows()
+	recs, err := marshal.EncodeRecords(mem, want, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[0].NumRows() != 2 || recs[1].NumRows() != 1 {
+		t.Fatalf("invalid records: %v", recs)
+	}
+
+	var got []row
+	for _, rec := range recs {
+		if err := marshal.DecodeRecords(rec, &got); err != nil {
+			t.Fatal(err)
+		}
+		rec.Release()
+	}
+	for i := range want {
+		// the ms unit truncates the time.
+		want[i].Created = want[i].Created.Truncate(time.Millisecond)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	ptrs := []*row{&want[0], nil}
+	if _, err := marshal.EncodeRecords(mem, ptrs, 0); err == nil {
+		t.Fatalf("expected an error for a nil row")
+	}
+	if recs, err := marshal.EncodeRecords(mem, []row{}, 0); err != nil || len(recs) != 0 {
+		t.Fatalf("invalid encoding of no rows: %v, %v", recs, err)
+	}
+}
+
+func TestDecodeRecords(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int16},
+		{Name: "b", Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}},
+		{Name: "c", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "extra", Type: arrow.PrimitiveTypes.Float64},
+	}, nil)
+	// the dictionary column is built from its indices.
+	fields := append([]arrow.Field(nil), schema.Fields()...)
+	fields[1].Type = arrow.PrimitiveTypes.Int8
+	b := array.NewRecordBuilder(mem, arrow.NewSchema(fields, nil))
+	defer b.Release()
+	b.Field(0).(*array.Int16Builder).AppendValues([]int16{1, 300}, nil)
+	b.Field(1).(*array.Int8Builder).AppendValues([]int8{1, 0}, []bool{true, false})
+	b.Field(2).(*array.Date32Builder).AppendValues([]arrow.Date32{1, 2}, nil)
+	b.Field(3).(*array.Float64Builder).AppendValues([]float64{1, 2}, nil)
+	built := b.NewRecord()
+	defer built.Release()
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"x", "y"}, nil)
+	dict := sb.NewArray()
+	defer dict.Release()
+	cols := append([]array.Interface(nil), built.Columns()...)
+	cols[1] = array.NewDictionaryArray(schema.Field(1).Type.(*arrow.DictionaryType), cols[1], dict)
+	defer cols[1].Release()
+
+	rec := array.NewRecord(schema, cols, 2)
+	defer rec.Release()
+
+	type tagged struct {
+		A int64     `arrow:"a"`
+		B *string   `arrow:"b"`
+		C time.Time `arrow:"c"`
+	}
+	type untagged struct {
+		A int64
+		B *string
+		C time.Time
+	}
+
+	var tags []*tagged
+	err := marshal.DecodeRecords(rec, &tags)
+	if err == nil || !strings.Contains(err.Error(), `"extra"`) || len(tags) != 0 {
+		t.Fatalf("invalid error for an unknown column: %v", err)
+	}
+	if err := marshal.DecodeRecords(rec, &tags, marshal.WithIgnoreUnknownColumns(true)); err != nil {
+		t.Fatal(err)
+	}
+	y := "y"
+	want := []*tagged{
+		{A: 1, B: &y, C: time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{A: 300, C: time.Date(1970, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Fatalf("invalid rows:\ngot= %+v\nwant=%+v", tags, want)
+	}
+
+	// fields are matched by their name, and A, B and C have no column.
+	var untags []untagged
+	if err := marshal.DecodeRecords(rec, &untags, marshal.WithIgnoreUnknownColumns(true)); err != nil {
+		t.Fatal(err)
+	}
+	if len(untags) != 2 || untags[0] != (untagged{}) {
+		t.Fatalf("invalid rows: %+v", untags)
+	}
+
+	type renamed struct {
+		A int32   `arrow:"a"`
+		B string  `arrow:"b"`
+		C float64 `arrow:"c"`
+	}
+	type narrow struct {
+		A int8 `arrow:"a"`
+	}
+
+	var rows []renamed
+	err = marshal.DecodeRecords(rec, &rows, marshal.WithIgnoreUnknownColumns(true))
+	if err == nil || !strings.Contains(err.Error(), `field "c"`) {
+		t.Fatalf("invalid error for a type mismatch: %v", err)
+	}
+
+	var narrows []narrow
+	err = marshal.DecodeRecords(rec, &narrows, marshal.WithIgnoreUnknownColumns(true))
+	if err == nil || !strings.Contains(err.Error(), `field "a"`) || len(narrows) != 0 {
+		t.Fatalf("invalid error for an overflow: %v", err)
+	}
+
+	if err := marshal.DecodeRecords(rec, tags); err == nil {
+		t.Fatalf("expected an error when decoding into a slice")
+	}
+}
+
+// TestEncodeDecodeRandom round trips random values of random struct types.
+func TestEncodeDecodeRandom(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		typ := randomStruct(r, 0)
+		want := reflect.MakeSlice(reflect.SliceOf(typ), r.Intn(20), 20)
+		for j := 0; j < want.Len(); j++ {
+			randomValue(r, want.Index(j))
+		}
+
+		recs, err := marshal.EncodeRecords(mem, want.Interface(), 1+r.Intn(8))
+		if err != nil {
+			t.Fatalf("%v: %v", typ, err)
+		}
+		got := reflect.New(want.Type())
+		for _, rec := range recs {
+			if err := marshal.DecodeRecords(rec, got.Interface()); err != nil {
+				t.Fatalf("%v: %v", typ, err)
+			}
+			rec.Release()
+		}
+		if got.Elem().Len() != want.Len() || (want.Len() > 0 && !reflect.DeepEqual(got.Elem().Interface(), want.Interface())) {
+			t.Fatalf("%v: invalid rows:\ngot= %+v\nwant=%+v", typ, got.Elem(), want)
+		}
+	}
+}
+
+var leafTypes = []reflect.Type{
+	reflect.TypeOf(false),
+	reflect.TypeOf(int8(0)),
+	reflect.TypeOf(int16(0)),
+	reflect.TypeOf(int32(0)),
+	reflect.TypeOf(int64(0)),
+	reflect.TypeOf(0),
+	reflect.TypeOf(uint8(0)),
+	reflect.TypeOf(uint16(0)),
+	reflect.TypeOf(uint32(0)),
+	reflect.TypeOf(uint64(0)),
+	reflect.TypeOf(uint(0)),
+	reflect.TypeOf(float32(0)),
+	reflect.TypeOf(float64(0)),
+	reflect.TypeOf(""),
+	reflect.TypeOf([]byte(nil)),
+	reflect.TypeOf([3]byte{}),
+	reflect.TypeOf(time.Time{}),
+	reflect.TypeOf(time.Duration(0)),
+	reflect.TypeOf(decimal128.Num{}),
+}
+
+func randomType(r *rand.Rand, depth int) reflect.Type {
+	if depth >= 3 {
+		return leafTypes[r.Intn(len(leafTypes))]
+	}
+	switch r.Intn(8) {
+	case 0:
+		// pointers to nil pointers, slices or maps are encoded as nulls,
+		// and decoded as nil pointers.
+		elem := randomType(r, depth+1)
+		switch elem.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			return elem
+		}
+		return reflect.PtrTo(elem)
+	case 1:
+		return reflect.SliceOf(randomType(r, depth+1))
+	case 2:
+		return reflect.ArrayOf(1+r.Intn(3), randomType(r, depth+1))
+	case 3:
+		return reflect.MapOf(reflect.TypeOf(""), randomType(r, depth+1))
+	case 4:
+		return randomStruct(r, depth+1)
+	}
+	return leafTypes[r.Intn(len(leafTypes))]
+}
+
+func randomStruct(r *rand.Rand, depth int) reflect.Type {
+	fields := make([]reflect.StructField, 1+r.Intn(5))
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: "F" + string('A'+rune(i)),
+			Type: randomType(r, depth),
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+// randomValue sets v to a random value of its type.
+func randomValue(r *rand.Rand, v reflect.Value) {
+	switch v.Type() {
+	case reflect.TypeOf(time.Time{}):
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(1<<33)-1<<32, r.Int63n(1e9)).UTC()))
+		return
+	case reflect.TypeOf(decimal128.Num{}):
+		v.Set(reflect.ValueOf(decimal128.New(r.Int63()-1<<62, r.Uint64())))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Uint64()) >> uint(64-v.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(r.Uint64() >> uint(64-v.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(float32(r.NormFloat64())))
+	case reflect.String:
+		b := make([]byte, r.Intn(20))
+		r.Read(b)
+		v.SetString(string(b))
+	case reflect.Ptr:
+		if r.Intn(4) != 0 {
+			v.Set(reflect.New(v.Type().Elem()))
+			randomValue(r, v.Elem())
+		}
+	case reflect.Slice:
+		if r.Intn(4) != 0 {
+			v.Set(reflect.MakeSlice(v.Type(), r.Intn(4), 4))
+			for i := 0; i < v.Len(); i++ {
+				randomValue(r, v.Index(i))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			randomValue(r, v.Index(i))
+		}
+	case reflect.Map:
+		if r.Intn(4) != 0 {
+			v.Set(reflect.MakeMap(v.Type()))
+			for i := r.Intn(4); i > 0; i-- {
+				k, e := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+				randomValue(r, k)
+				randomValue(r, e)
+				v.SetMapIndex(k, e)
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			randomValue(r, v.Field(i))
+		}
+	}
+}
@@ -55,3 +55,20 @@ func TestBufferReset(t *testing.T) {
 	assert.Equal(t, newBytes, buf.Bytes())
 	assert.Equal(t, len(newBytes), buf.Len())
 }
+
+func TestNewBufferWithAllocator(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	data := mem.Allocate(10)
+	buf := memory.NewBufferWithAllocator(data, mem)
+	buf.Retain() // refCount == 2
+	assert.Equal(t, 10, buf.Len())
+	assert.False(t, buf.Mutable())
+
+	buf.Release() // refCount == 1
+	assert.Equal(t, data, buf.Bytes())
+
+	buf.Release() // refCount == 0
+	assert.Nil(t, buf.Bytes())
+}
@@ -49,10 +49,19 @@ type TestingT interface {
 func (a *CheckedAllocator) AssertSize(t TestingT, sz int) {
 	if a.sz != sz {
 		t.Helper()
-		t.Errorf("invalid memory size exp=%d, got=%d", sz, a.sz)
+		t.Errorf("invalid memory size exp=%d, got=%d%s", sz, a.sz, a.report())
 	}
 }
 
+// report returns the report of the underlying allocator if it is a
+// DebugAllocator.
+func (a *CheckedAllocator) report() string {
+	if d, ok := a.mem.(*DebugAllocator); ok {
+		return "\n" + d.Report()
+	}
+	return ""
+}
+
 type CheckedAllocatorScope struct {
 	alloc *CheckedAllocator
 	sz    int
@@ -65,7 +74,7 @@ func NewCheckedAllocatorScope(alloc *CheckedAllocator) *CheckedAllocatorScope {
 func (c *CheckedAllocatorScope) CheckSize(t TestingT) {
 	if c.sz != c.alloc.sz {
 		t.Helper()
-		t.Errorf("invalid memory size exp=%d, got=%d", c.sz, c.alloc.sz)
+		t.Errorf("invalid memory size exp=%d, got=%d%s", c.sz, c.alloc.sz, c.alloc.report())
 	}
 }
 
@@ -0,0 +1,304 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// DebugAllocatorEnv is the environment variable which, when set, makes
+// DefaultAllocator a DebugAllocator over a GoAllocator. Its value is a
+// comma-separated list of options: "sample=N" to capture the stack of one
+// allocation every N, "depth=N" for the number of frames captured and
+// "guard=N" for the size of the guard regions, e.g. "sample=100,guard=64".
+// Any other value enables the default options.
+const DebugAllocatorEnv = "ARROW_DEBUG_ALLOCATOR"
+
+func init() {
+	if v, ok := os.LookupEnv(DebugAllocatorEnv); ok {
+		DefaultAllocator = NewDebugAllocator(NewGoAllocator(), debugOptionsFromEnv(v)...)
+	}
+}
+
+// guardByte fills the guard regions around the buffers of a DebugAllocator.
+const guardByte = 0xa5
+
+// DebugAllocator is an Allocator which keeps track of the outstanding
+// allocations of an underlying allocator, to report leaks along with the
+// stack traces of the code which allocated them, and detects double frees
+// and buffer overruns.
+//
+// Overruns are detected by surrounding each buffer with guard regions,
+// checked when the buffer is freed. Errors are recorded, see Errors, and
+// the faulty buffers are not freed.
+//
+// DebugAllocator is safe to use from multiple goroutines.
+type DebugAllocator struct {
+	mem    Allocator
+	sample int // stack of one allocation every sample, none if 0
+	depth  int
+	guard  int
+
+	mu     sync.Mutex
+	allocs map[uintptr]*debugAllocation
+	n      int // number of allocations
+	sz     int
+	errs   []error
+}
+
+type debugAllocation struct {
+	raw   []byte // buffer of the underlying allocator, with the guards
+	size  int
+	stack []uintptr
+}
+
+// DebugOption configures a DebugAllocator.
+type DebugOption func(*DebugAllocator)
+
+// WithStackSampling captures the stack trace of one allocation every n,
+// which lowers the cost of the allocator in production. Stacks are captured
+// for all allocations if n is 1, and never if n is 0. It defaults to 1.
+func WithStackSampling(n int) DebugOption {
+	return func(a *DebugAllocator) { a.sample = n }
+}
+
+// WithStackDepth sets the maximum number of frames of the captured stack
+// traces. It defaults to 32.
+func WithStackDepth(n int) DebugOption {
+	return func(a *DebugAllocator) { a.depth = n }
+}
+
+// WithGuardSize surrounds each buffer with guard regions of n bytes,
+// rounded up to keep buffers aligned, to detect overruns. There are no
+// guard regions by default.
+func WithGuardSize(n int) DebugOption {
+	return func(a *DebugAllocator) { a.guard = roundUpToMultipleOf64(n) }
+}
+
+// NewDebugAllocator returns a DebugAllocator allocating from mem.
+func NewDebugAllocator(mem Allocator, opts ...DebugOption) *DebugAllocator {
+	a := &DebugAllocator{
+		mem:    mem,
+		sample: 1,
+		depth:  32,
+		allocs: make(map[uintptr]*debugAllocation),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *DebugAllocator) Allocate(size int) []byte {
+	raw := a.mem.Allocate(size + 2*a.guard)
+	b := raw[a.guard : a.guard+size : a.guard+size]
+	if len(raw) == 0 {
+		// empty buffers may share their address, and need no tracking.
+		return b
+	}
+	for i := 0; i < a.guard; i++ {
+		raw[i], raw[len(raw)-1-i] = guardByte, guardByte
+	}
+
+	alloc := &debugAllocation{raw: raw, size: size}
+	a.mu.Lock()
+	a.n++
+	if a.sample > 0 && a.n%a.sample == 0 {
+		alloc.stack = callers(a.depth)
+	}
+	a.allocs[dataOf(b)] = alloc
+	a.sz += size
+	a.mu.Unlock()
+	return b
+}
+
+func (a *DebugAllocator) Reallocate(size int, b []byte) []byte {
+	if size == len(b) {
+		return b
+	}
+	out := a.Allocate(size)
+	copy(out, b)
+	a.Free(b)
+	return out
+}
+
+func (a *DebugAllocator) Free(b []byte) {
+	if dataOf(b) == 0 || (len(b) == 0 && a.guard == 0) {
+		a.mem.Free(b)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	addr := dataOf(b)
+	alloc, ok := a.allocs[addr]
+	if !ok {
+		a.errs = append(a.errs, fmt.Errorf("arrow/memory: free of a buffer of %d bytes at %#x which is not allocated, or already freed, at:\n%s",
+			len(b), addr, formatStack(callers(a.depth))))
+		return
+	}
+	delete(a.allocs, addr)
+	a.sz -= alloc.size
+
+	for i := 0; i < a.guard; i++ {
+		if alloc.raw[i] != guardByte || alloc.raw[len(alloc.raw)-1-i] != guardByte {
+			a.errs = append(a.errs, fmt.Errorf("arrow/memory: overrun of the buffer of %d bytes at %#x, allocated at:\n%s",
+				alloc.size, addr, formatStack(alloc.stack)))
+			return
+		}
+	}
+	a.mem.Free(alloc.raw)
+}
+
+// CurrentAlloc returns the number of bytes currently allocated.
+func (a *DebugAllocator) CurrentAlloc() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sz
+}
+
+// Errors returns the double frees and overruns detected so far.
+func (a *DebugAllocator) Errors() []error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]error(nil), a.errs...)
+}
+
+// Leak describes the outstanding allocations made from a call site.
+type Leak struct {
+	Stack string // stack trace of the call site, empty if not sampled
+	Count int    // number of allocations
+	Bytes int    // total size of the allocations
+}
+
+// Leaks returns the outstanding allocations, grouped by call site, by
+// decreasing total size.
+func (a *DebugAllocator) Leaks() []Leak {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index := make(map[string]int)
+	var leaks []Leak
+	for _, alloc := range a.allocs {
+		stack := formatStack(alloc.stack)
+		i, ok := index[stack]
+		if !ok {
+			i = len(leaks)
+			index[stack] = i
+			leaks = append(leaks, Leak{Stack: stack})
+		}
+		leaks[i].Count++
+		leaks[i].Bytes += alloc.size
+	}
+	sort.Slice(leaks, func(i, j int) bool {
+		if leaks[i].Bytes != leaks[j].Bytes {
+			return leaks[i].Bytes > leaks[j].Bytes
+		}
+		return leaks[i].Stack < leaks[j].Stack
+	})
+	return leaks
+}
+
+// Report returns a description of the leaks and errors of a.
+func (a *DebugAllocator) Report() string {
+	o := new(strings.Builder)
+	for _, leak := range a.Leaks() {
+		fmt.Fprintf(o, "%d bytes leaked in %d allocations", leak.Bytes, leak.Count)
+		if leak.Stack == "" {
+			o.WriteString(" (no stack trace sampled)\n")
+			continue
+		}
+		fmt.Fprintf(o, " from:\n%s", leak.Stack)
+	}
+	for _, err := range a.Errors() {
+		fmt.Fprintf(o, "%v", err)
+	}
+	return o.String()
+}
+
+// AssertSize checks that sz bytes are allocated, and that no errors were
+// detected. Failures print the report of the allocator.
+func (a *DebugAllocator) AssertSize(t TestingT, sz int) {
+	if cur := a.CurrentAlloc(); cur != sz || len(a.Errors()) > 0 {
+		t.Helper()
+		t.Errorf("invalid memory size exp=%d, got=%d\n%s", sz, cur, a.Report())
+	}
+}
+
+// callers returns the stack of the caller of the allocator, without the
+// frames of the allocator itself.
+func callers(depth int) []uintptr {
+	pcs := make([]uintptr, depth)
+	return pcs[:runtime.Callers(3, pcs)]
+}
+
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+	o := new(strings.Builder)
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(o, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return o.String()
+}
+
+// dataOf returns the address of the data of b, which may be empty.
+func dataOf(b []byte) uintptr {
+	return (*reflect.SliceHeader)(unsafe.Pointer(&b)).Data
+}
+
+func debugOptionsFromEnv(v string) []DebugOption {
+	var opts []DebugOption
+	for _, opt := range strings.Split(v, ",") {
+		kv := strings.SplitN(strings.TrimSpace(opt), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		n, err := strconv.Atoi(kv[1])
+		if err != nil || n < 0 {
+			continue
+		}
+		switch kv[0] {
+		case "sample":
+			opts = append(opts, WithStackSampling(n))
+		case "depth":
+			opts = append(opts, WithStackDepth(n))
+		case "guard":
+			opts = append(opts, WithGuardSize(n))
+		}
+	}
+	return opts
+}
+
+var (
+	_ Allocator = (*DebugAllocator)(nil)
+)
@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:noinline
+func allocateSmall(a Allocator) []byte { return a.Allocate(10) }
+
+//go:noinline
+func allocateLarge(a Allocator) []byte { return a.Allocate(1000) }
+
+func TestDebugAllocatorLeaks(t *testing.T) {
+	a := NewDebugAllocator(NewGoAllocator())
+
+	small1, small2 := allocateSmall(a), allocateSmall(a)
+	large := allocateLarge(a)
+	freed := allocateLarge(a)
+	a.Free(freed)
+	assert.Equal(t, 1020, a.CurrentAlloc())
+
+	leaks := a.Leaks()
+	if assert.Len(t, leaks, 2) {
+		assert.Equal(t, 1, leaks[0].Count)
+		assert.Equal(t, 1000, leaks[0].Bytes)
+		assert.Contains(t, leaks[0].Stack, "memory.allocateLarge")
+		assert.Equal(t, 2, leaks[1].Count)
+		assert.Equal(t, 20, leaks[1].Bytes)
+		assert.Contains(t, leaks[1].Stack, "memory.allocateSmall")
+	}
+
+	a.Free(small1)
+	a.Free(small2)
+	a.Free(large)
+	assert.Empty(t, a.Leaks())
+	assert.Empty(t, a.Errors())
+}
+
+func TestDebugAllocatorSampling(t *testing.T) {
+	a := NewDebugAllocator(NewGoAllocator(), WithStackSampling(0))
+	b := allocateSmall(a)
+	leaks := a.Leaks()
+	if assert.Len(t, leaks, 1) {
+		assert.Equal(t, "", leaks[0].Stack)
+	}
+	assert.Contains(t, a.Report(), "10 bytes leaked in 1 allocations (no stack trace sampled)")
+	a.Free(b)
+
+	a = NewDebugAllocator(NewGoAllocator(), WithStackSampling(2))
+	for i := 0; i < 4; i++ {
+		allocateSmall(a)
+	}
+	sampled := 0
+	for _, leak := range a.Leaks() {
+		if leak.Stack != "" {
+			sampled += leak.Count
+		}
+	}
+	assert.Equal(t, 2, sampled)
+}
+
+func TestDebugAllocatorDoubleFree(t *testing.T) {
+	a := NewDebugAllocator(NewGoAllocator())
+	b := a.Allocate(64)
+	a.Free(b)
+	a.Free(b)
+	a.Free(nil)
+
+	errs := a.Errors()
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), "not allocated, or already freed")
+		assert.Contains(t, errs[0].Error(), "TestDebugAllocatorDoubleFree")
+	}
+}
+
+func TestDebugAllocatorOverrun(t *testing.T) {
+	a := NewDebugAllocator(NewGoAllocator(), WithGuardSize(1))
+	b := allocateSmall(a)
+	assert.True(t, isAlignedTo(int(addressOf(b)), alignment))
+	assert.Equal(t, 10, len(b))
+	assert.Equal(t, 10, cap(b))
+
+	ok := a.Allocate(0)
+	ok = a.Reallocate(100, ok)
+	a.Free(ok)
+
+	// write past the end of b.
+	(*[11]byte)(unsafe.Pointer(&b[0]))[10] = 0
+	a.Free(b)
+
+	errs := a.Errors()
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), "overrun of the buffer of 10 bytes")
+		assert.Contains(t, errs[0].Error(), "memory.allocateSmall")
+	}
+}
+
+type testingT struct {
+	errs []string
+}
+
+func (t *testingT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+func (*testingT) Helper() {}
+
+func TestDebugAllocatorAssertSize(t *testing.T) {
+	a := NewDebugAllocator(NewGoAllocator())
+	checked := NewCheckedAllocator(a)
+	b := allocateLarge(checked)
+
+	var tt testingT
+	a.AssertSize(&tt, 0)
+	checked.AssertSize(&tt, 0)
+	if assert.Len(t, tt.errs, 2) {
+		for _, msg := range tt.errs {
+			assert.Contains(t, msg, "invalid memory size exp=0, got=1000")
+			assert.Contains(t, msg, "1000 bytes leaked in 1 allocations from:")
+			assert.Contains(t, msg, "memory.allocateLarge")
+		}
+	}
+
+	checked.Free(b)
+	tt.errs = nil
+	a.AssertSize(&tt, 0)
+	checked.AssertSize(&tt, 0)
+	assert.Empty(t, tt.errs)
+}
+
+func TestDebugOptionsFromEnv(t *testing.T) {
+	a := NewDebugAllocator(NewGoAllocator(), debugOptionsFromEnv("sample=10, guard=1,depth=x,other=2,on")...)
+	assert.Equal(t, 10, a.sample)
+	assert.Equal(t, 64, a.guard)
+	assert.Equal(t, 32, a.depth)
+
+	a = NewDebugAllocator(NewGoAllocator(), debugOptionsFromEnv("1")...)
+	assert.Equal(t, 1, a.sample)
+	assert.Equal(t, 0, a.guard)
+}
+
+func BenchmarkAllocators(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		mem  Allocator
+	}{
+		{"go", NewGoAllocator()},
+		{"checked", NewCheckedAllocator(NewGoAllocator())},
+		{"debug-nostack", NewDebugAllocator(NewGoAllocator(), WithStackSampling(0))},
+		{"debug-sample=100", NewDebugAllocator(NewGoAllocator(), WithStackSampling(100))},
+		{"debug", NewDebugAllocator(NewGoAllocator())},
+		{"debug-guard", NewDebugAllocator(NewGoAllocator(), WithGuardSize(64))},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				buf := bm.mem.Allocate(256)
+				buf = bm.mem.Reallocate(512, buf)
+				bm.mem.Free(buf)
+			}
+		})
+	}
+}
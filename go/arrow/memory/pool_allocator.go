@@ -0,0 +1,200 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+const (
+	// DefaultPoolMaxSize is the size of the largest buffers recycled by a
+	// PoolAllocator by default.
+	DefaultPoolMaxSize = 1 << 20
+
+	poolMinSize = alignment
+)
+
+// PoolAllocator is an Allocator recycling the buffers it allocates from
+// an underlying allocator. Buffers are rounded up to size classes, the
+// powers of two up to a maximum size, and freed buffers are kept in a free
+// list per class, to be handed out again, zeroed, by later allocations of
+// the same class. Larger buffers are allocated and freed directly.
+//
+// The buffers held by the free lists are only returned to the underlying
+// allocator by Trim.
+//
+// PoolAllocator is safe to use from multiple goroutines.
+type PoolAllocator struct {
+	mem     Allocator
+	maxSize int
+	classes []poolClass
+
+	hits   int64
+	misses int64
+	held   int64
+}
+
+type poolClass struct {
+	mu   sync.Mutex
+	free [][]byte
+}
+
+// PoolStats holds the statistics of a PoolAllocator.
+type PoolStats struct {
+	Hits      int64 // allocations served from a free list
+	Misses    int64 // allocations served by the underlying allocator
+	BytesHeld int64 // size of the buffers held by the free lists
+}
+
+// PoolOption configures a PoolAllocator.
+type PoolOption func(*PoolAllocator)
+
+// WithPoolMaxSize sets the size of the largest buffers recycled by the
+// pool, rounded up to a power of two. It defaults to DefaultPoolMaxSize.
+func WithPoolMaxSize(n int) PoolOption {
+	return func(a *PoolAllocator) { a.maxSize = n }
+}
+
+// NewPoolAllocator returns a PoolAllocator allocating from mem.
+func NewPoolAllocator(mem Allocator, opts ...PoolOption) *PoolAllocator {
+	a := &PoolAllocator{mem: mem, maxSize: DefaultPoolMaxSize}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.maxSize < poolMinSize {
+		a.maxSize = poolMinSize
+	}
+	a.maxSize = classSize(poolClassOf(a.maxSize))
+	a.classes = make([]poolClass, poolClassOf(a.maxSize)+1)
+	return a
+}
+
+// poolClassOf returns the size class of buffers of n bytes.
+func poolClassOf(n int) int {
+	c, size := 0, poolMinSize
+	for size < n {
+		c, size = c+1, size<<1
+	}
+	return c
+}
+
+func classSize(c int) int { return poolMinSize << uint(c) }
+
+func (a *PoolAllocator) Allocate(size int) []byte {
+	if size > a.maxSize {
+		atomic.AddInt64(&a.misses, 1)
+		return a.mem.Allocate(size)
+	}
+
+	c := poolClassOf(size)
+	pc := &a.classes[c]
+	pc.mu.Lock()
+	if n := len(pc.free); n > 0 {
+		buf := pc.free[n-1]
+		pc.free[n-1] = nil
+		pc.free = pc.free[:n-1]
+		pc.mu.Unlock()
+		atomic.AddInt64(&a.hits, 1)
+		atomic.AddInt64(&a.held, -int64(len(buf)))
+		Set(buf[:size], 0)
+		return buf[:size:size]
+	}
+	pc.mu.Unlock()
+
+	atomic.AddInt64(&a.misses, 1)
+	return a.mem.Allocate(classSize(c))[:size:size]
+}
+
+func (a *PoolAllocator) Reallocate(size int, b []byte) []byte {
+	if len(b) <= a.maxSize && size <= a.maxSize && b != nil && poolClassOf(len(b)) == poolClassOf(size) {
+		// the buffer of the class is large enough: resize it in place.
+		buf := a.class(b)
+		if size > len(b) {
+			Set(buf[len(b):size], 0)
+		}
+		return buf[:size:size]
+	}
+
+	out := a.Allocate(size)
+	copy(out, b)
+	a.Free(b)
+	return out
+}
+
+func (a *PoolAllocator) Free(b []byte) {
+	switch {
+	case b == nil:
+		return
+	case len(b) > a.maxSize:
+		a.mem.Free(b)
+		return
+	}
+
+	buf := a.class(b)
+	pc := &a.classes[poolClassOf(len(b))]
+	pc.mu.Lock()
+	pc.free = append(pc.free, buf)
+	pc.mu.Unlock()
+	atomic.AddInt64(&a.held, int64(len(buf)))
+}
+
+// class returns the whole buffer of the size class of b, which must have
+// been allocated by the pool.
+func (a *PoolAllocator) class(b []byte) []byte {
+	size := classSize(poolClassOf(len(b)))
+	var buf []byte
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&buf))
+	hdr.Data = (*reflect.SliceHeader)(unsafe.Pointer(&b)).Data
+	hdr.Len, hdr.Cap = size, size
+	return buf
+}
+
+// Stats returns the statistics of the pool.
+func (a *PoolAllocator) Stats() PoolStats {
+	return PoolStats{
+		Hits:      atomic.LoadInt64(&a.hits),
+		Misses:    atomic.LoadInt64(&a.misses),
+		BytesHeld: atomic.LoadInt64(&a.held),
+	}
+}
+
+// Trim returns the buffers held by the free lists to the underlying
+// allocator, and returns their total size.
+func (a *PoolAllocator) Trim() int64 {
+	var n int64
+	for i := range a.classes {
+		pc := &a.classes[i]
+		pc.mu.Lock()
+		free := pc.free
+		pc.free = nil
+		pc.mu.Unlock()
+
+		for _, buf := range free {
+			n += int64(len(buf))
+			a.mem.Free(buf)
+		}
+	}
+	atomic.AddInt64(&a.held, -n)
+	return n
+}
+
+var (
+	_ Allocator = (*PoolAllocator)(nil)
+)
@@ -0,0 +1,62 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// BenchmarkRecords builds and releases 100k small records.
+func BenchmarkRecords(b *testing.B) {
+	const (
+		records = 100000
+		rows    = 16
+	)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "f", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "s", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	for _, bm := range []struct {
+		name string
+		mem  memory.Allocator
+	}{
+		{"go", memory.NewGoAllocator()},
+		{"pool", memory.NewPoolAllocator(memory.NewGoAllocator())},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bldr := array.NewRecordBuilder(bm.mem, schema)
+				for r := 0; r < records; r++ {
+					for j := 0; j < rows; j++ {
+						bldr.Field(0).(*array.Int64Builder).AppendValues([]int64{int64(j)}, []bool{j%4 != 0})
+						bldr.Field(1).(*array.Float64Builder).Append(float64(j))
+						bldr.Field(2).(*array.StringBuilder).Append("value")
+					}
+					bldr.NewRecord().Release()
+				}
+				bldr.Release()
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolAllocator(t *testing.T) {
+	checked := NewCheckedAllocator(NewGoAllocator())
+	a := NewPoolAllocator(checked, WithPoolMaxSize(1000))
+	assert.Equal(t, 1024, a.maxSize)
+
+	b := a.Allocate(100)
+	assert.Equal(t, 100, len(b))
+	assert.Equal(t, 100, cap(b))
+	assert.True(t, isAlignedTo(int(addressOf(b)), alignment))
+	Set(b, 0xff)
+	a.Free(b)
+	assert.Equal(t, PoolStats{Misses: 1, BytesHeld: 128}, a.Stats())
+
+	// a buffer of the same class is recycled, zeroed.
+	c := a.Allocate(120)
+	assert.Equal(t, addressOf(b), addressOf(c))
+	assert.Equal(t, make([]byte, 120), c)
+	assert.Equal(t, PoolStats{Hits: 1, Misses: 1}, a.Stats())
+
+	// and reallocated in place within its class.
+	Set(c, 1)
+	d := a.Reallocate(128, c)
+	assert.Equal(t, addressOf(c), addressOf(d))
+	assert.Equal(t, []byte{1, 0}, d[119:121])
+
+	e := a.Reallocate(200, d)
+	assert.NotEqual(t, addressOf(d), addressOf(e))
+	assert.Equal(t, byte(1), e[0])
+	assert.Equal(t, PoolStats{Hits: 1, Misses: 2, BytesHeld: 128}, a.Stats())
+
+	large := a.Allocate(2000)
+	a.Free(large)
+	a.Free(e)
+	a.Free(nil)
+	assert.Equal(t, PoolStats{Hits: 1, Misses: 3, BytesHeld: 384}, a.Stats())
+
+	checked.AssertSize(t, 384)
+	assert.Equal(t, int64(384), a.Trim())
+	assert.Equal(t, int64(0), a.Stats().BytesHeld)
+	checked.AssertSize(t, 0)
+}
+
+func TestPoolAllocatorChecked(t *testing.T) {
+	pool := NewPoolAllocator(NewGoAllocator())
+	checked := NewCheckedAllocator(pool)
+
+	buf := NewResizableBuffer(checked)
+	buf.Resize(10)
+	buf.Resize(1000)
+	buf.Resize(100)
+	buf.Release()
+	checked.AssertSize(t, 0)
+	assert.NotZero(t, pool.Stats().BytesHeld)
+}
+
+func TestPoolAllocatorConcurrent(t *testing.T) {
+	a := NewPoolAllocator(NewGoAllocator())
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b := a.Allocate(64 + (i%8)*100)
+				for j := range b {
+					if b[j] != 0 {
+						t.Errorf("buffer is not zeroed")
+						return
+					}
+					b[j] = byte(g)
+				}
+				b = a.Reallocate(len(b)*2, b)
+				for j := range b[:len(b)/2] {
+					if b[j] != byte(g) {
+						t.Errorf("buffer is shared")
+						return
+					}
+				}
+				a.Free(b)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	stats := a.Stats()
+	assert.Equal(t, int64(8*1000*2), stats.Hits+stats.Misses)
+	assert.Equal(t, stats.BytesHeld, a.Trim())
+}
@@ -0,0 +1,201 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/float16"
+	"golang.org/x/xerrors"
+)
+
+const (
+	secondsPerDay = 86400
+	msPerDay      = secondsPerDay * 1000
+)
+
+// Cast converts s to the data type to.
+//
+// Null scalars cast to null scalars of any type and all scalars cast to
+// strings and binaries, while strings and binaries are parsed with
+// ParseScalar. Numeric scalars, including booleans, cast to one another
+// following the Go conversion rules, so that values are truncated or wrapped
+// around when they do not fit the target type. Integers cast to and from
+// temporal types, and temporal scalars cast between units and between dates
+// and timestamps, flooring the values when the target unit is coarser.
+func Cast(s Scalar, to arrow.DataType) (Scalar, error) {
+	from := s.DataType()
+	switch {
+	case arrow.TypeEqual(from, to):
+		return s, nil
+	case !s.IsValid():
+		return MakeNullScalar(to), nil
+	}
+
+	switch to.ID() {
+	case arrow.STRING:
+		return &String{scalar{to, true}, s.String()}, nil
+	case arrow.BINARY:
+		return &Binary{scalar{to, true}, []byte(s.String())}, nil
+	}
+
+	switch s := s.(type) {
+	case *String:
+		return ParseScalar(to, s.Value)
+	case *Binary:
+		return ParseScalar(to, string(s.Value))
+	}
+
+	if isTemporal(from.ID()) && isTemporal(to.ID()) {
+		return castTemporal(s, to)
+	}
+
+	i, u, f, ok := numericValue(s)
+	switch s := s.(type) {
+	case *Boolean:
+		if s.Value {
+			i, u, f = 1, 1, 1
+		}
+		ok = true
+	case *Float16:
+		f = float64(s.Value.Float32())
+		i, u = int64(f), uint64(f)
+		ok = true
+	}
+	if ok && isTemporal(from.ID()) != isTemporal(to.ID()) {
+		// only integers cast to and from temporal types.
+		ok = isInteger(from.ID()) || isInteger(to.ID())
+	}
+	if ok {
+		switch to.ID() {
+		case arrow.BOOL:
+			return &Boolean{scalar{to, true}, i != 0 || u != 0 || f != 0}, nil
+		case arrow.FLOAT16:
+			return &Float16{scalar{to, true}, float16.FromFloat64(f)}, nil
+		}
+		if v := makeNumeric(to, i, u, f); v != nil {
+			return v, nil
+		}
+	}
+	return nil, xerrors.Errorf("arrow/scalar: cannot cast scalar from %v to %v", from, to)
+}
+
+func isInteger(id arrow.Type) bool {
+	switch id {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return true
+	}
+	return false
+}
+
+func isTemporal(id arrow.Type) bool {
+	switch id {
+	case arrow.TIMESTAMP, arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64, arrow.DURATION:
+		return true
+	}
+	return false
+}
+
+func castTemporal(s Scalar, to arrow.DataType) (Scalar, error) {
+	v, _, _, _ := numericValue(s)
+	switch from := s.DataType().(type) {
+	case *arrow.TimestampType:
+		switch to := to.(type) {
+		case *arrow.TimestampType:
+			if unitsPerSecond(to.Unit) < unitsPerSecond(from.Unit) {
+				return makeNumeric(to, floorDiv(v, unitsPerSecond(from.Unit)/unitsPerSecond(to.Unit)), 0, 0), nil
+			}
+			return makeNumeric(to, convertUnit(v, from.Unit, to.Unit), 0, 0), nil
+		case *arrow.Date32Type:
+			return makeNumeric(to, floorDiv(v, unitsPerSecond(from.Unit)*secondsPerDay), 0, 0), nil
+		case *arrow.Date64Type:
+			return makeNumeric(to, floorDiv(v, unitsPerSecond(from.Unit)*secondsPerDay)*msPerDay, 0, 0), nil
+		}
+	case *arrow.Date32Type:
+		switch to := to.(type) {
+		case *arrow.Date64Type:
+			return makeNumeric(to, v*msPerDay, 0, 0), nil
+		case *arrow.TimestampType:
+			return makeNumeric(to, v*secondsPerDay*unitsPerSecond(to.Unit), 0, 0), nil
+		}
+	case *arrow.Date64Type:
+		switch to := to.(type) {
+		case *arrow.Date32Type:
+			return makeNumeric(to, floorDiv(v, msPerDay), 0, 0), nil
+		case *arrow.TimestampType:
+			return makeNumeric(to, floorDiv(v, msPerDay)*secondsPerDay*unitsPerSecond(to.Unit), 0, 0), nil
+		}
+	case *arrow.Time32Type, *arrow.Time64Type:
+		switch to := to.(type) {
+		case *arrow.Time32Type:
+			return makeNumeric(to, convertUnit(v, timeUnit(from), to.Unit), 0, 0), nil
+		case *arrow.Time64Type:
+			return makeNumeric(to, convertUnit(v, timeUnit(from), to.Unit), 0, 0), nil
+		}
+	case *arrow.DurationType:
+		if to, ok := to.(*arrow.DurationType); ok {
+			return makeNumeric(to, convertUnit(v, from.Unit, to.Unit), 0, 0), nil
+		}
+	}
+	return nil, xerrors.Errorf("arrow/scalar: cannot cast scalar from %v to %v", s.DataType(), to)
+}
+
+func timeUnit(dt arrow.DataType) arrow.TimeUnit {
+	switch dt := dt.(type) {
+	case *arrow.Time32Type:
+		return dt.Unit
+	case *arrow.Time64Type:
+		return dt.Unit
+	}
+	return arrow.Second
+}
+
+// unitsPerSecond returns the number of ticks of unit u in one second.
+func unitsPerSecond(u arrow.TimeUnit) int64 {
+	switch u {
+	case arrow.Millisecond:
+		return 1e3
+	case arrow.Microsecond:
+		return 1e6
+	case arrow.Nanosecond:
+		return 1e9
+	}
+	return 1
+}
+
+// nanosPer returns the number of nanoseconds in one tick of unit u.
+func nanosPer(u arrow.TimeUnit) int64 {
+	return 1e9 / unitsPerSecond(u)
+}
+
+// convertUnit converts v from unit from to unit to, truncating towards zero.
+func convertUnit(v int64, from, to arrow.TimeUnit) int64 {
+	a, b := unitsPerSecond(from), unitsPerSecond(to)
+	if a < b {
+		return v * (b / a)
+	}
+	return v / (a / b)
+}
+
+// floorDiv returns a / b rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,253 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"golang.org/x/xerrors"
+)
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal
+// to or greater than b. Nulls are less than any value and equal to each
+// other, and NaNs are greater than any other floating point value.
+//
+// Booleans, numbers, strings, binaries, decimals and temporal values are
+// ordered. Integers and floating point numbers of any type compare by
+// their value, while the other scalars must have the same data type.
+// Dictionary scalars compare by their decoded values.
+func Compare(a, b Scalar) (int, error) {
+	a, b, err := decodeDictionaries(a, b)
+	if err != nil {
+		return 0, err
+	}
+	defer release(a)
+	defer release(b)
+
+	ta, tb := a.DataType(), b.DataType()
+	if !isNumber(ta.ID()) || !isNumber(tb.ID()) {
+		if !arrow.TypeEqual(ta, tb) {
+			return 0, xerrors.Errorf("arrow/scalar: cannot compare %v and %v scalars", ta, tb)
+		}
+	}
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0, nil
+	case !a.IsValid():
+		return -1, nil
+	case !b.IsValid():
+		return 1, nil
+	}
+
+	switch a := a.(type) {
+	case *Boolean:
+		return compareInts(boolInt(a.Value), boolInt(b.(*Boolean).Value)), nil
+	case *String:
+		return compareStrings(a.Value, b.(*String).Value), nil
+	case *Binary:
+		return bytes.Compare(a.Value, b.(*Binary).Value), nil
+	case *FixedSizeBinary:
+		return bytes.Compare(a.Value, b.(*FixedSizeBinary).Value), nil
+	case *Decimal128:
+		return a.Value.Cmp(b.(*Decimal128).Value), nil
+	case *Decimal256:
+		return a.Value.Cmp(b.(*Decimal256).Value), nil
+	}
+	switch {
+	case isTemporal(ta.ID()):
+		x, _, _, _ := numericValue(a)
+		y, _, _, _ := numericValue(b)
+		return compareInts(x, y), nil
+	case isNumber(ta.ID()):
+		return compareNumbers(a, b), nil
+	}
+	return 0, xerrors.Errorf("arrow/scalar: %v scalars are not ordered", ta)
+}
+
+// decodeDictionaries returns the decoded values of a and b if they are
+// dictionary scalars.
+func decodeDictionaries(a, b Scalar) (Scalar, Scalar, error) {
+	var err error
+	if d, ok := a.(*Dictionary); ok {
+		if a, err = d.Decode(); err != nil {
+			return nil, nil, err
+		}
+	} else if r, ok := a.(interface{ Retain() }); ok {
+		r.Retain()
+	}
+	if d, ok := b.(*Dictionary); ok {
+		if b, err = d.Decode(); err != nil {
+			release(a)
+			return nil, nil, err
+		}
+	} else if r, ok := b.(interface{ Retain() }); ok {
+		r.Retain()
+	}
+	return a, b, nil
+}
+
+func isFloat(id arrow.Type) bool {
+	switch id {
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return true
+	}
+	return false
+}
+
+func isNumber(id arrow.Type) bool { return isInteger(id) || isFloat(id) }
+
+func isUnsigned(id arrow.Type) bool {
+	switch id {
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return true
+	}
+	return false
+}
+
+// compareNumbers compares the valid integer or floating point scalars a
+// and b.
+func compareNumbers(a, b Scalar) int {
+	na, nb := numberOf(a), numberOf(b)
+	switch {
+	case na.float && nb.float:
+		return compareFloats(na.f, nb.f)
+	case na.float:
+		return -nb.compareFloat(na.f)
+	case nb.float:
+		return na.compareFloat(nb.f)
+	}
+	return na.compareInt(nb)
+}
+
+// number is the value of an integer or floating point scalar.
+type number struct {
+	i        int64   // value of signed integers
+	u        uint64  // value of unsigned integers
+	f        float64 // value of floating point numbers
+	unsigned bool
+	float    bool
+}
+
+func numberOf(s Scalar) number {
+	if s, ok := s.(*Float16); ok {
+		return number{f: float64(s.Value.Float32()), float: true}
+	}
+	id := s.DataType().ID()
+	i, u, f, _ := numericValue(s)
+	return number{i: i, u: u, f: f, unsigned: isUnsigned(id), float: isFloat(id)}
+}
+
+// compareInt compares the integers n and o.
+func (n number) compareInt(o number) int {
+	switch {
+	case n.unsigned && o.unsigned:
+		return compareUints(n.u, o.u)
+	case n.unsigned:
+		if o.i < 0 {
+			return 1
+		}
+		return compareUints(n.u, uint64(o.i))
+	case o.unsigned:
+		if n.i < 0 {
+			return -1
+		}
+		return compareUints(uint64(n.i), o.u)
+	}
+	return compareInts(n.i, o.i)
+}
+
+// compareFloat compares the integer n and the floating point number f
+// exactly, which their conversions to float64 may not.
+func (n number) compareFloat(f float64) int {
+	switch {
+	case math.IsNaN(f) || f >= 1<<64:
+		return -1
+	case f < -(1 << 63):
+		return 1
+	}
+	t := math.Trunc(f)
+	var c int
+	if t < 1<<63 {
+		c = n.compareInt(number{i: int64(t)})
+	} else {
+		c = n.compareInt(number{u: uint64(t), unsigned: true})
+	}
+	switch {
+	case c != 0:
+		return c
+	case f > t:
+		return -1
+	case f < t:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return 1
+	}
+	return -1
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,125 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"math"
+
+	"golang.org/x/xerrors"
+)
+
+// ToInt64 returns the value of s, an integer, floating point or temporal
+// scalar, as an int64. Temporal values are counts of their unit. It fails
+// if s is null or if its value is not an integer that fits an int64.
+func ToInt64(s Scalar) (int64, error) {
+	n, err := nativeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case n.float:
+		if n.f == math.Trunc(n.f) && n.f >= -(1<<63) && n.f < 1<<63 {
+			return int64(n.f), nil
+		}
+	case n.unsigned:
+		if n.u <= math.MaxInt64 {
+			return int64(n.u), nil
+		}
+	default:
+		return n.i, nil
+	}
+	return 0, xerrors.Errorf("arrow/scalar: %v value %v overflows int64", s.DataType(), s)
+}
+
+// ToUint64 returns the value of s, an integer, floating point or temporal
+// scalar, as a uint64. It fails if s is null or if its value is not an
+// integer that fits a uint64.
+func ToUint64(s Scalar) (uint64, error) {
+	n, err := nativeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case n.float:
+		if n.f == math.Trunc(n.f) && n.f >= 0 && n.f < 1<<64 {
+			return uint64(n.f), nil
+		}
+	case n.unsigned:
+		return n.u, nil
+	default:
+		if n.i >= 0 {
+			return uint64(n.i), nil
+		}
+	}
+	return 0, xerrors.Errorf("arrow/scalar: %v value %v overflows uint64", s.DataType(), s)
+}
+
+// ToFloat64 returns the value of s, an integer, floating point or temporal
+// scalar, as a float64. It fails if s is null or if s is an integer which
+// cannot be represented exactly.
+func ToFloat64(s Scalar) (float64, error) {
+	n, err := nativeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case n.float:
+		return n.f, nil
+	case n.unsigned:
+		if f := float64(n.u); f < 1<<64 && uint64(f) == n.u {
+			return f, nil
+		}
+	default:
+		if f := float64(n.i); f < 1<<63 && int64(f) == n.i {
+			return f, nil
+		}
+	}
+	return 0, xerrors.Errorf("arrow/scalar: %v value %v cannot be represented as a float64", s.DataType(), s)
+}
+
+// ToBool returns the value of the boolean scalar s. It fails if s is null.
+func ToBool(s Scalar) (bool, error) {
+	b, ok := s.(*Boolean)
+	switch {
+	case !ok:
+		return false, xerrors.Errorf("arrow/scalar: cannot convert %v scalar to bool", s.DataType())
+	case !b.Valid:
+		return false, xerrors.New("arrow/scalar: cannot convert null scalar to bool")
+	}
+	return b.Value, nil
+}
+
+// nativeNumber returns the value of the valid integer, floating point or
+// temporal scalar s, decoding dictionaries.
+func nativeNumber(s Scalar) (number, error) {
+	if d, ok := s.(*Dictionary); ok {
+		v, err := d.Decode()
+		if err != nil {
+			return number{}, err
+		}
+		defer release(v)
+		s = v
+	}
+	id := s.DataType().ID()
+	switch {
+	case !isNumber(id) && !isTemporal(id):
+		return number{}, xerrors.Errorf("arrow/scalar: cannot convert %v scalar to a number", s.DataType())
+	case !s.IsValid():
+		return number{}, xerrors.Errorf("arrow/scalar: cannot convert null %v scalar to a number", s.DataType())
+	}
+	return numberOf(s), nil
+}
@@ -0,0 +1,215 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+	"golang.org/x/xerrors"
+)
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+// ParseScalar parses s as a valid scalar of type dt.
+//
+// Numbers use the syntax of the strconv package and decimals must be exactly
+// representable at the scale of dt. Temporal values are either given as an
+// integer count of their unit, or formatted as RFC 3339 timestamps, as
+// "2006-01-02" dates and as "15:04:05.999999999" times. Timestamps without
+// a time zone are interpreted in the time zone of dt.
+func ParseScalar(dt arrow.DataType, s string) (Scalar, error) {
+	base := scalar{Type: dt, Valid: true}
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: %w", s, dt, err)
+		}
+		return &Boolean{base, v}, nil
+	case *arrow.Float16Type:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: %w", s, dt, err)
+		}
+		return &Float16{base, float16.FromFloat64(v)}, nil
+	case *arrow.Decimal128Type:
+		v, err := parseDecimal(dt, dt.Precision, dt.Scale, s)
+		if err != nil {
+			return nil, err
+		}
+		lo := new(big.Int).And(v, new(big.Int).SetUint64(^uint64(0))).Uint64()
+		hi := new(big.Int).Rsh(v, 64).Int64()
+		return &Decimal128{base, decimal128.New(hi, lo)}, nil
+	case *arrow.Decimal256Type:
+		v, err := parseDecimal(dt, dt.Precision, dt.Scale, s)
+		if err != nil {
+			return nil, err
+		}
+		n, err := decimal256.FromBigInt(v)
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: %w", s, dt, err)
+		}
+		return &Decimal256{base, n}, nil
+	case *arrow.StringType:
+		return &String{base, s}, nil
+	case *arrow.BinaryType:
+		return &Binary{base, []byte(s)}, nil
+	case *arrow.FixedSizeBinaryType:
+		if len(s) != dt.ByteWidth {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: invalid length %d", s, dt, len(s))
+		}
+		return &FixedSizeBinary{base, []byte(s)}, nil
+	}
+
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		v, err := strconv.ParseInt(s, 10, bitWidth(dt))
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: %w", s, dt, err)
+		}
+		return makeNumeric(dt, v, 0, 0), nil
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		v, err := strconv.ParseUint(s, 10, bitWidth(dt))
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: %w", s, dt, err)
+		}
+		return makeNumeric(dt, 0, v, 0), nil
+	case arrow.FLOAT32, arrow.FLOAT64:
+		v, err := strconv.ParseFloat(s, bitWidth(dt))
+		if err != nil {
+			return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: %w", s, dt, err)
+		}
+		return makeNumeric(dt, 0, 0, v), nil
+	case arrow.TIMESTAMP, arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64, arrow.DURATION:
+		if v, err := strconv.ParseInt(s, 10, bitWidth(dt)); err == nil {
+			return makeNumeric(dt, v, 0, 0), nil
+		}
+		v, err := parseTemporal(dt, s)
+		if err != nil {
+			return nil, err
+		}
+		return makeNumeric(dt, v, 0, 0), nil
+	}
+	return nil, xerrors.Errorf("arrow/scalar: cannot parse scalar of type %v", dt)
+}
+
+func bitWidth(dt arrow.DataType) int {
+	return dt.(arrow.FixedWidthDataType).BitWidth()
+}
+
+// parseDecimal parses s as the unscaled value of a decimal of type dt.
+func parseDecimal(dt arrow.DataType, precision, scale int32, s string) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v", s, dt)
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(scale))), nil)
+	if scale >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(pow))
+	}
+	if !r.IsInt() {
+		return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: too many digits", s, dt)
+	}
+	v := r.Num()
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	if new(big.Int).Abs(v).Cmp(max) >= 0 {
+		return nil, xerrors.Errorf("arrow/scalar: could not parse %q as %v: precision overflow", s, dt)
+	}
+	return v, nil
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// parseTemporal parses s as a formatted value of the temporal type dt and
+// returns it as a count of the unit of dt.
+func parseTemporal(dt arrow.DataType, s string) (int64, error) {
+	var (
+		layouts = []string{dateLayout}
+		loc     = time.UTC
+	)
+	switch dt := dt.(type) {
+	case *arrow.TimestampType:
+		var err error
+		if loc, err = location(dt.TimeZone); err != nil {
+			return 0, err
+		}
+		layouts = timestampLayouts
+	case *arrow.Time32Type, *arrow.Time64Type:
+		layouts = []string{timeLayout}
+	case *arrow.DurationType:
+		return 0, xerrors.Errorf("arrow/scalar: could not parse %q as %v", s, dt)
+	}
+
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err != nil {
+			continue
+		}
+		switch dt := dt.(type) {
+		case *arrow.TimestampType:
+			return t.Unix()*unitsPerSecond(dt.Unit) + int64(t.Nanosecond())/nanosPer(dt.Unit), nil
+		case *arrow.Date32Type:
+			return t.Unix() / secondsPerDay, nil
+		case *arrow.Date64Type:
+			return t.Unix() / secondsPerDay * msPerDay, nil
+		case *arrow.Time32Type:
+			return sinceMidnight(t) / nanosPer(dt.Unit), nil
+		case *arrow.Time64Type:
+			return sinceMidnight(t) / nanosPer(dt.Unit), nil
+		}
+	}
+	return 0, xerrors.Errorf("arrow/scalar: could not parse %q as %v", s, dt)
+}
+
+func sinceMidnight(t time.Time) int64 {
+	return int64(t.Hour())*int64(time.Hour) + int64(t.Minute())*int64(time.Minute) +
+		int64(t.Second())*int64(time.Second) + int64(t.Nanosecond())
+}
+
+// location returns the location of the time zone tz, either a name of the
+// IANA time zone database or a "+hh:mm" offset.
+func location(tz string) (*time.Location, error) {
+	loc, err := arrow.LoadLocation(tz)
+	if err != nil {
+		return nil, xerrors.Errorf("arrow/scalar: %w", err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"github.com/apache/arrow/go/arrow/array"
+	"golang.org/x/xerrors"
+)
+
+// Row returns the values of the i-th row of rec, one scalar per column.
+// Unlike GetScalar, it returns the decoded values of dictionary and
+// run-end encoded columns. The List and FixedSizeList scalars it returns
+// must be released, see ReleaseRow.
+//
+// Row is a function of this package, rather than a method of records, as
+// the array package cannot depend on scalars.
+func Row(rec array.Record, i int) ([]Scalar, error) {
+	if i < 0 || int64(i) >= rec.NumRows() {
+		return nil, xerrors.Errorf("arrow/scalar: row %d out of range [0, %d)", i, rec.NumRows())
+	}
+	row := make([]Scalar, rec.NumCols())
+	for j, col := range rec.Columns() {
+		s, err := getDecoded(col, i)
+		if err != nil {
+			ReleaseRow(row[:j])
+			return nil, xerrors.Errorf("arrow/scalar: column %q: %w", rec.ColumnName(j), err)
+		}
+		row[j] = s
+	}
+	return row, nil
+}
+
+// RowMap returns the values of the i-th row of rec, like Row, keyed by
+// column name. Columns with the same name have the value of the last one.
+func RowMap(rec array.Record, i int) (map[string]Scalar, error) {
+	row, err := Row(rec, i)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]Scalar, len(row))
+	for j, s := range row {
+		name := rec.ColumnName(j)
+		if prev, ok := m[name]; ok {
+			release(prev)
+		}
+		m[name] = s
+	}
+	return m, nil
+}
+
+// ReleaseRow releases the scalars of row which hold reference counted
+// memory.
+func ReleaseRow(row []Scalar) {
+	for _, s := range row {
+		if s != nil {
+			release(s)
+		}
+	}
+}
+
+// getDecoded returns the i-th value of arr as a scalar, decoding the
+// values of dictionary and run-end encoded arrays.
+func getDecoded(arr array.Interface, i int) (Scalar, error) {
+	switch a := arr.(type) {
+	case *array.Dictionary:
+		if a.IsNull(i) {
+			return MakeNullScalar(a.Dictionary().DataType()), nil
+		}
+		return getDecoded(a.Dictionary(), a.GetValueIndex(i))
+	case *array.RunEndEncoded:
+		return getDecoded(a.Values(), a.GetPhysicalIndex(i))
+	}
+	return GetScalar(arr, i)
+}
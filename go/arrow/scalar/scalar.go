@@ -0,0 +1,858 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scalar provides typed single values of Arrow data types.
+package scalar // import "github.com/apache/arrow/go/arrow/scalar"
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"golang.org/x/xerrors"
+)
+
+// Scalar represents a single value of a given Arrow data type, or a null
+// value of that type.
+type Scalar interface {
+	fmt.Stringer
+	// DataType returns the Arrow data type of the scalar.
+	DataType() arrow.DataType
+	// IsValid reports whether the scalar holds a value, ie: is not null.
+	IsValid() bool
+	// Equals reports whether the scalar and other have the same data type
+	// and are either both null or both hold equal values.
+	Equals(other Scalar) bool
+}
+
+type scalar struct {
+	Type  arrow.DataType
+	Valid bool
+}
+
+func (s *scalar) DataType() arrow.DataType { return s.Type }
+func (s *scalar) IsValid() bool            { return s.Valid }
+
+func (s *scalar) equals(o *scalar) bool {
+	return s.Valid == o.Valid && arrow.TypeEqual(s.Type, o.Type)
+}
+
+// release releases s if it holds reference counted memory.
+func release(s Scalar) {
+	if r, ok := s.(interface{ Release() }); ok {
+		r.Release()
+	}
+}
+
+const nullString = "null"
+
+// Null is the scalar of the NULL data type.
+type Null struct {
+	scalar
+}
+
+// ScalarNull is the only valid value of a Null scalar.
+var ScalarNull = &Null{scalar{Type: arrow.Null, Valid: false}}
+
+func (*Null) String() string { return nullString }
+
+func (*Null) Equals(other Scalar) bool {
+	_, ok := other.(*Null)
+	return ok
+}
+
+// Boolean is a boolean scalar.
+type Boolean struct {
+	scalar
+	Value bool
+}
+
+// NewBooleanScalar returns a valid Boolean scalar holding v.
+func NewBooleanScalar(v bool) *Boolean {
+	return &Boolean{scalar{arrow.FixedWidthTypes.Boolean, true}, v}
+}
+
+func (s *Boolean) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return strconv.FormatBool(s.Value)
+}
+
+func (s *Boolean) Equals(other Scalar) bool {
+	o, ok := other.(*Boolean)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value == o.Value)
+}
+
+// Float16 is a half-precision floating point scalar.
+type Float16 struct {
+	scalar
+	Value float16.Num
+}
+
+// NewFloat16Scalar returns a valid Float16 scalar holding v.
+func NewFloat16Scalar(v float16.Num) *Float16 {
+	return &Float16{scalar{arrow.FixedWidthTypes.Float16, true}, v}
+}
+
+func (s *Float16) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return fmt.Sprint(s.Value)
+}
+
+func (s *Float16) Equals(other Scalar) bool {
+	o, ok := other.(*Float16)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value.Float32() == o.Value.Float32())
+}
+
+// Decimal128 is a 128-bit decimal scalar. Value holds the unscaled value.
+type Decimal128 struct {
+	scalar
+	Value decimal128.Num
+}
+
+// NewDecimal128Scalar returns a valid Decimal128 scalar of type dt holding
+// the unscaled value v.
+func NewDecimal128Scalar(v decimal128.Num, dt arrow.DataType) *Decimal128 {
+	return &Decimal128{scalar{dt, true}, v}
+}
+
+func (s *Decimal128) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	v := big.NewInt(s.Value.HighBits())
+	v.Lsh(v, 64).Add(v, new(big.Int).SetUint64(s.Value.LowBits()))
+	scale := s.Type.(*arrow.Decimal128Type).Scale
+	f := new(big.Rat).SetFrac(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	if scale < 0 {
+		scale = 0
+	}
+	return f.FloatString(int(scale))
+}
+
+func (s *Decimal128) Equals(other Scalar) bool {
+	o, ok := other.(*Decimal128)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value == o.Value)
+}
+
+// Decimal256 is a 256-bit decimal scalar. Value holds the unscaled value.
+type Decimal256 struct {
+	scalar
+	Value decimal256.Num
+}
+
+// NewDecimal256Scalar returns a valid Decimal256 scalar of type dt holding
+// the unscaled value v.
+func NewDecimal256Scalar(v decimal256.Num, dt arrow.DataType) *Decimal256 {
+	return &Decimal256{scalar{dt, true}, v}
+}
+
+func (s *Decimal256) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return s.Value.ToString(s.Type.(*arrow.Decimal256Type).Scale)
+}
+
+func (s *Decimal256) Equals(other Scalar) bool {
+	o, ok := other.(*Decimal256)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value == o.Value)
+}
+
+// String is a UTF-8 encoded string scalar.
+type String struct {
+	scalar
+	Value string
+}
+
+// NewStringScalar returns a valid String scalar holding v.
+func NewStringScalar(v string) *String {
+	return &String{scalar{arrow.BinaryTypes.String, true}, v}
+}
+
+func (s *String) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return s.Value
+}
+
+func (s *String) Equals(other Scalar) bool {
+	o, ok := other.(*String)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value == o.Value)
+}
+
+// Binary is a scalar holding a sequence of bytes.
+type Binary struct {
+	scalar
+	Value []byte
+}
+
+// NewBinaryScalar returns a valid Binary scalar holding v.
+func NewBinaryScalar(v []byte) *Binary {
+	return &Binary{scalar{arrow.BinaryTypes.Binary, true}, v}
+}
+
+func (s *Binary) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return string(s.Value)
+}
+
+func (s *Binary) Equals(other Scalar) bool {
+	o, ok := other.(*Binary)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || bytes.Equal(s.Value, o.Value))
+}
+
+// FixedSizeBinary is a scalar of a fixed size binary type.
+type FixedSizeBinary struct {
+	scalar
+	Value []byte
+}
+
+// NewFixedSizeBinaryScalar returns a valid FixedSizeBinary scalar of type dt
+// holding v, whose length must be the byte width of dt.
+func NewFixedSizeBinaryScalar(v []byte, dt *arrow.FixedSizeBinaryType) *FixedSizeBinary {
+	return &FixedSizeBinary{scalar{dt, true}, v}
+}
+
+func (s *FixedSizeBinary) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return string(s.Value)
+}
+
+func (s *FixedSizeBinary) Equals(other Scalar) bool {
+	o, ok := other.(*FixedSizeBinary)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || bytes.Equal(s.Value, o.Value))
+}
+
+// MonthInterval is a scalar of the month interval type.
+type MonthInterval struct {
+	scalar
+	Value arrow.MonthInterval
+}
+
+// NewMonthIntervalScalar returns a valid MonthInterval scalar holding v.
+func NewMonthIntervalScalar(v arrow.MonthInterval) *MonthInterval {
+	return &MonthInterval{scalar{arrow.FixedWidthTypes.MonthInterval, true}, v}
+}
+
+func (s *MonthInterval) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return fmt.Sprintf("%dM", s.Value)
+}
+
+func (s *MonthInterval) Equals(other Scalar) bool {
+	o, ok := other.(*MonthInterval)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value == o.Value)
+}
+
+// DayTimeInterval is a scalar of the day-time interval type.
+type DayTimeInterval struct {
+	scalar
+	Value arrow.DayTimeInterval
+}
+
+// NewDayTimeIntervalScalar returns a valid DayTimeInterval scalar holding v.
+func NewDayTimeIntervalScalar(v arrow.DayTimeInterval) *DayTimeInterval {
+	return &DayTimeInterval{scalar{arrow.FixedWidthTypes.DayTimeInterval, true}, v}
+}
+
+func (s *DayTimeInterval) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return fmt.Sprintf("%dd%dms", s.Value.Days, s.Value.Milliseconds)
+}
+
+func (s *DayTimeInterval) Equals(other Scalar) bool {
+	o, ok := other.(*DayTimeInterval)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || s.Value == o.Value)
+}
+
+// Struct is a scalar of a struct type, holding a scalar for each field.
+type Struct struct {
+	scalar
+	Value []Scalar
+}
+
+// NewStructScalar returns a valid Struct scalar of type dt holding the
+// field values fields, which must match the fields of dt.
+func NewStructScalar(fields []Scalar, dt *arrow.StructType) *Struct {
+	return &Struct{scalar{dt, true}, fields}
+}
+
+func (s *Struct) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	o := new(strings.Builder)
+	o.WriteString("{")
+	for i, f := range s.Type.(*arrow.StructType).Fields() {
+		if i > 0 {
+			o.WriteString(", ")
+		}
+		fmt.Fprintf(o, "%s: %v", f.Name, s.Value[i])
+	}
+	o.WriteString("}")
+	return o.String()
+}
+
+func (s *Struct) Equals(other Scalar) bool {
+	o, ok := other.(*Struct)
+	if !ok || !s.scalar.equals(&o.scalar) {
+		return false
+	}
+	if s.Valid {
+		for i, f := range s.Value {
+			if !f.Equals(o.Value[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// List is a scalar of a list type, holding the values of the list in an
+// array. The scalar holds a reference to Value, which must be released
+// with Release.
+type List struct {
+	scalar
+	Value array.Interface
+}
+
+// NewListScalar returns a valid List scalar holding the values of v, whose
+// type is a list of the data type of v.
+func NewListScalar(v array.Interface) *List {
+	v.Retain()
+	return &List{scalar{arrow.ListOf(v.DataType()), true}, v}
+}
+
+// Retain increases the reference count of the list values by 1.
+func (s *List) Retain() {
+	if s.Value != nil {
+		s.Value.Retain()
+	}
+}
+
+// Release decreases the reference count of the list values by 1.
+func (s *List) Release() {
+	if s.Value != nil {
+		s.Value.Release()
+	}
+}
+
+func (s *List) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return fmt.Sprint(s.Value)
+}
+
+func (s *List) Equals(other Scalar) bool {
+	o, ok := other.(*List)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || array.ArrayEqual(s.Value, o.Value))
+}
+
+// FixedSizeList is a scalar of a fixed size list type, holding the values
+// of the list in an array. The scalar holds a reference to Value, which
+// must be released with Release.
+type FixedSizeList struct {
+	scalar
+	Value array.Interface
+}
+
+// NewFixedSizeListScalar returns a valid FixedSizeList scalar holding the
+// values of v, whose type is a list of v.Len() values of the data type of v.
+func NewFixedSizeListScalar(v array.Interface) *FixedSizeList {
+	v.Retain()
+	return &FixedSizeList{scalar{arrow.FixedSizeListOf(int32(v.Len()), v.DataType()), true}, v}
+}
+
+// Retain increases the reference count of the list values by 1.
+func (s *FixedSizeList) Retain() {
+	if s.Value != nil {
+		s.Value.Retain()
+	}
+}
+
+// Release decreases the reference count of the list values by 1.
+func (s *FixedSizeList) Release() {
+	if s.Value != nil {
+		s.Value.Release()
+	}
+}
+
+func (s *FixedSizeList) String() string {
+	if !s.Valid {
+		return nullString
+	}
+	return fmt.Sprint(s.Value)
+}
+
+func (s *FixedSizeList) Equals(other Scalar) bool {
+	o, ok := other.(*FixedSizeList)
+	return ok && s.scalar.equals(&o.scalar) && (!s.Valid || array.ArrayEqual(s.Value, o.Value))
+}
+
+// Dictionary is a scalar of a dictionary type. Index is a scalar of the
+// index type locating the value in the Dict array. The scalar holds a
+// reference to Dict, which must be released with Release.
+type Dictionary struct {
+	scalar
+	Index Scalar
+	Dict  array.Interface
+}
+
+// NewDictionaryScalar returns a valid Dictionary scalar of type dt holding
+// the value at index in dict.
+func NewDictionaryScalar(index Scalar, dict array.Interface, dt *arrow.DictionaryType) *Dictionary {
+	dict.Retain()
+	return &Dictionary{scalar{dt, true}, index, dict}
+}
+
+// Retain increases the reference count of the dictionary by 1.
+func (s *Dictionary) Retain() {
+	if s.Dict != nil {
+		s.Dict.Retain()
+	}
+}
+
+// Release decreases the reference count of the dictionary by 1.
+func (s *Dictionary) Release() {
+	if s.Dict != nil {
+		s.Dict.Release()
+	}
+}
+
+// Decode returns the dictionary value the scalar refers to.
+func (s *Dictionary) Decode() (Scalar, error) {
+	if !s.Valid {
+		return MakeNullScalar(s.Type.(*arrow.DictionaryType).ValueType), nil
+	}
+	i, _, _, _ := numericValue(s.Index)
+	if i < 0 || i >= int64(s.Dict.Len()) {
+		return nil, xerrors.Errorf("arrow/scalar: dictionary index %d out of bounds", i)
+	}
+	return GetScalar(s.Dict, int(i))
+}
+
+func (s *Dictionary) String() string {
+	v, err := s.Decode()
+	if err != nil {
+		return err.Error()
+	}
+	defer release(v)
+	return v.String()
+}
+
+func (s *Dictionary) Equals(other Scalar) bool {
+	o, ok := other.(*Dictionary)
+	return ok && s.scalar.equals(&o.scalar) &&
+		(!s.Valid || (s.Index.Equals(o.Index) && array.ArrayEqual(s.Dict, o.Dict)))
+}
+
+// MakeNullScalar returns a null scalar of type dt.
+func MakeNullScalar(dt arrow.DataType) Scalar {
+	base := scalar{Type: dt}
+	switch dt.ID() {
+	case arrow.NULL:
+		return ScalarNull
+	case arrow.BOOL:
+		return &Boolean{scalar: base}
+	case arrow.FLOAT16:
+		return &Float16{scalar: base}
+	case arrow.DECIMAL:
+		return &Decimal128{scalar: base}
+	case arrow.DECIMAL256:
+		return &Decimal256{scalar: base}
+	case arrow.STRING, arrow.STRING_VIEW:
+		return &String{scalar: base}
+	case arrow.BINARY, arrow.BINARY_VIEW:
+		return &Binary{scalar: base}
+	case arrow.FIXED_SIZE_BINARY:
+		return &FixedSizeBinary{scalar: base}
+	case arrow.INTERVAL:
+		switch dt.(type) {
+		case *arrow.MonthIntervalType:
+			return &MonthInterval{scalar: base}
+		case *arrow.DayTimeIntervalType:
+			return &DayTimeInterval{scalar: base}
+		}
+	case arrow.STRUCT:
+		return &Struct{scalar: base}
+	case arrow.LIST:
+		return &List{scalar: base}
+	case arrow.FIXED_SIZE_LIST:
+		return &FixedSizeList{scalar: base}
+	case arrow.DICTIONARY:
+		return &Dictionary{scalar: base, Index: MakeNullScalar(dt.(*arrow.DictionaryType).IndexType)}
+	}
+	if s := makeNullNumeric(dt); s != nil {
+		return s
+	}
+	panic(xerrors.Errorf("arrow/scalar: unsupported scalar type %v", dt))
+}
+
+// MakeScalar returns a valid scalar holding the Go value v: bool, numeric,
+// float16.Num, decimal128.Num, string, []byte, arrow date, time, timestamp,
+// duration and interval values, time.Time and time.Duration values and
+// arrays, which make List scalars. Decimals have a precision of 38 and a
+// scale of 0, and timestamps, times and durations have a unit of
+// nanoseconds. A nil value makes a Null scalar and a Scalar is returned
+// as-is.
+//
+// MakeScalar panics if v is of any other type.
+func MakeScalar(v interface{}) Scalar {
+	switch v := v.(type) {
+	case nil:
+		return ScalarNull
+	case Scalar:
+		return v
+	case bool:
+		return NewBooleanScalar(v)
+	case int8:
+		return NewInt8Scalar(v)
+	case int16:
+		return NewInt16Scalar(v)
+	case int32:
+		return NewInt32Scalar(v)
+	case int64:
+		return NewInt64Scalar(v)
+	case int:
+		return NewInt64Scalar(int64(v))
+	case uint8:
+		return NewUint8Scalar(v)
+	case uint16:
+		return NewUint16Scalar(v)
+	case uint32:
+		return NewUint32Scalar(v)
+	case uint64:
+		return NewUint64Scalar(v)
+	case uint:
+		return NewUint64Scalar(uint64(v))
+	case float32:
+		return NewFloat32Scalar(v)
+	case float64:
+		return NewFloat64Scalar(v)
+	case float16.Num:
+		return NewFloat16Scalar(v)
+	case decimal128.Num:
+		return NewDecimal128Scalar(v, &arrow.Decimal128Type{Precision: 38, Scale: 0})
+	case string:
+		return NewStringScalar(v)
+	case []byte:
+		return NewBinaryScalar(v)
+	case arrow.Date32:
+		return NewDate32Scalar(v)
+	case arrow.Date64:
+		return NewDate64Scalar(v)
+	case arrow.Time64:
+		return NewTime64Scalar(v, arrow.FixedWidthTypes.Time64ns)
+	case arrow.Timestamp:
+		return NewTimestampScalar(v, &arrow.TimestampType{Unit: arrow.Nanosecond})
+	case arrow.Duration:
+		return NewDurationScalar(v, arrow.FixedWidthTypes.Duration_ns)
+	case arrow.MonthInterval:
+		return NewMonthIntervalScalar(v)
+	case arrow.DayTimeInterval:
+		return NewDayTimeIntervalScalar(v)
+	case time.Time:
+		return NewTimestampScalar(arrow.Timestamp(v.UnixNano()), arrow.FixedWidthTypes.Timestamp_ns)
+	case time.Duration:
+		return NewDurationScalar(arrow.Duration(v), arrow.FixedWidthTypes.Duration_ns)
+	case array.Interface:
+		return NewListScalar(v)
+	}
+	panic(xerrors.Errorf("arrow/scalar: cannot make scalar from %T value", v))
+}
+
+// MakeArrayFromScalar returns an array of n values which are all equal to s.
+func MakeArrayFromScalar(s Scalar, n int, mem memory.Allocator) (array.Interface, error) {
+	switch dt := s.DataType().(type) {
+	case *arrow.NullType:
+		return array.NewNull(n), nil
+	case *arrow.StructType:
+		return makeStructArray(s.(*Struct), dt, n, mem)
+	case *arrow.DictionaryType:
+		return makeDictionaryArray(s.(*Dictionary), dt, n, mem)
+	}
+
+	bldr := array.NewBuilder(mem, s.DataType())
+	defer bldr.Release()
+	bldr.Reserve(n)
+
+	if err := appendScalar(bldr, s, n); err != nil {
+		return nil, err
+	}
+	return bldr.NewArray(), nil
+}
+
+// appendScalar appends n copies of s to bldr.
+func appendScalar(bldr array.Builder, s Scalar, n int) error {
+	if !s.IsValid() {
+		if b, ok := bldr.(*array.FixedSizeListBuilder); ok {
+			// null fixed size lists still have their child values.
+			width := int(s.DataType().(*arrow.FixedSizeListType).Len())
+			for i := 0; i < n; i++ {
+				b.AppendNull()
+				for j := 0; j < width; j++ {
+					b.ValueBuilder().AppendNull()
+				}
+			}
+			return nil
+		}
+		for i := 0; i < n; i++ {
+			bldr.AppendNull()
+		}
+		return nil
+	}
+
+	switch s := s.(type) {
+	case *Boolean:
+		b := bldr.(*array.BooleanBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *Float16:
+		b := bldr.(*array.Float16Builder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *Decimal128:
+		b := bldr.(*array.Decimal128Builder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *Decimal256:
+		b := bldr.(*array.Decimal256Builder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *String:
+		b := bldr.(interface{ Append(string) })
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *Binary:
+		b := bldr.(interface{ Append([]byte) })
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *FixedSizeBinary:
+		b := bldr.(*array.FixedSizeBinaryBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *MonthInterval:
+		b := bldr.(*array.MonthIntervalBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *DayTimeInterval:
+		b := bldr.(*array.DayTimeIntervalBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(s.Value)
+		}
+	case *Struct:
+		b := bldr.(*array.StructBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(true)
+		}
+		for j, f := range s.Value {
+			if err := appendScalar(b.FieldBuilder(j), f, n); err != nil {
+				return err
+			}
+		}
+	case *List:
+		b := bldr.(*array.ListBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(true)
+			if err := appendArray(b.ValueBuilder(), s.Value); err != nil {
+				return err
+			}
+		}
+	case *FixedSizeList:
+		b := bldr.(*array.FixedSizeListBuilder)
+		for i := 0; i < n; i++ {
+			b.Append(true)
+			if err := appendArray(b.ValueBuilder(), s.Value); err != nil {
+				return err
+			}
+		}
+	default:
+		if !appendNumeric(bldr, s, n) {
+			return xerrors.Errorf("arrow/scalar: cannot make array from %T scalar", s)
+		}
+	}
+	return nil
+}
+
+// appendArray appends the values of arr to bldr.
+func appendArray(bldr array.Builder, arr array.Interface) error {
+	for i := 0; i < arr.Len(); i++ {
+		v, err := GetScalar(arr, i)
+		if err != nil {
+			return err
+		}
+		err = appendScalar(bldr, v, 1)
+		release(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func makeDictionaryArray(s *Dictionary, dt *arrow.DictionaryType, n int, mem memory.Allocator) (array.Interface, error) {
+	indices, err := MakeArrayFromScalar(s.Index, n, mem)
+	if err != nil {
+		return nil, err
+	}
+	defer indices.Release()
+
+	dict := s.Dict
+	if dict == nil {
+		bldr := array.NewBuilder(mem, dt.ValueType)
+		dict = bldr.NewArray()
+		bldr.Release()
+		defer dict.Release()
+	}
+	return array.NewDictionaryArray(dt, indices, dict), nil
+}
+
+func makeStructArray(s *Struct, dt *arrow.StructType, n int, mem memory.Allocator) (array.Interface, error) {
+	children := make([]*array.Data, 0, len(dt.Fields()))
+	defer func() {
+		for _, c := range children {
+			c.Release()
+		}
+	}()
+	for i, f := range dt.Fields() {
+		v := MakeNullScalar(f.Type)
+		if s.Valid {
+			v = s.Value[i]
+		}
+		child, err := MakeArrayFromScalar(v, n, mem)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child.Data())
+		child.Data().Retain()
+		child.Release()
+	}
+
+	var (
+		validity *memory.Buffer
+		nulls    int
+	)
+	if !s.Valid {
+		// the zeroed bitmap marks all the values as null.
+		validity = memory.NewResizableBuffer(mem)
+		validity.Resize(int(bitutil.BytesForBits(int64(n))))
+		memory.Set(validity.Bytes(), 0)
+		defer validity.Release()
+		nulls = n
+	}
+	data := array.NewData(dt, n, []*memory.Buffer{validity}, children, nulls, 0)
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+// GetScalar returns the i-th value of arr as a scalar. The List,
+// FixedSizeList and Dictionary scalars it returns must be released.
+func GetScalar(arr array.Interface, i int) (Scalar, error) {
+	if arr.DataType().ID() == arrow.NULL {
+		return ScalarNull, nil
+	}
+	if arr.IsNull(i) {
+		return MakeNullScalar(arr.DataType()), nil
+	}
+
+	base := scalar{Type: arr.DataType(), Valid: true}
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return &Boolean{base, arr.Value(i)}, nil
+	case *array.Float16:
+		return &Float16{base, arr.Value(i)}, nil
+	case *array.Decimal128:
+		return &Decimal128{base, arr.Value(i)}, nil
+	case *array.Decimal256:
+		return &Decimal256{base, arr.Value(i)}, nil
+	case *array.String:
+		return &String{base, arr.Value(i)}, nil
+	case *array.Binary:
+		return &Binary{base, append([]byte(nil), arr.Value(i)...)}, nil
+	case *array.StringView:
+		// the values of views are not copied when converted to strings.
+		return &String{base, string(append([]byte(nil), arr.Value(i)...))}, nil
+	case *array.BinaryView:
+		return &Binary{base, append([]byte(nil), arr.Value(i)...)}, nil
+	case *array.FixedSizeBinary:
+		return &FixedSizeBinary{base, append([]byte(nil), arr.Value(i)...)}, nil
+	case *array.MonthInterval:
+		return &MonthInterval{base, arr.Value(i)}, nil
+	case *array.DayTimeInterval:
+		return &DayTimeInterval{base, arr.Value(i)}, nil
+	case *array.List:
+		j := i + arr.Data().Offset()
+		beg, end := int64(arr.Offsets()[j]), int64(arr.Offsets()[j+1])
+		return &List{base, array.NewSlice(arr.ListValues(), beg, end)}, nil
+	case *array.FixedSizeList:
+		width := int64(arr.DataType().(*arrow.FixedSizeListType).Len())
+		j := int64(i + arr.Data().Offset())
+		return &FixedSizeList{base, array.NewSlice(arr.ListValues(), j*width, (j+1)*width)}, nil
+	case *array.Dictionary:
+		index, err := GetScalar(arr.Indices(), i)
+		if err != nil {
+			return nil, err
+		}
+		arr.Dictionary().Retain()
+		return &Dictionary{base, index, arr.Dictionary()}, nil
+	case *array.Struct:
+		fields := make([]Scalar, arr.NumField())
+		for j := range fields {
+			f, err := GetScalar(arr.Field(j), i)
+			if err != nil {
+				return nil, err
+			}
+			fields[j] = f
+		}
+		return &Struct{base, fields}, nil
+	}
+	if s := getNumeric(arr, i); s != nil {
+		return s, nil
+	}
+	return nil, xerrors.Errorf("arrow/scalar: cannot get scalar from %v array", arr.DataType())
+}
@@ -0,0 +1,559 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/internal/arrdata"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+)
+
+func release(s scalar.Scalar) {
+	if r, ok := s.(interface{ Release() }); ok {
+		r.Release()
+	}
+}
+
+// checkRoundTrip checks that the scalars of the values of arr make arrays
+// equal to the values they were taken from.
+func checkRoundTrip(t *testing.T, arr array.Interface) {
+	t.Helper()
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for i := 0; i < arr.Len(); i++ {
+		s, err := scalar.GetScalar(arr, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !arrow.TypeEqual(s.DataType(), arr.DataType()) {
+			t.Fatalf("invalid type for value %d: got=%v, want=%v", i, s.DataType(), arr.DataType())
+		}
+		if got, want := s.IsValid(), arr.IsValid(i) && arr.DataType().ID() != arrow.NULL; got != want {
+			t.Fatalf("invalid validity for value %d: got=%v, want=%v", i, got, want)
+		}
+
+		want := array.NewSlice(arr, int64(i), int64(i+1))
+		out, err := scalar.MakeArrayFromScalar(s, 3, mem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < out.Len(); j++ {
+			got := array.NewSlice(out, int64(j), int64(j+1))
+			if !array.ArrayEqual(got, want) {
+				t.Errorf("invalid array for value %d:\ngot= %v\nwant=%v", i, got, want)
+			}
+			got.Release()
+
+			v, err := scalar.GetScalar(out, j)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.Equals(s) || !s.Equals(v) {
+				t.Errorf("scalars of value %d should compare equal: %v, %v", i, v, s)
+			}
+			release(v)
+		}
+		out.Release()
+		want.Release()
+		release(s)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for name, recs := range arrdata.Records {
+		t.Run(name, func(t *testing.T) {
+			for _, rec := range recs {
+				for i, col := range rec.Columns() {
+					t.Run(rec.ColumnName(i), func(t *testing.T) {
+						checkRoundTrip(t, col)
+					})
+				}
+			}
+		})
+	}
+}
+
+func TestDictionaryRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt8Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int8{2, 0, 0, 1}, []bool{true, true, false, true})
+	indices := ib.NewArray()
+	defer indices.Release()
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "b", "c"}, nil)
+	dict := sb.NewArray()
+	defer dict.Release()
+
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	arr := array.NewDictionaryArray(dt, indices, dict)
+	defer arr.Release()
+
+	checkRoundTrip(t, arr)
+
+	s, err := scalar.GetScalar(arr, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release(s)
+	v, err := s.(*scalar.Dictionary).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Equals(scalar.NewStringScalar("c")) {
+		t.Fatalf("invalid decoded value: got=%v, want=c", v)
+	}
+
+	null, err := scalar.MakeArrayFromScalar(scalar.MakeNullScalar(dt), 2, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer null.Release()
+	if null.NullN() != 2 {
+		t.Fatalf("invalid null array: %v", null)
+	}
+}
+
+func TestEquals(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt32Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int32{1, 2}, nil)
+	l1 := ib.NewArray()
+	defer l1.Release()
+	ib.AppendValues([]int32{1, 3}, nil)
+	l2 := ib.NewArray()
+	defer l2.Release()
+
+	list1, list2 := scalar.NewListScalar(l1), scalar.NewListScalar(l2)
+	defer list1.Release()
+	defer list2.Release()
+
+	ms := arrow.FixedWidthTypes.Timestamp_ms
+	sdt := arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int32, Nullable: true})
+
+	for _, tc := range []struct {
+		a, b scalar.Scalar
+		want bool
+	}{
+		{scalar.NewInt32Scalar(1), scalar.NewInt32Scalar(1), true},
+		{scalar.NewInt32Scalar(1), scalar.NewInt32Scalar(2), false},
+		{scalar.NewInt32Scalar(1), scalar.NewInt64Scalar(1), false},
+		{scalar.NewInt32Scalar(1), scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), false},
+		{scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), true},
+		{scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), scalar.ScalarNull, false},
+		{scalar.ScalarNull, scalar.ScalarNull, true},
+		{scalar.NewTimestampScalar(1, ms), scalar.NewTimestampScalar(1, ms), true},
+		{scalar.NewTimestampScalar(1, ms), scalar.NewTimestampScalar(1, arrow.FixedWidthTypes.Timestamp_s), false},
+		{scalar.NewBinaryScalar([]byte("a")), scalar.NewBinaryScalar([]byte("a")), true},
+		{scalar.NewBinaryScalar([]byte("a")), scalar.NewStringScalar("a"), false},
+		{scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt32Scalar(1)}, sdt), scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt32Scalar(1)}, sdt), true},
+		{scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt32Scalar(1)}, sdt), scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt32Scalar(2)}, sdt), false},
+		{list1, list1, true},
+		{list1, list2, false},
+		{scalar.NewDayTimeIntervalScalar(arrow.DayTimeInterval{Days: 1, Milliseconds: 2}), scalar.NewDayTimeIntervalScalar(arrow.DayTimeInterval{Days: 1, Milliseconds: 2}), true},
+	} {
+		if got := tc.a.Equals(tc.b); got != tc.want {
+			t.Errorf("%v (%v) == %v (%v): got=%v, want=%v", tc.a, tc.a.DataType(), tc.b, tc.b.DataType(), got, tc.want)
+		}
+	}
+}
+
+func TestParseScalar(t *testing.T) {
+	for _, tc := range []struct {
+		dt   arrow.DataType
+		str  string
+		want scalar.Scalar
+	}{
+		{arrow.FixedWidthTypes.Boolean, "true", scalar.NewBooleanScalar(true)},
+		{arrow.PrimitiveTypes.Int8, "-12", scalar.NewInt8Scalar(-12)},
+		{arrow.PrimitiveTypes.Uint64, "18446744073709551615", scalar.NewUint64Scalar(18446744073709551615)},
+		{arrow.PrimitiveTypes.Float64, "1.5e3", scalar.NewFloat64Scalar(1500)},
+		{arrow.FixedWidthTypes.Float16, "0.5", scalar.NewFloat16Scalar(float16.New(0.5))},
+		{&arrow.Decimal128Type{Precision: 5, Scale: 2}, "-123.4", scalar.NewDecimal128Scalar(decimal128.FromI64(-12340), &arrow.Decimal128Type{Precision: 5, Scale: 2})},
+		{arrow.BinaryTypes.String, "abc", scalar.NewStringScalar("abc")},
+		{&arrow.FixedSizeBinaryType{ByteWidth: 2}, "ab", scalar.NewFixedSizeBinaryScalar([]byte("ab"), &arrow.FixedSizeBinaryType{ByteWidth: 2})},
+		{arrow.FixedWidthTypes.Date32, "1969-12-31", scalar.NewDate32Scalar(-1)},
+		{arrow.FixedWidthTypes.Date64, "1970-01-02", scalar.NewDate64Scalar(86400000)},
+		{arrow.FixedWidthTypes.Date32, "3", scalar.NewDate32Scalar(3)},
+		{arrow.FixedWidthTypes.Timestamp_ms, "1970-01-01T00:00:01.5Z", scalar.NewTimestampScalar(1500, arrow.FixedWidthTypes.Timestamp_ms)},
+		{&arrow.TimestampType{Unit: arrow.Second, TimeZone: "+01:00"}, "1970-01-01 01:00:00", scalar.NewTimestampScalar(0, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "+01:00"})},
+		{arrow.FixedWidthTypes.Time32ms, "01:00:00.25", scalar.NewTime32Scalar(3600250, arrow.FixedWidthTypes.Time32ms)},
+		{arrow.FixedWidthTypes.Time64ns, "00:00:01", scalar.NewTime64Scalar(1e9, arrow.FixedWidthTypes.Time64ns)},
+		{arrow.FixedWidthTypes.Duration_s, "-5", scalar.NewDurationScalar(-5, arrow.FixedWidthTypes.Duration_s)},
+	} {
+		got, err := scalar.ParseScalar(tc.dt, tc.str)
+		if err != nil {
+			t.Errorf("could not parse %q as %v: %v", tc.str, tc.dt, err)
+			continue
+		}
+		if !got.Equals(tc.want) {
+			t.Errorf("invalid value for %q as %v: got=%v, want=%v", tc.str, tc.dt, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		dt  arrow.DataType
+		str string
+	}{
+		{arrow.PrimitiveTypes.Int8, "128"},
+		{arrow.PrimitiveTypes.Uint8, "-1"},
+		{arrow.FixedWidthTypes.Boolean, "yes"},
+		{&arrow.Decimal128Type{Precision: 5, Scale: 2}, "1.234"},
+		{&arrow.Decimal128Type{Precision: 5, Scale: 2}, "1234"},
+		{&arrow.FixedSizeBinaryType{ByteWidth: 2}, "abc"},
+		{arrow.FixedWidthTypes.Date32, "1970-13-01"},
+		{arrow.FixedWidthTypes.Duration_s, "1s"},
+		{arrow.ListOf(arrow.PrimitiveTypes.Int8), "[1]"},
+	} {
+		if got, err := scalar.ParseScalar(tc.dt, tc.str); err == nil {
+			t.Errorf("parsing %q as %v should have failed, got=%v", tc.str, tc.dt, got)
+		}
+	}
+}
+
+func TestCast(t *testing.T) {
+	var (
+		tsS  = arrow.FixedWidthTypes.Timestamp_s
+		tsMS = arrow.FixedWidthTypes.Timestamp_ms
+	)
+	for _, tc := range []struct {
+		in   scalar.Scalar
+		to   arrow.DataType
+		want scalar.Scalar
+	}{
+		{scalar.NewInt32Scalar(-1), arrow.PrimitiveTypes.Int64, scalar.NewInt64Scalar(-1)},
+		{scalar.NewInt32Scalar(-1), arrow.PrimitiveTypes.Uint8, scalar.NewUint8Scalar(255)},
+		{scalar.NewUint64Scalar(1 << 40), arrow.PrimitiveTypes.Float64, scalar.NewFloat64Scalar(1 << 40)},
+		{scalar.NewFloat64Scalar(-2.7), arrow.PrimitiveTypes.Int16, scalar.NewInt16Scalar(-2)},
+		{scalar.NewFloat32Scalar(0.5), arrow.FixedWidthTypes.Float16, scalar.NewFloat16Scalar(float16.New(0.5))},
+		{scalar.NewFloat16Scalar(float16.New(3)), arrow.PrimitiveTypes.Int8, scalar.NewInt8Scalar(3)},
+		{scalar.NewBooleanScalar(true), arrow.PrimitiveTypes.Float32, scalar.NewFloat32Scalar(1)},
+		{scalar.NewInt8Scalar(0), arrow.FixedWidthTypes.Boolean, scalar.NewBooleanScalar(false)},
+		{scalar.NewInt64Scalar(42), arrow.BinaryTypes.String, scalar.NewStringScalar("42")},
+		{scalar.NewStringScalar("42"), arrow.PrimitiveTypes.Uint16, scalar.NewUint16Scalar(42)},
+		{scalar.NewBinaryScalar([]byte("true")), arrow.FixedWidthTypes.Boolean, scalar.NewBooleanScalar(true)},
+		{scalar.MakeNullScalar(arrow.PrimitiveTypes.Int8), arrow.BinaryTypes.String, scalar.MakeNullScalar(arrow.BinaryTypes.String)},
+		{scalar.NewInt64Scalar(7), tsS, scalar.NewTimestampScalar(7, tsS)},
+		{scalar.NewDate32Scalar(3), arrow.PrimitiveTypes.Int32, scalar.NewInt32Scalar(3)},
+		{scalar.NewTimestampScalar(-1500, tsMS), tsS, scalar.NewTimestampScalar(-2, tsS)},
+		{scalar.NewTimestampScalar(2, tsS), tsMS, scalar.NewTimestampScalar(2000, tsMS)},
+		{scalar.NewTimestampScalar(-1, tsS), arrow.FixedWidthTypes.Date32, scalar.NewDate32Scalar(-1)},
+		{scalar.NewTimestampScalar(90000, tsS), arrow.FixedWidthTypes.Date64, scalar.NewDate64Scalar(86400000)},
+		{scalar.NewDate32Scalar(1), tsMS, scalar.NewTimestampScalar(86400000, tsMS)},
+		{scalar.NewDate64Scalar(-1), arrow.FixedWidthTypes.Date32, scalar.NewDate32Scalar(-1)},
+		{scalar.NewTime32Scalar(1500, arrow.FixedWidthTypes.Time32ms), arrow.FixedWidthTypes.Time64us, scalar.NewTime64Scalar(1500000, arrow.FixedWidthTypes.Time64us)},
+		{scalar.NewDurationScalar(-1500, arrow.FixedWidthTypes.Duration_ms), arrow.FixedWidthTypes.Duration_s, scalar.NewDurationScalar(-1, arrow.FixedWidthTypes.Duration_s)},
+		{scalar.NewStringScalar("1970-01-02"), arrow.FixedWidthTypes.Date32, scalar.NewDate32Scalar(1)},
+	} {
+		got, err := scalar.Cast(tc.in, tc.to)
+		if err != nil {
+			t.Errorf("could not cast %v (%v) to %v: %v", tc.in, tc.in.DataType(), tc.to, err)
+			continue
+		}
+		if !got.Equals(tc.want) {
+			t.Errorf("invalid cast of %v (%v) to %v: got=%v, want=%v", tc.in, tc.in.DataType(), tc.to, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		in scalar.Scalar
+		to arrow.DataType
+	}{
+		{scalar.NewFloat64Scalar(1), tsS},
+		{scalar.NewTimestampScalar(1, tsS), arrow.PrimitiveTypes.Float64},
+		{scalar.NewTimestampScalar(1, tsS), arrow.FixedWidthTypes.Time32s},
+		{scalar.NewStringScalar("x"), arrow.PrimitiveTypes.Int32},
+		{scalar.NewInt32Scalar(1), arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+	} {
+		if got, err := scalar.Cast(tc.in, tc.to); err == nil {
+			t.Errorf("casting %v (%v) to %v should have failed, got=%v", tc.in, tc.in.DataType(), tc.to, got)
+		}
+	}
+}
+
+func TestMakeScalar(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int64{1, 2, 3}, nil)
+	arr := ib.NewArray()
+	defer arr.Release()
+
+	for _, tc := range []struct {
+		v    interface{}
+		want scalar.Scalar
+	}{
+		{nil, scalar.ScalarNull},
+		{true, scalar.NewBooleanScalar(true)},
+		{1, scalar.NewInt64Scalar(1)},
+		{uint16(2), scalar.NewUint16Scalar(2)},
+		{1.5, scalar.NewFloat64Scalar(1.5)},
+		{"a", scalar.NewStringScalar("a")},
+		{[]byte("a"), scalar.NewBinaryScalar([]byte("a"))},
+		{arrow.Date32(3), scalar.NewDate32Scalar(3)},
+		{arrow.MonthInterval(2), scalar.NewMonthIntervalScalar(2)},
+		{time.Second, scalar.NewDurationScalar(1e9, arrow.FixedWidthTypes.Duration_ns)},
+		{time.Unix(1, 0), scalar.NewTimestampScalar(1e9, arrow.FixedWidthTypes.Timestamp_ns)},
+		{decimal128.FromI64(5), scalar.NewDecimal128Scalar(decimal128.FromI64(5), &arrow.Decimal128Type{Precision: 38})},
+		{scalar.NewInt8Scalar(1), scalar.NewInt8Scalar(1)},
+	} {
+		if got := scalar.MakeScalar(tc.v); !got.Equals(tc.want) {
+			t.Errorf("invalid scalar for %v (%T): got=%v (%v), want=%v (%v)", tc.v, tc.v, got, got.DataType(), tc.want, tc.want.DataType())
+		}
+	}
+
+	list := scalar.MakeScalar(arr)
+	defer release(list)
+	if got, want := list.DataType(), arrow.ListOf(arrow.PrimitiveTypes.Int64); !arrow.TypeEqual(got, want) {
+		t.Fatalf("invalid list type: got=%v, want=%v", got, want)
+	}
+	if got, want := list.String(), "[1 2 3]"; got != want {
+		t.Fatalf("invalid list: got=%q, want=%q", got, want)
+	}
+}
+
+func TestViewRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	sb := array.NewStringViewBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "", "a value which is not inlined"}, []bool{true, false, true})
+	strs := sb.NewArray()
+	defer strs.Release()
+	checkRoundTrip(t, strs)
+
+	bb := array.NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
+	defer bb.Release()
+	bb.AppendValues([][]byte{[]byte("another value which is not inlined"), nil}, []bool{true, false})
+	bins := bb.NewArray()
+	defer bins.Release()
+	checkRoundTrip(t, bins)
+}
+
+func TestCompare(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "b"}, nil)
+	dict := sb.NewArray()
+	defer dict.Release()
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	da := scalar.NewDictionaryScalar(scalar.NewInt8Scalar(0), dict, dt)
+	defer da.Release()
+	db := scalar.NewDictionaryScalar(scalar.NewInt8Scalar(1), dict, dt)
+	defer db.Release()
+
+	ms := arrow.FixedWidthTypes.Timestamp_ms
+	dec := &arrow.Decimal128Type{Precision: 10, Scale: 2}
+	nan := math.NaN()
+
+	for _, tc := range []struct {
+		a, b scalar.Scalar
+		want int
+		err  bool
+	}{
+		{a: scalar.NewInt32Scalar(1), b: scalar.NewInt32Scalar(2), want: -1},
+		{a: scalar.NewInt32Scalar(2), b: scalar.NewInt32Scalar(2), want: 0},
+		{a: scalar.NewInt8Scalar(-1), b: scalar.NewUint64Scalar(math.MaxUint64), want: -1},
+		{a: scalar.NewUint64Scalar(math.MaxUint64), b: scalar.NewInt64Scalar(math.MaxInt64), want: 1},
+		{a: scalar.NewInt64Scalar(math.MaxInt64), b: scalar.NewFloat64Scalar(1 << 63), want: -1},
+		{a: scalar.NewFloat32Scalar(1.5), b: scalar.NewInt16Scalar(1), want: 1},
+		{a: scalar.NewUint8Scalar(2), b: scalar.NewFloat64Scalar(2), want: 0},
+		{a: scalar.NewFloat64Scalar(nan), b: scalar.NewFloat64Scalar(math.Inf(1)), want: 1},
+		{a: scalar.NewFloat64Scalar(nan), b: scalar.NewFloat64Scalar(nan), want: 0},
+		{a: scalar.NewInt64Scalar(1), b: scalar.NewFloat64Scalar(nan), want: -1},
+		{a: scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), b: scalar.NewInt64Scalar(math.MinInt64), want: -1},
+		{a: scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), b: scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), want: 0},
+		{a: scalar.NewBooleanScalar(true), b: scalar.NewBooleanScalar(false), want: 1},
+		{a: scalar.NewStringScalar("ab"), b: scalar.NewStringScalar("b"), want: -1},
+		{a: scalar.NewBinaryScalar([]byte("b")), b: scalar.NewBinaryScalar([]byte("ab")), want: 1},
+		{a: scalar.NewTimestampScalar(2, ms), b: scalar.NewTimestampScalar(1, ms), want: 1},
+		{a: scalar.NewDecimal128Scalar(decimal128.FromI64(-1), dec), b: scalar.NewDecimal128Scalar(decimal128.FromI64(1), dec), want: -1},
+		{a: da, b: db, want: -1},
+		{a: db, b: scalar.NewStringScalar("b"), want: 0},
+
+		{a: scalar.NewStringScalar("a"), b: scalar.NewBinaryScalar([]byte("a")), err: true},
+		{a: scalar.NewTimestampScalar(1, ms), b: scalar.NewTimestampScalar(1, arrow.FixedWidthTypes.Timestamp_s), err: true},
+		{a: scalar.NewTimestampScalar(1, ms), b: scalar.NewInt64Scalar(1), err: true},
+		{a: scalar.NewDayTimeIntervalScalar(arrow.DayTimeInterval{}), b: scalar.NewDayTimeIntervalScalar(arrow.DayTimeInterval{}), err: true},
+	} {
+		got, err := scalar.Compare(tc.a, tc.b)
+		switch {
+		case tc.err && err == nil:
+			t.Errorf("compare(%v, %v): expected an error", tc.a, tc.b)
+		case !tc.err && err != nil:
+			t.Errorf("compare(%v, %v): %v", tc.a, tc.b, err)
+		case got != tc.want:
+			t.Errorf("compare(%v, %v): got=%d, want=%d", tc.a, tc.b, got, tc.want)
+		}
+		if !tc.err {
+			if back, _ := scalar.Compare(tc.b, tc.a); back != -tc.want {
+				t.Errorf("compare(%v, %v): got=%d, want=%d", tc.b, tc.a, back, -tc.want)
+			}
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		s   scalar.Scalar
+		i   int64
+		u   uint64
+		f   float64
+		err string // the conversions which fail
+	}{
+		{s: scalar.NewInt8Scalar(-3), i: -3, f: -3, err: "u"},
+		{s: scalar.NewUint64Scalar(math.MaxUint64), u: math.MaxUint64, err: "if"},
+		{s: scalar.NewUint64Scalar(1 << 63), u: 1 << 63, f: 1 << 63, err: "i"},
+		{s: scalar.NewInt64Scalar(math.MaxInt64), i: math.MaxInt64, u: math.MaxInt64, err: "f"},
+		{s: scalar.NewFloat64Scalar(2), i: 2, u: 2, f: 2},
+		{s: scalar.NewFloat64Scalar(2.5), f: 2.5, err: "iu"},
+		{s: scalar.NewFloat64Scalar(1 << 63), u: 1 << 63, f: 1 << 63, err: "i"},
+		{s: scalar.NewFloat32Scalar(-1), i: -1, f: -1, err: "u"},
+		{s: scalar.NewFloat16Scalar(float16.New(0.5)), f: 0.5, err: "iu"},
+		{s: scalar.NewDate32Scalar(10), i: 10, u: 10, f: 10},
+		{s: scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), err: "iuf"},
+		{s: scalar.NewStringScalar("1"), err: "iuf"},
+	} {
+		if got, err := scalar.ToInt64(tc.s); (err != nil) != strings.Contains(tc.err, "i") || got != tc.i {
+			t.Errorf("ToInt64(%v): got=%d, err=%v", tc.s, got, err)
+		}
+		if got, err := scalar.ToUint64(tc.s); (err != nil) != strings.Contains(tc.err, "u") || got != tc.u {
+			t.Errorf("ToUint64(%v): got=%d, err=%v", tc.s, got, err)
+		}
+		if got, err := scalar.ToFloat64(tc.s); (err != nil) != strings.Contains(tc.err, "f") || got != tc.f {
+			t.Errorf("ToFloat64(%v): got=%v, err=%v", tc.s, got, err)
+		}
+	}
+
+	if v, err := scalar.ToBool(scalar.NewBooleanScalar(true)); err != nil || !v {
+		t.Errorf("ToBool(true): got=%v, err=%v", v, err)
+	}
+	if _, err := scalar.ToBool(scalar.MakeNullScalar(arrow.FixedWidthTypes.Boolean)); err == nil {
+		t.Errorf("ToBool(null): expected an error")
+	}
+}
+
+func TestRow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt32Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int32{1, 2, 3}, []bool{true, false, true})
+	ints := ib.NewArray()
+	defer ints.Release()
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "b"}, nil)
+	dict := sb.NewArray()
+	defer dict.Release()
+	xb := array.NewInt8Builder(mem)
+	defer xb.Release()
+	xb.AppendValues([]int8{1, 0, 0}, []bool{true, true, false})
+	indices := xb.NewArray()
+	defer indices.Release()
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	dicts := array.NewDictionaryArray(dt, indices, dict)
+	defer dicts.Release()
+
+	rb := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Float64)
+	defer rb.Release()
+	rb.AppendRuns([]uint64{2, 1})
+	rb.ValueBuilder().(*array.Float64Builder).AppendValues([]float64{1.5, 2.5}, nil)
+	runs := rb.NewArray()
+	defer runs.Release()
+
+	lb := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+	defer lb.Release()
+	vb := lb.ValueBuilder().(*array.Int64Builder)
+	lb.Append(true)
+	vb.AppendValues([]int64{1}, nil)
+	lb.Append(true)
+	vb.AppendValues([]int64{2, 3}, nil)
+	lb.AppendNull()
+	lists := lb.NewArray()
+	defer lists.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ints", Type: ints.DataType(), Nullable: true},
+		{Name: "dicts", Type: dicts.DataType(), Nullable: true},
+		{Name: "runs", Type: runs.DataType()},
+		{Name: "lists", Type: lists.DataType(), Nullable: true},
+	}, nil)
+	rec := array.NewRecord(schema, []array.Interface{ints, dicts, runs, lists}, 3)
+	defer rec.Release()
+
+	row, err := scalar.Row(rec, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scalar.ReleaseRow(row)
+	want := []string{"null", "a", "1.5", "[2 3]"}
+	for i, s := range row {
+		if s.String() != want[i] {
+			t.Errorf("invalid value of column %d: got=%v, want=%v", i, s, want[i])
+		}
+	}
+	if !row[1].Equals(scalar.NewStringScalar("a")) {
+		t.Errorf("dictionary value is not decoded: %v (%v)", row[1], row[1].DataType())
+	}
+
+	m, err := scalar.RowMap(rec, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range m {
+		release(s)
+	}
+	if got := m["ints"]; !got.Equals(scalar.NewInt32Scalar(3)) {
+		t.Errorf("invalid value of ints: %v", got)
+	}
+	if got := m["dicts"]; got.IsValid() || got.DataType().ID() != arrow.STRING {
+		t.Errorf("invalid value of dicts: %v (%v)", got, got.DataType())
+	}
+	if got := m["runs"]; !got.Equals(scalar.NewFloat64Scalar(2.5)) {
+		t.Errorf("invalid value of runs: %v", got)
+	}
+
+	if _, err := scalar.Row(rec, 3); err == nil {
+		t.Errorf("expected an error for an out of range row")
+	}
+}
@@ -0,0 +1,247 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiffKind is the kind of a difference between two schemas.
+type DiffKind int8
+
+const (
+	// DiffMissing is a field of the left schema missing from the right one.
+	DiffMissing DiffKind = iota
+	// DiffExtra is a field of the right schema missing from the left one.
+	DiffExtra
+	// DiffOrder is a field at different positions in the two schemas.
+	DiffOrder
+	// DiffType is a field of different types in the two schemas.
+	DiffType
+	// DiffNullable is a field nullable in only one of the schemas.
+	DiffNullable
+	// DiffMetadata is a field, or a schema, of different metadata.
+	DiffMetadata
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffMissing:
+		return "missing"
+	case DiffExtra:
+		return "extra"
+	case DiffOrder:
+		return "order"
+	case DiffType:
+		return "type"
+	case DiffNullable:
+		return "nullable"
+	case DiffMetadata:
+		return "metadata"
+	}
+	return "DiffKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Diff is a difference between two schemas.
+type Diff struct {
+	Kind DiffKind
+	// Path is the path of the field differing, the names of the fields
+	// from the top-level one joined with dots, "item" standing for the
+	// elements of lists. It is empty for the metadata of the schemas.
+	Path string
+	// Left and Right describe the field, or the metadata, in the left and
+	// right schemas, and are empty when missing.
+	Left, Right string
+}
+
+func (d Diff) String() string {
+	path := d.Path
+	if path == "" {
+		path = "<schema>"
+	}
+	left, right := d.Left, d.Right
+	if left == "" {
+		left = "<none>"
+	}
+	if right == "" {
+		right = "<none>"
+	}
+	return fmt.Sprintf("%s: %v: %s != %s", path, d.Kind, left, right)
+}
+
+// Diffs are the differences between two schemas, as reported by
+// SchemaDiff.
+type Diffs []Diff
+
+// Equal returns whether there is no difference.
+func (ds Diffs) Equal() bool { return len(ds) == 0 }
+
+// String returns the differences, one per line.
+func (ds Diffs) String() string {
+	o := new(strings.Builder)
+	for i, d := range ds {
+		if i > 0 {
+			o.WriteString("\n")
+		}
+		o.WriteString(d.String())
+	}
+	return o.String()
+}
+
+type diffConfig struct {
+	metadata bool
+	nullable bool
+}
+
+// DiffOption is a functional option type used for configuring SchemaDiff.
+type DiffOption func(*diffConfig)
+
+// IgnoreMetadata is an option for SchemaDiff ignoring the metadata of the
+// schemas and of their fields.
+func IgnoreMetadata() DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.metadata = false
+	}
+}
+
+// IgnoreNullability is an option for SchemaDiff ignoring whether fields
+// are nullable.
+func IgnoreNullability() DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.nullable = false
+	}
+}
+
+// SchemaDiff returns the differences between the left and right schemas.
+// Without options, schemas without differences are equal as of
+// Schema.Equal, and have the same metadata. The fields are matched by name, the fields of the same name
+// in the order of the schemas, so that a field missing does not make the
+// fields after it differ. The differences are reported in the order of the
+// fields of the left schema, then the fields only in the right one.
+func SchemaDiff(left, right *Schema, opts ...DiffOption) Diffs {
+	cfg := diffConfig{metadata: true, nullable: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var ds Diffs
+	if cfg.metadata && !metadataEqual(left.Metadata(), right.Metadata()) {
+		ds = append(ds, Diff{Kind: DiffMetadata, Left: left.Metadata().String(), Right: right.Metadata().String()})
+	}
+	return cfg.fields(ds, "", left.Fields(), right.Fields())
+}
+
+// fields appends the differences between the fields left and right,
+// children of the field at path, to ds.
+func (cfg diffConfig) fields(ds Diffs, path string, left, right []Field) Diffs {
+	var (
+		matched = make([]bool, len(right))
+		seen    = make(map[string]int, len(left))
+	)
+	for i, lf := range left {
+		// the n-th field named lf.Name matches the n-th such field of right.
+		j, n := -1, seen[lf.Name]
+		seen[lf.Name]++
+		for k, rf := range right {
+			if rf.Name != lf.Name {
+				continue
+			}
+			if n == 0 {
+				j = k
+				break
+			}
+			n--
+		}
+
+		fpath := joinPath(path, lf.Name)
+		if j < 0 {
+			ds = append(ds, Diff{Kind: DiffMissing, Path: fpath, Left: fmt.Sprint(lf.Type)})
+			continue
+		}
+		matched[j] = true
+		if i != j {
+			ds = append(ds, Diff{Kind: DiffOrder, Path: fpath, Left: "index " + strconv.Itoa(i), Right: "index " + strconv.Itoa(j)})
+		}
+		ds = cfg.field(ds, fpath, lf, right[j])
+	}
+	for j, rf := range right {
+		if !matched[j] {
+			ds = append(ds, Diff{Kind: DiffExtra, Path: joinPath(path, rf.Name), Right: fmt.Sprint(rf.Type)})
+		}
+	}
+	return ds
+}
+
+// field appends the differences between the fields left and right at path
+// to ds.
+func (cfg diffConfig) field(ds Diffs, path string, left, right Field) Diffs {
+	if cfg.nullable && left.Nullable != right.Nullable {
+		ds = append(ds, Diff{Kind: DiffNullable, Path: path, Left: strconv.FormatBool(left.Nullable), Right: strconv.FormatBool(right.Nullable)})
+	}
+	if cfg.metadata && !metadataEqual(left.Metadata, right.Metadata) {
+		ds = append(ds, Diff{Kind: DiffMetadata, Path: path, Left: left.Metadata.String(), Right: right.Metadata.String()})
+	}
+	return cfg.dataType(ds, path, left.Type, right.Type)
+}
+
+// dataType appends the differences between the types left and right of the
+// field at path to ds: the differences of their children for nested types
+// of the same kind, or else of the types themselves.
+func (cfg diffConfig) dataType(ds Diffs, path string, left, right DataType) Diffs {
+	typeDiff := Diff{Kind: DiffType, Path: path, Left: fmt.Sprint(left), Right: fmt.Sprint(right)}
+	if left.ID() != right.ID() {
+		return append(ds, typeDiff)
+	}
+
+	switch l := left.(type) {
+	case *StructType:
+		return cfg.fields(ds, path, l.Fields(), right.(*StructType).Fields())
+	case *ListType:
+		return cfg.dataType(ds, joinPath(path, "item"), l.Elem(), right.(*ListType).Elem())
+	case *FixedSizeListType:
+		r := right.(*FixedSizeListType)
+		if l.Len() != r.Len() {
+			return append(ds, typeDiff)
+		}
+		return cfg.dataType(ds, joinPath(path, "item"), l.Elem(), r.Elem())
+	}
+	if !TypeEqual(left, right) {
+		ds = append(ds, typeDiff)
+	}
+	return ds
+}
+
+func metadataEqual(left, right Metadata) bool {
+	if left.Len() != right.Len() {
+		return false
+	}
+	for i, k := range left.Keys() {
+		if right.Keys()[i] != k || right.Values()[i] != left.Values()[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
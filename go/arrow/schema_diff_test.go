@@ -0,0 +1,151 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	var (
+		md  = NewMetadata([]string{"k"}, []string{"v"})
+		md2 = NewMetadata([]string{"k"}, []string{"w"})
+		i64 = PrimitiveTypes.Int64
+		f64 = PrimitiveTypes.Float64
+	)
+	for _, tc := range []struct {
+		name        string
+		left, right *Schema
+		opts        []DiffOption
+		want        Diffs
+	}{
+		{
+			name:  "equal",
+			left:  NewSchema([]Field{{Name: "a", Type: i64}, {Name: "b", Type: ListOf(f64)}}, &md),
+			right: NewSchema([]Field{{Name: "a", Type: i64}, {Name: "b", Type: ListOf(f64)}}, &md),
+		},
+		{
+			name:  "missing",
+			left:  NewSchema([]Field{{Name: "a", Type: i64}, {Name: "b", Type: f64}, {Name: "c", Type: i64}}, nil),
+			right: NewSchema([]Field{{Name: "a", Type: i64}, {Name: "c", Type: i64}, {Name: "d", Type: f64}}, nil),
+			want: Diffs{
+				{Kind: DiffMissing, Path: "b", Left: "float64"},
+				{Kind: DiffOrder, Path: "c", Left: "index 2", Right: "index 1"},
+				{Kind: DiffExtra, Path: "d", Right: "float64"},
+			},
+		},
+		{
+			name:  "duplicate names",
+			left:  NewSchema([]Field{{Name: "a", Type: i64}, {Name: "a", Type: i64}}, nil),
+			right: NewSchema([]Field{{Name: "a", Type: i64}, {Name: "a", Type: f64}}, nil),
+			want:  Diffs{{Kind: DiffType, Path: "a", Left: "int64", Right: "float64"}},
+		},
+		{
+			name:  "type",
+			left:  NewSchema([]Field{{Name: "a", Type: i64}}, nil),
+			right: NewSchema([]Field{{Name: "a", Type: ListOf(i64)}}, nil),
+			want:  Diffs{{Kind: DiffType, Path: "a", Left: "int64", Right: "list<item: int64>"}},
+		},
+		{
+			name: "nested struct",
+			left: NewSchema([]Field{{Name: "s", Type: StructOf(
+				Field{Name: "x", Type: i64},
+				Field{Name: "y", Type: StructOf(Field{Name: "z", Type: f64, Nullable: true})},
+			)}}, nil),
+			right: NewSchema([]Field{{Name: "s", Type: StructOf(
+				Field{Name: "x", Type: i64, Metadata: md},
+				Field{Name: "y", Type: StructOf(Field{Name: "z", Type: i64})},
+			)}}, nil),
+			want: Diffs{
+				{Kind: DiffMetadata, Path: "s.x", Left: "[]", Right: `["k": "v"]`},
+				{Kind: DiffNullable, Path: "s.y.z", Left: "true", Right: "false"},
+				{Kind: DiffType, Path: "s.y.z", Left: "float64", Right: "int64"},
+			},
+		},
+		{
+			name: "nested struct ignoring metadata and nullability",
+			left: NewSchema([]Field{{Name: "s", Type: StructOf(
+				Field{Name: "x", Type: i64},
+				Field{Name: "y", Type: StructOf(Field{Name: "z", Type: f64, Nullable: true})},
+			)}}, &md),
+			right: NewSchema([]Field{{Name: "s", Type: StructOf(
+				Field{Name: "x", Type: i64, Metadata: md},
+				Field{Name: "y", Type: StructOf(Field{Name: "z", Type: i64})},
+			)}}, &md2),
+			opts: []DiffOption{IgnoreMetadata(), IgnoreNullability()},
+			want: Diffs{{Kind: DiffType, Path: "s.y.z", Left: "float64", Right: "int64"}},
+		},
+		{
+			name:  "list of structs",
+			left:  NewSchema([]Field{{Name: "l", Type: ListOf(StructOf(Field{Name: "x", Type: i64}))}}, nil),
+			right: NewSchema([]Field{{Name: "l", Type: ListOf(StructOf(Field{Name: "y", Type: i64}))}}, nil),
+			want: Diffs{
+				{Kind: DiffMissing, Path: "l.item.x", Left: "int64"},
+				{Kind: DiffExtra, Path: "l.item.y", Right: "int64"},
+			},
+		},
+		{
+			name:  "fixed size list",
+			left:  NewSchema([]Field{{Name: "l", Type: FixedSizeListOf(2, i64)}}, nil),
+			right: NewSchema([]Field{{Name: "l", Type: FixedSizeListOf(3, i64)}}, nil),
+			want:  Diffs{{Kind: DiffType, Path: "l", Left: "fixed_size_list<item: int64>[2]", Right: "fixed_size_list<item: int64>[3]"}},
+		},
+		{
+			name:  "dictionary",
+			left:  NewSchema([]Field{{Name: "d", Type: &DictionaryType{IndexType: PrimitiveTypes.Int8, ValueType: BinaryTypes.String}}}, nil),
+			right: NewSchema([]Field{{Name: "d", Type: &DictionaryType{IndexType: PrimitiveTypes.Int16, ValueType: BinaryTypes.String}}}, nil),
+			want: Diffs{{
+				Kind:  DiffType,
+				Path:  "d",
+				Left:  "dictionary<values=utf8, indices=int8, ordered=false>",
+				Right: "dictionary<values=utf8, indices=int16, ordered=false>",
+			}},
+		},
+		{
+			name:  "schema metadata",
+			left:  NewSchema([]Field{{Name: "a", Type: i64}}, &md),
+			right: NewSchema([]Field{{Name: "a", Type: i64}}, &md2),
+			want:  Diffs{{Kind: DiffMetadata, Left: `["k": "v"]`, Right: `["k": "w"]`}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SchemaDiff(tc.left, tc.right, tc.opts...)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid diff:\ngot:\n%v\nwant:\n%v", got, tc.want)
+			}
+			if len(tc.opts) == 0 {
+				equal := tc.left.Equal(tc.right) && metadataEqual(tc.left.Metadata(), tc.right.Metadata())
+				if got.Equal() != equal {
+					t.Fatalf("diff is inconsistent with Schema.Equal: %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffsString(t *testing.T) {
+	ds := Diffs{
+		{Kind: DiffMetadata, Left: `["k": "v"]`},
+		{Kind: DiffType, Path: "s.y", Left: "int64", Right: "float64"},
+	}
+	want := `<schema>: metadata: ["k": "v"] != <none>
+s.y: type: int64 != float64`
+	if got := ds.String(); got != want {
+		t.Fatalf("invalid string:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,244 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/float16"
+)
+
+// The tensors of this file have data types with parameters, such as a time
+// unit, which are those of their backing data.
+
+// Float16 is an n-dim array of float16s.
+type Float16 struct {
+	tensorBase
+	values []float16.Num
+}
+
+// NewFloat16 returns a new n-dimensional array of float16s.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewFloat16(data *array.Data, shape, strides []int64, names []string) *Float16 {
+	tsr := &Float16{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.Float16Traits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *Float16) Value(i []int64) float16.Num  { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Float16) Float16Values() []float16.Num { return tsr.values }
+func (tsr *Float16) value(i []int64) interface{}  { return tsr.Value(i) }
+
+// Time32 is an n-dim array of arrow.Time32s.
+type Time32 struct {
+	tensorBase
+	values []arrow.Time32
+}
+
+// NewTime32 returns a new n-dimensional array of arrow.Time32s.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewTime32(data *array.Data, shape, strides []int64, names []string) *Time32 {
+	tsr := &Time32{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.Time32Traits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *Time32) Value(i []int64) arrow.Time32 { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Time32) Time32Values() []arrow.Time32 { return tsr.values }
+func (tsr *Time32) value(i []int64) interface{}  { return tsr.Value(i) }
+
+// Time64 is an n-dim array of arrow.Time64s.
+type Time64 struct {
+	tensorBase
+	values []arrow.Time64
+}
+
+// NewTime64 returns a new n-dimensional array of arrow.Time64s.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewTime64(data *array.Data, shape, strides []int64, names []string) *Time64 {
+	tsr := &Time64{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.Time64Traits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *Time64) Value(i []int64) arrow.Time64 { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Time64) Time64Values() []arrow.Time64 { return tsr.values }
+func (tsr *Time64) value(i []int64) interface{}  { return tsr.Value(i) }
+
+// Timestamp is an n-dim array of arrow.Timestamps.
+type Timestamp struct {
+	tensorBase
+	values []arrow.Timestamp
+}
+
+// NewTimestamp returns a new n-dimensional array of arrow.Timestamps.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewTimestamp(data *array.Data, shape, strides []int64, names []string) *Timestamp {
+	tsr := &Timestamp{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.TimestampTraits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *Timestamp) Value(i []int64) arrow.Timestamp    { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Timestamp) TimestampValues() []arrow.Timestamp { return tsr.values }
+func (tsr *Timestamp) value(i []int64) interface{}        { return tsr.Value(i) }
+
+// Duration is an n-dim array of arrow.Durations.
+type Duration struct {
+	tensorBase
+	values []arrow.Duration
+}
+
+// NewDuration returns a new n-dimensional array of arrow.Durations.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewDuration(data *array.Data, shape, strides []int64, names []string) *Duration {
+	tsr := &Duration{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.DurationTraits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *Duration) Value(i []int64) arrow.Duration   { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Duration) DurationValues() []arrow.Duration { return tsr.values }
+func (tsr *Duration) value(i []int64) interface{}      { return tsr.Value(i) }
+
+// Decimal128 is an n-dim array of decimal128 numbers.
+type Decimal128 struct {
+	tensorBase
+	values []decimal128.Num
+}
+
+// NewDecimal128 returns a new n-dimensional array of decimal128 numbers.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewDecimal128(data *array.Data, shape, strides []int64, names []string) *Decimal128 {
+	tsr := &Decimal128{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.Decimal128Traits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *Decimal128) Value(i []int64) decimal128.Num     { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Decimal128) Decimal128Values() []decimal128.Num { return tsr.values }
+func (tsr *Decimal128) value(i []int64) interface{}        { return tsr.Value(i) }
+
+// MonthInterval is an n-dim array of arrow.MonthIntervals.
+type MonthInterval struct {
+	tensorBase
+	values []arrow.MonthInterval
+}
+
+// NewMonthInterval returns a new n-dimensional array of arrow.MonthIntervals.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewMonthInterval(data *array.Data, shape, strides []int64, names []string) *MonthInterval {
+	tsr := &MonthInterval{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.MonthIntervalTraits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *MonthInterval) Value(i []int64) arrow.MonthInterval {
+	j := int(tsr.offset(i))
+	return tsr.values[j]
+}
+func (tsr *MonthInterval) MonthIntervalValues() []arrow.MonthInterval { return tsr.values }
+func (tsr *MonthInterval) value(i []int64) interface{}                { return tsr.Value(i) }
+
+// DayTimeInterval is an n-dim array of arrow.DayTimeIntervals.
+type DayTimeInterval struct {
+	tensorBase
+	values []arrow.DayTimeInterval
+}
+
+// NewDayTimeInterval returns a new n-dimensional array of arrow.DayTimeIntervals.
+// If strides is nil, row-major strides will be inferred.
+// If names is nil, a slice of empty strings will be created.
+func NewDayTimeInterval(data *array.Data, shape, strides []int64, names []string) *DayTimeInterval {
+	tsr := &DayTimeInterval{tensorBase: *newTensor(data.DataType(), data, shape, strides, names)}
+	vals := tsr.data.Buffers()[1]
+	if vals != nil {
+		tsr.values = arrow.DayTimeIntervalTraits.CastFromBytes(vals.Bytes())
+		beg := tsr.data.Offset()
+		end := beg + tsr.data.Len()
+		tsr.values = tsr.values[beg:end]
+	}
+	return tsr
+}
+
+func (tsr *DayTimeInterval) Value(i []int64) arrow.DayTimeInterval {
+	j := int(tsr.offset(i))
+	return tsr.values[j]
+}
+func (tsr *DayTimeInterval) DayTimeIntervalValues() []arrow.DayTimeInterval { return tsr.values }
+func (tsr *DayTimeInterval) value(i []int64) interface{}                    { return tsr.Value(i) }
+
+var (
+	_ Interface = (*Float16)(nil)
+	_ Interface = (*Time32)(nil)
+	_ Interface = (*Time64)(nil)
+	_ Interface = (*Timestamp)(nil)
+	_ Interface = (*Duration)(nil)
+	_ Interface = (*Decimal128)(nil)
+	_ Interface = (*MonthInterval)(nil)
+	_ Interface = (*DayTimeInterval)(nil)
+)
@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Layout is the order of the elements of a contiguous tensor.
+type Layout int
+
+const (
+	// RowMajor is the layout where the last dimension varies fastest, as
+	// in C arrays.
+	RowMajor Layout = iota
+	// ColMajor is the layout where the first dimension varies fastest, as
+	// in Fortran arrays.
+	ColMajor
+)
+
+// ConvertLayout returns a copy of t, with its elements stored in the
+// given layout. t may have any strides, such as those of a slice of a
+// larger tensor.
+//
+// The returned tensor must be Release()'d after use.
+func ConvertLayout(t Interface, layout Layout, mem memory.Allocator) Interface {
+	var (
+		dtype = t.DataType()
+		shape = t.Shape()
+		src   = t.Strides()
+		bw    = byteWidth(dtype)
+		n     = int64(t.Len())
+	)
+	strides := rowMajorStrides(dtype, shape)
+	if layout == ColMajor {
+		strides = colMajorStrides(dtype, shape)
+	}
+
+	buf := memory.NewResizableBuffer(mem)
+	defer buf.Release()
+	buf.Resize(int(n * bw))
+
+	if n > 0 {
+		data := t.Data()
+		in := data.Buffers()[1].Bytes()[int64(data.Offset())*bw:]
+		out := buf.Bytes()
+		index := make([]int64, len(shape))
+		for {
+			var from, to int64
+			for i, v := range index {
+				from += v * src[i]
+				to += v * strides[i]
+			}
+			copy(out[to:to+bw], in[from:from+bw])
+
+			// the last dimension varies fastest.
+			i := len(index) - 1
+			for ; i >= 0; i-- {
+				if index[i]++; index[i] < shape[i] {
+					break
+				}
+				index[i] = 0
+			}
+			if i < 0 {
+				break
+			}
+		}
+	}
+
+	data := array.NewData(dtype, int(n), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+
+	var names []string
+	if t.DimNames() != nil {
+		names = append(names, t.DimNames()...)
+	}
+	return New(data, append([]int64(nil), shape...), strides, names)
+}
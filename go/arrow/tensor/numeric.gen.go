@@ -44,8 +44,9 @@ func NewInt8(data *array.Data, shape, strides []int64, names []string) *Int8 {
 	return tsr
 }
 
-func (tsr *Int8) Value(i []int64) int8 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Int8) Int8Values() []int8   { return tsr.values }
+func (tsr *Int8) Value(i []int64) int8        { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Int8) Int8Values() []int8          { return tsr.values }
+func (tsr *Int8) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Int16 is an n-dim array of int16s.
 type Int16 struct {
@@ -68,8 +69,9 @@ func NewInt16(data *array.Data, shape, strides []int64, names []string) *Int16 {
 	return tsr
 }
 
-func (tsr *Int16) Value(i []int64) int16 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Int16) Int16Values() []int16  { return tsr.values }
+func (tsr *Int16) Value(i []int64) int16       { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Int16) Int16Values() []int16        { return tsr.values }
+func (tsr *Int16) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Int32 is an n-dim array of int32s.
 type Int32 struct {
@@ -92,8 +94,9 @@ func NewInt32(data *array.Data, shape, strides []int64, names []string) *Int32 {
 	return tsr
 }
 
-func (tsr *Int32) Value(i []int64) int32 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Int32) Int32Values() []int32  { return tsr.values }
+func (tsr *Int32) Value(i []int64) int32       { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Int32) Int32Values() []int32        { return tsr.values }
+func (tsr *Int32) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Int64 is an n-dim array of int64s.
 type Int64 struct {
@@ -116,8 +119,9 @@ func NewInt64(data *array.Data, shape, strides []int64, names []string) *Int64 {
 	return tsr
 }
 
-func (tsr *Int64) Value(i []int64) int64 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Int64) Int64Values() []int64  { return tsr.values }
+func (tsr *Int64) Value(i []int64) int64       { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Int64) Int64Values() []int64        { return tsr.values }
+func (tsr *Int64) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Uint8 is an n-dim array of uint8s.
 type Uint8 struct {
@@ -140,8 +144,9 @@ func NewUint8(data *array.Data, shape, strides []int64, names []string) *Uint8 {
 	return tsr
 }
 
-func (tsr *Uint8) Value(i []int64) uint8 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Uint8) Uint8Values() []uint8  { return tsr.values }
+func (tsr *Uint8) Value(i []int64) uint8       { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Uint8) Uint8Values() []uint8        { return tsr.values }
+func (tsr *Uint8) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Uint16 is an n-dim array of uint16s.
 type Uint16 struct {
@@ -164,8 +169,9 @@ func NewUint16(data *array.Data, shape, strides []int64, names []string) *Uint16
 	return tsr
 }
 
-func (tsr *Uint16) Value(i []int64) uint16 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Uint16) Uint16Values() []uint16 { return tsr.values }
+func (tsr *Uint16) Value(i []int64) uint16      { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Uint16) Uint16Values() []uint16      { return tsr.values }
+func (tsr *Uint16) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Uint32 is an n-dim array of uint32s.
 type Uint32 struct {
@@ -188,8 +194,9 @@ func NewUint32(data *array.Data, shape, strides []int64, names []string) *Uint32
 	return tsr
 }
 
-func (tsr *Uint32) Value(i []int64) uint32 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Uint32) Uint32Values() []uint32 { return tsr.values }
+func (tsr *Uint32) Value(i []int64) uint32      { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Uint32) Uint32Values() []uint32      { return tsr.values }
+func (tsr *Uint32) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Uint64 is an n-dim array of uint64s.
 type Uint64 struct {
@@ -212,8 +219,9 @@ func NewUint64(data *array.Data, shape, strides []int64, names []string) *Uint64
 	return tsr
 }
 
-func (tsr *Uint64) Value(i []int64) uint64 { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Uint64) Uint64Values() []uint64 { return tsr.values }
+func (tsr *Uint64) Value(i []int64) uint64      { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Uint64) Uint64Values() []uint64      { return tsr.values }
+func (tsr *Uint64) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Float32 is an n-dim array of float32s.
 type Float32 struct {
@@ -236,8 +244,9 @@ func NewFloat32(data *array.Data, shape, strides []int64, names []string) *Float
 	return tsr
 }
 
-func (tsr *Float32) Value(i []int64) float32  { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Float32) Float32Values() []float32 { return tsr.values }
+func (tsr *Float32) Value(i []int64) float32     { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Float32) Float32Values() []float32    { return tsr.values }
+func (tsr *Float32) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Float64 is an n-dim array of float64s.
 type Float64 struct {
@@ -260,8 +269,9 @@ func NewFloat64(data *array.Data, shape, strides []int64, names []string) *Float
 	return tsr
 }
 
-func (tsr *Float64) Value(i []int64) float64  { j := int(tsr.offset(i)); return tsr.values[j] }
-func (tsr *Float64) Float64Values() []float64 { return tsr.values }
+func (tsr *Float64) Value(i []int64) float64     { j := int(tsr.offset(i)); return tsr.values[j] }
+func (tsr *Float64) Float64Values() []float64    { return tsr.values }
+func (tsr *Float64) value(i []int64) interface{} { return tsr.Value(i) }
 
 // Date32 is an n-dim array of date32s.
 type Date32 struct {
@@ -286,6 +296,7 @@ func NewDate32(data *array.Data, shape, strides []int64, names []string) *Date32
 
 func (tsr *Date32) Value(i []int64) arrow.Date32 { j := int(tsr.offset(i)); return tsr.values[j] }
 func (tsr *Date32) Date32Values() []arrow.Date32 { return tsr.values }
+func (tsr *Date32) value(i []int64) interface{}  { return tsr.Value(i) }
 
 // Date64 is an n-dim array of date64s.
 type Date64 struct {
@@ -310,6 +321,7 @@ func NewDate64(data *array.Data, shape, strides []int64, names []string) *Date64
 
 func (tsr *Date64) Value(i []int64) arrow.Date64 { j := int(tsr.offset(i)); return tsr.values[j] }
 func (tsr *Date64) Date64Values() []arrow.Date64 { return tsr.values }
+func (tsr *Date64) value(i []int64) interface{}  { return tsr.Value(i) }
 
 var (
 	_ Interface = (*Int8)(nil)
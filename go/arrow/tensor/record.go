@@ -0,0 +1,255 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Option configures the conversion of a record to a tensor.
+type Option func(*config)
+
+type config struct {
+	fill interface{}
+}
+
+// WithFillValue replaces the null values of the columns with v.
+// v must be of the type returned by the Value method of the tensor,
+// e.g. a float64 for columns of type arrow.PrimitiveTypes.Float64.
+func WithFillValue(v interface{}) Option {
+	return func(cfg *config) {
+		cfg.fill = v
+	}
+}
+
+// FromRecord returns a 2-dim row-major tensor of shape (rows, columns)
+// holding the named columns of rec, in order. If cols is nil, all the
+// columns of rec are used. The columns must all have the same fixed width
+// data type.
+//
+// FromRecord returns an error if a column holds null values, unless a
+// fill value was provided with WithFillValue.
+//
+// The tensor shares the memory of the column when a single column without
+// nulls is requested. Otherwise, the values are copied into memory
+// allocated from mem and copied is true.
+//
+// The returned tensor must be Release()'d after use.
+func FromRecord(rec array.Record, cols []string, mem memory.Allocator, opts ...Option) (tsr Interface, copied bool, err error) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cols == nil {
+		for i := 0; i < int(rec.NumCols()); i++ {
+			cols = append(cols, rec.ColumnName(i))
+		}
+	}
+	if len(cols) == 0 {
+		return nil, false, fmt.Errorf("arrow/tensor: no columns to convert")
+	}
+
+	arrs := make([]array.Interface, len(cols))
+	for i, name := range cols {
+		idx := rec.Schema().FieldIndices(name)
+		if len(idx) == 0 {
+			return nil, false, fmt.Errorf("arrow/tensor: no column named %q", name)
+		}
+		arrs[i] = rec.Column(idx[0])
+	}
+
+	dtype := arrs[0].DataType()
+	if !isTensorType(dtype) {
+		return nil, false, fmt.Errorf("arrow/tensor: invalid data type %s for column %q", dtype.Name(), cols[0])
+	}
+
+	nulls := false
+	for i, arr := range arrs {
+		if !arrow.TypeEqual(arr.DataType(), dtype) {
+			return nil, false, fmt.Errorf("arrow/tensor: column %q of type %v, want %v", cols[i], arr.DataType(), dtype)
+		}
+		if arr.NullN() > 0 {
+			if cfg.fill == nil {
+				return nil, false, fmt.Errorf("arrow/tensor: column %q has null values", cols[i])
+			}
+			nulls = true
+		}
+	}
+
+	var (
+		nrows = int64(rec.NumRows())
+		ncols = int64(len(arrs))
+		shape = []int64{nrows, ncols}
+	)
+
+	if ncols == 1 && !nulls {
+		return New(arrs[0].Data(), shape, nil, nil), false, nil
+	}
+
+	bw := byteWidth(dtype)
+	var fill []byte
+	if nulls {
+		fill, err = fillBytes(dtype, cfg.fill)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	buf := memory.NewResizableBuffer(mem)
+	defer buf.Release()
+	buf.Resize(int(nrows * ncols * bw))
+
+	out := buf.Bytes()
+	for j, arr := range arrs {
+		data := arr.Data()
+		var in []byte
+		if vals := data.Buffers()[1]; vals != nil {
+			in = vals.Bytes()[int64(data.Offset())*bw:]
+		}
+		for i := int64(0); i < nrows; i++ {
+			dst := out[(i*ncols+int64(j))*bw:][:bw]
+			if arr.IsNull(int(i)) {
+				copy(dst, fill)
+				continue
+			}
+			copy(dst, in[i*bw:(i+1)*bw])
+		}
+	}
+
+	data := array.NewData(dtype, int(nrows*ncols), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+
+	return New(data, shape, nil, nil), true, nil
+}
+
+// ToRecord returns a record holding the columns of the 2-dim tensor t, or
+// the single column of the 1-dim tensor t, with the given field names.
+//
+// The columns share the memory of the tensor when they are contiguous, as
+// for a 1-dim or a column-major tensor. Otherwise, the values are copied
+// into memory allocated from mem and copied is true.
+//
+// The returned record must be Release()'d after use.
+func ToRecord(t Interface, fieldNames []string, mem memory.Allocator) (rec array.Record, copied bool, err error) {
+	var (
+		dtype   = t.DataType()
+		shape   = t.Shape()
+		strides = t.Strides()
+		bw      = byteWidth(dtype)
+	)
+
+	switch len(shape) {
+	case 1:
+		shape = []int64{shape[0], 1}
+		strides = []int64{strides[0], 0}
+	case 2:
+	default:
+		return nil, false, fmt.Errorf("arrow/tensor: invalid tensor of %d dimensions, want 1 or 2", len(shape))
+	}
+
+	var (
+		nrows = shape[0]
+		ncols = shape[1]
+	)
+	if int64(len(fieldNames)) != ncols {
+		return nil, false, fmt.Errorf("arrow/tensor: got %d field names for %d columns", len(fieldNames), ncols)
+	}
+
+	// a column may be sliced out of the tensor if its elements are
+	// contiguous and aligned on the elements of the tensor.
+	contiguous := (nrows <= 1 || strides[0] == bw) && strides[1]%bw == 0
+
+	data := t.Data()
+	var in []byte
+	if vals := data.Buffers()[1]; vals != nil {
+		in = vals.Bytes()[int64(data.Offset())*bw:]
+	}
+
+	fields := make([]arrow.Field, ncols)
+	cols := make([]array.Interface, ncols)
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+
+	for j := range cols {
+		fields[j] = arrow.Field{Name: fieldNames[j], Type: dtype}
+
+		var col *array.Data
+		switch {
+		case contiguous:
+			offset := data.Offset() + int(int64(j)*strides[1]/bw)
+			col = array.NewData(dtype, int(nrows), []*memory.Buffer{nil, data.Buffers()[1]}, nil, 0, offset)
+		default:
+			buf := memory.NewResizableBuffer(mem)
+			buf.Resize(int(nrows * bw))
+			out := buf.Bytes()
+			for i := int64(0); i < nrows; i++ {
+				from := i*strides[0] + int64(j)*strides[1]
+				copy(out[i*bw:(i+1)*bw], in[from:from+bw])
+			}
+			col = array.NewData(dtype, int(nrows), []*memory.Buffer{nil, buf}, nil, 0, 0)
+			buf.Release()
+		}
+		cols[j] = array.MakeFromData(col)
+		col.Release()
+	}
+
+	schema := arrow.NewSchema(fields, nil)
+	return array.NewRecord(schema, cols, nrows), !contiguous, nil
+}
+
+// isTensorType returns whether a tensor may hold elements of type dtype.
+func isTensorType(dtype arrow.DataType) bool {
+	switch dtype.(type) {
+	case *arrow.BooleanType, *arrow.FixedSizeBinaryType:
+		return false
+	case arrow.FixedWidthDataType:
+		return true
+	}
+	return false
+}
+
+// fillBytes returns the in-memory representation of v, as an element of
+// type dtype.
+func fillBytes(dtype arrow.DataType, v interface{}) ([]byte, error) {
+	bw := byteWidth(dtype)
+	zero := array.NewData(dtype, 1, []*memory.Buffer{nil, memory.NewBufferBytes(make([]byte, bw))}, nil, 0, 0)
+	defer zero.Release()
+
+	tsr := New(zero, []int64{1}, nil, nil)
+	defer tsr.Release()
+
+	typ := reflect.TypeOf(Value(tsr, []int64{0}))
+	if reflect.TypeOf(v) != typ {
+		return nil, fmt.Errorf("arrow/tensor: fill value of type %T, want %v", v, typ)
+	}
+
+	out := make([]byte, bw)
+	reflect.NewAt(typ, unsafe.Pointer(&out[0])).Elem().Set(reflect.ValueOf(v))
+	return out, nil
+}
@@ -0,0 +1,258 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/tensor"
+)
+
+func makeRecord(t *testing.T, mem memory.Allocator, cols [][]float64, valid [][]bool) array.Record {
+	t.Helper()
+
+	var fields []arrow.Field
+	for i := range cols {
+		fields = append(fields, arrow.Field{Name: string(rune('a' + i)), Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	bld := array.NewRecordBuilder(mem, schema)
+	defer bld.Release()
+
+	for i, col := range cols {
+		var v []bool
+		if valid != nil {
+			v = valid[i]
+		}
+		bld.Field(i).(*array.Float64Builder).AppendValues(col, v)
+	}
+	return bld.NewRecord()
+}
+
+func TestFromRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := makeRecord(t, mem, [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, nil)
+	defer rec.Release()
+
+	tsr, copied, err := tensor.FromRecord(rec, []string{"c", "a"}, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tsr.Release()
+
+	if !copied {
+		t.Fatalf("multi-column tensor should be copied")
+	}
+	if got, want := tsr.Shape(), []int64{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+	if !tsr.IsRowMajor() {
+		t.Fatalf("tensor should be row-major: strides=%v", tsr.Strides())
+	}
+	if got, want := tsr.(*tensor.Float64).Float64Values(), []float64{7, 1, 8, 2, 9, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	out, copied, err := tensor.ToRecord(tsr, []string{"c", "a"}, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Release()
+
+	if !copied {
+		t.Fatalf("columns of a row-major tensor should be copied")
+	}
+	for i, name := range []string{"c", "a"} {
+		want := rec.Column(rec.Schema().FieldIndices(name)[0])
+		if !array.ArrayEqual(out.Column(i), want) {
+			t.Fatalf("invalid column %q: got=%v, want=%v", name, out.Column(i), want)
+		}
+	}
+
+	all, _, err := tensor.FromRecord(rec, nil, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer all.Release()
+
+	if got, want := all.Shape(), []int64{3, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+}
+
+func TestFromRecordZeroCopy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := makeRecord(t, mem, [][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}}, nil)
+	defer rec.Release()
+
+	sub := rec.NewSlice(1, 4)
+	defer sub.Release()
+
+	tsr, copied, err := tensor.FromRecord(sub, []string{"b"}, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tsr.Release()
+
+	if copied {
+		t.Fatalf("single column tensor should not be copied")
+	}
+	if got, want := tsr.Data().Buffers()[1], sub.Column(1).Data().Buffers()[1]; got != want {
+		t.Fatalf("tensor should share the column buffer")
+	}
+	if got, want := tsr.(*tensor.Float64).Float64Values(), []float64{6, 7, 8}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	out, copied, err := tensor.ToRecord(tsr, []string{"b"}, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Release()
+
+	if copied {
+		t.Fatalf("single column record should not be copied")
+	}
+	if got, want := out.Column(0).Data().Buffers()[1], tsr.Data().Buffers()[1]; got != want {
+		t.Fatalf("column should share the tensor buffer")
+	}
+	if !array.ArrayEqual(out.Column(0), sub.Column(1)) {
+		t.Fatalf("invalid column: got=%v, want=%v", out.Column(0), sub.Column(1))
+	}
+}
+
+func TestToRecordColMajor(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewFloat64Builder(mem)
+	defer bld.Release()
+	bld.AppendValues([]float64{1, 2, 3, 4, 5, 6}, nil)
+
+	arr := bld.NewFloat64Array()
+	defer arr.Release()
+
+	tsr := tensor.New(arr.Data(), []int64{3, 2}, []int64{8, 24}, nil)
+	defer tsr.Release()
+
+	rec, copied, err := tensor.ToRecord(tsr, []string{"x", "y"}, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	if copied {
+		t.Fatalf("columns of a col-major tensor should not be copied")
+	}
+	for i, want := range [][]float64{{1, 2, 3}, {4, 5, 6}} {
+		col := rec.Column(i).(*array.Float64)
+		if got := col.Float64Values(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid column %d: got=%v, want=%v", i, got, want)
+		}
+		if col.Data().Buffers()[1] != arr.Data().Buffers()[1] {
+			t.Fatalf("column %d should share the tensor buffer", i)
+		}
+	}
+
+	vec := tensor.New(arr.Data(), []int64{6}, nil, nil)
+	defer vec.Release()
+
+	one, copied, err := tensor.ToRecord(vec, []string{"v"}, mem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer one.Release()
+
+	if copied {
+		t.Fatalf("column of a 1-dim tensor should not be copied")
+	}
+	if !array.ArrayEqual(one.Column(0), arr) {
+		t.Fatalf("invalid column: got=%v, want=%v", one.Column(0), arr)
+	}
+}
+
+func TestFromRecordNulls(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := makeRecord(t, mem, [][]float64{{1, 2, 3}}, [][]bool{{true, false, true}})
+	defer rec.Release()
+
+	_, _, err := tensor.FromRecord(rec, nil, mem)
+	if err == nil {
+		t.Fatalf("expected an error for null values")
+	}
+
+	_, _, err = tensor.FromRecord(rec, nil, mem, tensor.WithFillValue(int64(0)))
+	if err == nil {
+		t.Fatalf("expected an error for a fill value of the wrong type")
+	}
+
+	tsr, copied, err := tensor.FromRecord(rec, nil, mem, tensor.WithFillValue(-1.0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tsr.Release()
+
+	if !copied {
+		t.Fatalf("tensor with filled nulls should be copied")
+	}
+	if got, want := tsr.(*tensor.Float64).Float64Values(), []float64{1, -1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+}
+
+func TestFromRecordInvalid(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "str", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	bld := array.NewRecordBuilder(mem, schema)
+	defer bld.Release()
+	bld.Field(0).(*array.Float64Builder).Append(1)
+	bld.Field(1).(*array.Int32Builder).Append(2)
+	bld.Field(2).(*array.StringBuilder).Append("3")
+
+	rec := bld.NewRecord()
+	defer rec.Release()
+
+	for _, cols := range [][]string{
+		{},
+		{"missing"},
+		{"f64", "i32"},
+		{"str"},
+	} {
+		_, _, err := tensor.FromRecord(rec, cols, mem)
+		if err == nil {
+			t.Fatalf("cols=%q: expected an error", cols)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"math"
+
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// NewSlice returns a view of t restricted to the indices [i, j) of the
+// given axis. The view shares the memory of t and keeps its strides, so
+// that slices of any axis but the first of a row-major tensor, or the last
+// of a column-major one, are not contiguous.
+//
+// NewSlice panics if the axis or the slice indices are out of range.
+//
+// The returned tensor must be Release()'d after use.
+func NewSlice(t Interface, axis int, i, j int64) Interface {
+	shape := append([]int64(nil), t.Shape()...)
+	if axis < 0 || axis >= len(shape) || i < 0 || j > shape[axis] || i > j {
+		panic("arrow/tensor: index out of range")
+	}
+
+	var (
+		bw      = byteWidth(t.DataType())
+		strides = append([]int64(nil), t.Strides()...)
+	)
+	shape[axis] = j - i
+	n, ok := span(bw, shape, strides)
+	if !ok {
+		panic("arrow/tensor: invalid shape and strides")
+	}
+	var beg int64
+	if n > 0 {
+		beg = i * strides[axis] / bw
+	}
+
+	data := array.NewSliceData(t.Data(), beg, beg+n)
+	defer data.Release()
+
+	var names []string
+	if t.DimNames() != nil {
+		names = append(names, t.DimNames()...)
+	}
+	return New(data, shape, strides, names)
+}
+
+// numElements returns the number of elements of a tensor of the given
+// shape. It reports false if a dimension is negative or if the number
+// overflows.
+func numElements(shape []int64) (int64, bool) {
+	n := int64(1)
+	for _, v := range shape {
+		var ok bool
+		if v < 0 {
+			return 0, false
+		}
+		if n, ok = mulInt64(n, v); !ok {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// span returns the number of elements of bw bytes from the first to the
+// last element of a tensor of the given shape and strides, in bytes, or 0
+// if the tensor is empty. It reports false if a dimension or a stride is
+// negative, if a stride is not a multiple of bw or if the span overflows.
+func span(bw int64, shape, strides []int64) (int64, bool) {
+	if bw <= 0 || len(strides) != len(shape) {
+		return 0, false
+	}
+	n, ok := numElements(shape)
+	if !ok {
+		return 0, false
+	}
+	for _, v := range strides {
+		if v < 0 || v%bw != 0 {
+			return 0, false
+		}
+	}
+	if n == 0 {
+		return 0, true
+	}
+
+	// the offset, in bytes, of the last element.
+	var last int64
+	for i, v := range shape {
+		off, ok := mulInt64(v-1, strides[i])
+		if !ok || off > math.MaxInt64-last {
+			return 0, false
+		}
+		last += off
+	}
+	return last/bw + 1, true
+}
+
+// mulInt64 returns a*b for non-negative a and b, reporting false if the
+// product overflows.
+func mulInt64(a, b int64) (int64, bool) {
+	if a != 0 && b > math.MaxInt64/a {
+		return 0, false
+	}
+	return a * b, true
+}
@@ -116,31 +116,161 @@ func (tb *tensorBase) IsContiguous() bool {
 	return tb.IsRowMajor() || tb.IsColMajor()
 }
 
+// IsRowMajor returns whether the elements of the tensor are contiguous,
+// with the last dimension varying fastest. Dimensions of size 1 may have
+// any stride.
 func (tb *tensorBase) IsRowMajor() bool {
-	strides := rowMajorStrides(tb.dtype, tb.shape)
-	return equalInt64s(strides, tb.strides)
+	return tb.isContiguous(RowMajor)
 }
 
+// IsColMajor returns whether the elements of the tensor are contiguous,
+// with the first dimension varying fastest. Dimensions of size 1 may have
+// any stride.
 func (tb *tensorBase) IsColMajor() bool {
-	strides := colMajorStrides(tb.dtype, tb.shape)
-	return equalInt64s(strides, tb.strides)
+	return tb.isContiguous(ColMajor)
 }
 
+func (tb *tensorBase) isContiguous(layout Layout) bool {
+	if len(tb.strides) != len(tb.shape) {
+		return false
+	}
+	for _, v := range tb.shape {
+		if v == 0 {
+			return true
+		}
+	}
+
+	want := tb.bw
+	for k := range tb.shape {
+		i := k
+		if layout == RowMajor {
+			i = len(tb.shape) - 1 - k
+		}
+		if tb.shape[i] == 1 {
+			continue
+		}
+		if tb.strides[i] != want {
+			return false
+		}
+		want *= tb.shape[i]
+	}
+	return true
+}
+
+// offset returns the index in the values of the tensor of the element at
+// index, panicking if index is out of range.
 func (tb *tensorBase) offset(index []int64) int64 {
+	if len(index) != len(tb.shape) {
+		panic(fmt.Errorf("arrow/tensor: index %v of %d dimensions, want %d", index, len(index), len(tb.shape)))
+	}
 	var offset int64
 	for i, v := range index {
+		if v < 0 || v >= tb.shape[i] {
+			panic(fmt.Errorf("arrow/tensor: index %v out of range of shape %v", index, tb.shape))
+		}
 		offset += v * tb.strides[i]
 	}
 	return offset / tb.bw
 }
 
+// Value returns the element of t at index, as an interface{} holding a
+// value of the type returned by the Value method of t.
+//
+// Value panics if index is out of range.
+func Value(t Interface, index []int64) interface{} {
+	return t.(interface{ value([]int64) interface{} }).value(index)
+}
+
 // New returns a new n-dim array from the provided backing data and the shape and strides.
 // If strides is nil, row-major strides will be inferred.
 // If names is nil, a slice of empty strings will be created.
 //
-// New panics if the backing data is not a numerical type.
+// New panics if the backing data is not a fixed width type of a whole
+// number of bytes.
 func New(data *array.Data, shape, strides []int64, names []string) Interface {
+	tsr := newOf(data, shape, strides, names)
+	if tsr == nil {
+		panic(fmt.Errorf("arrow/tensor: invalid data type %s", data.DataType().Name()))
+	}
+	return tsr
+}
+
+// FromArray returns a new n-dim array of the given shape and strides
+// holding the values of arr, whose memory is shared.
+// If strides is nil, row-major strides will be inferred and the number of
+// elements of the shape must be the length of arr. Otherwise, the elements
+// addressed by the strides, given in bytes, must lie within arr.
+// If names is not nil, it must hold the name of each dimension.
+//
+// FromArray returns an error if arr holds null values or is not of a fixed
+// width type of a whole number of bytes.
+//
+// The returned tensor must be Release()'d after use.
+func FromArray(arr array.Interface, shape, strides []int64, names []string) (Interface, error) {
+	dt := arr.DataType()
+	if !isSupported(dt) {
+		return nil, fmt.Errorf("arrow/tensor: invalid data type %s", dt.Name())
+	}
+	if arr.NullN() > 0 {
+		return nil, fmt.Errorf("arrow/tensor: array holds %d null values", arr.NullN())
+	}
+	if names != nil && len(names) != len(shape) {
+		return nil, fmt.Errorf("arrow/tensor: %d names for %d dimensions", len(names), len(shape))
+	}
+
+	bw := byteWidth(dt)
+	if strides == nil {
+		n, ok := numElements(shape)
+		if !ok {
+			return nil, fmt.Errorf("arrow/tensor: invalid shape %v", shape)
+		}
+		if n != int64(arr.Len()) {
+			return nil, fmt.Errorf("arrow/tensor: shape %v of %d elements does not match the array length %d", shape, n, arr.Len())
+		}
+		if len(shape) > 0 {
+			strides = rowMajorStrides(dt, shape)
+		}
+	} else {
+		if len(strides) != len(shape) {
+			return nil, fmt.Errorf("arrow/tensor: %d strides for %d dimensions", len(strides), len(shape))
+		}
+		n, ok := span(bw, shape, strides)
+		if !ok {
+			return nil, fmt.Errorf("arrow/tensor: invalid shape %v and strides %v", shape, strides)
+		}
+		if n > int64(arr.Len()) {
+			return nil, fmt.Errorf("arrow/tensor: shape %v and strides %v span %d elements, beyond the array length %d", shape, strides, n, arr.Len())
+		}
+	}
+
+	return newOf(arr.Data(), append([]int64(nil), shape...), append([]int64(nil), strides...), names), nil
+}
+
+// isSupported returns whether tensors may hold values of type dt.
+func isSupported(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+		arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64,
+		arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64,
+		arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64,
+		arrow.TIMESTAMP, arrow.DURATION, arrow.DECIMAL:
+		return true
+	case arrow.INTERVAL:
+		switch dt.(type) {
+		case *arrow.MonthIntervalType, *arrow.DayTimeIntervalType:
+			return true
+		}
+	}
+	return false
+}
+
+// newOf returns a new n-dim array of the type of data, or nil if the type
+// is not supported.
+func newOf(data *array.Data, shape, strides []int64, names []string) Interface {
 	dt := data.DataType()
+	if !isSupported(dt) {
+		return nil
+	}
 	switch dt.ID() {
 	case arrow.INT8:
 		return NewInt8(data, shape, strides, names)
@@ -166,16 +296,30 @@ func New(data *array.Data, shape, strides []int64, names []string) Interface {
 		return NewDate32(data, shape, strides, names)
 	case arrow.DATE64:
 		return NewDate64(data, shape, strides, names)
-	default:
-		panic(fmt.Errorf("arrow/tensor: invalid data type %s", dt.Name()))
+	case arrow.FLOAT16:
+		return NewFloat16(data, shape, strides, names)
+	case arrow.TIME32:
+		return NewTime32(data, shape, strides, names)
+	case arrow.TIME64:
+		return NewTime64(data, shape, strides, names)
+	case arrow.TIMESTAMP:
+		return NewTimestamp(data, shape, strides, names)
+	case arrow.DURATION:
+		return NewDuration(data, shape, strides, names)
+	case arrow.DECIMAL:
+		return NewDecimal128(data, shape, strides, names)
 	}
+	if _, ok := dt.(*arrow.MonthIntervalType); ok {
+		return NewMonthInterval(data, shape, strides, names)
+	}
+	return NewDayTimeInterval(data, shape, strides, names)
 }
 
 func newTensor(dtype arrow.DataType, data *array.Data, shape, strides []int64, names []string) *tensorBase {
 	tb := tensorBase{
 		refCount: 1,
 		dtype:    dtype,
-		bw:       int64(dtype.(arrow.FixedWidthDataType).BitWidth()) / 8,
+		bw:       byteWidth(dtype),
 		data:     data,
 		shape:    shape,
 		strides:  strides,
@@ -189,16 +333,23 @@ func newTensor(dtype arrow.DataType, data *array.Data, shape, strides []int64, n
 	return &tb
 }
 
+// byteWidth returns the number of bytes of an element of type dtype.
+func byteWidth(dtype arrow.DataType) int64 {
+	if dtype.ID() == arrow.DECIMAL {
+		return int64(arrow.Decimal128SizeBytes)
+	}
+	return int64(dtype.(arrow.FixedWidthDataType).BitWidth()) / 8
+}
+
 func rowMajorStrides(dtype arrow.DataType, shape []int64) []int64 {
-	dt := dtype.(arrow.FixedWidthDataType)
-	rem := int64(dt.BitWidth() / 8)
+	rem := byteWidth(dtype)
 	for _, v := range shape {
 		rem *= v
 	}
 
 	if rem == 0 {
 		strides := make([]int64, len(shape))
-		rem := int64(dt.BitWidth() / 8)
+		rem := byteWidth(dtype)
 		for i := range strides {
 			strides[i] = rem
 		}
@@ -214,8 +365,7 @@ func rowMajorStrides(dtype arrow.DataType, shape []int64) []int64 {
 }
 
 func colMajorStrides(dtype arrow.DataType, shape []int64) []int64 {
-	dt := dtype.(arrow.FixedWidthDataType)
-	total := int64(dt.BitWidth() / 8)
+	total := byteWidth(dtype)
 	for _, v := range shape {
 		if v == 0 {
 			strides := make([]int64, len(shape))
@@ -233,15 +383,3 @@ func colMajorStrides(dtype arrow.DataType, shape []int64) []int64 {
 	}
 	return strides
 }
-
-func equalInt64s(a, b []int64) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
-	}
-	return true
-}
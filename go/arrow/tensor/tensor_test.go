@@ -18,11 +18,14 @@ package tensor_test
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/float16"
 	"github.com/apache/arrow/go/arrow/memory"
 	"github.com/apache/arrow/go/arrow/tensor"
 )
@@ -164,3 +167,434 @@ func TestInvalidTensor(t *testing.T) {
 	})
 
 }
+
+func TestFixedWidthTensors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name  string
+		arr   func() array.Interface
+		want  []interface{}
+		check func(tsr tensor.Interface) bool
+	}{
+		{
+			name: "float16",
+			arr: func() array.Interface {
+				bld := array.NewFloat16Builder(mem)
+				defer bld.Release()
+				bld.AppendValues([]float16.Num{float16.New(1), float16.New(2), float16.New(3), float16.New(4)}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{float16.New(1), float16.New(2), float16.New(3), float16.New(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.Float16)
+				return ok
+			},
+		},
+		{
+			name: "time32",
+			arr: func() array.Interface {
+				bld := array.NewTime32Builder(mem, arrow.FixedWidthTypes.Time32ms.(*arrow.Time32Type))
+				defer bld.Release()
+				bld.AppendValues([]arrow.Time32{1, 2, 3, 4}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{arrow.Time32(1), arrow.Time32(2), arrow.Time32(3), arrow.Time32(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.Time32)
+				return ok
+			},
+		},
+		{
+			name: "time64",
+			arr: func() array.Interface {
+				bld := array.NewTime64Builder(mem, arrow.FixedWidthTypes.Time64us.(*arrow.Time64Type))
+				defer bld.Release()
+				bld.AppendValues([]arrow.Time64{1, 2, 3, 4}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{arrow.Time64(1), arrow.Time64(2), arrow.Time64(3), arrow.Time64(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.Time64)
+				return ok
+			},
+		},
+		{
+			name: "timestamp",
+			arr: func() array.Interface {
+				bld := array.NewTimestampBuilder(mem, arrow.FixedWidthTypes.Timestamp_s.(*arrow.TimestampType))
+				defer bld.Release()
+				bld.AppendValues([]arrow.Timestamp{1, 2, 3, 4}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{arrow.Timestamp(1), arrow.Timestamp(2), arrow.Timestamp(3), arrow.Timestamp(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.Timestamp)
+				return ok
+			},
+		},
+		{
+			name: "duration",
+			arr: func() array.Interface {
+				bld := array.NewDurationBuilder(mem, arrow.FixedWidthTypes.Duration_ns.(*arrow.DurationType))
+				defer bld.Release()
+				bld.AppendValues([]arrow.Duration{1, 2, 3, 4}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{arrow.Duration(1), arrow.Duration(2), arrow.Duration(3), arrow.Duration(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.Duration)
+				return ok
+			},
+		},
+		{
+			name: "decimal128",
+			arr: func() array.Interface {
+				bld := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: 10, Scale: 1})
+				defer bld.Release()
+				bld.AppendValues([]decimal128.Num{decimal128.FromI64(1), decimal128.FromI64(-2), decimal128.New(3, 0), decimal128.FromI64(4)}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{decimal128.FromI64(1), decimal128.FromI64(-2), decimal128.New(3, 0), decimal128.FromI64(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.Decimal128)
+				return ok
+			},
+		},
+		{
+			name: "month-interval",
+			arr: func() array.Interface {
+				bld := array.NewMonthIntervalBuilder(mem)
+				defer bld.Release()
+				bld.AppendValues([]arrow.MonthInterval{1, 2, 3, 4}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{arrow.MonthInterval(1), arrow.MonthInterval(2), arrow.MonthInterval(3), arrow.MonthInterval(4)},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.MonthInterval)
+				return ok
+			},
+		},
+		{
+			name: "day-time-interval",
+			arr: func() array.Interface {
+				bld := array.NewDayTimeIntervalBuilder(mem)
+				defer bld.Release()
+				bld.AppendValues([]arrow.DayTimeInterval{{Days: 1, Milliseconds: 1}, {Days: 2}, {Milliseconds: 3}, {Days: 4, Milliseconds: 4}}, nil)
+				return bld.NewArray()
+			},
+			want: []interface{}{
+				arrow.DayTimeInterval{Days: 1, Milliseconds: 1}, arrow.DayTimeInterval{Days: 2},
+				arrow.DayTimeInterval{Milliseconds: 3}, arrow.DayTimeInterval{Days: 4, Milliseconds: 4},
+			},
+			check: func(tsr tensor.Interface) bool {
+				_, ok := tsr.(*tensor.DayTimeInterval)
+				return ok
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.arr()
+			defer arr.Release()
+
+			tsr := tensor.New(arr.Data(), []int64{2, 2}, nil, []string{"x", "y"})
+			defer tsr.Release()
+
+			if !tc.check(tsr) {
+				t.Fatalf("invalid tensor type %T", tsr)
+			}
+			if got, want := tsr.DataType(), arr.DataType(); !arrow.TypeEqual(got, want) {
+				t.Fatalf("invalid data type: got=%v, want=%v", got, want)
+			}
+			if !tsr.IsRowMajor() {
+				t.Fatalf("tensor should be row-major: strides=%v", tsr.Strides())
+			}
+
+			for i, idx := range [][]int64{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+				if got, want := tensor.Value(tsr, idx), tc.want[i]; got != want {
+					t.Fatalf("tsr[%v]: got=%v, want=%v", idx, got, want)
+				}
+			}
+
+			col := tensor.ConvertLayout(tsr, tensor.ColMajor, mem)
+			defer col.Release()
+
+			if !col.IsColMajor() {
+				t.Fatalf("tensor should be col-major: strides=%v", col.Strides())
+			}
+			for _, idx := range [][]int64{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+				if got, want := tensor.Value(col, idx), tensor.Value(tsr, idx); got != want {
+					t.Fatalf("col[%v]: got=%v, want=%v", idx, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertLayout(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewInt32Builder(mem)
+	defer bld.Release()
+	for i := 0; i < 24; i++ {
+		bld.Append(int32(i))
+	}
+
+	arr := bld.NewInt32Array()
+	defer arr.Release()
+
+	sub := array.NewSlice(arr, 2, 24)
+	defer sub.Release()
+
+	// every other element of a 2x6 row-major tensor, starting at 2.
+	tsr := tensor.New(sub.Data(), []int64{2, 3}, []int64{24, 8}, nil)
+	defer tsr.Release()
+
+	if tsr.IsContiguous() {
+		t.Fatalf("strided tensor should not be contiguous")
+	}
+
+	for i := int64(0); i < 2; i++ {
+		for j := int64(0); j < 3; j++ {
+			idx := []int64{i, j}
+			if got, want := tsr.(*tensor.Int32).Value(idx), int32(2+6*i+2*j); got != want {
+				t.Fatalf("tsr[%v]: got=%v, want=%v", idx, got, want)
+			}
+		}
+	}
+
+	for _, tc := range []struct {
+		layout tensor.Layout
+		want   []int32
+	}{
+		{tensor.RowMajor, []int32{2, 4, 6, 8, 10, 12}},
+		{tensor.ColMajor, []int32{2, 8, 4, 10, 6, 12}},
+	} {
+		t.Run(fmt.Sprintf("layout=%d", tc.layout), func(t *testing.T) {
+			out := tensor.ConvertLayout(tsr, tc.layout, mem)
+			defer out.Release()
+
+			if !out.IsContiguous() {
+				t.Fatalf("converted tensor should be contiguous: strides=%v", out.Strides())
+			}
+			if got := out.(*tensor.Int32).Int32Values(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values: got=%v, want=%v", got, tc.want)
+			}
+			for i := int64(0); i < 2; i++ {
+				for j := int64(0); j < 3; j++ {
+					idx := []int64{i, j}
+					if got, want := tensor.Value(out, idx), tensor.Value(tsr, idx); got != want {
+						t.Fatalf("out[%v]: got=%v, want=%v", idx, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTensorLayout(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewInt32Builder(mem)
+	defer bld.Release()
+	bld.AppendValues([]int32{1, 2, 3}, nil)
+
+	arr := bld.NewInt32Array()
+	defer arr.Release()
+
+	// the stride of a dimension of size 1 does not matter.
+	tsr := tensor.New(arr.Data(), []int64{1, 3}, []int64{100, 4}, nil)
+	defer tsr.Release()
+
+	if !tsr.IsRowMajor() {
+		t.Fatalf("tensor should be row-major: strides=%v", tsr.Strides())
+	}
+
+	t.Run("out-of-range", func(t *testing.T) {
+		defer func() {
+			if e := recover(); e == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		tensor.Value(tsr, []int64{1, 0})
+	})
+}
+
+func TestFromArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewInt16Builder(mem)
+	defer bld.Release()
+	bld.AppendValues([]int16{1, 2, 3, 4, 5, 6}, nil)
+	arr := bld.NewInt16Array()
+	defer arr.Release()
+
+	tsr, err := tensor.FromArray(arr, []int64{2, 3}, nil, []string{"x", "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tsr.Release()
+	if got, want := tsr.Strides(), []int64{6, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid strides: got=%v, want=%v", got, want)
+	}
+	if got, want := tensor.Value(tsr, []int64{1, 2}), int16(6); got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+
+	// the transpose of the tensor, with explicit strides.
+	tr, err := tensor.FromArray(arr, []int64{3, 2}, []int64{2, 6}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Release()
+	if !tr.IsColMajor() || tensor.Value(tr, []int64{2, 0}) != int16(3) {
+		t.Fatalf("invalid transpose: strides=%v", tr.Strides())
+	}
+
+	bld.AppendValues([]int16{1, 2}, []bool{true, false})
+	nulls := bld.NewInt16Array()
+	defer nulls.Release()
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.Append("a")
+	strs := sb.NewStringArray()
+	defer strs.Release()
+
+	for _, tc := range []struct {
+		name    string
+		arr     array.Interface
+		shape   []int64
+		strides []int64
+		names   []string
+	}{
+		{name: "length", arr: arr, shape: []int64{2, 2}},
+		{name: "negative-dim", arr: arr, shape: []int64{-2, -3}},
+		{name: "overflow", arr: arr, shape: []int64{1 << 32, 1 << 32, 0, 4}},
+		{name: "strides-length", arr: arr, shape: []int64{2, 3}, strides: []int64{2}},
+		{name: "strides-range", arr: arr, shape: []int64{2, 3}, strides: []int64{8, 2}},
+		{name: "strides-width", arr: arr, shape: []int64{2, 3}, strides: []int64{6, 1}},
+		{name: "strides-overflow", arr: arr, shape: []int64{3, 3}, strides: []int64{math.MaxInt64 - 1, math.MaxInt64 - 1}},
+		{name: "negative-stride", arr: arr, shape: []int64{2, 3}, strides: []int64{-6, 2}},
+		{name: "names", arr: arr, shape: []int64{2, 3}, names: []string{"x"}},
+		{name: "nulls", arr: nulls, shape: []int64{2}},
+		{name: "type", arr: strs, shape: []int64{1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tsr, err := tensor.FromArray(tc.arr, tc.shape, tc.strides, tc.names)
+			if err == nil {
+				tsr.Release()
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestNewSlice(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	const (
+		n0, n1, n2 = 4, 5, 6
+	)
+	bld := array.NewInt32Builder(mem)
+	defer bld.Release()
+	for i := 0; i < n0*n1*n2; i++ {
+		bld.Append(int32(i))
+	}
+	arr := bld.NewInt32Array()
+	defer arr.Release()
+
+	// naive returns the element at index of the row-major n0xn1xn2 tensor.
+	naive := func(index []int64) int32 {
+		return int32((index[0]*n1+index[1])*n2 + index[2])
+	}
+
+	for _, layout := range []tensor.Layout{tensor.RowMajor, tensor.ColMajor} {
+		t.Run(fmt.Sprintf("layout=%d", layout), func(t *testing.T) {
+			base, err := tensor.FromArray(arr, []int64{n0, n1, n2}, nil, []string{"a", "b", "c"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer base.Release()
+			src := tensor.ConvertLayout(base, layout, mem)
+			defer src.Release()
+
+			// slice every axis, the view of the middle one being not
+			// contiguous.
+			v1 := tensor.NewSlice(src, 1, 1, 4)
+			defer v1.Release()
+			v2 := tensor.NewSlice(v1, 2, 2, 5)
+			defer v2.Release()
+			view := tensor.NewSlice(v2, 0, 1, 3)
+			defer view.Release()
+
+			if got, want := view.Shape(), []int64{2, 3, 3}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+			if !reflect.DeepEqual(view.Strides(), src.Strides()) {
+				t.Fatalf("invalid strides: got=%v, want=%v", view.Strides(), src.Strides())
+			}
+			if view.IsContiguous() || v1.IsContiguous() {
+				t.Fatalf("views should not be contiguous")
+			}
+			if got, want := view.DimNames(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid names: got=%v, want=%v", got, want)
+			}
+
+			copied := tensor.ConvertLayout(view, tensor.RowMajor, mem)
+			defer copied.Release()
+
+			var vals []int32
+			for i := int64(0); i < 2; i++ {
+				for j := int64(0); j < 3; j++ {
+					for k := int64(0); k < 3; k++ {
+						want := naive([]int64{i + 1, j + 1, k + 2})
+						if got := view.(*tensor.Int32).Value([]int64{i, j, k}); got != want {
+							t.Fatalf("view[%d, %d, %d]: got=%d, want=%d", i, j, k, got, want)
+						}
+						vals = append(vals, want)
+					}
+				}
+			}
+			if got := copied.(*tensor.Int32).Int32Values(); !reflect.DeepEqual(got, vals) {
+				t.Fatalf("invalid copy:\ngot= %v\nwant=%v", got, vals)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		tsr, err := tensor.FromArray(arr, []int64{n0 * n1, n2}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tsr.Release()
+		view := tensor.NewSlice(tsr, 0, n0*n1, n0*n1)
+		defer view.Release()
+		if view.Len() != 0 || view.Data().Len() != 0 {
+			t.Fatalf("invalid empty view: len=%d", view.Len())
+		}
+	})
+
+	t.Run("out-of-range", func(t *testing.T) {
+		tsr, err := tensor.FromArray(arr, []int64{n0 * n1 * n2}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tsr.Release()
+		for _, tc := range [][3]int64{{1, 0, 1}, {0, 2, 1}, {0, -1, 1}, {0, 0, n0*n1*n2 + 1}} {
+			func() {
+				defer func() {
+					if e := recover(); e == nil {
+						t.Fatalf("slice %v: expected a panic", tc)
+					}
+				}()
+				tensor.NewSlice(tsr, int(tc[0]), tc[1], tc[2])
+			}()
+		}
+	})
+}
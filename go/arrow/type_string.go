@@ -39,11 +39,15 @@ func _() {
 	_ = x[EXTENSION-28]
 	_ = x[FIXED_SIZE_LIST-29]
 	_ = x[DURATION-30]
+	_ = x[RUN_END_ENCODED-31]
+	_ = x[BINARY_VIEW-32]
+	_ = x[STRING_VIEW-33]
+	_ = x[DECIMAL256-34]
 }
 
-const _Type_name = "NULLBOOLUINT8INT8UINT16INT16UINT32INT32UINT64INT64FLOAT16FLOAT32FLOAT64STRINGBINARYFIXED_SIZE_BINARYDATE32DATE64TIMESTAMPTIME32TIME64INTERVALDECIMALLISTSTRUCTUNIONDICTIONARYMAPEXTENSIONFIXED_SIZE_LISTDURATION"
+const _Type_name = "NULLBOOLUINT8INT8UINT16INT16UINT32INT32UINT64INT64FLOAT16FLOAT32FLOAT64STRINGBINARYFIXED_SIZE_BINARYDATE32DATE64TIMESTAMPTIME32TIME64INTERVALDECIMALLISTSTRUCTUNIONDICTIONARYMAPEXTENSIONFIXED_SIZE_LISTDURATIONRUN_END_ENCODEDBINARY_VIEWSTRING_VIEWDECIMAL256"
 
-var _Type_index = [...]uint8{0, 4, 8, 13, 17, 23, 28, 34, 39, 45, 50, 57, 64, 71, 77, 83, 100, 106, 112, 121, 127, 133, 141, 148, 152, 158, 163, 173, 176, 185, 200, 208}
+var _Type_index = [...]uint8{0, 4, 8, 13, 17, 23, 28, 34, 39, 45, 50, 57, 64, 71, 77, 83, 100, 106, 112, 121, 127, 133, 141, 148, 152, 158, 163, 173, 176, 185, 200, 208, 223, 234, 245, 255}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+)
+
+// Traits describes how the values of a fixed width data type are laid out
+// in memory, for code that handles several types through one code path.
+type Traits struct {
+	// ByteWidth is the number of bytes used by a single value.
+	ByteWidth int
+	// GoType is the Go type of a single value, as returned by the value
+	// accessors of the corresponding array.
+	GoType reflect.Type
+	// Zero is the zero value of GoType.
+	Zero interface{}
+}
+
+// TypeTraits returns the traits of dt, and false if the values of dt do
+// not have a fixed byte width: this is the case of booleans, which are
+// bit-packed, and of variable length and nested types.
+//
+// Builders for any data type are created with array.NewBuilder.
+func TypeTraits(dt DataType) (Traits, bool) {
+	var zero interface{}
+	switch dt := dt.(type) {
+	case *Int8Type:
+		zero = int8(0)
+	case *Int16Type:
+		zero = int16(0)
+	case *Int32Type:
+		zero = int32(0)
+	case *Int64Type:
+		zero = int64(0)
+	case *Uint8Type:
+		zero = uint8(0)
+	case *Uint16Type:
+		zero = uint16(0)
+	case *Uint32Type:
+		zero = uint32(0)
+	case *Uint64Type:
+		zero = uint64(0)
+	case *Float16Type:
+		zero = float16.Num{}
+	case *Float32Type:
+		zero = float32(0)
+	case *Float64Type:
+		zero = float64(0)
+	case *Date32Type:
+		zero = Date32(0)
+	case *Date64Type:
+		zero = Date64(0)
+	case *Time32Type:
+		zero = Time32(0)
+	case *Time64Type:
+		zero = Time64(0)
+	case *TimestampType:
+		zero = Timestamp(0)
+	case *DurationType:
+		zero = Duration(0)
+	case *MonthIntervalType:
+		zero = MonthInterval(0)
+	case *DayTimeIntervalType:
+		zero = DayTimeInterval{}
+	case *Decimal128Type:
+		zero = decimal128.Num{}
+	case *Decimal256Type:
+		zero = decimal256.Num{}
+	case *FixedSizeBinaryType:
+		return Traits{
+			ByteWidth: dt.ByteWidth,
+			GoType:    reflect.TypeOf([]byte(nil)),
+			Zero:      make([]byte, dt.ByteWidth),
+		}, true
+	default:
+		return Traits{}, false
+	}
+
+	typ := reflect.TypeOf(zero)
+	return Traits{ByteWidth: int(typ.Size()), GoType: typ, Zero: zero}, true
+}
@@ -22,8 +22,10 @@ import (
 	"testing"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/decimal128"
 	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
 )
 
 func TestBooleanTraits(t *testing.T) {
@@ -199,3 +201,81 @@ func TestDayTimeIntervalTraits(t *testing.T) {
 		t.Fatalf("invalid values:\nv1=%v\nv2=%v\n", v1, v2)
 	}
 }
+
+func TestTypeTraits(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		dt    arrow.DataType
+		width int
+		zero  interface{}
+	}{
+		{arrow.PrimitiveTypes.Int8, 1, int8(0)},
+		{arrow.PrimitiveTypes.Int16, 2, int16(0)},
+		{arrow.PrimitiveTypes.Int32, 4, int32(0)},
+		{arrow.PrimitiveTypes.Int64, 8, int64(0)},
+		{arrow.PrimitiveTypes.Uint8, 1, uint8(0)},
+		{arrow.PrimitiveTypes.Uint16, 2, uint16(0)},
+		{arrow.PrimitiveTypes.Uint32, 4, uint32(0)},
+		{arrow.PrimitiveTypes.Uint64, 8, uint64(0)},
+		{arrow.FixedWidthTypes.Float16, 2, float16.Num{}},
+		{arrow.PrimitiveTypes.Float32, 4, float32(0)},
+		{arrow.PrimitiveTypes.Float64, 8, float64(0)},
+		{arrow.PrimitiveTypes.Date32, 4, arrow.Date32(0)},
+		{arrow.PrimitiveTypes.Date64, 8, arrow.Date64(0)},
+		{arrow.FixedWidthTypes.Time32s, 4, arrow.Time32(0)},
+		{arrow.FixedWidthTypes.Time64us, 8, arrow.Time64(0)},
+		{arrow.FixedWidthTypes.Timestamp_ms, 8, arrow.Timestamp(0)},
+		{arrow.FixedWidthTypes.Duration_ns, 8, arrow.Duration(0)},
+		{arrow.FixedWidthTypes.MonthInterval, 4, arrow.MonthInterval(0)},
+		{arrow.FixedWidthTypes.DayTimeInterval, 8, arrow.DayTimeInterval{}},
+		{&arrow.Decimal128Type{Precision: 10, Scale: 2}, 16, decimal128.Num{}},
+		{&arrow.FixedSizeBinaryType{ByteWidth: 5}, 5, make([]byte, 5)},
+	} {
+		t.Run(tc.dt.Name(), func(t *testing.T) {
+			traits, ok := arrow.TypeTraits(tc.dt)
+			if !ok {
+				t.Fatalf("no traits for %v", tc.dt)
+			}
+			if traits.ByteWidth != tc.width {
+				t.Fatalf("invalid byte width: got=%d, want=%d", traits.ByteWidth, tc.width)
+			}
+			if !reflect.DeepEqual(traits.Zero, tc.zero) {
+				t.Fatalf("invalid zero value: got=%#v, want=%#v", traits.Zero, tc.zero)
+			}
+			if got := reflect.TypeOf(traits.Zero); got != traits.GoType {
+				t.Fatalf("zero value of type %v, want %v", got, traits.GoType)
+			}
+
+			// the traits must match the layout of the concrete arrays.
+			const n = 7
+			bldr := array.NewBuilder(mem, tc.dt)
+			defer bldr.Release()
+			for i := 0; i < n; i++ {
+				bldr.AppendNull()
+			}
+			arr := bldr.NewArray()
+			defer arr.Release()
+
+			if got, want := arr.Data().Buffers()[1].Len(), n*traits.ByteWidth; got != want {
+				t.Fatalf("invalid data buffer length: got=%d, want=%d", got, want)
+			}
+			value := reflect.ValueOf(arr).MethodByName("Value")
+			if got := value.Type().Out(0); got != traits.GoType {
+				t.Fatalf("array values of type %v, want %v", got, traits.GoType)
+			}
+		})
+	}
+
+	for _, dt := range []arrow.DataType{
+		arrow.FixedWidthTypes.Boolean,
+		arrow.BinaryTypes.String,
+		arrow.ListOf(arrow.PrimitiveTypes.Int32),
+		arrow.Null,
+	} {
+		if _, ok := arrow.TypeTraits(dt); ok {
+			t.Errorf("unexpected traits for %v", dt)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow/endian"
+)
+
+const (
+	// ViewInlineSize is the maximum number of bytes of a value stored in
+	// its ViewHeader.
+	ViewInlineSize = 12
+
+	// ViewPrefixLen is the number of leading bytes of the values not stored
+	// inline which are copied to their ViewHeader.
+	ViewPrefixLen = 4
+)
+
+// ViewHeader is the 16 bytes view of a value of a BINARY_VIEW or
+// STRING_VIEW array: the length of the value, followed either by the value
+// itself when it is at most ViewInlineSize bytes long, or by its first
+// ViewPrefixLen bytes, the index of the data buffer holding it and its
+// offset in that buffer.
+type ViewHeader struct {
+	size int32
+	data [ViewInlineSize]byte
+}
+
+// Len returns the length of the value in bytes.
+func (h *ViewHeader) Len() int { return int(h.size) }
+
+// IsInline returns whether the value is stored in the header.
+func (h *ViewHeader) IsInline() bool { return h.size <= ViewInlineSize }
+
+// Prefix returns the first ViewPrefixLen bytes of the value, padded with
+// zeros.
+func (h *ViewHeader) Prefix() [ViewPrefixLen]byte {
+	var p [ViewPrefixLen]byte
+	copy(p[:], h.data[:ViewPrefixLen])
+	return p
+}
+
+// InlineBytes returns the value stored in the header, which must be
+// inline.
+func (h *ViewHeader) InlineBytes() []byte { return h.data[:h.size] }
+
+// BufferIndex returns the index of the data buffer holding the value,
+// which must not be inline.
+func (h *ViewHeader) BufferIndex() int32 {
+	return int32(endian.Native.Uint32(h.data[ViewPrefixLen:]))
+}
+
+// BufferOffset returns the offset of the value in its data buffer, which
+// must not be inline.
+func (h *ViewHeader) BufferOffset() int32 {
+	return int32(endian.Native.Uint32(h.data[ViewPrefixLen+4:]))
+}
+
+// SetBytes sets the length of the header to the one of v, and stores v in
+// the header if it is short enough, or else its prefix. The location of
+// the values not inline must then be set with SetIndexOffset.
+func (h *ViewHeader) SetBytes(v []byte) {
+	*h = ViewHeader{size: int32(len(v))}
+	if h.IsInline() {
+		copy(h.data[:], v)
+		return
+	}
+	copy(h.data[:ViewPrefixLen], v)
+}
+
+// SetString is like SetBytes for a string.
+func (h *ViewHeader) SetString(v string) {
+	*h = ViewHeader{size: int32(len(v))}
+	if h.IsInline() {
+		copy(h.data[:], v)
+		return
+	}
+	copy(h.data[:ViewPrefixLen], v)
+}
+
+// SetIndexOffset sets the index of the data buffer holding the value and
+// its offset in that buffer.
+func (h *ViewHeader) SetIndexOffset(index, offset int32) {
+	endian.Native.PutUint32(h.data[ViewPrefixLen:], uint32(index))
+	endian.Native.PutUint32(h.data[ViewPrefixLen+4:], uint32(offset))
+}
+
+// ViewHeader traits
+var ViewHeaderTraits viewHeaderTraits
+
+const (
+	// ViewHeaderSizeBytes specifies the number of bytes required to store a single ViewHeader in memory
+	ViewHeaderSizeBytes = int(unsafe.Sizeof(ViewHeader{}))
+)
+
+type viewHeaderTraits struct{}
+
+// BytesRequired returns the number of bytes required to store n elements in memory.
+func (viewHeaderTraits) BytesRequired(n int) int { return ViewHeaderSizeBytes * n }
+
+// CastFromBytes reinterprets the slice b to a slice of type ViewHeader.
+//
+// NOTE: len(b) must be a multiple of ViewHeaderSizeBytes.
+func (viewHeaderTraits) CastFromBytes(b []byte) []ViewHeader {
+	h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+
+	var res []ViewHeader
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&res))
+	s.Data = h.Data
+	s.Len = h.Len / ViewHeaderSizeBytes
+	s.Cap = h.Cap / ViewHeaderSizeBytes
+
+	return res
+}
+
+// CastToBytes reinterprets the slice b to a slice of bytes.
+func (viewHeaderTraits) CastToBytes(b []ViewHeader) []byte {
+	h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+
+	var res []byte
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&res))
+	s.Data = h.Data
+	s.Len = h.Len * ViewHeaderSizeBytes
+	s.Cap = h.Cap * ViewHeaderSizeBytes
+
+	return res
+}
+
+// Copy copies src to dst.
+func (viewHeaderTraits) Copy(dst, src []ViewHeader) { copy(dst, src) }